@@ -19,94 +19,356 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w9a2/kNpJ/hVACZDfXj5lcbrExsDg4Hk9iZCY2/MjhLvYt2FJ1N9cSqSGp9nQC//cD",
-	"i6RESVS32mPnNsh+ybRFqqpYLNabyq9JKopScOBaJUe/JipdQ0Hx53FZ5iylmgl+pamu8GEpRQlSM8C/",
-	"OC3A/JuBSiUrzdTkKPm+KignEmhGFzkQM4mIJdFrILSBOUsmid6WkBwlSkvGV8njJDEvbfsQr9dAeFUs",
-	"QBpAqeCaMg5SkYc1S9eESkB0W8L4SDRKU2lX3Mb0Y43FzyFioUBuICNLIXdAZ1zDCqQBr2p2fS5hmRwl",
-	"n80bLs8di+c9/l4bQI9I3oeKSciSo58tiz1jAsprLHc1BWLxD0i1ISAO+ujXBHhVGKgXEkqK3JgkVwag",
-	"/XlZcW5/nUopZDJJbvg9Fw88mSQnoihz0JAFGB1HJ8nHqYE83VBp6FUGRY+GEGdvMCCiN9ZQ1RvyZPYG",
-	"Grp7Q8FC2qxSV1VRULkdknbGl2KvtJtJskB4JANNWc74CsUmp0oTtVUailCEiJaUKzYoqwcLU3sZUaEa",
-	"JzoRQIEIfQ8012sjk29gJWkGWURsDhaVNs4Gx+CUAPngnIiUtCfU5D5OkpOLm0tQopIpvBecaSGvSkjN",
-	"ymmeny+To59370Ts5UcELHjGrNB0Zage8rpNOdlRqHQEB0JVCan2ejStpASuidlIp1yZIscXZ8SjN7LU",
-	"Fl8jf9e1rF2zmOq+9nKqWQEWU01aI6dGF0pRIF1WlIgWhHKh1yANYnsEkqMkoxqmBlZMsgtQiq72GxA3",
-	"jzCe4e7xVc0duhCVdhTvPkZei38HHCSNb4NZ/awATTOq6WxVzyR6TXWHGw9UEQWaLKiCjFSlRVsvnHH9",
-	"l6+jxkECVTHkf1pIBss/EzteG5sa4xdq1DrHqYta4Jyue/SQRr4W1SoIoaZgEhO4evnN7seUUJe8QO1c",
-	"y8qAeUtzBQcrmg5cB6vz1IPuPG7piBYfAuqOy1KKjddG/ucb4Ax/vKUst4NpCkqxRQ7dP/z5vaBS4dSr",
-	"LU/xx/kGZE7LkvHVFeSQaiENl3+iOTPDN2VGnQU1Osc/fl/lmpU5nD8Yh6meP45fp1yKPC+A60v4UIHS",
-	"waJOjGZZmgMJV2xlDPQBc2qODM6oWXUJpVBGk26jfDLsGRzoMTMcrBn7NgfQA9zFMc/LN7BhKQSMtg9C",
-	"dtsnPaZfQ1HmVMNPIBUT3O3Bo5/f1wb2OZFQSlDmDBJKyvVWsZTmJMPBvoanJXMI+gCPL87cGMlgyTgo",
-	"VC8b+wwyYs94bUtqzFYDiiWhnNgTOiNXRpVKRdRaVHlmdNQGpCYSUrHi7JcaGtoF6/toUJoYNSg5zcmG",
-	"5hVMCOUZKeiWSDBwScUDCDhFzch7Ia1XdUTWWpfqaD5fMT27/6uaMWGUVFFxprdzYzklW1Rm4+YZbCCf",
-	"K7aaUpmumYZUVxLmtGRTJJajDzArss+kkwgVU6b3jGd9Vv7AeEaY2RE705LacMw7fJenV9fEw7dctQwM",
-	"trXhpeED40uQdiYaWAMFeFYKxp39yRma/WpRMG02Cc+KYfOMnFDOhSYLIJWRUMhm5IyTE1pAfkIVvDgn",
-	"DffU1LBMxa29tav7bMw5sug9aIrmzPleu95oTuF4A+jecdavY8iCc+RkICA/Zq8stJZ7ORBDeA7QzBoQ",
-	"ml+0xg8KGA3qtmi+p6U5qpEow7IFVOA0NPQr6ww/OcjocRCX2cAd5tmJ4Eu2GuKWBJ6BhGxQq3mV5tzi",
-	"zGtN+5pRTEu2ivhJHXK7eHbSq0QOfVJXlxcnp+6omr/7jpkxUYKfvYmMdshpwQrfHKbreyHulQ9UOlZh",
-	"qUFewkIItFp9b9u8SuAjpJWGjOB0Iv18Ahyd8LRSWhSEprj7qLnRqXUe8gPTa4L+vxM+dcuFJMbnZqlR",
-	"49drUFC/LtK0kg5VsHFrqhxmyCaE5rl4MCQYX7gUSk/tGNFU3avZrTmdzKAad9gtC8xqvapwvKRS0q35",
-	"G+mpzfs4RlVu+svzyQpz5QCla8pXoMiaboAsALg97ZB5k+uchEO5hMuHXVxawFJIGC9Qdn4gUbivuKkv",
-	"wSyHLpAq1gjVCwiNxTdaahx5tdj8JsyIiw6V8BsJzeOg3jrDFTI9mHQbaZqi0JyN6qe/9pqlAUCfnhK0",
-	"CYs6Hcg8nucJ63cRf2gicC+sMJ1MlWoHuE3+9YarqiyFHJ85jmKuUURHa7zR0YaYgeGAwnrl51dxc8qK",
-	"aM5KKC0BCI66kockN5fv9jsfFuDwFpxfDSak46R0nKLzK0vVp1PSUYZ9T8PqnnhizSsmTe+Be8VklJy1",
-	"bs5ltYra6iafspuRU5quHQCjzWtl6so9QmbWj9jieza+zWZjdZhZ0DECj+n31koifp2vge1mrWfNLua6",
-	"1MTAZqdlNdZkhYDssZ8kGVP3n/J+AYUYq4ZjEDr8MKupgTrqxvJmuBLxX1S6StGJZJqlNH9yTSKGOCx5",
-	"9Ecb5LHRgKDYsCcyNhZmHoNYty8haOX7p+8dU9rVTZdsVWckVOuAZMy8UjBOtZAB7O2PKN8OuJcGwWFE",
-	"KeQ7pm18dyHFhmXgiiGT3W/9UC1ActCgriCVoA96+YznjMMTsH6vdRl7LSaUXRXRFKT7m1JQna4vqNYg",
-	"rWasOV7ah8lR8r8/0+kvd+Y/r6bfTP8+u/vy85gPsN+nWhtfc9wZbQJGs50jX3I20VbQXdqsn2k09LkK",
-	"uk19FbYU1nZD1Wj93KmoxXbAWo/sEPYX9OM74Cu9To6++o+/TLrbcTz9n1fTb45ub6d/n93e3t5++cRN",
-	"GXZ9G0UfS/3a0TABHHcjXf1NLAn13jtx7xYU3UqW266FVFc0byqGdEcauUnzjJOLSObLHgub5FI7Kp7B",
-	"Eq0tR6NPXRBjyIzWO0PqRwlRU32NH2CnOfevtZWxepwktQv/JOfcQDCRwBUAuhfjKqcHnNcaS+vEHmrD",
-	"D0gSOvFtpwf9CT1z8dIIAM38x0niMtqHRKPZQGoykMoWVZO23IcMCze5FhbchYayhj/Bhg57NL9Bq4nL",
-	"fvgC/fPFl5/UXzIEIvDnztGGxxtLmrTTJLkQDyAhO18un+jdtagIsPbGAkIio23frTUUkhsZbq0gMh7x",
-	"/FrHKGo46hmuRgXo/LFMzauKZViSqzj7UEG+JSwDrtlyGyZ/+vYgKPzEY7vjYIbR5xhLk0UXbE/qDHNs",
-	"QrwN81shNDl7cwgoQzBm1Oz643Se+0nkyoebIxF0w7mQJfU6+lQMn4BOyuyJsbTAcJo8rMFGwqqElC0Z",
-	"ZGTJciCOHEwn/t4DahN0vGW29DKKCjP53DMgRkhJjfMX468ZMcz1jiumZ13WlPFOOtVwGtOvTNkXU8qJ",
-	"K9kKAgxTttRvTep2RhLKiTl8hr9MYsPBdoTg7c0jtK3fs2csnVWxZu85rUqL7qdZlT6IwKrclNfiDdXm",
-	"uJ5X+nzpfgfdHE8xIS2UAYrIaIg1+nKnraQ9GloCpu6fv0Vx0pWJKyewTsqF9McBG/CYuieVcmnFtogN",
-	"n6ta0KMnrA1z9zlAHH1JMOzpNS31aelNaTfauLYKJIpiNxPN8Szjazsjp3814PyrAecP14DTO06H9eL0",
-	"X39CW46jNGYcBroYaR7xunzvYk/m/IjvQgZlvC607UYuvMpYU1VX43F+oMoWQuRA0RXxo8d6GNOxNjJu",
-	"gGMzNtXutkuI7oGqFqZx6QP/xrfbYezfbj32zv0dMyqj1j6nC8jVru6m3itt3BZAK2xxj7TASvW2U6dO",
-	"Yum2tsi4/RwlF96K7jEWZpolMphok1K9uV8ooqlcgUtd9U1GqmQfZaqkRXBx+n4KPBUZZOTih5Orz16/",
-	"ImnTMkuU7Zn18hDdlqyTbRzfFvcMW3rc3UjfSe/6FsgDMxa12VumvIuJQY1RslAzFZnStBfv3nvD2XHb",
-	"PpCIHZh4WE62BySab63V0UF6stZjj5MkkIqIPAUi05MrI0OQhWIVFaOd2dz+dRSIr/xTc7XDybzoVmNm",
-	"pl8UGLp4gvP9fZO9Pmh9g+FxkrSDzajza4AZ3tRBuT0MRoXXVwyFjb9NiGi45WOXEwk2briEQmzqsAXq",
-	"hNjImKVFZQ209bTG0Hpao+vMtbgfJwleq2GpS457PXpQTTFWzPRB3BOr7gEQ90pMSuJlytHRVH/pJpbq",
-	"dIcyfWkg9CRRVFxf1PHSkla5sd7zpKtHL1y85Gq4jDstGTupPv6KXHv1VykGrtgGlr6ZG3jQglQKCLV+",
-	"gdrylNgR7Bfrl8bQ8F3Chql4rqfXhVuT13t5MhTxdVtnLaPjkWGQlzr6Nahht/fEJsPoIofxea7T+h0r",
-	"AB2qApB3feEIipfjsNnkYhZF5YHdRSvXMYr7Ugl88xOVsdIuJ6K0XgDJXVfBD6f//befjt/dnJKSMomu",
-	"mjH51NjtDZOCo+HeUMkMMlVfbGt40rILewqsk0RWA/rVxE8mntXChGA+pTkhjKd5lWGvJd8SKldVgba7",
-	"UuaZ0pRnVGZErSHPjVBr+tFl85YM8oy45jBFCnerx2NSpGQl9k6uMBCYmEWzpc2bPoBsiCAVzzAJuKBq",
-	"TaYpXvOEj3F/7UHI+zdM7sugMB7EAw0zrUO1ACIrbmNYtiQMPacclppAUeqteYDz6kkGSKVMGLwWxUEZ",
-	"SbMfY0XtMMUaCPyoDo6YbHfOfTzXbuIkUek4xwv6kRVVQTKf78WO3fBzADaNjsrZ3iyfkVuOm+VfcWma",
-	"RZigp3ilyyg8tgHiLoiSW74UDv5iS6gN/SrO9Ixc+SbF5iGm9Y9u+ZR8ob5AghQYl0jho8I+KhivNNhH",
-	"a/toLSppH2T2QUa36tZp2boL4vX0m7vb2+zLn1Wxzu4+j0rCjm0PtdSn7Hl7r8yyD9aUN+alruAipH2G",
-	"IgQw8vMXXUvqNDJumHHwmlPbCENQqPHntwRpYgvInDJqZMgeeJrqFhoEbxzHCVFVukYF/JEagZy5WGtG",
-	"zpZNRM8U4UKTUpRVTlGq/IingFZaEOPDmYjf3/n27ilmcHdV4gaLV3UhxDMmWLwWft3eFW54hKcgNBXe",
-	"Oz7FmxcJJsbdL/ycBP4rSnvt0z24hFxQrONSKIwHi3+O856dLNTo3N8BVifxHrn/E2lwfzWk1A8cRR5c",
-	"i7CIAfyd2Qf3AZNAKqLWIt5+96xO+FrrMuqFG3m+2F0MdIcMJf9hDe6CiQRVCq7wMCktZFNBxaKgrTG3",
-	"7qbN4q7yb+yZq2q5ZB/7qC6orD8xcXP5zgaoqShABXe1FlTh6Iycaax1WgcLyIcKsLIjaQEaqxNWDx3d",
-	"8rlh4lyLuU+m/ydO/htOjtG4KzSot2tvNOB3PK7lB3tFn1XqmO0rGUyhaVnBvnU4GPFl7OyXfdalKIS/",
-	"P5AdX9JH3VrSdEQs7/RI88YkQLpXEhrS40x8j53oL/Phl6Cg0jtxzZjRIb6aYQ8ezXNjDRVTxjzXdTJS",
-	"VFho2MDEaXh3ABW+YVelnLbGuSlmeiKJR86Fbkz1E1O8zWT7QZRtmN+NXjdGetwnQZSmRTm+8zGDHJ74",
-	"6mrHl1+OiYIPFfC0/s5Yq5gYdG8EX4WpFbsyUuYS/OSidqg8J9AMzMgl0GwqeL4d+aGYT869+8vgtkZ6",
-	"D1t7qdDWdZ1upxzrlcpeARRyRTn7xd7LSamGlZDmzz+pVJT2qcKPY/zZi1l0f+N+cWjD3NyY9/jAQcY2",
-	"KKjjUk3Eg/EWbZ3cPjeBPrnFuuDcoLpNiGXy0Bfg8K3hcj0noqQfKvD8Q7SuX4654j1mbeUXKqirN3ev",
-	"mnL9uMjp0l1CH3fFI5af9zfTRzUx4+QnX174J7+c0PtswKAA/H4vMDzlKsKhHz3wlB/nIPVlFUsWdtr5",
-	"usd2XRWUT+vOsk7FFPMnBna8clkN6es3PrMSVshNmBrEPHQDkq7AdhQRFlRZ/OVDg5jx1Yy8RUVx5HV/",
-	"mILpJFYm3bTKpJ1UmbRSKrN2RuX2Nvu3wWTKJClBpsB1tDKFgUg9blhnl2XrqZKtVsbax9hp12S/QLGB",
-	"MRcVWpt+5V6Kd+R5iMFetdbRNkl7JayFLIjwo/f6sAl6XOQ+iKQBPDglwDg4x5ISrMaf9FgRrLBfmTI/",
-	"Ty5uBmug8c8Q2u6/QUU40Bno/duh94a936Yu54t2ThcedhNwYDX7Urq76NpjEgY48RjZpYEGbK/ydlkI",
-	"nERkhR3A5zzf2m814tMSjNawQoJVd6tUDrYaje6N2I1wN6JfnaFFmTO+OjMOiusxGFClC9APALw2dviq",
-	"WdeLaUfy3kQnC4gkwmdPyEW3avMBXybhXkZYEgke8V6XbZTOWQpcQZPvTY5Lmq6BfDV7lUySSubJUeLb",
-	"+R4eHmYUh2dCrubuXTV/d3Zy+uPV6fSr2avZWhfYsaGZNvY0OS+BE/e9t/eU0xVgxez44oxMCV2Z39B8",
-	"DGjjfZqk4q4v3yVdOS1ZcpT8++zV7LWrl6KMzWnJ5pvXc5uAUvNfzTIe5978Y3kaIsWPFdhulmWV53X8",
-	"0zQDt5OzdTW6zvOdZclR8p2JuXturSHOJ4lQdXS+wRwECjVcZkawBuzji+bTyH7bbSrFnp9oQmHwc6fY",
-	"XE26HpHDiqmqBi3OvexNHUZ7h94mJgpxQ7569cr58hrsx6KCm2fzf7ivgzbwdiuJHndRejtZhh+MjHz1",
-	"6uvIB68F8YQ8TpKvX71+NtJs802EmhtOK73GyDKzSL9+eaQ/Cv1WVNwh/OblEfqPTfNlzvxFYLpCd8QJ",
-	"9Z15NnA6m87ZMlaYlFDmNA07zdrH8U38OF7a11pdfnsOYxi1v3nOw3hnJ4PS3wr7zfdn2Q9H42PbIBhi",
-	"Hl/wGIZYY0fv62fENShx39KM+CsPf5CzvOdQNZ2jvlEfT5RQ0SNlW6qDblNs4Bw4SrZ7rn/X5GWkuo9n",
-	"lIC/fmkCOm2gyJPM2pq//ra4j3P7f4C4dDc6/2Cn7v/XoPXO2b5j6MzcoO9p9rJj0hopiJg1msVO4k7D",
-	"Zqu0fAWylIzrwa7l5zR3L2R9Rh0Qb4j+UEYhKpiYCsM7XygWNoKbJ493j/8XAAD//wafTUIOZwAA",
+	"H4sIAAAAAAAC/+y9C3McN5Ig/FcQvRvh8VyzKWtsf7YiNi5oipJ51oPLh2f3RvoUYBW6G8tqoAygSPVM",
+	"6L9fIBPPKlR3NSXP7t1OTMRY7MIjkUgkEvn826ySm1YKJoyePfvbTFdrtqHwz5MVE+amralhVy2r7E81",
+	"05XireFSzJ7NTgTp4DORS2LWjFDbg9xyQdWWmDU1hGvCRc1aJmr7ybV7e0X4hq7YglyvmRujdr25JrQy",
+	"/B5+kqJihBuiWCuV0WTNaGPW2zmRZs3UA9cMxmsVu+ey03EIxbSRitULcsk28p6LFTFhKqLYPbPDGZmA",
+	"3YdtNp+1SrZMGc4AH/DzEAtvT8+xB6mkMJQLP1mGDWrIcafV8S0Xx8uGr9amMs0RNFmQs4+0Ms2WSAGo",
+	"xNGoqEmnGrLptCG3jGhmLExm27LZs5k2iovV7NN8ptf06XffD+G6+vnk6Ol335Nqzao73W2Km1TLB9FI",
+	"WrOaLJXc2Aktyn7ruGI1eVgzATBw7advqTFM2fH//7/Qo+WTox/f/+37bz/9cwmyTjVDsG4uX5Ug+Uwk",
+	"3DOlYfz+dL/iBz9lRmtzQrUjLVaT2y35qrczxA371XDlfz05+t928fGfiw//4+j9HwuI+DSfKYfR2bO/",
+	"BFDfh4by9j9YZewyTtq24RW1sJ8iMTFVOHee0piy66KklfWQXKmq1tywynSKnVtk4q91ze0wtLnIWg8w",
+	"mk9pzynsiPaYjCAspSI1u+cV89i0J4DRak1SGAgXRBtqOr3QW23Y5lws5SJtMSe6s500oZv6+2+JVISq",
+	"zfffLshzN7xc4snPBtZz2/Jhzas1WdN7RoQ0cVvNmvG8PdkyMyeqE8T4VS1mhc2o5GZDRT3E/zUsHz4O",
+	"sWF/5EYTqlbdhgmj5xaWhlaeLfR6hvm5YZvyVrgfqFJ0i1tj+al+K8qgCbqJ24ToCuCF31tZO5SFo2Uo",
+	"ngO2lIoRs+aaSHEgaEzc/0qVHgJ2Ju65kmIDp4oqTm+bAi3Bifzl7N//5deTVzdnh009wp4D5Q4mKzIS",
+	"i7xxtBYA7gT/rWPkgZs1Fx61ZR4lm27DXsvOXbXDKbBFQAuN3IBsbDdWEy6MzEHIsPTPii1nz2b/dBxv",
+	"9WN3pR8nzOXXCMoQlT1+BRjx6N3DtH6G+/nU3jgjx8Z+IitqwmnozJG894zstunY0Uox5iULlBCQGatO",
+	"6OwEdcLwhnBj2UbFWK0tH7ANDN8w2RnCPrZcMT3kjaoTu481wOlhFOzB3wSFrUFWYvef3FK9JhKpADki",
+	"wp+TzqaVmpFWSYtA/3M6B9ekpVrDbsPHF6/OX/58fXr96sPJxcWr89OT6/O3bz5cXL79X2en14QVjlaR",
+	"AB1ahiv/WT6QRhZWu6FbYugdI0aSW1bJDYsimGXTpO4U0qfn3E83llsvadegfPXNZrH3RrS7sY+wpDYX",
+	"1KyRcEtXYs0Vq4xUW49R3AArXtQ7Tk/psA3ppaVmXSYYeqtl0xlGbJMwtYdl7nhslHYqxahhmvClJdxa",
+	"Mg3XFfvI9Yh4xxouuo+XrKG3rCBP/XnNgMXHKRQ21TkoSKHZ2j8secM+GHJ19spOQezcc6Iliu4Jiioq",
+	"CK0qpjXhJt/fJW10Sm23UjaMisEeAwb3bPKFrEceGnBdyWUKk15T5Q4oV0Qw8yDV3ZycX5zCFXxzfYUX",
+	"YUsrKyEEyUJkbBWQQkkjK9qQWyXv3A1OyYYZxStteYhUhqkiJ4Jb1A7xrx2tG2bsbWCApFDEqT0BwOVq",
+	"dxxE6pQ8pTR6QS5kbUUGRqRotkFKDVt2yZBuiDaKGrbaDkk0omaMsxVEgHm49eGlZX/lgud7Lzdtwwyr",
+	"H3PPRCG2dGELbk53QB2/obAmPSzAhwUjdGmYilLOnHBBpKrtv4IQM7JwXPcXXxK8Usv4h09h+ra7bbhe",
+	"M51fF8BVf357df3s9O2b65PzN2eXjkQFkS3K7WQttSHnF4TWtbJHslVsyT8C2R6bqrWX4HFXt0R3yyX/",
+	"GEn/hyc/PHn2w5NDpKreIU5obM9RvmRadqpiI8g4vbgBeDdsY1lTwzfu2OTHcw6nHN9mtGlsA9sugjEi",
+	"Huzg7ZZGqD+dRDf2DDKxlCrI5wjMHOCzf2um4KTCyVRM1HZgd3p1yypNHtZSZ5NosuQGOp9e3Oh0pelr",
+	"M5EShqe57UYxp4fCId2STjN3J//WUWG42YaN/2bxnSWK75482RSvGIStPJ+D+8AZv/vm6Wtu53z60p7F",
+	"rRT+tZHvH7C8O940rC6LCbtobFQplQJqOQfjcEPebu3R21Bx5GUwUHnQIJLZ67AnPVRSLPnKCTnwzoQF",
+	"D6+jmlUNVVFks5SRUuetXdJw57p27ri9htuBG0QR/hbYPRIjvcNWVmlDuNCG0TrCC3cyWUt5p/uyZhAC",
+	"hoR2yFsyo3C5DOvEt2K6LDcqkaKAg65FtY5bdh8lTueniVcbVpxp8sAUI3orKlbj0bT/1jlISGG1BIkK",
+	"exMpUBOB72AuSEsVbRrWHPa4nPYszFiXo3ddlvpVuAp6J8ISLBfFc3qgFFoi6wKEY9RuYb3nNdMD1RxM",
+	"YvfAgr9PM9fK+oDb1YuAcPEkV8jE7vHagQEsTp9TQ3dLzXYH611vb3cTcEVqaijyLNYmslzaGJTPG3nv",
+	"NaqRGaRis1GdexvCkHKJt7rFrLZDCGbfxDULkldfvJ7P8PxcOQ5xAJJu8o5BM7FHKREfGJ4wgv68QPZG",
+	"5/Sn2NJSt31HbgHjj1dbTNNY7BFQrkATaecuKPmf8xXTpoyOGr5l2rueBrBAQVY2iYIYauyffXvLvl0s",
+	"Ft89rZ8UT05DtblmasMFNU63PRFPaa9R5vVzt6GCKEZrqzAY42NFyGynEXlBdJtbxEHC05Am7LmBnv6O",
+	"3D8NSOkFunwTZvFtiLy1gpo9dVLtGJ0Lw1YovLunz8nIRhu+wZ1VnQCbzs4ddoMRauZ47uM56FoYitsb",
+	"TfF7a5S6EZpZ/mFPRn+koBNQHQC+lGpDzezZzJ7aIztUUU0Q6HkijeABuLbjjGj8cJeTbQizTDpbMPSz",
+	"v82Y6DZ21AvFWniyz+azKzsg/vMSsTubz86Ukmo2n92IOyEfxGw+O/Vvz9n7/pLns49HduSje6pASLFT",
+	"DGBI5xx8TIAYfItQDT55MAcfItyDT8lCclT1zveQCi0TiKSY231ySZd95O6uyDma/f1U1iPii/1KKlmX",
+	"1eOB9rgwf3paPEVLLrheTzhGEXaElFAznbwVo/qxLPAS+w60jvjzPCJoD1kPhyyoLNw+E74sLxokfMD3",
+	"kzl5+/b1L/D48c3vmBKscS8iwg0wM/axYqy2HMhyE3yQoQwMpBht4Rad/rRFiosHK0x3+HFK1p6OXG5R",
+	"OCHJ1wSKHn437VKPK3hRDiFr1sAjy+MBH98gRXFNGqmHOjbFUMs2OBqa/3XkWGzoR77pNsS28CcDAQAt",
+	"0+3WMLA2OP3h3Zxs7J8rp3QJV/333/b04WvaLP2AuIT8xXn4MxjFuUumu6ZwBK/QNBJJLFXvo1hyKe1u",
+	"/ESrO8KLRhiUdjNHCz/CLatop1kYWQpGHqgmnYh2AlGTF5Rbgi5SaoDQXgYBlNl8hp0Op1Un3ybDDrGV",
+	"zjP46icu4TnKjUOikZ0BE4nbUGDd0UEmZ9dDYpzMSN2Qvv1BfHTDtKar/dIgFzgePH9uZWeSmaMga39D",
+	"Ngq8CtA2Jsk56jzojeKIGsSbz3zmFAWdMGqAMLvP3k85eOkDbGhVS60y1gmA6UykTKyKfcPEmonhK6pa",
+	"U7EqGTTXueF1IopSc23gMl8SwTDiQWj0UmOOymD/QB1YkRWBVszp/UHTlJpvpWCga8uUMk5ntiDn4sLu",
+	"DWm7ptHxXadLxtkotAcI7OCgfXaKAnuOvJ3PrP2u1ZliWjTbBfmp6dhLYLSJejCdrGuJYB+Nf2inM873",
+	"4iKYdJA4nO09WZKFO5jOqUj4czJ2Cg4Maxs697re7Cmpphze795sPnOYns1nYe2PZvCOYpLRR9vEaUeb",
+	"JPDk9LlXIhny9kTnGey9JmiOLaN1XUvk2lcPe3usNYC4jShqqcBUAvbZc/SizBRbpBMN05qsnSEdNJBW",
+	"4Ep9+3KW4loewk9yK/1kvakD0akF9ugty64NdimHPA8SWfMx6qPUgWYnZez046H5ayvHP7S8mKjyTbGo",
+	"wyTURJx+vtNTvkvj+tJRldFb0Wx3q2KHS7D9jpBbPsbtwKkyIi737Ku+6jYbqraj6kGxlAcJTzUzlDfB",
+	"tki1ccbHjCqMokLzUeQdrNzJlzEi+0xR5RQGSlQ6KD9Y8ek5WylaZ69Nrw45mL3nc8Y5Rpskk4+2KbxJ",
+	"8wYBXIsAY5g2+NpdW2uRKInMpVZetBBw+VL/Av2tk3gJ2Pc71Z1i4BrqHDwkaNSZszEsFdNrwbQuqXJa",
+	"jsaZaz52YuGZgJ5x0b7jbdi0qlhr0GQrDSNcVE1XB0HJAj39LQHNy0DcUs2+/5YwUcma1Q4byYsc52Xa",
+	"M5Pri9cI0X5nMZx13sdFkY7jBl2C3X3nHmITvDkDPJ69WQVCvnXgrzhmvqdx2F/YdhKOwCOkIlQxSv5w",
+	"ffH6+sPFzU+vzk+/9iBYmJJxyR1zMRaarwQ6Oo/icG4ZpGH1+biPrI976Dsn+TAAQ4M/5Pgsh5KEW1rl",
+	"j09x0LZSn+u6vmYfw8w+LuKeNl28v2BNNbk4vdRzi1p00bg4vYT4lajQeWfBefLtu1nRZRxGmbT+dCdB",
+	"eWX3/OrDyfX12dX11xlU5SuBrwQ1nZo2W2jtSOvq/OWbk+uby7O9M42cvh6B+5WncLmNKx3M04sbb6l9",
+	"LQU3UnlfDto0b5ezZ3/ZfdOVOn+yjPtUCqSRojcZfvKykHZ3swYlqxSMUN0mHrlVpxQTBmIWHKVyTU4u",
+	"zomffnjuwWQX7vJxJj3Q6te8Jwd48zG80eCCIkYSKuCJ9uX1Pa6dJXa4HcUqYAfVPwjxbjHFm+BeMsHU",
+	"DpvGYsMMtUS/WIWWyMpybFhFomYGiNn6i0jRt0l8/23RJqFG1PN/uFWcLb/2OitvKQwzfqUnrXOaOBYI",
+	"zsmSExUsodu4QiVAMC8R3DxaNvzuF89gD7xErLtWHQP9a6PZwYJcb1w3Vu9XP3Tv51QGy/GQQHfSgrTk",
+	"pD3/z+dMcPiHU97OZyfgsMxvG9b/w5/fC6o0NL0CvyJrIblnqqFty8XqijXgM2Wx/CttuP0MGgNnwWxZ",
+	"5X9+3TWGtw17+wCukfPZayroitWnTacNUyf3lDcUpz5lyvClPWLszAowONi5JV3FzfZXpvgS13Gqtq2R",
+	"YGzhVBj7SyOru6s79gDf/7WjigrDBS5f8SWaZBCoaXt1JpRsmg0Txob8MW0ShCaQXvGV4GJ1QJuwG6Mt",
+	"wjZZsUtbLr4t7pHdmtEPg41MP4ZNfdEwZkZ2Fr75fcQos2ST8Yd0q/GXwYa7n0e3Hb+XNx+/lUjA9RoQ",
+	"gvs9Iwf8rUcU8FskjWu2aRtqmIuJdJTyyTcc8svn3n7WKqZB6qWkXW81r2gzLvu2/NexaMyTi/NfvS6R",
+	"LblwGkSn1mI1QS4Ybtsws3MNBE0b8rAFubKXDUQCyK4B7eo9U4YoVsmV4H8NowU/Jbt2bQgXhilBG5QA",
+	"0UBl/VkVs+OSTiQjQBO9IK+lwnf9M7I2ptXPjo9X3CzuftALLi0b33SCm+1xJYVR/Laz5HVcs3vWHGu+",
+	"OkrDD49py48AWAGv0MWm/qfo61a4bu54KQjxFy5qfKxgSwQ1YswL65dnV9fEj49YRQQm2xpxafHAxRLU",
+	"MVxHDzYm6lZy4W7ohoNg1N2C27bCE23RvCCnVAgJDoEuiMFq18kp3bDmlGr2u2PSYk8fWZTpsjyEkse+",
+	"W/gtoOg1M9T20k463dUj8orpIoLr4+SD3lWfnCNHAwn4pRsdR7PMsmGKWrl4RIlVK37P1OghvY4nMtim",
+	"oYf/i8YpivIRqypQt+h9LmKdqKRSrDKsJmenp94gzqAz0TxoDXB6Kw9isPpEOZCPBO/ymgnLiYtL6kdk",
+	"sMVqAZqbi9NzH3Oxw43+Whra/LQ1Y+6Uxn7P5nOr9m4FE9eGvW40q3dMVp6m0+zQ2cY1xBtZsyb3HtxD",
+	"HoZtWvu5U+yUNZqPmdOTdqVt4oLUbKUY08QNM9FjqTO84X9Fd2OmKiZGDO5Ju5H5W+w+cd57Jmqpxs6b",
+	"/TYNgz0+AXKJ03O7KXZxh/KzLP0Kt4qALBxSeO7uHCuDyssZmZzPZZZII0StYbAMqzFIAHWSsRmtrLDf",
+	"sHoFmlG8hyuqFGc1sU9Or43siRfVFGfYdD34kLIaw6lc/Owjq8b4xw3Ge58/95vlEDQM9fRJS8zQNcTh",
+	"1mJqsdvdrW8r2ab9uY7bMzKO+7rXqcRDRHtDTlMzPNA79la8ohP35c+heZGY3Rbn4O+jaXvZjbCoVskV",
+	"RMolOlu34NRMfRIJss6cTw90RUqh6o2ZfkrHT39PvI/66ytxymEbb4NIlx2MT26fUyqtGDolX5ePZmQF",
+	"zlptz+gW3REtXc+dRwASO3iTuoXF9D3gHLGiXCQxm+iVR6TyPtxf8qyXTm48sjlrWxykOOsdQXR68off",
+	"0xaxFH4kxdGrkzfhaMk7NveBP9Ht1ocZspgSRHam7UwSxuPzhVBBLGtKaLeom2KHYAzPTYgnmcwpFKPV",
+	"mmH4Ekw6lVvsPPEIfgrMvnNfdhg6EUNKx7tFu7ul53MZfVUsVVoFz7ozNbpzXyJ9Qj6s2XwWudd8BjfF",
+	"fHYGEaQo/R/OJMKc2b7E+fO2GSzppxSu9HcHY/ZTCm9EaC1bTxJjQhlsUILUpewwyi617hm4Rxhol5wi",
+	"ex492kKSLh9uhgIEtbP7w6Hh/Yp0lTCmtWxqTW6tq6ptuORKm6KYYU9KXGPpvgzaXy/nBz8bQWTrZLwK",
+	"dOT3nD2QB6+fhlm8N9+QZ2Ew8cg58mcIxkAcYWtCTSEqJFlz6GUXP/1i5vA4lorvAQinMlIiYn237UHO",
+	"pfKeqQfFjWHiBW/G3iRLnmf+WfJVFkyKnBRooEdXIFjWfLlkYJippDCYxStEF1ufiq3XfMBoHiams4Cz",
+	"vQGfnqh2vpJ9o/BcznFnN9jQOybw7ivJiBZg4En+qQtA80gYRSbfiY1TNR6Q9CFHJcAhZB55m+wCTqAn",
+	"R+k53ekAsL3BejmFDhFfXm2B2HZcFCvQcAPj3GGP6wT72KIywkkkeaK6RB+RmscLwfu001Pv4AS0U+gG",
+	"iceKbmVvEr1JH1I9GdQJD9X9sg++86j201sJKHUKb6Rs4R+aNcujzP8ULwxtOmRjY0F/ZX715xBxu3Km",
+	"WYXZ/CgXj5Q/cLPionMI/GZMI65Tv/E9qKmp1rVcxcCUIVqy7fOXKvMZLyw+NSINuN2a1iGHhafV0H1O",
+	"ThWFyICHHF0uBEmiJs0FGVmmaiUibSRYR+JGoqhOSUsFrwgoxjyTclM/+IW5sajwpOGS3si2RRptpai5",
+	"WKWSlscK2LoA3sNEpwTvyVCFTfGD51tWzh5xxYxxntguBZKSDVlnnvxOR12BX29QdjgXq8IjpmnkA6t/",
+	"lvLOeiAWOPVJ6sqp+0mkIPvB2jvEhtQjLgoE8z1YtT1sxoL8DD/AH/YixLwH2BMDcP8DGEcvHN0v7ivt",
+	"ciH1El+469UuRdv/4IiHXalA6Pt9QBHJkG0FeuihLmme5JmMDvY6Xv9WAgWj0IbepYkn8ax5kt8El6bN",
+	"49DhLu8Q4l+M02/k6pU1XwxXDT9nJx/mW+mDoEnPFBxVywepoY393Xk9PlAl3H/wWIEf63xWs9vO/mkU",
+	"rdjw+Nm7AF1srteKaZBE991rPd+cpKMzpLxgplpbc6e6pwWk+C/klpkHxgRpZeN8dCgEIySpdxbkBTD8",
+	"Z96msJR42CCZq/4KemlWSVHrOflqgz9suOgMsz+s8Ye17NThOE/zwX5z9OP7d+/qP/5Fb9bv/3ncZwRD",
+	"Dg5YvF8s9A4pU9oO2LuRGef5vwcZuI69/sy9/NPFQMiUo4+Yu6xwl0h/hwllEdzXE12pcr+pyNFwlMU4",
+	"Pq4OUN0kqMn1N79OTIScwpRGFeL7PmTr+Kxkyz7KDeZafFZi5JFlD+9vk0Rb9tAe9byQXtyF99s/WB56",
+	"erAYgiBl45a/stKXdOa41NRPfcyO6wzZY46xB2WCGDrOvqZtzGTYT3ZhOs100QfWpzjLYRmDcbJT72Ce",
+	"IrB3bHuMjhARVVnStSxhlCfQnsU3uP+ma/Y5awZwaIwieHR0Rjy7+gtsZhalPIalxCKlR6KVx/J9Jbfv",
+	"YYjqHXag3Yi88TN/enFz7oJu+gkvFdvrYdDIFTgr2bR5U1+/smZNeVybtjDau0d447iR13bH74kHwn6+",
+	"iAvdgSHa0lvecLMthaItWWZBd/nakqIHwSKmuxZsOM9IwpxQarCyFnJxHwL+k5SmensFAQWxjdRz4j3V",
+	"KnZVUaHjxyp8wEZSZ1wOGub53DC5QhoPOCfPub47E5V1iuNSxNFZ+G1OXnDFHuCV4r8u3S9z8pKqW7pi",
+	"p5bpVvkQq/6nuc3LOgnGVtbwMLcKVet4GAc1fJPfPhG3Ngo2QaO3OUbcuV96iLJ3SIYEa6B065vNZ4MF",
+	"zuaz3jKsL6AD9KDLLlJavor+196q+p+Hqyy1KKy612qAhX6DBCv9TyUs9dsMsdZvEbEYT6N9Yp7Cg7R0",
+	"HPGpmmrR4jvVoDp2O3zwFnSMIzP82Zsp0tFrGbU6oAyfuxx1cww9TtJQgoHSAmNtTwcGi+IBzTTKKs2Q",
+	"hksPr2Y5Jx1YPPBt5z47PvWwlg0jmu2wdLJqPMTAfRxwvRyGiBV80Mx7V54iUu/nzzoQkNuUHazaEscu",
+	"85rXZBxIH3OnMrzdxrs86BV7Wrm+ZmUXfe2BMjO3DIwhEfI5EVIwn4HGXTcbaqo1uP4caGRIT9iYmmmS",
+	"ucu1jBRyWEaqxxqIssknXP9hPSXjiN+nHTQXuW1R5enKHrg2Vm5eegOk2ymuia6oEF7Trk1qk22Z4rK2",
+	"UlazhXZ6YLN72zJxdXpyMe+VHLFDUUx1JbLiS7lPCSVOTIwuVxp0E4liLyxgSMo1NVQbxehmj+rVD29B",
+	"Jc5h2nYm2Ltf2aGvIsmaJiNdsapT3GzJy47XLNid317lMnVkRlAoCtI5HH/cNMe6ou2x1qtjZ/C0/z5S",
+	"a9b8eFTrxcdNsyhbfseUTDYvjVya3JLS27ex8g7fPF3nC3/6LaaC9VtKDWmYZT/flD3bHHmVyTB66Pzb",
+	"6enzF54WI2Y+VlW9/CDVaqH1yuXSXTi0fHCtP1QcKwKBZ8paKrhgNpHV8wk83YM56ViN8POrnGiBKfuT",
+	"AAjvvanc2Qo5MHoH0pmCyux6F41f7yDp9KTSeMxHHRPR3WlnQs4uMe8XmIkdYepTDGe77Iq+BOfPdVQ0",
+	"NUwPJplbYtxIbcjTJ08OM1XstYDC9nnfL74MXlDofQfe72Xyh7oun4M/GGEqAneetuyMjVGCZ/hFCQzb",
+	"7PZ0AUQ9JlXZISEU/cNYjJ30yEjCJ+MKwtYEGp9+9MsuaL6V6ck9uIFgRCvt9Zy8kSLr61KraUKFZyab",
+	"NP+jGz4hyUEiSBc4lo4cMnUc9ADsrbwQldZr0Zuy3MgBkiDYSuNjas+9kldPDR2yQGI3J+vvvwP68+wi",
+	"CKFlw4agVvSnTtRjB/Di7PWRj9A/Pemr2KoYLajn/hwmDkTBSe0eIwYzBohiPxP1kZFHTNTEqUdYTTTT",
+	"GpJUWVMsxFc74QvN9JY2odQKLCkEdxUZwOry4vTMRYYV+SomtdiTAiPg4N+efvfdNz/6TBg2v4Vc5kud",
+	"sqzEjRmjQzCxahjMNfS1FkbeltDm/HlhVT0qyXCQ9txBLhZkeV5MJdRvQfDzrVsHrFb2EvUPEojmRAjI",
+	"eMFbPcXuzjW57XjjojhenF9cHd3ThmM9Ipy9bOZe8lafCWsBq3fP43Lc9g5nJ0CctxOCirU8SSsbXo0Q",
+	"E9opjh54HdCEzcfE7OdnL05uXl0TqWBa73/JQx3YNdVEyGwwziYIjykq5gn691HEjvcZguAmCQlo+nXm",
+	"fJof/3B6SNDuX92MYUjKxqKbG0168cUxG8I8IYuQlDzRXz2KFtEkfeFwedhO9nicq0GzICdi67eaa+Km",
+	"sPsI6qVD/T4BxfuPiwfCvnuwZkck3lCzydU9ecSBGrd1W61n2SKyw3JRc32HposDdXrutKbxfbc28DwP",
+	"j9Q1LY6rJEZu0z2F6wA8u3ck9iB/0C0H+9zX8L3METRTnDYoPu9YOjZzhqEyy+d/ZTsiKdNE0AjtIQGU",
+	"5ax0ccpxzgDR+2XGkOeXXVMraGgfL7s0PQ4LodwYrp26y3tHE/jDp5cd0+4EraHTMe9x5Z6n7ilZ9bVM",
+	"zeojYIZ1u7zlJ2uuDW8a1B4mM6X6oogCKzpzUYc8Yy4XQuRx0M/pkKDLkGUdpkhJFKse8VIhNKM6lSc9",
+	"97PvNiMqlXL5Lnsh1HxSjRBY/2XSfhefAcrboas+gMp2aKcD6ko66Mdoch0khzw1D6+dWKTMYRQDVcyX",
+	"8hDgW0uzklXHSR1hqXofCW2c0lwknsAJKKFm6PTbbfm5YRl2QRuuNXhNKOeX6hRwoDdwuY4PvXSRIift",
+	"NZAPVvD3Ow6ESI23J+Gb2ZVQ4geEsUL1zl0ySb2fm40TAQUPoM8pv5hYAnAnPcjzfaUZXVxzMJaOi5pw",
+	"uUVjelGGzoq6AX+Fc80hrurVzS9XT2PVKElOG3bPNWk51ECS4frYkk6AKEEN5lf0frkUdCTtWlHdswQk",
+	"Au0WdaRJXVuEFGHz03seGt+J4BMPFaw0l8Ibr7w0U5B4XVc/5JBNJdWzJlmxzjwsmETYpwzZufV+jkl7",
+	"O8KzT5OEdp3Os/rqTLYZbP+XX3QvJ9rjl31fzN9wIoi0lcWXRy7thY1QIGhnVlCle2nJTMkqCZD2RNAP",
+	"0sLby4kQ/yE7JWizowDvPtuGSwEe2nvtH4ByyxppGeiY7+XnFXBg9xAfEnwBEhE8LcUaroGVkl2bKCgR",
+	"W2q0eglcAezjmnajKQlaXu9FEE40XcdtW+/PX50MWyyNvifu2estVLgLGrlasToi9iCZY0ouwITEfWC7",
+	"5fe7byjb4gCSKicYdFDvSiDYB24A1J7qRimIVkZuKAqEvrCPD2LiOfXV3aYFCV4lqhwJRX3ZahNShWFl",
+	"h0QPHqB5ZLwRLDQdJPl5GGJ09rF0v8ZvSV1QCO7PI/tRHdYzN18XE4s8IpGAY2Rub/OcCF95M1ZBdaNW",
+	"I4eMqlWX6aTcTAeGB2GnwwqcbvyqYw3oeSJH6DVrmikeljj1OJl7T7Jxucl7GHrguKjkBsQLRZdLXu27",
+	"ZLxblAWeiKWxXFwvyCspW4x3d8P4BSuG7Z3zQSWFQD+k3A7QMoGBX7R5oFvtMoCzOq2QDmaoTDRzMN0x",
+	"1mrM9BCCqsfC3LhoO3MR9LO72JpHpktGZDejG32XZDayPlLnaRqArnFORbYBJS2t7pghNas4ZDj3lx3H",
+	"HN8ODwty7RArZDIEAzsu6lTCwzVZ4pycwAD2ky8jM9WLyS/f2rWLEtAIDQ5cFseJMRfavRSjiGJVQ/km",
+	"SL2gGmtpxcbl+1Z1Pk1jlFhceRwhk986zVzVdF+p3nmqdaLTscAuJoKAt1kAwcaHeZjigNpIRTGl/rJr",
+	"GuhAkXcZH1XmnwfWmVF7vX3N2kZukSO54uP2ixR4XCxVQ/6qlI+iS1Hmq1EFRCeORgMf4eFJsEu6saw1",
+	"xL6NbBJmD0t4cIaM43uqjht+e5w8+QGR9FbeswH/cPvkkN3fqlzB9MOTomzlit7Nnn3z5Ml8tuHC/VVM",
+	"rfdorZhdY6fRyauoD/tTXx/2zYiL0XdlfZjd37f6eSSCfUECoYBRj3aw7J0kNo2JSzoie5AtyJ8tv36S",
+	"vhxTarRdoWccl8hibH7m/5Y6+8wDy3ftKyrsyQOhTmXAQZ90NTDYnr1OdvpJWb7uBPt1rEL00IJIGy1T",
+	"ruEfmANm4Z/iHbzCPZ2WS05jGaV66H/vi3FPLw8zmbvuUH0WuIVjDCnXyNjvIONQ//UI3fZowJLBH+VT",
+	"E1jTzsSHj2ZMIbdXlbHHz0k5YeFx+UDlsjc2eF5RsSXasBaPzO4ihHD57UyYmdyIfXwrBr5eh+XNdKXg",
+	"IRJiZx3rwd3am94NNBGdrvUeLhhn7zG+LzH3KMeIs6bH/JHTDQT5eIoK1D6ggf4GDaAfQeX4Q+FnquoH",
+	"qtgu7460Tc+/Y+0+9eUxzLapGBQHYnVulrvd7rSitN1EJzoXaef4xMgJSa2/A80Z++jrCd1zZTragNB1",
+	"oH9/MHAXHomrtrvApNXjVxG1p7ht6Nbn77Ci4x9eXtx8bXHocl6XrcmoexjjD5C5N+Q/f1zaXsHMg1R3",
+	"EOi/pNUYHwqzuPaEhw5DF4sDcPumN/0Ynlsl664yb0b9AlxUsGvn9GzKRUfSPObWvdE2lrBH3K32GfHd",
+	"dJkZ/+BpdsVmugmwyYEj95lQ281yUvIHqrT9GU3v4CtSjgYOXQ6lkahHsvCHEM+GL1m1rRrMG1N4ujhZ",
+	"/ArTRJSFeyt4ZpkgaS/3kuyw6oFbCu7W7FNSaX0w7llahp4Kwj6yqgMdSJLs8nOr0fcyWH7ZAsquFvuS",
+	"UCeD7MrSWfa3eZMoq+3+hGyhoPSCrIpONQGijnNPGw1Fbou1Iy8SBRo4fjsbLvp1KffA3plhtGaqcIrO",
+	"ot5RGypqqmqU3Ma2dE6M6kQFcr17uwDtfkt+4T+NTS07M23qqPv8QnOHeuK730CVf8ti6+k1KmG70nnm",
+	"g+O4tzp15BXaK4d6SlwromO+Ubuuwvm22aMQXUGiV749YeDVSKpOG7lxa0UHHjiDioa01i7xFLJV/U5I",
+	"5VWH+MbTLHSXVdWp5PHgeNWaajezlbvBq8+CYF+ArdTmCL8RQ/WdXrwTh92DiAJgqkXz6xwxFWrBTENU",
+	"55r//njKnS59POaa3jNyy5irRhxTBDlZ4VAswfLZLiyhFnk6QWH7hKJgX2FTfw9kJUruGMvoiep3IBqc",
+	"bzLVOPAC2fxdkFEmHTAR/F2IZlwFE2ogjUV+TEy1UhzNReMNo7f3ZiAZGejzawNHuzzcPdzP82Xqz+0C",
+	"/tCKwHvHSuqzXfg4qlDiy5d1s/9ySUYONL72Zg5TFL+GeYtfIzAjnxMIw8pfyWqknuFLJleKtmteQWq0",
+	"UKYq8BtB/vzyivzwLamkVDUX1JR8iKg9obTavmam6IV4pg3fgLSylor/VQpXRAY6BcnfA8AF2cBAE+Xy",
+	"hhpuupJc/sp9SaqtzAkUbOP3jAipojDJfut8wZLhlEHd/GNqWTj68UkJGilWY+D4T2V4wCoQvD34hpEN",
+	"U7zmVOyB6psfMrC++aEEF4bPTjt2nmCusM+e9PoWUmoGJp3a7uGG++K+fnsfmeg2bHKK4bCqaSn3e8sa",
+	"hjz7KmN7lgB+wql3hj18kLny5cWVLYh4cRB7yMEKY5U+4vilL3bOsNDXfDVWwbTXgCh2BMFfeiStUizb",
+	"Sl40fLU25NSllYX0ByI6A3BvcQdH3xj+h9e//c178IFzqY3RBnNJGopyywjfOM0FF8aZ9P1MaCovZaze",
+	"E6dIbuG7P1ynJ8lifW24xErvZkvjZABfaIClivmgRRdqPsilcHqSdnbrtvZk1Y0GI6q2wgqMGyZMGpY4",
+	"XNHN5SsPrI3f6y1k4joQ+T5QklvbK/U1H5PnzCZQCj7bObqVusikoYLh8CWUoR8htrHF7OUfBcDGGUVR",
+	"zzgMWKLVCRZUK68xaMP/8Prk9GtffM0vcKAaPTC0KfUNnDJW+dmerGEcHW+vRp7jSTXDaCP6jELn3uaL",
+	"LnVeSx+zJTMKDulx1sS1AV8SdqcWaYskIfim/v5bcKJVm++/tYc2GAGQv6XdMAcHMjZ4l0IchFeqmjXj",
+	"eXuyZdaBXyOBouE6qSZZyc0t96kpiM9fUUzIyMtF7qXt4kYO+uqby1cjIvZIvhhi6CqmofGla/0vOLiR",
+	"LvduRN2PRxrUT1ANk5Jlw5iBAncNZNMz6xQw3R89OrTB7IrUfAUlx7xngA/8bLmwt4fXWWMz+KftqJiW",
+	"zT3yYCAE8G3lvoIKThuBspYT75WDAFsYQk55OyI4OiAfDH4LNAY+eZ2N3y6CYQACteoI3f6Dhvu583CN",
+	"vBdHKKGXHyD1k/g8SC6UvGdiV06YgKmYu6SUIsph0D/I7fNwHgMdEHE623m3tzWG9hqJ+4SDY0b04a3P",
+	"A8eZ9LwHBvUc5v7CFVMQIWj2PDwvw9wvZHxjYl3kMXkutiBcywZuRUw9qeSGayybsOH6lq3pPZbHR8vs",
+	"CfktdK3dr6kY50S2XOsSY1pwb7zX7ZzcdmldRSEh5XkWTIfaIBFTNLiUA4VX5b4yglEnlqxhDlcH+0g3",
+	"rUsLw0XFa7sIlF5arIwywjdlm+VMnOAwZPvofQlnsSoSNz1gnadi3yMoKwwycLgCHWDDqJ6knndIHCeu",
+	"nlpweMlXI6i4XkcVHdYJcio6u8EoQDqzJKos3RG5ReJYkDO4zENpq6BWdA7eUtU+VMr2w4Lb9WSDsV1Q",
+	"9NDtn/ZsJX8bF7t2H2WPml3I1eFRV2Lxk70b8oF8OIW1y35Of7TyPn6EHaZjZzWejJu+Hu5nKC+zhYJ1",
+	"vuzDqeKGV1Aa4syVhvD6sEPe2/nEcaLS1zh56WsCUOmzB7L0LQD+KS3UXzh+K+ctMjGtvtda051s7Hof",
+	"u5KahXoNI17+KASHxPuKGrbaTj6faQb3EXNEzCJ3cBotNyJeWwVFHEdNG34P9eVzV5+a2y4bLqiRKtmY",
+	"LbqVuMH9UZKCvV3Onv1lN6AvrQeB7WZlLV4z5SDd3euX7pYpwQzTV6xSzBzU+Vw0XLBHzPqzMW2pW+lE",
+	"D7cuDUnvP5tNtb7Aihu5+JaW4aBHf31v/+/J0Y9HHxbv//jP41Fou0wzmKJkIv3ENDaWtyq+nHjwYpYL",
+	"6yUSUzpPi4/Lo5rBC8TlfZ7UP4vtsYqkQWroScOUwzM+zWdQomnaGNFybw/ExE5OuYC10/AYFh6udoft",
+	"ifVtiKvsk0um0331enV+SjTsIhIPIeAN/fiKiZVZz549/e77eZ+gT47+95OjH5+9e3f0YfHu3bt3f3w0",
+	"WfuAz/3ohRzfe+rP7C7OjF+JYs73UI9YAWNgdiyz7vpuKFgFfQH1CnwrdSh2Mp5vKVaSnxwR/vLiBt8Y",
+	"TqmTDNF3S4UUckGpA09OdH0IQqgvmdarF3SAPfkkzj8WNT6fUVe6duKQeaHbT/PDhYTYUwiXsuhzdHcn",
+	"cRTiqlpmbr3gpAREkyfEi8TTz25GiWI2rpGJmtXo1+6K1mIQCtbyN1hFMiha0R3ApkJr+B2LeXL0PD4k",
+	"loqxIwAlqbZCudKuHgj03DBDIdFsgh/UV3mlZEXte4dohh7bbrmbBfnFxdWl6g0grZBQK+RmQNLDfiVV",
+	"YF+Gm7C7w7o79hJMcjGOpIhJWmSQc627gU8FecF92uvSQhWjtdObcbFqDvb1PYc5TyNIo3nSD8i4nmDj",
+	"8WJlMoanrAJbCt8iz0Syh6dvFLgp/FolXGzADifhK0w4Iok5EXjKSpPEpY8RgULPzxCC4hj349Fvw+wY",
+	"yPMhO0bUTlqjSCNp3X/fOO4OfitPv8WicajGFuyBaczScyCjx1QeJaf/LyWQBcwEkWxSaFfuTA2a81F/",
+	"6gPWm7h0FxYdvIEe5eeDXh3anByAr5Mekmz/K8ag9zTn6CZxk5nuI2F7eqXWSybYmOH9eh2vlcUqNCxU",
+	"pvI5opzWNGexearMNdU+tQSrc3ZiBwLVITfg3NLo0vQT4z4OEObDBrTBnDCt78D80H8SHKqjOqC8mZN0",
+	"+4XNok1x4gCx/eFCeq+c2s9cm8nKuZusSxjjQsmVt09PHST0CaPUh3Sv/ToGsW3hxszw2pNy0i1PuUi4",
+	"yIAWI2Rxh5MT/37PS+cn+4DDsJPdj56kYRZu76rP4W19axvZf4a6golbiy9+7L+RB6aCMdmKszWpJfxt",
+	"A5+DpGDWTGlMR3jLrCzlW5esJBa8ndeBDlU7HFDzXMnuM6PahRx4CyCeHC4L90BCQbti2HchK136oalK",
+	"I5l4NO2jjUgW/QjnesQR4+fr6wsHMsYAOYDTUV01IfAkcPXEvTNAvjVzW+2hV5IBK9WXkfDYGvjJ0Ckx",
+	"foYPShRaJzmeAD53bEbGij/fWRnL2np/ALhg8+LEX9BpOYP9cb7KwyES08hb0OiCXWGlKMbaeFNDjGWw",
+	"ZfPs+anfLpePNJRkUCSzDr4lgBS+5maQ7FMKbuFztoLC94IRJbuxi5w9tMCUzZqBuMxrfdx1vAb3kk7w",
+	"3zrWbH2hy+3urOCJH1D5nJwkLQahmaMHZ26Nuub8+XBMW6HQZpw7YKjKV/0bzVvuSm3q8VqbqZzqqm32",
+	"0s6XFRzgSZDMD9cP+lZV1LlMeYmWag1R9dyEObBiv4Pu0Gprsbpo6XF8sHXAy3Ze5TTxrZSG1VtxGhRg",
+	"XKyQGMv78dY3IlfejD5xt/tm6pQ+A1ENoRhnR0GJPJ5jUG9FtVZS8L+WMuknGZm8MpVpAh2SFKhvrn2V",
+	"J0h57ZWRqH2SOlw1rl8xdX/qDtVX23+8umMPo8HBb5dLxwtS313IFwCCmFn7P+UyI1mXpCn6v/sPy4au",
+	"dE/vADUL7CgWljSXdy81z0iOo51ZjVopm2LUszbOM08uAcnQ0PttO98rO6tm90xZ1bx9zSp9WAY812n3",
+	"/IqcX3hP2AjPI+b7tJtYJySRDeS0n37n/ZB6pMAhjUmgoYkk5rxjEhKzuABoWAiYCZMlgSKO2WJHe4mt",
+	"Ga0nBsv4VYxGcpToP3hNOpcn/05xrreZSsIyQaqQg4eTHV01K8bvWZ30tnSHmnyi3ZGwc+oDSm2NhHO8",
+	"cV6yPcfryGU8I4l773wbxpxqqekKzBoGxI+lrZ0osidA7AjSLkE8oCVfvyOudIKjWDZ/Ric77oVOiFLN",
+	"jvSrk7LwLicGf1tK9UBVyJp3fXqRpYDEsPdgJjKS0EBfkKfb1723KrtmLXUWs5JakVxGjAd2S27OkVBN",
+	"ACum1WYCbBz2P76kRG6oynKYoSWoaiSwMNSxaXJ9/Yqwjy1XTD8iROV3KqW1p06Ww8XOMlluTSdmT4Zh",
+	"NxaiZfrp7VfhGs5gW8T4kB374mkoBWckEXUpjfMNSP1JJmccwt+TaCRExqUY3ehBfa8cmuK89hCUV2nP",
+	"gP3aly76VJ5PMCXD9JetYjaSnGy3q248c6KfVyIdBKhw5G4qkBoqRlynQ2ofuy4/bXeoNew8nWZQJcIu",
+	"3gcBurlX9D5Lg+u3K7x94DBuiws1ptmTm8ZNog3daph8gne/5dpAXumW56sNG4Uw5ChPT/o4y78RGElV",
+	"n44njD/pZYdP89DnIQIhcjCgDUcf8fYfTnUe67OHKVUn9CPz1oRBxqr/AjJGi1BLzYhrNBjRFVoFor1V",
+	"slutDelavKsxp/6RG2JUAVUKWU0F2YiCnriK48d70RV0IeApz82UOt5ObYfb4MHZQSZ9G8YQYaCE8z6m",
+	"+Y0HF34WOuACLuYoJ8djZ6N8ZGd0kJjJ2p8jeyf74Q29iynGb6moH3iNoikWYRvqdOxDaMWeywdhDcg7",
+	"sy66tphIrf8O1aQOY/iHu4Nqou3Ng7IvuVUKSt17FXs8cEE09p84uet46A4OjOlzS+vhXXtwPo5LVklV",
+	"7y244aEdRdp8bGP3UvJnxkdIJMHwkNItqzCCGKtjt84e+18pSALsQVPyRQFDQUG4ZcElHJLdNo3T2EBd",
+	"JVemKaQ599nB5kRRNyZ1A9ne4KvgSga7cOVsHLtkrHtsETzIkhXqpr14df7y5+vT61cfTn8+efPy7PmH",
+	"F+evzq4IE/dcSQEeZfdUcezruMQpTvUCZjLyjgnCOAD5QLfl/IuPjCqZz6R44epcT0zB3rC3nmJKO1fO",
+	"nXbtsG2R5d1nLZp9Eh0ueio1wLJFPIRNmTU4vTkpXHpsUE/LlSNlhUVuDbcEyRWrDBTEkAqyX5NVI2+J",
+	"c4yNlIAbKlXoAWpif18dM1MdixUXH23W3uWiPv7jAv6xX/e5N0THWb3XVI88zlr7KWekz8gl3qCYEQmj",
+	"u+Bq6aVEAk8dezrm5M+K25/QxyrpUqpcZgnb+VHOic/HlPr2Jf0H0WOJcCH9YaznxHM8LlYYq52M4S8r",
+	"wrPryh6FkwcKcKOlZhCM5kO7uDDSRWl6M1WKIhvGk67fGqQKy7JWnj6Ys/ksh+EgE1ayuz14Bt/7AA4a",
+	"jEHcb1dawqBRf019ekw8Nwok6b7mVFkSooYCFBaZG+wjVDAuRNnWUySgTPJBSor9iJZkSdVEgSP2e0W3",
+	"oyUNG/h24Iwj7+ORl0WMCk/Q5OeIJTkHpwrYxWcVv0JbtU68o8fHjMnOyyuIiuRhWnRHNbUULLEFGKqM",
+	"jhYPmDptXklhuOh8FBgGelLHCA4pzFBO1+/58GR3JejwWQHcbm/DW6GsMTLS0OawM2BkIJiJ1A+THEr4",
+	"O6YZIfl92ZxMymPsg9V5JLoX5fSMs3tj33G/MzqelsspexeUCuiN88TSw7JU0sBGkO/DkmefVBTTIcyT",
+	"TDp4QvxNqZNLF8oAHFAG4WCWHKYae4smSTBCp4FgAQXUZScMqyfXD/giZ3K0nKALlJ+yQ65puuYvRcMR",
+	"inlGNcOd2kfO9Rfzd+olZ8zQ+SV9nTK4H+frNBwi8XW6aa/lc2rstrztzNul+3fIUfo4x6ZsymSKwtd0",
+	"1mLnAEjp68A/6c/0jr0Vr+hoZrbQwNfYyzSllMTvPlczE7V2H46kOHp18sYXDzNyTqQvVQVGhJApqmdh",
+	"okptQSHoiIaOFIeK6aLYQUmv2Hjaq4Lu74HescN8DwxVK2amOimmc+w+7G7ceb7wIi1zfdcLKvX6Ito0",
+	"E0LDS50/zfsLunJvbfdAh5oF0N7uXixONdy5cZVAeKMXlQP5mLuxBXMMkQPU3y/dPiGzClbJLhXEnxM/",
+	"FEtK2IGOQcaYzuiCcAl/Z49T3x98Ju3XqQwkXQgwBfwBh/g0n5XqIxfxnhSWJjSrO22P/i1YS4xckBNf",
+	"MEwKZluHTIkuB1/vvQYUvjtxPs6VlkJJVC01uz+2m358uz1qqTINvWXNsXLCfaFc2dbrrkoTZrc52Oit",
+	"SREURVg+26sBceXDwpKds1TTuk6qJTrc3XJhX14LgrjWhDb2NtwG7PmG1GXttr/6dGS8vCBDS6mvr6lY",
+	"eTelBN5sp6ZqXe1YF3w07YUZr7sXiw8B1UByTE8NMXmjkQ63CaD9wm97fclMu3m6dyHtJqyjxwocGZY4",
+	"ZaGWNttVDsdhGsM2k+QjO4p9+1PuQzfNbD57I0X6540I3h/BfXoaB+jBnw7a+9Sbsve1B0H+0QFURldJ",
+	"Qpxy7tMTnxdQ/8L6isy9bscMlooLZ23bRqkg5ZITzt1+L0VPbgeWe2djJL7LFnomrH1yY2sshzDc/rbB",
+	"qTwCLvs5IfivYIBCrp4sdBsNUNwQBpDpYtg5C1AfOa+G/fjyPa5cB5fR9iimXT1iiZPP8M3RsupoyUy1",
+	"Pkprc468TY7wIbO7qWk3R17q2S23FBa8A/wysKOgJYDsJpFL9MUo1YfpNUlzcVDvAeOLSyp5Txt0ZrPd",
+	"dmXXaPnow/zk4tx9c0ZFd/rwN1YT3Ho8pTyJdI855AXBVS7Ilbs39RqioCoprGBHFKvkCvwN3WiBWCGB",
+	"IZYUUII2BLI0oNedTSWimB2XdCIZAZroBXktFb6En5G1Ma1+dny84mZx94NecGlpd9MJbrZQ/VHx285I",
+	"pa3Mw5pjzVdHqa/8MW35EQArMLnMpv6nNDpxKAvxUgHwX7ionRMktERQI8a8BHR5dnUd89sAVhGByXZH",
+	"XFo8cLEEgZkn+trMT65qOPjrdrcbboKvFGZ/jslpnZIFkrue0g1rTq22+ffGpMWePrIo0+XLB2OV97Ge",
+	"t4Ci18xQz0amMyt3nLwgNk3vMexeDnhNTpejjGRRDtJJDOHEnemC6wF8KbkL+y/ECsiY1eMheV95lmHD",
+	"x33BHeWrVg7t2v5rSc8Wv3mFhRnUA/DTWU1yOtM0dafvUfLWi9/87Olr330tO3N99o2LA2RBZO4nI+H2",
+	"3fbSppTu2ixWdLi+7HOSATksjjb4qtpAkXsWHkDI/FkNXtAuf38px84R6HxrIoXVAauOkVj9Oe3ll2IU",
+	"1es5Vih2eWJvpfEZJfSCXLoj4JBg8e8mjGTgnWDqDjXMLNL8RVb6PWah8eP6wzD3j368MyzgXrSBxoC2",
+	"CV7v4QhNOorlx3yxGdJF0hD3adD2K01QyYRCc8EOoQselpVWOEHqOX7xy+nVP33zJKu9oPlKYPw4zFY8",
+	"CXUvCdjUqO8vcopO+mfHvmmzlEK8adLjxHVPltUkym+AFL+lgx3t7b3F7LRtH4kmGml4WKq0wSAlQS3e",
+	"AAddTeHqyJNAFegpfhzSlaUhVqdkVY4w3ZUTqRR3VVz552c8Clzl7XIISF8hHPhkpmZPE/33qraCijF0",
+	"f31yOlRxO14cGW2qeY7f0RMYHYacX3Dgx1bO62cRm1C6Ne7Abrq+is+6HqV1Zs2E4dMS4QwGPOnMuveC",
+	"7Pieh98jX5juP0OGnq8gTjAK1SRUwcoG6EL5+ig5GUdeZh0eD2x7x7Zjbfq7OTL4cKhJKxjd83QCiz2p",
+	"uNmOrwOVoBPAHx82DFIEHDRfJYs+lmP18Uv+tJ1cnC/IKWDEOtZTUQW1MwY79HxL8QiiaiuULtJkw6jA",
+	"N/Taard0eGy6nIIDprzkrKl/5bLZlWMZGiXFErwAZCf1JvV72vB67oM6Hcxck1/t7zA4loSbzB1fZJCV",
+	"WOSeMsD+885An52n1Q5ziU2hk1FbqyrcbfXRdBNxZFHvisla1NghsJaEYSoRYZ33kfWbbHgFb2nv+KES",
+	"B8dEkEzKHJVfO3pH8YtBrpews0iUE/1vjLNy90Whm8vzkHLCqwVsWz8NnIC4+k6JZ0uok1SZ5hl8fPZG",
+	"mhdWMTqhIpLb5ff+0F2OBHGd+BvrKKh8/dofXHxX0EEFSvUq+J9o7RVb81mfpEEP75gDhp2+kOqW1zUT",
+	"oLHHpczms9fMrGX9RpoTW5QFWp7gS+fsI9dG2+TwjgTAoIdKFPf+Tr6c12zTSsNEtf2FbS9Zh4UY85/P",
+	"RfC+nM8c8NdSvrJi+mw+u5byNRVb98G2OXd6Kp/oxLHam0hpthvfMNmZgz0Wkq3JcJn8XkBr8rWH4eRL",
+	"iuzk5wTvya+FLUi+9ncj+ZSgP/l1fI+SRiPbNdoi27lssv4mJh+H+5mO39va5FNxl9Nxw4ZnmxE9Qnp8",
+	"unDu4Poo3x5cx8ujZAXOIhHKQZk4en+gAcuHZjuiCUI1dtbUkTfpllULqRcTaxPhJPnjsCQd5KEPRaA8",
+	"wwwxNfimtPK1j6vxYoC1Q2f2QsW8S4BVg3hHIxYSK008sxmUYdDs1zBD9muYrtc2+Ij7bO8nVRkB6X3q",
+	"88hDDuzWYHiFgmo1ii6XvEqXfgJtwJ9BtpOX6YFxff0POEYC7oWSRlayGXVnh6+elBx4hMYlqK5hGC0C",
+	"Sn78h3WbjJ35En3b01WZqp3NZ11t/59Xm0MX5sG+hmH6v97UpV/Pq02+9suuKV72sKRweNw6e1F0WaQU",
+	"F5XcwB8OP+iAjb240QEVc+LyJomaJJUtSnFr+2XJjN4mBdHahc3ts9ipHwkm8g4hsWIJUVoaGo5GyRfz",
+	"0WvDBXXGfu8alJOG0xPiJ1NhDEzdBtxkUvS4q/r33333p+/2+kj0w64SKp+C1HAqQhKTwqLzfDmKnJ4/",
+	"vyQKI7bSw1LJDUM7UGTC3zxZwP+Of8jPDE6WnZgD8u4M46uKrBqS4fLKJZX2mtODiqr0PbLDt8fXbEoG",
+	"cV2KsBfrtEz25Rsu3Xry5atZcXPJloXqErIT5iJ464GSe/Zsdjyblzw7jPQxY1w4rlE8UGU7+HwWyzTu",
+	"15XFtomZUkLRS+qz0IjKERfU3R/SEqi6L9k91+UgyYGzdQBv0Hk+5m/YG8MhuuyXmETAPvtbUsQn35MY",
+	"Wjo9ovYs9Ck6SCVDvh8SR1J7ZNpsmMKtLk7lB3tfLN1TgnhIlUzc/0pLQSkngmA1CtpAah5LLL+c/fu/",
+	"/Hry6ubM50yRoOSnuhhxG7PTRpwc5tOjOjGak2FD0RHwloXg6bm9SZsOaxSILaFq1VmINOlswQL7vhc1",
+	"VTXRa9Y0lqgN/ejCYFFmDpkJN11jeNuEmTRpeQvGrxXIYZA4DDU7W8xm64EgnahBdXFL9ZocWf4tDPtY",
+	"ttBoKupb+fEAcnAdPs1n1tv6OVf7fH9DRsZ8I9D8cgsBguhkEMpAN2xpCNu0Zot+tk0TG/nkKZqs5SaZ",
+	"Zv9DwO7lVDI9jCkn2JlU/qowYZ9nXMV9GdSAWHLBvNRDxTA6PXF1FS78UhDqYjNsP3dsSSe4yXSYKFOt",
+	"eVP73O9ZKW2M5YFeXEN5yhZePM76YPBZGroAMDsSV7Xdv3bS0Is9gYanFzcxP48b1OrwOo3Z82ghANG4",
+	"fHNSQP9HZDzEAhCv6cexfPv2cwEkLF0C+bR8srTAxX6Zk9dz8pJIRa6J7pZL/hFRGtMw3Ln6J3AU2MeK",
+	"sRovwIZvXBBtUvnpm6Mf3//lydGP7//4l19ev7x+/z//eUSzWttcXfZaL/HZWy2bzuCbW6dLqpwvJhRf",
+	"FdJA5PiBHNSe1TIK7Zd0NqBUqnN/Yu8d3qt39cFXcPtwVKx09WnnOS+n23DkO7LfKL7H9CcUdVXhhnGL",
+	"AKkJA7UW5J0ATui7OCe12zRHB9JvSL+I9EfeCawRjcF0FMnZnrsFucILgtXxR3BCf/ZOHJGv9FcAkMvE",
+	"Aj9t8KcNF51h+NMaf4LiJPBDjT/UdKvfiQKNvXtX//EverOu3x+O60R8+ByGmu+VXfbBIsyN7TRI7GJ/",
+	"3CfBpQMM6GZaEvOM58r0SozEkORq8Zdjy5RlXFjQl+uEhvA2pZXJpoHhrfIpPtVcxeJFKPNxvoz+TE5H",
+	"18q2a6i3S8MXDwHtjCT2cSXvIZYy3MJ2FuAZRcEirqWMm5DawyMmWbyRft1enRZxBKcg5UBeIXMmnKL0",
+	"OdfuX1eGKgP/lS0q790Pl8zGTtq2lG2kcH9O0+A4WgjTub+TWR3F+8n9n7KNf0VQwg8OIj9cBliBr/5f",
+	"Jny5JF8JVRRFsXJh0C/6Ol4b0xafx5aeL3antwk6tQZ8hBTLTHROKFIxiZJtiPTdy6/4zlpIQscoZalq",
+	"be8BlFXmIYtR6B32dZDfxXfFKt8OiEX5rexFoUlVWg0T5gV2+Pu/6vWaPv3u+/JUa/aReEeiq59Pjp5+",
+	"9z2B2m06ZssN1lPL9DQz8wTnIJ5Jl/sWu/lwrv+Aynsj2EPBrRQPE1Ns3ly+QtsA6s5iqL7NiWm/Lsi5",
+	"AfkKH4yM/NYxiJNUdMMMuLQj+372ThxbEjg28tg7lvxPaPwv0LgE4y5VR6DyvdoNf1BGLscBdZTdFOBb",
+	"fzvcywVs3nliKCSGZ7HCH5y1P+DRAbHw6znWaDRUeaKfBxG72ZLVX3kLMhgoNut5emhRYWCkYri3/u6w",
+	"32bzmRtu4kUwwMALHGXw+4kf9tMcs0o+56tiOMaJcMkULHpi9gJ7+cUMsNA3OoQJvrR/c+N9eH3gUs9s",
+	"NzLl9fiQaSaQxPoGJ/LZt8vv6GLRq9EQE3VbGUXIABN8NXQ1MqYUYckrrg3wC0uHVmVSKQZuC7QpZ97c",
+	"m2MHnN+WTDFRJSXPrA1x78HBsUsX1Wjp6y96VXGYZdwF1qiO7TvFbozyIR7WwxwaCfpNILhGQWKA3LdT",
+	"dwl+o2PhIPfJRoo3oyIzfs8l5+42S3u6x1l0LFyxfzs9j26T6TqsMTeUJvX0bR9HiStwnjbblxvBqq1r",
+	"6irr+YoH3n+9BKuQ5sReDdMrCQppfgLHzuld5IMYe4InMUGjWFhK1DXaQJNji8DiStCVFaOI91/XPcfX",
+	"aRvbeceygyq83kCvgeI6BXeeUqWfJ0V1slFFZlCes2BBp6a/UvAb04jmReK3nNEYSVxPWUqIPuxqTmI4",
+	"4d6erE5p0l+BcVQwVvrRJt6FZRScpWOWm7xOZvo0n/3S3TIlmGH6ilWKmd+Pt2oYf7+dbHqqTUBGS6sJ",
+	"pkL3Goo95smkewWzCHqZq78G3eSXTxlix06C4oY1SMI3dHt1ha5BDra+BC1TmmvD6sB3NOYYWCcZz508",
+	"jKWZcFXavTmhbaVYMZiFNpwWU8e86BTI+IDsPDUNcGxMHn67dfUM8KMGmIgbdJ741WpmzzAkwrSDceUb",
+	"WQcBWh/ZLCGHaUi/TKH02BhAHHXwj2wLMMnRm0wbummnXyk1a9hju3LdNnRblgBO0EX6aKk4E3WzLZUB",
+	"LGyTGxO3+DGbNYBytaPcro0r+q1jogplJrOA2yRrbakWr+Yg0kNEFrkIaje/X6As6EE3IR3aZwdLvaYt",
+	"Fhq1n20mFfTxwdhn95TF89K5pMhSragNkIZ2lp+vwBGU/EFXssVfsYT91/4YF6mwrD1N9921nS7ZnKRy",
+	"DTVEPgjtY8nxdygT924WRJp3M/dQHakekTmUjliqqa3w4fAH0zp3a57UvWfqK53EnuN4eUj7NP063Iu2",
+	"s00ru6ZNw0RJ4ig2wzCs+K5y58VSG5bMuXhNbJIY7xwGdW40yhauaoeP/HdrNFsgDloZfl+ONAyT7aoU",
+	"EsTDBLY/XF+8fvrTh/PnH97+9L/OTq+/jgl4LGzUGMuA4IjdsW2a5Md5ln+lYUEeth2FaAIz3FEipBOG",
+	"N4XaHN4PEn+rVCzFFddyQNkpGGESqjRjtUfS2ZvTy3+/uD57/uHq7PTy7PrrIQzzpDKRkaXtnRALFjcz",
+	"gDpA4fs9RDuakqLQiISYR5OmgQu0l4Up49FDq0aQd7elfFb7M84VY/DG08w5EA6ubbfn4VR8LCVzldKT",
+	"nDhyr08PO3mOdofnR7GB/adNdopUnrv4I2i/siTo1u+P5Sx6IuHhUuIR31NpKKzClRiiilE4G9cfLm5+",
+	"enV+Gg5EUnwoX2Y82eXV9bMDlQA+i+s6EOACpx2AOCfPzy5DRy7IxS/n/wa318Sr45I1dMvq573Q7FL4",
+	"4U7FUL+6GHbJ3rbD+lmR7SgLBujgS7WOQr0xqpOg/mwo0OWOZFr7zLrSoXrRyObJOuyNi9jy6U8jlFjJ",
+	"MFnrvBev62KfeZ7x2LqOFK8EvhJ0vAxw+FyGy0mXCU0Vdmu8AtguoCbU1MoXPITu0UmffcHvAbHGDUwx",
+	"lwD8ftrB+Klcp2SsZcLcQqF5ezkZspHakG+ePHmS1KPnLnVFQ7exemXgrr6dc8DXvCRXuUYjNOFBSIcr",
+	"kCXUQgmp76YeY+fcVDvoHI4nR5KWuNA+j3C/2vLmobHEDbijns60PBQxKgE83yZ2sk19zp80yXV5g9IW",
+	"+emzleF0kjd+kHrNbxcPNXGgTChbbSfvQFJrYixfZOVSDFYT8+afhvZ+xCokpyslddCymTwyNsZ+WIqs",
+	"cG1BrMYFOi/prOjNI2r+QmLYaeBBpla/5pg3clrns9Dej+Bjdqb19zEfvveKqlu6YqdW9D2A4l/2u/nx",
+	"oNLMxGrktqnvx6Opc+QA+OTx86TkQOpvGi4IZ/sMW9/LJ9/KOqstP08zea4wL9mabVMLaVIvy4kcwdzK",
+	"p2cLSa25pWB4vlJ0+g68Ds2tbmMiyt9eeXz/1lFFhXEWw/09/zW2LyfUH1WElJxI7ZrtHvg2IdVzZt/X",
+	"B1wQmQa6+HRqWTWqk/k1Kldgl2HYQCEuJ4rfcTEngq2k4TRcbYnT8xUzVrMHmhsl687ZkSFhgFfixDoG",
+	"ftSymSxGX/yOnMs4JcZ+Ggjl922vUHJ5Qj9su6+CQfmmxo09aZgyPoLxgBhjryNOksRmmbBg4+zYZetw",
+	"N6bW9QX9smRzkOM+SRztiiCCiZDwJOzXZduBiSFvNLp+PPPP9tSft+elO+/76M5zD9155p/bc4Z+967+",
+	"H6OeufuL+OSe87gsfLIrvlr5jNR9dMaa72Dk5mY79TTDpl+5TuWiDn7EZK96RVz2RXKPT5a4i/6ZKoHm",
+	"zFPFDa9Am3UulnKixXN0kjjwaJNkxtE2CEqyGs8IS6GOG9q2HJOLn17cjOa2urgp2fWwwsDoeR+pPuDN",
+	"jGP9xo2QMfrSh2a6q8LHZsZYuZ2Zusqr2Rd8swuuPZxvBBOfCrs08gDxLG/XBQqNIPRYO1uXFO4I2uNK",
+	"/AEBNT4ylYMv1ch7S1JLshvFNE3WndxWJUtSJI+wUl/x3g1JoCvTvyN3JK990vpBVMXiEYENWVabBC/z",
+	"dC8LKNnFlhyJXPtk/CVigN0O6fqT1yG4QA6ELD2sbgDRNJ1omM61Ti2riGYm2sKgDGeYCI31TpTRzIQp",
+	"jYyDf6VdzZdMtptjwJlreNvxxhyBH7QfvBgCNpVkE3ShFfnucT03jmsd3vfTjj3dtZng55LctNr7OqY2",
+	"Snffxus2aH78BritLiDR3Sa74uj6MPQueUr8IPGuj/o42WFgw+D2f8Cr7rMmdmMcMG9pH9LCFwMYfuKi",
+	"zlL8Q602E+tuzImWCBjE5jRbV+VC9/J8MUiNqqzCzIcS51th1t3mtlUuI2Ff3PLfwpvEZVBN1LIJUBgZ",
+	"aL8l09P63s6mmVOLuzIlFiyjOvCtSVMvDH3oVIFdW2f1wvx7GWKnyowuKd4xaS+80XmggM6R21alKPGL",
+	"00vt1GU+CCA4LSD6uCaa0Qae/dHn+P8LkXtXrOoUIz9J6TPlBsw7O2LoDkHdMGMxwUXI8fH0T/uqUe57",
+	"xNmffGm2hldMYO1KNGzMTlparRl5ungyc3s684nUHx4eFhQ+L6RaHbu++vjV+enZm6uzo6eLJ4u12UBm",
+	"EMNNY4d72zLh/WGjQ57Nf0iO3HWS1Ci490/umQ00h7LgLuBL0JbPns3+tHiy+MYlUQC82CTtx/ffHDsF",
+	"7vHf7DI+HSfGe9hfWTK4YMJUQoFCfutkNFRAzcMNo7pTDIPsExUQBouFtBUh7ui8Bv29HdPpbBMg5rMY",
+	"fwHy57hTynM/Mrdf7Ep90g9sN0uPCvpp491Scg58H+wVP8l66xKSGKd2TrTEx//hsujFoXZqeOPScMVI",
+	"Vjlc8IMLibEDPn3ybSEHjyQeok/z2bdPnnwxGDH7GcDVYxS0Jt5JAOb85vefM8tnB5N++/tPGnLiwYQ/",
+	"/v4TorUlpMX7BMlTVjqtrWR/239oj6vU82js+MIWEkpEKL6PQ3j3oscfY0x/NjjG0R/qP/U8Z2fqye9x",
+	"qONCC7v89pf/LsfmMPrdMKN4pccptu30mlwouWFmzSDl+kYadgSpD4jrTXSlaBuz/e0l1YtOr52S383/",
+	"X/6u+XgEScduu2W+W9ErgAuK2ZN6Uwz2SgvattujGJQ3il9bKZ/FTKT/uKqmnrnvnvzp73BzDPOSHnj6",
+	"vIHAglAsq7py3mfLrmn8sUpKkk46bC+ZKbgD7DlwbwaOQV/owM3Lxda1IZCvlQyrPsOsEOIbp4W2l4Om",
+	"B06bh5QG05UOOUWco5AzfIE/g3dVqSW8hagQsnMZf3jP/OVsIYm9LSah1CYtiF5aYmLO09nSJpvCfs97",
+	"t0BRo7fuJMb0D3n2v4Q8G3Oyt50ZLZOU1FXJWdDz0RdmrK6EAP6/9rp0ME56Uj75XWYtC7z/eJv+JwjZ",
+	"MXrUh6TufxLGPqgQf77rlTcsZvn7UPVwnkkE/s3vDUAvCSDgpMa75oe/79wuH7+rfM7q/2an7j/3Qhuc",
+	"s33H0F1zo/K23cvelZZFbfevNVqXTuLOiw0FQLFiKrN+lMb5r658mXRA/ltqXvYQZhpNs/9miOXTMKFB",
+	"liGoVeyIah9MICfEhA21MR6acOX8HldJKdzt7ywtDaqE/0Nu+m/3BsqO3uihPEyXHxpjmJyPeKaFKD9r",
+	"thuJa+vHEIeSFNzo4ZEeEQzLkdD/z57o8nL/caz/cazDsYawLq/mcDFge/Ub/Zi1XggahQC0xMuMq7HI",
+	"tY3zWkxHQP8MuupFlqHW0DuuGOmGhNqINv0LN1iZH1xyvPrXjwkpL1JvfSjyZ8H0gZYw2mJMN1OIQANf",
+	"vt+DcYyGFv6naE8SAC6Z7hrzD/4x+/bvYT+JVfHK+pRQAxWfUejYc2wdjP/PAKLtqJeOiAEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
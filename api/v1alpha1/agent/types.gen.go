@@ -11,10 +11,25 @@ import (
 type GetRenderedDeviceSpecParams struct {
 	// KnownRenderedVersion The last known renderedVersion
 	KnownRenderedVersion *string `form:"knownRenderedVersion,omitempty" json:"knownRenderedVersion,omitempty"`
+
+	// SpecVersions The rendered spec versions supported by the agent. Agents which do not announce their versions are served the schema of the first version.
+	SpecVersions *[]string `form:"specVersions,omitempty" json:"specVersions,omitempty"`
 }
 
+// ReportDeviceAttestationJSONRequestBody defines body for ReportDeviceAttestation for application/json ContentType.
+type ReportDeviceAttestationJSONRequestBody = externalRef0.AttestationReport
+
 // ReplaceDeviceStatusJSONRequestBody defines body for ReplaceDeviceStatus for application/json ContentType.
 type ReplaceDeviceStatusJSONRequestBody = externalRef0.Device
 
 // CreateEnrollmentRequestJSONRequestBody defines body for CreateEnrollmentRequest for application/json ContentType.
 type CreateEnrollmentRequestJSONRequestBody = externalRef0.EnrollmentRequest
+
+// ProvisionDeviceJSONRequestBody defines body for ProvisionDevice for application/json ContentType.
+type ProvisionDeviceJSONRequestBody = externalRef0.ProvisioningRequest
+
+// CreateProvisioningChallengeJSONRequestBody defines body for CreateProvisioningChallenge for application/json ContentType.
+type CreateProvisioningChallengeJSONRequestBody = externalRef0.ProvisioningRequest
+
+// ReplaceRelayedDeviceStatusesJSONRequestBody defines body for ReplaceRelayedDeviceStatuses for application/json ContentType.
+type ReplaceRelayedDeviceStatusesJSONRequestBody = externalRef0.RelayedDeviceStatusBatch
@@ -0,0 +1,18 @@
+package v1alpha1
+
+// The agent client and server take the types of their request bodies from
+// this package, which only generates them for the operations the user API
+// serves as well. The bodies of the operations only served to agents are
+// declared here.
+
+// ProvisionDeviceJSONRequestBody defines body for ProvisionDevice for application/json ContentType.
+type ProvisionDeviceJSONRequestBody = ProvisioningRequest
+
+// CreateProvisioningChallengeJSONRequestBody defines body for CreateProvisioningChallenge for application/json ContentType.
+type CreateProvisioningChallengeJSONRequestBody = ProvisioningRequest
+
+// ReportDeviceAttestationJSONRequestBody defines body for ReportDeviceAttestation for application/json ContentType.
+type ReportDeviceAttestationJSONRequestBody = AttestationReport
+
+// ReplaceRelayedDeviceStatusesJSONRequestBody defines body for ReplaceRelayedDeviceStatuses for application/json ContentType.
+type ReplaceRelayedDeviceStatusesJSONRequestBody = RelayedDeviceStatusBatch
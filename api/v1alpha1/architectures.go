@@ -0,0 +1,63 @@
+package v1alpha1
+
+import "github.com/samber/lo"
+
+// Architectures are the architectures devices report in
+// status.systemInfo.architecture, which images can be specified for.
+var Architectures = []string{"386", "amd64", "arm", "arm64", "ppc64le", "riscv64", "s390x"}
+
+// ImageForArchitecture returns the OS image for a device reporting the
+// architecture.
+func (o DeviceOSSpec) ImageForArchitecture(architecture string) string {
+	return imageForArchitecture(o.Image, o.ArchitectureImages, architecture)
+}
+
+// ImageForArchitecture returns the image of the container for a device
+// reporting the architecture.
+func (c ApplicationContainer) ImageForArchitecture(architecture string) string {
+	return imageForArchitecture(c.Image, c.ArchitectureImages, architecture)
+}
+
+func imageForArchitecture(image string, architectureImages *map[string]string, architecture string) string {
+	if architectureImage, ok := lo.FromPtr(architectureImages)[architecture]; ok && architecture != "" {
+		return architectureImage
+	}
+	return image
+}
+
+// ForArchitecture returns a copy of the spec whose OS and container images are
+// those for a device reporting the architecture, as the agent of the device
+// pulls them.
+func (s *DeviceSpec) ForArchitecture(architecture string) *DeviceSpec {
+	if s == nil {
+		return nil
+	}
+	spec := *s
+	if spec.Os != nil {
+		spec.Os = &DeviceOSSpec{Image: spec.Os.ImageForArchitecture(architecture), Stream: spec.Os.Stream}
+	}
+	if spec.Applications != nil {
+		applications := make([]ApplicationSpec, len(*spec.Applications))
+		for i, application := range *spec.Applications {
+			if application.Pod != nil {
+				pod := *application.Pod
+				pod.Containers = containersForArchitecture(pod.Containers, architecture)
+				if pod.InitContainers != nil {
+					pod.InitContainers = lo.ToPtr(containersForArchitecture(*pod.InitContainers, architecture))
+				}
+				application.Pod = &pod
+			}
+			applications[i] = application
+		}
+		spec.Applications = &applications
+	}
+	return &spec
+}
+
+func containersForArchitecture(containers []ApplicationContainer, architecture string) []ApplicationContainer {
+	return lo.Map(containers, func(container ApplicationContainer, _ int) ApplicationContainer {
+		container.Image = container.ImageForArchitecture(architecture)
+		container.ArchitectureImages = nil
+		return container
+	})
+}
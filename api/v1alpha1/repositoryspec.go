@@ -3,8 +3,12 @@ package v1alpha1
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"regexp"
 )
 
+var httpHeaderPlaceholderRegexp = regexp.MustCompile(`{{\s*([^{}\s]*)\s*}}`)
+
 // oapi-codegen generates AsGitHttpRepoSpec function but the generated function
 // is not using strict decoder.
 // The loose decoder settings may decode GitSshRepoSpec as GitHttpRepoSpec and vice-versa.
@@ -40,3 +44,33 @@ func (t RepositorySpec) GetRepoURL() (string, error) {
 	}
 	return genericRepo.Url, nil
 }
+
+// ExpandHeaders returns the headers of the HTTP configuration with their
+// placeholders replaced by the credentials of the configuration they refer to.
+func (c HttpConfig) ExpandHeaders() (map[string]string, error) {
+	credentials := map[string]*string{"username": c.Username, "password": c.Password, "token": c.Token}
+	headers := map[string]string{}
+	if c.Headers == nil {
+		return headers, nil
+	}
+	for name, value := range *c.Headers {
+		var err error
+		headers[name] = httpHeaderPlaceholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+			key := httpHeaderPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+			credential, ok := credentials[key]
+			switch {
+			case !ok:
+				err = fmt.Errorf("header %s refers to unknown credential %s, headers can only refer to username, password and token", name, placeholder)
+			case credential == nil:
+				err = fmt.Errorf("header %s refers to %s, which is not set", name, key)
+			default:
+				return *credential
+			}
+			return placeholder
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return headers, nil
+}
@@ -18,175 +18,664 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+x9/W/cNrbov0LMLtDd3vE4yXYXuwEuHlwnaf2aD8N2evHeOu+CljgzvNaQKkmNMy38",
-	"vz/wkJQoidRI48/Y+qV1Rvw4PDw8PN/8Y5LwVc4ZYUpOXv8xkcmSrDD8eZDnGU2wopydKqwK+DEXPCdC",
-	"UQL/YnhF9P9TIhNBc9108nryc7HCDAmCU3yREaQbIT5HakkQrsacTaYTtcnJ5PVEKkHZYnI9nehOm/aI",
-	"Z0uCWLG6IEIPlHCmMGVESHS1pMkSYUFgug2irOc0UmFhVlyf6WM5i2uD+IUkYk1SNOeiY3TKFFkQoYeX",
-	"Jbr+LMh88nryp/0Ky/sWxfst/J7pga4BvN8KKkg6ef1vg2KHGA/ycpYvJQT84n9IojQA4aFf/zEhrFjp",
-	"UY8FyTFgYzo51QOaP08Kxsxfb4XgYjKdfGaXjF+xyXRyyFd5RhRJvRktRqeTr3t65L01FhpeqadoweDP",
-	"2froAdH6VkHV+uTAbH2o4G598hZSR5U8LVYrLDYxaqdszrdSu24kVjAeSonCNKNsAWSTYamQ3EhFVj4J",
-	"ISUwkzRKq4OJqb6MIFH1I53AQB4J/UxwppaaJt+QhcApSQNkM5hU6nNWc0SbeJNH2wSopN6gBFcjoFDL",
-	"Q87mdNHea/1Ns585Xei9qpMHLtTSISnQDfAQ2F/d7fPJ+0gv/aXVqbGb5cTVYKGdPTz+fEIkL0RCPnBG",
-	"FRenOUkA8iz7NJ+8/nc3iYU6X2uMHWoczDViySld6KN6Qn4riFTtNUWbIkFyQaSeEGEk7I+a42Ik6YKR",
-	"FCVVXzQXfAWH6vCgvQ85/ZUICRO2cHp8ZL+hlMwpIxJGWZvfSIrMYs11RWUFlTmqfI4wQwalM3SqrwUh",
-	"kVzyIks1XayJ0CtJ+ILR38vRJFLccgClV6VvCsFwhtY4K8gUYZaiFd4gQfS4qGDeCNBEztAHLgxveY2W",
-	"SuXy9f7+gqrZ5T/ljHK9W6uCUbXZ13ejoBeF4kLup2RNsn1JF3tYJEuqSKIKQfZxTvcAWAYnYbZK/yTs",
-	"3soQhV5SlrZR+QtlKaJ6t0xLA2qFMcf2Tt6eniE3vsGqQaC35RUuNR4omxNhWpb7TFiac8oU/CPJKGEK",
-	"yeJiRZV01KLRPEOHmDGu0AVBRZ5iRdIZOmLoEK9IdogluXNMauzJPY2yIC5XROEUK7yNn38CFH0gCsMd",
-	"YA9qV4/o0TIHte9FEh/GdG8xn+q0WUrxFmkhD3Kj2Dzv6SDGoZsbMsz0X3yO4uxo5BR3zCmoIquAUP1+",
-	"287oy7TsuxN16tktOFgIvBn51sPwLb3VhmsN4xNm9wcxCie91Lf3vwTOcyIQFrxgKcKokETsJYJonKLD",
-	"05MpWvGUZCRFnKHL4oIIRhSRiHLAJc7pzJM05Gz9ctYNQpOrkK85FUbfIAnX+GwBabuTFKWFKBnGGmc0",
-	"pWpTKpoeHJPpxOgVRtP826ug4km+KgFbhNMUNAqcHddVGHfIWhvcPDx1gN/qgRFWhrKIdPq8Ri5SS6yQ",
-	"wzAIZRrLOc+LDH662MCvB8dHCDRpoTEP7fXCNU+jq1WhtPo0CRCAiAmTZ0uCLrAk//hhj7CEpyRFx28/",
-	"VH//cnj6p5cvNDQz9AGrZGl5uL6TZqWISUmWIsoQ9omhS041HMHfkIuNCor2ILiKj0EjyRFLDYEBSKIk",
-	"CNPHsHrgUr8VOKNzSlJkTQGtaQoaYHOfj97c/SZ5MEi8IAFK/wy/A8r1IoDtErgMLskGmV7e6q39hkpZ",
-	"1CX+2g2xlXj1isO2qY+eMeru8dLggaKUQzzKGMbzShkuRk04zwVf42w/JYzibH+OaVYIgoz055YOi9TA",
-	"W1uaDKBd61lUizEbRL5SCTanOqfz+VPwdNoB2wrctMIa4iwhFcL7nCvNVYG9BTBxWH4zRha9q9w/YzP0",
-	"i9b1UeI1FAQdAN5IOkVvCKP6/xo97zDNAKaS9vrpyiUUk+svmpfOcZFpDnbdItYGiXhLCxJGOW584dWe",
-	"GvuThPuEM4KwPobK0UBSCAHiiNI77eRYTehO02/bODIs1Vlprzqjq8jGg61L0RUxM5WgVbYukhohScNl",
-	"aVNxhBlXSyJmPhVoaWhPjxWWS6TmIVvNcrYdouagaCHPYQdf8EJZiLtNcc4S/BNhxFzb4dXPnGAzW5Qt",
-	"DaOpY+MKS+CG+hJLUZGbaf17/h8/BO95QbAMTf6XC0HJ/K/IfK/kCDfjd7LXOntqim5Upxm6kXp2C1om",
-	"rZXMQjANEVy5/Gr3O49KxTOd6fJMFHqYdziTZLCxsjGuHavxqxu68bNvZ6zjwYPOcSJjsHR/Gq4EUFuW",
-	"dJAkREpqLp7aP9z5PcZCQtPTDUvgj09rIjKc55QtTklGEq0kTKaTX7XkqTGhVQ/rFchJ4n7+UGSK5hn5",
-	"dMWI174fvt4ywbNsRZiyd5i3qOg916dNiZFoixJVJyTnkiouNkE8afREP7SQ6X8sEfsuI0RFsAvfHC7f",
-	"kDVNiIdo84OPbvNLC+lnZJXrK9KqUXYPNCUVUvHV7dt2p032cmqkOOu30NxlZdprdpoAFKV8LGdtWV4D",
-	"axbXZl3m97oZOF9uJE1whlL4OBsNOKOpdzT1yv2KZfS/rW2fHYy4ocvVjFbzp0Wcpg4DMYvEIA952zDx",
-	"Aef6qAbcqgYtQT40nUjj/dvZq9rCoDN323HjODOuxRi2BGEpESSNcjXH0qwMnzquabp5vsltmmh9nk54",
-	"Jc9IG9TFyfHhW3tUg0q51PcpZ0dvAl8b4NTG8nvG4fqZ80vpLrnGrTBXRJyQC87him2rBrorIl9JUmgN",
-	"H5oj4dojwkBjsPcZTqyOqFmglsCtOH9F1RKBsmKJT54zLsBGQPXth86WRJKyO0+SQtipvI1bYmlnBo0z",
-	"y/iVBkFfrTmXas98QwrLSzk7Z33N5AZFBgV6tY5VNO0kAE8pi/RDVGGb3z2eDDE7A2myxGxBJFriNUEX",
-	"hLCmfm+FhKFYguWTLixdkDkXpD9BmfYeRcG+wqbeBbLsdB5V0Yqo7oBozHy9qcaCV5LNvSAjTDpYkHsi",
-	"muso3zqCFVIVjTLqeTUFR7N3VDveZ+u1FBno5jFQxrpSxj9RN8/t2CC6gB8a+bR1LD9+DktZ18argLPP",
-	"TBZ5zkX/ULngzOUUwa/lvMGvFTCRzx6E5crDjvfqW93Lbn6Xo0720E51byMGMLDRX/7Y/OXTYZw/yut3",
-	"drSbcT+dhoVqugqa2blUghAEX22kt0CfT95vV0HMgJ2AxOJww6A0VKNPpwaqm0PSEIna+kYS8UWdLSvx",
-	"ROFLwpx4olmOkXGt4mrENSOhOC/DDL3FydIOoE9gKVJZLykXqdEmNtDPcNS0NyPQCzpIjJNqi/8/oN05",
-	"9+qW0NQk7styyLXW1MhmJ3nRV3D1BzKX/3SSUnl5k/4rsuJ9j2RohKZ7Ly8m5aAWur64iQdg/xcWNkD+",
-	"UFBFE5ztHIodmtiP9G5/rSYPffUACn12QIa++c4Sz+LVppBIsLa7IM33umG6ulOp7rKiDCsuvLE3JmrD",
-	"Du6ogTPSw5j+E1XGynMs+JqmpDKnd/X6pYw+OSWJIGpQ5yOWUUZ2mPVnpfJQtxBRNllElYfT3pQVVsny",
-	"GCstatUjj3Lz4+T15P/9G+/9/kX/58Xev/b+e/bl+z+HrtLtmtVSa5z9zmhlNtLb2bOTvRNN4pAV1Nqy",
-	"rYbPJg4ZGcg6R+rKaH9BreGTCe2AuT3SIehf4a/vCVuo5eT1q7//Y9rcjoO9//ti71+vz8/3/nt2fn5+",
-	"/v2OmxJXgGMhLP5X3w0UViarcBbsdHhk+2rRUglMM5OslagCZ1WQA+5wJlXG3n50EbB/949OKZdo7nK4",
-	"9LE1ZWgwgyEaPvT9AmarQJTgAbacc/taa3ZrrZU4lXInFV2PkGGpTgkB8aJfsMeA81rOUjuxQ+/wwVJ5",
-	"w0ngTuiRtZr0GKBqfz2dWNVmiE0qjTgoPKqsQTWt072PMH+TS2KBXaggq/DjbWhcormHDDtrA3UxRbdn",
-	"ZbpRWl1sCE+e+wR3eDifrjI+TyfH/IoIkn6az3eU7mpQeLO2vnmABL7WZbfaJx/cwOfaCgLfA5Jf7RgF",
-	"L46yhTVWmChTmsr9oqApGIEKRn8rSLZBNNWa/Hzjm4Db94FnAQjrdgdeC83PwaLmIkarYVtUp5Fj3GL1",
-	"MX/kXKGjN0OG0gCDXd2sPwznJ9cInTp1s+cETXXOR0m5jjYU8RPQMJzvqEtzUKfR1ZKwMqLbxEjPaUaQ",
-	"BceFdn7TCrVWOt5R44DtBYVu/MkhIARIjrXwF8Kv/qKR6wRXcNJY3wllDaeKxjQ4Yag0HRPMkLXdcUQo",
-	"OG6w25rE7oxAmCF9+DR+qYAYqU0PwttqR6jffrfut7C3irn2bvNWqcG9263SHsK7VT7nZ/yNSSD5VKhP",
-	"c/u3F4C2yxVSm9KbIvDVnzXYuREJV//augl811RDAUNWFKkHR0h3uucZIQoJogrBSGqYx5yoZAleSSQp",
-	"W2QEQbBe+zKQTcElFs/SDtZtQnkhCL5M+RXrhPNig87drOcTK84EY1kUVzgLH2j45FXOCM0ULmFhCP2e",
-	"l2uFyq7lNsOFYe3Bo0Hl5UOHRKZUXpoclzZBxblwyRaD/Lg+ZjfXhDm+BMMwq6jcqtZBI42tbLFnXe7b",
-	"GFo15qntcD2dLESe7K0wwwsCY5HOkKGcJHtwJvfAS7c2hB1hsHuGXrqbqny153Ddja3AgjvADwMbBc0D",
-	"JEStrSDpNmm0mnSUTLA5QHARQ7dOs8forx1jaJ9dDG3rOA0Lp213v93yCJGsCcPkWnZLkyvRojn3xWU9",
-	"EamlHhDMvYQ4CEJ0AXXQ3rtZLjjPCAY9wn09UPGZDiBKRA8OyV9Y2Qpd/nRXWNZm6mf7cz1+3MRn/3Hj",
-	"Zm/UHNNfRVBUz/AFyfpIOFWX+txmgJrNwf6kOASbbRqhZltlmnI/e9FFOGon2KwewNNqMl4NDx3KE9yS",
-	"Xkp/W34Y43ueaD2M8MW1nQPoZmafvYbGp9Rq+51ECosFsZ6nNmdIpGhPmUhhJghVYfCrd0mTpVdmZIcQ",
-	"nDachf1zW26BqR80WbnL3bWKAbqiWqauuDuVzkIEZgVNzZU6AUipEhq7ub/GbL9tj/hRIw2HuVR7XQ6V",
-	"QDKINZWSzPW0u4KATzItumrXFJgNLhXQToAnN+DBHa7WYUn+bT26LfMVaqmZVVJmoQ9SzA8KtYR6MZXK",
-	"W9AtqvmONoDSFBAoWuitoJogClUvVMHK2iFCcNHsecSy55h3m2JM20uyibVp7mZk8PZQvVYQ3XN/Ao09",
-	"LqjaxNdhqpX0AD8+bDlIEHBw/7UjT2IFGaC9q8Ow1XRVZvZfTyd1j0bY0LnJ4QSXnh/DsrWqUZbv5dbe",
-	"SDNgFc5Afgi1X8DHuuLr0jZOSq9rT8N4Dcpy0Nqv5Qy1X8vpGm3N3Nc2f7y97nfWnu0ZgeytlY6x+aOt",
-	"Z7T1VC5RfVKG2XdMl9u16cCYYX29/FTX0eHn8Rw/uGJe7UM/Fzww7FEDf6IaeMVOwue4Q9MGZ+dW7Vra",
-	"4jFbl6a1U1dpBujNlogJiWX3UYyiGbkQ5oRNH64DOo7riKrrfRym3hrHd9+AYWg9RQRCWXCWbRCtXOlV",
-	"C5OZro8MBMYnrqBg5T0szQdQbvJqaSXRVjrDMI219OLfPCA4bYVY3CARbYuWC0XUaGLjit1pGpSOEcoD",
-	"cfEvOyYseYPYLh2wn5Ccl0EFQUvTHGeSNAHtUyXNDe2WWohIiMdfcg5lq/TduuKK/BWC+0yxq16vB+iR",
-	"bZvgUoPJLL2jKNq7fD1tVRKh6kSP0OJZvGDquIyTsDUUJ/uTprnu2MZJ2EwfyuzpDF0bkVgAjROHtsj7",
-	"M55LyUNxdR9zVEiCsK0xu2EJMl+gtkA7LgFugBOypjIcEdiq2FKC1+o8jUV6NMusGESHI0K86MXXf3iZ",
-	"Ts3ixiSx1U57R0O+LfsEbwlvyC9t4vBSXPrNZkJQ0/CFZAf7EsxvCkEciotZ/4pFKAGIIZ4bFlAK8L+8",
-	"/T//+evB+89vUY6pAClZ6+hYIsLWVHAG18IaC6onk2XFxgonwwrfiiJiINHSmBbCFdcCnQt8nSLKkqxI",
-	"IQKObRAWi2IFd2gh9W9SYZZikSK5JFmmiVrhrzbm0xROtoUEJFrZcnVuJolymkOdjQV4nKd60XRuomuv",
-	"iKiAQAVLIVT0Assl2kvg+iRfw26BKy4u31CxLXKKMs/xXCHT2O0vCBIFMxIxnSMKSldG5gqRVa42+gdo",
-	"VzZyxYIlWvLVoLhVvR99SW0YY/UIvleeX4i2G+c+HJGt6IrwIlJ+e4W/0lWxqsqYQ3UX/60sE2wNzNk8",
-	"uzRD5ww2y3WxuuWFH8aNofyfZnh0TZCNLkTnbM7t+BcbhE2MgdYxZujUFbSofoTg79fnbA99J78DgKSp",
-	"xw4/rcxPK8oKRcxPS/PTkhfC/JCaH1K8keeWy5a5ci/3/vXl/Dz9/t9ytUy//DlICR3b7nOpm+x5fa/0",
-	"sgdzys+6U0sq0D9uuyj8AXq+Dde8SS1Hhg1D3D+1FTF44fzu/OZEaBlf66TAjCoaMgceJ6o2DQw/pxmZ",
-	"IlkkS2DAX7EmyJmVyWfoaF6FjlAJgnxVBrz84iDAheJIi6t8DZXXSkYBce76Pu7K14imOJTh8g4x3uIV",
-	"d+t2tuwKR3AK/KvCmbffMlua/A2V9i94aw3+z3NTz9T+cEIyjiHbB5MVZ/af/czflhbK6ey/vVktxbvJ",
-	"3T8BBvuvCpTyBwuRG64GWOAC/MbuB1vS36OK4G1RJmkP1DQSPEtEgHX/CK8mIOdLFZwr85JWQFyW8oqL",
-	"NJYwYr6aqNNCLU3Rrp/Pzo5NjoTmyX6IVzlcKGvikubGePUrEWVMdXvi00uaW2XHleRf+x1CsWsqk70w",
-	"cfb+FFzKyBqBegGuB78km/6D68Z9x+aXJObz0p9uBfPx5xLOLGUD69syVZ/7L1xt4Fa1yaVSeVCd1Iz5",
-	"uDv3yVlUNAu/WhJbVU8QmXMm4VaQiosqYQxyoExKXS25YRbW+e5ZxZTFfE6/tqc6xqJ8BODzyXv7BAZf",
-	"EekVqLzAEr7O0JGC1C6jKRD0W0EgNUHgFVHgGzAX6utztq+RuK/4vrMx/y9o/J/QOARjl45bbtdWtdbt",
-	"eERcga87GWqWNb7br4xG3zL4vQ08cM5gmzhKcJYhLlCScWYeQRxi3pn6CwrdM9EqIrd6QKnJOI5uhRIF",
-	"2bbldozwjndWUrnVpUgYf7vxqn+yJ8hTOU56mCqt7FD1mHqTbj00FehhJNZ9C4Fku5WpvHxJNlPjr7IW",
-	"Ds1M4EGVj28g5VaLTPusyDITTIecc0MiKFKi5ewlZYH3VOHz++GhfN3r9kcNnYHSXRR0Buov1qtzQSRy",
-	"XhWzarlhakkUTapaQ2hVSOMY8E0tGZXK1DtdY0F5IUvnBIAhZ+jAqyKDN8azwFm2gedS+Bz9UflppsgB",
-	"dh10JijKilBwnf0C42vdmyhrnjHvIoGZCmV0ZfQyVXuBG7SMMpXSPlblPWjlRUsSAfkFKy4ICFUIrzHN",
-	"wLKFNHsztEMl4jn+rSClp/gC4ACDFbwi5J6GKdMIrMPZc2di42ABbU1L7NS0EkQJStbmLmfkq3JhMiUk",
-	"Fd4PDVZMRmjCmaRSEabMWBos6xG1RnfiUGZXWs+Q1us26dMpgsRAkCcwQxjNyZUzPZjNzaHap0GJ23rn",
-	"xjeWtnriqrHPwTrLnTSodCqMqXGQmCwwVWHaSS7CPGYGks0UFSwjUqINLww8giSElqi0oqbWdTBDxI/k",
-	"iryvvsKUUbY4UmR1qJlSmwDbbcrkjZLOZHEh9Xbrb0ByFnrYjurtd70pVjyxopnbfrfAUru3vxoScgWs",
-	"UsuauHBWTcejprpTk/pLyB1QEhUmTxmo16BXD+O2AnTHgsGRYiniK6rs239gZCWC4oz+bh6UrwEKu2vM",
-	"ZugvNqX+giRYS4FGLQV347Jgl3okXn0FFFh8QgI7NPprtR5BLOoMXTbXZBZSmnl3WomLROCZKauAGVq/",
-	"nL38O0o5wK1HqeYwtE+ZIkxvo15EKQqHKOV7IhVdQe749+YM0t+twzbhmd4/AOIQIhxKC5GeVxBgpLGx",
-	"jYkceIQo7eU4Ub3eZgppPR+g3N/dvL3t+etbJ6z6pvFVv6u0IJlr/gLP7gXvK3O+7LmS0MPySWvsgLbm",
-	"6bxAiBJjXFWWrh0D8avG5qGsjR+FH8yGd0/zndEVkQqv8v7lpVKSkR27LjpeBDtAhoclJQ+pRfZ4JTK8",
-	"18JKdVJqwcUGiqDj5rOERvmcoROC0z0tIPR8QOzGGRLu3Q0TsHRJNk6eyQonAWil0bvFuVhgpo8oPDuI",
-	"FVlwof/5F5nw3Pxq2O5fy+s4tL9hO4WvOdu2IePrFSNBWdYLqsIK8St4DxFi48zvWnhD5xAktK+nOp8g",
-	"g+TI7Ve7vyOeQpB2LP5gWluUiLpXSoF7fie9WLqqwG0VotfP8HKspV4vtbx6zrC/NszzsILqxVyXBmo/",
-	"wBqnKZQVyzOjpAgTBf2lwznf3J//ffrpIzrmgIm4bR2ILwyjkX0URzgFWcxCM2upB2CNjqbpN63NJ/YZ",
-	"lX7lSUPJKe5tlV4F+KDxzoU3H3lhzdbDN9Fz9e0W39yljObQZ3tqhqXAy9PV1zJV2yZP1M2O3gleUGWN",
-	"R8FTe9Jh1jzxzZhepsJPVPkmTlMXCkxdXoWNMeh5TF549skL1QkalsHg9bvdNIZq4HAuQ/17PaGh/EbH",
-	"9KSHT2sQjd3oeTOW3H7McHiiGQ4NnlOLB+3hMyndbX2qz/dufCqXVdstUEcSBpothmUNVPJK79QBr8vN",
-	"A/3rg91vTrqThw8yItRJEQqMbRQ4bepwy2KF2V5Za7ORGgPo02OHi0EUMePKG2ds98sO8TURXnwPXhOB",
-	"F8RUzQNXg0sJds+x6IkpW8zQOyCB185Q44cbNoIIp80Qwmk9gHBaCx+c1aMHz8/T/4gGDk4nORGJvrkW",
-	"EW22+q5RZ5ZlnC6CLhZEyCA6zZpMavia9CndXtv0U9spXKPUjejtVW0ddfvRVgqrTeZFswVfOoGy0P2i",
-	"1KKTVANHm3gzRtsYULzVOP0xlNuyMk+F6z8Pjz9Hj/Dx55D111S4jKrXkeqXzhgd6xc3VVfpNi4Xx2rY",
-	"w95GiaxmG+/vgmuLoSGCievALkVKUjuW12V3gEZIFFAT+ZPz1Jpfc3CnGiIBKcgwlcG2iIr3BgQvfzeC",
-	"5SDwKs8oWxx5xTIjrPSCqCtCWGlCga56XXfGHdGHQoIc1g76nu0Qd13z93t4mfp7GUBJF1s63bAkJFBU",
-	"X5v1OOdEgNFfceO1tx5giBkzGYCeAURxE88F/mor/4KeU77ZMKpKozFkNIZ4522oOcTredsGkWpoZxIZ",
-	"T+vDGjZs3w1LBl+zwOlH08aTNW00OEjrsOZbY8Rx+ZhFLaOkoaOjI3ivy7WYnjNVy0GpzqjClJnwvtDd",
-	"b8LtGT9nsrhw3ak+gfCcCYDSGMuEDrgRoDAWSCDnzAb7uKcQH0WcejsVOpC6YwMhhG3Vxvew6PK+GdQN",
-	"gonalZpthlqWKn51MzsR3o33dVaFcOaSQ75a0UgiqIkxgwZoieWyqr+m4SBpeOfdyD91hM+Uo3vRMaHB",
-	"+4RmDTB4ncrlTilXuaBrrMgvZHOMpcyXAksST54y343mJJfHZd/HkDNVB2hbcpNdNzo9/bl/ftN1GPE7",
-	"pmtIf8u2WJLvKFlDr77h2napGzumbFSLClJphCFZJkSNJqoKwaxcAk864czVtE05+869iYNMfLUXfNWz",
-	"smMf227F7Yzo42KGIgFUWIaNyCucLCkj0amulpvGBBoH9q44n7zDNCsEqR6YMdG2VFZh6CbF0wTIQnxt",
-	"nX1XwesH6ATAREmGhQnbciEMdrH6YKCLQmOZmEhdviZC0JQgqrY8HBXcThfgViIPfYJ0gNfofHJaJAmR",
-	"8nyixRJvpXcu6cEzKJilexb4Xof8zNZ8euPbRGt5y+G6M1uSezpSmKLJh/0Mx0GASxgnkRXVgI018kGO",
-	"tfHyy7546IsqlY0GddOUH0eIXPWt0Rs/mphGExOW+42jM8zK1Ox8u4amxujh8JtAo3oMTqPBGIfz4Oaq",
-	"0I70Utua98BotXqiVqsQU2oXOAjXCT8rH1m8WnJJyhvfnc85BAzw7cVKzPh9wKuejeyV3eSXEJ1u4We7",
-	"mFfKFVsudQuxOLf5DL+ldfOuVp98oyGWjC/X1/Bav3n+NqMJYcYgYRJpJgc5TpYEvZq9mFi9duJO1tXV",
-	"1QzD5xkXi33bV+6/Pzp8+/H07d6r2YvZUq3gIQ9FVaaH+5QThsx+og9V4dOD46PJdLJ2l8qkYPZxZVsT",
-	"ieGcTl5P/jZ7MXtpjXGAU31I99cv93GhlvtVJsUiROc/EWXKk9RC/v3qOkepXnChlqWw7fJDYbJXL164",
-	"nGliMla9p/H3/8eqpGZLt224NwtsQCMz7xe97h9e/jNwvxZg7FXlKjSOYIgaLtY4o6mt9BvExq+2gUGJ",
-	"KSMTQoVrB1h3NT3gxFI9zJLglAhXC9V0qT/bUaKjSaRfwuhtnG7ILIbVAEpevIy1oaxqtRvivJcu7AtE",
-	"7vIxo2Uk9AqN+b2WVaqZwGE12KkZzKVXNbH8BgaItpd3SYalABojQYPvW5nLPAgSmOozs++K/A5bMp0o",
-	"vJCNp0fqGwJKbpCsQYjtxGUd+foq7mzeIPp4Sc+yoZZFTREc50wpMuVJO8a+6lc3sHcGjKAHgMRZU/1C",
-	"NRt959L5v7Op19Z4lQuyhlIR9bx2fQFpSAGg6piWdR+6Dug0lKlqEt9tHIoSNFFVOjp4Vm0VApcKbBJR",
-	"qbBPZc3QG1OoF0R2siZiU5b3CAGa1cqMDILWrz/pJ+eb7SgB9UsGVOUAzqqiDZDbbnLR4+ivdUd0Xt97",
-	"8pVKZQZtVGOAAOElYa3ylhU5QSiQV+kAMBTFF11BMlaFJ9/v8bdXIb/HlztkMNGzBcppB995cfd850ec",
-	"Iu85xMfM63IugyUyTJ0KD8nIYrnF6MyLQ123kh3tR55u7n77DW4qKVWJglw/BB3GafDVLdLDoOnNVqUG",
-	"hlcPA8NBkpC8BOKft3cw2g+RBibPBMHpBrLBhAVi5Ag+R+glte7/oS+F617Ca4CFoB0F1m1Ckx8d0j0t",
-	"XHAQeFHeb7aoWZ1x7KBlPBRTeQCS0pP+cPeTfuTqHS/YjSV4ffQbxYuT3rrUCcHpzoRZ2W2qWhsiQKmt",
-	"UW9Op9NJwehvBTkyxiK4DUfSfcSkm2vtrE28ORbKPAFkjHYNQu5vFICCLLfCYuPruEUG21dy3AO8/cew",
-	"fasVp7m2guMoJ/py4jORju6dH+gJ/3X3Ex5yNs+oLejSkwEVwbsTyhbtzHVOTP/bFu3u4MIcyHdGjXXk",
-	"RCMnugtONEQT3cfuaf9OlZRtdmZgbwjbfAPcaxT3n+uhitpyzdHY/eo+MP2/nat7pPQnSOnGn+zTu3c/",
-	"2Ndjd3Cm25dkI5bI6usz9ZPb10y7neIxHL6nUlXfRnf3t+ruPkBzmtn9CMLqXqS2paFraDZdbSHpQqJL",
-	"shkKuun5DgaqQd6/uunowd/Rg3+7pAtlsIduv6md/VC3vmFgY0yBven/di+ihStUFLuLwoKueRAAYXsh",
-	"RQIVyo93YeOxg/cy6Ly8k1lH88nDiKMBOm0LqEP85hEi9gXTIZpX2eOxq1lxYn6WzsJtEnjAqR2hnBOC",
-	"0350Y0xIaCSfJ0U+Eccy+EDdyx8lDaVhGoLGw5lPeuvU82TcwtvpdfR6PCWzVfho9ne5Rpk7NH4McsHD",
-	"StX3dzJHCX5kBfemMux7LzYF5UC7Z/bxUJ6BNYkZQ3eAW0Bj97DTkxcHyxesRkfNIydz985UlM4X1tg6",
-	"L7KsfL/QpGDPuegnxf5EVOD9tC2n4ONdybPTaN0587Jr8+mtsJUU2p60mj7MqQtgt+Ma/aG9yx85coCM",
-	"p/PxnM6qFE3cFiFrFcMGWCVOXRWv0ab1jIwSXZrPYFLydKDHQE3PRRMaFZP7OzIecyZlxqMpPOF5F6Jl",
-	"SkxLEJVMd8oWzn/fOlBVSmVZtmRrmpM7UTbqLEWHpyffAIduLXUk9vsidtSm9iZlx+j+BlVUqg2PBX+1",
-	"EoqfcRxYC+VbQsIq3KHOAilBHI+RYmNhlLEwyu0VQhiDl/ows+5CKFUfU9ivM8SoXYribrSBSMmL+ws8",
-	"6lVzo1Z0ZKz38XwCoULnrFOMGxIe1ZYw+opxQ2wCwVm+HV1mTFDZWYwNxFVVeA1aMQcTmgmPZwsickHN",
-	"xVKnuZHknirJDQj46MHorOHzljjdN5FMv6Po8yAU/5AS12iteqruul2lq1qqfHcihW3YdsCEmEUwafhZ",
-	"s6QDh+iHZk11QEaj9r2yiVev7mOVueAJkRJfZOQtU1RBef+/38euHtkXUcxTHK7ZLfCpmwQbbGdQQYl9",
-	"uNN4FNafubB+EwoMS+2PjAift+w+HgCfWcM7PLt4W9+ZjmELXfnxmTpX7etGnQ7VCALfU6nKT6PfdPSb",
-	"juUonnY5Cjjso0M3xkC3FIYA7EWctu7bXUg8Zux7ds56k47mwYe21jkSbQlT+3/A/6/33VOB9qm6XaSs",
-	"5muDMYGr+ernNtlBXwbA9tzN3ppoFtY45t6Zeni993FLgY393yIPbt9qfUk84o2ejgLqKKCOgX1DeEro",
-	"Ee5RCuxgoP0v2yGRR02e2O+SvTHrvTvO65sSe876qOzZrbfIR2PeMIkiEOu0lchPCE6/HRL/OJL4MyHx",
-	"AM/vz9rD9gHPSj3EK+M6PHbaitoJxtIY9/EExhbrf4A3h6lUM+ReNBoo53KbpNrivZQlWZESELxXKyw2",
-	"9Soa0on9cx+IhiiOU1skQJ6aMULqywXnGcFsPC73yIA90+uQ8oLzIAlD28F8dn7bfPbJ1BbcSqpj0NfT",
-	"jA31TmX/QPPYtQJtH176eVCvzL2dydEBNPKA25IoY6rQjSIrtwifw4PXRjXpG5f7domO3H7XPAJCeh43",
-	"zjMlXI85CpJzSRUXdKcnxE787mHbUaPJM/Vwl3jebHFuiy6MvqdSNfA5Bj6OfuXRr3yDaq3uXI4u5U6O",
-	"tSW60GsdDjE88RvchXzhTXDPwYbNmUeF86FtQDXajUg7Q3xjHdTdEHI2Q6T22rCPXQfspvJnKU/3EeoC",
-	"PqwOajohOB1paaSlYR6lDoKyLpfHQ1FPxsHUj4ZHC/NTszA3D2p/J1Mn34cO3+JBvTsJ/X7P6qgRjAzi",
-	"9hlETfmQvBAJkRuW7GZrNf1PNyyJqiFVk2dtbK0wvdXc6jUNm1trWB/NraO5dTS33uBirE7TaHDdwrW2",
-	"mlw7WJczutaY190Idd4U9254bc49CloPb3qtUXFM/hlmfe0g9LbgM0x1qg39+O1m3QT/TC1nfaS9oB22",
-	"g66MJXakqpGq3G08zCLbQVrWSvm4aOsJ2WX7UfNoeHl6hpfmkR1im+28C6x19ts8sncpzN/3uR3Vh5Fd",
-	"3A270J+Micec50Jkk9eT/cn1l+v/HwAA///2h66zr3EBAA==",
+	"H4sIAAAAAAAC/+z9i3Mct7EojP8rKN5T5SR3ScmK7ZvoV6e+S1OSrWvJ4iEpO/eL/LmwM9hdHM4CEwBD",
+	"apPS//4rdOM1M5jZGeoZaStVsbiDZ6O70ejnv44Kua2lYMLoo4f/OtLFhm0p/PN0zYR5WZfUsMuaFfan",
+	"kulC8dpwKY4eHp0K0sBnIlfEbBihtgdZckHVjpgNNYRrwkXJaiZK+8m1e3FJ+Jau2Qm52jA3Rul6c01o",
+	"YfgN/CRFwQg3RLFaKqPJhtHKbHYLIs2GqVuuGYxXK3bDZaPjEIppIxUrT8gF28obLtbEhKmIYjfMDmdk",
+	"suzu2o4WR7WSNVOGM4AH/NyHwouzp9iDFFIYyoWfrAUNasi9Rqt7Sy7urSq+3pjCVMfQ5IQ8fk0LU+2I",
+	"FABKHI2KkjSqIttGG7JkRDNj12R2NTt6eKSN4mJ99GZxpDf0wbff9dd1+ePp8YNvvyPFhhXXutlmD6mU",
+	"t6KStGQlWSm5tRNakP2j4YqV5HbDBKyBaz99TY1hyo7///2dHq/uH//1t399982b/8itrFFVf1kvL57l",
+	"VvKWQLhhSsP43el+wQ9+yhauLQjVDrVYSZY78lXnZIgb9qv+zv95evz/2s3Hf578/j+Pf/tTBhBvFkfK",
+	"QfTo4d/DUn8LDeXyv1lh7DZO67riBbVrP0NkYipDdx7TmLL7oqSWZR9dqSo23LDCNIo9tcDEX8uS22Fo",
+	"dd5q3YNoe0pLp3Ai2kMyLmElFSnZDS+Yh6alAEaLDUnXQLgg2lDT6BO904Ztn4qVPElbLIhubCdN6Lb8",
+	"7hsiFaFq+903J+SRG16ukPJbA+uFbXm74cWGbOgNI0KaeKxmw3i7PdkxsyCqEcT4XZ0cZQ6jkNstFWUf",
+	"/lewffjYh4b9kRtNqFo3WyaMXti1VLTwbKHTM8zPDdvmj8L9QJWiOzway0/1C5FfmqDbeEwIrrC88Hst",
+	"SweyQFqGIh2wlVSWr3JNpJi5NCZufqFK9xf2WNxwJcUWqIoqTpdVBpeAIn96/H//85fTZy8fz5t6gD0H",
+	"zO1NlmUkFnjDYM0suBH8Hw0jt9xsuPCgzfMoWTVb9lw27qrtT4EtAlho5AZka7uxknBhZHsJLSj9h2Kr",
+	"o4dH/+NevNXvuSv9XsJcfolL6YOyw68AIh68e5jWj3A/n9kbZ4Bs7CeypiZQQ2OO5Y1nZMuqYcdrxZiX",
+	"LFBCQGasGqFbFNQIwyvCjWUbBWOlJlJBA8O3TDaGsNc1V0z3eaNqxDhZwzr9GgW79TdB5miQldjzJ0uq",
+	"N0QiFiBHxPW3UWdbS81IraQFoP85nYNrUlOt4bTh45NnT3/48ers6tnvp+fnz56enV49ffHz7+cXL/7P",
+	"47MrwjKklUVAB5b+zn+Ut6SSmd1u6Y4Yes2IkWTJCrllUQSzbJqUjUL89Jz7wdZy6xVtKpSvvt6e7L0R",
+	"7WnsQyypzTk1G0Tc3JVYcsUKI9XOQxQPwIoX5Qj15Iitjy81NZs8wtClllVjGLFNwtR+LQvHY6O0UyhG",
+	"DdOEryzilpJpuK7Ya64HxDtWcdG8vmAVXbKMPPXrhgGLj1MobKrbS0EMbe399xWv2O+GXD5+Zqcgdu4F",
+	"0RJF9wREBRWEFgXTmnDTPt8VrXSKbUspK0ZF74wBgnsO+VyWAw8NuK7kKl2T3lDlCJQrIpi5lep6QZ6e",
+	"n8EV/PLqEi/CmhZMJ5KFaLFVAAollSxoRZZKXrsbnJItM4oX2vIQqQxTWU4Et6gd4r8aWlbM2NvAAEqh",
+	"iFN6BIDL1Z44iNQpekpp9Ak5l6UmVDEiRbULUmo4sguGeEO0UdSw9a6PohE0Q5wtIwIswq0PLy37Kxe8",
+	"ffZyW1fMsPIu90wUYnMXtuDmbGTV8RsKa9KvBfiwYISuDFNRylkQLohUpf1XEGIGNo77fudbgldqHv7w",
+	"KUxfN8uK6w3T7esCuOqPLy6vHp69+Pnq9OnPjy8cigoia5TbyUZqQ56eE1qWimlNasVW/DWg7T1T1EQq",
+	"cq8pa6Kb1Yq/jqj/l/t/uf/wL/fnSFUdIk5wbA8pXzAtG1WwAWCcnb+E9W7Z1rKmim8d2bTJcwFUjm8z",
+	"WlW2gW0XlzEgHozwdosj1FMn0ZWlQSZWUgX5HBezgPXZvzVTQKlAmYqJ0g7sqFfXrNDkdiN1axJNVtxA",
+	"57PzlzrdafraTKSEPjXXzSDkdF84pDvSaObu5H80VBhuduHgvz751iLFt/fvb7NXDK4tP59b98wZv/36",
+	"wXNu53zwg6XFnRT+tdE+P2B517yqWJkXE8ZwbFAplS7Ucg7G4YZc7izpbak49jIYqDxoEMnsddiRHgop",
+	"VnzthBx4Z8KG+9dRyYqKqiiyWcxIsXNpt9Q/uaZeOG6v4XbgBkGEvwV2j8hIr7GVVdoQLrRhtIzrhTuZ",
+	"bKS81l1ZMwgBfUSb85ZsYbhchX3iWzHdlhuVSJGBQVOjWsdtuwsSp/PTxKsNC840uWWKEb0TBSuRNO2/",
+	"dXtJiGGlBIkKexMpUBOB72AuSE0VrSpWzXtcTnsWtliXw3edl/pVuAo6FGERlossnc6UQnNonVnhELbb",
+	"td7wkumeag4msWdgl79PM1fLcsbt6kVAuHiSK2Ri93jtwAAWpo+ooeNSsz3Bcuzt7W4CrkhJDUWexepE",
+	"lksbg/J5K2+8RjUyg1RsNqpxb0MYUq7wVreQ1XYIweybuGRB8uqK14sjpJ9LxyFmAOllu2PQTOxRSsQH",
+	"hkeMoD/PoL3RbfxTbMUU9FjuAOJ3V1tM01jsEVAuQRNp584o+R/xNdMmD44SvrW0dx0NYAaDrGwSBTHU",
+	"2D/8Zsm+OTk5+fZBeT9LORXV5oqpLRfUON32RDilvQaZ14/NlgqiGC2twmCIj2VXZjsNyAui2S4RBglP",
+	"Q5ywdAM9/R25fxqQ0jN4+XOYxbchcmkFNUt1Uo2MzoVhaxTe3dPndOCgDd/iyapGgE1n9ITdYISaBdJ9",
+	"pIOmhqG4JiVT/MYapV4KzSz/sJTRHSnoBFQDC19JtaXm6OGRpdpjO1QOVjrg80QcQQK4suMMaPzwlJNj",
+	"CLNMoi0Y+uG/jphotnbUc8VqeLIfLY4u7YD4zwuE7tHi6LFSUh0tjl6KayFvxdHi6My/PY9+6255cfT6",
+	"2I58fEOVXa+2U/TWkM7Z+5gsovctrqr3yS+z9yGuu/cp2UgbVB367mOhZQIRFdt2n7aky15zd1e0OZr9",
+	"/UyWA+KL/UoKWebV4wH3uDB/fpClohUXXG8mkFFcO66UUDMdvRWj+q4s8AL79rSO+PMiAmgPWveHzKgs",
+	"3DkTvspvGiR8gPf9BXnx4vlP8Pjxza+ZEqxyLyLCDTAz9rpgrLQciBvtHmQoAwMqRlu4BaentohxkbDC",
+	"dPPJKdl7OnK+RYZCkq/JKjrw3dYrPazgRTmEbFgFjywPB3x8gxTFNamk7uvYFEMtW480NP/nAFls6Wu+",
+	"bbbEtvCUgQsALdNyZxhYG5z+8HpBtvbPtVO6hKv+u286+vANrVZ+QNxC+8U5/xmM4twF002VIcFLNI1E",
+	"FEvV+yiWXEh7Gt/T4prwrBEGpd2Wo4UfYckK2mgWRpaCkVuqSSOinUCU5AnlFqGzmBpWaC+DsJSjxRF2",
+	"mo+rTr5Nhu1DK52n99VPnINzlBv7SCMbAyYSd6DAuqODTJtd95FxMiN1Q/r2s/jolmlN1/ulQS5wPHj+",
+	"LGVjkpmjIGt/QzYKvArANiTJOeyc9UZxSA3izVs+c7KCThg1rLB1n/02hfDSB1jfqpZaZawTANMtkTKx",
+	"KnYNE5aH9V5RxYaKdc6guWkbXieCKDXXBi7zLgEMI84Co5ca26AM9g/UgWVZEWjFnN4fNE2p+VYKBrq2",
+	"llLG6cxOyFNxbs+G1E1V6fiu0znjbBTawwrs4KB9dooCQRTzdj6z8adWthTTotqdkO+rhv0AjDZRD6aT",
+	"NTUR7LXxD+10xsVeWASTDiKHs70nW7LrDqZzKhL+nIydLgeGtQ2de11n9hRVUw7vT+9oceQgfbQ4Cnu/",
+	"M4N3GJOMPtgmTjvYJFlPGz/3SiR93p7oPIO91wTNsWW0rmsOXbvqYW+PtQYQdxBZLRWYSsA++xS9KFuK",
+	"LdKIimlNNs6QDhpIK3Clvn1tluJazuEnbSv9ZL2pW6JTC+zRW+ZdG+xW5jwPElnzLuqj1IFmFDNG/Xho",
+	"+7XVhj+0PJ+o8k2hqMMk1ESYvr3TU/uUhvWlgyqjF6Lajati+1uw/Y6RW97F7cCpMiIs95yrvmy2W6p2",
+	"g+pBsZKzhKeSGcqrYFuk2jjjYwsrjKJC80HgzVbutLcxIPtMUeVkBkpUOig/WPHpEVsrWrZem14dMpu9",
+	"t+eMcww2SSYfbJN5k7YbhOVaACjDV7TIkbb7ggy2omrt+FRqKXZ3IxfOEdR1sT/TNSOKOnynwhOTfb4u",
+	"qWZ5tw4mTF4sQgNtySm47gRSdBNmUemaDShur9muO4DzDrHIGzxRrnl0XU3auQeB89O/l516K0u+4hMe",
+	"OAFi9iXp/PinK0IH3/TpWz5M4R/zXW3Xd99ktF0dErKwdBO2dpelKTfhI+dw/zLnG59phIim+Vqwkljf",
+	"ee+xb0/Fih0BVtxs7Dtt1Sj0kG7Mhgkz+Nx0vpF7D8PO6drOemlmnf+vNqy3iT0o24G5HXaRLH4M1s+4",
+	"HiFh+9WRMUeDjv+SeV8FS1V7rGe5ntOsWq7HXmMWjpbdpjFMG9TJbaxNW+Qe9rlW/gEk4IlAvZ7sH41E",
+	"UVWTLaO6UQwc2B3xS7D7MWcJXSmmN4JpPYBZKGXxIbkC0Av9d6MV2vNPWhSsNuhYIg0jXBRVE3AFFj0d",
+	"D6F5fhGW4373DWGikCUrHTQSvSHOi5zc/nx1/hxXtB9NcdZFFxZ7jvEC2OfoGWITxNuwHs/VrJqzfXTg",
+	"VT3kZETjsD+x3SQYgd9aQahilPzh6vz51e/nL79/9vTsj34Jdk3JuHCtwPPFsTDbZgiGCyvGGVY+Hfbk",
+	"99FZXRdKH6xkaPDaHp5lLkq4rRWefLKD1oV62wCbDXsdZvbRWze0aqKUDXsqyfnZhV5Y0KIj2fnZBUTZ",
+	"RbXzK7uc+9+8OsoGtsAok/afniSo2O2ZX/5+enX1+PLqj61V5QVXvhbUNGrabKG1Q63Lpz/8fHr18uLx",
+	"3pkGqK+D4H7n6brcwWUJszGbM/CIyVBkA2Yc+zFDV43Z5AU26AYTZYBlu728eDbQy37Zt+8wcRwst7Hv",
+	"m+r66TbPauI3spFViffEqmLMoIrIB3qhXgM9M8myqa4Jh14LQg3ZSm3I1/fv378PrFMaWuU8z2CkAS8L",
+	"N42RbqbJFyuGiuV8uHAX+fncDsN0i8RnIWw1+hS75U1e1BM7fPaqHzkca4ZwpNOGnJXBB4T4RDr3/lML",
+	"ArMTqVwY3cksw8Cvm11rOBDKhfSarfItFAp+yP0EDTtehNe8W+s4bg9ZxLotQnCxaZtwWmiNKj2/YO/R",
+	"Ak6JCSy876HViDJhomu6Rp+QJQM/kgi4zlsPP+xzrImrSEba+3ZZHK0QnwYooLs3tOZg4I+faOElIfCy",
+	"jz5QAKGptNBH8L1u5w4uyRZyR392/tL7/z2XghupvIcwraoXq6OHfx9fWK7zG6sOOLNHtLIvKXbJ14KL",
+	"tY2QznqIDTa1WKaYthMSSpT7cSVVfN0VsW90HTw77V8vNf9lKNz59PzpL15Zz1ZcOBW90xuzkuBm8ei4",
+	"jqtyvregykaQnpBLpm4w1EY2FZgvbpiyOynkWvB/htGCI2BFjd0VF4YpQSsUXtACbB3GFbPjkkYkI0AT",
+	"fUKeS4WKs4dkY0ytH967t+bm5Pov+oRLe1rbRnCzu1dIYRRfNkYqfa9kN6y6p/n6OI3vvUdrfgyLFXZT",
+	"+mRb/o+A3VmdSJaf/mR5Kb6+oSUuNULMy5kXjy+vIncEqCIAY1MdYWnhwMUK9D9cx3NmoqwldzyjqDgT",
+	"huhmCXERDlssmE/IGRVCgsetixKy5ityRresOrMapPcNSQs9fWxBpvP3iKGlc7kdI7YXAKLnzFDbSztC",
+	"HesxSFreYXialnR4GOzek6kitS38PRQ26Vae5UZD8+S1EqPN22qKwaYHTvG+OcUeNdDgyUy+HIfPNiPQ",
+	"HvjWh+db9qiRa83jE8NqvHG+1pfHFa1rpghVsoFA1UYzdewF0LPLiwXZypKBu5Ug182SKcFArScBlrTm",
+	"J4mkoU9uvj4ZX8Kwfu+SFdLCM+OvAd1ZGQPE5coiIi+52QUX7WQd05xN2Wuj6JiWZU4SjVZ6CjswoQYx",
+	"KypcLHBdOLSDMAhlFsq1rJuKJrF8p+dPQYXJlIU8tPfRI3y7bYy1DebUMWpImIwqkmOvIjl//Dz++6ez",
+	"y//x9X27mhPynJpi43g4hJsEEZM7h0maIsOYnIocIT0QayEZUu8w9XP2sfdUlIhg7qnnEQL7IKvnLniw",
+	"AssJcc+73jQNz7C5l08fvf9DStagfQadzjLgdwC53QSwXQaXgdV8Yq9k9+79xLVu2hL/vHA0u+P8G/vn",
+	"5H39/uHSc6n2ckiCGfN43oB7ZcQmWlszBK3ulUxwWt1zT0KXWshvHTZpF+8cH3QG7NQwcHgVO0y/oPsP",
+	"8rjMPHW6AfsPuEWEGvphBYBPoSvLVYG95aPi3Tf0IMA3ekJjJ+Qna8gmRdJQMXIKcLNv+EdMcB9F6Vxd",
+	"E9yb9lYOqzh685vlpeCZcfTwX28mhJD7rWURI4w7vPF4puhcoeE+kYIRaskwxGYVjVIgjpiQoo5rQPSL",
+	"RPHUPnGIuQrOGMP2q15YRsk7jhw+/s+uy+GmkYQK0Ae9e4dd145wJBQr5HnooP8urnjcz8THUP3ABFMj",
+	"QSknXrA5WYeWyGja0AD7PTNwidmAXykmqqrUQHzFH5aKs9UfvdNxkCP8jF/pSfuc+FL0o/qX4TQP2dBt",
+	"2CM2rGCRQ7hFDE0Z03R2l5f45VyphoEDfaXZbE+czrhurM6vfujOz6kTTRsOyeo8JzpapP9ErhTd/hdH",
+	"p5BxhuPF0/rD0+85VRqaXkJguA1xuWGqonXNxfqSVRD0bqH8i5U8LSTs08OFoNWs8D8/byrD64q9uBUM",
+	"2j+ngq5ZeVY12jB1ekN55S7A5OZ6bOVgHOypRV3Fze4XpkCWsS3VrjYSomU4FfZSPKtkcX15zW7h+381",
+	"VFFhuMDtK77C2wEXNe2sHgslq2rLhHH3ZwLQwTt2SptwGoMtwjFZi7TmRqpd9ozs0Qx+6B1k+jEcKtgv",
+	"Bk4WvvlzRPtGcsj4Q3rU+EvvwN3Pg8eO3/OHj99yKOB69RDB/d5CB/ytgxTwW0SNK7atrRDhHpoOU5DW",
+	"tKzYD7Zv9uYMX1HmloIdy9WKrOEnI4msmUB3VNuSSBG9QjDAyn1BWZarELYKshiaSzS8BkHqPCGYhgPw",
+	"zM1ip0BfJrGuwoDeqsZHsrX5gfb6KuFE9tbxXaZftL7H97sRw1WQqm83Mu4vTr2mN2yR8WgJMb03Fjl2",
+	"2UtpqkNWB6yu28JlQ/Jhx5CzS0hI68ZU53ynQyX78sKMh/H9BQs5GQ/bzBsVPRJwTQRj5WD0kHs+zUCA",
+	"0GdOjKnrMh0FQpd3hAPGVHuy8609EYMyxUm+BWsh/PgjDdhgutdE3rDzt+E9IHkEfnLqWMA41/Gt/DIh",
+	"EoKJ0oXV23YRi/LEP3oo6YMXO/AUXgsXrLYja37DCDf+fIqglDQ92zTCwOV9GzhEwie4LCY72AfNYbti",
+	"v1FUr9L3x8d7xzGbohdgBVBlVH7QpuSGVHLtYe2Ae3J3okzP3/UYR4B9p/r/c4n4NFPaRt7IW3xnu7Ns",
+	"I61LkOg+cfO+SJs8AT720PsCrKRbmE1drb+CHnh4ekG+2uIPWy4aY++Hrzb4w0Y2SreiKZymBGkBo80X",
+	"xCRR0FdXz/afVV4X1OYwWfxvtJHbd2+5X/RCoVFH50IuADbYHrgQrCJ6QWQc56yg9Yhh/kGrdaZrl7Ko",
+	"4kU2roUazOBjx6dhaMS24FAfZnQZq2xjiKe14YGyuCaKrRqNrlAwGkvHwmhE52MSU15ZDvdC1RsqXB8M",
+	"QBMlqRi9gb98c0xRbT+dUV3QkhFaaRm6tZfIDZG3QqfBfbBI+/KC6eyLAYeZ+IIZBKgfd7BBmHCwRVjJ",
+	"Gy9K90/pkU8RkHhn1Jud5gWthh1nD3bVgwfGl+eBEV/T05Vors8dfCtydwWOZtUJFVPUsvqBOL1S8Rum",
+	"Bon0KlJkSL8BPfxfNE6Rf6wVBUSU6X3Oeo0opFKsMKwkj8/OfM4PBp2J5iHkAKe3TxesxzFRU8oH3AV5",
+	"yYThK57dUjfpLDtZn8CVcH721KeVHckUeiUNrb7fmSFXQnD4bc3ndj0r2MrP9lKzcmSy/DSNZnNnG/ZZ",
+	"BXt6O0HaHvQwbFvbz41iZ6zSfChjSNIud0xckJKtFQODLQwzMSlTY3jF/4lXIVMFEwMP56TdwPw1dp84",
+	"7w0TpVRD9Ga/TYNg7snqrMNuijHukDdcpF/hVhFQaEiK5BWP/pju2ndx9C6tXKtWUCK9iZIpVmIeVAxo",
+	"is1oYdXhFSvXIDt5OVspzkoiG0N8KFNHvCim5PtL94OmBqtDmsrFH79mxRD/6Cl4HID62ewHXKfxgEOC",
+	"9TuphmgR02kmqpy3UA75Fd1NO3RLr9kL8YxOPJdfQ/MsMrsj3q9rSU95UDuQaZSILGmFq6ANMBIQcQdo",
+	"GEjhXeLiHQ74rXQFXdkCF74PplaAGGD7tZJrxXQriC6BUzBnRSIvWzkLZ2awSlfVGTP9lI6f/p4kreru",
+	"L3f79Nv4oNB02yFngTuslPILhrksr/LsLrJXp+EHdMMsdhbpFi6RDDIQSELoNharvkFOnTXlIkn1j8nc",
+	"iFQ+9ee7xNkcN4xssH1d3C2Mxo2B0RWeoXrcIpZrHEtx/Oz058Cu5DVb+HzRMVujz07PYsCPbEzdmCT7",
+	"sy8zRQWx7D7B3axFnM2BGNJNSEM8mfsqRosNw6zXMOlUDjzKRXH56WL20f1AfJ7oYzre19rd151UfTHF",
+	"kcVKa1beNKbELKAXiJ9QRvFocRRvhMUR3L6Lo8dQeABfVPOZRJizdS5x/nbb1lrST+m60t/dGls/peuN",
+	"AC1l7VFiSNCFA0qAugLn1RY4If891YSBTdu5zyxiIrRQ29FnKXcaWTu7Jw5U+yJeJYwJIxmXNsOhbbji",
+	"Ci7IvuhmKSXuMXdFBZ8T/3YK6ZkEkbWTmwvwzLnh7Jbceq8YmMUngevzLKxBMUBHnoZgDIQRtibUZJIJ",
+	"J3sOvezmZ5j9QOEgFd+zIJzKSImA9d12szJFyBumbhU3hoknvBp65614u2Dciq9bNQiQkwIOdPAKhPWS",
+	"r1ZMAT1jRhW8f7CXdaTbeW0SjObX1Inx3OuY6ZFqVPPgGwUVRBt29oANvWYC776c3I2efzqmeINF84gY",
+	"WSbfiK1zcJhRK6gNSliHkO2CDckp4AR6ZrTuy97C9qfFaGFoH/D53WaQbeSiWINfDTDOES/ARrDXNSp4",
+	"nETSrm+a6HjSfAWZ8E/a6Kl3cLK0M+gGXqPZbGQ/J7qo7kr15KVOePzvl33w7Uy1n95KQGku0UrKGv6h",
+	"WbU6bqUtxAtDmwbZ2FCu+Dy/+jUUalg7h1CFRWApF3eUP/Cw4qbbK/CHMQ25zvzBd1ZtXfpLuY75jPtg",
+	"aR2fv1SZL5Rk4akRaMDtNrQMpY88robuC3KmKCSUvW2Dy2WulqiddLmpfbohbST4ZMWDRFGdkpoKXhBv",
+	"xITlu6lv/cbcWFS4mXytNFnXiKO1BINYKml5qICHHax3nuiUwD0ZKnMofvD2keWDci6ZMS6Bp6ucp2RF",
+	"Nq0EsE7vj17sQYGUvGc7jxi07f4o5bVNXJfh1KdpBkDdrT0IRXM2Po9iqFjlkgdjmSBrCoHDOCE/wg/w",
+	"B1ggIYcU9sS6Df8NjKNTxcRv7ivtSuh16iW569VuBczoOOK8KxUQfX/qQAQyWO2hh+7r5xZJeeKYl1XH",
+	"699KoGBo29LrtF4x0ppH+W3IMbO9Gzjc5R1CdbLlXSq5fmZNQploQ/tzi/JhvrWetZqUpoBUIbzeUEiv",
+	"5ZLl3VIl3H+QrCD94eKoZMvG/mkULTKGXnsXoGX9aqOYBkn06OEsE37S0RmnnjBTbKyTpcp6G/kvZMnM",
+	"LWOC1LJykQEUctgmFdvemyPFFJinZcS/Pv7rb69elX/6u95ufvuPYU91zFQ7Y/N+s9A7VNqqG2DvRrY4",
+	"z78PMHAfezOr2Sldbl8wVb4Zv4SHTIhWuEukv3lCWVzu84kBHO1ojcjRcJSTYXhczlDdJKBp629+mVg/",
+	"P11Tmowe3/ehyNNb1ej3ydFhrpO3qqc/sO3+/W2SJP0dsEc9r+E33rEc/mDtigWzxRBcUmvc/FeW+5LO",
+	"HLdacarZsL4XP8OVbkJ5v6Dc5ppA/IYl/SXTvHSeQrZZkjs9hLblpJaB+Z+4tJQ4Y1pzjloNsZNdl7sT",
+	"8piCyMCpjvgU3cZdL6/yVGsqvAHTRZQKaVrenC7kKlHazQgS5rqu6C4f4XpKNpaEj1eKM1FWu5aF2HPg",
+	"TadWYwacrioUeqLY72i7Nzuv2jHSlZQbdDcdQvw02e2QpwQ1oxHVs8pJ9QOrn9M6lkPuVswyjc562i2O",
+	"UFBjZXstQ2ucnHOvN092sddsdw9djSKoWpVbW1UnPbvq+FSE7Hzpnn3hu946NKYivnOK58jJ9Ts4zFap",
+	"kyEoJTZfPVDyZKhoaCKLzQNUh/X7HCwOeMM3wNn5y6cuc3c3vfJgQqzow1PJNbgD2tq7U3UhsmTVcO3j",
+	"6FEycFMOu1HY7vg98fHZf0viRkcgRGu65BU3uxyjW7GWj4or+pqzK+umBoveQ5JcVShDWskb73RfR+Z7",
+	"KU3x4hLyfcY2Ui+Ij5Yq2GVBhY4fi/ABG0nd4nLQsF0UFis0pUUFFuQR19ePRWEDs7wvMIzOwm8L8oQr",
+	"dgtvVv915X5ZkB+oWtI1O7NXcNEeYt39tLDF3SetsZYlXGJWvW6D3+Kghm/bskiErS2lkYDRW6Aj7Nwv",
+	"HUBZiaIFBGuudvs7Whz1Nni0OOpsw8ajuYXOEn0iprV30f3a2VX3c3+XuRaZXXda9aDQbZBApfspB6Vu",
+	"mz7Uui0iFCM1WoXDGagncuSIiotUpxq1FhikUO366o+Mxnlghl+90SodvZRRxwemkYWTShZYvySpZQ3m",
+	"arsYa4mcmSASCbRlX1BpmVXcetChyAVpQEjCl7777PjU7UZWjGg2YvdmxXCYu/vY43rtNUSo4PN20bny",
+	"FJF6P3/WAYHcoYywaoscY8ZWr9eaiR+LIITHuzxomTs62q6ebQy/9qyyZXzrmcbiyhdESMF8GTt33Wxd",
+	"2htuZpqcUgobUjpOMn66lhFD5pW1vKu5sDX5hOs/7CdnKvPnNIJzkdtmFeBXrm4PtiG1kqEqRnxb6oIK",
+	"4e0u2qQW+popLksrZVU7aKd7FtwXNROXZ6fn/uXkC4/boSjWy0TQ+BzhbQ8jSpyYmGSfBU1VouYNG+ij",
+	"shU1tVGMbvco4v3wdqnER/xQAzEMjG47LiQ9hVmraTLSJSsaxc2O/NDwkgUvhBeXbZk6MqN7jVb3oCbU",
+	"vdfb6p4uaH1P6/U9Z/62/z5WG1b99bjUJ6+31UneD2BI5Wgj1+TKtO1qnXNbYMmrkALML+3rB5v2xh98",
+	"g/Xk/ZFSQypm2c/Xed9Rh155NIz+Wn87O3v0xONihMzroihXv0u1PtF67Qrynziw/O5a/15wDV5X4Ke0",
+	"kQoumG1k9XwCT/fLnERWA/z8so20wJQ9JQDAO28qR1uhkFaHIJ0GIs+ux3D8agSlU0qlkcwHXX/R+W20",
+	"qneTOHtkmIkdYepTDGe7aLKeJU8f6ah2rNqaKZgk5jN/cP/+POXRXns4HJ/3BOSr4BOHvpgQX5JHf6r1",
+	"28EPRpgKwFFqa9HYECZ4hp/bjGsz7vcEgLpLvdM5QUpdYszm7/HASFL4xB2Eowk4Pp308w6JvpXpyD14",
+	"gGBSzZ31gvwsRauvq8+qId0ZNt6mRaTd8AlK9qpJu+Ql6cih3NesB2Bn55nMKJ0WnSnzjdxCEgBbaXxI",
+	"7blX8uoYJUIpaeyW1H7YFwbdnmcMISCkvL/Ugn7fiHKIANOMkGenXRVbmulzES6H6E4WXBZvMGtNiwGi",
+	"2M9EeWzkMRMlceoRVhLNtIZKl9YwTzQLhQbRacPFK/soeR+LkGUA64vzs8cu9jLLV7HmzJ4KNQEGf3vw",
+	"7bdf/9UXqkmqmoWtTtlW4tSO8VemXSLNNUTJdCDi37V5+iizqw6WtGCQ9hxBF7tk+TRbj7DbguDnpdsH",
+	"7Fa2rbH9KuQdw44FxhNe6yleGFyTZcMrFyf15On55THkNICEkzh73ulhxWv9WFjTUjk+jyuU3yHORoA4",
+	"bycEFWt+knogYP8quCgd3/IygAmbD4nZjx4/OX357IpIBdN6k41jpy8uyQbKZbQG42yC8JiCYpGAfx9G",
+	"jLzPcAluklAfKn2NpEkx/MPpNgG7f3VjsZQN2/oih50cVzEj3yJBi5BmI9Ff3QkX0UHh3MFy3kl2eJx1",
+	"gWo0swmrdv6oeUz5wVeoXprrBQwg3k8ufhH23aMa0UJepxX2epe7ENSwZdBqPfMWkRHLRcn1NZouZur0",
+	"HLWm9tEl5LZoBSDrkmbHVRJzI9BqDzDt8jiU1wg9yB90zcE+90f4nucImilOKxSfR7aOzZxh6GSo+ORI",
+	"rHJagRJX+xbVJ108bJxymDNABrk8Y2gXqd9QK2hoH5G+Mh0OG0zU7eAJ73YEf/ga9UPanaA1dDrmPY79",
+	"i9RZKbQDU0mqZvXxUKnr+qpVTrrVXBteVag9TGZK9UURBFZ05qIMZQBdPr7I46Cf0yFBlz7LmqdISRSr",
+	"HvBS4WoGdSr3O86I324HVCrbPJExKCQ7KXDOruMiaT/GZwDzRnTVM7BsRDsdQJfTQd9Fk+tWMuepWcwI",
+	"qhjBzH5MC3VFppyjAWJA7HIvDAwKq/ZHQiunNBeJX3iyFAiYmXe7rd42SMduaMu1Bq8JFROTGR8egCyk",
+	"nHvpIkZOOmtAH8VumPLJ+xARqfH2JHwzC9uElHxGoHgjuBmVScr93GwYCSj4g82BzIglAE/SL7mFwsOX",
+	"STSWDouacLlFY3pWhk4viCXwV6BrDlF2z17+dPkg1Jg3kpxV7IZrUnOhY8wdZHRrBIgS1GD5U++lTUFH",
+	"Um8U1R1LQCLQ7lBHuov++LhSXJuf3vPQ+E6ECAlIM6i5FN545aWZjMTruvoh+2zKfWjVsBhjwo/9Wn6B",
+	"jj4pz+jR+zkmne0Azz5LkqrHdPseUqls0zv+d7/pTl7uu2/7Jpsh5VQQ2ZhjuTp2iWVsvApBO7OieoOO",
+	"L7WSRRIu75GgG7KHt5cTIf5bNkrkimIGCtxn26hluaUicnL80S1lySDtaznkiTs1qTwXyPLgIgl+yVDb",
+	"MPEFSERwB6eKb3kMRF8r2dSJghKh1erQvv/tFcBeb2gzmPSj5uVeAOFE03XctvX+FJPJsH2+O17uOtVb",
+	"qHAXVHK9ZmUE7CyZY0o++gTFfZoDy+/HbyjbYgZK5ZPcu1WPJbHvLq63qBcvnv+EUWJ8lULQhY6lS7Qy",
+	"ckVRIES8iiFtvI19ZbOtQYJXiSrHIjnRbL0NyfhAmk714GE1d4w+g42mgyQ/9wPOHr/O3a/xm8/Y4VM9",
+	"tPM8oDqsY26+yqbuuUNaCcfI3Nm2M2R85c1YGdWNWg8QGVXrpqWTcjPNDBbDTgNT5Oz0fkORU1u4LRI5",
+	"Qm9YVU3xsMSpR9D8NSv25PBJmkzI4KMaTPbrjj/G7eChsiKqvPrpc/5tDgb2OeVApuQbd9irccx3l25o",
+	"/+l7P8Jhqdn7l/rJuSjkFoRLRVcrXuwTMbxTHAizYgUhGvqEPJOyxtwXbhiP7ophe+d6Ukgh0AutbQXC",
+	"vP2KEVrd0p2G1G11pyA2GCFbgrlb0zVjtcasLyHBwhAOclE3JqbTHS2o7UDlkr3Zw2gGX6UtC2kXqIs0",
+	"JUhTOZcyDil7a1pcM0NKVnDIy+tFHY5lFBwcTsiVA6yQyRAMrPioUQtEmWxxQU5hAPvJFVCaXk/cbd96",
+	"NUwrK4442HNYHUbG9pPNy7DKkkxF+Ta8eUAxWtOCDb/uatX4NLhRXgUXLTSNhN8azVwuYswoA/pC260R",
+	"jWalf2ZgUhh4mYcl2FhRv6Y4oDZS2euJa7Jqqgo6UCR14yNM/eNwC3npndWmZHUld8j2lmwnHcVIgeRi",
+	"sRryA6a3KDqUtTx1igDoxM2s5yHepwS7JagsF+JgBw4JszMmN3ALGPduqLpX8eW9ROEDgKRLecN6/MOd",
+	"kwN296ja6sW/3M9K1i5j+NHDr+/fXxxtuXB/ZVOX3lknavcIpfWGtKF/7mpDvx5wMPs2rw215/tCP4pI",
+	"sC9EpFbshstGd3Hn2hK4kUTJqnIJiGRnZSfkV8uv76d6gxQbbVfoGceNKRVaeTpa3o+pq9cisPwk6C4W",
+	"B0kXB33S3cBge846Oen7+ddVI9gv8bG/z34MGcgTruHVCz1m4RUxDehgPJ6mupsEgVwNxF70BVUMzql9",
+	"LitaaTbPqNbnriOK7wy3cIwh5Rot9tvLPtbVHUC3PfrPZPA7eVQF1jSaWPbOjCnk+Sta7PFt0s/Y9bh8",
+	"y3LVGXvhK0Bqw2okmcT9KSNfwuU3mpA4uRG78FZQWWFmXmLkBSXEwegxp7/e3dqZ3g00EZyu9R4uGGfv",
+	"ML53Mfcgx4izpmR+x+l6gnykogy293Cge0C91Q+Acvih8CNV5S1VbMy3J23T8e7ZuE9deQyzGSu2UgyI",
+	"vmWUXe5GbWh1M9GF0sVZOj4xQCGp7b+nN2Wvi6oBJnHDlWloBULXzOiO4N6QeYmu6+YciwIMX0WUuMhv",
+	"n8unYor84Yfzl3+0MHQ1BfK+BKh5GuIPkBk91Je4W1p0wcytVNeQ9GNFiyE+FGZx7QkPHfoONjNg+3Nn",
+	"+iE410qWTWF+HvQKcTHhrp3TsioXG0vbEdfujba1iD3gbLfPhcNN13LimD3NWGSumwCbzBy5y4Tq5qiN",
+	"Sp6gcsffwukRviLlYNjYRV8aiVpEu/6gdqr4ihW7osIcUpmnS7OnNnyr5pGfhHbysMmmVW8aTwsznHNz",
+	"JssMSj0OSkzn4PmaFa4CM50jR3ipaNyK/BYS1KCggp7YdvVOBhnL2Lu//Lc9n5A5GDRrkGHVqSZA1HHO",
+	"iYOB6LZXf5LzREsHbv/Ogo9efco9sEezDZdMZajocdQ6a0NFSVWJktvQkS6IUY0osLY5vl0Ad78hP/Hv",
+	"h6aWjZk2ddR8v6O5m6JgrNzn2+pwK7QeeIRkfMHguNJ5Fj1ybOH3OK/QXjnU0RSvDFOYe9juK0Pf8lo7",
+	"cAWJXvn2hIFPq6+3RYvovgU0iKtFFSbmbUG2ql8JqYLjBLzxNAvdZVE0Knk8OF61odrNDPXOrX7cLsG+",
+	"AGupzTF+I4bqa33ySsy7BxEEwFSzxvcFQipUo50GqMY1f/9warvc+mjcDb1hZMmY6FaXd7LCXCjB9tkY",
+	"lFCLPB2hsH2CUXCucKjvA1iJkjtGsnqkeg9Ig/NNxhq3vIA2HwQYedQBE8EHQZphFcxTlzpp6N3kv5Na",
+	"sWOqNV87YwcX3PBuijq8i7dgu2Bo2eDen8clKd8xs4iJWEDU40aHR9ihdtyhdtyhdlwgbE9+d6khF/re",
+	"oZacW+Nve/nGMz5sm0/bIGpV9l9yRVrfeb6E+YHq3ynVh9ukkxvXnYi/qltHMuMGCvdI5oY+MJwPz3Ds",
+	"uSK7mUf2eOT76T5vB++3iVe9TiSD5S5aFBM3+5aqaUGen5754oqY5uv8OQFdkYZgPBteekJOw6DeiQBS",
+	"7VgrhXcMkZoRzQw26LOaii5ZNS8tYq6ygR0klXrXzGhfjMWJP9q7oGhWWQTmwEbwKbyqGDPZVIdbWpwi",
+	"FPJqtBRMcuXhaVcyrMh0MAPbinWCUgXV6MymWU0VdUq4QlZS6LvqD9PT7E3cUfcBCMYUiabePr7+kepN",
+	"frK4iQ17TXxs8+WPp8cPvv3OvmuDAsYFOncQqbO+r7TFNgDP+U9P/wa5TGZlEu1cvvsoBVolDG3Ak7jl",
+	"QQ+ydnucPnKHHhMqTnlCWjETSk61ZmynoybPsT1WcweqWyZLdHXJZpSLGAIlPMJasGzvcWIu0OxoLl1M",
+	"j03uT5E5MFBvdTxrlZrkOA7qMe7nIUZRofloSbLJsmF28dkEHm7YuYDw3sneE/jcJ/pwdfcWRy8F5E6G",
+	"f7ksmDO9gzszhymyX8O82a9xMQOfkxWGnY8Jv0NC70HW/eiybnIQMyTcg2T7qUm2i3mcf5DXv6VI/EwW",
+	"NJ8T8wcm14rWG15APYeoIfOvLUF+/eGS/OUbUkipSi6oyfIHq0qkxe45M9lg2cfa8C2IbBup+D+lcNXE",
+	"oVMwUfoFcEG2MNBEA2JFDTdNzoD4zH1Jym4vSC01N/yGESFVtHqxfzS+cnV/yuAX99fUBfL4r/dzq5Fi",
+	"PbQc/ym/Hnxs+LAWvmVkyxQvORV7VvX1X1rL+vovuXUhEU9DRI8wl9hnT01Qu1Jqer6nJTNMbblgZet4",
+	"71idKxxyCuGwq2l1Qjvb6mfm83xuzxaAtaVBRPYKhnI7P5xfHi2Onp7PEhLaywpj5T7i+Lkvds6w0efc",
+	"2QSG7v7QgCh2DMx5JCbFl1t4UvH1xpAzVwsLsnSKGLXAfWgAxKPHLFVop7C/+UBTiIG2qQTBrzPNmLJk",
+	"hG/dmwsenqihdzOhT3+uzN6edFpkCd89cZ2dJpv1t1MSTuBmS9O5ALzQU5wq5nNruYyIvZSfZ6dpZ7dv",
+	"6/iumsGcWaounkN1wy0TJs2e1d/Ry4tnfrE2zVRnIxP3gcD3+by4Jo2gN5RXaA8PdtdtwBT0L3DWEs0G",
+	"qiXP30J+9QPINrSZvfwjs7BhRhHIA51oMiU2oNleH/KOQxxoUJxrRYRrq3o9wVqzBatYOcl5rLNNv7Dh",
+	"vWWdvXob3KfSCS6Jf3h+evbHVLuTVevMzC6UhudOGSvvO5HsYRgcLy4HfCISWTE66r6F/s073mNUq8eM",
+	"WL6OQZGcZNYkvgTNufakTtIWSYXGbfndNxDHrrbffXPiHxAWhsi7026YBheZNjgHWIIOqi6zYbzdHi2i",
+	"jUbiw+iBRFYv5HbJfXZY4lPIZhWF0Ld/5NJ2cSMHp8GXF88GlAgDKZuJoeuYCRqkqiQQHQc30hVDi6D7",
+	"67HGykf2rUEdjRq2rSsoaGE26cJ0d/QYwgizK1LyNdMmhmf43Gs1F5pw4x0HsRn803ZUTMvqBu8XQARQ",
+	"eXFf0hqnjYuyqlr/RsMF2zWEIp92RIg2QR4fgkdozD3kHWf8cRHMxCFQ14mr209oeJ6jxDWgERvAhE6K",
+	"zjRY5e1Wcq7kDRNjaZkDpHREokyWdgdB7xVhFWCLmGsEAadbJ+/OFtBhaw8YzgkHxxKVmbjJwHEmvf+B",
+	"QT2Cud9xCWsECPqez0+NuvAbGT6Y/2qoosJwwYZk1diCcC1B4eOqvyi55RrvzC3XS7ahNxag3j3+lPwj",
+	"dC3dr6mI6sTRtn9ITCuDZ+MD3xdk2YD0Y4+VleBjyW7b+azQCCRillSX9TPzYt4X1hwdk5I9LODqYK/p",
+	"tnaZmbkowHzlJLMaS1UP8E1Zt8qWTIjasn30vppPWKaem85iXbhoNyyrVam5F/UG+sGKUT3JR9IBcRi5",
+	"Or5Z/Uu+GADF1Sb6SWHhducnZQ8YhWPnG45+Y45ElogcvuKdCxNNfLtcULpUpc9WZPuh3rScrO6zG4ph",
+	"0l1qb+3kX8Ni1zgpe9CMAVeHB2uOxU8OMWkP5DOaWOf4t+mPrvZ3H2HEf9+57k+GTdfS8CPU+7bD/Brq",
+	"8J4pbmxsR8i/Hc0Pc3QJ7YnjRLmvcfLc12RBuc9+kblvYeEBHgPkt3YhOxPrnHofIzrKxq72sSupWSig",
+	"O5BqAYXgUAlVUcPWu8n0mRZRHPAJjYUcZmeydyPitTVsQ8DvQXvfNiaU3HbZckGNVMnBuLqYbnBPSlKw",
+	"F6ujh38fX+gPNozDdrOyFi+Zcisd7/VTs2RKMMP0JSsUM7M6PxUVF+wOs/5oTJ3rlqPo/tGlWSG7z2ZT",
+	"bM6xBHJbfEvrItPjf/5m/+/+8V+Pfz/57U//MZwIasw/FrMET8SfmEna8lbFVxMJLyaataE6saratBRV",
+	"7cSCEIrjSq9N6t9KsGKVZL3qbJOGyefIeLM4gpr508aI4ROWICZ2csoFuEq8NbD/cLUnbCnWtyGu1Hpb",
+	"Mp1uDewUXs/hsEsKNgeBt/T1MybWZnP08MG33y26CH16/P/eP/7rw1evjn8/efXq1as/3Rmtfc61/eCF",
+	"Mnt7CoKPu7dMdWuJuRFpeF64vtbqaRTllY/0sQGuOlSfHk55XhSswvoFk5My/nD+Et8YTqmTDNGNDYYq",
+	"DkGpA09OjD+JmZHWvdfPTIPzaZx/KHHj4oiWcgbHOHWt43izhYTYUwiXNfxtdHencRQokmiYaMVWQ6QY",
+	"IE27JkVEnm6BAQr+DNstEyUrMbmAYnVFC/T1khCszAyErEdFK8Zk2GoEFb9mMVW1XsSHxEoxdgxLSQoe",
+	"U660K8kLPb3hmCTwQX2VV0q6At7oAxiCXbcn5CeX3ChVbwBqhZz2IT0qoh72y6kCuzLchNPtl762l2BS",
+	"DmUgS3PSorVyrnXTC2whT7ivPJfbqGK0dHqztHr5ZMJ5CnOexSUNliqcUfQwgcbdxcpkDI9ZGbYUvkWe",
+	"iWgPT98ocFP4tUi4WI8dToJXmHBAEnMi8JSdJrWD7iIChZ5vIQTFMW6GUxD1E9Qiz4cEtVE7aY0ilaRl",
+	"933juDt45j34hmxko5BFWH0V05goeyajx2y6ucwL70ogC5AJItmk/DrtiHbQnA8Gtc/YbxJXn9l08He8",
+	"kycjeqxoczoDXqcdINn+l4xB72kR6lXiAjTd/8P29EqtH5hgQ04FV5t4rZysQ8NMcXifpt1pTdsstl2t",
+	"ZkO1z+7KyjY7sQOB6pAbcNypdG76ick3Zgjz4QDqYE6Y1rdnfug+CebqqGb7ljnXsqR3sClOHCC2ny+k",
+	"h1lRpfIj12aycu5lq0sY41zJtbdPTx0k9AmjlHO6lwOBasmN2YJrR8pJjzzlIuEiA1yMK4snnFD8b3te",
+	"Ot/bB9z4cweaQOIunTxu8GbxpTG/vn//vpcHvYOPE1KdcqxovVCYJlRbcnaN0vkyGUXG3CfcR6eoi8Mr",
+	"5gcvF8jqXak2DabZRVpsM5NwMryuvX0rae7GfXuH29neGMmJRF+TPUeHDVuJKmVjCrl1ItYSzleuAuxO",
+	"yAhcb5kKHgAAXFJK+LtWmL0cxjcbppxL75JZAdi3zpm27PJG73Adqh0HFGtZRnxFKR8ONeNMEE4OlpnL",
+	"OyH7KZ47OWClW5/rnxNp24NpH24MuSAV2ZQ5dhc/Xl2de8y2rXI06aqwg/sHFngsvQdH+2gWtkpup5Qt",
+	"eizlgTA3lV+onBuHTpHxLRyH4ktjkrcQwHPkMFr359vH0JTMUB6cOEAq8mISQuIdxtK01n63EJr+EIk9",
+	"6wWo4cEYtFYUs9R4+1DMArI4OpeWfsoXq9UdrVutVSSz9r4lC8l8bduuWp/S5WY+t3aQ+Z6xfLXErCxn",
+	"Dy1cSAaDNw4v9b2m4SX4BDWC/6NhNmoUg1V349UUE+etPJ2cJi16Sc0GCWdhLfHm6aP+mN9LaWyljhlD",
+	"FbSmS15xM1jvccWoXV+7dlLLSzl9XGD0k+6U68xrpcD9I5kfrh8UciBCM1X7UK0hHyU3YQ4I3PWrm3k/",
+	"nflps4Ho8006XiD3esKJD9w0IaV9A4HWkos1ImP+PF74RuTS+z5MPO2ub0GKnwGp+qsYZkdB8z8cL6p3",
+	"otgoKfg/cxVIk1zmXgPONIEOSemon698dXwoFeg1yKgylDpcNa5ftuRp6sPWtbW8vrxmt4Np9V6sVo4X",
+	"pM7kkGkzxFbhn+3SBj69eQzI8B9WFV3rjrIIar3aUexa0hqInaTWA9nBR/OB11JW2XyB2jh3SrkCIEPD",
+	"+M4Ahzk7q2Y3TFl7CoaYzStQ4TqNz6/I03PvvhzXc4f53owj64TiWwGd9uNvLzodMbCPYxJwaCKKOZem",
+	"BMUsLGA1LERwhcmSyCXHbLGjvcQ2jJYTo7f8LgZDi3L4H1xdkYSDGtr5S7f0SJYJUoUcPFB29K8tGHfO",
+	"mUHyks78QrQjCTunnp4OUg/EF/3sXJs73vKRy3hGEs/eOaQMeUJT02SYNQyIH3NHO1FkTxYxkt4wt+Ie",
+	"Lvm6x3GnE7z7WvO38GTkXmiEyNU6Tr86KQvvcmLwt5VUt1SFehNXZ+et4ikuStnb9owkNOAX1DfEfAcC",
+	"QsSqjdStIKrU9Odyyd6yJXn5FBHVhGXFcoQMy+TY//hSvG3rYiv7P5rvikoCC0PFqCZXV88Ie11zxfQd",
+	"YqZC+f2z066JIbWoLdCTU6fxf2GVLkxK9y3TIKIJr5ippdCuag3CwocFZ/He7Wlvtgg3FoJlOvW2Kvdn",
+	"Z7At2lHNA+ficShdzkABv1z5u5cg9ScV8HAIf0+iZRcZl2J0G45pYDXZeS0R5HdpacB+7UoXXSxvTzCl",
+	"Mh/kN/mJDRTsX1LNvvsmoN/fHnz77dd/9UlRkkQofpd3KhsVaU50M7Kmg7iorKmo1grlmo5xocv3uxG1",
+	"hp2n0Qyq69rN+6hUN/ea3rQKSPnjCm8fIMZddqPGVHuyOrtJtKE7DZNPCMmwXBvQKz3y9m7DQeEa2iBP",
+	"KX0fy0+KsHXSy7xD/M6Bty3zQxArJuxEiPngxA9PFi4qpVdRDKLOXd3Fnb9xePuY4+3zTojrraqyTSUP",
+	"uYoUYhG0vSUQmMFPZg0Cq5mxppY02SEnF73zTiiKPAEkeejV0BilhSL3V/orUMxqlOQX5Kst/rDlooFr",
+	"+KsN/gAG/9bbzUkHGLDHXhdQsgTTKUECARAU5lbHm0rYw4T7UmBMbnk2XCH3tFMONy282w7ICjHo4YBw",
+	"9IHYqv5UEEPVKTammoHcYvtTtYdB8reukgCMgbLyWETeNeqNuHCh2Pa2WSrZ2DjppkZyxyLCx26IQc1x",
+	"LvlB+gKNIOi8M3H8KNC6CvYE4pJ4vrhvXt+Ox+CXM4ImXYtxLrWdNsGjvy2qgqTeCtRy5r8FPnAje7Qx",
+	"lbIxOjx1ycaTKzc6DG+jgYJctaSivOUlvil3lvdklLE3TNnoPHkrrLvOaKEh1xZrh3QVSJqUYQyvcXOr",
+	"mujp4Jeyr55DupSyo87ycODC86KJk7uOc0+w57q0sLgeFFKzU1BfANffa7n1qx0E2mLoYPdi8ltGo0lE",
+	"waAB0TUrMBcFFG5wOlQfbvdphKQt804J/RIJwFDwBVuzEIAD9d2qyqlaqVi7zepY2dMXxFgQRd2Y1A1k",
+	"e4O7AOLV1iW+aI1jt1xTdDSS/cIQ2kPpybOnP/x4dXb17PezH09//uHxo9+fPH32+JIwccOVFOC/e0MV",
+	"x76OS5zhVE9gJiNtNCHjsMhbusuXHLpjDN/iSAo7zeTAYdv4hceY3Mnly4VcOWhbYPlgBQtmnzeei45c",
+	"DFC2gIcgVbMBF2P3fJYeGtTjcuFQWUGVDmG4RUiuWGGgArhUUPCRrCu5JC4MIWICHqhUoQfYd/x9dY+Z",
+	"4p5Yc/HaJglbnZT3/nQC/9hvtNgbEOl8jDZUD2hVavupzUgfkgu8QbEIAMbSwtXSqQIAfpFY4vhXxe1P",
+	"6NGadHE7Dze4/WoR23mtL4gvQZB6Uif9e7G6iXAhPTGWC+I5HhdrzIyRjOEvK8Jb15UlhdNbCutGE2sv",
+	"9NcH0rp8s2l6pRRENmgy3b+1JGe2Zc2z3WUeLY7aa5hle05Ot7Oe3vfuAnsNhlbcbZfbQq9Rd09dfEz8",
+	"5DIo6b62sTInRPUFqAYMrb1zbIR259dx+poiAbUkH8Sk2I9oSVZUTRQ4Yr9ndMfUwIQVfJs548ALfuBl",
+	"EXNwJGDyc4SrpU9VwC5OZtWb6jicoJOJTvPzDo4Z63vmdxAtQP1KoA5rSilYYsQzVJkkDSRMnTa3jwwu",
+	"Gh9zi2H11DGCObWI8xVqPR+e7BwKHd4qXYY72/BWyKt6jTS0mkcDRgaEmYj9MMlcxB+ZZgDl9+UFNCmP",
+	"sQ9W5//tXpTTi6ztzTSC593C42lZAVvvgr4GQozwxNzDMlfFd1tXbB+U3Li+1lqf3cacbEghMTN7vHSh",
+	"8u2Myr+zWXKYaugtmqQcCp16goWQhhSyEYaVk0vmvhOaLIdo0qUlmXJCrmm653eFw3EVixbW9E9qHzqX",
+	"78xRsZPsuwXOd+mk2Fr33ZwU+0MkToov6yv5iBp7LC8a82Ll/h3Kct3NI7E1ZTJF5ms6a7ZzWEjua8+x",
+	"8BfObocM3fabM3PTG1YSzajCB3B06feeEIUtaix0fPrrjbzFYmILojfUhenY93ejkxtDqjX1zimHjOGH",
+	"mliHmliBlVnyC3kJ3l1FKzvsGVBr3lBiv7TS/N1wdpu+o39GzfsjLITt/npxK5g6Whw9wyIziyPnfOlY",
+	"I/jedN6pp23/zReX0L0XoLSfe8Yd+aV1fm4vtfvVL737e9hK90PYWvdD3Gr3S/aJnnxug6K3wsvs8jyo",
+	"Wkc7VqvBf8/Va7DfDjUbPpX6ZDf+NGZYJuAqPxRv+KzLksGdAAk1csXm+23syRnFC5M6z+kee49ynKZb",
+	"UAEbe5r2oc21idkw9Qk59dXGYjOoOebyrm9ZRmdHK07zteUzawte8Rg0h0GZJXLwkNbXFf/CJjA804Tj",
+	"QIDI2YdETFUyDMNTl7AE/TM9+For9HlbBlO6cOVcDGLu5XaWFG2t81t6HIuQvTq6ZrtXR3Zv8M//hF28",
+	"OiIOebZAZ9lNxavlwmnL5oPaedYmY7UVcKyMheyAsrdU7MBxTd/BoX9pPS+4WH8vX+cOwH8mS/l68BA6",
+	"aBJ0QaG6QkjDB9EJAPRXR9b+u9CyMZuF3csCine8OkoqaWRhDFXv3gHOcOUK6GVxIBz7/kOXt4K95UJg",
+	"iHYu2CcVY+Ye2zI68g5/AlTfn/uJ4wZ7qAY3GMKK7aZ1exUuXP0EG/ynD8J7N1EUQageY541Kwh+XKJZ",
+	"KiQE0R2+ieDGSHHr6NF+/uaUePBMHvLYCW/obs5/mKz7rOYC7e39yhF+pLay0fLyO8gU7rGwP0ds1yxP",
+	"zeBWIGIY7LvbrRTt8391VLojJ6cXz0N3Lsjj549PXx3lcTMhzolPK9+jpyDyH4av4V/p9WCiafvNX0X2",
+	"3y/EM8tZXYqZUKFh5RJ0wck4SGmeUxDf0mubVt7oEJ/PE28qZ/dx10xMCePHqRlTfTyks9PGoHFnqAZm",
+	"P2I6VAAVZYTFsRTHz05/JjUtrtmEzPAw4cKvdvw8AM5jh4IHwXV/kbR/ULhumlk1uELKG6dEBx/fUF2i",
+	"DYGCKrUDtzan+sSxcyF8vsQEm1Uog+n9FVA7eDQr9M1QtWZm8oknc4wfqxt30d74+PEmrtlDB+yapA8K",
+	"WBChpMaIayJX4YllNuCXEOoSdUiRbpEe+6c1iwq6w2E4onPpyZBEj5VLiE/PMQrNmMA0K4oVUBnoTm7W",
+	"MWf6rXUqeksva7syUJzkIZR4mIMg5KQ3FApiaYw+I2z7IpNXMFH2Lhi++9PapT25CT91JvUgwOoYiplG",
+	"CZ8HE6rHtpL4PfGljTOGO94uGrEni2Tqf9AVjhWj19aOs2epvvYAJXF+oiEn4468Shb16shfHrkEi7qb",
+	"sOJ9rxxW52YdXxrYpvNoBp8yeVjSmU5GLNEfeLs4aTm23S4Hhb1nOSbX153UvV7epVU1IQF3rrNNhN3x",
+	"83Y+ds4xTyrvwQfKAq6vSaOzfvPDroDBNy/rFNgec4/YYOfoAwcUpYqvzAXbspLTIbm1W79CsRvmUyRB",
+	"wkfCDVlxCKDwQ0Gt9xI51AI1FCFzbowZvoC/W05pvj8kObFfp6q+042A0hh/wCHeLI4e+yjQX6z8PpLn",
+	"4KxiN1i3RxNKnr386fIBuYE+hGt8jINq7jStZF9zEXQ+Osf1ENv7M57TWC0T51pCcG83xtXq7O7ZQ7+3",
+	"3B3XVBm4L+4p59TTt2yxnfdZzU3YsuKD+steReAg2gi7AK/vxJ0vehFPjQstpWXplF9KGw+7JQeFxQlB",
+	"WGtCK8VouQvQ8w0x/Rj+6os+DcX+UJHJy39FxdrnFUjW2zqpqU88O9Y5HywuYDaK6Y2sMsrjnwNnBayB",
+	"8ooeG2L5PyMdbJOFdtJBnOzVFZl6+2DvRupt2Ec2d1qWU3YJJB/Q4tkB9ZDGmzMp8YCsKeZzJbWseLFL",
+	"qdwnyLUy789SpH++FMyvI+Q7msYBOutPB+186kzZ+dpZQfujW1AeXDnPkCl0n1K8/82hx7v1U2zlwxiZ",
+	"wWJxhtZ2dXxHpVxyAt3tTyvi0W0MsbMoOoDiYzFQj4WNS9oy4Wpr5I4NqPIY1ZNvkegcraaZiiitBNko",
+	"QnNDGKwsL+CxsOpj9+TYDy/f49J1cDVRj2PhzmOWROX3NqNrVhyDaH8Mb+kbWuXbAfofo+Q23tTU22Mv",
+	"9YzLLZkNjyw/v9jBpSULGUeRwZd2r0la8YD6ZzdqtupayRtaYfyv7TZWw+BgYz54+Xx5Xj49cvKC2DR/",
+	"x373fFrhu3oL9cY/dTSdCTmEL7n8Pv4LsQIy1k64Td5XnmVsbAwyY4L49vl4Nv81518bv3kVr+lVlPfT",
+	"WQ/ydKZpbs6+Ry5/QPzmZ091ge6rGrYtvs2NiwO0sj66n4yE23fXKU6Ru2tbyV37+2t9TurMhs3RCl9V",
+	"W2qVYiw8gJD5sxLSFrU0re1KJsfg610SKazvt2oYlp6NaYMTJbP9zSiqNwuyopX21TiX0myitvDCkYAD",
+	"goW/mzCigQ9+LRs0e7OI8+cgw3eTenDtx/XEsPCPfrwz7MK9aAONAWwT0lQFEppEinknr2yztq9Xr8nh",
+	"Nv7YHl/ZI5n0fu/1PHh/fa7eX3lZYT8HsM3wnJOGyKl7bb/SBA1z+GzOGDJ0xu5VaIUTpMnezn86u/wf",
+	"X99PU7wRzdcCU74HLM9cbO1ia1MTtb+Te/S0e3t6B4ZQuolXVXqhct15zWoSX3AAFM/U96nzLWSnHftA",
+	"AtCBhvNK0k26HKIMOIs1BeGxXWwrg0/xYx+vLA6xMkWrLBqN1p7KpUq9Ow8erSwV5IoXq/5CugbjICm1",
+	"XBOCn4dDRJ7YtYIbpP32/PSs7xbgpLEoaqXW+vgdcwBhqgCXEShIZJa7d6u17bfAJCcwjteXUbHTwbTG",
+	"bJgwfFrBod6Ap43ZdHRIDd+j+rmjjimomrrMvr2DOMHgqiaBCnbWAxfeqscJZRz7m6pPHtj2mu2G2nRP",
+	"c2Dw/lCTdjB45ukEFnpScbMb3geaQSYsf3jYMEh24aD7zsXyMvslpBz11HZ6/vSEnAFENFkqKopgeEIn",
+	"jE5WGSRBVG57gxPXZMuoQC3axuq3dRBwXe3GHlNecVaVv3BZjdWyhkaBMcQnkJ3UB9Pe0IqXC5+H2a2Z",
+	"a/KL/R0Gf0J5NSOfz5PWynIsclC3D8D30Bn3axmlVjvMBTaFTkbtrLFg3O4L7joeRhb0uikKxkoLGjsE",
+	"ZyW8RlTyiHV5BwopVhUvQIL2Id8qSW2SPCUbEWxleX2HHrjts+VZwskiUk6MvDcuvrUrCr28eBqqRIQc",
+	"iLs6TAMUEHffKPFwVfH1xhSmeggfH/4szRNrGtl/W/hT/s0T3cWAz9Kpv7GOg9HH7/12s2th9ipgqjfC",
+	"fU9L/05aHHVRGixxjjlgpugnUi15WTIBNjvcytHi6DkzG1n+LM0pplW0gWyo63j8mmtj3w1nDgXApI9P",
+	"Jyf1J1+elmxbS8NEsfuJ7S5Yo1nZ+/mpCHlXFkdu8VdSPrNi+tHi6ErK51Ts3Afb5ql7G/vaJI7VvoyY",
+	"ZrvxLZONmR2rnBxNC5bJ7xmwJl87EE6+pMBOfk7gnvyaOYLka/c0kk8J+JNfh88oaTRwXIMtWifXmqx7",
+	"iMnH/nmm43eONvmUPeV03HDgrcOIseCPX6OUGdV/Q0bdaeXMfE6CaG0cSbOQrXfRS8LQKbzxFB5Y9tLi",
+	"W1w7pvjjQhvaySXEdWiE2S5wcB3LwXR8Nm0q8v1ca8S1oHPvZfgYXMf525jreBnn/GpGfe53zjnFjt4d",
+	"qHeFQrORvGwhzotVZeT1FmInUp+EeiTjYMJJ2o/tPMzSJHLZRfkLKGQnxDe6fa/4DIVerFpxvFSDB4Zi",
+	"3snKKpZDXHKoLTWRB7ZWGQZt/RpmaP0apuu0Ddm2nnDFbmlVnRZ5AKTyycq1hdrttcFEdUrWUO9rteJF",
+	"uvVTaAMeYrKevE2/GNfX/4BjJMs9V9LIQlaDicHgq0cltzxC4xZUUzHMuweKWvwHseFloTNfYZawdFem",
+	"qI8WR01p/58X27kb88u+gmG6v74sc78+LbbtvV80VVZ4gi0F4nH77OQjbeWc5KKQW/jDwQcD8rAXNzqA",
+	"YkFc6ShR+pJCdw3u6ODbpHTEdmMLq2ZwBh2CBehDcmGxgnyXGhoOFgrQOSd+bbigzn3KO1u2UcNZXvCT",
+	"KTCbYFkH2LReJcNJv7779ts/f7vX66ybwDLB8ilADVQR6rhkNt0uGaTI2dNHF0Rh7suUWAq5ZahNj0z4",
+	"6/sn8L97f2nTDE7WopgZQXP9TJV5Vl2xXFjIE+96Hn1CnEbVW+UOxqaD68fB9UPfA0qZ5+6BXd6tiweM",
+	"+YwLE6vDZig6NkiqPddKLiu21S6LvotpMGxbV06vTmOYRZvmb6myhqDhVKJ7Bg7BEwvCtrXZWWYnpNNi",
+	"r7zGYZp+yu/vV1zTXqYY1j4KTj/aMDxdC6RJt+U7gHJQe3aKusPUN7Zl/kuOMH9NjydsjiPYxexap5KO",
+	"HY+EcNF5QfgNnsBf+Aj8+/3fssvxWqo5Z5lN/gYDue1F5eOUw7zKKsogps0yV7nye14Q97I+p4pumWHK",
+	"xfqGE63Dh1YJGGSGztPGjqIYLTb29GxUh+YQGoNDqfgDvIBCtQr2GrwKVN946Ya3DwatF+SpgGfhS8Gd",
+	"w6YrtVbazv/V0LJi9nbjxt2jHMMQ22/19tw1VdrdkJB61itvcHzhMj5iwJ+ha0yuvWa6t3xXTlKxNddG",
+	"tRzpu6AFHVYGTqAKCzu0f6UrmvpWyKBAZgH5ZvlF5dq2F5pt0V58xE49zLO7nj/w80EC++juPvEcpt9Q",
+	"B7+ez9WvB473XMmttGtNPWw7lNgYuaWGF5CcN14lXjvKQUWwlQY0YAWY8bWk16xMDIuofnAhipaMnlPR",
+	"0MrVAcIhaqldon0IYGwP6m52XGzq4NJSN/mlWmMJTDCH3fZgkQ6Xb+En6YEz7yPVb+N3qfuCl/NYdYKN",
+	"c/rBvwAimaOwIs+G0cpsdijLUeN6rKS78SW9Bu/kE3KaPUrfHa2GQE1YVoiEdL4hehDtXDE9jSiJkJ3Y",
+	"KpyeYw4VsKtKRXxenSTm+G6+PgM4bPXLSm73O77g6pxc4KGRInQL7m1fFm3oGsp14JGsUJouG0zi0Y3z",
+	"Tmvr0mtrFRkpyJg5Wh/i2DreAF7TWjQwyehTk3x6pxXaUJg1TNm1/39///r4r7+9elX+6e96u/ntP/br",
+	"5u0BJdDYz6IGoiQvJYUI2kYYXrXJKEcXPUpYkHPPeyLSR1Zja1PoVmARMrDzHnsK095SP0CbPbmVHi2O",
+	"/Iz4T2h4J04FIInDZj4mM+W/usmz0M45AORaOTTSHS4dEsRA6aveqbRosIW8fYbgTu+Si2KMcLT9nkRd",
+	"jHA4X/3Dn7kzWU2PyRh8AIcikpnJhTR+AUNl9QAM++u15pBtRjUCBLDUc6ZKySne1yZ47cSTV15f7mlm",
+	"+rImBafmGIMVVN1KR3Psp/x/Bk46rLJsQy/aIJDK/x1Ifpz3ddc5Fj/rt3rDbVOn2ulmeRGGDZV6vubF",
+	"NeRRtMvka8HxelJ0vWXCDJenhnfUnnsKWW47SV0BFa8g7DJmaFcM/HloFbzA0gVMQ4xVXvOfv9LTKZxT",
+	"qTPqrwbqLftFjCNeehB4lfYvNlhnGHARjqcH2MHjdqk08rwXP+JLK82vI2itN9J0U77IW+Hy3w9p8uYk",
+	"CZpStah/PL1kOGM5gmqmWr8NZ+Fxo70QVxA78GJArZuZ3tX8bNUDCchjgxE8X/C1CbWXG7gi6YsPkmly",
+	"o0nhfLt7HMUFrJWQCNSVAfTr4B0uFGcTMixsarEoHPNXbjbo+gJJWaZCBJ4KkBnR3lA75mPnS7fnwe1J",
+	"NeBNM3HZ+IZwSbLyq02SF9GhRwSbp9VAGopOQ10Nx2xegwgNnGbNBMOkqkOsJrTYe/Xmhp1xlU5NoPWO",
+	"qNpSrptzjGyNo9anw8XGAlHG2qDLXQR5QqGzCBBldY+v8TlXc+GYpNMKd7TmKN4iu9JGMbo9canrY4Wg",
+	"hCnoIap2RYVgdIXpXKA6SbgocdN5VpO3TsyVeDz8PLiu2gMMl/saJdE+LoRrp5X0bAKi2VySlaTlY6tE",
+	"G5hONuZYro63bCvVjlzzqkLtQ6Go3rB2FttutlKoAfTgG3zDAsr6GdGLz/+lCV2tWBHUlpDt0A8KGZju",
+	"wnJ+be9un83PyxIpx+icR+4aHLwNujyhy3/3SCRDIdq9JkkKY9pWEwEXJ0mHvjyS9wXKJ7nM4qucxFrS",
+	"ouB7BhzKY7m36NRtL9GlBYZN1zWJX0+IQUy7ZM2Orlh599w9jPac+K97qHGwKVZHQ1Jk+AuoyOxkEfTI",
+	"Zz3JtU/CdRhFlXIeU+rEtcV1pYQ/kU+xEf4UZ36rKaaYnhENAOZ4/JGD5tfmba1gYE1O55ap9sEsiNOU",
+	"1UoWTOO15ffTGM0x+MGOo/c/ecOiFt4QXgYG5kC5BxU1+rpcGqmyxD3YlGgj/aXssyK2tdMu2awyfEUL",
+	"Q7Tr18nA0BNv27hYK7bir4c8S+w3P6DNO+//7Ra0SMKhYLng5Og+3nvV3L//5wIHgX8z/AWWjz+4NoZv",
+	"8Rs7+W8txcQctSnoRiwXSQswmJZNxbw+LQvZTuJGGyrDllgHWioiW4c0mtFRdo9+4nXbwRnLY9268+dU",
+	"KCkIe10rplN1kIVqij+Ep2I+aMAEeXl1dkIeYyHwFb8JYW9/QPX5AiSOBSkp+KxspTCbBf4HZBf3+y1j",
+	"139M4mv/t+1V7Rbkf5eUw39ti2oHff43dB9IvuxBPXyVRr7kT6W9xfMXl1dsbnq5Dt0HeA9TN9qXTpcj",
+	"Ko+kib1Zg5Mu/j7qp4QZVMd92oMFy2WO8aU2MWWm7e9NQUDKN1w2elx3OJB2ZRQC31NTbEbV7v2G6TXb",
+	"ujaXtg3+00MplN/w5Wz7wEJRbbKMjxvGqZB/4QAWVjEAkb0uGCtb9UaBotzJpQcZ01dmNAVccL3Z82hO",
+	"tSut5W1oGY5VqiREb9pTmovxKuSTgEOTcvj5PdYMkrm+xRxwjQtpwmZ3Q+mmxwrGpjoIHN21nqN8KPDY",
+	"32IzqhFZNX1uQ73yDQjJ1tGlq/JPn72MacDs6Ae170O0IQUuohhZshiEWy7I9zbrqM/eHkpv9IiFijJD",
+	"Dh2jBIV0wFaHT3kFKshz2njPCWCR9t+x1LIbCywU2FAqZKJ2ZbbTWbDso2NBn4acnZyp6EWT2DUTUBwt",
+	"jtxerWmTulBFtyqIT3VTzTF3pgfRnqv3OU7e7+lX0/sSl9f7lKw3gxb7GDW28f4vnu12md4cM6mrlz0Y",
+	"1LDEeIyBOtj4sTO/FV6KqilR0YFlrcCgw0tgJGg82rG5+o7+pZZRtC5d/uuLPZUiPKyix6cHpruWBXtt",
+	"cIML0jXrOqRYJKHwmELb8Zpg4U9maqO+zpFlyWqo1CLFoh1nH9fSGXtJRXnLS7Mhy6Zce68RrDiBLQop",
+	"UDNX7EjFt3z/DRk5LnZEiO9jupHTIqdy+V8AHeDA7Y/UkK+Tmebexemyo/OQM5Y6BjTHXI1y19Vb23jj",
+	"Xp2TTJpxPWXiqegXEvb5HfGwB1TfYuPZpdlzwBpa+Pw7eLItvXXXvRNLehev7m4V72D2IjC5FLKDt/jI",
+	"e3YkQ1kwM4xmJQsODzP9FVz0jXsyz3nP+q5Ta449a1UPTA6270XwvmtKZ3PkD8QHDWDGyCmP3cZ3SUjm",
+	"9Beuq/VCNoo6/zJaGOu7GtJ40RATZLfDaVVBYFDrPQYtooW1kMLQwifXiim2Y44WKyfClZIz2M/MMRae",
+	"pO8gr1ivnNH+kw+trW7eBcv/gOqTQd7isfFkHRq2doPJE8CYc4OJP6Im36cKI1fxD8jz6zSZDureBAYj",
+	"ec9l9o+GVjo3/USl7V15QhCR3C0wl21nYvP2ZEKDI+AF2ocCm+T2JLZcUMddfKAVVDB/eIRKUK9q7uGl",
+	"/5bTE3m7zt70bX4Q12Vk7ZYr+pXnjduQpbe70CmKdT+032qjBvIM/KGWWvMllNzZSsP+mIZIvbx4tvfe",
+	"syO7Ntmtclc5AXyPSjazplL/lG1FpTY81txcsFXmSrDKpfMQjwfu+0cPj+4dLXIVNox0KnIsaevyzQ3G",
+	"9/U+RLDtFzdi2ySURJJGs+CevROFC0l/JfJFbuzVfsHQn2s/Yqo0mKrTeTFU96kzhgN0vj7Uj1Jexwwc",
+	"UjB3uu0zYa9Z0RifR2zs5ON4j0Of7CWcDPlbHzmcwWj6bJfYITuVH+y3NzlUz624j5VM3PxClc5mx5M1",
+	"soAQe/bT4//7n7+cPnv5mNSUY4lgzYxFEiZuuJICbt0bqjiF7BH+qRZhMq+2imrEUIXd7ZZiQaalH56V",
+	"6eObih2hat1sQURpQLOkDRUlVSXRG1ZVFqkNfW0vNq5dph3d1Gh42TaV4XUVZtKk5jU8Xtag6UZPmRXW",
+	"CQZVjl8EaUTJFCiN9YYcFyCdsNcDjxkqyqV8PQMdXAdnmXzE1b4abFwkD7J4EJgEd8lALQguuSF6qGIr",
+	"4yOyDbYLjewgjWZKk43cJtPsf5DYs5yKpvOYcgIdz5HnUnKXZ1zGc+lIhJYnC+ZzpVCRghQzbSQPYGEB",
+	"h752xCgqNEbWpUbjNJMkZmLZ8KoMZmK5ihV4UASDXlwTbWRdtyIm2lYAXAwBL9mccquom/9qpKHnTBVM",
+	"mEEXj7Pzl/FR7Qa1Enyj0V2MkjqMkJr47L+lgP53qCyP3kjP6eshgXaLMdPdJVlYL3cQX+Nr4Qcu9tOC",
+	"PF+QH4hU5IroZrXirxGkbgiuwfsJs0Ny40wteAGC+igXpPP3+8d//e1Pf//p+Q9Xv/0//zHg71K+ENXO",
+	"Xus5PrvUsmoMBuTrdEuFc4chy8bAO+dWcTOTg1pazYPQfklnA0ylnfKsvkpfumt6/M/ff7P/f//4r78f",
+	"//an/5hmFu9Qae8icug7cN6Y9IeUPmKdukirlWxtwsigHDshr8TVhsUuLl55mfpBIv5KzQ2HOuaAf+SV",
+	"SCO5qHfP5/YJixcEK+OPoN56+Eocd2O+4Kd21Bf8lMZ9wQ8l/lDSnX4lRgLByt/mwzoRH96GobbPym57",
+	"tggDcfE9cd3+uE+CSwfo4c00B7cWz5XplRiRIWSj0+FyrJmyjIuVTkqIOIS3KS1MaxoYfsWrJFO3q4Z/",
+	"Et7RT1exrowLYqpl3VTUKzDgi18BbYwk9h0pbzDiwt/CdhbgGXmvvbCXPGzcrosAmGTzRvp9+yR8EUZA",
+	"BSkH8marx8Klq3zEtfvXpaHKwH9ljSlU3Q8XzDkvPaJsK4X7c5oNy+FCmM79nczqMN5P7v+UdfwrLiX8",
+	"4Fbkh2stLMNX/82EL+e3mGBFVhQzpo6ZPmeoAAp6UuTcQr6nmn33DfFZ/5WUhpyd5vB1w2jJ1NtUffgR",
+	"Rwh12YMbeFpFvv3aXThujckR2OvaeSinEVZcuLJFGz++ldROXfpb1GVZIYIrl7TCXduQZEHmA7a47uRf",
+	"oZr8619wtED7b94s7N811fpWqpK8eQOW5X/9ixh5zQR58yYXCeCbD6X7cYPZLdPGbBBAkH0aRFMIkkrk",
+	"tDBc7tlyzWvMKfELU6F6dH/iy2teO0WOAzO5STvkcmWbSk9Cpqtnl6RgyhCXm2HSwu3g12w3fXDbeOrY",
+	"9myGypjbY3sXkPc4MizT2a/7ppoiQgRe8P40ZRtj6qyqzN5t55MyV9mW1pyoWCtpunsgqRipYBviXdfx",
+	"eX9lc1aHju0QlBuA5JYajGZIJ448Poze7Zq4n3JxktebTQuUdIdhmDA+TvKDa/j0hj749rv8VBv2OpDO",
+	"5Y+nxw++/Y4UG1Zc62bby2dvBSDNzCKBOWApslnfzRuNLT6ycgB6+IjL1ShW4R388uIZBuRh9s3oy7Ok",
+	"Gr5CDuqCCqc8YuQfDYPa9S4zlPai3MNX4p5FgXtG3vMZc/4faPyf0Di3xjG1Z8DyvZpOTygDgnIPO7KH",
+	"hKjWPQ6nxQAW0b6UEBkegk+FWFeO1v6QJJ/5I8ZgEkOVR/pFeG5XO7L+J6/hPabATrRIiRYvaiMVw7P1",
+	"cqT9drQ4csNNFAp7EHiCo/R+P/XDOrDd0eSxaQlKEyjXtpwYgzDZVAJHBtgtSQEphhUpKikYcdlAJhtK",
+	"FumGcoIhRLc8gmi+rKIYY4DQP9bHr4Ih0PvguUjAUM1I8JX9m5sYUIiO0W04lwNTXg0P6f6GFcVHGDKv",
+	"h9+svqUnJyfkpdDM+IDhGJFgn3ZChjXBV0hwlx1TirBlzG/nEjV0JMjs84wPh1TBJwLxCiummCgSU2zN",
+	"iv2yPh8MRYJjvFwO5fLRcmVuwd+SY8LqLTVQqEo7JoFLs8+R5c5dn3pBCllVwKTjI8UHf+hW/j9CjaHg",
+	"M+cEYxjvK+2OMmeadyPvdfbxZ1hJaT1Dmxp+vfz+xfPW4U139omEOWiAcN97E0xyC7CncOZ/HnENmBBv",
+	"kIvD7y9mr6bwLWntLdJAWFhEseZupIFAAArJU9xNUwmm6JJXPFuBASfAQjmcqQDdTr+IVyHWyPODs18e",
+	"Hz+4/+Cb4z/f/+s3J8SqfMnZDjjyo79BHx+D1B30LSJCEFrh9BYtmhnlAfmkk63P7cST4dMh+eRHTz4J",
+	"2DSZ2US+f8g/+Znmn3wKaX3f94MdkwcPC8tGNWzfW8aNkX/KPNW6YeXZWF3RXhMgflWC7TT5lUO7TMHL",
+	"Xr6erRQ/D6pU8HvbltAghrs/9xUxLQfi/Ltv9EexnGe6D+vf7fZipBddIXo4lqhN2ofgV/uiXTIr+SoE",
+	"g2Y3TNEqDXforVVIc7oyaDKcJigJab4Hv+/pXeStGDJKJpxkEAoQTU01pOu+ZwGY3QmWWP0ZfPT3Ky06",
+	"BVmnHWyjJ8TP9tD1JfTqUkVruYsUK/08KaiTg8oyg+6cA3d9rlnnzu82Odz9H/3uLzqnMU0E6DHWgyjw",
+	"uYoCeY6TiQZzlQXatyZptMu2lVTTTttokhREZuk15M9nkfrg7+sZcx6nUZBxVLvtMNpEfWAeBI/TMfNN",
+	"niczvVkc/dQsmRLMMH3JCsXM+5OsNIy/3294qh84ftA1LSZ4iTvrcOyxSCbdq5yOS8/LdBA1k6+wFj4R",
+	"6rODg+KYas3XAi4i24IYGbQcVmsPxQEpgfQinYxUXDmPBqD8w2V1qFN1qFPlI9csoWX9yO9adiqMmpcv",
+	"W5/bcmX4dJAnP7o8iSxW+cOYJE5Gnn4QIz9TMbLNMoaJ235OMhT6zDWFCbc316Rkit/4fPTgcx0+Kaiy",
+	"i59iNo8QIQ4jgZskqaRYMxVvfKmSX3110X4Wnom1kWEe0TImQCAgOok5L04nXDy1skV6snYtyacNVaW1",
+	"pJ2s6+YccdYZBNAqhiEisYNX1ljRO6tqAGj9xAY8Pa5ZSGsS5CUUoYYH+8VSX344JMyBAVvu4abb2m4v",
+	"OyccD8yZq5/qHEJMihdQtgYnxaQBMe+g1O68NtEMazZMM89u725P8UWuA8AHSeMyCRrvXFJkS2u7pmu2",
+	"WyB4XLiUfXFRxcjpz48so3ls3Tzviaaq3LZ9ILpGdCZCmo1Lb9R5E9jPsIx5LpPjknw6anbfnslk7xL7",
+	"JWEEnsngrvVOmA0zvAisXWOSOhvEncZtWQkB88/aMDLZ6BBIDsvQttSOHwK4vx0AkcVhwr+ieLQgfmFv",
+	"soHfhoscEfgvMD5m0fPOAhA1Yf+mPqMIsoyoOQTEI4qZRgmfE4iL0j2AW+X8mAIM3krFwIuRhLL/yCMR",
+	"dywt1PQfDQuChuMUlihAJxrKG7mbzZNmcglSDIZnJd6TIIcZaZepOLtJMq24Qr9hJRHuZwgVn2deaK4N",
+	"EwbHssty96iL4GWpfwVTHeciu+9iQ8Ua+TiAAGOgyIrd+nAJPNyaao0e+FFB7KVAoNcAbbw2MNjPu9ni",
+	"SSIovds12nkLWrWZGBdJOhvvIGVLm1RMa7KTDa5HsYLxAErn2wm3lyBMKbsdrGYykP52S7k11D81bHtm",
+	"n9l9BOy38WmPIp7pZqntcQvjUM6tHo4jpkizh4LU5d/I/vhbDnmhp0chCznKLUyRNUnlYB141AIj3Nqr",
+	"Civ3i7KXHVRaDL5AOIw/CvB3x+IxtoHccmNYScoGZERUiwc/63ShcLoY6kP+wDBT5JIVFILAfAEaUmwa",
+	"AQWNZPwKIHDwhJwH0OiPcT+KOdAhXnb3hBvh+m124uVXWZU++u/m65OvvyWlhHXbUeIciPtcGCbsMTY6",
+	"cezMYcqfmDZ8C6nx/gTNNP+nc1Zy/gGwiDOQi8MDyM6rGDDSobEx3hZ4hArBt+7O35vOIedm/BwC+S4c",
+	"VT+Xghs5U72W6wyKp+SZ3KOw+I3w7l1lfelqpoC/lfn7CunL0ZWGHo5PugANaFsols10QytOdU4QetIo",
+	"wGP070lEUScfYgXe5c4Jk14iAq7kBm3lvgUkUrJZb3wKfGxka/DT8tjemvOchOCxFOOK7hiqERvDEvsm",
+	"2h6aACRdpRdt6Laebm0sWcXu2pXruqK7vHHYVWY+XinORFntcvnUM8fkxsQjvsthDRXAyOtLCN4RReDR",
+	"rfc2jXFg/cwwJdNchYog5DzEqPnzgudLZ3UTcrpU88XW9qaeo3SNnzH/M8qLEH6Dvt7xPUWMJFKtqdXH",
+	"QLuCGra2wTuM/EEXssZf8Vr7YxB3cliYD7xIz921nW70Pk1VHdTYSg/aq67wd0iH/OooWLtfHTlP7gHp",
+	"oiUfDaR1AGnSwQ+mDY5vOhHZvtKJqivmT4watGmRJC/qQfQMn0AxJEIUr12SoddMu5qC3sUOUMfd5iWz",
+	"uheuXf0oa3JKnFKT+E7nGuoj8dH1v7heK6gwT56a8MTwWbddzUJMiWiRQ2HVfKzQhcIFsBVWZpj7QX15",
+	"sDB8eRaGQMohqd6kwhuxWz7r1l1NE2HcJ5jXN8d5nWxlScq39lmEXbGpbk6/PTUqc8Psq4bylvVZ8pZc",
+	"v9JRyOSNNq3PbaNN+HQw2nx0o41sncUkm028hw82m8/UZtNmwlmuQouUqHz7GKjlywP6moUI8atOMl9M",
+	"bQ36Yo8DbQ12NODEOSDnQoZ3VOPq9CsQ4tywnZiX9gRxxUnkFzfsP7WRih1/fUJOhcutEAZ0dqNYa33Y",
+	"ZPI2b5Zg8rLE9H1TXYOaPQUOM9orXvoeuvGI0VwWVf9Za5BuWW+GpsShEgJM5p2uEpgS0xjwMltUy8Ug",
+	"ttFgHL3zVQSuXNpZ1kXvE3KOFQySuub+XYBYCQVFL1wEVUh3HhEqhY9TFF766gcL8gSve0zikdz98UkC",
+	"kbJnVBSs8jm7OJYRKNyPrRoAod6CW5LNTJIUW8D5bAUA13miX1wbgHGW9u9xzvbv6QraX8J62j/H1XUP",
+	"r9FDpZ36r67uWb5lnZUhQe1ksKJqo/YVUx0acwGRiDKxKnx9//78C9vLsLnaqiO543/NsOCIs26VUvWw",
+	"8C0rtnvFWKjk18kH7qLP3dG21jc34fuvA5cMK3005DtO797hQHepzDJwIMMoCAUq7zZ6eiGiEcZzHq5T",
+	"CO0p/+Izx+NKJtd9aXP9GdLISXJdwb2I3ABvK3eltQWBhYueeZQohZyKRrfbtYJjFHP5OcIVaBTVm3hL",
+	"7EDaqRu1bjPosD6bxKo381xubOGTjtj6kBv+jS0HY4rNBUrFFraBo8xIYyDr/KlEBIqpwNLd09KevGJ1",
+	"hc7CeDT9XSf5ibss4v9cvviZnEt4lQ1nMbvZ56RiJKFliXWzYTUnPeSFvF8DCYX7/DQtSX+2oVXFxDpf",
+	"r6jfzFWRD6H/DtfAN8Hi1tX5c7KRVeklZiZKqTR6yTsXnuD/hApZswNMtVRyk7UkxcmGitS00hola/vD",
+	"1fnzB9///vTR7y++/z+Pz67+SJZgXHK3FTWGafcAt24siUJ1S63Qb+P27Yb82vLxZJ0a/cNB/Ehxfa0t",
+	"LQpWG6+VLhSLYXdhLzN4PIwwCVSasdID6fHPZxf/9/zq8aPfLx+fXTy++mN/DQvbUe3qpLZd53j3K2+S",
+	"wwxL7YEwh8QpNk7LAENJnfQhK0XXdp0pkV/z4tpnyeNrATxlImPrr+enZLD+16dh+M5mEv42TH+uEQn1",
+	"KCzwvQdeJCTYtcNdZ/RAF4EQhrLLVTzc0uK0LBXTekgIfH56RqhvEutPGZsiENVOK5pU/3JLmPfI2h/N",
+	"mI1gTObqT1FvTx3tlmfz2IgjxD4zUKyXprKFZ4VnlZ6f2K+pV5Q/H8sm9UQqwq1EfjXoJtnZRd0sK14Q",
+	"qhgFQr/6/fzl98+env0xqSzbAaTbZmRT+d1ZzPLcdGjBj+O+Zi44c230lrggjx5fhI5ckPOfnv6N+Ht8",
+	"gtEuJbCrfGK7XhO8/5aMKqaSlHctGFVctKUwuB3RUSIXSgYjeOH2v6V3w9GubgnacwV6rlDnSlby1Q6e",
+	"3t4pDvWS2duTTskug3uBZxL2mH7jtEYdjMAzHnztR0AcJkJGnzP1o2zUvgdBDpZxKgt5B/SaYSribJLu",
+	"/mvE5ROfCDPXeuHSruGxc7TPR9/t4eOfDmjAh/2GFGjWUiEG9h8ShuLSLbJlz4JnnnovBbcm9aeP/Dww",
+	"xvtRJaLuD52wxJStLMiSaV4ynaoEUQEavTm6hXYTEXk4r6W7CFKSXyBFtz0yWzie0NCeRFbwvoRzzVLA",
+	"IiHgFDF/m8LPvPWrzRHCjTxJQdMbdH+6nkHVfW+sRPbplumfy1KiNrx3Z1l+7BxmuflorKcVVOBUZP2C",
+	"AZgD/ujh1/fv37+/r4DAvx+ZmYzw9aO8BSbZPlKo0RXyYNAkxb075v/14P6mDdT/BdnlJ17+F6yiO1a6",
+	"SnNBXdu5PMFAN5qqxaLF+ePnx+H5WfFORHjLx1EKwYrkAaXsMgDIOX8eTCTMtN1vYDLtoWDnA2j9lnZ3",
+	"JOvBhGlLWQbBzDkX+VrdcZWaCdPa68KTqG+AsS+87V673Jn845avBTVZXwfg1f5zfl0OPxOBMnNaPahM",
+	"WtQECau94f7qqJkqCeQdIvrIGg8whVyy4N+mEcZAgdihlsnLBnfoShoaspVoHrifBDqBb6ijgxB10DXX",
+	"YdAJWjozKTTH8giGJaTDZdCS0WITud1UMoYOEP4Cq3Mwnmz+yHGhffer323+8DAzaVJAs8fUaDGl2CCO",
+	"4KqLWa/oNRNmYifb1LtIgXq+GCvTkLZoU58Lg0P60LFIUND5++PiyjUi2ihq2Ho3+QRO4+x+yV3FhO1V",
+	"cSoKNm3/Z6G9H7EIeXQzSTiFltXkkbEx9oPYFZW5tsDef44FVtqS3v5AwR5KlYqvJh78I9vU79npCiej",
+	"2uPQ3o+w4ord0qqa1v+Ja+17r6la0jU7C7Eg04b5odvNj7eR8lpPG8NWdQmFd3nMKzxAAC8ufdrdkGm0",
+	"VROrW+w/HD22DURTyzL8W9esWER2hqkzNZp7knTEUaoILsSOgxBu5uVexC3m6GfL19E5ej/0nofm1qV8",
+	"IshfXHp4/6Ohigrjcvjt7/lfsT0wWtx+4ug36H+eK3Rl94wxYj58EyN22qGBesYF0Qr8yYHXHvWgX+Iv",
+	"0acdThmGDRgC+p544mJBBFtLw6lJJX9XmO2SGcPFGhzmlSwbl5m2ogZLXmhg4D4ez4+aN3fHCpHvkXMZ",
+	"Z47ZjwPW6hC4fyMEm8hzrrBtPwN+G4nyN3WSAj0jWsWvPozPDtGvdZBYM9bcuDTnWTPlxUgthfgtLXpN",
+	"yQ/cJHNBJnyXR987Mh68ZA+xAYfYACxpgFQyLzgg6fduowPiwNHypccoP2nmPQ2QPIHepSKXlz92Itxd",
+	"aQA/AmpqbjfSBvc/tjGzMWNBrL4ABKf1xv0FUaQYcNt/2d21CEUYfl+3y9BwQEvk95aPJGh/b4cShG/8",
+	"kAHq4wcTqM5pTJS+wpV5iCf4TOMJOoy7VUh9QsbLUF1nb0nmtBTPvsaXehPb7ln1gKtxt0Uq2HGBakbw",
+	"n1zKxqTqyA5TPyGuv0VBo6hzLaeFaWiVuKKnXXJ5aNBAkVnoWaMUoK1J1K/twSYR65mfI6vDiZfbhTRj",
+	"FnHIlhGUtr26hunSsCi6wvGmm3HtDKdFwbSeuorUU0xrVnbXAc6qWq+aqtrNW8eZLUU2dxmGacNKt5p+",
+	"yck7qrETHMljPLLK04op41OtzrAaTnHZpnbsfIL7Zii++1ETw7tjXfCb4AkA494wZbU6kOWcAKd3mYqW",
+	"bCWVm9g6KhP0FnvoTY1pkeZO6eVFt/Dyol12edEqutypcP3qVfk/B8stL47qPQXT2+XQcVvo4KT4eo0l",
+	"RPvgxD0dga/XDVPc7KaqP+DQL12nbIBNGDE5q9Y+2rbPvRjWmiypAfwrVS5w5ExxyK9kEy2LlZzoNzg4",
+	"SRx4sEky42AbXEqym0esZqJkohisCBSzv9Dwb1JCNwjX8nrH2A4/QpIC4RSFXUqcPmk6dDJt26CPPi3y",
+	"VmBKD2cfkKrNesBU6tqG+knzlW0BZLt81Sr8avburAWmdJvtvYWgw7hL7bcGv8RiULj7O1yO07aWl2gh",
+	"4I6LMl6A0dnsjjbnkSE6dO1EOWfjbOFV6yjGCDrZ9FhykpAZM86Bb6l9PnafAtimFl7sQiTLTNtAh4Gn",
+	"wTevzbQeHjkGYskCzK4A4BPy4lYwpTe8JltGBaa9DKfjssYwbLwgF56+c40j8ccu9nzBSusqDHqOHmYF",
+	"tur6DWhQcfjHr6HGcEbkTr8nZnBHxZ6Rwt2vjjUvY2GfTtBvki/WbuxJxdcbA+kJlawIF9pQgfVtHXp+",
+	"GRqGHN4X9PtGlNWwbw5ZwncP4rNTPWDiD6fAXrv0u2k8uHPc6PrqxLPwoVR863pzYSTqt4xqdF6wTKfP",
+	"76C1wFAlKbvOd1sqJXGrmDToY7catI3kRkQ6mDzgE9v8s9e8DFR2sjAcpN+rSKweZwdZRIvxwos1oI3d",
+	"TP4uYdvaUqlbwABm+lbR+telHzxwyK3AlVcwuJgUTyaLSWSENjX3xHLz6pbHsyZ0TdGfeRJ2XbX3uNfv",
+	"Jqdh6pxTwowCsmeAGUmrQ/9jlyxS16DHbCStPaVUHayMdKeUOtHcITlCB0huGWMbebrFjdjo6P4+uvxw",
+	"QrK9hE9NaB0BNaFxjg6m5ADNgORd4YH3BLAnze1Jb7mg7octrWt7Sg//dXR2/nJQUXb+MpdPdHH0iOvr",
+	"QYM319f5XpjedKjfcPLTN0Fgcdknj5yvhK8KPk0PO7CbfRrWsXXtMf0PQOLNb/1TGvDA8yqsMQ8SaORK",
+	"VmCOTSmcSgWcyr3CA248VBLNfg1GXVrObSc5jRwf0LZagM2cKwxTN7QaUY0tmbllTARnGOjK9HvUdpHn",
+	"zqxICbyV+Q3mFl4z1dGE/f3r47/+9upV+adBdVg3g3wCl0V6lhmQTCDkq41iGp4KGWSA0zahRXwlgNd7",
+	"z8uolRQSfccgJaTL7t0pIo15B/wYcEuHiUIaf5+o2U9pZBz8K00qWdCqbRX2Oaax4bLhlTmGp7UfPGMe",
+	"qJupKJuAC7PXXt+t59Zxrfl934yc6eVOFMPvQvu17V8T3qkWXGApd3niV7ximnDRtq8bSbQdA+ShoDFb",
+	"ceH05gcj88EX5+CLcy+lt7neOEnPd+2PE4fOB+YdqPVDu4S4vjtRzBadgNMfnEI+W6eQDgfpEWs+6U9a",
+	"cYvCJQ5pvLliBVzgXHRt5bbeB40tFq8E+uv7HpFGDeXCBx737358xgv5Suhm6btzS4GPrYYdltIZy2zS",
+	"EeySUQJ5JVxJEC8YvhL54MBhf+K+4SLxL/bYLaHIhzOCgVyDX/LTGarWzFwwjOvNT+nT+SvXqg/vvdJ9",
+	"u2l7zpEcS5mLY1QMvJtPTuRXb+dhQ+/G+0Y9bLxLw5ncbvmYO0kBDciG6g0+M2wQgl0HK/Mn70f+YaQI",
+	"RBg9qfGQG3yu8maiU8rYIw6KLiceE53TbPlNRLeJEPLoH17uiZfL8IFeAecjPhvdNXScNSjxg0SfjQCp",
+	"UjbLiuW8OG7RZeGtJnZjzJg39/66XMrtmUfYnJG3psW1nV4qUvGlomqXVH/iglCBwVR98A4Wn64bVQ3d",
+	"ADjZy4tnIdDYLy6a/uvr9UNVb+8pVm6ouSdrJrSu/vefT+6f/K98prjBmKRcQPBvA2CamDxLkMvvXzw/",
+	"IS9FUhlOsTXXRu0INYb6lMK2nRcUAwy9cfXy/NHfrK/MrqikYI/+NtFLJi7UDRB/SIayO+K5oHz7q3PG",
+	"lkWQgEN+RH8ClNQVFQbCWog2UrGFCzlN7X6Q5SCNh0ovNm1nwlKX9s9XR/aHV0fY6eD1fXiQHx7k1p2Z",
+	"m3dbldsOmI/I8F/asRj210MQxkd/cWt/DJPETeDthyf2Z/rEDjwhS8KditsUL1rvQLVsyjVzRaz8Va03",
+	"VGXEtyUV5S0vzeZ76DOUhNA1IlyQ5c4w7UxshXQz+hwUHTetVAqwqAKlN+WaoT+bHVpZk1ZjuuZ3SBTa",
+	"HYqSJeRPoSaOCg9+TNZvWitN016goOIKYqJ7kF2JNnSHigFfEwxgYIU6KOyL6fyhnGe2rt149qlpObo0",
+	"imLtCuOvjlDw+trC+3sl5KujiWmbLtPAvhlpoCEz+I9yMAfDRmqDVSXsom2koyvpa0/VsYVFKJ6biskv",
+	"aiZse5jhdzuOBm3LCfElv122GNDSGOkG1iThS+ifCNPbg0TuzsqUe2hmAmahKKqveY1s6hdIE1V4p/f+",
+	"S0VBCtSf2O6cal1vFNVDfv3hO5yX1pvz0DfFENvuVqoyN9vAuvpkfs1ryHhuQv3km+xGllJWjAoX15ks",
+	"qDfk951kotgUjvN66gYGsC6EY83Du7sEok515U0SCLxZHA2+Ru3uO3H89mFqJIGHFNxpe9VidvSFr2IS",
+	"N5Vl7APqr8vg/UVd6kR3RVtMK2hVOatzKcVXxrdAykgKVk4sVzYl2ifq1lAKGK3QoBjV+bAil5R2cKrb",
+	"za4zgYWBYyWvjlzhi1dHbj2uAjSmwMPS6FjOB4s2g/NcW1kYC6qfkgtYJikq6jPHuXwNbrOWMMiysVBm",
+	"6IYnb5hSvGRDSeT0+HH2yluQFxDw/ZC8wnIuWr86IlKlO33vQo+uWXFMRXnsFj+JyK+oWJ/zgbJS33Ph",
+	"fLlvZNVs0fOaGIpVr2+YWhAtEX+5wUu7EZUsrnVyeWNLAo8FWmzgzHoobTbNdlkrLrKyiv8WJY+1cBVi",
+	"/U/JolAEsd+S6Wlp3x5cM5dTcMkxRoVrdFPuSAU9hMgymkTVlc4/ia/kmIh3znyUurjpNBDrB26QCUFW",
+	"0ZJ5d7SfmiVTghmmL1mhmOl8fioqLli2Z0wh0PowTWOVXXBY49HAjlqLHWqULnmoTVw7qMW6vq19TGo3",
+	"aHulpIVwg7Pt4fV8UGYdlFl9v/F5Dibdzu/Wx6Qzel5DlmnUVpZ1Ghz0Zh9db5Y7kXcT43BgOp+HNi3H",
+	"lPIxIgOWP/vJGb9CWI+jz5U9umyVj1ykw6TlBV5Jq2qCj3+SzvbNorf83NjzPCu6gUzvIIFJzB/99q4V",
+	"DtcxSce7zqwB4mK9nfXy8ZW+enttA60uVAZc52cX2mHa5Y+nxw++/c6x4JCcm2uiGa1AkRmttf8rlBq/",
+	"ZEWjGPleSgf0+M5xsWWhO9RIgRnTN004kpBv/8GfE3Xn/Wwo0N58k1eK6s3Anes/tW9aBF7FoJhjiNG7",
+	"ZrXx+gGo1Xe4gD/2Bdw7pOk3sD1AVnpHocMN/NnewJ2DzmgKu1jUp3RXI9AVL/WVO6VKynN2E61ULFwN",
+	"I8UHwpTWH86vY3rFgbmpQXytUrv0J51yIO806YW0yRvG87BkuSzAwXa2sWZQk8Ju456dNzsPwH86lDkY",
+	"ELdUMGFzYEaAL7A6bTxwxQxit9+uT7LFKlrr6WnF9qRN8VgSd5LD4V/Z0qY5z5jz8ENLTdRJBJzWG+Qr",
+	"7ssihaK9igqNjsYQzx2KxsAFfnhjHrRLB+2S7eEobZ5WyXd6t9okN+rjm6xPbfrV576r6a6StCTnLy6v",
+	"nPhNbrEdcoOQuSuyA438wHoCm2LjGULmBYavrOEiL60KLXF8l0Ain9UPGk8tkeR9l+PI+atCsRsuG32X",
+	"lQ4n5OBbpg3d1ntuoDga3nDOdX76Pe9csydi3JVrbZ3Bh+6OLjA9QgA0t3jo+3ULfvhwaHGpi4AbKZxG",
+	"MDr/Rks+tl9p7sPhjvroz7Db5CQmvb7c0R1eXZ/rqyu9LocouuNL2Aa8RHl1F3wLHVt26sH0nkraWi0i",
+	"OGoIL8lKhWors7DZOFoBBLeRx3Ufb2VT/8pFKW+z+Zuh8CfOGQpkeR2YthzVrRWW7gKIgrcft55/dmhY",
+	"Q6lkXVu0eXcZN8byaOSzynpI7UUTGzxx6RvHS0kPRf0NnlgaWkVbkLTOMkE04WYjm9BS+yhLKEanQwSh",
+	"6nlxpmk5Z9RL6l+ePY3vkDOXfXL94fKP6MFld9fGDnvUQfiyW0w/u9wgK8orjfoFo+w7raktaq9ko0CO",
+	"0K5uVGlr02kCYVhYQF9uyQOPHSdTXcb80Y1R74CLUevzPI2+O9p3oMhPRnpbTf682MIOlmT1SXnE7wXZ",
+	"tRH/MQenOt1stzRkh8cSVbgeqDqU1uUgp52PnqZWXLGkNGlo1OWb4QPO5tPUlEn11ivVsJHjupz0EDrr",
+	"NMdCeXHhk/t7r8oWkKYVhbpMu4T0qp3jtT9ZLLZDVrxgAj1yUSN2dFrTYsPIg5P7R44XHPlb/fb29oTC",
+	"5xOp1vdcX33v2dOzxz9fPj5+cHL/ZGO2FT4aTGWHsy7KXiH3nAq6xsL6p+dPj5KowqNGoKBa2r6yZoLW",
+	"/OjhkQ1I/NrFPgMIrIBw7+bre1QZvqIFulRnfetBgoaQVt+UOJXmcpcqu44WR8GB8GnpBL7TMLydW9Et",
+	"M3AF/L07C3DrzFRoY7JWIfC2j+UiSa3Yir+OpiXH3e9ZGrcj/qNhEP/tjgObHy2O8KBzAZi/WdLWtbRn",
+	"Yb8/uH/foa9xj9akzOW9/3aupHG80RKVbkcWKIg57f2/+Mke2Df3v35nMz5WSqrcVC8FbcxGKvtgsJN+",
+	"e//P73/SS0SSlyJ4uiJF0bUG2dGB5+g3+2sPOe+V8lZYrcQglvoG9sHluxGzUbJZbwj1eV9fXjzroekj",
+	"19Of0D5M7VQfprFbDu3QZT3eGEY1bAwHF7npXgr+OqoHrNjgisgTOjSvazA694Q4+txqegWaLTSs+Apz",
+	"7gYWlNYung6OeSQpC8PMsTaK0W0bZ2P9Zy5oNoPEIEV+AOJ4ItWSlyXW5f/m/jfvf8afpXkiG/FvR/9O",
+	"ps6yAFesPyV2H4yAnXWomYm2jcAn/Nth1SiQqix/ZMJ4kTvY8yJRtVnIGczsGYhnKC9V9XF5yYe4z9LN",
+	"flrX2oGOIh01ZnMv1q/OUs8PzADet/NA9lD9tDGb4MX+/rArzjKMVF//JfOeaiCBkgm7sLjwpgeLG1rx",
+	"kho2CI1fXAMEiZHXLA8K365P6EDAG0ZLpiIFn7YYy12E0Y42wS6MwG4SOsu14SK2uhvglk11jem8YT21",
+	"1MM8OGjOhE8fDkG5TXWdr5qCinIO6kob21Yrdox5SpiKBZYgqzgTNhp34cR9q9OA6hPRJyDoR9B4tYT6",
+	"X9SwcoBtf99U15jO2jFXps33sty9M2ROJnjz5k2Xgb95j2QUZ3aZukc49P33z7u+pyXxuc8/zq2QcEpE",
+	"vTaf7OYtH38P52o6tJ/ECyIVluXG37lCEcKltkLrXf/R3CvsMPP13FoY0gNMy1qK5TKk+g3OmQ/ubxaE",
+	"i6JqSm8jkSKMQSvFaLlzY5VjDw8u1r/CVEez3joj22jXzIgy3CNvSMytJVgZP46M1DvHfY//L40GkxMe",
+	"JkQMjvSWtOgvl9EBwO+EkkJWFQbr25slOYBLHMwDoKcKgAEG2+v3KfIEv49PR4bOn1T7QCBScZhPjsKy",
+	"z/lGm49ywFNBZI3x/CQ0tOwCWALxyTBBUR1Mt2mALdqI3UMMRrADgAId/BuI6Tb6yp4FFw37iqw4q0rv",
+	"BOp9R5CTeYQ5GeBRfpB5nPI0Wiwxj7hRvEC2WYXMuKZR9h3sAu/jHQR5zfQJeZRo7tkNUzvLsddDC61a",
+	"Br1Zq7XwdV763mYpV+E4wkK5iBsIYCNX4aDILa8qTKIxAv5Wdxsx0Dp79pprg4P6/u5UoTQuxFK3dAQ6",
+	"QSdI5a6bpbZIKQzi1iC8+JaboyF9258f5PRt7/M2GqStw600h9fl3z2uRcrviIPywLNj7FZ6H6+Q4fk+",
+	"8KNkz0JyOPjg/tcfZ/oz93KENTz4OGuwVabrsIi/vDvCgIf0lgkzNrmT+S8Y1vE6cIQuR5gktd77l70U",
+	"3kwSXjMshNxRYN0nNKUenePTwgUHibPD/Qb/+VTU0XdgKl+CUvrtJHhL+p3ndjH5LXXBaHlnxEx8+DiU",
+	"7l9xlBk7mNob9e3xdHHUCP6Phj1FPyG4DQ+o+wmjbm1fZ33krakynFbVznnbdhB5ulLg3I7/Tljs8D7e",
+	"IYOdKjkeA9z+57xzA1gk6HmQE3ty4hciHX0E++o39//6/ie0VseKF2YOA2qyd2dd0eLuXOcC+79r0e49",
+	"XJgz+c7hxXrgRAdO9D440ZyX6D1a10qGgq9DT1KxuzMDe8TE7t+Aex3E/S+VqAZ1uUgad7+6T7H/v8/V",
+	"fcD0zxDT0Z6c4ntyP5SsZqJkouAjji5B/ROzWtG0bKEdQhMpQtRlbIcfISu+IDyvHHqUrmGKn+xIipoF",
+	"JqhZkIuYH10q0qrzOeBTi3Gqb+mgn0t1MzDhJ0WiHkCts/jSTYEfU9XVIszf2iRrER21oe3EUZMdYZBW",
+	"nsYh8uaETLMv1OulBfPdHleXlr46C15raM8A9+DXcvBrOfi13JmsWxS1Oziz7GVho577tMPHdgPuK22o",
+	"vyeflc4kk9R+X7/X2Q/Kto/zeBlB6BEZaY7bxT60z8hGuzkv+V7PT/35vh/9v0hj9FSZMOM8sQ/F8FV8",
+	"QLADgnVv7OkWxv04Br0+RTT7NOSHD4/fB5nloOF9ZwbC/eLR3TVH4wqjL15PtEc/NATDqBU6KIP+nZVB",
+	"p7ZisGHDa/XB7stdH8zY1SVObmz5kN3cpWPPJzBQa+Uh410/T3Ans90dDqCzKUhx6vIY3ipuDBPuE1eE",
+	"rpmAUgmuSGrSGLL32wyn9Fgzi5iGleSVzXjiC49es91/AsheHRF3h2+ZMD44GXDYJu1cMrJlZi7w4lIO",
+	"msD3qgl8t0QOlSPmnjV0mkvbS9mgQXMpX+8lBohSl5q57HXKBc+QSrqMQhVnoaY7N4D8r45umTYLLRuz",
+	"WTCqzUJIZTavjuyZlGytGNM2h6OdH4e17Qkr11CpYg1inSJmQwWU1GfUfy2U1NqlQKXC8C1TvORUzIWb",
+	"B8H38vU86F04WOkpwLKTLUjJdV3RHcGXhyIS6hG7JrTi1G7IJawH5J5N8HaM97MNbjaQhS4KII5HWZyh",
+	"CmuIkAoOCDIxbG19K5e1NSQyZGXKKeNYs6+0pO8FLkCP02woofX1R9HkHzT45QdLPPezhEvTJo8eEmj3",
+	"WAtC/o1hI8F7NQ58HKPA4WH9KRkDsq/cObr/ASROX7fzVWT/NhrYg+Z14jM+o9IfwJyoyd+HN+h9TA7o",
+	"81mhz0BMIoTPMZ1V2efjDuczn/KdY89nE1G4H18P+vDPyeM5T5rTbWmDzD0xoX1cueDjStUfjjIPEvyB",
+	"FXywJ8M9WphQDS7/ciioKFiFGjVo7Ct92fJ/UnX4CA7vlEDcaKcILznUhfI1isiO9QMlzmAiRNnTwiUN",
+	"PjxEviBJcjTdGCAgIJNc5ZHOSFJQZcNhGgNayaKd85USxZZS+prG3BDBXhuyYiipYhE7gUls7eCZ2xCW",
+	"8umg6Pu6E3FvHykEvQXegwD7xTl0jN9XaA+x82alW29PbBlVfNCe6zzEQBbBdrFC0za3BcU0Lx1zcDQ6",
+	"IiGfutV9nkzBbe4Tk5cPjODLZATGMI1uDGPSq2KeI/jal4xsGdWNd6kY5AVaYkUdoHwrJyQzEvuPZcW1",
+	"lRsEu4XU8RnWoJkXFmLfz1Ko/QR91j4JoXYYfwsptKyGq7I4bgPuidDS/lewIlupxjU+c2N+ZD181mNo",
+	"STX77ptjJgpZspL87cG33379V1LbWq1FWhcKNyYVVCuG8sT2V800VCbnmjBRqF1tX59MQJEE+58lM7eM",
+	"idYInQrJQz4DuISfoN7Ux3wR+sM7XHQHPc0gu1grKsz4fXcjr5kvbmu7EOgzJvMyKAQnFWhouLFE5vLC",
+	"lBlOY8d3uPoDrOZzvM9aGzxQ5GdfGfIulvFJ9NUjoB+YOVDPQdE5ouikDqOMtKKMSCRAKUb1FtTVuieN",
+	"ZopsKDhNOk6+R2T8BHDxPSTVTPb2sdJpHu6RL+oe+cSVJqkU2UqLuT87oE9yNlmqhEgVeg26UyzbCeZC",
+	"bjS5uno2mEnwC+FHpx74B4Z0YEhfLkNir1kxzH9mmX5Vg6LSdmv1Ps7T38fq2XlILSte8MT+EyqX3s0c",
+	"/Pg1K7zuBmb9PO0+dpsHU/CBWx241ZYZxYuRkuB1ozfkXMktMxvWWP6xlYYd2+hgRlxvogtFa1YOveb6",
+	"ztGNdr7Rz938nzybeX1cK2nkslm1TyvE3y25oKCE707ROystaF3vju3xKqY1Kwfh+6v9/3ZhwTEu9U3/",
+	"+H6WxG/oS2Ir334ItnKJl+1LQW8or+iyYnOp7x8NtVIrF2xcAV4xqgdSBUGiiGScvlIEOiPR/Ffa7uCH",
+	"+AWp53KORRFrRl+8XGN6g5IICZ4BLRFSg01SyPCKdnZNTRpheOWMLw6F+8aXiJGfs0N+3OXB1ejgOdG/",
+	"BzxFDbpOrJ3Hz6qpKk+ouPRBh/WcmebCzYNYcYkvwFF6+/l9xaZlPSoqqg25FvJWBCbzC1Ma/UOy+f9t",
+	"24te05nTthgaucFhNNFN7VI5uCd3UXEmXHIWaMqT97TP7kIN08YP0h5jKc0mGSh4c4RXe2C4mZHaL3yb",
+	"N0ZIwZA7m8GsQjUrHFj03bIKvd/6BT10HIkhmiDdHjw7PgnPDsW0kYqNacGgQTZgL6Y+M4rqjSUKppiT",
+	"I65ZbQLHg+9EMQuHDIV4FRjXBCXrnOsHrOOQI+BwFwfkxZw942V1sE1fdbs3nwDS1QHTDo8vH7M8G5WS",
+	"2IxPAZu+lBjmw0Ppi9SPm0YINlpms6ik182hrE+wz2T3uTM7AKLfFc72+V4PboMHKjvYvT4EXe/NBHU3",
+	"iv2BmRY6f8n0enB4TXs579YOXq2kuqUKfM2uzs6TqCd0Zw0v1oprw4Sru1nJglYbqUc80ryGHdzPCHtd",
+	"c5WN2UtC/D8FjH1fIiPu7aP6dRzut8P99knIrbf0ekT/Zr92eEotb0GNLVc+KbLVWFN9bdkRFUSKigtv",
+	"AyAUzRHa8gnNDXiraWad1Miv9JodS3H87PRnUtPimoHbf6aKsG34ORv97P4+KjOyCziwogNjsL+hmmkk",
+	"y0grh55rbGkOspu7MSzZS1GwmH/ItDPkm42SzRrCdixTWFPDbinU8qbWBYDuFq6tZSqu2nZToUaf0WJD",
+	"yslaL1ew5n0RL07yPSQC/SjEmyzgAoB0oOQPLlRMoqwbzm7vUpQJexPsPpa7+hfX4ouuzmTBNK2Cdx6g",
+	"sUyTB+ehVNOhbvehbvdb3lKWmA4VP0YZ1rR63dB8rAzHL9jg/Uk8MMFHKccRZz4k9P00vHsc8uZlnTuU",
+	"5c5id1fGmZ8m34/776FLH0LzL1iTPi7VDdfgzuJT9LI5YNOXjU3zC24PIFSidfhEcOrj3/4fFpEP0sZB",
+	"NfoOVaNTBJu00PawtiHSuHaP5xgnMI29tFUSE2tIv18WszjoQbweZNUoyCHolSFeY+3P3K3WAn9cFTLQ",
+	"6aAX+Zz1IgedyEeqgvrJSKHJFcOEklW1ZcIUUqz4OnlAZ++XH5gh2BINY9Dd8p8y3BGdrC1hgjPotu8S",
+	"sfTrLxKfFpWcXV78Gzx+els9ENmHQnjSx/guZg/hvXu33MVMFg98yEoWW1z4ab5YY1kP5HtsZhF2JAFe",
+	"X07NwvhgQTtY0A6S4ju4yhxNHYTGKcxsPK9e7APCzXiB+94JvCcDW3+eD2xnG1jAoALswf2/fNi5Tyur",
+	"7N+RC+dJdrD5fUCbX47ORsW4ORbAvoQxVYybowrLzvLv85YZoYwv0p4zQ4zNGAkjXLM2wtmIZkdfcbFm",
+	"qlY8ZmzNjXNAuc8L5WZYEicwOmdQfEec7j1g3Scj+nwUjP+YEtdBW/W55ku6q3Q1oZiBdyJ0DfuBojlm",
+	"ka1R8EWzpI9VuGDPQg5K7c/YM2Fx9M2DBx8CrLWSBdPaZid+LAw3O0yP/AHQ6KkwTAlaXYKu0Dd7B4zx",
+	"bTJ07eeI2SfC/ExLh9fBF/46eBsMzD8TPjEk/LIfC4eb+PPzEdx3I72upTIjtTKwQYfeVxVjRi+cqc+w",
+	"bV1Rw2KW4TQJMFPHmpeMKFZIVXrmwZX3/FhAKoWtn2VLuDCSUCHBVe1JxdcbQ86kMEpWhAttqBg0flww",
+	"LRtla+HY4d6T5aM9yUei6s5ODyT98e7RLV8jIrYpC2nkDt4hT7Bj3qIQPn6hziAA1T0OIAMAtKbo8Ong",
+	"53Hw8/jM/Tze7TnLW8HU3GOGTkcf6+kHxH5wQBlioHuCuAF6A3KW//Y+xCsc+wM7kySTHswZH9u64FG0",
+	"J0zd+xf89809/+LwD447SFm9R8uAwHXl2iUVR0ZlB3sZANvzN3tvopO8wmKV0NTHV5t92lJg5/z3yIP7",
+	"j9peEp/wQR9C2A4C6sEReRZP6VDzQQrcx0CnX7ZzPCW7PHHaJfvWrPf9cd7UEjFx1k/KHNaF9MEYNlOi",
+	"yPhm7kVya37990Hxnw8o/oWgeIbnT2ftef1AoqWeY9T1Hd5LwofbDYV83aUkt9xVqwzZC25FzHEBQDgh",
+	"31eyuF64ZiA0LohiK8gebIfxEIDmxNjR5a3Q0aD1QtUbKlxDHYcGu5grG6yhxkHoYr+cUV3QkhFaaRk6",
+	"J60GRK9ayZqu4QjOZcWL3dFiIv7AYdluvRE+gGLuYLP6kszQe+w2mVs1z1/sVTqJuzSC/6OJKQHeO5Ph",
+	"oqgaS7xEN9stVbt2RhvtH2yrdBEdSqalS/amL3GM3MNzKWXFqPjYJPpFXZ2J0hyyp/fw99z+zHQHhVdZ",
+	"FIa2s2/I1TtE3lmuTsew5f85D7znmOM9uEa8Odwmh9vkfdkJZoU0DV0r0Pajyq2/fXR72gejyYPp7sAD",
+	"3pVEOfSIvVdxXNCAnXvDiuu2jqPn1gyoBfmqCrndSkGYXaGGV6RsDNH0xqaw4iYWj2nEtZC3Ig4aWYmt",
+	"bacYLTY2bgFqxmhupOL2+cfFDa14SfROG7YtSSPsu48LwrFEFaYiapBjoX8l39I1SBzU2JetkAbV/Rnj",
+	"ljAHxvZufUqsM/WhgE05jyCjo+RY6WEqClYBDob23afUAKHebrgtt8RLIAbszcgu58UCk0Cv52FRH5M6",
+	"3mvixrDF/Tj7pb7qcvKjR6AJmLffYX3hsNNs2I5QMNEe15ILqDAmiRTOWi3Ya0N84p+VKzImSihjaGft",
+	"oTIeLkqud8i3+2/A5ztI/HEyes+goYOo+YHodvCiqZXcSliGywQ6WCHQfXeeLLKWmpUkdMd0W10bmE+A",
+	"3GECnsLdsxM196FvMDx0pU1YuZvSqoyU3A4FtMM0535xn+N9ddA6ftpPKouG3NKARYXhWGV7XxFKrnlx",
+	"rQ1VhkhF+FpwoKmVomvIKAMvF7gfqwo1p3Rtf7ePG4xaC5avSD7ozTWSnX6P2eA83cGnYqAEPgDuUoEr",
+	"OCANGAqw8eiko9rZBAhPcKjMqjbyllQypmgmBRXuYOJ5FIqVTBhOK91d+8K+hykp3as1PJEffLNpe+P9",
+	"L1LSnR7yLIOHsY3x/6hMqYU3h8v/0778w0kZec3EhKIXaR+CnQZE/azrcIocVzjlZ3g593a5z6nyS31M",
+	"jsfVAHo5WbHAIvQ74r4mmV59ghB4BI4/P73XcKFYuEBwFq5x+K7TceqmnYvv6R31Z/ak7O3vI+Ww7cP5",
+	"YMf497tf7v2Ll6M+c4rdyGtL+/17Zuo1g351nwxd9oTFp4/8NLk1Zqbk5ad7sR0utanEoCC39aCAtWaC",
+	"Keqi77Z1xako0PSlzDSl/vBLDtNqf7ZaELe9AyZOxkRtpGLDBl/XIG/i9TFyRlG9sZo8phjhxgpQ16xG",
+	"TXz4ThSz208MUzZiixeMSFH4bngXlBn8hWV8fIPsQYX3SaCtrCrZmHt06fhoXk299DmYXPsBbul10E1d",
+	"UsM0ETLU/PNs1si2Ytorta3WzceUwovhhimDajkcrUyHaEV+7o1/ObXLR66Gy/8cPRHc1mCvn5i71eHZ",
+	"8AXq6j1nqWmjhw1g8PXdcJZGGF65208x3Wwzt9+5ne6T4QSHK/CLpgxE0kHSwM8uQ0KjWbmHRHKiXrM9",
+	"YPsB2z8qtr9NZuk9T/D5yXsPSP0ZOsrtyw69P+TiE0CkLyPw4vAS+CJuAEynPJLVOeZbdrmc4f3vJXnM",
+	"+YzeNW+XiPnp9oMlYv7Qtrv2FofdQg8ZBD8kMQwkYwa3MdVU7C6pAqEzwd55u9wz2+LCNfhCc/IFEO/J",
+	"xjcGTetR0oLlIU3zIQveIQvenak40NIh/90Ys9rjsRU51oC0E8D8ngSdOP4HlnE6Ex8Em4+d8iDF26x4",
+	"MyeD1whed8SaOS/z1qifup5nFMG/SF3PBDEuk6xpBJWstvCASF86Is3I0DKKS9DhE0Knj37Zf1AUPsgW",
+	"B5Xlu9DSDIgxgdj3hOwk7XIahBfp54MG4aBBOGgQ7kzXgZYOGoS2eBPYzogGAYOfqYgMK0kDEpyGVSNC",
+	"dtAlLa7XyjJoxLZUgAmDEK6J96wvLa6iz5WQxqL5UExXOMn3pKSI439gJUVn4oMg8fHu9ZQosvf6dPVE",
+	"+03QIyC4Yjf0hpEVF1xvWDmgw0jRfvJbQSadPvWX5ydoFfqiZNn2RTBVYZIiNDcb8MmvlVwrprVLEw8G",
+	"5Zw25bNH6VGO/kUqU6Yy1nuYPm/QpzXJrpfBRSdLbCgKE56zOsF3Q8XapbiOPWhlkXtHtlC4QDEIlzoZ",
+	"SLh3QNwDO/5IIkiabvUOLiAXafe8oNFp8oV6gQQ47/a4gagxiNrHZgeeBz3OQY9z0OO8hbeip8uDImeU",
+	"Y+3xBUlaD3m+Jg3ej9drmOCDe7y2Zz5oWj62O0gLdweknTkeISPY3RFydnNE+NawH6zEW8axXbEVU0wU",
+	"kH6ntbDpVd9iH5fBMg7Lyl7tN24IFbtbuvtsireNc4FDmMnn+rCaItlnFF0jLMXqsj4RhvLxCeaLUmd1",
+	"Za45RdVGEMpVHft0MOqzqbF2YPoHpj/Pi2+U70OHf0dCfX/PtA9Lq4dn4YFBvHsGMf4CvZfkih9JuhKZ",
+	"SSa3fI6/EGrklhc2bdkCM/Cl3jW0KJjWrOwwj/BM7FfbuJCmpcc5S5b9WTOqdKOfIM86sI8viX1gcL3e",
+	"ieJu9jrsf7kTxaAqKzb5og12EdJ7TXZJ07zJrgX1g8nuYLI7mOzeOsGIpaaD0W4P19prththXe2UNY55",
+	"vc+ENTDFR0pXE+c+vNM+vvmuhcVD8s88C94IovcFn3kPmtbQn77afRzhv1DF+xRpL2vGGcErNOQcsOqA",
+	"Vf42nmfQGUEtZ+T4tHDrMzLrTMPmg+Ll81O8dEl2jmln9C5wxp1/T5J9n8L8h6bbw/PhwC7eD7tIXip6",
+	"KbcTKqxefv/iebDi8C31kUTBM6/xkXCdX4Xz1dsuQgHhZIjbjdQ4OGiHKBfa1RqD7RK6WoU60ZTcNJVg",
+	"ii55hfWE+xrMp3bYS9jSHqYFdTX3bi8yzUcsBHsP6J9w0/MUdblVOEBYuKWggMVx7ULKFalpcU3XjLy8",
+	"eLbA6j92LAOaP1NYxWLsrAd1oa7B26w6zhLW6AoJLYiRawbphwE10umypaJD/aG3BCFWqEPM47qNNxEP",
+	"z355fPzg/oNvjv98/6/fDMEw7YuRLNmVdzDz4zxuAvYf1I3tB45lch22x82dAsmwX14xc+m+faGWKAua",
+	"PRaoPPQstnrYHWxOB5vTweZ0dw7BzSFX8BBf2mNjgnZ529Ilfnofz1AY+gPbkuKch0fgx7YhOezsiiZz",
+	"bEZZxI0iyRz1jRvqk8+ZM4DAX6T2flzuytiCsvhibUAHbPmCsGWGwngAYaDpx8aZj3kjfygUPdz9BwXw",
+	"WyqA+2IGlMLfr/h1dfCDStdqyVxkNlTWdw8yV3hfqpIpVNfCrxwLsO7wVbdkpG7U2r64TFYLcAVrmqW5",
+	"9esLGu6ghLzmolx4va1U7ZKDnXecbfvR1Haw68OrrX1PIXq2MPaWLTdSXt9Fbfer75oXk5PPX6jyzsF2",
+	"j/7udgiMFnsTIB60eAct3kGLd2fydZR0uBKGedQeXZ5vmlfn/Rq+vo/3gx/9Ayv1WtMeZPuPrdeLyJqR",
+	"YOZo94ZQuSW5zHmBxwE/dcXNCEp/kbqbvUJaRtk3hD5W33dAni8UeWbo/obxB1p/Gij0kS/xD4i0B4nh",
+	"oA18e21gIpy8WRzhkw3JtlHV0cOje0dvfnvz/x8A/bpl+z5jBAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
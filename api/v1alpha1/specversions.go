@@ -0,0 +1,92 @@
+package v1alpha1
+
+// Versions of the schema of the rendered device spec. A version is added
+// whenever the rendered spec gains settings an agent has to act on, so that
+// the service can keep serving agents that predate them.
+const (
+	// RenderedSpecVersion1 is the schema of agents which do not announce the versions they support.
+	RenderedSpecVersion1 = "1"
+	// RenderedSpecVersion2 adds the spec fetch and status update intervals in agent.
+	RenderedSpecVersion2 = "2"
+	// RenderedSpecVersion3 adds the update of the agent binary in agent.update.
+	RenderedSpecVersion3 = "3"
+	// RenderedSpecVersion4 adds the disk encryption policy in encryption.
+	RenderedSpecVersion4 = "4"
+	// RenderedSpecVersion5 adds the NTP sources in time.
+	RenderedSpecVersion5 = "5"
+	// RenderedSpecVersion6 adds the quarantine of the device in quarantine.
+	RenderedSpecVersion6 = "6"
+	// RenderedSpecVersion7 adds the log level, monitor thresholds and allowed hook paths in agent.
+	RenderedSpecVersion7 = "7"
+	// RenderedSpecVersion8 adds the sandbox of executable actions in hooks.
+	RenderedSpecVersion8 = "8"
+	// RenderedSpecVersion9 adds the batching of device update hooks in hooks.
+	RenderedSpecVersion9 = "9"
+	// RenderedSpecVersion10 adds the update strategies of applications in applications.
+	RenderedSpecVersion10 = "10"
+	// RenderedSpecVersion11 adds the volumes and data retention of applications in applications.
+	RenderedSpecVersion11 = "11"
+	// RenderedSpecVersion12 adds the resource limits of applications in applications.
+	RenderedSpecVersion12 = "12"
+	// RenderedSpecVersion13 adds the applications running as pods in applications.
+	RenderedSpecVersion13 = "13"
+	// RenderedSpecVersion14 adds the requested device action in action.
+	RenderedSpecVersion14 = "14"
+	// RenderedSpecVersion15 adds the WakeOnLan device action.
+	RenderedSpecVersion15 = "15"
+	// RenderedSpecVersion16 adds the digests of the images of the spec in imageDigests.
+	RenderedSpecVersion16 = "16"
+	// RenderedSpecVersion17 adds the compliance profile in compliance.
+	RenderedSpecVersion17 = "17"
+	// RenderedSpecVersion18 adds the migration to another Flight Control instance in migration.
+	RenderedSpecVersion18 = "18"
+	// RenderedSpecVersion19 adds the Exec device action.
+	RenderedSpecVersion19 = "19"
+	// RenderedSpecVersion20 adds the firewall in firewall.
+	RenderedSpecVersion20 = "20"
+	// RenderedSpecVersion21 adds the dependencies between applications in applications.
+	RenderedSpecVersion21 = "21"
+	// RenderedSpecVersion22 adds the garbage collection policy in garbageCollection.
+	RenderedSpecVersion22 = "22"
+	// RenderedSpecVersion23 adds the EnforceSpec action in action.
+	RenderedSpecVersion23 = "23"
+	// RenderedSpecVersion24 adds the drift detection policy in drift.
+	RenderedSpecVersion24 = "24"
+	// RenderedSpecVersion25 adds the check-only mode in agent.checkOnly.
+	RenderedSpecVersion25 = "25"
+	// RenderedSpecVersion26 adds the end-to-end encryption of console sessions in console.publicKey.
+	RenderedSpecVersion26 = "26"
+	// RenderedSpecVersion27 adds the support tunnels in tunnel.
+	RenderedSpecVersion27 = "27"
+)
+
+// RenderedSpecVersions are the supported versions of the rendered device spec, oldest first.
+var RenderedSpecVersions = []string{
+	RenderedSpecVersion1,
+	RenderedSpecVersion2,
+	RenderedSpecVersion3,
+	RenderedSpecVersion4,
+	RenderedSpecVersion5,
+	RenderedSpecVersion6,
+	RenderedSpecVersion7,
+	RenderedSpecVersion8,
+	RenderedSpecVersion9,
+	RenderedSpecVersion10,
+	RenderedSpecVersion11,
+	RenderedSpecVersion12,
+	RenderedSpecVersion13,
+	RenderedSpecVersion14,
+	RenderedSpecVersion15,
+	RenderedSpecVersion16,
+	RenderedSpecVersion17,
+	RenderedSpecVersion18,
+	RenderedSpecVersion19,
+	RenderedSpecVersion20,
+	RenderedSpecVersion21,
+	RenderedSpecVersion22,
+	RenderedSpecVersion23,
+	RenderedSpecVersion24,
+	RenderedSpecVersion25,
+	RenderedSpecVersion26,
+	RenderedSpecVersion27,
+}
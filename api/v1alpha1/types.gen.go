@@ -21,6 +21,27 @@ const (
 	ApplicationStatusUnknown   ApplicationStatusType = "Unknown"
 )
 
+// Defines values for ApplicationTerminationReason.
+const (
+	ApplicationTerminationCompleted ApplicationTerminationReason = "Completed"
+	ApplicationTerminationError     ApplicationTerminationReason = "Error"
+	ApplicationTerminationOOMKilled ApplicationTerminationReason = "OOMKilled"
+)
+
+// Defines values for ApplicationUpdateResult.
+const (
+	ApplicationUpdateFailed     ApplicationUpdateResult = "Failed"
+	ApplicationUpdateRolledBack ApplicationUpdateResult = "RolledBack"
+	ApplicationUpdateSucceeded  ApplicationUpdateResult = "Succeeded"
+)
+
+// Defines values for ApplicationUpdateStrategyType.
+const (
+	ApplicationUpdateStrategyBlueGreen ApplicationUpdateStrategyType = "BlueGreen"
+	ApplicationUpdateStrategyInPlace   ApplicationUpdateStrategyType = "InPlace"
+	ApplicationUpdateStrategyRecreate  ApplicationUpdateStrategyType = "Recreate"
+)
+
 // Defines values for ApplicationsSummaryStatusType.
 const (
 	ApplicationsSummaryStatusDegraded ApplicationsSummaryStatusType = "Degraded"
@@ -41,7 +62,14 @@ const (
 	CertificateSigningRequestApproved ConditionType = "Approved"
 	CertificateSigningRequestDenied   ConditionType = "Denied"
 	CertificateSigningRequestFailed   ConditionType = "Failed"
+	DeviceCertificateExpiring         ConditionType = "CertificateExpiring"
+	DeviceClockSkewed                 ConditionType = "ClockSkewed"
+	DeviceCryptoCompliant             ConditionType = "CryptoCompliant"
+	DeviceDrifted                     ConditionType = "Drifted"
+	DeviceIntegrityVerified           ConditionType = "IntegrityVerified"
+	DeviceManagedClusterAvailable     ConditionType = "ManagedClusterAvailable"
 	DeviceMultipleOwners              ConditionType = "MultipleOwners"
+	DeviceQuarantined                 ConditionType = "Quarantined"
 	DeviceSpecValid                   ConditionType = "SpecValid"
 	DeviceUpdating                    ConditionType = "Updating"
 	EnrollmentRequestApproved         ConditionType = "Approved"
@@ -54,6 +82,81 @@ const (
 	TemplateVersionValid              ConditionType = "Valid"
 )
 
+// Defines values for DeletionPropagationPolicy.
+const (
+	DeletionPropagationPolicyBlock   DeletionPropagationPolicy = "Block"
+	DeletionPropagationPolicyCascade DeletionPropagationPolicy = "Cascade"
+	DeletionPropagationPolicyOrphan  DeletionPropagationPolicy = "Orphan"
+)
+
+// Defines values for DeviceActionState.
+const (
+	DeviceActionAcknowledged DeviceActionState = "Acknowledged"
+	DeviceActionCompleted    DeviceActionState = "Completed"
+	DeviceActionFailed       DeviceActionState = "Failed"
+)
+
+// Defines values for DeviceActionType.
+const (
+	DeviceActionEnforceSpec  DeviceActionType = "EnforceSpec"
+	DeviceActionExec         DeviceActionType = "Exec"
+	DeviceActionReboot       DeviceActionType = "Reboot"
+	DeviceActionRestartAgent DeviceActionType = "RestartAgent"
+	DeviceActionShutdown     DeviceActionType = "Shutdown"
+	DeviceActionWakeOnLan    DeviceActionType = "WakeOnLan"
+)
+
+// Defines values for DeviceAgentRestartCause.
+const (
+	DeviceAgentRestartCrashed  DeviceAgentRestartCause = "Crashed"
+	DeviceAgentRestartWatchdog DeviceAgentRestartCause = "Watchdog"
+)
+
+// Defines values for DeviceAgentSpecLogLevel.
+const (
+	DeviceAgentSpecLogLevelDebug   DeviceAgentSpecLogLevel = "debug"
+	DeviceAgentSpecLogLevelError   DeviceAgentSpecLogLevel = "error"
+	DeviceAgentSpecLogLevelFatal   DeviceAgentSpecLogLevel = "fatal"
+	DeviceAgentSpecLogLevelInfo    DeviceAgentSpecLogLevel = "info"
+	DeviceAgentSpecLogLevelPanic   DeviceAgentSpecLogLevel = "panic"
+	DeviceAgentSpecLogLevelTrace   DeviceAgentSpecLogLevel = "trace"
+	DeviceAgentSpecLogLevelWarn    DeviceAgentSpecLogLevel = "warn"
+	DeviceAgentSpecLogLevelWarning DeviceAgentSpecLogLevel = "warning"
+)
+
+// Defines values for DeviceAgentUpdateState.
+const (
+	DeviceAgentUpdateActivating DeviceAgentUpdateState = "Activating"
+	DeviceAgentUpdateActive     DeviceAgentUpdateState = "Active"
+	DeviceAgentUpdateRolledBack DeviceAgentUpdateState = "RolledBack"
+)
+
+// Defines values for DeviceCapability.
+const (
+	DeviceCapabilityAgentUpdate         DeviceCapability = "AgentUpdate"
+	DeviceCapabilityBootcOSImage        DeviceCapability = "BootcOSImage"
+	DeviceCapabilityComplianceScans     DeviceCapability = "ComplianceScans"
+	DeviceCapabilityComposeApplications DeviceCapability = "ComposeApplications"
+	DeviceCapabilityDiskEncryption      DeviceCapability = "DiskEncryption"
+	DeviceCapabilityFirewall            DeviceCapability = "Firewall"
+	DeviceCapabilityGarbageCollection   DeviceCapability = "GarbageCollection"
+	DeviceCapabilityPodApplications     DeviceCapability = "PodApplications"
+	DeviceCapabilityTimeSync            DeviceCapability = "TimeSync"
+)
+
+// Defines values for DeviceComplianceStatusType.
+const (
+	DeviceComplianceStatusCompliant    DeviceComplianceStatusType = "Compliant"
+	DeviceComplianceStatusError        DeviceComplianceStatusType = "Error"
+	DeviceComplianceStatusNonCompliant DeviceComplianceStatusType = "NonCompliant"
+)
+
+// Defines values for DeviceEventType.
+const (
+	DeviceEventCrashed   DeviceEventType = "Crashed"
+	DeviceEventOOMKilled DeviceEventType = "OOMKilled"
+)
+
 // Defines values for DeviceIntegrityStatusSummaryType.
 const (
 	DeviceIntegrityStatusFailed      DeviceIntegrityStatusSummaryType = "Failed"
@@ -62,6 +165,13 @@ const (
 	DeviceIntegrityStatusUnsupported DeviceIntegrityStatusSummaryType = "Unsupported"
 )
 
+// Defines values for DeviceLocationSource.
+const (
+	DeviceLocationSourceGPS    DeviceLocationSource = "GPS"
+	DeviceLocationSourceIP     DeviceLocationSource = "IP"
+	DeviceLocationSourceStatic DeviceLocationSource = "Static"
+)
+
 // Defines values for DeviceResourceStatusType.
 const (
 	DeviceResourceStatusCritical DeviceResourceStatusType = "Critical"
@@ -81,6 +191,15 @@ const (
 	DeviceSummaryStatusUnknown    DeviceSummaryStatusType = "Unknown"
 )
 
+// Defines values for DeviceUpdatePhase.
+const (
+	DeviceUpdatePhaseAwaitingReboot       DeviceUpdatePhase = "AwaitingReboot"
+	DeviceUpdatePhaseDownloadingImages    DeviceUpdatePhase = "DownloadingImages"
+	DeviceUpdatePhaseRunningHooks         DeviceUpdatePhase = "RunningHooks"
+	DeviceUpdatePhaseUpdatingApplications DeviceUpdatePhase = "UpdatingApplications"
+	DeviceUpdatePhaseWritingConfig        DeviceUpdatePhase = "WritingConfig"
+)
+
 // Defines values for DeviceUpdatedStatusType.
 const (
 	DeviceUpdatedStatusOutOfDate DeviceUpdatedStatusType = "OutOfDate"
@@ -89,6 +208,55 @@ const (
 	DeviceUpdatedStatusUpdating  DeviceUpdatedStatusType = "Updating"
 )
 
+// Defines values for DeviceViewColumn.
+const (
+	DeviceViewColumnApplications DeviceViewColumn = "Applications"
+	DeviceViewColumnArchitecture DeviceViewColumn = "Architecture"
+	DeviceViewColumnDisplayName  DeviceViewColumn = "DisplayName"
+	DeviceViewColumnLabels       DeviceViewColumn = "Labels"
+	DeviceViewColumnLastSeen     DeviceViewColumn = "LastSeen"
+	DeviceViewColumnName         DeviceViewColumn = "Name"
+	DeviceViewColumnOS           DeviceViewColumn = "OS"
+	DeviceViewColumnOwner        DeviceViewColumn = "Owner"
+	DeviceViewColumnSystem       DeviceViewColumn = "System"
+	DeviceViewColumnUpdated      DeviceViewColumn = "Updated"
+)
+
+// Defines values for DriftRemediation.
+const (
+	DriftRemediate DriftRemediation = "Remediate"
+	DriftReport    DriftRemediation = "Report"
+)
+
+// Defines values for EncryptedVolumeState.
+const (
+	EncryptedVolumeCompliant    EncryptedVolumeState = "Compliant"
+	EncryptedVolumeError        EncryptedVolumeState = "Error"
+	EncryptedVolumeNonCompliant EncryptedVolumeState = "NonCompliant"
+	EncryptedVolumeUnencrypted  EncryptedVolumeState = "Unencrypted"
+)
+
+// Defines values for ErrorReason.
+const (
+	ErrorReasonAlreadyExists            ErrorReason = "AlreadyExists"
+	ErrorReasonBadRequest               ErrorReason = "BadRequest"
+	ErrorReasonConflict                 ErrorReason = "Conflict"
+	ErrorReasonForbidden                ErrorReason = "Forbidden"
+	ErrorReasonIdempotencyKeyInProgress ErrorReason = "IdempotencyKeyInProgress"
+	ErrorReasonIdempotencyKeyReused     ErrorReason = "IdempotencyKeyReused"
+	ErrorReasonInternalError            ErrorReason = "InternalError"
+	ErrorReasonMethodNotAllowed         ErrorReason = "MethodNotAllowed"
+	ErrorReasonNotFound                 ErrorReason = "NotFound"
+	ErrorReasonRequestTooLarge          ErrorReason = "RequestTooLarge"
+	ErrorReasonResourceVersionConflict  ErrorReason = "ResourceVersionConflict"
+	ErrorReasonServiceUnavailable       ErrorReason = "ServiceUnavailable"
+	ErrorReasonTimeout                  ErrorReason = "Timeout"
+	ErrorReasonTooManyRequests          ErrorReason = "TooManyRequests"
+	ErrorReasonUnauthorized             ErrorReason = "Unauthorized"
+	ErrorReasonUnknown                  ErrorReason = "Unknown"
+	ErrorReasonValidationFailed         ErrorReason = "ValidationFailed"
+)
+
 // Defines values for FileOperation.
 const (
 	FileOperationCreate FileOperation = "Create"
@@ -97,6 +265,49 @@ const (
 	FileOperationUpdate FileOperation = "Update"
 )
 
+// Defines values for FirewallAction.
+const (
+	FirewallAccept FirewallAction = "Accept"
+	FirewallDrop   FirewallAction = "Drop"
+)
+
+// Defines values for FirewallProtocol.
+const (
+	FirewallProtocolIcmp FirewallProtocol = "icmp"
+	FirewallProtocolTcp  FirewallProtocol = "tcp"
+	FirewallProtocolUdp  FirewallProtocol = "udp"
+)
+
+// Defines values for FleetLintWarningType.
+const (
+	FleetLintWarningTypeImageNotFound         FleetLintWarningType = "ImageNotFound"
+	FleetLintWarningTypeInvalidUnit           FleetLintWarningType = "InvalidUnit"
+	FleetLintWarningTypeUnknownParameter      FleetLintWarningType = "UnknownParameter"
+	FleetLintWarningTypeUnreachableRepository FleetLintWarningType = "UnreachableRepository"
+)
+
+// Defines values for FleetPromotionApproval.
+const (
+	FleetPromotionApprovalAutomatic FleetPromotionApproval = "Automatic"
+	FleetPromotionApprovalManual    FleetPromotionApproval = "Manual"
+)
+
+// Defines values for FleetPromotionState.
+const (
+	FleetPromotionStatePromoted FleetPromotionState = "Promoted"
+	FleetPromotionStateProposed FleetPromotionState = "Proposed"
+	FleetPromotionStateSoaking  FleetPromotionState = "Soaking"
+)
+
+// Defines values for FleetRolloutState.
+const (
+	FleetRolloutStateAborted     FleetRolloutState = "Aborted"
+	FleetRolloutStateBlocked     FleetRolloutState = "Blocked"
+	FleetRolloutStateCompleted   FleetRolloutState = "Completed"
+	FleetRolloutStatePaused      FleetRolloutState = "Paused"
+	FleetRolloutStateProgressing FleetRolloutState = "Progressing"
+)
+
 // Defines values for HookActionSystemdUnitOperations.
 const (
 	SystemdDaemonReload HookActionSystemdUnitOperations = "DaemonReload"
@@ -108,6 +319,33 @@ const (
 	SystemdStop         HookActionSystemdUnitOperations = "Stop"
 )
 
+// Defines values for HttpContentFormat.
+const (
+	HttpContentFormatArchive HttpContentFormat = "archive"
+	HttpContentFormatFile    HttpContentFormat = "file"
+)
+
+// Defines values for IssuedCertificateUsage.
+const (
+	IssuedCertificateUsageEnrollment IssuedCertificateUsage = "enrollment"
+	IssuedCertificateUsageManagement IssuedCertificateUsage = "management"
+)
+
+// Defines values for OperationState.
+const (
+	OperationStateCanceled  OperationState = "Canceled"
+	OperationStateFailed    OperationState = "Failed"
+	OperationStatePending   OperationState = "Pending"
+	OperationStateRunning   OperationState = "Running"
+	OperationStateSucceeded OperationState = "Succeeded"
+)
+
+// Defines values for OperationType.
+const (
+	OperationTypeBulkLabel          OperationType = "BulkLabel"
+	OperationTypeDeviceDecommission OperationType = "DeviceDecommission"
+)
+
 // Defines values for PatchRequestOp.
 const (
 	Add     PatchRequestOp = "add"
@@ -115,6 +353,12 @@ const (
 	Replace PatchRequestOp = "replace"
 )
 
+// Defines values for ProvisioningFormat.
+const (
+	ProvisioningFormatIgnition  ProvisioningFormat = "ignition"
+	ProvisioningFormatKickstart ProvisioningFormat = "kickstart"
+)
+
 // Defines values for RepoSpecType.
 const (
 	Git  RepoSpecType = "git"
@@ -128,6 +372,18 @@ const (
 	ResourceAlertSeverityTypeWarning  ResourceAlertSeverityType = "Warning"
 )
 
+// Defines values for ResourceDependencyType.
+const (
+	Ownership ResourceDependencyType = "Ownership"
+	Reference ResourceDependencyType = "Reference"
+)
+
+// Defines values for SbomFormat.
+const (
+	SbomFormatCycloneDX SbomFormat = "CycloneDX"
+	SbomFormatSPDX      SbomFormat = "SPDX"
+)
+
 // Defines values for TemplateDiscriminators.
 const (
 	TemplateDiscriminatorGitConfig     TemplateDiscriminators = "GitConfigProviderSpec"
@@ -136,8 +392,119 @@ const (
 	TemplateDiscriminatorKubernetesSec TemplateDiscriminators = "KubernetesSecretProviderSpec"
 )
 
+// AgentUpdateSpec An update of the agent binary that is independent of the OS image. The updated agent is activated once it reports healthy, otherwise the previous agent is restored. Removing the update reverts to the agent of the OS image.
+type AgentUpdateSpec struct {
+	// Image OCI image containing the agent binary at /usr/bin/flightctl-agent. Exactly one of image and url must be set.
+	Image *string `json:"image,omitempty"`
+
+	// Sha256 SHA-256 checksum of the agent binary downloaded from url. Required when url is set.
+	Sha256 *string `json:"sha256,omitempty"`
+
+	// Url URL of the agent binary. Exactly one of image and url must be set.
+	Url *string `json:"url,omitempty"`
+
+	// Version Version of the updated agent, as reported by 'flightctl-agent version'.
+	Version string `json:"version"`
+}
+
+// ApplicationContainer A container of a pod.
+type ApplicationContainer struct {
+	// ArchitectureImages The images of the container for devices reporting each architecture in status.systemInfo.architecture, such as amd64 or arm64. Devices of other architectures, or which have not reported their architecture yet, run the image.
+	ArchitectureImages *map[string]string `json:"architectureImages,omitempty"`
+
+	// Command The command of the container and its arguments, replacing the command of the image.
+	Command *[]string `json:"command,omitempty"`
+
+	// DependsOn The names of the other containers of the pod which must be started before this one.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+
+	// EnvVars Environment variables of the container, as KEY=VALUE.
+	EnvVars *[]string `json:"envVars,omitempty"`
+
+	// Image The image of the container.
+	Image string `json:"image"`
+
+	// Name The name of the container, unique within the pod.
+	Name string `json:"name"`
+
+	// VolumeMounts The volumes of the application mounted into the container.
+	VolumeMounts *[]ApplicationVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// ApplicationHealthCheck The check gating the cut-over of a blue-green update. The agent runs the command until it succeeds or the timeout expires.
+type ApplicationHealthCheck struct {
+	// Run The command checking the new version of the application, run with bash on the device. The name of the compose project of the new version is passed in the FLIGHTCTL_APPLICATION_PROJECT environment variable.
+	Run string `json:"run"`
+
+	// Timeout How long the new version may take to become healthy, as a duration such as 2m. Defaults to 1m.
+	Timeout *string `json:"timeout,omitempty"`
+}
+
+// ApplicationHostPathVolume A directory of the device bind mounted into the containers of the application.
+type ApplicationHostPathVolume struct {
+	// Path The absolute path of the directory, which the agent creates if it does not exist.
+	Path string `json:"path"`
+
+	// SelinuxRelabel Whether the agent relabels the directory with the container_file_t SELinux type, so that containers can access it. Defaults to false.
+	SelinuxRelabel *bool `json:"selinuxRelabel,omitempty"`
+}
+
+// ApplicationPodSpec A pod of containers sharing their network, IPC and UTS namespaces, such as an application with a local broker and a metrics exporter. The agent runs the pod with Quadlet units of systemd, which start it when the device boots. Pods are only updated with the Recreate strategy.
+type ApplicationPodSpec struct {
+	// Containers The containers of the pod, started once the init containers completed.
+	Containers []ApplicationContainer `json:"containers"`
+
+	// InitContainers Containers run to completion one after the other, in order, before the containers of the pod start.
+	InitContainers *[]ApplicationContainer `json:"initContainers,omitempty"`
+
+	// Ports The ports the pod publishes on the device, as HOST:CONTAINER with an optional host IP address prefix and /tcp or /udp suffix, such as 8080:80.
+	Ports *[]string `json:"ports,omitempty"`
+}
+
+// ApplicationResources The CPU and memory limits of an application, shared by all of its containers. The agent runs the containers of the application in a systemd slice enforcing the limits, and the service only renders device specs whose applications fit the CPUs and memory reported by the device.
+type ApplicationResources struct {
+	// Cpu The CPUs the application may use, as a quantity such as 1.5 or 500m.
+	Cpu *string `json:"cpu,omitempty"`
+
+	// Memory The memory the application may use, as a quantity such as 512Mi or 2G, beyond which its containers are killed.
+	Memory *string `json:"memory,omitempty"`
+}
+
+// ApplicationSpec An application run either by podman-compose from a compose file of the device configuration, or as a pod of containers declared in the spec. The agent brings the application up, updates it with its update strategy and takes it down instead of the default hooks of the compose directory.
+type ApplicationSpec struct {
+	// DependsOn The names of the applications of the spec this application depends on. The agent brings up and updates the application once its dependencies were synced, and syncs applications which do not depend on each other in parallel.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+
+	// Name The name of the application, which is the compose project or the pod its containers run in.
+	Name string `json:"name"`
+
+	// Path The absolute path of the compose file of the application, which the device configuration provides. Exactly one of path and pod must be set.
+	Path *string `json:"path,omitempty"`
+
+	// Pod A pod of containers sharing their network, IPC and UTS namespaces, such as an application with a local broker and a metrics exporter. The agent runs the pod with Quadlet units of systemd, which start it when the device boots. Pods are only updated with the Recreate strategy.
+	Pod *ApplicationPodSpec `json:"pod,omitempty"`
+
+	// Resources The CPU and memory limits of an application, shared by all of its containers. The agent runs the containers of the application in a systemd slice enforcing the limits, and the service only renders device specs whose applications fit the CPUs and memory reported by the device.
+	Resources *ApplicationResources `json:"resources,omitempty"`
+
+	// RetainData Whether the named volumes of the application and their data are kept when the application is removed from the spec. Defaults to true. The data of host paths is never deleted.
+	RetainData *bool `json:"retainData,omitempty"`
+
+	// UpdateStrategy How the agent replaces the running version of an application when its compose file changes.
+	UpdateStrategy *ApplicationUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Volumes The volumes the agent provides to the application, which its compose file refers to by name.
+	Volumes *[]ApplicationVolume `json:"volumes,omitempty"`
+}
+
 // ApplicationStatus defines model for ApplicationStatus.
 type ApplicationStatus struct {
+	// ImageDigest The digest of the image the container of the application runs, such as sha256:4be4...52d0.
+	ImageDigest *string `json:"imageDigest,omitempty"`
+
+	// LastTermination The last time the container of an application exited.
+	LastTermination *ApplicationTermination `json:"lastTermination,omitempty"`
+
 	// Name Human readable name of the application.
 	Name string `json:"name"`
 
@@ -145,13 +512,91 @@ type ApplicationStatus struct {
 	Ready string `json:"ready"`
 
 	// Restarts Number of restarts observed for the application.
-	Restarts int                   `json:"restarts"`
-	Status   ApplicationStatusType `json:"status"`
+	Restarts int `json:"restarts"`
+
+	// StartedAt The time the running container of the application started at, from which its uptime is derived. Unset while the application does not run.
+	StartedAt *time.Time            `json:"startedAt,omitempty"`
+	Status    ApplicationStatusType `json:"status"`
 }
 
 // ApplicationStatusType defines model for ApplicationStatusType.
 type ApplicationStatusType string
 
+// ApplicationTermination The last time the container of an application exited.
+type ApplicationTermination struct {
+	// ExitCode The exit code of the container.
+	ExitCode int32 `json:"exitCode"`
+
+	// FinishedAt The time the container exited at.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// Reason Completed if the container exited with code 0, OOMKilled if the kernel killed it for exceeding its memory, and Error otherwise.
+	Reason ApplicationTerminationReason `json:"reason"`
+}
+
+// ApplicationTerminationReason Completed if the container exited with code 0, OOMKilled if the kernel killed it for exceeding its memory, and Error otherwise.
+type ApplicationTerminationReason string
+
+// ApplicationTmpfsVolume A volume held in memory, whose data is lost when the device reboots.
+type ApplicationTmpfsVolume struct {
+	// Size The maximum size of the volume, in bytes or with a k, m or g suffix such as 64m. Defaults to half of the memory of the device.
+	Size *string `json:"size,omitempty"`
+}
+
+// ApplicationUpdateResult Succeeded if the new version runs, RolledBack if a blue-green update kept the previous version because the new one was unhealthy, and Failed otherwise.
+type ApplicationUpdateResult string
+
+// ApplicationUpdateStatus The outcome of the last update of an application.
+type ApplicationUpdateStatus struct {
+	// FinishedAt The time the update finished at.
+	FinishedAt time.Time `json:"finishedAt"`
+
+	// Message Human readable information about the update, such as the reason it failed.
+	Message *string `json:"message,omitempty"`
+
+	// Result Succeeded if the new version runs, RolledBack if a blue-green update kept the previous version because the new one was unhealthy, and Failed otherwise.
+	Result ApplicationUpdateResult `json:"result"`
+
+	// Strategy Recreate takes the previous version down before bringing the new one up, which is the default. InPlace pulls the images of the new version while the previous one runs and then recreates the changed containers only. BlueGreen brings the new version up next to the previous one, takes the previous version down once the health check of the new one succeeds and otherwise takes the new version down and restores the previous compose file.
+	Strategy ApplicationUpdateStrategyType `json:"strategy"`
+}
+
+// ApplicationUpdateStrategy How the agent replaces the running version of an application when its compose file changes.
+type ApplicationUpdateStrategy struct {
+	// HealthCheck The check gating the cut-over of a blue-green update. The agent runs the command until it succeeds or the timeout expires.
+	HealthCheck *ApplicationHealthCheck `json:"healthCheck,omitempty"`
+
+	// Type Recreate takes the previous version down before bringing the new one up, which is the default. InPlace pulls the images of the new version while the previous one runs and then recreates the changed containers only. BlueGreen brings the new version up next to the previous one, takes the previous version down once the health check of the new one succeeds and otherwise takes the new version down and restores the previous compose file.
+	Type ApplicationUpdateStrategyType `json:"type"`
+}
+
+// ApplicationUpdateStrategyType Recreate takes the previous version down before bringing the new one up, which is the default. InPlace pulls the images of the new version while the previous one runs and then recreates the changed containers only. BlueGreen brings the new version up next to the previous one, takes the previous version down once the health check of the new one succeeds and otherwise takes the new version down and restores the previous compose file.
+type ApplicationUpdateStrategyType string
+
+// ApplicationVolume A volume of an application. The agent creates it as a podman volume before bringing the application up, so that all versions of the application share it. It is a named volume unless hostPath or tmpfs is set.
+type ApplicationVolume struct {
+	// HostPath A directory of the device bind mounted into the containers of the application.
+	HostPath *ApplicationHostPathVolume `json:"hostPath,omitempty"`
+
+	// Name The name of the volume in the compose file of the application.
+	Name string `json:"name"`
+
+	// Tmpfs A volume held in memory, whose data is lost when the device reboots.
+	Tmpfs *ApplicationTmpfsVolume `json:"tmpfs,omitempty"`
+}
+
+// ApplicationVolumeMount A volume of the application mounted into a container.
+type ApplicationVolumeMount struct {
+	// MountPath The absolute path the volume is mounted at in the container.
+	MountPath string `json:"mountPath"`
+
+	// Name The name of the volume in the volumes of the application.
+	Name string `json:"name"`
+
+	// ReadOnly Whether the volume is mounted read-only. Defaults to false.
+	ReadOnly *bool `json:"readOnly,omitempty"`
+}
+
 // ApplicationsSummaryStatus defines model for ApplicationsSummaryStatus.
 type ApplicationsSummaryStatus struct {
 	// Info Human readable information detailing the last system application transition.
@@ -162,6 +607,66 @@ type ApplicationsSummaryStatus struct {
 // ApplicationsSummaryStatusType defines model for ApplicationsSummaryStatusType.
 type ApplicationsSummaryStatusType string
 
+// Artifact Artifact is a large file the service stores in its artifact storage rather than in the database.
+type Artifact struct {
+	// ContentType The media type of the artifact.
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Key The key of the artifact, starting with the kind of the artifact, such as reports/.
+	Key string `json:"key"`
+
+	// ModifiedAt The time the artifact was stored.
+	ModifiedAt time.Time `json:"modifiedAt"`
+
+	// Size The size of the artifact in bytes.
+	Size int64 `json:"size"`
+}
+
+// ArtifactDownloadUrl ArtifactDownloadUrl is a signed URL downloading an artifact without further authentication.
+type ArtifactDownloadUrl struct {
+	// ExpiresAt The time the URL expires at.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Url The URL downloading the artifact.
+	Url string `json:"url"`
+}
+
+// ArtifactList ArtifactList is a list of Artifacts.
+type ArtifactList struct {
+	// Items List of Artifacts.
+	Items []Artifact `json:"items"`
+}
+
+// AttestationChallenge AttestationChallenge is the nonce a device quotes its measurements with to prove their freshness.
+type AttestationChallenge struct {
+	// ExpirationTime The time until which the service accepts a quote including the nonce.
+	ExpirationTime time.Time `json:"expirationTime"`
+
+	// Nonce The base64 encoded nonce the device includes in the TPM quote.
+	Nonce string `json:"nonce"`
+}
+
+// AttestationReport AttestationReport is a TPM quote of the boot measurements of a device.
+type AttestationReport struct {
+	// AttestationKey The base64 encoded public area (TPMT_PUBLIC) of the TPM attestation key that signed the quote.
+	AttestationKey string `json:"attestationKey"`
+
+	// BootedImage The OS image the device booted when taking the quote.
+	BootedImage *string `json:"bootedImage,omitempty"`
+
+	// Nonce The base64 encoded nonce of the challenge.
+	Nonce string `json:"nonce"`
+
+	// Pcrs The hex encoded SHA-256 values of the quoted PCRs, keyed by PCR index such as "pcr04".
+	Pcrs map[string]string `json:"pcrs"`
+
+	// Quote The base64 encoded attestation data (TPMS_ATTEST) of the quote.
+	Quote string `json:"quote"`
+
+	// Signature The base64 encoded signature (TPMT_SIGNATURE) of the quote.
+	Signature string `json:"signature"`
+}
+
 // AuthConfig Auth config.
 type AuthConfig struct {
 	// AuthType Auth type
@@ -171,6 +676,36 @@ type AuthConfig struct {
 	AuthURL string `json:"authURL"`
 }
 
+// BulkImport BulkImport holds the fleets and devices created by a bulk import, at most 10000 in total.
+type BulkImport struct {
+	// Devices The devices to create.
+	Devices *[]Device `json:"devices,omitempty"`
+
+	// Fleets The fleets to create, which are created before the devices.
+	Fleets *[]Fleet `json:"fleets,omitempty"`
+}
+
+// BulkImportFailure defines model for BulkImportFailure.
+type BulkImportFailure struct {
+	// Kind The kind of the resource, Fleet or Device.
+	Kind string `json:"kind"`
+
+	// Message Why the resource was not created.
+	Message string `json:"message"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+}
+
+// BulkImportResult BulkImportResult reports the outcome of a bulk import. The resources which were not created do not prevent the others from being created.
+type BulkImportResult struct {
+	// Created The number of resources created.
+	Created int64 `json:"created"`
+
+	// Failures The resources which failed to be created, in the order of the import.
+	Failures []BulkImportFailure `json:"failures"`
+}
+
 // CPUResourceMonitorSpec defines model for CPUResourceMonitorSpec.
 type CPUResourceMonitorSpec = ResourceMonitorSpec
 
@@ -263,6 +798,51 @@ type ConditionStatus string
 // ConditionType defines model for ConditionType.
 type ConditionType string
 
+// ConsoleGrant ConsoleGrant is a one-off grant to open a console on a device whose consoles require the approval of a second user. An approved grant opens a single console before it expires.
+type ConsoleGrant struct {
+	// ApprovedAt The time the grant was approved.
+	ApprovedAt *time.Time `json:"approvedAt,omitempty"`
+
+	// ApprovedBy The name the user who approved the grant gave, which the service does not verify.
+	ApprovedBy *string `json:"approvedBy,omitempty"`
+
+	// ExpiresAt The time the approved grant expires, after which it can no longer open a console.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Id Unique ID of the grant.
+	Id string `json:"id"`
+
+	// Reason Why the console is needed.
+	Reason string `json:"reason"`
+
+	// RequestedAt The time the grant was requested.
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// RequestedBy The name the user who requested the grant gave, which the service does not verify.
+	RequestedBy string `json:"requestedBy"`
+
+	// Ttl How long the grant is valid for once approved.
+	Ttl string `json:"ttl"`
+}
+
+// ConsoleGrantApproval ConsoleGrantApproval approves a pending console grant.
+type ConsoleGrantApproval struct {
+	// ApprovedBy The name of the user approving the grant, as they give it, which cannot be the name of the requester. The service does not verify it.
+	ApprovedBy string `json:"approvedBy"`
+}
+
+// ConsoleGrantRequest ConsoleGrantRequest requests a grant to open a console on a device whose consoles require the approval of a second user.
+type ConsoleGrantRequest struct {
+	// Reason Why the console is needed, recorded in the audit log of the service.
+	Reason string `json:"reason"`
+
+	// RequestedBy The name of the user requesting the grant, as they give it. The service does not verify it; only users allowed to approve console grants can approve it.
+	RequestedBy string `json:"requestedBy"`
+
+	// Ttl How long the grant is valid for once approved. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours. Defaults to, and cannot exceed, the maximum TTL of the service.
+	Ttl *string `json:"ttl,omitempty"`
+}
+
 // CustomResourceMonitorSpec defines model for CustomResourceMonitorSpec.
 type CustomResourceMonitorSpec struct {
 	// AlertRules Array of alert rules. Only one alert per severity is allowed.
@@ -273,6 +853,9 @@ type CustomResourceMonitorSpec struct {
 	SamplingInterval string `json:"samplingInterval"`
 }
 
+// DeletionPropagationPolicy What deleting a resource does with the resources depending on it. Block refuses to delete a resource while others depend on it, Orphan deletes it and leaves its dependents, and Cascade also deletes the resources it owns.
+type DeletionPropagationPolicy string
+
 // Device Device represents a physical device.
 type Device struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
@@ -289,54 +872,605 @@ type Device struct {
 	Status *DeviceStatus `json:"status,omitempty"`
 }
 
-// DeviceApplicationsStatus defines model for DeviceApplicationsStatus.
-type DeviceApplicationsStatus struct {
-	// Data Map of system application statuses.
-	Data    map[string]ApplicationStatus `json:"data"`
-	Summary ApplicationsSummaryStatus    `json:"summary"`
-}
+// DeviceAcceleratorStatus defines model for DeviceAcceleratorStatus.
+type DeviceAcceleratorStatus struct {
+	// DriverVersion The version of the driver of the accelerator.
+	DriverVersion *string `json:"driverVersion,omitempty"`
 
-// DeviceConfigStatus defines model for DeviceConfigStatus.
-type DeviceConfigStatus struct {
-	// RenderedVersion Version of the device rendered config.
-	RenderedVersion string `json:"renderedVersion"`
-}
+	// EccErrors The number of uncorrected ECC memory errors since the driver was loaded.
+	EccErrors *int64 `json:"eccErrors,omitempty"`
 
-// DeviceConsole defines model for DeviceConsole.
-type DeviceConsole struct {
-	GRPCEndpoint string `json:"gRPCEndpoint"`
-	SessionID    string `json:"sessionID"`
-}
+	// Id The identifier of the accelerator on the device, e.g. its PCI address.
+	Id string `json:"id"`
 
-// DeviceHooksSpec defines model for DeviceHooksSpec.
-type DeviceHooksSpec struct {
-	// AfterRebooting Hooks executed after rebooting enable custom actions and integration with other systems
-	// or services. These actions occur after the device has rebooted, allowing for post-reboot tasks.
-	AfterRebooting *[]DeviceRebootHookSpec `json:"afterRebooting,omitempty"`
+	// MemoryTotalBytes The total accelerator memory in bytes.
+	MemoryTotalBytes *int64 `json:"memoryTotalBytes,omitempty"`
 
-	// AfterUpdating Hooks executed after updating enable custom actions and integration with other systems
-	// or services. These actions occur after configuration changes have been applied to the device.
-	AfterUpdating *[]DeviceUpdateHookSpec `json:"afterUpdating,omitempty"`
+	// MemoryUsedBytes The accelerator memory in use in bytes.
+	MemoryUsedBytes *int64 `json:"memoryUsedBytes,omitempty"`
 
-	// BeforeRebooting Hooks executed before rebooting allow for custom actions and integration with other systems
-	// or services. These actions occur before the device is rebooted.
-	BeforeRebooting *[]DeviceRebootHookSpec `json:"beforeRebooting,omitempty"`
+	// Name The model name of the accelerator.
+	Name string `json:"name"`
 
-	// BeforeUpdating Hooks executed before updating allow for custom actions and integration with other systems
-	// or services. These actions occur before configuration changes are applied to the device.
-	BeforeUpdating *[]DeviceUpdateHookSpec `json:"beforeUpdating,omitempty"`
-}
+	// TemperatureCelsius The temperature of the accelerator in degrees Celsius.
+	TemperatureCelsius *int32 `json:"temperatureCelsius,omitempty"`
 
-// DeviceIntegrityStatus defines model for DeviceIntegrityStatus.
-type DeviceIntegrityStatus struct {
-	Summary DeviceIntegrityStatusSummary `json:"summary"`
-}
+	// UtilizationPercent The utilization of the accelerator in percent.
+	UtilizationPercent *int32 `json:"utilizationPercent,omitempty"`
 
-// DeviceIntegrityStatusSummary defines model for DeviceIntegrityStatusSummary.
-type DeviceIntegrityStatusSummary struct {
-	// Info Human readable information about the last integrity transition.
-	Info   *string                          `json:"info,omitempty"`
-	Status DeviceIntegrityStatusSummaryType `json:"status"`
+	// Vendor The vendor of the accelerator.
+	Vendor string `json:"vendor"`
+}
+
+// DeviceAction DeviceAction is an action requested for a device. It is served to the agent with the rendered spec until the agent acknowledges it, and carried out once.
+type DeviceAction struct {
+	// Action An action the agent carries out on the device.
+	Action DeviceActionType `json:"action"`
+
+	// Exec DeviceExec is the command an Exec action runs on the device. The agent reports the exit code and the end of the output of the command in the message of the action's status.
+	Exec *DeviceExec `json:"exec,omitempty"`
+
+	// Id Unique ID of the request, which the agent reports the outcome of the action with.
+	Id string `json:"id"`
+
+	// Reason Why the action is requested.
+	Reason *string `json:"reason,omitempty"`
+
+	// RequestedAt The time the action was requested.
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// WakeOnLan DeviceWakeOnLan is the device which a WakeOnLan action sends a Wake-on-LAN packet to, over the local network of the device carrying out the action.
+	WakeOnLan *DeviceWakeOnLan `json:"wakeOnLan,omitempty"`
+}
+
+// DeviceActionRequest DeviceActionRequest requests the agent of a device to carry out an action.
+type DeviceActionRequest struct {
+	// Action An action the agent carries out on the device.
+	Action DeviceActionType `json:"action"`
+
+	// Reason Why the action is requested, recorded in the audit log of the service.
+	Reason *string `json:"reason,omitempty"`
+}
+
+// DeviceActionState The progress of a device action.
+type DeviceActionState string
+
+// DeviceActionStatus DeviceActionStatus is the progress of the last action the agent received. The agent acknowledges an action before carrying it out, and reports it completed once it runs again after the reboot or restart.
+type DeviceActionStatus struct {
+	// Action An action the agent carries out on the device.
+	Action DeviceActionType `json:"action"`
+
+	// Id ID of the action request.
+	Id string `json:"id"`
+
+	// Message Why the action failed, which device a Wake-on-LAN action woke, or the exit code and the end of the output of the command of an Exec action.
+	Message *string `json:"message,omitempty"`
+
+	// State The progress of a device action.
+	State DeviceActionState `json:"state"`
+
+	// UpdatedAt The time the action reached its state.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeviceActionType An action the agent carries out on the device.
+type DeviceActionType string
+
+// DeviceAdoptionStatus The state the agent found on the device it was enrolled from, when its agent is configured to adopt the existing state. The agent holds back the first rendered spec until an EnforceSpec action is requested for the device, so that an operator can review what the spec replaces.
+type DeviceAdoptionStatus struct {
+	// EnforcedAt Time the spec was enforced at. Unset while the agent holds the spec back.
+	EnforcedAt *time.Time `json:"enforcedAt,omitempty"`
+
+	// InventoriedAt Time the agent took the inventory at.
+	InventoriedAt time.Time `json:"inventoriedAt"`
+
+	// OverwrittenFiles The files of the config of the spec which exist on the device with different content, and which applying the spec overwrites.
+	OverwrittenFiles []string `json:"overwrittenFiles"`
+
+	// RenderedVersion The rendered version the inventory was taken against, which the agent applies once the spec is enforced.
+	RenderedVersion string `json:"renderedVersion"`
+
+	// UnmanagedContainers The containers on the device which no application of the spec manages.
+	UnmanagedContainers []DeviceUnmanagedContainer `json:"unmanagedContainers"`
+}
+
+// DeviceAgentRestart The last unexpected restart of the agent since the device booted.
+type DeviceAgentRestart struct {
+	// Cause Watchdog when the self-health check of the agent found one of its loops stuck and had systemd restart the agent, Crashed when the agent exited or was killed without stopping, such as after a panic or once the systemd watchdog killed an agent which stopped responding.
+	Cause DeviceAgentRestartCause `json:"cause"`
+
+	// Count Number of unexpected restarts of the agent since the device booted.
+	Count int32 `json:"count"`
+
+	// Message Why the agent was restarted, such as the loop the self-health check found stuck.
+	Message string `json:"message"`
+
+	// RestartedAt When the agent started again.
+	RestartedAt time.Time `json:"restartedAt"`
+}
+
+// DeviceAgentRestartCause Watchdog when the self-health check of the agent found one of its loops stuck and had systemd restart the agent, Crashed when the agent exited or was killed without stopping, such as after a panic or once the systemd watchdog killed an agent which stopped responding.
+type DeviceAgentRestartCause string
+
+// DeviceAgentSpec Settings that control how the agent communicates with the service.
+type DeviceAgentSpec struct {
+	// AllowedHookPaths Absolute paths of the directories whose files device update hooks may watch. Hooks watching other files are rejected. Defaults to the agent's local configuration, which allows all files.
+	AllowedHookPaths *[]string `json:"allowedHookPaths,omitempty"`
+
+	// CheckOnly Whether the agent only checks the rendered spec, reporting the changes applying it would make in status.check without making them. Defaults to the agent's local configuration, which applies the spec.
+	CheckOnly *bool `json:"checkOnly,omitempty"`
+
+	// LogLevel Level of the agent's logs. Defaults to the agent's local configuration.
+	LogLevel *DeviceAgentSpecLogLevel `json:"logLevel,omitempty"`
+
+	// MonitorThresholds Alert thresholds of the default resource monitors, which the agent runs unless the device spec sets resources. Thresholds that are not set default to the agent's local configuration, then to the built-in thresholds.
+	MonitorThresholds *ResourceMonitorThresholds `json:"monitorThresholds,omitempty"`
+
+	// SpecFetchInterval Interval between polls for a new device spec. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours. Defaults to the agent's local configuration.
+	SpecFetchInterval *string `json:"specFetchInterval,omitempty"`
+
+	// StatusUpdateInterval Interval between device status updates pushed to the service. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours. Defaults to the agent's local configuration.
+	StatusUpdateInterval *string `json:"statusUpdateInterval,omitempty"`
+
+	// Update An update of the agent binary that is independent of the OS image. The updated agent is activated once it reports healthy, otherwise the previous agent is restored. Removing the update reverts to the agent of the OS image.
+	Update *AgentUpdateSpec `json:"update,omitempty"`
+}
+
+// DeviceAgentSpecLogLevel Level of the agent's logs. Defaults to the agent's local configuration.
+type DeviceAgentSpecLogLevel string
+
+// DeviceAgentStatus defines model for DeviceAgentStatus.
+type DeviceAgentStatus struct {
+	// LastRestart The last unexpected restart of the agent since the device booted.
+	LastRestart *DeviceAgentRestart `json:"lastRestart,omitempty"`
+
+	// UpdateMessage Human readable details about the agent update.
+	UpdateMessage *string `json:"updateMessage,omitempty"`
+
+	// UpdateState State of an agent update.
+	UpdateState *DeviceAgentUpdateState `json:"updateState,omitempty"`
+
+	// UpdateVersion Version of the agent update the update state refers to.
+	UpdateVersion *string `json:"updateVersion,omitempty"`
+
+	// Version Version of the running agent.
+	Version string `json:"version"`
+}
+
+// DeviceAgentUpdateState State of an agent update.
+type DeviceAgentUpdateState string
+
+// DeviceAliases DeviceAliases are the names a device is known by besides its name, which is immutable.
+type DeviceAliases struct {
+	// Aliases Further names the device can be found by. Each alias refers to a single device of the organization and is not the name of another device.
+	Aliases *[]string `json:"aliases,omitempty"`
+
+	// DisplayName A human-friendly name of the device shown instead of its name, which is derived from its identity. Unset to remove it.
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+// DeviceApplicationsStatus defines model for DeviceApplicationsStatus.
+type DeviceApplicationsStatus struct {
+	// Data Map of system application statuses.
+	Data map[string]ApplicationStatus `json:"data"`
+
+	// ReportedProperties Map of key/value properties reported by applications running on the device, keyed by application name.
+	ReportedProperties *map[string]map[string]string `json:"reportedProperties,omitempty"`
+	Summary            ApplicationsSummaryStatus     `json:"summary"`
+
+	// Updates Map of the outcomes of the last update of the applications of the device spec, keyed by application name.
+	Updates *map[string]ApplicationUpdateStatus `json:"updates,omitempty"`
+}
+
+// DeviceCPUInfo defines model for DeviceCPUInfo.
+type DeviceCPUInfo struct {
+	// Cores The number of logical CPUs.
+	Cores int32 `json:"cores"`
+
+	// Model The CPU model name.
+	Model *string `json:"model,omitempty"`
+
+	// Vendor The CPU vendor identifier.
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// DeviceCapability A feature of the spec the agent of a device supports: AgentUpdate for spec.agent.update, BootcOSImage for spec.os, ComplianceScans for spec.compliance, ComposeApplications for applications with a compose file, DiskEncryption for spec.encryption, Firewall for spec.firewall, GarbageCollection for spec.garbageCollection, PodApplications for applications with a pod and TimeSync for spec.time.
+type DeviceCapability string
+
+// DeviceCheckChange A change the agent would make to apply the rendered spec.
+type DeviceCheckChange struct {
+	// Change What the agent would do, such as write, remove, bring up, update or take down.
+	Change string `json:"change"`
+
+	// Name The file, application or image the change applies to, unset for a change of the whole section.
+	Name *string `json:"name,omitempty"`
+
+	// Section The section of the spec the change applies, such as config, applications or os.
+	Section string `json:"section"`
+}
+
+// DeviceCheckStatus The changes the agent would make to apply the rendered spec, found by the last check of an agent which only checks the spec.
+type DeviceCheckStatus struct {
+	// Changes The changes applying the rendered version would make, none if the device matches it.
+	Changes []DeviceCheckChange `json:"changes"`
+
+	// CheckedAt Time the agent checked the spec at.
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// RenderedVersion The rendered version the agent checked.
+	RenderedVersion string `json:"renderedVersion"`
+}
+
+// DeviceComplianceSpec The compliance profile the device is scanned against. The agent periodically scans the device with OpenSCAP, which must be installed in the OS image, and reports a summary of the results in status.compliance.
+type DeviceComplianceSpec struct {
+	// Datastream Absolute path of the SCAP source data stream on the device. Defaults to the data stream of the SCAP Security Guide for the OS of the device, such as /usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml.
+	Datastream *string `json:"datastream,omitempty"`
+
+	// Interval How often the agent scans the device, as a duration such as 12h. Defaults to 24h and must be at least 1h.
+	Interval *string `json:"interval,omitempty"`
+
+	// Profile The ID of the XCCDF profile, such as xccdf_org.ssgproject.content_profile_cis, or its short form such as cis.
+	Profile string `json:"profile"`
+}
+
+// DeviceComplianceStatus Summary of the last OpenSCAP scan of the device against the compliance profile of its spec.
+type DeviceComplianceStatus struct {
+	// Datastream The SCAP source data stream the device was scanned with.
+	Datastream *string `json:"datastream,omitempty"`
+
+	// Failed Number of rules of the profile the device fails.
+	Failed int32 `json:"failed"`
+
+	// FailedRules IDs of the rules the device fails, at most 200.
+	FailedRules *[]string `json:"failedRules,omitempty"`
+
+	// Message Why the scan failed, if its status is Error.
+	Message *string `json:"message,omitempty"`
+
+	// Passed Number of rules of the profile the device passes.
+	Passed int32 `json:"passed"`
+
+	// Profile The XCCDF profile the device was scanned against.
+	Profile string `json:"profile"`
+
+	// ScannedAt Time the scan finished at.
+	ScannedAt time.Time `json:"scannedAt"`
+
+	// Status Compliant if the device passes all rules of the profile, NonCompliant if it fails any of them, and Error if the scan failed.
+	Status DeviceComplianceStatusType `json:"status"`
+}
+
+// DeviceComplianceStatusType Compliant if the device passes all rules of the profile, NonCompliant if it fails any of them, and Error if the scan failed.
+type DeviceComplianceStatusType string
+
+// DeviceConfigStatus defines model for DeviceConfigStatus.
+type DeviceConfigStatus struct {
+	// RenderedVersion Version of the device rendered config.
+	RenderedVersion string `json:"renderedVersion"`
+}
+
+// DeviceConsole defines model for DeviceConsole.
+type DeviceConsole struct {
+	// CaBundle The PEM-encoded CAs of the device certificates, against which the operator verifies the device of an end-to-end encrypted session. Only set in the responses to console requests.
+	CaBundle     *string `json:"caBundle,omitempty"`
+	GRPCEndpoint string  `json:"gRPCEndpoint"`
+
+	// PublicKey The base64-encoded X25519 public key of the operator of an end-to-end encrypted session. The agent agrees the key of the session with it.
+	PublicKey *string `json:"publicKey,omitempty"`
+	SessionID string  `json:"sessionID"`
+}
+
+// DeviceCryptoInfo DeviceCryptoInfo describes the crypto configuration of the device.
+type DeviceCryptoInfo struct {
+	// AgentFips Whether the agent is built with FIPS-validated crypto.
+	AgentFips bool `json:"agentFips"`
+
+	// FipsEnabled Whether the kernel of the device runs in FIPS mode.
+	FipsEnabled bool `json:"fipsEnabled"`
+
+	// Policy The system-wide crypto policy of the device, such as DEFAULT or FIPS. Unset if the OS has no crypto policies.
+	Policy *string `json:"policy,omitempty"`
+}
+
+// DeviceCryptoSpec The crypto requirements of the device. The service reports whether the device meets them in its CryptoCompliant condition, the agent does not change the crypto configuration of the device.
+type DeviceCryptoSpec struct {
+	// AllowedPolicies The system-wide crypto policies the device may use. Any policy is allowed if unset.
+	AllowedPolicies *[]string `json:"allowedPolicies,omitempty"`
+
+	// RequireFips Whether the device must run in FIPS mode with an agent built with FIPS-validated crypto.
+	RequireFips *bool `json:"requireFips,omitempty"`
+}
+
+// DeviceDiskInfo defines model for DeviceDiskInfo.
+type DeviceDiskInfo struct {
+	// Model The disk model.
+	Model *string `json:"model,omitempty"`
+
+	// Name The kernel name of the block device, e.g. sda.
+	Name string `json:"name"`
+
+	// Rotational Whether the disk is a rotational (spinning) disk.
+	Rotational *bool `json:"rotational,omitempty"`
+
+	// SerialNumber The disk serial number.
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// SizeBytes The size of the disk in bytes.
+	SizeBytes int64 `json:"sizeBytes"`
+}
+
+// DeviceDriftSpec How the agent handles the drift of the device from its rendered spec between spec changes. The agent periodically checks that the files of the config of the spec, the services of the pod applications and the containers of the compose applications still match the spec, and reports the drift it finds in the Drifted condition and status.drift.
+type DeviceDriftSpec struct {
+	// Interval How often the agent checks the device for drift, as a duration such as 10m. Defaults to 5m and must be at least 1m.
+	Interval *string `json:"interval,omitempty"`
+
+	// Remediation Whether the agent reverts the drift it finds, Remediate by default, or only reports it with Report.
+	Remediation *DriftRemediation `json:"remediation,omitempty"`
+}
+
+// DeviceDriftStatus The drift of the device from its rendered spec found by the last check of the agent.
+type DeviceDriftStatus struct {
+	// CheckedAt Time the agent checked the device at.
+	CheckedAt time.Time `json:"checkedAt"`
+
+	// Containers The containers of the compose applications of the spec which are not running, as application/container, or as application alone if none of its containers exist.
+	Containers []string `json:"containers"`
+
+	// Files The files of the config of the spec which are missing or whose content was changed.
+	Files []string `json:"files"`
+
+	// RemediatedAt Time the agent last reverted the drift at, unset if it never did.
+	RemediatedAt *time.Time `json:"remediatedAt,omitempty"`
+
+	// Units The systemd services of the pod applications of the spec which are not active.
+	Units []string `json:"units"`
+}
+
+// DeviceEncryptionSpec The disk encryption policy of the device. The agent binds the listed LUKS2 volumes to Clevis pins, so that they unlock at boot without a passphrase, and reports whether they comply with the policy. The volumes must be encrypted when provisioning the device, the agent does not encrypt volumes.
+type DeviceEncryptionSpec struct {
+	Volumes []EncryptedVolumeSpec `json:"volumes"`
+}
+
+// DeviceEncryptionStatus Current status of the volumes of the disk encryption policy.
+type DeviceEncryptionStatus struct {
+	Volumes []EncryptedVolumeStatus `json:"volumes"`
+}
+
+// DeviceEvent An out-of-memory kill or a crash of a process of the device, which the agent found in the journal.
+type DeviceEvent struct {
+	// Container The ID of the podman container the process belonged to.
+	Container *string `json:"container,omitempty"`
+
+	// Message Human readable information about the event, such as whether the memory limit of the cgroup of the process or the memory of the device was exhausted.
+	Message string `json:"message"`
+
+	// Pid The ID of the process.
+	Pid *int32 `json:"pid,omitempty"`
+
+	// Process The name of the process.
+	Process *string `json:"process,omitempty"`
+
+	// Time The time the kernel or systemd logged the event at.
+	Time time.Time `json:"time"`
+
+	// Type OOMKilled if the kernel killed the process for lack of memory, Crashed if the process dumped core, such as on a segmentation fault.
+	Type DeviceEventType `json:"type"`
+
+	// Unit The systemd unit the process belonged to.
+	Unit *string `json:"unit,omitempty"`
+}
+
+// DeviceEventType OOMKilled if the kernel killed the process for lack of memory, Crashed if the process dumped core, such as on a segmentation fault.
+type DeviceEventType string
+
+// DeviceExec DeviceExec is the command an Exec action runs on the device. The agent reports the exit code and the end of the output of the command in the message of the action's status.
+type DeviceExec struct {
+	// Args The arguments of the command.
+	Args *[]string `json:"args,omitempty"`
+
+	// Command The absolute path of the command the agent runs, without a shell.
+	Command string `json:"command"`
+}
+
+// DeviceExecRequest DeviceExecRequest requests the agent of a device to run a command allowed by the exec policies of the service.
+type DeviceExecRequest struct {
+	// Args The arguments of the command.
+	Args *[]string `json:"args,omitempty"`
+
+	// Command The absolute path of the command to run, without a shell.
+	Command string `json:"command"`
+
+	// Reason Why the command is run, recorded in the audit log of the service.
+	Reason *string `json:"reason,omitempty"`
+}
+
+// DeviceFirewallSpec The firewall of the incoming traffic of the device, which the agent applies with nftables. Loopback traffic and the replies to the connections the device opens are always accepted before the rules, so that the agent keeps reaching the service.
+type DeviceFirewallSpec struct {
+	// InputPolicy Whether the firewall accepts or drops traffic.
+	InputPolicy *FirewallAction `json:"inputPolicy,omitempty"`
+
+	// Rules The rules of the incoming traffic, the first rule matching a packet deciding whether it is accepted. Traffic no rule matches is handled by the inputPolicy, Accept by default.
+	Rules *[]FirewallRule `json:"rules,omitempty"`
+}
+
+// DeviceGarbageCollectionSpec The policy of the agent for reclaiming the disk space of the device. The agent prunes the container images no container uses, and optionally the unused volumes, whenever the disk holding the container storage is fuller than the threshold, and removes the OS deployments beyond the ones to keep. It reports the result of the last collection in status.garbageCollection.
+type DeviceGarbageCollectionSpec struct {
+	// DiskUsageThreshold The percentage of the disk holding /var/lib/containers used above which the agent prunes unused container images. Defaults to 80.
+	DiskUsageThreshold *int32 `json:"diskUsageThreshold,omitempty"`
+
+	// Interval How often the agent checks the disk usage, as a duration such as 30m. Defaults to 1h and must be at least 5m.
+	Interval *string `json:"interval,omitempty"`
+
+	// KeepOsDeployments The number of previous OS deployments kept to roll back to. Defaults to 1. With 0, the agent removes the rollback deployment once the device booted the OS image of its spec, and the device can no longer roll back to its previous OS image.
+	KeepOsDeployments *int32 `json:"keepOsDeployments,omitempty"`
+
+	// PruneVolumes Whether the agent also prunes the volumes no container uses when pruning images. The volumes the agent created for applications are kept. Defaults to false.
+	PruneVolumes *bool `json:"pruneVolumes,omitempty"`
+}
+
+// DeviceGarbageCollectionStatus The result of the last garbage collection of the agent on the device.
+type DeviceGarbageCollectionStatus struct {
+	// CollectedAt Time the collection finished at.
+	CollectedAt time.Time `json:"collectedAt"`
+
+	// DiskUsagePercent The percentage of the disk holding /var/lib/containers used after the collection.
+	DiskUsagePercent int32 `json:"diskUsagePercent"`
+
+	// Message The errors of the collection, if any step of it failed.
+	Message *string `json:"message,omitempty"`
+
+	// ReclaimedBytes The disk space the collection freed, in bytes.
+	ReclaimedBytes int64 `json:"reclaimedBytes"`
+
+	// RemovedImages Number of container images the collection removed.
+	RemovedImages int32 `json:"removedImages"`
+
+	// RemovedOsDeployments Number of OS deployments the collection removed.
+	RemovedOsDeployments int32 `json:"removedOsDeployments"`
+
+	// RemovedVolumes Number of volumes the collection removed.
+	RemovedVolumes int32 `json:"removedVolumes"`
+}
+
+// DeviceHardwareInfo DeviceHardwareInfo describes the hardware of the device. It is refreshed periodically by the agent.
+type DeviceHardwareInfo struct {
+	Cpu DeviceCPUInfo `json:"cpu"`
+
+	// Disks The block devices of the device, excluding virtual ones.
+	Disks []DeviceDiskInfo `json:"disks"`
+
+	// GpuPresent Whether a display controller (GPU) is present.
+	GpuPresent bool `json:"gpuPresent"`
+
+	// MemoryBytes Total physical memory in bytes.
+	MemoryBytes int64 `json:"memoryBytes"`
+
+	// NetworkInterfaces The physical network interfaces of the device.
+	NetworkInterfaces []DeviceNetworkInterfaceInfo `json:"networkInterfaces"`
+
+	// ProductName The system product name or model as reported by the firmware.
+	ProductName *string `json:"productName,omitempty"`
+
+	// SerialNumber The system serial number as reported by the firmware.
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// Vendor The system vendor as reported by the firmware.
+	Vendor *string `json:"vendor,omitempty"`
+}
+
+// DeviceHookStatus Result of the last action run by a device lifecycle hook.
+type DeviceHookStatus struct {
+	// DurationSeconds How long the action ran.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// ExitCode Exit code of an executable action.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// FinishedAt Time the action finished at.
+	FinishedAt time.Time `json:"finishedAt"`
+
+	// Message Error of a failed action.
+	Message *string `json:"message,omitempty"`
+
+	// Name Name of the hook, or the path it watches if it has no name.
+	Name string `json:"name"`
+
+	// Path Path of the file whose change ran the action.
+	Path string `json:"path"`
+
+	// Stderr End of the standard error of an executable action, truncated to its last 4 KiB.
+	Stderr *string `json:"stderr,omitempty"`
+
+	// Stdout End of the standard output of an executable action, truncated to its last 4 KiB.
+	Stdout *string `json:"stdout,omitempty"`
+
+	// Succeeded Whether the action succeeded.
+	Succeeded bool `json:"succeeded"`
+}
+
+// DeviceHooksSpec defines model for DeviceHooksSpec.
+type DeviceHooksSpec struct {
+	// AfterRebooting Hooks executed after rebooting enable custom actions and integration with other systems
+	// or services. These actions occur after the device has rebooted, allowing for post-reboot tasks.
+	AfterRebooting *[]DeviceRebootHookSpec `json:"afterRebooting,omitempty"`
+
+	// AfterUpdating Hooks executed after updating enable custom actions and integration with other systems
+	// or services. These actions occur after configuration changes have been applied to the device.
+	AfterUpdating *[]DeviceUpdateHookSpec `json:"afterUpdating,omitempty"`
+
+	// BeforeRebooting Hooks executed before rebooting allow for custom actions and integration with other systems
+	// or services. These actions occur before the device is rebooted.
+	BeforeRebooting *[]DeviceRebootHookSpec `json:"beforeRebooting,omitempty"`
+
+	// BeforeUpdating Hooks executed before updating allow for custom actions and integration with other systems
+	// or services. These actions occur before configuration changes are applied to the device.
+	BeforeUpdating *[]DeviceUpdateHookSpec `json:"beforeUpdating,omitempty"`
+}
+
+// DeviceIdentity DeviceIdentity pre-assigns the initial configuration of a machine that is not enrolled yet, identified by its hardware.
+type DeviceIdentity struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec DeviceIdentitySpec identifies a machine by at least one of its serial number, MAC address and TPM endorsement key. A machine matches only if all of those set match.
+	Spec DeviceIdentitySpec `json:"spec"`
+
+	// Status DeviceIdentityStatus represents information about the provisioning of a DeviceIdentity.
+	Status *DeviceIdentityStatus `json:"status,omitempty"`
+}
+
+// DeviceIdentityList DeviceIdentityList is a list of DeviceIdentities.
+type DeviceIdentityList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of device identities.
+	Items []DeviceIdentity `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// DeviceIdentitySpec DeviceIdentitySpec identifies a machine by at least one of its serial number, MAC address and TPM endorsement key. A machine matches only if all of those set match.
+type DeviceIdentitySpec struct {
+	// Labels The labels the device gets when it enrolls, which select it into its fleet.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// MacAddress The MAC address of one of the network interfaces of the machine, in lowercase and separated by colons.
+	MacAddress *string `json:"macAddress,omitempty"`
+
+	// SerialNumber The serial number of the machine, as reported by its firmware.
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// TpmEkHash The lowercase hex encoded SHA-256 hash of the public endorsement key of the machine's TPM, in PKIX form.
+	TpmEkHash *string `json:"tpmEkHash,omitempty"`
+}
+
+// DeviceIdentityStatus DeviceIdentityStatus represents information about the provisioning of a DeviceIdentity.
+type DeviceIdentityStatus struct {
+	// ProvisionedAt The time the machine fetched its provisioning configuration. Machines can only be provisioned once.
+	ProvisionedAt *time.Time `json:"provisionedAt,omitempty"`
+}
+
+// DeviceIntegrityStatus defines model for DeviceIntegrityStatus.
+type DeviceIntegrityStatus struct {
+	Summary DeviceIntegrityStatusSummary `json:"summary"`
+}
+
+// DeviceIntegrityStatusSummary defines model for DeviceIntegrityStatusSummary.
+type DeviceIntegrityStatusSummary struct {
+	// Info Human readable information about the last integrity transition.
+	Info   *string                          `json:"info,omitempty"`
+	Status DeviceIntegrityStatusSummaryType `json:"status"`
 }
 
 // DeviceIntegrityStatusSummaryType defines model for DeviceIntegrityStatusSummaryType.
@@ -360,10 +1494,64 @@ type DeviceList struct {
 	Summary *DevicesSummary `json:"summary,omitempty"`
 }
 
+// DeviceLocation Geographic location of a device in WGS 84 coordinates.
+type DeviceLocation struct {
+	// AccuracyMeters Estimated horizontal accuracy of the location in meters.
+	AccuracyMeters *float64 `json:"accuracyMeters,omitempty"`
+
+	// Latitude Latitude in degrees, positive north of the equator.
+	Latitude float64 `json:"latitude"`
+
+	// Longitude Longitude in degrees, positive east of the prime meridian.
+	Longitude float64 `json:"longitude"`
+
+	// Source Source the agent determined the location from.
+	Source DeviceLocationSource `json:"source"`
+
+	// UpdatedAt Time at which the agent determined the location.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeviceLocationSource Source the agent determined the location from.
+type DeviceLocationSource string
+
+// DeviceMigration DeviceMigration re-points the agent of a device to another Flight Control instance, which it keeps its certificate and its current spec with. The device must be imported into that instance first.
+type DeviceMigration struct {
+	// CaBundle The PEM bundle of the CA the agent endpoints of the instance the device migrates to are verified with. Defaults to the CA the device currently trusts.
+	CaBundle *string `json:"caBundle,omitempty"`
+
+	// GrpcManagementEndpoint The URL of the gRPC agent endpoint of the instance the device migrates to. The console is unavailable after the migration if it is not set.
+	GrpcManagementEndpoint *string `json:"grpcManagementEndpoint,omitempty"`
+
+	// ManagementEndpoint The URL of the agent endpoint of the Flight Control instance the device migrates to.
+	ManagementEndpoint string `json:"managementEndpoint"`
+}
+
+// DeviceMigrationResult defines model for DeviceMigrationResult.
+type DeviceMigrationResult struct {
+	// Devices The number of devices of the fleet whose migration was requested or canceled.
+	Devices int64 `json:"devices"`
+}
+
+// DeviceNetworkInterfaceInfo defines model for DeviceNetworkInterfaceInfo.
+type DeviceNetworkInterfaceInfo struct {
+	// MacAddress The hardware (MAC) address of the network interface.
+	MacAddress string `json:"macAddress"`
+
+	// Name The name of the network interface.
+	Name string `json:"name"`
+}
+
 // DeviceOSSpec defines model for DeviceOSSpec.
 type DeviceOSSpec struct {
+	// ArchitectureImages The OS images for devices reporting each architecture in status.systemInfo.architecture, such as amd64 or arm64. Devices of other architectures, or which have not reported their architecture yet, use the image. Cannot be combined with a stream.
+	ArchitectureImages *map[string]string `json:"architectureImages,omitempty"`
+
 	// Image ostree image name or URL.
 	Image string `json:"image"`
+
+	// Stream A tag of the repository of the image to follow, such as 9-stable. In a fleet template, the image names the repository without a tag or digest, and the service pins it by the digest the tag resolves to each time it renders the template, rolling the fleet out when the tag moves. The image of a device following a stream is pinned by digest.
+	Stream *string `json:"stream,omitempty"`
 }
 
 // DeviceOSStatus defines model for DeviceOSStatus.
@@ -372,6 +1560,23 @@ type DeviceOSStatus struct {
 	Image string `json:"image"`
 }
 
+// DeviceProvenanceStatus The images of the rendered version the device applied last, with the digests the service resolved them to when rendering it.
+type DeviceProvenanceStatus struct {
+	Images []ImageDigest `json:"images"`
+
+	// RenderedVersion The rendered version the images are of.
+	RenderedVersion string `json:"renderedVersion"`
+}
+
+// DeviceQuarantine DeviceQuarantine isolates a compromised or misbehaving device. A quarantined device keeps its current configuration and reporting its status, but is served no new rendered specs and no console sessions.
+type DeviceQuarantine struct {
+	// Reason Why the device is quarantined, for example the incident it is part of.
+	Reason string `json:"reason"`
+
+	// StopApplications Whether the agent stops the applications of the device while it is quarantined. The applications are started again once the device is released.
+	StopApplications *bool `json:"stopApplications,omitempty"`
+}
+
 // DeviceRebootHookSpec defines model for DeviceRebootHookSpec.
 type DeviceRebootHookSpec struct {
 	// Actions The actions taken before and after system reboots are observed. Each action is executed in the order they are defined.
@@ -392,42 +1597,150 @@ type DeviceResourceStatusType string
 
 // DeviceSpec defines model for DeviceSpec.
 type DeviceSpec struct {
+	// Agent Settings that control how the agent communicates with the service.
+	Agent *DeviceAgentSpec `json:"agent,omitempty"`
+
+	// Applications The applications of the device whose updates the agent applies with their update strategy.
+	Applications *[]ApplicationSpec `json:"applications,omitempty"`
+
+	// Compliance The compliance profile the device is scanned against. The agent periodically scans the device with OpenSCAP, which must be installed in the OS image, and reports a summary of the results in status.compliance.
+	Compliance *DeviceComplianceSpec `json:"compliance,omitempty"`
+
 	// Config List of config resources.
 	Config     *[]DeviceSpec_Config_Item `json:"config,omitempty"`
 	Containers *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"containers,omitempty"`
-	Hooks *DeviceHooksSpec `json:"hooks,omitempty"`
-	Os    *DeviceOSSpec    `json:"os,omitempty"`
+
+	// Crypto The crypto requirements of the device. The service reports whether the device meets them in its CryptoCompliant condition, the agent does not change the crypto configuration of the device.
+	Crypto *DeviceCryptoSpec `json:"crypto,omitempty"`
+
+	// Drift How the agent handles the drift of the device from its rendered spec between spec changes. The agent periodically checks that the files of the config of the spec, the services of the pod applications and the containers of the compose applications still match the spec, and reports the drift it finds in the Drifted condition and status.drift.
+	Drift *DeviceDriftSpec `json:"drift,omitempty"`
+
+	// Encryption The disk encryption policy of the device. The agent binds the listed LUKS2 volumes to Clevis pins, so that they unlock at boot without a passphrase, and reports whether they comply with the policy. The volumes must be encrypted when provisioning the device, the agent does not encrypt volumes.
+	Encryption *DeviceEncryptionSpec `json:"encryption,omitempty"`
+
+	// Firewall The firewall of the incoming traffic of the device, which the agent applies with nftables. Loopback traffic and the replies to the connections the device opens are always accepted before the rules, so that the agent keeps reaching the service.
+	Firewall *DeviceFirewallSpec `json:"firewall,omitempty"`
+
+	// GarbageCollection The policy of the agent for reclaiming the disk space of the device. The agent prunes the container images no container uses, and optionally the unused volumes, whenever the disk holding the container storage is fuller than the threshold, and removes the OS deployments beyond the ones to keep. It reports the result of the last collection in status.garbageCollection.
+	GarbageCollection *DeviceGarbageCollectionSpec `json:"garbageCollection,omitempty"`
+	Hooks             *DeviceHooksSpec             `json:"hooks,omitempty"`
+	Os                *DeviceOSSpec                `json:"os,omitempty"`
 
 	// Resources Array of resource monitor configurations.
 	Resources *[]ResourceMonitor `json:"resources,omitempty"`
 	Systemd   *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"systemd,omitempty"`
+
+	// Time The time synchronization of the device. The agent configures chrony with the NTP sources in addition to those of the chrony configuration of the OS image.
+	Time *DeviceTimeSpec `json:"time,omitempty"`
+}
+
+// DeviceSpec_Config_Item defines model for DeviceSpec.config.Item.
+type DeviceSpec_Config_Item struct {
+	union json.RawMessage
+}
+
+// DeviceStatus DeviceStatus represents information about the status of a device. Status may trail the actual state of a device.
+type DeviceStatus struct {
+	// Accelerators Current status of the GPUs and other accelerators of the device. Only reported when enabled in the agent configuration.
+	Accelerators *[]DeviceAcceleratorStatus `json:"accelerators,omitempty"`
+
+	// Adoption The state the agent found on the device it was enrolled from, when its agent is configured to adopt the existing state. The agent holds back the first rendered spec until an EnforceSpec action is requested for the device, so that an operator can review what the spec replaces.
+	Adoption *DeviceAdoptionStatus `json:"adoption,omitempty"`
+	Agent    *DeviceAgentStatus    `json:"agent,omitempty"`
+
+	// Annotations Annotations written by the agent to inform the operator about the device, such as a recommended disk replacement or a detected hardware change. Unlike conditions, they are free-form key/value pairs keyed like metadata annotations, and devices can be selected by them. Kept by the service when an agent does not report them.
+	Annotations  *map[string]string       `json:"annotations,omitempty"`
+	Applications DeviceApplicationsStatus `json:"applications"`
+
+	// Certificates The certificates the service issued to the device. Filled in by the service when reading a single device.
+	Certificates *[]IssuedCertificate `json:"certificates,omitempty"`
+
+	// Check The changes the agent would make to apply the rendered spec, found by the last check of an agent which only checks the spec.
+	Check *DeviceCheckStatus `json:"check,omitempty"`
+
+	// Compliance Summary of the last OpenSCAP scan of the device against the compliance profile of its spec.
+	Compliance *DeviceComplianceStatus `json:"compliance,omitempty"`
+
+	// Conditions Conditions represent the observations of a the current state of a device.
+	Conditions []Condition        `json:"conditions"`
+	Config     DeviceConfigStatus `json:"config"`
+
+	// Drift The drift of the device from its rendered spec found by the last check of the agent.
+	Drift *DeviceDriftStatus `json:"drift,omitempty"`
+
+	// Encryption Current status of the volumes of the disk encryption policy.
+	Encryption *DeviceEncryptionStatus `json:"encryption,omitempty"`
+
+	// Events The out-of-memory kills and crashes of the workloads of the device in the last 24 hours, the newest last.
+	Events *[]DeviceEvent `json:"events,omitempty"`
+
+	// GarbageCollection The result of the last garbage collection of the agent on the device.
+	GarbageCollection *DeviceGarbageCollectionStatus `json:"garbageCollection,omitempty"`
+
+	// Hooks The result of the last action run by each device lifecycle hook.
+	Hooks     *[]DeviceHookStatus   `json:"hooks,omitempty"`
+	Integrity DeviceIntegrityStatus `json:"integrity"`
+
+	// LastAction DeviceActionStatus is the progress of the last action the agent received. The agent acknowledges an action before carrying it out, and reports it completed once it runs again after the reboot or restart.
+	LastAction *DeviceActionStatus `json:"lastAction,omitempty"`
+	LastSeen   time.Time           `json:"lastSeen"`
+
+	// Location Geographic location of a device in WGS 84 coordinates.
+	Location *DeviceLocation `json:"location,omitempty"`
+
+	// ObservedGeneration The metadata.generation of the device spec last rendered by the service. The service has processed the current spec once it equals metadata.generation.
+	ObservedGeneration *int64         `json:"observedGeneration,omitempty"`
+	Os                 DeviceOSStatus `json:"os"`
+
+	// Provenance The images of the rendered version the device applied last, with the digests the service resolved them to when rendering it.
+	Provenance *DeviceProvenanceStatus `json:"provenance,omitempty"`
+	Resources  DeviceResourceStatus    `json:"resources"`
+	Summary    DeviceSummaryStatus     `json:"summary"`
+
+	// SystemInfo DeviceSystemInfo is a set of ids/uuids to uniquely identify the device.
+	SystemInfo DeviceSystemInfo `json:"systemInfo"`
+
+	// Time Current state of the time synchronization of the device, as reported by chrony.
+	Time *DeviceTimeStatus `json:"time,omitempty"`
+
+	// UpdateHistory The last updates of the device to a new rendered version, from which the rollouts estimate how long its updates take and the bandwidth they use.
+	UpdateHistory *DeviceUpdateHistory `json:"updateHistory,omitempty"`
+
+	// UpdateProgress The progress of the update of the device to a rendered version, unset once the device runs it.
+	UpdateProgress *DeviceUpdateProgress `json:"updateProgress,omitempty"`
+	Updated        DeviceUpdatedStatus   `json:"updated"`
 }
 
-// DeviceSpec_Config_Item defines model for DeviceSpec.config.Item.
-type DeviceSpec_Config_Item struct {
-	union json.RawMessage
+// DeviceStatusBatch DeviceStatusBatch holds the statuses of at most 1000 devices, which replace their current statuses as by replaceDeviceStatus.
+type DeviceStatusBatch struct {
+	// Devices The devices whose statuses are replaced, each with its name, its status and optionally the resource version its status replaces.
+	Devices []Device `json:"devices"`
 }
 
-// DeviceStatus DeviceStatus represents information about the status of a device. Status may trail the actual state of a device.
-type DeviceStatus struct {
-	Applications DeviceApplicationsStatus `json:"applications"`
+// DeviceStatusBatchResult DeviceStatusBatchResult reports the outcome of a batch of statuses. The devices whose statuses were not replaced do not prevent the others from being replaced.
+type DeviceStatusBatchResult struct {
+	// Results The results for the devices, in the order of the batch.
+	Results []DeviceStatusResult `json:"results"`
 
-	// Conditions Conditions represent the observations of a the current state of a device.
-	Conditions []Condition           `json:"conditions"`
-	Config     DeviceConfigStatus    `json:"config"`
-	Integrity  DeviceIntegrityStatus `json:"integrity"`
-	LastSeen   time.Time             `json:"lastSeen"`
-	Os         DeviceOSStatus        `json:"os"`
-	Resources  DeviceResourceStatus  `json:"resources"`
-	Summary    DeviceSummaryStatus   `json:"summary"`
+	// Updated The number of devices whose statuses were replaced.
+	Updated int64 `json:"updated"`
+}
 
-	// SystemInfo DeviceSystemInfo is a set of ids/uuids to uniquely identify the device.
-	SystemInfo DeviceSystemInfo    `json:"systemInfo"`
-	Updated    DeviceUpdatedStatus `json:"updated"`
+// DeviceStatusResult defines model for DeviceStatusResult.
+type DeviceStatusResult struct {
+	// Code The HTTP status code replaceDeviceStatus would have responded with for the device, 200 if its status was replaced.
+	Code int32 `json:"code"`
+
+	// Message Why the status was not replaced.
+	Message *string `json:"message,omitempty"`
+
+	// Name The name of the device.
+	Name string `json:"name"`
 }
 
 // DeviceSummaryStatus defines model for DeviceSummaryStatus.
@@ -448,22 +1761,193 @@ type DeviceSystemInfo struct {
 	// BootID Boot ID reported by the device.
 	BootID string `json:"bootID"`
 
+	// Capabilities The features of the spec the agent of the device supports. Unset if the agent does not report its capabilities, in which case the service assumes it supports all features.
+	Capabilities *[]DeviceCapability `json:"capabilities,omitempty"`
+
+	// Crypto DeviceCryptoInfo describes the crypto configuration of the device.
+	Crypto *DeviceCryptoInfo `json:"crypto,omitempty"`
+
+	// Hardware DeviceHardwareInfo describes the hardware of the device. It is refreshed periodically by the agent.
+	Hardware *DeviceHardwareInfo `json:"hardware,omitempty"`
+
 	// OperatingSystem The Operating System reported by the device.
 	OperatingSystem string `json:"operatingSystem"`
 }
 
+// DeviceTimeSpec The time synchronization of the device. The agent configures chrony with the NTP sources in addition to those of the chrony configuration of the OS image.
+type DeviceTimeSpec struct {
+	// MaxSkewSeconds Offset of the device clock from the clock of the service beyond which the service flags the device in its ClockSkewed condition. Defaults to 10.
+	MaxSkewSeconds *int32 `json:"maxSkewSeconds,omitempty"`
+
+	// Pools Host names of NTP pools, which resolve to several servers.
+	Pools *[]string `json:"pools,omitempty"`
+
+	// Servers Host names or IP addresses of NTP servers.
+	Servers *[]string `json:"servers,omitempty"`
+}
+
+// DeviceTimeStatus Current state of the time synchronization of the device, as reported by chrony.
+type DeviceTimeStatus struct {
+	// OffsetSeconds Offset of the system clock from NTP time estimated by chrony, positive if the clock is ahead.
+	OffsetSeconds *float64 `json:"offsetSeconds,omitempty"`
+
+	// ReportedAt Time of the device clock when the agent sent the status. The service compares it with the time it received the status to detect skewed clocks.
+	ReportedAt time.Time `json:"reportedAt"`
+
+	// Source Name or address of the NTP source chrony synchronizes with.
+	Source *string `json:"source,omitempty"`
+
+	// Stratum NTP stratum of the system clock.
+	Stratum *int32 `json:"stratum,omitempty"`
+
+	// Synchronized Whether chrony synchronizes the system clock with an NTP source.
+	Synchronized bool `json:"synchronized"`
+}
+
+// DeviceTunnel DeviceTunnel is a support tunnel forwarding the TCP connections of an operator to a service listening on localhost of a device, such as a vendor web UI. The tunnel is encrypted end to end between the operator and the device, and closes once its TTL expires.
+type DeviceTunnel struct {
+	// CaBundle The PEM-encoded CAs of the device certificates, against which the operator verifies the device. Only set in the responses to tunnel requests.
+	CaBundle *string `json:"caBundle,omitempty"`
+
+	// ExpiresAt The time the tunnel closes.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// GRPCEndpoint The gRPC endpoint the operator and the device connect the tunnel to.
+	GRPCEndpoint *string `json:"gRPCEndpoint,omitempty"`
+
+	// Id Unique ID of the tunnel, which pairs the streams of the operator and the device.
+	Id string `json:"id"`
+
+	// Port The TCP port of the service on localhost of the device.
+	Port int32 `json:"port"`
+
+	// PublicKey The base64-encoded X25519 public key of the operator.
+	PublicKey string `json:"publicKey"`
+
+	// Reason Why the tunnel is needed.
+	Reason string `json:"reason"`
+
+	// RequestedAt The time the tunnel was requested.
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// RequestedBy The name the user who opened the tunnel gave, which the service does not verify.
+	RequestedBy string `json:"requestedBy"`
+
+	// Ttl How long the tunnel stays open.
+	Ttl string `json:"ttl"`
+}
+
+// DeviceTunnelRequest defines model for DeviceTunnelRequest.
+type DeviceTunnelRequest struct {
+	// Port The TCP port of the service on localhost of the device, which the service configuration must allow tunnels to.
+	Port int32 `json:"port"`
+
+	// PublicKey The base64-encoded X25519 public key of the operator, with which the agent agrees the key encrypting the tunnel end to end.
+	PublicKey string `json:"publicKey"`
+
+	// Reason Why the tunnel is needed, recorded in the audit log of the service.
+	Reason string `json:"reason"`
+
+	// RequestedBy The name of the user opening the tunnel, as they give it, recorded in the audit log of the service. The service does not verify it.
+	RequestedBy string `json:"requestedBy"`
+
+	// Ttl How long the tunnel stays open. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours. Defaults to, and cannot exceed, the maximum TTL of the service.
+	Ttl *string `json:"ttl,omitempty"`
+}
+
+// DeviceUnmanagedContainer A container the agent found on the device which it does not manage.
+type DeviceUnmanagedContainer struct {
+	// Image Image the container runs.
+	Image string `json:"image"`
+
+	// Name Name of the container.
+	Name string `json:"name"`
+
+	// Project The compose project of the container, if it was brought up with podman-compose.
+	Project *string `json:"project,omitempty"`
+
+	// State State of the container as reported by podman, such as running or exited.
+	State string `json:"state"`
+}
+
+// DeviceUpdateHistory The last updates of the device to a new rendered version, from which the rollouts estimate how long its updates take and the bandwidth they use.
+type DeviceUpdateHistory struct {
+	// AverageDownloadedBytes The average bytes of the OS images downloaded by the updates.
+	AverageDownloadedBytes int64 `json:"averageDownloadedBytes"`
+
+	// AverageDurationSeconds The average duration of the updates in seconds.
+	AverageDurationSeconds int64 `json:"averageDurationSeconds"`
+
+	// Updates The last updates of the device, the newest last, up to 10.
+	Updates []DeviceUpdateRecord `json:"updates"`
+}
+
 // DeviceUpdateHookSpec defines model for DeviceUpdateHookSpec.
 type DeviceUpdateHookSpec struct {
 	// Actions The actions to take when the specified file operations are observed. Each action is executed in the order they are defined.
-	Actions     []HookAction     `json:"actions"`
+	Actions []HookAction `json:"actions"`
+
+	// Batch Whether the actions run once per update for all the changed files matching the path, rather than once for each of them. The changed files are passed to executable actions in the FLIGHTCTL_CHANGED_FILES environment variable and the ChangedFiles token either way.
+	Batch       *bool            `json:"batch,omitempty"`
 	Description *string          `json:"description,omitempty"`
 	Name        *string          `json:"name,omitempty"`
 	OnFile      *[]FileOperation `json:"onFile,omitempty"`
 
-	// Path The path to monitor for changes in configuration files. This path can point to either a specific file or an entire directory.
+	// Path The path to monitor for changes in configuration files. This path can point to either a specific file or an entire directory, or be a glob pattern matching files or directories, such as /etc/nginx/conf.d/*.conf.
 	Path *string `json:"path,omitempty"`
 }
 
+// DeviceUpdatePhase The phase of the update: RunningHooks while the before updating hooks run, WritingConfig while the files of the config are written, UpdatingApplications while the applications are brought up or updated, DownloadingImages while the OS image is downloaded and AwaitingReboot once the device reboots into it.
+type DeviceUpdatePhase string
+
+// DeviceUpdateProgress The progress of the update of the device to a rendered version, unset once the device runs it.
+type DeviceUpdateProgress struct {
+	// DownloadedBytes The bytes of the image downloaded so far.
+	DownloadedBytes *int64 `json:"downloadedBytes,omitempty"`
+
+	// DownloadedLayers The layers of the image downloaded so far.
+	DownloadedLayers *int32 `json:"downloadedLayers,omitempty"`
+
+	// Image The image the device downloads in the DownloadingImages phase.
+	Image *string `json:"image,omitempty"`
+
+	// Message Human readable details about the phase.
+	Message *string `json:"message,omitempty"`
+
+	// Percentage The estimated percentage of the update done, which restarts from the phase the update continues with after a reboot.
+	Percentage int32 `json:"percentage"`
+
+	// Phase The phase of the update: RunningHooks while the before updating hooks run, WritingConfig while the files of the config are written, UpdatingApplications while the applications are brought up or updated, DownloadingImages while the OS image is downloaded and AwaitingReboot once the device reboots into it.
+	Phase DeviceUpdatePhase `json:"phase"`
+
+	// RenderedVersion The rendered version the device updates to.
+	RenderedVersion string `json:"renderedVersion"`
+
+	// TotalBytes The bytes of the image to download.
+	TotalBytes *int64 `json:"totalBytes,omitempty"`
+
+	// TotalLayers The layers of the image to download.
+	TotalLayers *int32 `json:"totalLayers,omitempty"`
+
+	// UpdatedAt Time the progress was last reported at.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeviceUpdateRecord An update of the device to a new rendered version.
+type DeviceUpdateRecord struct {
+	// CompletedAt Time the device ran the rendered version, after the reboot into its OS image if any.
+	CompletedAt time.Time `json:"completedAt"`
+
+	// DownloadedBytes The bytes of the OS image downloaded by the update. The images of the applications are not counted.
+	DownloadedBytes int64 `json:"downloadedBytes"`
+
+	// RenderedVersion The rendered version the device updated to.
+	RenderedVersion string `json:"renderedVersion"`
+
+	// StartedAt Time the device started the update.
+	StartedAt time.Time `json:"startedAt"`
+}
+
 // DeviceUpdatedStatus defines model for DeviceUpdatedStatus.
 type DeviceUpdatedStatus struct {
 	// Info Human readable information about the last device update transition.
@@ -474,8 +1958,110 @@ type DeviceUpdatedStatus struct {
 // DeviceUpdatedStatusType defines model for DeviceUpdatedStatusType.
 type DeviceUpdatedStatusType string
 
+// DeviceView DeviceView is a saved search of devices, with the columns they are shown with, shared by all users of the organization.
+type DeviceView struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec DeviceViewSpec describes which devices a device view selects and how they are shown.
+	Spec DeviceViewSpec `json:"spec"`
+}
+
+// DeviceViewColumn A column of a device view.
+type DeviceViewColumn string
+
+// DeviceViewList DeviceViewList is a list of DeviceViews.
+type DeviceViewList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of device views.
+	Items []DeviceView `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// DeviceViewSelector DeviceViewSelector restricts the devices of a device view with the same filters as listing devices. All of the filters set must be met.
+type DeviceViewSelector struct {
+	// Alias Restricts the devices to those whose name, display name or one of whose aliases is the value.
+	Alias *string `json:"alias,omitempty"`
+
+	// AnnotationSelector A selector to restrict the devices by the annotations written by their agent in status.annotations, as comma-separated "key" or "key=value" requirements.
+	AnnotationSelector *string `json:"annotationSelector,omitempty"`
+
+	// ApplicationRestarts Restricts the devices to those with an application which restarted at least this many times.
+	ApplicationRestarts *int32 `json:"applicationRestarts,omitempty"`
+
+	// BoundingBox A bounding box to restrict the devices to those whose reported location lies within it, as "west,south,east,north" in degrees.
+	BoundingBox *string `json:"boundingBox,omitempty"`
+
+	// LabelSelector A selector to restrict the devices by their labels, as comma-separated key=value requirements.
+	LabelSelector *string `json:"labelSelector,omitempty"`
+
+	// Owner A selector to restrict the devices by their owner, for example Fleet/emea.
+	Owner *string `json:"owner,omitempty"`
+
+	// StatusFilter Filters to restrict the devices by the value of status keys, for example summary.status=Degraded.
+	StatusFilter *[]string `json:"statusFilter,omitempty"`
+}
+
+// DeviceViewSpec DeviceViewSpec describes which devices a device view selects and how they are shown.
+type DeviceViewSpec struct {
+	// Columns The columns the devices of the view are shown with, in order. Defaults to the columns of the device list.
+	Columns *[]DeviceViewColumn `json:"columns,omitempty"`
+
+	// Description What the devices of the view have in common, for example "degraded ARM devices in EMEA".
+	Description *string `json:"description,omitempty"`
+
+	// Selector DeviceViewSelector restricts the devices of a device view with the same filters as listing devices. All of the filters set must be met.
+	Selector DeviceViewSelector `json:"selector"`
+}
+
+// DeviceWake DeviceWake is the WakeOnLan action requested for a device at the site of the device to wake. Its outcome is reported in the status.lastAction of the peer.
+type DeviceWake struct {
+	// Action DeviceAction is an action requested for a device. It is served to the agent with the rendered spec until the agent acknowledges it, and carried out once.
+	Action DeviceAction `json:"action"`
+
+	// Peer The name of the device which sends the Wake-on-LAN packet.
+	Peer string `json:"peer"`
+}
+
+// DeviceWakeOnLan DeviceWakeOnLan is the device which a WakeOnLan action sends a Wake-on-LAN packet to, over the local network of the device carrying out the action.
+type DeviceWakeOnLan struct {
+	// MacAddresses The hardware (MAC) addresses of the network interfaces of the device to wake.
+	MacAddresses []string `json:"macAddresses"`
+
+	// Target The name of the device to wake.
+	Target string `json:"target"`
+}
+
+// DeviceWakeRequest DeviceWakeRequest requests to wake a powered off device through another device at the same site.
+type DeviceWakeRequest struct {
+	// Peer The name of the device at the same site to send the Wake-on-LAN packet. Defaults to the online device at the site seen most recently.
+	Peer *string `json:"peer,omitempty"`
+
+	// Reason Why the device is woken, recorded in the audit log of the service.
+	Reason *string `json:"reason,omitempty"`
+
+	// SiteLabel The key of the label whose value names the site of the devices. Defaults to "site".
+	SiteLabel *string `json:"siteLabel,omitempty"`
+}
+
 // DevicesSummary A summary of the devices in the fleet returned when fetching a single Fleet.
 type DevicesSummary struct {
+	// ComplianceStatus A breakdown of the devices in the fleet reporting a compliance scan by "compliance" status.
+	ComplianceStatus *map[string]int `json:"complianceStatus,omitempty"`
+
 	// SummaryStatus A breakdown of the devices in the fleet by "summary" status.
 	SummaryStatus *map[string]int `json:"summaryStatus,omitempty"`
 
@@ -499,13 +2085,54 @@ type DiskResourceMonitorSpec struct {
 	SamplingInterval string `json:"samplingInterval"`
 }
 
+// DriftRemediation Whether the agent reverts the drift it finds, Remediate by default, or only reports it with Report.
+type DriftRemediation string
+
+// EncryptedVolumeSpec The Clevis pins a LUKS2 volume is bound to. At least one pin must be set.
+type EncryptedVolumeSpec struct {
+	// Device Path of the LUKS2 block device, such as /dev/disk/by-partlabel/root.
+	Device string `json:"device"`
+
+	// KeyFile Path on the device of a key file unlocking the volume, which the agent uses to add the first Clevis binding. Volumes already bound to Clevis are rebound without it.
+	KeyFile *string `json:"keyFile,omitempty"`
+
+	// Tang Tang servers the volume is bound to.
+	Tang *[]TangPinSpec `json:"tang,omitempty"`
+
+	// Threshold Number of pins that must be available to unlock the volume. Defaults to 1.
+	Threshold *int `json:"threshold,omitempty"`
+
+	// Tpm2 Binds the volume to the TPM of the device.
+	Tpm2 *TpmPinSpec `json:"tpm2,omitempty"`
+}
+
+// EncryptedVolumeState Whether a volume complies with the disk encryption policy.
+type EncryptedVolumeState string
+
+// EncryptedVolumeStatus defines model for EncryptedVolumeStatus.
+type EncryptedVolumeStatus struct {
+	// Device Path of the block device of the volume.
+	Device string `json:"device"`
+
+	// Message Human readable details about the state of the volume.
+	Message *string `json:"message,omitempty"`
+
+	// Pins Types of the Clevis pins the volume is bound to.
+	Pins *[]string `json:"pins,omitempty"`
+
+	// State Whether a volume complies with the disk encryption policy.
+	State EncryptedVolumeState `json:"state"`
+}
+
 // EnrollmentConfig defines model for EnrollmentConfig.
 type EnrollmentConfig struct {
-	EnrollmentService      EnrollmentService `json:"enrollment-service"`
-	GrpcManagementEndpoint string            `json:"grpc-management-endpoint"`
-	SpecFetchInterval      string            `json:"spec-fetch-interval"`
-	StatusUpdateInterval   string            `json:"status-update-interval"`
-	TpmPath                string            `json:"tpm-path"`
+	// DefaultLabels Labels the agent applies to the device when it enrolls.
+	DefaultLabels          *map[string]string `json:"default-labels,omitempty"`
+	EnrollmentService      EnrollmentService  `json:"enrollment-service"`
+	GrpcManagementEndpoint string             `json:"grpc-management-endpoint"`
+	SpecFetchInterval      string             `json:"spec-fetch-interval"`
+	StatusUpdateInterval   string             `json:"status-update-interval"`
+	TpmPath                string             `json:"tpm-path"`
 }
 
 // EnrollmentRequest EnrollmentRequest represents a request for approval to enroll a device.
@@ -539,6 +2166,9 @@ type EnrollmentRequestApproval struct {
 
 	// Labels labels is a set of labels to apply to the device.
 	Labels *map[string]string `json:"labels,omitempty"`
+
+	// ReplaceDevice replaceDevice resolves the approval of a machine already enrolled as another device, such as a re-imaged one. True moves the other device to the trash, false keeps both devices. Required to approve such a request when the duplicateEnrollmentPolicy of the service is RequireApproval, defaults to true when it is Replace.
+	ReplaceDevice *bool `json:"replaceDevice,omitempty"`
 }
 
 // EnrollmentRequestList EnrollmentRequestList is a list of EnrollmentRequest.
@@ -577,6 +2207,9 @@ type EnrollmentRequestStatus struct {
 
 	// Conditions Current state of the EnrollmentRequest.
 	Conditions []Condition `json:"conditions"`
+
+	// DuplicateOf The name of the enrolled device which reported the serial number or one of the MAC addresses of the machine, such as the device the machine was before it was re-imaged. Set by the service.
+	DuplicateOf *string `json:"duplicateOf,omitempty"`
 }
 
 // EnrollmentService defines model for EnrollmentService.
@@ -598,50 +2231,330 @@ type EnrollmentServiceService struct {
 	Server                   string `json:"server"`
 }
 
-// Error defines model for Error.
+// Error An error response of the API. Clients branch on the reason rather than on the message, which is meant for humans and may change.
 type Error struct {
+	// FieldViolations The fields of the request which are not valid, if the reason is ValidationFailed.
+	FieldViolations *[]FieldViolation `json:"fieldViolations,omitempty"`
+
 	// Message Error message
 	Message string `json:"message"`
+
+	// Reason A machine-readable code of why the request failed.
+	Reason *ErrorReason `json:"reason,omitempty"`
+
+	// Retryable Whether the same request may succeed if retried later, such as after a conflicting update or while the service is unavailable.
+	Retryable *bool `json:"retryable,omitempty"`
+
+	// Status The HTTP status code of the response.
+	Status *int32 `json:"status,omitempty"`
+
+	// Type A URI identifying the type of the error, such as urn:flightctl:error:NotFound.
+	Type *string `json:"type,omitempty"`
+}
+
+// ErrorReason A machine-readable code of why the request failed.
+type ErrorReason string
+
+// ExportedDevice defines model for ExportedDevice.
+type ExportedDevice struct {
+	// Device Device represents a physical device.
+	Device Device `json:"device"`
+
+	// RenderedConfig The rendered configuration of the rendered version of the device. It is not imported, the instance the device is imported into renders the spec of the device again.
+	RenderedConfig *string `json:"renderedConfig,omitempty"`
+}
+
+// FieldViolation A field of the request which is not valid.
+type FieldViolation struct {
+	// Description Why the field is not valid.
+	Description string `json:"description"`
+
+	// Field The path of the field, such as spec.os.image.
+	Field string `json:"field"`
+}
+
+// FileOperation The type of operation that was observed on the file.
+type FileOperation string
+
+// FirewallAction Whether the firewall accepts or drops traffic.
+type FirewallAction string
+
+// FirewallProtocol The protocol of the traffic a firewall rule matches. Matches any protocol if unset.
+type FirewallProtocol string
+
+// FirewallRule A rule of the firewall of the device, matching the incoming traffic with all of its protocol, ports and sources.
+type FirewallRule struct {
+	// Action Whether the firewall accepts or drops traffic.
+	Action FirewallAction `json:"action"`
+
+	// Name Name of the rule, added as a comment of the nftables rule.
+	Name *string `json:"name,omitempty"`
+
+	// Ports Destination ports the rule matches. Requires the tcp or udp protocol.
+	Ports *[]int32 `json:"ports,omitempty"`
+
+	// Protocol The protocol of the traffic a firewall rule matches. Matches any protocol if unset.
+	Protocol *FirewallProtocol `json:"protocol,omitempty"`
+
+	// Sources IP addresses or CIDR ranges the traffic comes from, such as 10.0.0.0/8. Matches any source if unset.
+	Sources *[]string `json:"sources,omitempty"`
+}
+
+// Fleet Fleet represents a set of devices.
+type Fleet struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec FleetSpec is a description of a fleet's target state.
+	Spec FleetSpec `json:"spec"`
+
+	// Status FleetStatus represents information about the status of a fleet. Status may trail the actual state of a fleet, especially if devices of a fleet have not contacted the management service in a while.
+	Status *FleetStatus `json:"status,omitempty"`
+}
+
+// FleetLintResult FleetLintResult holds the problems found in the template of a fleet.
+type FleetLintResult struct {
+	// Warnings The problems found in the template of the fleet, empty if none was found.
+	Warnings []FleetLintWarning `json:"warnings"`
+}
+
+// FleetLintWarning FleetLintWarning is a problem found in the template of a fleet.
+type FleetLintWarning struct {
+	// Message A human readable description of the problem.
+	Message string `json:"message"`
+
+	// Path The path of the property of the fleet the problem was found in, such as spec.template.spec.config[0].
+	Path string `json:"path"`
+
+	// Type The kind of problem, UnknownParameter for a template parameter the service cannot replace, UnreachableRepository for a repository that does not exist or that the service cannot access, InvalidUnit for a systemd or Quadlet unit of an inline configuration that does not parse, and ImageNotFound for an image whose tag or digest does not exist in its registry.
+	Type FleetLintWarningType `json:"type"`
+}
+
+// FleetLintWarningType The kind of problem, UnknownParameter for a template parameter the service cannot replace, UnreachableRepository for a repository that does not exist or that the service cannot access, InvalidUnit for a systemd or Quadlet unit of an inline configuration that does not parse, and ImageNotFound for an image whose tag or digest does not exist in its registry.
+type FleetLintWarningType string
+
+// FleetList FleetList is a list of Fleets.
+type FleetList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of Fleets.
+	Items []Fleet `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// FleetPromotionApproval Automatic if a template version is promoted once it soaked, which is the default, and Manual if it is proposed and only promoted once the promotion is approved.
+type FleetPromotionApproval string
+
+// FleetPromotionSpec FleetPromotionSpec promotes the template of another fleet to this fleet once a template version was healthy in that fleet for the soak time. A template version is healthy while its rollout completed without failed devices and no device of the fleet is in Error or Degraded status.
+type FleetPromotionSpec struct {
+	// Approval Automatic if a template version is promoted once it soaked, which is the default, and Manual if it is proposed and only promoted once the promotion is approved.
+	Approval *FleetPromotionApproval `json:"approval,omitempty"`
+
+	// From The name of the fleet whose template is promoted to this fleet, such as the staging fleet of a production fleet.
+	From string `json:"from"`
+
+	// SoakTime The time a template version must be healthy in the fleet it is promoted from before it is promoted. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours.
+	SoakTime string `json:"soakTime"`
+}
+
+// FleetPromotionState Soaking until the template version was healthy for the soak time, Proposed while its promotion waits for approval, and Promoted once the template was promoted.
+type FleetPromotionState string
+
+// FleetPromotionStatus FleetPromotionStatus follows the promotion of the newest template version of the fleet promoted from.
+type FleetPromotionStatus struct {
+	// HealthySince The time since which the template version is healthy, unset while it is not.
+	HealthySince *time.Time `json:"healthySince,omitempty"`
+
+	// Message Why the template version is not healthy.
+	Message *string `json:"message,omitempty"`
+
+	// PromotedAt The time the template was promoted at.
+	PromotedAt *time.Time `json:"promotedAt,omitempty"`
+
+	// ProposedAt The time the template version was proposed at, if the promotion requires approval.
+	ProposedAt *time.Time `json:"proposedAt,omitempty"`
+
+	// State Soaking until the template version was healthy for the soak time, Proposed while its promotion waits for approval, and Promoted once the template was promoted.
+	State FleetPromotionState `json:"state"`
+
+	// TemplateVersion The name of the template version of the fleet promoted from which soaks, was proposed or was promoted.
+	TemplateVersion string `json:"templateVersion"`
+}
+
+// FleetProvisioning defines model for FleetProvisioning.
+type FleetProvisioning struct {
+	// Content The kickstart or ignition fragment.
+	Content string `json:"content"`
+
+	// ExpirationTime The time until which devices can enroll with the credentials of the fragment.
+	ExpirationTime time.Time `json:"expirationTime"`
+
+	// Fleet The name of the fleet the fragment was rendered for.
+	Fleet string `json:"fleet"`
+
+	// Format The format of a provisioning fragment.
+	Format ProvisioningFormat `json:"format"`
+}
+
+// FleetReport FleetReport is a compliance snapshot of the devices owned by a fleet.
+type FleetReport struct {
+	// ComplianceStatus The number of devices reporting a compliance scan per compliance status.
+	ComplianceStatus *map[string]int64 `json:"complianceStatus,omitempty"`
+
+	// DevicesOnTargetOs The number of devices running the OS image the fleet's template specifies for their architecture, as its current template version resolved it, or all devices if the template specifies no OS image.
+	DevicesOnTargetOs int64 `json:"devicesOnTargetOs"`
+
+	// DevicesWithConfigDrift The number of devices that have not yet applied their current template version or rendered configuration.
+	DevicesWithConfigDrift int64 `json:"devicesWithConfigDrift"`
+
+	// FailedDevices The devices in an Error or Degraded state.
+	FailedDevices []FleetReportDevice `json:"failedDevices"`
+
+	// Fleet The name of the fleet the report was generated for.
+	Fleet string `json:"fleet"`
+
+	// GeneratedAt The time the report was generated.
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// SummaryStatus The number of devices per summary status.
+	SummaryStatus map[string]int64 `json:"summaryStatus"`
+
+	// TargetOsImage The OS image specified by the fleet's template, as its current template version resolved it once rendered, such as pinned by digest for a template following a stream. Devices of the architectures the template specifies images for are compared with the image for their architecture.
+	TargetOsImage *string `json:"targetOsImage,omitempty"`
+
+	// TemplateVersion The name of the fleet's current TemplateVersion.
+	TemplateVersion *string `json:"templateVersion,omitempty"`
+
+	// TotalDevices The number of devices owned by the fleet.
+	TotalDevices int64 `json:"totalDevices"`
+
+	// WorkloadEvents The out-of-memory kills and crashes the devices reported in the last 24 hours per workload, the workloads affecting the most devices first.
+	WorkloadEvents *[]FleetReportWorkloadEvents `json:"workloadEvents,omitempty"`
+}
+
+// FleetReportDevice FleetReportDevice describes a failed device in a FleetReport.
+type FleetReportDevice struct {
+	// Name The name of the device.
+	Name string `json:"name"`
+
+	// OsImage The OS image reported by the device.
+	OsImage string `json:"osImage"`
+
+	// Reason Human readable information about why the device is failing.
+	Reason        *string                 `json:"reason,omitempty"`
+	SummaryStatus DeviceSummaryStatusType `json:"summaryStatus"`
+}
+
+// FleetReportWorkloadEvents FleetReportWorkloadEvents counts the events of a type reported for a workload by the devices of a FleetReport.
+type FleetReportWorkloadEvents struct {
+	// Devices The number of devices which reported events of the workload.
+	Devices int64 `json:"devices"`
+
+	// Events The number of events of the workload.
+	Events int64 `json:"events"`
+
+	// Type OOMKilled if the kernel killed the process for lack of memory, Crashed if the process dumped core, such as on a segmentation fault.
+	Type DeviceEventType `json:"type"`
+
+	// Workload The systemd unit the events were reported for, or the process for events outside of units.
+	Workload string `json:"workload"`
+}
+
+// FleetReportsObjectStorage FleetReportsObjectStorage stores the reports of the fleet in the artifact storage of the service configuration.
+type FleetReportsObjectStorage struct {
+	// Prefix The prefix of the keys of the reports, which are stored as reports/<prefix><fleet>/<time>.json.
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// FleetReportsSpec FleetReportsSpec schedules health reports of the fleet. At least one of webhooks or objectStorage must be set.
+type FleetReportsSpec struct {
+	// ObjectStorage FleetReportsObjectStorage stores the reports of the fleet in the artifact storage of the service configuration.
+	ObjectStorage *FleetReportsObjectStorage `json:"objectStorage,omitempty"`
+
+	// Schedule The cron expression of the times the report is generated at, in UTC. Either five fields (minute, hour, day of month, month and day of week) or one of @hourly, @daily, @weekly and @monthly.
+	Schedule string `json:"schedule"`
+
+	// Webhooks The names of the webhooks the report is POSTed to.
+	Webhooks *[]string `json:"webhooks,omitempty"`
 }
 
-// FileOperation The type of operation that was observed on the file.
-type FileOperation string
+// FleetRolloutAbort FleetRolloutAbort aborts the rollout of a fleet.
+type FleetRolloutAbort struct {
+	// Revert Whether the devices already updated are reverted to the previous template version of the fleet.
+	Revert *bool `json:"revert,omitempty"`
+}
 
-// Fleet Fleet represents a set of devices.
-type Fleet struct {
-	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
-	ApiVersion string `json:"apiVersion"`
+// FleetRolloutBatchStatus FleetRolloutBatchStatus counts the devices of a batch of a rollout by their progress.
+type FleetRolloutBatchStatus struct {
+	// Failed The number of devices of the batch which failed to update or exceeded the update timeout of the rollout policy.
+	Failed int `json:"failed"`
 
-	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
-	Kind string `json:"kind"`
+	// FinishedAt The time all devices of the batch had updated or failed.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
 
-	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
-	Metadata ObjectMeta `json:"metadata"`
+	// InProgress The number of devices of the batch which are updating.
+	InProgress int `json:"inProgress"`
 
-	// Spec FleetSpec is a description of a fleet's target state.
-	Spec FleetSpec `json:"spec"`
+	// Pending The number of devices of the batch which were not updated yet.
+	Pending int `json:"pending"`
 
-	// Status FleetStatus represents information about the status of a fleet. Status may trail the actual state of a fleet, especially if devices of a fleet have not contacted the management service in a while.
-	Status *FleetStatus `json:"status,omitempty"`
+	// StartedAt The time the batch started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// Succeeded The number of devices of the batch which run the template version.
+	Succeeded int `json:"succeeded"`
 }
 
-// FleetList FleetList is a list of Fleets.
-type FleetList struct {
-	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
-	ApiVersion string `json:"apiVersion"`
+// FleetRolloutState Progressing while devices are being updated, Blocked when a device failed to update and the rollout policy of the fleet pauses on failure, Paused and Aborted after the rollout was paused or aborted, and Completed once all devices of the fleet were updated.
+type FleetRolloutState string
 
-	// Items List of Fleets.
-	Items []Fleet `json:"items"`
+// FleetRolloutStatus FleetRolloutStatus is the progress of the rollout of the newest template version of the fleet to its devices.
+type FleetRolloutStatus struct {
+	// Batches The batches of the rollout, including those which did not start yet.
+	Batches []FleetRolloutBatchStatus `json:"batches"`
 
-	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
-	Kind string `json:"kind"`
+	// BlockingReason Why the rollout does not progress to the next batch, set while it is Blocked, while the first batch waits for the rollouts of the fleets the rollout policy depends on, or while the next batch waits for the bandwidth budget of a site or the concurrency limit of the rollout policy.
+	BlockingReason *string `json:"blockingReason,omitempty"`
 
-	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
-	Metadata ListMeta `json:"metadata"`
+	// CurrentBatch The number of the batch being rolled out, starting at 1.
+	CurrentBatch int `json:"currentBatch"`
+
+	// FinishedAt The time the rollout completed or was aborted.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// PreviousTemplateVersion The name of the template version rolled out before, which the devices are reverted to when the rollout is aborted with revert.
+	PreviousTemplateVersion *string `json:"previousTemplateVersion,omitempty"`
+
+	// StartedAt The time the rollout of the template version started.
+	StartedAt time.Time `json:"startedAt"`
+
+	// State Progressing while devices are being updated, Blocked when a device failed to update and the rollout policy of the fleet pauses on failure, Paused and Aborted after the rollout was paused or aborted, and Completed once all devices of the fleet were updated.
+	State FleetRolloutState `json:"state"`
+
+	// TemplateVersion The name of the template version being rolled out.
+	TemplateVersion string `json:"templateVersion"`
 }
 
 // FleetSpec FleetSpec is a description of a fleet's target state.
 type FleetSpec struct {
+	// Promotion FleetPromotionSpec promotes the template of another fleet to this fleet once a template version was healthy in that fleet for the soak time. A template version is healthy while its rollout completed without failed devices and no device of the fleet is in Error or Degraded status.
+	Promotion *FleetPromotionSpec `json:"promotion,omitempty"`
+
+	// Reports FleetReportsSpec schedules health reports of the fleet. At least one of webhooks or objectStorage must be set.
+	Reports *FleetReportsSpec `json:"reports,omitempty"`
+
 	// Selector A map of key,value pairs that are ANDed. Empty/null label selectors match nothing.
 	Selector *LabelSelector `json:"selector,omitempty"`
 	Template struct {
@@ -658,6 +2571,15 @@ type FleetStatus struct {
 
 	// DevicesSummary A summary of the devices in the fleet returned when fetching a single Fleet.
 	DevicesSummary *DevicesSummary `json:"devicesSummary,omitempty"`
+
+	// ObservedGeneration The metadata.generation of the fleet spec last validated by the service. The service has processed the current spec once it equals metadata.generation.
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+
+	// Promotion FleetPromotionStatus follows the promotion of the newest template version of the fleet promoted from.
+	Promotion *FleetPromotionStatus `json:"promotion,omitempty"`
+
+	// Rollout FleetRolloutStatus is the progress of the rollout of the newest template version of the fleet to its devices.
+	Rollout *FleetRolloutStatus `json:"rollout,omitempty"`
 }
 
 // GenericConfigSpec defines model for GenericConfigSpec.
@@ -713,6 +2635,9 @@ type HookActionExecutable struct {
 	// Run The command to be executed, including any arguments using standard shell syntax. This field supports multiple commands piped together, as if they were executed under a bash -c context.
 	Run string `json:"run"`
 
+	// Sandbox Confinement of an executable action, which then runs in a transient systemd unit rather than as a child process of the agent. The unit is stopped once the timeout of the action expires.
+	Sandbox *HookActionSandbox `json:"sandbox,omitempty"`
+
 	// WorkDir The directory in which the executable will be run from if it is left empty it will run from the users home directory.
 	WorkDir *string `json:"workDir,omitempty"`
 }
@@ -725,6 +2650,9 @@ type HookActionExecutableSpec struct {
 	// Run The command to be executed, including any arguments using standard shell syntax. This field supports multiple commands piped together, as if they were executed under a bash -c context.
 	Run string `json:"run"`
 
+	// Sandbox Confinement of an executable action, which then runs in a transient systemd unit rather than as a child process of the agent. The unit is stopped once the timeout of the action expires.
+	Sandbox *HookActionSandbox `json:"sandbox,omitempty"`
+
 	// Timeout The maximum duration allowed for the action to complete.
 	// The duration should be specified as a positive integer
 	// followed by a time unit. Supported time units are:
@@ -738,6 +2666,21 @@ type HookActionExecutableSpec struct {
 	WorkDir *string `json:"workDir,omitempty"`
 }
 
+// HookActionSandbox Confinement of an executable action, which then runs in a transient systemd unit rather than as a child process of the agent. The unit is stopped once the timeout of the action expires.
+type HookActionSandbox struct {
+	// CpuQuotaPercentage The CPU time the action may use, as a percentage of the time of one CPU.
+	CpuQuotaPercentage *int32 `json:"cpuQuotaPercentage,omitempty"`
+
+	// MemoryMax The memory the action may use in bytes, with an optional K, M, G or T suffix. The action is killed if it exceeds the limit.
+	MemoryMax *string `json:"memoryMax,omitempty"`
+
+	// ReadOnlyPaths Absolute paths the action can read but not write.
+	ReadOnlyPaths *[]string `json:"readOnlyPaths,omitempty"`
+
+	// User The user the action runs as. Defaults to root.
+	User *string `json:"user,omitempty"`
+}
+
 // HookActionSpec defines model for HookActionSpec.
 type HookActionSpec struct {
 	// Timeout The maximum duration allowed for the action to complete.
@@ -783,6 +2726,9 @@ type HttpConfig struct {
 	// CaCrt Base64 encoded root CA
 	CaCrt *string `json:"ca.crt,omitempty"`
 
+	// Headers Headers to send with the requests to the repository, for servers expecting credentials in other headers than Authorization. Their values can refer to the credentials of this configuration as {{ username }}, {{ password }} and {{ token }}
+	Headers *map[string]string `json:"headers,omitempty"`
+
 	// Password The password for auth with HTTP transport
 	Password *string `json:"password,omitempty"`
 
@@ -807,11 +2753,18 @@ type HttpConfigProviderSpec struct {
 	ConfigType string `json:"configType"`
 	HttpRef    struct {
 		// FilePath The path of the file where the response is stored in the filesystem of the device.
+		// For responses of the archive format, the path of the directory the files of the archive are stored in.
 		FilePath string `json:"filePath"`
 
+		// Format The format of the response of an HTTP configuration source: a single file (the default), or a tar archive, optionally gzip compressed, of the files to store.
+		Format *HttpContentFormat `json:"format,omitempty"`
+
 		// Repository The name of the repository resource to use as the sync source
 		Repository string `json:"repository"`
 
+		// Sha256 The hex encoded SHA-256 checksum of the response. If set, responses with other checksums are rejected.
+		Sha256 *string `json:"sha256,omitempty"`
+
 		// Suffix Part of the URL that comes after the base URL. It can include query parameters such as:
 		// /path/to/endpoint?query=param
 		Suffix *string `json:"suffix,omitempty"`
@@ -819,6 +2772,9 @@ type HttpConfigProviderSpec struct {
 	Name string `json:"name"`
 }
 
+// HttpContentFormat The format of the response of an HTTP configuration source: a single file (the default), or a tar archive, optionally gzip compressed, of the files to store.
+type HttpContentFormat string
+
 // HttpRepoSpec defines model for HttpRepoSpec.
 type HttpRepoSpec struct {
 	HttpConfig HttpConfig `json:"httpConfig"`
@@ -830,6 +2786,54 @@ type HttpRepoSpec struct {
 	Url string `json:"url"`
 }
 
+// ImageDigest An image of a rendered spec and the digest of the manifest it resolved to.
+type ImageDigest struct {
+	// Digest The digest of the manifest of the image, such as sha256:4f5a... Unset if the service could not resolve the tag of the image, such as one of a registry requiring credentials.
+	Digest *string `json:"digest,omitempty"`
+
+	// Image The image as referenced by the spec.
+	Image string `json:"image"`
+}
+
+// ImageSbom The software bill of materials of an image run by devices, collected from the artifacts its registry attaches to the image's digest.
+type ImageSbom struct {
+	// CollectedAt The time the service looked up the SBOM of the image.
+	CollectedAt time.Time `json:"collectedAt"`
+
+	// Components The components of the image.
+	Components *[]SbomComponent `json:"components,omitempty"`
+
+	// Devices The names of the devices running the image.
+	Devices *[]string `json:"devices,omitempty"`
+
+	// Digest The digest of the manifest of the image.
+	Digest string `json:"digest"`
+
+	// Format The format of an SBOM. Unset if the registry attaches no SBOM to the image.
+	Format *SbomFormat `json:"format,omitempty"`
+
+	// Image The image as referenced by the device specs.
+	Image string `json:"image"`
+
+	// Vulnerabilities The identifiers of the vulnerabilities the SBOM reports, such as CVE-2024-3094. Only CycloneDX SBOMs report vulnerabilities.
+	Vulnerabilities *[]string `json:"vulnerabilities,omitempty"`
+}
+
+// ImageSbomList ImageSbomList is a list of ImageSboms.
+type ImageSbomList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of SBOMs.
+	Items []ImageSbom `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
 // InlineConfigProviderSpec defines model for InlineConfigProviderSpec.
 type InlineConfigProviderSpec struct {
 	ConfigType string                 `json:"configType"`
@@ -837,6 +2841,44 @@ type InlineConfigProviderSpec struct {
 	Name       string                 `json:"name"`
 }
 
+// IssuedCertificate IssuedCertificate records a certificate issued by the service.
+type IssuedCertificate struct {
+	// CommonName The common name of the subject of the certificate.
+	CommonName string `json:"commonName"`
+
+	// Device The name of the Device the certificate was issued to. Unset for enrollment certificates, which can be shared by several devices.
+	Device    *string   `json:"device,omitempty"`
+	NotAfter  time.Time `json:"notAfter"`
+	NotBefore time.Time `json:"notBefore"`
+
+	// Owner The resource the certificate was issued for, as kind/name.
+	Owner *string `json:"owner,omitempty"`
+
+	// SerialNumber The hex encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber"`
+
+	// Usage What the certificate is used for. Enrollment certificates authenticate enrollment requests, management certificates authenticate enrolled devices.
+	Usage IssuedCertificateUsage `json:"usage"`
+}
+
+// IssuedCertificateList IssuedCertificateList is a list of IssuedCertificates.
+type IssuedCertificateList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of certificates.
+	Items []IssuedCertificate `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// IssuedCertificateUsage What the certificate is used for. Enrollment certificates authenticate enrollment requests, management certificates authenticate enrolled devices.
+type IssuedCertificateUsage string
+
 // KubernetesSecretProviderSpec defines model for KubernetesSecretProviderSpec.
 type KubernetesSecretProviderSpec struct {
 	ConfigType string `json:"configType"`
@@ -848,6 +2890,51 @@ type KubernetesSecretProviderSpec struct {
 	} `json:"secretRef"`
 }
 
+// LabelRule LabelRule automatically assigns a label to devices based on a fact reported in their system info.
+type LabelRule struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec LabelRuleSpec describes which fact a label is derived from. The label is removed from a device when the fact is no longer reported or no longer matches.
+	Spec LabelRuleSpec `json:"spec"`
+}
+
+// LabelRuleList LabelRuleList is a list of LabelRules.
+type LabelRuleList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of label rules.
+	Items []LabelRule `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// LabelRuleSpec LabelRuleSpec describes which fact a label is derived from. The label is removed from a device when the fact is no longer reported or no longer matches.
+type LabelRuleSpec struct {
+	// Field The path of the fact in the device status, for example systemInfo.architecture or systemInfo.hardware.gpuPresent. Only facts under systemInfo can be used.
+	Field string `json:"field"`
+
+	// LabelKey The key of the label to assign.
+	LabelKey string `json:"labelKey"`
+
+	// LabelValue The value of the label to assign. Defaults to the value of the fact.
+	LabelValue *string `json:"labelValue,omitempty"`
+
+	// MatchValues If set, the label is only assigned to devices whose fact has one of these values.
+	MatchValues *[]string `json:"matchValues,omitempty"`
+}
+
 // LabelSelector A map of key,value pairs that are ANDed. Empty/null label selectors match nothing.
 type LabelSelector struct {
 	MatchLabels map[string]string `json:"matchLabels"`
@@ -867,11 +2954,17 @@ type MemoryResourceMonitorSpec = ResourceMonitorSpec
 
 // ObjectMeta ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
 type ObjectMeta struct {
+	// Aliases Further names the device can be found by. Only devices have aliases, which are set through their aliases. Read-only.
+	Aliases *[]string `json:"aliases,omitempty"`
+
 	// Annotations Properties set by the service.
 	Annotations       *map[string]string `json:"annotations,omitempty"`
 	CreationTimestamp *time.Time         `json:"creationTimestamp,omitempty"`
 	DeletionTimestamp *time.Time         `json:"deletionTimestamp,omitempty"`
 
+	// DisplayName A human-friendly name of the device. Only devices have display names, which are set through their aliases. Read-only.
+	DisplayName *string `json:"displayName,omitempty"`
+
 	// Generation A sequence number representing a specific generation of the desired state. Populated by the system. Read-only.
 	Generation *int64 `json:"generation,omitempty"`
 
@@ -888,6 +2981,96 @@ type ObjectMeta struct {
 	ResourceVersion *string `json:"resourceVersion,omitempty"`
 }
 
+// Operation Operation is an action that takes time, such as labeling or decommissioning many devices, which the service runs in the background. Its status reports its progress and result until it is deleted.
+type Operation struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec The action of an operation and the devices it applies to. The devices are those matching the label selector when the operation starts.
+	Spec OperationSpec `json:"spec"`
+
+	// Status The progress and result of an operation.
+	Status *OperationStatus `json:"status,omitempty"`
+}
+
+// OperationFailure A device an operation failed for.
+type OperationFailure struct {
+	// Message Why the operation failed for the device.
+	Message string `json:"message"`
+
+	// Name The name of the device.
+	Name string `json:"name"`
+}
+
+// OperationList OperationList is a list of Operations.
+type OperationList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of operations.
+	Items []Operation `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// OperationSpec The action of an operation and the devices it applies to. The devices are those matching the label selector when the operation starts.
+type OperationSpec struct {
+	// LabelSelector The selector of the devices the operation applies to, such as site=store-1. An empty selector matches no device.
+	LabelSelector string `json:"labelSelector"`
+
+	// Labels The labels a BulkLabel operation sets on the devices.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// RemoveLabels The keys of the labels a BulkLabel operation removes from the devices.
+	RemoveLabels *[]string `json:"removeLabels,omitempty"`
+
+	// Type The action of an operation. BulkLabel sets and removes labels of the devices, DeviceDecommission deletes the devices, which can be restored from the trash until they are purged.
+	Type OperationType `json:"type"`
+}
+
+// OperationState The state of an operation. Pending until the service starts it, Running while it applies to the devices, then Succeeded, Failed if it failed for any device, or Canceled once it was canceled.
+type OperationState string
+
+// OperationStatus The progress and result of an operation.
+type OperationStatus struct {
+	// Failed The number of devices the operation failed for.
+	Failed int `json:"failed"`
+
+	// Failures The devices the operation failed for, up to the first 100.
+	Failures *[]OperationFailure `json:"failures,omitempty"`
+
+	// FinishedAt When the operation succeeded, failed or was canceled.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	// Message A human-readable description of the result of the operation.
+	Message *string `json:"message,omitempty"`
+
+	// StartedAt When the operation started running.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// State The state of an operation. Pending until the service starts it, Running while it applies to the devices, then Succeeded, Failed if it failed for any device, or Canceled once it was canceled.
+	State OperationState `json:"state"`
+
+	// Succeeded The number of devices the operation succeeded for.
+	Succeeded int `json:"succeeded"`
+
+	// Total The number of devices the operation applies to, known once it is running.
+	Total int `json:"total"`
+}
+
+// OperationType The action of an operation. BulkLabel sets and removes labels of the devices, DeviceDecommission deletes the devices, which can be restored from the trash until they are purged.
+type OperationType string
+
 // PatchRequest defines model for PatchRequest.
 type PatchRequest = []struct {
 	// Op The operation to perform.
@@ -903,22 +3086,167 @@ type PatchRequest = []struct {
 // PatchRequestOp The operation to perform.
 type PatchRequestOp string
 
+// ProvisioningChallenge ProvisioningChallenge is a credential which only the TPM holding the endorsement key of a device identity can activate.
+type ProvisioningChallenge struct {
+	// Credential The base64 encoded credential (TPM2B_ID_OBJECT) bound to the attestation key, which the machine's TPM activates.
+	Credential string `json:"credential"`
+
+	// ExpirationTime The time until which the service accepts the secret of the credential.
+	ExpirationTime time.Time `json:"expirationTime"`
+
+	// Secret The base64 encoded seed (TPM2B_ENCRYPTED_SECRET) of the credential, encrypted to the endorsement key.
+	Secret string `json:"secret"`
+}
+
+// ProvisioningFormat The format of a provisioning fragment.
+type ProvisioningFormat string
+
+// ProvisioningRequest ProvisioningRequest presents the hardware identity of a machine that is not enrolled yet.
+type ProvisioningRequest struct {
+	// MacAddresses The MAC addresses of the network interfaces of the machine.
+	MacAddresses *[]string `json:"macAddresses,omitempty"`
+
+	// SerialNumber The serial number of the machine.
+	SerialNumber *string `json:"serialNumber,omitempty"`
+
+	// TpmActivatedCredential The base64 encoded secret the machine's TPM recovered from the provisioning challenge, which proves that the machine holds the endorsement key.
+	TpmActivatedCredential *string `json:"tpmActivatedCredential,omitempty"`
+
+	// TpmAttestationKey The base64 encoded public area (TPMT_PUBLIC) of the key of the machine's TPM which the provisioning challenge is bound to.
+	TpmAttestationKey *string `json:"tpmAttestationKey,omitempty"`
+
+	// TpmEndorsementKey The base64 encoded public endorsement key of the machine's TPM, DER encoded in PKIX form.
+	TpmEndorsementKey *string `json:"tpmEndorsementKey,omitempty"`
+}
+
+// ProvisioningToken ProvisioningToken is a bearer token for provisioning lines, which can only create enrollment requests for devices joining its fleet and cannot read or modify any other resource.
+type ProvisioningToken struct {
+	// CreatedAt The time the token was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Description What the token is for.
+	Description *string `json:"description,omitempty"`
+
+	// EnrollmentsPerHour The number of enrollment requests the token can create per hour.
+	EnrollmentsPerHour int32 `json:"enrollmentsPerHour"`
+
+	// ExpiresAt The time the token expires, after which it can no longer create enrollment requests.
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Fleet The name of the fleet the devices enrolled with the token join.
+	Fleet string `json:"fleet"`
+
+	// Id Unique ID of the token.
+	Id string `json:"id"`
+
+	// Labels Labels set on the devices enrolled with the token, besides the labels selected by the fleet.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// Token The secret bearer token, only returned when the token is created.
+	Token *string `json:"token,omitempty"`
+}
+
+// ProvisioningTokenList defines model for ProvisioningTokenList.
+type ProvisioningTokenList struct {
+	Items []ProvisioningToken `json:"items"`
+}
+
+// ProvisioningTokenRequest defines model for ProvisioningTokenRequest.
+type ProvisioningTokenRequest struct {
+	// Description What the token is for, such as the provisioning line using it.
+	Description *string `json:"description,omitempty"`
+
+	// EnrollmentsPerHour The number of enrollment requests the token can create per hour. Defaults to 100.
+	EnrollmentsPerHour *int32 `json:"enrollmentsPerHour,omitempty"`
+
+	// Labels Labels set on the devices enrolled with the token, besides the labels selected by the fleet.
+	Labels *map[string]string `json:"labels,omitempty"`
+
+	// Ttl How long the token is valid for, as a duration such as 720h. Defaults to 7 days.
+	Ttl *string `json:"ttl,omitempty"`
+}
+
+// RelayedDeviceStatus defines model for RelayedDeviceStatus.
+type RelayedDeviceStatus struct {
+	// ClientCertificate The PEM-encoded client certificate the device connected to the relay with, which the service verifies as when the device connects to it.
+	ClientCertificate string `json:"clientCertificate"`
+
+	// Name The name of the device.
+	Name string `json:"name"`
+
+	// Request The body of the status update the device sent to the relay, as the device signed it.
+	Request []byte `json:"request"`
+
+	// Signature The signature of the status update by the key of the client certificate of the device.
+	Signature []byte `json:"signature"`
+
+	// SignedAt The time the device signed the status update at.
+	SignedAt time.Time `json:"signedAt"`
+}
+
+// RelayedDeviceStatusBatch RelayedDeviceStatusBatch holds the statuses of at most 1000 devices which a relay received from the devices of its site.
+type RelayedDeviceStatusBatch struct {
+	// Devices The statuses the devices sent to the relay, each with the client certificate the device connected with and its signature.
+	Devices []RelayedDeviceStatus `json:"devices"`
+}
+
 // RenderedDeviceSpec defines model for RenderedDeviceSpec.
 type RenderedDeviceSpec struct {
-	Config     *string        `json:"config,omitempty"`
-	Console    *DeviceConsole `json:"console,omitempty"`
+	// Action DeviceAction is an action requested for a device. It is served to the agent with the rendered spec until the agent acknowledges it, and carried out once.
+	Action *DeviceAction `json:"action,omitempty"`
+
+	// Agent Settings that control how the agent communicates with the service.
+	Agent *DeviceAgentSpec `json:"agent,omitempty"`
+
+	// Applications The applications of the device whose updates the agent applies with their update strategy.
+	Applications *[]ApplicationSpec `json:"applications,omitempty"`
+
+	// Compliance The compliance profile the device is scanned against. The agent periodically scans the device with OpenSCAP, which must be installed in the OS image, and reports a summary of the results in status.compliance.
+	Compliance *DeviceComplianceSpec `json:"compliance,omitempty"`
+	Config     *string               `json:"config,omitempty"`
+	Console    *DeviceConsole        `json:"console,omitempty"`
 	Containers *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"containers,omitempty"`
-	Hooks           *DeviceHooksSpec `json:"hooks,omitempty"`
-	Os              *DeviceOSSpec    `json:"os,omitempty"`
-	RenderedVersion string           `json:"renderedVersion"`
+
+	// Drift How the agent handles the drift of the device from its rendered spec between spec changes. The agent periodically checks that the files of the config of the spec, the services of the pod applications and the containers of the compose applications still match the spec, and reports the drift it finds in the Drifted condition and status.drift.
+	Drift *DeviceDriftSpec `json:"drift,omitempty"`
+
+	// Encryption The disk encryption policy of the device. The agent binds the listed LUKS2 volumes to Clevis pins, so that they unlock at boot without a passphrase, and reports whether they comply with the policy. The volumes must be encrypted when provisioning the device, the agent does not encrypt volumes.
+	Encryption *DeviceEncryptionSpec `json:"encryption,omitempty"`
+
+	// Firewall The firewall of the incoming traffic of the device, which the agent applies with nftables. Loopback traffic and the replies to the connections the device opens are always accepted before the rules, so that the agent keeps reaching the service.
+	Firewall *DeviceFirewallSpec `json:"firewall,omitempty"`
+
+	// GarbageCollection The policy of the agent for reclaiming the disk space of the device. The agent prunes the container images no container uses, and optionally the unused volumes, whenever the disk holding the container storage is fuller than the threshold, and removes the OS deployments beyond the ones to keep. It reports the result of the last collection in status.garbageCollection.
+	GarbageCollection *DeviceGarbageCollectionSpec `json:"garbageCollection,omitempty"`
+	Hooks             *DeviceHooksSpec             `json:"hooks,omitempty"`
+
+	// ImageDigests The OS image, the image of the agent update and the container images of the pods of the spec, with the digests they resolved to when the service rendered it.
+	ImageDigests *[]ImageDigest `json:"imageDigests,omitempty"`
+
+	// Migration DeviceMigration re-points the agent of a device to another Flight Control instance, which it keeps its certificate and its current spec with. The device must be imported into that instance first.
+	Migration *DeviceMigration `json:"migration,omitempty"`
+	Os        *DeviceOSSpec    `json:"os,omitempty"`
+
+	// Quarantine DeviceQuarantine isolates a compromised or misbehaving device. A quarantined device keeps its current configuration and reporting its status, but is served no new rendered specs and no console sessions.
+	Quarantine      *DeviceQuarantine `json:"quarantine,omitempty"`
+	RenderedVersion string            `json:"renderedVersion"`
 
 	// Resources Array of resource monitor configurations.
 	Resources *[]ResourceMonitor `json:"resources,omitempty"`
-	Systemd   *struct {
+
+	// SpecVersion Version of the schema the spec was rendered in, negotiated with the agent. Settings introduced by later versions are not rendered.
+	SpecVersion *string `json:"specVersion,omitempty"`
+	Systemd     *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"systemd,omitempty"`
+
+	// Time The time synchronization of the device. The agent configures chrony with the NTP sources in addition to those of the chrony configuration of the OS image.
+	Time *DeviceTimeSpec `json:"time,omitempty"`
+
+	// Tunnel DeviceTunnel is a support tunnel forwarding the TCP connections of an operator to a service listening on localhost of a device, such as a vendor web UI. The tunnel is encrypted end to end between the operator and the device, and closes once its TTL expires.
+	Tunnel *DeviceTunnel `json:"tunnel,omitempty"`
 }
 
 // RepoSpecType RepoSpecType is the type of the repository
@@ -940,6 +3268,12 @@ type Repository struct {
 	Status *RepositoryStatus `json:"status,omitempty"`
 }
 
+// RepositoryCredentials RepositoryCredentials replaces the HTTP or SSH configuration of a repository as a whole. Exactly one of httpConfig and sshConfig must be set.
+type RepositoryCredentials struct {
+	HttpConfig *HttpConfig `json:"httpConfig,omitempty"`
+	SshConfig  *SshConfig  `json:"sshConfig,omitempty"`
+}
+
 // RepositoryList RepositoryList is a list of Repositories.
 type RepositoryList struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
@@ -964,6 +3298,15 @@ type RepositorySpec struct {
 type RepositoryStatus struct {
 	// Conditions Current state of the repository.
 	Conditions []Condition `json:"conditions"`
+
+	// CredentialsRotatedAt The last time the credentials of the repository were rotated.
+	CredentialsRotatedAt *time.Time `json:"credentialsRotatedAt,omitempty"`
+
+	// LastAccessedAt The last time the service accessed the repository successfully.
+	LastAccessedAt *time.Time `json:"lastAccessedAt,omitempty"`
+
+	// LastCheckedAt The last time the service tested access to the repository.
+	LastCheckedAt *time.Time `json:"lastCheckedAt,omitempty"`
 }
 
 // ResourceAlertRule defines model for ResourceAlertRule.
@@ -974,13 +3317,80 @@ type ResourceAlertRule struct {
 	// Duration Duration is the time over which the average usage is observed before alerting. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours, 'd' for days.
 	Duration string `json:"duration"`
 
-	// Percentage The percentage of usage that triggers the alert.
-	Percentage float32                   `json:"percentage"`
-	Severity   ResourceAlertSeverityType `json:"severity"`
+	// Percentage The percentage of usage that triggers the alert.
+	Percentage float32                   `json:"percentage"`
+	Severity   ResourceAlertSeverityType `json:"severity"`
+}
+
+// ResourceAlertSeverityType defines model for ResourceAlertSeverityType.
+type ResourceAlertSeverityType string
+
+// ResourceDependencies The resources a resource depends on and the resources depending on it.
+type ResourceDependencies struct {
+	// Dependencies The resources the resource depends on, such as the fleet owning a device or the repositories a fleet references.
+	Dependencies []ResourceDependency `json:"dependencies"`
+
+	// Dependents The resources depending on the resource, such as the devices a fleet owns or the fleets referencing a repository.
+	Dependents []ResourceDependency `json:"dependents"`
+
+	// Kind The kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+}
+
+// ResourceDependency A resource in the dependencies of another resource.
+type ResourceDependency struct {
+	// Kind The kind of the resource.
+	Kind string `json:"kind"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+
+	// Type How the resources depend on each other. Ownership means one resource owns the other, Reference means one resource references the other in its spec.
+	Type ResourceDependencyType `json:"type"`
+}
+
+// ResourceDependencyType How the resources depend on each other. Ownership means one resource owns the other, Reference means one resource references the other in its spec.
+type ResourceDependencyType string
+
+// ResourceExport ResourceExport holds the fleets and the server-side records of the devices moved from one Flight Control instance to another.
+type ResourceExport struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values.
+	ApiVersion string `json:"apiVersion"`
+
+	// CaBundle The PEM bundle of the CAs the client certificates of the exported devices are signed with, which the instance they are imported into must trust.
+	CaBundle string `json:"caBundle"`
+
+	// Certificates The certificates issued to the exported devices.
+	Certificates []IssuedCertificate `json:"certificates"`
+	Devices      []ExportedDevice    `json:"devices"`
+	Fleets       []Fleet             `json:"fleets"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase.
+	Kind string `json:"kind"`
+
+	// ServiceVersion The version of the Flight Control instance the resources were exported from.
+	ServiceVersion string `json:"serviceVersion"`
+
+	// TemplateVersions The template versions of the exported fleets. Their status is not imported, the instance they are imported into renders the templates of the fleets again.
+	TemplateVersions []TemplateVersion `json:"templateVersions"`
 }
 
-// ResourceAlertSeverityType defines model for ResourceAlertSeverityType.
-type ResourceAlertSeverityType string
+// ResourceExportRequest defines model for ResourceExportRequest.
+type ResourceExportRequest struct {
+	// Fleets The names of the fleets to export with their devices.
+	Fleets []string `json:"fleets"`
+}
+
+// ResourceImportResult defines model for ResourceImportResult.
+type ResourceImportResult struct {
+	Certificates     int64 `json:"certificates"`
+	Devices          int64 `json:"devices"`
+	Fleets           int64 `json:"fleets"`
+	TemplateVersions int64 `json:"templateVersions"`
+}
 
 // ResourceMonitor defines model for ResourceMonitor.
 type ResourceMonitor struct {
@@ -997,6 +3407,18 @@ type ResourceMonitorSpec struct {
 	SamplingInterval string `json:"samplingInterval"`
 }
 
+// ResourceMonitorThresholds Alert thresholds of the default resource monitors, which the agent runs unless the device spec sets resources. Thresholds that are not set default to the agent's local configuration, then to the built-in thresholds.
+type ResourceMonitorThresholds struct {
+	// Cpu Usage percentages of a resource that trigger the alerts of its default monitor.
+	Cpu *ResourceThresholds `json:"cpu,omitempty"`
+
+	// Disk Usage percentages of a resource that trigger the alerts of its default monitor.
+	Disk *ResourceThresholds `json:"disk,omitempty"`
+
+	// Memory Usage percentages of a resource that trigger the alerts of its default monitor.
+	Memory *ResourceThresholds `json:"memory,omitempty"`
+}
+
 // ResourceSync ResourceSync represents a reference to one or more files in a repository to sync to resource definitions
 type ResourceSync struct {
 	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
@@ -1055,8 +3477,71 @@ type ResourceSyncStatus struct {
 	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
 }
 
+// ResourceThresholds Usage percentages of a resource that trigger the alerts of its default monitor.
+type ResourceThresholds struct {
+	// CriticalPercentage Usage percentage that triggers a critical alert.
+	CriticalPercentage *float64 `json:"criticalPercentage,omitempty"`
+
+	// WarningPercentage Usage percentage that triggers a warning alert.
+	WarningPercentage *float64 `json:"warningPercentage,omitempty"`
+}
+
+// SbomComponent A package or library contained in an image.
+type SbomComponent struct {
+	Name string `json:"name"`
+
+	// Purl The package URL of the component, such as pkg:rpm/redhat/openssl@3.0.7.
+	Purl    *string `json:"purl,omitempty"`
+	Version *string `json:"version,omitempty"`
+}
+
+// SbomFormat The format of an SBOM. Unset if the registry attaches no SBOM to the image.
+type SbomFormat string
+
+// Site Site is a location of devices, such as a plant or a store, whose devices are labeled with the name of the site under the "site" label.
+type Site struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec SiteSpec describes a site and the budgets its devices share.
+	Spec SiteSpec `json:"spec"`
+}
+
+// SiteList SiteList is a list of Sites.
+type SiteList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of sites.
+	Items []Site `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// SiteSpec SiteSpec describes a site and the budgets its devices share.
+type SiteSpec struct {
+	// BandwidthBudget The bandwidth in bytes per second the updates of the devices of the site may use together. The rollouts of the fleets start the devices of a batch at the site only while the bandwidth their updates are estimated to use stays within the budget. Unlimited if unset.
+	BandwidthBudget *int64 `json:"bandwidthBudget,omitempty"`
+
+	// Description What the site is, for example "plant 1 in Brno".
+	Description *string `json:"description,omitempty"`
+}
+
 // SshConfig defines model for SshConfig.
 type SshConfig struct {
+	// KnownHosts The host keys the SSH server may present, in the format of an OpenSSH known_hosts file. If set, connections to servers presenting other keys are rejected. Cannot be set together with skipServerVerification
+	KnownHosts *string `json:"knownHosts,omitempty"`
+
 	// PrivateKeyPassphrase The passphrase for sshPrivateKey
 	PrivateKeyPassphrase *string `json:"privateKeyPassphrase,omitempty"`
 
@@ -1090,6 +3575,15 @@ type Status struct {
 	Status *string `json:"status,omitempty"`
 }
 
+// TangPinSpec Binds the volume to a tang server, so that it only unlocks while the server is reachable.
+type TangPinSpec struct {
+	// Thumbprint Thumbprint of the signing key of the tang server. The key the server advertises when binding is trusted if unset.
+	Thumbprint *string `json:"thumbprint,omitempty"`
+
+	// Url URL of the tang server.
+	Url string `json:"url"`
+}
+
 // TemplateDiscriminators defines model for TemplateDiscriminators.
 type TemplateDiscriminators string
 
@@ -1130,6 +3624,15 @@ type TemplateVersionSpec struct {
 
 // TemplateVersionStatus defines model for TemplateVersionStatus.
 type TemplateVersionStatus struct {
+	// Agent Settings that control how the agent communicates with the service.
+	Agent *DeviceAgentSpec `json:"agent,omitempty"`
+
+	// Applications The applications of the device whose updates the agent applies with their update strategy.
+	Applications *[]ApplicationSpec `json:"applications,omitempty"`
+
+	// Compliance The compliance profile the device is scanned against. The agent periodically scans the device with OpenSCAP, which must be installed in the OS image, and reports a summary of the results in status.compliance.
+	Compliance *DeviceComplianceSpec `json:"compliance,omitempty"`
+
 	// Conditions Current state of the device.
 	Conditions []Condition `json:"conditions"`
 
@@ -1138,15 +3641,33 @@ type TemplateVersionStatus struct {
 	Containers *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"containers,omitempty"`
-	Hooks *DeviceHooksSpec `json:"hooks,omitempty"`
-	Os    *DeviceOSSpec    `json:"os,omitempty"`
+
+	// Crypto The crypto requirements of the device. The service reports whether the device meets them in its CryptoCompliant condition, the agent does not change the crypto configuration of the device.
+	Crypto *DeviceCryptoSpec `json:"crypto,omitempty"`
+
+	// Drift How the agent handles the drift of the device from its rendered spec between spec changes. The agent periodically checks that the files of the config of the spec, the services of the pod applications and the containers of the compose applications still match the spec, and reports the drift it finds in the Drifted condition and status.drift.
+	Drift *DeviceDriftSpec `json:"drift,omitempty"`
+
+	// Encryption The disk encryption policy of the device. The agent binds the listed LUKS2 volumes to Clevis pins, so that they unlock at boot without a passphrase, and reports whether they comply with the policy. The volumes must be encrypted when provisioning the device, the agent does not encrypt volumes.
+	Encryption *DeviceEncryptionSpec `json:"encryption,omitempty"`
+
+	// Firewall The firewall of the incoming traffic of the device, which the agent applies with nftables. Loopback traffic and the replies to the connections the device opens are always accepted before the rules, so that the agent keeps reaching the service.
+	Firewall *DeviceFirewallSpec `json:"firewall,omitempty"`
+
+	// GarbageCollection The policy of the agent for reclaiming the disk space of the device. The agent prunes the container images no container uses, and optionally the unused volumes, whenever the disk holding the container storage is fuller than the threshold, and removes the OS deployments beyond the ones to keep. It reports the result of the last collection in status.garbageCollection.
+	GarbageCollection *DeviceGarbageCollectionSpec `json:"garbageCollection,omitempty"`
+	Hooks             *DeviceHooksSpec             `json:"hooks,omitempty"`
+	Os                *DeviceOSSpec                `json:"os,omitempty"`
 
 	// Resources Array of resource monitor configurations.
 	Resources *[]ResourceMonitor `json:"resources,omitempty"`
 	Systemd   *struct {
 		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
 	} `json:"systemd,omitempty"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+
+	// Time The time synchronization of the device. The agent configures chrony with the NTP sources in addition to those of the chrony configuration of the OS image.
+	Time      *DeviceTimeSpec `json:"time,omitempty"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
 }
 
 // TemplateVersionStatus_Config_Item defines model for TemplateVersionStatus.config.Item.
@@ -1154,11 +3675,165 @@ type TemplateVersionStatus_Config_Item struct {
 	union json.RawMessage
 }
 
+// TpmPinSpec Binds the volume to the TPM of the device.
+type TpmPinSpec struct {
+	// Pcrs PCRs whose SHA-256 values the key is sealed to, such as 7 for the Secure Boot state. The key is not sealed to any PCRs if unset.
+	Pcrs *[]int `json:"pcrs,omitempty"`
+}
+
+// TrashList TrashList is a list of the deleted resources kept in the trash.
+type TrashList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of deleted resources.
+	Items []TrashedResource `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// TrashedResource A deleted resource kept in the trash until it is restored or purged.
+type TrashedResource struct {
+	// DeletedAt The time the resource was deleted at.
+	DeletedAt time.Time `json:"deletedAt"`
+
+	// Kind The kind of the resource, Device or Fleet.
+	Kind string `json:"kind"`
+
+	// Name The name of the resource.
+	Name string `json:"name"`
+
+	// Owner The resource the deleted resource was owned by, as kind/name.
+	Owner *string `json:"owner,omitempty"`
+
+	// PurgeAt The time the resource is permanently deleted at, once the trash retention of the service elapsed.
+	PurgeAt time.Time `json:"purgeAt"`
+}
+
+// Webhook Webhook represents an HTTP endpoint that is notified when devices transition into selected states.
+type Webhook struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata ObjectMeta `json:"metadata"`
+
+	// Spec WebhookSpec describes the endpoint to notify and the device status transitions to notify about. If no selector is set, all devices are watched.
+	Spec WebhookSpec `json:"spec"`
+
+	// Status WebhookStatus represents information about the status of a webhook.
+	Status *WebhookStatus `json:"status,omitempty"`
+}
+
+// WebhookEvent WebhookEvent is the payload POSTed to a webhook when a device transitions into a watched state.
+type WebhookEvent struct {
+	// Current The state the device transitioned into.
+	Current string `json:"current"`
+
+	// Device The name of the device that transitioned.
+	Device string `json:"device"`
+
+	// Previous The state the device transitioned from.
+	Previous *string `json:"previous,omitempty"`
+
+	// Timestamp The time the transition was observed.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Trigger A device status transition that triggers a notification. Either summaryStatus or conditionType must be set. A conditionType trigger fires when the condition transitions to conditionStatus, which defaults to True.
+	Trigger WebhookTrigger `json:"trigger"`
+
+	// Webhook The name of the webhook that matched.
+	Webhook string `json:"webhook"`
+}
+
+// WebhookList WebhookList is a list of Webhooks.
+type WebhookList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of webhooks.
+	Items []Webhook `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata ListMeta `json:"metadata"`
+}
+
+// WebhookSpec WebhookSpec describes the endpoint to notify and the device status transitions to notify about. If no selector is set, all devices are watched.
+type WebhookSpec struct {
+	// DedupWindow Repeated notifications for the same device and trigger within this window are dropped. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours.
+	DedupWindow *string `json:"dedupWindow,omitempty"`
+
+	// Selector A map of key,value pairs that are ANDed. Empty/null label selectors match nothing.
+	Selector *LabelSelector `json:"selector,omitempty"`
+
+	// Triggers The device status transitions that trigger a notification. A webhook without triggers only receives the reports of the fleets referencing it.
+	Triggers []WebhookTrigger `json:"triggers"`
+
+	// Url The HTTP(S) URL that notifications are POSTed to. A notification which fails is retried up to four times, with delays doubling from 2 seconds.
+	Url string `json:"url"`
+}
+
+// WebhookStatus WebhookStatus represents information about the status of a webhook.
+type WebhookStatus struct {
+	// Conditions Current state of the webhook.
+	Conditions []Condition `json:"conditions"`
+}
+
+// WebhookTrigger A device status transition that triggers a notification. Either summaryStatus or conditionType must be set. A conditionType trigger fires when the condition transitions to conditionStatus, which defaults to True.
+type WebhookTrigger struct {
+	ConditionStatus *ConditionStatus         `json:"conditionStatus,omitempty"`
+	ConditionType   *ConditionType           `json:"conditionType,omitempty"`
+	SummaryStatus   *DeviceSummaryStatusType `json:"summaryStatus,omitempty"`
+}
+
+// ListArtifactsParams defines parameters for ListArtifacts.
+type ListArtifactsParams struct {
+	// Prefix only list the artifacts whose key starts with the prefix, such as reports/
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+}
+
+// DownloadArtifactParams defines parameters for DownloadArtifact.
+type DownloadArtifactParams struct {
+	// Key the key of the artifact
+	Key string `form:"key" json:"key"`
+
+	// Expires the Unix time the URL expires at
+	Expires int64 `form:"expires" json:"expires"`
+
+	// Signature the signature of the key and expiry
+	Signature string `form:"signature" json:"signature"`
+}
+
+// CreateArtifactDownloadUrlParams defines parameters for CreateArtifactDownloadUrl.
+type CreateArtifactDownloadUrlParams struct {
+	// Key the key of the artifact
+	Key string `form:"key" json:"key"`
+}
+
 // AuthValidateParams defines parameters for AuthValidate.
 type AuthValidateParams struct {
 	Authentication *string `json:"Authentication,omitempty"`
 }
 
+// ListIssuedCertificatesParams defines parameters for ListIssuedCertificates.
+type ListIssuedCertificatesParams struct {
+	// ExpiringWithin only list the certificates which expire within this duration, such as 720h, including the ones which already expired
+	ExpiringWithin *string `form:"expiringWithin,omitempty" json:"expiringWithin,omitempty"`
+
+	// Device only list the certificates issued to the specified Device
+	Device *string `form:"device,omitempty" json:"device,omitempty"`
+}
+
 // ListCertificateSigningRequestsParams defines parameters for ListCertificateSigningRequests.
 type ListCertificateSigningRequestsParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1171,6 +3846,27 @@ type ListCertificateSigningRequestsParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ReadDependenciesParams defines parameters for ReadDependencies.
+type ReadDependenciesParams struct {
+	// Kind the kind of the resource, Device, Fleet, Repository or ResourceSync
+	Kind string `form:"kind" json:"kind"`
+
+	// Name the name of the resource
+	Name string `form:"name" json:"name"`
+}
+
+// ListDeviceIdentitiesParams defines parameters for ListDeviceIdentities.
+type ListDeviceIdentitiesParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListDevicesParams defines parameters for ListDevices.
 type ListDevicesParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1182,17 +3878,62 @@ type ListDevicesParams struct {
 	// StatusFilter A filter to restrict the list of devices by the value of the filtered status key. Defaults to everything.
 	StatusFilter *[]string `form:"statusFilter,omitempty" json:"statusFilter,omitempty"`
 
+	// AnnotationSelector A selector to restrict the list of devices by the annotations written by their agent in status.annotations, as comma-separated "key" or "key=value" requirements which must all be met. Defaults to everything.
+	AnnotationSelector *string `form:"annotationSelector,omitempty" json:"annotationSelector,omitempty"`
+
 	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 
 	// Owner A selector to restrict the list of returned objects by their owner. Defaults to everything.
 	Owner *string `form:"owner,omitempty" json:"owner,omitempty"`
+
+	// BoundingBox A bounding box to restrict the list of devices to those whose reported location lies within it, as "west,south,east,north" in degrees. A box whose west edge is greater than its east edge crosses the antimeridian. Defaults to everything.
+	BoundingBox *string `form:"boundingBox,omitempty" json:"boundingBox,omitempty"`
+
+	// Alias Restricts the list of devices to those whose name, display name or one of whose aliases is the value. Defaults to everything.
+	Alias *string `form:"alias,omitempty" json:"alias,omitempty"`
+
+	// ApplicationRestarts Restricts the list of devices to those with an application which restarted at least this many times, as reported in status.applications. Defaults to everything.
+	ApplicationRestarts *int32 `form:"applicationRestarts,omitempty" json:"applicationRestarts,omitempty"`
+}
+
+// RequestConsoleParams defines parameters for RequestConsole.
+type RequestConsoleParams struct {
+	// PublicKey The base64-encoded X25519 public key of the operator. If set, the session is encrypted end to end between the operator and the device.
+	PublicKey *string `form:"publicKey,omitempty" json:"publicKey,omitempty"`
 }
 
 // GetRenderedDeviceSpecParams defines parameters for GetRenderedDeviceSpec.
 type GetRenderedDeviceSpecParams struct {
 	// KnownRenderedVersion The last known renderedVersion
 	KnownRenderedVersion *string `form:"knownRenderedVersion,omitempty" json:"knownRenderedVersion,omitempty"`
+
+	// SpecVersions The rendered spec versions supported by the client. The spec is rendered in the latest version supported by both the client and the service, which is the latest version of the service if none is set.
+	SpecVersions *[]string `form:"specVersions,omitempty" json:"specVersions,omitempty"`
+}
+
+// ListDeviceViewsParams defines parameters for ListDeviceViews.
+type ListDeviceViewsParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListDeviceViewDevicesParams defines parameters for ListDeviceViewDevices.
+type ListDeviceViewDevicesParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector further restricting the devices of the view by their labels. Defaults to the devices of the view.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // ListEnrollmentRequestsParams defines parameters for ListEnrollmentRequests.
@@ -1234,12 +3975,51 @@ type ListTemplateVersionsParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// DeleteFleetParams defines parameters for DeleteFleet.
+type DeleteFleetParams struct {
+	// PropagationPolicy what to do with the devices owned by the fleet. Block, the default, refuses to delete a fleet that owns devices, Orphan deletes the fleet and releases its devices, and Cascade also deletes its devices
+	PropagationPolicy *DeletionPropagationPolicy `form:"propagationPolicy,omitempty" json:"propagationPolicy,omitempty"`
+}
+
 // ReadFleetParams defines parameters for ReadFleet.
 type ReadFleetParams struct {
 	// AddDevicesSummary include a summary of the devices in the fleet
 	AddDevicesSummary *bool `form:"addDevicesSummary,omitempty" json:"addDevicesSummary,omitempty"`
 }
 
+// ReadFleetProvisioningParams defines parameters for ReadFleetProvisioning.
+type ReadFleetProvisioningParams struct {
+	// Format the format of the fragment
+	Format ProvisioningFormat `form:"format" json:"format"`
+
+	// Validity how long devices can enroll with the credentials of the fragment, as a duration such as 24h. Defaults to 7 days.
+	Validity *string `form:"validity,omitempty" json:"validity,omitempty"`
+}
+
+// ListLabelRulesParams defines parameters for ListLabelRules.
+type ListLabelRulesParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListOperationsParams defines parameters for ListOperations.
+type ListOperationsParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListRepositoriesParams defines parameters for ListRepositories.
 type ListRepositoriesParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1252,6 +4032,12 @@ type ListRepositoriesParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// DeleteRepositoryParams defines parameters for DeleteRepository.
+type DeleteRepositoryParams struct {
+	// PropagationPolicy what to do with the fleets and devices referencing the repository. Block, the default, refuses to delete a repository that is referenced, Orphan deletes it anyway
+	PropagationPolicy *DeletionPropagationPolicy `form:"propagationPolicy,omitempty" json:"propagationPolicy,omitempty"`
+}
+
 // ListResourceSyncParams defines parameters for ListResourceSync.
 type ListResourceSyncParams struct {
 	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
@@ -1264,6 +4050,54 @@ type ListResourceSyncParams struct {
 	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// ListImageSbomsParams defines parameters for ListImageSboms.
+type ListImageSbomsParams struct {
+	// Device only list the SBOMs of the images the specified Device runs
+	Device *string `form:"device,omitempty" json:"device,omitempty"`
+
+	// Component only list the SBOMs containing a component of this name or package URL, and only the matching components
+	Component *string `form:"component,omitempty" json:"component,omitempty"`
+
+	// Version only list the components of this version, together with component
+	Version *string `form:"version,omitempty" json:"version,omitempty"`
+
+	// Vulnerability only list the SBOMs reporting this vulnerability, such as CVE-2024-3094, and only the matching vulnerabilities
+	Vulnerability *string `form:"vulnerability,omitempty" json:"vulnerability,omitempty"`
+}
+
+// ListSitesParams defines parameters for ListSites.
+type ListSitesParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTrashParams defines parameters for ListTrash.
+type ListTrashParams struct {
+	// Kind only list the deleted resources of this kind, Device or Fleet
+	Kind *string `form:"kind,omitempty" json:"kind,omitempty"`
+}
+
+// ListWebhooksParams defines parameters for ListWebhooks.
+type ListWebhooksParams struct {
+	// Continue An optional parameter to query more results from the server. The value of the paramter must match the value of the 'continue' field in the previous list response.
+	Continue *string `form:"continue,omitempty" json:"continue,omitempty"`
+
+	// LabelSelector A selector to restrict the list of returned objects by their labels. Defaults to everything.
+	LabelSelector *string `form:"labelSelector,omitempty" json:"labelSelector,omitempty"`
+
+	// Limit The maximum number of results returned in the list response. The server will set the 'continue' field in the list response if more results exist. The continue value may then be specified as parameter in a subsequent query.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// CreateBulkImportJSONRequestBody defines body for CreateBulkImport for application/json ContentType.
+type CreateBulkImportJSONRequestBody = BulkImport
+
 // CreateCertificateSigningRequestJSONRequestBody defines body for CreateCertificateSigningRequest for application/json ContentType.
 type CreateCertificateSigningRequestJSONRequestBody = CertificateSigningRequest
 
@@ -1273,6 +4107,12 @@ type PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody = Pat
 // ReplaceCertificateSigningRequestJSONRequestBody defines body for ReplaceCertificateSigningRequest for application/json ContentType.
 type ReplaceCertificateSigningRequestJSONRequestBody = CertificateSigningRequest
 
+// CreateDeviceIdentityJSONRequestBody defines body for CreateDeviceIdentity for application/json ContentType.
+type CreateDeviceIdentityJSONRequestBody = DeviceIdentity
+
+// ReplaceDeviceIdentityJSONRequestBody defines body for ReplaceDeviceIdentity for application/json ContentType.
+type ReplaceDeviceIdentityJSONRequestBody = DeviceIdentity
+
 // CreateDeviceJSONRequestBody defines body for CreateDevice for application/json ContentType.
 type CreateDeviceJSONRequestBody = Device
 
@@ -1282,9 +4122,42 @@ type PatchDeviceApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 // ReplaceDeviceJSONRequestBody defines body for ReplaceDevice for application/json ContentType.
 type ReplaceDeviceJSONRequestBody = Device
 
+// RequestDeviceActionJSONRequestBody defines body for RequestDeviceAction for application/json ContentType.
+type RequestDeviceActionJSONRequestBody = DeviceActionRequest
+
+// ReplaceDeviceAliasesJSONRequestBody defines body for ReplaceDeviceAliases for application/json ContentType.
+type ReplaceDeviceAliasesJSONRequestBody = DeviceAliases
+
+// RequestConsoleGrantJSONRequestBody defines body for RequestConsoleGrant for application/json ContentType.
+type RequestConsoleGrantJSONRequestBody = ConsoleGrantRequest
+
+// ApproveConsoleGrantJSONRequestBody defines body for ApproveConsoleGrant for application/json ContentType.
+type ApproveConsoleGrantJSONRequestBody = ConsoleGrantApproval
+
+// ExecDeviceCommandJSONRequestBody defines body for ExecDeviceCommand for application/json ContentType.
+type ExecDeviceCommandJSONRequestBody = DeviceExecRequest
+
+// QuarantineDeviceJSONRequestBody defines body for QuarantineDevice for application/json ContentType.
+type QuarantineDeviceJSONRequestBody = DeviceQuarantine
+
 // ReplaceDeviceStatusJSONRequestBody defines body for ReplaceDeviceStatus for application/json ContentType.
 type ReplaceDeviceStatusJSONRequestBody = Device
 
+// RequestDeviceTunnelJSONRequestBody defines body for RequestDeviceTunnel for application/json ContentType.
+type RequestDeviceTunnelJSONRequestBody = DeviceTunnelRequest
+
+// WakeDeviceJSONRequestBody defines body for WakeDevice for application/json ContentType.
+type WakeDeviceJSONRequestBody = DeviceWakeRequest
+
+// ReplaceDeviceStatusesJSONRequestBody defines body for ReplaceDeviceStatuses for application/json ContentType.
+type ReplaceDeviceStatusesJSONRequestBody = DeviceStatusBatch
+
+// CreateDeviceViewJSONRequestBody defines body for CreateDeviceView for application/json ContentType.
+type CreateDeviceViewJSONRequestBody = DeviceView
+
+// ReplaceDeviceViewJSONRequestBody defines body for ReplaceDeviceView for application/json ContentType.
+type ReplaceDeviceViewJSONRequestBody = DeviceView
+
 // CreateEnrollmentRequestJSONRequestBody defines body for CreateEnrollmentRequest for application/json ContentType.
 type CreateEnrollmentRequestJSONRequestBody = EnrollmentRequest
 
@@ -1297,6 +4170,9 @@ type ApproveEnrollmentRequestJSONRequestBody = EnrollmentRequestApproval
 // ReplaceEnrollmentRequestStatusJSONRequestBody defines body for ReplaceEnrollmentRequestStatus for application/json ContentType.
 type ReplaceEnrollmentRequestStatusJSONRequestBody = EnrollmentRequest
 
+// CreateResourceExportJSONRequestBody defines body for CreateResourceExport for application/json ContentType.
+type CreateResourceExportJSONRequestBody = ResourceExportRequest
+
 // CreateFleetJSONRequestBody defines body for CreateFleet for application/json ContentType.
 type CreateFleetJSONRequestBody = Fleet
 
@@ -1306,9 +4182,33 @@ type PatchFleetApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 // ReplaceFleetJSONRequestBody defines body for ReplaceFleet for application/json ContentType.
 type ReplaceFleetJSONRequestBody = Fleet
 
+// LintFleetJSONRequestBody defines body for LintFleet for application/json ContentType.
+type LintFleetJSONRequestBody = Fleet
+
+// MigrateFleetDevicesJSONRequestBody defines body for MigrateFleetDevices for application/json ContentType.
+type MigrateFleetDevicesJSONRequestBody = DeviceMigration
+
+// CreateProvisioningTokenJSONRequestBody defines body for CreateProvisioningToken for application/json ContentType.
+type CreateProvisioningTokenJSONRequestBody = ProvisioningTokenRequest
+
+// AbortFleetRolloutJSONRequestBody defines body for AbortFleetRollout for application/json ContentType.
+type AbortFleetRolloutJSONRequestBody = FleetRolloutAbort
+
 // ReplaceFleetStatusJSONRequestBody defines body for ReplaceFleetStatus for application/json ContentType.
 type ReplaceFleetStatusJSONRequestBody = Fleet
 
+// CreateResourceImportJSONRequestBody defines body for CreateResourceImport for application/json ContentType.
+type CreateResourceImportJSONRequestBody = ResourceExport
+
+// CreateLabelRuleJSONRequestBody defines body for CreateLabelRule for application/json ContentType.
+type CreateLabelRuleJSONRequestBody = LabelRule
+
+// ReplaceLabelRuleJSONRequestBody defines body for ReplaceLabelRule for application/json ContentType.
+type ReplaceLabelRuleJSONRequestBody = LabelRule
+
+// CreateOperationJSONRequestBody defines body for CreateOperation for application/json ContentType.
+type CreateOperationJSONRequestBody = Operation
+
 // CreateRepositoryJSONRequestBody defines body for CreateRepository for application/json ContentType.
 type CreateRepositoryJSONRequestBody = Repository
 
@@ -1318,6 +4218,9 @@ type PatchRepositoryApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 // ReplaceRepositoryJSONRequestBody defines body for ReplaceRepository for application/json ContentType.
 type ReplaceRepositoryJSONRequestBody = Repository
 
+// RotateRepositoryCredentialsJSONRequestBody defines body for RotateRepositoryCredentials for application/json ContentType.
+type RotateRepositoryCredentialsJSONRequestBody = RepositoryCredentials
+
 // CreateResourceSyncJSONRequestBody defines body for CreateResourceSync for application/json ContentType.
 type CreateResourceSyncJSONRequestBody = ResourceSync
 
@@ -1327,6 +4230,18 @@ type PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody = PatchRequest
 // ReplaceResourceSyncJSONRequestBody defines body for ReplaceResourceSync for application/json ContentType.
 type ReplaceResourceSyncJSONRequestBody = ResourceSync
 
+// CreateSiteJSONRequestBody defines body for CreateSite for application/json ContentType.
+type CreateSiteJSONRequestBody = Site
+
+// ReplaceSiteJSONRequestBody defines body for ReplaceSite for application/json ContentType.
+type ReplaceSiteJSONRequestBody = Site
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
+// ReplaceWebhookJSONRequestBody defines body for ReplaceWebhook for application/json ContentType.
+type ReplaceWebhookJSONRequestBody = Webhook
+
 // AsGitConfigProviderSpec returns the union data inside the DeviceSpec_Config_Item as a GitConfigProviderSpec
 func (t DeviceSpec_Config_Item) AsGitConfigProviderSpec() (GitConfigProviderSpec, error) {
 	var body GitConfigProviderSpec
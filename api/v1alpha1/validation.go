@@ -1,13 +1,32 @@
 package v1alpha1
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/samber/lo"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 const maxBase64CertificateLength = 20 * 1024 * 1024
 
+var (
+	httpHeaderNameRegexp = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	sha256Regexp         = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+)
+
 type Validator interface {
 	Validate() []error
 }
@@ -19,7 +38,7 @@ func (r Device) Validate() []error {
 	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
 	if r.Spec != nil {
 		if r.Spec.Os != nil {
-			allErrs = append(allErrs, validation.ValidateOciImageReference(&r.Spec.Os.Image, "spec.os.image")...)
+			allErrs = append(allErrs, validateOs(r.Spec.Os, "spec.os", false)...)
 		}
 		if r.Spec.Config != nil {
 			for _, config := range *r.Spec.Config {
@@ -43,6 +62,33 @@ func (r Device) Validate() []error {
 				allErrs = append(allErrs, validation.ValidateString(&matchPattern, fmt.Sprintf("spec.systemd.matchPatterns[%d]", i), 1, 256, nil, "")...)
 			}
 		}
+		if r.Spec.Agent != nil {
+			allErrs = append(allErrs, validateAgent(r.Spec.Agent, "spec.agent")...)
+		}
+		if r.Spec.Encryption != nil {
+			allErrs = append(allErrs, validateEncryption(r.Spec.Encryption, "spec.encryption")...)
+		}
+		if r.Spec.Crypto != nil {
+			allErrs = append(allErrs, validateCrypto(r.Spec.Crypto, "spec.crypto")...)
+		}
+		if r.Spec.Time != nil {
+			allErrs = append(allErrs, validateTime(r.Spec.Time, "spec.time")...)
+		}
+		if r.Spec.Compliance != nil {
+			allErrs = append(allErrs, validateCompliance(r.Spec.Compliance, "spec.compliance")...)
+		}
+		if r.Spec.Firewall != nil {
+			allErrs = append(allErrs, validateFirewall(r.Spec.Firewall, "spec.firewall")...)
+		}
+		if r.Spec.GarbageCollection != nil {
+			allErrs = append(allErrs, validateGarbageCollection(r.Spec.GarbageCollection, "spec.garbageCollection")...)
+		}
+		if r.Spec.Drift != nil {
+			allErrs = append(allErrs, validateDrift(r.Spec.Drift, "spec.drift")...)
+		}
+		if r.Spec.Applications != nil {
+			allErrs = append(allErrs, validateApplications(*r.Spec.Applications, "spec.applications")...)
+		}
 	}
 	return allErrs
 }
@@ -77,6 +123,12 @@ func (h HttpConfigProviderSpec) Validate() []error {
 	allErrs = append(allErrs, validation.ValidateGenericName(&h.HttpRef.Repository, "spec.config[].httpRef.repository")...)
 	allErrs = append(allErrs, validation.ValidateString(&h.HttpRef.FilePath, "spec.config[].httpRef.filePath", 0, 2048, nil, "")...)
 	allErrs = append(allErrs, validation.ValidateString(h.HttpRef.Suffix, "spec.config[].httpRef.suffix", 0, 2048, nil, "")...)
+	if h.HttpRef.Format != nil && !lo.Contains([]HttpContentFormat{HttpContentFormatFile, HttpContentFormatArchive}, *h.HttpRef.Format) {
+		allErrs = append(allErrs, fmt.Errorf("spec.config[].httpRef.format must be one of %s or %s", HttpContentFormatFile, HttpContentFormatArchive))
+	}
+	if h.HttpRef.Sha256 != nil && !sha256Regexp.MatchString(*h.HttpRef.Sha256) {
+		allErrs = append(allErrs, fmt.Errorf("spec.config[].httpRef.sha256 must be a hex encoded SHA-256 checksum"))
+	}
 
 	return allErrs
 }
@@ -90,6 +142,13 @@ func (r EnrollmentRequest) Validate() []error {
 	return allErrs
 }
 
+func (r ProvisioningTokenRequest) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateLabelsWithPath(r.Labels, "labels")...)
+	allErrs = append(allErrs, validation.ValidateString(r.Description, "description", 0, 2048, nil, "")...)
+	return allErrs
+}
+
 func (r EnrollmentRequestApproval) Validate() []error {
 	allErrs := []error{}
 	allErrs = append(allErrs, validation.ValidateLabelsWithPath(r.Labels, "labels")...)
@@ -117,7 +176,7 @@ func (r Fleet) Validate() []error {
 
 	// Validate the Device spec settings
 	if r.Spec.Template.Spec.Os != nil {
-		allErrs = append(allErrs, validation.ValidateOciImageReference(&r.Spec.Template.Spec.Os.Image, "spec.template.spec.os.image")...)
+		allErrs = append(allErrs, validateOs(r.Spec.Template.Spec.Os, "spec.template.spec.os", true)...)
 	}
 
 	if r.Spec.Template.Spec.Config != nil {
@@ -131,6 +190,50 @@ func (r Fleet) Validate() []error {
 		}
 	}
 
+	if r.Spec.Template.Spec.Agent != nil {
+		allErrs = append(allErrs, validateAgent(r.Spec.Template.Spec.Agent, "spec.template.spec.agent")...)
+	}
+
+	if r.Spec.Template.Spec.Encryption != nil {
+		allErrs = append(allErrs, validateEncryption(r.Spec.Template.Spec.Encryption, "spec.template.spec.encryption")...)
+	}
+
+	if r.Spec.Template.Spec.Crypto != nil {
+		allErrs = append(allErrs, validateCrypto(r.Spec.Template.Spec.Crypto, "spec.template.spec.crypto")...)
+	}
+
+	if r.Spec.Template.Spec.Time != nil {
+		allErrs = append(allErrs, validateTime(r.Spec.Template.Spec.Time, "spec.template.spec.time")...)
+	}
+
+	if r.Spec.Template.Spec.Compliance != nil {
+		allErrs = append(allErrs, validateCompliance(r.Spec.Template.Spec.Compliance, "spec.template.spec.compliance")...)
+	}
+
+	if r.Spec.Template.Spec.Firewall != nil {
+		allErrs = append(allErrs, validateFirewall(r.Spec.Template.Spec.Firewall, "spec.template.spec.firewall")...)
+	}
+
+	if r.Spec.Template.Spec.GarbageCollection != nil {
+		allErrs = append(allErrs, validateGarbageCollection(r.Spec.Template.Spec.GarbageCollection, "spec.template.spec.garbageCollection")...)
+	}
+
+	if r.Spec.Template.Spec.Drift != nil {
+		allErrs = append(allErrs, validateDrift(r.Spec.Template.Spec.Drift, "spec.template.spec.drift")...)
+	}
+
+	if r.Spec.Template.Spec.Applications != nil {
+		allErrs = append(allErrs, validateApplications(*r.Spec.Template.Spec.Applications, "spec.template.spec.applications")...)
+	}
+
+	if r.Spec.Reports != nil {
+		allErrs = append(allErrs, validateFleetReports(r.Spec.Reports, "spec.reports")...)
+	}
+
+	if r.Spec.Promotion != nil {
+		allErrs = append(allErrs, validateFleetPromotion(r.Spec.Promotion, lo.FromPtr(r.Metadata.Name), "spec.promotion")...)
+	}
+
 	return allErrs
 }
 
@@ -178,8 +281,623 @@ func (r ResourceSync) Validate() []error {
 	return allErrs
 }
 
+func (r Webhook) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+	allErrs = append(allErrs, validation.ValidateString(&r.Spec.Url, "spec.url", 1, 2048, nil, "")...)
+	if r.Spec.Selector != nil {
+		allErrs = append(allErrs, validation.ValidateLabelsWithPath(&r.Spec.Selector.MatchLabels, "spec.selector.matchLabels")...)
+	}
+	for i, trigger := range r.Spec.Triggers {
+		if (trigger.SummaryStatus == nil) == (trigger.ConditionType == nil) {
+			allErrs = append(allErrs, fmt.Errorf("spec.triggers[%d]: exactly one of summaryStatus or conditionType must be set", i))
+		}
+		if trigger.ConditionStatus != nil && trigger.ConditionType == nil {
+			allErrs = append(allErrs, fmt.Errorf("spec.triggers[%d]: conditionStatus requires conditionType", i))
+		}
+	}
+	return allErrs
+}
+
+func (r LabelRule) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+	allErrs = append(allErrs, validation.ValidateSystemInfoField(&r.Spec.Field, "spec.field")...)
+	allErrs = append(allErrs, validation.ValidateLabelKey(&r.Spec.LabelKey, "spec.labelKey")...)
+	allErrs = append(allErrs, validation.ValidateLabelValue(r.Spec.LabelValue, "spec.labelValue")...)
+	if r.Spec.MatchValues != nil && len(*r.Spec.MatchValues) == 0 {
+		allErrs = append(allErrs, fmt.Errorf("spec.matchValues: must not be empty if set"))
+	}
+	return allErrs
+}
+
+func (r DeviceIdentity) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+	if r.Spec.SerialNumber == nil && r.Spec.MacAddress == nil && r.Spec.TpmEkHash == nil {
+		allErrs = append(allErrs, fmt.Errorf("spec: at least one of serialNumber, macAddress and tpmEkHash must be set"))
+	}
+	allErrs = append(allErrs, validation.ValidateString(r.Spec.SerialNumber, "spec.serialNumber", 1, 256, nil, "")...)
+	allErrs = append(allErrs, validation.ValidateMacAddress(r.Spec.MacAddress, "spec.macAddress")...)
+	allErrs = append(allErrs, validation.ValidateSha256(r.Spec.TpmEkHash, "spec.tpmEkHash")...)
+	allErrs = append(allErrs, validation.ValidateLabelsWithPath(r.Spec.Labels, "spec.labels")...)
+	return allErrs
+}
+
+func (r DeviceView) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+	allErrs = append(allErrs, validation.ValidateString(r.Spec.Description, "spec.description", 0, 1024, nil, "")...)
+	columns := lo.FromPtr(r.Spec.Columns)
+	for i, column := range columns {
+		if !lo.Contains(deviceViewColumns, column) {
+			allErrs = append(allErrs, fmt.Errorf("spec.columns[%d]: unknown column %q", i, column))
+		}
+	}
+	for _, column := range lo.FindDuplicates(columns) {
+		allErrs = append(allErrs, fmt.Errorf("spec.columns: %s is listed more than once", column))
+	}
+	return allErrs
+}
+
+func (r Site) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	// the devices of the site are labeled with its name
+	allErrs = append(allErrs, validation.ValidateLabelValue(r.Metadata.Name, "metadata.name")...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+	allErrs = append(allErrs, validation.ValidateString(r.Spec.Description, "spec.description", 0, 1024, nil, "")...)
+	if r.Spec.BandwidthBudget != nil && *r.Spec.BandwidthBudget < 1 {
+		allErrs = append(allErrs, fmt.Errorf("spec.bandwidthBudget must be at least 1 byte per second"))
+	}
+	return allErrs
+}
+
+var deviceViewColumns = []DeviceViewColumn{
+	DeviceViewColumnName,
+	DeviceViewColumnDisplayName,
+	DeviceViewColumnOwner,
+	DeviceViewColumnLabels,
+	DeviceViewColumnSystem,
+	DeviceViewColumnUpdated,
+	DeviceViewColumnApplications,
+	DeviceViewColumnArchitecture,
+	DeviceViewColumnOS,
+	DeviceViewColumnLastSeen,
+}
+
+// maxDeviceAliases is the number of aliases a device can have.
+const maxDeviceAliases = 16
+
+func (r DeviceAliases) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateString(r.DisplayName, "displayName", 1, 253, nil, "")...)
+	aliases := lo.FromPtr(r.Aliases)
+	if len(aliases) > maxDeviceAliases {
+		allErrs = append(allErrs, fmt.Errorf("aliases: a device can have at most %d aliases", maxDeviceAliases))
+	}
+	for i := range aliases {
+		allErrs = append(allErrs, validation.ValidateGenericName(&aliases[i], fmt.Sprintf("aliases[%d]", i))...)
+	}
+	for _, alias := range lo.FindDuplicates(aliases) {
+		allErrs = append(allErrs, fmt.Errorf("aliases: %s is listed more than once", alias))
+	}
+	return allErrs
+}
+
+// IsEmpty reports whether the identifying system information is unset. The
+// hardware facts are refreshed separately and are not taken into account.
 func (d *DeviceSystemInfo) IsEmpty() bool {
-	return *d == DeviceSystemInfo{}
+	return d.Architecture == "" && d.BootID == "" && d.OperatingSystem == ""
+}
+
+func validateAgent(agent *DeviceAgentSpec, path string) []error {
+	allErrs := []error{}
+	if agent.Update != nil {
+		allErrs = append(allErrs, validateAgentUpdate(agent.Update, path+".update")...)
+	}
+	if thresholds := agent.MonitorThresholds; thresholds != nil {
+		allErrs = append(allErrs, validateResourceThresholds(thresholds.Cpu, path+".monitorThresholds.cpu")...)
+		allErrs = append(allErrs, validateResourceThresholds(thresholds.Memory, path+".monitorThresholds.memory")...)
+		allErrs = append(allErrs, validateResourceThresholds(thresholds.Disk, path+".monitorThresholds.disk")...)
+	}
+	for i, hookPath := range lo.FromPtr(agent.AllowedHookPaths) {
+		if !filepath.IsAbs(hookPath) || filepath.Clean(hookPath) != hookPath {
+			allErrs = append(allErrs, fmt.Errorf("%s.allowedHookPaths[%d]: must be a clean absolute path", path, i))
+		}
+	}
+	return allErrs
+}
+
+func validateResourceThresholds(thresholds *ResourceThresholds, path string) []error {
+	if thresholds == nil {
+		return nil
+	}
+	allErrs := []error{}
+	if p := thresholds.WarningPercentage; p != nil && (*p < 0 || *p > 100) {
+		allErrs = append(allErrs, fmt.Errorf("%s.warningPercentage: must be between 0 and 100", path))
+	}
+	if p := thresholds.CriticalPercentage; p != nil && (*p < 0 || *p > 100) {
+		allErrs = append(allErrs, fmt.Errorf("%s.criticalPercentage: must be between 0 and 100", path))
+	}
+	if thresholds.WarningPercentage != nil && thresholds.CriticalPercentage != nil && *thresholds.WarningPercentage > *thresholds.CriticalPercentage {
+		allErrs = append(allErrs, fmt.Errorf("%s: warningPercentage must not exceed criticalPercentage", path))
+	}
+	return allErrs
+}
+
+func validateAgentUpdate(update *AgentUpdateSpec, path string) []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateVersion(&update.Version, path+".version")...)
+	if (update.Image == nil) == (update.Url == nil) {
+		allErrs = append(allErrs, fmt.Errorf("%s: exactly one of image or url must be set", path))
+	}
+	if update.Image != nil {
+		allErrs = append(allErrs, validation.ValidateOciImageReference(update.Image, path+".image")...)
+	}
+	if update.Url != nil {
+		allErrs = append(allErrs, validation.ValidateString(update.Url, path+".url", 1, 2048, nil, "")...)
+		if update.Sha256 == nil {
+			allErrs = append(allErrs, fmt.Errorf("%s.sha256: required when url is set", path))
+		}
+	}
+	if update.Sha256 != nil {
+		allErrs = append(allErrs, validation.ValidateSha256(update.Sha256, path+".sha256")...)
+	}
+	return allErrs
+}
+
+func validateEncryption(encryption *DeviceEncryptionSpec, path string) []error {
+	allErrs := []error{}
+	devices := map[string]bool{}
+	for i, volume := range encryption.Volumes {
+		volumePath := fmt.Sprintf("%s.volumes[%d]", path, i)
+		if !filepath.IsAbs(volume.Device) {
+			allErrs = append(allErrs, fmt.Errorf("%s.device: must be an absolute path", volumePath))
+		} else if devices[volume.Device] {
+			allErrs = append(allErrs, fmt.Errorf("%s.device: volume %s is listed more than once", volumePath, volume.Device))
+		}
+		devices[volume.Device] = true
+
+		pins := len(lo.FromPtr(volume.Tang))
+		if volume.Tpm2 != nil {
+			pins++
+			for j, pcr := range lo.FromPtr(volume.Tpm2.Pcrs) {
+				if pcr < 0 || pcr > 23 {
+					allErrs = append(allErrs, fmt.Errorf("%s.tpm2.pcrs[%d]: must be between 0 and 23", volumePath, j))
+				}
+			}
+		}
+		if pins == 0 {
+			allErrs = append(allErrs, fmt.Errorf("%s: at least one of tpm2 or tang must be set", volumePath))
+		}
+		if volume.Threshold != nil && (*volume.Threshold < 1 || *volume.Threshold > pins) {
+			allErrs = append(allErrs, fmt.Errorf("%s.threshold: must be between 1 and the number of pins (%d)", volumePath, pins))
+		}
+		for j, tang := range lo.FromPtr(volume.Tang) {
+			u, err := url.Parse(tang.Url)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, fmt.Errorf("%s.tang[%d].url: must be an http or https URL", volumePath, j))
+			}
+		}
+		if volume.KeyFile != nil && !filepath.IsAbs(*volume.KeyFile) {
+			allErrs = append(allErrs, fmt.Errorf("%s.keyFile: must be an absolute path", volumePath))
+		}
+	}
+	return allErrs
+}
+
+func validateCrypto(crypto *DeviceCryptoSpec, path string) []error {
+	allErrs := []error{}
+	if crypto.AllowedPolicies != nil {
+		if len(*crypto.AllowedPolicies) == 0 {
+			allErrs = append(allErrs, fmt.Errorf("%s.allowedPolicies: must not be empty if set", path))
+		}
+		for i, policy := range *crypto.AllowedPolicies {
+			policy := policy
+			allErrs = append(allErrs, validation.ValidateString(&policy, fmt.Sprintf("%s.allowedPolicies[%d]", path, i), 1, 256, nil, "")...)
+		}
+	}
+	return allErrs
+}
+
+// validateOs checks that the image following a stream is the repository in a
+// fleet template, which the service pins by digest, and is pinned by digest
+// on a device.
+func validateOs(os *DeviceOSSpec, path string, template bool) []error {
+	allErrs := validation.ValidateOciImageReference(&os.Image, path+".image")
+	allErrs = append(allErrs, validateArchitectureImages(os.ArchitectureImages, path+".architectureImages")...)
+	if os.Stream != nil && os.ArchitectureImages != nil {
+		allErrs = append(allErrs, fmt.Errorf("%s.architectureImages: cannot be combined with stream %s", path, *os.Stream))
+	}
+	if os.Stream == nil || len(allErrs) > 0 {
+		return allErrs
+	}
+	allErrs = append(allErrs, validation.ValidateOciImageTag(os.Stream, path+".stream")...)
+	match := validation.OciImageReferenceRegexp.FindStringSubmatch(os.Image)
+	hasTag, hasDigest := match[2] != "", match[3] != ""
+	switch {
+	case template && (hasTag || hasDigest):
+		allErrs = append(allErrs, fmt.Errorf("%s.image: must be a repository without tag or digest when following stream %s", path, *os.Stream))
+	case !template && !hasDigest:
+		allErrs = append(allErrs, fmt.Errorf("%s.image: must be pinned by digest when following stream %s, which is resolved in fleet templates only", path, *os.Stream))
+	}
+	return allErrs
+}
+
+// validateArchitectureImages checks that the images are specified for the
+// architectures devices report.
+func validateArchitectureImages(images *map[string]string, path string) []error {
+	allErrs := []error{}
+	architectures := lo.Keys(lo.FromPtr(images))
+	slices.Sort(architectures)
+	for _, architecture := range architectures {
+		if !lo.Contains(Architectures, architecture) {
+			allErrs = append(allErrs, fmt.Errorf("%s: unknown architecture %s, must be one of %s", path, architecture, strings.Join(Architectures, ", ")))
+			continue
+		}
+		image := (*images)[architecture]
+		allErrs = append(allErrs, validation.ValidateOciImageReference(&image, path+"."+architecture)...)
+	}
+	return allErrs
+}
+
+// validateTime checks that the NTP sources are host names, as the agent writes
+// them into the chrony configuration.
+func validateTime(timeSpec *DeviceTimeSpec, path string) []error {
+	allErrs := []error{}
+	for i, server := range lo.FromPtr(timeSpec.Servers) {
+		server := server
+		allErrs = append(allErrs, validation.ValidateHostname(&server, fmt.Sprintf("%s.servers[%d]", path, i))...)
+	}
+	for i, pool := range lo.FromPtr(timeSpec.Pools) {
+		pool := pool
+		allErrs = append(allErrs, validation.ValidateHostname(&pool, fmt.Sprintf("%s.pools[%d]", path, i))...)
+	}
+	if dups := lo.FindDuplicates(append(lo.FromPtr(timeSpec.Servers), lo.FromPtr(timeSpec.Pools)...)); len(dups) > 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s: NTP source %s is listed more than once", path, dups[0]))
+	}
+	allErrs = append(allErrs, validation.ValidateMinimum(timeSpec.MaxSkewSeconds, path+".maxSkewSeconds", 1)...)
+	return allErrs
+}
+
+const xccdfProfileFmt = `[A-Za-z0-9_.-]+`
+
+var xccdfProfileRegexp = regexp.MustCompile("^" + xccdfProfileFmt + "$")
+
+// validateCompliance checks that the profile is an XCCDF identifier, as the
+// agent passes it to oscap, and that the device is not scanned more often
+// than hourly, since a scan is expensive.
+func validateCompliance(compliance *DeviceComplianceSpec, path string) []error {
+	allErrs := validation.ValidateString(&compliance.Profile, path+".profile", 1, 256, xccdfProfileRegexp, xccdfProfileFmt, "xccdf_org.ssgproject.content_profile_cis")
+	if compliance.Datastream != nil && !filepath.IsAbs(*compliance.Datastream) {
+		allErrs = append(allErrs, fmt.Errorf("%s.datastream: must be an absolute path", path))
+	}
+	if compliance.Interval != nil {
+		if errs := validation.ValidateDuration(compliance.Interval, path+".interval"); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else if interval, _ := time.ParseDuration(*compliance.Interval); interval < time.Hour {
+			allErrs = append(allErrs, fmt.Errorf("%s.interval: must be at least 1h", path))
+		}
+	}
+	return allErrs
+}
+
+const firewallRuleNameFmt = `[A-Za-z0-9_. -]+`
+
+var firewallRuleNameRegexp = regexp.MustCompile("^" + firewallRuleNameFmt + "$")
+
+// validateFirewall checks the rules the agent writes into its nftables
+// ruleset: ports only match the tcp and udp protocols, and sources are IP
+// addresses or CIDR ranges.
+func validateFirewall(firewall *DeviceFirewallSpec, path string) []error {
+	allErrs := []error{}
+	if policy := firewall.InputPolicy; policy != nil && *policy != FirewallAccept && *policy != FirewallDrop {
+		allErrs = append(allErrs, fmt.Errorf("%s.inputPolicy: must be %s or %s", path, FirewallAccept, FirewallDrop))
+	}
+	for i, rule := range lo.FromPtr(firewall.Rules) {
+		rulePath := fmt.Sprintf("%s.rules[%d]", path, i)
+		if rule.Name != nil {
+			allErrs = append(allErrs, validation.ValidateString(rule.Name, rulePath+".name", 1, 64, firewallRuleNameRegexp, firewallRuleNameFmt, "allow ssh")...)
+		}
+		if rule.Action != FirewallAccept && rule.Action != FirewallDrop {
+			allErrs = append(allErrs, fmt.Errorf("%s.action: must be %s or %s", rulePath, FirewallAccept, FirewallDrop))
+		}
+		protocol := lo.FromPtr(rule.Protocol)
+		if !lo.Contains([]FirewallProtocol{"", FirewallProtocolTcp, FirewallProtocolUdp, FirewallProtocolIcmp}, protocol) {
+			allErrs = append(allErrs, fmt.Errorf("%s.protocol: must be %s, %s or %s", rulePath, FirewallProtocolTcp, FirewallProtocolUdp, FirewallProtocolIcmp))
+		}
+		if len(lo.FromPtr(rule.Ports)) > 0 && protocol != FirewallProtocolTcp && protocol != FirewallProtocolUdp {
+			allErrs = append(allErrs, fmt.Errorf("%s.ports: require the %s or %s protocol", rulePath, FirewallProtocolTcp, FirewallProtocolUdp))
+		}
+		for j, port := range lo.FromPtr(rule.Ports) {
+			if port < 1 || port > 65535 {
+				allErrs = append(allErrs, fmt.Errorf("%s.ports[%d]: must be between 1 and 65535", rulePath, j))
+			}
+		}
+		for j, source := range lo.FromPtr(rule.Sources) {
+			if _, err := netip.ParsePrefix(source); err == nil {
+				continue
+			}
+			if _, err := netip.ParseAddr(source); err != nil {
+				allErrs = append(allErrs, fmt.Errorf("%s.sources[%d]: %q is not an IP address or CIDR range", rulePath, j, source))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateGarbageCollection checks that the garbage collection keeps at most
+// the one rollback deployment bootc keeps, and that the disk usage is not
+// checked more often than every 5 minutes.
+func validateGarbageCollection(gc *DeviceGarbageCollectionSpec, path string) []error {
+	allErrs := []error{}
+	if keep := gc.KeepOsDeployments; keep != nil && (*keep < 0 || *keep > 1) {
+		allErrs = append(allErrs, fmt.Errorf("%s.keepOsDeployments: must be 0 or 1", path))
+	}
+	if threshold := gc.DiskUsageThreshold; threshold != nil && (*threshold < 1 || *threshold > 100) {
+		allErrs = append(allErrs, fmt.Errorf("%s.diskUsageThreshold: must be between 1 and 100", path))
+	}
+	if gc.Interval != nil {
+		if errs := validation.ValidateDuration(gc.Interval, path+".interval"); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else if interval, _ := time.ParseDuration(*gc.Interval); interval < 5*time.Minute {
+			allErrs = append(allErrs, fmt.Errorf("%s.interval: must be at least 5m", path))
+		}
+	}
+	return allErrs
+}
+
+// validateDrift checks the remediation of the drift policy, and that the
+// device is not checked for drift more often than every minute.
+func validateDrift(drift *DeviceDriftSpec, path string) []error {
+	allErrs := []error{}
+	if remediation := drift.Remediation; remediation != nil && *remediation != DriftRemediate && *remediation != DriftReport {
+		allErrs = append(allErrs, fmt.Errorf("%s.remediation: must be %s or %s", path, DriftRemediate, DriftReport))
+	}
+	if drift.Interval != nil {
+		if errs := validation.ValidateDuration(drift.Interval, path+".interval"); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else if interval, _ := time.ParseDuration(*drift.Interval); interval < time.Minute {
+			allErrs = append(allErrs, fmt.Errorf("%s.interval: must be at least 1m", path))
+		}
+	}
+	return allErrs
+}
+
+// validateApplications checks that each application has its own compose
+// project and file or its own pod, and that blue-green updates are gated by a
+// health check.
+func validateApplications(applications []ApplicationSpec, path string) []error {
+	allErrs := []error{}
+	for i, application := range applications {
+		applicationPath := fmt.Sprintf("%s[%d]", path, i)
+		name := application.Name
+		allErrs = append(allErrs, validation.ValidateGenericName(&name, applicationPath+".name")...)
+		switch {
+		case (application.Path == nil) == (application.Pod == nil):
+			allErrs = append(allErrs, fmt.Errorf("%s: exactly one of path and pod must be set", applicationPath))
+		case application.Path != nil:
+			if !filepath.IsAbs(*application.Path) || filepath.Clean(*application.Path) != *application.Path {
+				allErrs = append(allErrs, fmt.Errorf("%s.path: must be a clean absolute path", applicationPath))
+			}
+		default:
+			allErrs = append(allErrs, validateApplicationPod(application, applicationPath+".pod")...)
+		}
+		allErrs = append(allErrs, validateApplicationVolumes(lo.FromPtr(application.Volumes), applicationPath+".volumes")...)
+		if application.Resources != nil {
+			allErrs = append(allErrs, validation.ValidateQuantity(application.Resources.Cpu, applicationPath+".resources.cpu")...)
+			allErrs = append(allErrs, validation.ValidateQuantity(application.Resources.Memory, applicationPath+".resources.memory")...)
+		}
+		strategy := application.UpdateStrategy
+		if strategy == nil {
+			continue
+		}
+		switch strategy.Type {
+		case ApplicationUpdateStrategyRecreate, ApplicationUpdateStrategyInPlace:
+			if strategy.HealthCheck != nil {
+				allErrs = append(allErrs, fmt.Errorf("%s.updateStrategy.healthCheck: only supported by the %s strategy", applicationPath, ApplicationUpdateStrategyBlueGreen))
+			}
+		case ApplicationUpdateStrategyBlueGreen:
+			if strategy.HealthCheck == nil {
+				allErrs = append(allErrs, fmt.Errorf("%s.updateStrategy.healthCheck: required by the %s strategy", applicationPath, ApplicationUpdateStrategyBlueGreen))
+				continue
+			}
+			run := strategy.HealthCheck.Run
+			allErrs = append(allErrs, validation.ValidateString(&run, applicationPath+".updateStrategy.healthCheck.run", 1, 4096, nil, "")...)
+			if strategy.HealthCheck.Timeout != nil {
+				allErrs = append(allErrs, validation.ValidateDuration(strategy.HealthCheck.Timeout, applicationPath+".updateStrategy.healthCheck.timeout")...)
+			}
+		default:
+			allErrs = append(allErrs, fmt.Errorf("%s.updateStrategy.type: unsupported strategy %q", applicationPath, strategy.Type))
+		}
+	}
+	names := lo.Map(applications, func(application ApplicationSpec, _ int) string { return application.Name })
+	if dups := lo.FindDuplicates(names); len(dups) > 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s: application %s is listed more than once", path, dups[0]))
+	}
+	dependencies := map[string][]string{}
+	for i, application := range applications {
+		for j, dependency := range lo.FromPtr(application.DependsOn) {
+			if dependency == application.Name || !lo.Contains(names, dependency) {
+				allErrs = append(allErrs, fmt.Errorf("%s[%d].dependsOn[%d]: must be another application of the spec", path, i, j))
+			}
+		}
+		dependencies[application.Name] = lo.FromPtr(application.DependsOn)
+	}
+	if cycle := dependencyCycle(names, dependencies); cycle != "" {
+		allErrs = append(allErrs, fmt.Errorf("%s: application %s depends on itself", path, cycle))
+	}
+	paths := lo.FilterMap(applications, func(application ApplicationSpec, _ int) (string, bool) {
+		return lo.FromPtr(application.Path), application.Path != nil
+	})
+	if dups := lo.FindDuplicates(paths); len(dups) > 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s: compose file %s is used by more than one application", path, dups[0]))
+	}
+	return allErrs
+}
+
+var (
+	podPortRegexp = regexp.MustCompile(`^([0-9a-fA-F.:\[\]]+:)?[0-9]{1,5}:[0-9]{1,5}(/(tcp|udp))?$`)
+	envVarRegexp  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+)
+
+// validateApplicationPod checks the containers of a pod, the volumes they mount
+// and the containers they depend on.
+func validateApplicationPod(application ApplicationSpec, path string) []error {
+	allErrs := []error{}
+	pod := application.Pod
+	if len(pod.Containers) == 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s.containers: at least one container is required", path))
+	}
+	if application.UpdateStrategy != nil && application.UpdateStrategy.Type != ApplicationUpdateStrategyRecreate {
+		allErrs = append(allErrs, fmt.Errorf("%s: pods only support the %s update strategy", path, ApplicationUpdateStrategyRecreate))
+	}
+	for i, port := range lo.FromPtr(pod.Ports) {
+		port := port
+		allErrs = append(allErrs, validation.ValidateString(&port, fmt.Sprintf("%s.ports[%d]", path, i), 1, 64, podPortRegexp, "[IP:]HOST:CONTAINER[/PROTOCOL]", "8080:80")...)
+	}
+
+	volumes := lo.Map(lo.FromPtr(application.Volumes), func(volume ApplicationVolume, _ int) string { return volume.Name })
+	containers := lo.Map(pod.Containers, func(container ApplicationContainer, _ int) string { return container.Name })
+	validateContainer := func(container ApplicationContainer, containerPath string, init bool) {
+		name := container.Name
+		allErrs = append(allErrs, validation.ValidateGenericName(&name, containerPath+".name")...)
+		image := container.Image
+		allErrs = append(allErrs, validation.ValidateOciImageReference(&image, containerPath+".image")...)
+		allErrs = append(allErrs, validateArchitectureImages(container.ArchitectureImages, containerPath+".architectureImages")...)
+		for i, envVar := range lo.FromPtr(container.EnvVars) {
+			if !envVarRegexp.MatchString(envVar) {
+				allErrs = append(allErrs, fmt.Errorf("%s.envVars[%d]: must be KEY=VALUE", containerPath, i))
+			}
+		}
+		for i, mount := range lo.FromPtr(container.VolumeMounts) {
+			if !lo.Contains(volumes, mount.Name) {
+				allErrs = append(allErrs, fmt.Errorf("%s.volumeMounts[%d].name: volume %s is not a volume of the application", containerPath, i, mount.Name))
+			}
+			if !filepath.IsAbs(mount.MountPath) || filepath.Clean(mount.MountPath) != mount.MountPath {
+				allErrs = append(allErrs, fmt.Errorf("%s.volumeMounts[%d].mountPath: must be a clean absolute path", containerPath, i))
+			}
+		}
+		for i, dependency := range lo.FromPtr(container.DependsOn) {
+			if init || dependency == container.Name || !lo.Contains(containers, dependency) {
+				allErrs = append(allErrs, fmt.Errorf("%s.dependsOn[%d]: must be another container of the pod", containerPath, i))
+			}
+		}
+	}
+	for i, container := range pod.Containers {
+		validateContainer(container, fmt.Sprintf("%s.containers[%d]", path, i), false)
+	}
+	for i, container := range lo.FromPtr(pod.InitContainers) {
+		validateContainer(container, fmt.Sprintf("%s.initContainers[%d]", path, i), true)
+	}
+
+	names := append(containers, lo.Map(lo.FromPtr(pod.InitContainers), func(container ApplicationContainer, _ int) string { return container.Name })...)
+	if dups := lo.FindDuplicates(names); len(dups) > 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s: container %s is listed more than once", path, dups[0]))
+	}
+	dependencies := map[string][]string{}
+	for _, container := range pod.Containers {
+		dependencies[container.Name] = lo.FromPtr(container.DependsOn)
+	}
+	if cycle := dependencyCycle(containers, dependencies); cycle != "" {
+		allErrs = append(allErrs, fmt.Errorf("%s.containers: container %s depends on itself", path, cycle))
+	}
+	return allErrs
+}
+
+// dependencyCycle returns one of the names, of containers or applications,
+// which transitively depends on itself, or an empty string if the
+// dependencies form no cycle.
+func dependencyCycle(names []string, dependencies map[string][]string) string {
+	// 1 while visiting the dependencies of a container, 2 once they are visited
+	state := map[string]int{}
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case 1:
+			return true
+		case 2:
+			return false
+		}
+		state[name] = 1
+		for _, dependency := range dependencies[name] {
+			if visit(dependency) {
+				return true
+			}
+		}
+		state[name] = 2
+		return false
+	}
+	for _, name := range names {
+		if visit(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+var tmpfsSizeRegexp = regexp.MustCompile(`^[0-9]+[kmg]?$`)
+
+func validateApplicationVolumes(volumes []ApplicationVolume, path string) []error {
+	allErrs := []error{}
+	for i, volume := range volumes {
+		volumePath := fmt.Sprintf("%s[%d]", path, i)
+		name := volume.Name
+		allErrs = append(allErrs, validation.ValidateGenericName(&name, volumePath+".name")...)
+		if volume.HostPath != nil && volume.Tmpfs != nil {
+			allErrs = append(allErrs, fmt.Errorf("%s: only one of hostPath and tmpfs may be set", volumePath))
+		}
+		if volume.HostPath != nil {
+			hostPath := volume.HostPath.Path
+			if !filepath.IsAbs(hostPath) || filepath.Clean(hostPath) != hostPath || hostPath == "/" {
+				allErrs = append(allErrs, fmt.Errorf("%s.hostPath.path: must be a clean absolute path below the root directory", volumePath))
+			}
+		}
+		if volume.Tmpfs != nil {
+			allErrs = append(allErrs, validation.ValidateString(volume.Tmpfs.Size, volumePath+".tmpfs.size", 1, 20, tmpfsSizeRegexp, "a number of bytes with an optional k, m or g suffix", "64m")...)
+		}
+	}
+	names := lo.Map(volumes, func(volume ApplicationVolume, _ int) string { return volume.Name })
+	if dups := lo.FindDuplicates(names); len(dups) > 0 {
+		allErrs = append(allErrs, fmt.Errorf("%s: volume %s is listed more than once", path, dups[0]))
+	}
+	return allErrs
+}
+
+func validateFleetReports(reports *FleetReportsSpec, path string) []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateCronSchedule(&reports.Schedule, path+".schedule")...)
+	for i, webhook := range lo.FromPtr(reports.Webhooks) {
+		webhook := webhook
+		allErrs = append(allErrs, validation.ValidateGenericName(&webhook, fmt.Sprintf("%s.webhooks[%d]", path, i))...)
+	}
+	if len(lo.FromPtr(reports.Webhooks)) == 0 && reports.ObjectStorage == nil {
+		allErrs = append(allErrs, fmt.Errorf("%s: at least one of webhooks or objectStorage must be set", path))
+	}
+	return allErrs
+}
+
+func validateFleetPromotion(promotion *FleetPromotionSpec, fleet string, path string) []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateString(&promotion.From, path+".from", 1, 253, nil, "")...)
+	if promotion.From == fleet {
+		allErrs = append(allErrs, fmt.Errorf("%s.from: a fleet cannot be promoted from itself", path))
+	}
+	allErrs = append(allErrs, validation.ValidateDuration(&promotion.SoakTime, path+".soakTime")...)
+	if promotion.Approval != nil && *promotion.Approval != FleetPromotionApprovalAutomatic && *promotion.Approval != FleetPromotionApprovalManual {
+		allErrs = append(allErrs, fmt.Errorf("%s.approval: must be %s or %s", path, FleetPromotionApprovalAutomatic, FleetPromotionApprovalManual))
+	}
+	return allErrs
 }
 
 func validateHttpConfig(config *HttpConfig) []error {
@@ -212,6 +930,17 @@ func validateHttpConfig(config *HttpConfig) []error {
 		if config.Token != nil {
 			errs = append(errs, validation.ValidateBearerToken(config.Token, "spec.httpConfig.token")...)
 		}
+
+		if config.Headers != nil {
+			for name := range *config.Headers {
+				if !httpHeaderNameRegexp.MatchString(name) {
+					errs = append(errs, fmt.Errorf("spec.httpConfig.headers: invalid header name %q", name))
+				}
+			}
+			if _, err := config.ExpandHeaders(); err != nil {
+				errs = append(errs, fmt.Errorf("spec.httpConfig.headers: %w", err))
+			}
+		}
 	}
 	return errs
 }
@@ -228,9 +957,98 @@ func validateSshConfig(config *SshConfig) []error {
 			errs = append(errs, validation.ValidateString(config.PrivateKeyPassphrase, "spec.sshConfig.privateKeyPassphrase", 1, 256, nil, "")...)
 		}
 		if config.SshPrivateKey != nil {
-			errs = append(errs, validation.ValidateBase64Field(*config.SshPrivateKey, "spec.sshConfig.SshPrivateKey", maxBase64CertificateLength)...)
+			keyErrs := validation.ValidateBase64Field(*config.SshPrivateKey, "spec.sshConfig.SshPrivateKey", maxBase64CertificateLength)
+			errs = append(errs, keyErrs...)
+			if len(keyErrs) == 0 {
+				errs = append(errs, validateSshPrivateKey(*config.SshPrivateKey, config.PrivateKeyPassphrase)...)
+			}
+		}
+
+		if config.KnownHosts != nil {
+			if config.SkipServerVerification != nil && *config.SkipServerVerification {
+				errs = append(errs, fmt.Errorf("spec.sshConfig.knownHosts cannot be specified together with spec.sshConfig.skipServerVerification"))
+			}
+			errs = append(errs, validateKnownHosts(*config.KnownHosts)...)
 		}
 	}
 
 	return errs
 }
+
+// validateSshPrivateKey checks that the private key parses, decrypting it with
+// the passphrase if one is specified.
+func validateSshPrivateKey(base64Key string, passphrase *string) []error {
+	key, err := base64.StdEncoding.DecodeString(base64Key)
+	if err != nil {
+		return []error{fmt.Errorf("spec.sshConfig.sshPrivateKey: %w", err)}
+	}
+	if passphrase != nil {
+		_, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(*passphrase))
+	} else {
+		_, err = ssh.ParsePrivateKey(key)
+	}
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing):
+		return []error{fmt.Errorf("spec.sshConfig.sshPrivateKey is protected by a passphrase, which must be specified in spec.sshConfig.privateKeyPassphrase")}
+	case errors.Is(err, x509.IncorrectPasswordError):
+		return []error{fmt.Errorf("spec.sshConfig.privateKeyPassphrase does not decrypt spec.sshConfig.sshPrivateKey")}
+	case err != nil:
+		return []error{fmt.Errorf("spec.sshConfig.sshPrivateKey is not a valid SSH private key: %w", err)}
+	}
+	return nil
+}
+
+// validateKnownHosts checks that every line of the known_hosts file which is
+// neither empty nor a comment is a host key entry.
+func validateKnownHosts(knownHosts string) []error {
+	rest := []byte(knownHosts)
+	entries := 0
+	for len(rest) > 0 {
+		var err error
+		_, _, _, _, rest, err = ssh.ParseKnownHosts(rest)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return []error{fmt.Errorf("spec.sshConfig.knownHosts: %w", err)}
+		}
+		entries++
+	}
+	if entries == 0 {
+		return []error{fmt.Errorf("spec.sshConfig.knownHosts has no host keys")}
+	}
+	return nil
+}
+
+func (r Operation) Validate() []error {
+	allErrs := []error{}
+	allErrs = append(allErrs, validation.ValidateResourceName(r.Metadata.Name)...)
+	allErrs = append(allErrs, validation.ValidateLabels(r.Metadata.Labels)...)
+	allErrs = append(allErrs, validation.ValidateAnnotations(r.Metadata.Annotations)...)
+
+	// an empty selector would apply the operation to every device
+	if strings.TrimSpace(r.Spec.LabelSelector) == "" {
+		allErrs = append(allErrs, fmt.Errorf("spec.labelSelector must select devices"))
+	} else if _, err := labels.ConvertSelectorToLabelsMap(r.Spec.LabelSelector); err != nil {
+		allErrs = append(allErrs, fmt.Errorf("spec.labelSelector is not a valid selector: %w", err))
+	}
+
+	switch r.Spec.Type {
+	case OperationTypeBulkLabel:
+		if len(lo.FromPtr(r.Spec.Labels)) == 0 && len(lo.FromPtr(r.Spec.RemoveLabels)) == 0 {
+			allErrs = append(allErrs, fmt.Errorf("a BulkLabel operation must set spec.labels or spec.removeLabels"))
+		}
+		allErrs = append(allErrs, validation.ValidateLabelsWithPath(r.Spec.Labels, "spec.labels")...)
+		for i, key := range lo.FromPtr(r.Spec.RemoveLabels) {
+			allErrs = append(allErrs, validation.ValidateLabelKey(&key, fmt.Sprintf("spec.removeLabels[%d]", i))...)
+		}
+	case OperationTypeDeviceDecommission:
+		if r.Spec.Labels != nil || r.Spec.RemoveLabels != nil {
+			allErrs = append(allErrs, fmt.Errorf("spec.labels and spec.removeLabels are only allowed for BulkLabel operations"))
+		}
+	default:
+		allErrs = append(allErrs, fmt.Errorf("spec.type must be %s or %s", OperationTypeBulkLabel, OperationTypeDeviceDecommission))
+	}
+	return allErrs
+}
@@ -0,0 +1,125 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+const (
+	FleetAPI = "v1beta1"
+
+	// fleetStorageAPI is the API version the service stores fleets in
+	fleetStorageAPI = "v1alpha1"
+
+	// FleetAnnotationConversion holds the fields of a fleet which do not exist in
+	// v1alpha1, so that a fleet survives the round trip through the storage version.
+	FleetAnnotationConversion = "conversion/v1beta1"
+)
+
+// fleetConversionFields are the fields of a v1beta1 fleet which are kept in the
+// conversion annotation of its v1alpha1 representation.
+type fleetConversionFields struct {
+	RolloutPolicy *FleetRolloutPolicy `json:"rolloutPolicy,omitempty"`
+}
+
+// ConvertFleetToV1alpha1 converts a fleet to the v1alpha1 API, which is the
+// version the service stores.
+func ConvertFleetToV1alpha1(in *Fleet) (*v1alpha1.Fleet, error) {
+	out := &v1alpha1.Fleet{
+		ApiVersion: fleetStorageAPI,
+		Kind:       in.Kind,
+		Metadata:   in.Metadata,
+		Spec: v1alpha1.FleetSpec{
+			Selector:  in.Spec.Selector,
+			Template:  in.Spec.Template,
+			Reports:   in.Spec.Reports,
+			Promotion: in.Spec.Promotion,
+		},
+		Status: in.Status,
+	}
+
+	annotations := copyAnnotations(in.Metadata.Annotations)
+	delete(annotations, FleetAnnotationConversion)
+	fields := fleetConversionFields{RolloutPolicy: in.Spec.RolloutPolicy}
+	if fields != (fleetConversionFields{}) {
+		value, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed marshalling conversion fields: %w", err)
+		}
+		annotations[FleetAnnotationConversion] = string(value)
+	}
+	out.Metadata.Annotations = annotationsOrNil(annotations, in.Metadata.Annotations)
+	return out, nil
+}
+
+// ConvertFleetFromV1alpha1 converts a fleet of the v1alpha1 API, restoring the
+// fields kept in its conversion annotation.
+func ConvertFleetFromV1alpha1(in *v1alpha1.Fleet) (*Fleet, error) {
+	out := &Fleet{
+		ApiVersion: FleetAPI,
+		Kind:       in.Kind,
+		Metadata:   in.Metadata,
+		Spec: FleetSpec{
+			Selector:  in.Spec.Selector,
+			Template:  in.Spec.Template,
+			Reports:   in.Spec.Reports,
+			Promotion: in.Spec.Promotion,
+		},
+		Status: in.Status,
+	}
+
+	annotations := copyAnnotations(in.Metadata.Annotations)
+	if value, ok := annotations[FleetAnnotationConversion]; ok {
+		var fields fleetConversionFields
+		if err := json.Unmarshal([]byte(value), &fields); err != nil {
+			return nil, fmt.Errorf("failed parsing annotation %s: %w", FleetAnnotationConversion, err)
+		}
+		out.Spec.RolloutPolicy = fields.RolloutPolicy
+		delete(annotations, FleetAnnotationConversion)
+		// the annotations were only set to hold the conversion fields
+		if len(annotations) == 0 {
+			out.Metadata.Annotations = nil
+			return out, nil
+		}
+	}
+	out.Metadata.Annotations = annotationsOrNil(annotations, in.Metadata.Annotations)
+	return out, nil
+}
+
+// ConvertFleetListFromV1alpha1 converts a list of fleets of the v1alpha1 API.
+func ConvertFleetListFromV1alpha1(in *v1alpha1.FleetList) (*FleetList, error) {
+	out := &FleetList{
+		ApiVersion: FleetAPI,
+		Kind:       in.Kind,
+		Metadata:   in.Metadata,
+		Items:      make([]Fleet, 0, len(in.Items)),
+	}
+	for i := range in.Items {
+		fleet, err := ConvertFleetFromV1alpha1(&in.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		out.Items = append(out.Items, *fleet)
+	}
+	return out, nil
+}
+
+func copyAnnotations(annotations *map[string]string) map[string]string {
+	result := map[string]string{}
+	if annotations != nil {
+		for k, v := range *annotations {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// annotationsOrNil keeps unset annotations unset when the conversion leaves none.
+func annotationsOrNil(annotations map[string]string, original *map[string]string) *map[string]string {
+	if len(annotations) == 0 && original == nil {
+		return nil
+	}
+	return &annotations
+}
@@ -0,0 +1,157 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFleet(rolloutPolicy *FleetRolloutPolicy, annotations *map[string]string) *Fleet {
+	fleet := &Fleet{
+		ApiVersion: FleetAPI,
+		Kind:       "Fleet",
+		Metadata: v1alpha1.ObjectMeta{
+			Name:        lo.ToPtr("fleet"),
+			Annotations: annotations,
+		},
+	}
+	fleet.Spec.RolloutPolicy = rolloutPolicy
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "quay.io/example/os:v1"}
+	return fleet
+}
+
+func TestConvertFleetRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		rolloutPolicy *FleetRolloutPolicy
+		annotations   *map[string]string
+	}{
+		{
+			name: "no v1beta1 fields",
+		},
+		{
+			name:          "rollout policy",
+			rolloutPolicy: &FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](5), UpdateTimeout: lo.ToPtr("30m"), PauseOnFailure: lo.ToPtr(true)},
+		},
+		{
+			name:          "rollout policy with a concurrency limit",
+			rolloutPolicy: &FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](5), ConcurrencyLimit: &FleetRolloutConcurrencyLimit{Label: "site", MaxUpdating: 1}},
+		},
+		{
+			name:          "rollout policy with dependencies",
+			rolloutPolicy: &FleetRolloutPolicy{DependsOn: &[]string{"gateways"}},
+		},
+		{
+			name:          "rollout policy and annotations",
+			rolloutPolicy: &FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](1)},
+			annotations:   &map[string]string{"fleet-controller/templateVersion": "tv-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			fleet := newTestFleet(tt.rolloutPolicy, tt.annotations)
+
+			stored, err := ConvertFleetToV1alpha1(fleet)
+			require.NoError(err)
+			require.Equal("v1alpha1", stored.ApiVersion)
+			require.Equal(fleet.Spec.Template, stored.Spec.Template)
+			if tt.rolloutPolicy == nil {
+				require.Equal(tt.annotations, stored.Metadata.Annotations)
+			} else {
+				require.Contains(*stored.Metadata.Annotations, FleetAnnotationConversion)
+			}
+
+			converted, err := ConvertFleetFromV1alpha1(stored)
+			require.NoError(err)
+			require.Equal(fleet, converted)
+		})
+	}
+}
+
+func TestConvertFleetToV1alpha1KeepsInput(t *testing.T) {
+	require := require.New(t)
+
+	annotations := map[string]string{"key": "value"}
+	fleet := newTestFleet(&FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](2)}, &annotations)
+
+	_, err := ConvertFleetToV1alpha1(fleet)
+	require.NoError(err)
+	require.Equal(map[string]string{"key": "value"}, annotations)
+}
+
+func TestConvertFleetToV1alpha1DropsStaleAnnotation(t *testing.T) {
+	require := require.New(t)
+
+	annotations := map[string]string{FleetAnnotationConversion: `{"rolloutPolicy":{"batchSize":3}}`}
+	fleet := newTestFleet(nil, &annotations)
+
+	stored, err := ConvertFleetToV1alpha1(fleet)
+	require.NoError(err)
+	require.Empty(*stored.Metadata.Annotations)
+}
+
+func TestConvertFleetFromV1alpha1InvalidAnnotation(t *testing.T) {
+	require := require.New(t)
+
+	fleet := &v1alpha1.Fleet{
+		ApiVersion: "v1alpha1",
+		Kind:       "Fleet",
+		Metadata: v1alpha1.ObjectMeta{
+			Name:        lo.ToPtr("fleet"),
+			Annotations: &map[string]string{FleetAnnotationConversion: "not json"},
+		},
+	}
+	_, err := ConvertFleetFromV1alpha1(fleet)
+	require.Error(err)
+
+	_, err = ConvertFleetListFromV1alpha1(&v1alpha1.FleetList{Items: []v1alpha1.Fleet{*fleet}})
+	require.Error(err)
+}
+
+func TestFleetValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := newTestFleet(&FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](10), UpdateTimeout: lo.ToPtr("1h")}, nil)
+	require.Empty(valid.Validate())
+
+	invalidBatchSize := newTestFleet(&FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](0)}, nil)
+	require.NotEmpty(invalidBatchSize.Validate())
+
+	invalidTimeout := newTestFleet(&FleetRolloutPolicy{UpdateTimeout: lo.ToPtr("1d")}, nil)
+	require.NotEmpty(invalidTimeout.Validate())
+
+	limited := newTestFleet(&FleetRolloutPolicy{ConcurrencyLimit: &FleetRolloutConcurrencyLimit{Label: "site", MaxUpdating: 1}}, nil)
+	require.Empty(limited.Validate())
+
+	invalidLimit := newTestFleet(&FleetRolloutPolicy{ConcurrencyLimit: &FleetRolloutConcurrencyLimit{Label: "site", MaxUpdating: 0}}, nil)
+	require.NotEmpty(invalidLimit.Validate())
+
+	invalidLabel := newTestFleet(&FleetRolloutPolicy{ConcurrencyLimit: &FleetRolloutConcurrencyLimit{Label: "not a label", MaxUpdating: 1}}, nil)
+	require.NotEmpty(invalidLabel.Validate())
+
+	dependent := newTestFleet(&FleetRolloutPolicy{DependsOn: &[]string{"gateways"}}, nil)
+	require.Empty(dependent.Validate())
+
+	selfDependent := newTestFleet(&FleetRolloutPolicy{DependsOn: &[]string{*dependent.Metadata.Name}}, nil)
+	require.NotEmpty(selfDependent.Validate())
+}
+
+func TestConvertFleetKeepsReportsAndPromotion(t *testing.T) {
+	require := require.New(t)
+	fleet := newTestFleet(nil, nil)
+	fleet.Spec.Reports = &v1alpha1.FleetReportsSpec{Schedule: "@daily", Webhooks: &[]string{"ops"}}
+	fleet.Spec.Promotion = &v1alpha1.FleetPromotionSpec{From: "staging", SoakTime: "24h"}
+
+	stored, err := ConvertFleetToV1alpha1(fleet)
+	require.NoError(err)
+	require.Equal(fleet.Spec.Reports, stored.Spec.Reports)
+	require.Equal(fleet.Spec.Promotion, stored.Spec.Promotion)
+	require.Nil(stored.Metadata.Annotations)
+
+	converted, err := ConvertFleetFromV1alpha1(stored)
+	require.NoError(err)
+	require.Equal(fleet, converted)
+}
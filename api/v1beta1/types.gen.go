@@ -0,0 +1,88 @@
+// Package v1beta1 provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package v1beta1
+
+import (
+	externalRef0 "github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+// Fleet Fleet represents a set of devices.
+type Fleet struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+	Metadata externalRef0.ObjectMeta `json:"metadata"`
+
+	// Spec FleetSpec is a description of a fleet's target state.
+	Spec FleetSpec `json:"spec"`
+
+	// Status FleetStatus represents information about the status of a fleet. Status may trail the actual state of a fleet, especially if devices of a fleet have not contacted the management service in a while.
+	Status *externalRef0.FleetStatus `json:"status,omitempty"`
+}
+
+// FleetList FleetList is a list of Fleets.
+type FleetList struct {
+	// ApiVersion APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+	ApiVersion string `json:"apiVersion"`
+
+	// Items List of Fleets.
+	Items []Fleet `json:"items"`
+
+	// Kind Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+	Kind string `json:"kind"`
+
+	// Metadata ListMeta describes metadata that synthetic resources must have, including lists and various status objects. A resource may have only one of {ObjectMeta, ListMeta}.
+	Metadata externalRef0.ListMeta `json:"metadata"`
+}
+
+// FleetRolloutConcurrencyLimit FleetRolloutConcurrencyLimit limits the devices of a group, such as co-located devices backing each other up, which update at the same time. Devices without the label are not limited.
+type FleetRolloutConcurrencyLimit struct {
+	// Label The key of the label whose value groups the devices, for example "site" for the devices of the same store.
+	Label string `json:"label"`
+
+	// MaxUpdating The maximum number of devices with the same value of the label that update at the same time, in any fleet.
+	MaxUpdating int32 `json:"maxUpdating"`
+}
+
+// FleetRolloutPolicy FleetRolloutPolicy controls how a new template is rolled out to the devices of a fleet.
+type FleetRolloutPolicy struct {
+	// BatchSize The maximum number of devices of the fleet that are updated to a new template at the same time. Defaults to all devices.
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// ConcurrencyLimit FleetRolloutConcurrencyLimit limits the devices of a group, such as co-located devices backing each other up, which update at the same time. Devices without the label are not limited.
+	ConcurrencyLimit *FleetRolloutConcurrencyLimit `json:"concurrencyLimit,omitempty"`
+
+	// DependsOn The names of the fleets whose rollouts of their newest template versions must complete without failed devices before a new template of this fleet starts rolling out, for example the fleet of the gateways the devices of this fleet are connected through.
+	DependsOn *[]string `json:"dependsOn,omitempty"`
+
+	// PauseOnFailure Whether the rollout stops updating further devices once an update failed.
+	PauseOnFailure *bool `json:"pauseOnFailure,omitempty"`
+
+	// UpdateTimeout The time a device may take to update before the update counts as failed. Format: number followed by 's' for seconds, 'm' for minutes, 'h' for hours.
+	UpdateTimeout *string `json:"updateTimeout,omitempty"`
+}
+
+// FleetSpec FleetSpec is a description of a fleet's target state.
+type FleetSpec struct {
+	// Promotion FleetPromotionSpec promotes the template of another fleet to this fleet once a template version was healthy in that fleet for the soak time. A template version is healthy while its rollout completed without failed devices and no device of the fleet is in Error or Degraded status.
+	Promotion *externalRef0.FleetPromotionSpec `json:"promotion,omitempty"`
+
+	// Reports FleetReportsSpec schedules health reports of the fleet. At least one of webhooks or objectStorage must be set.
+	Reports *externalRef0.FleetReportsSpec `json:"reports,omitempty"`
+
+	// RolloutPolicy FleetRolloutPolicy controls how a new template is rolled out to the devices of a fleet.
+	RolloutPolicy *FleetRolloutPolicy `json:"rolloutPolicy,omitempty"`
+
+	// Selector A map of key,value pairs that are ANDed. Empty/null label selectors match nothing.
+	Selector *externalRef0.LabelSelector `json:"selector,omitempty"`
+	Template struct {
+		// Metadata ObjectMeta is metadata that all persisted resources must have, which includes all objects users must create.
+		Metadata *externalRef0.ObjectMeta `json:"metadata,omitempty"`
+		Spec     externalRef0.DeviceSpec  `json:"spec"`
+	} `json:"template"`
+}
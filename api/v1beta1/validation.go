@@ -0,0 +1,35 @@
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/samber/lo"
+)
+
+func (r Fleet) Validate() []error {
+	allErrs := []error{}
+	fleet, err := ConvertFleetToV1alpha1(&r)
+	if err != nil {
+		return append(allErrs, err)
+	}
+	allErrs = append(allErrs, fleet.Validate()...)
+
+	if r.Spec.RolloutPolicy != nil {
+		allErrs = append(allErrs, validation.ValidateMinimum(r.Spec.RolloutPolicy.BatchSize, "spec.rolloutPolicy.batchSize", 1)...)
+		allErrs = append(allErrs, validation.ValidateDuration(r.Spec.RolloutPolicy.UpdateTimeout, "spec.rolloutPolicy.updateTimeout")...)
+		if limit := r.Spec.RolloutPolicy.ConcurrencyLimit; limit != nil {
+			allErrs = append(allErrs, validation.ValidateLabelKey(&limit.Label, "spec.rolloutPolicy.concurrencyLimit.label")...)
+			allErrs = append(allErrs, validation.ValidateMinimum(&limit.MaxUpdating, "spec.rolloutPolicy.concurrencyLimit.maxUpdating", 1)...)
+		}
+		for i, name := range lo.FromPtr(r.Spec.RolloutPolicy.DependsOn) {
+			path := fmt.Sprintf("spec.rolloutPolicy.dependsOn[%d]", i)
+			allErrs = append(allErrs, validation.ValidateString(&name, path, 1, 253, nil, "")...)
+			if name == lo.FromPtr(r.Metadata.Name) {
+				allErrs = append(allErrs, errors.New(path+": a fleet cannot depend on itself"))
+			}
+		}
+	}
+	return allErrs
+}
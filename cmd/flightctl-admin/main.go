@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/backup"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+const passphraseEnv = "FLIGHTCTL_BACKUP_PASSPHRASE"
+
+func main() {
+	command := NewFlightCtlAdminCommand()
+	if err := command.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func NewFlightCtlAdminCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flightctl-admin [flags] [options]",
+		Short: "flightctl-admin administrates the Flight Control service.",
+		Run: func(cmd *cobra.Command, args []string) {
+			_ = cmd.Help()
+			os.Exit(1)
+		},
+	}
+	cmd.AddCommand(newCmdBackup())
+	cmd.AddCommand(newCmdRestore())
+	return cmd
+}
+
+type backupOptions struct {
+	file           string
+	passphraseFile string
+	force          bool
+}
+
+func newCmdBackup() *cobra.Command {
+	o := &backupOptions{}
+	cmd := &cobra.Command{
+		Use:   "backup --output FILE",
+		Short: "Back up the database, the CA and the artifacts of the service into an encrypted file.",
+		Long: "Back up the database, the certificate directory holding the keys of the CA and the artifact store of the service " +
+			"into a file encrypted with a passphrase. The passphrase is read from --passphrase-file, or from $" + passphraseEnv + ". " +
+			"The database is dumped from a single snapshot, so the service does not need to be stopped.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runBackup(cmd.Context())
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&o.file, "output", "o", "", "the file the backup is written to")
+	cmd.Flags().StringVar(&o.passphraseFile, "passphrase-file", "", "the file holding the passphrase the backup is encrypted with")
+	_ = cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func newCmdRestore() *cobra.Command {
+	o := &backupOptions{}
+	cmd := &cobra.Command{
+		Use:   "restore --input FILE",
+		Short: "Restore the database, the CA and the artifacts of the service from an encrypted backup.",
+		Long: "Restore a backup created by flightctl-admin backup with the same version of the service. " +
+			"Stop the service before restoring. The database is migrated first, and the restore fails if it or the " +
+			"certificate directory already holds data, unless --force is set.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runRestore(cmd.Context())
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&o.file, "input", "i", "", "the file the backup is read from")
+	cmd.Flags().StringVar(&o.passphraseFile, "passphrase-file", "", "the file holding the passphrase the backup is encrypted with")
+	cmd.Flags().BoolVar(&o.force, "force", false, "replace the data of the database and the certificate directory")
+	_ = cmd.MarkFlagRequired("input")
+	return cmd
+}
+
+func (o *backupOptions) passphrase() ([]byte, error) {
+	if o.passphraseFile != "" {
+		content, err := os.ReadFile(o.passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading passphrase: %w", err)
+		}
+		return []byte(strings.TrimRight(string(content), "\r\n")), nil
+	}
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	return nil, fmt.Errorf("either --passphrase-file or $%s must be set", passphraseEnv)
+}
+
+func (o *backupOptions) newBackup() (*backup.Backup, store.Store, error) {
+	log := log.InitLogs()
+	cfg, err := config.NewFromFile(config.ConfigFile())
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading configuration: %w", err)
+	}
+	artifactStore, err := artifacts.New(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initializing artifact store: %w", err)
+	}
+	db, err := store.InitDB(cfg, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initializing data store: %w", err)
+	}
+	dataStore := store.NewStore(db, log.WithField("pkg", "store"))
+	return backup.New(db, config.CertificateDir(), artifactStore, log), dataStore, nil
+}
+
+func (o *backupOptions) runBackup(ctx context.Context) error {
+	passphrase, err := o.passphrase()
+	if err != nil {
+		return err
+	}
+	b, dataStore, err := o.newBackup()
+	if err != nil {
+		return err
+	}
+	defer dataStore.Close()
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	file, err := os.OpenFile(o.file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	manifest, err := b.Create(ctx, file, passphrase)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// an incomplete backup must not be mistaken for a valid one
+		_ = os.Remove(o.file)
+		return err
+	}
+	fmt.Printf("Backed up %d tables, %d certificate files and %d artifacts to %s\n", len(manifest.Tables), len(manifest.Certificates), manifest.Artifacts, o.file)
+	return nil
+}
+
+func (o *backupOptions) runRestore(ctx context.Context) error {
+	passphrase, err := o.passphrase()
+	if err != nil {
+		return err
+	}
+	b, dataStore, err := o.newBackup()
+	if err != nil {
+		return err
+	}
+	defer dataStore.Close()
+	if err := dataStore.InitialMigration(); err != nil {
+		return fmt.Errorf("migrating the database: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	file, err := os.Open(o.file)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	manifest, err := b.Restore(ctx, file, passphrase, backup.RestoreOptions{Force: o.force})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Restored %d tables, %d certificate files and %d artifacts of the backup of %s created at %s\n",
+		len(manifest.Tables), len(manifest.Certificates), manifest.Artifacts, manifest.ServiceVersion, manifest.CreatedAt)
+	return nil
+}
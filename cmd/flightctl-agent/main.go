@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/version"
 )
@@ -19,6 +20,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "check-update" {
+		os.Exit(checkUpdate())
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "preflight" {
+		os.Exit(preflight(os.Args[2:]))
+	}
+
 	command := NewAgentCommand()
 	if err := command.Execute(); err != nil {
 		os.Exit(1)
@@ -38,13 +47,16 @@ func NewAgentCommand() *agentCmd {
 	}
 
 	flag.StringVar(&a.configFile, "config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	checkOnly := flag.Bool("check", false, "Only check the rendered specs, reporting the changes applying them would make without making them.")
 
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
 		fmt.Println("flags:")
 		flag.PrintDefaults()
 		fmt.Println("commands:")
-		fmt.Println("  version    Display version information")
+		fmt.Println("  version       Display version information")
+		fmt.Println("  check-update  Roll back an agent update that fails to start, run by systemd")
+		fmt.Println("  preflight     Check that the device can enroll, without enrolling it")
 	}
 
 	flag.Parse()
@@ -52,6 +64,9 @@ func NewAgentCommand() *agentCmd {
 	if err := a.config.ParseConfigFile(a.configFile); err != nil {
 		a.log.Fatalf("Error parsing config: %v", err)
 	}
+	if *checkOnly {
+		a.config.CheckOnly = true
+	}
 	if err := a.config.Complete(); err != nil {
 		a.log.Fatalf("Error completing config: %v", err)
 	}
@@ -71,3 +86,51 @@ func (a *agentCmd) Execute() error {
 	}
 	return nil
 }
+
+// checkUpdate is run by systemd before starting the agent. Failing to check
+// does not fail the start, only a rollback does, so that systemd starts the
+// restored agent.
+func checkUpdate() int {
+	log := log.NewPrefixLogger("")
+	config := agent.NewDefault()
+	if err := config.ParseConfigFile(agent.DefaultConfigFile); err != nil {
+		log.Errorf("Error parsing config: %v", err)
+		return 0
+	}
+	rolledBack, err := agent.CheckUpdate(context.Background(), log, config)
+	if err != nil {
+		log.Errorf("Error checking agent update: %v", err)
+		return 0
+	}
+	if rolledBack {
+		return 1
+	}
+	return 0
+}
+
+// preflight prints the results of the preflight checks of the device and
+// fails if any check fails, so that imaging pipelines can stop shipping it.
+func preflight(args []string) int {
+	flags := flag.NewFlagSet("preflight", flag.ExitOnError)
+	configFile := flags.String("config", agent.DefaultConfigFile, "Path to the agent's configuration file.")
+	_ = flags.Parse(args)
+
+	failed := 0
+	checks := agent.Preflight(context.Background(), *configFile, &executer.CommonExecuter{})
+	for _, check := range checks {
+		if check.Message == "" {
+			fmt.Printf("%s  %s\n", check.Status, check.Name)
+		} else {
+			fmt.Printf("%s  %s: %s\n", check.Status, check.Name, check.Message)
+		}
+		if check.Status == agent.PreflightCheckFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("\n%d of %d checks failed\n", failed, len(checks))
+		return 1
+	}
+	fmt.Printf("\nall %d checks passed\n", len(checks))
+	return 0
+}
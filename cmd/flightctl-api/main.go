@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sync"
 	"syscall"
 
 	apiserver "github.com/flightctl/flightctl/internal/api_server"
@@ -13,6 +14,7 @@ import (
 	"github.com/flightctl/flightctl/internal/client"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/externalca"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/queues"
@@ -50,12 +52,36 @@ func main() {
 		log.Fatalf("ensuring CA cert: %v", err)
 	}
 
+	clientSigner, externalCACerts, err := externalca.New(cfg)
+	if err != nil {
+		log.Fatalf("initializing external CA: %v", err)
+	}
+	if clientSigner != nil {
+		log.Println("Signing client certificates with the external CA")
+		ca.SetClientSigner(clientSigner, externalCACerts)
+	}
+
+	// devices imported from other instances keep the certificates signed by
+	// the CAs of those instances until they renew them
+	clientCACerts := externalCACerts
+	for _, file := range cfg.Service.TrustedClientCAFiles {
+		bundle, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("reading trusted client CA bundle: %v", err)
+		}
+		certs, err := crypto.ParseCertificates(bundle)
+		if err != nil {
+			log.Fatalf("parsing trusted client CA bundle %s: %v", file, err)
+		}
+		clientCACerts = append(clientCACerts, certs...)
+	}
+
 	// default certificate hostnames to localhost if nothing else is configured
 	if len(cfg.Service.AltNames) == 0 {
 		cfg.Service.AltNames = []string{"localhost"}
 	}
 
-	serverCerts, _, err := ca.EnsureServerCertificate(certFile(serverCertName), keyFile(serverCertName), cfg.Service.AltNames, serverCertValidityDays)
+	_, _, err = ca.EnsureServerCertificate(certFile(serverCertName), keyFile(serverCertName), cfg.Service.AltNames, serverCertValidityDays)
 	if err != nil {
 		log.Fatalf("ensuring server cert: %v", err)
 	}
@@ -77,56 +103,96 @@ func main() {
 		log.Fatalf("initializing data store: %v", err)
 	}
 
-	store := store.NewStore(db, log.WithField("pkg", "store"))
+	readReplicas, err := store.InitReadReplicas(cfg, log)
+	if err != nil {
+		log.Fatalf("initializing read replicas: %v", err)
+	}
+
+	store := store.NewStore(db, log.WithField("pkg", "store"),
+		store.WithReadReplicaDBs(readReplicas...),
+		store.WithRenderedSpecCache(cfg.Service.RenderedSpecCacheEntries()))
 	defer store.Close()
 
 	if err := store.InitialMigration(); err != nil {
 		log.Fatalf("running initial migration: %v", err)
 	}
 
-	tlsConfig, agentTlsConfig, grpcTlsConfig, err := crypto.TLSConfigForServer(ca.Config, serverCerts)
+	migrated, err := store.Fleet().MigrateStorageVersion(context.Background())
 	if err != nil {
-		log.Fatalf("failed creating TLS config: %v", err)
+		log.Fatalf("migrating fleets to the storage version: %v", err)
+	}
+	if migrated > 0 {
+		log.Printf("Migrated %d fleets to the storage version", migrated)
+	}
+
+	// the serving certificate is reloaded once it is rotated, without
+	// interrupting the agents connected with the previous one
+	certReloader, err := crypto.NewCertificateReloader(certFile(serverCertName), keyFile(serverCertName), log.WithField("pkg", "cert-reloader"))
+	if err != nil {
+		log.Fatalf("loading server cert: %v", err)
+	}
+	tlsConfig, agentTlsConfig, grpcTlsConfig := crypto.TLSConfigForReloadingServer(ca.Config, certReloader, clientCACerts...)
+	if acmeCfg := cfg.Service.Acme; acmeCfg != nil {
+		cacheDir := acmeCfg.CacheDir
+		if cacheDir == "" {
+			cacheDir = filepath.Join(config.CertificateDir(), "acme")
+		}
+		log.Printf("Obtaining the API certificate of %v from the ACME CA", acmeCfg.Domains)
+		tlsConfig = crypto.WithACME(tlsConfig, crypto.ACMEConfig{
+			Domains:      acmeCfg.Domains,
+			Email:        acmeCfg.Email,
+			DirectoryURL: acmeCfg.DirectoryUrl,
+			CacheDir:     cacheDir,
+		})
 	}
 	provider := queues.NewAmqpProvider(cfg.Queue.AmqpURL, log)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
-	go func() {
+	go certReloader.Run(ctx, crypto.CertificateReloaderInterval)
+	// once the service is asked to stop, the servers stop accepting
+	// connections and drain the ones in flight, and then the task queues are
+	// flushed, so that the service can be upgraded without dropping the
+	// status updates of agents or the tasks they triggered
+	var servers sync.WaitGroup
+	serve := func(run func() error) {
+		servers.Add(1)
+		go func() {
+			defer servers.Done()
+			if err := run(); err != nil {
+				log.Fatalf("Error running server: %s", err)
+			}
+			cancel()
+		}()
+	}
+
+	serve(func() error {
 		listener, err := middleware.NewTLSListener(cfg.Service.Address, tlsConfig)
 		if err != nil {
 			log.Fatalf("creating listener: %s", err)
 		}
+		return apiserver.New(log, cfg, store, ca, listener, provider).Run(ctx)
+	})
 
-		server := apiserver.New(log, cfg, store, ca, listener, provider)
-		if err := server.Run(ctx); err != nil {
-			log.Fatalf("Error running server: %s", err)
-		}
-		cancel()
-	}()
-
-	go func() {
+	serve(func() error {
 		listener, err := middleware.NewTLSListener(cfg.Service.AgentEndpointAddress, agentTlsConfig)
 		if err != nil {
 			log.Fatalf("creating listener: %s", err)
 		}
+		agentServer := agentserver.New(log, cfg, store, ca, listener, provider)
+		agentServer.SetClientCAs(agentTlsConfig.ClientCAs)
+		return agentServer.Run(ctx)
+	})
 
-		agentserver := agentserver.New(log, cfg, store, ca, listener)
-		if err := agentserver.Run(ctx); err != nil {
-			log.Fatalf("Error running server: %s", err)
-		}
-		cancel()
-	}()
-
-	go func() {
-
-		grpcServer := agentserver.NewAgentGrpcServer(log, cfg, grpcTlsConfig)
-		if err := grpcServer.Run(ctx); err != nil {
-			log.Fatalf("Error running server: %s", err)
-		}
-		cancel()
-	}()
+	serve(func() error {
+		return agentserver.NewAgentGrpcServer(log, cfg, grpcTlsConfig).Run(ctx)
+	})
 
 	<-ctx.Done()
+	log.Printf("Draining connections for up to %s", cfg.Service.GracefulShutdownTimeout())
+	servers.Wait()
+	log.Println("Flushing task queues")
+	provider.Stop()
+	provider.Wait()
 }
 
 func certFile(name string) string {
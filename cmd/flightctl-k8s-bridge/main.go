@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/k8sbridge"
+	fcclient "github.com/flightctl/flightctl/pkg/client"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func main() {
+	log := log.InitLogs()
+
+	var clientConfigFile, kubeconfig, namespace, logLevel string
+	var interval time.Duration
+	flag.StringVar(&clientConfigFile, "client-config", client.DefaultFlightctlClientConfigPath(), "Path to the flightctl client configuration file.")
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file. The in-cluster configuration is used if empty.")
+	flag.StringVar(&namespace, "namespace", os.Getenv("POD_NAMESPACE"), "Namespace of the mirrored custom resources.")
+	flag.DurationVar(&interval, "interval", 30*time.Second, "Interval between syncs.")
+	flag.StringVar(&logLevel, "log-level", "info", "Log level.")
+	flag.Parse()
+
+	logLvl, err := logrus.ParseLevel(logLevel)
+	if err != nil {
+		logLvl = logrus.InfoLevel
+	}
+	log.SetLevel(logLvl)
+	log.Println("Starting Kubernetes bridge")
+	defer log.Println("Kubernetes bridge stopped")
+
+	if namespace == "" {
+		log.Fatalf("the namespace of the custom resources must be set")
+	}
+
+	flightctl, err := fcclient.NewFromConfigFile(clientConfigFile)
+	if err != nil {
+		log.Fatalf("creating flightctl client: %v", err)
+	}
+
+	var restConfig *rest.Config
+	if kubeconfig == "" {
+		restConfig, err = rest.InClusterConfig()
+	} else {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		log.Fatalf("reading Kubernetes configuration: %v", err)
+	}
+	k8s, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		log.Fatalf("creating Kubernetes client: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	log.Printf("Mirroring flightctl resources in namespace %s every %s", namespace, interval)
+	k8sbridge.New(log.WithField("pkg", "k8s-bridge"), flightctl, k8s, namespace).Run(ctx, interval)
+}
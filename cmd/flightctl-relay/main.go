@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/flightctl/flightctl/internal/relay"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "version" {
+		versionInfo := version.Get()
+		fmt.Printf("Flightctl Relay Version: %s\n", versionInfo.String())
+		fmt.Printf("Git Commit: %s\n", versionInfo.GitCommit)
+		os.Exit(0)
+	}
+
+	configFile := flag.String("config", relay.DefaultConfigFile, "Path to the relay's configuration file.")
+	flag.Parse()
+
+	log := log.InitLogs()
+	log.Println("Starting relay")
+	defer log.Println("Relay stopped")
+
+	cfg, err := relay.LoadConfig(*configFile)
+	if err != nil {
+		log.Fatalf("reading configuration: %v", err)
+	}
+	logLvl, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		logLvl = logrus.InfoLevel
+	}
+	log.SetLevel(logLvl)
+
+	r, err := relay.New(cfg, log)
+	if err != nil {
+		log.Fatalf("initializing relay: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
+	defer cancel()
+	if err := r.Run(ctx); err != nil {
+		log.Fatalf("running relay: %v", err)
+	}
+}
@@ -25,14 +25,28 @@ func NewFlightCtlCommand() *cobra.Command {
 	}
 	cmd.AddCommand(cli.NewCmdGet())
 	cmd.AddCommand(cli.NewCmdApply())
+	cmd.AddCommand(cli.NewCmdImport())
 	cmd.AddCommand(cli.NewCmdDelete())
+	cmd.AddCommand(cli.NewCmdRestore())
 	cmd.AddCommand(cli.NewCmdApprove())
 	cmd.AddCommand(cli.NewCmdDeny())
+	cmd.AddCommand(cli.NewCmdReport())
+	cmd.AddCommand(cli.NewCmdQuarantine())
+	cmd.AddCommand(cli.NewCmdRename())
+	cmd.AddCommand(cli.NewCmdAction())
+	cmd.AddCommand(cli.NewCmdWake())
+	cmd.AddCommand(cli.NewCmdExec())
+	cmd.AddCommand(cli.NewCmdRollout())
+	cmd.AddCommand(cli.NewCmdOperation())
 	cmd.AddCommand(cli.NewCmdLogin())
 	cmd.AddCommand(cli.NewCmdVersion())
 	cmd.AddCommand(cli.NewConsoleCmd())
+	cmd.AddCommand(cli.NewCmdConsoleGrant())
+	cmd.AddCommand(cli.NewCmdTunnel())
+	cmd.AddCommand(cli.NewCmdProvisioningToken())
 	cmd.AddCommand(cli.NewCmdCompletion())
 	cmd.AddCommand(cli.NewCmdEnrollmentConfig())
+	cmd.AddCommand(cli.NewCmdBuildConfig())
 
 	return cmd
 }
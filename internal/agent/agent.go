@@ -3,24 +3,30 @@ package agent
 import (
 	"context"
 	"crypto"
-	"encoding/base32"
+	"crypto/tls"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"strings"
 	"syscall"
 	"time"
 
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
 	"github.com/flightctl/flightctl/internal/agent/client"
 	"github.com/flightctl/flightctl/internal/agent/device"
+	"github.com/flightctl/flightctl/internal/agent/device/attestation"
+	"github.com/flightctl/flightctl/internal/agent/device/compliance"
 	"github.com/flightctl/flightctl/internal/agent/device/config"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/gc"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/metrics"
+	"github.com/flightctl/flightctl/internal/agent/device/nudge"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
 	"github.com/flightctl/flightctl/internal/container"
 	fcrypto "github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/pkg/executer"
@@ -75,6 +81,8 @@ func (a *Agent) Run(ctx context.Context) error {
 		}
 	}(ctx)
 
+	client.SetProxy(a.config.Proxy)
+
 	// create file io writer and reader
 	deviceReadWriter := fileio.NewReadWriter(fileio.WithTestRootDir(a.config.testRootDir))
 
@@ -88,12 +96,10 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	publicKeyHash, err := fcrypto.HashPublicKey(publicKey)
+	deviceName, err := fcrypto.DeviceFingerprint(publicKey)
 	if err != nil {
 		return err
 	}
-
-	deviceName := strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(publicKeyHash))
 	csr, err := fcrypto.MakeCSR(privateKey.(crypto.Signer), deviceName)
 	if err != nil {
 		return err
@@ -101,14 +107,14 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	executer := &executer.CommonExecuter{}
 
-	// create enrollment client
-	enrollmentClient, err := newEnrollmentClient(a.config)
+	// record the restarts of the agent, and have systemd restart it once its
+	// reconciliation loop is stuck
+	agentWatchdog := watchdog.New(a.config.DataDir, deviceReadWriter, time.Duration(a.config.Watchdog.StallTimeout), a.log)
+	lastRestart, err := agentWatchdog.Start()
 	if err != nil {
-		return err
+		a.log.Warnf("Failed to record the agent start: %v", err)
 	}
-
-	// create bootc client
-	bootcClient := container.NewBootcCmd(executer)
+	go agentWatchdog.Run(ctx)
 
 	// TODO: this needs tuned
 	backoff := wait.Backoff{
@@ -118,6 +124,22 @@ func (a *Agent) Run(ctx context.Context) error {
 		Steps:    24,
 	}
 
+	// machines installed from a generic image fetch their enrollment configuration first
+	if a.config.ZeroTouchProvisioning {
+		if err := a.provision(ctx, deviceReadWriter, backoff); err != nil {
+			return err
+		}
+	}
+
+	// create enrollment client
+	enrollmentClient, err := newEnrollmentClient(a.config)
+	if err != nil {
+		return err
+	}
+
+	// create bootc client
+	bootcClient := container.NewBootcCmd(executer)
+
 	// create spec manager
 	specManager := spec.NewManager(
 		deviceName,
@@ -136,18 +158,70 @@ func (a *Agent) Run(ctx context.Context) error {
 	// create hook manager
 	hookManager := hook.NewManager(executer, a.log)
 
+	// create reported properties manager
+	reportedManager := reported.NewManager(
+		deviceReadWriter.PathFor(a.config.ReportedPropertiesSocket),
+		a.config.DataDir,
+		deviceReadWriter,
+		a.log,
+	)
+
+	// create the locator of the device, if its location is reported
+	locator, err := a.config.Geolocation.Locator()
+	if err != nil {
+		return err
+	}
+
+	// create the sinks the status is written to besides the management service
+	statusSinks := make([]status.Sink, 0, len(a.config.StatusSinks))
+	for i := range a.config.StatusSinks {
+		sink, err := a.config.StatusSinks[i].Sink(deviceName, deviceReadWriter)
+		if err != nil {
+			return err
+		}
+		statusSinks = append(statusSinks, sink)
+	}
+
+	// create the compliance manager scanning the device against the profile of its spec
+	complianceManager := compliance.NewManager(a.config.DataDir, executer, deviceReadWriter, a.log)
+
+	// create the garbage collection manager reclaiming the disk space of the device
+	gcManager := gc.NewManager(a.config.DataDir, executer, deviceReadWriter, a.log)
+
 	// create status manager
 	statusManager := status.NewManager(
 		deviceName,
 		resourceManager,
 		hookManager,
+		reportedManager,
+		executer,
+		a.config.AcceleratorStatus,
+		locator,
+		time.Duration(a.config.Geolocation.Interval),
+		complianceManager,
+		gcManager,
+		lastRestart,
+		statusSinks,
+		a.log,
+	)
+
+	// create agent update controller
+	agentUpdateController := device.NewAgentUpdateController(
+		a.config.DataDir,
+		deviceReadWriter,
 		executer,
+		statusManager,
 		a.log,
 	)
+	// an updated agent which cannot bootstrap is rolled back as well
+	go agentUpdateController.Run(ctx)
+
+	// create the progress reporter of the updates of the device
+	updateProgress := device.NewUpdateProgress(a.config.DataDir, deviceReadWriter, statusManager, a.log)
 
 	// create config controller
 	configController := config.NewController(
-		hookManager,
+		updateProgress.Hooks(hookManager),
 		deviceReadWriter,
 		a.log,
 	)
@@ -192,35 +266,213 @@ func (a *Agent) Run(ctx context.Context) error {
 		specManager,
 		a.log,
 	)
+	osImageController.SetUpdateProgress(updateProgress)
+
+	// create encryption controller
+	encryptionController := device.NewEncryptionController(
+		executer,
+		statusManager,
+		a.log,
+	)
+
+	// create time sync controller
+	timeSyncController := device.NewTimeSyncController(
+		executer,
+		deviceReadWriter,
+		a.log,
+	)
+
+	// create firewall controller
+	firewallController := device.NewFirewallController(
+		executer,
+		deviceReadWriter,
+		a.log,
+	)
+
+	// create quarantine controller
+	quarantineController := device.NewQuarantineController(
+		a.config.DataDir,
+		executer,
+		deviceReadWriter,
+		a.log,
+	)
+
+	// create migration controller
+	migrationController := device.NewMigrationController(
+		a.config.DataDir,
+		executer,
+		deviceReadWriter,
+		a.log,
+	)
+
+	// create action controller
+	actionController := device.NewActionController(
+		a.config.DataDir,
+		executer,
+		deviceReadWriter,
+		statusManager,
+		a.log,
+	)
+
+	// create adoption controller holding back the first spec of a brownfield device
+	adoptionController := device.NewAdoptionController(
+		a.config.AdoptExistingState,
+		a.config.DataDir,
+		executer,
+		deviceReadWriter,
+		statusManager,
+		a.log,
+	)
+	actionController.SetSpecEnforcer(adoptionController.Enforce)
+
+	// create application controller
+	applicationController := device.NewApplicationController(
+		a.config.DataDir,
+		executer,
+		deviceReadWriter,
+		statusManager,
+		specManager,
+		a.config.ApplicationConcurrency,
+		a.log,
+	)
+
+	// create check controller reporting the changes of the spec in check-only mode
+	checkController := device.NewCheckController(
+		a.config.CheckOnly,
+		deviceReadWriter,
+		specManager,
+		statusManager,
+		applicationController,
+		a.log,
+	)
+
+	// create drift controller checking the device against its spec between spec changes
+	driftController := device.NewDriftController(
+		deviceReadWriter,
+		statusManager,
+		applicationController,
+		a.log,
+	)
 
 	// create console controller
 	consoleController := device.NewConsoleController(
 		grpcClient,
 		deviceName,
+		consoleIdentity(a.config),
 		executer,
 		a.log,
 	)
 
+	// create tunnel controller
+	tunnelController := device.NewTunnelController(
+		grpcClient,
+		deviceName,
+		consoleIdentity(a.config),
+		a.log,
+	)
+
 	// create agent
 	agent := device.NewAgent(
 		deviceName,
-		deviceReadWriter,
-		statusManager,
-		specManager,
 		a.config.SpecFetchInterval,
 		a.config.StatusUpdateInterval,
-		hookManager,
-		configController,
-		osImageController,
-		resourceController,
-		consoleController,
+		device.Dependencies{
+			DeviceWriter:          deviceReadWriter,
+			StatusManager:         statusManager,
+			SpecManager:           specManager,
+			HookManager:           hookManager,
+			ConfigController:      configController,
+			OSImageController:     osImageController,
+			AgentUpdateController: agentUpdateController,
+			EncryptionController:  encryptionController,
+			TimeSyncController:    timeSyncController,
+			FirewallController:    firewallController,
+			QuarantineController:  quarantineController,
+			MigrationController:   migrationController,
+			ActionController:      actionController,
+			CheckController:       checkController,
+			AdoptionController:    adoptionController,
+			DriftController:       driftController,
+			ApplicationController: applicationController,
+			ResourceController:    resourceController,
+			ConsoleController:     consoleController,
+			TunnelController:      tunnelController,
+			UpdateProgress:        updateProgress,
+		},
+		a.log,
+	)
+
+	// create metrics manager, this must be done after bootstrap
+	managementClient, err := newManagementClient(a.config)
+	if err != nil {
+		return err
+	}
+	metricsManager := metrics.NewManager(
+		deviceName,
+		a.config.Metrics.ScrapeTargets,
+		time.Duration(a.config.Metrics.ScrapeInterval),
+		managementClient,
 		a.log,
 	)
 
+	// create attestation manager
+	tpmPath := a.config.TPMPath
+	if tpmPath != "" {
+		tpmPath = deviceReadWriter.PathFor(tpmPath)
+	}
+	attestationManager := attestation.NewManager(
+		deviceName,
+		tpmPath,
+		time.Duration(a.config.Attestation.Interval),
+		bootcClient,
+		managementClient,
+		a.log,
+	)
+
+	// subscribe to the notifications of new rendered specs, if the service publishes them
+	if a.config.SpecNotifications != nil {
+		mqttClient, err := a.config.SpecNotifications.Client(deviceName, deviceReadWriter)
+		if err != nil {
+			return err
+		}
+		notifications := spec.NewNotifications(mqttClient, a.config.SpecNotifications.Topic(deviceName), specManager, a.log)
+		go notifications.Run(ctx, agent.TriggerSync)
+	}
+
+	// wait for the nudges of the out-of-band channels
+	for i := range a.config.NudgeSources {
+		source, err := a.config.NudgeSources[i].Source(executer, deviceReadWriter)
+		if err != nil {
+			return err
+		}
+		minInterval := time.Duration(a.config.NudgeSources[i].MinInterval)
+		if minInterval == 0 {
+			minInterval = nudge.DefaultMinInterval
+		}
+		go nudge.Run(ctx, source, minInterval, agent.TriggerSync, a.log)
+	}
+
 	go hookManager.Run(ctx)
 	go resourceManager.Run(ctx)
+	go reportedManager.Run(ctx)
+	go metricsManager.Run(ctx)
+	go attestationManager.Run(ctx)
+	go complianceManager.Run(ctx)
+	go gcManager.Run(ctx)
+	reloader := newConfigReloader(a.config.configFile, a.config, agent, hookManager, resourceManager, a.log)
+	agent.SetAgentSpecHandler(reloader.SetAgentSpec)
+	// the settings of the config file apply until the first spec is synced
+	reloader.SetAgentSpec(nil)
+	if a.config.configFile != "" {
+		go reloader.Run(ctx)
+	}
 
-	return agent.Run(ctx)
+	agent.SetHeartbeat(agentWatchdog.Register("reconciliation loop"))
+	if err := agent.Run(ctx); err != nil {
+		return err
+	}
+	agentWatchdog.Stop()
+	return nil
 }
 
 func newEnrollmentClient(cfg *Config) (client.Enrollment, error) {
@@ -231,6 +483,14 @@ func newEnrollmentClient(cfg *Config) (client.Enrollment, error) {
 	return client.NewEnrollment(httpClient), nil
 }
 
+func newManagementClient(cfg *Config) (client.Management, error) {
+	httpClient, err := client.NewFromConfig(&cfg.ManagementService.Config)
+	if err != nil {
+		return nil, err
+	}
+	return client.NewManagement(httpClient), nil
+}
+
 func newGrpcClient(cfg *Config) (grpc_v1.RouterServiceClient, error) {
 	if cfg.GrpcManagementEndpoint == "" {
 		return nil, fmt.Errorf("no gRPC endpoint, disabling console functionality")
@@ -241,3 +501,22 @@ func newGrpcClient(cfg *Config) (grpc_v1.RouterServiceClient, error) {
 	}
 	return client, nil
 }
+
+// consoleIdentity returns the loader of the agent certificate, which signs the
+// handshakes of end-to-end encrypted console sessions.
+func consoleIdentity(cfg *Config) func() (tls.Certificate, error) {
+	return func() (tls.Certificate, error) {
+		config := cfg.ManagementService.Config.DeepCopy()
+		if err := config.Flatten(); err != nil {
+			return tls.Certificate{}, err
+		}
+		return tls.X509KeyPair(config.AuthInfo.ClientCertificateData, config.AuthInfo.ClientKeyData)
+	}
+}
+
+// CheckUpdate rolls back an agent update which repeatedly failed to start.
+// It returns true if the update was rolled back.
+func CheckUpdate(ctx context.Context, log *log.PrefixLogger, config *Config) (bool, error) {
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(config.testRootDir))
+	return device.CheckAgentUpdate(ctx, config.DataDir, readWriter, &executer.CommonExecuter{}, log)
+}
@@ -1,14 +1,21 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	client "github.com/flightctl/flightctl/internal/api/client/agent"
 	baseclient "github.com/flightctl/flightctl/internal/client"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/pkg/reqid"
 	"github.com/go-chi/chi/middleware"
 )
@@ -20,11 +27,46 @@ func NewFromConfig(config *baseclient.Config) (*client.ClientWithResponses, erro
 	if err != nil {
 		return nil, fmt.Errorf("NewFromConfig: creating HTTP client %w", err)
 	}
+	opts := []client.ClientOption{client.WithHTTPClient(httpClient)}
+	if transport, ok := httpClient.Transport.(*http.Transport); ok {
+		transport.Proxy = proxy.proxyFor
+		if certs := transport.TLSClientConfig.Certificates; len(certs) > 0 {
+			if signer, ok := certs[0].PrivateKey.(crypto.Signer); ok {
+				opts = append(opts, client.WithRequestEditorFn(signRequest(signer)))
+			}
+		}
+	}
 	ref := client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
 		req.Header.Set(middleware.RequestIDHeader, reqid.GetReqID())
 		return nil
 	})
-	return client.NewClientWithResponses(config.Service.Server, client.WithHTTPClient(httpClient), ref)
+	return client.NewClientWithResponses(config.Service.Server, append(opts, ref)...)
+}
+
+// signRequest returns a request editor signing the requests with the key of
+// the client certificate, so that a relay forwarding them to the service
+// cannot forge them.
+func signRequest(signer crypto.Signer) client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		var body []byte
+		if req.Body != nil {
+			var err error
+			body, err = io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return fmt.Errorf("reading the body to sign: %w", err)
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		signedAt := time.Now()
+		signature, err := fcrypto.SignRequest(signer, req.Method, req.URL.Path, signedAt, body)
+		if err != nil {
+			return fmt.Errorf("signing the request: %w", err)
+		}
+		req.Header.Set(fcrypto.DeviceSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+		req.Header.Set(fcrypto.DeviceSignatureTimeHeader, strconv.FormatInt(signedAt.Unix(), 10))
+		return nil
+	}
 }
 
 func NewGRPCClientFromConfig(config *baseclient.Config, endpoint string) (grpc_v1.RouterServiceClient, error) {
@@ -43,6 +85,9 @@ func NewDefault() *Config {
 type Management interface {
 	UpdateDeviceStatus(ctx context.Context, name string, device v1alpha1.Device, rcb ...client.RequestEditorFn) error
 	GetRenderedDeviceSpec(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...client.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error)
+	PushDeviceMetrics(ctx context.Context, name string, payload []byte, rcb ...client.RequestEditorFn) error
+	CreateDeviceAttestationChallenge(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.AttestationChallenge, error)
+	ReportDeviceAttestation(ctx context.Context, name string, report v1alpha1.AttestationReport, rcb ...client.RequestEditorFn) error
 }
 
 // Enrollment is client the interface for managing device enrollment.
@@ -50,4 +95,6 @@ type Enrollment interface {
 	SetRPCMetricsCallback(cb func(operation string, durationSeconds float64, err error))
 	CreateEnrollmentRequest(ctx context.Context, req v1alpha1.EnrollmentRequest, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentRequest, error)
 	GetEnrollmentRequest(ctx context.Context, id string, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentRequest, error)
+	CreateProvisioningChallenge(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.ProvisioningChallenge, error)
+	ProvisionDevice(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentConfig, error)
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -12,6 +13,13 @@ import (
 
 var _ Enrollment = (*enrollment)(nil)
 
+var (
+	// ErrNoDeviceIdentity is returned when no device identity matches the hardware identity of the machine.
+	ErrNoDeviceIdentity = errors.New("no device identity matches the machine")
+	// ErrNotFound is returned when the enrollment request does not exist, such as once it expired.
+	ErrNotFound = errors.New("not found")
+)
+
 func NewEnrollment(
 	client *client.ClientWithResponses,
 ) Enrollment {
@@ -71,6 +79,9 @@ func (e *enrollment) GetEnrollmentRequest(ctx context.Context, id string, cb ...
 		e.rpcMetricsCallbackFunc("get_enrollmentrequest_duration", time.Since(start).Seconds(), err)
 	}
 
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("get enrollmentrequest failed: %w", ErrNotFound)
+	}
 	if resp.StatusCode() != http.StatusOK {
 		return nil, fmt.Errorf("get enrollmentrequest failed: %s", resp.Status())
 	}
@@ -81,3 +92,59 @@ func (e *enrollment) GetEnrollmentRequest(ctx context.Context, id string, cb ...
 
 	return resp.JSON200, nil
 }
+
+func (e *enrollment) CreateProvisioningChallenge(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.ProvisioningChallenge, error) {
+	start := time.Now()
+	resp, err := e.client.CreateProvisioningChallengeWithResponse(ctx, req, cb...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if e.rpcMetricsCallbackFunc != nil {
+		e.rpcMetricsCallbackFunc("create_provisioning_challenge_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrNoDeviceIdentity
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("create provisioning challenge failed: %s", resp.Status())
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("create provisioning challenge failed: %s", ErrEmptyResponse)
+	}
+
+	return resp.JSON200, nil
+}
+
+func (e *enrollment) ProvisionDevice(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentConfig, error) {
+	start := time.Now()
+	resp, err := e.client.ProvisionDeviceWithResponse(ctx, req, cb...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if e.rpcMetricsCallbackFunc != nil {
+		e.rpcMetricsCallbackFunc("provision_device_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrNoDeviceIdentity
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("provision device failed: %s", resp.Status())
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("provision device failed: %s", ErrEmptyResponse)
+	}
+
+	return resp.JSON200, nil
+}
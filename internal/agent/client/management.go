@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	client "github.com/flightctl/flightctl/internal/api/client/agent"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 )
 
 var _ Management = (*management)(nil)
@@ -79,3 +81,75 @@ func (m *management) GetRenderedDeviceSpec(ctx context.Context, name string, par
 
 	return nil, resp.StatusCode(), nil
 }
+
+// PushDeviceMetrics sends a snappy-compressed remote-write payload of metrics
+// scraped on the device with the given name.
+func (m *management) PushDeviceMetrics(ctx context.Context, name string, payload []byte, rcb ...client.RequestEditorFn) error {
+	start := time.Now()
+	rcb = append(rcb, func(ctx context.Context, req *http.Request) error {
+		remotewrite.SetHeaders(req.Header)
+		return nil
+	})
+	resp, err := m.client.PushDeviceMetricsWithBodyWithResponse(ctx, name, remotewrite.ContentType, bytes.NewReader(payload), rcb...)
+	if err != nil {
+		return err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("push_device_metrics_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf("push device metrics failed: %s", resp.Status())
+	}
+
+	return nil
+}
+
+// CreateDeviceAttestationChallenge returns the nonce the device with the given
+// name has to quote its measurements with.
+func (m *management) CreateDeviceAttestationChallenge(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.AttestationChallenge, error) {
+	start := time.Now()
+	resp, err := m.client.CreateDeviceAttestationChallengeWithResponse(ctx, name, rcb...)
+	if err != nil {
+		return nil, err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("create_device_attestation_challenge_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("create device attestation challenge failed: %s", resp.Status())
+	}
+	return resp.JSON200, nil
+}
+
+// ReportDeviceAttestation sends the quote of the measurements of the device
+// with the given name.
+func (m *management) ReportDeviceAttestation(ctx context.Context, name string, report v1alpha1.AttestationReport, rcb ...client.RequestEditorFn) error {
+	start := time.Now()
+	resp, err := m.client.ReportDeviceAttestationWithResponse(ctx, name, report, rcb...)
+	if err != nil {
+		return err
+	}
+	if resp.HTTPResponse != nil {
+		defer resp.HTTPResponse.Body.Close()
+	}
+
+	if m.rpcMetricsCallbackFunc != nil {
+		m.rpcMetricsCallbackFunc("report_device_attestation_duration", time.Since(start).Seconds(), err)
+	}
+
+	if resp.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf("report device attestation failed: %s", resp.Status())
+	}
+
+	return nil
+}
@@ -41,6 +41,26 @@ func (m *MockManagement) EXPECT() *MockManagementMockRecorder {
 	return m.recorder
 }
 
+// CreateDeviceAttestationChallenge mocks base method.
+func (m *MockManagement) CreateDeviceAttestationChallenge(ctx context.Context, name string, rcb ...client.RequestEditorFn) (*v1alpha1.AttestationChallenge, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateDeviceAttestationChallenge", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.AttestationChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeviceAttestationChallenge indicates an expected call of CreateDeviceAttestationChallenge.
+func (mr *MockManagementMockRecorder) CreateDeviceAttestationChallenge(ctx, name any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeviceAttestationChallenge", reflect.TypeOf((*MockManagement)(nil).CreateDeviceAttestationChallenge), varargs...)
+}
+
 // GetRenderedDeviceSpec mocks base method.
 func (m *MockManagement) GetRenderedDeviceSpec(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...client.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +82,44 @@ func (mr *MockManagementMockRecorder) GetRenderedDeviceSpec(ctx, name, params an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRenderedDeviceSpec", reflect.TypeOf((*MockManagement)(nil).GetRenderedDeviceSpec), varargs...)
 }
 
+// PushDeviceMetrics mocks base method.
+func (m *MockManagement) PushDeviceMetrics(ctx context.Context, name string, payload []byte, rcb ...client.RequestEditorFn) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name, payload}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PushDeviceMetrics", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PushDeviceMetrics indicates an expected call of PushDeviceMetrics.
+func (mr *MockManagementMockRecorder) PushDeviceMetrics(ctx, name, payload any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name, payload}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushDeviceMetrics", reflect.TypeOf((*MockManagement)(nil).PushDeviceMetrics), varargs...)
+}
+
+// ReportDeviceAttestation mocks base method.
+func (m *MockManagement) ReportDeviceAttestation(ctx context.Context, name string, report v1alpha1.AttestationReport, rcb ...client.RequestEditorFn) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, name, report}
+	for _, a := range rcb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReportDeviceAttestation", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportDeviceAttestation indicates an expected call of ReportDeviceAttestation.
+func (mr *MockManagementMockRecorder) ReportDeviceAttestation(ctx, name, report any, rcb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, name, report}, rcb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportDeviceAttestation", reflect.TypeOf((*MockManagement)(nil).ReportDeviceAttestation), varargs...)
+}
+
 // UpdateDeviceStatus mocks base method.
 func (m *MockManagement) UpdateDeviceStatus(ctx context.Context, name string, device v1alpha1.Device, rcb ...client.RequestEditorFn) error {
 	m.ctrl.T.Helper()
@@ -124,6 +182,26 @@ func (mr *MockEnrollmentMockRecorder) CreateEnrollmentRequest(ctx, req any, cb .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnrollmentRequest", reflect.TypeOf((*MockEnrollment)(nil).CreateEnrollmentRequest), varargs...)
 }
 
+// CreateProvisioningChallenge mocks base method.
+func (m *MockEnrollment) CreateProvisioningChallenge(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.ProvisioningChallenge, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range cb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateProvisioningChallenge", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.ProvisioningChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProvisioningChallenge indicates an expected call of CreateProvisioningChallenge.
+func (mr *MockEnrollmentMockRecorder) CreateProvisioningChallenge(ctx, req any, cb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, cb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProvisioningChallenge", reflect.TypeOf((*MockEnrollment)(nil).CreateProvisioningChallenge), varargs...)
+}
+
 // GetEnrollmentRequest mocks base method.
 func (m *MockEnrollment) GetEnrollmentRequest(ctx context.Context, id string, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentRequest, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +222,26 @@ func (mr *MockEnrollmentMockRecorder) GetEnrollmentRequest(ctx, id any, cb ...an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnrollmentRequest", reflect.TypeOf((*MockEnrollment)(nil).GetEnrollmentRequest), varargs...)
 }
 
+// ProvisionDevice mocks base method.
+func (m *MockEnrollment) ProvisionDevice(ctx context.Context, req v1alpha1.ProvisioningRequest, cb ...client.RequestEditorFn) (*v1alpha1.EnrollmentConfig, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range cb {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ProvisionDevice", varargs...)
+	ret0, _ := ret[0].(*v1alpha1.EnrollmentConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProvisionDevice indicates an expected call of ProvisionDevice.
+func (mr *MockEnrollmentMockRecorder) ProvisionDevice(ctx, req any, cb ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, cb...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisionDevice", reflect.TypeOf((*MockEnrollment)(nil).ProvisionDevice), varargs...)
+}
+
 // SetRPCMetricsCallback mocks base method.
 func (m *MockEnrollment) SetRPCMetricsCallback(cb func(string, float64, error)) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyConfig is the HTTP proxy configuration used to connect to the services.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy used for http requests
+	HTTPProxy string `json:"http-proxy,omitempty"`
+	// HTTPSProxy is the proxy used for https requests
+	HTTPSProxy string `json:"https-proxy,omitempty"`
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs which are not proxied
+	NoProxy string `json:"no-proxy,omitempty"`
+}
+
+// Validate checks that the proxies are valid URLs.
+func (p *ProxyConfig) Validate() error {
+	for name, proxy := range map[string]string{"http-proxy": p.HTTPProxy, "https-proxy": p.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		if _, err := url.Parse(proxy); err != nil {
+			return fmt.Errorf("proxy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// proxy is shared by all clients of the agent so that an updated configuration
+// applies to new requests without recreating the clients.
+var proxy = &proxySelector{}
+
+type proxySelector struct {
+	mu        sync.RWMutex
+	proxyFunc func(*url.URL) (*url.URL, error)
+}
+
+// SetProxy replaces the proxy configuration of all clients. An empty
+// configuration connects directly, ignoring the proxy environment variables.
+func SetProxy(config ProxyConfig) {
+	var proxyFunc func(*url.URL) (*url.URL, error)
+	if config != (ProxyConfig{}) {
+		c := httpproxy.Config{
+			HTTPProxy:  config.HTTPProxy,
+			HTTPSProxy: config.HTTPSProxy,
+			NoProxy:    config.NoProxy,
+		}
+		proxyFunc = c.ProxyFunc()
+	}
+
+	proxy.mu.Lock()
+	defer proxy.mu.Unlock()
+	proxy.proxyFunc = proxyFunc
+}
+
+// Proxy returns the proxy for the request according to the agent's proxy
+// configuration, for HTTP clients which are not created from a client config.
+func Proxy(req *http.Request) (*url.URL, error) {
+	return proxy.proxyFor(req)
+}
+
+func (p *proxySelector) proxyFor(req *http.Request) (*url.URL, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.proxyFunc == nil {
+		return nil, nil
+	}
+	return p.proxyFunc(req.URL)
+}
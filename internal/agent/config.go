@@ -1,16 +1,33 @@
 package agent
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device"
+	"github.com/flightctl/flightctl/internal/agent/device/attestation"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/geolocation"
+	"github.com/flightctl/flightctl/internal/agent/device/metrics"
+	"github.com/flightctl/flightctl/internal/agent/device/nudge"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
+	"github.com/flightctl/flightctl/internal/fips"
+	"github.com/flightctl/flightctl/internal/mqtt"
 	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/yaml"
@@ -68,9 +85,58 @@ type Config struct {
 	// StatusUpdateInterval is the interval between two status updates
 	StatusUpdateInterval util.Duration `json:"status-update-interval,omitempty"`
 
+	// Proxy is the HTTP proxy configuration used to connect to the enrollment and management services
+	Proxy client.ProxyConfig `json:"proxy,omitempty"`
+
+	// ReportedPropertiesSocket is the path of the unix socket applications use to report properties
+	ReportedPropertiesSocket string `json:"reported-properties-socket,omitempty"`
+
+	// AcceleratorStatus enables reporting the status of GPUs and other accelerators
+	AcceleratorStatus bool `json:"accelerator-status,omitempty"`
+
+	// Metrics is the configuration of the local Prometheus exporters forwarded to the management service
+	Metrics Metrics `json:"metrics,omitempty"`
+
 	// TPMPath is the path to the TPM device
 	TPMPath string `json:"tpm-path,omitempty"`
 
+	// Attestation is the configuration of the periodic attestation of the boot measurements with the TPM
+	Attestation Attestation `json:"attestation,omitempty"`
+
+	// Geolocation is the configuration of the reporting of the location of the device
+	Geolocation Geolocation `json:"geolocation,omitempty"`
+
+	// StatusSinks are the local files and MQTT topics the agent writes the device status to
+	// besides pushing it to the management service
+	StatusSinks []StatusSink `json:"status-sinks,omitempty"`
+
+	// SpecNotifications is the MQTT broker the service notifies the device of new rendered
+	// specs through, which the agent then applies without waiting for the next spec fetch
+	SpecNotifications *SpecNotifications `json:"spec-notifications,omitempty"`
+
+	// NudgeSources are the out-of-band channels, such as an SMS modem, whose nudges make the
+	// agent sync the device right away
+	NudgeSources []NudgeSource `json:"nudge-sources,omitempty"`
+
+	// MonitorThresholds are the alert thresholds of the default resource monitors, which
+	// the thresholds set by the device spec take precedence over
+	MonitorThresholds MonitorThresholds `json:"monitor-thresholds,omitempty"`
+
+	// AllowedHookPaths are the directories whose files device update hooks may watch, all
+	// files if unset. The paths set by the device spec take precedence.
+	AllowedHookPaths []string `json:"allowed-hook-paths,omitempty"`
+
+	// ApplicationConcurrency is the number of applications of the spec the agent syncs at
+	// once, among those which do not depend on each other
+	ApplicationConcurrency int `json:"application-concurrency,omitempty"`
+
+	// Watchdog is the configuration of the self-health check which has systemd restart the
+	// agent once its reconciliation loop is stuck
+	Watchdog Watchdog `json:"watchdog,omitempty"`
+
+	// RequireFIPS refuses to start the agent unless it is built with FIPS-validated crypto
+	RequireFIPS bool `json:"require-fips,omitempty"`
+
 	// LogLevel is the level of logging. can be:  "panic", "fatal", "error", "warn"/"warning",
 	// "info", "debug" or "trace", any other will be treated as "info"
 	LogLevel string `json:"log-level,omitempty"`
@@ -79,12 +145,28 @@ type Config struct {
 
 	// testRootDir is the root directory of the test agent
 	testRootDir string
+	// configFile is the path of the parsed config file, which is watched for changes
+	configFile string
 	// enrollmentMetricsCallback is a callback to report metrics about the enrollment process.
 	enrollmentMetricsCallback func(operation string, durationSeconds float64, err error)
 
+	// ZeroTouchProvisioning makes the agent replace the enrollment configuration with the one
+	// assigned to the hardware identity of the machine by the service before enrolling
+	ZeroTouchProvisioning bool `json:"zero-touch-provisioning,omitempty"`
+
 	// DefaultLabels are automatically applied to this device when the agent is enrolled in a service
 	DefaultLabels map[string]string `json:"default-labels,omitempty"`
 
+	// AdoptExistingState makes the agent of a device enrolled with containers and config already
+	// on it report them in status.adoption, and hold back the first rendered spec until an
+	// EnforceSpec action is requested for the device
+	AdoptExistingState bool `json:"adopt-existing-state,omitempty"`
+
+	// CheckOnly makes the agent only check the rendered specs, reporting the changes applying
+	// them would make in status.check without making them. Set by the --check flag, or for a
+	// device by agent.checkOnly of its spec
+	CheckOnly bool `json:"check-only,omitempty"`
+
 	reader fileio.Reader
 }
 
@@ -99,6 +181,301 @@ type ManagementService struct {
 	client.Config
 }
 
+type Metrics struct {
+	// ScrapeTargets are the URLs of the localhost exporters to scrape, e.g. http://localhost:9100/metrics
+	ScrapeTargets []string `json:"scrape-targets,omitempty"`
+	// ScrapeInterval is the interval between two scrapes of the exporters
+	ScrapeInterval util.Duration `json:"scrape-interval,omitempty"`
+}
+
+const (
+	// StatusSinkFile writes the device status to a local JSON file.
+	StatusSinkFile = "file"
+	// StatusSinkMQTT publishes the device status to an MQTT topic.
+	StatusSinkMQTT = "mqtt"
+	// DefaultStatusSinkTopic is the topic the status is published to unless set,
+	// where {device} is replaced by the name of the device.
+	DefaultStatusSinkTopic = "flightctl/devices/{device}/status"
+)
+
+// MQTTBroker is the connection of the agent to an MQTT broker.
+type MQTTBroker struct {
+	// Broker is the URL of the broker, e.g. ssl://broker.local:8883
+	Broker string `json:"broker,omitempty"`
+	// ClientID is the client identifier of the agent, the name of the device if unset
+	ClientID string `json:"client-id,omitempty"`
+	// Username and PasswordFile are the credentials of the agent
+	Username     string `json:"username,omitempty"`
+	PasswordFile string `json:"password-file,omitempty"`
+	// CertificateAuthority is the CA bundle the broker's certificate is verified with,
+	// the system's if unset
+	CertificateAuthority string `json:"certificate-authority,omitempty"`
+}
+
+// Validate checks that the broker is an MQTT URL and that the paths are absolute.
+func (b *MQTTBroker) Validate() error {
+	if _, err := mqtt.New(mqtt.Options{Broker: b.Broker}); err != nil {
+		return err
+	}
+	for _, path := range []string{b.PasswordFile, b.CertificateAuthority} {
+		if path != "" && !filepath.IsAbs(path) {
+			return fmt.Errorf("%q must be an absolute path", path)
+		}
+	}
+	return nil
+}
+
+// Client creates the client of the broker for the device.
+func (b *MQTTBroker) Client(deviceName string, reader fileio.Reader) (*mqtt.Client, error) {
+	opts := mqtt.Options{
+		Broker:   b.Broker,
+		ClientID: lo.Ternary(b.ClientID != "", b.ClientID, deviceName),
+		Username: b.Username,
+	}
+	if b.PasswordFile != "" {
+		password, err := reader.ReadFile(b.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading MQTT password: %w", err)
+		}
+		opts.Password = strings.TrimSpace(string(password))
+	}
+	if b.CertificateAuthority != "" {
+		caData, err := reader.ReadFile(b.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("reading MQTT certificate authority: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("no certificates found in %s", b.CertificateAuthority)
+		}
+		opts.TLSConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return mqtt.New(opts)
+}
+
+type StatusSink struct {
+	// Type is where the status is written to: "file" or "mqtt"
+	Type string `json:"type"`
+	// Path is the absolute path of the JSON file written by the file sink
+	Path string `json:"path,omitempty"`
+	// MQTTBroker is the broker the mqtt sink publishes to
+	MQTTBroker
+	// Topic is the topic the mqtt sink publishes to, where {device} is replaced by the
+	// name of the device
+	Topic string `json:"topic,omitempty"`
+}
+
+// Validate checks that the settings of the sink's type are set.
+func (s *StatusSink) Validate() error {
+	switch s.Type {
+	case StatusSinkFile:
+		if !filepath.IsAbs(s.Path) {
+			return fmt.Errorf("status-sinks: path of the file sink must be an absolute path")
+		}
+	case StatusSinkMQTT:
+		if err := s.MQTTBroker.Validate(); err != nil {
+			return fmt.Errorf("status-sinks: %w", err)
+		}
+	default:
+		return fmt.Errorf("status-sinks: type must be %s or %s", StatusSinkFile, StatusSinkMQTT)
+	}
+	return nil
+}
+
+// Sink creates the status sink of the device.
+func (s *StatusSink) Sink(deviceName string, readWriter fileio.ReadWriter) (status.Sink, error) {
+	switch s.Type {
+	case StatusSinkFile:
+		return status.NewFileSink(s.Path, readWriter), nil
+	case StatusSinkMQTT:
+		client, err := s.MQTTBroker.Client(deviceName, readWriter)
+		if err != nil {
+			return nil, err
+		}
+		topic := lo.Ternary(s.Topic != "", s.Topic, DefaultStatusSinkTopic)
+		return status.NewMQTTSink(client, strings.ReplaceAll(topic, "{device}", deviceName)), nil
+	default:
+		return nil, fmt.Errorf("unknown status sink type %q", s.Type)
+	}
+}
+
+type SpecNotifications struct {
+	// MQTTBroker is the broker the service publishes the notifications to
+	MQTTBroker
+	// TopicPrefix is the prefix of the topic of the device, flightctl/devices if unset
+	TopicPrefix string `json:"topic-prefix,omitempty"`
+}
+
+// Topic returns the topic the service notifies the device on.
+func (n *SpecNotifications) Topic(deviceName string) string {
+	return mqtt.SpecTopic(lo.Ternary(n.TopicPrefix != "", n.TopicPrefix, mqtt.DefaultSpecTopicPrefix), deviceName)
+}
+
+type NudgeSource struct {
+	// Type is the channel the nudges are received on: "exec" or "serial"
+	Type string `json:"type"`
+	// Command is the command of the exec source, which exits with 0 once the device is nudged
+	Command []string `json:"command,omitempty"`
+	// Device is the serial device of the modem read by the serial source, e.g. /dev/ttyUSB2
+	Device string `json:"device,omitempty"`
+	// Pattern is the text of the lines of the modem which nudge the device, +CMTI: if unset
+	Pattern string `json:"pattern,omitempty"`
+	// MinInterval is the minimum interval between two syncs triggered by the source
+	MinInterval util.Duration `json:"min-interval,omitempty"`
+}
+
+// Validate checks that the settings of the source's type are set.
+func (n *NudgeSource) Validate() error {
+	switch n.Type {
+	case nudge.SourceExec:
+		if len(n.Command) == 0 || !filepath.IsAbs(n.Command[0]) {
+			return fmt.Errorf("nudge-sources: command of the exec source must start with an absolute path")
+		}
+	case nudge.SourceSerial:
+		if !filepath.IsAbs(n.Device) {
+			return fmt.Errorf("nudge-sources: device of the serial source must be an absolute path")
+		}
+	default:
+		return fmt.Errorf("nudge-sources: type must be %s or %s", nudge.SourceExec, nudge.SourceSerial)
+	}
+	if n.MinInterval < 0 {
+		return fmt.Errorf("nudge-sources: min-interval must not be negative")
+	}
+	return nil
+}
+
+// Source creates the nudge source.
+func (n *NudgeSource) Source(exec executer.Executer, readWriter fileio.ReadWriter) (nudge.Source, error) {
+	device := n.Device
+	if device != "" {
+		device = readWriter.PathFor(device)
+	}
+	return nudge.New(n.Type, exec, n.Command, device, n.Pattern)
+}
+
+type MonitorThresholds struct {
+	CPU    ResourceThresholds `json:"cpu,omitempty"`
+	Memory ResourceThresholds `json:"memory,omitempty"`
+	Disk   ResourceThresholds `json:"disk,omitempty"`
+}
+
+// ResourceThresholds are the usage percentages that trigger the warning and critical
+// alerts of a default monitor. Unset thresholds keep their built-in value.
+type ResourceThresholds struct {
+	WarningPercentage  *float64 `json:"warning-percentage,omitempty"`
+	CriticalPercentage *float64 `json:"critical-percentage,omitempty"`
+}
+
+// Validate checks that the thresholds are percentages and that no warning threshold
+// exceeds its critical threshold.
+func (m *MonitorThresholds) Validate() error {
+	for _, resource := range []struct {
+		name       string
+		thresholds ResourceThresholds
+	}{{"cpu", m.CPU}, {"memory", m.Memory}, {"disk", m.Disk}} {
+		name, thresholds := resource.name, resource.thresholds
+		for _, percentage := range []*float64{thresholds.WarningPercentage, thresholds.CriticalPercentage} {
+			if percentage != nil && (*percentage < 0 || *percentage > 100) {
+				return fmt.Errorf("monitor-thresholds: %s threshold %v must be between 0 and 100", name, *percentage)
+			}
+		}
+		if thresholds.WarningPercentage != nil && thresholds.CriticalPercentage != nil && *thresholds.WarningPercentage > *thresholds.CriticalPercentage {
+			return fmt.Errorf("monitor-thresholds: %s warning-percentage must not exceed critical-percentage", name)
+		}
+	}
+	return nil
+}
+
+type Attestation struct {
+	// Interval is the interval between two attestations, zero disables attestation.
+	// Devices are only attested if the tpm-path is set.
+	Interval util.Duration `json:"interval,omitempty"`
+}
+
+type Watchdog struct {
+	// StallTimeout is how long the reconciliation loop may take to get back to waiting for
+	// work, such as while syncing a spec, before the agent is restarted. Zero disables the check.
+	StallTimeout util.Duration `json:"stall-timeout,omitempty"`
+}
+
+type Geolocation struct {
+	// Source is where the location of the device is determined from: "static", "gps" or "ip".
+	// The location is not reported if unset.
+	Source string `json:"source,omitempty"`
+	// Latitude and Longitude are the coordinates in degrees reported by the static source
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	// GPSDevice is the serial device of the GPS receiver read by the gps source, e.g. /dev/ttyACM0
+	GPSDevice string `json:"gps-device,omitempty"`
+	// IPLookupURL is the URL of the service the ip source queries for the location of the public IP address
+	IPLookupURL string `json:"ip-lookup-url,omitempty"`
+	// Interval is the interval between two locates of the device
+	Interval util.Duration `json:"interval,omitempty"`
+}
+
+// Validate checks that the settings of the configured source are set.
+func (g *Geolocation) Validate() error {
+	switch g.Source {
+	case "":
+		return nil
+	case geolocation.SourceStatic:
+		if g.Latitude == nil || g.Longitude == nil {
+			return fmt.Errorf("geolocation: latitude and longitude are required by the static source")
+		}
+		if *g.Latitude < -90 || *g.Latitude > 90 {
+			return fmt.Errorf("geolocation: latitude %v must be between -90 and 90", *g.Latitude)
+		}
+		if *g.Longitude < -180 || *g.Longitude > 180 {
+			return fmt.Errorf("geolocation: longitude %v must be between -180 and 180", *g.Longitude)
+		}
+	case geolocation.SourceGPS:
+		if !filepath.IsAbs(g.GPSDevice) {
+			return fmt.Errorf("geolocation: gps-device must be an absolute path")
+		}
+	case geolocation.SourceIP:
+		u, err := url.Parse(g.IPLookupURL)
+		if err != nil {
+			return fmt.Errorf("geolocation: ip-lookup-url: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("geolocation: ip-lookup-url must be an HTTP URL")
+		}
+	default:
+		return fmt.Errorf("geolocation: source must be one of %s, %s or %s", geolocation.SourceStatic, geolocation.SourceGPS, geolocation.SourceIP)
+	}
+	if g.Interval <= 0 {
+		return fmt.Errorf("geolocation: interval must be positive")
+	}
+	return nil
+}
+
+// Locator returns the locator of the configured source, or nil if the location is not reported.
+func (g *Geolocation) Locator() (geolocation.Locator, error) {
+	if g.Source == "" {
+		return nil, nil
+	}
+	return geolocation.New(g.Source, lo.FromPtr(g.Latitude), lo.FromPtr(g.Longitude), g.GPSDevice, g.IPLookupURL)
+}
+
+// Validate checks that the scrape targets are HTTP URLs of exporters running on the device.
+func (m *Metrics) Validate() error {
+	for _, target := range m.ScrapeTargets {
+		u, err := url.Parse(target)
+		if err != nil {
+			return fmt.Errorf("metrics scrape target %q: %w", target, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("metrics scrape target %q: scheme must be http or https", target)
+		}
+		if host := u.Hostname(); host != "localhost" {
+			if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+				return fmt.Errorf("metrics scrape target %q: host must be localhost or a loopback address", target)
+			}
+		}
+	}
+	return nil
+}
+
 func (s *EnrollmentService) Equal(s2 *EnrollmentService) bool {
 	if s == s2 {
 		return true
@@ -115,15 +492,21 @@ func (s *ManagementService) Equal(s2 *ManagementService) bool {
 
 func NewDefault() *Config {
 	c := &Config{
-		ConfigDir:            DefaultConfigDir,
-		DataDir:              DefaultDataDir,
-		EnrollmentService:    EnrollmentService{Config: *client.NewDefault()},
-		ManagementService:    ManagementService{Config: *client.NewDefault()},
-		StatusUpdateInterval: DefaultStatusUpdateInterval,
-		SpecFetchInterval:    DefaultSpecFetchInterval,
-		reader:               fileio.NewReader(),
-		LogLevel:             logrus.InfoLevel.String(),
-		DefaultLabels:        make(map[string]string),
+		ConfigDir:                DefaultConfigDir,
+		DataDir:                  DefaultDataDir,
+		EnrollmentService:        EnrollmentService{Config: *client.NewDefault()},
+		ManagementService:        ManagementService{Config: *client.NewDefault()},
+		StatusUpdateInterval:     DefaultStatusUpdateInterval,
+		SpecFetchInterval:        DefaultSpecFetchInterval,
+		ReportedPropertiesSocket: reported.DefaultSocketPath,
+		Metrics:                  Metrics{ScrapeInterval: util.Duration(metrics.DefaultScrapeInterval)},
+		Attestation:              Attestation{Interval: util.Duration(attestation.DefaultInterval)},
+		Geolocation:              Geolocation{Interval: util.Duration(geolocation.DefaultInterval)},
+		ApplicationConcurrency:   device.DefaultApplicationConcurrency,
+		Watchdog:                 Watchdog{StallTimeout: util.Duration(watchdog.DefaultStallTimeout)},
+		reader:                   fileio.NewReader(),
+		LogLevel:                 logrus.InfoLevel.String(),
+		DefaultLabels:            make(map[string]string),
 	}
 
 	if value := os.Getenv(TestRootDirEnvKey); value != "" {
@@ -178,6 +561,18 @@ func (cfg *Config) Complete() error {
 		cfg.ManagementService.Config = *cfg.EnrollmentService.Config.DeepCopy()
 		cfg.ManagementService.Config.AuthInfo = client.AuthInfo{}
 	}
+	// a device migrated to another instance keeps connecting to it
+	migration, err := device.ReadMigration(cfg.reader, cfg.DataDir)
+	if err != nil {
+		return err
+	}
+	if migration != nil {
+		cfg.ManagementService.Service.Server = migration.ManagementEndpoint
+		cfg.GrpcManagementEndpoint = lo.FromPtr(migration.GrpcManagementEndpoint)
+		if migration.CaBundle != nil {
+			cfg.ManagementService.Service.CertificateAuthorityData = []byte(*migration.CaBundle)
+		}
+	}
 	return nil
 }
 
@@ -189,6 +584,46 @@ func (cfg *Config) Validate() error {
 	if err := cfg.ManagementService.Validate(); err != nil {
 		return err
 	}
+	if err := cfg.Metrics.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Proxy.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Geolocation.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.MonitorThresholds.Validate(); err != nil {
+		return err
+	}
+	if cfg.SpecNotifications != nil {
+		if err := cfg.SpecNotifications.Validate(); err != nil {
+			return fmt.Errorf("spec-notifications: %w", err)
+		}
+	}
+	for i := range cfg.NudgeSources {
+		if err := cfg.NudgeSources[i].Validate(); err != nil {
+			return err
+		}
+	}
+	for i := range cfg.StatusSinks {
+		if err := cfg.StatusSinks[i].Validate(); err != nil {
+			return err
+		}
+	}
+	if cfg.ApplicationConcurrency < 0 {
+		return fmt.Errorf("application-concurrency: must not be negative")
+	}
+	for _, hookPath := range cfg.AllowedHookPaths {
+		if !filepath.IsAbs(hookPath) {
+			return fmt.Errorf("allowed-hook-paths: %q must be an absolute path", hookPath)
+		}
+	}
+	if cfg.RequireFIPS {
+		if err := fips.Require(); err != nil {
+			return err
+		}
+	}
 
 	requiredFields := []struct {
 		value     string
@@ -228,6 +663,7 @@ func (cfg *Config) ParseConfigFile(cfgFile string) error {
 	}
 	cfg.EnrollmentService.Config.SetBaseDir(filepath.Dir(cfgFile))
 	cfg.ManagementService.Config.SetBaseDir(filepath.Dir(cfgFile))
+	cfg.configFile = cfgFile
 	return nil
 }
 
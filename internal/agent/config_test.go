@@ -3,7 +3,10 @@ package agent
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -63,3 +66,163 @@ func TestParseConfigFile_NoFile(t *testing.T) {
 	// Expect an error because the file does not exist
 	require.Error(err)
 }
+
+func TestCompleteAppliesMigration(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	filePath := tmpDir + "/config.yaml"
+	require.NoError(os.WriteFile(filePath, []byte(yamlConfig), 0600))
+	cfg := NewDefault()
+	require.NoError(cfg.ParseConfigFile(filePath))
+	cfg.DataDir = tmpDir
+	cfg.GrpcManagementEndpoint = "https://management.endpoint:7444"
+
+	// without a migration the config file applies
+	require.NoError(cfg.Complete())
+	require.Equal("https://management.endpoint", cfg.ManagementService.Service.Server)
+	clientCertificate := cfg.ManagementService.AuthInfo.ClientCertificateData
+
+	migration := `{"managementEndpoint":"https://target.endpoint:7443","caBundle":"target CA"}`
+	require.NoError(os.WriteFile(tmpDir+"/migration.json", []byte(migration), 0600))
+	require.NoError(cfg.Complete())
+	require.Equal("https://target.endpoint:7443", cfg.ManagementService.Service.Server)
+	require.Equal([]byte("target CA"), cfg.ManagementService.Service.CertificateAuthorityData)
+	require.Equal("", cfg.GrpcManagementEndpoint)
+	// the client certificate is kept
+	require.Equal(clientCertificate, cfg.ManagementService.AuthInfo.ClientCertificateData)
+}
+
+func TestMetricsValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := Metrics{ScrapeTargets: []string{"http://localhost:9100/metrics", "https://127.0.0.1:8443/metrics", "http://[::1]:9090/metrics"}}
+	require.NoError(valid.Validate())
+
+	for _, target := range []string{"http://exporter.example.com:9100/metrics", "ftp://localhost/metrics", "http://10.0.0.1:9100/metrics"} {
+		invalid := Metrics{ScrapeTargets: []string{target}}
+		require.Error(invalid.Validate(), target)
+	}
+}
+
+func TestGeolocationValidate(t *testing.T) {
+	require := require.New(t)
+	interval := util.Duration(time.Minute)
+
+	valid := []Geolocation{
+		{},
+		{Source: "static", Latitude: lo.ToPtr(48.8584), Longitude: lo.ToPtr(-2.2945), Interval: interval},
+		{Source: "gps", GPSDevice: "/dev/ttyACM0", Interval: interval},
+		{Source: "ip", IPLookupURL: "https://ipinfo.io/json", Interval: interval},
+	}
+	for _, g := range valid {
+		require.NoError(g.Validate(), g.Source)
+	}
+
+	invalid := []Geolocation{
+		{Source: "wifi", Interval: interval},
+		{Source: "static", Latitude: lo.ToPtr(48.8584), Interval: interval},
+		{Source: "static", Latitude: lo.ToPtr(91.0), Longitude: lo.ToPtr(0.0), Interval: interval},
+		{Source: "static", Latitude: lo.ToPtr(0.0), Longitude: lo.ToPtr(-181.0), Interval: interval},
+		{Source: "gps", GPSDevice: "ttyACM0", Interval: interval},
+		{Source: "ip", IPLookupURL: "ipinfo.io/json", Interval: interval},
+		{Source: "gps", GPSDevice: "/dev/ttyACM0"},
+	}
+	for _, g := range invalid {
+		require.Error(g.Validate(), g)
+	}
+}
+
+func TestMonitorThresholdsValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := []MonitorThresholds{
+		{},
+		{CPU: ResourceThresholds{WarningPercentage: lo.ToPtr(70.0), CriticalPercentage: lo.ToPtr(85.0)}},
+		{Disk: ResourceThresholds{CriticalPercentage: lo.ToPtr(95.0)}},
+	}
+	for _, m := range valid {
+		require.NoError(m.Validate(), m)
+	}
+
+	invalid := []MonitorThresholds{
+		{CPU: ResourceThresholds{WarningPercentage: lo.ToPtr(-1.0)}},
+		{Memory: ResourceThresholds{CriticalPercentage: lo.ToPtr(101.0)}},
+		{Disk: ResourceThresholds{WarningPercentage: lo.ToPtr(90.0), CriticalPercentage: lo.ToPtr(80.0)}},
+	}
+	for _, m := range invalid {
+		require.Error(m.Validate(), m)
+	}
+}
+
+func TestStatusSinkValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := []StatusSink{
+		{Type: "file", Path: "/run/flightctl/status.json"},
+		{Type: "mqtt", MQTTBroker: MQTTBroker{Broker: "tcp://broker.local:1883"}},
+		{Type: "mqtt", MQTTBroker: MQTTBroker{Broker: "ssl://broker.local", PasswordFile: "/etc/flightctl/mqtt-password", CertificateAuthority: "/etc/flightctl/certs/mqtt-ca.crt"}},
+	}
+	for _, s := range valid {
+		require.NoError(s.Validate(), s)
+	}
+
+	invalid := []StatusSink{
+		{Type: "syslog"},
+		{Type: "file", Path: "status.json"},
+		{Type: "mqtt"},
+		{Type: "mqtt", MQTTBroker: MQTTBroker{Broker: "https://broker.local"}},
+		{Type: "mqtt", MQTTBroker: MQTTBroker{Broker: "tcp://broker.local", PasswordFile: "mqtt-password"}},
+	}
+	for _, s := range invalid {
+		require.Error(s.Validate(), s)
+	}
+}
+
+func TestParseConfigFileSpecNotifications(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	configFile := tmpDir + "/config.yaml"
+	contents := `spec-notifications:
+  broker: ssl://broker.local:8883
+  username: device
+  topic-prefix: site-a/devices
+status-sinks:
+- type: mqtt
+  broker: tcp://broker.local
+  topic: site-a/devices/{device}/status
+`
+	require.NoError(os.WriteFile(configFile, []byte(contents), 0600))
+
+	cfg := NewDefault()
+	require.NoError(cfg.ParseConfigFile(configFile))
+	require.NotNil(cfg.SpecNotifications)
+	require.Equal("ssl://broker.local:8883", cfg.SpecNotifications.Broker)
+	require.Equal("device", cfg.SpecNotifications.Username)
+	require.Equal("site-a/devices/device-1/spec", cfg.SpecNotifications.Topic("device-1"))
+	require.Len(cfg.StatusSinks, 1)
+	require.Equal("tcp://broker.local", cfg.StatusSinks[0].Broker)
+}
+
+func TestNudgeSourceValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := []NudgeSource{
+		{Type: "exec", Command: []string{"/usr/libexec/lora-wait", "--port", "1"}},
+		{Type: "serial", Device: "/dev/ttyUSB2", Pattern: "RING", MinInterval: util.Duration(time.Minute)},
+	}
+	for _, n := range valid {
+		require.NoError(n.Validate(), n)
+	}
+
+	invalid := []NudgeSource{
+		{Type: "sms"},
+		{Type: "exec"},
+		{Type: "exec", Command: []string{"lora-wait"}},
+		{Type: "serial", Device: "ttyUSB2"},
+		{Type: "serial", Device: "/dev/ttyUSB2", MinInterval: util.Duration(-time.Minute)},
+	}
+	for _, n := range invalid {
+		require.Error(n.Validate(), n)
+	}
+}
@@ -0,0 +1,295 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	// actionStateFile records the last action the agent received, relative to the data dir
+	actionStateFile = "action.json"
+
+	actionCommandTimeout = time.Minute
+)
+
+// ActionController carries out the actions requested for the device, such as
+// a reboot or waking another device of the site. An action is acknowledged to
+// the service before it is carried out, and recorded in the data dir so that
+// it is carried out once even though the service serves it until it receives
+// the acknowledgement.
+type ActionController struct {
+	dataDir       string
+	exec          executer.Executer
+	readWriter    fileio.ReadWriter
+	statusManager status.Manager
+	// last is the last action the agent received, nil until it is restored
+	// from the data dir
+	last *v1alpha1.DeviceActionStatus
+	// sendMagicPacket sends a Wake-on-LAN packet to the hardware address
+	sendMagicPacket func(macAddress string) error
+	// enforceSpec applies the spec held back after adopting the existing
+	// state of the device
+	enforceSpec func(ctx context.Context) (string, error)
+	log         *log.PrefixLogger
+}
+
+func NewActionController(
+	dataDir string,
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	statusManager status.Manager,
+	log *log.PrefixLogger,
+) *ActionController {
+	return &ActionController{
+		dataDir:         dataDir,
+		exec:            exec,
+		readWriter:      readWriter,
+		statusManager:   statusManager,
+		sendMagicPacket: broadcastMagicPacket,
+		log:             log,
+	}
+}
+
+// SetSpecEnforcer sets the function carrying out the EnforceSpec action.
+func (c *ActionController) SetSpecEnforcer(enforceSpec func(ctx context.Context) (string, error)) {
+	c.enforceSpec = enforceSpec
+}
+
+// Sync carries out the action of the desired spec unless it was received
+// before, and reports the outcome of the last action.
+func (c *ActionController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing device action")
+	defer c.log.Debug("Finished syncing device action")
+
+	if c.last == nil {
+		if err := c.restore(ctx); err != nil {
+			return err
+		}
+	}
+	if desired.Action == nil || desired.Action.Id == c.last.Id {
+		return nil
+	}
+	return c.run(ctx, *desired.Action)
+}
+
+// restore reports the last action recorded in the data dir. An acknowledged
+// action which restarts the agent was carried out, while the agent stopped
+// in the middle of any other one.
+func (c *ActionController) restore(ctx context.Context) error {
+	last, err := c.readState()
+	if err != nil {
+		return err
+	}
+	if last == nil {
+		c.last = &v1alpha1.DeviceActionStatus{}
+		return nil
+	}
+	if last.State == v1alpha1.DeviceActionAcknowledged {
+		if restartsAgent(last.Action) {
+			c.log.Infof("Completed %s action %s", last.Action, last.Id)
+			last.State = v1alpha1.DeviceActionCompleted
+		} else {
+			c.log.Warnf("Failed %s action %s: the agent stopped while carrying it out", last.Action, last.Id)
+			last.State = v1alpha1.DeviceActionFailed
+			last.Message = lo.ToPtr("the agent stopped while carrying out the action")
+		}
+		last.UpdatedAt = time.Now().UTC()
+		if err := c.writeState(last); err != nil {
+			return err
+		}
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetLastAction(*last)); err != nil {
+		return err
+	}
+	c.last = last
+	return nil
+}
+
+func (c *ActionController) run(ctx context.Context, action v1alpha1.DeviceAction) error {
+	c.log.Warnf("Carrying out %s action %s requested for the device: %s", action.Action, action.Id, lo.FromPtr(action.Reason))
+	acknowledged := v1alpha1.DeviceActionStatus{
+		Id:        action.Id,
+		Action:    action.Action,
+		State:     v1alpha1.DeviceActionAcknowledged,
+		UpdatedAt: time.Now().UTC(),
+	}
+	updateFns := []status.UpdateStatusFn{status.SetLastAction(acknowledged)}
+	switch action.Action {
+	case v1alpha1.DeviceActionReboot:
+		updateFns = append(updateFns, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
+			Status: v1alpha1.DeviceSummaryStatusRebooting,
+			Info:   lo.ToPtr("Device is rebooting on request"),
+		}))
+	case v1alpha1.DeviceActionShutdown:
+		updateFns = append(updateFns, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
+			Status: v1alpha1.DeviceSummaryStatusPoweredOff,
+			Info:   lo.ToPtr("Device is shutting down on request"),
+		}))
+	}
+	// the action is only carried out once the service knows about it, so
+	// that it is not carried out again after the restart
+	if _, err := c.statusManager.Update(ctx, updateFns...); err != nil {
+		return fmt.Errorf("acknowledging %s action %s: %w", action.Action, action.Id, err)
+	}
+	if err := c.writeState(&acknowledged); err != nil {
+		return err
+	}
+	c.last = &acknowledged
+
+	message, err := c.execute(ctx, action)
+	if err != nil {
+		failed := acknowledged
+		failed.State = v1alpha1.DeviceActionFailed
+		failed.Message = lo.ToPtr(err.Error())
+		failed.UpdatedAt = time.Now().UTC()
+		c.last = &failed
+		if err := c.writeState(&failed); err != nil {
+			return err
+		}
+		if _, err := c.statusManager.Update(ctx, status.SetLastAction(failed)); err != nil {
+			c.log.Errorf("Failed to report the failure of %s action %s: %v", action.Action, action.Id, err)
+		}
+		return err
+	}
+	if restartsAgent(action.Action) {
+		// completed once the agent runs again
+		return nil
+	}
+
+	completed := acknowledged
+	completed.State = v1alpha1.DeviceActionCompleted
+	completed.Message = lo.ToPtr(message)
+	completed.UpdatedAt = time.Now().UTC()
+	c.last = &completed
+	if err := c.writeState(&completed); err != nil {
+		return err
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetLastAction(completed)); err != nil {
+		return fmt.Errorf("reporting %s action %s: %w", action.Action, action.Id, err)
+	}
+	return nil
+}
+
+// execute carries out the action and returns its outcome, if the agent
+// reports it before a restart.
+func (c *ActionController) execute(ctx context.Context, action v1alpha1.DeviceAction) (string, error) {
+	switch action.Action {
+	case v1alpha1.DeviceActionWakeOnLan:
+		return c.wakeOnLan(action.WakeOnLan)
+	case v1alpha1.DeviceActionExec:
+		return c.runCommand(ctx, action.Exec)
+	case v1alpha1.DeviceActionEnforceSpec:
+		if c.enforceSpec == nil {
+			return "", fmt.Errorf("the agent holds back no spec")
+		}
+		return c.enforceSpec(ctx)
+	}
+
+	var args []string
+	switch action.Action {
+	case v1alpha1.DeviceActionReboot:
+		args = []string{"--no-block", "reboot"}
+	case v1alpha1.DeviceActionShutdown:
+		args = []string{"--no-block", "poweroff"}
+	case v1alpha1.DeviceActionRestartAgent:
+		args = []string{"--no-block", "restart", agentServiceName}
+	default:
+		return "", fmt.Errorf("unsupported action %q", action.Action)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, actionCommandTimeout)
+	defer cancel()
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, args...)
+	if exitCode != 0 {
+		return "", fmt.Errorf("failed to %s: exit code %d: %s", action.Action, exitCode, stderr)
+	}
+	return "", nil
+}
+
+// wakeOnLan sends a Wake-on-LAN packet to each network interface of the
+// target, which succeeds if any of them could be sent. Whether the target
+// wakes up is only known once its agent reports.
+func (c *ActionController) wakeOnLan(wake *v1alpha1.DeviceWakeOnLan) (string, error) {
+	if wake == nil || len(wake.MacAddresses) == 0 {
+		return "", fmt.Errorf("no device to wake")
+	}
+	var sent []string
+	var errs []error
+	for _, mac := range wake.MacAddresses {
+		if err := c.sendMagicPacket(mac); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent = append(sent, mac)
+	}
+	if len(sent) == 0 {
+		return "", fmt.Errorf("failed to wake %s: %w", wake.Target, errors.Join(errs...))
+	}
+	c.log.Infof("Sent Wake-on-LAN packets to %s at %s", wake.Target, strings.Join(sent, ", "))
+	return fmt.Sprintf("sent Wake-on-LAN packets to %s at %s", wake.Target, strings.Join(sent, ", ")), nil
+}
+
+// runCommand runs the command of an Exec action without a shell, and returns
+// its exit code and the end of its output, which the service records in its
+// audit log. A command exiting non-zero fails the action.
+func (c *ActionController) runCommand(ctx context.Context, command *v1alpha1.DeviceExec) (string, error) {
+	if command == nil || !filepath.IsAbs(command.Command) {
+		return "", fmt.Errorf("no command to run")
+	}
+	ctx, cancel := context.WithTimeout(ctx, actionCommandTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, command.Command, lo.FromPtr(command.Args)...)
+	c.log.Infof("Ran %s: exit code %d", command.Command, exitCode)
+	if exitCode != 0 {
+		return "", fmt.Errorf("exit code %d: %s", exitCode, hook.TruncateOutput(strings.TrimSpace(stderr+"\n"+stdout)))
+	}
+	return fmt.Sprintf("exit code 0: %s", hook.TruncateOutput(strings.TrimSpace(stdout))), nil
+}
+
+// restartsAgent returns whether the agent stops to carry out the action.
+func restartsAgent(action v1alpha1.DeviceActionType) bool {
+	return action != v1alpha1.DeviceActionWakeOnLan && action != v1alpha1.DeviceActionExec && action != v1alpha1.DeviceActionEnforceSpec
+}
+
+func (c *ActionController) statePath() string {
+	return filepath.Join(c.dataDir, actionStateFile)
+}
+
+// readState returns the last action recorded in the data dir, or nil if the
+// agent never received one.
+func (c *ActionController) readState() (*v1alpha1.DeviceActionStatus, error) {
+	exists, err := c.readWriter.FileExists(c.statePath())
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := c.readWriter.ReadFile(c.statePath())
+	if err != nil {
+		return nil, err
+	}
+	var state v1alpha1.DeviceActionStatus
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("reading device action state: %w", err)
+	}
+	return &state, nil
+}
+
+func (c *ActionController) writeState(state *v1alpha1.DeviceActionStatus) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.readWriter.WriteFile(c.statePath(), content, 0600)
+}
@@ -0,0 +1,180 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestActionIsAcknowledgedAndCarriedOutOnce(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusMock := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+
+	deviceStatus := v1alpha1.NewDeviceStatus()
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, updateFuncs ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+			for _, update := range updateFuncs {
+				require.NoError(update(&deviceStatus))
+			}
+			return &deviceStatus, nil
+		}).AnyTimes()
+
+	c := NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	withAction := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Action: &v1alpha1.DeviceAction{Id: "42", Action: v1alpha1.DeviceActionReboot}}
+
+	// the reboot is acknowledged before it is carried out
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "reboot").DoAndReturn(
+		func(ctx context.Context, command string, args ...string) (string, string, int) {
+			require.Equal(v1alpha1.DeviceActionAcknowledged, deviceStatus.LastAction.State)
+			require.Equal(v1alpha1.DeviceSummaryStatusRebooting, deviceStatus.Summary.Status)
+			return "", "", 0
+		})
+	require.NoError(c.Sync(ctx, withAction))
+	// the action served again until the service receives the acknowledgement is ignored
+	require.NoError(c.Sync(ctx, withAction))
+
+	// the agent which runs after the reboot reports the action completed
+	deviceStatus = v1alpha1.NewDeviceStatus()
+	c = NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	require.NoError(c.Sync(ctx, withAction))
+	require.Equal("42", deviceStatus.LastAction.Id)
+	require.Equal(v1alpha1.DeviceActionCompleted, deviceStatus.LastAction.State)
+}
+
+func TestActionFailure(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusMock := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+	c := NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	withAction := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Action: &v1alpha1.DeviceAction{Id: "42", Action: v1alpha1.DeviceActionShutdown}}
+
+	// an action which cannot be acknowledged is not carried out
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("unreachable"))
+	require.Error(c.Sync(ctx, withAction))
+
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "poweroff").Return("", "access denied", 1)
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, updateFuncs ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+			deviceStatus := v1alpha1.NewDeviceStatus()
+			require.NoError(updateFuncs[0](&deviceStatus))
+			require.Equal(v1alpha1.DeviceActionFailed, deviceStatus.LastAction.State)
+			require.Contains(*deviceStatus.LastAction.Message, "access denied")
+			return &deviceStatus, nil
+		})
+	require.ErrorContains(c.Sync(ctx, withAction), "access denied")
+	// a failed action is not retried
+	require.NoError(c.Sync(ctx, withAction))
+}
+
+func TestWakeOnLanAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusMock := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+
+	deviceStatus := v1alpha1.NewDeviceStatus()
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, updateFuncs ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+			for _, update := range updateFuncs {
+				require.NoError(update(&deviceStatus))
+			}
+			return &deviceStatus, nil
+		}).AnyTimes()
+
+	c := NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	var sent []string
+	c.sendMagicPacket = func(macAddress string) error {
+		if macAddress == "52:54:00:00:00:02" {
+			return errors.New("network is unreachable")
+		}
+		sent = append(sent, macAddress)
+		return nil
+	}
+	withAction := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Action: &v1alpha1.DeviceAction{
+		Id:     "42",
+		Action: v1alpha1.DeviceActionWakeOnLan,
+		WakeOnLan: &v1alpha1.DeviceWakeOnLan{
+			Target:       "kiosk-2",
+			MacAddresses: []string{"52:54:00:00:00:01", "52:54:00:00:00:02"},
+		},
+	}}
+
+	// the packets are sent and the outcome reported without a restart
+	require.NoError(c.Sync(ctx, withAction))
+	require.Equal([]string{"52:54:00:00:00:01"}, sent)
+	require.Equal(v1alpha1.DeviceActionCompleted, deviceStatus.LastAction.State)
+	require.Contains(*deviceStatus.LastAction.Message, "kiosk-2")
+	require.Equal(v1alpha1.DeviceSummaryStatusUnknown, deviceStatus.Summary.Status)
+	require.NoError(c.Sync(ctx, withAction))
+	require.Len(sent, 1)
+
+	// the agent restarted in the middle of the action reports it failed
+	require.NoError(c.writeState(&v1alpha1.DeviceActionStatus{Id: "43", Action: v1alpha1.DeviceActionWakeOnLan, State: v1alpha1.DeviceActionAcknowledged}))
+	c = NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	require.NoError(c.Sync(ctx, &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}))
+	require.Equal("43", deviceStatus.LastAction.Id)
+	require.Equal(v1alpha1.DeviceActionFailed, deviceStatus.LastAction.State)
+}
+
+func TestExecAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusMock := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+
+	deviceStatus := v1alpha1.NewDeviceStatus()
+	statusMock.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, updateFuncs ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+			for _, update := range updateFuncs {
+				require.NoError(update(&deviceStatus))
+			}
+			return &deviceStatus, nil
+		}).AnyTimes()
+
+	c := NewActionController("/var/lib/flightctl", execMock, readWriter, statusMock, flightlog.NewPrefixLogger(""))
+	exec := func(id string) *v1alpha1.RenderedDeviceSpec {
+		return &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Action: &v1alpha1.DeviceAction{
+			Id:     id,
+			Action: v1alpha1.DeviceActionExec,
+			Exec:   &v1alpha1.DeviceExec{Command: "/usr/bin/systemctl", Args: &[]string{"status", "nginx"}},
+		}}
+	}
+
+	// the output of the command is reported without a restart
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/systemctl", "status", "nginx").Return("nginx.service - active (running)\n", "", 0)
+	require.NoError(c.Sync(ctx, exec("42")))
+	require.Equal(v1alpha1.DeviceActionCompleted, deviceStatus.LastAction.State)
+	require.Equal("exit code 0: nginx.service - active (running)", *deviceStatus.LastAction.Message)
+	require.Equal(v1alpha1.DeviceSummaryStatusUnknown, deviceStatus.Summary.Status)
+	require.NoError(c.Sync(ctx, exec("42")))
+
+	// a command exiting non-zero fails the action
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/systemctl", "status", "nginx").Return("", "Unit nginx.service could not be found.", 4)
+	require.ErrorContains(c.Sync(ctx, exec("43")), "exit code 4")
+	require.Equal(v1alpha1.DeviceActionFailed, deviceStatus.LastAction.State)
+	require.Contains(*deviceStatus.LastAction.Message, "could not be found")
+}
@@ -0,0 +1,216 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	// adoptionStateFile records the inventory of the existing state of the
+	// device, relative to the data dir
+	adoptionStateFile = "adoption.json"
+
+	// composeProjectLabel labels the containers brought up by podman-compose
+	// with their compose project
+	composeProjectLabel = "com.docker.compose.project"
+
+	adoptionCommandTimeout = time.Minute
+)
+
+// AdoptionController lets a device enrolled with containers and config
+// already on it, such as a brownfield device, be reviewed before its first
+// rendered spec is applied. It takes an inventory of the containers of the
+// device and of the files of the spec which already exist with different
+// content, reports it in status.adoption and holds back the spec until an
+// EnforceSpec action is requested for the device.
+//
+// The inventory is kept in the data dir so that the spec stays held back, or
+// enforced, across restarts of the agent. A device which applied a spec
+// before the agent was configured to adopt its state is not held back.
+type AdoptionController struct {
+	enabled       bool
+	dataDir       string
+	exec          executer.Executer
+	readWriter    fileio.ReadWriter
+	statusManager status.Manager
+	// reported is the inventory last reported in the device status
+	reported *v1alpha1.DeviceAdoptionStatus
+	log      *log.PrefixLogger
+}
+
+func NewAdoptionController(
+	enabled bool,
+	dataDir string,
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	statusManager status.Manager,
+	log *log.PrefixLogger,
+) *AdoptionController {
+	return &AdoptionController{
+		enabled:       enabled,
+		dataDir:       dataDir,
+		exec:          exec,
+		readWriter:    readWriter,
+		statusManager: statusManager,
+		log:           log,
+	}
+}
+
+// Sync returns whether the desired spec is held back until it is enforced.
+// The inventory is taken again whenever the rendered version changes while
+// the spec is held back, since what the spec replaces changes with it.
+func (c *AdoptionController) Sync(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) (bool, error) {
+	if !c.enabled {
+		return false, nil
+	}
+	c.log.Debug("Syncing adoption of the existing state")
+	defer c.log.Debug("Finished syncing adoption of the existing state")
+
+	state, err := c.readState()
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case state == nil && current.RenderedVersion != "":
+		return false, nil
+	case state != nil && state.EnforcedAt != nil:
+		c.report(ctx, state)
+		return false, nil
+	case state == nil || state.RenderedVersion != desired.RenderedVersion:
+		if state, err = c.inventory(ctx, desired); err != nil {
+			return false, err
+		}
+		if err := c.writeState(state); err != nil {
+			return false, err
+		}
+		c.log.Warnf("Holding back renderedVersion %s until it is enforced: %d unmanaged containers, %d files overwritten",
+			desired.RenderedVersion, len(state.UnmanagedContainers), len(state.OverwrittenFiles))
+	}
+	c.report(ctx, state)
+	return true, nil
+}
+
+// Enforce lets the agent apply the spec it holds back, once the operator
+// reviewed the inventory. It is carried out by the EnforceSpec action.
+func (c *AdoptionController) Enforce(ctx context.Context) (string, error) {
+	state, err := c.readState()
+	if err != nil {
+		return "", err
+	}
+	if state == nil || state.EnforcedAt != nil {
+		return "", fmt.Errorf("the agent holds back no spec")
+	}
+	state.EnforcedAt = lo.ToPtr(time.Now().UTC())
+	if err := c.writeState(state); err != nil {
+		return "", err
+	}
+	c.log.Infof("Enforcing renderedVersion %s over the existing state of the device", state.RenderedVersion)
+	c.report(ctx, state)
+	return fmt.Sprintf("enforcing renderedVersion %s", state.RenderedVersion), nil
+}
+
+// inventory lists the containers of the device, none of which the agent
+// manages before it applied a spec, and the files of the config of the spec
+// which exist with different content.
+func (c *AdoptionController) inventory(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) (*v1alpha1.DeviceAdoptionStatus, error) {
+	containers, err := c.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	files, err := outdatedFiles(c.readWriter, desired, false)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.DeviceAdoptionStatus{
+		InventoriedAt:       time.Now().UTC(),
+		RenderedVersion:     desired.RenderedVersion,
+		UnmanagedContainers: containers,
+		OverwrittenFiles:    files,
+	}, nil
+}
+
+func (c *AdoptionController) containers(ctx context.Context) ([]v1alpha1.DeviceUnmanagedContainer, error) {
+	ctx, cancel := context.WithTimeout(ctx, adoptionCommandTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "ps", "--all", "--format", "json")
+	if exitCode != 0 {
+		return nil, fmt.Errorf("failed listing podman containers with code %d: %s", exitCode, stderr)
+	}
+	var list []struct {
+		Names  []string          `json:"Names"`
+		Image  string            `json:"Image"`
+		State  string            `json:"State"`
+		Labels map[string]string `json:"Labels"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &list); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling podman containers: %w", err)
+	}
+	containers := []v1alpha1.DeviceUnmanagedContainer{}
+	for _, entry := range list {
+		if len(entry.Names) == 0 {
+			continue
+		}
+		container := v1alpha1.DeviceUnmanagedContainer{Name: entry.Names[0], Image: entry.Image, State: entry.State}
+		if project, ok := entry.Labels[composeProjectLabel]; ok {
+			container.Project = &project
+		}
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// report sets the inventory in the device status unless it was reported
+// already. A failure is logged, and the inventory reported on the next sync.
+func (c *AdoptionController) report(ctx context.Context, state *v1alpha1.DeviceAdoptionStatus) {
+	if reflect.DeepEqual(c.reported, state) {
+		return
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetAdoption(*state)); err != nil {
+		c.log.Warnf("Failed setting adoption status: %v", err)
+		return
+	}
+	c.reported = state
+}
+
+func (c *AdoptionController) statePath() string {
+	return filepath.Join(c.dataDir, adoptionStateFile)
+}
+
+// readState returns the inventory recorded in the data dir, or nil if the
+// agent never took one.
+func (c *AdoptionController) readState() (*v1alpha1.DeviceAdoptionStatus, error) {
+	exists, err := c.readWriter.FileExists(c.statePath())
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := c.readWriter.ReadFile(c.statePath())
+	if err != nil {
+		return nil, err
+	}
+	var state v1alpha1.DeviceAdoptionStatus
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("reading adoption state: %w", err)
+	}
+	return &state, nil
+}
+
+func (c *AdoptionController) writeState(state *v1alpha1.DeviceAdoptionStatus) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.readWriter.WriteFile(c.statePath(), content, 0600)
+}
@@ -0,0 +1,83 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// adoptionConfig writes /etc/app/settings.conf and /etc/app/new.conf, whose
+// contents are "managed".
+const adoptionConfig = `{"ignition":{"version":"3.4.0"},"storage":{"files":[` +
+	`{"path":"/etc/app/settings.conf","contents":{"source":"data:,managed"},"mode":420},` +
+	`{"path":"/etc/app/new.conf","contents":{"source":"data:,managed"},"mode":420}]}}`
+
+func TestAdoptionHoldsBackTheFirstSpec(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	require.NoError(readWriter.WriteFile("/etc/app/settings.conf", []byte("hand-edited"), 0644))
+	ctx := context.Background()
+
+	first := &v1alpha1.RenderedDeviceSpec{}
+	desired := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Config: lo.ToPtr(adoptionConfig)}
+
+	// the spec is applied right away unless the agent adopts the state
+	c := NewAdoptionController(false, "/var/lib/flightctl", execMock, readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	held, err := c.Sync(ctx, first, desired)
+	require.NoError(err)
+	require.False(held)
+
+	// the inventory is reported once and the spec held back until enforced
+	c = NewAdoptionController(true, "/var/lib/flightctl", execMock, readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "ps", "--all", "--format", "json").
+		Return(`[{"Names":["web"],"Image":"quay.io/acme/web:1","State":"running","Labels":{"com.docker.compose.project":"shop"}},`+
+			`{"Names":["backup"],"Image":"quay.io/acme/backup:2","State":"exited"}]`, "", 0)
+	var reported v1alpha1.DeviceStatus
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fns ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+		for _, fn := range fns {
+			require.NoError(fn(&reported))
+		}
+		return &reported, nil
+	}).Times(2)
+	for i := 0; i < 2; i++ {
+		held, err = c.Sync(ctx, first, desired)
+		require.NoError(err)
+		require.True(held)
+	}
+	require.Equal("1", reported.Adoption.RenderedVersion)
+	require.Equal([]v1alpha1.DeviceUnmanagedContainer{
+		{Name: "backup", Image: "quay.io/acme/backup:2", State: "exited"},
+		{Name: "web", Image: "quay.io/acme/web:1", State: "running", Project: lo.ToPtr("shop")},
+	}, reported.Adoption.UnmanagedContainers)
+	require.Equal([]string{"/etc/app/settings.conf"}, reported.Adoption.OverwrittenFiles)
+	require.Nil(reported.Adoption.EnforcedAt)
+
+	// the spec is applied once enforced, also after a restart of the agent
+	message, err := c.Enforce(ctx)
+	require.NoError(err)
+	require.Equal("enforcing renderedVersion 1", message)
+	require.NotNil(reported.Adoption.EnforcedAt)
+	held, err = c.Sync(ctx, first, desired)
+	require.NoError(err)
+	require.False(held)
+	_, err = c.Enforce(ctx)
+	require.Error(err)
+
+	// a device which applied a spec already is not held back
+	c = NewAdoptionController(true, "/var/lib/flightctl-managed", execMock, readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	held, err = c.Sync(ctx, desired, &v1alpha1.RenderedDeviceSpec{RenderedVersion: "2"})
+	require.NoError(err)
+	require.False(held)
+}
@@ -0,0 +1,519 @@
+package device
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/samber/lo"
+)
+
+const (
+	// agentUpdateStateFile records the progress of an agent update, relative to the data dir
+	agentUpdateStateFile = "agent-update.json"
+	// agentBinariesDir holds the staged agent binaries by version, relative to the data dir
+	agentBinariesDir = "agent"
+	agentBinaryName  = "flightctl-agent"
+	// osImageAgentPath is the agent of the OS image, which is also the path of the binary in agent images
+	osImageAgentPath       = "/usr/bin/flightctl-agent"
+	agentServiceName       = "flightctl-agent.service"
+	agentServiceDropInPath = "/etc/systemd/system/flightctl-agent.service.d/50-agent-update.conf"
+
+	// agentMaxStartAttempts is the number of times systemd starts an updated agent before rolling it back
+	agentMaxStartAttempts = 3
+	// agentActivationTimeout is the time an updated agent has to reach the service before it is rolled back
+	agentActivationTimeout = 10 * time.Minute
+	agentDownloadTimeout   = 10 * time.Minute
+	agentCommandTimeout    = 2 * time.Minute
+
+	podmanCommand    = "/usr/bin/podman"
+	systemctlCommand = "/usr/bin/systemctl"
+)
+
+// agentUpdateState is kept in the data dir so that the updated agent, and the
+// pre-start check run by the agent of the OS image, can pick up an update.
+type agentUpdateState struct {
+	// Version and Path of the agent systemd starts, empty for the agent of the OS image
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path,omitempty"`
+	// PreviousVersion and PreviousPath of the agent restored when the update fails
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	PreviousPath    string `json:"previousPath,omitempty"`
+	// UpdateVersion is the version of the update the state refers to
+	UpdateVersion string                          `json:"updateVersion"`
+	State         v1alpha1.DeviceAgentUpdateState `json:"state"`
+	Message       string                          `json:"message,omitempty"`
+	ActivatedAt   time.Time                       `json:"activatedAt"`
+	StartAttempts int                             `json:"startAttempts"`
+}
+
+// AgentUpdateController updates the agent binary independently of the OS
+// image. The updated agent is staged in the data dir and started by systemd
+// through a drop-in of the agent service. It is activated once it fetched the
+// desired spec, and the previous agent is restored if it fails to start or to
+// reach the service within agentActivationTimeout.
+type AgentUpdateController struct {
+	dataDir       string
+	readWriter    fileio.ReadWriter
+	exec          executer.Executer
+	systemd       *client.Systemd
+	statusManager status.Manager
+	httpClient    *http.Client
+	// version of the running agent
+	version  string
+	reported *v1alpha1.DeviceAgentStatus
+	// serializes the state changes of Sync and Run
+	mu  sync.Mutex
+	log *log.PrefixLogger
+}
+
+func NewAgentUpdateController(
+	dataDir string,
+	readWriter fileio.ReadWriter,
+	exec executer.Executer,
+	statusManager status.Manager,
+	log *log.PrefixLogger,
+) *AgentUpdateController {
+	return &AgentUpdateController{
+		dataDir:       dataDir,
+		readWriter:    readWriter,
+		exec:          exec,
+		systemd:       client.NewSystemd(exec),
+		statusManager: statusManager,
+		httpClient: &http.Client{
+			Transport: &http.Transport{Proxy: client.Proxy},
+			Timeout:   agentDownloadTimeout,
+		},
+		version: version.Get().GitVersion,
+		log:     log,
+	}
+}
+
+// Run rolls the update back if the running agent is an update which has not
+// been activated within agentActivationTimeout.
+func (c *AgentUpdateController) Run(ctx context.Context) {
+	state, err := readAgentUpdateState(c.readWriter, c.dataDir)
+	if err != nil {
+		c.log.Errorf("Failed to read agent update state: %v", err)
+		return
+	}
+	if !c.isActivating(state) {
+		return
+	}
+
+	timer := time.NewTimer(time.Until(state.ActivatedAt.Add(agentActivationTimeout)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, err = readAgentUpdateState(c.readWriter, c.dataDir)
+	if err != nil {
+		c.log.Errorf("Failed to read agent update state: %v", err)
+		return
+	}
+	if !c.isActivating(state) {
+		return
+	}
+	message := fmt.Sprintf("Agent %s did not reach the service within %s", c.version, agentActivationTimeout)
+	c.log.Errorf("%s, rolling back", message)
+	if err := rollbackAgentUpdate(ctx, c.readWriter, c.systemd, c.dataDir, state, message); err != nil {
+		c.log.Errorf("Failed to roll back agent update: %v", err)
+		return
+	}
+	if err := c.restartAgent(ctx); err != nil {
+		c.log.Errorf("Failed to restart agent: %v", err)
+	}
+}
+
+func (c *AgentUpdateController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing agent update")
+	defer c.log.Debug("Finished syncing agent update")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, err := readAgentUpdateState(c.readWriter, c.dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read agent update state: %w", err)
+	}
+
+	err = c.ensureAgent(ctx, desired, state)
+	if err != nil {
+		err = fmt.Errorf("failed to update agent: %w", err)
+	}
+
+	// report the state after the sync, including a failed one
+	state, stateErr := readAgentUpdateState(c.readWriter, c.dataDir)
+	if stateErr != nil {
+		c.log.Warnf("Failed to read agent update state: %v", stateErr)
+	}
+	c.updateStatus(ctx, state)
+	return err
+}
+
+func (c *AgentUpdateController) ensureAgent(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec, state *agentUpdateState) error {
+	if state != nil && state.State == v1alpha1.DeviceAgentUpdateActivating {
+		if state.Version != c.version {
+			c.log.Debugf("Restart into agent %s is pending", state.Version)
+			return nil
+		}
+		// the updated agent fetched the desired spec, so it is healthy
+		c.log.Infof("Agent %s is healthy, activating the update", c.version)
+		state.State = v1alpha1.DeviceAgentUpdateActive
+		state.Message = ""
+		state.StartAttempts = 0
+		if err := writeAgentUpdateState(c.readWriter, c.dataDir, state); err != nil {
+			return err
+		}
+		c.pruneBinaries(state.Version, state.PreviousVersion)
+	}
+
+	var update *v1alpha1.AgentUpdateSpec
+	if desired.Agent != nil {
+		update = desired.Agent.Update
+	}
+
+	if update == nil {
+		if state == nil {
+			return nil
+		}
+		return c.revertToOSImageAgent(ctx, state)
+	}
+
+	if update.Version == c.version {
+		c.log.Debugf("Agent is running the desired version %s", c.version)
+		return nil
+	}
+	if state != nil && state.State == v1alpha1.DeviceAgentUpdateRolledBack && state.UpdateVersion == update.Version {
+		// a failed update is not retried until the device spec requests another version
+		c.log.Debugf("Agent update to %s was rolled back", update.Version)
+		return nil
+	}
+
+	return c.activate(ctx, update, state)
+}
+
+// activate stages the agent of the update and restarts the agent service into it.
+func (c *AgentUpdateController) activate(ctx context.Context, update *v1alpha1.AgentUpdateSpec, state *agentUpdateState) error {
+	c.log.Infof("Updating agent from %s to %s", c.version, update.Version)
+	path, err := c.stage(ctx, update)
+	if err != nil {
+		return fmt.Errorf("staging agent %s: %w", update.Version, err)
+	}
+
+	newState := &agentUpdateState{
+		Version:       update.Version,
+		Path:          path,
+		UpdateVersion: update.Version,
+		State:         v1alpha1.DeviceAgentUpdateActivating,
+		ActivatedAt:   time.Now(),
+	}
+	if state != nil {
+		// the agent restored when the update fails is the one running now
+		newState.PreviousVersion = state.Version
+		newState.PreviousPath = state.Path
+	}
+	if err := writeAgentUpdateState(c.readWriter, c.dataDir, newState); err != nil {
+		return err
+	}
+	if err := writeAgentServiceDropIn(c.readWriter, path); err != nil {
+		return err
+	}
+	if err := c.systemd.DaemonReload(ctx); err != nil {
+		return err
+	}
+	c.updateStatus(ctx, newState)
+
+	c.log.Infof("Restarting into agent %s", update.Version)
+	return c.restartAgent(ctx)
+}
+
+// revertToOSImageAgent discards the update once it was removed from the device spec.
+func (c *AgentUpdateController) revertToOSImageAgent(ctx context.Context, state *agentUpdateState) error {
+	if state.Path == "" {
+		// the agent of the OS image is running already, e.g. after a rollback
+		return c.readWriter.RemoveFile(filepath.Join(c.dataDir, agentUpdateStateFile))
+	}
+
+	c.log.Infof("Agent update was removed, reverting from agent %s to the agent of the OS image", state.Version)
+	if err := writeAgentServiceDropIn(c.readWriter, ""); err != nil {
+		return err
+	}
+	if err := c.readWriter.RemoveFile(filepath.Join(c.dataDir, agentUpdateStateFile)); err != nil {
+		return err
+	}
+	if err := c.systemd.DaemonReload(ctx); err != nil {
+		return err
+	}
+	c.pruneBinaries()
+	return c.restartAgent(ctx)
+}
+
+// stage places the agent binary of the update in the data dir and checks that it runs.
+func (c *AgentUpdateController) stage(ctx context.Context, update *v1alpha1.AgentUpdateSpec) (string, error) {
+	dir := filepath.Join(c.dataDir, agentBinariesDir, update.Version)
+	if err := os.MkdirAll(c.readWriter.PathFor(dir), 0755); err != nil {
+		return "", err
+	}
+	tmpPath := filepath.Join(dir, agentBinaryName+".tmp")
+	defer func() {
+		_ = c.readWriter.RemoveFile(tmpPath)
+	}()
+
+	switch {
+	case update.Image != nil:
+		if err := c.extractFromImage(ctx, *update.Image, tmpPath); err != nil {
+			return "", err
+		}
+	case update.Url != nil:
+		if err := c.download(ctx, *update.Url, lo.FromPtr(update.Sha256), tmpPath); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("neither image nor url is set")
+	}
+	if err := os.Chmod(c.readWriter.PathFor(tmpPath), 0755); err != nil {
+		return "", err
+	}
+	if err := c.checkVersion(ctx, tmpPath, update.Version); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, agentBinaryName)
+	if err := os.Rename(c.readWriter.PathFor(tmpPath), c.readWriter.PathFor(path)); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func (c *AgentUpdateController) extractFromImage(ctx context.Context, image string, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, agentDownloadTimeout)
+	defer cancel()
+
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "create", image, agentBinaryName)
+	if exitCode != 0 {
+		return fmt.Errorf("creating container from image %s: exit code %d: %s", image, exitCode, stderr)
+	}
+	container := strings.TrimSpace(stdout)
+	defer func() {
+		if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "rm", container); exitCode != 0 {
+			c.log.Warnf("Failed to remove container %s: exit code %d: %s", container, exitCode, stderr)
+		}
+	}()
+
+	_, stderr, exitCode = c.exec.ExecuteWithContext(ctx, podmanCommand, "cp", container+":"+osImageAgentPath, c.readWriter.PathFor(path))
+	if exitCode != 0 {
+		return fmt.Errorf("copying agent from image %s: exit code %d: %s", image, exitCode, stderr)
+	}
+	return nil
+}
+
+func (c *AgentUpdateController) download(ctx context.Context, url string, checksum string, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading agent: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading agent: unexpected status code %d", resp.StatusCode)
+	}
+
+	file, err := os.OpenFile(c.readWriter.PathFor(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		return fmt.Errorf("downloading agent: %w", err)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum, sum)
+	}
+	return file.Close()
+}
+
+// checkVersion runs the staged agent to make sure it is executable on the
+// device and that it is the version requested.
+func (c *AgentUpdateController) checkVersion(ctx context.Context, path string, expected string) error {
+	ctx, cancel := context.WithTimeout(ctx, agentCommandTimeout)
+	defer cancel()
+
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, c.readWriter.PathFor(path), "version")
+	if exitCode != 0 {
+		return fmt.Errorf("running agent: exit code %d: %s", exitCode, stderr)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		// the version is printed as <build date>-<git version>
+		if reported, found := strings.CutPrefix(line, "Flightctl Agent Version: "); found {
+			if !strings.HasSuffix(strings.TrimSpace(reported), "-"+expected) {
+				return fmt.Errorf("agent reports version %s, expected %s", reported, expected)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("agent does not report its version")
+}
+
+// pruneBinaries removes the staged agents other than the given versions.
+func (c *AgentUpdateController) pruneBinaries(keep ...string) {
+	dir := c.readWriter.PathFor(filepath.Join(c.dataDir, agentBinariesDir))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			c.log.Warnf("Failed to list staged agents: %v", err)
+		}
+		return
+	}
+	for _, entry := range entries {
+		if lo.Contains(keep, entry.Name()) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			c.log.Warnf("Failed to remove staged agent %s: %v", entry.Name(), err)
+		}
+	}
+}
+
+func (c *AgentUpdateController) restartAgent(ctx context.Context) error {
+	// do not wait for the restart, which stops this agent
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, "--no-block", "restart", agentServiceName)
+	if exitCode != 0 {
+		return fmt.Errorf("failed to restart %s: exit code %d: %s", agentServiceName, exitCode, stderr)
+	}
+	return nil
+}
+
+func (c *AgentUpdateController) isActivating(state *agentUpdateState) bool {
+	return state != nil && state.State == v1alpha1.DeviceAgentUpdateActivating && state.Version == c.version
+}
+
+func (c *AgentUpdateController) updateStatus(ctx context.Context, state *agentUpdateState) {
+	agentStatus := v1alpha1.DeviceAgentStatus{
+		Version: c.version,
+	}
+	if state != nil {
+		agentStatus.UpdateState = lo.ToPtr(state.State)
+		agentStatus.UpdateVersion = lo.ToPtr(state.UpdateVersion)
+		if state.Message != "" {
+			agentStatus.UpdateMessage = lo.ToPtr(state.Message)
+		}
+	}
+	if reflect.DeepEqual(c.reported, &agentStatus) {
+		return
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetAgent(agentStatus)); err != nil {
+		c.log.Warnf("Failed setting status: %v", err)
+		return
+	}
+	c.reported = &agentStatus
+}
+
+// CheckAgentUpdate is run by systemd before it starts the agent. It counts
+// the starts of an updated agent that is being activated, and rolls the
+// update back once the agent failed to start agentMaxStartAttempts times. It
+// returns true if the update was rolled back, in which case the start has to
+// fail so that systemd starts the restored agent instead.
+func CheckAgentUpdate(ctx context.Context, dataDir string, readWriter fileio.ReadWriter, exec executer.Executer, log *log.PrefixLogger) (bool, error) {
+	state, err := readAgentUpdateState(readWriter, dataDir)
+	if err != nil {
+		return false, err
+	}
+	if state == nil || state.State != v1alpha1.DeviceAgentUpdateActivating {
+		return false, nil
+	}
+
+	if state.StartAttempts < agentMaxStartAttempts {
+		state.StartAttempts++
+		log.Infof("Starting agent %s, attempt %d of %d", state.Version, state.StartAttempts, agentMaxStartAttempts)
+		return false, writeAgentUpdateState(readWriter, dataDir, state)
+	}
+
+	message := fmt.Sprintf("Agent %s failed to start %d times", state.Version, state.StartAttempts)
+	log.Errorf("%s, rolling back", message)
+	if err := rollbackAgentUpdate(ctx, readWriter, client.NewSystemd(exec), dataDir, state, message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// rollbackAgentUpdate points the agent service back to the agent which ran before the update.
+func rollbackAgentUpdate(ctx context.Context, readWriter fileio.ReadWriter, systemd *client.Systemd, dataDir string, state *agentUpdateState, message string) error {
+	state.Version = state.PreviousVersion
+	state.Path = state.PreviousPath
+	state.PreviousVersion = ""
+	state.PreviousPath = ""
+	state.State = v1alpha1.DeviceAgentUpdateRolledBack
+	state.Message = message
+	state.StartAttempts = 0
+
+	if err := writeAgentServiceDropIn(readWriter, state.Path); err != nil {
+		return err
+	}
+	if err := writeAgentUpdateState(readWriter, dataDir, state); err != nil {
+		return err
+	}
+	return systemd.DaemonReload(ctx)
+}
+
+// writeAgentServiceDropIn makes systemd start the agent at path, or the agent
+// of the OS image if path is empty.
+func writeAgentServiceDropIn(readWriter fileio.ReadWriter, path string) error {
+	if path == "" {
+		return readWriter.RemoveFile(agentServiceDropInPath)
+	}
+	// the check is run by the agent of the OS image, which is known to start
+	dropIn := fmt.Sprintf("[Service]\nExecStartPre=%s check-update\nExecStart=\nExecStart=%s\n", osImageAgentPath, path)
+	return readWriter.WriteFile(agentServiceDropInPath, []byte(dropIn), 0644)
+}
+
+func readAgentUpdateState(reader fileio.Reader, dataDir string) (*agentUpdateState, error) {
+	contents, err := reader.ReadFile(filepath.Join(dataDir, agentUpdateStateFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state agentUpdateState
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return nil, fmt.Errorf("unmarshalling agent update state: %w", err)
+	}
+	return &state, nil
+}
+
+func writeAgentUpdateState(writer fileio.Writer, dataDir string, state *agentUpdateState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writer.WriteFile(filepath.Join(dataDir, agentUpdateStateFile), contents, 0600)
+}
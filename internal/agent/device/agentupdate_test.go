@@ -0,0 +1,354 @@
+package device
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testDataDir = "/var/lib/flightctl"
+
+func newTestAgentUpdateController(t *testing.T) (*AgentUpdateController, fileio.ReadWriter, *executer.MockExecuter, *status.MockManager) {
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+
+	c := NewAgentUpdateController(testDataDir, readWriter, execMock, statusManager, flightlog.NewPrefixLogger(""))
+	c.version = "v0.1.0"
+	return c, readWriter, execMock, statusManager
+}
+
+func desiredAgentUpdate(update *v1alpha1.AgentUpdateSpec) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		Agent:           &v1alpha1.DeviceAgentSpec{Update: update},
+	}
+}
+
+func TestAgentUpdateSyncWithoutUpdate(t *testing.T) {
+	require := require.New(t)
+	c, _, _, statusManager := newTestAgentUpdateController(t)
+
+	// the version is reported once
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	require.NoError(c.Sync(context.Background(), &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}))
+	require.NoError(c.Sync(context.Background(), &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}))
+	require.Equal(&v1alpha1.DeviceAgentStatus{Version: "v0.1.0"}, c.reported)
+}
+
+func TestAgentUpdateSyncFromURL(t *testing.T) {
+	binary := []byte("agent binary")
+	sum := sha256.Sum256(binary)
+	checksum := hex.EncodeToString(sum[:])
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name           string
+		checksum       string
+		versionOutput  string
+		expectActivate bool
+	}{
+		{
+			name:           "agent is staged and activated",
+			checksum:       checksum,
+			versionOutput:  "Flightctl Agent Version: 20240801-v0.2.0\nGit Commit: abcdef\n",
+			expectActivate: true,
+		},
+		{
+			name:     "checksum mismatch",
+			checksum: "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:          "agent reports another version",
+			checksum:      checksum,
+			versionOutput: "Flightctl Agent Version: 20240801-v0.3.0\nGit Commit: abcdef\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			c, readWriter, execMock, statusManager := newTestAgentUpdateController(t)
+			statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+			if tc.versionOutput != "" {
+				execMock.EXPECT().ExecuteWithContext(gomock.Any(), gomock.Any(), "version").Return(tc.versionOutput, "", 0)
+			}
+			if tc.expectActivate {
+				execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0)
+				execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "restart", agentServiceName).Return("", "", 0)
+			}
+
+			desired := desiredAgentUpdate(&v1alpha1.AgentUpdateSpec{
+				Version: "v0.2.0",
+				Url:     lo.ToPtr(server.URL),
+				Sha256:  lo.ToPtr(tc.checksum),
+			})
+			err := c.Sync(context.Background(), desired)
+
+			dropInExists, existsErr := readWriter.FileExists(agentServiceDropInPath)
+			require.NoError(existsErr)
+			if !tc.expectActivate {
+				require.Error(err)
+				require.False(dropInExists)
+				return
+			}
+			require.NoError(err)
+
+			path := filepath.Join(testDataDir, agentBinariesDir, "v0.2.0", agentBinaryName)
+			staged, err := readWriter.ReadFile(path)
+			require.NoError(err)
+			require.Equal(binary, staged)
+
+			dropIn, err := readWriter.ReadFile(agentServiceDropInPath)
+			require.NoError(err)
+			require.Contains(string(dropIn), "ExecStart=\nExecStart="+path+"\n")
+			require.Contains(string(dropIn), "ExecStartPre="+osImageAgentPath+" check-update\n")
+
+			state, err := readAgentUpdateState(readWriter, testDataDir)
+			require.NoError(err)
+			require.Equal(v1alpha1.DeviceAgentUpdateActivating, state.State)
+			require.Equal("v0.2.0", state.Version)
+			require.Empty(state.PreviousPath)
+			require.Equal(lo.ToPtr(v1alpha1.DeviceAgentUpdateActivating), c.reported.UpdateState)
+		})
+	}
+}
+
+func TestAgentUpdateSyncFromImage(t *testing.T) {
+	require := require.New(t)
+	c, readWriter, execMock, statusManager := newTestAgentUpdateController(t)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	// the agent runs a staged update, which is restored if the new update fails
+	previous := &agentUpdateState{
+		Version:       "v0.1.0",
+		Path:          "/var/lib/flightctl/agent/v0.1.0/flightctl-agent",
+		UpdateVersion: "v0.1.0",
+		State:         v1alpha1.DeviceAgentUpdateActive,
+	}
+	require.NoError(writeAgentUpdateState(readWriter, testDataDir, previous))
+
+	image := "quay.io/flightctl/flightctl-agent:v0.2.0"
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "create", image, agentBinaryName).Return("container-id\n", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "cp", "container-id:"+osImageAgentPath, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, command string, args ...string) (string, string, int) {
+				require.NoError(os.WriteFile(args[2], []byte("agent binary"), 0600))
+				return "", "", 0
+			}),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "rm", "container-id").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), gomock.Any(), "version").Return("Flightctl Agent Version: -v0.2.0\n", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "restart", agentServiceName).Return("", "", 0),
+	)
+
+	desired := desiredAgentUpdate(&v1alpha1.AgentUpdateSpec{Version: "v0.2.0", Image: lo.ToPtr(image)})
+	require.NoError(c.Sync(context.Background(), desired))
+
+	state, err := readAgentUpdateState(readWriter, testDataDir)
+	require.NoError(err)
+	require.Equal(v1alpha1.DeviceAgentUpdateActivating, state.State)
+	require.Equal("v0.2.0", state.Version)
+	require.Equal(previous.Version, state.PreviousVersion)
+	require.Equal(previous.Path, state.PreviousPath)
+}
+
+func TestAgentUpdateSyncState(t *testing.T) {
+	update := &v1alpha1.AgentUpdateSpec{
+		Version: "v0.1.0",
+		Url:     lo.ToPtr("https://example.com/flightctl-agent"),
+		Sha256:  lo.ToPtr("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+	}
+
+	testCases := []struct {
+		name          string
+		state         *agentUpdateState
+		update        *v1alpha1.AgentUpdateSpec
+		expectRestart bool
+		expectState   *v1alpha1.DeviceAgentUpdateState
+		expectDropIn  bool
+	}{
+		{
+			name: "updated agent fetched the spec and is activated",
+			state: &agentUpdateState{
+				Version:       "v0.1.0",
+				Path:          "/var/lib/flightctl/agent/v0.1.0/flightctl-agent",
+				UpdateVersion: "v0.1.0",
+				State:         v1alpha1.DeviceAgentUpdateActivating,
+				StartAttempts: 1,
+			},
+			update:       update,
+			expectState:  lo.ToPtr(v1alpha1.DeviceAgentUpdateActive),
+			expectDropIn: true,
+		},
+		{
+			name: "restart into the updated agent is pending",
+			state: &agentUpdateState{
+				Version:       "v0.2.0",
+				Path:          "/var/lib/flightctl/agent/v0.2.0/flightctl-agent",
+				UpdateVersion: "v0.2.0",
+				State:         v1alpha1.DeviceAgentUpdateActivating,
+			},
+			update:       &v1alpha1.AgentUpdateSpec{Version: "v0.2.0", Image: lo.ToPtr("quay.io/flightctl/flightctl-agent:v0.2.0")},
+			expectState:  lo.ToPtr(v1alpha1.DeviceAgentUpdateActivating),
+			expectDropIn: true,
+		},
+		{
+			name: "rolled back update is not retried",
+			state: &agentUpdateState{
+				UpdateVersion: "v0.2.0",
+				State:         v1alpha1.DeviceAgentUpdateRolledBack,
+				Message:       "Agent v0.2.0 failed to start 3 times",
+			},
+			update:      &v1alpha1.AgentUpdateSpec{Version: "v0.2.0", Image: lo.ToPtr("quay.io/flightctl/flightctl-agent:v0.2.0")},
+			expectState: lo.ToPtr(v1alpha1.DeviceAgentUpdateRolledBack),
+		},
+		{
+			name: "removed update reverts to the agent of the OS image",
+			state: &agentUpdateState{
+				Version:       "v0.1.0",
+				Path:          "/var/lib/flightctl/agent/v0.1.0/flightctl-agent",
+				UpdateVersion: "v0.1.0",
+				State:         v1alpha1.DeviceAgentUpdateActive,
+			},
+			expectRestart: true,
+		},
+		{
+			name: "removed update after a rollback to the agent of the OS image",
+			state: &agentUpdateState{
+				UpdateVersion: "v0.2.0",
+				State:         v1alpha1.DeviceAgentUpdateRolledBack,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			c, readWriter, execMock, statusManager := newTestAgentUpdateController(t)
+			statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+			require.NoError(writeAgentUpdateState(readWriter, testDataDir, tc.state))
+			require.NoError(writeAgentServiceDropIn(readWriter, tc.state.Path))
+			if tc.expectRestart {
+				execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0)
+				execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "restart", agentServiceName).Return("", "", 0)
+			}
+
+			require.NoError(c.Sync(context.Background(), desiredAgentUpdate(tc.update)))
+
+			state, err := readAgentUpdateState(readWriter, testDataDir)
+			require.NoError(err)
+			dropInExists, err := readWriter.FileExists(agentServiceDropInPath)
+			require.NoError(err)
+			require.Equal(tc.expectDropIn, dropInExists)
+			if tc.expectState == nil {
+				require.Nil(state)
+				require.Nil(c.reported.UpdateState)
+				return
+			}
+			require.Equal(*tc.expectState, state.State)
+			require.Equal(tc.expectState, c.reported.UpdateState)
+		})
+	}
+}
+
+func TestAgentUpdatePruneBinaries(t *testing.T) {
+	require := require.New(t)
+	c, readWriter, _, statusManager := newTestAgentUpdateController(t)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	for _, version := range []string{"v0.0.9", "v0.1.0", "v0.0.1"} {
+		require.NoError(readWriter.WriteFile(filepath.Join(testDataDir, agentBinariesDir, version, agentBinaryName), []byte(version), 0755))
+	}
+	state := &agentUpdateState{
+		Version:         "v0.1.0",
+		Path:            "/var/lib/flightctl/agent/v0.1.0/flightctl-agent",
+		PreviousVersion: "v0.0.9",
+		PreviousPath:    "/var/lib/flightctl/agent/v0.0.9/flightctl-agent",
+		UpdateVersion:   "v0.1.0",
+		State:           v1alpha1.DeviceAgentUpdateActivating,
+	}
+	require.NoError(writeAgentUpdateState(readWriter, testDataDir, state))
+
+	require.NoError(c.Sync(context.Background(), &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		Agent:           &v1alpha1.DeviceAgentSpec{Update: &v1alpha1.AgentUpdateSpec{Version: "v0.1.0", Image: lo.ToPtr("image")}},
+	}))
+
+	entries, err := os.ReadDir(readWriter.PathFor(filepath.Join(testDataDir, agentBinariesDir)))
+	require.NoError(err)
+	require.Equal([]string{"v0.0.9", "v0.1.0"}, lo.Map(entries, func(e os.DirEntry, _ int) string { return e.Name() }))
+}
+
+func TestCheckAgentUpdate(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	log := flightlog.NewPrefixLogger("")
+
+	state := &agentUpdateState{
+		Version:         "v0.2.0",
+		Path:            "/var/lib/flightctl/agent/v0.2.0/flightctl-agent",
+		PreviousVersion: "v0.1.0",
+		PreviousPath:    "/var/lib/flightctl/agent/v0.1.0/flightctl-agent",
+		UpdateVersion:   "v0.2.0",
+		State:           v1alpha1.DeviceAgentUpdateActivating,
+		ActivatedAt:     time.Now(),
+	}
+	require.NoError(writeAgentUpdateState(readWriter, testDataDir, state))
+	require.NoError(writeAgentServiceDropIn(readWriter, state.Path))
+
+	for attempt := 1; attempt <= agentMaxStartAttempts; attempt++ {
+		rolledBack, err := CheckAgentUpdate(context.Background(), testDataDir, readWriter, execMock, log)
+		require.NoError(err)
+		require.False(rolledBack)
+		current, err := readAgentUpdateState(readWriter, testDataDir)
+		require.NoError(err)
+		require.Equal(attempt, current.StartAttempts)
+	}
+
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0)
+	rolledBack, err := CheckAgentUpdate(context.Background(), testDataDir, readWriter, execMock, log)
+	require.NoError(err)
+	require.True(rolledBack)
+
+	current, err := readAgentUpdateState(readWriter, testDataDir)
+	require.NoError(err)
+	require.Equal(v1alpha1.DeviceAgentUpdateRolledBack, current.State)
+	require.Equal("v0.1.0", current.Version)
+	require.Equal("v0.2.0", current.UpdateVersion)
+	require.NotEmpty(current.Message)
+
+	dropIn, err := readWriter.ReadFile(agentServiceDropInPath)
+	require.NoError(err)
+	require.Contains(string(dropIn), "ExecStart="+state.PreviousPath+"\n")
+
+	// once rolled back, the restored agent starts without further checks
+	rolledBack, err = CheckAgentUpdate(context.Background(), testDataDir, readWriter, execMock, log)
+	require.NoError(err)
+	require.False(rolledBack)
+}
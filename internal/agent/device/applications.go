@@ -0,0 +1,902 @@
+package device
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/spec"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// applicationsStateFile records the running versions of the applications
+	// of the spec, relative to the data dir
+	applicationsStateFile = "applications.json"
+	// applicationsDir keeps the compose files of the running versions,
+	// relative to the data dir
+	applicationsDir = "applications"
+
+	podmanComposeCommand = "podman-compose"
+	chconCommand         = "chcon"
+	// applicationSliceDir holds the systemd slices enforcing the resource
+	// limits of the applications
+	applicationSliceDir = "/etc/systemd/system"
+	// applicationLabel labels the podman volumes the agent creates with the
+	// application they belong to
+	applicationLabel = "io.flightctl.application"
+	// applicationCommandTimeout bounds the podman-compose commands, which may
+	// pull images
+	applicationCommandTimeout  = 5 * time.Minute
+	defaultHealthCheckTimeout  = time.Minute
+	defaultHealthCheckInterval = 5 * time.Second
+	// applicationProjectEnvVar passes the compose project of the new version
+	// of an application to its health check
+	applicationProjectEnvVar = "FLIGHTCTL_APPLICATION_PROJECT"
+
+	// DefaultApplicationConcurrency is the number of applications synced at
+	// once by default.
+	DefaultApplicationConcurrency = 4
+)
+
+// applicationState is the running version of an application, kept in the data
+// dir so that the agent takes the right version down after a restart.
+type applicationState struct {
+	// Project is the compose project the version runs in, empty if the
+	// application never came up.
+	Project string `json:"project,omitempty"`
+	// Hash is the SHA-256 of the compose file of the version.
+	Hash string `json:"hash,omitempty"`
+	// RejectedHash is the SHA-256 of the compose file of a version whose
+	// blue-green update was rolled back, which is not retried until the
+	// compose file changes again.
+	RejectedHash string                            `json:"rejectedHash,omitempty"`
+	LastUpdate   *v1alpha1.ApplicationUpdateStatus `json:"lastUpdate,omitempty"`
+	// EmbeddedImages is the SHA-256 of the IDs of the images of the version
+	// when they are all embedded in the OS image, empty otherwise.
+	EmbeddedImages string `json:"embeddedImages,omitempty"`
+	// QuadletFiles are the Quadlet files of the version of an application
+	// running as a pod.
+	QuadletFiles []string `json:"quadletFiles,omitempty"`
+	// PurgeVolumes are the podman volumes removed with the application, which
+	// exclude the named volumes whose data is retained.
+	PurgeVolumes []string `json:"purgeVolumes,omitempty"`
+}
+
+// ApplicationController brings up, updates and takes down the applications of
+// the spec with podman-compose. When the compose file of an application
+// changes, it replaces the running version with the update strategy of the
+// application. A copy of the compose file of each running version is kept in
+// the data dir, from which the version is taken down once it is replaced.
+//
+// The volumes of an application are created as podman volumes named after the
+// application, outside of its compose projects, so that they outlive the
+// versions of the application. A compose file generated next to the copy
+// declares them as external volumes of the compose file of the application.
+//
+// The containers of an application with resource limits run in a systemd slice
+// of the application, which enforces the limits for all of its containers.
+//
+// Applications declared as a pod rather than a compose file run as a podman pod
+// generated from Quadlet files, see syncPod.
+//
+// Applications which do not depend on each other are synced in parallel, up to
+// the concurrency of the controller, see syncApplications.
+type ApplicationController struct {
+	dataDir             string
+	exec                executer.Executer
+	readWriter          fileio.ReadWriter
+	statusManager       status.Manager
+	specManager         spec.Manager
+	concurrency         int
+	healthCheckInterval time.Duration
+	// started is set once the running versions were brought up after the
+	// agent started, which they may not be after a reboot
+	started  bool
+	reported map[string]v1alpha1.ApplicationUpdateStatus
+	log      *log.PrefixLogger
+}
+
+func NewApplicationController(
+	dataDir string,
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	statusManager status.Manager,
+	specManager spec.Manager,
+	concurrency int,
+	log *log.PrefixLogger,
+) *ApplicationController {
+	return &ApplicationController{
+		dataDir:             dataDir,
+		exec:                exec,
+		readWriter:          readWriter,
+		statusManager:       statusManager,
+		specManager:         specManager,
+		concurrency:         concurrency,
+		healthCheckInterval: defaultHealthCheckInterval,
+		log:                 log,
+	}
+}
+
+// Sync brings up the applications of the desired spec, updates those whose
+// compose file changed and takes down the applications removed from the spec.
+// Failed updates are reported rather than failing the sync, so that they do
+// not hold back the rest of the spec, and are retried on the next sync.
+//
+// The applications are not synced while the device has yet to boot the OS
+// image of the desired spec, so that applications whose images are embedded in
+// the OS image are updated once the images are available.
+func (c *ApplicationController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing applications")
+	defer c.log.Debug("Finished syncing applications")
+
+	if desired.Os != nil && desired.Os.Image != "" {
+		_, reconciled, err := c.specManager.CheckOsReconciliation(ctx)
+		if err != nil {
+			return err
+		}
+		if !reconciled {
+			c.log.Infof("Deferring the applications until the device runs os image %s", desired.Os.Image)
+			return nil
+		}
+	}
+
+	states, err := c.readState()
+	if err != nil {
+		return err
+	}
+	applications := lo.FromPtr(desired.Applications)
+	for name, state := range c.syncApplications(ctx, applications, states) {
+		states[name] = state
+	}
+	for _, name := range lo.Without(lo.Keys(states), lo.Map(applications, func(a v1alpha1.ApplicationSpec, _ int) string { return a.Name })...) {
+		c.takeDown(ctx, name, states[name])
+		delete(states, name)
+	}
+	c.started = true
+
+	if err := c.writeState(states); err != nil {
+		return err
+	}
+	c.updateStatus(ctx, states)
+	return nil
+}
+
+// syncApplications syncs the applications, at most the concurrency of the
+// controller at once, each once the applications it depends on were synced, and
+// returns their new states. An application is not synced while one of its
+// dependencies fails to sync, so that it keeps running its current version
+// until the next sync.
+func (c *ApplicationController) syncApplications(ctx context.Context, applications []v1alpha1.ApplicationSpec, states map[string]*applicationState) map[string]*applicationState {
+	// synced is closed once the application was synced or skipped
+	synced := map[string]chan struct{}{}
+	dependencies := map[string][]string{}
+	for _, application := range applications {
+		synced[application.Name] = make(chan struct{})
+	}
+	for _, application := range applications {
+		dependencies[application.Name] = lo.Filter(lo.FromPtr(application.DependsOn), func(dependency string, _ int) bool {
+			_, ok := synced[dependency]
+			return ok
+		})
+	}
+	// the service rejects dependency cycles, which would otherwise keep the
+	// applications of the cycle waiting for each other
+	cyclic := dependencyCycles(dependencies)
+
+	var mu sync.Mutex
+	results := map[string]*applicationState{}
+	failed := map[string]bool{}
+	slots := make(chan struct{}, max(c.concurrency, 1))
+	var wg sync.WaitGroup
+	for _, application := range applications {
+		wg.Add(1)
+		go func(application v1alpha1.ApplicationSpec, state *applicationState) {
+			defer wg.Done()
+			defer close(synced[application.Name])
+
+			fail := func(message string, args ...any) {
+				c.log.Warnf("Not syncing application %s: %s", application.Name, fmt.Sprintf(message, args...))
+				mu.Lock()
+				defer mu.Unlock()
+				failed[application.Name] = true
+			}
+			if cyclic[application.Name] {
+				mu.Lock()
+				results[application.Name] = c.withResult(state, updateStrategyType(application), v1alpha1.ApplicationUpdateFailed, fmt.Errorf("the dependencies of the application form a cycle"))
+				failed[application.Name] = true
+				mu.Unlock()
+				return
+			}
+			for _, dependency := range dependencies[application.Name] {
+				<-synced[dependency]
+				mu.Lock()
+				dependencyFailed := failed[dependency]
+				mu.Unlock()
+				if dependencyFailed {
+					fail("dependency %s failed to sync", dependency)
+					return
+				}
+			}
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				fail("%v", ctx.Err())
+				return
+			}
+
+			result := c.syncApplicationWithResources(ctx, application, state)
+			mu.Lock()
+			defer mu.Unlock()
+			results[application.Name] = result
+			if result.LastUpdate != nil && (state == nil || result.LastUpdate != state.LastUpdate) && result.LastUpdate.Result == v1alpha1.ApplicationUpdateFailed {
+				failed[application.Name] = true
+			}
+		}(application, states[application.Name])
+	}
+	wg.Wait()
+	return results
+}
+
+// syncApplicationWithResources provides the volumes and the slice of an
+// application before it syncs the application.
+func (c *ApplicationController) syncApplicationWithResources(ctx context.Context, application v1alpha1.ApplicationSpec, state *applicationState) *applicationState {
+	err := c.ensureVolumes(ctx, application)
+	if err == nil {
+		err = c.ensureSlice(ctx, application)
+	}
+	if err != nil {
+		return c.withResult(state, updateStrategyType(application), v1alpha1.ApplicationUpdateFailed, err)
+	}
+	state = c.syncApplication(ctx, application, state)
+	state.PurgeVolumes = purgeVolumes(application)
+	return state
+}
+
+// dependencyCycles returns the applications which transitively depend on
+// themselves, and those depending on them.
+func dependencyCycles(dependencies map[string][]string) map[string]bool {
+	cyclic := map[string]bool{}
+	// 1 while visiting the dependencies of an application, 2 once they are
+	// visited
+	state := map[string]int{}
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case 1:
+			return true
+		case 2:
+			return cyclic[name]
+		}
+		state[name] = 1
+		for _, dependency := range dependencies[name] {
+			if visit(dependency) {
+				cyclic[name] = true
+			}
+		}
+		state[name] = 2
+		return cyclic[name]
+	}
+	for name := range dependencies {
+		visit(name)
+	}
+	return cyclic
+}
+
+func (c *ApplicationController) syncApplication(ctx context.Context, application v1alpha1.ApplicationSpec, state *applicationState) *applicationState {
+	if application.Pod != nil {
+		return c.syncPod(ctx, application, state)
+	}
+	strategy := updateStrategyType(application)
+	content, err := c.readWriter.ReadFile(*application.Path)
+	if err != nil {
+		return c.withResult(state, strategy, v1alpha1.ApplicationUpdateFailed, fmt.Errorf("reading compose file: %w", err))
+	}
+	hash := contentHash(content)
+	deployed := state != nil && state.Project != ""
+	if deployed && c.started && (hash == state.Hash || hash == state.RejectedHash) {
+		return state
+	}
+	embedded := c.embeddedImages(ctx, application.Name, content)
+	switch {
+	case !deployed:
+		return c.bringUp(ctx, application, content, hash, embedded)
+	case hash == state.Hash || hash == state.RejectedHash:
+		return c.restart(ctx, application, state, hash, embedded)
+	default:
+		return c.update(ctx, application, *state, content, hash, embedded)
+	}
+}
+
+// bringUp starts the first version of an application in the compose project
+// named after it.
+func (c *ApplicationController) bringUp(ctx context.Context, application v1alpha1.ApplicationSpec, content []byte, hash string, embedded string) *applicationState {
+	strategy := updateStrategyType(application)
+	c.log.Infof("Bringing up application %s", application.Name)
+	if err := c.compose(ctx, application.Name, application.Name, c.readWriter.PathFor(*application.Path), "up", "-d"); err != nil {
+		return c.withResult(nil, strategy, v1alpha1.ApplicationUpdateFailed, err)
+	}
+	return c.deployed(application, application.Name, content, hash, embedded)
+}
+
+// restart brings up the running version of an application after the agent
+// started, from the copy of its compose file if the compose file of the spec
+// is a rejected version.
+//
+// The agent starts after the device booted into a new OS image, whose embedded
+// images may differ from those the containers of the application were created
+// from although its compose file did not change. The application is then
+// recreated from the embedded images of the booted OS image.
+func (c *ApplicationController) restart(ctx context.Context, application v1alpha1.ApplicationSpec, state *applicationState, hash string, embedded string) *applicationState {
+	file := c.readWriter.PathFor(*application.Path)
+	if hash != state.Hash {
+		file = c.readWriter.PathFor(c.copyPath(application.Name))
+	} else if embedded != "" && embedded != state.EmbeddedImages {
+		c.log.Infof("Recreating application %s from the embedded images of the OS image", application.Name)
+		err := c.compose(ctx, application.Name, state.Project, c.readWriter.PathFor(c.copyPath(application.Name)), "down")
+		if err == nil {
+			err = c.compose(ctx, application.Name, state.Project, file, "up", "-d")
+		}
+		if err != nil {
+			return c.withResult(state, v1alpha1.ApplicationUpdateStrategyRecreate, v1alpha1.ApplicationUpdateFailed, err)
+		}
+		state.EmbeddedImages = embedded
+		return c.withResult(state, v1alpha1.ApplicationUpdateStrategyRecreate, v1alpha1.ApplicationUpdateSucceeded, nil)
+	}
+	if err := c.compose(ctx, application.Name, state.Project, file, "up", "-d"); err != nil {
+		c.log.Errorf("Failed to bring up application %s: %v", application.Name, err)
+	}
+	return state
+}
+
+// update replaces the running version of an application with the version of
+// its compose file, using the update strategy of the application.
+// The images embedded in the OS image are not pulled, as devices running such
+// applications may be offline.
+func (c *ApplicationController) update(ctx context.Context, application v1alpha1.ApplicationSpec, state applicationState, content []byte, hash string, embedded string) *applicationState {
+	strategy := updateStrategyType(application)
+	file := c.readWriter.PathFor(*application.Path)
+	previousFile := c.readWriter.PathFor(c.copyPath(application.Name))
+	c.log.Infof("Updating application %s with the %s strategy", application.Name, strategy)
+
+	project := state.Project
+	var err error
+	switch strategy {
+	case v1alpha1.ApplicationUpdateStrategyInPlace:
+		// the previous version runs until the images of the new one are
+		// pulled, then compose recreates the changed containers only
+		if embedded == "" {
+			err = c.compose(ctx, application.Name, project, file, "pull")
+		}
+		if err == nil {
+			err = c.compose(ctx, application.Name, project, file, "up", "-d")
+		}
+	case v1alpha1.ApplicationUpdateStrategyBlueGreen:
+		project = nextProject(application.Name, state.Project)
+		if err := c.bringUpNext(ctx, application, project, file, embedded == ""); err != nil {
+			c.log.Warnf("Rolling back the update of application %s: %v", application.Name, err)
+			state.RejectedHash = hash
+			return c.withResult(&state, strategy, v1alpha1.ApplicationUpdateRolledBack, err)
+		}
+		if err := c.compose(ctx, application.Name, state.Project, previousFile, "down"); err != nil {
+			c.log.Warnf("Failed to take down the previous version of application %s: %v", application.Name, err)
+		}
+	default:
+		if err = c.compose(ctx, application.Name, project, previousFile, "down"); err == nil {
+			err = c.compose(ctx, application.Name, project, file, "up", "-d")
+		}
+	}
+	if err != nil {
+		return c.withResult(&state, strategy, v1alpha1.ApplicationUpdateFailed, err)
+	}
+	return c.deployed(application, project, content, hash, embedded)
+}
+
+// bringUpNext brings up the new version of a blue-green update next to the
+// running one and waits until it is healthy, taking it down otherwise.
+func (c *ApplicationController) bringUpNext(ctx context.Context, application v1alpha1.ApplicationSpec, project string, file string, pull bool) error {
+	if pull {
+		if err := c.compose(ctx, application.Name, project, file, "pull"); err != nil {
+			return err
+		}
+	}
+	err := c.compose(ctx, application.Name, project, file, "up", "-d")
+	if err == nil {
+		err = c.waitHealthy(ctx, application.UpdateStrategy.HealthCheck, project)
+	}
+	if err != nil {
+		if downErr := c.compose(ctx, application.Name, project, file, "down"); downErr != nil {
+			c.log.Warnf("Failed to take down the new version of application %s: %v", application.Name, downErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// waitHealthy runs the health check until it succeeds or its timeout expires.
+func (c *ApplicationController) waitHealthy(ctx context.Context, healthCheck *v1alpha1.ApplicationHealthCheck, project string) error {
+	if healthCheck == nil {
+		return nil
+	}
+	timeout := defaultHealthCheckTimeout
+	if healthCheck.Timeout != nil {
+		var err error
+		if timeout, err = time.ParseDuration(*healthCheck.Timeout); err != nil {
+			return fmt.Errorf("invalid health check timeout: %w", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		_, stderr, exitCode := c.exec.ExecuteWithContextFromDir(ctx, "", "bash", []string{"-c", healthCheck.Run}, applicationProjectEnvVar+"="+project)
+		if exitCode == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("health check failed after %s with code %d: %s", timeout, exitCode, strings.TrimSpace(stderr))
+		case <-time.After(c.healthCheckInterval):
+		}
+	}
+}
+
+// takeDown takes down the running version of an application removed from the spec.
+func (c *ApplicationController) takeDown(ctx context.Context, name string, state *applicationState) {
+	if len(state.QuadletFiles) > 0 {
+		c.takeDownPod(ctx, name, state)
+	} else if state.Project != "" {
+		c.log.Infof("Taking down application %s", name)
+		if err := c.compose(ctx, name, state.Project, c.readWriter.PathFor(c.copyPath(name)), "down"); err != nil {
+			c.log.Errorf("Failed to take down application %s: %v", name, err)
+		}
+	}
+	if err := c.readWriter.RemoveFile(c.copyPath(name)); err != nil {
+		c.log.Warnf("Failed to remove the compose file of application %s: %v", name, err)
+	}
+	if err := c.readWriter.RemoveFile(c.volumesPath(name)); err != nil {
+		c.log.Warnf("Failed to remove the volumes of application %s: %v", name, err)
+	}
+	if err := c.removeSlice(ctx, name); err != nil {
+		c.log.Warnf("Failed to remove the slice of application %s: %v", name, err)
+	}
+	for _, volume := range state.PurgeVolumes {
+		if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "volume", "rm", "--force", volume); exitCode != 0 {
+			c.log.Warnf("Failed to remove volume %s of application %s: %s", volume, name, strings.TrimSpace(stderr))
+		}
+	}
+}
+
+// drift returns the services of the pods of the running applications which
+// are not active and their compose containers which stopped, as
+// application/container. A compose application none of whose containers exist
+// is returned by its name.
+func (c *ApplicationController) drift(ctx context.Context) ([]string, []string, error) {
+	states, err := c.readState()
+	if err != nil {
+		return nil, nil, err
+	}
+	units := []string{}
+	containers := []string{}
+	for name, state := range states {
+		switch {
+		case len(state.QuadletFiles) > 0:
+			service := quadletService(podQuadletFile(name))
+			if _, _, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, "is-active", "--quiet", service); exitCode != 0 {
+				units = append(units, service)
+			}
+		case state.Project != "":
+			stopped, err := c.stoppedContainers(ctx, name, state.Project)
+			if err != nil {
+				return nil, nil, err
+			}
+			containers = append(containers, stopped...)
+		}
+	}
+	sort.Strings(units)
+	sort.Strings(containers)
+	return units, containers, nil
+}
+
+// stoppedContainers returns the containers of the compose project of an
+// application which are not running, other than those which exited
+// successfully such as one-shot containers.
+func (c *ApplicationController) stoppedContainers(ctx context.Context, name string, project string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, applicationCommandTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "ps", "--all",
+		"--filter", "label="+composeProjectLabel+"="+project, "--format", "json")
+	if exitCode != 0 {
+		return nil, fmt.Errorf("failed listing containers of application %s with code %d: %s", name, exitCode, strings.TrimSpace(stderr))
+	}
+	var list []struct {
+		Names    []string `json:"Names"`
+		State    string   `json:"State"`
+		ExitCode int      `json:"ExitCode"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &list); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling containers of application %s: %w", name, err)
+	}
+	if len(list) == 0 {
+		return []string{name}, nil
+	}
+	stopped := []string{}
+	for _, entry := range list {
+		if entry.State == "running" || (entry.State == "exited" && entry.ExitCode == 0) || len(entry.Names) == 0 {
+			continue
+		}
+		stopped = append(stopped, name+"/"+entry.Names[0])
+	}
+	return stopped, nil
+}
+
+// changes returns the changes the sync of the applications of the desired
+// spec would make, given the files the sync of the config would write, which
+// include the compose files of the applications they change.
+func (c *ApplicationController) changes(desired *v1alpha1.RenderedDeviceSpec, writes []string) ([]v1alpha1.DeviceCheckChange, error) {
+	states, err := c.readState()
+	if err != nil {
+		return nil, err
+	}
+	changes := []v1alpha1.DeviceCheckChange{}
+	change := func(name string, change string) {
+		changes = append(changes, v1alpha1.DeviceCheckChange{Section: "applications", Name: lo.ToPtr(name), Change: change})
+	}
+	applications := lo.FromPtr(desired.Applications)
+	for _, application := range applications {
+		state := states[application.Name]
+		if state == nil || state.Project == "" {
+			change(application.Name, "bring up")
+			continue
+		}
+		hash := ""
+		if application.Pod != nil {
+			hash = podHash(podQuadletFiles(application))
+		} else if path := lo.FromPtr(application.Path); !lo.Contains(writes, path) {
+			if content, err := c.readWriter.ReadFile(path); err == nil {
+				hash = contentHash(content)
+			}
+		}
+		if hash == "" || (hash != state.Hash && hash != state.RejectedHash) {
+			change(application.Name, "update")
+		}
+	}
+	removed := lo.Without(lo.Keys(states), lo.Map(applications, func(a v1alpha1.ApplicationSpec, _ int) string { return a.Name })...)
+	sort.Strings(removed)
+	for _, name := range removed {
+		change(name, "take down")
+	}
+	return changes, nil
+}
+
+// Remediate lets the next sync bring up the running versions of the
+// applications again, as it does after the agent started.
+func (c *ApplicationController) Remediate() {
+	c.started = false
+}
+
+// ensureVolumes creates the missing volumes of an application and declares
+// them in the compose file of its volumes. Volumes which already exist are left
+// as they are, so that they keep their data.
+func (c *ApplicationController) ensureVolumes(ctx context.Context, application v1alpha1.ApplicationSpec) error {
+	volumes := lo.FromPtr(application.Volumes)
+	if len(volumes) == 0 {
+		return c.readWriter.RemoveFile(c.volumesPath(application.Name))
+	}
+
+	declared := map[string]any{}
+	for _, volume := range volumes {
+		name := volumeName(application.Name, volume.Name)
+		args := []string{"volume", "create", "--ignore", "--label", applicationLabel + "=" + application.Name}
+		switch {
+		case volume.HostPath != nil:
+			path := c.readWriter.PathFor(volume.HostPath.Path)
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return fmt.Errorf("creating host path of volume %s: %w", volume.Name, err)
+			}
+			if lo.FromPtr(volume.HostPath.SelinuxRelabel) {
+				if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, chconCommand, "-R", "-t", "container_file_t", path); exitCode != 0 {
+					return fmt.Errorf("relabeling host path of volume %s failed with code %d: %s", volume.Name, exitCode, strings.TrimSpace(stderr))
+				}
+			}
+			args = append(args, "--opt", "type=none", "--opt", "o=bind", "--opt", "device="+path)
+		case volume.Tmpfs != nil:
+			args = append(args, "--opt", "type=tmpfs", "--opt", "device=tmpfs")
+			if volume.Tmpfs.Size != nil {
+				args = append(args, "--opt", "o=size="+*volume.Tmpfs.Size)
+			}
+		}
+		if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, append(args, name)...); exitCode != 0 {
+			return fmt.Errorf("creating volume %s failed with code %d: %s", volume.Name, exitCode, strings.TrimSpace(stderr))
+		}
+		declared[volume.Name] = map[string]any{"external": true, "name": name}
+	}
+	if application.Pod != nil {
+		return c.readWriter.RemoveFile(c.volumesPath(application.Name))
+	}
+
+	content, err := yaml.Marshal(map[string]any{"volumes": declared})
+	if err != nil {
+		return err
+	}
+	return c.readWriter.WriteFile(c.volumesPath(application.Name), content, 0600)
+}
+
+// embeddedImages returns the SHA-256 of the IDs of the images of a compose
+// file if they are all embedded in the OS image, and an empty string otherwise.
+// The images embedded in a bootc OS image are found in the read-only image
+// stores of podman, such as /usr/lib/containers/storage.
+func (c *ApplicationController) embeddedImages(ctx context.Context, name string, content []byte) string {
+	var compose struct {
+		Services map[string]struct {
+			Image string `json:"image"`
+		} `json:"services"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil || len(compose.Services) == 0 {
+		return ""
+	}
+	images := []string{}
+	for _, service := range compose.Services {
+		if service.Image == "" {
+			return ""
+		}
+		images = append(images, service.Image)
+	}
+
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanCommand, "images", "--filter", "readonly=true", "--format", "json")
+	if exitCode != 0 {
+		c.log.Warnf("Failed to list the embedded images of application %s: %s", name, strings.TrimSpace(stderr))
+		return ""
+	}
+	var readOnlyImages []struct {
+		ID    string   `json:"Id"`
+		Names []string `json:"Names"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &readOnlyImages); err != nil {
+		c.log.Warnf("Failed to list the embedded images of application %s: %v", name, err)
+		return ""
+	}
+	ids := map[string]string{}
+	for _, image := range readOnlyImages {
+		for _, imageName := range image.Names {
+			ids[imageName] = image.ID
+		}
+	}
+
+	embedded := []string{}
+	for _, image := range images {
+		id, ok := ids[image]
+		if !ok {
+			id, ok = ids[image+":latest"]
+		}
+		if !ok {
+			return ""
+		}
+		embedded = append(embedded, id)
+	}
+	sort.Strings(embedded)
+	return contentHash([]byte(strings.Join(embedded, "\n")))
+}
+
+// ensureSlice writes the systemd slice enforcing the resource limits of an
+// application, or removes it if the application has no limits.
+func (c *ApplicationController) ensureSlice(ctx context.Context, application v1alpha1.ApplicationSpec) error {
+	if application.Resources == nil || (application.Resources.Cpu == nil && application.Resources.Memory == nil) {
+		return c.removeSlice(ctx, application.Name)
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "[Unit]\nDescription=Flight Control application %s\n\n[Slice]\n", application.Name)
+	if application.Resources.Cpu != nil {
+		cpu, err := resource.ParseQuantity(*application.Resources.Cpu)
+		if err != nil {
+			return fmt.Errorf("invalid CPU limit: %w", err)
+		}
+		// a quota of 100% is one CPU
+		fmt.Fprintf(&content, "CPUQuota=%d%%\n", (cpu.MilliValue()+9)/10)
+	}
+	if application.Resources.Memory != nil {
+		memory, err := resource.ParseQuantity(*application.Resources.Memory)
+		if err != nil {
+			return fmt.Errorf("invalid memory limit: %w", err)
+		}
+		fmt.Fprintf(&content, "MemoryMax=%d\n", memory.Value())
+	}
+
+	path := c.slicePath(application.Name)
+	if current, err := c.readWriter.ReadFile(path); err == nil && string(current) == content.String() {
+		return nil
+	}
+	if err := c.readWriter.WriteFile(path, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("writing slice of application: %w", err)
+	}
+	// reloading applies the limits to the running containers of the application
+	return client.NewSystemd(c.exec).DaemonReload(ctx)
+}
+
+func (c *ApplicationController) removeSlice(ctx context.Context, name string) error {
+	path := c.slicePath(name)
+	exists, err := c.readWriter.FileExists(path)
+	if err != nil || !exists {
+		return err
+	}
+	if err := c.readWriter.RemoveFile(path); err != nil {
+		return err
+	}
+	return client.NewSystemd(c.exec).DaemonReload(ctx)
+}
+
+// deployed records the version which runs in the compose project.
+func (c *ApplicationController) deployed(application v1alpha1.ApplicationSpec, project string, content []byte, hash string, embedded string) *applicationState {
+	if err := c.readWriter.WriteFile(c.copyPath(application.Name), content, 0600); err != nil {
+		c.log.Warnf("Failed to keep the compose file of application %s: %v", application.Name, err)
+	}
+	state := &applicationState{Project: project, Hash: hash, EmbeddedImages: embedded}
+	return c.withResult(state, updateStrategyType(application), v1alpha1.ApplicationUpdateSucceeded, nil)
+}
+
+func (c *ApplicationController) withResult(state *applicationState, strategy v1alpha1.ApplicationUpdateStrategyType, result v1alpha1.ApplicationUpdateResult, err error) *applicationState {
+	if state == nil {
+		state = &applicationState{}
+	}
+	state.LastUpdate = &v1alpha1.ApplicationUpdateStatus{
+		Strategy:   strategy,
+		Result:     result,
+		FinishedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		state.LastUpdate.Message = lo.ToPtr(err.Error())
+	}
+	return state
+}
+
+func (c *ApplicationController) compose(ctx context.Context, name string, project string, file string, args ...string) error {
+	composeArgs := []string{"-p", project, "-f", file}
+	// the volumes of the application are declared for all of its compose files
+	volumesFile := c.volumesPath(name)
+	if exists, err := c.readWriter.FileExists(volumesFile); err != nil {
+		return err
+	} else if exists {
+		composeArgs = append(composeArgs, "-f", c.readWriter.PathFor(volumesFile))
+	}
+	if exists, err := c.readWriter.FileExists(c.slicePath(name)); err != nil {
+		return err
+	} else if exists {
+		composeArgs = append(composeArgs, "--podman-run-args=--cgroup-parent="+sliceName(name))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, applicationCommandTimeout)
+	defer cancel()
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, podmanComposeCommand, append(composeArgs, args...)...)
+	if exitCode != 0 {
+		return fmt.Errorf("podman-compose %s of project %s failed with code %d: %s", args[0], project, exitCode, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+func (c *ApplicationController) updateStatus(ctx context.Context, states map[string]*applicationState) {
+	updates := map[string]v1alpha1.ApplicationUpdateStatus{}
+	for name, state := range states {
+		if state.LastUpdate != nil {
+			updates[name] = *state.LastUpdate
+		}
+	}
+	if c.reported != nil && reflect.DeepEqual(c.reported, updates) {
+		return
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetApplicationUpdates(updates)); err != nil {
+		c.log.Warnf("Failed setting application update status: %v", err)
+		return
+	}
+	c.reported = updates
+}
+
+func (c *ApplicationController) statePath() string {
+	return filepath.Join(c.dataDir, applicationsStateFile)
+}
+
+func (c *ApplicationController) copyPath(name string) string {
+	return filepath.Join(c.dataDir, applicationsDir, name+".yaml")
+}
+
+func (c *ApplicationController) slicePath(name string) string {
+	return filepath.Join(applicationSliceDir, sliceName(name))
+}
+
+func (c *ApplicationController) volumesPath(name string) string {
+	return filepath.Join(c.dataDir, applicationsDir, name+".volumes.yaml")
+}
+
+func (c *ApplicationController) readState() (map[string]*applicationState, error) {
+	states := map[string]*applicationState{}
+	exists, err := c.readWriter.FileExists(c.statePath())
+	if err != nil || !exists {
+		return states, err
+	}
+	content, err := c.readWriter.ReadFile(c.statePath())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, &states); err != nil {
+		return nil, fmt.Errorf("reading application state: %w", err)
+	}
+	return states, nil
+}
+
+func (c *ApplicationController) writeState(states map[string]*applicationState) error {
+	if len(states) == 0 {
+		return c.readWriter.RemoveFile(c.statePath())
+	}
+	content, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+	return c.readWriter.WriteFile(c.statePath(), content, 0600)
+}
+
+func updateStrategyType(application v1alpha1.ApplicationSpec) v1alpha1.ApplicationUpdateStrategyType {
+	if application.UpdateStrategy == nil {
+		return v1alpha1.ApplicationUpdateStrategyRecreate
+	}
+	return application.UpdateStrategy.Type
+}
+
+// volumeName returns the name of the podman volume of an application volume,
+// which follows the naming of the volumes compose creates for a project.
+func volumeName(application string, volume string) string {
+	return application + "_" + volume
+}
+
+// sliceName returns the systemd slice of an application, below the
+// flightctl-app.slice of all applications. The dashes of the name of the
+// application are escaped, as they separate the levels of the slice hierarchy.
+func sliceName(application string) string {
+	return "flightctl-app-" + strings.ReplaceAll(application, "-", `\x2d`) + ".slice"
+}
+
+// purgeVolumes returns the podman volumes of an application which are removed
+// with it. They are the tmpfs and host path volumes, which hold no data of
+// their own, and the named volumes unless their data is retained.
+func purgeVolumes(application v1alpha1.ApplicationSpec) []string {
+	retainData := application.RetainData == nil || *application.RetainData
+	volumes := []string{}
+	for _, volume := range lo.FromPtr(application.Volumes) {
+		if volume.HostPath == nil && volume.Tmpfs == nil && retainData {
+			continue
+		}
+		volumes = append(volumes, volumeName(application.Name, volume.Name))
+	}
+	return volumes
+}
+
+// nextProject returns the compose project of the new version of a blue-green
+// update, which alternates between two projects so that both versions run side
+// by side.
+func nextProject(name string, project string) string {
+	if project == name+"-blue" {
+		return name + "-green"
+	}
+	return name + "-blue"
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,369 @@
+package device
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/spec"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testComposeFile = "/etc/compose/web.yaml"
+
+func newTestApplicationController(t *testing.T) (*ApplicationController, *executer.MockExecuter, *status.MockManager, fileio.ReadWriter) {
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	c := NewApplicationController("/var/lib/flightctl", execMock, readWriter, statusManager, spec.NewMockManager(ctrl), DefaultApplicationConcurrency, flightlog.NewPrefixLogger(""))
+	c.healthCheckInterval = 10 * time.Millisecond
+	return c, execMock, statusManager, readWriter
+}
+
+func desiredApplications(applications ...v1alpha1.ApplicationSpec) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Applications: &applications}
+}
+
+func expectCompose(execMock *executer.MockExecuter, project string, file string, args ...string) *gomock.Call {
+	matchers := []any{"-p", project, "-f", file}
+	for _, arg := range args {
+		matchers = append(matchers, arg)
+	}
+	return execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanComposeCommand, matchers...)
+}
+
+func TestApplicationSyncInPlace(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web.yaml")
+	desired := desiredApplications(v1alpha1.ApplicationSpec{
+		Name:           "web",
+		Path:           lo.ToPtr(testComposeFile),
+		UpdateStrategy: &v1alpha1.ApplicationUpdateStrategy{Type: v1alpha1.ApplicationUpdateStrategyInPlace},
+	})
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+
+	// the first version is brought up, and left alone until it changes
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 1"), 0600))
+	expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0)
+	require.NoError(c.Sync(ctx, desired))
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["web"].Result)
+
+	// the images are pulled before the containers are recreated in the same project
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 2"), 0600))
+	gomock.InOrder(
+		expectCompose(execMock, "web", file, "pull").Return("", "", 0),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateStrategyInPlace, c.reported["web"].Strategy)
+	content, err := readWriter.ReadFile("/var/lib/flightctl/applications/web.yaml")
+	require.NoError(err)
+	require.Equal("version: 2", string(content))
+
+	// an application removed from the spec is taken down with the file it was brought up from
+	expectCompose(execMock, "web", previousFile, "down").Return("", "", 0)
+	require.NoError(c.Sync(ctx, desiredApplications()))
+	require.Empty(c.reported)
+	exists, err := readWriter.FileExists("/var/lib/flightctl/applications/web.yaml")
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestApplicationSyncRecreate(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web.yaml")
+	desired := desiredApplications(v1alpha1.ApplicationSpec{Name: "web", Path: lo.ToPtr(testComposeFile)})
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 1"), 0600))
+	expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0)
+	require.NoError(c.Sync(ctx, desired))
+
+	// the previous version is taken down before the new one comes up
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 2"), 0600))
+	gomock.InOrder(
+		expectCompose(execMock, "web", previousFile, "down").Return("", "", 0),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "port in use", 1),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateFailed, c.reported["web"].Result)
+	require.Contains(*c.reported["web"].Message, "port in use")
+
+	// a failed update is retried on the next sync
+	gomock.InOrder(
+		expectCompose(execMock, "web", previousFile, "down").Return("", "", 0),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["web"].Result)
+	require.Equal(v1alpha1.ApplicationUpdateStrategyRecreate, c.reported["web"].Strategy)
+}
+
+func TestApplicationSyncDependencies(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	brokerFile, webFile := "/etc/compose/broker.yaml", "/etc/compose/web.yaml"
+	desired := desiredApplications(
+		v1alpha1.ApplicationSpec{Name: "web", Path: lo.ToPtr(webFile), DependsOn: &[]string{"broker"}},
+		v1alpha1.ApplicationSpec{Name: "broker", Path: lo.ToPtr(brokerFile)},
+	)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	require.NoError(readWriter.WriteFile(brokerFile, []byte("version: 1"), 0600))
+	require.NoError(readWriter.WriteFile(webFile, []byte("version: 1"), 0600))
+
+	// an application is not brought up while its dependency fails to
+	expectCompose(execMock, "broker", readWriter.PathFor(brokerFile), "up", "-d").Return("", "no such image", 1)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateFailed, c.reported["broker"].Result)
+	require.NotContains(c.reported, "web")
+
+	// it is brought up once its dependency is
+	gomock.InOrder(
+		expectCompose(execMock, "broker", readWriter.PathFor(brokerFile), "up", "-d").Return("", "", 0),
+		expectCompose(execMock, "web", readWriter.PathFor(webFile), "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["broker"].Result)
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["web"].Result)
+}
+
+func TestDependencyCycles(t *testing.T) {
+	cyclic := dependencyCycles(map[string][]string{
+		"web":    {"broker"},
+		"broker": {"db"},
+		"db":     {"broker"},
+		"cache":  {},
+		"api":    {"cache"},
+	})
+	require.Equal(t, map[string]bool{"web": true, "broker": true, "db": true}, cyclic)
+}
+
+func TestApplicationSyncBlueGreen(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web.yaml")
+	desired := desiredApplications(v1alpha1.ApplicationSpec{
+		Name: "web",
+		Path: lo.ToPtr(testComposeFile),
+		UpdateStrategy: &v1alpha1.ApplicationUpdateStrategy{
+			Type:        v1alpha1.ApplicationUpdateStrategyBlueGreen,
+			HealthCheck: &v1alpha1.ApplicationHealthCheck{Run: "curl -sf localhost:8080/healthz", Timeout: lo.ToPtr("1s")},
+		},
+	})
+	healthCheck := func(project string) *gomock.Call {
+		return execMock.EXPECT().ExecuteWithContextFromDir(gomock.Any(), "", "bash", []string{"-c", "curl -sf localhost:8080/healthz"}, applicationProjectEnvVar+"="+project)
+	}
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(4)
+
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 1"), 0600))
+	expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0)
+	require.NoError(c.Sync(ctx, desired))
+
+	// the previous version is taken down once the new one is healthy
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 2"), 0600))
+	gomock.InOrder(
+		expectCompose(execMock, "web-blue", file, "pull").Return("", "", 0),
+		expectCompose(execMock, "web-blue", file, "up", "-d").Return("", "", 0),
+		healthCheck("web-blue").Return("", "starting", 7),
+		healthCheck("web-blue").Return("", "", 0),
+		expectCompose(execMock, "web", previousFile, "down").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["web"].Result)
+
+	// an unhealthy version is taken down and not retried until the compose file changes
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 3"), 0600))
+	gomock.InOrder(
+		expectCompose(execMock, "web-green", file, "pull").Return("", "", 0),
+		expectCompose(execMock, "web-green", file, "up", "-d").Return("", "", 0),
+		healthCheck("web-green").Return("", "connection refused", 7).MinTimes(1),
+		expectCompose(execMock, "web-green", file, "down").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.NoError(c.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateRolledBack, c.reported["web"].Result)
+	require.Contains(*c.reported["web"].Message, "connection refused")
+
+	// the version which kept running is taken down when the application is removed
+	expectCompose(execMock, "web-blue", previousFile, "down").Return("", "", 0)
+	require.NoError(c.Sync(ctx, desiredApplications()))
+}
+
+func TestApplicationSyncVolumes(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web.yaml")
+	volumesFile := readWriter.PathFor("/var/lib/flightctl/applications/web.volumes.yaml")
+	hostPath := readWriter.PathFor("/var/lib/web/uploads")
+	desired := desiredApplications(v1alpha1.ApplicationSpec{
+		Name: "web",
+		Path: lo.ToPtr(testComposeFile),
+		Volumes: &[]v1alpha1.ApplicationVolume{
+			{Name: "db"},
+			{Name: "uploads", HostPath: &v1alpha1.ApplicationHostPathVolume{Path: "/var/lib/web/uploads", SelinuxRelabel: lo.ToPtr(true)}},
+			{Name: "cache", Tmpfs: &v1alpha1.ApplicationTmpfsVolume{Size: lo.ToPtr("64m")}},
+		},
+	})
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	// the volumes are created before the application comes up with their declarations
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 1"), 0600))
+	label := applicationLabel + "=web"
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "create", "--ignore", "--label", label, "web_db").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), chconCommand, "-R", "-t", "container_file_t", hostPath).Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "create", "--ignore", "--label", label,
+			"--opt", "type=none", "--opt", "o=bind", "--opt", "device="+hostPath, "web_uploads").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "create", "--ignore", "--label", label,
+			"--opt", "type=tmpfs", "--opt", "device=tmpfs", "--opt", "o=size=64m", "web_cache").Return("", "", 0),
+		expectCompose(execMock, "web", file, "-f", volumesFile, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.DirExists(hostPath)
+	content, err := readWriter.ReadFile("/var/lib/flightctl/applications/web.volumes.yaml")
+	require.NoError(err)
+	require.YAMLEq(`volumes:
+  db: {external: true, name: web_db}
+  uploads: {external: true, name: web_uploads}
+  cache: {external: true, name: web_cache}
+`, string(content))
+
+	// the named volume keeps its data once the application is removed
+	gomock.InOrder(
+		expectCompose(execMock, "web", previousFile, "-f", volumesFile, "down").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "rm", "--force", "web_uploads").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "rm", "--force", "web_cache").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desiredApplications()))
+	exists, err := readWriter.FileExists("/var/lib/flightctl/applications/web.volumes.yaml")
+	require.NoError(err)
+	require.False(exists)
+	require.DirExists(hostPath)
+}
+
+func TestPurgeVolumes(t *testing.T) {
+	volumes := &[]v1alpha1.ApplicationVolume{
+		{Name: "db"},
+		{Name: "cache", Tmpfs: &v1alpha1.ApplicationTmpfsVolume{}},
+	}
+	require.Equal(t, []string{"web_cache"}, purgeVolumes(v1alpha1.ApplicationSpec{Name: "web", Volumes: volumes}))
+	require.Equal(t, []string{"web_cache"}, purgeVolumes(v1alpha1.ApplicationSpec{Name: "web", Volumes: volumes, RetainData: lo.ToPtr(true)}))
+	require.Equal(t, []string{"web_db", "web_cache"}, purgeVolumes(v1alpha1.ApplicationSpec{Name: "web", Volumes: volumes, RetainData: lo.ToPtr(false)}))
+}
+
+func TestApplicationSyncResources(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web-app.yaml")
+	slice := "/etc/systemd/system/flightctl-app-web\\x2dapp.slice"
+	desired := desiredApplications(v1alpha1.ApplicationSpec{
+		Name:      "web-app",
+		Path:      lo.ToPtr(testComposeFile),
+		Resources: &v1alpha1.ApplicationResources{Cpu: lo.ToPtr("1.5"), Memory: lo.ToPtr("512Mi")},
+	})
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	// the containers run in the slice of the application, which is reloaded once
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("version: 1"), 0600))
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+		expectCompose(execMock, "web-app", file, "--podman-run-args=--cgroup-parent=flightctl-app-web\\x2dapp.slice", "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+	require.NoError(c.Sync(ctx, desired))
+	content, err := readWriter.ReadFile(slice)
+	require.NoError(err)
+	require.Equal("[Unit]\nDescription=Flight Control application web-app\n\n[Slice]\nCPUQuota=150%\nMemoryMax=536870912\n", string(content))
+
+	// the slice is removed with the application
+	gomock.InOrder(
+		expectCompose(execMock, "web-app", previousFile, "--podman-run-args=--cgroup-parent=flightctl-app-web\\x2dapp.slice", "down").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desiredApplications()))
+	exists, err := readWriter.FileExists(slice)
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestApplicationSyncEmbeddedImages(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	file := readWriter.PathFor(testComposeFile)
+	previousFile := readWriter.PathFor("/var/lib/flightctl/applications/web.yaml")
+	desired := desiredApplications(v1alpha1.ApplicationSpec{
+		Name:           "web",
+		Path:           lo.ToPtr(testComposeFile),
+		UpdateStrategy: &v1alpha1.ApplicationUpdateStrategy{Type: v1alpha1.ApplicationUpdateStrategyInPlace},
+	})
+	expectEmbeddedImages := func(images string) *gomock.Call {
+		return execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "images", "--filter", "readonly=true", "--format", "json").Return(images, "", 0)
+	}
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(4)
+
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("services:\n  web:\n    image: quay.io/example/web:v1\n"), 0600))
+	gomock.InOrder(
+		expectEmbeddedImages(`[{"Id":"1111","Names":["quay.io/example/web:v1"]}]`),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+
+	// the embedded images of an update are not pulled
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("services:\n  web:\n    image: quay.io/example/web\n"), 0600))
+	gomock.InOrder(
+		expectEmbeddedImages(`[{"Id":"1111","Names":["quay.io/example/web:v1"]},{"Id":"2222","Names":["quay.io/example/web:latest"]}]`),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desired))
+
+	// after booting an OS image embedding other images, the application is recreated from them
+	restarted := NewApplicationController("/var/lib/flightctl", execMock, readWriter, statusManager, c.specManager, DefaultApplicationConcurrency, flightlog.NewPrefixLogger(""))
+	gomock.InOrder(
+		expectEmbeddedImages(`[{"Id":"3333","Names":["quay.io/example/web:latest"]}]`),
+		expectCompose(execMock, "web", previousFile, "down").Return("", "", 0),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(restarted.Sync(ctx, desired))
+	require.Equal(v1alpha1.ApplicationUpdateStrategyRecreate, restarted.reported["web"].Strategy)
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, restarted.reported["web"].Result)
+
+	// the applications wait until the device booted the OS image of the spec
+	require.NoError(readWriter.WriteFile(testComposeFile, []byte("services:\n  web:\n    image: quay.io/example/web:v3\n"), 0600))
+	specManager := c.specManager.(*spec.MockManager)
+	desired.Os = &v1alpha1.DeviceOSSpec{Image: "quay.io/example/os:v3"}
+	specManager.EXPECT().CheckOsReconciliation(gomock.Any()).Return("quay.io/example/os:v2", false, nil)
+	require.NoError(restarted.Sync(ctx, desired))
+
+	// images missing from the OS image are pulled
+	specManager.EXPECT().CheckOsReconciliation(gomock.Any()).Return("quay.io/example/os:v3", true, nil)
+	gomock.InOrder(
+		expectEmbeddedImages(`[{"Id":"3333","Names":["quay.io/example/web:latest"]}]`),
+		expectCompose(execMock, "web", file, "pull").Return("", "", 0),
+		expectCompose(execMock, "web", file, "up", "-d").Return("", "", 0),
+	)
+	require.NoError(restarted.Sync(ctx, desired))
+}
@@ -0,0 +1,122 @@
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+// DefaultInterval is the default interval between two attestations.
+const DefaultInterval = time.Hour
+
+var _ Manager = (*manager)(nil)
+
+// Manager periodically quotes the boot measurements of the device with its
+// TPM, so that the management service can detect devices which were tampered
+// with after enrollment.
+type Manager interface {
+	// Run attests the device until the context is canceled.
+	Run(ctx context.Context)
+}
+
+// QuoteFunc quotes the PCRs with the TPM of the device.
+type QuoteFunc func(pcrs []int, nonce []byte) (*tpm.Quote, error)
+
+type manager struct {
+	deviceName       string
+	interval         time.Duration
+	quote            QuoteFunc
+	bootcClient      container.BootcClient
+	managementClient client.Management
+	log              *log.PrefixLogger
+}
+
+// NewManager creates a new attestation manager quoting with the TPM at
+// tpmPath. The device is not attested if tpmPath is empty or interval is zero.
+func NewManager(deviceName string, tpmPath string, interval time.Duration, bootcClient container.BootcClient, managementClient client.Management, log *log.PrefixLogger) Manager {
+	var quote QuoteFunc
+	if tpmPath != "" {
+		quote = func(pcrs []int, nonce []byte) (*tpm.Quote, error) {
+			t, err := tpm.OpenTPM(tpmPath)
+			if err != nil {
+				return nil, fmt.Errorf("opening TPM: %w", err)
+			}
+			defer t.Close()
+			return t.Quote(pcrs, nonce)
+		}
+	}
+	return newManager(deviceName, interval, quote, bootcClient, managementClient, log)
+}
+
+func newManager(deviceName string, interval time.Duration, quote QuoteFunc, bootcClient container.BootcClient, managementClient client.Management, log *log.PrefixLogger) *manager {
+	return &manager{
+		deviceName:       deviceName,
+		interval:         interval,
+		quote:            quote,
+		bootcClient:      bootcClient,
+		managementClient: managementClient,
+		log:              log,
+	}
+}
+
+func (m *manager) Run(ctx context.Context) {
+	if m.quote == nil || m.interval <= 0 {
+		return
+	}
+	m.log.Infof("Attesting the boot measurements every %s", m.interval)
+
+	if err := m.attest(ctx); err != nil {
+		m.log.Errorf("Failed to attest device: %v", err)
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.attest(ctx); err != nil {
+				m.log.Errorf("Failed to attest device: %v", err)
+			}
+		}
+	}
+}
+
+func (m *manager) attest(ctx context.Context) error {
+	challenge, err := m.managementClient.CreateDeviceAttestationChallenge(ctx, m.deviceName)
+	if err != nil {
+		return err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(challenge.Nonce)
+	if err != nil {
+		return fmt.Errorf("decoding nonce: %w", err)
+	}
+	quote, err := m.quote(attestation.QuotedPCRs, nonce)
+	if err != nil {
+		return err
+	}
+
+	report := v1alpha1.AttestationReport{
+		Nonce:          challenge.Nonce,
+		AttestationKey: base64.StdEncoding.EncodeToString(quote.AttestationKey),
+		Quote:          base64.StdEncoding.EncodeToString(quote.Quote),
+		Signature:      base64.StdEncoding.EncodeToString(quote.Signature),
+		Pcrs:           quote.PCRs,
+	}
+	// the service verifies the measurements against those of the booted image
+	host, err := m.bootcClient.Status(ctx)
+	if err != nil {
+		m.log.Debugf("Attesting without the booted image: %v", err)
+	} else if image := host.GetBootedImage(); image != "" {
+		report.BootedImage = &image
+	}
+	return m.managementClient.ReportDeviceAttestation(ctx, m.deviceName, report)
+}
@@ -0,0 +1,67 @@
+package attestation
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttest(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	simulator, err := tpm.OpenTPMSimulator()
+	require.NoError(err)
+	defer simulator.Close()
+
+	mockManagementClient := client.NewMockManagement(ctrl)
+	mockBootcClient := container.NewMockBootcClient(ctrl)
+	m := newManager("mydevice", time.Hour, simulator.Quote, mockBootcClient, mockManagementClient, log.NewPrefixLogger("test"))
+
+	ctx := context.Background()
+	nonce := []byte("0123456789abcdef0123456789abcdef")
+	host := &container.BootcHost{}
+	host.Status.Booted.Image.Image.Image = "quay.io/flightctl/os:v1"
+
+	var report v1alpha1.AttestationReport
+	mockManagementClient.EXPECT().CreateDeviceAttestationChallenge(ctx, "mydevice").
+		Return(&v1alpha1.AttestationChallenge{Nonce: base64.StdEncoding.EncodeToString(nonce)}, nil)
+	mockBootcClient.EXPECT().Status(ctx).Return(host, nil)
+	mockManagementClient.EXPECT().ReportDeviceAttestation(ctx, "mydevice", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, r v1alpha1.AttestationReport, _ ...any) error {
+			report = r
+			return nil
+		})
+	require.NoError(m.attest(ctx))
+
+	// the report holds everything the service needs to verify the quote
+	require.Equal("quay.io/flightctl/os:v1", *report.BootedImage)
+	require.Equal(base64.StdEncoding.EncodeToString(nonce), report.Nonce)
+	akPublic, err := base64.StdEncoding.DecodeString(report.AttestationKey)
+	require.NoError(err)
+	quote, err := base64.StdEncoding.DecodeString(report.Quote)
+	require.NoError(err)
+	signature, err := base64.StdEncoding.DecodeString(report.Signature)
+	require.NoError(err)
+	require.NoError(attestation.VerifyQuote(akPublic, quote, signature, nonce, report.Pcrs))
+}
+
+func TestRunDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// without a TPM the manager returns right away, without any requests
+	m := NewManager("mydevice", "", time.Hour, container.NewMockBootcClient(ctrl), client.NewMockManagement(ctrl), log.NewPrefixLogger("test"))
+	m.Run(context.Background())
+}
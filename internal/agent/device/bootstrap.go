@@ -3,8 +3,8 @@ package device
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"net"
 	"os"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
@@ -13,6 +13,7 @@ import (
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/spec"
 	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/agent/device/watchdog"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -285,6 +286,14 @@ func (b *Bootstrap) isEnrolled() bool {
 
 func (b *Bootstrap) verifyEnrollment(ctx context.Context) (bool, error) {
 	enrollmentRequest, err := b.enrollmentClient.GetEnrollmentRequest(ctx, b.deviceName)
+	if errors.Is(err, client.ErrNotFound) {
+		// pending enrollment requests expire, request enrollment again
+		b.log.Warn("Enrollment request not found, it may have expired before it was approved, requesting enrollment again")
+		if err := b.enrollmentRequest(ctx); err != nil {
+			b.log.Errorf("Error requesting enrollment: %v", err)
+		}
+		return false, nil
+	}
 	if err != nil {
 		b.log.Errorf("Error checking enrollment status: %v", err)
 		return false, nil
@@ -438,25 +447,12 @@ func (b *Bootstrap) setManagementClient() error {
 }
 
 func SdNotify(state string) error {
-	socketAddr := &net.UnixAddr{
-		Name: os.Getenv("NOTIFY_SOCKET"),
-		Net:  "unixgram",
-	}
-
-	// NOTIFY_SOCKET not set
-	if socketAddr.Name == "" {
-		klog.Warningf("NOTIFY_SOCKET not set, skipping systemd notification")
-		return nil
-	}
-	conn, err := net.DialUnix(socketAddr.Net, nil, socketAddr)
+	sent, err := watchdog.Notify(state)
 	if err != nil {
-		return fmt.Errorf("failed to connect to systemd: %w", err)
+		return err
 	}
-	defer conn.Close()
-
-	_, err = conn.Write([]byte("READY=1\n"))
-	if err != nil {
-		return fmt.Errorf("failed to write to systemd: %w", err)
+	if !sent {
+		klog.Warningf("NOTIFY_SOCKET not set, skipping systemd notification")
 	}
 	return nil
 }
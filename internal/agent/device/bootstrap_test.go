@@ -3,6 +3,7 @@ package device
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
@@ -234,3 +235,30 @@ func TestEnsureBootedOS(t *testing.T) {
 		require.NoError(err)
 	})
 }
+
+func TestBootstrapVerifyEnrollmentExpired(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatusManager := status.NewMockManager(ctrl)
+	mockEnrollmentClient := client.NewMockEnrollment(ctrl)
+
+	b := &Bootstrap{
+		statusManager:    mockStatusManager,
+		enrollmentClient: mockEnrollmentClient,
+		deviceName:       "device",
+		log:              flightlog.NewPrefixLogger("test"),
+	}
+	ctx := context.TODO()
+
+	// an expired enrollment request is requested again
+	mockEnrollmentClient.EXPECT().GetEnrollmentRequest(ctx, "device").Return(nil, fmt.Errorf("get enrollmentrequest failed: %w", client.ErrNotFound))
+	mockStatusManager.EXPECT().Collect(ctx).Return(nil)
+	mockStatusManager.EXPECT().Get(ctx).Return(&v1alpha1.DeviceStatus{})
+	mockEnrollmentClient.EXPECT().CreateEnrollmentRequest(ctx, gomock.Any()).Return(&v1alpha1.EnrollmentRequest{}, nil)
+
+	approved, err := b.verifyEnrollment(ctx)
+	require.NoError(err)
+	require.False(approved)
+}
@@ -0,0 +1,170 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/config"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/spec"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+// CheckController lets the agent audit a rendered spec against the device,
+// for example to validate a new fleet template on a few devices before it is
+// applied. In check-only mode, set by the --check flag of the agent or by
+// agent.checkOnly of the spec, the agent computes the changes applying the
+// spec would make, reports them in status.check and makes none of them: the
+// device keeps its current spec, and only the actions requested for it and
+// its console are carried out.
+type CheckController struct {
+	enabled               bool
+	readWriter            fileio.ReadWriter
+	specManager           spec.Manager
+	statusManager         status.Manager
+	applicationController *ApplicationController
+	// reported is the check last reported in the device status
+	reported *v1alpha1.DeviceCheckStatus
+	log      *log.PrefixLogger
+}
+
+func NewCheckController(
+	enabled bool,
+	readWriter fileio.ReadWriter,
+	specManager spec.Manager,
+	statusManager status.Manager,
+	applicationController *ApplicationController,
+	log *log.PrefixLogger,
+) *CheckController {
+	return &CheckController{
+		enabled:               enabled,
+		readWriter:            readWriter,
+		specManager:           specManager,
+		statusManager:         statusManager,
+		applicationController: applicationController,
+		log:                   log,
+	}
+}
+
+// Sync returns whether the agent only checks the desired spec, in which case
+// it reported the changes applying it would make.
+func (c *CheckController) Sync(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) (bool, error) {
+	if !c.enabled && !lo.FromPtr(lo.FromPtr(desired.Agent).CheckOnly) {
+		return false, nil
+	}
+	c.log.Debug("Checking the desired spec")
+	defer c.log.Debug("Finished checking the desired spec")
+
+	changes, err := c.changes(ctx, current, desired)
+	if err != nil {
+		return true, err
+	}
+	c.report(ctx, v1alpha1.DeviceCheckStatus{
+		CheckedAt:       time.Now().UTC(),
+		RenderedVersion: desired.RenderedVersion,
+		Changes:         changes,
+	})
+	return true, nil
+}
+
+// changes returns the changes applying the desired spec would make: the files
+// of the config written or removed, the applications brought up, updated or
+// taken down, the OS image switched to and the other sections of the spec
+// which differ from the current spec.
+func (c *CheckController) changes(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) ([]v1alpha1.DeviceCheckChange, error) {
+	changes := []v1alpha1.DeviceCheckChange{}
+	writes, err := outdatedFiles(c.readWriter, desired, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range writes {
+		changes = append(changes, v1alpha1.DeviceCheckChange{Section: "config", Name: lo.ToPtr(path), Change: "write"})
+	}
+	currentPaths, err := configPaths(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredPaths, err := configPaths(desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range lo.Without(currentPaths, desiredPaths...) {
+		changes = append(changes, v1alpha1.DeviceCheckChange{Section: "config", Name: lo.ToPtr(path), Change: "remove"})
+	}
+
+	applicationChanges, err := c.applicationController.changes(desired, writes)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, applicationChanges...)
+
+	if desired.Os != nil && desired.Os.Image != "" {
+		bootedImage, _, err := c.specManager.CheckOsReconciliation(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if bootedImage != desired.Os.Image {
+			changes = append(changes, v1alpha1.DeviceCheckChange{Section: "os", Name: lo.ToPtr(desired.Os.Image), Change: "switch to"})
+		}
+	}
+
+	// the check-only mode itself is not a change of the agent settings
+	currentAgent, desiredAgent := lo.FromPtr(current.Agent), lo.FromPtr(desired.Agent)
+	currentAgent.CheckOnly, desiredAgent.CheckOnly = nil, nil
+	sections := []struct {
+		name             string
+		current, desired any
+	}{
+		{"agent", currentAgent, desiredAgent},
+		{"compliance", current.Compliance, desired.Compliance},
+		{"drift", current.Drift, desired.Drift},
+		{"encryption", current.Encryption, desired.Encryption},
+		{"firewall", current.Firewall, desired.Firewall},
+		{"garbageCollection", current.GarbageCollection, desired.GarbageCollection},
+		{"hooks", current.Hooks, desired.Hooks},
+		{"resources", current.Resources, desired.Resources},
+		{"time", current.Time, desired.Time},
+	}
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.current, section.desired) {
+			changes = append(changes, v1alpha1.DeviceCheckChange{Section: section.name, Change: "update"})
+		}
+	}
+	return changes, nil
+}
+
+// report sets the check in the device status unless its changes were
+// reported already. A failure is logged, and the check reported on the next
+// sync.
+func (c *CheckController) report(ctx context.Context, check v1alpha1.DeviceCheckStatus) {
+	if c.reported != nil && c.reported.RenderedVersion == check.RenderedVersion && reflect.DeepEqual(c.reported.Changes, check.Changes) {
+		return
+	}
+	c.log.Infof("Checked renderedVersion %s without applying it: %d changes", check.RenderedVersion, len(check.Changes))
+	if _, err := c.statusManager.Update(ctx, status.SetCheck(check)); err != nil {
+		c.log.Warnf("Failed setting check status: %v", err)
+		return
+	}
+	c.reported = &check
+}
+
+// configPaths returns the paths of the files of the config of the spec.
+func configPaths(spec *v1alpha1.RenderedDeviceSpec) ([]string, error) {
+	if spec.Config == nil {
+		return nil, nil
+	}
+	ignition, err := config.ParseAndConvertConfig([]byte(*spec.Config))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the config of renderedVersion %s: %w", spec.RenderedVersion, err)
+	}
+	paths := []string{}
+	for _, file := range ignition.Storage.Files {
+		paths = append(paths, file.Path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,81 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/spec"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckController(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	specManager := spec.NewMockManager(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+	log := flightlog.NewPrefixLogger("")
+
+	applicationController := NewApplicationController("/var/lib/flightctl", execMock, readWriter, statusManager, specManager, DefaultApplicationConcurrency, log)
+	require.NoError(applicationController.writeState(map[string]*applicationState{
+		"legacy": {Project: "legacy"},
+	}))
+	require.NoError(readWriter.WriteFile("/etc/app/settings.conf", []byte("hand-edited"), 0644))
+
+	current := &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		Config:          lo.ToPtr(`{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/app/old.conf","contents":{"source":"data:,old"}}]}}`),
+	}
+	desired := &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "2",
+		Config:          lo.ToPtr(adoptionConfig),
+		Os:              &v1alpha1.DeviceOSSpec{Image: "quay.io/acme/os:2"},
+		Applications:    &[]v1alpha1.ApplicationSpec{{Name: "shop", Path: lo.ToPtr("/etc/compose/shop.yaml")}},
+		Time:            &v1alpha1.DeviceTimeSpec{},
+	}
+
+	// the spec is applied unless the agent only checks it
+	c := NewCheckController(false, readWriter, specManager, statusManager, applicationController, log)
+	checked, err := c.Sync(ctx, current, desired)
+	require.NoError(err)
+	require.False(checked)
+
+	// the changes are reported once, as long as they stay the same
+	desired.Agent = &v1alpha1.DeviceAgentSpec{CheckOnly: lo.ToPtr(true)}
+	specManager.EXPECT().CheckOsReconciliation(gomock.Any()).Return("quay.io/acme/os:1", false, nil).Times(2)
+	var reported v1alpha1.DeviceStatus
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fns ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+		for _, fn := range fns {
+			require.NoError(fn(&reported))
+		}
+		return &reported, nil
+	}).Times(1)
+	for i := 0; i < 2; i++ {
+		checked, err = c.Sync(ctx, current, desired)
+		require.NoError(err)
+		require.True(checked)
+	}
+	require.Equal("2", reported.Check.RenderedVersion)
+	require.Equal([]v1alpha1.DeviceCheckChange{
+		{Section: "config", Name: lo.ToPtr("/etc/app/new.conf"), Change: "write"},
+		{Section: "config", Name: lo.ToPtr("/etc/app/settings.conf"), Change: "write"},
+		{Section: "config", Name: lo.ToPtr("/etc/app/old.conf"), Change: "remove"},
+		{Section: "applications", Name: lo.ToPtr("shop"), Change: "bring up"},
+		{Section: "applications", Name: lo.ToPtr("legacy"), Change: "take down"},
+		{Section: "os", Name: lo.ToPtr("quay.io/acme/os:2"), Change: "switch to"},
+		{Section: "time", Change: "update"},
+	}, reported.Check.Changes)
+	exists, err := readWriter.FileExists("/etc/app/new.conf")
+	require.NoError(err)
+	require.False(exists)
+}
@@ -0,0 +1,322 @@
+package compliance
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	oscapCommand = "/usr/bin/oscap"
+
+	// DefaultInterval is the default interval between two scans.
+	DefaultInterval = 24 * time.Hour
+
+	// statusFile keeps the summary of the last scan across restarts of the
+	// agent, relative to the data dir
+	statusFile = "compliance.json"
+	// resultsFile keeps the XCCDF results of the last scan, relative to the data dir
+	resultsFile = "compliance-results.xml"
+
+	ssgContentDir = "/usr/share/xml/scap/ssg/content"
+	osReleaseFile = "/etc/os-release"
+
+	scanTimeout   = time.Hour
+	checkInterval = time.Minute
+
+	// maxFailedRules bounds the failed rules reported in the device status
+	maxFailedRules = 200
+)
+
+var _ Manager = (*manager)(nil)
+
+// Manager scans the device with OpenSCAP against the compliance profile of
+// the device spec and keeps a summary of the results of the last scan.
+type Manager interface {
+	// Run scans the device whenever a scan is due until the context is canceled.
+	Run(ctx context.Context)
+	// SetSpec sets the compliance profile of the spec, which is scanned
+	// against right away if it changed.
+	SetSpec(spec *v1alpha1.DeviceComplianceSpec)
+	// Status returns the summary of the last scan, or nil if the spec sets
+	// no compliance profile.
+	Status() *v1alpha1.DeviceComplianceStatus
+}
+
+type manager struct {
+	dataDir    string
+	exec       executer.Executer
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+	trigger    chan struct{}
+
+	mu      sync.Mutex
+	specSet bool
+	spec    *v1alpha1.DeviceComplianceSpec
+	status  *v1alpha1.DeviceComplianceStatus
+}
+
+// NewManager creates a new compliance manager keeping the results of the
+// scans in dataDir.
+func NewManager(dataDir string, exec executer.Executer, readWriter fileio.ReadWriter, log *log.PrefixLogger) Manager {
+	return &manager{
+		dataDir:    dataDir,
+		exec:       exec,
+		readWriter: readWriter,
+		log:        log,
+		trigger:    make(chan struct{}, 1),
+	}
+}
+
+func (m *manager) SetSpec(spec *v1alpha1.DeviceComplianceSpec) {
+	m.mu.Lock()
+	changed := !m.specSet || !reflect.DeepEqual(m.spec, spec)
+	m.specSet = true
+	m.spec = spec
+	m.mu.Unlock()
+
+	if changed {
+		select {
+		case m.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (m *manager) Status() *v1alpha1.DeviceComplianceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+func (m *manager) Run(ctx context.Context) {
+	if err := m.loadStatus(); err != nil {
+		m.log.Warnf("Failed to read the last compliance scan: %v", err)
+	}
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.trigger:
+		}
+		if err := m.scanIfDue(ctx); err != nil {
+			m.log.Errorf("Failed to record compliance scan: %v", err)
+		}
+	}
+}
+
+// scanIfDue scans the device if it was not scanned against the profile of
+// the spec yet, or not within the interval of the spec. Scans run one at a
+// time, since they are expensive.
+func (m *manager) scanIfDue(ctx context.Context) error {
+	m.mu.Lock()
+	specSet, spec, last := m.specSet, m.spec, m.status
+	m.mu.Unlock()
+	if !specSet {
+		// the spec is not synced yet
+		return nil
+	}
+	if spec == nil {
+		if last == nil {
+			return nil
+		}
+		m.log.Info("Removing the compliance scan results, the spec sets no compliance profile")
+		m.setStatus(nil)
+		return m.readWriter.RemoveFile(m.statusPath())
+	}
+
+	datastream, datastreamErr := m.datastream(spec)
+	interval := DefaultInterval
+	if spec.Interval != nil {
+		if parsed, err := time.ParseDuration(*spec.Interval); err == nil {
+			interval = parsed
+		}
+	}
+	if last != nil && last.Profile == spec.Profile && lo.FromPtr(last.Datastream) == datastream && time.Since(last.ScannedAt) < interval {
+		return nil
+	}
+
+	var status *v1alpha1.DeviceComplianceStatus
+	if datastreamErr != nil {
+		status = scanError(spec.Profile, datastream, datastreamErr)
+	} else {
+		status = m.scan(ctx, spec.Profile, datastream)
+	}
+	if ctx.Err() != nil {
+		// the agent is stopping, the device is scanned again after it restarted
+		return nil
+	}
+	m.setStatus(status)
+	content, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return m.readWriter.WriteFile(m.statusPath(), content, 0600)
+}
+
+func (m *manager) scan(ctx context.Context, profile string, datastream string) *v1alpha1.DeviceComplianceStatus {
+	m.log.Infof("Scanning the device against compliance profile %s of %s", profile, datastream)
+	ctx, cancel := context.WithTimeout(ctx, scanTimeout)
+	defer cancel()
+
+	results := filepath.Join(m.dataDir, resultsFile)
+	_, stderr, exitCode := m.exec.ExecuteWithContext(ctx, oscapCommand, "xccdf", "eval",
+		"--profile", profile, "--results", m.readWriter.PathFor(results), m.readWriter.PathFor(datastream))
+	// oscap exits with 2 if the device fails any rule
+	if exitCode != 0 && exitCode != 2 {
+		return scanError(profile, datastream, fmt.Errorf("%s: exit code %d: %s", oscapCommand, exitCode, strings.TrimSpace(stderr)))
+	}
+	content, err := m.readWriter.ReadFile(results)
+	if err != nil {
+		return scanError(profile, datastream, err)
+	}
+	status, err := parseResults(content)
+	if err != nil {
+		return scanError(profile, datastream, err)
+	}
+	status.Profile = profile
+	status.Datastream = lo.ToPtr(datastream)
+	m.log.Infof("Device passes %d and fails %d rules of compliance profile %s", status.Passed, status.Failed, profile)
+	return status
+}
+
+func scanError(profile string, datastream string, err error) *v1alpha1.DeviceComplianceStatus {
+	status := &v1alpha1.DeviceComplianceStatus{
+		Profile:   profile,
+		Status:    v1alpha1.DeviceComplianceStatusError,
+		ScannedAt: time.Now().UTC(),
+		Message:   lo.ToPtr(err.Error()),
+	}
+	if datastream != "" {
+		status.Datastream = lo.ToPtr(datastream)
+	}
+	return status
+}
+
+// datastream returns the data stream of the spec, which defaults to the one
+// of the SCAP Security Guide for the OS of the device.
+func (m *manager) datastream(spec *v1alpha1.DeviceComplianceSpec) (string, error) {
+	if spec.Datastream != nil {
+		return *spec.Datastream, nil
+	}
+	osRelease, err := m.readWriter.ReadFile(osReleaseFile)
+	if err != nil {
+		return "", err
+	}
+	return defaultDatastream(osRelease)
+}
+
+// defaultDatastream returns the path of the data stream the SCAP Security
+// Guide installs for the OS of the os-release file, such as ssg-rhel9-ds.xml.
+func defaultDatastream(osRelease []byte) (string, error) {
+	fields := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(osRelease))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok {
+			fields[key] = strings.Trim(value, `"'`)
+		}
+	}
+	id := fields["ID"]
+	major, _, _ := strings.Cut(fields["VERSION_ID"], ".")
+	var name string
+	switch id {
+	case "":
+		return "", fmt.Errorf("%s does not identify the OS", osReleaseFile)
+	case "fedora":
+		name = "fedora"
+	case "centos":
+		// CentOS Stream
+		name = "cs" + major
+	default:
+		name = id + major
+	}
+	return filepath.Join(ssgContentDir, "ssg-"+name+"-ds.xml"), nil
+}
+
+type xccdfBenchmark struct {
+	TestResults []struct {
+		RuleResults []struct {
+			IDRef  string `xml:"idref,attr"`
+			Result string `xml:"result"`
+		} `xml:"rule-result"`
+	} `xml:"TestResult"`
+}
+
+// parseResults summarizes the XCCDF results written by oscap. Rules which are
+// not applicable, not selected or only informational are neither passed nor
+// failed.
+func parseResults(content []byte) (*v1alpha1.DeviceComplianceStatus, error) {
+	var benchmark xccdfBenchmark
+	if err := xml.Unmarshal(content, &benchmark); err != nil {
+		return nil, fmt.Errorf("parsing XCCDF results: %w", err)
+	}
+	if len(benchmark.TestResults) == 0 {
+		return nil, fmt.Errorf("parsing XCCDF results: no test result")
+	}
+	status := &v1alpha1.DeviceComplianceStatus{ScannedAt: time.Now().UTC()}
+	failedRules := []string{}
+	for _, rule := range benchmark.TestResults[len(benchmark.TestResults)-1].RuleResults {
+		switch rule.Result {
+		case "pass", "fixed":
+			status.Passed++
+		case "fail":
+			status.Failed++
+			if len(failedRules) < maxFailedRules {
+				failedRules = append(failedRules, rule.IDRef)
+			}
+		}
+	}
+	status.Status = v1alpha1.DeviceComplianceStatusCompliant
+	if status.Failed > 0 {
+		status.Status = v1alpha1.DeviceComplianceStatusNonCompliant
+		status.FailedRules = &failedRules
+	}
+	return status, nil
+}
+
+func (m *manager) setStatus(status *v1alpha1.DeviceComplianceStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = status
+}
+
+func (m *manager) statusPath() string {
+	return filepath.Join(m.dataDir, statusFile)
+}
+
+// loadStatus reads the summary of the scan before the agent restarted, so
+// that the device is not scanned again before the interval elapsed.
+func (m *manager) loadStatus() error {
+	exists, err := m.readWriter.FileExists(m.statusPath())
+	if err != nil || !exists {
+		return err
+	}
+	content, err := m.readWriter.ReadFile(m.statusPath())
+	if err != nil {
+		return err
+	}
+	var status v1alpha1.DeviceComplianceStatus
+	if err := json.Unmarshal(content, &status); err != nil {
+		return err
+	}
+	m.setStatus(&status)
+	return nil
+}
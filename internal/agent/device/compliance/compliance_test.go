@@ -0,0 +1,160 @@
+package compliance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testResults = `<?xml version="1.0" encoding="UTF-8"?>
+<Benchmark xmlns="http://checklists.nist.gov/xccdf/1.2" id="xccdf_org.ssgproject.content_benchmark_RHEL-9">
+  <TestResult id="xccdf_org.open-scap_testresult_xccdf_org.ssgproject.content_profile_cis">
+    <rule-result idref="xccdf_org.ssgproject.content_rule_package_aide_installed" severity="medium">
+      <result>fail</result>
+    </rule-result>
+    <rule-result idref="xccdf_org.ssgproject.content_rule_sshd_disable_root_login" severity="medium">
+      <result>pass</result>
+    </rule-result>
+    <rule-result idref="xccdf_org.ssgproject.content_rule_partition_for_tmp" severity="low">
+      <result>notapplicable</result>
+    </rule-result>
+    <rule-result idref="xccdf_org.ssgproject.content_rule_grub2_password" severity="high">
+      <result>fail</result>
+    </rule-result>
+    <rule-result idref="xccdf_org.ssgproject.content_rule_selinux_state" severity="high">
+      <result>pass</result>
+    </rule-result>
+  </TestResult>
+</Benchmark>
+`
+
+func TestParseResults(t *testing.T) {
+	require := require.New(t)
+
+	status, err := parseResults([]byte(testResults))
+	require.NoError(err)
+	require.Equal(v1alpha1.DeviceComplianceStatusNonCompliant, status.Status)
+	require.Equal(int32(2), status.Passed)
+	require.Equal(int32(2), status.Failed)
+	require.Equal([]string{
+		"xccdf_org.ssgproject.content_rule_package_aide_installed",
+		"xccdf_org.ssgproject.content_rule_grub2_password",
+	}, *status.FailedRules)
+
+	status, err = parseResults([]byte(`<Benchmark><TestResult><rule-result idref="a"><result>pass</result></rule-result></TestResult></Benchmark>`))
+	require.NoError(err)
+	require.Equal(v1alpha1.DeviceComplianceStatusCompliant, status.Status)
+	require.Nil(status.FailedRules)
+
+	_, err = parseResults([]byte(`<Benchmark></Benchmark>`))
+	require.Error(err)
+}
+
+func TestDefaultDatastream(t *testing.T) {
+	tests := []struct {
+		name      string
+		osRelease string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:      "rhel",
+			osRelease: "NAME=\"Red Hat Enterprise Linux\"\nID=\"rhel\"\nVERSION_ID=\"9.4\"\n",
+			expected:  "/usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml",
+		},
+		{
+			name:      "centos stream",
+			osRelease: "ID=\"centos\"\nVERSION_ID=\"9\"\n",
+			expected:  "/usr/share/xml/scap/ssg/content/ssg-cs9-ds.xml",
+		},
+		{
+			name:      "fedora",
+			osRelease: "ID=fedora\nVERSION_ID=40\n",
+			expected:  "/usr/share/xml/scap/ssg/content/ssg-fedora-ds.xml",
+		},
+		{
+			name:      "no id",
+			osRelease: "NAME=Linux\n",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			datastream, err := defaultDatastream([]byte(tt.osRelease))
+			if tt.expectErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, datastream)
+		})
+	}
+}
+
+func TestScanIfDue(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	rootDir := t.TempDir()
+	readWriter.SetRootdir(rootDir)
+	require.NoError(readWriter.WriteFile("/etc/os-release", []byte("ID=\"rhel\"\nVERSION_ID=\"9.4\"\n"), 0644))
+	require.NoError(os.MkdirAll(filepath.Join(rootDir, "/var/lib/flightctl"), 0755))
+	m := NewManager("/var/lib/flightctl", execMock, readWriter, log.NewPrefixLogger("test")).(*manager)
+	ctx := context.Background()
+
+	// nothing is scanned before the spec is synced
+	require.NoError(m.scanIfDue(ctx))
+	require.Nil(m.Status())
+
+	results := filepath.Join(rootDir, "/var/lib/flightctl", resultsFile)
+	datastream := filepath.Join(rootDir, "/usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml")
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), oscapCommand, "xccdf", "eval", "--profile", "cis", "--results", results, datastream).
+		DoAndReturn(func(ctx context.Context, command string, args ...string) (string, string, int) {
+			require.NoError(os.WriteFile(results, []byte(testResults), 0600))
+			return "", "", 2
+		})
+	m.SetSpec(&v1alpha1.DeviceComplianceSpec{Profile: "cis"})
+	require.NoError(m.scanIfDue(ctx))
+	status := m.Status()
+	require.NotNil(status)
+	require.Equal("cis", status.Profile)
+	require.Equal("/usr/share/xml/scap/ssg/content/ssg-rhel9-ds.xml", *status.Datastream)
+	require.Equal(v1alpha1.DeviceComplianceStatusNonCompliant, status.Status)
+
+	// the device is not scanned again within the interval, even after the agent restarted
+	require.NoError(m.scanIfDue(ctx))
+	restarted := NewManager("/var/lib/flightctl", execMock, readWriter, log.NewPrefixLogger("test")).(*manager)
+	require.NoError(restarted.loadStatus())
+	restarted.SetSpec(&v1alpha1.DeviceComplianceSpec{Profile: "cis"})
+	require.NoError(restarted.scanIfDue(ctx))
+	require.Equal(status.ScannedAt.Unix(), restarted.Status().ScannedAt.Unix())
+
+	// a failing scan is reported as an error
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), oscapCommand, "xccdf", "eval", "--profile", "stig", "--results", results, datastream).
+		Return("", "OpenSCAP Error: No such profile", 1)
+	m.SetSpec(&v1alpha1.DeviceComplianceSpec{Profile: "stig", Interval: lo.ToPtr("12h")})
+	require.NoError(m.scanIfDue(ctx))
+	require.Equal(v1alpha1.DeviceComplianceStatusError, m.Status().Status)
+	require.Contains(*m.Status().Message, "No such profile")
+	require.WithinDuration(time.Now(), m.Status().ScannedAt, time.Minute)
+
+	// the results are removed once the spec sets no profile
+	m.SetSpec(nil)
+	require.NoError(m.scanIfDue(ctx))
+	require.Nil(m.Status())
+	exists, err := readWriter.FileExists(m.statusPath())
+	require.NoError(err)
+	require.False(exists)
+}
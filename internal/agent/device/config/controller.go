@@ -50,8 +50,11 @@ func (c *controller) Initialize(ctx context.Context, current *v1alpha1.RenderedD
 		c.log.Warnf("failed to parse current ignition: %+v", err)
 		return
 	}
-	for _, f := range currentIgnition.Storage.Files {
-		c.hookManager.OnAfterReboot(ctx, f.Path)
+	changes := lo.Map(currentIgnition.Storage.Files, func(f ignv3types.File, _ int) hook.FileChange {
+		return hook.FileChange{Path: f.Path, Operation: v1alpha1.FileOperationReboot}
+	})
+	if len(changes) > 0 {
+		c.hookManager.OnAfterUpdating(ctx, changes)
 	}
 }
 
@@ -96,65 +99,98 @@ func (c *controller) ensureConfigData(ctx context.Context, currentData, desiredD
 
 	// calculate diff between existing and desired files
 	removeFiles := computeRemoval(currentIgnition.Storage.Files, desiredIgnition.Storage.Files)
-	for _, file := range removeFiles {
-		c.log.Infof("Deleting file: %s", file)
-		// trigger delete pre hook and wait for it to complete
-		c.hookManager.OnBeforeRemove(ctx, file)
-		if err := c.deviceWriter.RemoveFile(file); err != nil {
-			return fmt.Errorf("deleting files failed: %w", err)
-		}
-		c.hookManager.OnAfterRemove(ctx, file)
-	}
-
-	if len(desiredIgnition.Storage.Files) == 0 {
-		// no files to write
-		return nil
-	}
-
-	// write ignition files to disk and trigger pre hooks
-	c.log.Debug("Writing ignition files")
-	err = c.WriteIgnitionFiles(ctx, desiredIgnition.Storage.Files)
+	writes, err := c.pendingWrites(desiredIgnition.Storage.Files)
 	if err != nil {
 		c.log.Warnf("Writing ignition files failed: %+v", err)
 		return fmt.Errorf("failed to apply configuration: %w", err)
 	}
-	return nil
+	return c.applyChanges(ctx, removeFiles, writes)
 }
 
 func (c *controller) WriteIgnitionFiles(ctx context.Context, files []ignv3types.File) error {
+	writes, err := c.pendingWrites(files)
+	if err != nil {
+		return err
+	}
+	return c.applyChanges(ctx, nil, writes)
+}
+
+// pendingWrite is a file which is not up to date on disk.
+type pendingWrite struct {
+	file        fileio.ManagedFile
+	path        string
+	createdFile bool
+}
+
+func (c *controller) pendingWrites(files []ignv3types.File) ([]pendingWrite, error) {
+	var writes []pendingWrite
 	for _, file := range files {
 		managedFile := c.deviceWriter.CreateManagedFile(file)
 		upToDate, err := managedFile.IsUpToDate()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if upToDate {
 			continue
 		}
 		exists, err := managedFile.Exists()
 		if err != nil {
-			return err
+			return nil, err
+		}
+		writes = append(writes, pendingWrite{file: managedFile, path: file.Path, createdFile: !exists})
+	}
+	return writes, nil
+}
+
+// applyChanges removes and writes the files. The before updating hooks run
+// for all the changes before any of them is applied, so that a hook matching
+// several files runs once when batched, and the after updating hooks run for
+// the changes applied, even if a later one failed.
+func (c *controller) applyChanges(ctx context.Context, removeFiles []string, writes []pendingWrite) error {
+	changes := lo.Map(removeFiles, func(file string, _ int) hook.FileChange {
+		return hook.FileChange{Path: file, Operation: v1alpha1.FileOperationRemove}
+	})
+	for _, write := range writes {
+		operation := v1alpha1.FileOperationUpdate
+		if write.createdFile {
+			operation = v1alpha1.FileOperationCreate
 		}
-		if !exists {
-			c.hookManager.OnBeforeCreate(ctx, file.Path)
-		} else {
-			c.hookManager.OnBeforeUpdate(ctx, file.Path)
+		changes = append(changes, hook.FileChange{Path: write.path, Operation: operation})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// trigger the pre hooks and wait for them to complete
+	c.hookManager.OnBeforeUpdating(ctx, changes)
+	applied := 0
+	defer func() {
+		if applied > 0 {
+			c.hookManager.OnAfterUpdating(ctx, changes[:applied])
 		}
-		if err := managedFile.Write(); err != nil {
-			c.log.Warnf("failed to write file %s: %v", file.Path, err)
+	}()
+
+	for _, file := range removeFiles {
+		c.log.Infof("Deleting file: %s", file)
+		if err := c.deviceWriter.RemoveFile(file); err != nil {
+			return fmt.Errorf("deleting files failed: %w", err)
+		}
+		applied++
+	}
+
+	c.log.Debug("Writing ignition files")
+	for _, write := range writes {
+		if err := write.file.Write(); err != nil {
+			c.log.Warnf("failed to write file %s: %v", write.path, err)
 			// in order to create clearer error in status in case we fail in temp file creation
 			// we don't want to return temp filename but rather change the error message to return given file path
 			var err2 *fs.PathError
 			if errors.As(err, &err2) {
-				return fmt.Errorf("failed to write file %s: %w", file.Path, err2.Err)
+				return fmt.Errorf("failed to write file %s: %w", write.path, err2.Err)
 			}
 			return err
 		}
-		if !exists {
-			c.hookManager.OnAfterCreate(ctx, file.Path)
-		} else {
-			c.hookManager.OnAfterUpdate(ctx, file.Path)
-		}
+		applied++
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/coreos/ignition/v2/config/shared/errors"
+	ignv3types "github.com/coreos/ignition/v2/config/v3_4/types"
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
@@ -81,15 +82,25 @@ func TestSync(t *testing.T) {
 				log.NewPrefixLogger("test"),
 			)
 			mockHookManager.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			var created []hook.FileChange
 			for _, f := range tt.createdFiles {
 				mockWriter.EXPECT().CreateManagedFile(gomock.Any()).Return(mockManagedFile)
 				mockManagedFile.EXPECT().IsUpToDate().Return(false, nil)
 				mockManagedFile.EXPECT().Exists().Return(false, nil)
 				mockManagedFile.EXPECT().Write().Return(nil)
-				mockHookManager.EXPECT().OnBeforeCreate(gomock.Any(), f)
-				mockHookManager.EXPECT().OnAfterCreate(gomock.Any(), f)
+				created = append(created, hook.FileChange{Path: f, Operation: v1alpha1.FileOperationCreate})
+			}
+			if len(created) > 0 {
+				mockHookManager.EXPECT().OnBeforeUpdating(gomock.Any(), created)
+				mockHookManager.EXPECT().OnAfterUpdating(gomock.Any(), created)
 			}
 
+			// the hooks see the removals and the updates of the sync at once
+			var changes []hook.FileChange
+			for _, f := range tt.removedFiles {
+				mockWriter.EXPECT().RemoveFile(f).Return(nil)
+				changes = append(changes, hook.FileChange{Path: f, Operation: v1alpha1.FileOperationRemove})
+			}
 			for i, f := range lo.Without(tt.createdFiles, tt.removedFiles...) {
 				mockWriter.EXPECT().CreateManagedFile(gomock.Any()).Return(mockManagedFile)
 				upToDate := i%2 == 0
@@ -97,23 +108,23 @@ func TestSync(t *testing.T) {
 				if !upToDate {
 					mockManagedFile.EXPECT().Exists().Return(true, nil)
 					mockManagedFile.EXPECT().Write().Return(nil)
-					mockHookManager.EXPECT().OnBeforeUpdate(gomock.Any(), f)
-					mockHookManager.EXPECT().OnAfterUpdate(gomock.Any(), f)
+					changes = append(changes, hook.FileChange{Path: f, Operation: v1alpha1.FileOperationUpdate})
 				}
 			}
-
-			for _, f := range tt.removedFiles {
-				mockHookManager.EXPECT().OnBeforeRemove(gomock.Any(), f)
-				mockHookManager.EXPECT().OnAfterRemove(gomock.Any(), f)
-				mockWriter.EXPECT().RemoveFile(f).Return(nil)
+			if len(changes) > 0 {
+				mockHookManager.EXPECT().OnBeforeUpdating(gomock.Any(), changes)
+				mockHookManager.EXPECT().OnAfterUpdating(gomock.Any(), changes)
 			}
 			// Write the current config to the disk
 			if tt.current.Config != nil {
 				currentConfigRaw := []byte(*tt.current.Config)
 				currentIgnitionConfig, err := ParseAndConvertConfig(currentConfigRaw)
 				require.NoError(err)
-				for _, f := range currentIgnitionConfig.Storage.Files {
-					mockHookManager.EXPECT().OnAfterReboot(gomock.Any(), f.Path)
+				rebooted := lo.Map(currentIgnitionConfig.Storage.Files, func(f ignv3types.File, _ int) hook.FileChange {
+					return hook.FileChange{Path: f.Path, Operation: v1alpha1.FileOperationReboot}
+				})
+				if len(rebooted) > 0 {
+					mockHookManager.EXPECT().OnAfterUpdating(gomock.Any(), rebooted)
 				}
 				err = controller.WriteIgnitionFiles(ctx, currentIgnitionConfig.Storage.Files)
 				require.NoError(err)
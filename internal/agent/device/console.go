@@ -2,6 +2,7 @@ package device
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -9,8 +10,10 @@ import (
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api_server/agentserver"
+	"github.com/flightctl/flightctl/internal/console"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/metadata"
 )
@@ -19,6 +22,9 @@ type ConsoleController struct {
 	grpcClient grpc_v1.RouterServiceClient
 	log        *log.PrefixLogger
 	deviceName string
+	// identity loads the agent certificate, which signs the key of end-to-end
+	// encrypted sessions
+	identity func() (tls.Certificate, error)
 
 	active           bool
 	streamClient     grpc_v1.RouterService_StreamClient
@@ -30,12 +36,14 @@ type ConsoleController struct {
 func NewConsoleController(
 	grpcClient grpc_v1.RouterServiceClient,
 	deviceName string,
+	identity func() (tls.Certificate, error),
 	executor executer.Executer,
 	log *log.PrefixLogger,
 ) *ConsoleController {
 	return &ConsoleController{
 		grpcClient: grpcClient,
 		deviceName: deviceName,
+		identity:   identity,
 		executor:   executor,
 		log:        log,
 	}
@@ -77,6 +85,24 @@ func (c *ConsoleController) Sync(ctx context.Context, desired *v1alpha1.Rendered
 		return nil
 	}
 	c.log.Infof("starting console for session %s", desired.Console.SessionID)
+
+	// the key of an end-to-end encrypted session is agreed before anything
+	// else runs, so that nothing is sent in the clear
+	var handshake []byte
+	var session *console.Session
+	if operatorKey := lo.FromPtr(desired.Console.PublicKey); operatorKey != "" {
+		identity, err := c.identity()
+		if err != nil {
+			return fmt.Errorf("loading the agent certificate for console session %s: %w", desired.Console.SessionID, err)
+		}
+		handshake, session, err = console.AcceptOperator(operatorKey, desired.Console.SessionID, identity)
+		if err != nil {
+			// the request cannot succeed, so it is not retried
+			c.lastClosedStream = desired.Console.SessionID
+			return fmt.Errorf("agreeing the key of console session %s: %w", desired.Console.SessionID, err)
+		}
+	}
+
 	// add key-value pairs of metadata to context, for now we are ignoring the Console.GRPCEndpoint
 	ctx = metadata.AppendToOutgoingContext(ctx, agentserver.SessionIDKey, desired.Console.SessionID)
 	ctx = metadata.AppendToOutgoingContext(ctx, agentserver.ClientNameKey, c.deviceName)
@@ -92,6 +118,13 @@ func (c *ConsoleController) Sync(ctx context.Context, desired *v1alpha1.Rendered
 	if err != nil {
 		return fmt.Errorf("error creating console stream client: %w", err)
 	}
+	if session != nil {
+		if err := streamClient.Send(&grpc_v1.StreamRequest{Payload: handshake}); err != nil {
+			return fmt.Errorf("error sending console handshake: %w", err)
+		}
+		streamClient = console.EncryptStream(streamClient, session)
+		c.log.Infof("console session %s is encrypted end to end", desired.Console.SessionID)
+	}
 	c.streamClient = streamClient
 	c.active = true
 	c.currentStreamID = desired.Console.SessionID
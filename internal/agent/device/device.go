@@ -17,91 +17,209 @@ import (
 	"github.com/lthibault/jitterbug"
 )
 
+// heartbeatInterval is the interval between two heartbeats of the
+// reconciliation loop while it is waiting for work.
+const heartbeatInterval = 10 * time.Second
+
 // Agent is responsible for managing the applications, configuration and status of the device.
 type Agent struct {
-	name               string
-	deviceWriter       fileio.Writer
-	statusManager      status.Manager
-	specManager        spec.Manager
-	hookManager        hook.Manager
-	configController   config.Controller
-	osImageController  *OSImageController
-	resourceController *resource.Controller
-	consoleController  *ConsoleController
+	name                  string
+	deviceWriter          fileio.Writer
+	statusManager         status.Manager
+	specManager           spec.Manager
+	hookManager           hook.Manager
+	configController      config.Controller
+	osImageController     *OSImageController
+	agentUpdateController *AgentUpdateController
+	encryptionController  *EncryptionController
+	timeSyncController    *TimeSyncController
+	firewallController    *FirewallController
+	quarantineController  *QuarantineController
+	migrationController   *MigrationController
+	actionController      *ActionController
+	checkController       *CheckController
+	adoptionController    *AdoptionController
+	driftController       *DriftController
+	applicationController *ApplicationController
+	resourceController    *resource.Controller
+	consoleController     *ConsoleController
+	tunnelController      *TunnelController
+	updateProgress        *UpdateProgress
 
 	fetchSpecInterval   util.Duration
 	fetchStatusInterval util.Duration
 
+	// intervals requested by the rendered device spec, which take precedence
+	// over the locally configured ones.
+	desiredSpecInterval   time.Duration
+	desiredStatusInterval time.Duration
+	// agent settings of the last rendered device spec
+	agentSpec *v1alpha1.DeviceAgentSpec
+
+	// locally configured intervals, updated when the agent config is reloaded
+	configuredIntervals chan configuredIntervals
+	// requests to sync the device spec before the next spec fetch
+	syncRequests chan struct{}
+	// applies the agent settings of the desired spec which are not handled by
+	// the device agent itself
+	agentSpecHandler func(*v1alpha1.DeviceAgentSpec)
+	// reports to the watchdog that the reconciliation loop makes progress
+	heartbeat func()
+
 	log *log.PrefixLogger
 }
 
+// Dependencies are the managers and controllers the device agent reconciles
+// the device with.
+type Dependencies struct {
+	DeviceWriter          fileio.Writer
+	StatusManager         status.Manager
+	SpecManager           spec.Manager
+	HookManager           hook.Manager
+	ConfigController      config.Controller
+	OSImageController     *OSImageController
+	AgentUpdateController *AgentUpdateController
+	EncryptionController  *EncryptionController
+	TimeSyncController    *TimeSyncController
+	FirewallController    *FirewallController
+	QuarantineController  *QuarantineController
+	MigrationController   *MigrationController
+	ActionController      *ActionController
+	CheckController       *CheckController
+	AdoptionController    *AdoptionController
+	DriftController       *DriftController
+	ApplicationController *ApplicationController
+	ResourceController    *resource.Controller
+	ConsoleController     *ConsoleController
+	TunnelController      *TunnelController
+	UpdateProgress        *UpdateProgress
+}
+
 // NewAgent creates a new device agent.
 func NewAgent(
 	name string,
-	deviceWriter fileio.Writer,
-	statusManager status.Manager,
-	specManager spec.Manager,
 	fetchSpecInterval util.Duration,
 	fetchStatusInterval util.Duration,
-	hookManager hook.Manager,
-	configController config.Controller,
-	osImageController *OSImageController,
-	resourceController *resource.Controller,
-	consoleController *ConsoleController,
+	deps Dependencies,
 	log *log.PrefixLogger,
 ) *Agent {
 	return &Agent{
-		name:                name,
-		deviceWriter:        deviceWriter,
-		statusManager:       statusManager,
-		specManager:         specManager,
-		hookManager:         hookManager,
-		fetchSpecInterval:   fetchSpecInterval,
-		fetchStatusInterval: fetchStatusInterval,
-		configController:    configController,
-		osImageController:   osImageController,
-		resourceController:  resourceController,
-		consoleController:   consoleController,
-		log:                 log,
+		name:                  name,
+		deviceWriter:          deps.DeviceWriter,
+		statusManager:         deps.StatusManager,
+		specManager:           deps.SpecManager,
+		hookManager:           deps.HookManager,
+		fetchSpecInterval:     fetchSpecInterval,
+		fetchStatusInterval:   fetchStatusInterval,
+		desiredSpecInterval:   time.Duration(fetchSpecInterval),
+		desiredStatusInterval: time.Duration(fetchStatusInterval),
+		configController:      deps.ConfigController,
+		osImageController:     deps.OSImageController,
+		agentUpdateController: deps.AgentUpdateController,
+		encryptionController:  deps.EncryptionController,
+		timeSyncController:    deps.TimeSyncController,
+		firewallController:    deps.FirewallController,
+		quarantineController:  deps.QuarantineController,
+		migrationController:   deps.MigrationController,
+		actionController:      deps.ActionController,
+		checkController:       deps.CheckController,
+		adoptionController:    deps.AdoptionController,
+		driftController:       deps.DriftController,
+		applicationController: deps.ApplicationController,
+		resourceController:    deps.ResourceController,
+		consoleController:     deps.ConsoleController,
+		tunnelController:      deps.TunnelController,
+		updateProgress:        deps.UpdateProgress,
+		configuredIntervals:   make(chan configuredIntervals, 1),
+		syncRequests:          make(chan struct{}, 1),
+		log:                   log,
 	}
 }
 
+type configuredIntervals struct {
+	fetchSpec   util.Duration
+	fetchStatus util.Duration
+}
+
+// SetConfiguredIntervals replaces the locally configured spec fetch and status
+// update intervals, for example after the agent config was reloaded. Intervals
+// set by the device spec keep taking precedence.
+func (a *Agent) SetConfiguredIntervals(fetchSpecInterval util.Duration, fetchStatusInterval util.Duration) {
+	// only the latest update is of interest
+	select {
+	case <-a.configuredIntervals:
+	default:
+	}
+	a.configuredIntervals <- configuredIntervals{fetchSpec: fetchSpecInterval, fetchStatus: fetchStatusInterval}
+}
+
+// TriggerSync makes the agent sync the device spec right away rather than at
+// the next spec fetch, for example once the service announced a new spec.
+// Requests made while a sync is pending are coalesced.
+func (a *Agent) TriggerSync() {
+	select {
+	case a.syncRequests <- struct{}{}:
+	default:
+	}
+}
+
+// SetAgentSpecHandler registers the function applying the agent settings of
+// each desired spec, such as the log level, before the spec is synced.
+func (a *Agent) SetAgentSpecHandler(handler func(*v1alpha1.DeviceAgentSpec)) {
+	a.agentSpecHandler = handler
+}
+
+// SetHeartbeat sets the function the reconciliation loop calls whenever it
+// is waiting for work, so that the watchdog finds the loop stuck once a sync
+// does not return.
+func (a *Agent) SetHeartbeat(heartbeat func()) {
+	a.heartbeat = heartbeat
+}
+
 // Run starts the device agent reconciliation loop.
 func (a *Agent) Run(ctx context.Context) error {
-	// TODO: needs tuned
-	fetchSpecTicker := jitterbug.New(time.Duration(a.fetchSpecInterval), &jitterbug.Norm{Stdev: 30 * time.Millisecond, Mean: 0})
-	defer fetchSpecTicker.Stop()
-	fetchStatusTicker := jitterbug.New(time.Duration(a.fetchStatusInterval), &jitterbug.Norm{Stdev: 30 * time.Millisecond, Mean: 0})
-	defer fetchStatusTicker.Stop()
+	fetchSpecTicker := newTicker(a.desiredSpecInterval)
+	defer func() { fetchSpecTicker.Stop() }()
+	fetchStatusTicker := newTicker(a.desiredStatusInterval)
+	defer func() { fetchStatusTicker.Stop() }()
+	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	defer heartbeatTicker.Stop()
+
+	resetTickers := func() {
+		if fetchSpecTicker.Interval != a.desiredSpecInterval {
+			a.log.Infof("Updating spec fetch interval from %s to %s", fetchSpecTicker.Interval, a.desiredSpecInterval)
+			fetchSpecTicker.Stop()
+			fetchSpecTicker = newTicker(a.desiredSpecInterval)
+		}
+		if fetchStatusTicker.Interval != a.desiredStatusInterval {
+			a.log.Infof("Updating status update interval from %s to %s", fetchStatusTicker.Interval, a.desiredStatusInterval)
+			fetchStatusTicker.Stop()
+			fetchStatusTicker = newTicker(a.desiredStatusInterval)
+		}
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
+		case <-heartbeatTicker.C:
+			if a.heartbeat != nil {
+				a.heartbeat()
+			}
+		case intervals := <-a.configuredIntervals:
+			a.fetchSpecInterval = intervals.fetchSpec
+			a.fetchStatusInterval = intervals.fetchStatus
+			a.updateDesiredIntervals()
+			resetTickers()
 		case <-fetchSpecTicker.C:
 			a.log.Debug("Fetching device spec")
-			deviceUpdated, err := a.syncDevice(ctx)
-			if err != nil {
-				infoMsg := fmt.Sprintf("Failed to sync device: %v", err)
-				_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
-					Status: v1alpha1.DeviceSummaryStatusDegraded,
-					Info:   util.StrToPtr(infoMsg),
-				}))
-				if updateErr != nil {
-					a.log.Errorf("Failed to update device status: %v", updateErr)
-				}
-				a.log.Error(infoMsg)
-				continue
+			if a.fetchSpec(ctx) {
+				resetTickers()
 			}
-
-			if deviceUpdated {
-				_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
-					Status: v1alpha1.DeviceSummaryStatusOnline,
-					Info:   nil,
-				}))
-				if updateErr != nil {
-					a.log.Errorf("Updating device status: %v", updateErr)
-				}
+		case <-a.syncRequests:
+			a.log.Debug("Fetching device spec on request")
+			if a.fetchSpec(ctx) {
+				resetTickers()
 			}
 		case <-fetchStatusTicker.C:
 			a.log.Debug("Fetching device status")
@@ -121,6 +239,35 @@ func (a *Agent) Run(ctx context.Context) error {
 	}
 }
 
+// fetchSpec syncs the device spec and reports the outcome in the summary
+// status. It returns false if the sync failed.
+func (a *Agent) fetchSpec(ctx context.Context) bool {
+	deviceUpdated, err := a.syncDevice(ctx)
+	if err != nil {
+		infoMsg := fmt.Sprintf("Failed to sync device: %v", err)
+		_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
+			Status: v1alpha1.DeviceSummaryStatusDegraded,
+			Info:   util.StrToPtr(infoMsg),
+		}))
+		if updateErr != nil {
+			a.log.Errorf("Failed to update device status: %v", updateErr)
+		}
+		a.log.Error(infoMsg)
+		return false
+	}
+
+	if deviceUpdated {
+		_, updateErr := a.statusManager.Update(ctx, status.SetDeviceSummary(v1alpha1.DeviceSummaryStatus{
+			Status: v1alpha1.DeviceSummaryStatusOnline,
+			Info:   nil,
+		}))
+		if updateErr != nil {
+			a.log.Errorf("Updating device status: %v", updateErr)
+		}
+	}
+	return true
+}
+
 func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 	// TODO: make state reads more efficient do we really need the complete
 	// current and desired specs in memory? could we cache the rendered versions?
@@ -134,6 +281,35 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	// actions are carried out even on quarantined devices, before the spec
+	// is applied since they restart the agent
+	if err := a.actionController.Sync(ctx, desired); err != nil {
+		return false, err
+	}
+
+	// a migrating device keeps its current spec, which the instance it
+	// migrates to serves it from the same rendered version
+	if desired.Migration != nil {
+		return false, a.migrationController.Sync(ctx, desired)
+	}
+
+	if err := a.quarantineController.Sync(ctx, current, desired); err != nil {
+		return false, err
+	}
+
+	// a quarantined device keeps its current spec, and the missing console
+	// and tunnel of the quarantine spec close an open console and tunnel
+	if desired.Quarantine != nil {
+		if err := a.consoleController.Sync(ctx, desired); err != nil {
+			a.log.Errorf("Failed to sync console configuration: %s", err)
+		}
+		if err := a.tunnelController.Sync(ctx, desired); err != nil {
+			a.log.Errorf("Failed to sync tunnel configuration: %s", err)
+		}
+		a.log.Warnf("Device is quarantined, not applying rendered specs: %s", desired.Quarantine.Reason)
+		return false, nil
+	}
+
 	if current.RenderedVersion == "" && desired.RenderedVersion == "" {
 		return false, nil
 	}
@@ -141,8 +317,41 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 	if err := a.consoleController.Sync(ctx, desired); err != nil {
 		a.log.Errorf("Failed to sync console configuration: %s", err)
 	}
+	if err := a.tunnelController.Sync(ctx, desired); err != nil {
+		a.log.Errorf("Failed to sync tunnel configuration: %s", err)
+	}
+
+	// in check-only mode the changes the spec would make are reported
+	// rather than made
+	checked, err := a.checkController.Sync(ctx, current, desired)
+	if err != nil {
+		return false, err
+	}
+	if checked {
+		return false, nil
+	}
+
+	// an adopted device keeps its existing state until the spec is
+	// enforced, while its console serves the review of the state
+	held, err := a.adoptionController.Sync(ctx, current, desired)
+	if err != nil {
+		return false, err
+	}
+	if held {
+		return false, nil
+	}
+
+	// the allowed hook paths and monitor thresholds apply to the hooks and
+	// monitors synced below
+	if a.agentSpecHandler != nil {
+		a.agentSpecHandler(desired.Agent)
+	}
+
+	// the history of the updates is reported again after a restart of the agent
+	a.updateProgress.ReportHistory(ctx)
 
 	if spec.IsUpdating(current, desired) {
+		a.updateProgress.Start(desired.RenderedVersion)
 		updateErr := a.statusManager.UpdateCondition(ctx, v1alpha1.Condition{
 			Type:    v1alpha1.DeviceUpdating,
 			Status:  v1alpha1.ConditionStatusTrue,
@@ -154,11 +363,28 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 		}
 	}
 
+	// between spec changes the device is checked for drift, which the sync
+	// of the spec reverts unless the drift policy only reports it
+	remediate := true
+	if !spec.IsUpdating(current, desired) {
+		if remediate, err = a.driftController.Sync(ctx, desired, time.Now()); err != nil {
+			a.log.Warnf("Failed to check the device for drift: %v", err)
+		}
+	}
+
 	if err := a.hookManager.Sync(current, desired); err != nil {
 		return false, err
 	}
 
-	if err := a.configController.Sync(ctx, current, desired); err != nil {
+	if remediate {
+		if err := a.configController.Sync(ctx, current, desired); err != nil {
+			return false, err
+		}
+	}
+
+	// the compose files of the applications are written by the config sync
+	a.updateProgress.Phase(ctx, v1alpha1.DeviceUpdatePhaseUpdatingApplications, "Bringing up and updating the applications")
+	if err := a.applicationController.Sync(ctx, desired); err != nil {
 		return false, err
 	}
 
@@ -170,9 +396,27 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	if err := a.agentUpdateController.Sync(ctx, desired); err != nil {
+		return false, err
+	}
+
+	if err := a.encryptionController.Sync(ctx, desired); err != nil {
+		return false, err
+	}
+
+	if err := a.timeSyncController.Sync(ctx, desired); err != nil {
+		return false, err
+	}
+
+	if err := a.firewallController.Sync(ctx, desired); err != nil {
+		return false, err
+	}
+
 	// set status collector properties based on new desired spec
 	a.statusManager.SetProperties(desired)
 
+	a.setDesiredIntervals(desired)
+
 	if !spec.IsUpdating(current, desired) {
 		return false, nil
 	}
@@ -203,6 +447,21 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 		}))
 	}
 
+	// the digests the service recorded for the applied version trace what
+	// the device runs back to the content of the registries
+	var provenance *v1alpha1.DeviceProvenanceStatus
+	if desired.ImageDigests != nil {
+		provenance = &v1alpha1.DeviceProvenanceStatus{
+			RenderedVersion: desired.RenderedVersion,
+			Images:          *desired.ImageDigests,
+		}
+	}
+	updateFns = append(updateFns, status.SetProvenance(provenance))
+	updateFns = append(updateFns, status.SetUpdateProgress(nil))
+	if history := a.updateProgress.Stop(); history != nil {
+		updateFns = append(updateFns, status.SetUpdateHistory(*history))
+	}
+
 	_, updateErr = a.statusManager.Update(ctx, updateFns...)
 	if updateErr != nil {
 		a.log.Warnf("Failed setting status: %v", updateErr)
@@ -212,3 +471,50 @@ func (a *Agent) syncDevice(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+// setDesiredIntervals updates the spec fetch and status update intervals from
+// the desired spec, falling back to the agent's configured intervals for any
+// that are unset or invalid.
+func (a *Agent) setDesiredIntervals(desired *v1alpha1.RenderedDeviceSpec) {
+	a.agentSpec = desired.Agent
+	a.updateDesiredIntervals()
+}
+
+func (a *Agent) updateDesiredIntervals() {
+	a.desiredSpecInterval = time.Duration(a.fetchSpecInterval)
+	a.desiredStatusInterval = time.Duration(a.fetchStatusInterval)
+	if a.agentSpec == nil {
+		return
+	}
+
+	if interval, err := parseInterval(a.agentSpec.SpecFetchInterval); err != nil {
+		a.log.Warnf("Ignoring spec fetch interval: %v", err)
+	} else if interval > 0 {
+		a.desiredSpecInterval = interval
+	}
+
+	if interval, err := parseInterval(a.agentSpec.StatusUpdateInterval); err != nil {
+		a.log.Warnf("Ignoring status update interval: %v", err)
+	} else if interval > 0 {
+		a.desiredStatusInterval = interval
+	}
+}
+
+func parseInterval(interval *string) (time.Duration, error) {
+	if interval == nil {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(*interval)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("interval must be positive: %s", *interval)
+	}
+	return d, nil
+}
+
+func newTicker(interval time.Duration) *jitterbug.Ticker {
+	// TODO: needs tuned
+	return jitterbug.New(interval, &jitterbug.Norm{Stdev: 30 * time.Millisecond, Mean: 0})
+}
@@ -0,0 +1,69 @@
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDesiredIntervals(t *testing.T) {
+	defaultSpecInterval := util.Duration(60 * time.Second)
+	defaultStatusInterval := util.Duration(30 * time.Second)
+
+	testCases := []struct {
+		name                   string
+		agent                  *v1alpha1.DeviceAgentSpec
+		expectedSpecInterval   time.Duration
+		expectedStatusInterval time.Duration
+	}{
+		{
+			name:                   "no agent settings",
+			expectedSpecInterval:   time.Duration(defaultSpecInterval),
+			expectedStatusInterval: time.Duration(defaultStatusInterval),
+		},
+		{
+			name: "independent intervals",
+			agent: &v1alpha1.DeviceAgentSpec{
+				SpecFetchInterval:    util.StrToPtr("10s"),
+				StatusUpdateInterval: util.StrToPtr("5m"),
+			},
+			expectedSpecInterval:   10 * time.Second,
+			expectedStatusInterval: 5 * time.Minute,
+		},
+		{
+			name: "only status interval",
+			agent: &v1alpha1.DeviceAgentSpec{
+				StatusUpdateInterval: util.StrToPtr("2h"),
+			},
+			expectedSpecInterval:   time.Duration(defaultSpecInterval),
+			expectedStatusInterval: 2 * time.Hour,
+		},
+		{
+			name: "invalid intervals fall back to defaults",
+			agent: &v1alpha1.DeviceAgentSpec{
+				SpecFetchInterval:    util.StrToPtr("soon"),
+				StatusUpdateInterval: util.StrToPtr("-1s"),
+			},
+			expectedSpecInterval:   time.Duration(defaultSpecInterval),
+			expectedStatusInterval: time.Duration(defaultStatusInterval),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			a := &Agent{
+				fetchSpecInterval:   defaultSpecInterval,
+				fetchStatusInterval: defaultStatusInterval,
+				log:                 flightlog.NewPrefixLogger("test"),
+			}
+			a.setDesiredIntervals(&v1alpha1.RenderedDeviceSpec{Agent: tc.agent})
+			require.Equal(tc.expectedSpecInterval, a.desiredSpecInterval)
+			require.Equal(tc.expectedStatusInterval, a.desiredStatusInterval)
+		})
+	}
+}
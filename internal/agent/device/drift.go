@@ -0,0 +1,193 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/config"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultDriftInterval = 5 * time.Minute
+	// driftMessageItems is the number of drifted items named in the message
+	// of the Drifted condition, all of them being listed in status.drift
+	driftMessageItems = 5
+)
+
+// DriftController checks, between spec changes, that the device still
+// matches its rendered spec: that the files of the config of the spec are
+// unchanged, that the services of the pod applications are active and that
+// the containers of the compose applications run. It reports the drift it
+// finds in the Drifted condition and status.drift.
+//
+// With the Remediate remediation of the drift policy, the default, the sync
+// of the spec reverts the drift: the config files are written again and the
+// applications are brought up again. With Report, the device is left as is
+// until the next spec change.
+type DriftController struct {
+	readWriter            fileio.ReadWriter
+	statusManager         status.Manager
+	applicationController *ApplicationController
+	// lastCheck is the time of the last check, which the device is checked
+	// again an interval of the drift policy after
+	lastCheck time.Time
+	reported  *v1alpha1.DeviceDriftStatus
+	log       *log.PrefixLogger
+}
+
+func NewDriftController(
+	readWriter fileio.ReadWriter,
+	statusManager status.Manager,
+	applicationController *ApplicationController,
+	log *log.PrefixLogger,
+) *DriftController {
+	return &DriftController{
+		readWriter:            readWriter,
+		statusManager:         statusManager,
+		applicationController: applicationController,
+		log:                   log,
+	}
+}
+
+// Sync checks the device for drift from the desired spec, which the device
+// runs already, once the interval of the drift policy elapsed. It returns
+// whether the sync of the spec reverts the drift.
+func (c *DriftController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec, now time.Time) (bool, error) {
+	policy := lo.FromPtr(desired.Drift)
+	remediate := lo.FromPtr(policy.Remediation) != v1alpha1.DriftReport
+	interval := defaultDriftInterval
+	if policy.Interval != nil {
+		if d, err := time.ParseDuration(*policy.Interval); err == nil && d > 0 {
+			interval = d
+		}
+	}
+	if now.Sub(c.lastCheck) < interval {
+		return remediate, nil
+	}
+	c.log.Debug("Checking the device for drift")
+	defer c.log.Debug("Finished checking the device for drift")
+
+	files, err := outdatedFiles(c.readWriter, desired, true)
+	if err != nil {
+		return remediate, err
+	}
+	units, containers, err := c.applicationController.drift(ctx)
+	if err != nil {
+		return remediate, err
+	}
+	c.lastCheck = now
+
+	drift := v1alpha1.DeviceDriftStatus{CheckedAt: now.UTC(), Files: files, Units: units, Containers: containers}
+	drifted := len(files)+len(units)+len(containers) > 0
+	if c.reported != nil {
+		drift.RemediatedAt = c.reported.RemediatedAt
+	}
+	if drifted && remediate {
+		drift.RemediatedAt = lo.ToPtr(now.UTC())
+		c.applicationController.Remediate()
+	}
+	c.report(ctx, desired.RenderedVersion, drift, remediate)
+	return remediate, nil
+}
+
+// report sets the drift in the device status, and the Drifted condition if it
+// changed. A failure is logged, and the drift reported on the next check.
+func (c *DriftController) report(ctx context.Context, renderedVersion string, drift v1alpha1.DeviceDriftStatus, remediate bool) {
+	if _, err := c.statusManager.Update(ctx, status.SetDrift(drift)); err != nil {
+		c.log.Warnf("Failed setting drift status: %v", err)
+		return
+	}
+	if c.reported != nil && reflect.DeepEqual(driftItems(*c.reported), driftItems(drift)) {
+		c.reported = &drift
+		return
+	}
+	if err := c.statusManager.UpdateCondition(ctx, driftedCondition(renderedVersion, drift, remediate)); err != nil {
+		c.log.Warnf("Failed setting drifted condition: %v", err)
+		return
+	}
+	c.reported = &drift
+}
+
+// driftedCondition returns the Drifted condition of the drift, which names
+// the first drifted items.
+func driftedCondition(renderedVersion string, drift v1alpha1.DeviceDriftStatus, remediate bool) v1alpha1.Condition {
+	items := driftItems(drift)
+	if len(items) == 0 {
+		return v1alpha1.Condition{
+			Type:    v1alpha1.DeviceDrifted,
+			Status:  v1alpha1.ConditionStatusFalse,
+			Reason:  "NoDrift",
+			Message: fmt.Sprintf("The device matches renderedVersion %s", renderedVersion),
+		}
+	}
+	named := strings.Join(lo.Slice(items, 0, driftMessageItems), ", ")
+	if len(items) > driftMessageItems {
+		named += fmt.Sprintf(" and %d more", len(items)-driftMessageItems)
+	}
+	condition := v1alpha1.Condition{
+		Type:    v1alpha1.DeviceDrifted,
+		Status:  v1alpha1.ConditionStatusTrue,
+		Reason:  "Drifted",
+		Message: fmt.Sprintf("The device drifted from renderedVersion %s: %s", renderedVersion, named),
+	}
+	if remediate {
+		condition.Reason = "Remediating"
+		condition.Message += ". The drift is being reverted"
+	}
+	return condition
+}
+
+// driftItems returns the drifted files, units and containers.
+func driftItems(drift v1alpha1.DeviceDriftStatus) []string {
+	items := append([]string{}, drift.Files...)
+	items = append(items, drift.Units...)
+	return append(items, drift.Containers...)
+}
+
+// outdatedFiles returns the files of the config of the spec whose content
+// differs on the device, and those missing from the device if missing is set.
+func outdatedFiles(readWriter fileio.ReadWriter, spec *v1alpha1.RenderedDeviceSpec, missing bool) ([]string, error) {
+	files := []string{}
+	if spec.Config == nil {
+		return files, nil
+	}
+	ignition, err := config.ParseAndConvertConfig([]byte(*spec.Config))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the config of renderedVersion %s: %w", spec.RenderedVersion, err)
+	}
+	for _, file := range ignition.Storage.Files {
+		// a managed file is looked up at its path as is, rather than in the
+		// root dir the agent writes it to
+		path := file.Path
+		file.Path = readWriter.PathFor(path)
+		managedFile := readWriter.CreateManagedFile(file)
+		exists, err := managedFile.Exists()
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			if missing {
+				files = append(files, path)
+			}
+			continue
+		}
+		upToDate, err := managedFile.IsUpToDate()
+		if err != nil {
+			return nil, err
+		}
+		if !upToDate {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
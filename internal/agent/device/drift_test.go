@@ -0,0 +1,107 @@
+package device
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDriftController(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+	log := flightlog.NewPrefixLogger("")
+
+	dataDir := "/var/lib/flightctl"
+	applicationController := NewApplicationController(dataDir, execMock, readWriter, statusManager, nil, DefaultApplicationConcurrency, log)
+	require.NoError(applicationController.writeState(map[string]*applicationState{
+		"shop":    {Project: "shop-1"},
+		"monitor": {Project: "monitor-1", QuadletFiles: []string{podQuadletFile("monitor")}},
+	}))
+	require.NoError(readWriter.WriteFile("/etc/app/settings.conf", []byte("managed"), 0644))
+	require.NoError(readWriter.WriteFile("/etc/app/new.conf", []byte("managed"), 0644))
+	applicationController.started = true
+
+	var reported v1alpha1.DeviceStatus
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fns ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+		for _, fn := range fns {
+			require.NoError(fn(&reported))
+		}
+		return &reported, nil
+	}).AnyTimes()
+	var conditions []v1alpha1.Condition
+	statusManager.EXPECT().UpdateCondition(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, condition v1alpha1.Condition) error {
+		conditions = append(conditions, condition)
+		return nil
+	}).AnyTimes()
+	expectApplications := func(podActive bool, containers string) {
+		exitCode := 0
+		if !podActive {
+			exitCode = 3
+		}
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "is-active", "--quiet", quadletService(podQuadletFile("monitor"))).
+			Return("", "", exitCode)
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "ps", "--all", "--filter", "label="+composeProjectLabel+"=shop-1", "--format", "json").
+			Return(containers, "", 0)
+	}
+
+	c := NewDriftController(readWriter, statusManager, applicationController, log)
+	desired := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Config: lo.ToPtr(adoptionConfig)}
+	now := time.Now()
+
+	// a device matching its spec reports no drift
+	expectApplications(true, `[{"Names":["shop-1_web_1"],"State":"running"},{"Names":["shop-1_migrate_1"],"State":"exited","ExitCode":0}]`)
+	remediate, err := c.Sync(ctx, desired, now)
+	require.NoError(err)
+	require.True(remediate)
+	require.Len(conditions, 1)
+	require.Equal(v1alpha1.ConditionStatusFalse, conditions[0].Status)
+	require.Empty(reported.Drift.Files)
+
+	// the device is checked again once the interval elapsed
+	remediate, err = c.Sync(ctx, desired, now.Add(time.Minute))
+	require.NoError(err)
+	require.True(remediate)
+
+	// the drift is reported, and the applications brought up again
+	require.NoError(readWriter.WriteFile("/etc/app/settings.conf", []byte("hand-edited"), 0644))
+	require.NoError(readWriter.RemoveFile("/etc/app/new.conf"))
+	expectApplications(false, `[{"Names":["shop-1_web_1"],"State":"exited","ExitCode":137}]`)
+	now = now.Add(defaultDriftInterval)
+	remediate, err = c.Sync(ctx, desired, now)
+	require.NoError(err)
+	require.True(remediate)
+	require.Equal([]string{"/etc/app/new.conf", "/etc/app/settings.conf"}, reported.Drift.Files)
+	require.Equal([]string{"flightctl-monitor-pod.service"}, reported.Drift.Units)
+	require.Equal([]string{"shop/shop-1_web_1"}, reported.Drift.Containers)
+	require.NotNil(reported.Drift.RemediatedAt)
+	require.Len(conditions, 2)
+	require.Equal(v1alpha1.ConditionStatusTrue, conditions[1].Status)
+	require.Equal("Remediating", conditions[1].Reason)
+	require.False(applicationController.started)
+
+	// the report-only policy leaves the drift as is
+	applicationController.started = true
+	desired.Drift = &v1alpha1.DeviceDriftSpec{Remediation: lo.ToPtr(v1alpha1.DriftReport), Interval: lo.ToPtr("1m")}
+	expectApplications(false, `[]`)
+	remediate, err = c.Sync(ctx, desired, now.Add(time.Minute))
+	require.NoError(err)
+	require.False(remediate)
+	require.Equal([]string{"shop"}, reported.Drift.Containers)
+	require.Len(conditions, 3)
+	require.Equal("Drifted", conditions[2].Reason)
+	require.True(applicationController.started)
+}
@@ -0,0 +1,364 @@
+package device
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	cryptsetupCommand = "/usr/sbin/cryptsetup"
+	clevisCommand     = "/usr/bin/clevis"
+
+	// encryptionCommandTimeout bounds the clevis commands, which may have to
+	// reach the tang servers
+	encryptionCommandTimeout = 2 * time.Minute
+)
+
+// clevisBindingPattern matches the bindings listed by clevis luks list, such as
+// 1: tpm2 '{"hash":"sha256","key":"ecc","pcr_bank":"sha256","pcr_ids":"7"}'
+var clevisBindingPattern = regexp.MustCompile(`^(\d+): (\S+) '(.*)'$`)
+
+// EncryptionController binds the LUKS volumes of the disk encryption policy to
+// the Clevis pins of the policy and reports whether they comply with it. It
+// neither encrypts volumes nor touches their key slots which are not bound to
+// Clevis, such as a recovery passphrase.
+type EncryptionController struct {
+	exec          executer.Executer
+	statusManager status.Manager
+	reported      *v1alpha1.DeviceEncryptionStatus
+	log           *log.PrefixLogger
+}
+
+func NewEncryptionController(
+	exec executer.Executer,
+	statusManager status.Manager,
+	log *log.PrefixLogger,
+) *EncryptionController {
+	return &EncryptionController{
+		exec:          exec,
+		statusManager: statusManager,
+		log:           log,
+	}
+}
+
+// Sync binds the volumes of the desired encryption policy and reports their
+// status. Volumes which cannot be bound are reported rather than failing the
+// sync, so that they do not hold back the rest of the spec, and are retried on
+// the next sync.
+func (c *EncryptionController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing disk encryption")
+	defer c.log.Debug("Finished syncing disk encryption")
+
+	var encryptionStatus *v1alpha1.DeviceEncryptionStatus
+	if desired.Encryption != nil {
+		encryptionStatus = &v1alpha1.DeviceEncryptionStatus{Volumes: []v1alpha1.EncryptedVolumeStatus{}}
+		for _, volume := range desired.Encryption.Volumes {
+			encryptionStatus.Volumes = append(encryptionStatus.Volumes, c.ensureVolume(ctx, volume))
+		}
+	}
+	c.updateStatus(ctx, encryptionStatus)
+	return nil
+}
+
+func (c *EncryptionController) ensureVolume(ctx context.Context, volume v1alpha1.EncryptedVolumeSpec) v1alpha1.EncryptedVolumeStatus {
+	result := func(state v1alpha1.EncryptedVolumeState, message string) v1alpha1.EncryptedVolumeStatus {
+		if state != v1alpha1.EncryptedVolumeCompliant {
+			c.log.Warnf("Volume %s does not comply with the disk encryption policy: %s", volume.Device, message)
+		}
+		return v1alpha1.EncryptedVolumeStatus{Device: volume.Device, State: state, Message: lo.ToPtr(message)}
+	}
+
+	isLuks, err := c.isLuks(ctx, volume.Device)
+	if err != nil {
+		return result(v1alpha1.EncryptedVolumeError, err.Error())
+	}
+	if !isLuks {
+		return result(v1alpha1.EncryptedVolumeUnencrypted, "The volume is not encrypted with LUKS")
+	}
+
+	policy := desiredClevisPolicy(volume)
+	bindings, err := c.listBindings(ctx, volume.Device)
+	if err != nil {
+		return result(v1alpha1.EncryptedVolumeError, err.Error())
+	}
+	match := findClevisBinding(bindings, policy)
+	if match == nil {
+		config, err := clevisConfig(volume)
+		if err != nil {
+			return result(v1alpha1.EncryptedVolumeError, err.Error())
+		}
+		// only sss bindings can be edited into the policy, as clevis keeps
+		// the pin of the binding it edits
+		editable, hasEditable := lo.Find(bindings, func(b clevisBinding) bool { return b.pin == "sss" })
+		switch {
+		case hasEditable:
+			c.log.Infof("Rebinding volume %s in slot %s to the disk encryption policy", volume.Device, editable.slot)
+			err = c.clevis(ctx, "luks", "edit", "-d", volume.Device, "-s", editable.slot, "-c", config)
+		case volume.KeyFile != nil:
+			c.log.Infof("Binding volume %s to the disk encryption policy", volume.Device)
+			// the advertisement of tang servers without a thumbprint is trusted
+			err = c.clevis(ctx, "luks", "bind", "-y", "-d", volume.Device, "-k", *volume.KeyFile, "sss", config)
+		case len(bindings) > 0:
+			return result(v1alpha1.EncryptedVolumeNonCompliant, fmt.Sprintf("The %s binding in slot %s differs from the policy and cannot be edited, a keyFile is required to bind the volume again", bindings[0].pin, bindings[0].slot))
+		default:
+			return result(v1alpha1.EncryptedVolumeNonCompliant, "The volume is not bound to Clevis, a keyFile is required to bind it")
+		}
+		if err != nil {
+			return result(v1alpha1.EncryptedVolumeError, err.Error())
+		}
+
+		if bindings, err = c.listBindings(ctx, volume.Device); err != nil {
+			return result(v1alpha1.EncryptedVolumeError, err.Error())
+		}
+		if match = findClevisBinding(bindings, policy); match == nil {
+			return result(v1alpha1.EncryptedVolumeError, "The binding of the volume does not match the policy after binding it")
+		}
+	}
+
+	// other bindings would unlock the volume without satisfying the policy
+	for _, binding := range bindings {
+		if binding.slot == match.slot {
+			continue
+		}
+		c.log.Infof("Unbinding slot %s of volume %s, which is not part of the disk encryption policy", binding.slot, volume.Device)
+		if err := c.clevis(ctx, "luks", "unbind", "-f", "-d", volume.Device, "-s", binding.slot); err != nil {
+			return result(v1alpha1.EncryptedVolumeError, err.Error())
+		}
+	}
+
+	volumeStatus := result(v1alpha1.EncryptedVolumeCompliant, fmt.Sprintf("The volume is bound to the policy in slot %s", match.slot))
+	volumeStatus.Pins = lo.ToPtr(lo.Uniq(match.policy.pins))
+	return volumeStatus
+}
+
+func (c *EncryptionController) isLuks(ctx context.Context, device string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, encryptionCommandTimeout)
+	defer cancel()
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, cryptsetupCommand, "isLuks", device)
+	switch exitCode {
+	case 0:
+		return true, nil
+	case 1:
+		return false, nil
+	default:
+		return false, fmt.Errorf("checking volume %s: exit code %d: %s", device, exitCode, strings.TrimSpace(stderr))
+	}
+}
+
+func (c *EncryptionController) clevis(ctx context.Context, args ...string) error {
+	_, err := c.clevisOutput(ctx, args...)
+	return err
+}
+
+func (c *EncryptionController) clevisOutput(ctx context.Context, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, encryptionCommandTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := c.exec.ExecuteWithContext(ctx, clevisCommand, args...)
+	if exitCode != 0 {
+		return "", fmt.Errorf("clevis %s %s: exit code %d: %s", args[0], args[1], exitCode, strings.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
+type clevisBinding struct {
+	slot   string
+	pin    string
+	policy *clevisPolicy
+}
+
+func (c *EncryptionController) listBindings(ctx context.Context, device string) ([]clevisBinding, error) {
+	stdout, err := c.clevisOutput(ctx, "luks", "list", "-d", device)
+	if err != nil {
+		return nil, err
+	}
+	bindings := []clevisBinding{}
+	for _, line := range strings.Split(stdout, "\n") {
+		matches := clevisBindingPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		binding := clevisBinding{slot: matches[1], pin: matches[2]}
+		binding.policy, err = parseClevisPolicy(binding.pin, []byte(matches[3]))
+		if err != nil {
+			// the binding is replaced like any other which differs from the policy
+			c.log.Warnf("Failed to parse the %s binding in slot %s of volume %s: %v", binding.pin, binding.slot, device, err)
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+func (c *EncryptionController) updateStatus(ctx context.Context, encryptionStatus *v1alpha1.DeviceEncryptionStatus) {
+	if reflect.DeepEqual(c.reported, encryptionStatus) {
+		return
+	}
+	if _, err := c.statusManager.Update(ctx, status.SetEncryption(encryptionStatus)); err != nil {
+		c.log.Warnf("Failed setting status: %v", err)
+		return
+	}
+	c.reported = encryptionStatus
+}
+
+// clevisPolicy is the part of a Clevis binding the encryption policy
+// determines. Clevis does not list the thumbprints of tang servers, so they
+// are only used when binding.
+type clevisPolicy struct {
+	threshold int
+	// pins are the sorted types of the pins, once per pin
+	pins []string
+	// pcrs are the sorted PCRs of the tpm2 pin
+	pcrs []int
+	// tangURLs are the sorted URLs of the tang pins
+	tangURLs []string
+}
+
+func (p *clevisPolicy) equal(other *clevisPolicy) bool {
+	return other != nil && p.threshold == other.threshold && slices.Equal(p.pins, other.pins) &&
+		slices.Equal(p.pcrs, other.pcrs) && slices.Equal(p.tangURLs, other.tangURLs)
+}
+
+func findClevisBinding(bindings []clevisBinding, policy *clevisPolicy) *clevisBinding {
+	for i := range bindings {
+		if policy.equal(bindings[i].policy) {
+			return &bindings[i]
+		}
+	}
+	return nil
+}
+
+func desiredClevisPolicy(volume v1alpha1.EncryptedVolumeSpec) *clevisPolicy {
+	policy := &clevisPolicy{threshold: lo.FromPtrOr(volume.Threshold, 1)}
+	if volume.Tpm2 != nil {
+		policy.pins = append(policy.pins, "tpm2")
+		policy.pcrs = slices.Clone(lo.FromPtr(volume.Tpm2.Pcrs))
+	}
+	for _, tang := range lo.FromPtr(volume.Tang) {
+		policy.pins = append(policy.pins, "tang")
+		policy.tangURLs = append(policy.tangURLs, tang.Url)
+	}
+	policy.sort()
+	return policy
+}
+
+// parseClevisPolicy parses the config of a binding as listed by clevis. Pins
+// other than sss are the only pin of a policy with a threshold of 1.
+func parseClevisPolicy(pin string, config []byte) (*clevisPolicy, error) {
+	policy := &clevisPolicy{threshold: 1}
+	if pin != "sss" {
+		var pinConfig map[string]any
+		if err := json.Unmarshal(config, &pinConfig); err != nil {
+			return nil, err
+		}
+		if err := policy.add(pin, pinConfig); err != nil {
+			return nil, err
+		}
+		policy.sort()
+		return policy, nil
+	}
+
+	var sss struct {
+		T    int                        `json:"t"`
+		Pins map[string]json.RawMessage `json:"pins"`
+	}
+	if err := json.Unmarshal(config, &sss); err != nil {
+		return nil, err
+	}
+	policy.threshold = sss.T
+	for name, raw := range sss.Pins {
+		// a pin is listed as an array if it is used more than once
+		var pinConfigs []map[string]any
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+			if err := json.Unmarshal(raw, &pinConfigs); err != nil {
+				return nil, err
+			}
+		} else {
+			var pinConfig map[string]any
+			if err := json.Unmarshal(raw, &pinConfig); err != nil {
+				return nil, err
+			}
+			pinConfigs = append(pinConfigs, pinConfig)
+		}
+		for _, pinConfig := range pinConfigs {
+			if err := policy.add(name, pinConfig); err != nil {
+				return nil, err
+			}
+		}
+	}
+	policy.sort()
+	return policy, nil
+}
+
+func (p *clevisPolicy) add(pin string, config map[string]any) error {
+	p.pins = append(p.pins, pin)
+	switch pin {
+	case "tpm2":
+		ids, ok := config["pcr_ids"]
+		if !ok {
+			return nil
+		}
+		for _, id := range strings.Split(fmt.Sprint(ids), ",") {
+			pcr, err := strconv.Atoi(strings.TrimSpace(id))
+			if err != nil {
+				return fmt.Errorf("invalid pcr_ids %q", ids)
+			}
+			p.pcrs = append(p.pcrs, pcr)
+		}
+	case "tang":
+		url, _ := config["url"].(string)
+		p.tangURLs = append(p.tangURLs, url)
+	}
+	return nil
+}
+
+func (p *clevisPolicy) sort() {
+	slices.Sort(p.pins)
+	slices.Sort(p.pcrs)
+	slices.Sort(p.tangURLs)
+}
+
+// clevisConfig returns the config of the sss pin binding the volume to the
+// pins of the policy.
+func clevisConfig(volume v1alpha1.EncryptedVolumeSpec) (string, error) {
+	pins := map[string]any{}
+	if volume.Tpm2 != nil {
+		tpm2 := map[string]string{}
+		if pcrs := lo.FromPtr(volume.Tpm2.Pcrs); len(pcrs) > 0 {
+			tpm2["pcr_bank"] = "sha256"
+			tpm2["pcr_ids"] = strings.Join(lo.Map(pcrs, func(pcr int, _ int) string { return strconv.Itoa(pcr) }), ",")
+		}
+		pins["tpm2"] = tpm2
+	}
+	if tangs := lo.FromPtr(volume.Tang); len(tangs) > 0 {
+		servers := []map[string]string{}
+		for _, tang := range tangs {
+			server := map[string]string{"url": tang.Url}
+			if tang.Thumbprint != nil {
+				server["thp"] = *tang.Thumbprint
+			}
+			servers = append(servers, server)
+		}
+		pins["tang"] = servers
+	}
+	config, err := json.Marshal(map[string]any{
+		"t":    lo.FromPtrOr(volume.Threshold, 1),
+		"pins": pins,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(config), nil
+}
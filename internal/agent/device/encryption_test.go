@@ -0,0 +1,128 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testVolume = "/dev/vda4"
+
+func newTestEncryptionController(t *testing.T) (*EncryptionController, *executer.MockExecuter, *status.MockManager) {
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	statusManager := status.NewMockManager(ctrl)
+	return NewEncryptionController(execMock, statusManager, flightlog.NewPrefixLogger("")), execMock, statusManager
+}
+
+func desiredEncryption(volumes ...v1alpha1.EncryptedVolumeSpec) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		Encryption:      &v1alpha1.DeviceEncryptionSpec{Volumes: volumes},
+	}
+}
+
+func expectLuksList(execMock *executer.MockExecuter, stdout string) *gomock.Call {
+	return execMock.EXPECT().ExecuteWithContext(gomock.Any(), clevisCommand, "luks", "list", "-d", testVolume).Return(stdout, "", 0)
+}
+
+func TestEncryptionSyncCompliant(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager := newTestEncryptionController(t)
+
+	// a single pin bound directly matches a policy with a threshold of 1
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), cryptsetupCommand, "isLuks", testVolume).Return("", "", 0).Times(2)
+	expectLuksList(execMock, `1: tpm2 '{"hash":"sha256","key":"ecc","pcr_bank":"sha256","pcr_ids":"7,0"}'`+"\n").Times(2)
+	// the status is reported once
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	desired := desiredEncryption(v1alpha1.EncryptedVolumeSpec{Device: testVolume, Tpm2: &v1alpha1.TpmPinSpec{Pcrs: &[]int{0, 7}}})
+	require.NoError(c.Sync(context.Background(), desired))
+	require.NoError(c.Sync(context.Background(), desired))
+	require.Len(c.reported.Volumes, 1)
+	require.Equal(v1alpha1.EncryptedVolumeCompliant, c.reported.Volumes[0].State)
+	require.Equal([]string{"tpm2"}, *c.reported.Volumes[0].Pins)
+}
+
+func TestEncryptionSyncBindsWithKeyFile(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager := newTestEncryptionController(t)
+
+	config := `{"pins":{"tang":[{"thp":"abc","url":"http://tang.example.com"}],"tpm2":{}},"t":2}`
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), cryptsetupCommand, "isLuks", testVolume).Return("", "", 0),
+		expectLuksList(execMock, ""),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), clevisCommand, "luks", "bind", "-y", "-d", testVolume, "-k", "/etc/luks.key", "sss", config).Return("", "", 0),
+		expectLuksList(execMock, `2: sss '{"t":2,"pins":{"tang":[{"url":"http://tang.example.com"}],"tpm2":[{"hash":"sha256","key":"ecc"}]}}'`),
+	)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	require.NoError(c.Sync(context.Background(), desiredEncryption(v1alpha1.EncryptedVolumeSpec{
+		Device:    testVolume,
+		Tpm2:      &v1alpha1.TpmPinSpec{},
+		Tang:      &[]v1alpha1.TangPinSpec{{Url: "http://tang.example.com", Thumbprint: lo.ToPtr("abc")}},
+		Threshold: lo.ToPtr(2),
+		KeyFile:   lo.ToPtr("/etc/luks.key"),
+	})))
+	require.Equal(v1alpha1.EncryptedVolumeCompliant, c.reported.Volumes[0].State)
+	require.Equal([]string{"tang", "tpm2"}, *c.reported.Volumes[0].Pins)
+}
+
+func TestEncryptionSyncEditsAndUnbinds(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager := newTestEncryptionController(t)
+
+	// the sss binding is edited into the policy and the tpm2 binding, which
+	// would unlock the volume without the tang server, is removed
+	bindings := "1: tpm2 '{\"hash\":\"sha256\",\"key\":\"ecc\"}'\n2: sss '{\"t\":1,\"pins\":{\"tang\":[{\"url\":\"http://old.example.com\"}]}}'\n"
+	edited := "1: tpm2 '{\"hash\":\"sha256\",\"key\":\"ecc\"}'\n2: sss '{\"t\":1,\"pins\":{\"tang\":[{\"url\":\"http://tang.example.com\"}]}}'\n"
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), cryptsetupCommand, "isLuks", testVolume).Return("", "", 0),
+		expectLuksList(execMock, bindings),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), clevisCommand, "luks", "edit", "-d", testVolume, "-s", "2", "-c", `{"pins":{"tang":[{"url":"http://tang.example.com"}]},"t":1}`).Return("", "", 0),
+		expectLuksList(execMock, edited),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), clevisCommand, "luks", "unbind", "-f", "-d", testVolume, "-s", "1").Return("", "", 0),
+	)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	require.NoError(c.Sync(context.Background(), desiredEncryption(v1alpha1.EncryptedVolumeSpec{
+		Device: testVolume,
+		Tang:   &[]v1alpha1.TangPinSpec{{Url: "http://tang.example.com"}},
+	})))
+	require.Equal(v1alpha1.EncryptedVolumeCompliant, c.reported.Volumes[0].State)
+	require.Equal([]string{"tang"}, *c.reported.Volumes[0].Pins)
+}
+
+func TestEncryptionSyncNonCompliant(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager := newTestEncryptionController(t)
+
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), cryptsetupCommand, "isLuks", testVolume).Return("", "", 0)
+	expectLuksList(execMock, `1: tpm2 '{"hash":"sha256","key":"ecc"}'`)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), cryptsetupCommand, "isLuks", "/dev/vdb").Return("", "", 1)
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	// without a key file, a binding with another pin cannot be replaced
+	require.NoError(c.Sync(context.Background(), desiredEncryption(
+		v1alpha1.EncryptedVolumeSpec{Device: testVolume, Tpm2: &v1alpha1.TpmPinSpec{Pcrs: &[]int{7}}},
+		v1alpha1.EncryptedVolumeSpec{Device: "/dev/vdb", Tpm2: &v1alpha1.TpmPinSpec{}},
+	)))
+	require.Len(c.reported.Volumes, 2)
+	require.Equal(v1alpha1.EncryptedVolumeNonCompliant, c.reported.Volumes[0].State)
+	require.Equal(v1alpha1.EncryptedVolumeUnencrypted, c.reported.Volumes[1].State)
+}
+
+func TestEncryptionSyncWithoutPolicy(t *testing.T) {
+	c, _, _ := newTestEncryptionController(t)
+
+	// nothing is run nor reported without a policy
+	require.NoError(t, c.Sync(context.Background(), &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}))
+	require.Nil(t, c.reported)
+}
@@ -0,0 +1,225 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	nftCommand = "/usr/sbin/nft"
+	// firewallTable is the nftables table of the firewall of the device spec,
+	// which leaves the tables of other tools, such as firewalld, untouched
+	firewallTable       = "flightctl"
+	firewallRulesetFile = "/etc/nftables/flightctl.nft"
+
+	firewallCommandTimeout = 30 * time.Second
+)
+
+// FirewallController applies the firewall of the device spec as an nftables
+// table filtering the incoming traffic of the device. The table always
+// accepts loopback traffic, IPv6 neighbor discovery and the replies to the
+// connections the device opens before the rules of the spec, so that no rule
+// cuts the agent off from the service.
+type FirewallController struct {
+	exec       executer.Executer
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+	// applied is set once the ruleset is loaded, as the table does not
+	// survive a reboot while the ruleset file does
+	applied bool
+}
+
+func NewFirewallController(
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	log *log.PrefixLogger,
+) *FirewallController {
+	return &FirewallController{
+		exec:       exec,
+		readWriter: readWriter,
+		log:        log,
+	}
+}
+
+// Sync loads the ruleset of the firewall of the desired spec, once nftables
+// checked it, and deletes the table once the spec no longer sets a firewall.
+func (c *FirewallController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing firewall")
+	defer c.log.Debug("Finished syncing firewall")
+
+	ruleset, err := nftRuleset(desired.Firewall)
+	if err != nil {
+		return err
+	}
+	exists, err := c.readWriter.FileExists(firewallRulesetFile)
+	if err != nil {
+		return err
+	}
+	if !exists && ruleset == "" {
+		return nil
+	}
+	if exists && c.applied {
+		current, err := c.readWriter.ReadFile(firewallRulesetFile)
+		if err != nil {
+			return err
+		}
+		if string(current) == ruleset {
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, firewallCommandTimeout)
+	defer cancel()
+	if ruleset == "" {
+		c.log.Info("Removing the firewall of the device spec")
+		_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, nftCommand, "delete", "table", "inet", firewallTable)
+		if exitCode != 0 && !strings.Contains(stderr, "No such file or directory") {
+			return fmt.Errorf("deleting firewall table: %s: exit code %d: %s", nftCommand, exitCode, stderr)
+		}
+		c.applied = false
+		return c.readWriter.RemoveFile(firewallRulesetFile)
+	}
+
+	// the ruleset is checked before it is loaded, so that a ruleset nftables
+	// rejects does not replace the one in effect
+	candidate := firewallRulesetFile + ".new"
+	if err := c.readWriter.WriteFile(candidate, []byte(ruleset), 0600); err != nil {
+		return err
+	}
+	defer func() { _ = c.readWriter.RemoveFile(candidate) }()
+	if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, nftCommand, "-c", "-f", c.readWriter.PathFor(candidate)); exitCode != 0 {
+		return fmt.Errorf("checking firewall ruleset: %s: exit code %d: %s", nftCommand, exitCode, stderr)
+	}
+	c.log.Info("Applying the firewall of the device spec")
+	if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, nftCommand, "-f", c.readWriter.PathFor(candidate)); exitCode != 0 {
+		return fmt.Errorf("applying firewall ruleset: %s: exit code %d: %s", nftCommand, exitCode, stderr)
+	}
+	if err := c.readWriter.WriteFile(firewallRulesetFile, []byte(ruleset), 0600); err != nil {
+		return err
+	}
+	c.applied = true
+	return nil
+}
+
+// nftRuleset renders the firewall as an nftables ruleset replacing the table
+// of the firewall atomically. The rules are validated by the service, but are
+// checked again since they are written into the ruleset.
+func nftRuleset(firewall *v1alpha1.DeviceFirewallSpec) (string, error) {
+	if firewall == nil {
+		return "", nil
+	}
+	policy, err := nftVerdict(lo.FromPtr(firewall.InputPolicy))
+	if err != nil {
+		return "", fmt.Errorf("invalid input policy: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("# Firewall of the flightctl device spec, applied by the agent\n")
+	// declaring the table before deleting it makes the deletion succeed
+	// when the table does not exist yet
+	fmt.Fprintf(&b, "table inet %s\ndelete table inet %s\n", firewallTable, firewallTable)
+	fmt.Fprintf(&b, "table inet %s {\n\tchain input {\n", firewallTable)
+	fmt.Fprintf(&b, "\t\ttype filter hook input priority filter; policy %s;\n", policy)
+	b.WriteString("\t\t# keep the device reachable and the agent connected to the service\n")
+	b.WriteString("\t\tiif \"lo\" accept\n")
+	b.WriteString("\t\tct state established,related accept\n")
+	b.WriteString("\t\ticmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-advert } accept\n")
+	for i, rule := range lo.FromPtr(firewall.Rules) {
+		lines, err := nftRule(rule)
+		if err != nil {
+			return "", fmt.Errorf("invalid firewall rule %d: %w", i, err)
+		}
+		for _, line := range lines {
+			fmt.Fprintf(&b, "\t\t%s\n", line)
+		}
+	}
+	b.WriteString("\t}\n}\n")
+	return b.String(), nil
+}
+
+// nftRule renders a rule as nftables rules, one for each address family of
+// its sources.
+func nftRule(rule v1alpha1.FirewallRule) ([]string, error) {
+	verdict, err := nftVerdict(rule.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	var match string
+	ports := lo.Map(lo.FromPtr(rule.Ports), func(port int32, _ int) string { return fmt.Sprint(port) })
+	switch protocol := lo.FromPtr(rule.Protocol); protocol {
+	case v1alpha1.FirewallProtocolTcp, v1alpha1.FirewallProtocolUdp:
+		match = fmt.Sprintf("meta l4proto %s", protocol)
+		if len(ports) > 0 {
+			match = fmt.Sprintf("%s dport { %s }", protocol, strings.Join(ports, ", "))
+		}
+	case v1alpha1.FirewallProtocolIcmp:
+		match = "meta l4proto { icmp, ipv6-icmp }"
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+	if len(ports) > 0 && !strings.Contains(match, "dport") {
+		return nil, fmt.Errorf("ports require the tcp or udp protocol")
+	}
+
+	suffix := verdict
+	if name := lo.FromPtr(rule.Name); name != "" {
+		if strings.ContainsAny(name, "\"\\\r\n") {
+			return nil, fmt.Errorf("invalid name %q", name)
+		}
+		suffix += fmt.Sprintf(" comment %q", name)
+	}
+
+	ipv4, ipv6 := []string{}, []string{}
+	for _, source := range lo.FromPtr(rule.Sources) {
+		prefix, err := netip.ParsePrefix(source)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(source)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid source %q", source)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		if prefix.Addr().Is4() {
+			ipv4 = append(ipv4, prefix.Masked().String())
+		} else {
+			ipv6 = append(ipv6, prefix.Masked().String())
+		}
+	}
+
+	join := func(parts ...string) string {
+		return strings.Join(lo.Compact(parts), " ")
+	}
+	if len(ipv4) == 0 && len(ipv6) == 0 {
+		return []string{join(match, suffix)}, nil
+	}
+	lines := []string{}
+	if len(ipv4) > 0 {
+		lines = append(lines, join(fmt.Sprintf("ip saddr { %s }", strings.Join(ipv4, ", ")), match, suffix))
+	}
+	if len(ipv6) > 0 {
+		lines = append(lines, join(fmt.Sprintf("ip6 saddr { %s }", strings.Join(ipv6, ", ")), match, suffix))
+	}
+	return lines, nil
+}
+
+func nftVerdict(action v1alpha1.FirewallAction) (string, error) {
+	switch action {
+	case v1alpha1.FirewallAccept, "":
+		return "accept", nil
+	case v1alpha1.FirewallDrop:
+		return "drop", nil
+	default:
+		return "", fmt.Errorf("unknown action %q", action)
+	}
+}
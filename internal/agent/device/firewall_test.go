@@ -0,0 +1,114 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestFirewallController(t *testing.T) (*FirewallController, fileio.ReadWriter, *executer.MockExecuter) {
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	return NewFirewallController(execMock, readWriter, flightlog.NewPrefixLogger("")), readWriter, execMock
+}
+
+func desiredFirewall(firewall *v1alpha1.DeviceFirewallSpec) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Firewall: firewall}
+}
+
+func TestNftRuleset(t *testing.T) {
+	require := require.New(t)
+	ruleset, err := nftRuleset(&v1alpha1.DeviceFirewallSpec{
+		InputPolicy: lo.ToPtr(v1alpha1.FirewallDrop),
+		Rules: &[]v1alpha1.FirewallRule{
+			{Name: lo.ToPtr("ssh from the office"), Action: v1alpha1.FirewallAccept, Protocol: lo.ToPtr(v1alpha1.FirewallProtocolTcp), Ports: &[]int32{22}, Sources: &[]string{"10.1.0.0/16", "192.168.1.7", "fd00::/8"}},
+			{Action: v1alpha1.FirewallAccept, Protocol: lo.ToPtr(v1alpha1.FirewallProtocolTcp), Ports: &[]int32{80, 443}},
+			{Action: v1alpha1.FirewallAccept, Protocol: lo.ToPtr(v1alpha1.FirewallProtocolIcmp)},
+			{Action: v1alpha1.FirewallDrop, Sources: &[]string{"10.1.2.3/16"}},
+		},
+	})
+	require.NoError(err)
+	require.Equal(`# Firewall of the flightctl device spec, applied by the agent
+table inet flightctl
+delete table inet flightctl
+table inet flightctl {
+	chain input {
+		type filter hook input priority filter; policy drop;
+		# keep the device reachable and the agent connected to the service
+		iif "lo" accept
+		ct state established,related accept
+		icmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-advert } accept
+		ip saddr { 10.1.0.0/16, 192.168.1.7/32 } tcp dport { 22 } accept comment "ssh from the office"
+		ip6 saddr { fd00::/8 } tcp dport { 22 } accept comment "ssh from the office"
+		tcp dport { 80, 443 } accept
+		meta l4proto { icmp, ipv6-icmp } accept
+		ip saddr { 10.1.0.0/16 } drop
+	}
+}
+`, ruleset)
+
+	ruleset, err = nftRuleset(nil)
+	require.NoError(err)
+	require.Empty(ruleset)
+
+	// rules are checked again before they are written into the ruleset
+	for _, rule := range []v1alpha1.FirewallRule{
+		{Action: "Reject"},
+		{Action: v1alpha1.FirewallAccept, Ports: &[]int32{22}},
+		{Action: v1alpha1.FirewallAccept, Sources: &[]string{"10.0.0.0/8 } accept"}},
+		{Action: v1alpha1.FirewallAccept, Name: lo.ToPtr("ssh\" accept")},
+	} {
+		_, err := nftRuleset(&v1alpha1.DeviceFirewallSpec{Rules: &[]v1alpha1.FirewallRule{rule}})
+		require.Error(err)
+	}
+}
+
+func TestFirewallAppliesRuleset(t *testing.T) {
+	require := require.New(t)
+	c, readWriter, execMock := newTestFirewallController(t)
+	candidate := readWriter.PathFor(firewallRulesetFile + ".new")
+
+	// the ruleset is checked before it is loaded
+	firewall := &v1alpha1.DeviceFirewallSpec{InputPolicy: lo.ToPtr(v1alpha1.FirewallDrop)}
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "-c", "-f", candidate).Return("", "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "-f", candidate).Return("", "", 0)
+	require.NoError(c.Sync(context.Background(), desiredFirewall(firewall)))
+	exists, err := readWriter.FileExists(firewallRulesetFile)
+	require.NoError(err)
+	require.True(exists)
+
+	// an unchanged ruleset is not loaded again
+	require.NoError(c.Sync(context.Background(), desiredFirewall(firewall)))
+
+	// the agent started after a reboot loads the ruleset again
+	c = NewFirewallController(execMock, readWriter, flightlog.NewPrefixLogger(""))
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "-c", "-f", candidate).Return("", "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "-f", candidate).Return("", "", 0)
+	require.NoError(c.Sync(context.Background(), desiredFirewall(firewall)))
+
+	// a ruleset nftables rejects does not replace the one in effect
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "-c", "-f", candidate).Return("", "Error: syntax error", 1)
+	require.ErrorContains(c.Sync(context.Background(), desiredFirewall(&v1alpha1.DeviceFirewallSpec{})), "syntax error")
+	current, err := readWriter.ReadFile(firewallRulesetFile)
+	require.NoError(err)
+	require.Contains(string(current), "policy drop;")
+
+	// removing the firewall from the spec deletes the table
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), nftCommand, "delete", "table", "inet", firewallTable).Return("", "", 0)
+	require.NoError(c.Sync(context.Background(), desiredFirewall(nil)))
+	exists, err = readWriter.FileExists(firewallRulesetFile)
+	require.NoError(err)
+	require.False(exists)
+
+	// nothing is run without a firewall
+	require.NoError(c.Sync(context.Background(), desiredFirewall(nil)))
+}
@@ -0,0 +1,338 @@
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	podmanCommand    = "/usr/bin/podman"
+	rpmOstreeCommand = "/usr/bin/rpm-ostree"
+
+	// DefaultInterval is the default interval between two checks of the disk usage.
+	DefaultInterval = time.Hour
+	// DefaultDiskUsageThreshold is the default percentage of the disk used
+	// above which unused container images are pruned.
+	DefaultDiskUsageThreshold = 80
+	// DefaultKeepOsDeployments is the default number of previous OS
+	// deployments kept, the rollback deployment of bootc.
+	DefaultKeepOsDeployments = 1
+
+	// containerStorageDir is the directory whose disk usage is checked
+	containerStorageDir = "/var/lib/containers"
+	// applicationLabel labels the volumes the agent creates for applications,
+	// which are not pruned
+	applicationLabel = "io.flightctl.application"
+
+	// statusFile keeps the result of the last collection across restarts of
+	// the agent, relative to the data dir
+	statusFile = "garbage-collection.json"
+
+	commandTimeout = 10 * time.Minute
+	checkInterval  = time.Minute
+)
+
+var _ Manager = (*manager)(nil)
+
+// Manager reclaims the disk space of the device with the garbage collection
+// policy of the device spec, and keeps the result of the last collection.
+type Manager interface {
+	// Run checks the disk usage of the device whenever a check is due until
+	// the context is canceled.
+	Run(ctx context.Context)
+	// SetSpec sets the garbage collection policy and the OS of the spec. The
+	// disk usage is checked right away if the policy changed.
+	SetSpec(spec *v1alpha1.DeviceGarbageCollectionSpec, os *v1alpha1.DeviceOSSpec)
+	// Status returns the result of the last collection, or nil if the spec
+	// sets no garbage collection policy.
+	Status() *v1alpha1.DeviceGarbageCollectionStatus
+}
+
+// diskUsage is the usage of the filesystem of a directory.
+type diskUsage struct {
+	Total uint64
+	Used  uint64
+}
+
+func (u diskUsage) percent() int32 {
+	if u.Total == 0 {
+		return 0
+	}
+	return int32(math.Round(float64(u.Used) / float64(u.Total) * 100))
+}
+
+type manager struct {
+	dataDir    string
+	exec       executer.Executer
+	bootc      container.BootcClient
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+	trigger    chan struct{}
+	diskUsage  func(dir string) (diskUsage, error)
+
+	mu        sync.Mutex
+	specSet   bool
+	spec      *v1alpha1.DeviceGarbageCollectionSpec
+	osImage   string
+	status    *v1alpha1.DeviceGarbageCollectionStatus
+	lastCheck time.Time
+}
+
+// NewManager creates a new garbage collection manager keeping the result of
+// the last collection in dataDir.
+func NewManager(dataDir string, exec executer.Executer, readWriter fileio.ReadWriter, log *log.PrefixLogger) Manager {
+	return &manager{
+		dataDir:    dataDir,
+		exec:       exec,
+		bootc:      container.NewBootcCmd(exec),
+		readWriter: readWriter,
+		log:        log,
+		trigger:    make(chan struct{}, 1),
+		diskUsage:  getDiskUsage,
+	}
+}
+
+func (m *manager) SetSpec(spec *v1alpha1.DeviceGarbageCollectionSpec, os *v1alpha1.DeviceOSSpec) {
+	osImage := ""
+	if os != nil {
+		osImage = os.Image
+	}
+	m.mu.Lock()
+	changed := !m.specSet || !reflect.DeepEqual(m.spec, spec) || m.osImage != osImage
+	m.specSet = true
+	m.spec = spec
+	m.osImage = osImage
+	if changed {
+		m.lastCheck = time.Time{}
+	}
+	m.mu.Unlock()
+
+	if changed {
+		select {
+		case m.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (m *manager) Status() *v1alpha1.DeviceGarbageCollectionStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+func (m *manager) Run(ctx context.Context) {
+	if err := m.loadStatus(); err != nil {
+		m.log.Warnf("Failed to read the last garbage collection: %v", err)
+	}
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.trigger:
+		}
+		if err := m.collectIfDue(ctx); err != nil {
+			m.log.Errorf("Failed to record garbage collection: %v", err)
+		}
+	}
+}
+
+// collectIfDue checks the disk usage once per interval of the spec, and
+// collects the unused images and volumes if the disk is fuller than the
+// threshold of the spec, and the rollback deployment if the spec keeps none.
+func (m *manager) collectIfDue(ctx context.Context) error {
+	m.mu.Lock()
+	specSet, spec, osImage, last, lastCheck := m.specSet, m.spec, m.osImage, m.status, m.lastCheck
+	m.mu.Unlock()
+	if !specSet {
+		// the spec is not synced yet
+		return nil
+	}
+	if spec == nil {
+		if last == nil {
+			return nil
+		}
+		m.log.Info("Removing the garbage collection result, the spec sets no garbage collection policy")
+		m.setStatus(nil)
+		return m.readWriter.RemoveFile(m.statusPath())
+	}
+
+	interval := DefaultInterval
+	if spec.Interval != nil {
+		if parsed, err := time.ParseDuration(*spec.Interval); err == nil {
+			interval = parsed
+		}
+	}
+	if !lastCheck.IsZero() && time.Since(lastCheck) < interval {
+		return nil
+	}
+	m.mu.Lock()
+	m.lastCheck = time.Now()
+	m.mu.Unlock()
+
+	status := m.collect(ctx, spec, osImage)
+	if status == nil || ctx.Err() != nil {
+		// nothing was collected, or the agent is stopping
+		return nil
+	}
+	m.setStatus(status)
+	content, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return m.readWriter.WriteFile(m.statusPath(), content, 0600)
+}
+
+// collect runs the steps of the garbage collection which are due, and returns
+// their result, or nil if none was due.
+func (m *manager) collect(ctx context.Context, spec *v1alpha1.DeviceGarbageCollectionSpec, osImage string) *v1alpha1.DeviceGarbageCollectionStatus {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	before, err := m.diskUsage(m.readWriter.PathFor(containerStorageDir))
+	if err != nil {
+		m.log.Warnf("Failed to read the disk usage of %s: %v", containerStorageDir, err)
+		return nil
+	}
+	threshold := lo.FromPtrOr(spec.DiskUsageThreshold, DefaultDiskUsageThreshold)
+	pruneImages := before.percent() > threshold
+	removeRollback := lo.FromPtrOr(spec.KeepOsDeployments, DefaultKeepOsDeployments) == 0 && m.rollbackRemovable(ctx, osImage)
+	if !pruneImages && !removeRollback {
+		return nil
+	}
+
+	status := &v1alpha1.DeviceGarbageCollectionStatus{}
+	var errs []error
+	if removeRollback {
+		m.log.Info("Removing the rollback OS deployment")
+		if _, stderr, exitCode := m.exec.ExecuteWithContext(ctx, rpmOstreeCommand, "cleanup", "--rollback"); exitCode != 0 {
+			errs = append(errs, fmt.Errorf("removing rollback deployment: %s: exit code %d: %s", rpmOstreeCommand, exitCode, strings.TrimSpace(stderr)))
+		} else {
+			status.RemovedOsDeployments = 1
+		}
+	}
+	if pruneImages {
+		m.log.Infof("Pruning unused container images, %d%% of the disk of %s is used", before.percent(), containerStorageDir)
+		removed, err := m.prune(ctx, "image", "prune", "--all", "--force")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pruning images: %w", err))
+		}
+		status.RemovedImages = removed
+		if lo.FromPtr(spec.PruneVolumes) {
+			removed, err := m.prune(ctx, "volume", "prune", "--force", "--filter", "label!="+applicationLabel)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("pruning volumes: %w", err))
+			}
+			status.RemovedVolumes = removed
+		}
+	}
+
+	after, err := m.diskUsage(m.readWriter.PathFor(containerStorageDir))
+	if err != nil {
+		errs = append(errs, err)
+		after = before
+	}
+	if after.Used < before.Used {
+		status.ReclaimedBytes = int64(before.Used - after.Used)
+	}
+	status.DiskUsagePercent = after.percent()
+	status.CollectedAt = time.Now().UTC()
+	if err := errors.Join(errs...); err != nil {
+		status.Message = lo.ToPtr(err.Error())
+	}
+	m.log.Infof("Garbage collection removed %d images, %d volumes and %d OS deployments, reclaiming %d bytes",
+		status.RemovedImages, status.RemovedVolumes, status.RemovedOsDeployments, status.ReclaimedBytes)
+	return status
+}
+
+// rollbackRemovable returns whether the device has a rollback deployment
+// which may be removed: the device booted the OS image of its spec, no update
+// is staged, and the rollback deployment is not pinned.
+func (m *manager) rollbackRemovable(ctx context.Context, osImage string) bool {
+	if osImage == "" {
+		return false
+	}
+	host, err := m.bootc.Status(ctx)
+	if err != nil {
+		m.log.Warnf("Failed to read the OS deployments: %v", err)
+		return false
+	}
+	return host.GetBootedImage() == osImage &&
+		host.GetStagedImage() == "" &&
+		host.GetRollbackImage() != "" &&
+		!host.Status.Rollback.Pinned
+}
+
+// prune runs a podman prune command and returns the number of objects it
+// removed, which it prints one per line.
+func (m *manager) prune(ctx context.Context, args ...string) (int32, error) {
+	stdout, stderr, exitCode := m.exec.ExecuteWithContext(ctx, podmanCommand, args...)
+	if exitCode != 0 {
+		return 0, fmt.Errorf("%s: exit code %d: %s", podmanCommand, exitCode, strings.TrimSpace(stderr))
+	}
+	var removed int32
+	for _, line := range strings.Split(stdout, "\n") {
+		if strings.TrimSpace(line) != "" {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func getDiskUsage(dir string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return diskUsage{}, err
+	}
+	return diskUsage{
+		Total: stat.Blocks * uint64(stat.Bsize),
+		Used:  (stat.Blocks - stat.Bfree) * uint64(stat.Bsize),
+	}, nil
+}
+
+func (m *manager) setStatus(status *v1alpha1.DeviceGarbageCollectionStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.status = status
+}
+
+func (m *manager) statusPath() string {
+	return filepath.Join(m.dataDir, statusFile)
+}
+
+// loadStatus reads the result of the collection before the agent restarted.
+func (m *manager) loadStatus() error {
+	exists, err := m.readWriter.FileExists(m.statusPath())
+	if err != nil || !exists {
+		return err
+	}
+	content, err := m.readWriter.ReadFile(m.statusPath())
+	if err != nil {
+		return err
+	}
+	var status v1alpha1.DeviceGarbageCollectionStatus
+	if err := json.Unmarshal(content, &status); err != nil {
+		return err
+	}
+	m.setStatus(&status)
+	return nil
+}
@@ -0,0 +1,117 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const gib = 1 << 30
+
+func bootcHost(booted, staged, rollback string) *container.BootcHost {
+	host := &container.BootcHost{}
+	host.Status.Booted.Image.Image.Image = booted
+	host.Status.Staged.Image.Image.Image = staged
+	host.Status.Rollback.Image.Image.Image = rollback
+	return host
+}
+
+func TestCollectIfDue(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	bootcMock := container.NewMockBootcClient(ctrl)
+	readWriter := fileio.NewReadWriter()
+	rootDir := t.TempDir()
+	readWriter.SetRootdir(rootDir)
+	require.NoError(os.MkdirAll(filepath.Join(rootDir, "/var/lib/flightctl"), 0755))
+	m := NewManager("/var/lib/flightctl", execMock, readWriter, log.NewPrefixLogger("test")).(*manager)
+	m.bootc = bootcMock
+	usage := []diskUsage{}
+	m.diskUsage = func(dir string) (diskUsage, error) {
+		require.Equal(filepath.Join(rootDir, containerStorageDir), dir)
+		current := usage[0]
+		usage = usage[1:]
+		return current, nil
+	}
+	ctx := context.Background()
+	osSpec := &v1alpha1.DeviceOSSpec{Image: "quay.io/example/os:v2"}
+
+	// nothing is collected before the spec is synced
+	require.NoError(m.collectIfDue(ctx))
+	require.Nil(m.Status())
+
+	// nothing is collected while the disk is below the threshold
+	usage = []diskUsage{{Total: 10 * gib, Used: 7 * gib}}
+	m.SetSpec(&v1alpha1.DeviceGarbageCollectionSpec{}, osSpec)
+	require.NoError(m.collectIfDue(ctx))
+	require.Nil(m.Status())
+
+	// the unused images and volumes are pruned above the threshold, keeping
+	// the volumes of the applications
+	usage = []diskUsage{{Total: 10 * gib, Used: 9 * gib}, {Total: 10 * gib, Used: 6 * gib}}
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "image", "prune", "--all", "--force").Return("3f5a1c\n9b2e4d\n", "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "volume", "prune", "--force", "--filter", "label!="+applicationLabel).Return("cache\n", "", 0)
+	m.SetSpec(&v1alpha1.DeviceGarbageCollectionSpec{PruneVolumes: lo.ToPtr(true)}, osSpec)
+	require.NoError(m.collectIfDue(ctx))
+	status := m.Status()
+	require.NotNil(status)
+	require.Equal(int32(2), status.RemovedImages)
+	require.Equal(int32(1), status.RemovedVolumes)
+	require.Equal(int32(0), status.RemovedOsDeployments)
+	require.Equal(int64(3*gib), status.ReclaimedBytes)
+	require.Equal(int32(60), status.DiskUsagePercent)
+	require.Nil(status.Message)
+
+	// the disk usage is not checked again within the interval, and the
+	// result is kept after the agent restarted
+	require.NoError(m.collectIfDue(ctx))
+	restarted := NewManager("/var/lib/flightctl", execMock, readWriter, log.NewPrefixLogger("test")).(*manager)
+	require.NoError(restarted.loadStatus())
+	require.Equal(status.CollectedAt.Unix(), restarted.Status().CollectedAt.Unix())
+
+	// the rollback deployment is kept while an update is staged, and removed
+	// once the device booted the OS image of the spec
+	spec := &v1alpha1.DeviceGarbageCollectionSpec{KeepOsDeployments: lo.ToPtr(int32(0)), DiskUsageThreshold: lo.ToPtr(int32(95))}
+	usage = []diskUsage{{Total: 10 * gib, Used: 6 * gib}}
+	bootcMock.EXPECT().Status(gomock.Any()).Return(bootcHost("quay.io/example/os:v1", "quay.io/example/os:v2", "quay.io/example/os:v0"), nil)
+	m.SetSpec(spec, osSpec)
+	require.NoError(m.collectIfDue(ctx))
+	require.Equal(status, m.Status())
+
+	usage = []diskUsage{{Total: 10 * gib, Used: 6 * gib}, {Total: 10 * gib, Used: 6 * gib}}
+	bootcMock.EXPECT().Status(gomock.Any()).Return(bootcHost("quay.io/example/os:v2", "", "quay.io/example/os:v1"), nil)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), rpmOstreeCommand, "cleanup", "--rollback").Return("", "", 0)
+	m.lastCheck = m.lastCheck.Add(-DefaultInterval)
+	require.NoError(m.collectIfDue(ctx))
+	require.Equal(int32(1), m.Status().RemovedOsDeployments)
+	require.Equal(int32(0), m.Status().RemovedImages)
+
+	// a failing step is reported in the message
+	usage = []diskUsage{{Total: 10 * gib, Used: 10 * gib}, {Total: 10 * gib, Used: 10 * gib}}
+	bootcMock.EXPECT().Status(gomock.Any()).Return(nil, errors.New("bootc not found"))
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), podmanCommand, "image", "prune", "--all", "--force").Return("", "database is locked", 125)
+	m.lastCheck = m.lastCheck.Add(-DefaultInterval)
+	require.NoError(m.collectIfDue(ctx))
+	require.Contains(*m.Status().Message, "database is locked")
+	require.Equal(int32(100), m.Status().DiskUsagePercent)
+
+	// the result is removed once the spec sets no policy
+	m.SetSpec(nil, osSpec)
+	require.NoError(m.collectIfDue(ctx))
+	require.Nil(m.Status())
+	exists, err := readWriter.FileExists(m.statusPath())
+	require.NoError(err)
+	require.False(exists)
+}
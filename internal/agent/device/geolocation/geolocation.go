@@ -0,0 +1,64 @@
+package geolocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+const (
+	// DefaultInterval is the default interval between two locates of the device.
+	DefaultInterval = 10 * time.Minute
+
+	// SourceStatic reports the coordinates set in the agent configuration.
+	SourceStatic = "static"
+	// SourceGPS reads the coordinates from a GPS receiver emitting NMEA sentences.
+	SourceGPS = "gps"
+	// SourceIP looks up the coordinates of the public IP address of the device.
+	SourceIP = "ip"
+)
+
+// Locator determines the location of the device.
+type Locator interface {
+	Locate(ctx context.Context) (*v1alpha1.DeviceLocation, error)
+}
+
+// New creates the locator of the given source. The coordinates are only used
+// by the static source, the GPS device and the lookup URL by the gps and ip
+// sources respectively.
+func New(source string, latitude, longitude float64, gpsDevice, ipLookupURL string) (Locator, error) {
+	switch source {
+	case SourceStatic:
+		return NewStatic(latitude, longitude), nil
+	case SourceGPS:
+		return NewGPS(gpsDevice), nil
+	case SourceIP:
+		return NewIP(ipLookupURL), nil
+	default:
+		return nil, fmt.Errorf("unknown geolocation source %q", source)
+	}
+}
+
+var _ Locator = (*static)(nil)
+
+type static struct {
+	latitude  float64
+	longitude float64
+}
+
+// NewStatic creates a locator reporting fixed coordinates, for devices which
+// are installed at a known place and do not move.
+func NewStatic(latitude, longitude float64) Locator {
+	return &static{latitude: latitude, longitude: longitude}
+}
+
+func (s *static) Locate(context.Context) (*v1alpha1.DeviceLocation, error) {
+	return &v1alpha1.DeviceLocation{
+		Latitude:  s.latitude,
+		Longitude: s.longitude,
+		Source:    v1alpha1.DeviceLocationSourceStatic,
+		UpdatedAt: time.Now().UTC(),
+	}, nil
+}
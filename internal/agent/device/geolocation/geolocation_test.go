@@ -0,0 +1,191 @@
+package geolocation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatic(t *testing.T) {
+	require := require.New(t)
+
+	location, err := NewStatic(48.8584, 2.2945).Locate(context.Background())
+	require.NoError(err)
+	require.Equal(48.8584, location.Latitude)
+	require.Equal(2.2945, location.Longitude)
+	require.Equal(v1alpha1.DeviceLocationSourceStatic, location.Source)
+	require.False(location.UpdatedAt.IsZero())
+}
+
+func TestParseNMEA(t *testing.T) {
+	tests := []struct {
+		name         string
+		sentence     string
+		wantLat      float64
+		wantLon      float64
+		wantAccuracy *float64
+		wantNoFix    bool
+		wantErr      bool
+	}{
+		{
+			name:         "GGA",
+			sentence:     "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+			wantLat:      48.1173,
+			wantLon:      11.516667,
+			wantAccuracy: lo.ToPtr(4.5),
+		},
+		{
+			name:     "RMC in the southern and western hemispheres",
+			sentence: "$GNRMC,220516,A,3351.720,S,15112.600,W,0.0,0.0,130694,,,A",
+			wantLat:  -33.862,
+			wantLon:  -151.21,
+		},
+		{
+			name:      "GGA without fix",
+			sentence:  "$GPGGA,123519,,,,,0,00,,,M,,M,,",
+			wantNoFix: true,
+		},
+		{
+			name:      "RMC void",
+			sentence:  "$GPRMC,220516,V,,,,,,,130694,,,N",
+			wantNoFix: true,
+		},
+		{
+			name:      "other sentence",
+			sentence:  "$GPGSV,3,1,11,03,03,111,00,04,15,270,00,06,01,010,00,13,06,292,00",
+			wantNoFix: true,
+		},
+		{
+			name:     "checksum mismatch",
+			sentence: "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48",
+			wantErr:  true,
+		},
+		{
+			name:     "partial sentence",
+			sentence: "07.038,N,01131.000,E,1,08,0.9",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid hemisphere",
+			sentence: "$GPRMC,220516,A,3351.720,X,15112.600,W,0.0,0.0,130694,,,A",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			location, err := parseNMEA(tt.sentence)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			if tt.wantNoFix {
+				require.Nil(location)
+				return
+			}
+			require.InDelta(tt.wantLat, location.Latitude, 1e-6)
+			require.InDelta(tt.wantLon, location.Longitude, 1e-6)
+			require.Equal(v1alpha1.DeviceLocationSourceGPS, location.Source)
+			if tt.wantAccuracy == nil {
+				require.Nil(location.AccuracyMeters)
+			} else {
+				require.InDelta(*tt.wantAccuracy, *location.AccuracyMeters, 1e-6)
+			}
+		})
+	}
+}
+
+func TestGPSLocate(t *testing.T) {
+	require := require.New(t)
+	device := filepath.Join(t.TempDir(), "ttyACM0")
+
+	// the first sentence is cut off and the receiver has no fix yet
+	sentences := "1131.000,E,1,08\n" +
+		"$GPGGA,123518,,,,,0,00,,,M,,M,,\n" +
+		"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\n"
+	require.NoError(os.WriteFile(device, []byte(sentences), 0600))
+	location, err := NewGPS(device).Locate(context.Background())
+	require.NoError(err)
+	require.InDelta(48.1173, location.Latitude, 1e-6)
+	require.False(location.UpdatedAt.IsZero())
+
+	require.NoError(os.WriteFile(device, []byte("$GPGGA,123518,,,,,0,00,,,M,,M,,\n"), 0600))
+	_, err = NewGPS(device).Locate(context.Background())
+	require.Error(err)
+}
+
+func TestIPLocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		status   int
+		wantLat  float64
+		wantLon  float64
+		wantErr  bool
+	}{
+		{
+			name:     "latitude and longitude",
+			response: `{"ip":"203.0.113.7","latitude":52.52,"longitude":13.405}`,
+			wantLat:  52.52,
+			wantLon:  13.405,
+		},
+		{
+			name:     "lat and lon",
+			response: `{"status":"success","lat":-33.8688,"lon":151.2093}`,
+			wantLat:  -33.8688,
+			wantLon:  151.2093,
+		},
+		{
+			name:     "loc",
+			response: `{"ip":"203.0.113.7","loc":"37.3860,-122.0838"}`,
+			wantLat:  37.386,
+			wantLon:  -122.0838,
+		},
+		{
+			name:     "no coordinates",
+			response: `{"ip":"203.0.113.7"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "out of range",
+			response: `{"lat":95,"lon":10}`,
+			wantErr:  true,
+		},
+		{
+			name:     "error status",
+			response: `{"error":"rate limited"}`,
+			status:   http.StatusTooManyRequests,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			location, err := NewIP(server.URL).Locate(context.Background())
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.InDelta(tt.wantLat, location.Latitude, 1e-9)
+			require.InDelta(tt.wantLon, location.Longitude, 1e-9)
+			require.Equal(v1alpha1.DeviceLocationSourceIP, location.Source)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package geolocation
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+)
+
+const (
+	// gpsReadTimeout is how long to wait for a fix. Receivers emit their
+	// sentences every second, but may need minutes to acquire a first fix.
+	gpsReadTimeout = 10 * time.Second
+	// uereMeters is the typical range error of GPS, which multiplied by the
+	// HDOP of a fix estimates its horizontal accuracy.
+	uereMeters = 5.0
+)
+
+var _ Locator = (*gps)(nil)
+
+type gps struct {
+	device  string
+	timeout time.Duration
+}
+
+// NewGPS creates a locator reading the NMEA sentences of the GPS receiver at
+// the given serial device, such as /dev/ttyACM0.
+func NewGPS(device string) Locator {
+	return &gps{device: device, timeout: gpsReadTimeout}
+}
+
+func (g *gps) Locate(ctx context.Context) (*v1alpha1.DeviceLocation, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	file, err := os.Open(g.device)
+	if err != nil {
+		return nil, fmt.Errorf("opening GPS device: %w", err)
+	}
+	// closing the device unblocks the pending read once the context is done
+	stop := context.AfterFunc(ctx, func() { file.Close() })
+	defer func() {
+		if stop() {
+			file.Close()
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		location, err := parseNMEA(scanner.Text())
+		if err != nil {
+			// receivers emit partial sentences while the port is opened
+			continue
+		}
+		if location != nil {
+			location.UpdatedAt = time.Now().UTC()
+			return location, nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("no GPS fix from %s within %s", g.device, g.timeout)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading GPS device: %w", err)
+	}
+	return nil, fmt.Errorf("no GPS fix from %s", g.device)
+}
+
+// parseNMEA parses the location of a GGA or RMC sentence, such as
+// $GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47.
+// Other sentences and sentences without a fix have no location.
+func parseNMEA(sentence string) (*v1alpha1.DeviceLocation, error) {
+	sentence = strings.TrimSpace(sentence)
+	if !strings.HasPrefix(sentence, "$") {
+		return nil, fmt.Errorf("invalid NMEA sentence %q", sentence)
+	}
+	body, checksum, hasChecksum := strings.Cut(sentence[1:], "*")
+	if hasChecksum {
+		expected, err := strconv.ParseUint(checksum, 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NMEA checksum %q", checksum)
+		}
+		var sum byte
+		for i := 0; i < len(body); i++ {
+			sum ^= body[i]
+		}
+		if uint64(sum) != expected {
+			return nil, fmt.Errorf("NMEA checksum mismatch in %q", sentence)
+		}
+	}
+
+	fields := strings.Split(body, ",")
+	// the first two letters identify the constellation, such as GP or GN
+	if len(fields[0]) != 5 {
+		return nil, nil
+	}
+	var latitude, longitude []string
+	var accuracy *float64
+	switch fields[0][2:] {
+	case "GGA":
+		if len(fields) < 9 || fields[6] == "" || fields[6] == "0" {
+			return nil, nil
+		}
+		latitude, longitude = fields[2:4], fields[4:6]
+		if hdop, err := strconv.ParseFloat(fields[8], 64); err == nil {
+			accuracy = lo.ToPtr(hdop * uereMeters)
+		}
+	case "RMC":
+		if len(fields) < 7 || fields[2] != "A" {
+			return nil, nil
+		}
+		latitude, longitude = fields[3:5], fields[5:7]
+	default:
+		return nil, nil
+	}
+
+	lat, err := parseCoordinate(latitude[0], latitude[1], "N", "S", 90)
+	if err != nil {
+		return nil, err
+	}
+	lon, err := parseCoordinate(longitude[0], longitude[1], "E", "W", 180)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.DeviceLocation{
+		Latitude:       lat,
+		Longitude:      lon,
+		AccuracyMeters: accuracy,
+		Source:         v1alpha1.DeviceLocationSourceGPS,
+	}, nil
+}
+
+// parseCoordinate converts a coordinate in degrees and decimal minutes, such
+// as 4807.038 for 48°07.038', to degrees.
+func parseCoordinate(value, hemisphere, positive, negative string, limit float64) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid NMEA coordinate %q", value)
+	}
+	degrees := math.Floor(v / 100)
+	coordinate := degrees + (v-degrees*100)/60
+	if coordinate > limit {
+		return 0, fmt.Errorf("invalid NMEA coordinate %q", value)
+	}
+	switch hemisphere {
+	case positive:
+		return coordinate, nil
+	case negative:
+		return -coordinate, nil
+	default:
+		return 0, fmt.Errorf("invalid NMEA hemisphere %q", hemisphere)
+	}
+}
@@ -0,0 +1,110 @@
+package geolocation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+)
+
+const (
+	ipLookupTimeout = 10 * time.Second
+	// maxIPLookupResponseSize bounds the response read from the lookup service.
+	maxIPLookupResponseSize = 64 * 1024
+)
+
+var _ Locator = (*ip)(nil)
+
+type ip struct {
+	url    string
+	client *http.Client
+}
+
+// NewIP creates a locator querying the lookup service at the given URL for
+// the location of the public IP address the request comes from. The location
+// is only as accurate as the database of the service, usually a city.
+func NewIP(url string) Locator {
+	return &ip{
+		url: url,
+		client: &http.Client{
+			Timeout:   ipLookupTimeout,
+			Transport: &http.Transport{Proxy: client.Proxy},
+		},
+	}
+}
+
+func (i *ip) Locate(ctx context.Context) (*v1alpha1.DeviceLocation, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, i.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("looking up IP location: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("looking up IP location: unexpected status %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxIPLookupResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading IP location: %w", err)
+	}
+	latitude, longitude, err := parseIPLookup(body)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.DeviceLocation{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Source:    v1alpha1.DeviceLocationSourceIP,
+		UpdatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// parseIPLookup reads the coordinates from the JSON response of a lookup
+// service. Services name them latitude and longitude, lat and lon, or combine
+// them in loc as "latitude,longitude".
+func parseIPLookup(body []byte) (float64, float64, error) {
+	var response struct {
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Lat       *float64 `json:"lat"`
+		Lon       *float64 `json:"lon"`
+		Loc       *string  `json:"loc"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, 0, fmt.Errorf("decoding IP location: %w", err)
+	}
+
+	var latitude, longitude float64
+	switch {
+	case response.Latitude != nil && response.Longitude != nil:
+		latitude, longitude = *response.Latitude, *response.Longitude
+	case response.Lat != nil && response.Lon != nil:
+		latitude, longitude = *response.Lat, *response.Lon
+	case response.Loc != nil:
+		lat, lon, found := strings.Cut(*response.Loc, ",")
+		var err1, err2 error
+		latitude, err1 = strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		longitude, err2 = strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if !found || err1 != nil || err2 != nil {
+			return 0, 0, fmt.Errorf("invalid IP location %q", *response.Loc)
+		}
+	default:
+		return 0, 0, fmt.Errorf("IP location has no coordinates")
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return 0, 0, fmt.Errorf("IP location %v,%v is out of range", latitude, longitude)
+	}
+	return latitude, longitude, nil
+}
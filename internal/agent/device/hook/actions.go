@@ -5,21 +5,51 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 )
 
 const (
 	DefaultHookActionTimeout = 10 * time.Second
+	// MaxActionOutputSize is the number of bytes kept from the end of each
+	// output stream of an action, enough for the errors usually printed last.
+	MaxActionOutputSize = 4 * 1024
 )
 
+// ActionOutput is the output of an action, truncated to its end.
+type ActionOutput struct {
+	Stdout string
+	Stderr string
+}
+
+func newActionOutput(stdout, stderr string) *ActionOutput {
+	return &ActionOutput{Stdout: TruncateOutput(stdout), Stderr: TruncateOutput(stderr)}
+}
+
+// TruncateOutput keeps the last MaxActionOutputSize bytes of the output,
+// without splitting a UTF-8 character.
+func TruncateOutput(output string) string {
+	if len(output) <= MaxActionOutputSize {
+		return output
+	}
+	start := len(output) - MaxActionOutputSize
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return output[start:]
+}
+
 type systemdActionHook struct {
 	actionTimeout time.Duration
 	operations    []v1alpha1.HookActionSystemdUnitOperations
@@ -38,7 +68,7 @@ func newSystemdActionHook(unitName string, operations []v1alpha1.HookActionSyste
 	}
 }
 
-func (s *systemdActionHook) OnChange(ctx context.Context, path string) error {
+func (s *systemdActionHook) OnChange(ctx context.Context, path string) (*ActionOutput, error) {
 	var unitName string
 	var err error
 	if s.unitName != "" {
@@ -48,17 +78,17 @@ func (s *systemdActionHook) OnChange(ctx context.Context, path string) error {
 		unitName, err = getSystemdUnitNameFromFilePath(path)
 		if err != nil {
 			s.log.Errorf("%v: skipping...", err)
-			return nil
+			return nil, nil
 		}
 	}
 
 	for _, op := range s.operations {
 		if err := s.executeSystemdOperation(ctx, op, unitName); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return nil, nil
 }
 
 func (s *systemdActionHook) executeSystemdOperation(ctx context.Context, op v1alpha1.HookActionSystemdUnitOperations, unitName string) error {
@@ -197,33 +227,64 @@ func replaceTokens(args string, tokens map[string]string) (string, error) {
 	return output, nil
 }
 
-func newTokenMap(filePath string) map[string]string {
+func newTokenMap(filePath string, changedFiles []string) map[string]string {
 	return map[string]string{
-		FilePathKey: filePath,
+		FilePathKey:     filePath,
+		ChangedFilesKey: strings.Join(changedFiles, " "),
 	}
 }
 
+type changedFilesKey struct{}
+
+// withChangedFiles returns a context passing the changed files matching a
+// hook to its actions.
+func withChangedFiles(ctx context.Context, changedFiles []string) context.Context {
+	return context.WithValue(ctx, changedFilesKey{}, changedFiles)
+}
+
+// changedFilesFrom returns the changed files passed to an action, which are
+// those of the changed path alone unless the context lists them.
+func changedFilesFrom(ctx context.Context, path string) []string {
+	if changedFiles, ok := ctx.Value(changedFilesKey{}).([]string); ok {
+		return changedFiles
+	}
+	return []string{path}
+}
+
 type executableActionHook struct {
 	cmd           string
 	envVars       []string
 	exec          executer.Executer
 	actionTimeout time.Duration
 	workDir       *string
+	sandbox       *v1alpha1.HookActionSandbox
 	log           *log.PrefixLogger
 }
 
-func newExecutableActionHook(cmd string, envVars []string, exec executer.Executer, actionTimeout time.Duration, workDir *string, log *log.PrefixLogger) ActionHook {
+func newExecutableActionHook(cmd string, envVars []string, exec executer.Executer, actionTimeout time.Duration, workDir *string, sandbox *v1alpha1.HookActionSandbox, log *log.PrefixLogger) ActionHook {
 	return &executableActionHook{
 		cmd:           cmd,
 		envVars:       envVars,
 		exec:          exec,
 		actionTimeout: actionTimeout,
 		workDir:       workDir,
+		sandbox:       sandbox,
 		log:           log,
 	}
 }
 
-func (e *executableActionHook) OnChange(ctx context.Context, path string) error {
+// ExitError is the error of an executable action which exited with a non-zero code.
+type ExitError struct {
+	Cmd      string
+	ExitCode int
+	Stderr   string
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("failed to execute command: %s %d: %s", e.Cmd, e.ExitCode, e.Stderr)
+}
+
+func (e *executableActionHook) OnChange(ctx context.Context, path string) (*ActionOutput, error) {
 	ctx, cancel := context.WithTimeout(ctx, e.actionTimeout)
 	defer cancel()
 
@@ -232,29 +293,87 @@ func (e *executableActionHook) OnChange(ctx context.Context, path string) error
 		workDir = *e.workDir
 		dirExists, err := dirExists(workDir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// we expect the directory to exist should be created by config if its new.
 		if !dirExists {
-			return os.ErrNotExist
+			return nil, os.ErrNotExist
 		}
 	}
 
 	// replace file token in args if it exists
-	tokenMap := newTokenMap(path)
+	changedFiles := changedFilesFrom(ctx, path)
+	tokenMap := newTokenMap(path, changedFiles)
 	cmd, err := replaceTokens(e.cmd, tokenMap)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	changedFilesEnvVar := ChangedFilesEnvVar + "=" + strings.Join(changedFiles, "\n")
 
 	// We cannot split the cmd by whitespace because we want to allow running a command with arguments the contain spaces
 	// For example bash -c might be useful if we want to run several commands as a single action.  Therefore, all commands
 	// will run by using 'bash -c' to let bash do the parsing
-	_, stderr, exitCode := e.exec.ExecuteWithContextFromDir(ctx, workDir, "bash", []string{"-c", cmd}, e.envVars...)
+	var stdout, stderr string
+	var exitCode int
+	if e.sandbox != nil {
+		envVars := append(slices.Clone(e.envVars), changedFilesEnvVar)
+		stdout, stderr, exitCode = e.exec.ExecuteWithContext(ctx, "systemd-run", sandboxArgs(e.sandbox, e.actionTimeout, workDir, envVars, cmd)...)
+	} else {
+		// actions without environment variables inherit the environment of the agent
+		envVars := e.envVars
+		if len(envVars) == 0 {
+			envVars = os.Environ()
+		}
+		envVars = append(slices.Clone(envVars), changedFilesEnvVar)
+		stdout, stderr, exitCode = e.exec.ExecuteWithContextFromDir(ctx, workDir, "bash", []string{"-c", cmd}, envVars...)
+	}
+	output := newActionOutput(stdout, stderr)
 	if exitCode != 0 {
-		return fmt.Errorf("failed to execute command: %s %d: %s", e.cmd, exitCode, stderr)
+		return output, &ExitError{Cmd: e.cmd, ExitCode: exitCode, Stderr: output.Stderr}
 	}
 
+	return output, nil
+}
+
+// sandboxArgs returns the arguments of systemd-run running the command in a
+// transient service confined by the sandbox. systemd stops the service once
+// the timeout expires, even if the agent stops waiting for it earlier.
+func sandboxArgs(sandbox *v1alpha1.HookActionSandbox, timeout time.Duration, workDir string, envVars []string, cmd string) []string {
+	args := []string{
+		"--quiet",
+		"--wait",
+		"--pipe",
+		"--collect",
+		"--service-type=exec",
+		fmt.Sprintf("--property=RuntimeMaxSec=%d", int64(math.Ceil(timeout.Seconds()))),
+	}
+	if user := lo.FromPtr(sandbox.User); user != "" {
+		args = append(args, "--uid="+user)
+	}
+	if sandbox.CpuQuotaPercentage != nil {
+		args = append(args, fmt.Sprintf("--property=CPUQuota=%d%%", *sandbox.CpuQuotaPercentage))
+	}
+	if sandbox.MemoryMax != nil {
+		args = append(args, "--property=MemoryMax="+*sandbox.MemoryMax)
+	}
+	for _, readOnlyPath := range lo.FromPtr(sandbox.ReadOnlyPaths) {
+		args = append(args, "--property=ReadOnlyPaths="+readOnlyPath)
+	}
+	if workDir != "" {
+		args = append(args, "--working-directory="+workDir)
+	}
+	for _, envVar := range envVars {
+		args = append(args, "--setenv="+envVar)
+	}
+	return append(args, "--", "bash", "-c", cmd)
+}
+
+func validateSandbox(sandbox *v1alpha1.HookActionSandbox) error {
+	for _, readOnlyPath := range lo.FromPtr(sandbox.ReadOnlyPaths) {
+		if !filepath.IsAbs(readOnlyPath) || strings.ContainsAny(readOnlyPath, " \t\n") {
+			return fmt.Errorf("invalid sandbox: read-only path must be an absolute path without spaces: %s", readOnlyPath)
+		}
+	}
 	return nil
 }
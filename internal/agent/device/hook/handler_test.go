@@ -1,7 +1,9 @@
 package hook
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +74,20 @@ func TestReplaceTokensInrun(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncateOutput(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("short output", TruncateOutput("short output"))
+
+	long := strings.Repeat("a", MaxActionOutputSize) + "error: failed"
+	truncated := TruncateOutput(long)
+	require.Len(truncated, MaxActionOutputSize)
+	require.True(strings.HasSuffix(truncated, "error: failed"))
+
+	// a multi-byte character straddling the limit is dropped rather than split
+	multiByte := "é" + strings.Repeat("b", MaxActionOutputSize-1)
+	truncated = TruncateOutput(multiByte)
+	require.True(utf8.ValidString(truncated))
+	require.Equal(strings.Repeat("b", MaxActionOutputSize-1), truncated)
+}
@@ -10,7 +10,13 @@ const (
 
 	// FilePathKey is a placeholder which will be replaced with the file path
 	FilePathKey = "FilePath"
-	noValueKey  = "<no value>"
+	// ChangedFilesKey is a placeholder which will be replaced with the
+	// space-separated paths of the changed files matching the hook
+	ChangedFilesKey = "ChangedFiles"
+	// ChangedFilesEnvVar is the environment variable listing the changed
+	// files matching the hook to executable actions, one path per line
+	ChangedFilesEnvVar = "FLIGHTCTL_CHANGED_FILES"
+	noValueKey         = "<no value>"
 )
 
 var (
@@ -18,4 +24,5 @@ var (
 	ErrTokenNotSupported              = errors.New("invalid token: not supported")
 	ErrActionTypeNotFound             = errors.New("failed to find action type")
 	ErrUnsupportedFilesystemOperation = errors.New("unsupported filesystem operation")
+	ErrPathNotAllowed                 = errors.New("hook path is not allowed")
 )
@@ -2,11 +2,16 @@ package hook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/pkg/executer"
@@ -19,6 +24,15 @@ var _ Manager = (*manager)(nil)
 type Manager interface {
 	Run(ctx context.Context)
 	Sync(current, desired *v1alpha1.RenderedDeviceSpec) error
+	// SetAllowedPaths restricts the device update hooks to the files below the
+	// given directories. No restriction applies if paths is empty.
+	SetAllowedPaths(paths []string)
+	// OnBeforeUpdating runs the before updating hooks matching the changes of
+	// an update, before any of them is applied.
+	OnBeforeUpdating(ctx context.Context, changes []FileChange)
+	// OnAfterUpdating runs the after updating hooks matching the changes
+	// applied by an update in the background.
+	OnAfterUpdating(ctx context.Context, changes []FileChange)
 	OnBeforeCreate(ctx context.Context, path string)
 	OnAfterCreate(ctx context.Context, path string)
 	OnBeforeUpdate(ctx context.Context, path string)
@@ -28,14 +42,26 @@ type Manager interface {
 	OnBeforeReboot(ctx context.Context, path string)
 	OnAfterReboot(ctx context.Context, path string)
 	Errors() []error
+	// Results returns the result of the last action run by each hook, ordered by hook name.
+	Results() []v1alpha1.DeviceHookStatus
 	Close() error
 }
 
 type ActionHook interface {
-	OnChange(ctx context.Context, path string) error
+	// OnChange runs the action for the changed path. Actions which produce
+	// output return it, truncated to MaxActionOutputSize, even if they fail.
+	OnChange(ctx context.Context, path string) (*ActionOutput, error)
 }
 
-type ActionMap map[string][]ActionHook
+// ActionMap maps the paths watched by hooks to their actions. A path is
+// either a file or directory, or a glob pattern matching either.
+type ActionMap map[string][]*hookAction
+
+// FileChange is a change of a managed file by an update.
+type FileChange struct {
+	Path      string
+	Operation v1alpha1.FileOperation
+}
 
 type manager struct {
 	onBeforeCreate ActionMap
@@ -52,6 +78,8 @@ type manager struct {
 	backgroundJobs chan func(ctx context.Context)
 	exec           executer.Executer
 	initialized    atomic.Bool
+	allowedPaths   []string
+	results        map[string]v1alpha1.DeviceHookStatus
 }
 
 func NewManager(exec executer.Executer, log *log.PrefixLogger) Manager {
@@ -66,6 +94,7 @@ func NewManager(exec executer.Executer, log *log.PrefixLogger) Manager {
 		onAfterReboot:  make(ActionMap),
 		log:            log,
 		errors:         make(map[string]error),
+		results:        make(map[string]v1alpha1.DeviceHookStatus),
 		backgroundJobs: make(chan func(ctx context.Context), 100),
 		exec:           exec,
 	}
@@ -84,11 +113,17 @@ func (m *manager) createExecutableActionHook(action v1alpha1.HookAction) (Action
 	if err = validateEnvVars(envVars); err != nil {
 		return nil, err
 	}
+	if spec.Executable.Sandbox != nil {
+		if err = validateSandbox(spec.Executable.Sandbox); err != nil {
+			return nil, err
+		}
+	}
 	return newExecutableActionHook(spec.Executable.Run,
 		lo.FromPtr(spec.Executable.EnvVars),
 		m.exec,
 		actionTimeout,
 		spec.Executable.WorkDir,
+		spec.Executable.Sandbox,
 		m.log), nil
 }
 
@@ -108,12 +143,19 @@ func (m *manager) createSystemdActionHook(action v1alpha1.HookAction) (ActionHoo
 		m.log), nil
 }
 
-func (m *manager) generateOperationMaps(hookSpecs []v1alpha1.DeviceUpdateHookSpec) (ActionMap, ActionMap, ActionMap, ActionMap, error) {
+// generateOperationMaps returns the actions of the hooks and default hooks by
+// operation. The default hooks do not run for the compose files of the
+// applications of the spec, which are updated with their update strategy.
+func (m *manager) generateOperationMaps(hookSpecs, defaultHookSpecs []v1alpha1.DeviceUpdateHookSpec, applicationPaths []string) (ActionMap, ActionMap, ActionMap, ActionMap, error) {
 	createMap := make(ActionMap)
 	updateMap := make(ActionMap)
 	removeMap := make(ActionMap)
 	rebootMap := make(ActionMap)
-	for _, hookSpec := range hookSpecs {
+	for i, hookSpec := range append(slices.Clone(hookSpecs), defaultHookSpecs...) {
+		var ignoredPaths []string
+		if i >= len(hookSpecs) {
+			ignoredPaths = applicationPaths
+		}
 		for _, action := range hookSpec.Actions {
 			hookActionType, err := action.Type()
 			if err != nil {
@@ -132,17 +174,26 @@ func (m *manager) generateOperationMaps(hookSpecs []v1alpha1.DeviceUpdateHookSpe
 				return nil, nil, nil, nil, err
 			}
 			path := lo.FromPtr(hookSpec.Path)
+			hookAction := &hookAction{
+				name:       hookName(hookSpec),
+				path:       path,
+				batch:      lo.FromPtr(hookSpec.Batch),
+				ignored:    ignoredPaths,
+				executable: hookActionType == ExecutableActionType,
+				action:     actionHook,
+				record:     m.setResult,
+			}
 			opts := lo.FromPtr(hookSpec.OnFile)
 			for _, op := range opts {
 				switch op {
 				case v1alpha1.FileOperationCreate:
-					createMap[path] = append(createMap[path], actionHook)
+					createMap[path] = append(createMap[path], hookAction)
 				case v1alpha1.FileOperationUpdate:
-					updateMap[path] = append(updateMap[path], actionHook)
+					updateMap[path] = append(updateMap[path], hookAction)
 				case v1alpha1.FileOperationRemove:
-					removeMap[path] = append(removeMap[path], actionHook)
+					removeMap[path] = append(removeMap[path], hookAction)
 				case v1alpha1.FileOperationReboot:
-					rebootMap[path] = append(rebootMap[path], actionHook)
+					rebootMap[path] = append(rebootMap[path], hookAction)
 				default:
 					return nil, nil, nil, nil, ErrUnsupportedFilesystemOperation
 				}
@@ -158,21 +209,33 @@ func (m *manager) Sync(currentPtr, desiredPtr *v1alpha1.RenderedDeviceSpec) erro
 
 	current := lo.FromPtr(currentPtr)
 	desired := lo.FromPtr(desiredPtr)
-	if m.initialized.Load() && reflect.DeepEqual(current.Hooks, desired.Hooks) {
+	if m.initialized.Load() && reflect.DeepEqual(current.Hooks, desired.Hooks) && reflect.DeepEqual(current.Applications, desired.Applications) {
 		m.log.Debug("Hooks are equal. Nothing to update")
 		return nil
 	}
 	desiredHooks := lo.FromPtr(desired.Hooks)
-	beforeCreateMap, beforeUpdateMap, beforeRemoveMap, beforeRebootMap, err := m.generateOperationMaps(append(lo.FromPtr(desiredHooks.BeforeUpdating), defaultBeforeUpdateHooks()...))
+	if err := m.checkAllowedPaths(append(lo.FromPtr(desiredHooks.BeforeUpdating), lo.FromPtr(desiredHooks.AfterUpdating)...)); err != nil {
+		return err
+	}
+	applicationPaths := lo.FilterMap(lo.FromPtr(desired.Applications), func(application v1alpha1.ApplicationSpec, _ int) (string, bool) {
+		return lo.FromPtr(application.Path), application.Path != nil
+	})
+	beforeCreateMap, beforeUpdateMap, beforeRemoveMap, beforeRebootMap, err := m.generateOperationMaps(lo.FromPtr(desiredHooks.BeforeUpdating), defaultBeforeUpdateHooks(), applicationPaths)
 	if err != nil {
 		return err
 	}
-	afterCreateMap, afterUpdateMap, afterRemoveMap, afterRebootMap, err := m.generateOperationMaps(append(lo.FromPtr(desiredHooks.AfterUpdating), defaultAfterUpdateHooks()...))
+	afterCreateMap, afterUpdateMap, afterRemoveMap, afterRebootMap, err := m.generateOperationMaps(lo.FromPtr(desiredHooks.AfterUpdating), defaultAfterUpdateHooks(), applicationPaths)
 	if err != nil {
 		return err
 	}
+	hookNames := lo.Map(append(append(lo.FromPtr(desiredHooks.BeforeUpdating), lo.FromPtr(desiredHooks.AfterUpdating)...),
+		append(defaultBeforeUpdateHooks(), defaultAfterUpdateHooks()...)...), func(hookSpec v1alpha1.DeviceUpdateHookSpec, _ int) string {
+		return hookName(hookSpec)
+	})
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	// the results of removed hooks are no longer reported
+	m.results = lo.PickByKeys(m.results, hookNames)
 	m.onBeforeCreate = beforeCreateMap
 	m.onBeforeUpdate = beforeUpdateMap
 	m.onBeforeRemove = beforeRemoveMap
@@ -185,26 +248,123 @@ func (m *manager) Sync(currentPtr, desiredPtr *v1alpha1.RenderedDeviceSpec) erro
 	return nil
 }
 
+func (m *manager) SetAllowedPaths(paths []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if reflect.DeepEqual(m.allowedPaths, paths) {
+		return
+	}
+	m.allowedPaths = paths
+	// the hooks of the current spec are checked again by the next sync
+	m.initialized.Store(false)
+}
+
+// checkAllowedPaths rejects the hooks watching files outside the allowed
+// directories. The default hooks of the agent are not restricted.
+func (m *manager) checkAllowedPaths(hookSpecs []v1alpha1.DeviceUpdateHookSpec) error {
+	m.mu.Lock()
+	allowedPaths := m.allowedPaths
+	m.mu.Unlock()
+	if len(allowedPaths) == 0 {
+		return nil
+	}
+	for _, hookSpec := range hookSpecs {
+		path := filepath.Clean(lo.FromPtr(hookSpec.Path))
+		allowed := lo.ContainsBy(allowedPaths, func(dir string) bool {
+			rel, err := filepath.Rel(dir, path)
+			return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
+		})
+		if !allowed {
+			return fmt.Errorf("%w: %s", ErrPathNotAllowed, path)
+		}
+	}
+	return nil
+}
+
 func (m *manager) setError(path string, err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.errors[path] = err
 }
 
-func (m *manager) getActionsForPath(path string, actions ActionMap) []ActionHook {
+// actionsForPath returns the actions of the hooks watching the path or its
+// directory, either literally or by a glob pattern.
+func actionsForPath(path string, actions ActionMap) []*hookAction {
+	dir := filepath.Dir(path)
+	result := append(append([]*hookAction{}, actions[path]...), actions[dir]...)
+	patterns := lo.Filter(lo.Keys(actions), func(key string, _ int) bool {
+		return isPattern(key) && key != path && key != dir
+	})
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if match(pattern, path) || match(pattern, dir) {
+			result = append(result, actions[pattern]...)
+		}
+	}
+	return result
+}
+
+func isPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+func match(pattern, path string) bool {
+	matched, err := filepath.Match(pattern, path)
+	return err == nil && matched
+}
+
+// actionRun is a run of a hook action for a changed path.
+type actionRun struct {
+	action       *hookAction
+	path         string
+	changedFiles []string
+}
+
+// planRuns returns the runs of the before or after updating actions matching
+// the changes. Actions run for each matching change in the order of the
+// changes, except batch actions which run once after them for all the changes
+// they match. Either way an action receives all the changed files it matches.
+func (m *manager) planRuns(changes []FileChange, before bool) []actionRun {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	return append(actions[path], actions[filepath.Dir(path)]...)
-}
+	maps := map[v1alpha1.FileOperation]ActionMap{
+		v1alpha1.FileOperationCreate: m.onAfterCreate,
+		v1alpha1.FileOperationUpdate: m.onAfterUpdate,
+		v1alpha1.FileOperationRemove: m.onAfterRemove,
+		v1alpha1.FileOperationReboot: m.onAfterReboot,
+	}
+	if before {
+		maps = map[v1alpha1.FileOperation]ActionMap{
+			v1alpha1.FileOperationCreate: m.onBeforeCreate,
+			v1alpha1.FileOperationUpdate: m.onBeforeUpdate,
+			v1alpha1.FileOperationRemove: m.onBeforeRemove,
+			v1alpha1.FileOperationReboot: m.onBeforeReboot,
+		}
+	}
 
-func (m *manager) submitBackgroundJob(path string, actions ActionMap) {
-	actionList := m.getActionsForPath(path, actions)
-	if len(actionList) == 0 {
-		return
+	var runs []actionRun
+	var batches []*hookAction
+	changedFiles := make(map[*hookAction][]string)
+	for _, change := range changes {
+		for _, action := range actionsForPath(change.Path, maps[change.Operation]) {
+			if lo.Contains(action.ignored, change.Path) || lo.Contains(changedFiles[action], change.Path) {
+				continue
+			}
+			changedFiles[action] = append(changedFiles[action], change.Path)
+			if !action.batch {
+				runs = append(runs, actionRun{action: action, path: change.Path})
+			} else if len(changedFiles[action]) == 1 {
+				batches = append(batches, action)
+			}
+		}
 	}
-	m.backgroundJobs <- func(ctx context.Context) {
-		m.runActionList(ctx, path, actionList)
+	for i := range runs {
+		runs[i].changedFiles = changedFiles[runs[i].action]
 	}
+	for _, action := range batches {
+		runs = append(runs, actionRun{action: action, path: action.path, changedFiles: changedFiles[action]})
+	}
+	return runs
 }
 
 func (m *manager) Run(ctx context.Context) {
@@ -223,49 +383,59 @@ func (m *manager) Run(ctx context.Context) {
 	}
 }
 
-func (m *manager) runActionList(ctx context.Context, path string, actionHooks []ActionHook) {
-	for _, actionHook := range actionHooks {
-		if err := actionHook.OnChange(ctx, path); err != nil {
-			m.log.Errorf("error while running hook for path %s: %+v", path, err)
-			m.setError(path, err)
+func (m *manager) runActions(ctx context.Context, runs []actionRun) {
+	for _, run := range runs {
+		if err := run.action.run(ctx, run.path, run.changedFiles); err != nil {
+			m.log.Errorf("error while running hook for path %s: %+v", run.path, err)
+			m.setError(run.path, err)
 		}
 	}
 }
 
-func (m *manager) runActions(ctx context.Context, path string, actions ActionMap) {
-	m.runActionList(ctx, path, m.getActionsForPath(path, actions))
+func (m *manager) OnBeforeUpdating(ctx context.Context, changes []FileChange) {
+	m.runActions(ctx, m.planRuns(changes, true))
+}
+
+func (m *manager) OnAfterUpdating(ctx context.Context, changes []FileChange) {
+	runs := m.planRuns(changes, false)
+	if len(runs) == 0 {
+		return
+	}
+	m.backgroundJobs <- func(ctx context.Context) {
+		m.runActions(ctx, runs)
+	}
 }
 
 func (m *manager) OnBeforeCreate(ctx context.Context, path string) {
-	m.runActions(ctx, path, m.onBeforeCreate)
+	m.OnBeforeUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationCreate}})
 }
 
 func (m *manager) OnAfterCreate(ctx context.Context, path string) {
-	m.submitBackgroundJob(path, m.onAfterCreate)
+	m.OnAfterUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationCreate}})
 }
 
 func (m *manager) OnBeforeUpdate(ctx context.Context, path string) {
-	m.runActions(ctx, path, m.onBeforeUpdate)
+	m.OnBeforeUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationUpdate}})
 }
 
 func (m *manager) OnAfterUpdate(ctx context.Context, path string) {
-	m.submitBackgroundJob(path, m.onAfterUpdate)
+	m.OnAfterUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationUpdate}})
 }
 
 func (m *manager) OnBeforeRemove(ctx context.Context, path string) {
-	m.runActions(ctx, path, m.onBeforeRemove)
+	m.OnBeforeUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationRemove}})
 }
 
 func (m *manager) OnAfterRemove(ctx context.Context, path string) {
-	m.submitBackgroundJob(path, m.onAfterRemove)
+	m.OnAfterUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationRemove}})
 }
 
 func (m *manager) OnBeforeReboot(ctx context.Context, path string) {
-	m.runActions(ctx, path, m.onBeforeReboot)
+	m.OnBeforeUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationReboot}})
 }
 
 func (m *manager) OnAfterReboot(ctx context.Context, path string) {
-	m.submitBackgroundJob(path, m.onAfterReboot)
+	m.OnAfterUpdating(ctx, []FileChange{{Path: path, Operation: v1alpha1.FileOperationReboot}})
 }
 
 func (m *manager) Errors() []error {
@@ -274,6 +444,72 @@ func (m *manager) Errors() []error {
 	return lo.Values(m.errors)
 }
 
+func (m *manager) Results() []v1alpha1.DeviceHookStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	results := lo.Values(m.results)
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+func (m *manager) setResult(result v1alpha1.DeviceHookStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[result.Name] = result
+}
+
+// hookName returns the name results of the hook are reported under.
+func hookName(hookSpec v1alpha1.DeviceUpdateHookSpec) string {
+	if name := lo.FromPtr(hookSpec.Name); name != "" {
+		return name
+	}
+	return lo.FromPtr(hookSpec.Path)
+}
+
+// hookAction is an action of a hook, which records the exit status, duration
+// and output of each of its runs.
+type hookAction struct {
+	name  string
+	path  string
+	batch bool
+	// ignored are the changed paths the action does not run for
+	ignored    []string
+	executable bool
+	action     ActionHook
+	record     func(v1alpha1.DeviceHookStatus)
+}
+
+// run runs the action for the changed path, passing it the changed files
+// matching the hook.
+func (r *hookAction) run(ctx context.Context, path string, changedFiles []string) error {
+	start := time.Now()
+	output, err := r.action.OnChange(withChangedFiles(ctx, changedFiles), path)
+	finished := time.Now()
+
+	result := v1alpha1.DeviceHookStatus{
+		Name:            r.name,
+		Path:            path,
+		Succeeded:       err == nil,
+		DurationSeconds: finished.Sub(start).Seconds(),
+		FinishedAt:      finished.UTC(),
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = lo.ToPtr(int32(exitErr.ExitCode))
+	} else if err == nil && r.executable {
+		result.ExitCode = lo.ToPtr(int32(0))
+	}
+	if err != nil {
+		result.Message = lo.ToPtr(err.Error())
+	}
+	if output != nil {
+		result.Stdout = lo.EmptyableToPtr(output.Stdout)
+		result.Stderr = lo.EmptyableToPtr(output.Stderr)
+	}
+	r.record(result)
+	return err
+}
+
 func (m *manager) Close() error {
 	close(m.backgroundJobs)
 	return nil
@@ -2,6 +2,7 @@ package hook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -12,6 +13,8 @@ import (
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -153,3 +156,208 @@ func TestHookManager(t *testing.T) {
 		})
 	}
 }
+
+func TestHookManagerAllowedPaths(t *testing.T) {
+	testCases := []struct {
+		name         string
+		allowedPaths []string
+		path         string
+		expectErr    bool
+	}{
+		{
+			name: "no restriction",
+			path: "/etc/app/config.yaml",
+		},
+		{
+			name:         "file below an allowed directory",
+			allowedPaths: []string{"/var/lib/app", "/etc/app"},
+			path:         "/etc/app/config.yaml",
+		},
+		{
+			name:         "allowed directory",
+			allowedPaths: []string{"/etc/app"},
+			path:         "/etc/app",
+		},
+		{
+			name:         "file outside the allowed directories",
+			allowedPaths: []string{"/etc/app"},
+			path:         "/etc/application/config.yaml",
+			expectErr:    true,
+		},
+		{
+			name:         "pattern below an allowed directory",
+			allowedPaths: []string{"/etc/app"},
+			path:         "/etc/app/*.conf",
+		},
+		{
+			name:         "pattern matching outside the allowed directories",
+			allowedPaths: []string{"/etc/app"},
+			path:         "/etc/*/config.yaml",
+			expectErr:    true,
+		},
+		{
+			name:         "path escaping an allowed directory",
+			allowedPaths: []string{"/etc/app"},
+			path:         "/etc/app/../shadow",
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			hookManager := NewManager(executer.NewMockExecuter(ctrl), log.NewPrefixLogger("test"))
+			hookManager.SetAllowedPaths(tc.allowedPaths)
+
+			hooks := []v1alpha1.DeviceUpdateHookSpec{
+				{
+					Actions: []v1alpha1.HookAction{marshalExecutable("run-action", nil, "/tmp", "1m")},
+					OnFile:  &[]v1alpha1.FileOperation{v1alpha1.FileOperationUpdate},
+					Path:    util.StrToPtr(tc.path),
+				},
+			}
+			desired := &v1alpha1.RenderedDeviceSpec{Hooks: &v1alpha1.DeviceHooksSpec{AfterUpdating: &hooks}}
+			err := hookManager.Sync(nil, desired)
+			if tc.expectErr {
+				if !errors.Is(err, ErrPathNotAllowed) {
+					t.Fatalf("expected %v, got %v", ErrPathNotAllowed, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHookManagerChangedFiles(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockExecuter := executer.NewMockExecuter(ctrl)
+	hookManager := NewManager(mockExecuter, log.NewPrefixLogger("test"))
+	workDir := t.TempDir()
+
+	ops := &[]v1alpha1.FileOperation{v1alpha1.FileOperationCreate, v1alpha1.FileOperationUpdate}
+	hooks := []v1alpha1.DeviceUpdateHookSpec{
+		{
+			Name:    util.StrToPtr("validate"),
+			Actions: []v1alpha1.HookAction{marshalExecutable("validate {{ .FilePath }}", &[]string{"MODE=test"}, workDir, "1m")},
+			OnFile:  ops,
+			Path:    util.StrToPtr("/etc/app/*.conf"),
+		},
+		{
+			Name:    util.StrToPtr("reload"),
+			Actions: []v1alpha1.HookAction{marshalExecutable("reload {{ .ChangedFiles }}", &[]string{"MODE=test"}, workDir, "1m")},
+			OnFile:  ops,
+			Path:    util.StrToPtr("/etc/app/*.conf"),
+			Batch:   lo.ToPtr(true),
+		},
+	}
+	desired := &v1alpha1.RenderedDeviceSpec{Hooks: &v1alpha1.DeviceHooksSpec{BeforeUpdating: &hooks}}
+	require.NoError(hookManager.Sync(nil, desired))
+
+	// the per-file hook runs for each matching file and the batch hook once,
+	// both with the list of matching files
+	mode, changedFiles := "MODE=test", ChangedFilesEnvVar+"=/etc/app/a.conf\n/etc/app/c.conf"
+	gomock.InOrder(
+		mockExecuter.EXPECT().ExecuteWithContextFromDir(gomock.Any(), workDir, "bash", []string{"-c", "validate /etc/app/a.conf"}, mode, changedFiles),
+		mockExecuter.EXPECT().ExecuteWithContextFromDir(gomock.Any(), workDir, "bash", []string{"-c", "validate /etc/app/c.conf"}, mode, changedFiles),
+		mockExecuter.EXPECT().ExecuteWithContextFromDir(gomock.Any(), workDir, "bash", []string{"-c", "reload /etc/app/a.conf /etc/app/c.conf"}, mode, changedFiles),
+	)
+	hookManager.OnBeforeUpdating(context.Background(), []FileChange{
+		{Path: "/etc/app/a.conf", Operation: v1alpha1.FileOperationCreate},
+		{Path: "/etc/app/b.yaml", Operation: v1alpha1.FileOperationUpdate},
+		{Path: "/etc/app/c.conf", Operation: v1alpha1.FileOperationUpdate},
+		{Path: "/etc/other/d.conf", Operation: v1alpha1.FileOperationUpdate},
+	})
+
+	results := hookManager.Results()
+	require.Len(results, 2)
+	require.Equal("reload", results[0].Name)
+	require.Equal("/etc/app/*.conf", results[0].Path)
+	require.Equal("validate", results[1].Name)
+	require.Equal("/etc/app/c.conf", results[1].Path)
+}
+
+func TestHookManagerApplicationPaths(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hookManager := NewManager(executer.NewMockExecuter(ctrl), log.NewPrefixLogger("test"))
+
+	desired := &v1alpha1.RenderedDeviceSpec{
+		Applications: &[]v1alpha1.ApplicationSpec{{Name: "web", Path: lo.ToPtr("/var/run/flightctl/compose/web.yaml")}},
+	}
+	require.NoError(hookManager.Sync(nil, desired))
+
+	// the default compose hooks leave the compose file of the application to
+	// its update strategy
+	hookManager.OnBeforeUpdating(context.Background(), []FileChange{
+		{Path: "/var/run/flightctl/compose/web.yaml", Operation: v1alpha1.FileOperationUpdate},
+		{Path: "/var/run/flightctl/compose/other.yaml", Operation: v1alpha1.FileOperationUpdate},
+	})
+	results := hookManager.Results()
+	require.Len(results, 1)
+	require.Equal("podman compose down", results[0].Name)
+	require.Equal("/var/run/flightctl/compose/other.yaml", results[0].Path)
+}
+
+func TestHookManagerSandboxResults(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockExecuter := executer.NewMockExecuter(ctrl)
+	hookManager := NewManager(mockExecuter, log.NewPrefixLogger("test"))
+
+	workDir := t.TempDir()
+	action := v1alpha1.HookAction{}
+	require.NoError(action.FromHookAction0(v1alpha1.HookAction0{
+		Executable: v1alpha1.HookActionExecutableSpec{
+			Run:     "update-app {{ .FilePath }}",
+			EnvVars: &[]string{"MODE=strict"},
+			WorkDir: &workDir,
+			Timeout: util.StrToPtr("90s"),
+			Sandbox: &v1alpha1.HookActionSandbox{
+				User:               util.StrToPtr("app"),
+				CpuQuotaPercentage: lo.ToPtr(int32(50)),
+				MemoryMax:          util.StrToPtr("256M"),
+				ReadOnlyPaths:      &[]string{"/etc", "/usr"},
+			},
+		},
+	}))
+	hooks := []v1alpha1.DeviceUpdateHookSpec{
+		{
+			Name:    util.StrToPtr("update app"),
+			Actions: []v1alpha1.HookAction{action},
+			OnFile:  &[]v1alpha1.FileOperation{v1alpha1.FileOperationUpdate},
+			Path:    util.StrToPtr("/etc/app"),
+		},
+	}
+	desired := &v1alpha1.RenderedDeviceSpec{Hooks: &v1alpha1.DeviceHooksSpec{BeforeUpdating: &hooks}}
+	require.NoError(hookManager.Sync(nil, desired))
+
+	mockExecuter.EXPECT().ExecuteWithContext(gomock.Any(), "systemd-run",
+		"--quiet", "--wait", "--pipe", "--collect", "--service-type=exec", "--property=RuntimeMaxSec=90",
+		"--uid=app", "--property=CPUQuota=50%", "--property=MemoryMax=256M",
+		"--property=ReadOnlyPaths=/etc", "--property=ReadOnlyPaths=/usr",
+		"--working-directory="+workDir, "--setenv=MODE=strict", "--setenv=FLIGHTCTL_CHANGED_FILES=/etc/app/config.yaml",
+		"--", "bash", "-c", "update-app /etc/app/config.yaml").Return("", "out of memory", 137)
+	hookManager.OnBeforeUpdate(context.Background(), "/etc/app/config.yaml")
+
+	results := hookManager.Results()
+	require.Len(results, 1)
+	require.Equal("update app", results[0].Name)
+	require.Equal("/etc/app/config.yaml", results[0].Path)
+	require.False(results[0].Succeeded)
+	require.Equal(lo.ToPtr(int32(137)), results[0].ExitCode)
+	require.Contains(lo.FromPtr(results[0].Message), "out of memory")
+	require.Equal(lo.ToPtr("out of memory"), results[0].Stderr)
+	require.Nil(results[0].Stdout)
+
+	// the results of removed hooks are no longer reported
+	require.NoError(hookManager.Sync(desired, &v1alpha1.RenderedDeviceSpec{}))
+	require.Empty(hookManager.Results())
+}
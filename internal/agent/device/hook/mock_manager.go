@@ -116,6 +116,18 @@ func (mr *MockManagerMockRecorder) OnAfterUpdate(ctx, path any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnAfterUpdate", reflect.TypeOf((*MockManager)(nil).OnAfterUpdate), ctx, path)
 }
 
+// OnAfterUpdating mocks base method.
+func (m *MockManager) OnAfterUpdating(ctx context.Context, changes []FileChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnAfterUpdating", ctx, changes)
+}
+
+// OnAfterUpdating indicates an expected call of OnAfterUpdating.
+func (mr *MockManagerMockRecorder) OnAfterUpdating(ctx, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnAfterUpdating", reflect.TypeOf((*MockManager)(nil).OnAfterUpdating), ctx, changes)
+}
+
 // OnBeforeCreate mocks base method.
 func (m *MockManager) OnBeforeCreate(ctx context.Context, path string) {
 	m.ctrl.T.Helper()
@@ -164,6 +176,32 @@ func (mr *MockManagerMockRecorder) OnBeforeUpdate(ctx, path any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnBeforeUpdate", reflect.TypeOf((*MockManager)(nil).OnBeforeUpdate), ctx, path)
 }
 
+// OnBeforeUpdating mocks base method.
+func (m *MockManager) OnBeforeUpdating(ctx context.Context, changes []FileChange) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnBeforeUpdating", ctx, changes)
+}
+
+// OnBeforeUpdating indicates an expected call of OnBeforeUpdating.
+func (mr *MockManagerMockRecorder) OnBeforeUpdating(ctx, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnBeforeUpdating", reflect.TypeOf((*MockManager)(nil).OnBeforeUpdating), ctx, changes)
+}
+
+// Results mocks base method.
+func (m *MockManager) Results() []v1alpha1.DeviceHookStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Results")
+	ret0, _ := ret[0].([]v1alpha1.DeviceHookStatus)
+	return ret0
+}
+
+// Results indicates an expected call of Results.
+func (mr *MockManagerMockRecorder) Results() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Results", reflect.TypeOf((*MockManager)(nil).Results))
+}
+
 // Run mocks base method.
 func (m *MockManager) Run(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -176,6 +214,18 @@ func (mr *MockManagerMockRecorder) Run(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockManager)(nil).Run), ctx)
 }
 
+// SetAllowedPaths mocks base method.
+func (m *MockManager) SetAllowedPaths(paths []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAllowedPaths", paths)
+}
+
+// SetAllowedPaths indicates an expected call of SetAllowedPaths.
+func (mr *MockManagerMockRecorder) SetAllowedPaths(paths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllowedPaths", reflect.TypeOf((*MockManager)(nil).SetAllowedPaths), paths)
+}
+
 // Sync mocks base method.
 func (m *MockManager) Sync(current, desired *v1alpha1.RenderedDeviceSpec) error {
 	m.ctrl.T.Helper()
@@ -214,11 +264,12 @@ func (m *MockActionHook) EXPECT() *MockActionHookMockRecorder {
 }
 
 // OnChange mocks base method.
-func (m *MockActionHook) OnChange(ctx context.Context, path string) error {
+func (m *MockActionHook) OnChange(ctx context.Context, path string) (*ActionOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "OnChange", ctx, path)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*ActionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // OnChange indicates an expected call of OnChange.
@@ -0,0 +1,206 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/remotewrite"
+	"github.com/flightctl/flightctl/pkg/log"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// DefaultScrapeInterval is the default interval between two scrapes of the local exporters.
+	DefaultScrapeInterval = 60 * time.Second
+
+	scrapeTimeout = 10 * time.Second
+)
+
+var _ Manager = (*manager)(nil)
+
+// Manager periodically scrapes local Prometheus exporters and pushes their
+// series to the management service, which forwards them to a remote-write receiver.
+type Manager interface {
+	// Run scrapes and pushes metrics until the context is canceled.
+	Run(ctx context.Context)
+}
+
+type manager struct {
+	deviceName       string
+	targets          []string
+	interval         time.Duration
+	managementClient client.Management
+	httpClient       *http.Client
+	log              *log.PrefixLogger
+}
+
+// NewManager creates a new metrics manager scraping the given exporter URLs.
+func NewManager(deviceName string, targets []string, interval time.Duration, managementClient client.Management, log *log.PrefixLogger) Manager {
+	if interval <= 0 {
+		interval = DefaultScrapeInterval
+	}
+	return &manager{
+		deviceName:       deviceName,
+		targets:          targets,
+		interval:         interval,
+		managementClient: managementClient,
+		httpClient:       &http.Client{Timeout: scrapeTimeout},
+		log:              log,
+	}
+}
+
+func (m *manager) Run(ctx context.Context) {
+	if len(m.targets) == 0 {
+		return
+	}
+	m.log.Infof("Forwarding metrics of %d exporters every %s", len(m.targets), m.interval)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.scrapeAndPush(ctx); err != nil {
+				m.log.Errorf("Failed to forward metrics: %v", err)
+			}
+		}
+	}
+}
+
+func (m *manager) scrapeAndPush(ctx context.Context) error {
+	req := &remotewrite.WriteRequest{}
+	for _, target := range m.targets {
+		series, err := m.scrape(ctx, target)
+		if err != nil {
+			// one failing exporter must not prevent forwarding the others
+			m.log.Warnf("Failed to scrape %s: %v", target, err)
+			continue
+		}
+		req.Timeseries = append(req.Timeseries, series...)
+	}
+	if len(req.Timeseries) == 0 {
+		return nil
+	}
+	payload, err := remotewrite.Encode(req)
+	if err != nil {
+		return err
+	}
+	return m.managementClient.PushDeviceMetrics(ctx, m.deviceName, payload)
+}
+
+func (m *manager) scrape(ctx context.Context, target string) ([]remotewrite.TimeSeries, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("parsing target: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	// the text format is the only one every exporter supports
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+
+	now := time.Now()
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metrics: %w", err)
+	}
+	return toTimeSeries(families, targetURL.Host, now.UnixMilli()), nil
+}
+
+// toTimeSeries flattens metric families into series the way Prometheus does
+// on ingestion: histograms and summaries are split into their _bucket/quantile,
+// _sum and _count series. Every series is labeled with the scraped instance.
+func toTimeSeries(families map[string]*dto.MetricFamily, instance string, timestamp int64) []remotewrite.TimeSeries {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var series []remotewrite.TimeSeries
+	for _, name := range names {
+		family := families[name]
+		for _, metric := range family.GetMetric() {
+			ts := timestamp
+			if metric.TimestampMs != nil {
+				ts = metric.GetTimestampMs()
+			}
+			add := func(suffix string, value float64, extra ...string) {
+				s := remotewrite.TimeSeries{Samples: []remotewrite.Sample{{Value: value, Timestamp: ts}}}
+				for _, l := range metric.GetLabel() {
+					remotewrite.SetLabel(&s, l.GetName(), l.GetValue())
+				}
+				for i := 0; i+1 < len(extra); i += 2 {
+					remotewrite.SetLabel(&s, extra[i], extra[i+1])
+				}
+				remotewrite.SetLabel(&s, "__name__", name+suffix)
+				remotewrite.SetLabel(&s, "instance", instance)
+				series = append(series, s)
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", metric.GetGauge().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, q := range summary.GetQuantile() {
+					add("", q.GetValue(), "quantile", formatFloat(q.GetQuantile()))
+				}
+				add("_sum", summary.GetSampleSum())
+				add("_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				infSeen := false
+				for _, b := range histogram.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						infSeen = true
+					}
+					add("_bucket", float64(b.GetCumulativeCount()), "le", formatFloat(b.GetUpperBound()))
+				}
+				if !infSeen {
+					add("_bucket", float64(histogram.GetSampleCount()), "le", "+Inf")
+				}
+				add("_sum", histogram.GetSampleSum())
+				add("_count", float64(histogram.GetSampleCount()))
+			default:
+				add("", metric.GetUntyped().GetValue())
+			}
+		}
+	}
+	return series
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/remotewrite"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+)
+
+const exposition = `# TYPE node_load1 gauge
+node_load1 0.5
+# TYPE http_requests_total counter
+http_requests_total{code="200"} 10 1700000000123
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.2
+rpc_duration_seconds_sum 4
+rpc_duration_seconds_count 8
+# TYPE request_size_bytes histogram
+request_size_bytes_bucket{le="100"} 3
+request_size_bytes_bucket{le="+Inf"} 5
+request_size_bytes_sum 700
+request_size_bytes_count 5
+`
+
+func TestToTimeSeries(t *testing.T) {
+	require := require.New(t)
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(exposition))
+	require.NoError(err)
+
+	const now = int64(1700000000000)
+	series := toTimeSeries(families, "localhost:9100", now)
+
+	type flat struct {
+		labels    map[string]string
+		value     float64
+		timestamp int64
+	}
+	var got []flat
+	for _, s := range series {
+		labels := map[string]string{}
+		for _, l := range s.Labels {
+			labels[l.Name] = l.Value
+		}
+		require.Equal("localhost:9100", labels["instance"])
+		delete(labels, "instance")
+		require.Len(s.Samples, 1)
+		got = append(got, flat{labels, s.Samples[0].Value, s.Samples[0].Timestamp})
+	}
+
+	require.Equal([]flat{
+		{map[string]string{"__name__": "http_requests_total", "code": "200"}, 10, 1700000000123},
+		{map[string]string{"__name__": "node_load1"}, 0.5, now},
+		{map[string]string{"__name__": "request_size_bytes_bucket", "le": "100"}, 3, now},
+		{map[string]string{"__name__": "request_size_bytes_bucket", "le": "+Inf"}, 5, now},
+		{map[string]string{"__name__": "request_size_bytes_sum"}, 700, now},
+		{map[string]string{"__name__": "request_size_bytes_count"}, 5, now},
+		{map[string]string{"__name__": "rpc_duration_seconds", "quantile": "0.5"}, 0.2, now},
+		{map[string]string{"__name__": "rpc_duration_seconds_sum"}, 4, now},
+		{map[string]string{"__name__": "rpc_duration_seconds_count"}, 8, now},
+	}, got)
+
+	// labels are sorted as remote-write receivers expect
+	for _, s := range series {
+		require.IsIncreasing(labelNames(s))
+	}
+}
+
+func labelNames(s remotewrite.TimeSeries) []string {
+	names := make([]string, 0, len(s.Labels))
+	for _, l := range s.Labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
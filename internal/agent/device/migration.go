@@ -0,0 +1,100 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// MigrationFile records the instance the device migrated to, relative to the data dir
+	MigrationFile = "migration.json"
+
+	migrationCommandTimeout = time.Minute
+)
+
+// MigrationController re-points the agent to the Flight Control instance a
+// device migrates to. The migration is recorded in the data dir, whose
+// endpoints take precedence over the management service of the config file
+// once the agent restarts.
+type MigrationController struct {
+	dataDir    string
+	exec       executer.Executer
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+}
+
+func NewMigrationController(
+	dataDir string,
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	log *log.PrefixLogger,
+) *MigrationController {
+	return &MigrationController{
+		dataDir:    dataDir,
+		exec:       exec,
+		readWriter: readWriter,
+		log:        log,
+	}
+}
+
+// Sync records the migration of the desired spec and restarts the agent, so
+// that it connects to the instance the device migrates to.
+func (c *MigrationController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	if desired.Migration == nil {
+		return nil
+	}
+	c.log.Debug("Syncing migration")
+	defer c.log.Debug("Finished syncing migration")
+
+	recorded, err := ReadMigration(c.readWriter, c.dataDir)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(recorded, desired.Migration) {
+		content, err := json.Marshal(desired.Migration)
+		if err != nil {
+			return err
+		}
+		if err := c.readWriter.WriteFile(filepath.Join(c.dataDir, MigrationFile), content, 0600); err != nil {
+			return fmt.Errorf("recording migration: %w", err)
+		}
+	}
+
+	c.log.Warnf("Device is migrating to %s, restarting the agent", desired.Migration.ManagementEndpoint)
+	ctx, cancel := context.WithTimeout(ctx, migrationCommandTimeout)
+	defer cancel()
+	// do not wait for the restart, which stops this agent
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, "--no-block", "restart", agentServiceName)
+	if exitCode != 0 {
+		return fmt.Errorf("failed to restart %s: exit code %d: %s", agentServiceName, exitCode, stderr)
+	}
+	return nil
+}
+
+// ReadMigration returns the migration recorded in the data dir, or nil if
+// the device did not migrate.
+func ReadMigration(reader fileio.Reader, dataDir string) (*v1alpha1.DeviceMigration, error) {
+	path := filepath.Join(dataDir, MigrationFile)
+	exists, err := reader.FileExists(path)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := reader.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var migration v1alpha1.DeviceMigration
+	if err := json.Unmarshal(content, &migration); err != nil {
+		return nil, fmt.Errorf("reading migration: %w", err)
+	}
+	return &migration, nil
+}
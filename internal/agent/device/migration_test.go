@@ -0,0 +1,47 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMigrationRecordsEndpointsAndRestarts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	c := NewMigrationController("/var/lib/flightctl", execMock, readWriter, flightlog.NewPrefixLogger(""))
+	ctx := context.Background()
+
+	// a spec without a migration does nothing
+	require.NoError(c.Sync(ctx, &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}))
+	migration, err := ReadMigration(readWriter, "/var/lib/flightctl")
+	require.NoError(err)
+	require.Nil(migration)
+
+	desired := &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		Migration: &v1alpha1.DeviceMigration{
+			ManagementEndpoint:     "https://target.example.com:7443",
+			GrpcManagementEndpoint: lo.ToPtr("https://target.example.com:7444"),
+		},
+	}
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "restart", agentServiceName).Return("", "", 0)
+	require.NoError(c.Sync(ctx, desired))
+	migration, err = ReadMigration(readWriter, "/var/lib/flightctl")
+	require.NoError(err)
+	require.Equal(desired.Migration, migration)
+
+	// a failed restart is retried on the next sync
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "--no-block", "restart", agentServiceName).Return("", "failed", 1)
+	require.Error(c.Sync(ctx, desired))
+}
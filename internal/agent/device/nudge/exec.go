@@ -0,0 +1,35 @@
+package nudge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/flightctl/flightctl/pkg/executer"
+)
+
+var _ Source = (*execSource)(nil)
+
+type execSource struct {
+	exec    executer.Executer
+	command []string
+}
+
+// NewExec creates a source running the command, such as a script waiting for
+// a LoRa downlink, which blocks until the device is nudged and then exits
+// with 0. Other exit codes are failures of the source.
+func NewExec(exec executer.Executer, command []string) Source {
+	return &execSource{exec: exec, command: command}
+}
+
+func (e *execSource) Name() string {
+	return "command " + e.command[0]
+}
+
+func (e *execSource) Wait(ctx context.Context) error {
+	_, stderr, exitCode := e.exec.ExecuteWithContext(ctx, e.command[0], e.command[1:]...)
+	if exitCode != 0 {
+		return fmt.Errorf("exited with code %d: %s", exitCode, strings.TrimSpace(stderr))
+	}
+	return nil
+}
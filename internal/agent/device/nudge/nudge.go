@@ -0,0 +1,94 @@
+// Package nudge wakes the agent up for an immediate sync of the device by
+// out-of-band means, such as an SMS received by a modem or a LoRa downlink,
+// so that devices which poll the service rarely can be updated on demand.
+package nudge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// SourceExec runs a command which exits with 0 once the device is nudged.
+	SourceExec = "exec"
+	// SourceSerial reads the lines written by a modem to its serial device.
+	SourceSerial = "serial"
+
+	// DefaultMinInterval is the minimum interval between two syncs triggered
+	// by the nudges of a source.
+	DefaultMinInterval = 30 * time.Second
+	// retryInterval is the interval between two waits of a source which failed.
+	retryInterval = 30 * time.Second
+)
+
+// Source waits for the nudges of an out-of-band channel.
+type Source interface {
+	// Name identifies the source in the logs.
+	Name() string
+	// Wait blocks until the device is nudged, returning nil, or until the
+	// source fails or the context is done.
+	Wait(ctx context.Context) error
+}
+
+// Run waits for the nudges of the source until the context is done, calling
+// onNudge for each one. Nudges received within minInterval of the previous
+// sync are coalesced into a single sync at the end of the interval, so a
+// chatty channel cannot keep the device busy syncing.
+func Run(ctx context.Context, source Source, minInterval time.Duration, onNudge func(), log *log.PrefixLogger) {
+	log.Infof("Waiting for nudges from %s", source.Name())
+	var lastNudge time.Time
+	for {
+		err := source.Wait(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Warnf("Waiting for nudges from %s failed, retrying in %s: %v", source.Name(), retryInterval, err)
+			if !sleep(ctx, retryInterval) {
+				return
+			}
+			continue
+		}
+
+		if wait := minInterval - time.Since(lastNudge); wait > 0 {
+			log.Debugf("Delaying nudge from %s by %s", source.Name(), wait)
+			if !sleep(ctx, wait) {
+				return
+			}
+		}
+		log.Infof("Nudged by %s, syncing device", source.Name())
+		lastNudge = time.Now()
+		onNudge()
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// New creates the source of the given type. The command is only used by the
+// exec source, the serial device and the pattern by the serial source.
+func New(source string, exec executer.Executer, command []string, device string, pattern string) (Source, error) {
+	switch source {
+	case SourceExec:
+		if len(command) == 0 {
+			return nil, fmt.Errorf("the exec source requires a command")
+		}
+		return NewExec(exec, command), nil
+	case SourceSerial:
+		return NewSerial(device, pattern), nil
+	default:
+		return nil, fmt.Errorf("unknown nudge source %q", source)
+	}
+}
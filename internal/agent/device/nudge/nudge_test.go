@@ -0,0 +1,86 @@
+package nudge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeSource struct {
+	nudges chan error
+}
+
+func (f *fakeSource) Name() string {
+	return "fake"
+}
+
+func (f *fakeSource) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-f.nudges:
+		return err
+	}
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := &fakeSource{nudges: make(chan error)}
+	synced := make(chan time.Time, 10)
+	done := make(chan struct{})
+	minInterval := 200 * time.Millisecond
+	go func() {
+		Run(ctx, source, minInterval, func() { synced <- time.Now() }, log.NewPrefixLogger("test"))
+		close(done)
+	}()
+
+	source.nudges <- nil
+	first := <-synced
+	// a nudge within the minimum interval is delayed until its end
+	source.nudges <- nil
+	second := <-synced
+	require.GreaterOrEqual(second.Sub(first), minInterval)
+
+	cancel()
+	<-done
+	require.Empty(synced)
+}
+
+func TestExec(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	execMock := executer.NewMockExecuter(ctrl)
+
+	source, err := New(SourceExec, execMock, []string{"/usr/libexec/lora-wait", "--port", "1"}, "", "")
+	require.NoError(err)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/libexec/lora-wait", "--port", "1").Return("", "", 0)
+	require.NoError(source.Wait(context.Background()))
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/libexec/lora-wait", "--port", "1").Return("", "no radio\n", 1)
+	require.ErrorContains(source.Wait(context.Background()), "exited with code 1: no radio")
+
+	_, err = New(SourceExec, execMock, nil, "", "")
+	require.Error(err)
+}
+
+func TestSerial(t *testing.T) {
+	require := require.New(t)
+	device := filepath.Join(t.TempDir(), "ttyUSB2")
+
+	require.NoError(os.WriteFile(device, []byte("OK\r\n+CMTI: \"SM\",3\r\n"), 0600))
+	require.NoError(NewSerial(device, "").Wait(context.Background()))
+
+	require.NoError(os.WriteFile(device, []byte("OK\r\nRING\r\n"), 0600))
+	require.NoError(NewSerial(device, "RING").Wait(context.Background()))
+	require.ErrorContains(NewSerial(device, "").Wait(context.Background()), "closed")
+}
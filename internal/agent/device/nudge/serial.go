@@ -0,0 +1,62 @@
+package nudge
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultSerialPattern is the unsolicited result code modems write when they
+// receive an SMS.
+const DefaultSerialPattern = "+CMTI:"
+
+var _ Source = (*serial)(nil)
+
+type serial struct {
+	device  string
+	pattern string
+}
+
+// NewSerial creates a source reading the lines a modem writes to the serial
+// device, such as /dev/ttyUSB2, which nudges the device with each line
+// containing the pattern, DefaultSerialPattern if empty.
+func NewSerial(device string, pattern string) Source {
+	if pattern == "" {
+		pattern = DefaultSerialPattern
+	}
+	return &serial{device: device, pattern: pattern}
+}
+
+func (s *serial) Name() string {
+	return "serial device " + s.device
+}
+
+func (s *serial) Wait(ctx context.Context) error {
+	file, err := os.Open(s.device)
+	if err != nil {
+		return fmt.Errorf("opening serial device: %w", err)
+	}
+	// closing the device unblocks the pending read once the context is done
+	stop := context.AfterFunc(ctx, func() { file.Close() })
+	defer func() {
+		if stop() {
+			file.Close()
+		}
+	}()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if strings.Contains(scanner.Text(), s.pattern) {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading serial device: %w", err)
+	}
+	return fmt.Errorf("serial device %s closed", s.device)
+}
@@ -23,6 +23,7 @@ type OSImageController struct {
 	bootc         *container.BootcCmd
 	statusManager status.Manager
 	specManager   spec.Manager
+	progress      *UpdateProgress
 	log           *log.PrefixLogger
 }
 
@@ -40,6 +41,12 @@ func NewOSImageController(
 	}
 }
 
+// SetUpdateProgress sets the progress the download of the OS image and the
+// reboot into it are reported to.
+func (c *OSImageController) SetUpdateProgress(progress *UpdateProgress) {
+	c.progress = progress
+}
+
 func (c *OSImageController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
 	c.log.Debug("Syncing device image")
 	defer c.log.Debug("Finished syncing device image")
@@ -71,7 +78,7 @@ func (c *OSImageController) ensureImage(ctx context.Context, desired *v1alpha1.R
 
 	image := desired.Os.Image
 	c.log.Infof("Switching to os image: %s", image)
-	if err := c.bootc.Switch(ctx, image); err != nil {
+	if err := c.switchImage(ctx, image); err != nil {
 		return err
 	}
 
@@ -95,6 +102,7 @@ func (c *OSImageController) ensureImage(ctx context.Context, desired *v1alpha1.R
 	}
 
 	c.log.Info(infoMsg)
+	c.progress.Phase(ctx, v1alpha1.DeviceUpdatePhaseAwaitingReboot, infoMsg)
 
 	if err := c.specManager.PrepareRollback(ctx); err != nil {
 		return err
@@ -102,3 +110,15 @@ func (c *OSImageController) ensureImage(ctx context.Context, desired *v1alpha1.R
 
 	return c.bootc.Apply(ctx)
 }
+
+// switchImage stages the image, reporting the progress of its download if the
+// progress of the update is reported.
+func (c *OSImageController) switchImage(ctx context.Context, image string) error {
+	if c.progress == nil {
+		return c.bootc.Switch(ctx, image)
+	}
+	c.progress.Phase(ctx, v1alpha1.DeviceUpdatePhaseDownloadingImages, fmt.Sprintf("Downloading os image %s", image))
+	return c.bootc.SwitchWithProgress(ctx, image, func(progress container.BootcProgress) {
+		c.progress.Download(ctx, image, progress)
+	})
+}
@@ -0,0 +1,219 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/samber/lo"
+)
+
+// quadletDir holds the Quadlet files of the pods, from which podman generates
+// their systemd services on daemon-reload.
+const quadletDir = "/etc/containers/systemd"
+
+// syncPod runs an application declared as a pod of containers. The pod and its
+// containers are written as Quadlet files, so that systemd starts them in the
+// order of their dependencies and brings them up again at boot. Pods are
+// always updated by recreating them.
+func (c *ApplicationController) syncPod(ctx context.Context, application v1alpha1.ApplicationSpec, state *applicationState) *applicationState {
+	files := podQuadletFiles(application)
+	names := lo.Keys(files)
+	sort.Strings(names)
+	hash := podHash(files)
+
+	deployed := state != nil && state.Project != ""
+	if deployed && hash == state.Hash {
+		if !c.started {
+			if err := c.startPod(ctx, application.Name, names, "start"); err != nil {
+				c.log.Errorf("Failed to bring up application %s: %v", application.Name, err)
+			}
+		}
+		return state
+	}
+
+	if deployed {
+		c.log.Infof("Recreating pod of application %s", application.Name)
+	} else {
+		c.log.Infof("Bringing up pod of application %s", application.Name)
+	}
+	if err := c.writeQuadletFiles(ctx, files, state); err != nil {
+		return c.withResult(state, v1alpha1.ApplicationUpdateStrategyRecreate, v1alpha1.ApplicationUpdateFailed, err)
+	}
+	state = &applicationState{Project: application.Name, QuadletFiles: names}
+	if err := c.startPod(ctx, application.Name, names, "restart"); err != nil {
+		return c.withResult(state, v1alpha1.ApplicationUpdateStrategyRecreate, v1alpha1.ApplicationUpdateFailed, err)
+	}
+	state.Hash = hash
+	return c.withResult(state, v1alpha1.ApplicationUpdateStrategyRecreate, v1alpha1.ApplicationUpdateSucceeded, nil)
+}
+
+// writeQuadletFiles writes the Quadlet files of a pod, removes those of the
+// running version which are no longer part of it and has podman generate the
+// services of the pod.
+func (c *ApplicationController) writeQuadletFiles(ctx context.Context, files map[string]string, state *applicationState) error {
+	for name, content := range files {
+		if err := c.readWriter.WriteFile(filepath.Join(quadletDir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("writing Quadlet file %s: %w", name, err)
+		}
+	}
+	if state != nil {
+		for _, name := range state.QuadletFiles {
+			if _, ok := files[name]; ok {
+				continue
+			}
+			if err := c.readWriter.RemoveFile(filepath.Join(quadletDir, name)); err != nil {
+				return fmt.Errorf("removing Quadlet file %s: %w", name, err)
+			}
+		}
+	}
+	return client.NewSystemd(c.exec).DaemonReload(ctx)
+}
+
+// startPod starts or restarts the services of a pod. The containers are
+// started with the pod, as they are bound to the pod service and taken down
+// with it.
+func (c *ApplicationController) startPod(ctx context.Context, name string, files []string, action string) error {
+	ctx, cancel := context.WithTimeout(ctx, applicationCommandTimeout)
+	defer cancel()
+	args := append([]string{action}, lo.Map(files, func(file string, _ int) string { return quadletService(file) })...)
+	if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, args...); exitCode != 0 {
+		return fmt.Errorf("%s of pod %s failed with code %d: %s", action, name, exitCode, strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// takeDownPod stops the pod of an application removed from the spec and
+// removes its Quadlet files.
+func (c *ApplicationController) takeDownPod(ctx context.Context, name string, state *applicationState) {
+	c.log.Infof("Taking down pod of application %s", name)
+	if _, stderr, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, "stop", quadletService(podQuadletFile(name))); exitCode != 0 {
+		c.log.Errorf("Failed to take down pod of application %s: %s", name, strings.TrimSpace(stderr))
+	}
+	for _, file := range state.QuadletFiles {
+		if err := c.readWriter.RemoveFile(filepath.Join(quadletDir, file)); err != nil {
+			c.log.Warnf("Failed to remove Quadlet file %s of application %s: %v", file, name, err)
+		}
+	}
+	if err := client.NewSystemd(c.exec).DaemonReload(ctx); err != nil {
+		c.log.Warnf("Failed to remove the services of application %s: %v", name, err)
+	}
+}
+
+// podHash returns the SHA-256 of the Quadlet files of a pod, which changes
+// with the name or the content of any of them.
+func podHash(files map[string]string) string {
+	names := lo.Keys(files)
+	sort.Strings(names)
+	var content strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&content, "%s\n%s\n", name, files[name])
+	}
+	return contentHash([]byte(content.String()))
+}
+
+// podQuadletFiles returns the Quadlet files of the pod of an application by
+// their name. The init containers run to completion one after the other before
+// the containers of the pod start, and a container starts after the containers
+// it depends on.
+func podQuadletFiles(application v1alpha1.ApplicationSpec) map[string]string {
+	pod := application.Pod
+	podFile := podQuadletFile(application.Name)
+	slice := ""
+	if application.Resources != nil && (application.Resources.Cpu != nil || application.Resources.Memory != nil) {
+		slice = sliceName(application.Name)
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "[Unit]\nDescription=Flight Control application %s\n\n[Pod]\nPodName=%s\n", application.Name, application.Name)
+	for _, port := range lo.FromPtr(pod.Ports) {
+		fmt.Fprintf(&content, "PublishPort=%s\n", port)
+	}
+	writeServiceSection(&content, slice, false)
+	files := map[string]string{podFile: content.String()}
+
+	initServices := []string{}
+	for _, container := range lo.FromPtr(pod.InitContainers) {
+		file := containerQuadletFile(application.Name, container.Name)
+		files[file] = containerQuadlet(application.Name, podFile, container, initServices, slice, true)
+		initServices = append(initServices, quadletService(file))
+	}
+	for _, container := range pod.Containers {
+		after := append(slices.Clone(initServices), lo.Map(lo.FromPtr(container.DependsOn), func(dependency string, _ int) string {
+			return quadletService(containerQuadletFile(application.Name, dependency))
+		})...)
+		files[containerQuadletFile(application.Name, container.Name)] = containerQuadlet(application.Name, podFile, container, after, slice, false)
+	}
+	return files
+}
+
+func containerQuadlet(application string, podFile string, container v1alpha1.ApplicationContainer, after []string, slice string, init bool) string {
+	var content strings.Builder
+	fmt.Fprintf(&content, "[Unit]\nDescription=Flight Control application %s container %s\n", application, container.Name)
+	if len(after) > 0 {
+		fmt.Fprintf(&content, "After=%s\nRequires=%s\n", strings.Join(after, " "), strings.Join(after, " "))
+	}
+	fmt.Fprintf(&content, "\n[Container]\nContainerName=%s-%s\nImage=%s\nPod=%s\n", application, container.Name, container.Image, podFile)
+	if command := lo.FromPtr(container.Command); len(command) > 0 {
+		fmt.Fprintf(&content, "Exec=%s\n", strings.Join(lo.Map(command, func(arg string, _ int) string { return quoteUnitArg(arg) }), " "))
+	}
+	for _, envVar := range lo.FromPtr(container.EnvVars) {
+		fmt.Fprintf(&content, "Environment=%s\n", quoteUnitArg(envVar))
+	}
+	for _, mount := range lo.FromPtr(container.VolumeMounts) {
+		volume := volumeName(application, mount.Name) + ":" + mount.MountPath
+		if lo.FromPtr(mount.ReadOnly) {
+			volume += ":ro"
+		}
+		fmt.Fprintf(&content, "Volume=%s\n", volume)
+	}
+	writeServiceSection(&content, slice, init)
+	return content.String()
+}
+
+// writeServiceSection writes the systemd sections shared by the Quadlet files
+// of a pod. The pod starts at boot, and its containers with it.
+func writeServiceSection(content *strings.Builder, slice string, init bool) {
+	content.WriteString("\n[Service]\n")
+	if slice != "" {
+		fmt.Fprintf(content, "Slice=%s\n", slice)
+	}
+	if init {
+		content.WriteString("Type=oneshot\nRemainAfterExit=yes\n")
+	} else {
+		content.WriteString("Restart=always\n")
+	}
+	content.WriteString("\n[Install]\nWantedBy=multi-user.target\n")
+}
+
+func podQuadletFile(application string) string {
+	return "flightctl-" + application + ".pod"
+}
+
+func containerQuadletFile(application string, container string) string {
+	return "flightctl-" + application + "-" + container + ".container"
+}
+
+// quadletService returns the systemd service podman generates from a Quadlet
+// file, whose name is suffixed with -pod for pods.
+func quadletService(file string) string {
+	if name, ok := strings.CutSuffix(file, ".pod"); ok {
+		return name + "-pod.service"
+	}
+	return strings.TrimSuffix(file, ".container") + ".service"
+}
+
+// quoteUnitArg quotes an argument of a systemd unit setting if it contains
+// whitespace or quotes.
+func quoteUnitArg(arg string) string {
+	if arg == "" || strings.ContainsAny(arg, " \t\"'\\") {
+		return strconv.Quote(arg)
+	}
+	return arg
+}
@@ -0,0 +1,118 @@
+package device
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplicationSyncPod(t *testing.T) {
+	require := require.New(t)
+	c, execMock, statusManager, readWriter := newTestApplicationController(t)
+	ctx := context.Background()
+	application := v1alpha1.ApplicationSpec{
+		Name: "web",
+		Pod: &v1alpha1.ApplicationPodSpec{
+			Containers: []v1alpha1.ApplicationContainer{
+				{Name: "app", Image: "quay.io/example/app:v1", DependsOn: &[]string{"db"}},
+				{Name: "db", Image: "quay.io/example/db:v1"},
+			},
+			InitContainers: &[]v1alpha1.ApplicationContainer{{Name: "migrate", Image: "quay.io/example/app:v1", Command: &[]string{"migrate", "--all"}}},
+		},
+	}
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+
+	// the services generated from the Quadlet files are restarted together
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "restart",
+			"flightctl-web-app.service", "flightctl-web-db.service", "flightctl-web-migrate.service", "flightctl-web-pod.service").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desiredApplications(application)))
+	require.NoError(c.Sync(ctx, desiredApplications(application)))
+	require.Equal(v1alpha1.ApplicationUpdateSucceeded, c.reported["web"].Result)
+	content, err := readWriter.ReadFile(filepath.Join(quadletDir, "flightctl-web-app.container"))
+	require.NoError(err)
+	require.Contains(string(content), "After=flightctl-web-migrate.service flightctl-web-db.service\n")
+
+	// the Quadlet files of containers removed from the pod are removed
+	application.Pod.InitContainers = nil
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "restart",
+			"flightctl-web-app.service", "flightctl-web-db.service", "flightctl-web-pod.service").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desiredApplications(application)))
+	require.Equal(v1alpha1.ApplicationUpdateStrategyRecreate, c.reported["web"].Strategy)
+	exists, err := readWriter.FileExists(filepath.Join(quadletDir, "flightctl-web-migrate.container"))
+	require.NoError(err)
+	require.False(exists)
+
+	// an application removed from the spec is taken down with its pod
+	gomock.InOrder(
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "stop", "flightctl-web-pod.service").Return("", "", 0),
+		execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "daemon-reload").Return("", "", 0),
+	)
+	require.NoError(c.Sync(ctx, desiredApplications()))
+	require.Empty(c.reported)
+	exists, err = readWriter.FileExists(filepath.Join(quadletDir, "flightctl-web.pod"))
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestPodQuadletFiles(t *testing.T) {
+	require := require.New(t)
+	files := podQuadletFiles(v1alpha1.ApplicationSpec{
+		Name:      "web",
+		Resources: &v1alpha1.ApplicationResources{Cpu: lo.ToPtr("1")},
+		Volumes:   &[]v1alpha1.ApplicationVolume{{Name: "data"}},
+		Pod: &v1alpha1.ApplicationPodSpec{
+			Ports: &[]string{"8080:80"},
+			Containers: []v1alpha1.ApplicationContainer{{
+				Name:         "app",
+				Image:        "quay.io/example/app:v1",
+				Command:      &[]string{"serve", "--greeting", "hello world"},
+				EnvVars:      &[]string{"LOG_LEVEL=debug"},
+				VolumeMounts: &[]v1alpha1.ApplicationVolumeMount{{Name: "data", MountPath: "/data", ReadOnly: lo.ToPtr(true)}},
+			}},
+		},
+	})
+	require.Len(files, 2)
+	require.Equal(`[Unit]
+Description=Flight Control application web
+
+[Pod]
+PodName=web
+PublishPort=8080:80
+
+[Service]
+Slice=flightctl-app-web.slice
+Restart=always
+
+[Install]
+WantedBy=multi-user.target
+`, files["flightctl-web.pod"])
+	require.Equal(`[Unit]
+Description=Flight Control application web container app
+
+[Container]
+ContainerName=web-app
+Image=quay.io/example/app:v1
+Pod=flightctl-web.pod
+Exec=serve --greeting "hello world"
+Environment=LOG_LEVEL=debug
+Volume=web_data:/data:ro
+
+[Service]
+Slice=flightctl-app-web.slice
+Restart=always
+
+[Install]
+WantedBy=multi-user.target
+`, files["flightctl-web-app.container"])
+}
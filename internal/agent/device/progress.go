@@ -0,0 +1,266 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+// progressReportInterval is the minimum interval between two reports of the
+// download of an image, while the reports of a new phase are pushed right away.
+const progressReportInterval = 10 * time.Second
+
+const (
+	// updatesStateFile records the update in progress and the history of the
+	// updates, relative to the data dir
+	updatesStateFile = "updates.json"
+
+	// maxUpdateHistory is the number of updates kept in the history
+	maxUpdateHistory = 10
+)
+
+// phasePercentages are the estimated percentages of an update done when its
+// phases start. The download of the OS image takes up most of an update with
+// an OS image, and the percentage moves with its bytes downloaded.
+var phasePercentages = map[v1alpha1.DeviceUpdatePhase]int32{
+	v1alpha1.DeviceUpdatePhaseRunningHooks:         0,
+	v1alpha1.DeviceUpdatePhaseWritingConfig:        5,
+	v1alpha1.DeviceUpdatePhaseUpdatingApplications: 10,
+	v1alpha1.DeviceUpdatePhaseDownloadingImages:    20,
+	v1alpha1.DeviceUpdatePhaseAwaitingReboot:       95,
+}
+
+// UpdateProgress reports the progress of the update of the device to a
+// rendered version in status.updateProgress: the phase the update is in, the
+// estimated percentage of the update done and, while the OS image downloads,
+// its layers and bytes downloaded so far. It reports nothing outside of an
+// update, so that the syncs of an up-to-date spec do not report progress.
+//
+// It also records how long the updates took and the bytes of the OS images
+// they downloaded in status.updateHistory, from which the rollouts of the
+// fleets estimate the bandwidth the updates of the device use. The update in
+// progress is kept in the data dir, so that an update continuing after the
+// reboot into its OS image keeps the time it started at.
+//
+// A nil UpdateProgress reports nothing.
+type UpdateProgress struct {
+	dataDir       string
+	readWriter    fileio.ReadWriter
+	statusManager status.Manager
+	progress      *v1alpha1.DeviceUpdateProgress
+	lastReport    time.Time
+	// state is the update in progress and the history, read from the data
+	// dir on first use
+	state *updateState
+	// reported is the history last reported in the device status
+	reported *v1alpha1.DeviceUpdateHistory
+	log      *log.PrefixLogger
+}
+
+// updateState is the update in progress, whose completion time is not set,
+// and the last updates of the device, the newest last.
+type updateState struct {
+	Current *v1alpha1.DeviceUpdateRecord  `json:"current,omitempty"`
+	History []v1alpha1.DeviceUpdateRecord `json:"history"`
+}
+
+func NewUpdateProgress(dataDir string, readWriter fileio.ReadWriter, statusManager status.Manager, log *log.PrefixLogger) *UpdateProgress {
+	return &UpdateProgress{
+		dataDir:       dataDir,
+		readWriter:    readWriter,
+		statusManager: statusManager,
+		log:           log,
+	}
+}
+
+// Start starts reporting the progress of the update to the rendered version,
+// unless it is reported already, and records the time the update started at
+// unless it continues after a restart.
+func (p *UpdateProgress) Start(renderedVersion string) {
+	if p == nil || (p.progress != nil && p.progress.RenderedVersion == renderedVersion) {
+		return
+	}
+	p.progress = &v1alpha1.DeviceUpdateProgress{RenderedVersion: renderedVersion, Phase: v1alpha1.DeviceUpdatePhaseRunningHooks}
+	state := p.loadState()
+	if state.Current == nil || state.Current.RenderedVersion != renderedVersion {
+		state.Current = &v1alpha1.DeviceUpdateRecord{RenderedVersion: renderedVersion, StartedAt: time.Now().UTC()}
+		p.writeState()
+	}
+}
+
+// Stop stops reporting the progress once the device runs the rendered
+// version, and adds the update to the history which it returns. The progress
+// is unset and the history set by the status update of the new version.
+func (p *UpdateProgress) Stop() *v1alpha1.DeviceUpdateHistory {
+	if p == nil {
+		return nil
+	}
+	p.progress = nil
+	state := p.loadState()
+	if state.Current == nil {
+		return nil
+	}
+	state.Current.CompletedAt = time.Now().UTC()
+	state.History = append(state.History, *state.Current)
+	state.History = state.History[max(len(state.History)-maxUpdateHistory, 0):]
+	state.Current = nil
+	p.writeState()
+	p.reported = updateHistory(state.History)
+	return p.reported
+}
+
+// ReportHistory sets the history of the updates in the device status unless
+// it was reported already, such as after a restart of the agent.
+func (p *UpdateProgress) ReportHistory(ctx context.Context) {
+	if p == nil {
+		return
+	}
+	history := updateHistory(p.loadState().History)
+	if history == nil || reflect.DeepEqual(p.reported, history) {
+		return
+	}
+	if _, err := p.statusManager.Update(ctx, status.SetUpdateHistory(*history)); err != nil {
+		p.log.Warnf("Failed setting update history: %v", err)
+		return
+	}
+	p.reported = history
+}
+
+// Phase reports the phase the update enters.
+func (p *UpdateProgress) Phase(ctx context.Context, phase v1alpha1.DeviceUpdatePhase, message string) {
+	if p == nil || p.progress == nil {
+		return
+	}
+	p.progress.Phase = phase
+	p.progress.Percentage = max(p.progress.Percentage, phasePercentages[phase])
+	p.progress.Message = lo.EmptyableToPtr(message)
+	p.progress.Image, p.progress.DownloadedLayers, p.progress.TotalLayers, p.progress.DownloadedBytes, p.progress.TotalBytes = nil, nil, nil, nil, nil
+	p.report(ctx)
+}
+
+// Download reports the progress of the download of the OS image, at most once
+// per progressReportInterval.
+func (p *UpdateProgress) Download(ctx context.Context, image string, download container.BootcProgress) {
+	if p == nil || p.progress == nil {
+		return
+	}
+	p.progress.Phase = v1alpha1.DeviceUpdatePhaseDownloadingImages
+	p.progress.Image = &image
+	p.progress.Message = lo.ToPtr(fmt.Sprintf("Downloading os image %s", image))
+	p.progress.DownloadedLayers, p.progress.TotalLayers = &download.Steps, &download.StepsTotal
+	p.progress.DownloadedBytes, p.progress.TotalBytes = &download.Bytes, &download.BytesTotal
+	if state := p.loadState(); state.Current != nil {
+		state.Current.DownloadedBytes = max(state.Current.DownloadedBytes, download.Bytes)
+	}
+	if download.BytesTotal > 0 {
+		start := phasePercentages[v1alpha1.DeviceUpdatePhaseDownloadingImages]
+		end := phasePercentages[v1alpha1.DeviceUpdatePhaseAwaitingReboot]
+		done := start + int32(int64(end-start)*min(download.Bytes, download.BytesTotal)/download.BytesTotal)
+		p.progress.Percentage = max(p.progress.Percentage, done)
+	}
+	if time.Since(p.lastReport) < progressReportInterval {
+		return
+	}
+	p.report(ctx)
+}
+
+// Hooks returns the hook manager of the config sync, which reports the hooks
+// it runs and the files it writes as phases of the update.
+func (p *UpdateProgress) Hooks(manager hook.Manager) hook.Manager {
+	return &progressHooks{Manager: manager, progress: p}
+}
+
+// report sets the progress in the device status and records the bytes
+// downloaded so far. A failure is logged, and the progress reported with the
+// next phase.
+func (p *UpdateProgress) report(ctx context.Context) {
+	if p.loadState().Current != nil {
+		p.writeState()
+	}
+	p.progress.UpdatedAt = time.Now().UTC()
+	p.lastReport = p.progress.UpdatedAt
+	progress := *p.progress
+	if _, err := p.statusManager.Update(ctx, status.SetUpdateProgress(&progress)); err != nil {
+		p.log.Warnf("Failed setting update progress: %v", err)
+	}
+}
+
+// updateHistory returns the history of the updates with their averages, or
+// nil if the device completed no update yet.
+func updateHistory(records []v1alpha1.DeviceUpdateRecord) *v1alpha1.DeviceUpdateHistory {
+	if len(records) == 0 {
+		return nil
+	}
+	history := &v1alpha1.DeviceUpdateHistory{Updates: append([]v1alpha1.DeviceUpdateRecord{}, records...)}
+	for _, record := range records {
+		history.AverageDurationSeconds += int64(record.CompletedAt.Sub(record.StartedAt).Seconds())
+		history.AverageDownloadedBytes += record.DownloadedBytes
+	}
+	history.AverageDurationSeconds /= int64(len(records))
+	history.AverageDownloadedBytes /= int64(len(records))
+	return history
+}
+
+func (p *UpdateProgress) statePath() string {
+	return filepath.Join(p.dataDir, updatesStateFile)
+}
+
+// loadState returns the update in progress and the history, read from the
+// data dir on first use. A state which cannot be read is logged and starts
+// over, since it only serves the estimates of the rollouts.
+func (p *UpdateProgress) loadState() *updateState {
+	if p.state != nil {
+		return p.state
+	}
+	p.state = &updateState{}
+	exists, err := p.readWriter.FileExists(p.statePath())
+	if err != nil || !exists {
+		return p.state
+	}
+	content, err := p.readWriter.ReadFile(p.statePath())
+	if err == nil {
+		err = json.Unmarshal(content, p.state)
+	}
+	if err != nil {
+		p.log.Warnf("Failed reading the history of the updates: %v", err)
+		p.state = &updateState{}
+	}
+	return p.state
+}
+
+// writeState records the update in progress and the history in the data dir.
+// A failure is logged, and the state written again with the next change.
+func (p *UpdateProgress) writeState() {
+	content, err := json.Marshal(p.state)
+	if err == nil {
+		err = p.readWriter.WriteFile(p.statePath(), content, 0600)
+	}
+	if err != nil {
+		p.log.Warnf("Failed writing the history of the updates: %v", err)
+	}
+}
+
+// progressHooks reports the before updating hooks of an update and the write
+// of the files of the config as its phases. The after updating hooks run in
+// the background, alongside the rest of the update.
+type progressHooks struct {
+	hook.Manager
+	progress *UpdateProgress
+}
+
+func (m *progressHooks) OnBeforeUpdating(ctx context.Context, changes []hook.FileChange) {
+	m.progress.Phase(ctx, v1alpha1.DeviceUpdatePhaseRunningHooks, "Running the before updating hooks")
+	m.Manager.OnBeforeUpdating(ctx, changes)
+	m.progress.Phase(ctx, v1alpha1.DeviceUpdatePhaseWritingConfig, fmt.Sprintf("Writing %d files of the config", len(changes)))
+}
@@ -0,0 +1,113 @@
+package device
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/status"
+	"github.com/flightctl/flightctl/internal/container"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpdateProgress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	statusManager := status.NewMockManager(ctrl)
+	hookManager := hook.NewMockManager(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	ctx := context.Background()
+
+	var reported []v1alpha1.DeviceUpdateProgress
+	var history *v1alpha1.DeviceUpdateHistory
+	statusManager.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fns ...status.UpdateStatusFn) (*v1alpha1.DeviceStatus, error) {
+		var deviceStatus v1alpha1.DeviceStatus
+		for _, fn := range fns {
+			require.NoError(fn(&deviceStatus))
+		}
+		if deviceStatus.UpdateProgress != nil {
+			reported = append(reported, *deviceStatus.UpdateProgress)
+		}
+		if deviceStatus.UpdateHistory != nil {
+			history = deviceStatus.UpdateHistory
+		}
+		return &deviceStatus, nil
+	}).AnyTimes()
+
+	// nothing is reported outside of an update, nor by a nil progress
+	p := NewUpdateProgress("/var/lib/flightctl", readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	p.Phase(ctx, v1alpha1.DeviceUpdatePhaseUpdatingApplications, "")
+	var unset *UpdateProgress
+	unset.Start("2")
+	unset.Phase(ctx, v1alpha1.DeviceUpdatePhaseWritingConfig, "")
+	require.Empty(reported)
+
+	// the hooks of the config sync are reported as phases
+	p.Start("2")
+	hookManager.EXPECT().OnBeforeUpdating(gomock.Any(), gomock.Any())
+	p.Hooks(hookManager).OnBeforeUpdating(ctx, []hook.FileChange{{Path: "/etc/app/settings.conf"}})
+	require.Len(reported, 2)
+	require.Equal(v1alpha1.DeviceUpdatePhaseRunningHooks, reported[0].Phase)
+	require.Equal(v1alpha1.DeviceUpdatePhaseWritingConfig, reported[1].Phase)
+	require.Equal(int32(5), reported[1].Percentage)
+	require.Equal("2", reported[1].RenderedVersion)
+
+	// the download moves the percentage with its bytes, and is reported at
+	// most once per interval
+	p.Phase(ctx, v1alpha1.DeviceUpdatePhaseDownloadingImages, "Downloading os image quay.io/acme/os:2")
+	require.Equal(int32(20), reported[2].Percentage)
+	p.lastReport = p.lastReport.Add(-progressReportInterval)
+	p.Download(ctx, "quay.io/acme/os:2", container.BootcProgress{Bytes: 50, BytesTotal: 100, Steps: 2, StepsTotal: 4})
+	p.Download(ctx, "quay.io/acme/os:2", container.BootcProgress{Bytes: 75, BytesTotal: 100, Steps: 3, StepsTotal: 4})
+	require.Len(reported, 4)
+	require.Equal(int32(57), reported[3].Percentage)
+	require.Equal(lo.ToPtr(int64(50)), reported[3].DownloadedBytes)
+	require.Equal(lo.ToPtr(int32(4)), reported[3].TotalLayers)
+
+	// the percentage never goes back
+	p.Phase(ctx, v1alpha1.DeviceUpdatePhaseAwaitingReboot, "")
+	p.Phase(ctx, v1alpha1.DeviceUpdatePhaseRunningHooks, "")
+	require.Equal(int32(95), reported[5].Percentage)
+	require.Nil(reported[5].DownloadedBytes)
+
+	// an update continuing after a reboot keeps the time it started at
+	started := p.loadState().Current.StartedAt
+	p = NewUpdateProgress("/var/lib/flightctl", readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	p.Start("2")
+	require.Equal(started, p.loadState().Current.StartedAt)
+	require.Equal(int64(75), p.loadState().Current.DownloadedBytes)
+
+	// the completed update is added to the history with its averages
+	completed := p.Stop()
+	require.NotNil(completed)
+	require.Len(completed.Updates, 1)
+	require.Equal("2", completed.Updates[0].RenderedVersion)
+	require.Equal(int64(75), completed.AverageDownloadedBytes)
+	p.Phase(ctx, v1alpha1.DeviceUpdatePhaseWritingConfig, "")
+	require.Len(reported, 6)
+
+	// the history is reported again after a restart of the agent
+	p = NewUpdateProgress("/var/lib/flightctl", readWriter, statusManager, flightlog.NewPrefixLogger(""))
+	p.ReportHistory(ctx)
+	require.Equal(completed, history)
+	require.Nil(p.Stop())
+}
+
+func TestUpdateHistory(t *testing.T) {
+	require := require.New(t)
+	started := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	require.Nil(updateHistory(nil))
+	history := updateHistory([]v1alpha1.DeviceUpdateRecord{
+		{RenderedVersion: "2", StartedAt: started, CompletedAt: started.Add(10 * time.Minute), DownloadedBytes: 600},
+		{RenderedVersion: "3", StartedAt: started, CompletedAt: started.Add(20 * time.Minute), DownloadedBytes: 0},
+	})
+	require.Equal(int64(900), history.AverageDurationSeconds)
+	require.Equal(int64(300), history.AverageDownloadedBytes)
+}
@@ -0,0 +1,164 @@
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	// quarantineStateFile records the applications stopped by a quarantine, relative to the data dir
+	quarantineStateFile = "quarantine.json"
+
+	quarantineCommandTimeout = 2 * time.Minute
+)
+
+// quarantineState is kept in the data dir so that the applications stopped
+// by a quarantine are started again once it is released, even if the agent
+// restarted in between.
+type quarantineState struct {
+	StoppedUnits []string `json:"stoppedUnits"`
+}
+
+// QuarantineController stops the applications of a quarantined device whose
+// quarantine asks to, which are the systemd units matched by the patterns of
+// the current spec, and starts them again once the device is released.
+type QuarantineController struct {
+	dataDir    string
+	exec       executer.Executer
+	readWriter fileio.ReadWriter
+	systemd    *client.Systemd
+	log        *log.PrefixLogger
+}
+
+func NewQuarantineController(
+	dataDir string,
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	log *log.PrefixLogger,
+) *QuarantineController {
+	return &QuarantineController{
+		dataDir:    dataDir,
+		exec:       exec,
+		readWriter: readWriter,
+		systemd:    client.NewSystemd(exec),
+		log:        log,
+	}
+}
+
+// Sync stops or starts the applications of the device according to the
+// quarantine of the desired spec.
+func (c *QuarantineController) Sync(ctx context.Context, current, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing quarantine")
+	defer c.log.Debug("Finished syncing quarantine")
+
+	state, err := c.readState()
+	if err != nil {
+		return err
+	}
+	stopApplications := desired.Quarantine != nil && lo.FromPtr(desired.Quarantine.StopApplications)
+
+	ctx, cancel := context.WithTimeout(ctx, quarantineCommandTimeout)
+	defer cancel()
+	switch {
+	case stopApplications && state == nil:
+		return c.stopApplications(ctx, current)
+	case !stopApplications && state != nil:
+		return c.startApplications(ctx, state)
+	default:
+		return nil
+	}
+}
+
+func (c *QuarantineController) stopApplications(ctx context.Context, current *v1alpha1.RenderedDeviceSpec) error {
+	units, err := c.activeUnits(ctx, current)
+	if err != nil {
+		return err
+	}
+	// the state is written first so that units stopped before a failure are
+	// started again on release
+	state := &quarantineState{StoppedUnits: units}
+	if err := c.writeState(state); err != nil {
+		return err
+	}
+	for _, unit := range units {
+		c.log.Warnf("Stopping %s while the device is quarantined", unit)
+		if err := c.systemd.Stop(ctx, unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *QuarantineController) startApplications(ctx context.Context, state *quarantineState) error {
+	for _, unit := range state.StoppedUnits {
+		c.log.Infof("Starting %s after the device was released from quarantine", unit)
+		if err := c.systemd.Start(ctx, unit); err != nil {
+			return err
+		}
+	}
+	return c.readWriter.RemoveFile(c.statePath())
+}
+
+// activeUnits returns the active systemd units matched by the patterns of the
+// spec, which are the applications the agent reports.
+func (c *QuarantineController) activeUnits(ctx context.Context, spec *v1alpha1.RenderedDeviceSpec) ([]string, error) {
+	units := []string{}
+	if spec.Systemd == nil || len(lo.FromPtr(spec.Systemd.MatchPatterns)) == 0 {
+		return units, nil
+	}
+	args := append([]string{"list-units", "--state=active", "--output", "json"}, *spec.Systemd.MatchPatterns...)
+	out, errOut, exitCode := c.exec.ExecuteWithContext(ctx, systemctlCommand, args...)
+	if exitCode != 0 {
+		return nil, fmt.Errorf("failed listing systemd units with code %d: %s", exitCode, errOut)
+	}
+	var list []struct {
+		Unit string `json:"unit"`
+	}
+	if err := json.Unmarshal([]byte(out), &list); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling systemctl list-units output: %w", err)
+	}
+	for _, entry := range list {
+		units = append(units, entry.Unit)
+	}
+	return units, nil
+}
+
+func (c *QuarantineController) statePath() string {
+	return filepath.Join(c.dataDir, quarantineStateFile)
+}
+
+// readState returns the applications stopped by the quarantine, or nil if
+// the quarantine did not stop them.
+func (c *QuarantineController) readState() (*quarantineState, error) {
+	exists, err := c.readWriter.FileExists(c.statePath())
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := c.readWriter.ReadFile(c.statePath())
+	if err != nil {
+		return nil, err
+	}
+	var state quarantineState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("reading quarantine state: %w", err)
+	}
+	return &state, nil
+}
+
+func (c *QuarantineController) writeState(state *quarantineState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return c.readWriter.WriteFile(c.statePath(), content, 0600)
+}
@@ -0,0 +1,52 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuarantineStopsAndStartsApplications(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	c := NewQuarantineController("/var/lib/flightctl", execMock, readWriter, flightlog.NewPrefixLogger(""))
+	ctx := context.Background()
+
+	current := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}
+	current.Systemd = &struct {
+		MatchPatterns *[]string `json:"matchPatterns,omitempty"`
+	}{MatchPatterns: &[]string{"app-*.service"}}
+	quarantined := func(stopApplications bool) *v1alpha1.RenderedDeviceSpec {
+		return &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Quarantine: &v1alpha1.DeviceQuarantine{Reason: "incident 42", StopApplications: lo.ToPtr(stopApplications)}}
+	}
+
+	// a quarantine which keeps the applications running does nothing
+	require.NoError(c.Sync(ctx, current, quarantined(false)))
+
+	// the active applications are stopped once
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "list-units", "--state=active", "--output", "json", "app-*.service").
+		Return(`[{"unit":"app-a.service"},{"unit":"app-b.service"}]`, "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "stop", "app-a.service").Return("", "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "stop", "app-b.service").Return("", "", 0)
+	require.NoError(c.Sync(ctx, current, quarantined(true)))
+	require.NoError(c.Sync(ctx, current, quarantined(true)))
+
+	// the stopped applications are started again on release
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "start", "app-a.service").Return("", "", 0)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), systemctlCommand, "start", "app-b.service").Return("", "", 0)
+	require.NoError(c.Sync(ctx, current, current))
+	exists, err := readWriter.FileExists("/var/lib/flightctl/" + quarantineStateFile)
+	require.NoError(err)
+	require.False(exists)
+	require.NoError(c.Sync(ctx, current, current))
+}
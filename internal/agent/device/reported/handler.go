@@ -0,0 +1,105 @@
+package reported
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const maxRequestBodySize = 256 * 1024
+
+// NewHandler returns the HTTP handler exposing the reported properties API:
+//
+//	GET    /applications/{name}/properties
+//	PUT    /applications/{name}/properties
+//	DELETE /applications/{name}/properties
+//	GET    /annotations
+//	PUT    /annotations/{key}
+//	DELETE /annotations/{key}
+//
+// The key of an annotation may contain a slash, as in "example.com/name".
+func NewHandler(m Manager) http.Handler {
+	h := &handler{manager: m}
+	r := chi.NewRouter()
+	r.Get("/applications/{name}/properties", h.get)
+	r.Put("/applications/{name}/properties", h.put)
+	r.Delete("/applications/{name}/properties", h.delete)
+	r.Get("/annotations", h.getAnnotations)
+	r.Put("/annotations/*", h.putAnnotation)
+	r.Delete("/annotations/*", h.deleteAnnotation)
+	return r
+}
+
+type handler struct {
+	manager Manager
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	props, err := h.manager.Get(chi.URLParam(r, "name"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(props)
+}
+
+func (h *handler) put(w http.ResponseWriter, r *http.Request) {
+	var props map[string]string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&props); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.manager.Set(chi.URLParam(r, "name"), props); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) delete(w http.ResponseWriter, r *http.Request) {
+	h.manager.Delete(chi.URLParam(r, "name"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) getAnnotations(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(h.manager.Annotations())
+}
+
+func (h *handler) putAnnotation(w http.ResponseWriter, r *http.Request) {
+	var annotation struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(&annotation); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := h.manager.SetAnnotation(chi.URLParam(r, "*"), annotation.Value); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) deleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	if err := h.manager.DeleteAnnotation(chi.URLParam(r, "*")); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": err.Error()})
+}
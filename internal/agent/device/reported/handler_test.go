@@ -0,0 +1,103 @@
+package reported
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testDataDir = "/var/lib/flightctl"
+
+func TestHandler(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	m := NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test"))
+	h := NewHandler(m)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, "/applications/app1/properties", "")
+	require.Equal(http.StatusNotFound, rec.Code)
+
+	rec = do(http.MethodPut, "/applications/app1/properties", `{"modelVersion":"v2","sensorCount":"4"}`)
+	require.Equal(http.StatusNoContent, rec.Code)
+
+	rec = do(http.MethodGet, "/applications/app1/properties", "")
+	require.Equal(http.StatusOK, rec.Code)
+	var props map[string]string
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &props))
+	require.Equal(map[string]string{"modelVersion": "v2", "sensorCount": "4"}, props)
+	require.Equal(map[string]map[string]string{"app1": props}, m.List())
+
+	// the properties outlive the agent
+	require.Equal(m.List(), NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test")).List())
+
+	rec = do(http.MethodPut, "/applications/app1/properties", `{"bad key":"v"}`)
+	require.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodPut, "/applications/app1/properties", `not json`)
+	require.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodDelete, "/applications/app1/properties", "")
+	require.Equal(http.StatusNoContent, rec.Code)
+	require.Empty(m.List())
+	require.Empty(NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test")).List())
+}
+
+func TestValidateProperties(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(validateProperties("podman-app.service", map[string]string{"model.version": "1"}))
+	require.Error(validateProperties("", map[string]string{}))
+	require.Error(validateProperties("app", map[string]string{"key": strings.Repeat("a", MaxPropertyValueLength+1)}))
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxPropertiesPerApplication; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	require.ErrorIs(validateProperties("app", tooMany), ErrTooManyProperties)
+}
+
+func TestHandlerAnnotations(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	m := NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test"))
+	h := NewHandler(m)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPut, "/annotations/example.com/disk", `{"value":"replacement recommended"}`)
+	require.Equal(http.StatusNoContent, rec.Code)
+	rec = do(http.MethodPut, "/annotations/bad%20key", `{"value":"v"}`)
+	require.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = do(http.MethodGet, "/annotations", "")
+	require.Equal(http.StatusOK, rec.Code)
+	var annotations map[string]string
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &annotations))
+	require.Equal(map[string]string{"example.com/disk": "replacement recommended"}, annotations)
+
+	// the annotations outlive the agent
+	require.Equal(annotations, NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test")).Annotations())
+
+	rec = do(http.MethodDelete, "/annotations/example.com/disk", "")
+	require.Equal(http.StatusNoContent, rec.Code)
+	require.Empty(m.Annotations())
+	require.Empty(NewManager(DefaultSocketPath, testDataDir, readWriter, log.NewPrefixLogger("test")).Annotations())
+}
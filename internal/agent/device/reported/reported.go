@@ -0,0 +1,293 @@
+package reported
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// DefaultSocketPath is the default path of the unix socket applications use to report properties.
+	DefaultSocketPath = "/var/run/flightctl/agent.sock"
+	// MaxPropertiesPerApplication is the maximum number of properties a single application can report.
+	MaxPropertiesPerApplication = 64
+	// MaxPropertyValueLength is the maximum length of a reported property value.
+	MaxPropertyValueLength = 1024
+	// MaxAnnotations is the maximum number of annotations of the device.
+	MaxAnnotations = 64
+	// AnnotationsFile keeps the annotations of the device, relative to the data dir.
+	AnnotationsFile = "annotations.json"
+	// PropertiesFile keeps the properties reported by applications, relative to the data dir.
+	PropertiesFile = "reported-properties.json"
+
+	shutdownTimeout = 5 * time.Second
+	maxNameLength   = 253
+	nameFmt         = `[a-zA-Z0-9]([-a-zA-Z0-9_.@:]*[a-zA-Z0-9])?`
+)
+
+var nameRegexp = regexp.MustCompile("^" + nameFmt + "$")
+
+var (
+	ErrTooManyProperties  = errors.New("too many properties")
+	ErrTooManyAnnotations = errors.New("too many annotations")
+	ErrNotFound           = errors.New("application has no reported properties")
+)
+
+var _ Manager = (*manager)(nil)
+
+// Manager holds the key/value properties reported by applications running on
+// the device and the annotations of the device, and serves them over a local
+// unix socket. Annotations are messages to the operator, which the agent and
+// local tools write for the device as a whole. Both are kept in files in the
+// data dir of the agent, so that they outlive restarts of the agent.
+type Manager interface {
+	// Run serves the reported properties API until the context is canceled.
+	Run(ctx context.Context)
+	// Set replaces the properties reported by the given application.
+	Set(application string, properties map[string]string) error
+	// Get returns the properties reported by the given application.
+	Get(application string) (map[string]string, error)
+	// Delete removes all properties reported by the given application.
+	Delete(application string)
+	// List returns a copy of the properties reported by all applications.
+	List() map[string]map[string]string
+	// SetAnnotation sets an annotation of the device.
+	SetAnnotation(key string, value string) error
+	// DeleteAnnotation removes an annotation of the device.
+	DeleteAnnotation(key string) error
+	// Annotations returns a copy of the annotations of the device.
+	Annotations() map[string]string
+}
+
+type manager struct {
+	socketPath string
+	dataDir    string
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+
+	mu          sync.RWMutex
+	properties  map[string]map[string]string
+	annotations map[string]string
+}
+
+// NewManager creates a new reported properties manager which serves on the
+// given unix socket path, and loads the properties and annotations the agent
+// kept in the data dir, unless it is empty.
+func NewManager(socketPath string, dataDir string, readWriter fileio.ReadWriter, log *log.PrefixLogger) Manager {
+	m := &manager{
+		socketPath:  socketPath,
+		dataDir:     dataDir,
+		readWriter:  readWriter,
+		log:         log,
+		properties:  make(map[string]map[string]string),
+		annotations: make(map[string]string),
+	}
+	if err := m.readFile(PropertiesFile, &m.properties); err != nil {
+		log.Warnf("Failed to read the reported properties: %v", err)
+	}
+	if err := m.readFile(AnnotationsFile, &m.annotations); err != nil {
+		log.Warnf("Failed to read the annotations of the device: %v", err)
+	}
+	return m
+}
+
+func (m *manager) Run(ctx context.Context) {
+	if err := m.serve(ctx); err != nil {
+		m.log.Errorf("Reported properties server stopped: %v", err)
+	}
+}
+
+func (m *manager) serve(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(m.socketPath), 0755); err != nil {
+		return fmt.Errorf("creating socket directory: %w", err)
+	}
+	// remove a stale socket left behind by a previous run
+	if err := os.Remove(m.socketPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", m.socketPath)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", m.socketPath, err)
+	}
+	defer os.Remove(m.socketPath)
+
+	server := &http.Server{
+		Handler:           NewHandler(m),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			m.log.Warnf("Failed to shutdown reported properties server: %v", err)
+		}
+	}()
+
+	m.log.Infof("Serving reported properties on %s", m.socketPath)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (m *manager) Set(application string, properties map[string]string) error {
+	if err := validateProperties(application, properties); err != nil {
+		return err
+	}
+
+	props := make(map[string]string, len(properties))
+	for k, v := range properties {
+		props[k] = v
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.properties[application] = props
+	return m.writeFile(PropertiesFile, m.properties)
+}
+
+func (m *manager) Get(application string) (map[string]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	props, ok := m.properties[application]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	out := make(map[string]string, len(props))
+	for k, v := range props {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (m *manager) Delete(application string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.properties[application]; !ok {
+		return
+	}
+	delete(m.properties, application)
+	if err := m.writeFile(PropertiesFile, m.properties); err != nil {
+		m.log.Warnf("Failed to write the reported properties: %v", err)
+	}
+}
+
+func (m *manager) List() map[string]map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make(map[string]map[string]string, len(m.properties))
+	for app, props := range m.properties {
+		copied := make(map[string]string, len(props))
+		for k, v := range props {
+			copied[k] = v
+		}
+		out[app] = copied
+	}
+	return out
+}
+
+func (m *manager) SetAnnotation(key string, value string) error {
+	if errs := validation.ValidateLabelKey(&key, "annotation"); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(value) > MaxPropertyValueLength {
+		return fmt.Errorf("value of annotation %q exceeds the maximum length of %d", key, MaxPropertyValueLength)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.annotations[key]; ok && current == value {
+		return nil
+	} else if !ok && len(m.annotations) >= MaxAnnotations {
+		return fmt.Errorf("%w: the device has the maximum of %d", ErrTooManyAnnotations, MaxAnnotations)
+	}
+	m.annotations[key] = value
+	return m.writeFile(AnnotationsFile, m.annotations)
+}
+
+func (m *manager) DeleteAnnotation(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.annotations[key]; !ok {
+		return nil
+	}
+	delete(m.annotations, key)
+	return m.writeFile(AnnotationsFile, m.annotations)
+}
+
+func (m *manager) Annotations() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make(map[string]string, len(m.annotations))
+	for k, v := range m.annotations {
+		out[k] = v
+	}
+	return out
+}
+
+// readFile reads the state kept in the named file of the data dir into v.
+func (m *manager) readFile(name string, v any) error {
+	if m.dataDir == "" {
+		return nil
+	}
+	path := filepath.Join(m.dataDir, name)
+	exists, err := m.readWriter.FileExists(path)
+	if err != nil || !exists {
+		return err
+	}
+	content, err := m.readWriter.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+// writeFile writes v to the named file of the data dir, with the lock held.
+func (m *manager) writeFile(name string, v any) error {
+	if m.dataDir == "" {
+		return nil
+	}
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := m.readWriter.WriteFile(filepath.Join(m.dataDir, name), content, 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+func validateProperties(application string, properties map[string]string) error {
+	if errs := validation.ValidateString(&application, "application", 1, maxNameLength, nameRegexp, nameFmt); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if len(properties) > MaxPropertiesPerApplication {
+		return fmt.Errorf("%w: %d exceeds the maximum of %d", ErrTooManyProperties, len(properties), MaxPropertiesPerApplication)
+	}
+	for k, v := range properties {
+		k := k
+		if errs := validation.ValidateString(&k, "property", 1, maxNameLength, nameRegexp, nameFmt); len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+		if len(v) > MaxPropertyValueLength {
+			return fmt.Errorf("value of property %q exceeds the maximum length of %d", k, MaxPropertyValueLength)
+		}
+	}
+	return nil
+}
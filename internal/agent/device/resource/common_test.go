@@ -6,6 +6,7 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -303,3 +304,22 @@ func newMockCPUResourceMonitor(require *require.Assertions, samplingInterval tim
 	require.NoError(err)
 	return rm
 }
+
+func TestDefaultResourceMonitorThresholds(t *testing.T) {
+	require := require.New(t)
+
+	monitor, err := defaultCPUResourceMonitor(nil)
+	require.NoError(err)
+	spec, err := monitor.AsCPUResourceMonitorSpec()
+	require.NoError(err)
+	require.Equal(float32(DefaultCriticalPercentage), spec.AlertRules[0].Percentage)
+	require.Equal(float32(DefaultWarningPercentage), spec.AlertRules[1].Percentage)
+
+	// unset thresholds keep their built-in value
+	monitor, err = defaultDiskResourceMonitor(&v1alpha1.ResourceThresholds{WarningPercentage: lo.ToPtr(70.0)})
+	require.NoError(err)
+	diskSpec, err := monitor.AsDiskResourceMonitorSpec()
+	require.NoError(err)
+	require.Equal(float32(DefaultCriticalPercentage), diskSpec.AlertRules[0].Percentage)
+	require.Equal(float32(70), diskSpec.AlertRules[1].Percentage)
+}
@@ -80,6 +80,18 @@ func (mr *MockManagerMockRecorder) Run(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockManager)(nil).Run), ctx)
 }
 
+// SetDefaultThresholds mocks base method.
+func (m *MockManager) SetDefaultThresholds(thresholds *v1alpha1.ResourceMonitorThresholds) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDefaultThresholds", thresholds)
+}
+
+// SetDefaultThresholds indicates an expected call of SetDefaultThresholds.
+func (mr *MockManagerMockRecorder) SetDefaultThresholds(thresholds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultThresholds", reflect.TypeOf((*MockManager)(nil).SetDefaultThresholds), thresholds)
+}
+
 // Update mocks base method.
 func (m *MockManager) Update(monitor *v1alpha1.ResourceMonitor) (bool, error) {
 	m.ctrl.T.Helper()
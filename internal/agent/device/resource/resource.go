@@ -9,6 +9,7 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 )
 
 type MonitorType string
@@ -19,6 +20,11 @@ const (
 	MemoryMonitorType = "Memory"
 
 	DefaultSamplingInterval = 1 * time.Minute
+
+	// DefaultWarningPercentage and DefaultCriticalPercentage are the usage
+	// percentages that trigger the alerts of the default monitors.
+	DefaultWarningPercentage  = 80
+	DefaultCriticalPercentage = 90
 )
 
 type Manager interface {
@@ -26,6 +32,10 @@ type Manager interface {
 	Update(monitor *v1alpha1.ResourceMonitor) (bool, error)
 	// ResetAlertDefaults clears all alerts and resets the monitors to their default state.
 	ResetAlertDefaults() error
+	// SetDefaultThresholds replaces the alert thresholds of the default
+	// monitors, which take effect when the alerts are next reset. Unset
+	// thresholds keep their built-in value.
+	SetDefaultThresholds(thresholds *v1alpha1.ResourceMonitorThresholds)
 	Alerts() *Alerts
 }
 
@@ -41,6 +51,9 @@ type ResourceManager struct {
 	diskMonitor   Monitor[DiskUsage]
 	memoryMonitor Monitor[MemoryUsage]
 	log           *log.PrefixLogger
+
+	mu         sync.Mutex
+	thresholds v1alpha1.ResourceMonitorThresholds
 }
 
 // NewManager creates a new resource Manager.
@@ -84,11 +97,20 @@ func (m *ResourceManager) Update(monitor *v1alpha1.ResourceMonitor) (bool, error
 	}
 }
 
+func (m *ResourceManager) SetDefaultThresholds(thresholds *v1alpha1.ResourceMonitorThresholds) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.thresholds = lo.FromPtr(thresholds)
+}
+
 func (m *ResourceManager) ResetAlertDefaults() error {
 	var errs []error
+	m.mu.Lock()
+	thresholds := m.thresholds
+	m.mu.Unlock()
 
 	// cpu
-	cpuMonitor, err := defaultCPUResourceMonitor()
+	cpuMonitor, err := defaultCPUResourceMonitor(thresholds.Cpu)
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -101,7 +123,7 @@ func (m *ResourceManager) ResetAlertDefaults() error {
 	}
 
 	// disk
-	diskMonitor, err := defaultDiskResourceMonitor()
+	diskMonitor, err := defaultDiskResourceMonitor(thresholds.Disk)
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -114,7 +136,7 @@ func (m *ResourceManager) ResetAlertDefaults() error {
 	}
 
 	// memory
-	memoryMonitor, err := defaultMemoryResourceMonitor()
+	memoryMonitor, err := defaultMemoryResourceMonitor(thresholds.Memory)
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -263,20 +285,38 @@ func GetHighestSeverityResourceStatusFromAlerts(resource string, alerts []v1alph
 	return highestSeverity, info
 }
 
-func defaultCPUResourceMonitor() (*v1alpha1.ResourceMonitor, error) {
+// criticalPercentage returns the usage percentage of the critical alert of a
+// default monitor.
+func criticalPercentage(thresholds *v1alpha1.ResourceThresholds) float32 {
+	if thresholds != nil && thresholds.CriticalPercentage != nil {
+		return float32(*thresholds.CriticalPercentage)
+	}
+	return DefaultCriticalPercentage
+}
+
+// warningPercentage returns the usage percentage of the warning alert of a
+// default monitor.
+func warningPercentage(thresholds *v1alpha1.ResourceThresholds) float32 {
+	if thresholds != nil && thresholds.WarningPercentage != nil {
+		return float32(*thresholds.WarningPercentage)
+	}
+	return DefaultWarningPercentage
+}
+
+func defaultCPUResourceMonitor(thresholds *v1alpha1.ResourceThresholds) (*v1alpha1.ResourceMonitor, error) {
 	spec := v1alpha1.CPUResourceMonitorSpec{
 		SamplingInterval: DefaultSamplingInterval.String(),
 		MonitorType:      CPUMonitorType,
 		AlertRules: []v1alpha1.ResourceAlertRule{
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeCritical,
-				Percentage:  90,
+				Percentage:  criticalPercentage(thresholds),
 				Duration:    "30m",
 				Description: "", // use generated description
 			},
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeWarning,
-				Percentage:  80,
+				Percentage:  warningPercentage(thresholds),
 				Duration:    "1h",
 				Description: "", // use generated description
 			},
@@ -287,20 +327,20 @@ func defaultCPUResourceMonitor() (*v1alpha1.ResourceMonitor, error) {
 	return rm, err
 }
 
-func defaultDiskResourceMonitor() (*v1alpha1.ResourceMonitor, error) {
+func defaultDiskResourceMonitor(thresholds *v1alpha1.ResourceThresholds) (*v1alpha1.ResourceMonitor, error) {
 	spec := v1alpha1.DiskResourceMonitorSpec{
 		SamplingInterval: DefaultSamplingInterval.String(),
 		MonitorType:      DiskMonitorType,
 		AlertRules: []v1alpha1.ResourceAlertRule{
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeCritical,
-				Percentage:  90,
+				Percentage:  criticalPercentage(thresholds),
 				Duration:    "10m",
 				Description: "", // use generated description
 			},
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeWarning,
-				Percentage:  80,
+				Percentage:  warningPercentage(thresholds),
 				Duration:    "30m",
 				Description: "", // use generated description
 			},
@@ -312,20 +352,20 @@ func defaultDiskResourceMonitor() (*v1alpha1.ResourceMonitor, error) {
 	return rm, err
 }
 
-func defaultMemoryResourceMonitor() (*v1alpha1.ResourceMonitor, error) {
+func defaultMemoryResourceMonitor(thresholds *v1alpha1.ResourceThresholds) (*v1alpha1.ResourceMonitor, error) {
 	spec := v1alpha1.MemoryResourceMonitorSpec{
 		SamplingInterval: DefaultSamplingInterval.String(),
 		MonitorType:      MemoryMonitorType,
 		AlertRules: []v1alpha1.ResourceAlertRule{
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeCritical,
-				Percentage:  90,
+				Percentage:  criticalPercentage(thresholds),
 				Duration:    "30m",
 				Description: "", // use generated description
 			},
 			{
 				Severity:    v1alpha1.ResourceAlertSeverityTypeWarning,
-				Percentage:  80,
+				Percentage:  warningPercentage(thresholds),
 				Duration:    "1h",
 				Description: "", // use generated description
 			},
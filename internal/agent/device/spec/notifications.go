@@ -0,0 +1,73 @@
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/mqtt"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+// notificationsRetryInterval is the interval between two connections to the
+// broker after the subscription failed.
+const notificationsRetryInterval = 30 * time.Second
+
+// Notifications receives the notifications of new rendered specs which the
+// service publishes to the topic of the device on an MQTT broker.
+type Notifications struct {
+	client  *mqtt.Client
+	topic   string
+	manager *SpecManager
+	log     *log.PrefixLogger
+}
+
+// NewNotifications creates the subscriber to the notifications of the topic,
+// which pushes the rendered specs they carry to the spec manager.
+func NewNotifications(client *mqtt.Client, topic string, manager *SpecManager, log *log.PrefixLogger) *Notifications {
+	return &Notifications{client: client, topic: topic, manager: manager, log: log}
+}
+
+// Run subscribes to the topic until the context is done, calling onNotify
+// after each notification so that the agent syncs the new spec right away.
+// Notifications without a spec the agent supports make it fetch the spec
+// from the management API.
+func (n *Notifications) Run(ctx context.Context, onNotify func()) {
+	n.log.Infof("Subscribing to rendered spec notifications on %s", n.topic)
+	for {
+		err := n.client.Subscribe(ctx, n.topic, func(payload []byte) {
+			n.handle(payload)
+			onNotify()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		n.log.Warnf("Rendered spec notifications interrupted, reconnecting in %s: %v", notificationsRetryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(notificationsRetryInterval):
+		}
+	}
+}
+
+func (n *Notifications) handle(payload []byte) {
+	var notification mqtt.SpecNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		n.log.Warnf("Ignoring invalid rendered spec notification: %v", err)
+		return
+	}
+	n.log.Infof("Notified of rendered version %s", notification.RenderedVersion)
+	spec := notification.Spec
+	if spec == nil || spec.RenderedVersion != notification.RenderedVersion {
+		return
+	}
+	if !slices.Contains(v1alpha1.RenderedSpecVersions, lo.FromPtr(spec.SpecVersion)) {
+		n.log.Debugf("Fetching rendered version %s, whose spec version %q is not supported", spec.RenderedVersion, lo.FromPtr(spec.SpecVersion))
+		return
+	}
+	n.manager.SetPushed(spec)
+}
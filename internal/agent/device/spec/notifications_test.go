@@ -0,0 +1,43 @@
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/mqtt"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestNotificationsHandle(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	n := NewNotifications(nil, "flightctl/devices/device/spec", s, log.NewPrefixLogger("test"))
+
+	notify := func(notification mqtt.SpecNotification) {
+		payload, err := json.Marshal(notification)
+		require.NoError(err)
+		n.handle(payload)
+	}
+
+	// notifications of large specs only announce the version, which is fetched
+	notify(mqtt.SpecNotification{RenderedVersion: "3"})
+	require.Nil(s.pushed)
+
+	// specs of versions the agent does not know are fetched as well
+	notify(mqtt.SpecNotification{RenderedVersion: "3", Spec: &v1alpha1.RenderedDeviceSpec{RenderedVersion: "3", SpecVersion: lo.ToPtr("1000")}})
+	require.Nil(s.pushed)
+
+	spec := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "3", SpecVersion: lo.ToPtr(latestRenderedSpecVersion())}
+	notify(mqtt.SpecNotification{RenderedVersion: "3", Spec: spec})
+	require.Equal(spec, s.pushed)
+
+	n.handle([]byte("invalid"))
+	require.Equal(spec, s.pushed)
+}
@@ -1,19 +1,20 @@
 package spec
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
+	"slices"
 	"strconv"
+	"sync"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/state"
 	"github.com/flightctl/flightctl/internal/container"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 	"golang.org/x/net/context"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
@@ -34,12 +35,13 @@ const (
 var _ Manager = (*SpecManager)(nil)
 
 type Manager interface {
-	// Initialize initializes the current, desired and rollback spec files on
-	// disk. If the files already exist, they are overwritten.
+	// Initialize initializes the current, desired and rollback specs in the
+	// state store. If the specs already exist, they are overwritten.
 	Initialize() error
-	// Ensure ensures that spec files exist on disk and re initializes them if they do not.
+	// Ensure ensures that the specs exist in the state store, migrating the
+	// spec files of older agents, and re initializes them if they do not.
 	Ensure() error
-	// Read returns the rendered device spec of the specified type from disk.
+	// Read returns the rendered device spec of the specified type from the state store.
 	Read(specType Type) (*v1alpha1.RenderedDeviceSpec, error)
 	// Upgrade updates the current rendered spec to the desired rendered spec
 	// and resets the rollback spec.
@@ -62,15 +64,20 @@ type Manager interface {
 
 // Manager is responsible for managing the rendered device spec.
 type SpecManager struct {
-	deviceName   string
-	currentPath  string
-	desiredPath  string
-	rollbackPath string
+	deviceName string
 
-	deviceReadWriter fileio.ReadWriter
+	store            *state.Store
 	managementClient client.Management
 	bootcClient      container.BootcClient
 
+	// set once the desired spec was fetched in the latest rendered spec version of the agent
+	specVersionRefreshed bool
+
+	// the latest rendered spec pushed by the service, used instead of
+	// fetching it from the management API
+	pushedMu sync.Mutex
+	pushed   *v1alpha1.RenderedDeviceSpec
+
 	log     *log.PrefixLogger
 	backoff wait.Backoff
 }
@@ -85,46 +92,47 @@ func NewManager(
 	log *log.PrefixLogger,
 ) *SpecManager {
 	return &SpecManager{
-		deviceName:       deviceName,
-		currentPath:      filepath.Join(dataDir, string(Current)+".json"),
-		desiredPath:      filepath.Join(dataDir, string(Desired)+".json"),
-		rollbackPath:     filepath.Join(dataDir, string(Rollback)+".json"),
-		deviceReadWriter: deviceReadWriter,
-		bootcClient:      bootcClient,
-		backoff:          backoff,
-		log:              log,
+		deviceName:  deviceName,
+		store:       state.NewStore(dataDir, deviceReadWriter, log),
+		bootcClient: bootcClient,
+		backoff:     backoff,
+		log:         log,
 	}
 }
 
 func (s *SpecManager) Initialize() error {
-	// current
-	if err := s.write(Current, &v1alpha1.RenderedDeviceSpec{}); err != nil {
-		return fmt.Errorf("writing current rendered spec: %w", err)
-	}
-	// desired
-	if err := s.write(Desired, &v1alpha1.RenderedDeviceSpec{}); err != nil {
-		return fmt.Errorf("writing desired rendered spec: %w", err)
-	}
-	// rollback
-	if err := s.write(Rollback, &v1alpha1.RenderedDeviceSpec{}); err != nil {
-		return fmt.Errorf("writing rollback rendered spec: %w", err)
+	err := s.store.Update(state.EventInitialized, func(st *state.State) error {
+		st.Current = &v1alpha1.RenderedDeviceSpec{}
+		st.Desired = &v1alpha1.RenderedDeviceSpec{}
+		st.Rollback = &v1alpha1.RenderedDeviceSpec{}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("writing rendered specs: %w", err)
 	}
 	return nil
 }
 
 func (s *SpecManager) Ensure() error {
-	for _, specType := range []Type{Current, Desired, Rollback} {
-		exists, err := s.exists(specType)
-		if err != nil {
-			return err
-		}
+	st, err := s.store.Load()
+	if err != nil {
+		return fmt.Errorf("reading rendered specs: %w", err)
+	}
+	if st != nil && st.Current != nil && st.Desired != nil && st.Rollback != nil {
+		return nil
+	}
 
-		if !exists {
-			s.log.Warnf("Spec file does not exist %s. Resetting state to empty...", specType)
-			if err := s.write(specType, &v1alpha1.RenderedDeviceSpec{}); err != nil {
-				return fmt.Errorf("writing %s rendered spec: %w", specType, err)
+	err = s.store.Update(state.EventReset, func(st *state.State) error {
+		for specType, spec := range map[Type]**v1alpha1.RenderedDeviceSpec{Current: &st.Current, Desired: &st.Desired, Rollback: &st.Rollback} {
+			if *spec == nil {
+				s.log.Warnf("Spec %s does not exist. Resetting state to empty...", specType)
+				*spec = &v1alpha1.RenderedDeviceSpec{}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("writing rendered specs: %w", err)
 	}
 	return nil
 }
@@ -171,13 +179,18 @@ func (s *SpecManager) Upgrade() error {
 		return fmt.Errorf("read current rendered spec: %w", err)
 	}
 
-	if err := s.write(Current, desired); err != nil {
+	// the current spec and the rollback spec change at once, so that a crash
+	// does not leave a rollback spec for an upgraded device
+	err = s.store.Update(state.EventUpgraded, func(st *state.State) error {
+		st.Current = desired
+		st.Rollback = &v1alpha1.RenderedDeviceSpec{}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("write current rendered spec: %w", err)
 	}
-
-	s.log.Infof("Spec upgrade complete: clearing rollback spec")
-	// clear the rollback spec
-	return s.write(Rollback, &v1alpha1.RenderedDeviceSpec{})
+	s.log.Infof("Spec upgrade complete: cleared rollback spec")
+	return nil
 }
 
 func (s *SpecManager) PrepareRollback(ctx context.Context) error {
@@ -206,7 +219,11 @@ func (s *SpecManager) PrepareRollback(ctx context.Context) error {
 		Os:              &v1alpha1.DeviceOSSpec{Image: currentOSImage},
 	}
 
-	if err := s.write(Rollback, rollback); err != nil {
+	err = s.store.Update(state.EventRollbackPrepared, func(st *state.State) error {
+		st.Rollback = rollback
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("write rollback to desired rendered spec: %w", err)
 	}
 	return nil
@@ -215,15 +232,39 @@ func (s *SpecManager) PrepareRollback(ctx context.Context) error {
 func (s *SpecManager) Rollback() error {
 	// copy the current rendered spec to the desired rendered spec
 	// this will reconcile the device with the desired "rollback" state
-	return s.deviceReadWriter.CopyFile(s.currentPath, s.desiredPath)
+	return s.store.Update(state.EventRolledBack, func(st *state.State) error {
+		if st.Current == nil {
+			return fmt.Errorf("%w: %s", ErrMissingRenderedSpec, Current)
+		}
+		st.Desired = st.Current
+		return nil
+	})
 }
 
 func (s *SpecManager) Read(specType Type) (*v1alpha1.RenderedDeviceSpec, error) {
-	filePath, err := s.pathFromType(specType)
+	st, err := s.store.Load()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("read rendered specs: %w", err)
+	}
+	if st == nil {
+		st = &state.State{}
+	}
+	var spec *v1alpha1.RenderedDeviceSpec
+	switch specType {
+	case Current:
+		spec = st.Current
+	case Desired:
+		spec = st.Desired
+	case Rollback:
+		spec = st.Rollback
+	default:
+		return nil, fmt.Errorf("unknown spec type: %s", specType)
+	}
+	if spec == nil {
+		// the spec has been removed/corrupted
+		return nil, fmt.Errorf("%w: %s", ErrMissingRenderedSpec, specType)
 	}
-	return readRenderedSpecFromFile(s.deviceReadWriter, filePath)
+	return spec, nil
 }
 
 func (s *SpecManager) GetDesired(ctx context.Context, currentRenderedVersion string) (*v1alpha1.RenderedDeviceSpec, error) {
@@ -242,10 +283,24 @@ func (s *SpecManager) GetDesired(ctx context.Context, currentRenderedVersion str
 		return nil, fmt.Errorf("get next rendered version: %w", err)
 	}
 
-	newDesired := &v1alpha1.RenderedDeviceSpec{}
-	err = wait.ExponentialBackoff(s.backoff, func() (bool, error) {
-		return s.getRenderedFromManagementAPIWithRetry(ctx, renderedVersion, newDesired)
-	})
+	// the desired spec may have been rendered for the agent this one updated,
+	// so fetch it once in full rather than only when a new version is rendered
+	if !s.specVersionRefreshed && renderedVersion == currentRenderedVersion && lo.FromPtr(desired.SpecVersion) != latestRenderedSpecVersion() {
+		renderedVersion = ""
+	}
+
+	newDesired := s.takePushed(renderedVersion, desired.RenderedVersion)
+	if newDesired != nil {
+		s.log.Infof("Using desired rendered spec pushed by management service with rendered version: %s", newDesired.RenderedVersion)
+	} else {
+		newDesired = &v1alpha1.RenderedDeviceSpec{}
+		err = wait.ExponentialBackoff(s.backoff, func() (bool, error) {
+			return s.getRenderedFromManagementAPIWithRetry(ctx, renderedVersion, newDesired)
+		})
+	}
+	if err == nil || errors.Is(err, ErrNoContent) {
+		s.specVersionRefreshed = true
+	}
 	if err != nil {
 		// no content means there is no new rendered version
 		if errors.Is(err, ErrNoContent) {
@@ -257,18 +312,69 @@ func (s *SpecManager) GetDesired(ctx context.Context, currentRenderedVersion str
 		return nil, err
 	}
 
+	// the quarantine spec only conveys the quarantine, so it does not replace
+	// the desired spec on disk
+	if newDesired.Quarantine != nil {
+		return newDesired, nil
+	}
+
+	// an action is carried out once rather than reconciled, so it is passed
+	// on with the desired spec but not written to disk
+	action := newDesired.Action
+	newDesired.Action = nil
+
 	s.log.Infof("Received desired rendered spec from management service with rendered version: %s", newDesired.RenderedVersion)
-	if newDesired.RenderedVersion == desired.RenderedVersion {
+	if newDesired.RenderedVersion == desired.RenderedVersion && lo.FromPtr(newDesired.SpecVersion) == lo.FromPtr(desired.SpecVersion) {
 		s.log.Infof("No new rendered version from management service, retry reconciling version: %s", newDesired.RenderedVersion)
-		return desired, nil
+		return withAction(desired, action), nil
 	}
 
 	// write to disk
 	s.log.Infof("Writing desired rendered spec to disk with rendered version: %s", newDesired.RenderedVersion)
-	if err := s.write(Desired, newDesired); err != nil {
+	if err := s.writeDesired(newDesired); err != nil {
 		return nil, fmt.Errorf("write rendered spec to disk: %w", err)
 	}
-	return newDesired, nil
+	return withAction(newDesired, action), nil
+}
+
+func withAction(spec *v1alpha1.RenderedDeviceSpec, action *v1alpha1.DeviceAction) *v1alpha1.RenderedDeviceSpec {
+	if action == nil {
+		return spec
+	}
+	withAction := *spec
+	withAction.Action = action
+	return &withAction
+}
+
+// SetPushed records the rendered spec the service pushed to the device, which
+// the next call to GetDesired uses instead of fetching it if it is newer than
+// the desired spec.
+func (s *SpecManager) SetPushed(rendered *v1alpha1.RenderedDeviceSpec) {
+	s.pushedMu.Lock()
+	defer s.pushedMu.Unlock()
+	s.pushed = rendered
+}
+
+// takePushed returns the pushed rendered spec if it is a new version, which
+// the management API would serve for the known rendered version as well.
+func (s *SpecManager) takePushed(knownRenderedVersion, desiredRenderedVersion string) *v1alpha1.RenderedDeviceSpec {
+	s.pushedMu.Lock()
+	pushed := s.pushed
+	s.pushed = nil
+	s.pushedMu.Unlock()
+
+	if pushed == nil || pushed.RenderedVersion == knownRenderedVersion {
+		return nil
+	}
+	pushedVersion, err := strconv.Atoi(pushed.RenderedVersion)
+	if err != nil {
+		return nil
+	}
+	// a notification received late does not roll the device back
+	if desiredVersion, err := strconv.Atoi(desiredRenderedVersion); err == nil && pushedVersion <= desiredVersion {
+		return nil
+	}
+	return pushed
 }
 
 func (s *SpecManager) SetClient(client client.Management) {
@@ -315,20 +421,11 @@ func (s *SpecManager) CheckOsReconciliation(ctx context.Context) (string, bool,
 	return bootedOSImage, desired.Os.Image == bootc.GetBootedImage(), nil
 }
 
-func (s *SpecManager) write(specType Type, spec *v1alpha1.RenderedDeviceSpec) error {
-	filePath, err := s.pathFromType(specType)
-	if err != nil {
-		return err
-	}
-	return writeRenderedToFile(s.deviceReadWriter, spec, filePath)
-}
-
-func (s *SpecManager) exists(specType Type) (bool, error) {
-	filePath, err := s.pathFromType(specType)
-	if err != nil {
-		return false, err
-	}
-	return s.deviceReadWriter.FileExists(filePath)
+func (s *SpecManager) writeDesired(desired *v1alpha1.RenderedDeviceSpec) error {
+	return s.store.Update(state.EventDesiredReceived, func(st *state.State) error {
+		st.Desired = desired
+		return nil
+	})
 }
 
 // getRenderedVersion returns the last rendered version observed by the device. If the current rendered version
@@ -350,27 +447,14 @@ func (s *SpecManager) getRenderedVersion(currentRenderedVersion, desiredRendered
 	return currentRenderedVersion, nil
 }
 
-func (s *SpecManager) pathFromType(specType Type) (string, error) {
-	var filePath string
-	switch specType {
-	case Current:
-		filePath = s.currentPath
-	case Desired:
-		filePath = s.desiredPath
-	case Rollback:
-		filePath = s.rollbackPath
-	default:
-		return "", fmt.Errorf("unknown spec type: %s", specType)
-	}
-	return filePath, nil
-}
-
 func (m *SpecManager) getRenderedFromManagementAPIWithRetry(
 	ctx context.Context,
 	renderedVersion string,
 	rendered *v1alpha1.RenderedDeviceSpec,
 ) (bool, error) {
-	params := &v1alpha1.GetRenderedDeviceSpecParams{}
+	params := &v1alpha1.GetRenderedDeviceSpecParams{
+		SpecVersions: lo.ToPtr(slices.Clone(v1alpha1.RenderedSpecVersions)),
+	}
 	if renderedVersion != "" {
 		params.KnownRenderedVersion = &renderedVersion
 	}
@@ -391,37 +475,8 @@ func (m *SpecManager) getRenderedFromManagementAPIWithRetry(
 	return false, fmt.Errorf("received nil response for rendered device spec")
 }
 
-func readRenderedSpecFromFile(
-	reader fileio.Reader,
-	filePath string,
-) (*v1alpha1.RenderedDeviceSpec, error) {
-	var current v1alpha1.RenderedDeviceSpec
-	renderedBytes, err := reader.ReadFile(filePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// if the file does not exist, this means it has been removed/corrupted
-			return nil, fmt.Errorf("%w: current: %w", ErrMissingRenderedSpec, err)
-		}
-		return nil, fmt.Errorf("read device specification from '%s': %w", filePath, err)
-	}
-
-	// read bytes from file
-	if err := json.Unmarshal(renderedBytes, &current); err != nil {
-		return nil, fmt.Errorf("unmarshal device specification: %w", err)
-	}
-
-	return &current, nil
-}
-
-func writeRenderedToFile(writer fileio.Writer, rendered *v1alpha1.RenderedDeviceSpec, filePath string) error {
-	renderedBytes, err := json.Marshal(rendered)
-	if err != nil {
-		return err
-	}
-	if err := writer.WriteFile(filePath, renderedBytes, fileio.DefaultFilePermissions); err != nil {
-		return fmt.Errorf("write default device spec file %q: %w", filePath, err)
-	}
-	return nil
+func latestRenderedSpecVersion() string {
+	return v1alpha1.RenderedSpecVersions[len(v1alpha1.RenderedSpecVersions)-1]
 }
 
 func IsUpdating(current *v1alpha1.RenderedDeviceSpec, desired *v1alpha1.RenderedDeviceSpec) bool {
@@ -3,14 +3,20 @@ package spec
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
 	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/agent/device/state"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
 	"github.com/flightctl/flightctl/internal/container"
 	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestBootstrapCheckRollback(t *testing.T) {
@@ -18,21 +24,16 @@ func TestBootstrapCheckRollback(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockReadWriter := fileio.NewMockReadWriter(ctrl)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
 	mockBootcClient := container.NewMockBootcClient(ctrl)
-
-	s := &SpecManager{
-		log:              log.NewPrefixLogger("test"),
-		deviceReadWriter: mockReadWriter,
-		bootcClient:      mockBootcClient,
-	}
+	s := NewManager("device", "/var/lib/flightctl", readWriter, mockBootcClient, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	require.NoError(s.Initialize())
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	t.Run("no rollback: bootstrap case empty desired spec", func(t *testing.T) {
 		wantIsRollback := false
-		mockReadWriter.EXPECT().ReadFile(gomock.Any()).Return([]byte(`{}`), nil)
 
 		isRollback, err := s.IsRollingBack(ctx)
 		require.NoError(err)
@@ -45,15 +46,7 @@ func TestBootstrapCheckRollback(t *testing.T) {
 		bootedImage := "flightctl-device:v2"
 		desiredImage := "flightctl-device:v2"
 
-		// desiredSpec
-		desiredSpec, err := createTestSpec(desiredImage)
-		require.NoError(err)
-		mockReadWriter.EXPECT().ReadFile(gomock.Any()).Return(desiredSpec, nil)
-
-		// rollbackSpec
-		rollbackSpec, err := createTestSpec(rollbackImage)
-		require.NoError(err)
-		mockReadWriter.EXPECT().ReadFile(gomock.Any()).Return(rollbackSpec, nil)
+		writeSpecs(t, s, &v1alpha1.RenderedDeviceSpec{}, createTestSpec(desiredImage), createTestSpec(rollbackImage))
 
 		// bootcStatus
 		bootcStatus := &container.BootcHost{}
@@ -71,15 +64,7 @@ func TestBootstrapCheckRollback(t *testing.T) {
 		bootedImage := "flightctl-device:v1"
 		desiredImage := "flightctl-device:v2"
 
-		// desiredSpec
-		desiredSpec, err := createTestSpec(desiredImage)
-		require.NoError(err)
-		mockReadWriter.EXPECT().ReadFile(gomock.Any()).Return(desiredSpec, nil)
-
-		// rollbackSpec
-		rollbackSpec, err := createTestSpec(rollbackImage)
-		require.NoError(err)
-		mockReadWriter.EXPECT().ReadFile(gomock.Any()).Return(rollbackSpec, nil)
+		writeSpecs(t, s, &v1alpha1.RenderedDeviceSpec{}, createTestSpec(desiredImage), createTestSpec(rollbackImage))
 
 		// bootcStatus
 		bootcStatus := &container.BootcHost{}
@@ -93,11 +78,211 @@ func TestBootstrapCheckRollback(t *testing.T) {
 
 }
 
-func createTestSpec(image string) ([]byte, error) {
-	spec := v1alpha1.RenderedDeviceSpec{
+func createTestSpec(image string) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{
 		Os: &v1alpha1.DeviceOSSpec{
 			Image: image,
 		},
 	}
-	return json.Marshal(spec)
+}
+
+func writeSpecs(t *testing.T, s *SpecManager, current, desired, rollback *v1alpha1.RenderedDeviceSpec) {
+	require.NoError(t, s.store.Update(state.EventInitialized, func(st *state.State) error {
+		st.Current, st.Desired, st.Rollback = current, desired, rollback
+		return nil
+	}))
+}
+
+func TestUpgradeAndRollback(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+
+	current := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Os: &v1alpha1.DeviceOSSpec{Image: "quay.io/flightctl/device:v1"}}
+	desired := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "2", Os: &v1alpha1.DeviceOSSpec{Image: "quay.io/flightctl/device:v2"}}
+	writeSpecs(t, s, current, desired, &v1alpha1.RenderedDeviceSpec{})
+
+	require.NoError(s.PrepareRollback(context.Background()))
+	rollback, err := s.Read(Rollback)
+	require.NoError(err)
+	require.Equal("1", rollback.RenderedVersion)
+	require.Equal(current.Os, rollback.Os)
+
+	// rolling back reconciles the device with the current spec
+	require.NoError(s.Rollback())
+	rolledBack, err := s.Read(Desired)
+	require.NoError(err)
+	require.Equal(current, rolledBack)
+
+	writeSpecs(t, s, current, desired, rollback)
+	require.NoError(s.Upgrade())
+	upgraded, err := s.Read(Current)
+	require.NoError(err)
+	require.Equal(desired, upgraded)
+	rollback, err = s.Read(Rollback)
+	require.NoError(err)
+	require.Equal(&v1alpha1.RenderedDeviceSpec{}, rollback)
+}
+
+func TestEnsureMigratesSpecFiles(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+
+	// the spec files of an agent which predates the state store
+	current, err := json.Marshal(&v1alpha1.RenderedDeviceSpec{RenderedVersion: "3"})
+	require.NoError(err)
+	require.NoError(readWriter.WriteFile("/var/lib/flightctl/current.json", current, fileio.DefaultFilePermissions))
+	require.NoError(readWriter.WriteFile("/var/lib/flightctl/desired.json", current, fileio.DefaultFilePermissions))
+
+	require.NoError(s.Ensure())
+	for _, specType := range []Type{Current, Desired} {
+		spec, err := s.Read(specType)
+		require.NoError(err)
+		require.Equal("3", spec.RenderedVersion)
+	}
+	rollback, err := s.Read(Rollback)
+	require.NoError(err)
+	require.Equal(&v1alpha1.RenderedDeviceSpec{}, rollback)
+
+	exists, err := readWriter.FileExists("/var/lib/flightctl/current.json")
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestGetDesiredRefreshesSpecVersion(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	mockClient := client.NewMockManagement(ctrl)
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	s.SetClient(mockClient)
+	ctx := context.Background()
+
+	// rendered for an agent which supported an older version
+	onDisk := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", SpecVersion: lo.ToPtr(v1alpha1.RenderedSpecVersion2)}
+	writeSpecs(t, s, onDisk, onDisk, &v1alpha1.RenderedDeviceSpec{})
+
+	refreshed := &v1alpha1.RenderedDeviceSpec{
+		RenderedVersion: "1",
+		SpecVersion:     lo.ToPtr(v1alpha1.RenderedSpecVersion3),
+		Agent: &v1alpha1.DeviceAgentSpec{
+			Update: &v1alpha1.AgentUpdateSpec{Version: "v0.3.1", Image: lo.ToPtr("quay.io/flightctl/flightctl-agent:v0.3.1")},
+		},
+	}
+	mockClient.EXPECT().GetRenderedDeviceSpec(gomock.Any(), "device", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...agentclient.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error) {
+			require.Nil(params.KnownRenderedVersion)
+			require.Equal(v1alpha1.RenderedSpecVersions, *params.SpecVersions)
+			return refreshed, http.StatusOK, nil
+		})
+	desired, err := s.GetDesired(ctx, "1")
+	require.NoError(err)
+	require.Equal(refreshed, desired)
+
+	// once refreshed, only new rendered versions are fetched
+	mockClient.EXPECT().GetRenderedDeviceSpec(gomock.Any(), "device", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name string, params *v1alpha1.GetRenderedDeviceSpecParams, rcb ...agentclient.RequestEditorFn) (*v1alpha1.RenderedDeviceSpec, int, error) {
+			require.Equal(lo.ToPtr("1"), params.KnownRenderedVersion)
+			return nil, http.StatusNoContent, nil
+		})
+	desired, err = s.GetDesired(ctx, "1")
+	require.NoError(err)
+	require.Equal(refreshed, desired)
+}
+
+func TestGetDesiredKeepsSpecOfQuarantinedDevice(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	mockClient := client.NewMockManagement(ctrl)
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	s.SetClient(mockClient)
+	s.specVersionRefreshed = true
+	ctx := context.Background()
+
+	onDisk := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", SpecVersion: lo.ToPtr(latestRenderedSpecVersion()), Os: &v1alpha1.DeviceOSSpec{Image: "quay.io/flightctl/device:v2"}}
+	writeSpecs(t, s, onDisk, onDisk, &v1alpha1.RenderedDeviceSpec{})
+
+	quarantined := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Quarantine: &v1alpha1.DeviceQuarantine{Reason: "incident 42"}}
+	mockClient.EXPECT().GetRenderedDeviceSpec(gomock.Any(), "device", gomock.Any()).Return(quarantined, http.StatusOK, nil)
+	desired, err := s.GetDesired(ctx, "1")
+	require.NoError(err)
+	require.Equal(quarantined, desired)
+
+	// the desired spec on disk is kept for the release of the device
+	onDiskDesired, err := s.Read(Desired)
+	require.NoError(err)
+	require.Equal(onDisk, onDiskDesired)
+}
+
+func TestGetDesiredPassesOnAction(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	mockClient := client.NewMockManagement(ctrl)
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	s.SetClient(mockClient)
+	s.specVersionRefreshed = true
+	ctx := context.Background()
+
+	onDisk := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", SpecVersion: lo.ToPtr(latestRenderedSpecVersion())}
+	writeSpecs(t, s, onDisk, onDisk, &v1alpha1.RenderedDeviceSpec{})
+
+	action := &v1alpha1.DeviceAction{Id: "42", Action: v1alpha1.DeviceActionReboot}
+	withAction := *onDisk
+	withAction.Action = action
+	mockClient.EXPECT().GetRenderedDeviceSpec(gomock.Any(), "device", gomock.Any()).Return(&withAction, http.StatusOK, nil)
+	desired, err := s.GetDesired(ctx, "1")
+	require.NoError(err)
+	require.Equal("1", desired.RenderedVersion)
+	require.Equal(action, desired.Action)
+
+	// the action is not reconciled from the desired spec on disk
+	onDiskDesired, err := s.Read(Desired)
+	require.NoError(err)
+	require.Nil(onDiskDesired.Action)
+}
+
+func TestGetDesiredUsesPushedSpec(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	mockClient := client.NewMockManagement(ctrl)
+	s := NewManager("device", "/var/lib/flightctl", readWriter, nil, wait.Backoff{Steps: 1}, log.NewPrefixLogger("test"))
+	s.SetClient(mockClient)
+	s.specVersionRefreshed = true
+	ctx := context.Background()
+
+	onDisk := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "2", SpecVersion: lo.ToPtr(latestRenderedSpecVersion())}
+	writeSpecs(t, s, onDisk, onDisk, &v1alpha1.RenderedDeviceSpec{})
+
+	// a new version is used without fetching it
+	pushed := &v1alpha1.RenderedDeviceSpec{RenderedVersion: "3", SpecVersion: lo.ToPtr(latestRenderedSpecVersion()), Os: &v1alpha1.DeviceOSSpec{Image: "quay.io/flightctl/device:v3"}}
+	s.SetPushed(pushed)
+	desired, err := s.GetDesired(ctx, "2")
+	require.NoError(err)
+	require.Equal(pushed, desired)
+	onDiskDesired, err := s.Read(Desired)
+	require.NoError(err)
+	require.Equal(pushed, onDiskDesired)
+
+	// an older version received late is ignored and the spec is fetched
+	s.SetPushed(onDisk)
+	mockClient.EXPECT().GetRenderedDeviceSpec(gomock.Any(), "device", gomock.Any()).Return(nil, http.StatusNoContent, nil)
+	desired, err = s.GetDesired(ctx, "2")
+	require.NoError(err)
+	require.Equal(pushed, desired)
 }
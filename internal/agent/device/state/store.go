@@ -0,0 +1,338 @@
+// Package state is the store of the rendered specs the agent reconciles the
+// device with, and of the journal of their transitions. The store survives
+// crashes and power losses at any point: each change is written as a whole
+// to a checksummed file which is synced to disk before it replaces the
+// previous one, kept as a backup the store falls back to if the current file
+// is missing or corrupted.
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// SchemaVersion is the version of the schema of the state the agent
+	// writes.
+	SchemaVersion = 1
+	// journalSize is the number of transitions the journal keeps.
+	journalSize = 50
+
+	storeFile = "state.json"
+)
+
+var (
+	ErrCorrupted    = errors.New("corrupted agent state")
+	ErrNewerVersion = errors.New("agent state written by a newer agent")
+)
+
+// legacyFiles are the files of the rendered specs written by agents which
+// predate the store, by the field of the state they are migrated into.
+var legacyFiles = []string{"current.json", "desired.json", "rollback.json"}
+
+// migrations upgrade the state of a schema version, their index, to the next
+// version.
+var migrations = map[int]func(json.RawMessage) (json.RawMessage, error){}
+
+type Event string
+
+const (
+	EventInitialized      Event = "Initialized"
+	EventMigrated         Event = "Migrated"
+	EventReset            Event = "Reset"
+	EventDesiredReceived  Event = "DesiredReceived"
+	EventUpgraded         Event = "Upgraded"
+	EventRollbackPrepared Event = "RollbackPrepared"
+	EventRolledBack       Event = "RolledBack"
+)
+
+// State is the state of the agent.
+type State struct {
+	Current  *v1alpha1.RenderedDeviceSpec `json:"current,omitempty"`
+	Desired  *v1alpha1.RenderedDeviceSpec `json:"desired,omitempty"`
+	Rollback *v1alpha1.RenderedDeviceSpec `json:"rollback,omitempty"`
+	// Journal holds the latest transitions of the state, the newest last.
+	Journal []Entry `json:"journal,omitempty"`
+}
+
+// Entry is a transition of the state, with the rendered versions of the specs
+// it resulted in.
+type Entry struct {
+	Time            time.Time `json:"time"`
+	Event           Event     `json:"event"`
+	CurrentVersion  string    `json:"currentVersion,omitempty"`
+	DesiredVersion  string    `json:"desiredVersion,omitempty"`
+	RollbackVersion string    `json:"rollbackVersion,omitempty"`
+}
+
+// document is the content of the store file.
+type document struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Checksum      string `json:"checksum"`
+	// State is kept raw so that the checksum is computed on the bytes
+	// written
+	State json.RawMessage `json:"state"`
+}
+
+// Store reads and writes the state of the agent.
+type Store struct {
+	dataDir    string
+	readWriter fileio.ReadWriter
+	log        *log.PrefixLogger
+	now        func() time.Time
+
+	mu sync.Mutex
+	// cleaned is set once the files of agents which predate the store are
+	// removed
+	cleaned bool
+}
+
+// NewStore returns the store of the state of the agent in dataDir.
+func NewStore(dataDir string, readWriter fileio.ReadWriter, log *log.PrefixLogger) *Store {
+	return &Store{
+		dataDir:    dataDir,
+		readWriter: readWriter,
+		log:        log,
+		now:        time.Now,
+	}
+}
+
+// Load returns the stored state, nil if nothing is stored. The first load
+// migrates the files of agents which predate the store.
+func (s *Store) Load() (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Update applies the change to the stored state, or to an empty state if
+// nothing is stored, and records the event in the journal. The change is
+// written to disk as a whole, or not at all.
+func (s *Store) Update(event Event, change func(*State) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &State{}
+	}
+	if err := change(state); err != nil {
+		return err
+	}
+	return s.write(event, state)
+}
+
+func (s *Store) load() (*State, error) {
+	state, err := s.read(s.path())
+	if err == nil {
+		s.removeLegacyFiles()
+		return state, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, ErrCorrupted) {
+		return nil, err
+	}
+
+	// the write of the last change was interrupted between the backup of the
+	// previous state and its replacement, or the state was corrupted on disk
+	backup, backupErr := s.read(s.backupPath())
+	if backupErr == nil {
+		s.log.Warnf("Recovered agent state from its backup: %v", err)
+		return backup, nil
+	}
+	switch {
+	case errors.Is(err, os.ErrNotExist) && errors.Is(backupErr, os.ErrNotExist):
+		return s.migrate()
+	case errors.Is(err, os.ErrNotExist):
+		return nil, backupErr
+	default:
+		return nil, err
+	}
+}
+
+// read returns the state of the store file at the path.
+func (s *Store) read(path string) (*State, error) {
+	content, err := os.ReadFile(s.readWriter.PathFor(path))
+	if err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrCorrupted, path, err)
+	}
+	if doc.Checksum != checksum(doc.State) {
+		return nil, fmt.Errorf("%w: %s: checksum mismatch", ErrCorrupted, path)
+	}
+	if doc.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("%w: schema version %d", ErrNewerVersion, doc.SchemaVersion)
+	}
+
+	raw := doc.State
+	for version := doc.SchemaVersion; version < SchemaVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: unknown schema version %d", ErrCorrupted, path, doc.SchemaVersion)
+		}
+		if raw, err = migrate(raw); err != nil {
+			return nil, fmt.Errorf("migrating agent state from schema version %d: %w", version, err)
+		}
+	}
+	var state State
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrCorrupted, path, err)
+	}
+	return &state, nil
+}
+
+// write replaces the store file with the state, keeping the previous file as
+// the backup. The new file is synced before it replaces the previous one, and
+// the directory once it did, so that the store holds either state after a
+// power loss.
+func (s *Store) write(event Event, state *State) error {
+	entry := Entry{Time: s.now().UTC(), Event: event}
+	if state.Current != nil {
+		entry.CurrentVersion = state.Current.RenderedVersion
+	}
+	if state.Desired != nil {
+		entry.DesiredVersion = state.Desired.RenderedVersion
+	}
+	if state.Rollback != nil {
+		entry.RollbackVersion = state.Rollback.RenderedVersion
+	}
+	state.Journal = append(state.Journal, entry)
+	if len(state.Journal) > journalSize {
+		state.Journal = state.Journal[len(state.Journal)-journalSize:]
+	}
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(&document{SchemaVersion: SchemaVersion, Checksum: checksum(raw), State: raw})
+	if err != nil {
+		return err
+	}
+
+	path := s.readWriter.PathFor(s.path())
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating agent state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := writeSynced(tmp, content); err != nil {
+		return fmt.Errorf("writing agent state: %w", err)
+	}
+	if err := os.Rename(path, s.readWriter.PathFor(s.backupPath())); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("backing up agent state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("replacing agent state: %w", err)
+	}
+	if err := syncDir(dir); err != nil {
+		return fmt.Errorf("syncing agent state directory: %w", err)
+	}
+	return nil
+}
+
+// migrate moves the files of the rendered specs written by agents which
+// predate the store into the store, and returns the migrated state, nil if
+// there are no such files.
+func (s *Store) migrate() (*State, error) {
+	state := &State{}
+	specs := []**v1alpha1.RenderedDeviceSpec{&state.Current, &state.Desired, &state.Rollback}
+	found := false
+	for i, name := range legacyFiles {
+		path := filepath.Join(s.dataDir, name)
+		exists, err := s.readWriter.FileExists(path)
+		if err != nil {
+			return nil, err
+		}
+		*specs[i] = &v1alpha1.RenderedDeviceSpec{}
+		if !exists {
+			continue
+		}
+		content, err := s.readWriter.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(content, *specs[i]); err != nil {
+			return nil, fmt.Errorf("migrating %s: %w", path, err)
+		}
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+
+	s.log.Infof("Migrating the rendered specs of the agent to %s", s.path())
+	if err := s.write(EventMigrated, state); err != nil {
+		return nil, err
+	}
+	s.removeLegacyFiles()
+	return state, nil
+}
+
+// removeLegacyFiles removes the files of agents which predate the store, left
+// behind if the agent stopped before it removed them.
+func (s *Store) removeLegacyFiles() {
+	if s.cleaned {
+		return
+	}
+	s.cleaned = true
+	for _, name := range legacyFiles {
+		if err := s.readWriter.RemoveFile(filepath.Join(s.dataDir, name)); err != nil {
+			s.log.Warnf("Removing migrated state file: %v", err)
+		}
+	}
+}
+
+func (s *Store) path() string {
+	return filepath.Join(s.dataDir, storeFile)
+}
+
+func (s *Store) backupPath() string {
+	return s.path() + ".bak"
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func writeSynced(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,91 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+const dataDir = "/var/lib/flightctl"
+
+func setDesired(version string) func(*State) error {
+	return func(state *State) error {
+		state.Desired = &v1alpha1.RenderedDeviceSpec{RenderedVersion: version}
+		return nil
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	store := NewStore(dataDir, fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir)), log.NewPrefixLogger("test"))
+
+	state, err := store.Load()
+	require.NoError(err)
+	require.Nil(state)
+
+	require.NoError(store.Update(EventDesiredReceived, setDesired("1")))
+	require.NoError(store.Update(EventDesiredReceived, setDesired("2")))
+	state, err = store.Load()
+	require.NoError(err)
+	require.Equal("2", state.Desired.RenderedVersion)
+	require.Len(state.Journal, 2)
+	require.Equal(EventDesiredReceived, state.Journal[1].Event)
+	require.Equal("2", state.Journal[1].DesiredVersion)
+
+	// the journal keeps the latest transitions
+	for i := 0; i < journalSize; i++ {
+		require.NoError(store.Update(EventDesiredReceived, setDesired("3")))
+	}
+	state, err = store.Load()
+	require.NoError(err)
+	require.Len(state.Journal, journalSize)
+	require.Equal("3", state.Journal[0].DesiredVersion)
+}
+
+func TestLoadRecoversBackup(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	store := NewStore(dataDir, fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir)), log.NewPrefixLogger("test"))
+	require.NoError(store.Update(EventDesiredReceived, setDesired("1")))
+	require.NoError(store.Update(EventDesiredReceived, setDesired("2")))
+	path := filepath.Join(tmpDir, dataDir, storeFile)
+
+	// a corrupted state falls back to the previous one
+	content, err := os.ReadFile(path)
+	require.NoError(err)
+	require.NoError(os.WriteFile(path, content[:len(content)/2], 0600))
+	state, err := store.Load()
+	require.NoError(err)
+	require.Equal("1", state.Desired.RenderedVersion)
+
+	// so does a state whose write was interrupted before it replaced the
+	// previous one
+	require.NoError(os.Remove(path))
+	state, err = store.Load()
+	require.NoError(err)
+	require.Equal("1", state.Desired.RenderedVersion)
+
+	require.NoError(os.Remove(path + ".bak"))
+	require.NoError(os.WriteFile(path, []byte(`{"schemaVersion":1,"checksum":"sha256:00","state":{}}`), 0600))
+	_, err = store.Load()
+	require.ErrorIs(err, ErrCorrupted)
+}
+
+func TestLoadNewerVersion(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	store := NewStore(dataDir, fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir)), log.NewPrefixLogger("test"))
+
+	content := []byte(`{}`)
+	doc := `{"schemaVersion":2,"checksum":"` + checksum(content) + `","state":{}}`
+	require.NoError(os.MkdirAll(filepath.Join(tmpDir, dataDir), 0755))
+	require.NoError(os.WriteFile(filepath.Join(tmpDir, dataDir, storeFile), []byte(doc), 0600))
+	_, err := store.Load()
+	require.ErrorIs(err, ErrNewerVersion)
+}
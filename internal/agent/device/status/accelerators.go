@@ -0,0 +1,246 @@
+package status
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	nvidiaSmiCommand        = "nvidia-smi"
+	nvidiaSmiCommandTimeout = 10 * time.Second
+	nvidiaSmiQuery          = "pci.bus_id,name,driver_version,utilization.gpu,memory.used,memory.total,temperature.gpu,ecc.errors.uncorrected.volatile.total"
+
+	pciVendorNvidia = "0x10de"
+)
+
+var (
+	// DRM cards, excluding their connectors such as card0-HDMI-A-1
+	drmCardRegexp = regexp.MustCompile(`^card[0-9]+$`)
+
+	pciVendorNames = map[string]string{
+		"0x8086":        "Intel",
+		"0x1002":        "AMD",
+		pciVendorNvidia: "NVIDIA",
+	}
+)
+
+var _ Exporter = (*Accelerators)(nil)
+
+// Accelerators collects the status of GPUs and other accelerators. NVIDIA GPUs
+// are queried with nvidia-smi, other GPUs and Coral TPUs are read from sysfs.
+type Accelerators struct {
+	exec    executer.Executer
+	rootDir string
+	log     *log.PrefixLogger
+}
+
+func newAccelerators(exec executer.Executer, log *log.PrefixLogger) *Accelerators {
+	return &Accelerators{
+		exec:    exec,
+		rootDir: "/",
+		log:     log,
+	}
+}
+
+// Export sets the status of the accelerators. Failing to query an accelerator
+// is logged rather than reported, so that a missing tool does not degrade the device.
+func (a *Accelerators) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	accelerators := []v1alpha1.DeviceAcceleratorStatus{}
+
+	nvidia, err := a.nvidiaGPUs(ctx)
+	if err != nil {
+		a.log.Warnf("Failed to query NVIDIA GPUs: %v", err)
+	}
+	accelerators = append(accelerators, nvidia...)
+	accelerators = append(accelerators, a.drmGPUs(len(nvidia) > 0)...)
+	accelerators = append(accelerators, a.coralTPUs()...)
+
+	if len(accelerators) == 0 {
+		status.Accelerators = nil
+		return nil
+	}
+	status.Accelerators = &accelerators
+	return nil
+}
+
+func (a *Accelerators) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+func (a *Accelerators) nvidiaGPUs(ctx context.Context) ([]v1alpha1.DeviceAcceleratorStatus, error) {
+	nvidiaSmi, err := a.exec.LookPath(nvidiaSmiCommand)
+	if err != nil {
+		// no NVIDIA driver installed
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, nvidiaSmiCommandTimeout)
+	defer cancel()
+	args := []string{"--query-gpu=" + nvidiaSmiQuery, "--format=csv,noheader,nounits"}
+	stdout, stderr, exitCode := a.exec.ExecuteWithContext(ctx, nvidiaSmi, args...)
+	if exitCode != 0 {
+		return nil, fmt.Errorf("%s: exit code %d: %s", nvidiaSmiCommand, exitCode, stderr)
+	}
+	return parseNvidiaSmi(stdout)
+}
+
+func parseNvidiaSmi(output string) ([]v1alpha1.DeviceAcceleratorStatus, error) {
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s output: %w", nvidiaSmiCommand, err)
+	}
+
+	gpus := []v1alpha1.DeviceAcceleratorStatus{}
+	for _, record := range records {
+		if len(record) != 8 {
+			return nil, fmt.Errorf("parsing %s output: unexpected number of fields %d", nvidiaSmiCommand, len(record))
+		}
+		gpu := v1alpha1.DeviceAcceleratorStatus{
+			Id:                 record[0],
+			Name:               record[1],
+			Vendor:             pciVendorNames[pciVendorNvidia],
+			UtilizationPercent: parseInt32(record[3]),
+			TemperatureCelsius: parseInt32(record[6]),
+			EccErrors:          parseInt64(record[7]),
+		}
+		if record[2] != "" {
+			gpu.DriverVersion = lo.ToPtr(record[2])
+		}
+		// memory is reported in MiB
+		if used := parseInt64(record[4]); used != nil {
+			gpu.MemoryUsedBytes = lo.ToPtr(*used * 1024 * 1024)
+		}
+		if total := parseInt64(record[5]); total != nil {
+			gpu.MemoryTotalBytes = lo.ToPtr(*total * 1024 * 1024)
+		}
+		gpus = append(gpus, gpu)
+	}
+	return gpus, nil
+}
+
+// parseInt64 returns nil for the values nvidia-smi and sysfs use for
+// unsupported metrics, such as "[N/A]" or "[Not Supported]".
+func parseInt64(value string) *int64 {
+	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &i
+}
+
+func parseInt32(value string) *int32 {
+	i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return nil
+	}
+	return lo.ToPtr(int32(i))
+}
+
+func (a *Accelerators) path(elem ...string) string {
+	return filepath.Join(append([]string{a.rootDir}, elem...)...)
+}
+
+func (a *Accelerators) readString(elem ...string) string {
+	contents, err := os.ReadFile(a.path(elem...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(contents))
+}
+
+// temperature reads the first sensor matching pattern. Sensors report millidegrees Celsius.
+func (a *Accelerators) temperature(pattern string) *int32 {
+	matches, _ := filepath.Glob(a.path(pattern))
+	sort.Strings(matches)
+	for _, match := range matches {
+		contents, err := os.ReadFile(match)
+		if err != nil {
+			continue
+		}
+		if milli := parseInt64(string(contents)); milli != nil {
+			return lo.ToPtr(int32(*milli / 1000))
+		}
+	}
+	return nil
+}
+
+func (a *Accelerators) drmGPUs(skipNvidia bool) []v1alpha1.DeviceAcceleratorStatus {
+	entries, err := os.ReadDir(a.path("sys/class/drm"))
+	if err != nil {
+		return nil
+	}
+
+	var gpus []v1alpha1.DeviceAcceleratorStatus
+	for _, entry := range entries {
+		card := entry.Name()
+		if !drmCardRegexp.MatchString(card) {
+			continue
+		}
+		device := filepath.Join("sys/class/drm", card, "device")
+		vendorID := a.readString(device, "vendor")
+		if skipNvidia && vendorID == pciVendorNvidia {
+			// already reported by nvidia-smi
+			continue
+		}
+		id := card
+		if target, err := os.Readlink(a.path(device)); err == nil {
+			id = filepath.Base(target)
+		}
+		var driverName string
+		if driver, err := os.Readlink(a.path(device, "driver")); err == nil {
+			driverName = filepath.Base(driver)
+		}
+		gpu := v1alpha1.DeviceAcceleratorStatus{
+			Id:   id,
+			Name: lo.CoalesceOrEmpty(a.readString(device, "product_name"), card),
+			// GPUs of SoCs are platform devices without a PCI vendor
+			Vendor:             lo.CoalesceOrEmpty(pciVendorNames[vendorID], vendorID, driverName),
+			UtilizationPercent: parseInt32(a.readString(device, "gpu_busy_percent")),
+			MemoryUsedBytes:    parseInt64(a.readString(device, "mem_info_vram_used")),
+			MemoryTotalBytes:   parseInt64(a.readString(device, "mem_info_vram_total")),
+			TemperatureCelsius: a.temperature(filepath.Join(device, "hwmon/hwmon*/temp1_input")),
+		}
+		if version := a.readString("sys/module", driverName, "version"); driverName != "" && version != "" {
+			gpu.DriverVersion = lo.ToPtr(version)
+		}
+		gpus = append(gpus, gpu)
+	}
+	return gpus
+}
+
+func (a *Accelerators) coralTPUs() []v1alpha1.DeviceAcceleratorStatus {
+	entries, err := os.ReadDir(a.path("sys/class/apex"))
+	if err != nil {
+		return nil
+	}
+
+	var tpus []v1alpha1.DeviceAcceleratorStatus
+	for _, entry := range entries {
+		apex := filepath.Join("sys/class/apex", entry.Name())
+		tpu := v1alpha1.DeviceAcceleratorStatus{
+			Id:                 entry.Name(),
+			Name:               "Coral Edge TPU",
+			Vendor:             "Google",
+			TemperatureCelsius: a.temperature(filepath.Join(apex, "temp")),
+		}
+		if version := a.readString("sys/module/apex/version"); version != "" {
+			tpu.DriverVersion = lo.ToPtr(version)
+		}
+		tpus = append(tpus, tpu)
+	}
+	return tpus
+}
@@ -0,0 +1,125 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const nvidiaSmiResult = `00000000:01:00.0, NVIDIA A2, 535.104.05, 37, 1024, 15360, 54, 0
+00000000:02:00.0, Tesla T4, 535.104.05, [N/A], 512, 15360, 61, [N/A]
+`
+
+func TestAcceleratorsExport(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	root := t.TempDir()
+
+	execMock.EXPECT().LookPath(nvidiaSmiCommand).Return("/usr/bin/nvidia-smi", nil)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/nvidia-smi", "--query-gpu="+nvidiaSmiQuery, "--format=csv,noheader,nounits").Return(nvidiaSmiResult, "", 0)
+
+	// an AMD GPU
+	pciDevice := filepath.Join(root, "sys/devices/pci0000:00/0000:03:00.0")
+	writeTestFile(t, root, "sys/devices/pci0000:00/0000:03:00.0/vendor", "0x1002\n")
+	writeTestFile(t, root, "sys/devices/pci0000:00/0000:03:00.0/gpu_busy_percent", "12\n")
+	writeTestFile(t, root, "sys/devices/pci0000:00/0000:03:00.0/mem_info_vram_total", "8589934592\n")
+	writeTestFile(t, root, "sys/devices/pci0000:00/0000:03:00.0/mem_info_vram_used", "1073741824\n")
+	writeTestFile(t, root, "sys/devices/pci0000:00/0000:03:00.0/hwmon/hwmon3/temp1_input", "48000\n")
+	writeTestFile(t, root, "sys/module/amdgpu/version", "6.2.4\n")
+	require.NoError(os.MkdirAll(filepath.Join(root, "sys/bus/pci/drivers/amdgpu"), 0755))
+	require.NoError(os.Symlink(filepath.Join(root, "sys/bus/pci/drivers/amdgpu"), filepath.Join(pciDevice, "driver")))
+	require.NoError(os.MkdirAll(filepath.Join(root, "sys/class/drm/card1"), 0755))
+	require.NoError(os.Symlink(pciDevice, filepath.Join(root, "sys/class/drm/card1/device")))
+	require.NoError(os.MkdirAll(filepath.Join(root, "sys/class/drm/card1-DP-1"), 0755))
+
+	// an NVIDIA GPU already reported by nvidia-smi
+	writeTestFile(t, root, "sys/class/drm/card0/device/vendor", "0x10de\n")
+
+	// a Coral TPU
+	writeTestFile(t, root, "sys/class/apex/apex_0/temp", "39500\n")
+
+	accelerators := newAccelerators(execMock, log.NewPrefixLogger("test"))
+	accelerators.rootDir = root
+
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(accelerators.Export(context.Background(), &status))
+	require.Equal(&[]v1alpha1.DeviceAcceleratorStatus{
+		{
+			Id:                 "00000000:01:00.0",
+			Name:               "NVIDIA A2",
+			Vendor:             "NVIDIA",
+			DriverVersion:      lo.ToPtr("535.104.05"),
+			UtilizationPercent: lo.ToPtr(int32(37)),
+			MemoryUsedBytes:    lo.ToPtr(int64(1024 * 1024 * 1024)),
+			MemoryTotalBytes:   lo.ToPtr(int64(15360 * 1024 * 1024)),
+			TemperatureCelsius: lo.ToPtr(int32(54)),
+			EccErrors:          lo.ToPtr(int64(0)),
+		},
+		{
+			Id:                 "00000000:02:00.0",
+			Name:               "Tesla T4",
+			Vendor:             "NVIDIA",
+			DriverVersion:      lo.ToPtr("535.104.05"),
+			MemoryUsedBytes:    lo.ToPtr(int64(512 * 1024 * 1024)),
+			MemoryTotalBytes:   lo.ToPtr(int64(15360 * 1024 * 1024)),
+			TemperatureCelsius: lo.ToPtr(int32(61)),
+		},
+		{
+			Id:                 "0000:03:00.0",
+			Name:               "card1",
+			Vendor:             "AMD",
+			DriverVersion:      lo.ToPtr("6.2.4"),
+			UtilizationPercent: lo.ToPtr(int32(12)),
+			MemoryUsedBytes:    lo.ToPtr(int64(1073741824)),
+			MemoryTotalBytes:   lo.ToPtr(int64(8589934592)),
+			TemperatureCelsius: lo.ToPtr(int32(48)),
+		},
+		{
+			Id:                 "apex_0",
+			Name:               "Coral Edge TPU",
+			Vendor:             "Google",
+			TemperatureCelsius: lo.ToPtr(int32(39)),
+		},
+	}, status.Accelerators)
+}
+
+func TestAcceleratorsExportNone(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+
+	execMock.EXPECT().LookPath(nvidiaSmiCommand).Return("", os.ErrNotExist)
+
+	accelerators := newAccelerators(execMock, log.NewPrefixLogger("test"))
+	accelerators.rootDir = t.TempDir()
+
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(accelerators.Export(context.Background(), &status))
+	require.Nil(status.Accelerators)
+}
+
+func TestAcceleratorsExportNvidiaSmiFailure(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+
+	execMock.EXPECT().LookPath(nvidiaSmiCommand).Return("/usr/bin/nvidia-smi", nil)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/nvidia-smi", gomock.Any(), gomock.Any()).Return("", "NVIDIA-SMI has failed", 9)
+
+	accelerators := newAccelerators(execMock, log.NewPrefixLogger("test"))
+	accelerators.rootDir = t.TempDir()
+
+	// a broken driver does not fail the status collection
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(accelerators.Export(context.Background(), &status))
+	require.Nil(status.Accelerators)
+}
@@ -0,0 +1,86 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+// quadletGeneratorPath is the systemd generator of podman which generates the
+// units of the Quadlet files the agent writes for pods.
+const quadletGeneratorPath = "usr/lib/systemd/system-generators/podman-system-generator"
+
+// capabilityCommands are the commands the agent runs for each capability.
+var capabilityCommands = []struct {
+	capability v1alpha1.DeviceCapability
+	commands   []string
+}{
+	{v1alpha1.DeviceCapabilityBootcOSImage, []string{"bootc"}},
+	{v1alpha1.DeviceCapabilityComposeApplications, []string{"podman-compose"}},
+	{v1alpha1.DeviceCapabilityDiskEncryption, []string{"/usr/bin/clevis", "/usr/sbin/cryptsetup"}},
+	{v1alpha1.DeviceCapabilityTimeSync, []string{"/usr/bin/chronyc"}},
+	{v1alpha1.DeviceCapabilityComplianceScans, []string{"/usr/bin/oscap"}},
+	{v1alpha1.DeviceCapabilityFirewall, []string{"/usr/sbin/nft"}},
+	{v1alpha1.DeviceCapabilityGarbageCollection, []string{"/usr/bin/podman"}},
+}
+
+var _ Exporter = (*Capabilities)(nil)
+
+// Capabilities reports the features of the spec the agent supports on the
+// device, so that the service does not render it a spec the agent cannot
+// apply.
+type Capabilities struct {
+	exec         executer.Executer
+	rootDir      string
+	log          *log.PrefixLogger
+	capabilities *[]v1alpha1.DeviceCapability
+}
+
+func newCapabilities(exec executer.Executer, log *log.PrefixLogger) *Capabilities {
+	return &Capabilities{
+		exec:    exec,
+		rootDir: "/",
+		log:     log,
+	}
+}
+
+// Export sets the capabilities, which are detected once, as the tools they
+// depend on only change with the OS image.
+func (c *Capabilities) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	if c.capabilities == nil {
+		c.capabilities = c.detect()
+	}
+	status.SystemInfo.Capabilities = c.capabilities
+	return nil
+}
+
+func (c *Capabilities) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+func (c *Capabilities) detect() *[]v1alpha1.DeviceCapability {
+	// updating the agent only takes the agent itself
+	capabilities := []v1alpha1.DeviceCapability{v1alpha1.DeviceCapabilityAgentUpdate}
+	for _, entry := range capabilityCommands {
+		if c.hasCommands(entry.commands...) {
+			capabilities = append(capabilities, entry.capability)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(c.rootDir, quadletGeneratorPath)); err == nil && c.hasCommands("podman") {
+		capabilities = append(capabilities, v1alpha1.DeviceCapabilityPodApplications)
+	}
+	c.log.Debugf("Detected capabilities: %v", capabilities)
+	return &capabilities
+}
+
+func (c *Capabilities) hasCommands(commands ...string) bool {
+	for _, command := range commands {
+		if _, err := c.exec.LookPath(command); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCapabilitiesExport(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		commands             []string
+		quadletGenerator     bool
+		expectedCapabilities []v1alpha1.DeviceCapability
+	}{
+		{
+			name:                 "agent only",
+			expectedCapabilities: []v1alpha1.DeviceCapability{v1alpha1.DeviceCapabilityAgentUpdate},
+		},
+		{
+			name:     "image mode device",
+			commands: []string{"bootc", "podman-compose", "podman", "/usr/bin/chronyc"},
+			expectedCapabilities: []v1alpha1.DeviceCapability{
+				v1alpha1.DeviceCapabilityAgentUpdate,
+				v1alpha1.DeviceCapabilityBootcOSImage,
+				v1alpha1.DeviceCapabilityComposeApplications,
+				v1alpha1.DeviceCapabilityTimeSync,
+			},
+		},
+		{
+			name:             "pods need podman and its quadlet generator",
+			commands:         []string{"podman", "/usr/bin/clevis", "/usr/sbin/cryptsetup", "/usr/bin/oscap"},
+			quadletGenerator: true,
+			expectedCapabilities: []v1alpha1.DeviceCapability{
+				v1alpha1.DeviceCapabilityAgentUpdate,
+				v1alpha1.DeviceCapabilityDiskEncryption,
+				v1alpha1.DeviceCapabilityComplianceScans,
+				v1alpha1.DeviceCapabilityPodApplications,
+			},
+		},
+		{
+			name:     "encryption needs both clevis and cryptsetup",
+			commands: []string{"/usr/bin/clevis"},
+			expectedCapabilities: []v1alpha1.DeviceCapability{
+				v1alpha1.DeviceCapabilityAgentUpdate,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			root := t.TempDir()
+			if tc.quadletGenerator {
+				writeTestFile(t, root, quadletGeneratorPath, "")
+			}
+			mockExec := executer.NewMockExecuter(ctrl)
+			mockExec.EXPECT().LookPath(gomock.Any()).DoAndReturn(func(file string) (string, error) {
+				for _, command := range tc.commands {
+					if command == file {
+						return file, nil
+					}
+				}
+				return "", errors.New("not found")
+			}).AnyTimes()
+
+			capabilities := newCapabilities(mockExec, log.NewPrefixLogger("test"))
+			capabilities.rootDir = root
+			status := v1alpha1.NewDeviceStatus()
+			require.NoError(capabilities.Export(context.Background(), &status))
+			require.NotNil(status.SystemInfo.Capabilities)
+			require.Equal(tc.expectedCapabilities, *status.SystemInfo.Capabilities)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package status
+
+import (
+	"context"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/compliance"
+)
+
+var _ Exporter = (*Compliance)(nil)
+
+// Compliance reports the summary of the last compliance scan of the device,
+// and passes the compliance profile of the spec on to the scans.
+type Compliance struct {
+	manager compliance.Manager
+}
+
+func newCompliance(manager compliance.Manager) *Compliance {
+	return &Compliance{
+		manager: manager,
+	}
+}
+
+func (c *Compliance) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	status.Compliance = c.manager.Status()
+	return nil
+}
+
+func (c *Compliance) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+	c.manager.SetSpec(spec.Compliance)
+}
@@ -27,6 +27,10 @@ func newHooks(log *log.PrefixLogger, manager hook.Manager) *Hooks {
 
 // Export returns the status of the config hooks.
 func (s *Hooks) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	status.Hooks = nil
+	if results := s.manager.Results(); len(results) > 0 {
+		status.Hooks = &results
+	}
 	if err := errors.Join(s.manager.Errors()...); err != nil {
 		return fmt.Errorf("hook manager: %v", err)
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -50,6 +51,19 @@ type PodmanContainerListEntry struct {
 	Restarts int      `json:"Restarts"`
 }
 
+// PodmanContainerInspect holds the details of a container which podman ps
+// does not list.
+type PodmanContainerInspect struct {
+	Id          string `json:"Id"`
+	ImageDigest string `json:"ImageDigest"`
+	State       struct {
+		OOMKilled  bool      `json:"OOMKilled"`
+		ExitCode   int       `json:"ExitCode"`
+		StartedAt  time.Time `json:"StartedAt"`
+		FinishedAt time.Time `json:"FinishedAt"`
+	} `json:"State"`
+}
+
 type CrioContainerList struct {
 	Containers []CrioContainerListEntry `json:"containers"`
 }
@@ -96,18 +110,83 @@ func (c *Container) PodmanExport(ctx context.Context, status *v1alpha1.DeviceSta
 		return fmt.Errorf("failed unmarshalling podman containers: %s", err)
 	}
 
+	details, err := c.podmanInspect(ctx, containers)
+	if err != nil {
+		return err
+	}
+
 	// TODO: handle removed containers and use appropriate status
 	for _, c := range containers {
-		status.Applications.Data[c.Names[0]] = v1alpha1.ApplicationStatus{
+		appStatus := v1alpha1.ApplicationStatus{
 			Name:     c.Names[0],
 			Status:   podmanApplicationStatus(c),
 			Restarts: c.Restarts,
 		}
+		if detail, ok := details[c.Id]; ok {
+			setPodmanApplicationDetails(&appStatus, c, detail)
+		}
+		status.Applications.Data[c.Names[0]] = appStatus
 	}
 
 	return nil
 }
 
+// podmanInspect returns the details of the containers by their ID.
+func (c *Container) podmanInspect(ctx context.Context, containers PodmanContainerList) (map[string]PodmanContainerInspect, error) {
+	details := map[string]PodmanContainerInspect{}
+	if len(containers) == 0 {
+		return details, nil
+	}
+	podmanExecCtx, cancel := context.WithTimeout(ctx, podmanCommandTimeout)
+	defer cancel()
+	args := []string{"container", "inspect", "--format", "json"}
+	for _, container := range containers {
+		args = append(args, container.Id)
+	}
+	podmanOut, podmanErrOut, podmanExitCode := c.exec.ExecuteWithContext(podmanExecCtx, podmanCommand, args...)
+	if podmanExitCode != 0 {
+		return nil, fmt.Errorf("failed inspecting podman containers with code %d: %s", podmanExitCode, podmanErrOut)
+	}
+
+	var inspected []PodmanContainerInspect
+	if err := json.Unmarshal([]byte(podmanOut), &inspected); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling podman container details: %s", err)
+	}
+	for _, detail := range inspected {
+		details[detail.Id] = detail
+	}
+	return details, nil
+}
+
+// setPodmanApplicationDetails sets the image digest, the start time and the
+// last termination of the container in the status of its application, so
+// that a container which restarts over and over is told apart from one which
+// runs steadily.
+func setPodmanApplicationDetails(appStatus *v1alpha1.ApplicationStatus, entry PodmanContainerListEntry, detail PodmanContainerInspect) {
+	if detail.ImageDigest != "" {
+		appStatus.ImageDigest = lo.ToPtr(detail.ImageDigest)
+	}
+	if entry.State == podmanContainerRunning && !detail.State.StartedAt.IsZero() {
+		appStatus.StartedAt = lo.ToPtr(detail.State.StartedAt.UTC())
+	}
+	// podman keeps the exit of the previous run of a restarted container
+	if detail.State.FinishedAt.IsZero() {
+		return
+	}
+	reason := v1alpha1.ApplicationTerminationError
+	switch {
+	case detail.State.OOMKilled:
+		reason = v1alpha1.ApplicationTerminationOOMKilled
+	case detail.State.ExitCode == 0:
+		reason = v1alpha1.ApplicationTerminationCompleted
+	}
+	appStatus.LastTermination = &v1alpha1.ApplicationTermination{
+		Reason:     reason,
+		ExitCode:   int32(detail.State.ExitCode),
+		FinishedAt: lo.ToPtr(detail.State.FinishedAt.UTC()),
+	}
+}
+
 func (c *Container) CrioExport(ctx context.Context, status *v1alpha1.DeviceStatus) error {
 	crioExecCtx, cancel := context.WithTimeout(ctx, podmanCommandTimeout)
 	defer cancel()
@@ -131,10 +210,15 @@ func (c *Container) CrioExport(ctx context.Context, status *v1alpha1.DeviceStatu
 	// TODO: handle removed containers and use appropriate status
 	for _, c := range containers.Containers {
 		name := c.Metadata.Name
-		status.Applications.Data[name] = v1alpha1.ApplicationStatus{
+		appStatus := v1alpha1.ApplicationStatus{
 			Name:   name,
 			Status: v1alpha1.ApplicationStatusUnknown,
 		}
+		// the image reference of CRI-O pins the digest of the image
+		if _, digest, ok := strings.Cut(c.Image, "@"); ok {
+			appStatus.ImageDigest = lo.ToPtr(digest)
+		}
+		status.Applications.Data[name] = appStatus
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/pkg/executer"
@@ -117,6 +118,53 @@ const podmanListResult = `
 ]
 `
 
+const podmanInspectResult = `
+[
+  {
+    "Id": "id1",
+    "Created": "2024-01-25T10:30:50.1234+01:00",
+    "State": {
+      "OciVersion": "1.1.0",
+      "Status": "running",
+      "Running": true,
+      "Paused": false,
+      "OOMKilled": true,
+      "Dead": false,
+      "Pid": 1136940,
+      "ExitCode": 137,
+      "StartedAt": "2024-01-25T10:31:02.25124087+01:00",
+      "FinishedAt": "2024-01-25T10:31:01.7254+01:00"
+    },
+    "Image": "b22b91a96569c182755b01c5ab342d7194f825984fa293cebfd1b1c8c383252b",
+    "ImageDigest": "sha256:8c1ec3d5e9d2b7ed6c6e0a3a3a7e1b95ad1c3fdaf5a9c2c0c0d54aaf38e9a312",
+    "ImageName": "quay.io/image1:latest",
+    "Name": "myfirstname",
+    "RestartCount": 3
+  },
+  {
+    "Id": "id2",
+    "Created": "2024-01-25T10:30:50.1234+01:00",
+    "State": {
+      "OciVersion": "1.1.0",
+      "Status": "paused",
+      "Running": false,
+      "Paused": true,
+      "OOMKilled": false,
+      "Dead": false,
+      "Pid": 1136940,
+      "ExitCode": 0,
+      "StartedAt": "2024-01-25T10:31:02.25124087+01:00",
+      "FinishedAt": "0001-01-01T00:00:00Z"
+    },
+    "Image": "b22b91a96569c182755b01c5ab342d7194f825984fa293cebfd1b1c8c383252c",
+    "ImageDigest": "sha256:1f8e54eeb3a4e1b56a9e8f4e4e1f1b0dcd5c3a9b1f84f5c26a0e4e64e540bd0a",
+    "ImageName": "quay.io/image2:latest",
+    "Name": "agreatname",
+    "RestartCount": 0
+  }
+]
+`
+
 const crioListResult = `
 {
   "containers": [
@@ -185,10 +233,31 @@ var _ = Describe("containers exporter", func() {
 			execMock.EXPECT().LookPath("crictl").Return("", fmt.Errorf("not found"))
 			execMock.EXPECT().LookPath("podman").Return("/usr/bin/podman", nil)
 			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "ps", "-a", "--format", "json", "--filter", "name=myfirstname", "--filter", "name=agreatname").Return(podmanListResult, "", 0)
+			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "container", "inspect", "--format", "json", "id1", "id2").Return(podmanInspectResult, "", 0)
 			err := container.Export(context.TODO(), &deviceStatus)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(len(deviceStatus.Applications.Data)).To(Equal(2))
+			running := deviceStatus.Applications.Data["myfirstname"]
+			Expect(running.Status).To(Equal(v1alpha1.ApplicationStatusRunning))
+			Expect(*running.ImageDigest).To(Equal("sha256:8c1ec3d5e9d2b7ed6c6e0a3a3a7e1b95ad1c3fdaf5a9c2c0c0d54aaf38e9a312"))
+			Expect(*running.StartedAt).To(Equal(time.Date(2024, 1, 25, 9, 31, 2, 251240870, time.UTC)))
+			Expect(running.LastTermination).ToNot(BeNil())
+			Expect(running.LastTermination.Reason).To(Equal(v1alpha1.ApplicationTerminationOOMKilled))
+			Expect(running.LastTermination.ExitCode).To(Equal(int32(137)))
+
+			paused := deviceStatus.Applications.Data["agreatname"]
+			Expect(paused.StartedAt).To(BeNil())
+			Expect(paused.LastTermination).To(BeNil())
+		})
+
+		It("fails when podman cannot inspect the containers", func() {
+			container.matchPatterns = []string{"myfirstname", "agreatname"}
+			execMock.EXPECT().LookPath("podman").Return("/usr/bin/podman", nil)
+			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "ps", "-a", "--format", "json", "--filter", "name=myfirstname", "--filter", "name=agreatname").Return(podmanListResult, "", 0)
+			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "container", "inspect", "--format", "json", "id1", "id2").Return("", "no such container", 125)
+			err := container.Export(context.TODO(), &deviceStatus)
+			Expect(err).To(HaveOccurred())
 		})
 
 		It("list crio containers", func() {
@@ -200,6 +269,7 @@ var _ = Describe("containers exporter", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(len(deviceStatus.Applications.Data)).To(Equal(2))
+			Expect(*deviceStatus.Applications.Data["alpine"].ImageDigest).To(Equal("sha256:c4a262d530f57d1b7b68b52ba8383c2e55fd1a0cb5b4f46b11eed7a2c4e143da"))
 		})
 
 		It("list both podman and crio containers", func() {
@@ -207,6 +277,7 @@ var _ = Describe("containers exporter", func() {
 			execMock.EXPECT().LookPath("podman").Return("/usr/bin/podman", nil)
 			execMock.EXPECT().LookPath("crictl").Return("/usr/bin/crictl", nil)
 			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "ps", "-a", "--format", "json", "--filter", "name=myfirstname", "--filter", "name=alpine").Return(podmanListResult, "", 0)
+			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "container", "inspect", "--format", "json", "id1", "id2").Return(podmanInspectResult, "", 0)
 			execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/crictl", "ps", "-a", "--output", "json", "--name", "myfirstname", "--name", "alpine").Return(crioListResult, "", 0)
 			err := container.Export(context.TODO(), &deviceStatus)
 			Expect(err).ToNot(HaveOccurred())
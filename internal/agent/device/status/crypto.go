@@ -0,0 +1,68 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/fips"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	// fipsEnabledPath reports 1 if the kernel runs in FIPS mode.
+	fipsEnabledPath = "proc/sys/crypto/fips_enabled"
+	// cryptoPolicyStatePath holds the system-wide crypto policy applied by update-crypto-policies.
+	cryptoPolicyStatePath = "etc/crypto-policies/state/current"
+	// cryptoPolicyConfigPath holds the configured policy, which is applied on the next update-crypto-policies run.
+	cryptoPolicyConfigPath = "etc/crypto-policies/config"
+)
+
+var _ Exporter = (*Crypto)(nil)
+
+// Crypto reports the FIPS mode and the system-wide crypto policy of the device.
+type Crypto struct {
+	rootDir string
+	log     *log.PrefixLogger
+}
+
+func newCrypto(log *log.PrefixLogger) *Crypto {
+	return &Crypto{
+		rootDir: "/",
+		log:     log,
+	}
+}
+
+// Export sets the crypto configuration, which is read on every export as the
+// crypto policy can change without a reboot.
+func (c *Crypto) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	info := &v1alpha1.DeviceCryptoInfo{
+		FipsEnabled: c.readString(fipsEnabledPath) == "1",
+		AgentFips:   fips.Enabled(),
+	}
+	for _, path := range []string{cryptoPolicyStatePath, cryptoPolicyConfigPath} {
+		if policy := c.readString(path); policy != "" {
+			info.Policy = lo.ToPtr(policy)
+			break
+		}
+	}
+	status.SystemInfo.Crypto = info
+	return nil
+}
+
+func (c *Crypto) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+func (c *Crypto) readString(path string) string {
+	data, err := os.ReadFile(filepath.Join(c.rootDir, path))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.log.Debugf("Failed to read %s: %v", path, err)
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
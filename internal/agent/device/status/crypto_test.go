@@ -0,0 +1,35 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/fips"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoExport(t *testing.T) {
+	require := require.New(t)
+	root := t.TempDir()
+	crypto := newCrypto(log.NewPrefixLogger("test"))
+	crypto.rootDir = root
+
+	// neither FIPS mode nor crypto policies
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(crypto.Export(context.Background(), &status))
+	require.Equal(&v1alpha1.DeviceCryptoInfo{AgentFips: fips.Enabled()}, status.SystemInfo.Crypto)
+
+	// the configured policy is reported until it is applied
+	writeTestFile(t, root, "proc/sys/crypto/fips_enabled", "1\n")
+	writeTestFile(t, root, "etc/crypto-policies/config", "FIPS\n")
+	require.NoError(crypto.Export(context.Background(), &status))
+	require.True(status.SystemInfo.Crypto.FipsEnabled)
+	require.Equal(lo.ToPtr("FIPS"), status.SystemInfo.Crypto.Policy)
+
+	writeTestFile(t, root, "etc/crypto-policies/state/current", "FIPS:OSPP\n")
+	require.NoError(crypto.Export(context.Background(), &status))
+	require.Equal(lo.ToPtr("FIPS:OSPP"), status.SystemInfo.Crypto.Policy)
+}
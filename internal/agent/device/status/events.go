@@ -0,0 +1,211 @@
+package status
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	journalctlCommand        = "/usr/bin/journalctl"
+	journalctlCommandTimeout = 10 * time.Second
+
+	// eventsWindow is how long the events are reported for.
+	eventsWindow = 24 * time.Hour
+	// maxEvents is the number of events reported, the newest ones.
+	maxEvents = 20
+
+	// unitProcessExitMessageID is the journal message ID of systemd logging
+	// the exit of a process of a unit
+	unitProcessExitMessageID = "98e322203f7a4ed290d09fe03c09fe15"
+	exitCodeDumped           = "dumped"
+)
+
+// libpodScope matches the cgroup of a podman container, with the cgroup of
+// the payload of the container when a systemd unit runs it.
+var libpodScope = regexp.MustCompile(`^libpod-(?:payload-)?([0-9a-f]{12,64})(?:\.scope)?$`)
+
+var _ Exporter = (*Events)(nil)
+
+// Events reports the out-of-memory kills and the crashes of the workloads of
+// the device, which it reads from the journal. The kernel logs the cgroup of
+// the processes it kills for lack of memory, from which the unit or the
+// container of the process is found, and systemd logs the processes of its
+// units which dumped core.
+type Events struct {
+	exec executer.Executer
+	log  *log.PrefixLogger
+	now  func() time.Time
+	// cursor is the position in the journal of the last entry read
+	cursor string
+	events []v1alpha1.DeviceEvent
+}
+
+func newEvents(exec executer.Executer, log *log.PrefixLogger) *Events {
+	return &Events{
+		exec: exec,
+		log:  log,
+		now:  time.Now,
+	}
+}
+
+// journalEntry holds the fields of a journal entry in the JSON output of
+// journalctl.
+type journalEntry struct {
+	Cursor    journalField `json:"__CURSOR"`
+	Realtime  journalField `json:"__REALTIME_TIMESTAMP"`
+	Transport journalField `json:"_TRANSPORT"`
+	MessageID journalField `json:"MESSAGE_ID"`
+	Message   journalField `json:"MESSAGE"`
+	Unit      journalField `json:"UNIT"`
+	ExitCode  journalField `json:"EXIT_CODE"`
+}
+
+// journalField is a field of a journal entry. journalctl outputs the fields
+// which are not valid UTF-8 as arrays of bytes, and the fields set more than
+// once as arrays, which are left empty.
+type journalField string
+
+func (f *journalField) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = journalField(s)
+	}
+	return nil
+}
+
+// Export reads the journal since the last export and reports the events of
+// the last 24 hours. A device whose journal cannot be read keeps reporting the
+// events found before.
+func (e *Events) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	if err := e.read(ctx); err != nil {
+		e.log.Debugf("Failed to read the journal: %v", err)
+	}
+	since := e.now().Add(-eventsWindow)
+	e.events = lo.Filter(e.events, func(event v1alpha1.DeviceEvent, _ int) bool { return event.Time.After(since) })
+	if len(e.events) > maxEvents {
+		e.events = e.events[len(e.events)-maxEvents:]
+	}
+
+	if len(e.events) == 0 {
+		status.Events = nil
+		return nil
+	}
+	events := make([]v1alpha1.DeviceEvent, len(e.events))
+	copy(events, e.events)
+	status.Events = &events
+	return nil
+}
+
+func (e *Events) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+// read appends the events of the journal entries written since the last read.
+func (e *Events) read(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, journalctlCommandTimeout)
+	defer cancel()
+	args := []string{"--output", "json", "--no-pager"}
+	if e.cursor != "" {
+		args = append(args, "--after-cursor", e.cursor)
+	} else {
+		args = append(args, "--since", fmt.Sprintf("-%dh", int(eventsWindow.Hours())))
+	}
+	args = append(args, "_TRANSPORT=kernel", "+", "MESSAGE_ID="+unitProcessExitMessageID)
+	stdout, stderr, exitCode := e.exec.ExecuteWithContext(ctx, journalctlCommand, args...)
+	if exitCode != 0 {
+		return fmt.Errorf("%s: exit code %d: %s", journalctlCommand, exitCode, stderr)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry journalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return fmt.Errorf("parsing %s output: %w", journalctlCommand, err)
+		}
+		if entry.Cursor != "" {
+			e.cursor = string(entry.Cursor)
+		}
+		if event := parseJournalEvent(entry); event != nil {
+			e.log.Warnf("Device event %s: %s", event.Type, event.Message)
+			e.events = append(e.events, *event)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseJournalEvent returns the event of the journal entry, nil if the entry
+// is not an out-of-memory kill or a crash.
+func parseJournalEvent(entry journalEntry) *v1alpha1.DeviceEvent {
+	var event *v1alpha1.DeviceEvent
+	switch {
+	case entry.Transport == "kernel" && strings.HasPrefix(string(entry.Message), "oom-kill:"):
+		event = parseOOMKill(string(entry.Message))
+	case entry.MessageID == unitProcessExitMessageID && entry.ExitCode == exitCodeDumped:
+		event = &v1alpha1.DeviceEvent{
+			Type:    v1alpha1.DeviceEventCrashed,
+			Message: string(entry.Message),
+		}
+		if entry.Unit != "" {
+			event.Unit = lo.ToPtr(string(entry.Unit))
+		}
+	}
+	if event == nil {
+		return nil
+	}
+	// the journal timestamps entries in microseconds since the epoch
+	if usec, err := strconv.ParseInt(string(entry.Realtime), 10, 64); err == nil {
+		event.Time = time.UnixMicro(usec).UTC()
+	}
+	return event
+}
+
+// parseOOMKill parses the summary the kernel logs of an out-of-memory kill,
+// such as oom-kill:constraint=CONSTRAINT_MEMCG,nodemask=(null),cpuset=/,
+// mems_allowed=0,oom_memcg=/system.slice/web.service,
+// task_memcg=/system.slice/web.service,task=python3,pid=4242,uid=0
+func parseOOMKill(message string) *v1alpha1.DeviceEvent {
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(message, "oom-kill:"), ",") {
+		if key, value, ok := strings.Cut(field, "="); ok {
+			fields[key] = value
+		}
+	}
+
+	event := &v1alpha1.DeviceEvent{Type: v1alpha1.DeviceEventOOMKilled}
+	if task := fields["task"]; task != "" {
+		event.Process = lo.ToPtr(task)
+	}
+	if pid, err := strconv.ParseInt(fields["pid"], 10, 32); err == nil {
+		event.Pid = lo.ToPtr(int32(pid))
+	}
+	for _, part := range strings.Split(fields["task_memcg"], "/") {
+		if strings.HasSuffix(part, ".service") && event.Unit == nil {
+			event.Unit = lo.ToPtr(part)
+		}
+		if match := libpodScope.FindStringSubmatch(part); match != nil {
+			event.Container = lo.ToPtr(match[1])
+		}
+	}
+
+	process := fmt.Sprintf("%s (%s)", fields["task"], fields["pid"])
+	switch constraint := fields["constraint"]; constraint {
+	case "CONSTRAINT_MEMCG":
+		event.Message = fmt.Sprintf("the kernel killed process %s, which exceeded the memory limit of cgroup %s", process, fields["oom_memcg"])
+	case "CONSTRAINT_NONE":
+		event.Message = fmt.Sprintf("the kernel killed process %s, as the device ran out of memory", process)
+	default:
+		event.Message = fmt.Sprintf("the kernel killed process %s for lack of memory: %s", process, constraint)
+	}
+	return event
+}
@@ -0,0 +1,97 @@
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const journalEventsResult = `{"__CURSOR":"s=1;i=10","__REALTIME_TIMESTAMP":"1791964800000000","_TRANSPORT":"kernel","MESSAGE":"oom-kill:constraint=CONSTRAINT_MEMCG,nodemask=(null),cpuset=libpod-4f3c2a1b9d8e.scope,mems_allowed=0,oom_memcg=/machine.slice/libpod-4f3c2a1b9d8e.scope,task_memcg=/machine.slice/libpod-4f3c2a1b9d8e.scope/container,task=python3,pid=4242,uid=0"}
+{"__CURSOR":"s=1;i=11","__REALTIME_TIMESTAMP":"1791964800100000","_TRANSPORT":"kernel","MESSAGE":"Memory cgroup out of memory: Killed process 4242 (python3) total-vm:1048576kB, anon-rss:262144kB"}
+{"__CURSOR":"s=1;i=12","__REALTIME_TIMESTAMP":"1791964860000000","_TRANSPORT":"journal","MESSAGE_ID":"98e322203f7a4ed290d09fe03c09fe15","UNIT":"sensor.service","EXIT_CODE":"dumped","EXIT_STATUS":"11","MESSAGE":"sensor.service: Main process exited, code=dumped, status=11/SEGV"}
+{"__CURSOR":"s=1;i=13","__REALTIME_TIMESTAMP":"1791964920000000","_TRANSPORT":"journal","MESSAGE_ID":"98e322203f7a4ed290d09fe03c09fe15","UNIT":"backup.service","EXIT_CODE":"exited","EXIT_STATUS":"1","MESSAGE":"backup.service: Main process exited, code=exited, status=1/FAILURE"}
+{"__CURSOR":"s=1;i=14","__REALTIME_TIMESTAMP":"1791964980000000","_TRANSPORT":"kernel","MESSAGE":[101,116,104,48]}
+`
+
+func TestEventsExport(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	events := newEvents(execMock, log.NewPrefixLogger("test"))
+	events.now = func() time.Time { return now }
+
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), journalctlCommand, "--output", "json", "--no-pager", "--since", "-24h", "_TRANSPORT=kernel", "+", "MESSAGE_ID="+unitProcessExitMessageID).Return(journalEventsResult, "", 0)
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(events.Export(context.Background(), &status))
+	require.NotNil(status.Events)
+	require.Equal([]v1alpha1.DeviceEvent{
+		{
+			Type:      v1alpha1.DeviceEventOOMKilled,
+			Time:      time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC),
+			Container: lo.ToPtr("4f3c2a1b9d8e"),
+			Process:   lo.ToPtr("python3"),
+			Pid:       lo.ToPtr(int32(4242)),
+			Message:   "the kernel killed process python3 (4242), which exceeded the memory limit of cgroup /machine.slice/libpod-4f3c2a1b9d8e.scope",
+		},
+		{
+			Type:    v1alpha1.DeviceEventCrashed,
+			Time:    time.Date(2026, 10, 14, 8, 1, 0, 0, time.UTC),
+			Unit:    lo.ToPtr("sensor.service"),
+			Message: "sensor.service: Main process exited, code=dumped, status=11/SEGV",
+		},
+	}, *status.Events)
+
+	// the next export reads the journal from the last entry read, and keeps
+	// the events while the journal cannot be read
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), journalctlCommand, "--output", "json", "--no-pager", "--after-cursor", "s=1;i=14", "_TRANSPORT=kernel", "+", "MESSAGE_ID="+unitProcessExitMessageID).Return("", "Failed to open journal", 1)
+	require.NoError(events.Export(context.Background(), &status))
+	require.Len(*status.Events, 2)
+
+	// the events expire after a day
+	now = now.Add(eventsWindow)
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), journalctlCommand, "--output", "json", "--no-pager", "--after-cursor", "s=1;i=14", "_TRANSPORT=kernel", "+", "MESSAGE_ID="+unitProcessExitMessageID).Return("", "", 0)
+	require.NoError(events.Export(context.Background(), &status))
+	require.Nil(status.Events)
+}
+
+func TestParseOOMKill(t *testing.T) {
+	tests := []struct {
+		name          string
+		message       string
+		wantUnit      *string
+		wantContainer *string
+		wantMessage   string
+	}{
+		{
+			name:        "service exceeding its memory limit",
+			message:     "oom-kill:constraint=CONSTRAINT_MEMCG,nodemask=(null),cpuset=/,mems_allowed=0,oom_memcg=/system.slice/web.service,task_memcg=/system.slice/web.service,task=node,pid=812,uid=0",
+			wantUnit:    lo.ToPtr("web.service"),
+			wantMessage: "the kernel killed process node (812), which exceeded the memory limit of cgroup /system.slice/web.service",
+		},
+		{
+			name:          "container of a unit on a device out of memory",
+			message:       "oom-kill:constraint=CONSTRAINT_NONE,nodemask=(null),cpuset=/,mems_allowed=0,global_oom,task_memcg=/system.slice/cache.service/libpod-payload-9a8b7c6d5e4f3a2b,task=redis-server,pid=977,uid=999",
+			wantUnit:      lo.ToPtr("cache.service"),
+			wantContainer: lo.ToPtr("9a8b7c6d5e4f3a2b"),
+			wantMessage:   "the kernel killed process redis-server (977), as the device ran out of memory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			event := parseOOMKill(tt.message)
+			require.Equal(v1alpha1.DeviceEventOOMKilled, event.Type)
+			require.Equal(tt.wantUnit, event.Unit)
+			require.Equal(tt.wantContainer, event.Container)
+			require.Equal(tt.wantMessage, event.Message)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -14,6 +15,7 @@ import (
 func newExporters(
 	resourceManager resource.Manager,
 	hookManager hook.Manager,
+	reportedManager reported.Manager,
 	executer executer.Executer,
 	log *log.PrefixLogger,
 ) []Exporter {
@@ -21,8 +23,14 @@ func newExporters(
 		newSystemD(executer),
 		newContainer(executer),
 		newSystemInfo(executer),
+		newCapabilities(executer, log),
+		newHardware(reportedManager, log),
+		newCrypto(log),
+		newTimeSync(executer, log),
+		newEvents(executer, log),
 		newResources(log, resourceManager),
 		newHooks(log, hookManager),
+		newReportedProperties(reportedManager),
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -15,6 +16,7 @@ import (
 func newExporters(
 	_ resource.Manager,
 	_ hook.Manager,
+	reportedManager reported.Manager,
 	executer executer.Executer,
 	log *log.PrefixLogger,
 ) []Exporter {
@@ -22,8 +24,14 @@ func newExporters(
 		newSystemD(executer),
 		newContainer(executer),
 		newSystemInfo(executer),
+		newCapabilities(executer, log),
+		newUnsupportedExporter(log, "hardware"),
+		newUnsupportedExporter(log, "crypto"),
+		newUnsupportedExporter(log, "time"),
+		newUnsupportedExporter(log, "events"),
 		newUnsupportedExporter(log, "resources"),
 		newUnsupportedExporter(log, "hooks"),
+		newReportedProperties(reportedManager),
 	}
 }
 
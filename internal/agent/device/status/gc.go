@@ -0,0 +1,32 @@
+package status
+
+import (
+	"context"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/gc"
+)
+
+var _ Exporter = (*GarbageCollection)(nil)
+
+// GarbageCollection reports the result of the last garbage collection of the
+// device, and passes the garbage collection policy of the spec on to the
+// collections.
+type GarbageCollection struct {
+	manager gc.Manager
+}
+
+func newGarbageCollection(manager gc.Manager) *GarbageCollection {
+	return &GarbageCollection{
+		manager: manager,
+	}
+}
+
+func (g *GarbageCollection) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	status.GarbageCollection = g.manager.Status()
+	return nil
+}
+
+func (g *GarbageCollection) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+	g.manager.SetSpec(spec.GarbageCollection, spec.Os)
+}
@@ -0,0 +1,286 @@
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	// HardwareRefreshInterval is the interval between two collections of the hardware facts.
+	HardwareRefreshInterval = time.Hour
+	// HardwareChangedAnnotation is the annotation of the device describing the
+	// last change of its hardware detected by the agent.
+	HardwareChangedAnnotation = "hardware.flightctl.io/changed"
+
+	// pciClassDisplay is the PCI base class of display controllers.
+	pciClassDisplay = "0x03"
+	// sectorSize is the unit of /sys/block/*/size.
+	sectorSize = 512
+)
+
+// virtual block devices which are not disks
+var virtualBlockDevicePrefixes = []string{"loop", "ram", "zram", "dm-", "md", "nbd", "sr"}
+
+var _ Exporter = (*Hardware)(nil)
+
+// Hardware collects the hardware facts of the device from procfs and sysfs.
+// Changes of the CPUs, memory, disks and network interfaces between two
+// collections are written to the HardwareChangedAnnotation of the device.
+type Hardware struct {
+	rootDir         string
+	reportedManager reported.Manager
+	log             *log.PrefixLogger
+	info            *v1alpha1.DeviceHardwareInfo
+	collectedAt     time.Time
+}
+
+func newHardware(reportedManager reported.Manager, log *log.PrefixLogger) *Hardware {
+	return &Hardware{
+		rootDir:         "/",
+		reportedManager: reportedManager,
+		log:             log,
+	}
+}
+
+// Export sets the hardware facts, collecting them again once they are older than HardwareRefreshInterval.
+func (h *Hardware) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	if h.info == nil || time.Since(h.collectedAt) >= HardwareRefreshInterval {
+		info := h.collect()
+		if h.info != nil {
+			if changes := hardwareChanges(h.info, info); len(changes) > 0 {
+				h.log.Infof("Detected a hardware change: %s", strings.Join(changes, "; "))
+				if err := h.reportedManager.SetAnnotation(HardwareChangedAnnotation, strings.Join(changes, "; ")); err != nil {
+					h.log.Warnf("Failed to annotate the hardware change: %v", err)
+				}
+			}
+		}
+		h.info = info
+		h.collectedAt = time.Now()
+	}
+	status.SystemInfo.Hardware = h.info
+	return nil
+}
+
+func (h *Hardware) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+// hardwareChanges describes the changes of the CPUs, memory, disks and network
+// interfaces between two collections of the hardware facts.
+func hardwareChanges(previous *v1alpha1.DeviceHardwareInfo, current *v1alpha1.DeviceHardwareInfo) []string {
+	changes := []string{}
+	if previous.Cpu.Cores != current.Cpu.Cores {
+		changes = append(changes, fmt.Sprintf("CPU cores changed from %d to %d", previous.Cpu.Cores, current.Cpu.Cores))
+	}
+	if previous.MemoryBytes != current.MemoryBytes {
+		changes = append(changes, fmt.Sprintf("memory changed from %d to %d bytes", previous.MemoryBytes, current.MemoryBytes))
+	}
+	diskName := func(disk v1alpha1.DeviceDiskInfo, _ int) string { return disk.Name }
+	changes = append(changes, addedRemoved("disk", lo.Map(previous.Disks, diskName), lo.Map(current.Disks, diskName))...)
+	interfaceName := func(nic v1alpha1.DeviceNetworkInterfaceInfo, _ int) string { return nic.Name }
+	changes = append(changes, addedRemoved("network interface", lo.Map(previous.NetworkInterfaces, interfaceName), lo.Map(current.NetworkInterfaces, interfaceName))...)
+	return changes
+}
+
+func addedRemoved(kind string, previous []string, current []string) []string {
+	changes := []string{}
+	removed, added := lo.Difference(previous, current)
+	for _, name := range removed {
+		changes = append(changes, fmt.Sprintf("%s %s removed", kind, name))
+	}
+	for _, name := range added {
+		changes = append(changes, fmt.Sprintf("%s %s added", kind, name))
+	}
+	return changes
+}
+
+// collect gathers the hardware facts. Facts which cannot be read are left
+// empty rather than failing the status update.
+func (h *Hardware) collect() *v1alpha1.DeviceHardwareInfo {
+	return &v1alpha1.DeviceHardwareInfo{
+		Cpu:               h.cpuInfo(),
+		MemoryBytes:       h.memoryBytes(),
+		Disks:             h.disks(),
+		NetworkInterfaces: h.networkInterfaces(),
+		GpuPresent:        h.gpuPresent(),
+		Vendor:            h.firmwareString("sys/class/dmi/id/sys_vendor"),
+		ProductName:       h.firmwareString("sys/class/dmi/id/product_name", "proc/device-tree/model"),
+		SerialNumber:      h.firmwareString("sys/class/dmi/id/product_serial", "proc/device-tree/serial-number"),
+	}
+}
+
+func (h *Hardware) path(elem ...string) string {
+	return filepath.Join(append([]string{h.rootDir}, elem...)...)
+}
+
+// readString returns the trimmed content of a file, or the empty string if it cannot be read.
+func (h *Hardware) readString(elem ...string) string {
+	contents, err := os.ReadFile(h.path(elem...))
+	if err != nil {
+		return ""
+	}
+	// device tree strings are NUL terminated
+	return strings.TrimSpace(string(bytes.TrimRight(contents, "\x00")))
+}
+
+func (h *Hardware) firmwareString(paths ...string) *string {
+	for _, p := range paths {
+		if value := h.readString(p); value != "" {
+			return lo.ToPtr(value)
+		}
+	}
+	return nil
+}
+
+func (h *Hardware) cpuInfo() v1alpha1.DeviceCPUInfo {
+	info := v1alpha1.DeviceCPUInfo{}
+
+	file, err := os.Open(h.path("proc/cpuinfo"))
+	if err != nil {
+		h.log.Debugf("Failed to read cpuinfo: %v", err)
+		info.Cores = int32(runtime.NumCPU())
+		return info
+	}
+	defer file.Close()
+
+	var cores int32
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "processor":
+			cores++
+		case "model name":
+			if info.Model == nil && value != "" {
+				info.Model = lo.ToPtr(value)
+			}
+		case "vendor_id", "CPU implementer":
+			if info.Vendor == nil && value != "" {
+				info.Vendor = lo.ToPtr(value)
+			}
+		}
+	}
+	if cores == 0 {
+		cores = int32(runtime.NumCPU())
+	}
+	info.Cores = cores
+	return info
+}
+
+func (h *Hardware) memoryBytes() int64 {
+	file, err := os.Open(h.path("proc/meminfo"))
+	if err != nil {
+		h.log.Debugf("Failed to read meminfo: %v", err)
+		return 0
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kb * 1024
+		}
+	}
+	return 0
+}
+
+func (h *Hardware) disks() []v1alpha1.DeviceDiskInfo {
+	disks := []v1alpha1.DeviceDiskInfo{}
+	entries, err := os.ReadDir(h.path("sys/block"))
+	if err != nil {
+		h.log.Debugf("Failed to list block devices: %v", err)
+		return disks
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if lo.SomeBy(virtualBlockDevicePrefixes, func(prefix string) bool { return strings.HasPrefix(name, prefix) }) {
+			continue
+		}
+		sectors, err := strconv.ParseInt(h.readString("sys/block", name, "size"), 10, 64)
+		if err != nil || sectors == 0 {
+			continue
+		}
+		disk := v1alpha1.DeviceDiskInfo{
+			Name:      name,
+			SizeBytes: sectors * sectorSize,
+		}
+		if model := h.readString("sys/block", name, "device/model"); model != "" {
+			disk.Model = lo.ToPtr(model)
+		}
+		serial := h.readString("sys/block", name, "device/serial")
+		if serial == "" {
+			// nvme and some virtio disks expose the serial on the block device itself
+			serial = h.readString("sys/block", name, "serial")
+		}
+		if serial != "" {
+			disk.SerialNumber = lo.ToPtr(serial)
+		}
+		if rotational := h.readString("sys/block", name, "queue/rotational"); rotational != "" {
+			disk.Rotational = lo.ToPtr(rotational == "1")
+		}
+		disks = append(disks, disk)
+	}
+	return disks
+}
+
+func (h *Hardware) networkInterfaces() []v1alpha1.DeviceNetworkInterfaceInfo {
+	nics := []v1alpha1.DeviceNetworkInterfaceInfo{}
+	entries, err := os.ReadDir(h.path("sys/class/net"))
+	if err != nil {
+		h.log.Debugf("Failed to list network interfaces: %v", err)
+		return nics
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		// virtual interfaces (loopback, bridges, veths...) have no backing device
+		if _, err := os.Stat(h.path("sys/class/net", name, "device")); err != nil {
+			continue
+		}
+		mac := h.readString("sys/class/net", name, "address")
+		if mac == "" {
+			continue
+		}
+		nics = append(nics, v1alpha1.DeviceNetworkInterfaceInfo{Name: name, MacAddress: mac})
+	}
+	sort.Slice(nics, func(i, j int) bool { return nics[i].Name < nics[j].Name })
+	return nics
+}
+
+func (h *Hardware) gpuPresent() bool {
+	entries, err := os.ReadDir(h.path("sys/bus/pci/devices"))
+	if err == nil {
+		for _, entry := range entries {
+			if strings.HasPrefix(h.readString("sys/bus/pci/devices", entry.Name(), "class"), pciClassDisplay) {
+				return true
+			}
+		}
+	}
+	// devices without PCI (e.g. SoCs) expose their GPU as a DRM render node
+	matches, _ := filepath.Glob(h.path("sys/class/drm/renderD*"))
+	return len(matches) > 0
+}
@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+const testCPUInfo = `processor	: 0
+vendor_id	: GenuineIntel
+model name	: Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz
+
+processor	: 1
+vendor_id	: GenuineIntel
+model name	: Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz
+`
+
+func writeTestFile(t *testing.T, root string, path string, contents string) {
+	t.Helper()
+	path = filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+}
+
+func TestHardwareExport(t *testing.T) {
+	require := require.New(t)
+	root := t.TempDir()
+
+	writeTestFile(t, root, "proc/cpuinfo", testCPUInfo)
+	writeTestFile(t, root, "proc/meminfo", "MemTotal:        8048576 kB\nMemFree:         1024 kB\n")
+	writeTestFile(t, root, "sys/block/sda/size", "1953525168\n")
+	writeTestFile(t, root, "sys/block/sda/device/model", "ST1000DM010\n")
+	writeTestFile(t, root, "sys/block/sda/device/serial", "Z9A1B2C3\n")
+	writeTestFile(t, root, "sys/block/sda/queue/rotational", "1\n")
+	writeTestFile(t, root, "sys/block/nvme0n1/size", "1000215216\n")
+	writeTestFile(t, root, "sys/block/nvme0n1/serial", "S4EWNX0N\n")
+	writeTestFile(t, root, "sys/block/nvme0n1/queue/rotational", "0\n")
+	writeTestFile(t, root, "sys/block/loop0/size", "100\n")
+	writeTestFile(t, root, "sys/class/net/eth0/address", "52:54:00:12:34:56\n")
+	writeTestFile(t, root, "sys/class/net/eth0/device/vendor", "0x8086\n")
+	writeTestFile(t, root, "sys/class/net/lo/address", "00:00:00:00:00:00\n")
+	writeTestFile(t, root, "sys/bus/pci/devices/0000:00:02.0/class", "0x030000\n")
+	writeTestFile(t, root, "sys/class/dmi/id/sys_vendor", "LENOVO\n")
+	writeTestFile(t, root, "sys/class/dmi/id/product_serial", "PF1ABCDE\n")
+	writeTestFile(t, root, "proc/device-tree/model", "Raspberry Pi 4 Model B\x00")
+
+	reportedManager := reported.NewManager(reported.DefaultSocketPath, "", nil, log.NewPrefixLogger("test"))
+	hardware := newHardware(reportedManager, log.NewPrefixLogger("test"))
+	hardware.rootDir = root
+
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(hardware.Export(context.Background(), &status))
+
+	require.Equal(&v1alpha1.DeviceHardwareInfo{
+		Cpu: v1alpha1.DeviceCPUInfo{
+			Model:  lo.ToPtr("Intel(R) Xeon(R) CPU E5-2680 v4 @ 2.40GHz"),
+			Vendor: lo.ToPtr("GenuineIntel"),
+			Cores:  2,
+		},
+		MemoryBytes: 8048576 * 1024,
+		Disks: []v1alpha1.DeviceDiskInfo{
+			{Name: "nvme0n1", SizeBytes: 1000215216 * 512, SerialNumber: lo.ToPtr("S4EWNX0N"), Rotational: lo.ToPtr(false)},
+			{Name: "sda", SizeBytes: 1953525168 * 512, Model: lo.ToPtr("ST1000DM010"), SerialNumber: lo.ToPtr("Z9A1B2C3"), Rotational: lo.ToPtr(true)},
+		},
+		NetworkInterfaces: []v1alpha1.DeviceNetworkInterfaceInfo{{Name: "eth0", MacAddress: "52:54:00:12:34:56"}},
+		GpuPresent:        true,
+		Vendor:            lo.ToPtr("LENOVO"),
+		ProductName:       lo.ToPtr("Raspberry Pi 4 Model B"),
+		SerialNumber:      lo.ToPtr("PF1ABCDE"),
+	}, status.SystemInfo.Hardware)
+
+	// the facts are cached until the refresh interval elapses
+	writeTestFile(t, root, "proc/meminfo", "MemTotal:        1024 kB\n")
+	require.NoError(hardware.Export(context.Background(), &status))
+	require.Equal(int64(8048576*1024), status.SystemInfo.Hardware.MemoryBytes)
+	require.Empty(reportedManager.Annotations())
+
+	// changes found by the next collection are annotated
+	require.NoError(os.RemoveAll(filepath.Join(root, "sys/block/sda")))
+	hardware.collectedAt = time.Time{}
+	require.NoError(hardware.Export(context.Background(), &status))
+	require.Equal(map[string]string{
+		HardwareChangedAnnotation: "memory changed from 8241741824 to 1048576 bytes; disk sda removed",
+	}, reportedManager.Annotations())
+
+	// hardware facts do not count as identifying system information
+	require.True(status.SystemInfo.IsEmpty())
+}
+
+func TestHardwareExportMissingFacts(t *testing.T) {
+	require := require.New(t)
+
+	hardware := newHardware(nil, log.NewPrefixLogger("test"))
+	hardware.rootDir = t.TempDir()
+
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(hardware.Export(context.Background(), &status))
+	require.NotZero(status.SystemInfo.Hardware.Cpu.Cores)
+	require.Empty(status.SystemInfo.Hardware.Disks)
+	require.Empty(status.SystemInfo.Hardware.NetworkInterfaces)
+	require.False(status.SystemInfo.Hardware.GpuPresent)
+	require.Nil(status.SystemInfo.Hardware.SerialNumber)
+}
@@ -0,0 +1,49 @@
+package status
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/geolocation"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+var _ Exporter = (*Location)(nil)
+
+// Location reports the location of the device, locating it again once per interval.
+type Location struct {
+	locator   geolocation.Locator
+	interval  time.Duration
+	log       *log.PrefixLogger
+	location  *v1alpha1.DeviceLocation
+	locatedAt time.Time
+}
+
+func newLocation(locator geolocation.Locator, interval time.Duration, log *log.PrefixLogger) *Location {
+	return &Location{
+		locator:  locator,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Export sets the last known location of the device. Failing to locate the
+// device is logged and retried after the interval, so that a GPS receiver
+// without a fix does not delay every status update.
+func (l *Location) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	if l.locatedAt.IsZero() || time.Since(l.locatedAt) >= l.interval {
+		l.locatedAt = time.Now()
+		location, err := l.locator.Locate(ctx)
+		if err != nil {
+			l.log.Warnf("Failed to locate device: %v", err)
+		} else {
+			l.location = location
+		}
+	}
+	status.Location = l.location
+	return nil
+}
+
+func (l *Location) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
@@ -0,0 +1,51 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+type testLocator struct {
+	locations []*v1alpha1.DeviceLocation
+	calls     int
+}
+
+func (l *testLocator) Locate(context.Context) (*v1alpha1.DeviceLocation, error) {
+	l.calls++
+	location := l.locations[l.calls-1]
+	if location == nil {
+		return nil, errors.New("no fix")
+	}
+	return location, nil
+}
+
+func TestLocationExport(t *testing.T) {
+	require := require.New(t)
+	first := &v1alpha1.DeviceLocation{Latitude: 48.1173, Longitude: 11.5167, Source: v1alpha1.DeviceLocationSourceGPS}
+	second := &v1alpha1.DeviceLocation{Latitude: 48.2, Longitude: 11.6, Source: v1alpha1.DeviceLocationSourceGPS}
+	locator := &testLocator{locations: []*v1alpha1.DeviceLocation{first, nil, second}}
+	location := newLocation(locator, time.Hour, log.NewPrefixLogger("test"))
+
+	// the location is cached for the interval
+	status := v1alpha1.NewDeviceStatus()
+	require.NoError(location.Export(context.Background(), &status))
+	require.NoError(location.Export(context.Background(), &status))
+	require.Equal(first, status.Location)
+	require.Equal(1, locator.calls)
+
+	// the last known location is kept when locating fails
+	location.locatedAt = time.Now().Add(-time.Hour)
+	require.NoError(location.Export(context.Background(), &status))
+	require.Equal(first, status.Location)
+
+	location.locatedAt = time.Now().Add(-time.Hour)
+	require.NoError(location.Export(context.Background(), &status))
+	require.Equal(second, status.Location)
+	require.Equal(3, locator.calls)
+}
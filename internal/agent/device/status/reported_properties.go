@@ -0,0 +1,40 @@
+package status
+
+import (
+	"context"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
+)
+
+var _ Exporter = (*ReportedProperties)(nil)
+
+// ReportedProperties collects the key/value properties reported by applications
+// and the annotations of the device.
+type ReportedProperties struct {
+	manager reported.Manager
+}
+
+func newReportedProperties(manager reported.Manager) *ReportedProperties {
+	return &ReportedProperties{
+		manager: manager,
+	}
+}
+
+// Export sets the properties currently reported by applications and the
+// annotations of the device. The annotations are always set, even if empty, as
+// the service keeps those of agents which do not report them.
+func (r *ReportedProperties) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	annotations := r.manager.Annotations()
+	status.Annotations = &annotations
+	props := r.manager.List()
+	if len(props) == 0 {
+		status.Applications.ReportedProperties = nil
+		return nil
+	}
+	status.Applications.ReportedProperties = &props
+	return nil
+}
+
+func (r *ReportedProperties) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
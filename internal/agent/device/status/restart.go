@@ -0,0 +1,33 @@
+package status
+
+import (
+	"context"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/version"
+)
+
+var _ Exporter = (*AgentRestart)(nil)
+
+// AgentRestart reports the last unexpected restart of the agent since the
+// device booted, which the watchdog of the agent found when it started.
+type AgentRestart struct {
+	restart *v1alpha1.DeviceAgentRestart
+}
+
+func newAgentRestart(restart *v1alpha1.DeviceAgentRestart) *AgentRestart {
+	return &AgentRestart{
+		restart: restart,
+	}
+}
+
+func (a *AgentRestart) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	if status.Agent == nil {
+		status.Agent = &v1alpha1.DeviceAgentStatus{Version: version.Get().GitVersion}
+	}
+	status.Agent.LastRestart = a.restart
+	return nil
+}
+
+func (a *AgentRestart) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
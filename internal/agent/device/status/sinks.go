@@ -0,0 +1,75 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/mqtt"
+)
+
+// Sink receives the device status each time the agent pushes it, so that
+// site-local monitoring systems which cannot query the service can read it.
+type Sink interface {
+	// Name identifies the sink in the logs.
+	Name() string
+	// Publish writes the latest status of the device.
+	Publish(ctx context.Context, device *v1alpha1.Device) error
+}
+
+var _ Sink = (*fileSink)(nil)
+
+type fileSink struct {
+	path   string
+	writer fileio.Writer
+}
+
+// NewFileSink creates a sink replacing the JSON file at the path with the
+// device and its latest status.
+func NewFileSink(path string, writer fileio.Writer) Sink {
+	return &fileSink{path: path, writer: writer}
+}
+
+func (s *fileSink) Name() string {
+	return "file " + s.path
+}
+
+func (s *fileSink) Publish(_ context.Context, device *v1alpha1.Device) error {
+	contents, err := json.Marshal(device)
+	if err != nil {
+		return err
+	}
+	// the file is written atomically, so readers never see a partial status
+	return s.writer.WriteFile(s.path, contents, fileio.DefaultFilePermissions)
+}
+
+var _ Sink = (*mqttSink)(nil)
+
+type mqttSink struct {
+	client *mqtt.Client
+	topic  string
+}
+
+// NewMQTTSink creates a sink publishing the device and its latest status as
+// a retained JSON message to the topic, so subscribers receive the latest
+// status as soon as they subscribe.
+func NewMQTTSink(client *mqtt.Client, topic string) Sink {
+	return &mqttSink{client: client, topic: topic}
+}
+
+func (s *mqttSink) Name() string {
+	return "MQTT topic " + s.topic
+}
+
+func (s *mqttSink) Publish(ctx context.Context, device *v1alpha1.Device) error {
+	contents, err := json.Marshal(device)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Publish(ctx, s.topic, contents, true); err != nil {
+		return fmt.Errorf("publishing device status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(tmpDir))
+
+	name := "device"
+	status := v1alpha1.NewDeviceStatus()
+	status.Summary.Status = v1alpha1.DeviceSummaryStatusOnline
+	device := &v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: &name}, Status: &status}
+
+	sink := NewFileSink("/run/flightctl/status.json", readWriter)
+	require.NoError(sink.Publish(context.Background(), device))
+
+	contents, err := os.ReadFile(filepath.Join(tmpDir, "run/flightctl/status.json"))
+	require.NoError(err)
+	var written v1alpha1.Device
+	require.NoError(json.Unmarshal(contents, &written))
+	require.Equal(name, *written.Metadata.Name)
+	require.Equal(v1alpha1.DeviceSummaryStatusOnline, written.Status.Summary.Status)
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/compliance"
+	"github.com/flightctl/flightctl/internal/agent/device/gc"
+	"github.com/flightctl/flightctl/internal/agent/device/geolocation"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -20,14 +25,38 @@ func NewManager(
 	deviceName string,
 	resourceManager resource.Manager,
 	hookManager hook.Manager,
+	reportedManager reported.Manager,
 	executer executer.Executer,
+	acceleratorStatus bool,
+	locator geolocation.Locator,
+	locateInterval time.Duration,
+	complianceManager compliance.Manager,
+	gcManager gc.Manager,
+	lastRestart *v1alpha1.DeviceAgentRestart,
+	sinks []Sink,
 	log *log.PrefixLogger,
 ) *StatusManager {
-	exporters := newExporters(resourceManager, hookManager, executer, log)
+	exporters := newExporters(resourceManager, hookManager, reportedManager, executer, log)
+	if acceleratorStatus {
+		exporters = append(exporters, newAccelerators(executer, log))
+	}
+	if locator != nil {
+		exporters = append(exporters, newLocation(locator, locateInterval, log))
+	}
+	if complianceManager != nil {
+		exporters = append(exporters, newCompliance(complianceManager))
+	}
+	if gcManager != nil {
+		exporters = append(exporters, newGarbageCollection(gcManager))
+	}
+	if lastRestart != nil {
+		exporters = append(exporters, newAgentRestart(lastRestart))
+	}
 	status := v1alpha1.NewDeviceStatus()
 	return &StatusManager{
 		deviceName: deviceName,
 		exporters:  exporters,
+		sinks:      sinks,
 		device: &v1alpha1.Device{
 			Metadata: v1alpha1.ObjectMeta{
 				Name: &deviceName,
@@ -43,6 +72,7 @@ type StatusManager struct {
 	deviceName       string
 	managementClient client.Management
 	exporters        []Exporter
+	sinks            []Sink
 	log              *log.PrefixLogger
 	device           *v1alpha1.Device
 }
@@ -110,14 +140,37 @@ func (m *StatusManager) Sync(ctx context.Context) error {
 		return err
 	}
 	if m.managementClient == nil {
+		m.publish(ctx)
 		return nil
 	}
-	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device); err != nil {
+	if err := m.push(ctx); err != nil {
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
 	return nil
 }
 
+// push sends the device status to the management service.
+func (m *StatusManager) push(ctx context.Context) error {
+	// the service compares the time of the device clock with the time it
+	// receives the status at, so the time is taken when sending the status
+	if m.device.Status.Time != nil {
+		m.device.Status.Time.ReportedAt = time.Now().UTC()
+	}
+	// the sinks receive the status even while the service is unreachable
+	m.publish(ctx)
+	return m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device)
+}
+
+// publish writes the device status to the sinks. A failing sink is logged
+// rather than failing the push, since the sinks only serve local monitoring.
+func (m *StatusManager) publish(ctx context.Context) {
+	for _, sink := range m.sinks {
+		if err := sink.Publish(ctx, m.device); err != nil {
+			m.log.Warnf("Failed to publish device status to %s: %v", sink.Name(), err)
+		}
+	}
+}
+
 func (m *StatusManager) UpdateCondition(ctx context.Context, condition v1alpha1.Condition) error {
 	if m.managementClient == nil {
 		return fmt.Errorf("management client not set")
@@ -128,7 +181,7 @@ func (m *StatusManager) UpdateCondition(ctx context.Context, condition v1alpha1.
 		return nil
 	}
 
-	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device); err != nil {
+	if err := m.push(ctx); err != nil {
 		return fmt.Errorf("failed to update device status: %w", err)
 	}
 	return nil
@@ -150,7 +203,7 @@ func (m *StatusManager) Update(ctx context.Context, updateFuncs ...UpdateStatusF
 	}
 
 	// TODO: handle retries
-	if err := m.managementClient.UpdateDeviceStatus(ctx, m.deviceName, *m.device); err != nil {
+	if err := m.push(ctx); err != nil {
 		return nil, fmt.Errorf("failed to update device status: %w", err)
 	}
 
@@ -178,3 +231,98 @@ func SetOSImage(osStatus v1alpha1.DeviceOSStatus) UpdateStatusFn {
 		return nil
 	}
 }
+
+// SetProvenance sets the images of the rendered version the device applied,
+// or clears them if the service recorded none.
+func SetProvenance(provenance *v1alpha1.DeviceProvenanceStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.Provenance = provenance
+		return nil
+	}
+}
+
+// SetAgent sets the version and update of the agent, keeping the last restart
+// of the agent reported by its exporter.
+func SetAgent(agentStatus v1alpha1.DeviceAgentStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		if status.Agent != nil {
+			agentStatus.LastRestart = status.Agent.LastRestart
+		}
+		status.Agent = &agentStatus
+		return nil
+	}
+}
+
+// SetEncryption sets the status of the encrypted volumes, or clears it if
+// encryptionStatus is nil.
+func SetEncryption(encryptionStatus *v1alpha1.DeviceEncryptionStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.Encryption = encryptionStatus
+		return nil
+	}
+}
+
+// SetApplicationUpdates sets the outcomes of the last update of the
+// applications of the spec, or clears them if there are none.
+func SetApplicationUpdates(updates map[string]v1alpha1.ApplicationUpdateStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		if len(updates) == 0 {
+			status.Applications.Updates = nil
+			return nil
+		}
+		status.Applications.Updates = &updates
+		return nil
+	}
+}
+
+// SetLastAction sets the progress of the last action the agent received.
+func SetLastAction(actionStatus v1alpha1.DeviceActionStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.LastAction = &actionStatus
+		return nil
+	}
+}
+
+// SetAdoption sets the inventory of the existing state of the device the
+// agent adopted.
+func SetAdoption(adoptionStatus v1alpha1.DeviceAdoptionStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.Adoption = &adoptionStatus
+		return nil
+	}
+}
+
+// SetDrift sets the drift of the device from its spec found by the last
+// check of the agent.
+func SetDrift(driftStatus v1alpha1.DeviceDriftStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.Drift = &driftStatus
+		return nil
+	}
+}
+
+// SetCheck sets the changes the agent would make to apply the rendered spec
+// it only checks.
+func SetCheck(checkStatus v1alpha1.DeviceCheckStatus) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.Check = &checkStatus
+		return nil
+	}
+}
+
+// SetUpdateProgress sets the progress of the update of the device, which is
+// unset once the device runs the rendered version.
+func SetUpdateProgress(progress *v1alpha1.DeviceUpdateProgress) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.UpdateProgress = progress
+		return nil
+	}
+}
+
+// SetUpdateHistory sets the last updates of the device and their averages.
+func SetUpdateHistory(history v1alpha1.DeviceUpdateHistory) UpdateStatusFn {
+	return func(status *v1alpha1.DeviceStatus) error {
+		status.UpdateHistory = &history
+		return nil
+	}
+}
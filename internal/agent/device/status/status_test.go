@@ -6,6 +6,7 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/reported"
 	"github.com/flightctl/flightctl/internal/agent/device/resource"
 	"github.com/flightctl/flightctl/pkg/executer"
 	"github.com/flightctl/flightctl/pkg/log"
@@ -29,9 +30,10 @@ func BenchmarkAggregateDeviceStatus(b *testing.B) {
 	execMock.EXPECT().LookPath("podman").Return("/usr/bin/podman", nil).AnyTimes()
 	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/crictl", "ps", "-a", "--output", "json").Return(crioListResult, "", 0).AnyTimes()
 	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "ps", "-a", "--format", "json").Return(podmanListResult, "", 0).AnyTimes()
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/podman", "container", "inspect", "--format", "json", "id1", "id2").Return(podmanInspectResult, "", 0).AnyTimes()
 	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/systemctl", "list-units", "--all", "--output", "json", "crio.service").Return(systemdUnitListResult, "", 0).AnyTimes()
 
-	manager := NewManager("test", resourceManagerMock, hookManagerMock, execMock, log)
+	manager := NewManager("test", resourceManagerMock, hookManagerMock, reported.NewManager(reported.DefaultSocketPath, "", nil, log), execMock, false, nil, 0, nil, nil, nil, nil, log)
 	systemdPatterns := []string{"crio.service"}
 
 	spec := &v1alpha1.RenderedDeviceSpec{
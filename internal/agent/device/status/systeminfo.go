@@ -38,11 +38,9 @@ func (s *SystemInfo) Export(ctx context.Context, status *v1alpha1.DeviceStatus)
 		return fmt.Errorf("getting boot ID: %w", err)
 	}
 
-	status.SystemInfo = v1alpha1.DeviceSystemInfo{
-		Architecture:    runtime.GOARCH,
-		OperatingSystem: runtime.GOOS,
-		BootID:          bootID,
-	}
+	status.SystemInfo.Architecture = runtime.GOARCH
+	status.SystemInfo.OperatingSystem = runtime.GOOS
+	status.SystemInfo.BootID = bootID
 
 	bootcInfo, err := s.bootcClient.Status(ctx)
 	if err != nil {
@@ -0,0 +1,96 @@
+package status
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	chronycCommand        = "/usr/bin/chronyc"
+	chronycCommandTimeout = 10 * time.Second
+
+	// chronyNotSynchronised is the leap status of a clock chrony does not synchronize
+	chronyNotSynchronised = "Not synchronised"
+)
+
+var _ Exporter = (*TimeSync)(nil)
+
+// TimeSync collects the state of the time synchronization of the device from chrony.
+type TimeSync struct {
+	exec executer.Executer
+	log  *log.PrefixLogger
+}
+
+func newTimeSync(exec executer.Executer, log *log.PrefixLogger) *TimeSync {
+	return &TimeSync{
+		exec: exec,
+		log:  log,
+	}
+}
+
+// Export sets the state of the time synchronization. The clock of a device
+// without chrony is reported as not synchronized, so that the service still
+// compares it with its own clock.
+func (t *TimeSync) Export(ctx context.Context, status *v1alpha1.DeviceStatus) error {
+	timeStatus, err := t.tracking(ctx)
+	if err != nil {
+		t.log.Debugf("Failed to query chrony: %v", err)
+		timeStatus = &v1alpha1.DeviceTimeStatus{}
+	}
+	timeStatus.ReportedAt = time.Now().UTC()
+	status.Time = timeStatus
+	return nil
+}
+
+func (t *TimeSync) SetProperties(spec *v1alpha1.RenderedDeviceSpec) {
+}
+
+func (t *TimeSync) tracking(ctx context.Context) (*v1alpha1.DeviceTimeStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, chronycCommandTimeout)
+	defer cancel()
+	stdout, stderr, exitCode := t.exec.ExecuteWithContext(ctx, chronycCommand, "-c", "tracking")
+	if exitCode != 0 {
+		return nil, fmt.Errorf("%s: exit code %d: %s", chronycCommand, exitCode, stderr)
+	}
+	return parseChronyTracking(stdout)
+}
+
+// parseChronyTracking parses the CSV output of chronyc -c tracking, such as
+// A29FC87B,time.cloudflare.com,4,1721921727.311440821,-0.000011464,...,Normal
+func parseChronyTracking(output string) (*v1alpha1.DeviceTimeStatus, error) {
+	record, err := csv.NewReader(strings.NewReader(output)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s output: %w", chronycCommand, err)
+	}
+	if len(record) != 14 {
+		return nil, fmt.Errorf("parsing %s output: unexpected number of fields %d", chronycCommand, len(record))
+	}
+	stratum, err := strconv.ParseInt(record[2], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s stratum: %w", chronycCommand, err)
+	}
+	// chrony reports the correction of the system clock, positive if it is behind
+	correction, err := strconv.ParseFloat(record[4], 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s system time: %w", chronycCommand, err)
+	}
+
+	timeStatus := &v1alpha1.DeviceTimeStatus{
+		Synchronized:  record[13] != chronyNotSynchronised && record[0] != "00000000",
+		Stratum:       lo.ToPtr(int32(stratum)),
+		OffsetSeconds: lo.ToPtr(-correction),
+	}
+	if record[1] != "" {
+		timeStatus.Source = lo.ToPtr(record[1])
+	}
+	return timeStatus, nil
+}
@@ -0,0 +1,66 @@
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTimeSyncExport(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdout   string
+		exitCode int
+		want     *v1alpha1.DeviceTimeStatus
+	}{
+		{
+			name:   "synchronized clock behind NTP time",
+			stdout: "A29FC87B,time.cloudflare.com,4,1721921727.311440821,0.000011464,0.000005069,0.000013976,-8.942,-0.002,0.049,0.004408830,0.000715974,1025.8,Normal\n",
+			want: &v1alpha1.DeviceTimeStatus{
+				Synchronized:  true,
+				Source:        lo.ToPtr("time.cloudflare.com"),
+				Stratum:       lo.ToPtr(int32(4)),
+				OffsetSeconds: lo.ToPtr(-0.000011464),
+			},
+		},
+		{
+			name:   "unsynchronized clock",
+			stdout: "00000000,,0,0.000000000,0.000000000,0.000000000,0.000000000,0.000,0.000,0.000,1.000000000,1.000000000,0.0,Not synchronised\n",
+			want: &v1alpha1.DeviceTimeStatus{
+				Synchronized:  false,
+				Stratum:       lo.ToPtr(int32(0)),
+				OffsetSeconds: lo.ToPtr(0.0),
+			},
+		},
+		{
+			name:     "chronyd not running",
+			exitCode: 1,
+			want:     &v1alpha1.DeviceTimeStatus{},
+		},
+		{
+			name:   "unexpected output",
+			stdout: "506 Cannot talk to daemon\n",
+			want:   &v1alpha1.DeviceTimeStatus{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			execMock := executer.NewMockExecuter(ctrl)
+			execMock.EXPECT().ExecuteWithContext(gomock.Any(), chronycCommand, "-c", "tracking").Return(tt.stdout, "", tt.exitCode)
+
+			status := v1alpha1.NewDeviceStatus()
+			require.NoError(newTimeSync(execMock, log.NewPrefixLogger("test")).Export(context.Background(), &status))
+			require.False(status.Time.ReportedAt.IsZero())
+			status.Time.ReportedAt = tt.want.ReportedAt
+			require.Equal(tt.want, status.Time)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+)
+
+const (
+	chronycCommand   = "/usr/bin/chronyc"
+	chronyConfigFile = "/etc/chrony.conf"
+	chronyService    = "chronyd.service"
+	// chronySourcesDir is read by the sourcedir directive, whose sources
+	// chrony reloads without restarting
+	chronySourcesDir  = "/etc/chrony.d"
+	chronySourcesFile = chronySourcesDir + "/flightctl.sources"
+
+	timeSyncCommandTimeout = 30 * time.Second
+)
+
+// TimeSyncController configures chrony with the NTP sources of the device
+// spec. The sources are added to those of the chrony configuration of the OS
+// image, which the controller leaves untouched but for the sourcedir directive
+// reading the sources it manages.
+type TimeSyncController struct {
+	exec       executer.Executer
+	readWriter fileio.ReadWriter
+	systemd    *client.Systemd
+	log        *log.PrefixLogger
+}
+
+func NewTimeSyncController(
+	exec executer.Executer,
+	readWriter fileio.ReadWriter,
+	log *log.PrefixLogger,
+) *TimeSyncController {
+	return &TimeSyncController{
+		exec:       exec,
+		readWriter: readWriter,
+		systemd:    client.NewSystemd(exec),
+		log:        log,
+	}
+}
+
+// Sync writes the NTP sources of the desired spec and has chrony reload them.
+// The sources are removed once the spec no longer sets any.
+func (c *TimeSyncController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.log.Debug("Syncing time synchronization")
+	defer c.log.Debug("Finished syncing time synchronization")
+
+	sources, err := chronySources(desired.Time)
+	if err != nil {
+		return err
+	}
+	exists, err := c.readWriter.FileExists(chronySourcesFile)
+	if err != nil {
+		return err
+	}
+	if !exists && sources == "" {
+		return nil
+	}
+	if exists {
+		current, err := c.readWriter.ReadFile(chronySourcesFile)
+		if err != nil {
+			return err
+		}
+		if string(current) == sources {
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeSyncCommandTimeout)
+	defer cancel()
+	if sources == "" {
+		c.log.Info("Removing the NTP sources of the device spec")
+		if err := c.readWriter.RemoveFile(chronySourcesFile); err != nil {
+			return err
+		}
+		return c.reloadSources(ctx)
+	}
+
+	restart, err := c.ensureSourceDir()
+	if err != nil {
+		return err
+	}
+	c.log.Info("Configuring the NTP sources of the device spec")
+	if err := c.readWriter.WriteFile(chronySourcesFile, []byte(sources), 0644); err != nil {
+		return err
+	}
+	if restart {
+		// chronyd only reads new directives when starting
+		return c.systemd.Restart(ctx, chronyService)
+	}
+	return c.reloadSources(ctx)
+}
+
+// ensureSourceDir adds the sourcedir directive of the managed sources to the
+// chrony configuration if it is missing, and returns whether it was added.
+func (c *TimeSyncController) ensureSourceDir() (bool, error) {
+	config, err := c.readWriter.ReadFile(chronyConfigFile)
+	if err != nil {
+		return false, fmt.Errorf("reading chrony configuration, is chrony installed?: %w", err)
+	}
+	for _, line := range strings.Split(string(config), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "sourcedir" && strings.TrimSuffix(fields[1], "/") == chronySourcesDir {
+			return false, nil
+		}
+	}
+
+	updated := string(config)
+	if updated != "" && !strings.HasSuffix(updated, "\n") {
+		updated += "\n"
+	}
+	updated += "\n# NTP sources of the flightctl device spec\nsourcedir " + chronySourcesDir + "\n"
+	if err := c.readWriter.WriteFile(chronyConfigFile, []byte(updated), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *TimeSyncController) reloadSources(ctx context.Context) error {
+	_, stderr, exitCode := c.exec.ExecuteWithContext(ctx, chronycCommand, "reload", "sources")
+	if exitCode != 0 {
+		return fmt.Errorf("reloading NTP sources: %s: exit code %d: %s", chronycCommand, exitCode, stderr)
+	}
+	return nil
+}
+
+// chronySources renders the NTP sources as chrony directives. The sources are
+// validated by the service, but are checked again since they are written into
+// the chrony configuration.
+func chronySources(timeSpec *v1alpha1.DeviceTimeSpec) (string, error) {
+	if timeSpec == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	write := func(directive string, hosts []string) error {
+		for _, host := range hosts {
+			if host == "" || strings.ContainsAny(host, " \t\r\n#") {
+				return fmt.Errorf("invalid NTP source %q", host)
+			}
+			fmt.Fprintf(&b, "%s %s iburst\n", directive, host)
+		}
+		return nil
+	}
+	if err := write("server", lo.FromPtr(timeSpec.Servers)); err != nil {
+		return "", err
+	}
+	if err := write("pool", lo.FromPtr(timeSpec.Pools)); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
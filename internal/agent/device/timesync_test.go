@@ -0,0 +1,82 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/executer"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testChronyConfig = "pool 2.fedora.pool.ntp.org iburst\nsourcedir /run/chrony-dhcp\n"
+
+func newTestTimeSyncController(t *testing.T) (*TimeSyncController, fileio.ReadWriter, *executer.MockExecuter) {
+	ctrl := gomock.NewController(t)
+	execMock := executer.NewMockExecuter(ctrl)
+	readWriter := fileio.NewReadWriter()
+	readWriter.SetRootdir(t.TempDir())
+	return NewTimeSyncController(execMock, readWriter, flightlog.NewPrefixLogger("")), readWriter, execMock
+}
+
+func desiredTime(timeSpec *v1alpha1.DeviceTimeSpec) *v1alpha1.RenderedDeviceSpec {
+	return &v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Time: timeSpec}
+}
+
+func TestTimeSyncConfiguresSources(t *testing.T) {
+	require := require.New(t)
+	c, readWriter, execMock := newTestTimeSyncController(t)
+	require.NoError(readWriter.WriteFile(chronyConfigFile, []byte(testChronyConfig), 0644))
+
+	// the sourcedir directive is added once, which requires a restart
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), "/usr/bin/systemctl", "restart", chronyService).Return("", "", 0)
+	timeSpec := &v1alpha1.DeviceTimeSpec{Servers: &[]string{"ntp1.example.com"}, Pools: &[]string{"pool.example.com"}}
+	require.NoError(c.Sync(context.Background(), desiredTime(timeSpec)))
+
+	config, err := readWriter.ReadFile(chronyConfigFile)
+	require.NoError(err)
+	require.Equal(testChronyConfig+"\n# NTP sources of the flightctl device spec\nsourcedir /etc/chrony.d\n", string(config))
+	sources, err := readWriter.ReadFile(chronySourcesFile)
+	require.NoError(err)
+	require.Equal("server ntp1.example.com iburst\npool pool.example.com iburst\n", string(sources))
+
+	// unchanged sources are not reloaded
+	require.NoError(c.Sync(context.Background(), desiredTime(timeSpec)))
+
+	// changed sources are reloaded without restarting
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), chronycCommand, "reload", "sources").Return("", "", 0)
+	require.NoError(c.Sync(context.Background(), desiredTime(&v1alpha1.DeviceTimeSpec{Servers: &[]string{"ntp2.example.com"}})))
+	sources, err = readWriter.ReadFile(chronySourcesFile)
+	require.NoError(err)
+	require.Equal("server ntp2.example.com iburst\n", string(sources))
+
+	// removing the sources from the spec removes them from chrony
+	execMock.EXPECT().ExecuteWithContext(gomock.Any(), chronycCommand, "reload", "sources").Return("", "", 0)
+	require.NoError(c.Sync(context.Background(), desiredTime(nil)))
+	exists, err := readWriter.FileExists(chronySourcesFile)
+	require.NoError(err)
+	require.False(exists)
+
+	// nothing is run without sources
+	require.NoError(c.Sync(context.Background(), desiredTime(nil)))
+}
+
+func TestTimeSyncRejectsInvalidSources(t *testing.T) {
+	require := require.New(t)
+	c, readWriter, _ := newTestTimeSyncController(t)
+	require.NoError(readWriter.WriteFile(chronyConfigFile, []byte(testChronyConfig), 0644))
+
+	err := c.Sync(context.Background(), desiredTime(&v1alpha1.DeviceTimeSpec{Servers: &[]string{"ntp.example.com\nallow all"}}))
+	require.Error(err)
+	exists, err := readWriter.FileExists(chronySourcesFile)
+	require.NoError(err)
+	require.False(exists)
+}
+
+func TestTimeSyncWithoutChrony(t *testing.T) {
+	c, _, _ := newTestTimeSyncController(t)
+	require.Error(t, c.Sync(context.Background(), desiredTime(&v1alpha1.DeviceTimeSpec{Servers: &[]string{"ntp.example.com"}})))
+}
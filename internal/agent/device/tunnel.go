@@ -0,0 +1,139 @@
+package device
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/agentserver"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/flightctl/flightctl/pkg/log"
+	"google.golang.org/grpc/metadata"
+)
+
+// tunnelDialTimeout bounds connecting to the service of a tunnel.
+const tunnelDialTimeout = 5 * time.Second
+
+// TunnelController forwards the support tunnel of the rendered spec to the
+// service it was opened to on localhost of the device, until it is closed
+// or expires.
+type TunnelController struct {
+	grpcClient grpc_v1.RouterServiceClient
+	deviceName string
+	// identity loads the agent certificate, which signs the key of the tunnel
+	identity func() (tls.Certificate, error)
+	log      *log.PrefixLogger
+
+	mu sync.Mutex
+	// the ID of the open tunnel and the cancel function closing it
+	activeTunnel string
+	cancel       context.CancelFunc
+	// the tunnel which last closed, which is not opened again
+	lastClosedTunnel string
+}
+
+func NewTunnelController(
+	grpcClient grpc_v1.RouterServiceClient,
+	deviceName string,
+	identity func() (tls.Certificate, error),
+	log *log.PrefixLogger,
+) *TunnelController {
+	return &TunnelController{
+		grpcClient: grpcClient,
+		deviceName: deviceName,
+		identity:   identity,
+		log:        log,
+	}
+}
+
+func (c *TunnelController) Sync(ctx context.Context, desired *v1alpha1.RenderedDeviceSpec) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// a tunnel which is no longer desired, or replaced by another one, is
+	// closed
+	if c.cancel != nil && (desired.Tunnel == nil || desired.Tunnel.Id != c.activeTunnel) {
+		c.log.Infof("closing tunnel %s", c.activeTunnel)
+		c.cancel()
+		c.activeTunnel = ""
+		c.cancel = nil
+	}
+
+	tunnel := desired.Tunnel
+	if tunnel == nil || tunnel.Id == c.activeTunnel || tunnel.Id == c.lastClosedTunnel {
+		return nil
+	}
+	if !time.Now().Before(tunnel.ExpiresAt) {
+		c.log.Debugf("tunnel %s expired at %s, not opening it", tunnel.Id, tunnel.ExpiresAt)
+		c.lastClosedTunnel = tunnel.Id
+		return nil
+	}
+	if c.grpcClient == nil {
+		c.log.Errorf("no gRPC client available, cannot open tunnel %s", tunnel.Id)
+		return nil
+	}
+
+	// tunnels are always encrypted end to end, the key is agreed before any
+	// connection is forwarded
+	identity, err := c.identity()
+	if err != nil {
+		return fmt.Errorf("loading the agent certificate for tunnel %s: %w", tunnel.Id, err)
+	}
+	handshake, session, err := console.AcceptOperator(tunnel.PublicKey, tunnel.Id, identity)
+	if err != nil {
+		// the request cannot succeed, so it is not retried
+		c.lastClosedTunnel = tunnel.Id
+		return fmt.Errorf("agreeing the key of tunnel %s: %w", tunnel.Id, err)
+	}
+
+	// the tunnel closes once it expires by the clock of the device, even if
+	// the service cannot be reached to close it
+	tunnelCtx, cancel := context.WithDeadline(ctx, tunnel.ExpiresAt)
+	tunnelCtx = metadata.AppendToOutgoingContext(tunnelCtx, agentserver.SessionIDKey, tunnel.Id, agentserver.ClientNameKey, c.deviceName)
+	stream, err := c.grpcClient.Stream(tunnelCtx)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("opening the stream of tunnel %s: %w", tunnel.Id, err)
+	}
+	if err := stream.Send(&grpc_v1.StreamRequest{Payload: handshake}); err != nil {
+		cancel()
+		return fmt.Errorf("sending the handshake of tunnel %s: %w", tunnel.Id, err)
+	}
+	c.activeTunnel = tunnel.Id
+	c.cancel = cancel
+	c.log.Infof("opened tunnel %s to port %d for %s until %s: %s", tunnel.Id, tunnel.Port, tunnel.RequestedBy, tunnel.ExpiresAt.Format(time.RFC3339), tunnel.Reason)
+
+	address := net.JoinHostPort("localhost", strconv.Itoa(int(tunnel.Port)))
+	go func() {
+		err := console.ServeTunnel(tunnelCtx, console.EncryptStream(stream, session), func(ctx context.Context) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: tunnelDialTimeout}
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err != nil {
+				c.log.Warnf("tunnel %s: connecting to %s: %v", tunnel.Id, address, err)
+			}
+			return conn, err
+		})
+		if err != nil && tunnelCtx.Err() == nil {
+			c.log.Errorf("tunnel %s: %v", tunnel.Id, err)
+		}
+		_ = stream.CloseSend()
+		cancel()
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.activeTunnel == tunnel.Id {
+			c.activeTunnel = ""
+			c.cancel = nil
+		}
+		c.lastClosedTunnel = tunnel.Id
+		c.log.Infof("tunnel %s closed", tunnel.Id)
+	}()
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package device
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// wakeOnLanPort is the discard port, which Wake-on-LAN packets are
+// conventionally sent to.
+const wakeOnLanPort = 9
+
+// magicPacket returns the Wake-on-LAN packet waking the network interface with
+// the given hardware address: 6 bytes of 0xff followed by 16 repetitions of
+// the address.
+func magicPacket(macAddress string) ([]byte, error) {
+	mac, err := net.ParseMAC(macAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid hardware address %q: not an EUI-48 address", macAddress)
+	}
+	packet := bytes.Repeat([]byte{0xff}, 6)
+	packet = append(packet, bytes.Repeat(mac, 16)...)
+	return packet, nil
+}
+
+// broadcastMagicPacket sends the Wake-on-LAN packet to the broadcast address
+// of each network the device is attached to, since the device to wake is on
+// the local network of one of them.
+func broadcastMagicPacket(macAddress string) error {
+	packet, err := magicPacket(macAddress)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	sent := 0
+	for _, addr := range broadcastAddresses() {
+		conn, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: addr, Port: wakeOnLanPort})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		_, err = conn.Write(packet)
+		conn.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sent++
+	}
+	if sent == 0 {
+		return fmt.Errorf("sending Wake-on-LAN packet to %s: %w", macAddress, errors.Join(errs...))
+	}
+	return nil
+}
+
+// broadcastAddresses returns the limited broadcast address and the broadcast
+// addresses of the IPv4 networks of the interfaces that are up.
+func broadcastAddresses() []net.IP {
+	addresses := []net.IP{net.IPv4bcast}
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return addresses
+	}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagBroadcast == 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() == nil || len(ipNet.Mask) != net.IPv4len {
+				continue
+			}
+			broadcast := make(net.IP, net.IPv4len)
+			for i, b := range ipNet.IP.To4() {
+				broadcast[i] = b | ^ipNet.Mask[i]
+			}
+			addresses = append(addresses, broadcast)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,25 @@
+package device
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMagicPacket(t *testing.T) {
+	require := require.New(t)
+
+	packet, err := magicPacket("52:54:00:12:34:56")
+	require.NoError(err)
+	require.Len(packet, 102)
+	require.Equal(bytes.Repeat([]byte{0xff}, 6), packet[:6])
+	for i := 6; i < len(packet); i += 6 {
+		require.Equal([]byte{0x52, 0x54, 0x00, 0x12, 0x34, 0x56}, packet[i:i+6])
+	}
+
+	_, err = magicPacket("52:54:00:12:34")
+	require.Error(err)
+	_, err = magicPacket("00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10")
+	require.Error(err)
+}
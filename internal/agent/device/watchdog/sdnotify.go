@@ -0,0 +1,44 @@
+package watchdog
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Notify sends the state, such as READY=1, to systemd. It returns false if
+// the agent is not run by systemd as a notify service.
+func Notify(state string) (bool, error) {
+	socketAddr := &net.UnixAddr{
+		Name: os.Getenv("NOTIFY_SOCKET"),
+		Net:  "unixgram",
+	}
+	if socketAddr.Name == "" {
+		return false, nil
+	}
+	conn, err := net.DialUnix(socketAddr.Net, nil, socketAddr)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to systemd: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(state + "\n")); err != nil {
+		return false, fmt.Errorf("failed to write to systemd: %w", err)
+	}
+	return true, nil
+}
+
+// systemdTimeout returns the WatchdogSec of the service, or zero if systemd
+// does not watch the agent.
+func systemdTimeout() time.Duration {
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
@@ -0,0 +1,251 @@
+// Package watchdog keeps systemd from restarting the agent while its loops
+// make progress, and has systemd restart it once one of them stalls, such as
+// a sync loop stuck on a deadlocked manager. It records why the agent was
+// restarted, so that the agent reports its unexpected restarts in the device
+// status.
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/log"
+)
+
+const (
+	// DefaultStallTimeout is how long a loop of the agent may go without a
+	// heartbeat before the agent is considered stuck. It exceeds the time
+	// the agent takes to pull the images of a spec.
+	DefaultStallTimeout = 30 * time.Minute
+	// checkInterval is the interval between two health checks when systemd
+	// does not watch the agent.
+	checkInterval = 30 * time.Second
+
+	stateFile  = "watchdog.json"
+	bootIDPath = "/proc/sys/kernel/random/boot_id"
+)
+
+// state is the record of the runs of the agent since the device booted.
+type state struct {
+	BootID string `json:"bootId"`
+	// Running is set while the agent runs, so that a run which ends without
+	// stopping is found by the next one
+	Running bool                             `json:"running"`
+	Cause   v1alpha1.DeviceAgentRestartCause `json:"cause,omitempty"`
+	Message string                           `json:"message,omitempty"`
+	Restart *v1alpha1.DeviceAgentRestart     `json:"restart,omitempty"`
+}
+
+type heartbeat struct {
+	name string
+	last time.Time
+}
+
+// Watchdog checks the heartbeats of the loops of the agent, and pings the
+// watchdog of systemd while none of them stalls.
+type Watchdog struct {
+	dataDir      string
+	readWriter   fileio.ReadWriter
+	stallTimeout time.Duration
+	log          *log.PrefixLogger
+
+	mu         sync.Mutex
+	heartbeats []*heartbeat
+	state      state
+
+	notify func(state string) (bool, error)
+	exit   func(code int)
+	now    func() time.Time
+}
+
+// New returns the watchdog of the agent. A zero stallTimeout disables the
+// health checks, systemd is still pinged.
+func New(dataDir string, readWriter fileio.ReadWriter, stallTimeout time.Duration, log *log.PrefixLogger) *Watchdog {
+	return &Watchdog{
+		dataDir:      dataDir,
+		readWriter:   readWriter,
+		stallTimeout: stallTimeout,
+		log:          log,
+		notify:       Notify,
+		exit:         os.Exit,
+		now:          time.Now,
+	}
+}
+
+// Start records that the agent runs, and returns the last unexpected restart
+// of the agent since the device booted, nil if there was none.
+func (w *Watchdog) Start() (*v1alpha1.DeviceAgentRestart, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	bootID, err := w.readWriter.ReadFile(bootIDPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading boot ID: %w", err)
+	}
+	previous, err := w.readState()
+	if err != nil {
+		return nil, err
+	}
+
+	w.state = state{BootID: strings.TrimSpace(string(bootID)), Running: true}
+	if previous != nil && previous.BootID == w.state.BootID {
+		w.state.Restart = previous.Restart
+		if previous.Running {
+			restart := &v1alpha1.DeviceAgentRestart{
+				Cause:       v1alpha1.DeviceAgentRestartCrashed,
+				Message:     "the agent exited without stopping",
+				RestartedAt: w.now().UTC(),
+				Count:       1,
+			}
+			if previous.Cause != "" {
+				restart.Cause, restart.Message = previous.Cause, previous.Message
+			}
+			if previous.Restart != nil {
+				restart.Count = previous.Restart.Count + 1
+			}
+			w.log.Warnf("Agent restarted: %s: %s", restart.Cause, restart.Message)
+			w.state.Restart = restart
+		}
+	}
+	if err := w.writeState(); err != nil {
+		return nil, err
+	}
+	return w.state.Restart, nil
+}
+
+// Stop records that the agent stopped, so that its next run does not report
+// a restart.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.state.Running = false
+	if err := w.writeState(); err != nil {
+		w.log.Errorf("Recording agent stop: %v", err)
+	}
+}
+
+// Register adds a loop to the health checks, and returns the function the
+// loop calls on each of its iterations.
+func (w *Watchdog) Register(name string) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	beat := &heartbeat{name: name, last: w.now()}
+	w.heartbeats = append(w.heartbeats, beat)
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		beat.last = w.now()
+	}
+}
+
+// Run checks the heartbeats until the context is done, pinging systemd while
+// all loops make progress. Once a loop stalls, it records why and has systemd
+// restart the agent, or exits if systemd does not watch the agent, which
+// makes systemd restart it as well.
+func (w *Watchdog) Run(ctx context.Context) {
+	timeout := systemdTimeout()
+	interval := checkInterval
+	if timeout > 0 {
+		// systemd recommends pinging twice per timeout
+		interval = timeout / 2
+		w.log.Infof("Pinging the systemd watchdog every %s", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !w.check(timeout > 0) {
+				return
+			}
+		}
+	}
+}
+
+// check returns whether the loops of the agent make progress, and restarts
+// the agent if they do not.
+func (w *Watchdog) check(watched bool) bool {
+	if stalled := w.stalled(); stalled != "" {
+		w.fail(stalled, watched)
+		return false
+	}
+	if watched {
+		if _, err := w.notify("WATCHDOG=1"); err != nil {
+			w.log.Warnf("Pinging the systemd watchdog: %v", err)
+		}
+	}
+	return true
+}
+
+// stalled returns why a loop is found stuck, or an empty string.
+func (w *Watchdog) stalled() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stallTimeout <= 0 {
+		return ""
+	}
+	now := w.now()
+	for _, beat := range w.heartbeats {
+		if since := now.Sub(beat.last); since > w.stallTimeout {
+			return fmt.Sprintf("the %s made no progress for %s", beat.name, since.Round(time.Second))
+		}
+	}
+	return ""
+}
+
+func (w *Watchdog) fail(message string, watched bool) {
+	w.log.Errorf("Self-health check failed, restarting the agent: %s", message)
+	w.mu.Lock()
+	w.state.Cause, w.state.Message = v1alpha1.DeviceAgentRestartWatchdog, message
+	if err := w.writeState(); err != nil {
+		w.log.Errorf("Recording agent restart: %v", err)
+	}
+	w.mu.Unlock()
+
+	if watched {
+		// systemd handles the trigger as a missed ping, and restarts the
+		// agent right away
+		if _, err := w.notify("WATCHDOG=trigger"); err == nil {
+			return
+		}
+	}
+	w.exit(1)
+}
+
+func (w *Watchdog) statePath() string {
+	return filepath.Join(w.dataDir, stateFile)
+}
+
+func (w *Watchdog) readState() (*state, error) {
+	exists, err := w.readWriter.FileExists(w.statePath())
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := w.readWriter.ReadFile(w.statePath())
+	if err != nil {
+		return nil, err
+	}
+	var s state
+	if err := json.Unmarshal(content, &s); err != nil {
+		return nil, fmt.Errorf("reading watchdog state: %w", err)
+	}
+	return &s, nil
+}
+
+func (w *Watchdog) writeState() error {
+	content, err := json.Marshal(&w.state)
+	if err != nil {
+		return err
+	}
+	return w.readWriter.WriteFile(w.statePath(), content, 0600)
+}
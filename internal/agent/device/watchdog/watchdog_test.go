@@ -0,0 +1,120 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+const dataDir = "/var/lib/flightctl"
+
+func newTestWatchdog(readWriter fileio.ReadWriter, now *time.Time) *Watchdog {
+	w := New(dataDir, readWriter, time.Minute, log.NewPrefixLogger("test"))
+	w.now = func() time.Time { return *now }
+	return w
+}
+
+func setBootID(t *testing.T, readWriter fileio.ReadWriter, bootID string) {
+	require.NoError(t, readWriter.WriteFile(bootIDPath, []byte(bootID+"\n"), 0644))
+}
+
+func TestStart(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	setBootID(t, readWriter, "boot-1")
+	now := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+
+	// first run of the boot
+	restart, err := newTestWatchdog(readWriter, &now).Start()
+	require.NoError(err)
+	require.Nil(restart)
+
+	// the previous run exited without stopping
+	w := newTestWatchdog(readWriter, &now)
+	restart, err = w.Start()
+	require.NoError(err)
+	require.NotNil(restart)
+	require.Equal(v1alpha1.DeviceAgentRestartCrashed, restart.Cause)
+	require.Equal(int32(1), restart.Count)
+	require.Equal(now, restart.RestartedAt)
+
+	// a stopped run keeps the restart of the boot
+	w.Stop()
+	restart, err = newTestWatchdog(readWriter, &now).Start()
+	require.NoError(err)
+	require.NotNil(restart)
+	require.Equal(int32(1), restart.Count)
+
+	// a reboot resets the restarts
+	setBootID(t, readWriter, "boot-2")
+	restart, err = newTestWatchdog(readWriter, &now).Start()
+	require.NoError(err)
+	require.Nil(restart)
+}
+
+func TestCheck(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	setBootID(t, readWriter, "boot-1")
+	now := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+
+	w := newTestWatchdog(readWriter, &now)
+	var notified []string
+	w.notify = func(state string) (bool, error) {
+		notified = append(notified, state)
+		return true, nil
+	}
+	exitCode := -1
+	w.exit = func(code int) { exitCode = code }
+	_, err := w.Start()
+	require.NoError(err)
+	beat := w.Register("reconciliation loop")
+
+	now = now.Add(50 * time.Second)
+	require.True(w.check(true))
+	require.Equal([]string{"WATCHDOG=1"}, notified)
+
+	now = now.Add(50 * time.Second)
+	beat()
+	require.True(w.check(true))
+
+	now = now.Add(2 * time.Minute)
+	require.False(w.check(true))
+	require.Equal([]string{"WATCHDOG=1", "WATCHDOG=1", "WATCHDOG=trigger"}, notified)
+	require.Equal(-1, exitCode)
+
+	// the next run reports the restart by the watchdog
+	restart, err := newTestWatchdog(readWriter, &now).Start()
+	require.NoError(err)
+	require.NotNil(restart)
+	require.Equal(v1alpha1.DeviceAgentRestartWatchdog, restart.Cause)
+	require.Equal("the reconciliation loop made no progress for 2m0s", restart.Message)
+	require.Equal(int32(1), restart.Count)
+}
+
+func TestCheckNotWatched(t *testing.T) {
+	require := require.New(t)
+	readWriter := fileio.NewReadWriter(fileio.WithTestRootDir(t.TempDir()))
+	setBootID(t, readWriter, "boot-1")
+	now := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+
+	w := newTestWatchdog(readWriter, &now)
+	w.notify = func(state string) (bool, error) {
+		require.Fail("systemd notified", state)
+		return false, nil
+	}
+	exitCode := -1
+	w.exit = func(code int) { exitCode = code }
+	_, err := w.Start()
+	require.NoError(err)
+	w.Register("reconciliation loop")
+
+	require.True(w.check(false))
+	now = now.Add(2 * time.Minute)
+	require.False(w.check(false))
+	require.Equal(1, exitCode)
+}
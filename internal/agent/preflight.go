@@ -0,0 +1,183 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/client"
+	baseclient "github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/container"
+	"github.com/flightctl/flightctl/pkg/executer"
+)
+
+// preflightRequestTimeout bounds each request to the services during a
+// preflight run.
+const preflightRequestTimeout = 10 * time.Second
+
+// PreflightCheckStatus is the outcome of a check of a preflight run.
+type PreflightCheckStatus string
+
+const (
+	PreflightCheckPassed  PreflightCheckStatus = "PASS"
+	PreflightCheckFailed  PreflightCheckStatus = "FAIL"
+	PreflightCheckSkipped PreflightCheckStatus = "SKIP"
+)
+
+// PreflightCheck is the result of a check of a preflight run.
+type PreflightCheck struct {
+	Name   string
+	Status PreflightCheckStatus
+	// Message is the reason a check failed or was skipped
+	Message string
+}
+
+type preflight struct {
+	checks []PreflightCheck
+}
+
+func (p *preflight) pass(name string) {
+	p.checks = append(p.checks, PreflightCheck{Name: name, Status: PreflightCheckPassed})
+}
+
+func (p *preflight) fail(name string, err error) {
+	p.checks = append(p.checks, PreflightCheck{Name: name, Status: PreflightCheckFailed, Message: err.Error()})
+}
+
+func (p *preflight) skip(name string, reason string) {
+	p.checks = append(p.checks, PreflightCheck{Name: name, Status: PreflightCheckSkipped, Message: reason})
+}
+
+// Preflight checks, without enrolling the device, that the agent can enroll
+// it once it is shipped to its site: that the agent's config file is valid,
+// that the enrollment certificate is valid, that the enrollment and
+// management services are reachable and trusted, and that bootc can read the
+// status of the host. It returns the results of all checks, so that imaging
+// pipelines can report every problem of a device at once.
+func Preflight(ctx context.Context, configFile string, exec executer.Executer) []PreflightCheck {
+	p := &preflight{}
+
+	config := NewDefault()
+	err := config.ParseConfigFile(configFile)
+	if err == nil {
+		err = config.Complete()
+	}
+	if err == nil {
+		err = config.Validate()
+	}
+	if err != nil {
+		p.fail("agent configuration is valid", err)
+		return p.checks
+	}
+	p.pass("agent configuration is valid")
+
+	if err := checkEnrollmentCertificate(&config.EnrollmentService.Config, time.Now()); err != nil {
+		p.fail("enrollment certificate is valid", err)
+	} else {
+		p.pass("enrollment certificate is valid")
+	}
+
+	client.SetProxy(config.Proxy)
+	p.checkService(ctx, "enrollment service", &config.EnrollmentService.Config)
+	p.checkService(ctx, "management service", &config.ManagementService.Config)
+
+	if _, err := exec.LookPath(container.CmdBootc); err != nil {
+		p.fail("bootc is installed", err)
+		p.skip("bootc can read the host status", "bootc is not installed")
+	} else {
+		p.pass("bootc is installed")
+		// reading the status requires the permissions bootc needs to switch images
+		if _, err := container.NewBootcCmd(exec).Status(ctx); err != nil {
+			p.fail("bootc can read the host status", err)
+		} else {
+			p.pass("bootc can read the host status")
+		}
+	}
+	return p.checks
+}
+
+// checkEnrollmentCertificate checks that the enrollment certificate and key
+// form a pair and that the certificate is currently valid.
+func checkEnrollmentCertificate(config *client.Config, now time.Time) error {
+	if !config.HasCredentials() {
+		return fmt.Errorf("no enrollment certificate is configured")
+	}
+	config = config.DeepCopy()
+	if err := config.Flatten(); err != nil {
+		return err
+	}
+	if _, err := tls.X509KeyPair(config.AuthInfo.ClientCertificateData, config.AuthInfo.ClientKeyData); err != nil {
+		return fmt.Errorf("parsing enrollment certificate and key: %w", err)
+	}
+	block, _ := pem.Decode(config.AuthInfo.ClientCertificateData)
+	if block == nil {
+		return fmt.Errorf("enrollment certificate is not PEM-encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing enrollment certificate: %w", err)
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Errorf("enrollment certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("enrollment certificate expired at %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkService requests the server of the service, through the agent's
+// proxy. Any HTTP response shows the service is reachable and its certificate
+// is trusted by the configured certificate authority.
+func (p *preflight) checkService(ctx context.Context, service string, config *client.Config) {
+	reachable := service + " is reachable"
+	trusted := service + " certificate is trusted"
+
+	// the management service need not accept the enrollment certificate
+	config = config.DeepCopy()
+	config.AuthInfo = client.AuthInfo{}
+	httpClient, err := baseclient.NewHTTPClientFromConfig(config)
+	if err != nil {
+		p.skip(reachable, "the TLS configuration is invalid")
+		p.fail(trusted, err)
+		return
+	}
+	if transport, ok := httpClient.Transport.(*http.Transport); ok {
+		transport.Proxy = client.Proxy
+	}
+	httpClient.Timeout = preflightRequestTimeout
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.Service.Server, nil)
+	if err != nil {
+		p.fail(reachable, err)
+		p.skip(trusted, "the service is not reachable")
+		return
+	}
+	resp, err := httpClient.Do(req)
+	switch {
+	case err == nil:
+		resp.Body.Close()
+		p.pass(reachable)
+		p.pass(trusted)
+	case isCertificateError(err):
+		p.pass(reachable)
+		p.fail(trusted, err)
+	default:
+		p.fail(reachable, err)
+		p.skip(trusted, "the service is not reachable")
+	}
+}
+
+func isCertificateError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &verificationErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
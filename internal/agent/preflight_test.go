@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEnrollmentCertificate(t *testing.T) {
+	require := require.New(t)
+	tmpDir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(tmpDir, "ca.crt"), filepath.Join(tmpDir, "ca.key"), filepath.Join(tmpDir, "ca.serial"), "ca", 1)
+	require.NoError(err)
+	certData, keyData, err := ca.IssueClientCertificate("client-enrollment", 3600)
+	require.NoError(err)
+
+	config := client.NewDefault()
+	require.ErrorContains(checkEnrollmentCertificate(config, time.Now()), "no enrollment certificate is configured")
+
+	config.AuthInfo = client.AuthInfo{ClientCertificateData: certData, ClientKeyData: keyData}
+	require.NoError(checkEnrollmentCertificate(config, time.Now()))
+	require.ErrorContains(checkEnrollmentCertificate(config, time.Now().Add(2*time.Hour)), "enrollment certificate expired at")
+
+	_, otherKeyData, err := ca.IssueClientCertificate("client-enrollment", 3600)
+	require.NoError(err)
+	config.AuthInfo.ClientKeyData = otherKeyData
+	require.ErrorContains(checkEnrollmentCertificate(config, time.Now()), "parsing enrollment certificate and key")
+}
+
+func TestPreflightCheckService(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	// all httptest servers use the same certificate, so the untrusted CA is generated
+	tmpDir := t.TempDir()
+	otherCA, err := crypto.MakeSelfSignedCA(filepath.Join(tmpDir, "ca.crt"), filepath.Join(tmpDir, "ca.key"), filepath.Join(tmpDir, "ca.serial"), "ca", 1)
+	require.NoError(t, err)
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	caData := func(cert *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	tests := []struct {
+		name     string
+		server   string
+		caData   []byte
+		expected []PreflightCheckStatus
+	}{
+		{
+			name:     "trusted service",
+			server:   server.URL,
+			caData:   caData(server.Certificate()),
+			expected: []PreflightCheckStatus{PreflightCheckPassed, PreflightCheckPassed},
+		},
+		{
+			name:     "untrusted service",
+			server:   server.URL,
+			caData:   caData(otherCA.Config.Certs[0]),
+			expected: []PreflightCheckStatus{PreflightCheckPassed, PreflightCheckFailed},
+		},
+		{
+			name:     "unreachable service",
+			server:   closedServer.URL,
+			caData:   caData(server.Certificate()),
+			expected: []PreflightCheckStatus{PreflightCheckFailed, PreflightCheckSkipped},
+		},
+		{
+			name:     "invalid certificate authority",
+			server:   server.URL,
+			caData:   []byte("invalid"),
+			expected: []PreflightCheckStatus{PreflightCheckSkipped, PreflightCheckFailed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := client.NewDefault()
+			config.Service = client.Service{Server: tt.server, CertificateAuthorityData: tt.caData}
+			p := &preflight{}
+			p.checkService(context.Background(), "enrollment service", config)
+			require.Len(t, p.checks, 2)
+			require.Equal(t, "enrollment service is reachable", p.checks[0].Name)
+			require.Equal(t, "enrollment service certificate is trusted", p.checks[1].Name)
+			require.Equal(t, tt.expected, []PreflightCheckStatus{p.checks[0].Status, p.checks[1].Status})
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device/fileio"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// zeroTouchProvisioningKey is the key of the ZeroTouchProvisioning setting in the config file
+	zeroTouchProvisioningKey = "zero-touch-provisioning"
+	// managementServiceKey is the key of the management service in the config file
+	managementServiceKey = "management-service"
+)
+
+// serialNumberPaths are the files the serial number of the machine is read
+// from, in order of preference.
+var serialNumberPaths = []string{
+	"/sys/class/dmi/id/product_serial",
+	"/proc/device-tree/serial-number",
+}
+
+// provision replaces the enrollment configuration of a generic image with
+// the configuration the service assigned to the hardware identity of the
+// machine, and persists it in the config file so that the machine is only
+// provisioned once.
+func (a *Agent) provision(ctx context.Context, readWriter fileio.ReadWriter, backoff wait.Backoff) error {
+	if a.config.configFile == "" {
+		return fmt.Errorf("zero-touch provisioning requires a config file")
+	}
+
+	identity, err := hardwareIdentity(readWriter, a.config.TPMPath)
+	if err != nil {
+		return err
+	}
+	enrollmentClient, err := newEnrollmentClient(a.config)
+	if err != nil {
+		return err
+	}
+
+	var provisioned *v1alpha1.EnrollmentConfig
+	a.log.Info("Waiting for the device identity of the machine to be registered")
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func() (bool, error) {
+		if identity.TpmEndorsementKey != nil {
+			// the machine proves to hold the endorsement key it presents
+			if identity.TpmActivatedCredential, err = activateProvisioningChallenge(ctx, enrollmentClient, readWriter, a.config.TPMPath, *identity); err != nil {
+				if !errors.Is(err, client.ErrNoDeviceIdentity) {
+					a.log.Errorf("Error proving the TPM endorsement key of the machine: %v", err)
+				}
+				return false, nil
+			}
+		}
+		provisioned, err = enrollmentClient.ProvisionDevice(ctx, *identity)
+		if err != nil {
+			if !errors.Is(err, client.ErrNoDeviceIdentity) {
+				a.log.Errorf("Error provisioning the machine: %v", err)
+			}
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("provisioning the machine: %w", err)
+	}
+
+	contents, err := readWriter.ReadFile(a.config.configFile)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	merged, err := mergeProvisionedConfig(contents, provisioned)
+	if err != nil {
+		return err
+	}
+	// the config holds the private key of the enrollment certificate
+	if err := readWriter.WriteFile(a.config.configFile, merged, 0600); err != nil {
+		return fmt.Errorf("writing config file: %w", err)
+	}
+
+	// drop the credentials of the image before reading the provisioned ones
+	a.config.EnrollmentService = EnrollmentService{}
+	a.config.ManagementService = ManagementService{}
+	a.config.DefaultLabels = make(map[string]string)
+	a.config.ZeroTouchProvisioning = false
+	if err := a.config.ParseConfigFile(a.config.configFile); err != nil {
+		return err
+	}
+	if err := a.config.Complete(); err != nil {
+		return err
+	}
+	a.log.Info("Machine provisioned")
+	return nil
+}
+
+// activateProvisioningChallenge requests a provisioning challenge for the
+// hardware identity, and returns the base64 encoded secret the TPM recovers
+// from it.
+func activateProvisioningChallenge(ctx context.Context, enrollmentClient client.Enrollment, reader fileio.Reader, tpmPath string, identity v1alpha1.ProvisioningRequest) (*string, error) {
+	challenge, err := enrollmentClient.CreateProvisioningChallenge(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	credential, err1 := base64.StdEncoding.DecodeString(challenge.Credential)
+	secret, err2 := base64.StdEncoding.DecodeString(challenge.Secret)
+	if err := errors.Join(err1, err2); err != nil {
+		return nil, fmt.Errorf("provisioning challenge must be base64 encoded: %w", err)
+	}
+	t, err := tpm.OpenTPM(reader.PathFor(tpmPath))
+	if err != nil {
+		return nil, fmt.Errorf("opening TPM: %w", err)
+	}
+	defer t.Close()
+	recovered, err := t.ActivateCredential(credential, secret)
+	if err != nil {
+		return nil, err
+	}
+	return lo.ToPtr(base64.StdEncoding.EncodeToString(recovered)), nil
+}
+
+// mergeProvisionedConfig overrides the settings of the config file with the
+// provisioned configuration and disables zero-touch provisioning. The
+// management service is derived from the provisioned enrollment service again,
+// while settings which are not provisioned, such as the proxy, are kept.
+func mergeProvisionedConfig(contents []byte, provisioned *v1alpha1.EnrollmentConfig) ([]byte, error) {
+	current := map[string]any{}
+	if err := yaml.Unmarshal(contents, &current); err != nil {
+		return nil, fmt.Errorf("unmarshalling config file: %w", err)
+	}
+	marshalled, err := yaml.Marshal(provisioned)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling provisioned config: %w", err)
+	}
+	overrides := map[string]any{}
+	if err := yaml.Unmarshal(marshalled, &overrides); err != nil {
+		return nil, fmt.Errorf("unmarshalling provisioned config: %w", err)
+	}
+
+	for key, value := range overrides {
+		current[key] = value
+	}
+	delete(current, zeroTouchProvisioningKey)
+	delete(current, managementServiceKey)
+	return yaml.Marshal(current)
+}
+
+// hardwareIdentity returns the serial number, MAC addresses and TPM
+// endorsement and attestation keys of the machine, as far as they are
+// available.
+func hardwareIdentity(reader fileio.Reader, tpmPath string) (*v1alpha1.ProvisioningRequest, error) {
+	identity := &v1alpha1.ProvisioningRequest{}
+	for _, path := range serialNumberPaths {
+		contents, err := reader.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// the device tree terminates strings with a null character
+		if serial := strings.TrimSpace(strings.TrimRight(string(contents), "\x00")); serial != "" {
+			identity.SerialNumber = &serial
+			break
+		}
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("listing network interfaces: %w", err)
+	}
+	macAddresses := macAddressesOf(interfaces)
+	if len(macAddresses) > 0 {
+		identity.MacAddresses = &macAddresses
+	}
+
+	if tpmPath != "" {
+		t, err := tpm.OpenTPM(reader.PathFor(tpmPath))
+		if err != nil {
+			return nil, fmt.Errorf("opening TPM: %w", err)
+		}
+		defer t.Close()
+		ek, err := t.GetEndorsementKey()
+		if err != nil {
+			return nil, err
+		}
+		ak, err := t.GetAttestationKey()
+		if err != nil {
+			return nil, err
+		}
+		identity.TpmEndorsementKey = lo.ToPtr(base64.StdEncoding.EncodeToString(ek))
+		identity.TpmAttestationKey = lo.ToPtr(base64.StdEncoding.EncodeToString(ak))
+	}
+
+	if identity.SerialNumber == nil && identity.MacAddresses == nil && identity.TpmEndorsementKey == nil {
+		return nil, fmt.Errorf("no hardware identity found")
+	}
+	return identity, nil
+}
+
+// macAddressesOf returns the sorted, lower case hardware addresses of the
+// Ethernet interfaces.
+func macAddressesOf(interfaces []net.Interface) []string {
+	macAddresses := []string{}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 || len(iface.HardwareAddr) != 6 {
+			continue
+		}
+		macAddresses = append(macAddresses, strings.ToLower(iface.HardwareAddr.String()))
+	}
+	macAddresses = lo.Uniq(macAddresses)
+	sort.Strings(macAddresses)
+	return macAddresses
+}
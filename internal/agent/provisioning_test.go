@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"encoding/base64"
+	"net"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMergeProvisionedConfig(t *testing.T) {
+	require := require.New(t)
+
+	golden := `zero-touch-provisioning: true
+enrollment-service:
+  service:
+    server: https://golden.endpoint
+  authentication:
+    client-certificate-data: golden
+    client-key-data: golden
+management-service:
+  service:
+    server: https://golden.endpoint
+proxy:
+  http-proxy: http://proxy:3128
+log-level: debug`
+	labels := map[string]string{"site": "a"}
+	provisioned := &v1alpha1.EnrollmentConfig{
+		EnrollmentService: v1alpha1.EnrollmentService{
+			Authentication: v1alpha1.EnrollmentServiceAuth{
+				ClientCertificateData: base64.StdEncoding.EncodeToString([]byte("cert")),
+				ClientKeyData:         base64.StdEncoding.EncodeToString([]byte("key")),
+			},
+			Service: v1alpha1.EnrollmentServiceService{
+				CertificateAuthorityData: "abcd",
+				Server:                   "https://enrollment.endpoint",
+			},
+			EnrollmentUiEndpoint: "https://ui.enrollment.endpoint",
+		},
+		SpecFetchInterval:    "0m10s",
+		StatusUpdateInterval: "0m10s",
+		DefaultLabels:        &labels,
+	}
+
+	merged, err := mergeProvisionedConfig([]byte(golden), provisioned)
+	require.NoError(err)
+
+	cfg := NewDefault()
+	require.NoError(yaml.Unmarshal(merged, cfg))
+	require.False(cfg.ZeroTouchProvisioning)
+	require.Equal("https://enrollment.endpoint", cfg.EnrollmentService.Service.Server)
+	require.Equal([]byte("cert"), cfg.EnrollmentService.AuthInfo.ClientCertificateData)
+	require.Equal("https://ui.enrollment.endpoint", cfg.EnrollmentService.EnrollmentUIEndpoint)
+	require.Empty(cfg.ManagementService.Service.Server)
+	require.Equal(labels, cfg.DefaultLabels)
+	require.Equal("10s", cfg.SpecFetchInterval.String())
+	// settings which are not provisioned are kept
+	require.Equal("http://proxy:3128", cfg.Proxy.HTTPProxy)
+	require.Equal("debug", cfg.LogLevel)
+}
+
+func TestMacAddressesOf(t *testing.T) {
+	require := require.New(t)
+
+	mac := func(s string) net.HardwareAddr {
+		addr, err := net.ParseMAC(s)
+		require.NoError(err)
+		return addr
+	}
+	interfaces := []net.Interface{
+		{Name: "lo", Flags: net.FlagLoopback},
+		{Name: "eth1", HardwareAddr: mac("52:54:00:AB:CD:02")},
+		{Name: "eth0", HardwareAddr: mac("52:54:00:ab:cd:01")},
+		{Name: "bond0", HardwareAddr: mac("52:54:00:ab:cd:01")},
+		{Name: "ib0", HardwareAddr: mac("00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01")},
+	}
+	require.Equal([]string{"52:54:00:ab:cd:01", "52:54:00:ab:cd:02"}, macAddressesOf(interfaces))
+}
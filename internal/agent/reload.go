@@ -0,0 +1,229 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/client"
+	"github.com/flightctl/flightctl/internal/agent/device"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/resource"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// configWatchInterval is the interval between two checks of the config file for changes
+	configWatchInterval = 10 * time.Second
+)
+
+// configReloader applies changes of the config file to the running agent,
+// either when the file changes or when the agent receives SIGHUP. Only the
+// tunables are reloaded: the log level, the spec fetch and status update
+// intervals, the proxy, the monitor thresholds and the allowed hook paths.
+// Changing any other setting requires a restart.
+//
+// The agent settings of the device spec take precedence over the log level,
+// monitor thresholds and allowed hook paths of the config file, so the
+// reloader also applies them whenever the device agent syncs a spec.
+type configReloader struct {
+	configFile      string
+	config          *Config
+	contents        []byte
+	deviceAgent     *device.Agent
+	hookManager     hook.Manager
+	resourceManager resource.Manager
+	log             *log.PrefixLogger
+
+	mu sync.Mutex
+	// agent settings of the last synced device spec
+	agentSpec *v1alpha1.DeviceAgentSpec
+}
+
+func newConfigReloader(configFile string, config *Config, deviceAgent *device.Agent, hookManager hook.Manager, resourceManager resource.Manager, log *log.PrefixLogger) *configReloader {
+	r := &configReloader{
+		configFile:      configFile,
+		config:          config,
+		deviceAgent:     deviceAgent,
+		hookManager:     hookManager,
+		resourceManager: resourceManager,
+		log:             log,
+	}
+	// the config the agent was started with is the baseline for detecting changes
+	if contents, err := config.reader.ReadFile(configFile); err == nil {
+		r.contents = contents
+	}
+	return r
+}
+
+// Run watches the config file until the context is canceled.
+func (r *configReloader) Run(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(configWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			r.log.Infof("Agent received SIGHUP, reloading config")
+			r.reload(true)
+		case <-ticker.C:
+			r.reload(false)
+		}
+	}
+}
+
+// reload applies the config file if it changed since it was last applied, or unconditionally if force is set.
+func (r *configReloader) reload(force bool) {
+	contents, err := r.config.reader.ReadFile(r.configFile)
+	if err != nil {
+		r.log.Errorf("Failed to read config file: %v", err)
+		return
+	}
+	if !force && bytes.Equal(contents, r.contents) {
+		return
+	}
+	r.contents = contents
+
+	newConfig := NewDefault()
+	newConfig.reader = r.config.reader
+	if err := newConfig.ParseConfigFile(r.configFile); err != nil {
+		r.log.Errorf("Failed to reload config, keeping the current one: %v", err)
+		return
+	}
+	if err := newConfig.Complete(); err != nil {
+		r.log.Errorf("Failed to reload config, keeping the current one: %v", err)
+		return
+	}
+	if err := newConfig.Validate(); err != nil {
+		r.log.Errorf("Failed to reload config, keeping the current one: %v", err)
+		return
+	}
+	r.apply(newConfig)
+}
+
+// SetAgentSpec applies the agent settings of a synced device spec.
+func (r *configReloader) SetAgentSpec(agentSpec *v1alpha1.DeviceAgentSpec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agentSpec = agentSpec
+	r.applySettings()
+}
+
+func (r *configReloader) apply(newConfig *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.config
+
+	current.LogLevel = newConfig.LogLevel
+	current.MonitorThresholds = newConfig.MonitorThresholds
+	current.AllowedHookPaths = newConfig.AllowedHookPaths
+	r.applySettings()
+
+	if newConfig.SpecFetchInterval != current.SpecFetchInterval || newConfig.StatusUpdateInterval != current.StatusUpdateInterval {
+		r.deviceAgent.SetConfiguredIntervals(newConfig.SpecFetchInterval, newConfig.StatusUpdateInterval)
+		current.SpecFetchInterval = newConfig.SpecFetchInterval
+		current.StatusUpdateInterval = newConfig.StatusUpdateInterval
+	}
+
+	if newConfig.Proxy != current.Proxy {
+		r.log.Infof("Updating proxy configuration")
+		client.SetProxy(newConfig.Proxy)
+		current.Proxy = newConfig.Proxy
+	}
+
+	for _, setting := range restartRequiredSettings(current, newConfig) {
+		r.log.Warnf("Config setting %s changed, the change takes effect once the agent is restarted", setting)
+	}
+}
+
+// applySettings applies the settings which the device spec can override,
+// taking each from the spec if set and from the config file otherwise.
+func (r *configReloader) applySettings() {
+	logLevel := r.config.LogLevel
+	if r.agentSpec != nil && r.agentSpec.LogLevel != nil {
+		logLevel = string(*r.agentSpec.LogLevel)
+	}
+	// like the logger, any unknown level is treated as info
+	level, err := logrus.ParseLevel(logLevel)
+	if err != nil {
+		level = logrus.InfoLevel
+	}
+	if level != r.log.GetLevel() {
+		r.log.Infof("Updating log level from %s to %s", r.log.GetLevel(), level)
+		r.log.SetLevel(level)
+	}
+
+	var specThresholds *v1alpha1.ResourceMonitorThresholds
+	allowedHookPaths := r.config.AllowedHookPaths
+	if r.agentSpec != nil {
+		specThresholds = r.agentSpec.MonitorThresholds
+		if r.agentSpec.AllowedHookPaths != nil {
+			allowedHookPaths = *r.agentSpec.AllowedHookPaths
+		}
+	}
+	r.resourceManager.SetDefaultThresholds(monitorThresholds(specThresholds, &r.config.MonitorThresholds))
+	r.hookManager.SetAllowedPaths(allowedHookPaths)
+}
+
+// monitorThresholds merges the thresholds of the device spec over those of
+// the config file.
+func monitorThresholds(spec *v1alpha1.ResourceMonitorThresholds, config *MonitorThresholds) *v1alpha1.ResourceMonitorThresholds {
+	spec = lo.ToPtr(lo.FromPtr(spec))
+	merge := func(spec *v1alpha1.ResourceThresholds, config ResourceThresholds) *v1alpha1.ResourceThresholds {
+		return &v1alpha1.ResourceThresholds{
+			WarningPercentage:  lo.CoalesceOrEmpty(lo.FromPtr(spec).WarningPercentage, config.WarningPercentage),
+			CriticalPercentage: lo.CoalesceOrEmpty(lo.FromPtr(spec).CriticalPercentage, config.CriticalPercentage),
+		}
+	}
+	return &v1alpha1.ResourceMonitorThresholds{
+		Cpu:    merge(spec.Cpu, config.CPU),
+		Memory: merge(spec.Memory, config.Memory),
+		Disk:   merge(spec.Disk, config.Disk),
+	}
+}
+
+// restartRequiredSettings returns the settings that differ between the configs
+// but can only be applied by restarting the agent.
+func restartRequiredSettings(current *Config, newConfig *Config) []string {
+	var changed []string
+	if !current.EnrollmentService.Equal(&newConfig.EnrollmentService) {
+		changed = append(changed, "enrollment-service")
+	}
+	// the agent fills in the credentials of the management service after enrollment
+	if !reflect.DeepEqual(current.ManagementService.Service, newConfig.ManagementService.Service) {
+		changed = append(changed, "management-service")
+	}
+	if current.GrpcManagementEndpoint != newConfig.GrpcManagementEndpoint {
+		changed = append(changed, "grpc-management-endpoint")
+	}
+	if current.ReportedPropertiesSocket != newConfig.ReportedPropertiesSocket {
+		changed = append(changed, "reported-properties-socket")
+	}
+	if current.AcceleratorStatus != newConfig.AcceleratorStatus {
+		changed = append(changed, "accelerator-status")
+	}
+	if !reflect.DeepEqual(current.Metrics, newConfig.Metrics) {
+		changed = append(changed, "metrics")
+	}
+	if current.TPMPath != newConfig.TPMPath {
+		changed = append(changed, "tpm-path")
+	}
+	if !reflect.DeepEqual(current.DefaultLabels, newConfig.DefaultLabels) {
+		changed = append(changed, "default-labels")
+	}
+	return changed
+}
@@ -0,0 +1,135 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/agent/device"
+	"github.com/flightctl/flightctl/internal/agent/device/hook"
+	"github.com/flightctl/flightctl/internal/agent/device/resource"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConfigReloader(t *testing.T) {
+	require := require.New(t)
+
+	tmpDir := t.TempDir()
+	t.Setenv(TestRootDirEnvKey, tmpDir)
+	require.NoError(os.MkdirAll(filepath.Join(tmpDir, DefaultConfigDir), 0755))
+	require.NoError(os.MkdirAll(filepath.Join(tmpDir, DefaultDataDir), 0755))
+	writeConfig := func(contents string) {
+		require.NoError(os.WriteFile(filepath.Join(tmpDir, DefaultConfigFile), []byte(contents), 0600))
+	}
+	writeConfig(yamlConfig)
+
+	cfg := NewDefault()
+	require.NoError(cfg.ParseConfigFile(DefaultConfigFile))
+	require.NoError(cfg.Complete())
+	require.NoError(cfg.Validate())
+
+	logger := log.NewPrefixLogger("test")
+	logger.Level(cfg.LogLevel)
+	deviceAgent := device.NewAgent("device", cfg.SpecFetchInterval, cfg.StatusUpdateInterval, device.Dependencies{}, logger)
+	reloader := newConfigReloader(DefaultConfigFile, cfg, deviceAgent, hook.NewManager(nil, logger), resource.NewManager(logger), logger)
+
+	// an unchanged file is not reloaded
+	reloader.reload(false)
+	require.Equal(logrus.InfoLevel, logger.GetLevel())
+
+	writeConfig(yamlConfig + `
+log-level: debug
+proxy:
+  https-proxy: http://proxy.example.com:3128
+metrics:
+  scrape-targets:
+  - http://localhost:9100/metrics`)
+	reloader.reload(false)
+	require.Equal(logrus.DebugLevel, logger.GetLevel())
+	require.Equal("debug", cfg.LogLevel)
+	require.Equal("http://proxy.example.com:3128", cfg.Proxy.HTTPSProxy)
+	// settings which require a restart are not applied
+	require.Empty(cfg.Metrics.ScrapeTargets)
+
+	// an invalid config keeps the current one
+	writeConfig(yamlConfig + `
+log-level: warn
+proxy:
+  https-proxy: "http://proxy.example.com:port"`)
+	reloader.reload(false)
+	require.Equal(logrus.DebugLevel, logger.GetLevel())
+
+	writeConfig(strings.Replace(yamlConfig, "spec-fetch-interval: 0m10s", "spec-fetch-interval: 5m", 1) + `
+log-level: debug`)
+	reloader.reload(false)
+	require.Equal(5*time.Minute, time.Duration(cfg.SpecFetchInterval))
+
+	// SIGHUP reloads the file even if it did not change
+	logger.Level("info")
+	cfg.LogLevel = "info"
+	reloader.reload(true)
+	require.Equal(logrus.DebugLevel, logger.GetLevel())
+}
+
+func TestConfigReloaderAgentSpec(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hookManager := hook.NewMockManager(ctrl)
+	resourceManager := resource.NewMockManager(ctrl)
+
+	cfg := NewDefault()
+	cfg.AllowedHookPaths = []string{"/etc/app"}
+	cfg.MonitorThresholds.CPU = ResourceThresholds{WarningPercentage: lo.ToPtr(60.0), CriticalPercentage: lo.ToPtr(70.0)}
+	logger := log.NewPrefixLogger("test")
+	logger.Level(cfg.LogLevel)
+	reloader := newConfigReloader("", cfg, nil, hookManager, resourceManager, logger)
+
+	// the settings of the spec take precedence over the config file
+	hookManager.EXPECT().SetAllowedPaths([]string{"/var/lib/app"})
+	resourceManager.EXPECT().SetDefaultThresholds(&v1alpha1.ResourceMonitorThresholds{
+		Cpu:    &v1alpha1.ResourceThresholds{WarningPercentage: lo.ToPtr(60.0), CriticalPercentage: lo.ToPtr(95.0)},
+		Memory: &v1alpha1.ResourceThresholds{},
+		Disk:   &v1alpha1.ResourceThresholds{},
+	})
+	reloader.SetAgentSpec(&v1alpha1.DeviceAgentSpec{
+		LogLevel:          lo.ToPtr(v1alpha1.DeviceAgentSpecLogLevelDebug),
+		AllowedHookPaths:  &[]string{"/var/lib/app"},
+		MonitorThresholds: &v1alpha1.ResourceMonitorThresholds{Cpu: &v1alpha1.ResourceThresholds{CriticalPercentage: lo.ToPtr(95.0)}},
+	})
+	require.Equal(logrus.DebugLevel, logger.GetLevel())
+
+	// removing the settings from the spec restores those of the config file
+	hookManager.EXPECT().SetAllowedPaths([]string{"/etc/app"})
+	resourceManager.EXPECT().SetDefaultThresholds(&v1alpha1.ResourceMonitorThresholds{
+		Cpu:    &v1alpha1.ResourceThresholds{WarningPercentage: lo.ToPtr(60.0), CriticalPercentage: lo.ToPtr(70.0)},
+		Memory: &v1alpha1.ResourceThresholds{},
+		Disk:   &v1alpha1.ResourceThresholds{},
+	})
+	reloader.SetAgentSpec(&v1alpha1.DeviceAgentSpec{})
+	require.Equal(logrus.InfoLevel, logger.GetLevel())
+}
+
+func TestRestartRequiredSettings(t *testing.T) {
+	require := require.New(t)
+
+	current := NewDefault()
+	newConfig := NewDefault()
+	require.Empty(restartRequiredSettings(current, newConfig))
+
+	newConfig.LogLevel = "debug"
+	newConfig.Proxy.HTTPProxy = "http://proxy.example.com:3128"
+	require.Empty(restartRequiredSettings(current, newConfig))
+
+	newConfig.ManagementService.Service.Server = "https://management.example.com"
+	newConfig.AcceleratorStatus = true
+	require.Equal([]string{"management-service", "accelerator-status"}, restartRequiredSettings(current, newConfig))
+}
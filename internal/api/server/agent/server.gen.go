@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	. "github.com/flightctl/flightctl/api/v1alpha1"
@@ -19,6 +20,15 @@ import (
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /api/v1/devices/{name}/attestation)
+	ReportDeviceAttestation(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/attestation/challenge)
+	CreateDeviceAttestationChallenge(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/metrics)
+	PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams)
 
@@ -30,12 +40,36 @@ type ServerInterface interface {
 
 	// (GET /api/v1/enrollmentrequests/{name})
 	ReadEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/provisioning)
+	ProvisionDevice(w http.ResponseWriter, r *http.Request)
+
+	// (POST /api/v1/provisioning/challenge)
+	CreateProvisioningChallenge(w http.ResponseWriter, r *http.Request)
+
+	// (PUT /api/v1/relay/devicestatuses)
+	ReplaceRelayedDeviceStatuses(w http.ResponseWriter, r *http.Request)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// (POST /api/v1/devices/{name}/attestation)
+func (_ Unimplemented) ReportDeviceAttestation(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/attestation/challenge)
+func (_ Unimplemented) CreateDeviceAttestationChallenge(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/metrics)
+func (_ Unimplemented) PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/rendered)
 func (_ Unimplemented) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -56,6 +90,21 @@ func (_ Unimplemented) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/provisioning)
+func (_ Unimplemented) ProvisionDevice(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/provisioning/challenge)
+func (_ Unimplemented) CreateProvisioningChallenge(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/relay/devicestatuses)
+func (_ Unimplemented) ReplaceRelayedDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -65,6 +114,84 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ReportDeviceAttestation operation middleware
+func (siw *ServerInterfaceWrapper) ReportDeviceAttestation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportDeviceAttestation(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateDeviceAttestationChallenge operation middleware
+func (siw *ServerInterfaceWrapper) CreateDeviceAttestationChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDeviceAttestationChallenge(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PushDeviceMetrics operation middleware
+func (siw *ServerInterfaceWrapper) PushDeviceMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PushDeviceMetrics(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -91,6 +218,14 @@ func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Optional query parameter "specVersions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "specVersions", r.URL.Query(), &params.SpecVersions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specVersions", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetRenderedDeviceSpec(w, r, name, params)
 	}))
@@ -169,6 +304,51 @@ func (siw *ServerInterfaceWrapper) ReadEnrollmentRequest(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
+// ProvisionDevice operation middleware
+func (siw *ServerInterfaceWrapper) ProvisionDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ProvisionDevice(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateProvisioningChallenge operation middleware
+func (siw *ServerInterfaceWrapper) CreateProvisioningChallenge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProvisioningChallenge(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceRelayedDeviceStatuses operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceRelayedDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceRelayedDeviceStatuses(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -282,6 +462,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/attestation", wrapper.ReportDeviceAttestation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/attestation/challenge", wrapper.CreateDeviceAttestationChallenge)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/metrics", wrapper.PushDeviceMetrics)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/devices/{name}/rendered", wrapper.GetRenderedDeviceSpec)
 	})
@@ -294,10 +483,151 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/enrollmentrequests/{name}", wrapper.ReadEnrollmentRequest)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/provisioning", wrapper.ProvisionDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/provisioning/challenge", wrapper.CreateProvisioningChallenge)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/relay/devicestatuses", wrapper.ReplaceRelayedDeviceStatuses)
+	})
 
 	return r
 }
 
+type ReportDeviceAttestationRequestObject struct {
+	Name string `json:"name"`
+	Body *ReportDeviceAttestationJSONRequestBody
+}
+
+type ReportDeviceAttestationResponseObject interface {
+	VisitReportDeviceAttestationResponse(w http.ResponseWriter) error
+}
+
+type ReportDeviceAttestation204Response struct {
+}
+
+func (response ReportDeviceAttestation204Response) VisitReportDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type ReportDeviceAttestation400JSONResponse externalRef0.Error
+
+func (response ReportDeviceAttestation400JSONResponse) VisitReportDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportDeviceAttestation401JSONResponse externalRef0.Error
+
+func (response ReportDeviceAttestation401JSONResponse) VisitReportDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportDeviceAttestation404JSONResponse externalRef0.Error
+
+func (response ReportDeviceAttestation404JSONResponse) VisitReportDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReportDeviceAttestation409JSONResponse externalRef0.Error
+
+func (response ReportDeviceAttestation409JSONResponse) VisitReportDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceAttestationChallengeRequestObject struct {
+	Name string `json:"name"`
+}
+
+type CreateDeviceAttestationChallengeResponseObject interface {
+	VisitCreateDeviceAttestationChallengeResponse(w http.ResponseWriter) error
+}
+
+type CreateDeviceAttestationChallenge200JSONResponse externalRef0.AttestationChallenge
+
+func (response CreateDeviceAttestationChallenge200JSONResponse) VisitCreateDeviceAttestationChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceAttestationChallenge401JSONResponse externalRef0.Error
+
+func (response CreateDeviceAttestationChallenge401JSONResponse) VisitCreateDeviceAttestationChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceAttestationChallenge404JSONResponse externalRef0.Error
+
+func (response CreateDeviceAttestationChallenge404JSONResponse) VisitCreateDeviceAttestationChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetricsRequestObject struct {
+	Name string `json:"name"`
+	Body io.Reader
+}
+
+type PushDeviceMetricsResponseObject interface {
+	VisitPushDeviceMetricsResponse(w http.ResponseWriter) error
+}
+
+type PushDeviceMetrics204Response struct {
+}
+
+func (response PushDeviceMetrics204Response) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PushDeviceMetrics400JSONResponse externalRef0.Error
+
+func (response PushDeviceMetrics400JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetrics401JSONResponse externalRef0.Error
+
+func (response PushDeviceMetrics401JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetrics503JSONResponse externalRef0.Error
+
+func (response PushDeviceMetrics503JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetRenderedDeviceSpecRequestObject struct {
 	Name   string `json:"name"`
 	Params GetRenderedDeviceSpecParams
@@ -484,9 +814,168 @@ func (response ReadEnrollmentRequest404JSONResponse) VisitReadEnrollmentRequestR
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ProvisionDeviceRequestObject struct {
+	Body *ProvisionDeviceJSONRequestBody
+}
+
+type ProvisionDeviceResponseObject interface {
+	VisitProvisionDeviceResponse(w http.ResponseWriter) error
+}
+
+type ProvisionDevice200JSONResponse externalRef0.EnrollmentConfig
+
+func (response ProvisionDevice200JSONResponse) VisitProvisionDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ProvisionDevice400JSONResponse externalRef0.Error
+
+func (response ProvisionDevice400JSONResponse) VisitProvisionDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ProvisionDevice401JSONResponse externalRef0.Error
+
+func (response ProvisionDevice401JSONResponse) VisitProvisionDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ProvisionDevice404JSONResponse externalRef0.Error
+
+func (response ProvisionDevice404JSONResponse) VisitProvisionDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ProvisionDevice409JSONResponse externalRef0.Error
+
+func (response ProvisionDevice409JSONResponse) VisitProvisionDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningChallengeRequestObject struct {
+	Body *CreateProvisioningChallengeJSONRequestBody
+}
+
+type CreateProvisioningChallengeResponseObject interface {
+	VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error
+}
+
+type CreateProvisioningChallenge200JSONResponse externalRef0.ProvisioningChallenge
+
+func (response CreateProvisioningChallenge200JSONResponse) VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningChallenge400JSONResponse externalRef0.Error
+
+func (response CreateProvisioningChallenge400JSONResponse) VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningChallenge401JSONResponse externalRef0.Error
+
+func (response CreateProvisioningChallenge401JSONResponse) VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningChallenge404JSONResponse externalRef0.Error
+
+func (response CreateProvisioningChallenge404JSONResponse) VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningChallenge409JSONResponse externalRef0.Error
+
+func (response CreateProvisioningChallenge409JSONResponse) VisitCreateProvisioningChallengeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceRelayedDeviceStatusesRequestObject struct {
+	Body *ReplaceRelayedDeviceStatusesJSONRequestBody
+}
+
+type ReplaceRelayedDeviceStatusesResponseObject interface {
+	VisitReplaceRelayedDeviceStatusesResponse(w http.ResponseWriter) error
+}
+
+type ReplaceRelayedDeviceStatuses200JSONResponse externalRef0.DeviceStatusBatchResult
+
+func (response ReplaceRelayedDeviceStatuses200JSONResponse) VisitReplaceRelayedDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceRelayedDeviceStatuses400JSONResponse externalRef0.Error
+
+func (response ReplaceRelayedDeviceStatuses400JSONResponse) VisitReplaceRelayedDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceRelayedDeviceStatuses401JSONResponse externalRef0.Error
+
+func (response ReplaceRelayedDeviceStatuses401JSONResponse) VisitReplaceRelayedDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceRelayedDeviceStatuses403JSONResponse externalRef0.Error
+
+func (response ReplaceRelayedDeviceStatuses403JSONResponse) VisitReplaceRelayedDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 
+	// (POST /api/v1/devices/{name}/attestation)
+	ReportDeviceAttestation(ctx context.Context, request ReportDeviceAttestationRequestObject) (ReportDeviceAttestationResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/attestation/challenge)
+	CreateDeviceAttestationChallenge(ctx context.Context, request CreateDeviceAttestationChallengeRequestObject) (CreateDeviceAttestationChallengeResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/metrics)
+	PushDeviceMetrics(ctx context.Context, request PushDeviceMetricsRequestObject) (PushDeviceMetricsResponseObject, error)
+
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(ctx context.Context, request GetRenderedDeviceSpecRequestObject) (GetRenderedDeviceSpecResponseObject, error)
 
@@ -498,6 +987,15 @@ type StrictServerInterface interface {
 
 	// (GET /api/v1/enrollmentrequests/{name})
 	ReadEnrollmentRequest(ctx context.Context, request ReadEnrollmentRequestRequestObject) (ReadEnrollmentRequestResponseObject, error)
+
+	// (POST /api/v1/provisioning)
+	ProvisionDevice(ctx context.Context, request ProvisionDeviceRequestObject) (ProvisionDeviceResponseObject, error)
+
+	// (POST /api/v1/provisioning/challenge)
+	CreateProvisioningChallenge(ctx context.Context, request CreateProvisioningChallengeRequestObject) (CreateProvisioningChallengeResponseObject, error)
+
+	// (PUT /api/v1/relay/devicestatuses)
+	ReplaceRelayedDeviceStatuses(ctx context.Context, request ReplaceRelayedDeviceStatusesRequestObject) (ReplaceRelayedDeviceStatusesResponseObject, error)
 }
 
 type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
@@ -529,6 +1027,93 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ReportDeviceAttestation operation middleware
+func (sh *strictHandler) ReportDeviceAttestation(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReportDeviceAttestationRequestObject
+
+	request.Name = name
+
+	var body ReportDeviceAttestationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReportDeviceAttestation(ctx, request.(ReportDeviceAttestationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReportDeviceAttestation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReportDeviceAttestationResponseObject); ok {
+		if err := validResponse.VisitReportDeviceAttestationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateDeviceAttestationChallenge operation middleware
+func (sh *strictHandler) CreateDeviceAttestationChallenge(w http.ResponseWriter, r *http.Request, name string) {
+	var request CreateDeviceAttestationChallengeRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDeviceAttestationChallenge(ctx, request.(CreateDeviceAttestationChallengeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDeviceAttestationChallenge")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDeviceAttestationChallengeResponseObject); ok {
+		if err := validResponse.VisitCreateDeviceAttestationChallengeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PushDeviceMetrics operation middleware
+func (sh *strictHandler) PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string) {
+	var request PushDeviceMetricsRequestObject
+
+	request.Name = name
+
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PushDeviceMetrics(ctx, request.(PushDeviceMetricsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PushDeviceMetrics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PushDeviceMetricsResponseObject); ok {
+		if err := validResponse.VisitPushDeviceMetricsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetRenderedDeviceSpec operation middleware
 func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
 	var request GetRenderedDeviceSpecRequestObject
@@ -645,3 +1230,96 @@ func (sh *strictHandler) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Re
 		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
 	}
 }
+
+// ProvisionDevice operation middleware
+func (sh *strictHandler) ProvisionDevice(w http.ResponseWriter, r *http.Request) {
+	var request ProvisionDeviceRequestObject
+
+	var body ProvisionDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ProvisionDevice(ctx, request.(ProvisionDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ProvisionDevice")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ProvisionDeviceResponseObject); ok {
+		if err := validResponse.VisitProvisionDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateProvisioningChallenge operation middleware
+func (sh *strictHandler) CreateProvisioningChallenge(w http.ResponseWriter, r *http.Request) {
+	var request CreateProvisioningChallengeRequestObject
+
+	var body CreateProvisioningChallengeJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateProvisioningChallenge(ctx, request.(CreateProvisioningChallengeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateProvisioningChallenge")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateProvisioningChallengeResponseObject); ok {
+		if err := validResponse.VisitCreateProvisioningChallengeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplaceRelayedDeviceStatuses operation middleware
+func (sh *strictHandler) ReplaceRelayedDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	var request ReplaceRelayedDeviceStatusesRequestObject
+
+	var body ReplaceRelayedDeviceStatusesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceRelayedDeviceStatuses(ctx, request.(ReplaceRelayedDeviceStatusesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceRelayedDeviceStatuses")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceRelayedDeviceStatusesResponseObject); ok {
+		if err := validResponse.VisitReplaceRelayedDeviceStatusesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
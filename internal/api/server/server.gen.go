@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	. "github.com/flightctl/flightctl/api/v1alpha1"
@@ -18,12 +19,27 @@ import (
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (GET /api/v1/artifacts)
+	ListArtifacts(w http.ResponseWriter, r *http.Request, params ListArtifactsParams)
+
+	// (GET /api/v1/artifacts/download)
+	DownloadArtifact(w http.ResponseWriter, r *http.Request, params DownloadArtifactParams)
+
+	// (GET /api/v1/artifacts/downloadurl)
+	CreateArtifactDownloadUrl(w http.ResponseWriter, r *http.Request, params CreateArtifactDownloadUrlParams)
+
 	// (GET /api/v1/auth/config)
 	AuthConfig(w http.ResponseWriter, r *http.Request)
 
 	// (GET /api/v1/auth/validate)
 	AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams)
 
+	// (POST /api/v1/bulkimports)
+	CreateBulkImport(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/certificates)
+	ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams)
+
 	// (DELETE /api/v1/certificatesigningrequests)
 	DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request)
 
@@ -51,6 +67,27 @@ type ServerInterface interface {
 	// (POST /api/v1/certificatesigningrequests/{name}/approval)
 	ApproveCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string)
 
+	// (GET /api/v1/dependencies)
+	ReadDependencies(w http.ResponseWriter, r *http.Request, params ReadDependenciesParams)
+
+	// (DELETE /api/v1/deviceidentities)
+	DeleteDeviceIdentities(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/deviceidentities)
+	ListDeviceIdentities(w http.ResponseWriter, r *http.Request, params ListDeviceIdentitiesParams)
+
+	// (POST /api/v1/deviceidentities)
+	CreateDeviceIdentity(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/deviceidentities/{name})
+	DeleteDeviceIdentity(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/deviceidentities/{name})
+	ReadDeviceIdentity(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/deviceidentities/{name})
+	ReplaceDeviceIdentity(w http.ResponseWriter, r *http.Request, name string)
+
 	// (DELETE /api/v1/devices)
 	DeleteDevices(w http.ResponseWriter, r *http.Request)
 
@@ -72,18 +109,93 @@ type ServerInterface interface {
 	// (PUT /api/v1/devices/{name})
 	ReplaceDevice(w http.ResponseWriter, r *http.Request, name string)
 
+	// (DELETE /api/v1/devices/{name}/action)
+	CancelDeviceAction(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/action)
+	RequestDeviceAction(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devices/{name}/aliases)
+	ReplaceDeviceAliases(w http.ResponseWriter, r *http.Request, name string)
+
+	// (DELETE /api/v1/devices/{name}/attestation)
+	ResetDeviceAttestation(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/devices/{name}/console)
-	RequestConsole(w http.ResponseWriter, r *http.Request, name string)
+	RequestConsole(w http.ResponseWriter, r *http.Request, name string, params RequestConsoleParams)
+
+	// (DELETE /api/v1/devices/{name}/consolegrant)
+	RevokeConsoleGrant(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/devices/{name}/consolegrant)
+	GetConsoleGrant(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/consolegrant)
+	RequestConsoleGrant(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/consolegrant/approval)
+	ApproveConsoleGrant(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/exec)
+	ExecDeviceCommand(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/metrics)
+	PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string)
+
+	// (DELETE /api/v1/devices/{name}/quarantine)
+	ReleaseDeviceQuarantine(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devices/{name}/quarantine)
+	QuarantineDevice(w http.ResponseWriter, r *http.Request, name string)
 
 	// (GET /api/v1/devices/{name}/rendered)
 	GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams)
 
+	// (POST /api/v1/devices/{name}/restore)
+	RestoreDevice(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/devices/{name}/status)
 	ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/devices/{name}/status)
 	ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (DELETE /api/v1/devices/{name}/tunnel)
+	CloseDeviceTunnel(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/devices/{name}/tunnel)
+	GetDeviceTunnel(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/tunnel)
+	RequestDeviceTunnel(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/devices/{name}/wake)
+	WakeDevice(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/devicestatuses)
+	ReplaceDeviceStatuses(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/deviceviews)
+	DeleteDeviceViews(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/deviceviews)
+	ListDeviceViews(w http.ResponseWriter, r *http.Request, params ListDeviceViewsParams)
+
+	// (POST /api/v1/deviceviews)
+	CreateDeviceView(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/deviceviews/{name})
+	DeleteDeviceView(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/deviceviews/{name})
+	ReadDeviceView(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/deviceviews/{name})
+	ReplaceDeviceView(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/deviceviews/{name}/devices)
+	ListDeviceViewDevices(w http.ResponseWriter, r *http.Request, name string, params ListDeviceViewDevicesParams)
+
 	// (GET /api/v1/enrollmentconfig/{name})
 	EnrollmentConfig(w http.ResponseWriter, r *http.Request, name string)
 
@@ -114,6 +226,9 @@ type ServerInterface interface {
 	// (PUT /api/v1/enrollmentrequests/{name}/status)
 	ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (POST /api/v1/exports)
+	CreateResourceExport(w http.ResponseWriter, r *http.Request)
+
 	// (DELETE /api/v1/fleets)
 	DeleteFleets(w http.ResponseWriter, r *http.Request)
 
@@ -136,7 +251,7 @@ type ServerInterface interface {
 	ReadTemplateVersion(w http.ResponseWriter, r *http.Request, fleet string, name string)
 
 	// (DELETE /api/v1/fleets/{name})
-	DeleteFleet(w http.ResponseWriter, r *http.Request, name string)
+	DeleteFleet(w http.ResponseWriter, r *http.Request, name string, params DeleteFleetParams)
 
 	// (GET /api/v1/fleets/{name})
 	ReadFleet(w http.ResponseWriter, r *http.Request, name string, params ReadFleetParams)
@@ -147,12 +262,87 @@ type ServerInterface interface {
 	// (PUT /api/v1/fleets/{name})
 	ReplaceFleet(w http.ResponseWriter, r *http.Request, name string)
 
+	// (POST /api/v1/fleets/{name}/lint)
+	LintFleet(w http.ResponseWriter, r *http.Request, name string)
+
+	// (DELETE /api/v1/fleets/{name}/migration)
+	CancelFleetMigration(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/fleets/{name}/migration)
+	MigrateFleetDevices(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/fleets/{name}/promotion/approve)
+	ApproveFleetPromotion(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/fleets/{name}/provisioning)
+	ReadFleetProvisioning(w http.ResponseWriter, r *http.Request, name string, params ReadFleetProvisioningParams)
+
+	// (GET /api/v1/fleets/{name}/provisioningtokens)
+	ListProvisioningTokens(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/fleets/{name}/provisioningtokens)
+	CreateProvisioningToken(w http.ResponseWriter, r *http.Request, name string)
+
+	// (DELETE /api/v1/fleets/{name}/provisioningtokens/{id})
+	DeleteProvisioningToken(w http.ResponseWriter, r *http.Request, name string, id string)
+
+	// (GET /api/v1/fleets/{name}/report)
+	ReadFleetReport(w http.ResponseWriter, r *http.Request, name string)
+
+	// (POST /api/v1/fleets/{name}/restore)
+	RestoreFleet(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/fleets/{name}/rollout/abort)
+	AbortFleetRollout(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/fleets/{name}/rollout/pause)
+	PauseFleetRollout(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/fleets/{name}/rollout/resume)
+	ResumeFleetRollout(w http.ResponseWriter, r *http.Request, name string)
+
 	// (GET /api/v1/fleets/{name}/status)
 	ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string)
 
 	// (PUT /api/v1/fleets/{name}/status)
 	ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string)
 
+	// (POST /api/v1/imports)
+	CreateResourceImport(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/labelrules)
+	DeleteLabelRules(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/labelrules)
+	ListLabelRules(w http.ResponseWriter, r *http.Request, params ListLabelRulesParams)
+
+	// (POST /api/v1/labelrules)
+	CreateLabelRule(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/labelrules/{name})
+	DeleteLabelRule(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/labelrules/{name})
+	ReadLabelRule(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/labelrules/{name})
+	ReplaceLabelRule(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/operations)
+	ListOperations(w http.ResponseWriter, r *http.Request, params ListOperationsParams)
+
+	// (POST /api/v1/operations)
+	CreateOperation(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/operations/{name})
+	DeleteOperation(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/operations/{name})
+	ReadOperation(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/operations/{name}/cancel)
+	CancelOperation(w http.ResponseWriter, r *http.Request, name string)
+
 	// (DELETE /api/v1/repositories)
 	DeleteRepositories(w http.ResponseWriter, r *http.Request)
 
@@ -163,7 +353,7 @@ type ServerInterface interface {
 	CreateRepository(w http.ResponseWriter, r *http.Request)
 
 	// (DELETE /api/v1/repositories/{name})
-	DeleteRepository(w http.ResponseWriter, r *http.Request, name string)
+	DeleteRepository(w http.ResponseWriter, r *http.Request, name string, params DeleteRepositoryParams)
 
 	// (GET /api/v1/repositories/{name})
 	ReadRepository(w http.ResponseWriter, r *http.Request, name string)
@@ -174,6 +364,9 @@ type ServerInterface interface {
 	// (PUT /api/v1/repositories/{name})
 	ReplaceRepository(w http.ResponseWriter, r *http.Request, name string)
 
+	// (PUT /api/v1/repositories/{name}/credentials)
+	RotateRepositoryCredentials(w http.ResponseWriter, r *http.Request, name string)
+
 	// (DELETE /api/v1/resourcesyncs)
 	DeleteResourceSyncs(w http.ResponseWriter, r *http.Request)
 
@@ -194,12 +387,69 @@ type ServerInterface interface {
 
 	// (PUT /api/v1/resourcesyncs/{name})
 	ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/sboms)
+	ListImageSboms(w http.ResponseWriter, r *http.Request, params ListImageSbomsParams)
+
+	// (DELETE /api/v1/sites)
+	DeleteSites(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/sites)
+	ListSites(w http.ResponseWriter, r *http.Request, params ListSitesParams)
+
+	// (POST /api/v1/sites)
+	CreateSite(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/sites/{name})
+	DeleteSite(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/sites/{name})
+	ReadSite(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/sites/{name})
+	ReplaceSite(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/trash)
+	ListTrash(w http.ResponseWriter, r *http.Request, params ListTrashParams)
+
+	// (DELETE /api/v1/webhooks)
+	DeleteWebhooks(w http.ResponseWriter, r *http.Request)
+
+	// (GET /api/v1/webhooks)
+	ListWebhooks(w http.ResponseWriter, r *http.Request, params ListWebhooksParams)
+
+	// (POST /api/v1/webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+
+	// (DELETE /api/v1/webhooks/{name})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, name string)
+
+	// (GET /api/v1/webhooks/{name})
+	ReadWebhook(w http.ResponseWriter, r *http.Request, name string)
+
+	// (PUT /api/v1/webhooks/{name})
+	ReplaceWebhook(w http.ResponseWriter, r *http.Request, name string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// (GET /api/v1/artifacts)
+func (_ Unimplemented) ListArtifacts(w http.ResponseWriter, r *http.Request, params ListArtifactsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/artifacts/download)
+func (_ Unimplemented) DownloadArtifact(w http.ResponseWriter, r *http.Request, params DownloadArtifactParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/artifacts/downloadurl)
+func (_ Unimplemented) CreateArtifactDownloadUrl(w http.ResponseWriter, r *http.Request, params CreateArtifactDownloadUrlParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/auth/config)
 func (_ Unimplemented) AuthConfig(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -210,6 +460,16 @@ func (_ Unimplemented) AuthValidate(w http.ResponseWriter, r *http.Request, para
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/bulkimports)
+func (_ Unimplemented) CreateBulkImport(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/certificates)
+func (_ Unimplemented) ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/certificatesigningrequests)
 func (_ Unimplemented) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -255,6 +515,41 @@ func (_ Unimplemented) ApproveCertificateSigningRequest(w http.ResponseWriter, r
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (GET /api/v1/dependencies)
+func (_ Unimplemented) ReadDependencies(w http.ResponseWriter, r *http.Request, params ReadDependenciesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/deviceidentities)
+func (_ Unimplemented) DeleteDeviceIdentities(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/deviceidentities)
+func (_ Unimplemented) ListDeviceIdentities(w http.ResponseWriter, r *http.Request, params ListDeviceIdentitiesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/deviceidentities)
+func (_ Unimplemented) CreateDeviceIdentity(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/deviceidentities/{name})
+func (_ Unimplemented) DeleteDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/deviceidentities/{name})
+func (_ Unimplemented) ReadDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/deviceidentities/{name})
+func (_ Unimplemented) ReplaceDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/devices)
 func (_ Unimplemented) DeleteDevices(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -290,8 +585,68 @@ func (_ Unimplemented) ReplaceDevice(w http.ResponseWriter, r *http.Request, nam
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (DELETE /api/v1/devices/{name}/action)
+func (_ Unimplemented) CancelDeviceAction(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/action)
+func (_ Unimplemented) RequestDeviceAction(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/aliases)
+func (_ Unimplemented) ReplaceDeviceAliases(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/devices/{name}/attestation)
+func (_ Unimplemented) ResetDeviceAttestation(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/console)
-func (_ Unimplemented) RequestConsole(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) RequestConsole(w http.ResponseWriter, r *http.Request, name string, params RequestConsoleParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/devices/{name}/consolegrant)
+func (_ Unimplemented) RevokeConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/devices/{name}/consolegrant)
+func (_ Unimplemented) GetConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/consolegrant)
+func (_ Unimplemented) RequestConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/consolegrant/approval)
+func (_ Unimplemented) ApproveConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/exec)
+func (_ Unimplemented) ExecDeviceCommand(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/metrics)
+func (_ Unimplemented) PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/devices/{name}/quarantine)
+func (_ Unimplemented) ReleaseDeviceQuarantine(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devices/{name}/quarantine)
+func (_ Unimplemented) QuarantineDevice(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -300,6 +655,11 @@ func (_ Unimplemented) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/devices/{name}/restore)
+func (_ Unimplemented) RestoreDevice(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/devices/{name}/status)
 func (_ Unimplemented) ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -310,6 +670,66 @@ func (_ Unimplemented) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (DELETE /api/v1/devices/{name}/tunnel)
+func (_ Unimplemented) CloseDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/devices/{name}/tunnel)
+func (_ Unimplemented) GetDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/tunnel)
+func (_ Unimplemented) RequestDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/devices/{name}/wake)
+func (_ Unimplemented) WakeDevice(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/devicestatuses)
+func (_ Unimplemented) ReplaceDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/deviceviews)
+func (_ Unimplemented) DeleteDeviceViews(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/deviceviews)
+func (_ Unimplemented) ListDeviceViews(w http.ResponseWriter, r *http.Request, params ListDeviceViewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /api/v1/deviceviews)
+func (_ Unimplemented) CreateDeviceView(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /api/v1/deviceviews/{name})
+func (_ Unimplemented) DeleteDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/deviceviews/{name})
+func (_ Unimplemented) ReadDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/deviceviews/{name})
+func (_ Unimplemented) ReplaceDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/deviceviews/{name}/devices)
+func (_ Unimplemented) ListDeviceViewDevices(w http.ResponseWriter, r *http.Request, name string, params ListDeviceViewDevicesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (GET /api/v1/enrollmentconfig/{name})
 func (_ Unimplemented) EnrollmentConfig(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -360,6 +780,11 @@ func (_ Unimplemented) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// (POST /api/v1/exports)
+func (_ Unimplemented) CreateResourceExport(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // (DELETE /api/v1/fleets)
 func (_ Unimplemented) DeleteFleets(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
@@ -396,7 +821,7 @@ func (_ Unimplemented) ReadTemplateVersion(w http.ResponseWriter, r *http.Reques
 }
 
 // (DELETE /api/v1/fleets/{name})
-func (_ Unimplemented) DeleteFleet(w http.ResponseWriter, r *http.Request, name string) {
+func (_ Unimplemented) DeleteFleet(w http.ResponseWriter, r *http.Request, name string, params DeleteFleetParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -415,242 +840,314 @@ func (_ Unimplemented) ReplaceFleet(w http.ResponseWriter, r *http.Request, name
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (GET /api/v1/fleets/{name}/status)
-func (_ Unimplemented) ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
+// (POST /api/v1/fleets/{name}/lint)
+func (_ Unimplemented) LintFleet(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (PUT /api/v1/fleets/{name}/status)
-func (_ Unimplemented) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
+// (DELETE /api/v1/fleets/{name}/migration)
+func (_ Unimplemented) CancelFleetMigration(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (DELETE /api/v1/repositories)
-func (_ Unimplemented) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
+// (PUT /api/v1/fleets/{name}/migration)
+func (_ Unimplemented) MigrateFleetDevices(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (GET /api/v1/repositories)
-func (_ Unimplemented) ListRepositories(w http.ResponseWriter, r *http.Request, params ListRepositoriesParams) {
+// (PUT /api/v1/fleets/{name}/promotion/approve)
+func (_ Unimplemented) ApproveFleetPromotion(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (POST /api/v1/repositories)
-func (_ Unimplemented) CreateRepository(w http.ResponseWriter, r *http.Request) {
+// (GET /api/v1/fleets/{name}/provisioning)
+func (_ Unimplemented) ReadFleetProvisioning(w http.ResponseWriter, r *http.Request, name string, params ReadFleetProvisioningParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (DELETE /api/v1/repositories/{name})
-func (_ Unimplemented) DeleteRepository(w http.ResponseWriter, r *http.Request, name string) {
+// (GET /api/v1/fleets/{name}/provisioningtokens)
+func (_ Unimplemented) ListProvisioningTokens(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (GET /api/v1/repositories/{name})
-func (_ Unimplemented) ReadRepository(w http.ResponseWriter, r *http.Request, name string) {
+// (POST /api/v1/fleets/{name}/provisioningtokens)
+func (_ Unimplemented) CreateProvisioningToken(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (PATCH /api/v1/repositories/{name})
-func (_ Unimplemented) PatchRepository(w http.ResponseWriter, r *http.Request, name string) {
+// (DELETE /api/v1/fleets/{name}/provisioningtokens/{id})
+func (_ Unimplemented) DeleteProvisioningToken(w http.ResponseWriter, r *http.Request, name string, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (PUT /api/v1/repositories/{name})
-func (_ Unimplemented) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
+// (GET /api/v1/fleets/{name}/report)
+func (_ Unimplemented) ReadFleetReport(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (DELETE /api/v1/resourcesyncs)
-func (_ Unimplemented) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
+// (POST /api/v1/fleets/{name}/restore)
+func (_ Unimplemented) RestoreFleet(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (GET /api/v1/resourcesyncs)
-func (_ Unimplemented) ListResourceSync(w http.ResponseWriter, r *http.Request, params ListResourceSyncParams) {
+// (PUT /api/v1/fleets/{name}/rollout/abort)
+func (_ Unimplemented) AbortFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (POST /api/v1/resourcesyncs)
-func (_ Unimplemented) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
+// (PUT /api/v1/fleets/{name}/rollout/pause)
+func (_ Unimplemented) PauseFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (DELETE /api/v1/resourcesyncs/{name})
-func (_ Unimplemented) DeleteResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+// (PUT /api/v1/fleets/{name}/rollout/resume)
+func (_ Unimplemented) ResumeFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (GET /api/v1/resourcesyncs/{name})
-func (_ Unimplemented) ReadResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+// (GET /api/v1/fleets/{name}/status)
+func (_ Unimplemented) ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (PATCH /api/v1/resourcesyncs/{name})
-func (_ Unimplemented) PatchResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+// (PUT /api/v1/fleets/{name}/status)
+func (_ Unimplemented) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// (PUT /api/v1/resourcesyncs/{name})
-func (_ Unimplemented) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+// (POST /api/v1/imports)
+func (_ Unimplemented) CreateResourceImport(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// (DELETE /api/v1/labelrules)
+func (_ Unimplemented) DeleteLabelRules(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// (GET /api/v1/labelrules)
+func (_ Unimplemented) ListLabelRules(w http.ResponseWriter, r *http.Request, params ListLabelRulesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-// AuthConfig operation middleware
-func (siw *ServerInterfaceWrapper) AuthConfig(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// (POST /api/v1/labelrules)
+func (_ Unimplemented) CreateLabelRule(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AuthConfig(w, r)
-	}))
+// (DELETE /api/v1/labelrules/{name})
+func (_ Unimplemented) DeleteLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// (GET /api/v1/labelrules/{name})
+func (_ Unimplemented) ReadLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// (PUT /api/v1/labelrules/{name})
+func (_ Unimplemented) ReplaceLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// AuthValidate operation middleware
-func (siw *ServerInterfaceWrapper) AuthValidate(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// (GET /api/v1/operations)
+func (_ Unimplemented) ListOperations(w http.ResponseWriter, r *http.Request, params ListOperationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	var err error
+// (POST /api/v1/operations)
+func (_ Unimplemented) CreateOperation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params AuthValidateParams
+// (DELETE /api/v1/operations/{name})
+func (_ Unimplemented) DeleteOperation(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	headers := r.Header
+// (GET /api/v1/operations/{name})
+func (_ Unimplemented) ReadOperation(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// ------------- Optional header parameter "Authentication" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Authentication")]; found {
-		var Authentication string
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Authentication", Count: n})
-			return
-		}
+// (PUT /api/v1/operations/{name}/cancel)
+func (_ Unimplemented) CancelOperation(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Authentication", valueList[0], &Authentication, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Authentication", Err: err})
-			return
-		}
+// (DELETE /api/v1/repositories)
+func (_ Unimplemented) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-		params.Authentication = &Authentication
+// (GET /api/v1/repositories)
+func (_ Unimplemented) ListRepositories(w http.ResponseWriter, r *http.Request, params ListRepositoriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	}
+// (POST /api/v1/repositories)
+func (_ Unimplemented) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AuthValidate(w, r, params)
-	}))
+// (DELETE /api/v1/repositories/{name})
+func (_ Unimplemented) DeleteRepository(w http.ResponseWriter, r *http.Request, name string, params DeleteRepositoryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// (GET /api/v1/repositories/{name})
+func (_ Unimplemented) ReadRepository(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// (PATCH /api/v1/repositories/{name})
+func (_ Unimplemented) PatchRepository(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// DeleteCertificateSigningRequests operation middleware
-func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// (PUT /api/v1/repositories/{name})
+func (_ Unimplemented) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteCertificateSigningRequests(w, r)
-	}))
+// (PUT /api/v1/repositories/{name}/credentials)
+func (_ Unimplemented) RotateRepositoryCredentials(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// (DELETE /api/v1/resourcesyncs)
+func (_ Unimplemented) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// (GET /api/v1/resourcesyncs)
+func (_ Unimplemented) ListResourceSync(w http.ResponseWriter, r *http.Request, params ListResourceSyncParams) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// ListCertificateSigningRequests operation middleware
-func (siw *ServerInterfaceWrapper) ListCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// (POST /api/v1/resourcesyncs)
+func (_ Unimplemented) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	var err error
+// (DELETE /api/v1/resourcesyncs/{name})
+func (_ Unimplemented) DeleteResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListCertificateSigningRequestsParams
+// (GET /api/v1/resourcesyncs/{name})
+func (_ Unimplemented) ReadResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// ------------- Optional query parameter "continue" -------------
+// (PATCH /api/v1/resourcesyncs/{name})
+func (_ Unimplemented) PatchResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
-		return
-	}
+// (PUT /api/v1/resourcesyncs/{name})
+func (_ Unimplemented) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// ------------- Optional query parameter "labelSelector" -------------
+// (GET /api/v1/sboms)
+func (_ Unimplemented) ListImageSboms(w http.ResponseWriter, r *http.Request, params ListImageSbomsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
-		return
-	}
+// (DELETE /api/v1/sites)
+func (_ Unimplemented) DeleteSites(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	// ------------- Optional query parameter "limit" -------------
+// (GET /api/v1/sites)
+func (_ Unimplemented) ListSites(w http.ResponseWriter, r *http.Request, params ListSitesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
+// (POST /api/v1/sites)
+func (_ Unimplemented) CreateSite(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListCertificateSigningRequests(w, r, params)
-	}))
+// (DELETE /api/v1/sites/{name})
+func (_ Unimplemented) DeleteSite(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// (GET /api/v1/sites/{name})
+func (_ Unimplemented) ReadSite(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// (PUT /api/v1/sites/{name})
+func (_ Unimplemented) ReplaceSite(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// CreateCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) CreateCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+// (GET /api/v1/trash)
+func (_ Unimplemented) ListTrash(w http.ResponseWriter, r *http.Request, params ListTrashParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateCertificateSigningRequest(w, r)
-	}))
+// (DELETE /api/v1/webhooks)
+func (_ Unimplemented) DeleteWebhooks(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+// (GET /api/v1/webhooks)
+func (_ Unimplemented) ListWebhooks(w http.ResponseWriter, r *http.Request, params ListWebhooksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+// (POST /api/v1/webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// DeleteCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+// (DELETE /api/v1/webhooks/{name})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /api/v1/webhooks/{name})
+func (_ Unimplemented) ReadWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /api/v1/webhooks/{name})
+func (_ Unimplemented) ReplaceWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListArtifacts operation middleware
+func (siw *ServerInterfaceWrapper) ListArtifacts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListArtifactsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteCertificateSigningRequest(w, r, name)
+		siw.Handler.ListArtifacts(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -660,49 +1157,62 @@ func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequest(w http.Respon
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) ReadCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+// DownloadArtifact operation middleware
+func (siw *ServerInterfaceWrapper) DownloadArtifact(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DownloadArtifactParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadCertificateSigningRequest(w, r, name)
-	}))
+	// ------------- Required query parameter "expires" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	if paramValue := r.URL.Query().Get("expires"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "expires"})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	err = runtime.BindQueryParameter("form", true, true, "expires", r.URL.Query(), &params.Expires)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires", Err: err})
+		return
+	}
 
-// PatchCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) PatchCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	// ------------- Required query parameter "signature" -------------
 
-	var err error
+	if paramValue := r.URL.Query().Get("signature"); paramValue != "" {
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "signature"})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, true, "signature", r.URL.Query(), &params.Signature)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "signature", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchCertificateSigningRequest(w, r, name)
+		siw.Handler.DownloadArtifact(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -712,23 +1222,32 @@ func (siw *ServerInterfaceWrapper) PatchCertificateSigningRequest(w http.Respons
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+// CreateArtifactDownloadUrl operation middleware
+func (siw *ServerInterfaceWrapper) CreateArtifactDownloadUrl(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateArtifactDownloadUrlParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "key" -------------
+
+	if paramValue := r.URL.Query().Get("key"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "key"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "key", r.URL.Query(), &params.Key)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceCertificateSigningRequest(w, r, name)
+		siw.Handler.CreateArtifactDownloadUrl(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -738,23 +1257,12 @@ func (siw *ServerInterfaceWrapper) ReplaceCertificateSigningRequest(w http.Respo
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DenyCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) DenyCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+// AuthConfig operation middleware
+func (siw *ServerInterfaceWrapper) AuthConfig(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "name" -------------
-	var name string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DenyCertificateSigningRequest(w, r, name)
+		siw.Handler.AuthConfig(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -764,23 +1272,38 @@ func (siw *ServerInterfaceWrapper) DenyCertificateSigningRequest(w http.Response
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ApproveCertificateSigningRequest operation middleware
-func (siw *ServerInterfaceWrapper) ApproveCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+// AuthValidate operation middleware
+func (siw *ServerInterfaceWrapper) AuthValidate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthValidateParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Authentication" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Authentication")]; found {
+		var Authentication string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Authentication", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Authentication", valueList[0], &Authentication, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Authentication", Err: err})
+			return
+		}
+
+		params.Authentication = &Authentication
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ApproveCertificateSigningRequest(w, r, name)
+		siw.Handler.AuthValidate(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -790,12 +1313,12 @@ func (siw *ServerInterfaceWrapper) ApproveCertificateSigningRequest(w http.Respo
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteDevices operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDevices(w http.ResponseWriter, r *http.Request) {
+// CreateBulkImport operation middleware
+func (siw *ServerInterfaceWrapper) CreateBulkImport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteDevices(w, r)
+		siw.Handler.CreateBulkImport(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -805,57 +1328,33 @@ func (siw *ServerInterfaceWrapper) DeleteDevices(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListDevices operation middleware
-func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Request) {
+// ListIssuedCertificates operation middleware
+func (siw *ServerInterfaceWrapper) ListIssuedCertificates(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListDevicesParams
-
-	// ------------- Optional query parameter "continue" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "labelSelector" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "statusFilter" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "statusFilter", r.URL.Query(), &params.StatusFilter)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "statusFilter", Err: err})
-		return
-	}
+	var params ListIssuedCertificatesParams
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "expiringWithin" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "expiringWithin", r.URL.Query(), &params.ExpiringWithin)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expiringWithin", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "owner" -------------
+	// ------------- Optional query parameter "device" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
+	err = runtime.BindQueryParameter("form", true, false, "device", r.URL.Query(), &params.Device)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListDevices(w, r, params)
+		siw.Handler.ListIssuedCertificates(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -865,12 +1364,12 @@ func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateDevice operation middleware
-func (siw *ServerInterfaceWrapper) CreateDevice(w http.ResponseWriter, r *http.Request) {
+// DeleteCertificateSigningRequests operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateDevice(w, r)
+		siw.Handler.DeleteCertificateSigningRequests(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -880,23 +1379,41 @@ func (siw *ServerInterfaceWrapper) CreateDevice(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteDevice operation middleware
-func (siw *ServerInterfaceWrapper) DeleteDevice(w http.ResponseWriter, r *http.Request) {
+// ListCertificateSigningRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCertificateSigningRequestsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteDevice(w, r, name)
+		siw.Handler.ListCertificateSigningRequests(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -906,23 +1423,12 @@ func (siw *ServerInterfaceWrapper) DeleteDevice(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadDevice operation middleware
-func (siw *ServerInterfaceWrapper) ReadDevice(w http.ResponseWriter, r *http.Request) {
+// CreateCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreateCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "name" -------------
-	var name string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadDevice(w, r, name)
+		siw.Handler.CreateCertificateSigningRequest(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -932,8 +1438,8 @@ func (siw *ServerInterfaceWrapper) ReadDevice(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchDevice operation middleware
-func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Request) {
+// DeleteCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -948,7 +1454,7 @@ func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchDevice(w, r, name)
+		siw.Handler.DeleteCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -958,8 +1464,8 @@ func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceDevice operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceDevice(w http.ResponseWriter, r *http.Request) {
+// ReadCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) ReadCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -974,7 +1480,7 @@ func (siw *ServerInterfaceWrapper) ReplaceDevice(w http.ResponseWriter, r *http.
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceDevice(w, r, name)
+		siw.Handler.ReadCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -984,8 +1490,8 @@ func (siw *ServerInterfaceWrapper) ReplaceDevice(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// RequestConsole operation middleware
-func (siw *ServerInterfaceWrapper) RequestConsole(w http.ResponseWriter, r *http.Request) {
+// PatchCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) PatchCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1000,7 +1506,7 @@ func (siw *ServerInterfaceWrapper) RequestConsole(w http.ResponseWriter, r *http
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RequestConsole(w, r, name)
+		siw.Handler.PatchCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1010,8 +1516,8 @@ func (siw *ServerInterfaceWrapper) RequestConsole(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetRenderedDeviceSpec operation middleware
-func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request) {
+// ReplaceCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1025,19 +1531,8 @@ func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter,
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetRenderedDeviceSpecParams
-
-	// ------------- Optional query parameter "knownRenderedVersion" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "knownRenderedVersion", r.URL.Query(), &params.KnownRenderedVersion)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "knownRenderedVersion", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRenderedDeviceSpec(w, r, name, params)
+		siw.Handler.ReplaceCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1047,8 +1542,8 @@ func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadDeviceStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReadDeviceStatus(w http.ResponseWriter, r *http.Request) {
+// DenyCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) DenyCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1063,7 +1558,7 @@ func (siw *ServerInterfaceWrapper) ReadDeviceStatus(w http.ResponseWriter, r *ht
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadDeviceStatus(w, r, name)
+		siw.Handler.DenyCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1073,8 +1568,8 @@ func (siw *ServerInterfaceWrapper) ReadDeviceStatus(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceDeviceStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request) {
+// ApproveCertificateSigningRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1089,7 +1584,7 @@ func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceDeviceStatus(w, r, name)
+		siw.Handler.ApproveCertificateSigningRequest(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1099,23 +1594,47 @@ func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// EnrollmentConfig operation middleware
-func (siw *ServerInterfaceWrapper) EnrollmentConfig(w http.ResponseWriter, r *http.Request) {
+// ReadDependencies operation middleware
+func (siw *ServerInterfaceWrapper) ReadDependencies(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadDependenciesParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Required query parameter "kind" -------------
+
+	if paramValue := r.URL.Query().Get("kind"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "kind"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "name" -------------
+
+	if paramValue := r.URL.Query().Get("name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "name", r.URL.Query(), &params.Name)
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.EnrollmentConfig(w, r, name)
+		siw.Handler.ReadDependencies(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1125,12 +1644,12 @@ func (siw *ServerInterfaceWrapper) EnrollmentConfig(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteEnrollmentRequests operation middleware
-func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
+// DeleteDeviceIdentities operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeviceIdentities(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteEnrollmentRequests(w, r)
+		siw.Handler.DeleteDeviceIdentities(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1140,14 +1659,14 @@ func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequests(w http.ResponseWrite
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListEnrollmentRequests operation middleware
-func (siw *ServerInterfaceWrapper) ListEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
+// ListDeviceIdentities operation middleware
+func (siw *ServerInterfaceWrapper) ListDeviceIdentities(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListEnrollmentRequestsParams
+	var params ListDeviceIdentitiesParams
 
 	// ------------- Optional query parameter "continue" -------------
 
@@ -1174,7 +1693,7 @@ func (siw *ServerInterfaceWrapper) ListEnrollmentRequests(w http.ResponseWriter,
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListEnrollmentRequests(w, r, params)
+		siw.Handler.ListDeviceIdentities(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1184,12 +1703,12 @@ func (siw *ServerInterfaceWrapper) ListEnrollmentRequests(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateEnrollmentRequest operation middleware
-func (siw *ServerInterfaceWrapper) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+// CreateDeviceIdentity operation middleware
+func (siw *ServerInterfaceWrapper) CreateDeviceIdentity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateEnrollmentRequest(w, r)
+		siw.Handler.CreateDeviceIdentity(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1199,8 +1718,8 @@ func (siw *ServerInterfaceWrapper) CreateEnrollmentRequest(w http.ResponseWriter
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteEnrollmentRequest operation middleware
-func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+// DeleteDeviceIdentity operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeviceIdentity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1215,7 +1734,7 @@ func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequest(w http.ResponseWriter
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteEnrollmentRequest(w, r, name)
+		siw.Handler.DeleteDeviceIdentity(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1225,8 +1744,8 @@ func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequest(w http.ResponseWriter
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadEnrollmentRequest operation middleware
-func (siw *ServerInterfaceWrapper) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+// ReadDeviceIdentity operation middleware
+func (siw *ServerInterfaceWrapper) ReadDeviceIdentity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1241,7 +1760,7 @@ func (siw *ServerInterfaceWrapper) ReadEnrollmentRequest(w http.ResponseWriter,
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadEnrollmentRequest(w, r, name)
+		siw.Handler.ReadDeviceIdentity(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1251,8 +1770,8 @@ func (siw *ServerInterfaceWrapper) ReadEnrollmentRequest(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceEnrollmentRequest operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+// ReplaceDeviceIdentity operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceIdentity(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1267,7 +1786,7 @@ func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequest(w http.ResponseWrite
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceEnrollmentRequest(w, r, name)
+		siw.Handler.ReplaceDeviceIdentity(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1277,23 +1796,12 @@ func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequest(w http.ResponseWrite
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ApproveEnrollmentRequest operation middleware
-func (siw *ServerInterfaceWrapper) ApproveEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+// DeleteDevices operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "name" -------------
-	var name string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ApproveEnrollmentRequest(w, r, name)
+		siw.Handler.DeleteDevices(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1303,116 +1811,89 @@ func (siw *ServerInterfaceWrapper) ApproveEnrollmentRequest(w http.ResponseWrite
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadEnrollmentRequestStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReadEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request) {
+// ListDevices operation middleware
+func (siw *ServerInterfaceWrapper) ListDevices(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "name" -------------
-	var name string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDevicesParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadEnrollmentRequestStatus(w, r, name)
-	}))
+	// ------------- Optional query parameter "labelSelector" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "statusFilter" -------------
 
-// ReplaceEnrollmentRequestStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	var err error
-
-	// ------------- Path parameter "name" -------------
-	var name string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "statusFilter", r.URL.Query(), &params.StatusFilter)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "statusFilter", Err: err})
 		return
 	}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceEnrollmentRequestStatus(w, r, name)
-	}))
+	// ------------- Optional query parameter "annotationSelector" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "annotationSelector", r.URL.Query(), &params.AnnotationSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "annotationSelector", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
-
-// DeleteFleets operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFleets(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteFleets(w, r)
-	}))
+	// ------------- Optional query parameter "limit" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
-
-// ListFleets operation middleware
-func (siw *ServerInterfaceWrapper) ListFleets(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListFleetsParams
-
-	// ------------- Optional query parameter "continue" -------------
+	// ------------- Optional query parameter "owner" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "labelSelector" -------------
+	// ------------- Optional query parameter "boundingBox" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	err = runtime.BindQueryParameter("form", true, false, "boundingBox", r.URL.Query(), &params.BoundingBox)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "boundingBox", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Optional query parameter "alias" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, false, "alias", r.URL.Query(), &params.Alias)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "alias", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "owner" -------------
+	// ------------- Optional query parameter "applicationRestarts" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
+	err = runtime.BindQueryParameter("form", true, false, "applicationRestarts", r.URL.Query(), &params.ApplicationRestarts)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "applicationRestarts", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListFleets(w, r, params)
+		siw.Handler.ListDevices(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1422,12 +1903,12 @@ func (siw *ServerInterfaceWrapper) ListFleets(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateFleet operation middleware
-func (siw *ServerInterfaceWrapper) CreateFleet(w http.ResponseWriter, r *http.Request) {
+// CreateDevice operation middleware
+func (siw *ServerInterfaceWrapper) CreateDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateFleet(w, r)
+		siw.Handler.CreateDevice(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1437,23 +1918,23 @@ func (siw *ServerInterfaceWrapper) CreateFleet(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteTemplateVersions operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTemplateVersions(w http.ResponseWriter, r *http.Request) {
+// DeleteDevice operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "fleet" -------------
-	var fleet string
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTemplateVersions(w, r, fleet)
+		siw.Handler.DeleteDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1463,50 +1944,23 @@ func (siw *ServerInterfaceWrapper) DeleteTemplateVersions(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListTemplateVersions operation middleware
-func (siw *ServerInterfaceWrapper) ListTemplateVersions(w http.ResponseWriter, r *http.Request) {
+// ReadDevice operation middleware
+func (siw *ServerInterfaceWrapper) ReadDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "fleet" -------------
-	var fleet string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
-		return
-	}
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTemplateVersionsParams
-
-	// ------------- Optional query parameter "continue" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "labelSelector" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTemplateVersions(w, r, fleet, params)
+		siw.Handler.ReadDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1516,21 +1970,12 @@ func (siw *ServerInterfaceWrapper) ListTemplateVersions(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteTemplateVersion operation middleware
-func (siw *ServerInterfaceWrapper) DeleteTemplateVersion(w http.ResponseWriter, r *http.Request) {
+// PatchDevice operation middleware
+func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "fleet" -------------
-	var fleet string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
-		return
-	}
-
 	// ------------- Path parameter "name" -------------
 	var name string
 
@@ -1541,7 +1986,7 @@ func (siw *ServerInterfaceWrapper) DeleteTemplateVersion(w http.ResponseWriter,
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTemplateVersion(w, r, fleet, name)
+		siw.Handler.PatchDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1551,21 +1996,12 @@ func (siw *ServerInterfaceWrapper) DeleteTemplateVersion(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadTemplateVersion operation middleware
-func (siw *ServerInterfaceWrapper) ReadTemplateVersion(w http.ResponseWriter, r *http.Request) {
+// ReplaceDevice operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "fleet" -------------
-	var fleet string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
-		return
-	}
-
 	// ------------- Path parameter "name" -------------
 	var name string
 
@@ -1576,7 +2012,7 @@ func (siw *ServerInterfaceWrapper) ReadTemplateVersion(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadTemplateVersion(w, r, fleet, name)
+		siw.Handler.ReplaceDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1586,8 +2022,8 @@ func (siw *ServerInterfaceWrapper) ReadTemplateVersion(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteFleet operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFleet(w http.ResponseWriter, r *http.Request) {
+// CancelDeviceAction operation middleware
+func (siw *ServerInterfaceWrapper) CancelDeviceAction(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1602,7 +2038,7 @@ func (siw *ServerInterfaceWrapper) DeleteFleet(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteFleet(w, r, name)
+		siw.Handler.CancelDeviceAction(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1612,8 +2048,8 @@ func (siw *ServerInterfaceWrapper) DeleteFleet(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadFleet operation middleware
-func (siw *ServerInterfaceWrapper) ReadFleet(w http.ResponseWriter, r *http.Request) {
+// RequestDeviceAction operation middleware
+func (siw *ServerInterfaceWrapper) RequestDeviceAction(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1627,19 +2063,8 @@ func (siw *ServerInterfaceWrapper) ReadFleet(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ReadFleetParams
-
-	// ------------- Optional query parameter "addDevicesSummary" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "addDevicesSummary", r.URL.Query(), &params.AddDevicesSummary)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "addDevicesSummary", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadFleet(w, r, name, params)
+		siw.Handler.RequestDeviceAction(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1649,8 +2074,8 @@ func (siw *ServerInterfaceWrapper) ReadFleet(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchFleet operation middleware
-func (siw *ServerInterfaceWrapper) PatchFleet(w http.ResponseWriter, r *http.Request) {
+// ReplaceDeviceAliases operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceAliases(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1665,7 +2090,7 @@ func (siw *ServerInterfaceWrapper) PatchFleet(w http.ResponseWriter, r *http.Req
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchFleet(w, r, name)
+		siw.Handler.ReplaceDeviceAliases(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1675,8 +2100,8 @@ func (siw *ServerInterfaceWrapper) PatchFleet(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceFleet operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceFleet(w http.ResponseWriter, r *http.Request) {
+// ResetDeviceAttestation operation middleware
+func (siw *ServerInterfaceWrapper) ResetDeviceAttestation(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1691,7 +2116,7 @@ func (siw *ServerInterfaceWrapper) ReplaceFleet(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceFleet(w, r, name)
+		siw.Handler.ResetDeviceAttestation(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1701,8 +2126,8 @@ func (siw *ServerInterfaceWrapper) ReplaceFleet(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadFleetStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReadFleetStatus(w http.ResponseWriter, r *http.Request) {
+// RequestConsole operation middleware
+func (siw *ServerInterfaceWrapper) RequestConsole(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1716,8 +2141,19 @@ func (siw *ServerInterfaceWrapper) ReadFleetStatus(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RequestConsoleParams
+
+	// ------------- Optional query parameter "publicKey" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "publicKey", r.URL.Query(), &params.PublicKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicKey", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadFleetStatus(w, r, name)
+		siw.Handler.RequestConsole(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1727,8 +2163,8 @@ func (siw *ServerInterfaceWrapper) ReadFleetStatus(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceFleetStatus operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request) {
+// RevokeConsoleGrant operation middleware
+func (siw *ServerInterfaceWrapper) RevokeConsoleGrant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1743,7 +2179,7 @@ func (siw *ServerInterfaceWrapper) ReplaceFleetStatus(w http.ResponseWriter, r *
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceFleetStatus(w, r, name)
+		siw.Handler.RevokeConsoleGrant(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1753,12 +2189,23 @@ func (siw *ServerInterfaceWrapper) ReplaceFleetStatus(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteRepositories operation middleware
-func (siw *ServerInterfaceWrapper) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
+// GetConsoleGrant operation middleware
+func (siw *ServerInterfaceWrapper) GetConsoleGrant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteRepositories(w, r)
+		siw.Handler.GetConsoleGrant(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1768,41 +2215,23 @@ func (siw *ServerInterfaceWrapper) DeleteRepositories(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListRepositories operation middleware
-func (siw *ServerInterfaceWrapper) ListRepositories(w http.ResponseWriter, r *http.Request) {
+// RequestConsoleGrant operation middleware
+func (siw *ServerInterfaceWrapper) RequestConsoleGrant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListRepositoriesParams
-
-	// ------------- Optional query parameter "continue" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "labelSelector" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListRepositories(w, r, params)
+		siw.Handler.RequestConsoleGrant(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1812,12 +2241,23 @@ func (siw *ServerInterfaceWrapper) ListRepositories(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateRepository operation middleware
-func (siw *ServerInterfaceWrapper) CreateRepository(w http.ResponseWriter, r *http.Request) {
+// ApproveConsoleGrant operation middleware
+func (siw *ServerInterfaceWrapper) ApproveConsoleGrant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateRepository(w, r)
+		siw.Handler.ApproveConsoleGrant(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1827,8 +2267,8 @@ func (siw *ServerInterfaceWrapper) CreateRepository(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteRepository operation middleware
-func (siw *ServerInterfaceWrapper) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+// ExecDeviceCommand operation middleware
+func (siw *ServerInterfaceWrapper) ExecDeviceCommand(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1843,7 +2283,7 @@ func (siw *ServerInterfaceWrapper) DeleteRepository(w http.ResponseWriter, r *ht
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteRepository(w, r, name)
+		siw.Handler.ExecDeviceCommand(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1853,8 +2293,8 @@ func (siw *ServerInterfaceWrapper) DeleteRepository(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadRepository operation middleware
-func (siw *ServerInterfaceWrapper) ReadRepository(w http.ResponseWriter, r *http.Request) {
+// PushDeviceMetrics operation middleware
+func (siw *ServerInterfaceWrapper) PushDeviceMetrics(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1869,7 +2309,7 @@ func (siw *ServerInterfaceWrapper) ReadRepository(w http.ResponseWriter, r *http
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadRepository(w, r, name)
+		siw.Handler.PushDeviceMetrics(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1879,8 +2319,8 @@ func (siw *ServerInterfaceWrapper) ReadRepository(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchRepository operation middleware
-func (siw *ServerInterfaceWrapper) PatchRepository(w http.ResponseWriter, r *http.Request) {
+// ReleaseDeviceQuarantine operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseDeviceQuarantine(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1895,7 +2335,7 @@ func (siw *ServerInterfaceWrapper) PatchRepository(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchRepository(w, r, name)
+		siw.Handler.ReleaseDeviceQuarantine(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1905,8 +2345,8 @@ func (siw *ServerInterfaceWrapper) PatchRepository(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceRepository operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceRepository(w http.ResponseWriter, r *http.Request) {
+// QuarantineDevice operation middleware
+func (siw *ServerInterfaceWrapper) QuarantineDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1921,7 +2361,7 @@ func (siw *ServerInterfaceWrapper) ReplaceRepository(w http.ResponseWriter, r *h
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceRepository(w, r, name)
+		siw.Handler.QuarantineDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1931,12 +2371,42 @@ func (siw *ServerInterfaceWrapper) ReplaceRepository(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteResourceSyncs operation middleware
-func (siw *ServerInterfaceWrapper) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
+// GetRenderedDeviceSpec operation middleware
+func (siw *ServerInterfaceWrapper) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRenderedDeviceSpecParams
+
+	// ------------- Optional query parameter "knownRenderedVersion" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "knownRenderedVersion", r.URL.Query(), &params.KnownRenderedVersion)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "knownRenderedVersion", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "specVersions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "specVersions", r.URL.Query(), &params.SpecVersions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specVersions", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteResourceSyncs(w, r)
+		siw.Handler.GetRenderedDeviceSpec(w, r, name, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1946,41 +2416,49 @@ func (siw *ServerInterfaceWrapper) DeleteResourceSyncs(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ListResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) ListResourceSync(w http.ResponseWriter, r *http.Request) {
+// RestoreDevice operation middleware
+func (siw *ServerInterfaceWrapper) RestoreDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListResourceSyncParams
-
-	// ------------- Optional query parameter "continue" -------------
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "labelSelector" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreDevice(w, r, name)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+// ReadDeviceStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReadDeviceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListResourceSync(w, r, params)
+		siw.Handler.ReadDeviceStatus(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1990,12 +2468,23 @@ func (siw *ServerInterfaceWrapper) ListResourceSync(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
+// ReplaceDeviceStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateResourceSync(w, r)
+		siw.Handler.ReplaceDeviceStatus(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2005,8 +2494,8 @@ func (siw *ServerInterfaceWrapper) CreateResourceSync(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) DeleteResourceSync(w http.ResponseWriter, r *http.Request) {
+// CloseDeviceTunnel operation middleware
+func (siw *ServerInterfaceWrapper) CloseDeviceTunnel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -2021,7 +2510,7 @@ func (siw *ServerInterfaceWrapper) DeleteResourceSync(w http.ResponseWriter, r *
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteResourceSync(w, r, name)
+		siw.Handler.CloseDeviceTunnel(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2031,8 +2520,8 @@ func (siw *ServerInterfaceWrapper) DeleteResourceSync(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReadResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) ReadResourceSync(w http.ResponseWriter, r *http.Request) {
+// GetDeviceTunnel operation middleware
+func (siw *ServerInterfaceWrapper) GetDeviceTunnel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -2047,7 +2536,7 @@ func (siw *ServerInterfaceWrapper) ReadResourceSync(w http.ResponseWriter, r *ht
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReadResourceSync(w, r, name)
+		siw.Handler.GetDeviceTunnel(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2057,8 +2546,8 @@ func (siw *ServerInterfaceWrapper) ReadResourceSync(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) PatchResourceSync(w http.ResponseWriter, r *http.Request) {
+// RequestDeviceTunnel operation middleware
+func (siw *ServerInterfaceWrapper) RequestDeviceTunnel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -2073,7 +2562,7 @@ func (siw *ServerInterfaceWrapper) PatchResourceSync(w http.ResponseWriter, r *h
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchResourceSync(w, r, name)
+		siw.Handler.RequestDeviceTunnel(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2083,8 +2572,8 @@ func (siw *ServerInterfaceWrapper) PatchResourceSync(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// ReplaceResourceSync operation middleware
-func (siw *ServerInterfaceWrapper) ReplaceResourceSync(w http.ResponseWriter, r *http.Request) {
+// WakeDevice operation middleware
+func (siw *ServerInterfaceWrapper) WakeDevice(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -2099,7 +2588,7 @@ func (siw *ServerInterfaceWrapper) ReplaceResourceSync(w http.ResponseWriter, r
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReplaceResourceSync(w, r, name)
+		siw.Handler.WakeDevice(w, r, name)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2109,184 +2598,2649 @@ func (siw *ServerInterfaceWrapper) ReplaceResourceSync(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// ReplaceDeviceStatuses operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceDeviceStatuses(w, r)
+	}))
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+// DeleteDeviceViews operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeviceViews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDeviceViews(w, r)
+	}))
 
-type RequiredParamError struct {
-	ParamName string
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+// ListDeviceViews operation middleware
+func (siw *ServerInterfaceWrapper) ListDeviceViews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	var err error
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeviceViewsParams
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "continue" -------------
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "labelSelector" -------------
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	// ------------- Optional query parameter "limit" -------------
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeviceViews(w, r, params)
+	}))
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// CreateDeviceView operation middleware
+func (siw *ServerInterfaceWrapper) CreateDeviceView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	if r == nil {
-		r = chi.NewRouter()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDeviceView(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteDeviceView operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDeviceView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/auth/config", wrapper.AuthConfig)
-	})
-	r.Group(func(r chi.Router) {
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDeviceView(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadDeviceView operation middleware
+func (siw *ServerInterfaceWrapper) ReadDeviceView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadDeviceView(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceDeviceView operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceDeviceView(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceDeviceView(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListDeviceViewDevices operation middleware
+func (siw *ServerInterfaceWrapper) ListDeviceViewDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListDeviceViewDevicesParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListDeviceViewDevices(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// EnrollmentConfig operation middleware
+func (siw *ServerInterfaceWrapper) EnrollmentConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnrollmentConfig(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteEnrollmentRequests operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEnrollmentRequests(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListEnrollmentRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListEnrollmentRequestsParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListEnrollmentRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateEnrollmentRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateEnrollmentRequest(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteEnrollmentRequest operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEnrollmentRequest(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadEnrollmentRequest operation middleware
+func (siw *ServerInterfaceWrapper) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadEnrollmentRequest(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceEnrollmentRequest operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceEnrollmentRequest(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ApproveEnrollmentRequest operation middleware
+func (siw *ServerInterfaceWrapper) ApproveEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveEnrollmentRequest(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadEnrollmentRequestStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReadEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadEnrollmentRequestStatus(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceEnrollmentRequestStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceEnrollmentRequestStatus(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateResourceExport operation middleware
+func (siw *ServerInterfaceWrapper) CreateResourceExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateResourceExport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteFleets operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFleets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFleets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListFleets operation middleware
+func (siw *ServerInterfaceWrapper) ListFleets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListFleetsParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "owner" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "owner", r.URL.Query(), &params.Owner)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "owner", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFleets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateFleet operation middleware
+func (siw *ServerInterfaceWrapper) CreateFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateFleet(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTemplateVersions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fleet" -------------
+	var fleet string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTemplateVersions(w, r, fleet)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListTemplateVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListTemplateVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fleet" -------------
+	var fleet string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTemplateVersionsParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTemplateVersions(w, r, fleet, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteTemplateVersion operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTemplateVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fleet" -------------
+	var fleet string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTemplateVersion(w, r, fleet, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadTemplateVersion operation middleware
+func (siw *ServerInterfaceWrapper) ReadTemplateVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fleet" -------------
+	var fleet string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fleet", chi.URLParam(r, "fleet"), &fleet, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fleet", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadTemplateVersion(w, r, fleet, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteFleet operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteFleetParams
+
+	// ------------- Optional query parameter "propagationPolicy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "propagationPolicy", r.URL.Query(), &params.PropagationPolicy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "propagationPolicy", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFleet(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadFleet operation middleware
+func (siw *ServerInterfaceWrapper) ReadFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadFleetParams
+
+	// ------------- Optional query parameter "addDevicesSummary" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "addDevicesSummary", r.URL.Query(), &params.AddDevicesSummary)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "addDevicesSummary", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadFleet(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchFleet operation middleware
+func (siw *ServerInterfaceWrapper) PatchFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchFleet(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceFleet operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceFleet(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LintFleet operation middleware
+func (siw *ServerInterfaceWrapper) LintFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LintFleet(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CancelFleetMigration operation middleware
+func (siw *ServerInterfaceWrapper) CancelFleetMigration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelFleetMigration(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// MigrateFleetDevices operation middleware
+func (siw *ServerInterfaceWrapper) MigrateFleetDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MigrateFleetDevices(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ApproveFleetPromotion operation middleware
+func (siw *ServerInterfaceWrapper) ApproveFleetPromotion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveFleetPromotion(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadFleetProvisioning operation middleware
+func (siw *ServerInterfaceWrapper) ReadFleetProvisioning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReadFleetProvisioningParams
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "validity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "validity", r.URL.Query(), &params.Validity)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "validity", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadFleetProvisioning(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListProvisioningTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListProvisioningTokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProvisioningTokens(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateProvisioningToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateProvisioningToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProvisioningToken(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteProvisioningToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProvisioningToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProvisioningToken(w, r, name, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadFleetReport operation middleware
+func (siw *ServerInterfaceWrapper) ReadFleetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadFleetReport(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RestoreFleet operation middleware
+func (siw *ServerInterfaceWrapper) RestoreFleet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestoreFleet(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AbortFleetRollout operation middleware
+func (siw *ServerInterfaceWrapper) AbortFleetRollout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AbortFleetRollout(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PauseFleetRollout operation middleware
+func (siw *ServerInterfaceWrapper) PauseFleetRollout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseFleetRollout(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ResumeFleetRollout operation middleware
+func (siw *ServerInterfaceWrapper) ResumeFleetRollout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResumeFleetRollout(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadFleetStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReadFleetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadFleetStatus(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceFleetStatus operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceFleetStatus(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateResourceImport operation middleware
+func (siw *ServerInterfaceWrapper) CreateResourceImport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateResourceImport(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteLabelRules operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabelRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLabelRules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListLabelRules operation middleware
+func (siw *ServerInterfaceWrapper) ListLabelRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListLabelRulesParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListLabelRules(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateLabelRule operation middleware
+func (siw *ServerInterfaceWrapper) CreateLabelRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateLabelRule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteLabelRule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLabelRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteLabelRule(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadLabelRule operation middleware
+func (siw *ServerInterfaceWrapper) ReadLabelRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadLabelRule(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceLabelRule operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceLabelRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceLabelRule(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListOperations operation middleware
+func (siw *ServerInterfaceWrapper) ListOperations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOperationsParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOperations(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateOperation operation middleware
+func (siw *ServerInterfaceWrapper) CreateOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateOperation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteOperation operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteOperation(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadOperation operation middleware
+func (siw *ServerInterfaceWrapper) ReadOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadOperation(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CancelOperation operation middleware
+func (siw *ServerInterfaceWrapper) CancelOperation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelOperation(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepositories operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepositories(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListRepositories operation middleware
+func (siw *ServerInterfaceWrapper) ListRepositories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListRepositoriesParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRepositories(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateRepository operation middleware
+func (siw *ServerInterfaceWrapper) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRepository(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteRepository operation middleware
+func (siw *ServerInterfaceWrapper) DeleteRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteRepositoryParams
+
+	// ------------- Optional query parameter "propagationPolicy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "propagationPolicy", r.URL.Query(), &params.PropagationPolicy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "propagationPolicy", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteRepository(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadRepository operation middleware
+func (siw *ServerInterfaceWrapper) ReadRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadRepository(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchRepository operation middleware
+func (siw *ServerInterfaceWrapper) PatchRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchRepository(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceRepository operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceRepository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceRepository(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RotateRepositoryCredentials operation middleware
+func (siw *ServerInterfaceWrapper) RotateRepositoryCredentials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateRepositoryCredentials(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteResourceSyncs operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteResourceSyncs(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) ListResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListResourceSyncParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListResourceSync(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateResourceSync(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) DeleteResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteResourceSync(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) ReadResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadResourceSync(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PatchResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) PatchResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchResourceSync(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceResourceSync operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceResourceSync(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceResourceSync(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListImageSboms operation middleware
+func (siw *ServerInterfaceWrapper) ListImageSboms(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListImageSbomsParams
+
+	// ------------- Optional query parameter "device" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device", r.URL.Query(), &params.Device)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "device", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "component" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "component", r.URL.Query(), &params.Component)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "component", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", r.URL.Query(), &params.Version)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "version", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "vulnerability" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "vulnerability", r.URL.Query(), &params.Vulnerability)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "vulnerability", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListImageSboms(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSites operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSites(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListSites operation middleware
+func (siw *ServerInterfaceWrapper) ListSites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSitesParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSites(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateSite operation middleware
+func (siw *ServerInterfaceWrapper) CreateSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSite(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSite operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSite(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadSite operation middleware
+func (siw *ServerInterfaceWrapper) ReadSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadSite(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceSite operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceSite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceSite(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListTrash operation middleware
+func (siw *ServerInterfaceWrapper) ListTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTrashParams
+
+	// ------------- Optional query parameter "kind" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", r.URL.Query(), &params.Kind)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "kind", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTrash(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhooks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhooksParams
+
+	// ------------- Optional query parameter "continue" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "continue", r.URL.Query(), &params.Continue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "continue", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "labelSelector" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "labelSelector", r.URL.Query(), &params.LabelSelector)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "labelSelector", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhooks(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWebhook(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWebhook(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReadWebhook operation middleware
+func (siw *ServerInterfaceWrapper) ReadWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadWebhook(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReplaceWebhook operation middleware
+func (siw *ServerInterfaceWrapper) ReplaceWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplaceWebhook(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/artifacts", wrapper.ListArtifacts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/artifacts/download", wrapper.DownloadArtifact)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/artifacts/downloadurl", wrapper.CreateArtifactDownloadUrl)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/auth/config", wrapper.AuthConfig)
+	})
+	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/auth/validate", wrapper.AuthValidate)
 	})
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.DeleteCertificateSigningRequests)
+		r.Post(options.BaseURL+"/api/v1/bulkimports", wrapper.CreateBulkImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/certificates", wrapper.ListIssuedCertificates)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.DeleteCertificateSigningRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.ListCertificateSigningRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.CreateCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.DeleteCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.ReadCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.PatchCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.ReplaceCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests/{name}/approval", wrapper.DenyCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/certificatesigningrequests/{name}/approval", wrapper.ApproveCertificateSigningRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/dependencies", wrapper.ReadDependencies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/deviceidentities", wrapper.DeleteDeviceIdentities)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/deviceidentities", wrapper.ListDeviceIdentities)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/deviceidentities", wrapper.CreateDeviceIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/deviceidentities/{name}", wrapper.DeleteDeviceIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/deviceidentities/{name}", wrapper.ReadDeviceIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/deviceidentities/{name}", wrapper.ReplaceDeviceIdentity)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices", wrapper.DeleteDevices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/devices", wrapper.ListDevices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices", wrapper.CreateDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}", wrapper.DeleteDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/devices/{name}", wrapper.ReadDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/api/v1/devices/{name}", wrapper.PatchDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}", wrapper.ReplaceDevice)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/action", wrapper.CancelDeviceAction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/action", wrapper.RequestDeviceAction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/aliases", wrapper.ReplaceDeviceAliases)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/attestation", wrapper.ResetDeviceAttestation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/console", wrapper.RequestConsole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/consolegrant", wrapper.RevokeConsoleGrant)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.ListCertificateSigningRequests)
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/consolegrant", wrapper.GetConsoleGrant)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/api/v1/certificatesigningrequests", wrapper.CreateCertificateSigningRequest)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/consolegrant", wrapper.RequestConsoleGrant)
 	})
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.DeleteCertificateSigningRequest)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/consolegrant/approval", wrapper.ApproveConsoleGrant)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.ReadCertificateSigningRequest)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/exec", wrapper.ExecDeviceCommand)
 	})
 	r.Group(func(r chi.Router) {
-		r.Patch(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.PatchCertificateSigningRequest)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/metrics", wrapper.PushDeviceMetrics)
 	})
 	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/api/v1/certificatesigningrequests/{name}", wrapper.ReplaceCertificateSigningRequest)
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/quarantine", wrapper.ReleaseDeviceQuarantine)
 	})
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/api/v1/certificatesigningrequests/{name}/approval", wrapper.DenyCertificateSigningRequest)
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/quarantine", wrapper.QuarantineDevice)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/api/v1/certificatesigningrequests/{name}/approval", wrapper.ApproveCertificateSigningRequest)
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/rendered", wrapper.GetRenderedDeviceSpec)
 	})
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/api/v1/devices", wrapper.DeleteDevices)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/restore", wrapper.RestoreDevice)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/devices", wrapper.ListDevices)
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReadDeviceStatus)
 	})
 	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/api/v1/devices", wrapper.CreateDevice)
+		r.Put(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReplaceDeviceStatus)
 	})
 	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/api/v1/devices/{name}", wrapper.DeleteDevice)
+		r.Delete(options.BaseURL+"/api/v1/devices/{name}/tunnel", wrapper.CloseDeviceTunnel)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/devices/{name}", wrapper.ReadDevice)
+		r.Get(options.BaseURL+"/api/v1/devices/{name}/tunnel", wrapper.GetDeviceTunnel)
 	})
 	r.Group(func(r chi.Router) {
-		r.Patch(options.BaseURL+"/api/v1/devices/{name}", wrapper.PatchDevice)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/tunnel", wrapper.RequestDeviceTunnel)
 	})
 	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/api/v1/devices/{name}", wrapper.ReplaceDevice)
+		r.Post(options.BaseURL+"/api/v1/devices/{name}/wake", wrapper.WakeDevice)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/devices/{name}/console", wrapper.RequestConsole)
+		r.Put(options.BaseURL+"/api/v1/devicestatuses", wrapper.ReplaceDeviceStatuses)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/devices/{name}/rendered", wrapper.GetRenderedDeviceSpec)
+		r.Delete(options.BaseURL+"/api/v1/deviceviews", wrapper.DeleteDeviceViews)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReadDeviceStatus)
+		r.Get(options.BaseURL+"/api/v1/deviceviews", wrapper.ListDeviceViews)
 	})
 	r.Group(func(r chi.Router) {
-		r.Put(options.BaseURL+"/api/v1/devices/{name}/status", wrapper.ReplaceDeviceStatus)
+		r.Post(options.BaseURL+"/api/v1/deviceviews", wrapper.CreateDeviceView)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/deviceviews/{name}", wrapper.DeleteDeviceView)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/deviceviews/{name}", wrapper.ReadDeviceView)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/deviceviews/{name}", wrapper.ReplaceDeviceView)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/deviceviews/{name}/devices", wrapper.ListDeviceViewDevices)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/enrollmentconfig/{name}", wrapper.EnrollmentConfig)
@@ -2318,6 +5272,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/enrollmentrequests/{name}/status", wrapper.ReplaceEnrollmentRequestStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/exports", wrapper.CreateResourceExport)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/fleets", wrapper.DeleteFleets)
 	})
@@ -2351,12 +5308,87 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/fleets/{name}", wrapper.ReplaceFleet)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/fleets/{name}/lint", wrapper.LintFleet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/fleets/{name}/migration", wrapper.CancelFleetMigration)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/fleets/{name}/migration", wrapper.MigrateFleetDevices)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/fleets/{name}/promotion/approve", wrapper.ApproveFleetPromotion)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/fleets/{name}/provisioning", wrapper.ReadFleetProvisioning)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/fleets/{name}/provisioningtokens", wrapper.ListProvisioningTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/fleets/{name}/provisioningtokens", wrapper.CreateProvisioningToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/fleets/{name}/provisioningtokens/{id}", wrapper.DeleteProvisioningToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/fleets/{name}/report", wrapper.ReadFleetReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/fleets/{name}/restore", wrapper.RestoreFleet)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/fleets/{name}/rollout/abort", wrapper.AbortFleetRollout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/fleets/{name}/rollout/pause", wrapper.PauseFleetRollout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/fleets/{name}/rollout/resume", wrapper.ResumeFleetRollout)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/fleets/{name}/status", wrapper.ReadFleetStatus)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/fleets/{name}/status", wrapper.ReplaceFleetStatus)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/imports", wrapper.CreateResourceImport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/labelrules", wrapper.DeleteLabelRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/labelrules", wrapper.ListLabelRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/labelrules", wrapper.CreateLabelRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/labelrules/{name}", wrapper.DeleteLabelRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/labelrules/{name}", wrapper.ReadLabelRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/labelrules/{name}", wrapper.ReplaceLabelRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/operations", wrapper.ListOperations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/operations", wrapper.CreateOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/operations/{name}", wrapper.DeleteOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/operations/{name}", wrapper.ReadOperation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/operations/{name}/cancel", wrapper.CancelOperation)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/repositories", wrapper.DeleteRepositories)
 	})
@@ -2378,6 +5410,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/repositories/{name}", wrapper.ReplaceRepository)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/repositories/{name}/credentials", wrapper.RotateRepositoryCredentials)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/api/v1/resourcesyncs", wrapper.DeleteResourceSyncs)
 	})
@@ -2399,2688 +5434,8592 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/api/v1/resourcesyncs/{name}", wrapper.ReplaceResourceSync)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/sboms", wrapper.ListImageSboms)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/sites", wrapper.DeleteSites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/sites", wrapper.ListSites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/sites", wrapper.CreateSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/sites/{name}", wrapper.DeleteSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/sites/{name}", wrapper.ReadSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/sites/{name}", wrapper.ReplaceSite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/trash", wrapper.ListTrash)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/webhooks", wrapper.DeleteWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/webhooks", wrapper.ListWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/webhooks/{name}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/webhooks/{name}", wrapper.ReadWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/api/v1/webhooks/{name}", wrapper.ReplaceWebhook)
+	})
+
+	return r
+}
+
+type ListArtifactsRequestObject struct {
+	Params ListArtifactsParams
+}
+
+type ListArtifactsResponseObject interface {
+	VisitListArtifactsResponse(w http.ResponseWriter) error
+}
+
+type ListArtifacts200JSONResponse ArtifactList
+
+func (response ListArtifacts200JSONResponse) VisitListArtifactsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListArtifacts401JSONResponse Error
+
+func (response ListArtifacts401JSONResponse) VisitListArtifactsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListArtifacts503JSONResponse Error
+
+func (response ListArtifacts503JSONResponse) VisitListArtifactsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadArtifactRequestObject struct {
+	Params DownloadArtifactParams
+}
+
+type DownloadArtifactResponseObject interface {
+	VisitDownloadArtifactResponse(w http.ResponseWriter) error
+}
+
+type DownloadArtifact200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response DownloadArtifact200ApplicationoctetStreamResponse) VisitDownloadArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type DownloadArtifact403JSONResponse Error
+
+func (response DownloadArtifact403JSONResponse) VisitDownloadArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadArtifact404JSONResponse Error
+
+func (response DownloadArtifact404JSONResponse) VisitDownloadArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DownloadArtifact503JSONResponse Error
+
+func (response DownloadArtifact503JSONResponse) VisitDownloadArtifactResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateArtifactDownloadUrlRequestObject struct {
+	Params CreateArtifactDownloadUrlParams
+}
+
+type CreateArtifactDownloadUrlResponseObject interface {
+	VisitCreateArtifactDownloadUrlResponse(w http.ResponseWriter) error
+}
+
+type CreateArtifactDownloadUrl200JSONResponse ArtifactDownloadUrl
+
+func (response CreateArtifactDownloadUrl200JSONResponse) VisitCreateArtifactDownloadUrlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateArtifactDownloadUrl401JSONResponse Error
+
+func (response CreateArtifactDownloadUrl401JSONResponse) VisitCreateArtifactDownloadUrlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateArtifactDownloadUrl404JSONResponse Error
+
+func (response CreateArtifactDownloadUrl404JSONResponse) VisitCreateArtifactDownloadUrlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateArtifactDownloadUrl503JSONResponse Error
+
+func (response CreateArtifactDownloadUrl503JSONResponse) VisitCreateArtifactDownloadUrlResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthConfigRequestObject struct {
+}
+
+type AuthConfigResponseObject interface {
+	VisitAuthConfigResponse(w http.ResponseWriter) error
+}
+
+type AuthConfig200JSONResponse AuthConfig
+
+func (response AuthConfig200JSONResponse) VisitAuthConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthConfig418Response struct {
+}
+
+func (response AuthConfig418Response) VisitAuthConfigResponse(w http.ResponseWriter) error {
+	w.WriteHeader(418)
+	return nil
+}
+
+type AuthValidateRequestObject struct {
+	Params AuthValidateParams
+}
+
+type AuthValidateResponseObject interface {
+	VisitAuthValidateResponse(w http.ResponseWriter) error
+}
+
+type AuthValidate200Response struct {
+}
+
+func (response AuthValidate200Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type AuthValidate401Response struct {
+}
+
+func (response AuthValidate401Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
+type AuthValidate418Response struct {
+}
+
+func (response AuthValidate418Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
+	w.WriteHeader(418)
+	return nil
+}
+
+type CreateBulkImportRequestObject struct {
+	Body *CreateBulkImportJSONRequestBody
+}
+
+type CreateBulkImportResponseObject interface {
+	VisitCreateBulkImportResponse(w http.ResponseWriter) error
+}
+
+type CreateBulkImport200JSONResponse BulkImportResult
+
+func (response CreateBulkImport200JSONResponse) VisitCreateBulkImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBulkImport400JSONResponse Error
+
+func (response CreateBulkImport400JSONResponse) VisitCreateBulkImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateBulkImport401JSONResponse Error
+
+func (response CreateBulkImport401JSONResponse) VisitCreateBulkImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListIssuedCertificatesRequestObject struct {
+	Params ListIssuedCertificatesParams
+}
+
+type ListIssuedCertificatesResponseObject interface {
+	VisitListIssuedCertificatesResponse(w http.ResponseWriter) error
+}
+
+type ListIssuedCertificates200JSONResponse IssuedCertificateList
+
+func (response ListIssuedCertificates200JSONResponse) VisitListIssuedCertificatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListIssuedCertificates400JSONResponse Error
+
+func (response ListIssuedCertificates400JSONResponse) VisitListIssuedCertificatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListIssuedCertificates401JSONResponse Error
+
+func (response ListIssuedCertificates401JSONResponse) VisitListIssuedCertificatesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCertificateSigningRequestsRequestObject struct {
+}
+
+type DeleteCertificateSigningRequestsResponseObject interface {
+	VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error
+}
+
+type DeleteCertificateSigningRequests200JSONResponse Status
+
+func (response DeleteCertificateSigningRequests200JSONResponse) VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCertificateSigningRequests401JSONResponse Error
+
+func (response DeleteCertificateSigningRequests401JSONResponse) VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCertificateSigningRequestsRequestObject struct {
+	Params ListCertificateSigningRequestsParams
+}
+
+type ListCertificateSigningRequestsResponseObject interface {
+	VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error
+}
+
+type ListCertificateSigningRequests200JSONResponse CertificateSigningRequestList
+
+func (response ListCertificateSigningRequests200JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCertificateSigningRequests400JSONResponse Error
+
+func (response ListCertificateSigningRequests400JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListCertificateSigningRequests401JSONResponse Error
+
+func (response ListCertificateSigningRequests401JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequestRequestObject struct {
+	Body *CreateCertificateSigningRequestJSONRequestBody
+}
+
+type CreateCertificateSigningRequestResponseObject interface {
+	VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type CreateCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response CreateCertificateSigningRequest200JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequest201JSONResponse CertificateSigningRequest
+
+func (response CreateCertificateSigningRequest201JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequest202JSONResponse CertificateSigningRequest
+
+func (response CreateCertificateSigningRequest202JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequest208JSONResponse EnrollmentRequest
+
+func (response CreateCertificateSigningRequest208JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(208)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequest400JSONResponse Error
+
+func (response CreateCertificateSigningRequest400JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCertificateSigningRequest401JSONResponse Error
+
+func (response CreateCertificateSigningRequest401JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteCertificateSigningRequestResponseObject interface {
+	VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type DeleteCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response DeleteCertificateSigningRequest200JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCertificateSigningRequest401JSONResponse Error
+
+func (response DeleteCertificateSigningRequest401JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCertificateSigningRequest404JSONResponse Error
+
+func (response DeleteCertificateSigningRequest404JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadCertificateSigningRequestResponseObject interface {
+	VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type ReadCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response ReadCertificateSigningRequest200JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadCertificateSigningRequest401JSONResponse Error
+
+func (response ReadCertificateSigningRequest401JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadCertificateSigningRequest404JSONResponse Error
+
+func (response ReadCertificateSigningRequest404JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+	Body *PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+}
+
+type PatchCertificateSigningRequestResponseObject interface {
+	VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type PatchCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response PatchCertificateSigningRequest200JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequest201JSONResponse CertificateSigningRequest
+
+func (response PatchCertificateSigningRequest201JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequest400JSONResponse Error
+
+func (response PatchCertificateSigningRequest400JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequest401JSONResponse Error
+
+func (response PatchCertificateSigningRequest401JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequest404JSONResponse Error
+
+func (response PatchCertificateSigningRequest404JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchCertificateSigningRequest409JSONResponse Error
+
+func (response PatchCertificateSigningRequest409JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceCertificateSigningRequestJSONRequestBody
+}
+
+type ReplaceCertificateSigningRequestResponseObject interface {
+	VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type ReplaceCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response ReplaceCertificateSigningRequest200JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequest201JSONResponse CertificateSigningRequest
+
+func (response ReplaceCertificateSigningRequest201JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequest400JSONResponse Error
+
+func (response ReplaceCertificateSigningRequest400JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequest401JSONResponse Error
+
+func (response ReplaceCertificateSigningRequest401JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequest404JSONResponse Error
+
+func (response ReplaceCertificateSigningRequest404JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceCertificateSigningRequest409JSONResponse Error
+
+func (response ReplaceCertificateSigningRequest409JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DenyCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DenyCertificateSigningRequestResponseObject interface {
+	VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type DenyCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response DenyCertificateSigningRequest200JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DenyCertificateSigningRequest401JSONResponse Error
+
+func (response DenyCertificateSigningRequest401JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DenyCertificateSigningRequest404JSONResponse Error
+
+func (response DenyCertificateSigningRequest404JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DenyCertificateSigningRequest409JSONResponse Error
+
+func (response DenyCertificateSigningRequest409JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCertificateSigningRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ApproveCertificateSigningRequestResponseObject interface {
+	VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error
+}
+
+type ApproveCertificateSigningRequest200JSONResponse CertificateSigningRequest
+
+func (response ApproveCertificateSigningRequest200JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCertificateSigningRequest401JSONResponse Error
+
+func (response ApproveCertificateSigningRequest401JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCertificateSigningRequest404JSONResponse Error
+
+func (response ApproveCertificateSigningRequest404JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveCertificateSigningRequest409JSONResponse Error
+
+func (response ApproveCertificateSigningRequest409JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDependenciesRequestObject struct {
+	Params ReadDependenciesParams
+}
+
+type ReadDependenciesResponseObject interface {
+	VisitReadDependenciesResponse(w http.ResponseWriter) error
+}
+
+type ReadDependencies200JSONResponse ResourceDependencies
+
+func (response ReadDependencies200JSONResponse) VisitReadDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDependencies400JSONResponse Error
+
+func (response ReadDependencies400JSONResponse) VisitReadDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDependencies401JSONResponse Error
+
+func (response ReadDependencies401JSONResponse) VisitReadDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDependencies404JSONResponse Error
+
+func (response ReadDependencies404JSONResponse) VisitReadDependenciesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceIdentitiesRequestObject struct {
+}
+
+type DeleteDeviceIdentitiesResponseObject interface {
+	VisitDeleteDeviceIdentitiesResponse(w http.ResponseWriter) error
+}
+
+type DeleteDeviceIdentities200JSONResponse Status
+
+func (response DeleteDeviceIdentities200JSONResponse) VisitDeleteDeviceIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceIdentities401JSONResponse Error
+
+func (response DeleteDeviceIdentities401JSONResponse) VisitDeleteDeviceIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceIdentitiesRequestObject struct {
+	Params ListDeviceIdentitiesParams
+}
+
+type ListDeviceIdentitiesResponseObject interface {
+	VisitListDeviceIdentitiesResponse(w http.ResponseWriter) error
+}
+
+type ListDeviceIdentities200JSONResponse DeviceIdentityList
+
+func (response ListDeviceIdentities200JSONResponse) VisitListDeviceIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceIdentities400JSONResponse Error
+
+func (response ListDeviceIdentities400JSONResponse) VisitListDeviceIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceIdentities401JSONResponse Error
+
+func (response ListDeviceIdentities401JSONResponse) VisitListDeviceIdentitiesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceIdentityRequestObject struct {
+	Body *CreateDeviceIdentityJSONRequestBody
+}
+
+type CreateDeviceIdentityResponseObject interface {
+	VisitCreateDeviceIdentityResponse(w http.ResponseWriter) error
+}
+
+type CreateDeviceIdentity201JSONResponse DeviceIdentity
+
+func (response CreateDeviceIdentity201JSONResponse) VisitCreateDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceIdentity400JSONResponse Error
+
+func (response CreateDeviceIdentity400JSONResponse) VisitCreateDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceIdentity401JSONResponse Error
+
+func (response CreateDeviceIdentity401JSONResponse) VisitCreateDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceIdentity409JSONResponse Error
+
+func (response CreateDeviceIdentity409JSONResponse) VisitCreateDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceIdentityRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteDeviceIdentityResponseObject interface {
+	VisitDeleteDeviceIdentityResponse(w http.ResponseWriter) error
+}
+
+type DeleteDeviceIdentity200JSONResponse DeviceIdentity
+
+func (response DeleteDeviceIdentity200JSONResponse) VisitDeleteDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceIdentity401JSONResponse Error
+
+func (response DeleteDeviceIdentity401JSONResponse) VisitDeleteDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceIdentity404JSONResponse Error
+
+func (response DeleteDeviceIdentity404JSONResponse) VisitDeleteDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceIdentityRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadDeviceIdentityResponseObject interface {
+	VisitReadDeviceIdentityResponse(w http.ResponseWriter) error
+}
+
+type ReadDeviceIdentity200JSONResponse DeviceIdentity
+
+func (response ReadDeviceIdentity200JSONResponse) VisitReadDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceIdentity401JSONResponse Error
+
+func (response ReadDeviceIdentity401JSONResponse) VisitReadDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceIdentity404JSONResponse Error
+
+func (response ReadDeviceIdentity404JSONResponse) VisitReadDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentityRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceIdentityJSONRequestBody
+}
+
+type ReplaceDeviceIdentityResponseObject interface {
+	VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceIdentity200JSONResponse DeviceIdentity
+
+func (response ReplaceDeviceIdentity200JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentity201JSONResponse DeviceIdentity
+
+func (response ReplaceDeviceIdentity201JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentity400JSONResponse Error
+
+func (response ReplaceDeviceIdentity400JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentity401JSONResponse Error
+
+func (response ReplaceDeviceIdentity401JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentity404JSONResponse Error
+
+func (response ReplaceDeviceIdentity404JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceIdentity409JSONResponse Error
+
+func (response ReplaceDeviceIdentity409JSONResponse) VisitReplaceDeviceIdentityResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDevicesRequestObject struct {
+}
+
+type DeleteDevicesResponseObject interface {
+	VisitDeleteDevicesResponse(w http.ResponseWriter) error
+}
+
+type DeleteDevices200JSONResponse Status
+
+func (response DeleteDevices200JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDevices401JSONResponse Error
+
+func (response DeleteDevices401JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDevicesRequestObject struct {
+	Params ListDevicesParams
+}
+
+type ListDevicesResponseObject interface {
+	VisitListDevicesResponse(w http.ResponseWriter) error
+}
+
+type ListDevices200JSONResponse DeviceList
+
+func (response ListDevices200JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDevices400JSONResponse Error
+
+func (response ListDevices400JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDevices401JSONResponse Error
+
+func (response ListDevices401JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDevices403JSONResponse Error
+
+func (response ListDevices403JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceRequestObject struct {
+	Body *CreateDeviceJSONRequestBody
+}
+
+type CreateDeviceResponseObject interface {
+	VisitCreateDeviceResponse(w http.ResponseWriter) error
+}
+
+type CreateDevice201JSONResponse Device
+
+func (response CreateDevice201JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDevice400JSONResponse Error
+
+func (response CreateDevice400JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDevice401JSONResponse Error
+
+func (response CreateDevice401JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDevice409JSONResponse Error
+
+func (response CreateDevice409JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteDeviceResponseObject interface {
+	VisitDeleteDeviceResponse(w http.ResponseWriter) error
+}
+
+type DeleteDevice200JSONResponse Device
+
+func (response DeleteDevice200JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDevice401JSONResponse Error
+
+func (response DeleteDevice401JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDevice404JSONResponse Error
+
+func (response DeleteDevice404JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadDeviceResponseObject interface {
+	VisitReadDeviceResponse(w http.ResponseWriter) error
+}
+
+type ReadDevice200JSONResponse Device
+
+func (response ReadDevice200JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDevice401JSONResponse Error
+
+func (response ReadDevice401JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDevice404JSONResponse Error
+
+func (response ReadDevice404JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDeviceRequestObject struct {
+	Name string `json:"name"`
+	Body *PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+}
+
+type PatchDeviceResponseObject interface {
+	VisitPatchDeviceResponse(w http.ResponseWriter) error
+}
+
+type PatchDevice200JSONResponse Device
+
+func (response PatchDevice200JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDevice400JSONResponse Error
+
+func (response PatchDevice400JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDevice401JSONResponse Error
+
+func (response PatchDevice401JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDevice404JSONResponse Error
+
+func (response PatchDevice404JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchDevice409JSONResponse Error
+
+func (response PatchDevice409JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceJSONRequestBody
+}
+
+type ReplaceDeviceResponseObject interface {
+	VisitReplaceDeviceResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDevice200JSONResponse Device
+
+func (response ReplaceDevice200JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDevice201JSONResponse Device
+
+func (response ReplaceDevice201JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDevice400JSONResponse Error
+
+func (response ReplaceDevice400JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDevice401JSONResponse Error
+
+func (response ReplaceDevice401JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDevice404JSONResponse Error
+
+func (response ReplaceDevice404JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDevice409JSONResponse Error
+
+func (response ReplaceDevice409JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelDeviceActionRequestObject struct {
+	Name string `json:"name"`
+}
+
+type CancelDeviceActionResponseObject interface {
+	VisitCancelDeviceActionResponse(w http.ResponseWriter) error
+}
+
+type CancelDeviceAction200JSONResponse Device
+
+func (response CancelDeviceAction200JSONResponse) VisitCancelDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelDeviceAction401JSONResponse Error
+
+func (response CancelDeviceAction401JSONResponse) VisitCancelDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelDeviceAction404JSONResponse Error
+
+func (response CancelDeviceAction404JSONResponse) VisitCancelDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceActionRequestObject struct {
+	Name string `json:"name"`
+	Body *RequestDeviceActionJSONRequestBody
+}
+
+type RequestDeviceActionResponseObject interface {
+	VisitRequestDeviceActionResponse(w http.ResponseWriter) error
+}
+
+type RequestDeviceAction200JSONResponse DeviceAction
+
+func (response RequestDeviceAction200JSONResponse) VisitRequestDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceAction400JSONResponse Error
+
+func (response RequestDeviceAction400JSONResponse) VisitRequestDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceAction401JSONResponse Error
+
+func (response RequestDeviceAction401JSONResponse) VisitRequestDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceAction404JSONResponse Error
+
+func (response RequestDeviceAction404JSONResponse) VisitRequestDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceAction409JSONResponse Error
+
+func (response RequestDeviceAction409JSONResponse) VisitRequestDeviceActionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceAliasesRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceAliasesJSONRequestBody
+}
+
+type ReplaceDeviceAliasesResponseObject interface {
+	VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceAliases200JSONResponse Device
+
+func (response ReplaceDeviceAliases200JSONResponse) VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceAliases400JSONResponse Error
+
+func (response ReplaceDeviceAliases400JSONResponse) VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceAliases401JSONResponse Error
+
+func (response ReplaceDeviceAliases401JSONResponse) VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceAliases404JSONResponse Error
+
+func (response ReplaceDeviceAliases404JSONResponse) VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceAliases409JSONResponse Error
+
+func (response ReplaceDeviceAliases409JSONResponse) VisitReplaceDeviceAliasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetDeviceAttestationRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ResetDeviceAttestationResponseObject interface {
+	VisitResetDeviceAttestationResponse(w http.ResponseWriter) error
+}
+
+type ResetDeviceAttestation200JSONResponse Status
+
+func (response ResetDeviceAttestation200JSONResponse) VisitResetDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetDeviceAttestation401JSONResponse Error
+
+func (response ResetDeviceAttestation401JSONResponse) VisitResetDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetDeviceAttestation404JSONResponse Error
+
+func (response ResetDeviceAttestation404JSONResponse) VisitResetDeviceAttestationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleRequestObject struct {
+	Name   string `json:"name"`
+	Params RequestConsoleParams
+}
+
+type RequestConsoleResponseObject interface {
+	VisitRequestConsoleResponse(w http.ResponseWriter) error
+}
+
+type RequestConsole200JSONResponse DeviceConsole
+
+func (response RequestConsole200JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsole400JSONResponse Error
+
+func (response RequestConsole400JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsole401JSONResponse Error
+
+func (response RequestConsole401JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsole404JSONResponse Error
+
+func (response RequestConsole404JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsole409JSONResponse Error
+
+func (response RequestConsole409JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConsoleGrantRequestObject struct {
+	Name string `json:"name"`
+}
+
+type RevokeConsoleGrantResponseObject interface {
+	VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error
+}
+
+type RevokeConsoleGrant200JSONResponse ConsoleGrant
+
+func (response RevokeConsoleGrant200JSONResponse) VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConsoleGrant400JSONResponse Error
+
+func (response RevokeConsoleGrant400JSONResponse) VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConsoleGrant401JSONResponse Error
+
+func (response RevokeConsoleGrant401JSONResponse) VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConsoleGrant403JSONResponse Error
+
+func (response RevokeConsoleGrant403JSONResponse) VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeConsoleGrant404JSONResponse Error
+
+func (response RevokeConsoleGrant404JSONResponse) VisitRevokeConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConsoleGrantRequestObject struct {
+	Name string `json:"name"`
+}
+
+type GetConsoleGrantResponseObject interface {
+	VisitGetConsoleGrantResponse(w http.ResponseWriter) error
+}
+
+type GetConsoleGrant200JSONResponse ConsoleGrant
+
+func (response GetConsoleGrant200JSONResponse) VisitGetConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConsoleGrant401JSONResponse Error
+
+func (response GetConsoleGrant401JSONResponse) VisitGetConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetConsoleGrant404JSONResponse Error
+
+func (response GetConsoleGrant404JSONResponse) VisitGetConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrantRequestObject struct {
+	Name string `json:"name"`
+	Body *RequestConsoleGrantJSONRequestBody
+}
+
+type RequestConsoleGrantResponseObject interface {
+	VisitRequestConsoleGrantResponse(w http.ResponseWriter) error
+}
+
+type RequestConsoleGrant200JSONResponse ConsoleGrant
+
+func (response RequestConsoleGrant200JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrant400JSONResponse Error
+
+func (response RequestConsoleGrant400JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrant401JSONResponse Error
+
+func (response RequestConsoleGrant401JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrant403JSONResponse Error
+
+func (response RequestConsoleGrant403JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrant404JSONResponse Error
+
+func (response RequestConsoleGrant404JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestConsoleGrant409JSONResponse Error
+
+func (response RequestConsoleGrant409JSONResponse) VisitRequestConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrantRequestObject struct {
+	Name string `json:"name"`
+	Body *ApproveConsoleGrantJSONRequestBody
+}
+
+type ApproveConsoleGrantResponseObject interface {
+	VisitApproveConsoleGrantResponse(w http.ResponseWriter) error
+}
+
+type ApproveConsoleGrant200JSONResponse ConsoleGrant
+
+func (response ApproveConsoleGrant200JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrant400JSONResponse Error
+
+func (response ApproveConsoleGrant400JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrant401JSONResponse Error
+
+func (response ApproveConsoleGrant401JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrant403JSONResponse Error
+
+func (response ApproveConsoleGrant403JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrant404JSONResponse Error
+
+func (response ApproveConsoleGrant404JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveConsoleGrant409JSONResponse Error
+
+func (response ApproveConsoleGrant409JSONResponse) VisitApproveConsoleGrantResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommandRequestObject struct {
+	Name string `json:"name"`
+	Body *ExecDeviceCommandJSONRequestBody
+}
+
+type ExecDeviceCommandResponseObject interface {
+	VisitExecDeviceCommandResponse(w http.ResponseWriter) error
+}
+
+type ExecDeviceCommand200JSONResponse DeviceAction
+
+func (response ExecDeviceCommand200JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommand400JSONResponse Error
+
+func (response ExecDeviceCommand400JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommand401JSONResponse Error
+
+func (response ExecDeviceCommand401JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommand403JSONResponse Error
+
+func (response ExecDeviceCommand403JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommand404JSONResponse Error
+
+func (response ExecDeviceCommand404JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ExecDeviceCommand409JSONResponse Error
+
+func (response ExecDeviceCommand409JSONResponse) VisitExecDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetricsRequestObject struct {
+	Name string `json:"name"`
+	Body io.Reader
+}
+
+type PushDeviceMetricsResponseObject interface {
+	VisitPushDeviceMetricsResponse(w http.ResponseWriter) error
+}
+
+type PushDeviceMetrics204Response struct {
+}
+
+func (response PushDeviceMetrics204Response) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PushDeviceMetrics400JSONResponse Error
+
+func (response PushDeviceMetrics400JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetrics401JSONResponse Error
+
+func (response PushDeviceMetrics401JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushDeviceMetrics503JSONResponse Error
+
+func (response PushDeviceMetrics503JSONResponse) VisitPushDeviceMetricsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceQuarantineRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReleaseDeviceQuarantineResponseObject interface {
+	VisitReleaseDeviceQuarantineResponse(w http.ResponseWriter) error
+}
+
+type ReleaseDeviceQuarantine200JSONResponse Device
+
+func (response ReleaseDeviceQuarantine200JSONResponse) VisitReleaseDeviceQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceQuarantine401JSONResponse Error
+
+func (response ReleaseDeviceQuarantine401JSONResponse) VisitReleaseDeviceQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReleaseDeviceQuarantine404JSONResponse Error
+
+func (response ReleaseDeviceQuarantine404JSONResponse) VisitReleaseDeviceQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type QuarantineDeviceRequestObject struct {
+	Name string `json:"name"`
+	Body *QuarantineDeviceJSONRequestBody
+}
+
+type QuarantineDeviceResponseObject interface {
+	VisitQuarantineDeviceResponse(w http.ResponseWriter) error
+}
+
+type QuarantineDevice200JSONResponse Device
+
+func (response QuarantineDevice200JSONResponse) VisitQuarantineDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type QuarantineDevice400JSONResponse Error
+
+func (response QuarantineDevice400JSONResponse) VisitQuarantineDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type QuarantineDevice401JSONResponse Error
+
+func (response QuarantineDevice401JSONResponse) VisitQuarantineDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type QuarantineDevice404JSONResponse Error
+
+func (response QuarantineDevice404JSONResponse) VisitQuarantineDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpecRequestObject struct {
+	Name   string `json:"name"`
+	Params GetRenderedDeviceSpecParams
+}
+
+type GetRenderedDeviceSpecResponseObject interface {
+	VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error
+}
+
+type GetRenderedDeviceSpec200JSONResponse RenderedDeviceSpec
+
+func (response GetRenderedDeviceSpec200JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec204Response struct {
+}
+
+func (response GetRenderedDeviceSpec204Response) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type GetRenderedDeviceSpec401JSONResponse Error
+
+func (response GetRenderedDeviceSpec401JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec404JSONResponse Error
+
+func (response GetRenderedDeviceSpec404JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRenderedDeviceSpec409JSONResponse Error
+
+func (response GetRenderedDeviceSpec409JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreDeviceRequestObject struct {
+	Name string `json:"name"`
+}
+
+type RestoreDeviceResponseObject interface {
+	VisitRestoreDeviceResponse(w http.ResponseWriter) error
+}
+
+type RestoreDevice200JSONResponse Device
+
+func (response RestoreDevice200JSONResponse) VisitRestoreDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreDevice401JSONResponse Error
+
+func (response RestoreDevice401JSONResponse) VisitRestoreDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreDevice404JSONResponse Error
+
+func (response RestoreDevice404JSONResponse) VisitRestoreDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceStatusRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadDeviceStatusResponseObject interface {
+	VisitReadDeviceStatusResponse(w http.ResponseWriter) error
+}
+
+type ReadDeviceStatus200JSONResponse Device
+
+func (response ReadDeviceStatus200JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceStatus401JSONResponse Error
+
+func (response ReadDeviceStatus401JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceStatus404JSONResponse Error
+
+func (response ReadDeviceStatus404JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatusRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceStatusJSONRequestBody
+}
+
+type ReplaceDeviceStatusResponseObject interface {
+	VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceStatus200JSONResponse Device
+
+func (response ReplaceDeviceStatus200JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatus400JSONResponse Error
+
+func (response ReplaceDeviceStatus400JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatus401JSONResponse Error
+
+func (response ReplaceDeviceStatus401JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatus404JSONResponse Error
+
+func (response ReplaceDeviceStatus404JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatus409JSONResponse Error
+
+func (response ReplaceDeviceStatus409JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnelRequestObject struct {
+	Name string `json:"name"`
+}
+
+type CloseDeviceTunnelResponseObject interface {
+	VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error
+}
+
+type CloseDeviceTunnel200JSONResponse DeviceTunnel
+
+func (response CloseDeviceTunnel200JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnel400JSONResponse Error
+
+func (response CloseDeviceTunnel400JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnel401JSONResponse Error
+
+func (response CloseDeviceTunnel401JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnel403JSONResponse Error
+
+func (response CloseDeviceTunnel403JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnel404JSONResponse Error
+
+func (response CloseDeviceTunnel404JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CloseDeviceTunnel409JSONResponse Error
+
+func (response CloseDeviceTunnel409JSONResponse) VisitCloseDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceTunnelRequestObject struct {
+	Name string `json:"name"`
+}
+
+type GetDeviceTunnelResponseObject interface {
+	VisitGetDeviceTunnelResponse(w http.ResponseWriter) error
+}
+
+type GetDeviceTunnel200JSONResponse DeviceTunnel
+
+func (response GetDeviceTunnel200JSONResponse) VisitGetDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceTunnel401JSONResponse Error
+
+func (response GetDeviceTunnel401JSONResponse) VisitGetDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceTunnel404JSONResponse Error
+
+func (response GetDeviceTunnel404JSONResponse) VisitGetDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnelRequestObject struct {
+	Name string `json:"name"`
+	Body *RequestDeviceTunnelJSONRequestBody
+}
+
+type RequestDeviceTunnelResponseObject interface {
+	VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error
+}
+
+type RequestDeviceTunnel200JSONResponse DeviceTunnel
+
+func (response RequestDeviceTunnel200JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnel400JSONResponse Error
+
+func (response RequestDeviceTunnel400JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnel401JSONResponse Error
+
+func (response RequestDeviceTunnel401JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnel403JSONResponse Error
+
+func (response RequestDeviceTunnel403JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnel404JSONResponse Error
+
+func (response RequestDeviceTunnel404JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequestDeviceTunnel409JSONResponse Error
+
+func (response RequestDeviceTunnel409JSONResponse) VisitRequestDeviceTunnelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WakeDeviceRequestObject struct {
+	Name string `json:"name"`
+	Body *WakeDeviceJSONRequestBody
+}
+
+type WakeDeviceResponseObject interface {
+	VisitWakeDeviceResponse(w http.ResponseWriter) error
+}
+
+type WakeDevice200JSONResponse DeviceWake
+
+func (response WakeDevice200JSONResponse) VisitWakeDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WakeDevice400JSONResponse Error
+
+func (response WakeDevice400JSONResponse) VisitWakeDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WakeDevice401JSONResponse Error
+
+func (response WakeDevice401JSONResponse) VisitWakeDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WakeDevice404JSONResponse Error
+
+func (response WakeDevice404JSONResponse) VisitWakeDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type WakeDevice409JSONResponse Error
+
+func (response WakeDevice409JSONResponse) VisitWakeDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatusesRequestObject struct {
+	Body *ReplaceDeviceStatusesJSONRequestBody
+}
+
+type ReplaceDeviceStatusesResponseObject interface {
+	VisitReplaceDeviceStatusesResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceStatuses200JSONResponse DeviceStatusBatchResult
+
+func (response ReplaceDeviceStatuses200JSONResponse) VisitReplaceDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatuses400JSONResponse Error
+
+func (response ReplaceDeviceStatuses400JSONResponse) VisitReplaceDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatuses401JSONResponse Error
+
+func (response ReplaceDeviceStatuses401JSONResponse) VisitReplaceDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceStatuses403JSONResponse Error
+
+func (response ReplaceDeviceStatuses403JSONResponse) VisitReplaceDeviceStatusesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceViewsRequestObject struct {
+}
+
+type DeleteDeviceViewsResponseObject interface {
+	VisitDeleteDeviceViewsResponse(w http.ResponseWriter) error
+}
+
+type DeleteDeviceViews200JSONResponse Status
+
+func (response DeleteDeviceViews200JSONResponse) VisitDeleteDeviceViewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceViews401JSONResponse Error
+
+func (response DeleteDeviceViews401JSONResponse) VisitDeleteDeviceViewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewsRequestObject struct {
+	Params ListDeviceViewsParams
+}
+
+type ListDeviceViewsResponseObject interface {
+	VisitListDeviceViewsResponse(w http.ResponseWriter) error
+}
+
+type ListDeviceViews200JSONResponse DeviceViewList
+
+func (response ListDeviceViews200JSONResponse) VisitListDeviceViewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViews400JSONResponse Error
+
+func (response ListDeviceViews400JSONResponse) VisitListDeviceViewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViews401JSONResponse Error
+
+func (response ListDeviceViews401JSONResponse) VisitListDeviceViewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceViewRequestObject struct {
+	Body *CreateDeviceViewJSONRequestBody
+}
+
+type CreateDeviceViewResponseObject interface {
+	VisitCreateDeviceViewResponse(w http.ResponseWriter) error
+}
+
+type CreateDeviceView201JSONResponse DeviceView
+
+func (response CreateDeviceView201JSONResponse) VisitCreateDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceView400JSONResponse Error
+
+func (response CreateDeviceView400JSONResponse) VisitCreateDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceView401JSONResponse Error
+
+func (response CreateDeviceView401JSONResponse) VisitCreateDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDeviceView409JSONResponse Error
+
+func (response CreateDeviceView409JSONResponse) VisitCreateDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceViewRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteDeviceViewResponseObject interface {
+	VisitDeleteDeviceViewResponse(w http.ResponseWriter) error
+}
+
+type DeleteDeviceView200JSONResponse DeviceView
+
+func (response DeleteDeviceView200JSONResponse) VisitDeleteDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceView401JSONResponse Error
+
+func (response DeleteDeviceView401JSONResponse) VisitDeleteDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDeviceView404JSONResponse Error
+
+func (response DeleteDeviceView404JSONResponse) VisitDeleteDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceViewRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadDeviceViewResponseObject interface {
+	VisitReadDeviceViewResponse(w http.ResponseWriter) error
+}
+
+type ReadDeviceView200JSONResponse DeviceView
+
+func (response ReadDeviceView200JSONResponse) VisitReadDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceView401JSONResponse Error
+
+func (response ReadDeviceView401JSONResponse) VisitReadDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadDeviceView404JSONResponse Error
+
+func (response ReadDeviceView404JSONResponse) VisitReadDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceViewRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceDeviceViewJSONRequestBody
+}
+
+type ReplaceDeviceViewResponseObject interface {
+	VisitReplaceDeviceViewResponse(w http.ResponseWriter) error
+}
+
+type ReplaceDeviceView200JSONResponse DeviceView
+
+func (response ReplaceDeviceView200JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceView201JSONResponse DeviceView
+
+func (response ReplaceDeviceView201JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceView400JSONResponse Error
+
+func (response ReplaceDeviceView400JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceView401JSONResponse Error
+
+func (response ReplaceDeviceView401JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceView404JSONResponse Error
+
+func (response ReplaceDeviceView404JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceDeviceView409JSONResponse Error
+
+func (response ReplaceDeviceView409JSONResponse) VisitReplaceDeviceViewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewDevicesRequestObject struct {
+	Name   string `json:"name"`
+	Params ListDeviceViewDevicesParams
+}
+
+type ListDeviceViewDevicesResponseObject interface {
+	VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error
+}
+
+type ListDeviceViewDevices200JSONResponse DeviceList
+
+func (response ListDeviceViewDevices200JSONResponse) VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewDevices400JSONResponse Error
+
+func (response ListDeviceViewDevices400JSONResponse) VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewDevices401JSONResponse Error
+
+func (response ListDeviceViewDevices401JSONResponse) VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewDevices403JSONResponse Error
+
+func (response ListDeviceViewDevices403JSONResponse) VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListDeviceViewDevices404JSONResponse Error
+
+func (response ListDeviceViewDevices404JSONResponse) VisitListDeviceViewDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollmentConfigRequestObject struct {
+	Name string `json:"name"`
+}
+
+type EnrollmentConfigResponseObject interface {
+	VisitEnrollmentConfigResponse(w http.ResponseWriter) error
+}
+
+type EnrollmentConfig200JSONResponse EnrollmentConfig
+
+func (response EnrollmentConfig200JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollmentConfig400JSONResponse Error
+
+func (response EnrollmentConfig400JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollmentConfig401JSONResponse Error
+
+func (response EnrollmentConfig401JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnrollmentConfig404JSONResponse Error
+
+func (response EnrollmentConfig404JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEnrollmentRequestsRequestObject struct {
+}
+
+type DeleteEnrollmentRequestsResponseObject interface {
+	VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error
+}
+
+type DeleteEnrollmentRequests200JSONResponse Status
+
+func (response DeleteEnrollmentRequests200JSONResponse) VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEnrollmentRequests401JSONResponse Error
+
+func (response DeleteEnrollmentRequests401JSONResponse) VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListEnrollmentRequestsRequestObject struct {
+	Params ListEnrollmentRequestsParams
+}
+
+type ListEnrollmentRequestsResponseObject interface {
+	VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error
+}
+
+type ListEnrollmentRequests200JSONResponse EnrollmentRequestList
+
+func (response ListEnrollmentRequests200JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListEnrollmentRequests400JSONResponse Error
+
+func (response ListEnrollmentRequests400JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListEnrollmentRequests401JSONResponse Error
+
+func (response ListEnrollmentRequests401JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateEnrollmentRequestRequestObject struct {
+	Body *CreateEnrollmentRequestJSONRequestBody
+}
+
+type CreateEnrollmentRequestResponseObject interface {
+	VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error
+}
+
+type CreateEnrollmentRequest201JSONResponse EnrollmentRequest
+
+func (response CreateEnrollmentRequest201JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateEnrollmentRequest208JSONResponse EnrollmentRequest
+
+func (response CreateEnrollmentRequest208JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(208)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateEnrollmentRequest400JSONResponse Error
+
+func (response CreateEnrollmentRequest400JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateEnrollmentRequest401JSONResponse Error
+
+func (response CreateEnrollmentRequest401JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateEnrollmentRequest409JSONResponse Error
+
+func (response CreateEnrollmentRequest409JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEnrollmentRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteEnrollmentRequestResponseObject interface {
+	VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error
+}
+
+type DeleteEnrollmentRequest200JSONResponse EnrollmentRequest
+
+func (response DeleteEnrollmentRequest200JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEnrollmentRequest401JSONResponse Error
+
+func (response DeleteEnrollmentRequest401JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEnrollmentRequest404JSONResponse Error
+
+func (response DeleteEnrollmentRequest404JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequestRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadEnrollmentRequestResponseObject interface {
+	VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error
+}
+
+type ReadEnrollmentRequest200JSONResponse EnrollmentRequest
+
+func (response ReadEnrollmentRequest200JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequest401JSONResponse Error
+
+func (response ReadEnrollmentRequest401JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequest404JSONResponse Error
+
+func (response ReadEnrollmentRequest404JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceEnrollmentRequestJSONRequestBody
+}
+
+type ReplaceEnrollmentRequestResponseObject interface {
+	VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error
+}
+
+type ReplaceEnrollmentRequest200JSONResponse EnrollmentRequest
+
+func (response ReplaceEnrollmentRequest200JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequest201JSONResponse EnrollmentRequest
+
+func (response ReplaceEnrollmentRequest201JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequest400JSONResponse Error
+
+func (response ReplaceEnrollmentRequest400JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequest401JSONResponse Error
+
+func (response ReplaceEnrollmentRequest401JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequest404JSONResponse Error
+
+func (response ReplaceEnrollmentRequest404JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequest409JSONResponse Error
+
+func (response ReplaceEnrollmentRequest409JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequestRequestObject struct {
+	Name string `json:"name"`
+	Body *ApproveEnrollmentRequestJSONRequestBody
+}
+
+type ApproveEnrollmentRequestResponseObject interface {
+	VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error
+}
+
+type ApproveEnrollmentRequest200JSONResponse EnrollmentRequestApproval
+
+func (response ApproveEnrollmentRequest200JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest400JSONResponse Error
+
+func (response ApproveEnrollmentRequest400JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest401JSONResponse Error
+
+func (response ApproveEnrollmentRequest401JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest404JSONResponse Error
+
+func (response ApproveEnrollmentRequest404JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest409JSONResponse Error
+
+func (response ApproveEnrollmentRequest409JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest422JSONResponse Error
+
+func (response ApproveEnrollmentRequest422JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveEnrollmentRequest500JSONResponse Error
+
+func (response ApproveEnrollmentRequest500JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequestStatusRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadEnrollmentRequestStatusResponseObject interface {
+	VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error
+}
+
+type ReadEnrollmentRequestStatus200JSONResponse EnrollmentRequest
+
+func (response ReadEnrollmentRequestStatus200JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequestStatus401JSONResponse Error
+
+func (response ReadEnrollmentRequestStatus401JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadEnrollmentRequestStatus404JSONResponse Error
+
+func (response ReadEnrollmentRequestStatus404JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestStatusRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceEnrollmentRequestStatusJSONRequestBody
+}
+
+type ReplaceEnrollmentRequestStatusResponseObject interface {
+	VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error
+}
+
+type ReplaceEnrollmentRequestStatus200JSONResponse EnrollmentRequest
+
+func (response ReplaceEnrollmentRequestStatus200JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestStatus400JSONResponse Error
+
+func (response ReplaceEnrollmentRequestStatus400JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestStatus401JSONResponse Error
+
+func (response ReplaceEnrollmentRequestStatus401JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestStatus404JSONResponse Error
+
+func (response ReplaceEnrollmentRequestStatus404JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceEnrollmentRequestStatus409JSONResponse Error
+
+func (response ReplaceEnrollmentRequestStatus409JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceExportRequestObject struct {
+	Body *CreateResourceExportJSONRequestBody
+}
+
+type CreateResourceExportResponseObject interface {
+	VisitCreateResourceExportResponse(w http.ResponseWriter) error
+}
+
+type CreateResourceExport200JSONResponse ResourceExport
+
+func (response CreateResourceExport200JSONResponse) VisitCreateResourceExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceExport400JSONResponse Error
+
+func (response CreateResourceExport400JSONResponse) VisitCreateResourceExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceExport401JSONResponse Error
+
+func (response CreateResourceExport401JSONResponse) VisitCreateResourceExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceExport404JSONResponse Error
+
+func (response CreateResourceExport404JSONResponse) VisitCreateResourceExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleetsRequestObject struct {
+}
+
+type DeleteFleetsResponseObject interface {
+	VisitDeleteFleetsResponse(w http.ResponseWriter) error
+}
+
+type DeleteFleets200JSONResponse Status
+
+func (response DeleteFleets200JSONResponse) VisitDeleteFleetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleets401JSONResponse Error
+
+func (response DeleteFleets401JSONResponse) VisitDeleteFleetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleetsRequestObject struct {
+	Params ListFleetsParams
+}
+
+type ListFleetsResponseObject interface {
+	VisitListFleetsResponse(w http.ResponseWriter) error
+}
+
+type ListFleets200JSONResponse FleetList
+
+func (response ListFleets200JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleets400JSONResponse Error
+
+func (response ListFleets400JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFleets401JSONResponse Error
+
+func (response ListFleets401JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFleetRequestObject struct {
+	Body *CreateFleetJSONRequestBody
+}
+
+type CreateFleetResponseObject interface {
+	VisitCreateFleetResponse(w http.ResponseWriter) error
+}
+
+type CreateFleet201JSONResponse Fleet
+
+func (response CreateFleet201JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFleet400JSONResponse Error
+
+func (response CreateFleet400JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFleet401JSONResponse Error
+
+func (response CreateFleet401JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateFleet409JSONResponse Error
+
+func (response CreateFleet409JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateVersionsRequestObject struct {
+	Fleet string `json:"fleet"`
+}
+
+type DeleteTemplateVersionsResponseObject interface {
+	VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error
+}
+
+type DeleteTemplateVersions200JSONResponse Status
+
+func (response DeleteTemplateVersions200JSONResponse) VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateVersions401JSONResponse Error
+
+func (response DeleteTemplateVersions401JSONResponse) VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTemplateVersionsRequestObject struct {
+	Fleet  string `json:"fleet"`
+	Params ListTemplateVersionsParams
+}
+
+type ListTemplateVersionsResponseObject interface {
+	VisitListTemplateVersionsResponse(w http.ResponseWriter) error
+}
+
+type ListTemplateVersions200JSONResponse TemplateVersionList
+
+func (response ListTemplateVersions200JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTemplateVersions400JSONResponse Error
+
+func (response ListTemplateVersions400JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTemplateVersions401JSONResponse Error
+
+func (response ListTemplateVersions401JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateVersionRequestObject struct {
+	Fleet string `json:"fleet"`
+	Name  string `json:"name"`
+}
+
+type DeleteTemplateVersionResponseObject interface {
+	VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error
+}
+
+type DeleteTemplateVersion200JSONResponse TemplateVersion
+
+func (response DeleteTemplateVersion200JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateVersion401JSONResponse Error
+
+func (response DeleteTemplateVersion401JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteTemplateVersion404JSONResponse Error
+
+func (response DeleteTemplateVersion404JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadTemplateVersionRequestObject struct {
+	Fleet string `json:"fleet"`
+	Name  string `json:"name"`
+}
+
+type ReadTemplateVersionResponseObject interface {
+	VisitReadTemplateVersionResponse(w http.ResponseWriter) error
+}
+
+type ReadTemplateVersion200JSONResponse TemplateVersion
+
+func (response ReadTemplateVersion200JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadTemplateVersion401JSONResponse Error
+
+func (response ReadTemplateVersion401JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadTemplateVersion404JSONResponse Error
+
+func (response ReadTemplateVersion404JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleetRequestObject struct {
+	Name   string `json:"name"`
+	Params DeleteFleetParams
+}
+
+type DeleteFleetResponseObject interface {
+	VisitDeleteFleetResponse(w http.ResponseWriter) error
+}
+
+type DeleteFleet200JSONResponse Fleet
+
+func (response DeleteFleet200JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleet400JSONResponse Error
+
+func (response DeleteFleet400JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleet401JSONResponse Error
+
+func (response DeleteFleet401JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleet404JSONResponse Error
+
+func (response DeleteFleet404JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFleet409JSONResponse Error
+
+func (response DeleteFleet409JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetRequestObject struct {
+	Name   string `json:"name"`
+	Params ReadFleetParams
+}
+
+type ReadFleetResponseObject interface {
+	VisitReadFleetResponse(w http.ResponseWriter) error
+}
+
+type ReadFleet200JSONResponse Fleet
+
+func (response ReadFleet200JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleet401JSONResponse Error
+
+func (response ReadFleet401JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleet404JSONResponse Error
+
+func (response ReadFleet404JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchFleetRequestObject struct {
+	Name string `json:"name"`
+	Body *PatchFleetApplicationJSONPatchPlusJSONRequestBody
+}
+
+type PatchFleetResponseObject interface {
+	VisitPatchFleetResponse(w http.ResponseWriter) error
+}
+
+type PatchFleet200JSONResponse Fleet
+
+func (response PatchFleet200JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchFleet400JSONResponse Error
+
+func (response PatchFleet400JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchFleet401JSONResponse Error
+
+func (response PatchFleet401JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchFleet404JSONResponse Error
+
+func (response PatchFleet404JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchFleet409JSONResponse Error
+
+func (response PatchFleet409JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceFleetJSONRequestBody
+}
+
+type ReplaceFleetResponseObject interface {
+	VisitReplaceFleetResponse(w http.ResponseWriter) error
+}
+
+type ReplaceFleet200JSONResponse Fleet
+
+func (response ReplaceFleet200JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleet201JSONResponse Fleet
+
+func (response ReplaceFleet201JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleet400JSONResponse Error
+
+func (response ReplaceFleet400JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleet401JSONResponse Error
+
+func (response ReplaceFleet401JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleet404JSONResponse Error
+
+func (response ReplaceFleet404JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleet409JSONResponse Error
+
+func (response ReplaceFleet409JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LintFleetRequestObject struct {
+	Name string `json:"name"`
+	Body *LintFleetJSONRequestBody
+}
+
+type LintFleetResponseObject interface {
+	VisitLintFleetResponse(w http.ResponseWriter) error
+}
+
+type LintFleet200JSONResponse FleetLintResult
+
+func (response LintFleet200JSONResponse) VisitLintFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LintFleet400JSONResponse Error
+
+func (response LintFleet400JSONResponse) VisitLintFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LintFleet401JSONResponse Error
+
+func (response LintFleet401JSONResponse) VisitLintFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFleetMigrationRequestObject struct {
+	Name string `json:"name"`
+}
+
+type CancelFleetMigrationResponseObject interface {
+	VisitCancelFleetMigrationResponse(w http.ResponseWriter) error
+}
+
+type CancelFleetMigration200JSONResponse DeviceMigrationResult
+
+func (response CancelFleetMigration200JSONResponse) VisitCancelFleetMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFleetMigration401JSONResponse Error
+
+func (response CancelFleetMigration401JSONResponse) VisitCancelFleetMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelFleetMigration404JSONResponse Error
+
+func (response CancelFleetMigration404JSONResponse) VisitCancelFleetMigrationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MigrateFleetDevicesRequestObject struct {
+	Name string `json:"name"`
+	Body *MigrateFleetDevicesJSONRequestBody
+}
+
+type MigrateFleetDevicesResponseObject interface {
+	VisitMigrateFleetDevicesResponse(w http.ResponseWriter) error
+}
+
+type MigrateFleetDevices200JSONResponse DeviceMigrationResult
+
+func (response MigrateFleetDevices200JSONResponse) VisitMigrateFleetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MigrateFleetDevices400JSONResponse Error
+
+func (response MigrateFleetDevices400JSONResponse) VisitMigrateFleetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MigrateFleetDevices401JSONResponse Error
+
+func (response MigrateFleetDevices401JSONResponse) VisitMigrateFleetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MigrateFleetDevices404JSONResponse Error
+
+func (response MigrateFleetDevices404JSONResponse) VisitMigrateFleetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveFleetPromotionRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ApproveFleetPromotionResponseObject interface {
+	VisitApproveFleetPromotionResponse(w http.ResponseWriter) error
+}
+
+type ApproveFleetPromotion200JSONResponse Fleet
+
+func (response ApproveFleetPromotion200JSONResponse) VisitApproveFleetPromotionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveFleetPromotion401JSONResponse Error
+
+func (response ApproveFleetPromotion401JSONResponse) VisitApproveFleetPromotionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveFleetPromotion404JSONResponse Error
+
+func (response ApproveFleetPromotion404JSONResponse) VisitApproveFleetPromotionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveFleetPromotion409JSONResponse Error
+
+func (response ApproveFleetPromotion409JSONResponse) VisitApproveFleetPromotionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetProvisioningRequestObject struct {
+	Name   string `json:"name"`
+	Params ReadFleetProvisioningParams
+}
+
+type ReadFleetProvisioningResponseObject interface {
+	VisitReadFleetProvisioningResponse(w http.ResponseWriter) error
+}
+
+type ReadFleetProvisioning200JSONResponse FleetProvisioning
+
+func (response ReadFleetProvisioning200JSONResponse) VisitReadFleetProvisioningResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetProvisioning400JSONResponse Error
+
+func (response ReadFleetProvisioning400JSONResponse) VisitReadFleetProvisioningResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetProvisioning401JSONResponse Error
+
+func (response ReadFleetProvisioning401JSONResponse) VisitReadFleetProvisioningResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetProvisioning404JSONResponse Error
+
+func (response ReadFleetProvisioning404JSONResponse) VisitReadFleetProvisioningResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProvisioningTokensRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ListProvisioningTokensResponseObject interface {
+	VisitListProvisioningTokensResponse(w http.ResponseWriter) error
+}
+
+type ListProvisioningTokens200JSONResponse ProvisioningTokenList
+
+func (response ListProvisioningTokens200JSONResponse) VisitListProvisioningTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProvisioningTokens401JSONResponse Error
+
+func (response ListProvisioningTokens401JSONResponse) VisitListProvisioningTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListProvisioningTokens404JSONResponse Error
+
+func (response ListProvisioningTokens404JSONResponse) VisitListProvisioningTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningTokenRequestObject struct {
+	Name string `json:"name"`
+	Body *CreateProvisioningTokenJSONRequestBody
+}
+
+type CreateProvisioningTokenResponseObject interface {
+	VisitCreateProvisioningTokenResponse(w http.ResponseWriter) error
+}
+
+type CreateProvisioningToken201JSONResponse ProvisioningToken
+
+func (response CreateProvisioningToken201JSONResponse) VisitCreateProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningToken400JSONResponse Error
+
+func (response CreateProvisioningToken400JSONResponse) VisitCreateProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningToken401JSONResponse Error
+
+func (response CreateProvisioningToken401JSONResponse) VisitCreateProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateProvisioningToken404JSONResponse Error
+
+func (response CreateProvisioningToken404JSONResponse) VisitCreateProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningTokenRequestObject struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+}
+
+type DeleteProvisioningTokenResponseObject interface {
+	VisitDeleteProvisioningTokenResponse(w http.ResponseWriter) error
+}
+
+type DeleteProvisioningToken200JSONResponse ProvisioningToken
+
+func (response DeleteProvisioningToken200JSONResponse) VisitDeleteProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningToken401JSONResponse Error
+
+func (response DeleteProvisioningToken401JSONResponse) VisitDeleteProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteProvisioningToken404JSONResponse Error
+
+func (response DeleteProvisioningToken404JSONResponse) VisitDeleteProvisioningTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetReportRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadFleetReportResponseObject interface {
+	VisitReadFleetReportResponse(w http.ResponseWriter) error
+}
+
+type ReadFleetReport200JSONResponse FleetReport
+
+func (response ReadFleetReport200JSONResponse) VisitReadFleetReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetReport401JSONResponse Error
+
+func (response ReadFleetReport401JSONResponse) VisitReadFleetReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetReport404JSONResponse Error
+
+func (response ReadFleetReport404JSONResponse) VisitReadFleetReportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreFleetRequestObject struct {
+	Name string `json:"name"`
+}
+
+type RestoreFleetResponseObject interface {
+	VisitRestoreFleetResponse(w http.ResponseWriter) error
+}
+
+type RestoreFleet200JSONResponse Fleet
+
+func (response RestoreFleet200JSONResponse) VisitRestoreFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreFleet401JSONResponse Error
+
+func (response RestoreFleet401JSONResponse) VisitRestoreFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreFleet404JSONResponse Error
+
+func (response RestoreFleet404JSONResponse) VisitRestoreFleetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AbortFleetRolloutRequestObject struct {
+	Name string `json:"name"`
+	Body *AbortFleetRolloutJSONRequestBody
+}
+
+type AbortFleetRolloutResponseObject interface {
+	VisitAbortFleetRolloutResponse(w http.ResponseWriter) error
+}
+
+type AbortFleetRollout200JSONResponse Fleet
+
+func (response AbortFleetRollout200JSONResponse) VisitAbortFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AbortFleetRollout400JSONResponse Error
+
+func (response AbortFleetRollout400JSONResponse) VisitAbortFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AbortFleetRollout401JSONResponse Error
+
+func (response AbortFleetRollout401JSONResponse) VisitAbortFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AbortFleetRollout404JSONResponse Error
+
+func (response AbortFleetRollout404JSONResponse) VisitAbortFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AbortFleetRollout409JSONResponse Error
+
+func (response AbortFleetRollout409JSONResponse) VisitAbortFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFleetRolloutRequestObject struct {
+	Name string `json:"name"`
+}
+
+type PauseFleetRolloutResponseObject interface {
+	VisitPauseFleetRolloutResponse(w http.ResponseWriter) error
+}
+
+type PauseFleetRollout200JSONResponse Fleet
+
+func (response PauseFleetRollout200JSONResponse) VisitPauseFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFleetRollout401JSONResponse Error
+
+func (response PauseFleetRollout401JSONResponse) VisitPauseFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFleetRollout404JSONResponse Error
+
+func (response PauseFleetRollout404JSONResponse) VisitPauseFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseFleetRollout409JSONResponse Error
+
+func (response PauseFleetRollout409JSONResponse) VisitPauseFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFleetRolloutRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ResumeFleetRolloutResponseObject interface {
+	VisitResumeFleetRolloutResponse(w http.ResponseWriter) error
+}
+
+type ResumeFleetRollout200JSONResponse Fleet
+
+func (response ResumeFleetRollout200JSONResponse) VisitResumeFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFleetRollout401JSONResponse Error
+
+func (response ResumeFleetRollout401JSONResponse) VisitResumeFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFleetRollout404JSONResponse Error
+
+func (response ResumeFleetRollout404JSONResponse) VisitResumeFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeFleetRollout409JSONResponse Error
+
+func (response ResumeFleetRollout409JSONResponse) VisitResumeFleetRolloutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetStatusRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadFleetStatusResponseObject interface {
+	VisitReadFleetStatusResponse(w http.ResponseWriter) error
+}
+
+type ReadFleetStatus200JSONResponse Fleet
+
+func (response ReadFleetStatus200JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetStatus401JSONResponse Error
+
+func (response ReadFleetStatus401JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadFleetStatus404JSONResponse Error
+
+func (response ReadFleetStatus404JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetStatusRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceFleetStatusJSONRequestBody
+}
+
+type ReplaceFleetStatusResponseObject interface {
+	VisitReplaceFleetStatusResponse(w http.ResponseWriter) error
+}
+
+type ReplaceFleetStatus200JSONResponse Fleet
+
+func (response ReplaceFleetStatus200JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetStatus400JSONResponse Error
+
+func (response ReplaceFleetStatus400JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetStatus401JSONResponse Error
+
+func (response ReplaceFleetStatus401JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetStatus404JSONResponse Error
+
+func (response ReplaceFleetStatus404JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceFleetStatus409JSONResponse Error
+
+func (response ReplaceFleetStatus409JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceImportRequestObject struct {
+	Body *CreateResourceImportJSONRequestBody
+}
+
+type CreateResourceImportResponseObject interface {
+	VisitCreateResourceImportResponse(w http.ResponseWriter) error
+}
+
+type CreateResourceImport201JSONResponse ResourceImportResult
+
+func (response CreateResourceImport201JSONResponse) VisitCreateResourceImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceImport400JSONResponse Error
+
+func (response CreateResourceImport400JSONResponse) VisitCreateResourceImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceImport401JSONResponse Error
+
+func (response CreateResourceImport401JSONResponse) VisitCreateResourceImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateResourceImport409JSONResponse Error
+
+func (response CreateResourceImport409JSONResponse) VisitCreateResourceImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLabelRulesRequestObject struct {
+}
+
+type DeleteLabelRulesResponseObject interface {
+	VisitDeleteLabelRulesResponse(w http.ResponseWriter) error
+}
+
+type DeleteLabelRules200JSONResponse Status
+
+func (response DeleteLabelRules200JSONResponse) VisitDeleteLabelRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLabelRules401JSONResponse Error
+
+func (response DeleteLabelRules401JSONResponse) VisitDeleteLabelRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLabelRulesRequestObject struct {
+	Params ListLabelRulesParams
+}
+
+type ListLabelRulesResponseObject interface {
+	VisitListLabelRulesResponse(w http.ResponseWriter) error
+}
+
+type ListLabelRules200JSONResponse LabelRuleList
+
+func (response ListLabelRules200JSONResponse) VisitListLabelRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLabelRules400JSONResponse Error
+
+func (response ListLabelRules400JSONResponse) VisitListLabelRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListLabelRules401JSONResponse Error
+
+func (response ListLabelRules401JSONResponse) VisitListLabelRulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabelRuleRequestObject struct {
+	Body *CreateLabelRuleJSONRequestBody
+}
+
+type CreateLabelRuleResponseObject interface {
+	VisitCreateLabelRuleResponse(w http.ResponseWriter) error
+}
+
+type CreateLabelRule201JSONResponse LabelRule
+
+func (response CreateLabelRule201JSONResponse) VisitCreateLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabelRule400JSONResponse Error
+
+func (response CreateLabelRule400JSONResponse) VisitCreateLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabelRule401JSONResponse Error
+
+func (response CreateLabelRule401JSONResponse) VisitCreateLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateLabelRule409JSONResponse Error
+
+func (response CreateLabelRule409JSONResponse) VisitCreateLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLabelRuleRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteLabelRuleResponseObject interface {
+	VisitDeleteLabelRuleResponse(w http.ResponseWriter) error
+}
+
+type DeleteLabelRule200JSONResponse LabelRule
+
+func (response DeleteLabelRule200JSONResponse) VisitDeleteLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLabelRule401JSONResponse Error
+
+func (response DeleteLabelRule401JSONResponse) VisitDeleteLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteLabelRule404JSONResponse Error
+
+func (response DeleteLabelRule404JSONResponse) VisitDeleteLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadLabelRuleRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadLabelRuleResponseObject interface {
+	VisitReadLabelRuleResponse(w http.ResponseWriter) error
+}
+
+type ReadLabelRule200JSONResponse LabelRule
+
+func (response ReadLabelRule200JSONResponse) VisitReadLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadLabelRule401JSONResponse Error
+
+func (response ReadLabelRule401JSONResponse) VisitReadLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadLabelRule404JSONResponse Error
+
+func (response ReadLabelRule404JSONResponse) VisitReadLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceLabelRuleRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceLabelRuleJSONRequestBody
+}
+
+type ReplaceLabelRuleResponseObject interface {
+	VisitReplaceLabelRuleResponse(w http.ResponseWriter) error
+}
+
+type ReplaceLabelRule200JSONResponse LabelRule
+
+func (response ReplaceLabelRule200JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
 
-	return r
+type ReplaceLabelRule201JSONResponse LabelRule
+
+func (response ReplaceLabelRule201JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthConfigRequestObject struct {
+type ReplaceLabelRule400JSONResponse Error
+
+func (response ReplaceLabelRule400JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthConfigResponseObject interface {
-	VisitAuthConfigResponse(w http.ResponseWriter) error
+type ReplaceLabelRule401JSONResponse Error
+
+func (response ReplaceLabelRule401JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthConfig200JSONResponse AuthConfig
+type ReplaceLabelRule404JSONResponse Error
 
-func (response AuthConfig200JSONResponse) VisitAuthConfigResponse(w http.ResponseWriter) error {
+func (response ReplaceLabelRule404JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceLabelRule409JSONResponse Error
+
+func (response ReplaceLabelRule409JSONResponse) VisitReplaceLabelRuleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListOperationsRequestObject struct {
+	Params ListOperationsParams
+}
+
+type ListOperationsResponseObject interface {
+	VisitListOperationsResponse(w http.ResponseWriter) error
+}
+
+type ListOperations200JSONResponse OperationList
+
+func (response ListOperations200JSONResponse) VisitListOperationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthConfig418Response struct {
+type ListOperations400JSONResponse Error
+
+func (response ListOperations400JSONResponse) VisitListOperationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response AuthConfig418Response) VisitAuthConfigResponse(w http.ResponseWriter) error {
-	w.WriteHeader(418)
-	return nil
+type ListOperations401JSONResponse Error
+
+func (response ListOperations401JSONResponse) VisitListOperationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthValidateRequestObject struct {
-	Params AuthValidateParams
+type CreateOperationRequestObject struct {
+	Body *CreateOperationJSONRequestBody
 }
 
-type AuthValidateResponseObject interface {
-	VisitAuthValidateResponse(w http.ResponseWriter) error
+type CreateOperationResponseObject interface {
+	VisitCreateOperationResponse(w http.ResponseWriter) error
 }
 
-type AuthValidate200Response struct {
+type CreateOperation201JSONResponse Operation
+
+func (response CreateOperation201JSONResponse) VisitCreateOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response AuthValidate200Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
+type CreateOperation400JSONResponse Error
+
+func (response CreateOperation400JSONResponse) VisitCreateOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOperation401JSONResponse Error
+
+func (response CreateOperation401JSONResponse) VisitCreateOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOperation409JSONResponse Error
+
+func (response CreateOperation409JSONResponse) VisitCreateOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteOperationRequestObject struct {
+	Name string `json:"name"`
+}
+
+type DeleteOperationResponseObject interface {
+	VisitDeleteOperationResponse(w http.ResponseWriter) error
+}
+
+type DeleteOperation200JSONResponse Status
+
+func (response DeleteOperation200JSONResponse) VisitDeleteOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthValidate401Response struct {
+type DeleteOperation401JSONResponse Error
+
+func (response DeleteOperation401JSONResponse) VisitDeleteOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response AuthValidate401Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
+type DeleteOperation404JSONResponse Error
+
+func (response DeleteOperation404JSONResponse) VisitDeleteOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteOperation409JSONResponse Error
+
+func (response DeleteOperation409JSONResponse) VisitDeleteOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadOperationRequestObject struct {
+	Name string `json:"name"`
+}
+
+type ReadOperationResponseObject interface {
+	VisitReadOperationResponse(w http.ResponseWriter) error
+}
+
+type ReadOperation200JSONResponse Operation
+
+func (response ReadOperation200JSONResponse) VisitReadOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadOperation401JSONResponse Error
+
+func (response ReadOperation401JSONResponse) VisitReadOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type AuthValidate418Response struct {
+type ReadOperation404JSONResponse Error
+
+func (response ReadOperation404JSONResponse) VisitReadOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response AuthValidate418Response) VisitAuthValidateResponse(w http.ResponseWriter) error {
-	w.WriteHeader(418)
-	return nil
+type CancelOperationRequestObject struct {
+	Name string `json:"name"`
 }
 
-type DeleteCertificateSigningRequestsRequestObject struct {
+type CancelOperationResponseObject interface {
+	VisitCancelOperationResponse(w http.ResponseWriter) error
+}
+
+type CancelOperation200JSONResponse Operation
+
+func (response CancelOperation200JSONResponse) VisitCancelOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOperation401JSONResponse Error
+
+func (response CancelOperation401JSONResponse) VisitCancelOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOperation404JSONResponse Error
+
+func (response CancelOperation404JSONResponse) VisitCancelOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelOperation409JSONResponse Error
+
+func (response CancelOperation409JSONResponse) VisitCancelOperationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepositoriesRequestObject struct {
+}
+
+type DeleteRepositoriesResponseObject interface {
+	VisitDeleteRepositoriesResponse(w http.ResponseWriter) error
+}
+
+type DeleteRepositories200JSONResponse Status
+
+func (response DeleteRepositories200JSONResponse) VisitDeleteRepositoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRepositories401JSONResponse Error
+
+func (response DeleteRepositories401JSONResponse) VisitDeleteRepositoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRepositoriesRequestObject struct {
+	Params ListRepositoriesParams
+}
+
+type ListRepositoriesResponseObject interface {
+	VisitListRepositoriesResponse(w http.ResponseWriter) error
+}
+
+type ListRepositories200JSONResponse RepositoryList
+
+func (response ListRepositories200JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRepositories400JSONResponse Error
+
+func (response ListRepositories400JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRepositories401JSONResponse Error
+
+func (response ListRepositories401JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRepositoryRequestObject struct {
+	Body *CreateRepositoryJSONRequestBody
+}
+
+type CreateRepositoryResponseObject interface {
+	VisitCreateRepositoryResponse(w http.ResponseWriter) error
+}
+
+type CreateRepository201JSONResponse Repository
+
+func (response CreateRepository201JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequestsResponseObject interface {
-	VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error
+type CreateRepository400JSONResponse Error
+
+func (response CreateRepository400JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequests200JSONResponse Status
+type CreateRepository401JSONResponse Error
 
-func (response DeleteCertificateSigningRequests200JSONResponse) VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+func (response CreateRepository401JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequests401JSONResponse Error
+type CreateRepository409JSONResponse Error
 
-func (response DeleteCertificateSigningRequests401JSONResponse) VisitDeleteCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+func (response CreateRepository409JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListCertificateSigningRequestsRequestObject struct {
-	Params ListCertificateSigningRequestsParams
+type DeleteRepositoryRequestObject struct {
+	Name   string `json:"name"`
+	Params DeleteRepositoryParams
 }
 
-type ListCertificateSigningRequestsResponseObject interface {
-	VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error
+type DeleteRepositoryResponseObject interface {
+	VisitDeleteRepositoryResponse(w http.ResponseWriter) error
 }
 
-type ListCertificateSigningRequests200JSONResponse CertificateSigningRequestList
+type DeleteRepository200JSONResponse Repository
 
-func (response ListCertificateSigningRequests200JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteRepository200JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListCertificateSigningRequests400JSONResponse Error
+type DeleteRepository400JSONResponse Error
 
-func (response ListCertificateSigningRequests400JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteRepository400JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListCertificateSigningRequests401JSONResponse Error
+type DeleteRepository401JSONResponse Error
 
-func (response ListCertificateSigningRequests401JSONResponse) VisitListCertificateSigningRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteRepository401JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCertificateSigningRequestRequestObject struct {
-	Body *CreateCertificateSigningRequestJSONRequestBody
-}
-
-type CreateCertificateSigningRequestResponseObject interface {
-	VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error
-}
-
-type CreateCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type DeleteRepository404JSONResponse Error
 
-func (response CreateCertificateSigningRequest200JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteRepository404JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCertificateSigningRequest201JSONResponse CertificateSigningRequest
+type DeleteRepository409JSONResponse Error
 
-func (response CreateCertificateSigningRequest201JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteRepository409JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCertificateSigningRequest202JSONResponse CertificateSigningRequest
-
-func (response CreateCertificateSigningRequest202JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(202)
+type ReadRepositoryRequestObject struct {
+	Name string `json:"name"`
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ReadRepositoryResponseObject interface {
+	VisitReadRepositoryResponse(w http.ResponseWriter) error
 }
 
-type CreateCertificateSigningRequest208JSONResponse EnrollmentRequest
+type ReadRepository200JSONResponse Repository
 
-func (response CreateCertificateSigningRequest208JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReadRepository200JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(208)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCertificateSigningRequest400JSONResponse Error
+type ReadRepository401JSONResponse Error
 
-func (response CreateCertificateSigningRequest400JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReadRepository401JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateCertificateSigningRequest401JSONResponse Error
+type ReadRepository404JSONResponse Error
 
-func (response CreateCertificateSigningRequest401JSONResponse) VisitCreateCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReadRepository404JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequestRequestObject struct {
+type PatchRepositoryRequestObject struct {
 	Name string `json:"name"`
+	Body *PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
 }
 
-type DeleteCertificateSigningRequestResponseObject interface {
-	VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error
+type PatchRepositoryResponseObject interface {
+	VisitPatchRepositoryResponse(w http.ResponseWriter) error
 }
 
-type DeleteCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type PatchRepository200JSONResponse Repository
 
-func (response DeleteCertificateSigningRequest200JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response PatchRepository200JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequest401JSONResponse Error
+type PatchRepository400JSONResponse Error
 
-func (response DeleteCertificateSigningRequest401JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response PatchRepository400JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchRepository401JSONResponse Error
+
+func (response PatchRepository401JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteCertificateSigningRequest404JSONResponse Error
+type PatchRepository404JSONResponse Error
 
-func (response DeleteCertificateSigningRequest404JSONResponse) VisitDeleteCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response PatchRepository404JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadCertificateSigningRequestRequestObject struct {
+type PatchRepository409JSONResponse Error
+
+func (response PatchRepository409JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceRepositoryRequestObject struct {
 	Name string `json:"name"`
+	Body *ReplaceRepositoryJSONRequestBody
 }
 
-type ReadCertificateSigningRequestResponseObject interface {
-	VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error
+type ReplaceRepositoryResponseObject interface {
+	VisitReplaceRepositoryResponse(w http.ResponseWriter) error
 }
 
-type ReadCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type ReplaceRepository200JSONResponse Repository
 
-func (response ReadCertificateSigningRequest200JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceRepository200JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadCertificateSigningRequest401JSONResponse Error
+type ReplaceRepository201JSONResponse Repository
 
-func (response ReadCertificateSigningRequest401JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceRepository201JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadCertificateSigningRequest404JSONResponse Error
+type ReplaceRepository400JSONResponse Error
 
-func (response ReadCertificateSigningRequest404JSONResponse) VisitReadCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceRepository400JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+type ReplaceRepository401JSONResponse Error
+
+func (response ReplaceRepository401JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequestResponseObject interface {
-	VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error
+type ReplaceRepository404JSONResponse Error
+
+func (response ReplaceRepository404JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type ReplaceRepository409JSONResponse Error
 
-func (response PatchCertificateSigningRequest200JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceRepository409JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest201JSONResponse CertificateSigningRequest
+type RotateRepositoryCredentialsRequestObject struct {
+	Name string `json:"name"`
+	Body *RotateRepositoryCredentialsJSONRequestBody
+}
 
-func (response PatchCertificateSigningRequest201JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+type RotateRepositoryCredentialsResponseObject interface {
+	VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error
+}
+
+type RotateRepositoryCredentials200JSONResponse Repository
+
+func (response RotateRepositoryCredentials200JSONResponse) VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest400JSONResponse Error
+type RotateRepositoryCredentials400JSONResponse Error
 
-func (response PatchCertificateSigningRequest400JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response RotateRepositoryCredentials400JSONResponse) VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest401JSONResponse Error
+type RotateRepositoryCredentials401JSONResponse Error
 
-func (response PatchCertificateSigningRequest401JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response RotateRepositoryCredentials401JSONResponse) VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest404JSONResponse Error
+type RotateRepositoryCredentials404JSONResponse Error
 
-func (response PatchCertificateSigningRequest404JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response RotateRepositoryCredentials404JSONResponse) VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchCertificateSigningRequest409JSONResponse Error
+type RotateRepositoryCredentials409JSONResponse Error
 
-func (response PatchCertificateSigningRequest409JSONResponse) VisitPatchCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response RotateRepositoryCredentials409JSONResponse) VisitRotateRepositoryCredentialsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceCertificateSigningRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceCertificateSigningRequestJSONRequestBody
+type DeleteResourceSyncsRequestObject struct {
 }
 
-type ReplaceCertificateSigningRequestResponseObject interface {
-	VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error
+type DeleteResourceSyncsResponseObject interface {
+	VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error
 }
 
-type ReplaceCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type DeleteResourceSyncs200JSONResponse Status
 
-func (response ReplaceCertificateSigningRequest200JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteResourceSyncs200JSONResponse) VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceCertificateSigningRequest201JSONResponse CertificateSigningRequest
+type DeleteResourceSyncs401JSONResponse Error
 
-func (response ReplaceCertificateSigningRequest201JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteResourceSyncs401JSONResponse) VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceCertificateSigningRequest400JSONResponse Error
-
-func (response ReplaceCertificateSigningRequest400JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+type ListResourceSyncRequestObject struct {
+	Params ListResourceSyncParams
+}
 
-	return json.NewEncoder(w).Encode(response)
+type ListResourceSyncResponseObject interface {
+	VisitListResourceSyncResponse(w http.ResponseWriter) error
 }
 
-type ReplaceCertificateSigningRequest401JSONResponse Error
+type ListResourceSync200JSONResponse ResourceSyncList
 
-func (response ReplaceCertificateSigningRequest401JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ListResourceSync200JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceCertificateSigningRequest404JSONResponse Error
+type ListResourceSync400JSONResponse Error
 
-func (response ReplaceCertificateSigningRequest404JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ListResourceSync400JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceCertificateSigningRequest409JSONResponse Error
+type ListResourceSync401JSONResponse Error
 
-func (response ReplaceCertificateSigningRequest409JSONResponse) VisitReplaceCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response ListResourceSync401JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DenyCertificateSigningRequestRequestObject struct {
-	Name string `json:"name"`
+type CreateResourceSyncRequestObject struct {
+	Body *CreateResourceSyncJSONRequestBody
 }
 
-type DenyCertificateSigningRequestResponseObject interface {
-	VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error
+type CreateResourceSyncResponseObject interface {
+	VisitCreateResourceSyncResponse(w http.ResponseWriter) error
 }
 
-type DenyCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type CreateResourceSync201JSONResponse ResourceSync
 
-func (response DenyCertificateSigningRequest200JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response CreateResourceSync201JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DenyCertificateSigningRequest401JSONResponse Error
+type CreateResourceSync400JSONResponse Error
 
-func (response DenyCertificateSigningRequest401JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response CreateResourceSync400JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DenyCertificateSigningRequest404JSONResponse Error
+type CreateResourceSync401JSONResponse Error
 
-func (response DenyCertificateSigningRequest404JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response CreateResourceSync401JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DenyCertificateSigningRequest409JSONResponse Error
+type CreateResourceSync409JSONResponse Error
 
-func (response DenyCertificateSigningRequest409JSONResponse) VisitDenyCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response CreateResourceSync409JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApproveCertificateSigningRequestRequestObject struct {
+type DeleteResourceSyncRequestObject struct {
 	Name string `json:"name"`
 }
 
-type ApproveCertificateSigningRequestResponseObject interface {
-	VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error
+type DeleteResourceSyncResponseObject interface {
+	VisitDeleteResourceSyncResponse(w http.ResponseWriter) error
 }
 
-type ApproveCertificateSigningRequest200JSONResponse CertificateSigningRequest
+type DeleteResourceSync200JSONResponse ResourceSync
 
-func (response ApproveCertificateSigningRequest200JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteResourceSync200JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApproveCertificateSigningRequest401JSONResponse Error
+type DeleteResourceSync401JSONResponse Error
 
-func (response ApproveCertificateSigningRequest401JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteResourceSync401JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApproveCertificateSigningRequest404JSONResponse Error
+type DeleteResourceSync404JSONResponse Error
 
-func (response ApproveCertificateSigningRequest404JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
+func (response DeleteResourceSync404JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ApproveCertificateSigningRequest409JSONResponse Error
-
-func (response ApproveCertificateSigningRequest409JSONResponse) VisitApproveCertificateSigningRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type DeleteDevicesRequestObject struct {
+type ReadResourceSyncRequestObject struct {
+	Name string `json:"name"`
 }
 
-type DeleteDevicesResponseObject interface {
-	VisitDeleteDevicesResponse(w http.ResponseWriter) error
+type ReadResourceSyncResponseObject interface {
+	VisitReadResourceSyncResponse(w http.ResponseWriter) error
 }
 
-type DeleteDevices200JSONResponse Status
+type ReadResourceSync200JSONResponse ResourceSync
 
-func (response DeleteDevices200JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
+func (response ReadResourceSync200JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDevices401JSONResponse Error
+type ReadResourceSync401JSONResponse Error
 
-func (response DeleteDevices401JSONResponse) VisitDeleteDevicesResponse(w http.ResponseWriter) error {
+func (response ReadResourceSync401JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDevicesRequestObject struct {
-	Params ListDevicesParams
+type ReadResourceSync404JSONResponse Error
+
+func (response ReadResourceSync404JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDevicesResponseObject interface {
-	VisitListDevicesResponse(w http.ResponseWriter) error
+type PatchResourceSyncRequestObject struct {
+	Name string `json:"name"`
+	Body *PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
 }
 
-type ListDevices200JSONResponse DeviceList
+type PatchResourceSyncResponseObject interface {
+	VisitPatchResourceSyncResponse(w http.ResponseWriter) error
+}
 
-func (response ListDevices200JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+type PatchResourceSync200JSONResponse ResourceSync
+
+func (response PatchResourceSync200JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDevices400JSONResponse Error
+type PatchResourceSync400JSONResponse Error
 
-func (response ListDevices400JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+func (response PatchResourceSync400JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDevices401JSONResponse Error
+type PatchResourceSync401JSONResponse Error
 
-func (response ListDevices401JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+func (response PatchResourceSync401JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListDevices403JSONResponse Error
+type PatchResourceSync404JSONResponse Error
 
-func (response ListDevices403JSONResponse) VisitListDevicesResponse(w http.ResponseWriter) error {
+func (response PatchResourceSync404JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDeviceRequestObject struct {
-	Body *CreateDeviceJSONRequestBody
+type PatchResourceSync409JSONResponse Error
+
+func (response PatchResourceSync409JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDeviceResponseObject interface {
-	VisitCreateDeviceResponse(w http.ResponseWriter) error
+type ReplaceResourceSyncRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceResourceSyncJSONRequestBody
 }
 
-type CreateDevice201JSONResponse Device
+type ReplaceResourceSyncResponseObject interface {
+	VisitReplaceResourceSyncResponse(w http.ResponseWriter) error
+}
 
-func (response CreateDevice201JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+type ReplaceResourceSync200JSONResponse ResourceSync
+
+func (response ReplaceResourceSync200JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceResourceSync201JSONResponse ResourceSync
+
+func (response ReplaceResourceSync201JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDevice400JSONResponse Error
+type ReplaceResourceSync400JSONResponse Error
 
-func (response CreateDevice400JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+func (response ReplaceResourceSync400JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDevice401JSONResponse Error
+type ReplaceResourceSync401JSONResponse Error
 
-func (response CreateDevice401JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+func (response ReplaceResourceSync401JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateDevice409JSONResponse Error
+type ReplaceResourceSync404JSONResponse Error
 
-func (response CreateDevice409JSONResponse) VisitCreateDeviceResponse(w http.ResponseWriter) error {
+func (response ReplaceResourceSync404JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplaceResourceSync409JSONResponse Error
+
+func (response ReplaceResourceSync409JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDeviceRequestObject struct {
-	Name string `json:"name"`
+type ListImageSbomsRequestObject struct {
+	Params ListImageSbomsParams
 }
 
-type DeleteDeviceResponseObject interface {
-	VisitDeleteDeviceResponse(w http.ResponseWriter) error
+type ListImageSbomsResponseObject interface {
+	VisitListImageSbomsResponse(w http.ResponseWriter) error
 }
 
-type DeleteDevice200JSONResponse Device
+type ListImageSboms200JSONResponse ImageSbomList
 
-func (response DeleteDevice200JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+func (response ListImageSboms200JSONResponse) VisitListImageSbomsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDevice401JSONResponse Error
+type ListImageSboms400JSONResponse Error
 
-func (response DeleteDevice401JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+func (response ListImageSboms400JSONResponse) VisitListImageSbomsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteDevice404JSONResponse Error
+type ListImageSboms401JSONResponse Error
 
-func (response DeleteDevice404JSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+func (response ListImageSboms401JSONResponse) VisitListImageSbomsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDeviceRequestObject struct {
-	Name string `json:"name"`
+type DeleteSitesRequestObject struct {
 }
 
-type ReadDeviceResponseObject interface {
-	VisitReadDeviceResponse(w http.ResponseWriter) error
+type DeleteSitesResponseObject interface {
+	VisitDeleteSitesResponse(w http.ResponseWriter) error
 }
 
-type ReadDevice200JSONResponse Device
+type DeleteSites200JSONResponse Status
 
-func (response ReadDevice200JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+func (response DeleteSites200JSONResponse) VisitDeleteSitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDevice401JSONResponse Error
+type DeleteSites401JSONResponse Error
 
-func (response ReadDevice401JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
+func (response DeleteSites401JSONResponse) VisitDeleteSitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDevice404JSONResponse Error
-
-func (response ReadDevice404JSONResponse) VisitReadDeviceResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type PatchDeviceRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+type ListSitesRequestObject struct {
+	Params ListSitesParams
 }
 
-type PatchDeviceResponseObject interface {
-	VisitPatchDeviceResponse(w http.ResponseWriter) error
+type ListSitesResponseObject interface {
+	VisitListSitesResponse(w http.ResponseWriter) error
 }
 
-type PatchDevice200JSONResponse Device
+type ListSites200JSONResponse SiteList
 
-func (response PatchDevice200JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+func (response ListSites200JSONResponse) VisitListSitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchDevice400JSONResponse Error
+type ListSites400JSONResponse Error
 
-func (response PatchDevice400JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+func (response ListSites400JSONResponse) VisitListSitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchDevice401JSONResponse Error
+type ListSites401JSONResponse Error
 
-func (response PatchDevice401JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+func (response ListSites401JSONResponse) VisitListSitesResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PatchDevice404JSONResponse Error
-
-func (response PatchDevice404JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+type CreateSiteRequestObject struct {
+	Body *CreateSiteJSONRequestBody
+}
 
-	return json.NewEncoder(w).Encode(response)
+type CreateSiteResponseObject interface {
+	VisitCreateSiteResponse(w http.ResponseWriter) error
 }
 
-type PatchDevice409JSONResponse Error
+type CreateSite201JSONResponse Site
 
-func (response PatchDevice409JSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+func (response CreateSite201JSONResponse) VisitCreateSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDeviceRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceDeviceJSONRequestBody
-}
-
-type ReplaceDeviceResponseObject interface {
-	VisitReplaceDeviceResponse(w http.ResponseWriter) error
-}
-
-type ReplaceDevice200JSONResponse Device
+type CreateSite400JSONResponse Error
 
-func (response ReplaceDevice200JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+func (response CreateSite400JSONResponse) VisitCreateSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDevice201JSONResponse Device
+type CreateSite401JSONResponse Error
 
-func (response ReplaceDevice201JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+func (response CreateSite401JSONResponse) VisitCreateSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDevice400JSONResponse Error
+type CreateSite409JSONResponse Error
 
-func (response ReplaceDevice400JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+func (response CreateSite409JSONResponse) VisitCreateSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDevice401JSONResponse Error
+type DeleteSiteRequestObject struct {
+	Name string `json:"name"`
+}
 
-func (response ReplaceDevice401JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+type DeleteSiteResponseObject interface {
+	VisitDeleteSiteResponse(w http.ResponseWriter) error
+}
+
+type DeleteSite200JSONResponse Site
+
+func (response DeleteSite200JSONResponse) VisitDeleteSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDevice404JSONResponse Error
+type DeleteSite401JSONResponse Error
 
-func (response ReplaceDevice404JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+func (response DeleteSite401JSONResponse) VisitDeleteSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDevice409JSONResponse Error
+type DeleteSite404JSONResponse Error
 
-func (response ReplaceDevice409JSONResponse) VisitReplaceDeviceResponse(w http.ResponseWriter) error {
+func (response DeleteSite404JSONResponse) VisitDeleteSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RequestConsoleRequestObject struct {
+type ReadSiteRequestObject struct {
 	Name string `json:"name"`
 }
 
-type RequestConsoleResponseObject interface {
-	VisitRequestConsoleResponse(w http.ResponseWriter) error
+type ReadSiteResponseObject interface {
+	VisitReadSiteResponse(w http.ResponseWriter) error
 }
 
-type RequestConsole200JSONResponse DeviceConsole
+type ReadSite200JSONResponse Site
 
-func (response RequestConsole200JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+func (response ReadSite200JSONResponse) VisitReadSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RequestConsole401JSONResponse Error
+type ReadSite401JSONResponse Error
 
-func (response RequestConsole401JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+func (response ReadSite401JSONResponse) VisitReadSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RequestConsole404JSONResponse Error
+type ReadSite404JSONResponse Error
 
-func (response RequestConsole404JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
+func (response ReadSite404JSONResponse) VisitReadSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RequestConsole409JSONResponse Error
-
-func (response RequestConsole409JSONResponse) VisitRequestConsoleResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
-
-	return json.NewEncoder(w).Encode(response)
+type ReplaceSiteRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceSiteJSONRequestBody
 }
 
-type GetRenderedDeviceSpecRequestObject struct {
-	Name   string `json:"name"`
-	Params GetRenderedDeviceSpecParams
+type ReplaceSiteResponseObject interface {
+	VisitReplaceSiteResponse(w http.ResponseWriter) error
 }
 
-type GetRenderedDeviceSpecResponseObject interface {
-	VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error
+type ReplaceSite200JSONResponse Site
+
+func (response ReplaceSite200JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec200JSONResponse RenderedDeviceSpec
+type ReplaceSite201JSONResponse Site
 
-func (response GetRenderedDeviceSpec200JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ReplaceSite201JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec204Response struct {
-}
+type ReplaceSite400JSONResponse Error
 
-func (response GetRenderedDeviceSpec204Response) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
+func (response ReplaceSite400JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec401JSONResponse Error
+type ReplaceSite401JSONResponse Error
 
-func (response GetRenderedDeviceSpec401JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ReplaceSite401JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec404JSONResponse Error
+type ReplaceSite404JSONResponse Error
 
-func (response GetRenderedDeviceSpec404JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ReplaceSite404JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRenderedDeviceSpec409JSONResponse Error
+type ReplaceSite409JSONResponse Error
 
-func (response GetRenderedDeviceSpec409JSONResponse) VisitGetRenderedDeviceSpecResponse(w http.ResponseWriter) error {
+func (response ReplaceSite409JSONResponse) VisitReplaceSiteResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDeviceStatusRequestObject struct {
-	Name string `json:"name"`
+type ListTrashRequestObject struct {
+	Params ListTrashParams
 }
 
-type ReadDeviceStatusResponseObject interface {
-	VisitReadDeviceStatusResponse(w http.ResponseWriter) error
+type ListTrashResponseObject interface {
+	VisitListTrashResponse(w http.ResponseWriter) error
 }
 
-type ReadDeviceStatus200JSONResponse Device
+type ListTrash200JSONResponse TrashList
 
-func (response ReadDeviceStatus200JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+func (response ListTrash200JSONResponse) VisitListTrashResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDeviceStatus401JSONResponse Error
+type ListTrash400JSONResponse Error
 
-func (response ReadDeviceStatus401JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+func (response ListTrash400JSONResponse) VisitListTrashResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReadDeviceStatus404JSONResponse Error
+type ListTrash401JSONResponse Error
 
-func (response ReadDeviceStatus404JSONResponse) VisitReadDeviceStatusResponse(w http.ResponseWriter) error {
+func (response ListTrash401JSONResponse) VisitListTrashResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDeviceStatusRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceDeviceStatusJSONRequestBody
+type DeleteWebhooksRequestObject struct {
 }
 
-type ReplaceDeviceStatusResponseObject interface {
-	VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error
+type DeleteWebhooksResponseObject interface {
+	VisitDeleteWebhooksResponse(w http.ResponseWriter) error
 }
 
-type ReplaceDeviceStatus200JSONResponse Device
+type DeleteWebhooks200JSONResponse Status
 
-func (response ReplaceDeviceStatus200JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+func (response DeleteWebhooks200JSONResponse) VisitDeleteWebhooksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDeviceStatus400JSONResponse Error
+type DeleteWebhooks401JSONResponse Error
 
-func (response ReplaceDeviceStatus400JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+func (response DeleteWebhooks401JSONResponse) VisitDeleteWebhooksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDeviceStatus401JSONResponse Error
+type ListWebhooksRequestObject struct {
+	Params ListWebhooksParams
+}
 
-func (response ReplaceDeviceStatus401JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+type ListWebhooksResponseObject interface {
+	VisitListWebhooksResponse(w http.ResponseWriter) error
+}
+
+type ListWebhooks200JSONResponse WebhookList
+
+func (response ListWebhooks200JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ReplaceDeviceStatus404JSONResponse Error
+type ListWebhooks400JSONResponse Error
 
-func (response ReplaceDeviceStatus404JSONResponse) VisitReplaceDeviceStatusResponse(w http.ResponseWriter) error {
+func (response ListWebhooks400JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type EnrollmentConfigRequestObject struct {
-	Name string `json:"name"`
+type ListWebhooks401JSONResponse Error
+
+func (response ListWebhooks401JSONResponse) VisitListWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type EnrollmentConfigResponseObject interface {
-	VisitEnrollmentConfigResponse(w http.ResponseWriter) error
+type CreateWebhookRequestObject struct {
+	Body *CreateWebhookJSONRequestBody
 }
 
-type EnrollmentConfig200JSONResponse EnrollmentConfig
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(w http.ResponseWriter) error
+}
 
-func (response EnrollmentConfig200JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+type CreateWebhook201JSONResponse Webhook
+
+func (response CreateWebhook201JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type EnrollmentConfig400JSONResponse Error
+type CreateWebhook400JSONResponse Error
 
-func (response EnrollmentConfig400JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+func (response CreateWebhook400JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type EnrollmentConfig401JSONResponse Error
+type CreateWebhook401JSONResponse Error
 
-func (response EnrollmentConfig401JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+func (response CreateWebhook401JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type EnrollmentConfig404JSONResponse Error
+type CreateWebhook409JSONResponse Error
 
-func (response EnrollmentConfig404JSONResponse) VisitEnrollmentConfigResponse(w http.ResponseWriter) error {
+func (response CreateWebhook409JSONResponse) VisitCreateWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteEnrollmentRequestsRequestObject struct {
+type DeleteWebhookRequestObject struct {
+	Name string `json:"name"`
 }
 
-type DeleteEnrollmentRequestsResponseObject interface {
-	VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(w http.ResponseWriter) error
 }
 
-type DeleteEnrollmentRequests200JSONResponse Status
+type DeleteWebhook200JSONResponse Webhook
 
-func (response DeleteEnrollmentRequests200JSONResponse) VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteWebhook200JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteEnrollmentRequests401JSONResponse Error
+type DeleteWebhook401JSONResponse Error
 
-func (response DeleteEnrollmentRequests401JSONResponse) VisitDeleteEnrollmentRequestsResponse(w http.ResponseWriter) error {
+func (response DeleteWebhook401JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListEnrollmentRequestsRequestObject struct {
-	Params ListEnrollmentRequestsParams
+type DeleteWebhook404JSONResponse Error
+
+func (response DeleteWebhook404JSONResponse) VisitDeleteWebhookResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListEnrollmentRequestsResponseObject interface {
-	VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error
+type ReadWebhookRequestObject struct {
+	Name string `json:"name"`
 }
 
-type ListEnrollmentRequests200JSONResponse EnrollmentRequestList
+type ReadWebhookResponseObject interface {
+	VisitReadWebhookResponse(w http.ResponseWriter) error
+}
 
-func (response ListEnrollmentRequests200JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+type ReadWebhook200JSONResponse Webhook
+
+func (response ReadWebhook200JSONResponse) VisitReadWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListEnrollmentRequests400JSONResponse Error
+type ReadWebhook401JSONResponse Error
 
-func (response ListEnrollmentRequests400JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+func (response ReadWebhook401JSONResponse) VisitReadWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListEnrollmentRequests401JSONResponse Error
+type ReadWebhook404JSONResponse Error
 
-func (response ListEnrollmentRequests401JSONResponse) VisitListEnrollmentRequestsResponse(w http.ResponseWriter) error {
+func (response ReadWebhook404JSONResponse) VisitReadWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateEnrollmentRequestRequestObject struct {
-	Body *CreateEnrollmentRequestJSONRequestBody
+type ReplaceWebhookRequestObject struct {
+	Name string `json:"name"`
+	Body *ReplaceWebhookJSONRequestBody
 }
 
-type CreateEnrollmentRequestResponseObject interface {
-	VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error
+type ReplaceWebhookResponseObject interface {
+	VisitReplaceWebhookResponse(w http.ResponseWriter) error
 }
 
-type CreateEnrollmentRequest201JSONResponse EnrollmentRequest
+type ReplaceWebhook200JSONResponse Webhook
 
-func (response CreateEnrollmentRequest201JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook200JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateEnrollmentRequest208JSONResponse EnrollmentRequest
+type ReplaceWebhook201JSONResponse Webhook
 
-func (response CreateEnrollmentRequest208JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook201JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(208)
+	w.WriteHeader(201)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateEnrollmentRequest400JSONResponse Error
+type ReplaceWebhook400JSONResponse Error
 
-func (response CreateEnrollmentRequest400JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook400JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateEnrollmentRequest401JSONResponse Error
+type ReplaceWebhook401JSONResponse Error
 
-func (response CreateEnrollmentRequest401JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook401JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateEnrollmentRequest409JSONResponse Error
+type ReplaceWebhook404JSONResponse Error
 
-func (response CreateEnrollmentRequest409JSONResponse) VisitCreateEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook404JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteEnrollmentRequestRequestObject struct {
-	Name string `json:"name"`
-}
-
-type DeleteEnrollmentRequestResponseObject interface {
-	VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error
-}
-
-type DeleteEnrollmentRequest200JSONResponse EnrollmentRequest
+type ReplaceWebhook409JSONResponse Error
 
-func (response DeleteEnrollmentRequest200JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
+func (response ReplaceWebhook409JSONResponse) VisitReplaceWebhookResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(409)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteEnrollmentRequest401JSONResponse Error
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
 
-func (response DeleteEnrollmentRequest401JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (GET /api/v1/artifacts)
+	ListArtifacts(ctx context.Context, request ListArtifactsRequestObject) (ListArtifactsResponseObject, error)
+
+	// (GET /api/v1/artifacts/download)
+	DownloadArtifact(ctx context.Context, request DownloadArtifactRequestObject) (DownloadArtifactResponseObject, error)
+
+	// (GET /api/v1/artifacts/downloadurl)
+	CreateArtifactDownloadUrl(ctx context.Context, request CreateArtifactDownloadUrlRequestObject) (CreateArtifactDownloadUrlResponseObject, error)
+
+	// (GET /api/v1/auth/config)
+	AuthConfig(ctx context.Context, request AuthConfigRequestObject) (AuthConfigResponseObject, error)
+
+	// (GET /api/v1/auth/validate)
+	AuthValidate(ctx context.Context, request AuthValidateRequestObject) (AuthValidateResponseObject, error)
+
+	// (POST /api/v1/bulkimports)
+	CreateBulkImport(ctx context.Context, request CreateBulkImportRequestObject) (CreateBulkImportResponseObject, error)
+
+	// (GET /api/v1/certificates)
+	ListIssuedCertificates(ctx context.Context, request ListIssuedCertificatesRequestObject) (ListIssuedCertificatesResponseObject, error)
+
+	// (DELETE /api/v1/certificatesigningrequests)
+	DeleteCertificateSigningRequests(ctx context.Context, request DeleteCertificateSigningRequestsRequestObject) (DeleteCertificateSigningRequestsResponseObject, error)
+
+	// (GET /api/v1/certificatesigningrequests)
+	ListCertificateSigningRequests(ctx context.Context, request ListCertificateSigningRequestsRequestObject) (ListCertificateSigningRequestsResponseObject, error)
+
+	// (POST /api/v1/certificatesigningrequests)
+	CreateCertificateSigningRequest(ctx context.Context, request CreateCertificateSigningRequestRequestObject) (CreateCertificateSigningRequestResponseObject, error)
+
+	// (DELETE /api/v1/certificatesigningrequests/{name})
+	DeleteCertificateSigningRequest(ctx context.Context, request DeleteCertificateSigningRequestRequestObject) (DeleteCertificateSigningRequestResponseObject, error)
+
+	// (GET /api/v1/certificatesigningrequests/{name})
+	ReadCertificateSigningRequest(ctx context.Context, request ReadCertificateSigningRequestRequestObject) (ReadCertificateSigningRequestResponseObject, error)
+
+	// (PATCH /api/v1/certificatesigningrequests/{name})
+	PatchCertificateSigningRequest(ctx context.Context, request PatchCertificateSigningRequestRequestObject) (PatchCertificateSigningRequestResponseObject, error)
+
+	// (PUT /api/v1/certificatesigningrequests/{name})
+	ReplaceCertificateSigningRequest(ctx context.Context, request ReplaceCertificateSigningRequestRequestObject) (ReplaceCertificateSigningRequestResponseObject, error)
+
+	// (DELETE /api/v1/certificatesigningrequests/{name}/approval)
+	DenyCertificateSigningRequest(ctx context.Context, request DenyCertificateSigningRequestRequestObject) (DenyCertificateSigningRequestResponseObject, error)
+
+	// (POST /api/v1/certificatesigningrequests/{name}/approval)
+	ApproveCertificateSigningRequest(ctx context.Context, request ApproveCertificateSigningRequestRequestObject) (ApproveCertificateSigningRequestResponseObject, error)
+
+	// (GET /api/v1/dependencies)
+	ReadDependencies(ctx context.Context, request ReadDependenciesRequestObject) (ReadDependenciesResponseObject, error)
+
+	// (DELETE /api/v1/deviceidentities)
+	DeleteDeviceIdentities(ctx context.Context, request DeleteDeviceIdentitiesRequestObject) (DeleteDeviceIdentitiesResponseObject, error)
+
+	// (GET /api/v1/deviceidentities)
+	ListDeviceIdentities(ctx context.Context, request ListDeviceIdentitiesRequestObject) (ListDeviceIdentitiesResponseObject, error)
+
+	// (POST /api/v1/deviceidentities)
+	CreateDeviceIdentity(ctx context.Context, request CreateDeviceIdentityRequestObject) (CreateDeviceIdentityResponseObject, error)
+
+	// (DELETE /api/v1/deviceidentities/{name})
+	DeleteDeviceIdentity(ctx context.Context, request DeleteDeviceIdentityRequestObject) (DeleteDeviceIdentityResponseObject, error)
+
+	// (GET /api/v1/deviceidentities/{name})
+	ReadDeviceIdentity(ctx context.Context, request ReadDeviceIdentityRequestObject) (ReadDeviceIdentityResponseObject, error)
+
+	// (PUT /api/v1/deviceidentities/{name})
+	ReplaceDeviceIdentity(ctx context.Context, request ReplaceDeviceIdentityRequestObject) (ReplaceDeviceIdentityResponseObject, error)
+
+	// (DELETE /api/v1/devices)
+	DeleteDevices(ctx context.Context, request DeleteDevicesRequestObject) (DeleteDevicesResponseObject, error)
+
+	// (GET /api/v1/devices)
+	ListDevices(ctx context.Context, request ListDevicesRequestObject) (ListDevicesResponseObject, error)
+
+	// (POST /api/v1/devices)
+	CreateDevice(ctx context.Context, request CreateDeviceRequestObject) (CreateDeviceResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name})
+	DeleteDevice(ctx context.Context, request DeleteDeviceRequestObject) (DeleteDeviceResponseObject, error)
+
+	// (GET /api/v1/devices/{name})
+	ReadDevice(ctx context.Context, request ReadDeviceRequestObject) (ReadDeviceResponseObject, error)
+
+	// (PATCH /api/v1/devices/{name})
+	PatchDevice(ctx context.Context, request PatchDeviceRequestObject) (PatchDeviceResponseObject, error)
+
+	// (PUT /api/v1/devices/{name})
+	ReplaceDevice(ctx context.Context, request ReplaceDeviceRequestObject) (ReplaceDeviceResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name}/action)
+	CancelDeviceAction(ctx context.Context, request CancelDeviceActionRequestObject) (CancelDeviceActionResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/action)
+	RequestDeviceAction(ctx context.Context, request RequestDeviceActionRequestObject) (RequestDeviceActionResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/aliases)
+	ReplaceDeviceAliases(ctx context.Context, request ReplaceDeviceAliasesRequestObject) (ReplaceDeviceAliasesResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name}/attestation)
+	ResetDeviceAttestation(ctx context.Context, request ResetDeviceAttestationRequestObject) (ResetDeviceAttestationResponseObject, error)
+
+	// (GET /api/v1/devices/{name}/console)
+	RequestConsole(ctx context.Context, request RequestConsoleRequestObject) (RequestConsoleResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name}/consolegrant)
+	RevokeConsoleGrant(ctx context.Context, request RevokeConsoleGrantRequestObject) (RevokeConsoleGrantResponseObject, error)
+
+	// (GET /api/v1/devices/{name}/consolegrant)
+	GetConsoleGrant(ctx context.Context, request GetConsoleGrantRequestObject) (GetConsoleGrantResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/consolegrant)
+	RequestConsoleGrant(ctx context.Context, request RequestConsoleGrantRequestObject) (RequestConsoleGrantResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/consolegrant/approval)
+	ApproveConsoleGrant(ctx context.Context, request ApproveConsoleGrantRequestObject) (ApproveConsoleGrantResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/exec)
+	ExecDeviceCommand(ctx context.Context, request ExecDeviceCommandRequestObject) (ExecDeviceCommandResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/metrics)
+	PushDeviceMetrics(ctx context.Context, request PushDeviceMetricsRequestObject) (PushDeviceMetricsResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name}/quarantine)
+	ReleaseDeviceQuarantine(ctx context.Context, request ReleaseDeviceQuarantineRequestObject) (ReleaseDeviceQuarantineResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/quarantine)
+	QuarantineDevice(ctx context.Context, request QuarantineDeviceRequestObject) (QuarantineDeviceResponseObject, error)
+
+	// (GET /api/v1/devices/{name}/rendered)
+	GetRenderedDeviceSpec(ctx context.Context, request GetRenderedDeviceSpecRequestObject) (GetRenderedDeviceSpecResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/restore)
+	RestoreDevice(ctx context.Context, request RestoreDeviceRequestObject) (RestoreDeviceResponseObject, error)
+
+	// (GET /api/v1/devices/{name}/status)
+	ReadDeviceStatus(ctx context.Context, request ReadDeviceStatusRequestObject) (ReadDeviceStatusResponseObject, error)
+
+	// (PUT /api/v1/devices/{name}/status)
+	ReplaceDeviceStatus(ctx context.Context, request ReplaceDeviceStatusRequestObject) (ReplaceDeviceStatusResponseObject, error)
+
+	// (DELETE /api/v1/devices/{name}/tunnel)
+	CloseDeviceTunnel(ctx context.Context, request CloseDeviceTunnelRequestObject) (CloseDeviceTunnelResponseObject, error)
+
+	// (GET /api/v1/devices/{name}/tunnel)
+	GetDeviceTunnel(ctx context.Context, request GetDeviceTunnelRequestObject) (GetDeviceTunnelResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/tunnel)
+	RequestDeviceTunnel(ctx context.Context, request RequestDeviceTunnelRequestObject) (RequestDeviceTunnelResponseObject, error)
+
+	// (POST /api/v1/devices/{name}/wake)
+	WakeDevice(ctx context.Context, request WakeDeviceRequestObject) (WakeDeviceResponseObject, error)
+
+	// (PUT /api/v1/devicestatuses)
+	ReplaceDeviceStatuses(ctx context.Context, request ReplaceDeviceStatusesRequestObject) (ReplaceDeviceStatusesResponseObject, error)
+
+	// (DELETE /api/v1/deviceviews)
+	DeleteDeviceViews(ctx context.Context, request DeleteDeviceViewsRequestObject) (DeleteDeviceViewsResponseObject, error)
+
+	// (GET /api/v1/deviceviews)
+	ListDeviceViews(ctx context.Context, request ListDeviceViewsRequestObject) (ListDeviceViewsResponseObject, error)
+
+	// (POST /api/v1/deviceviews)
+	CreateDeviceView(ctx context.Context, request CreateDeviceViewRequestObject) (CreateDeviceViewResponseObject, error)
+
+	// (DELETE /api/v1/deviceviews/{name})
+	DeleteDeviceView(ctx context.Context, request DeleteDeviceViewRequestObject) (DeleteDeviceViewResponseObject, error)
+
+	// (GET /api/v1/deviceviews/{name})
+	ReadDeviceView(ctx context.Context, request ReadDeviceViewRequestObject) (ReadDeviceViewResponseObject, error)
+
+	// (PUT /api/v1/deviceviews/{name})
+	ReplaceDeviceView(ctx context.Context, request ReplaceDeviceViewRequestObject) (ReplaceDeviceViewResponseObject, error)
+
+	// (GET /api/v1/deviceviews/{name}/devices)
+	ListDeviceViewDevices(ctx context.Context, request ListDeviceViewDevicesRequestObject) (ListDeviceViewDevicesResponseObject, error)
+
+	// (GET /api/v1/enrollmentconfig/{name})
+	EnrollmentConfig(ctx context.Context, request EnrollmentConfigRequestObject) (EnrollmentConfigResponseObject, error)
+
+	// (DELETE /api/v1/enrollmentrequests)
+	DeleteEnrollmentRequests(ctx context.Context, request DeleteEnrollmentRequestsRequestObject) (DeleteEnrollmentRequestsResponseObject, error)
+
+	// (GET /api/v1/enrollmentrequests)
+	ListEnrollmentRequests(ctx context.Context, request ListEnrollmentRequestsRequestObject) (ListEnrollmentRequestsResponseObject, error)
+
+	// (POST /api/v1/enrollmentrequests)
+	CreateEnrollmentRequest(ctx context.Context, request CreateEnrollmentRequestRequestObject) (CreateEnrollmentRequestResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/enrollmentrequests/{name})
+	DeleteEnrollmentRequest(ctx context.Context, request DeleteEnrollmentRequestRequestObject) (DeleteEnrollmentRequestResponseObject, error)
 
-type DeleteEnrollmentRequest404JSONResponse Error
+	// (GET /api/v1/enrollmentrequests/{name})
+	ReadEnrollmentRequest(ctx context.Context, request ReadEnrollmentRequestRequestObject) (ReadEnrollmentRequestResponseObject, error)
 
-func (response DeleteEnrollmentRequest404JSONResponse) VisitDeleteEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// (PUT /api/v1/enrollmentrequests/{name})
+	ReplaceEnrollmentRequest(ctx context.Context, request ReplaceEnrollmentRequestRequestObject) (ReplaceEnrollmentRequestResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (POST /api/v1/enrollmentrequests/{name}/approval)
+	ApproveEnrollmentRequest(ctx context.Context, request ApproveEnrollmentRequestRequestObject) (ApproveEnrollmentRequestResponseObject, error)
 
-type ReadEnrollmentRequestRequestObject struct {
-	Name string `json:"name"`
-}
+	// (GET /api/v1/enrollmentrequests/{name}/status)
+	ReadEnrollmentRequestStatus(ctx context.Context, request ReadEnrollmentRequestStatusRequestObject) (ReadEnrollmentRequestStatusResponseObject, error)
 
-type ReadEnrollmentRequestResponseObject interface {
-	VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error
-}
+	// (PUT /api/v1/enrollmentrequests/{name}/status)
+	ReplaceEnrollmentRequestStatus(ctx context.Context, request ReplaceEnrollmentRequestStatusRequestObject) (ReplaceEnrollmentRequestStatusResponseObject, error)
 
-type ReadEnrollmentRequest200JSONResponse EnrollmentRequest
+	// (POST /api/v1/exports)
+	CreateResourceExport(ctx context.Context, request CreateResourceExportRequestObject) (CreateResourceExportResponseObject, error)
 
-func (response ReadEnrollmentRequest200JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// (DELETE /api/v1/fleets)
+	DeleteFleets(ctx context.Context, request DeleteFleetsRequestObject) (DeleteFleetsResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/fleets)
+	ListFleets(ctx context.Context, request ListFleetsRequestObject) (ListFleetsResponseObject, error)
 
-type ReadEnrollmentRequest401JSONResponse Error
+	// (POST /api/v1/fleets)
+	CreateFleet(ctx context.Context, request CreateFleetRequestObject) (CreateFleetResponseObject, error)
 
-func (response ReadEnrollmentRequest401JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (DELETE /api/v1/fleets/{fleet}/templateversions)
+	DeleteTemplateVersions(ctx context.Context, request DeleteTemplateVersionsRequestObject) (DeleteTemplateVersionsResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/fleets/{fleet}/templateversions)
+	ListTemplateVersions(ctx context.Context, request ListTemplateVersionsRequestObject) (ListTemplateVersionsResponseObject, error)
 
-type ReadEnrollmentRequest404JSONResponse Error
+	// (DELETE /api/v1/fleets/{fleet}/templateversions/{name})
+	DeleteTemplateVersion(ctx context.Context, request DeleteTemplateVersionRequestObject) (DeleteTemplateVersionResponseObject, error)
 
-func (response ReadEnrollmentRequest404JSONResponse) VisitReadEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// (GET /api/v1/fleets/{fleet}/templateversions/{name})
+	ReadTemplateVersion(ctx context.Context, request ReadTemplateVersionRequestObject) (ReadTemplateVersionResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/fleets/{name})
+	DeleteFleet(ctx context.Context, request DeleteFleetRequestObject) (DeleteFleetResponseObject, error)
 
-type ReplaceEnrollmentRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceEnrollmentRequestJSONRequestBody
-}
+	// (GET /api/v1/fleets/{name})
+	ReadFleet(ctx context.Context, request ReadFleetRequestObject) (ReadFleetResponseObject, error)
 
-type ReplaceEnrollmentRequestResponseObject interface {
-	VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error
-}
+	// (PATCH /api/v1/fleets/{name})
+	PatchFleet(ctx context.Context, request PatchFleetRequestObject) (PatchFleetResponseObject, error)
 
-type ReplaceEnrollmentRequest200JSONResponse EnrollmentRequest
+	// (PUT /api/v1/fleets/{name})
+	ReplaceFleet(ctx context.Context, request ReplaceFleetRequestObject) (ReplaceFleetResponseObject, error)
 
-func (response ReplaceEnrollmentRequest200JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// (POST /api/v1/fleets/{name}/lint)
+	LintFleet(ctx context.Context, request LintFleetRequestObject) (LintFleetResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/fleets/{name}/migration)
+	CancelFleetMigration(ctx context.Context, request CancelFleetMigrationRequestObject) (CancelFleetMigrationResponseObject, error)
 
-type ReplaceEnrollmentRequest201JSONResponse EnrollmentRequest
+	// (PUT /api/v1/fleets/{name}/migration)
+	MigrateFleetDevices(ctx context.Context, request MigrateFleetDevicesRequestObject) (MigrateFleetDevicesResponseObject, error)
 
-func (response ReplaceEnrollmentRequest201JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	// (PUT /api/v1/fleets/{name}/promotion/approve)
+	ApproveFleetPromotion(ctx context.Context, request ApproveFleetPromotionRequestObject) (ApproveFleetPromotionResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/fleets/{name}/provisioning)
+	ReadFleetProvisioning(ctx context.Context, request ReadFleetProvisioningRequestObject) (ReadFleetProvisioningResponseObject, error)
 
-type ReplaceEnrollmentRequest400JSONResponse Error
+	// (GET /api/v1/fleets/{name}/provisioningtokens)
+	ListProvisioningTokens(ctx context.Context, request ListProvisioningTokensRequestObject) (ListProvisioningTokensResponseObject, error)
 
-func (response ReplaceEnrollmentRequest400JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// (POST /api/v1/fleets/{name}/provisioningtokens)
+	CreateProvisioningToken(ctx context.Context, request CreateProvisioningTokenRequestObject) (CreateProvisioningTokenResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/fleets/{name}/provisioningtokens/{id})
+	DeleteProvisioningToken(ctx context.Context, request DeleteProvisioningTokenRequestObject) (DeleteProvisioningTokenResponseObject, error)
 
-type ReplaceEnrollmentRequest401JSONResponse Error
+	// (GET /api/v1/fleets/{name}/report)
+	ReadFleetReport(ctx context.Context, request ReadFleetReportRequestObject) (ReadFleetReportResponseObject, error)
 
-func (response ReplaceEnrollmentRequest401JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (POST /api/v1/fleets/{name}/restore)
+	RestoreFleet(ctx context.Context, request RestoreFleetRequestObject) (RestoreFleetResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (PUT /api/v1/fleets/{name}/rollout/abort)
+	AbortFleetRollout(ctx context.Context, request AbortFleetRolloutRequestObject) (AbortFleetRolloutResponseObject, error)
 
-type ReplaceEnrollmentRequest404JSONResponse Error
+	// (PUT /api/v1/fleets/{name}/rollout/pause)
+	PauseFleetRollout(ctx context.Context, request PauseFleetRolloutRequestObject) (PauseFleetRolloutResponseObject, error)
 
-func (response ReplaceEnrollmentRequest404JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// (PUT /api/v1/fleets/{name}/rollout/resume)
+	ResumeFleetRollout(ctx context.Context, request ResumeFleetRolloutRequestObject) (ResumeFleetRolloutResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/fleets/{name}/status)
+	ReadFleetStatus(ctx context.Context, request ReadFleetStatusRequestObject) (ReadFleetStatusResponseObject, error)
 
-type ReplaceEnrollmentRequest409JSONResponse Error
+	// (PUT /api/v1/fleets/{name}/status)
+	ReplaceFleetStatus(ctx context.Context, request ReplaceFleetStatusRequestObject) (ReplaceFleetStatusResponseObject, error)
 
-func (response ReplaceEnrollmentRequest409JSONResponse) VisitReplaceEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	// (POST /api/v1/imports)
+	CreateResourceImport(ctx context.Context, request CreateResourceImportRequestObject) (CreateResourceImportResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/labelrules)
+	DeleteLabelRules(ctx context.Context, request DeleteLabelRulesRequestObject) (DeleteLabelRulesResponseObject, error)
 
-type ApproveEnrollmentRequestRequestObject struct {
-	Name string `json:"name"`
-	Body *ApproveEnrollmentRequestJSONRequestBody
-}
+	// (GET /api/v1/labelrules)
+	ListLabelRules(ctx context.Context, request ListLabelRulesRequestObject) (ListLabelRulesResponseObject, error)
 
-type ApproveEnrollmentRequestResponseObject interface {
-	VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error
-}
+	// (POST /api/v1/labelrules)
+	CreateLabelRule(ctx context.Context, request CreateLabelRuleRequestObject) (CreateLabelRuleResponseObject, error)
 
-type ApproveEnrollmentRequest200JSONResponse EnrollmentRequestApproval
+	// (DELETE /api/v1/labelrules/{name})
+	DeleteLabelRule(ctx context.Context, request DeleteLabelRuleRequestObject) (DeleteLabelRuleResponseObject, error)
 
-func (response ApproveEnrollmentRequest200JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// (GET /api/v1/labelrules/{name})
+	ReadLabelRule(ctx context.Context, request ReadLabelRuleRequestObject) (ReadLabelRuleResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (PUT /api/v1/labelrules/{name})
+	ReplaceLabelRule(ctx context.Context, request ReplaceLabelRuleRequestObject) (ReplaceLabelRuleResponseObject, error)
 
-type ApproveEnrollmentRequest400JSONResponse Error
+	// (GET /api/v1/operations)
+	ListOperations(ctx context.Context, request ListOperationsRequestObject) (ListOperationsResponseObject, error)
 
-func (response ApproveEnrollmentRequest400JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// (POST /api/v1/operations)
+	CreateOperation(ctx context.Context, request CreateOperationRequestObject) (CreateOperationResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/operations/{name})
+	DeleteOperation(ctx context.Context, request DeleteOperationRequestObject) (DeleteOperationResponseObject, error)
 
-type ApproveEnrollmentRequest401JSONResponse Error
+	// (GET /api/v1/operations/{name})
+	ReadOperation(ctx context.Context, request ReadOperationRequestObject) (ReadOperationResponseObject, error)
 
-func (response ApproveEnrollmentRequest401JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (PUT /api/v1/operations/{name}/cancel)
+	CancelOperation(ctx context.Context, request CancelOperationRequestObject) (CancelOperationResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/repositories)
+	DeleteRepositories(ctx context.Context, request DeleteRepositoriesRequestObject) (DeleteRepositoriesResponseObject, error)
 
-type ApproveEnrollmentRequest404JSONResponse Error
+	// (GET /api/v1/repositories)
+	ListRepositories(ctx context.Context, request ListRepositoriesRequestObject) (ListRepositoriesResponseObject, error)
 
-func (response ApproveEnrollmentRequest404JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// (POST /api/v1/repositories)
+	CreateRepository(ctx context.Context, request CreateRepositoryRequestObject) (CreateRepositoryResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (DELETE /api/v1/repositories/{name})
+	DeleteRepository(ctx context.Context, request DeleteRepositoryRequestObject) (DeleteRepositoryResponseObject, error)
 
-type ApproveEnrollmentRequest422JSONResponse Error
+	// (GET /api/v1/repositories/{name})
+	ReadRepository(ctx context.Context, request ReadRepositoryRequestObject) (ReadRepositoryResponseObject, error)
 
-func (response ApproveEnrollmentRequest422JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(422)
+	// (PATCH /api/v1/repositories/{name})
+	PatchRepository(ctx context.Context, request PatchRepositoryRequestObject) (PatchRepositoryResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (PUT /api/v1/repositories/{name})
+	ReplaceRepository(ctx context.Context, request ReplaceRepositoryRequestObject) (ReplaceRepositoryResponseObject, error)
 
-type ApproveEnrollmentRequest500JSONResponse Error
+	// (PUT /api/v1/repositories/{name}/credentials)
+	RotateRepositoryCredentials(ctx context.Context, request RotateRepositoryCredentialsRequestObject) (RotateRepositoryCredentialsResponseObject, error)
 
-func (response ApproveEnrollmentRequest500JSONResponse) VisitApproveEnrollmentRequestResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// (DELETE /api/v1/resourcesyncs)
+	DeleteResourceSyncs(ctx context.Context, request DeleteResourceSyncsRequestObject) (DeleteResourceSyncsResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/resourcesyncs)
+	ListResourceSync(ctx context.Context, request ListResourceSyncRequestObject) (ListResourceSyncResponseObject, error)
 
-type ReadEnrollmentRequestStatusRequestObject struct {
-	Name string `json:"name"`
-}
+	// (POST /api/v1/resourcesyncs)
+	CreateResourceSync(ctx context.Context, request CreateResourceSyncRequestObject) (CreateResourceSyncResponseObject, error)
 
-type ReadEnrollmentRequestStatusResponseObject interface {
-	VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error
-}
+	// (DELETE /api/v1/resourcesyncs/{name})
+	DeleteResourceSync(ctx context.Context, request DeleteResourceSyncRequestObject) (DeleteResourceSyncResponseObject, error)
 
-type ReadEnrollmentRequestStatus200JSONResponse EnrollmentRequest
+	// (GET /api/v1/resourcesyncs/{name})
+	ReadResourceSync(ctx context.Context, request ReadResourceSyncRequestObject) (ReadResourceSyncResponseObject, error)
 
-func (response ReadEnrollmentRequestStatus200JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// (PATCH /api/v1/resourcesyncs/{name})
+	PatchResourceSync(ctx context.Context, request PatchResourceSyncRequestObject) (PatchResourceSyncResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (PUT /api/v1/resourcesyncs/{name})
+	ReplaceResourceSync(ctx context.Context, request ReplaceResourceSyncRequestObject) (ReplaceResourceSyncResponseObject, error)
 
-type ReadEnrollmentRequestStatus401JSONResponse Error
+	// (GET /api/v1/sboms)
+	ListImageSboms(ctx context.Context, request ListImageSbomsRequestObject) (ListImageSbomsResponseObject, error)
 
-func (response ReadEnrollmentRequestStatus401JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (DELETE /api/v1/sites)
+	DeleteSites(ctx context.Context, request DeleteSitesRequestObject) (DeleteSitesResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/sites)
+	ListSites(ctx context.Context, request ListSitesRequestObject) (ListSitesResponseObject, error)
 
-type ReadEnrollmentRequestStatus404JSONResponse Error
+	// (POST /api/v1/sites)
+	CreateSite(ctx context.Context, request CreateSiteRequestObject) (CreateSiteResponseObject, error)
 
-func (response ReadEnrollmentRequestStatus404JSONResponse) VisitReadEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	// (DELETE /api/v1/sites/{name})
+	DeleteSite(ctx context.Context, request DeleteSiteRequestObject) (DeleteSiteResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (GET /api/v1/sites/{name})
+	ReadSite(ctx context.Context, request ReadSiteRequestObject) (ReadSiteResponseObject, error)
 
-type ReplaceEnrollmentRequestStatusRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceEnrollmentRequestStatusJSONRequestBody
-}
+	// (PUT /api/v1/sites/{name})
+	ReplaceSite(ctx context.Context, request ReplaceSiteRequestObject) (ReplaceSiteResponseObject, error)
 
-type ReplaceEnrollmentRequestStatusResponseObject interface {
-	VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error
-}
+	// (GET /api/v1/trash)
+	ListTrash(ctx context.Context, request ListTrashRequestObject) (ListTrashResponseObject, error)
 
-type ReplaceEnrollmentRequestStatus200JSONResponse EnrollmentRequest
+	// (DELETE /api/v1/webhooks)
+	DeleteWebhooks(ctx context.Context, request DeleteWebhooksRequestObject) (DeleteWebhooksResponseObject, error)
 
-func (response ReplaceEnrollmentRequestStatus200JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// (GET /api/v1/webhooks)
+	ListWebhooks(ctx context.Context, request ListWebhooksRequestObject) (ListWebhooksResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// (POST /api/v1/webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
 
-type ReplaceEnrollmentRequestStatus401JSONResponse Error
+	// (DELETE /api/v1/webhooks/{name})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
 
-func (response ReplaceEnrollmentRequestStatus401JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	// (GET /api/v1/webhooks/{name})
+	ReadWebhook(ctx context.Context, request ReadWebhookRequestObject) (ReadWebhookResponseObject, error)
 
-	return json.NewEncoder(w).Encode(response)
+	// (PUT /api/v1/webhooks/{name})
+	ReplaceWebhook(ctx context.Context, request ReplaceWebhookRequestObject) (ReplaceWebhookResponseObject, error)
 }
 
-type ReplaceEnrollmentRequestStatus404JSONResponse Error
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
 
-func (response ReplaceEnrollmentRequestStatus404JSONResponse) VisitReplaceEnrollmentRequestStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
 
-	return json.NewEncoder(w).Encode(response)
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
 }
 
-type DeleteFleetsRequestObject struct {
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
 }
 
-type DeleteFleetsResponseObject interface {
-	VisitDeleteFleetsResponse(w http.ResponseWriter) error
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
 }
 
-type DeleteFleets200JSONResponse Status
-
-func (response DeleteFleets200JSONResponse) VisitDeleteFleetsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ListArtifacts operation middleware
+func (sh *strictHandler) ListArtifacts(w http.ResponseWriter, r *http.Request, params ListArtifactsParams) {
+	var request ListArtifactsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type DeleteFleets401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListArtifacts(ctx, request.(ListArtifactsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListArtifacts")
+	}
 
-func (response DeleteFleets401JSONResponse) VisitDeleteFleetsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListArtifactsResponseObject); ok {
+		if err := validResponse.VisitListArtifactsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListFleetsRequestObject struct {
-	Params ListFleetsParams
-}
+// DownloadArtifact operation middleware
+func (sh *strictHandler) DownloadArtifact(w http.ResponseWriter, r *http.Request, params DownloadArtifactParams) {
+	var request DownloadArtifactRequestObject
 
-type ListFleetsResponseObject interface {
-	VisitListFleetsResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type ListFleets200JSONResponse FleetList
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DownloadArtifact(ctx, request.(DownloadArtifactRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DownloadArtifact")
+	}
 
-func (response ListFleets200JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DownloadArtifactResponseObject); ok {
+		if err := validResponse.VisitDownloadArtifactResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListFleets400JSONResponse Error
+// CreateArtifactDownloadUrl operation middleware
+func (sh *strictHandler) CreateArtifactDownloadUrl(w http.ResponseWriter, r *http.Request, params CreateArtifactDownloadUrlParams) {
+	var request CreateArtifactDownloadUrlRequestObject
 
-func (response ListFleets400JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateArtifactDownloadUrl(ctx, request.(CreateArtifactDownloadUrlRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateArtifactDownloadUrl")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateArtifactDownloadUrlResponseObject); ok {
+		if err := validResponse.VisitCreateArtifactDownloadUrlResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListFleets401JSONResponse Error
+// AuthConfig operation middleware
+func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
+	var request AuthConfigRequestObject
 
-func (response ListFleets401JSONResponse) VisitListFleetsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthConfig(ctx, request.(AuthConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthConfig")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateFleetRequestObject struct {
-	Body *CreateFleetJSONRequestBody
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthConfigResponseObject); ok {
+		if err := validResponse.VisitAuthConfigResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateFleetResponseObject interface {
-	VisitCreateFleetResponse(w http.ResponseWriter) error
-}
+// AuthValidate operation middleware
+func (sh *strictHandler) AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams) {
+	var request AuthValidateRequestObject
 
-type CreateFleet201JSONResponse Fleet
+	request.Params = params
 
-func (response CreateFleet201JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthValidate(ctx, request.(AuthValidateRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthValidate")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateFleet400JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthValidateResponseObject); ok {
+		if err := validResponse.VisitAuthValidateResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response CreateFleet400JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// CreateBulkImport operation middleware
+func (sh *strictHandler) CreateBulkImport(w http.ResponseWriter, r *http.Request) {
+	var request CreateBulkImportRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateBulkImportJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type CreateFleet401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateBulkImport(ctx, request.(CreateBulkImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateBulkImport")
+	}
 
-func (response CreateFleet401JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateBulkImportResponseObject); ok {
+		if err := validResponse.VisitCreateBulkImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateFleet409JSONResponse Error
+// ListIssuedCertificates operation middleware
+func (sh *strictHandler) ListIssuedCertificates(w http.ResponseWriter, r *http.Request, params ListIssuedCertificatesParams) {
+	var request ListIssuedCertificatesRequestObject
 
-func (response CreateFleet409JSONResponse) VisitCreateFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListIssuedCertificates(ctx, request.(ListIssuedCertificatesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListIssuedCertificates")
+	}
 
-type DeleteTemplateVersionsRequestObject struct {
-	Fleet string `json:"fleet"`
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteTemplateVersionsResponseObject interface {
-	VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListIssuedCertificatesResponseObject); ok {
+		if err := validResponse.VisitListIssuedCertificatesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteTemplateVersions200JSONResponse Status
+// DeleteCertificateSigningRequests operation middleware
+func (sh *strictHandler) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
+	var request DeleteCertificateSigningRequestsRequestObject
 
-func (response DeleteTemplateVersions200JSONResponse) VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCertificateSigningRequests(ctx, request.(DeleteCertificateSigningRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCertificateSigningRequests")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteCertificateSigningRequestsResponseObject); ok {
+		if err := validResponse.VisitDeleteCertificateSigningRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteTemplateVersions401JSONResponse Error
+// ListCertificateSigningRequests operation middleware
+func (sh *strictHandler) ListCertificateSigningRequests(w http.ResponseWriter, r *http.Request, params ListCertificateSigningRequestsParams) {
+	var request ListCertificateSigningRequestsRequestObject
 
-func (response DeleteTemplateVersions401JSONResponse) VisitDeleteTemplateVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListCertificateSigningRequests(ctx, request.(ListCertificateSigningRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListCertificateSigningRequests")
+	}
 
-type ListTemplateVersionsRequestObject struct {
-	Fleet  string `json:"fleet"`
-	Params ListTemplateVersionsParams
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ListTemplateVersionsResponseObject interface {
-	VisitListTemplateVersionsResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListCertificateSigningRequestsResponseObject); ok {
+		if err := validResponse.VisitListCertificateSigningRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListTemplateVersions200JSONResponse TemplateVersionList
+// CreateCertificateSigningRequest operation middleware
+func (sh *strictHandler) CreateCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
+	var request CreateCertificateSigningRequestRequestObject
 
-func (response ListTemplateVersions200JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body CreateCertificateSigningRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCertificateSigningRequest(ctx, request.(CreateCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCertificateSigningRequest")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ListTemplateVersions400JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitCreateCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response ListTemplateVersions400JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// DeleteCertificateSigningRequest operation middleware
+func (sh *strictHandler) DeleteCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteCertificateSigningRequestRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ListTemplateVersions401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCertificateSigningRequest(ctx, request.(DeleteCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCertificateSigningRequest")
+	}
 
-func (response ListTemplateVersions401JSONResponse) VisitListTemplateVersionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitDeleteCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteTemplateVersionRequestObject struct {
-	Fleet string `json:"fleet"`
-	Name  string `json:"name"`
-}
+// ReadCertificateSigningRequest operation middleware
+func (sh *strictHandler) ReadCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadCertificateSigningRequestRequestObject
 
-type DeleteTemplateVersionResponseObject interface {
-	VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type DeleteTemplateVersion200JSONResponse TemplateVersion
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadCertificateSigningRequest(ctx, request.(ReadCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadCertificateSigningRequest")
+	}
 
-func (response DeleteTemplateVersion200JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitReadCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteTemplateVersion401JSONResponse Error
+// PatchCertificateSigningRequest operation middleware
+func (sh *strictHandler) PatchCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request PatchCertificateSigningRequestRequestObject
 
-func (response DeleteTemplateVersion401JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type DeleteTemplateVersion404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchCertificateSigningRequest(ctx, request.(PatchCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchCertificateSigningRequest")
+	}
 
-func (response DeleteTemplateVersion404JSONResponse) VisitDeleteTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitPatchCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadTemplateVersionRequestObject struct {
-	Fleet string `json:"fleet"`
-	Name  string `json:"name"`
-}
+// ReplaceCertificateSigningRequest operation middleware
+func (sh *strictHandler) ReplaceCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceCertificateSigningRequestRequestObject
 
-type ReadTemplateVersionResponseObject interface {
-	VisitReadTemplateVersionResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type ReadTemplateVersion200JSONResponse TemplateVersion
+	var body ReplaceCertificateSigningRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response ReadTemplateVersion200JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceCertificateSigningRequest(ctx, request.(ReplaceCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceCertificateSigningRequest")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReadTemplateVersion401JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitReplaceCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response ReadTemplateVersion401JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// DenyCertificateSigningRequest operation middleware
+func (sh *strictHandler) DenyCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request DenyCertificateSigningRequestRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReadTemplateVersion404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DenyCertificateSigningRequest(ctx, request.(DenyCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DenyCertificateSigningRequest")
+	}
 
-func (response ReadTemplateVersion404JSONResponse) VisitReadTemplateVersionResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DenyCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitDenyCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteFleetRequestObject struct {
-	Name string `json:"name"`
-}
+// ApproveCertificateSigningRequest operation middleware
+func (sh *strictHandler) ApproveCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ApproveCertificateSigningRequestRequestObject
 
-type DeleteFleetResponseObject interface {
-	VisitDeleteFleetResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type DeleteFleet200JSONResponse Fleet
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveCertificateSigningRequest(ctx, request.(ApproveCertificateSigningRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveCertificateSigningRequest")
+	}
 
-func (response DeleteFleet200JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveCertificateSigningRequestResponseObject); ok {
+		if err := validResponse.VisitApproveCertificateSigningRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteFleet401JSONResponse Error
-
-func (response DeleteFleet401JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// ReadDependencies operation middleware
+func (sh *strictHandler) ReadDependencies(w http.ResponseWriter, r *http.Request, params ReadDependenciesParams) {
+	var request ReadDependenciesRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type DeleteFleet404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDependencies(ctx, request.(ReadDependenciesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDependencies")
+	}
 
-func (response DeleteFleet404JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDependenciesResponseObject); ok {
+		if err := validResponse.VisitReadDependenciesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteFleet409JSONResponse Error
+// DeleteDeviceIdentities operation middleware
+func (sh *strictHandler) DeleteDeviceIdentities(w http.ResponseWriter, r *http.Request) {
+	var request DeleteDeviceIdentitiesRequestObject
 
-func (response DeleteFleet409JSONResponse) VisitDeleteFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDeviceIdentities(ctx, request.(DeleteDeviceIdentitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDeviceIdentities")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDeviceIdentitiesResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceIdentitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadFleetRequestObject struct {
-	Name   string `json:"name"`
-	Params ReadFleetParams
-}
+// ListDeviceIdentities operation middleware
+func (sh *strictHandler) ListDeviceIdentities(w http.ResponseWriter, r *http.Request, params ListDeviceIdentitiesParams) {
+	var request ListDeviceIdentitiesRequestObject
 
-type ReadFleetResponseObject interface {
-	VisitReadFleetResponse(w http.ResponseWriter) error
-}
+	request.Params = params
 
-type ReadFleet200JSONResponse Fleet
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeviceIdentities(ctx, request.(ListDeviceIdentitiesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeviceIdentities")
+	}
 
-func (response ReadFleet200JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDeviceIdentitiesResponseObject); ok {
+		if err := validResponse.VisitListDeviceIdentitiesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadFleet401JSONResponse Error
-
-func (response ReadFleet401JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// CreateDeviceIdentity operation middleware
+func (sh *strictHandler) CreateDeviceIdentity(w http.ResponseWriter, r *http.Request) {
+	var request CreateDeviceIdentityRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateDeviceIdentityJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReadFleet404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDeviceIdentity(ctx, request.(CreateDeviceIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDeviceIdentity")
+	}
 
-func (response ReadFleet404JSONResponse) VisitReadFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDeviceIdentityResponseObject); ok {
+		if err := validResponse.VisitCreateDeviceIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchFleetRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchFleetApplicationJSONPatchPlusJSONRequestBody
-}
+// DeleteDeviceIdentity operation middleware
+func (sh *strictHandler) DeleteDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteDeviceIdentityRequestObject
 
-type PatchFleetResponseObject interface {
-	VisitPatchFleetResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type PatchFleet200JSONResponse Fleet
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDeviceIdentity(ctx, request.(DeleteDeviceIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDeviceIdentity")
+	}
 
-func (response PatchFleet200JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDeviceIdentityResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchFleet400JSONResponse Error
-
-func (response PatchFleet400JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ReadDeviceIdentity operation middleware
+func (sh *strictHandler) ReadDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadDeviceIdentityRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type PatchFleet401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDeviceIdentity(ctx, request.(ReadDeviceIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDeviceIdentity")
+	}
 
-func (response PatchFleet401JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDeviceIdentityResponseObject); ok {
+		if err := validResponse.VisitReadDeviceIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchFleet404JSONResponse Error
-
-func (response PatchFleet404JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// ReplaceDeviceIdentity operation middleware
+func (sh *strictHandler) ReplaceDeviceIdentity(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceIdentityRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type PatchFleet409JSONResponse Error
+	var body ReplaceDeviceIdentityJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response PatchFleet409JSONResponse) VisitPatchFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceIdentity(ctx, request.(ReplaceDeviceIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceIdentity")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReplaceFleetRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceFleetJSONRequestBody
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceIdentityResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceIdentityResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleetResponseObject interface {
-	VisitReplaceFleetResponse(w http.ResponseWriter) error
-}
+// DeleteDevices operation middleware
+func (sh *strictHandler) DeleteDevices(w http.ResponseWriter, r *http.Request) {
+	var request DeleteDevicesRequestObject
 
-type ReplaceFleet200JSONResponse Fleet
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDevices(ctx, request.(DeleteDevicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDevices")
+	}
 
-func (response ReplaceFleet200JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDevicesResponseObject); ok {
+		if err := validResponse.VisitDeleteDevicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleet201JSONResponse Fleet
-
-func (response ReplaceFleet201JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+// ListDevices operation middleware
+func (sh *strictHandler) ListDevices(w http.ResponseWriter, r *http.Request, params ListDevicesParams) {
+	var request ListDevicesRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type ReplaceFleet400JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDevices(ctx, request.(ListDevicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDevices")
+	}
 
-func (response ReplaceFleet400JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDevicesResponseObject); ok {
+		if err := validResponse.VisitListDevicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleet401JSONResponse Error
-
-func (response ReplaceFleet401JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// CreateDevice operation middleware
+func (sh *strictHandler) CreateDevice(w http.ResponseWriter, r *http.Request) {
+	var request CreateDeviceRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReplaceFleet404JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDevice(ctx, request.(CreateDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDevice")
+	}
 
-func (response ReplaceFleet404JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDeviceResponseObject); ok {
+		if err := validResponse.VisitCreateDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleet409JSONResponse Error
+// DeleteDevice operation middleware
+func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteDeviceRequestObject
 
-func (response ReplaceFleet409JSONResponse) VisitReplaceFleetResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDevice(ctx, request.(DeleteDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDevice")
+	}
 
-type ReadFleetStatusRequestObject struct {
-	Name string `json:"name"`
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReadFleetStatusResponseObject interface {
-	VisitReadFleetStatusResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDeviceResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadFleetStatus200JSONResponse Fleet
-
-func (response ReadFleetStatus200JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ReadDevice operation middleware
+func (sh *strictHandler) ReadDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadDeviceRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReadFleetStatus401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDevice(ctx, request.(ReadDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDevice")
+	}
 
-func (response ReadFleetStatus401JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDeviceResponseObject); ok {
+		if err := validResponse.VisitReadDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadFleetStatus404JSONResponse Error
+// PatchDevice operation middleware
+func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request PatchDeviceRequestObject
 
-func (response ReadFleetStatus404JSONResponse) VisitReadFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReplaceFleetStatusRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceFleetStatusJSONRequestBody
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchDevice(ctx, request.(PatchDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchDevice")
+	}
 
-type ReplaceFleetStatusResponseObject interface {
-	VisitReplaceFleetStatusResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchDeviceResponseObject); ok {
+		if err := validResponse.VisitPatchDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleetStatus200JSONResponse Fleet
+// ReplaceDevice operation middleware
+func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceRequestObject
 
-func (response ReplaceFleetStatus200JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ReplaceDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReplaceFleetStatus401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDevice(ctx, request.(ReplaceDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDevice")
+	}
 
-func (response ReplaceFleetStatus401JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceFleetStatus404JSONResponse Error
+// CancelDeviceAction operation middleware
+func (sh *strictHandler) CancelDeviceAction(w http.ResponseWriter, r *http.Request, name string) {
+	var request CancelDeviceActionRequestObject
 
-func (response ReplaceFleetStatus404JSONResponse) VisitReplaceFleetStatusResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelDeviceAction(ctx, request.(CancelDeviceActionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelDeviceAction")
+	}
 
-type DeleteRepositoriesRequestObject struct {
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteRepositoriesResponseObject interface {
-	VisitDeleteRepositoriesResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelDeviceActionResponseObject); ok {
+		if err := validResponse.VisitCancelDeviceActionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepositories200JSONResponse Status
+// RequestDeviceAction operation middleware
+func (sh *strictHandler) RequestDeviceAction(w http.ResponseWriter, r *http.Request, name string) {
+	var request RequestDeviceActionRequestObject
 
-func (response DeleteRepositories200JSONResponse) VisitDeleteRepositoriesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body RequestDeviceActionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type DeleteRepositories401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestDeviceAction(ctx, request.(RequestDeviceActionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestDeviceAction")
+	}
 
-func (response DeleteRepositories401JSONResponse) VisitDeleteRepositoriesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestDeviceActionResponseObject); ok {
+		if err := validResponse.VisitRequestDeviceActionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListRepositoriesRequestObject struct {
-	Params ListRepositoriesParams
-}
+// ReplaceDeviceAliases operation middleware
+func (sh *strictHandler) ReplaceDeviceAliases(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceAliasesRequestObject
 
-type ListRepositoriesResponseObject interface {
-	VisitListRepositoriesResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type ListRepositories200JSONResponse RepositoryList
+	var body ReplaceDeviceAliasesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response ListRepositories200JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceAliases(ctx, request.(ReplaceDeviceAliasesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceAliases")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ListRepositories400JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceAliasesResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceAliasesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response ListRepositories400JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ResetDeviceAttestation operation middleware
+func (sh *strictHandler) ResetDeviceAttestation(w http.ResponseWriter, r *http.Request, name string) {
+	var request ResetDeviceAttestationRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ListRepositories401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetDeviceAttestation(ctx, request.(ResetDeviceAttestationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetDeviceAttestation")
+	}
 
-func (response ListRepositories401JSONResponse) VisitListRepositoriesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetDeviceAttestationResponseObject); ok {
+		if err := validResponse.VisitResetDeviceAttestationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateRepositoryRequestObject struct {
-	Body *CreateRepositoryJSONRequestBody
-}
+// RequestConsole operation middleware
+func (sh *strictHandler) RequestConsole(w http.ResponseWriter, r *http.Request, name string, params RequestConsoleParams) {
+	var request RequestConsoleRequestObject
 
-type CreateRepositoryResponseObject interface {
-	VisitCreateRepositoryResponse(w http.ResponseWriter) error
-}
+	request.Name = name
+	request.Params = params
 
-type CreateRepository201JSONResponse Repository
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestConsole(ctx, request.(RequestConsoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestConsole")
+	}
 
-func (response CreateRepository201JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestConsoleResponseObject); ok {
+		if err := validResponse.VisitRequestConsoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateRepository400JSONResponse Error
-
-func (response CreateRepository400JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// RevokeConsoleGrant operation middleware
+func (sh *strictHandler) RevokeConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	var request RevokeConsoleGrantRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type CreateRepository401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeConsoleGrant(ctx, request.(RevokeConsoleGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeConsoleGrant")
+	}
 
-func (response CreateRepository401JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeConsoleGrantResponseObject); ok {
+		if err := validResponse.VisitRevokeConsoleGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateRepository409JSONResponse Error
+// GetConsoleGrant operation middleware
+func (sh *strictHandler) GetConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	var request GetConsoleGrantRequestObject
 
-func (response CreateRepository409JSONResponse) VisitCreateRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConsoleGrant(ctx, request.(GetConsoleGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConsoleGrant")
+	}
 
-type DeleteRepositoryRequestObject struct {
-	Name string `json:"name"`
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteRepositoryResponseObject interface {
-	VisitDeleteRepositoryResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetConsoleGrantResponseObject); ok {
+		if err := validResponse.VisitGetConsoleGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepository200JSONResponse Repository
+// RequestConsoleGrant operation middleware
+func (sh *strictHandler) RequestConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	var request RequestConsoleGrantRequestObject
 
-func (response DeleteRepository200JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body RequestConsoleGrantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type DeleteRepository401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestConsoleGrant(ctx, request.(RequestConsoleGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestConsoleGrant")
+	}
 
-func (response DeleteRepository401JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestConsoleGrantResponseObject); ok {
+		if err := validResponse.VisitRequestConsoleGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteRepository404JSONResponse Error
+// ApproveConsoleGrant operation middleware
+func (sh *strictHandler) ApproveConsoleGrant(w http.ResponseWriter, r *http.Request, name string) {
+	var request ApproveConsoleGrantRequestObject
 
-func (response DeleteRepository404JSONResponse) VisitDeleteRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ApproveConsoleGrantJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReadRepositoryRequestObject struct {
-	Name string `json:"name"`
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveConsoleGrant(ctx, request.(ApproveConsoleGrantRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveConsoleGrant")
+	}
 
-type ReadRepositoryResponseObject interface {
-	VisitReadRepositoryResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveConsoleGrantResponseObject); ok {
+		if err := validResponse.VisitApproveConsoleGrantResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadRepository200JSONResponse Repository
+// ExecDeviceCommand operation middleware
+func (sh *strictHandler) ExecDeviceCommand(w http.ResponseWriter, r *http.Request, name string) {
+	var request ExecDeviceCommandRequestObject
 
-func (response ReadRepository200JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ExecDeviceCommandJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReadRepository401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ExecDeviceCommand(ctx, request.(ExecDeviceCommandRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExecDeviceCommand")
+	}
 
-func (response ReadRepository401JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ExecDeviceCommandResponseObject); ok {
+		if err := validResponse.VisitExecDeviceCommandResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadRepository404JSONResponse Error
+// PushDeviceMetrics operation middleware
+func (sh *strictHandler) PushDeviceMetrics(w http.ResponseWriter, r *http.Request, name string) {
+	var request PushDeviceMetricsRequestObject
 
-func (response ReadRepository404JSONResponse) VisitReadRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Body = r.Body
 
-type PatchRepositoryRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PushDeviceMetrics(ctx, request.(PushDeviceMetricsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PushDeviceMetrics")
+	}
 
-type PatchRepositoryResponseObject interface {
-	VisitPatchRepositoryResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PushDeviceMetricsResponseObject); ok {
+		if err := validResponse.VisitPushDeviceMetricsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchRepository200JSONResponse Repository
+// ReleaseDeviceQuarantine operation middleware
+func (sh *strictHandler) ReleaseDeviceQuarantine(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReleaseDeviceQuarantineRequestObject
 
-func (response PatchRepository200JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReleaseDeviceQuarantine(ctx, request.(ReleaseDeviceQuarantineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReleaseDeviceQuarantine")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReleaseDeviceQuarantineResponseObject); ok {
+		if err := validResponse.VisitReleaseDeviceQuarantineResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchRepository400JSONResponse Error
+// QuarantineDevice operation middleware
+func (sh *strictHandler) QuarantineDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request QuarantineDeviceRequestObject
 
-func (response PatchRepository400JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body QuarantineDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type PatchRepository401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.QuarantineDevice(ctx, request.(QuarantineDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "QuarantineDevice")
+	}
 
-func (response PatchRepository401JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(QuarantineDeviceResponseObject); ok {
+		if err := validResponse.VisitQuarantineDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchRepository404JSONResponse Error
+// GetRenderedDeviceSpec operation middleware
+func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
+	var request GetRenderedDeviceSpecRequestObject
 
-func (response PatchRepository404JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRenderedDeviceSpec(ctx, request.(GetRenderedDeviceSpecRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRenderedDeviceSpec")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRenderedDeviceSpecResponseObject); ok {
+		if err := validResponse.VisitGetRenderedDeviceSpecResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchRepository409JSONResponse Error
+// RestoreDevice operation middleware
+func (sh *strictHandler) RestoreDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request RestoreDeviceRequestObject
 
-func (response PatchRepository409JSONResponse) VisitPatchRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreDevice(ctx, request.(RestoreDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreDevice")
+	}
 
-type ReplaceRepositoryRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceRepositoryJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReplaceRepositoryResponseObject interface {
-	VisitReplaceRepositoryResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RestoreDeviceResponseObject); ok {
+		if err := validResponse.VisitRestoreDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceRepository200JSONResponse Repository
-
-func (response ReplaceRepository200JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ReadDeviceStatus operation middleware
+func (sh *strictHandler) ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadDeviceStatusRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReplaceRepository201JSONResponse Repository
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDeviceStatus(ctx, request.(ReadDeviceStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDeviceStatus")
+	}
 
-func (response ReplaceRepository201JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDeviceStatusResponseObject); ok {
+		if err := validResponse.VisitReadDeviceStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceRepository400JSONResponse Error
+// ReplaceDeviceStatus operation middleware
+func (sh *strictHandler) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceStatusRequestObject
 
-func (response ReplaceRepository400JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ReplaceDeviceStatusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReplaceRepository401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceStatus(ctx, request.(ReplaceDeviceStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceStatus")
+	}
 
-func (response ReplaceRepository401JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceStatusResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceRepository404JSONResponse Error
-
-func (response ReplaceRepository404JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// CloseDeviceTunnel operation middleware
+func (sh *strictHandler) CloseDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	var request CloseDeviceTunnelRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReplaceRepository409JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CloseDeviceTunnel(ctx, request.(CloseDeviceTunnelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CloseDeviceTunnel")
+	}
 
-func (response ReplaceRepository409JSONResponse) VisitReplaceRepositoryResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CloseDeviceTunnelResponseObject); ok {
+		if err := validResponse.VisitCloseDeviceTunnelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteResourceSyncsRequestObject struct {
-}
+// GetDeviceTunnel operation middleware
+func (sh *strictHandler) GetDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	var request GetDeviceTunnelRequestObject
 
-type DeleteResourceSyncsResponseObject interface {
-	VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error
-}
+	request.Name = name
 
-type DeleteResourceSyncs200JSONResponse Status
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDeviceTunnel(ctx, request.(GetDeviceTunnelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDeviceTunnel")
+	}
 
-func (response DeleteResourceSyncs200JSONResponse) VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDeviceTunnelResponseObject); ok {
+		if err := validResponse.VisitGetDeviceTunnelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteResourceSyncs401JSONResponse Error
+// RequestDeviceTunnel operation middleware
+func (sh *strictHandler) RequestDeviceTunnel(w http.ResponseWriter, r *http.Request, name string) {
+	var request RequestDeviceTunnelRequestObject
 
-func (response DeleteResourceSyncs401JSONResponse) VisitDeleteResourceSyncsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body RequestDeviceTunnelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ListResourceSyncRequestObject struct {
-	Params ListResourceSyncParams
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestDeviceTunnel(ctx, request.(RequestDeviceTunnelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestDeviceTunnel")
+	}
 
-type ListResourceSyncResponseObject interface {
-	VisitListResourceSyncResponse(w http.ResponseWriter) error
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequestDeviceTunnelResponseObject); ok {
+		if err := validResponse.VisitRequestDeviceTunnelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListResourceSync200JSONResponse ResourceSyncList
+// WakeDevice operation middleware
+func (sh *strictHandler) WakeDevice(w http.ResponseWriter, r *http.Request, name string) {
+	var request WakeDeviceRequestObject
 
-func (response ListResourceSync200JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body WakeDeviceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ListResourceSync400JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.WakeDevice(ctx, request.(WakeDeviceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "WakeDevice")
+	}
 
-func (response ListResourceSync400JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(WakeDeviceResponseObject); ok {
+		if err := validResponse.VisitWakeDeviceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ListResourceSync401JSONResponse Error
+// ReplaceDeviceStatuses operation middleware
+func (sh *strictHandler) ReplaceDeviceStatuses(w http.ResponseWriter, r *http.Request) {
+	var request ReplaceDeviceStatusesRequestObject
 
-func (response ListResourceSync401JSONResponse) VisitListResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var body ReplaceDeviceStatusesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceStatuses(ctx, request.(ReplaceDeviceStatusesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceStatuses")
+	}
 
-type CreateResourceSyncRequestObject struct {
-	Body *CreateResourceSyncJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateResourceSyncResponseObject interface {
-	VisitCreateResourceSyncResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceStatusesResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceStatusesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateResourceSync201JSONResponse ResourceSync
+// DeleteDeviceViews operation middleware
+func (sh *strictHandler) DeleteDeviceViews(w http.ResponseWriter, r *http.Request) {
+	var request DeleteDeviceViewsRequestObject
 
-func (response CreateResourceSync201JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDeviceViews(ctx, request.(DeleteDeviceViewsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDeviceViews")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type CreateResourceSync400JSONResponse Error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDeviceViewsResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceViewsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-func (response CreateResourceSync400JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ListDeviceViews operation middleware
+func (sh *strictHandler) ListDeviceViews(w http.ResponseWriter, r *http.Request, params ListDeviceViewsParams) {
+	var request ListDeviceViewsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type CreateResourceSync401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeviceViews(ctx, request.(ListDeviceViewsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeviceViews")
+	}
 
-func (response CreateResourceSync401JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDeviceViewsResponseObject); ok {
+		if err := validResponse.VisitListDeviceViewsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type CreateResourceSync409JSONResponse Error
+// CreateDeviceView operation middleware
+func (sh *strictHandler) CreateDeviceView(w http.ResponseWriter, r *http.Request) {
+	var request CreateDeviceViewRequestObject
 
-func (response CreateResourceSync409JSONResponse) VisitCreateResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var body CreateDeviceViewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDeviceView(ctx, request.(CreateDeviceViewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDeviceView")
+	}
 
-type DeleteResourceSyncRequestObject struct {
-	Name string `json:"name"`
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type DeleteResourceSyncResponseObject interface {
-	VisitDeleteResourceSyncResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDeviceViewResponseObject); ok {
+		if err := validResponse.VisitCreateDeviceViewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteResourceSync200JSONResponse ResourceSync
-
-func (response DeleteResourceSync200JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// DeleteDeviceView operation middleware
+func (sh *strictHandler) DeleteDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteDeviceViewRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type DeleteResourceSync401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDeviceView(ctx, request.(DeleteDeviceViewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDeviceView")
+	}
 
-func (response DeleteResourceSync401JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDeviceViewResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceViewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type DeleteResourceSync404JSONResponse Error
+// ReadDeviceView operation middleware
+func (sh *strictHandler) ReadDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadDeviceViewRequestObject
 
-func (response DeleteResourceSync404JSONResponse) VisitDeleteResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadDeviceView(ctx, request.(ReadDeviceViewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadDeviceView")
+	}
 
-type ReadResourceSyncRequestObject struct {
-	Name string `json:"name"`
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReadResourceSyncResponseObject interface {
-	VisitReadResourceSyncResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadDeviceViewResponseObject); ok {
+		if err := validResponse.VisitReadDeviceViewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadResourceSync200JSONResponse ResourceSync
+// ReplaceDeviceView operation middleware
+func (sh *strictHandler) ReplaceDeviceView(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceDeviceViewRequestObject
 
-func (response ReadResourceSync200JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ReplaceDeviceViewJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReadResourceSync401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceDeviceView(ctx, request.(ReplaceDeviceViewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceDeviceView")
+	}
 
-func (response ReadResourceSync401JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceDeviceViewResponseObject); ok {
+		if err := validResponse.VisitReplaceDeviceViewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReadResourceSync404JSONResponse Error
+// ListDeviceViewDevices operation middleware
+func (sh *strictHandler) ListDeviceViewDevices(w http.ResponseWriter, r *http.Request, name string, params ListDeviceViewDevicesParams) {
+	var request ListDeviceViewDevicesRequestObject
 
-func (response ReadResourceSync404JSONResponse) VisitReadResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListDeviceViewDevices(ctx, request.(ListDeviceViewDevicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListDeviceViewDevices")
+	}
 
-type PatchResourceSyncRequestObject struct {
-	Name string `json:"name"`
-	Body *PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PatchResourceSyncResponseObject interface {
-	VisitPatchResourceSyncResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListDeviceViewDevicesResponseObject); ok {
+		if err := validResponse.VisitListDeviceViewDevicesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchResourceSync200JSONResponse ResourceSync
-
-func (response PatchResourceSync200JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// EnrollmentConfig operation middleware
+func (sh *strictHandler) EnrollmentConfig(w http.ResponseWriter, r *http.Request, name string) {
+	var request EnrollmentConfigRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type PatchResourceSync400JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EnrollmentConfig(ctx, request.(EnrollmentConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EnrollmentConfig")
+	}
 
-func (response PatchResourceSync400JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EnrollmentConfigResponseObject); ok {
+		if err := validResponse.VisitEnrollmentConfigResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchResourceSync401JSONResponse Error
+// DeleteEnrollmentRequests operation middleware
+func (sh *strictHandler) DeleteEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
+	var request DeleteEnrollmentRequestsRequestObject
 
-func (response PatchResourceSync401JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteEnrollmentRequests(ctx, request.(DeleteEnrollmentRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteEnrollmentRequests")
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteEnrollmentRequestsResponseObject); ok {
+		if err := validResponse.VisitDeleteEnrollmentRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchResourceSync404JSONResponse Error
+// ListEnrollmentRequests operation middleware
+func (sh *strictHandler) ListEnrollmentRequests(w http.ResponseWriter, r *http.Request, params ListEnrollmentRequestsParams) {
+	var request ListEnrollmentRequestsRequestObject
 
-func (response PatchResourceSync404JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListEnrollmentRequests(ctx, request.(ListEnrollmentRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListEnrollmentRequests")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListEnrollmentRequestsResponseObject); ok {
+		if err := validResponse.VisitListEnrollmentRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchResourceSync409JSONResponse Error
+// CreateEnrollmentRequest operation middleware
+func (sh *strictHandler) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
+	var request CreateEnrollmentRequestRequestObject
 
-func (response PatchResourceSync409JSONResponse) VisitPatchResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var body CreateEnrollmentRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateEnrollmentRequest(ctx, request.(CreateEnrollmentRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateEnrollmentRequest")
+	}
 
-type ReplaceResourceSyncRequestObject struct {
-	Name string `json:"name"`
-	Body *ReplaceResourceSyncJSONRequestBody
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type ReplaceResourceSyncResponseObject interface {
-	VisitReplaceResourceSyncResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateEnrollmentRequestResponseObject); ok {
+		if err := validResponse.VisitCreateEnrollmentRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceResourceSync200JSONResponse ResourceSync
-
-func (response ReplaceResourceSync200JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// DeleteEnrollmentRequest operation middleware
+func (sh *strictHandler) DeleteEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteEnrollmentRequestRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReplaceResourceSync201JSONResponse ResourceSync
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteEnrollmentRequest(ctx, request.(DeleteEnrollmentRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteEnrollmentRequest")
+	}
 
-func (response ReplaceResourceSync201JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteEnrollmentRequestResponseObject); ok {
+		if err := validResponse.VisitDeleteEnrollmentRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceResourceSync400JSONResponse Error
-
-func (response ReplaceResourceSync400JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ReadEnrollmentRequest operation middleware
+func (sh *strictHandler) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadEnrollmentRequestRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Name = name
 
-type ReplaceResourceSync401JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadEnrollmentRequest(ctx, request.(ReadEnrollmentRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadEnrollmentRequest")
+	}
 
-func (response ReplaceResourceSync401JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadEnrollmentRequestResponseObject); ok {
+		if err := validResponse.VisitReadEnrollmentRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type ReplaceResourceSync404JSONResponse Error
+// ReplaceEnrollmentRequest operation middleware
+func (sh *strictHandler) ReplaceEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceEnrollmentRequestRequestObject
 
-func (response ReplaceResourceSync404JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Name = name
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body ReplaceEnrollmentRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type ReplaceResourceSync409JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceEnrollmentRequest(ctx, request.(ReplaceEnrollmentRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceEnrollmentRequest")
+	}
 
-func (response ReplaceResourceSync409JSONResponse) VisitReplaceResourceSyncResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceEnrollmentRequestResponseObject); ok {
+		if err := validResponse.VisitReplaceEnrollmentRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
+// ApproveEnrollmentRequest operation middleware
+func (sh *strictHandler) ApproveEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
+	var request ApproveEnrollmentRequestRequestObject
 
-	// (GET /api/v1/auth/config)
-	AuthConfig(ctx context.Context, request AuthConfigRequestObject) (AuthConfigResponseObject, error)
+	request.Name = name
 
-	// (GET /api/v1/auth/validate)
-	AuthValidate(ctx context.Context, request AuthValidateRequestObject) (AuthValidateResponseObject, error)
+	var body ApproveEnrollmentRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	// (DELETE /api/v1/certificatesigningrequests)
-	DeleteCertificateSigningRequests(ctx context.Context, request DeleteCertificateSigningRequestsRequestObject) (DeleteCertificateSigningRequestsResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveEnrollmentRequest(ctx, request.(ApproveEnrollmentRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveEnrollmentRequest")
+	}
 
-	// (GET /api/v1/certificatesigningrequests)
-	ListCertificateSigningRequests(ctx context.Context, request ListCertificateSigningRequestsRequestObject) (ListCertificateSigningRequestsResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (POST /api/v1/certificatesigningrequests)
-	CreateCertificateSigningRequest(ctx context.Context, request CreateCertificateSigningRequestRequestObject) (CreateCertificateSigningRequestResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveEnrollmentRequestResponseObject); ok {
+		if err := validResponse.VisitApproveEnrollmentRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (DELETE /api/v1/certificatesigningrequests/{name})
-	DeleteCertificateSigningRequest(ctx context.Context, request DeleteCertificateSigningRequestRequestObject) (DeleteCertificateSigningRequestResponseObject, error)
+// ReadEnrollmentRequestStatus operation middleware
+func (sh *strictHandler) ReadEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadEnrollmentRequestStatusRequestObject
 
-	// (GET /api/v1/certificatesigningrequests/{name})
-	ReadCertificateSigningRequest(ctx context.Context, request ReadCertificateSigningRequestRequestObject) (ReadCertificateSigningRequestResponseObject, error)
+	request.Name = name
 
-	// (PATCH /api/v1/certificatesigningrequests/{name})
-	PatchCertificateSigningRequest(ctx context.Context, request PatchCertificateSigningRequestRequestObject) (PatchCertificateSigningRequestResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadEnrollmentRequestStatus(ctx, request.(ReadEnrollmentRequestStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadEnrollmentRequestStatus")
+	}
 
-	// (PUT /api/v1/certificatesigningrequests/{name})
-	ReplaceCertificateSigningRequest(ctx context.Context, request ReplaceCertificateSigningRequestRequestObject) (ReplaceCertificateSigningRequestResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (DELETE /api/v1/certificatesigningrequests/{name}/approval)
-	DenyCertificateSigningRequest(ctx context.Context, request DenyCertificateSigningRequestRequestObject) (DenyCertificateSigningRequestResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadEnrollmentRequestStatusResponseObject); ok {
+		if err := validResponse.VisitReadEnrollmentRequestStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (POST /api/v1/certificatesigningrequests/{name}/approval)
-	ApproveCertificateSigningRequest(ctx context.Context, request ApproveCertificateSigningRequestRequestObject) (ApproveCertificateSigningRequestResponseObject, error)
+// ReplaceEnrollmentRequestStatus operation middleware
+func (sh *strictHandler) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceEnrollmentRequestStatusRequestObject
 
-	// (DELETE /api/v1/devices)
-	DeleteDevices(ctx context.Context, request DeleteDevicesRequestObject) (DeleteDevicesResponseObject, error)
+	request.Name = name
 
-	// (GET /api/v1/devices)
-	ListDevices(ctx context.Context, request ListDevicesRequestObject) (ListDevicesResponseObject, error)
+	var body ReplaceEnrollmentRequestStatusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	// (POST /api/v1/devices)
-	CreateDevice(ctx context.Context, request CreateDeviceRequestObject) (CreateDeviceResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplaceEnrollmentRequestStatus(ctx, request.(ReplaceEnrollmentRequestStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplaceEnrollmentRequestStatus")
+	}
 
-	// (DELETE /api/v1/devices/{name})
-	DeleteDevice(ctx context.Context, request DeleteDeviceRequestObject) (DeleteDeviceResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/devices/{name})
-	ReadDevice(ctx context.Context, request ReadDeviceRequestObject) (ReadDeviceResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplaceEnrollmentRequestStatusResponseObject); ok {
+		if err := validResponse.VisitReplaceEnrollmentRequestStatusResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (PATCH /api/v1/devices/{name})
-	PatchDevice(ctx context.Context, request PatchDeviceRequestObject) (PatchDeviceResponseObject, error)
+// CreateResourceExport operation middleware
+func (sh *strictHandler) CreateResourceExport(w http.ResponseWriter, r *http.Request) {
+	var request CreateResourceExportRequestObject
 
-	// (PUT /api/v1/devices/{name})
-	ReplaceDevice(ctx context.Context, request ReplaceDeviceRequestObject) (ReplaceDeviceResponseObject, error)
+	var body CreateResourceExportJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	// (GET /api/v1/devices/{name}/console)
-	RequestConsole(ctx context.Context, request RequestConsoleRequestObject) (RequestConsoleResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateResourceExport(ctx, request.(CreateResourceExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateResourceExport")
+	}
 
-	// (GET /api/v1/devices/{name}/rendered)
-	GetRenderedDeviceSpec(ctx context.Context, request GetRenderedDeviceSpecRequestObject) (GetRenderedDeviceSpecResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/devices/{name}/status)
-	ReadDeviceStatus(ctx context.Context, request ReadDeviceStatusRequestObject) (ReadDeviceStatusResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateResourceExportResponseObject); ok {
+		if err := validResponse.VisitCreateResourceExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (PUT /api/v1/devices/{name}/status)
-	ReplaceDeviceStatus(ctx context.Context, request ReplaceDeviceStatusRequestObject) (ReplaceDeviceStatusResponseObject, error)
+// DeleteFleets operation middleware
+func (sh *strictHandler) DeleteFleets(w http.ResponseWriter, r *http.Request) {
+	var request DeleteFleetsRequestObject
 
-	// (GET /api/v1/enrollmentconfig/{name})
-	EnrollmentConfig(ctx context.Context, request EnrollmentConfigRequestObject) (EnrollmentConfigResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFleets(ctx, request.(DeleteFleetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFleets")
+	}
 
-	// (DELETE /api/v1/enrollmentrequests)
-	DeleteEnrollmentRequests(ctx context.Context, request DeleteEnrollmentRequestsRequestObject) (DeleteEnrollmentRequestsResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFleetsResponseObject); ok {
+		if err := validResponse.VisitDeleteFleetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (GET /api/v1/enrollmentrequests)
-	ListEnrollmentRequests(ctx context.Context, request ListEnrollmentRequestsRequestObject) (ListEnrollmentRequestsResponseObject, error)
+// ListFleets operation middleware
+func (sh *strictHandler) ListFleets(w http.ResponseWriter, r *http.Request, params ListFleetsParams) {
+	var request ListFleetsRequestObject
 
-	// (POST /api/v1/enrollmentrequests)
-	CreateEnrollmentRequest(ctx context.Context, request CreateEnrollmentRequestRequestObject) (CreateEnrollmentRequestResponseObject, error)
+	request.Params = params
 
-	// (DELETE /api/v1/enrollmentrequests/{name})
-	DeleteEnrollmentRequest(ctx context.Context, request DeleteEnrollmentRequestRequestObject) (DeleteEnrollmentRequestResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFleets(ctx, request.(ListFleetsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFleets")
+	}
 
-	// (GET /api/v1/enrollmentrequests/{name})
-	ReadEnrollmentRequest(ctx context.Context, request ReadEnrollmentRequestRequestObject) (ReadEnrollmentRequestResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (PUT /api/v1/enrollmentrequests/{name})
-	ReplaceEnrollmentRequest(ctx context.Context, request ReplaceEnrollmentRequestRequestObject) (ReplaceEnrollmentRequestResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFleetsResponseObject); ok {
+		if err := validResponse.VisitListFleetsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (POST /api/v1/enrollmentrequests/{name}/approval)
-	ApproveEnrollmentRequest(ctx context.Context, request ApproveEnrollmentRequestRequestObject) (ApproveEnrollmentRequestResponseObject, error)
+// CreateFleet operation middleware
+func (sh *strictHandler) CreateFleet(w http.ResponseWriter, r *http.Request) {
+	var request CreateFleetRequestObject
 
-	// (GET /api/v1/enrollmentrequests/{name}/status)
-	ReadEnrollmentRequestStatus(ctx context.Context, request ReadEnrollmentRequestStatusRequestObject) (ReadEnrollmentRequestStatusResponseObject, error)
+	var body CreateFleetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	// (PUT /api/v1/enrollmentrequests/{name}/status)
-	ReplaceEnrollmentRequestStatus(ctx context.Context, request ReplaceEnrollmentRequestStatusRequestObject) (ReplaceEnrollmentRequestStatusResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateFleet(ctx, request.(CreateFleetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateFleet")
+	}
 
-	// (DELETE /api/v1/fleets)
-	DeleteFleets(ctx context.Context, request DeleteFleetsRequestObject) (DeleteFleetsResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/fleets)
-	ListFleets(ctx context.Context, request ListFleetsRequestObject) (ListFleetsResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateFleetResponseObject); ok {
+		if err := validResponse.VisitCreateFleetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (POST /api/v1/fleets)
-	CreateFleet(ctx context.Context, request CreateFleetRequestObject) (CreateFleetResponseObject, error)
+// DeleteTemplateVersions operation middleware
+func (sh *strictHandler) DeleteTemplateVersions(w http.ResponseWriter, r *http.Request, fleet string) {
+	var request DeleteTemplateVersionsRequestObject
 
-	// (DELETE /api/v1/fleets/{fleet}/templateversions)
-	DeleteTemplateVersions(ctx context.Context, request DeleteTemplateVersionsRequestObject) (DeleteTemplateVersionsResponseObject, error)
+	request.Fleet = fleet
 
-	// (GET /api/v1/fleets/{fleet}/templateversions)
-	ListTemplateVersions(ctx context.Context, request ListTemplateVersionsRequestObject) (ListTemplateVersionsResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTemplateVersions(ctx, request.(DeleteTemplateVersionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTemplateVersions")
+	}
 
-	// (DELETE /api/v1/fleets/{fleet}/templateversions/{name})
-	DeleteTemplateVersion(ctx context.Context, request DeleteTemplateVersionRequestObject) (DeleteTemplateVersionResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/fleets/{fleet}/templateversions/{name})
-	ReadTemplateVersion(ctx context.Context, request ReadTemplateVersionRequestObject) (ReadTemplateVersionResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTemplateVersionsResponseObject); ok {
+		if err := validResponse.VisitDeleteTemplateVersionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (DELETE /api/v1/fleets/{name})
-	DeleteFleet(ctx context.Context, request DeleteFleetRequestObject) (DeleteFleetResponseObject, error)
+// ListTemplateVersions operation middleware
+func (sh *strictHandler) ListTemplateVersions(w http.ResponseWriter, r *http.Request, fleet string, params ListTemplateVersionsParams) {
+	var request ListTemplateVersionsRequestObject
 
-	// (GET /api/v1/fleets/{name})
-	ReadFleet(ctx context.Context, request ReadFleetRequestObject) (ReadFleetResponseObject, error)
+	request.Fleet = fleet
+	request.Params = params
 
-	// (PATCH /api/v1/fleets/{name})
-	PatchFleet(ctx context.Context, request PatchFleetRequestObject) (PatchFleetResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTemplateVersions(ctx, request.(ListTemplateVersionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTemplateVersions")
+	}
 
-	// (PUT /api/v1/fleets/{name})
-	ReplaceFleet(ctx context.Context, request ReplaceFleetRequestObject) (ReplaceFleetResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/fleets/{name}/status)
-	ReadFleetStatus(ctx context.Context, request ReadFleetStatusRequestObject) (ReadFleetStatusResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTemplateVersionsResponseObject); ok {
+		if err := validResponse.VisitListTemplateVersionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (PUT /api/v1/fleets/{name}/status)
-	ReplaceFleetStatus(ctx context.Context, request ReplaceFleetStatusRequestObject) (ReplaceFleetStatusResponseObject, error)
+// DeleteTemplateVersion operation middleware
+func (sh *strictHandler) DeleteTemplateVersion(w http.ResponseWriter, r *http.Request, fleet string, name string) {
+	var request DeleteTemplateVersionRequestObject
 
-	// (DELETE /api/v1/repositories)
-	DeleteRepositories(ctx context.Context, request DeleteRepositoriesRequestObject) (DeleteRepositoriesResponseObject, error)
+	request.Fleet = fleet
+	request.Name = name
 
-	// (GET /api/v1/repositories)
-	ListRepositories(ctx context.Context, request ListRepositoriesRequestObject) (ListRepositoriesResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteTemplateVersion(ctx, request.(DeleteTemplateVersionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteTemplateVersion")
+	}
 
-	// (POST /api/v1/repositories)
-	CreateRepository(ctx context.Context, request CreateRepositoryRequestObject) (CreateRepositoryResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (DELETE /api/v1/repositories/{name})
-	DeleteRepository(ctx context.Context, request DeleteRepositoryRequestObject) (DeleteRepositoryResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteTemplateVersionResponseObject); ok {
+		if err := validResponse.VisitDeleteTemplateVersionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (GET /api/v1/repositories/{name})
-	ReadRepository(ctx context.Context, request ReadRepositoryRequestObject) (ReadRepositoryResponseObject, error)
+// ReadTemplateVersion operation middleware
+func (sh *strictHandler) ReadTemplateVersion(w http.ResponseWriter, r *http.Request, fleet string, name string) {
+	var request ReadTemplateVersionRequestObject
 
-	// (PATCH /api/v1/repositories/{name})
-	PatchRepository(ctx context.Context, request PatchRepositoryRequestObject) (PatchRepositoryResponseObject, error)
+	request.Fleet = fleet
+	request.Name = name
 
-	// (PUT /api/v1/repositories/{name})
-	ReplaceRepository(ctx context.Context, request ReplaceRepositoryRequestObject) (ReplaceRepositoryResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadTemplateVersion(ctx, request.(ReadTemplateVersionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadTemplateVersion")
+	}
 
-	// (DELETE /api/v1/resourcesyncs)
-	DeleteResourceSyncs(ctx context.Context, request DeleteResourceSyncsRequestObject) (DeleteResourceSyncsResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (GET /api/v1/resourcesyncs)
-	ListResourceSync(ctx context.Context, request ListResourceSyncRequestObject) (ListResourceSyncResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadTemplateVersionResponseObject); ok {
+		if err := validResponse.VisitReadTemplateVersionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (POST /api/v1/resourcesyncs)
-	CreateResourceSync(ctx context.Context, request CreateResourceSyncRequestObject) (CreateResourceSyncResponseObject, error)
+// DeleteFleet operation middleware
+func (sh *strictHandler) DeleteFleet(w http.ResponseWriter, r *http.Request, name string, params DeleteFleetParams) {
+	var request DeleteFleetRequestObject
 
-	// (DELETE /api/v1/resourcesyncs/{name})
-	DeleteResourceSync(ctx context.Context, request DeleteResourceSyncRequestObject) (DeleteResourceSyncResponseObject, error)
+	request.Name = name
+	request.Params = params
 
-	// (GET /api/v1/resourcesyncs/{name})
-	ReadResourceSync(ctx context.Context, request ReadResourceSyncRequestObject) (ReadResourceSyncResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFleet(ctx, request.(DeleteFleetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFleet")
+	}
 
-	// (PATCH /api/v1/resourcesyncs/{name})
-	PatchResourceSync(ctx context.Context, request PatchResourceSyncRequestObject) (PatchResourceSyncResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (PUT /api/v1/resourcesyncs/{name})
-	ReplaceResourceSync(ctx context.Context, request ReplaceResourceSyncRequestObject) (ReplaceResourceSyncResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFleetResponseObject); ok {
+		if err := validResponse.VisitDeleteFleetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// ReadFleet operation middleware
+func (sh *strictHandler) ReadFleet(w http.ResponseWriter, r *http.Request, name string, params ReadFleetParams) {
+	var request ReadFleetRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.Name = name
+	request.Params = params
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReadFleet(ctx, request.(ReadFleetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReadFleet")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadFleetResponseObject); ok {
+		if err := validResponse.VisitReadFleetResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// AuthConfig operation middleware
-func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
-	var request AuthConfigRequestObject
+// PatchFleet operation middleware
+func (sh *strictHandler) PatchFleet(w http.ResponseWriter, r *http.Request, name string) {
+	var request PatchFleetRequestObject
+
+	request.Name = name
+
+	var body PatchFleetApplicationJSONPatchPlusJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthConfig(ctx, request.(AuthConfigRequestObject))
+		return sh.ssi.PatchFleet(ctx, request.(PatchFleetRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthConfig")
+		handler = middleware(handler, "PatchFleet")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthConfigResponseObject); ok {
-		if err := validResponse.VisitAuthConfigResponse(w); err != nil {
+	} else if validResponse, ok := response.(PatchFleetResponseObject); ok {
+		if err := validResponse.VisitPatchFleetResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5088,25 +14027,32 @@ func (sh *strictHandler) AuthConfig(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// AuthValidate operation middleware
-func (sh *strictHandler) AuthValidate(w http.ResponseWriter, r *http.Request, params AuthValidateParams) {
-	var request AuthValidateRequestObject
+// ReplaceFleet operation middleware
+func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceFleetRequestObject
 
-	request.Params = params
+	request.Name = name
+
+	var body ReplaceFleetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthValidate(ctx, request.(AuthValidateRequestObject))
+		return sh.ssi.ReplaceFleet(ctx, request.(ReplaceFleetRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthValidate")
+		handler = middleware(handler, "ReplaceFleet")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthValidateResponseObject); ok {
-		if err := validResponse.VisitAuthValidateResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceFleetResponseObject); ok {
+		if err := validResponse.VisitReplaceFleetResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5114,23 +14060,32 @@ func (sh *strictHandler) AuthValidate(w http.ResponseWriter, r *http.Request, pa
 	}
 }
 
-// DeleteCertificateSigningRequests operation middleware
-func (sh *strictHandler) DeleteCertificateSigningRequests(w http.ResponseWriter, r *http.Request) {
-	var request DeleteCertificateSigningRequestsRequestObject
+// LintFleet operation middleware
+func (sh *strictHandler) LintFleet(w http.ResponseWriter, r *http.Request, name string) {
+	var request LintFleetRequestObject
+
+	request.Name = name
+
+	var body LintFleetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteCertificateSigningRequests(ctx, request.(DeleteCertificateSigningRequestsRequestObject))
+		return sh.ssi.LintFleet(ctx, request.(LintFleetRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteCertificateSigningRequests")
+		handler = middleware(handler, "LintFleet")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteCertificateSigningRequestsResponseObject); ok {
-		if err := validResponse.VisitDeleteCertificateSigningRequestsResponse(w); err != nil {
+	} else if validResponse, ok := response.(LintFleetResponseObject); ok {
+		if err := validResponse.VisitLintFleetResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5138,25 +14093,25 @@ func (sh *strictHandler) DeleteCertificateSigningRequests(w http.ResponseWriter,
 	}
 }
 
-// ListCertificateSigningRequests operation middleware
-func (sh *strictHandler) ListCertificateSigningRequests(w http.ResponseWriter, r *http.Request, params ListCertificateSigningRequestsParams) {
-	var request ListCertificateSigningRequestsRequestObject
+// CancelFleetMigration operation middleware
+func (sh *strictHandler) CancelFleetMigration(w http.ResponseWriter, r *http.Request, name string) {
+	var request CancelFleetMigrationRequestObject
 
-	request.Params = params
+	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListCertificateSigningRequests(ctx, request.(ListCertificateSigningRequestsRequestObject))
+		return sh.ssi.CancelFleetMigration(ctx, request.(CancelFleetMigrationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListCertificateSigningRequests")
+		handler = middleware(handler, "CancelFleetMigration")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListCertificateSigningRequestsResponseObject); ok {
-		if err := validResponse.VisitListCertificateSigningRequestsResponse(w); err != nil {
+	} else if validResponse, ok := response.(CancelFleetMigrationResponseObject); ok {
+		if err := validResponse.VisitCancelFleetMigrationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5164,11 +14119,13 @@ func (sh *strictHandler) ListCertificateSigningRequests(w http.ResponseWriter, r
 	}
 }
 
-// CreateCertificateSigningRequest operation middleware
-func (sh *strictHandler) CreateCertificateSigningRequest(w http.ResponseWriter, r *http.Request) {
-	var request CreateCertificateSigningRequestRequestObject
+// MigrateFleetDevices operation middleware
+func (sh *strictHandler) MigrateFleetDevices(w http.ResponseWriter, r *http.Request, name string) {
+	var request MigrateFleetDevicesRequestObject
 
-	var body CreateCertificateSigningRequestJSONRequestBody
+	request.Name = name
+
+	var body MigrateFleetDevicesJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5176,18 +14133,18 @@ func (sh *strictHandler) CreateCertificateSigningRequest(w http.ResponseWriter,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateCertificateSigningRequest(ctx, request.(CreateCertificateSigningRequestRequestObject))
+		return sh.ssi.MigrateFleetDevices(ctx, request.(MigrateFleetDevicesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateCertificateSigningRequest")
+		handler = middleware(handler, "MigrateFleetDevices")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitCreateCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(MigrateFleetDevicesResponseObject); ok {
+		if err := validResponse.VisitMigrateFleetDevicesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5195,25 +14152,25 @@ func (sh *strictHandler) CreateCertificateSigningRequest(w http.ResponseWriter,
 	}
 }
 
-// DeleteCertificateSigningRequest operation middleware
-func (sh *strictHandler) DeleteCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteCertificateSigningRequestRequestObject
+// ApproveFleetPromotion operation middleware
+func (sh *strictHandler) ApproveFleetPromotion(w http.ResponseWriter, r *http.Request, name string) {
+	var request ApproveFleetPromotionRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteCertificateSigningRequest(ctx, request.(DeleteCertificateSigningRequestRequestObject))
+		return sh.ssi.ApproveFleetPromotion(ctx, request.(ApproveFleetPromotionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteCertificateSigningRequest")
+		handler = middleware(handler, "ApproveFleetPromotion")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitDeleteCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(ApproveFleetPromotionResponseObject); ok {
+		if err := validResponse.VisitApproveFleetPromotionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5221,25 +14178,26 @@ func (sh *strictHandler) DeleteCertificateSigningRequest(w http.ResponseWriter,
 	}
 }
 
-// ReadCertificateSigningRequest operation middleware
-func (sh *strictHandler) ReadCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadCertificateSigningRequestRequestObject
+// ReadFleetProvisioning operation middleware
+func (sh *strictHandler) ReadFleetProvisioning(w http.ResponseWriter, r *http.Request, name string, params ReadFleetProvisioningParams) {
+	var request ReadFleetProvisioningRequestObject
 
 	request.Name = name
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadCertificateSigningRequest(ctx, request.(ReadCertificateSigningRequestRequestObject))
+		return sh.ssi.ReadFleetProvisioning(ctx, request.(ReadFleetProvisioningRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadCertificateSigningRequest")
+		handler = middleware(handler, "ReadFleetProvisioning")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitReadCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadFleetProvisioningResponseObject); ok {
+		if err := validResponse.VisitReadFleetProvisioningResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5247,32 +14205,25 @@ func (sh *strictHandler) ReadCertificateSigningRequest(w http.ResponseWriter, r
 	}
 }
 
-// PatchCertificateSigningRequest operation middleware
-func (sh *strictHandler) PatchCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request PatchCertificateSigningRequestRequestObject
+// ListProvisioningTokens operation middleware
+func (sh *strictHandler) ListProvisioningTokens(w http.ResponseWriter, r *http.Request, name string) {
+	var request ListProvisioningTokensRequestObject
 
 	request.Name = name
 
-	var body PatchCertificateSigningRequestApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchCertificateSigningRequest(ctx, request.(PatchCertificateSigningRequestRequestObject))
+		return sh.ssi.ListProvisioningTokens(ctx, request.(ListProvisioningTokensRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchCertificateSigningRequest")
+		handler = middleware(handler, "ListProvisioningTokens")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitPatchCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListProvisioningTokensResponseObject); ok {
+		if err := validResponse.VisitListProvisioningTokensResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5280,13 +14231,13 @@ func (sh *strictHandler) PatchCertificateSigningRequest(w http.ResponseWriter, r
 	}
 }
 
-// ReplaceCertificateSigningRequest operation middleware
-func (sh *strictHandler) ReplaceCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceCertificateSigningRequestRequestObject
+// CreateProvisioningToken operation middleware
+func (sh *strictHandler) CreateProvisioningToken(w http.ResponseWriter, r *http.Request, name string) {
+	var request CreateProvisioningTokenRequestObject
 
 	request.Name = name
 
-	var body ReplaceCertificateSigningRequestJSONRequestBody
+	var body CreateProvisioningTokenJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5294,18 +14245,18 @@ func (sh *strictHandler) ReplaceCertificateSigningRequest(w http.ResponseWriter,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceCertificateSigningRequest(ctx, request.(ReplaceCertificateSigningRequestRequestObject))
+		return sh.ssi.CreateProvisioningToken(ctx, request.(CreateProvisioningTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceCertificateSigningRequest")
+		handler = middleware(handler, "CreateProvisioningToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitReplaceCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateProvisioningTokenResponseObject); ok {
+		if err := validResponse.VisitCreateProvisioningTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5313,25 +14264,26 @@ func (sh *strictHandler) ReplaceCertificateSigningRequest(w http.ResponseWriter,
 	}
 }
 
-// DenyCertificateSigningRequest operation middleware
-func (sh *strictHandler) DenyCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request DenyCertificateSigningRequestRequestObject
+// DeleteProvisioningToken operation middleware
+func (sh *strictHandler) DeleteProvisioningToken(w http.ResponseWriter, r *http.Request, name string, id string) {
+	var request DeleteProvisioningTokenRequestObject
 
 	request.Name = name
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DenyCertificateSigningRequest(ctx, request.(DenyCertificateSigningRequestRequestObject))
+		return sh.ssi.DeleteProvisioningToken(ctx, request.(DeleteProvisioningTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DenyCertificateSigningRequest")
+		handler = middleware(handler, "DeleteProvisioningToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DenyCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitDenyCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteProvisioningTokenResponseObject); ok {
+		if err := validResponse.VisitDeleteProvisioningTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5339,25 +14291,25 @@ func (sh *strictHandler) DenyCertificateSigningRequest(w http.ResponseWriter, r
 	}
 }
 
-// ApproveCertificateSigningRequest operation middleware
-func (sh *strictHandler) ApproveCertificateSigningRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ApproveCertificateSigningRequestRequestObject
+// ReadFleetReport operation middleware
+func (sh *strictHandler) ReadFleetReport(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadFleetReportRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ApproveCertificateSigningRequest(ctx, request.(ApproveCertificateSigningRequestRequestObject))
+		return sh.ssi.ReadFleetReport(ctx, request.(ReadFleetReportRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ApproveCertificateSigningRequest")
+		handler = middleware(handler, "ReadFleetReport")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ApproveCertificateSigningRequestResponseObject); ok {
-		if err := validResponse.VisitApproveCertificateSigningRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadFleetReportResponseObject); ok {
+		if err := validResponse.VisitReadFleetReportResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5365,23 +14317,25 @@ func (sh *strictHandler) ApproveCertificateSigningRequest(w http.ResponseWriter,
 	}
 }
 
-// DeleteDevices operation middleware
-func (sh *strictHandler) DeleteDevices(w http.ResponseWriter, r *http.Request) {
-	var request DeleteDevicesRequestObject
+// RestoreFleet operation middleware
+func (sh *strictHandler) RestoreFleet(w http.ResponseWriter, r *http.Request, name string) {
+	var request RestoreFleetRequestObject
+
+	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteDevices(ctx, request.(DeleteDevicesRequestObject))
+		return sh.ssi.RestoreFleet(ctx, request.(RestoreFleetRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteDevices")
+		handler = middleware(handler, "RestoreFleet")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteDevicesResponseObject); ok {
-		if err := validResponse.VisitDeleteDevicesResponse(w); err != nil {
+	} else if validResponse, ok := response.(RestoreFleetResponseObject); ok {
+		if err := validResponse.VisitRestoreFleetResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5389,25 +14343,32 @@ func (sh *strictHandler) DeleteDevices(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ListDevices operation middleware
-func (sh *strictHandler) ListDevices(w http.ResponseWriter, r *http.Request, params ListDevicesParams) {
-	var request ListDevicesRequestObject
+// AbortFleetRollout operation middleware
+func (sh *strictHandler) AbortFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
+	var request AbortFleetRolloutRequestObject
 
-	request.Params = params
+	request.Name = name
+
+	var body AbortFleetRolloutJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListDevices(ctx, request.(ListDevicesRequestObject))
+		return sh.ssi.AbortFleetRollout(ctx, request.(AbortFleetRolloutRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListDevices")
+		handler = middleware(handler, "AbortFleetRollout")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListDevicesResponseObject); ok {
-		if err := validResponse.VisitListDevicesResponse(w); err != nil {
+	} else if validResponse, ok := response.(AbortFleetRolloutResponseObject); ok {
+		if err := validResponse.VisitAbortFleetRolloutResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5415,30 +14376,25 @@ func (sh *strictHandler) ListDevices(w http.ResponseWriter, r *http.Request, par
 	}
 }
 
-// CreateDevice operation middleware
-func (sh *strictHandler) CreateDevice(w http.ResponseWriter, r *http.Request) {
-	var request CreateDeviceRequestObject
+// PauseFleetRollout operation middleware
+func (sh *strictHandler) PauseFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
+	var request PauseFleetRolloutRequestObject
 
-	var body CreateDeviceJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateDevice(ctx, request.(CreateDeviceRequestObject))
+		return sh.ssi.PauseFleetRollout(ctx, request.(PauseFleetRolloutRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateDevice")
+		handler = middleware(handler, "PauseFleetRollout")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateDeviceResponseObject); ok {
-		if err := validResponse.VisitCreateDeviceResponse(w); err != nil {
+	} else if validResponse, ok := response.(PauseFleetRolloutResponseObject); ok {
+		if err := validResponse.VisitPauseFleetRolloutResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5446,25 +14402,25 @@ func (sh *strictHandler) CreateDevice(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteDevice operation middleware
-func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteDeviceRequestObject
+// ResumeFleetRollout operation middleware
+func (sh *strictHandler) ResumeFleetRollout(w http.ResponseWriter, r *http.Request, name string) {
+	var request ResumeFleetRolloutRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteDevice(ctx, request.(DeleteDeviceRequestObject))
+		return sh.ssi.ResumeFleetRollout(ctx, request.(ResumeFleetRolloutRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteDevice")
+		handler = middleware(handler, "ResumeFleetRollout")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteDeviceResponseObject); ok {
-		if err := validResponse.VisitDeleteDeviceResponse(w); err != nil {
+	} else if validResponse, ok := response.(ResumeFleetRolloutResponseObject); ok {
+		if err := validResponse.VisitResumeFleetRolloutResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5472,25 +14428,25 @@ func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, na
 	}
 }
 
-// ReadDevice operation middleware
-func (sh *strictHandler) ReadDevice(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadDeviceRequestObject
+// ReadFleetStatus operation middleware
+func (sh *strictHandler) ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadFleetStatusRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadDevice(ctx, request.(ReadDeviceRequestObject))
+		return sh.ssi.ReadFleetStatus(ctx, request.(ReadFleetStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadDevice")
+		handler = middleware(handler, "ReadFleetStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadDeviceResponseObject); ok {
-		if err := validResponse.VisitReadDeviceResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadFleetStatusResponseObject); ok {
+		if err := validResponse.VisitReadFleetStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5498,13 +14454,13 @@ func (sh *strictHandler) ReadDevice(w http.ResponseWriter, r *http.Request, name
 	}
 }
 
-// PatchDevice operation middleware
-func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, name string) {
-	var request PatchDeviceRequestObject
+// ReplaceFleetStatus operation middleware
+func (sh *strictHandler) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceFleetStatusRequestObject
 
 	request.Name = name
 
-	var body PatchDeviceApplicationJSONPatchPlusJSONRequestBody
+	var body ReplaceFleetStatusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5512,18 +14468,18 @@ func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, nam
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchDevice(ctx, request.(PatchDeviceRequestObject))
+		return sh.ssi.ReplaceFleetStatus(ctx, request.(ReplaceFleetStatusRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchDevice")
+		handler = middleware(handler, "ReplaceFleetStatus")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchDeviceResponseObject); ok {
-		if err := validResponse.VisitPatchDeviceResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceFleetStatusResponseObject); ok {
+		if err := validResponse.VisitReplaceFleetStatusResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5531,13 +14487,11 @@ func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, nam
 	}
 }
 
-// ReplaceDevice operation middleware
-func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceDeviceRequestObject
-
-	request.Name = name
+// CreateResourceImport operation middleware
+func (sh *strictHandler) CreateResourceImport(w http.ResponseWriter, r *http.Request) {
+	var request CreateResourceImportRequestObject
 
-	var body ReplaceDeviceJSONRequestBody
+	var body CreateResourceImportJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5545,18 +14499,18 @@ func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, n
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceDevice(ctx, request.(ReplaceDeviceRequestObject))
+		return sh.ssi.CreateResourceImport(ctx, request.(CreateResourceImportRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceDevice")
+		handler = middleware(handler, "CreateResourceImport")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceDeviceResponseObject); ok {
-		if err := validResponse.VisitReplaceDeviceResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateResourceImportResponseObject); ok {
+		if err := validResponse.VisitCreateResourceImportResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5564,25 +14518,23 @@ func (sh *strictHandler) ReplaceDevice(w http.ResponseWriter, r *http.Request, n
 	}
 }
 
-// RequestConsole operation middleware
-func (sh *strictHandler) RequestConsole(w http.ResponseWriter, r *http.Request, name string) {
-	var request RequestConsoleRequestObject
-
-	request.Name = name
+// DeleteLabelRules operation middleware
+func (sh *strictHandler) DeleteLabelRules(w http.ResponseWriter, r *http.Request) {
+	var request DeleteLabelRulesRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RequestConsole(ctx, request.(RequestConsoleRequestObject))
+		return sh.ssi.DeleteLabelRules(ctx, request.(DeleteLabelRulesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RequestConsole")
+		handler = middleware(handler, "DeleteLabelRules")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RequestConsoleResponseObject); ok {
-		if err := validResponse.VisitRequestConsoleResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteLabelRulesResponseObject); ok {
+		if err := validResponse.VisitDeleteLabelRulesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5590,26 +14542,25 @@ func (sh *strictHandler) RequestConsole(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// GetRenderedDeviceSpec operation middleware
-func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Request, name string, params GetRenderedDeviceSpecParams) {
-	var request GetRenderedDeviceSpecRequestObject
+// ListLabelRules operation middleware
+func (sh *strictHandler) ListLabelRules(w http.ResponseWriter, r *http.Request, params ListLabelRulesParams) {
+	var request ListLabelRulesRequestObject
 
-	request.Name = name
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetRenderedDeviceSpec(ctx, request.(GetRenderedDeviceSpecRequestObject))
+		return sh.ssi.ListLabelRules(ctx, request.(ListLabelRulesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetRenderedDeviceSpec")
+		handler = middleware(handler, "ListLabelRules")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetRenderedDeviceSpecResponseObject); ok {
-		if err := validResponse.VisitGetRenderedDeviceSpecResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListLabelRulesResponseObject); ok {
+		if err := validResponse.VisitListLabelRulesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5617,25 +14568,30 @@ func (sh *strictHandler) GetRenderedDeviceSpec(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// ReadDeviceStatus operation middleware
-func (sh *strictHandler) ReadDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadDeviceStatusRequestObject
+// CreateLabelRule operation middleware
+func (sh *strictHandler) CreateLabelRule(w http.ResponseWriter, r *http.Request) {
+	var request CreateLabelRuleRequestObject
 
-	request.Name = name
+	var body CreateLabelRuleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadDeviceStatus(ctx, request.(ReadDeviceStatusRequestObject))
+		return sh.ssi.CreateLabelRule(ctx, request.(CreateLabelRuleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadDeviceStatus")
+		handler = middleware(handler, "CreateLabelRule")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadDeviceStatusResponseObject); ok {
-		if err := validResponse.VisitReadDeviceStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateLabelRuleResponseObject); ok {
+		if err := validResponse.VisitCreateLabelRuleResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5643,32 +14599,25 @@ func (sh *strictHandler) ReadDeviceStatus(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// ReplaceDeviceStatus operation middleware
-func (sh *strictHandler) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceDeviceStatusRequestObject
+// DeleteLabelRule operation middleware
+func (sh *strictHandler) DeleteLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteLabelRuleRequestObject
 
 	request.Name = name
 
-	var body ReplaceDeviceStatusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceDeviceStatus(ctx, request.(ReplaceDeviceStatusRequestObject))
+		return sh.ssi.DeleteLabelRule(ctx, request.(DeleteLabelRuleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceDeviceStatus")
+		handler = middleware(handler, "DeleteLabelRule")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceDeviceStatusResponseObject); ok {
-		if err := validResponse.VisitReplaceDeviceStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteLabelRuleResponseObject); ok {
+		if err := validResponse.VisitDeleteLabelRuleResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5676,25 +14625,25 @@ func (sh *strictHandler) ReplaceDeviceStatus(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// EnrollmentConfig operation middleware
-func (sh *strictHandler) EnrollmentConfig(w http.ResponseWriter, r *http.Request, name string) {
-	var request EnrollmentConfigRequestObject
+// ReadLabelRule operation middleware
+func (sh *strictHandler) ReadLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadLabelRuleRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.EnrollmentConfig(ctx, request.(EnrollmentConfigRequestObject))
+		return sh.ssi.ReadLabelRule(ctx, request.(ReadLabelRuleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "EnrollmentConfig")
+		handler = middleware(handler, "ReadLabelRule")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(EnrollmentConfigResponseObject); ok {
-		if err := validResponse.VisitEnrollmentConfigResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadLabelRuleResponseObject); ok {
+		if err := validResponse.VisitReadLabelRuleResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5702,23 +14651,32 @@ func (sh *strictHandler) EnrollmentConfig(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// DeleteEnrollmentRequests operation middleware
-func (sh *strictHandler) DeleteEnrollmentRequests(w http.ResponseWriter, r *http.Request) {
-	var request DeleteEnrollmentRequestsRequestObject
+// ReplaceLabelRule operation middleware
+func (sh *strictHandler) ReplaceLabelRule(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceLabelRuleRequestObject
+
+	request.Name = name
+
+	var body ReplaceLabelRuleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteEnrollmentRequests(ctx, request.(DeleteEnrollmentRequestsRequestObject))
+		return sh.ssi.ReplaceLabelRule(ctx, request.(ReplaceLabelRuleRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteEnrollmentRequests")
+		handler = middleware(handler, "ReplaceLabelRule")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteEnrollmentRequestsResponseObject); ok {
-		if err := validResponse.VisitDeleteEnrollmentRequestsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceLabelRuleResponseObject); ok {
+		if err := validResponse.VisitReplaceLabelRuleResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5726,25 +14684,25 @@ func (sh *strictHandler) DeleteEnrollmentRequests(w http.ResponseWriter, r *http
 	}
 }
 
-// ListEnrollmentRequests operation middleware
-func (sh *strictHandler) ListEnrollmentRequests(w http.ResponseWriter, r *http.Request, params ListEnrollmentRequestsParams) {
-	var request ListEnrollmentRequestsRequestObject
+// ListOperations operation middleware
+func (sh *strictHandler) ListOperations(w http.ResponseWriter, r *http.Request, params ListOperationsParams) {
+	var request ListOperationsRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListEnrollmentRequests(ctx, request.(ListEnrollmentRequestsRequestObject))
+		return sh.ssi.ListOperations(ctx, request.(ListOperationsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListEnrollmentRequests")
+		handler = middleware(handler, "ListOperations")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListEnrollmentRequestsResponseObject); ok {
-		if err := validResponse.VisitListEnrollmentRequestsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListOperationsResponseObject); ok {
+		if err := validResponse.VisitListOperationsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5752,11 +14710,11 @@ func (sh *strictHandler) ListEnrollmentRequests(w http.ResponseWriter, r *http.R
 	}
 }
 
-// CreateEnrollmentRequest operation middleware
-func (sh *strictHandler) CreateEnrollmentRequest(w http.ResponseWriter, r *http.Request) {
-	var request CreateEnrollmentRequestRequestObject
+// CreateOperation operation middleware
+func (sh *strictHandler) CreateOperation(w http.ResponseWriter, r *http.Request) {
+	var request CreateOperationRequestObject
 
-	var body CreateEnrollmentRequestJSONRequestBody
+	var body CreateOperationJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5764,18 +14722,18 @@ func (sh *strictHandler) CreateEnrollmentRequest(w http.ResponseWriter, r *http.
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateEnrollmentRequest(ctx, request.(CreateEnrollmentRequestRequestObject))
+		return sh.ssi.CreateOperation(ctx, request.(CreateOperationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateEnrollmentRequest")
+		handler = middleware(handler, "CreateOperation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateEnrollmentRequestResponseObject); ok {
-		if err := validResponse.VisitCreateEnrollmentRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateOperationResponseObject); ok {
+		if err := validResponse.VisitCreateOperationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5783,25 +14741,25 @@ func (sh *strictHandler) CreateEnrollmentRequest(w http.ResponseWriter, r *http.
 	}
 }
 
-// DeleteEnrollmentRequest operation middleware
-func (sh *strictHandler) DeleteEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteEnrollmentRequestRequestObject
+// DeleteOperation operation middleware
+func (sh *strictHandler) DeleteOperation(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteOperationRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteEnrollmentRequest(ctx, request.(DeleteEnrollmentRequestRequestObject))
+		return sh.ssi.DeleteOperation(ctx, request.(DeleteOperationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteEnrollmentRequest")
+		handler = middleware(handler, "DeleteOperation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteEnrollmentRequestResponseObject); ok {
-		if err := validResponse.VisitDeleteEnrollmentRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteOperationResponseObject); ok {
+		if err := validResponse.VisitDeleteOperationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5809,25 +14767,25 @@ func (sh *strictHandler) DeleteEnrollmentRequest(w http.ResponseWriter, r *http.
 	}
 }
 
-// ReadEnrollmentRequest operation middleware
-func (sh *strictHandler) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadEnrollmentRequestRequestObject
+// ReadOperation operation middleware
+func (sh *strictHandler) ReadOperation(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadOperationRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadEnrollmentRequest(ctx, request.(ReadEnrollmentRequestRequestObject))
+		return sh.ssi.ReadOperation(ctx, request.(ReadOperationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadEnrollmentRequest")
+		handler = middleware(handler, "ReadOperation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadEnrollmentRequestResponseObject); ok {
-		if err := validResponse.VisitReadEnrollmentRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadOperationResponseObject); ok {
+		if err := validResponse.VisitReadOperationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5835,32 +14793,25 @@ func (sh *strictHandler) ReadEnrollmentRequest(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// ReplaceEnrollmentRequest operation middleware
-func (sh *strictHandler) ReplaceEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceEnrollmentRequestRequestObject
+// CancelOperation operation middleware
+func (sh *strictHandler) CancelOperation(w http.ResponseWriter, r *http.Request, name string) {
+	var request CancelOperationRequestObject
 
 	request.Name = name
 
-	var body ReplaceEnrollmentRequestJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceEnrollmentRequest(ctx, request.(ReplaceEnrollmentRequestRequestObject))
+		return sh.ssi.CancelOperation(ctx, request.(CancelOperationRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceEnrollmentRequest")
+		handler = middleware(handler, "CancelOperation")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceEnrollmentRequestResponseObject); ok {
-		if err := validResponse.VisitReplaceEnrollmentRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(CancelOperationResponseObject); ok {
+		if err := validResponse.VisitCancelOperationResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5868,32 +14819,23 @@ func (sh *strictHandler) ReplaceEnrollmentRequest(w http.ResponseWriter, r *http
 	}
 }
 
-// ApproveEnrollmentRequest operation middleware
-func (sh *strictHandler) ApproveEnrollmentRequest(w http.ResponseWriter, r *http.Request, name string) {
-	var request ApproveEnrollmentRequestRequestObject
-
-	request.Name = name
-
-	var body ApproveEnrollmentRequestJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+// DeleteRepositories operation middleware
+func (sh *strictHandler) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
+	var request DeleteRepositoriesRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ApproveEnrollmentRequest(ctx, request.(ApproveEnrollmentRequestRequestObject))
+		return sh.ssi.DeleteRepositories(ctx, request.(DeleteRepositoriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ApproveEnrollmentRequest")
+		handler = middleware(handler, "DeleteRepositories")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ApproveEnrollmentRequestResponseObject); ok {
-		if err := validResponse.VisitApproveEnrollmentRequestResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteRepositoriesResponseObject); ok {
+		if err := validResponse.VisitDeleteRepositoriesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5901,25 +14843,25 @@ func (sh *strictHandler) ApproveEnrollmentRequest(w http.ResponseWriter, r *http
 	}
 }
 
-// ReadEnrollmentRequestStatus operation middleware
-func (sh *strictHandler) ReadEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadEnrollmentRequestStatusRequestObject
+// ListRepositories operation middleware
+func (sh *strictHandler) ListRepositories(w http.ResponseWriter, r *http.Request, params ListRepositoriesParams) {
+	var request ListRepositoriesRequestObject
 
-	request.Name = name
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadEnrollmentRequestStatus(ctx, request.(ReadEnrollmentRequestStatusRequestObject))
+		return sh.ssi.ListRepositories(ctx, request.(ListRepositoriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadEnrollmentRequestStatus")
+		handler = middleware(handler, "ListRepositories")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadEnrollmentRequestStatusResponseObject); ok {
-		if err := validResponse.VisitReadEnrollmentRequestStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListRepositoriesResponseObject); ok {
+		if err := validResponse.VisitListRepositoriesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5927,13 +14869,11 @@ func (sh *strictHandler) ReadEnrollmentRequestStatus(w http.ResponseWriter, r *h
 	}
 }
 
-// ReplaceEnrollmentRequestStatus operation middleware
-func (sh *strictHandler) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceEnrollmentRequestStatusRequestObject
-
-	request.Name = name
+// CreateRepository operation middleware
+func (sh *strictHandler) CreateRepository(w http.ResponseWriter, r *http.Request) {
+	var request CreateRepositoryRequestObject
 
-	var body ReplaceEnrollmentRequestStatusJSONRequestBody
+	var body CreateRepositoryJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -5941,18 +14881,18 @@ func (sh *strictHandler) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceEnrollmentRequestStatus(ctx, request.(ReplaceEnrollmentRequestStatusRequestObject))
+		return sh.ssi.CreateRepository(ctx, request.(CreateRepositoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceEnrollmentRequestStatus")
+		handler = middleware(handler, "CreateRepository")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceEnrollmentRequestStatusResponseObject); ok {
-		if err := validResponse.VisitReplaceEnrollmentRequestStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateRepositoryResponseObject); ok {
+		if err := validResponse.VisitCreateRepositoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5960,23 +14900,26 @@ func (sh *strictHandler) ReplaceEnrollmentRequestStatus(w http.ResponseWriter, r
 	}
 }
 
-// DeleteFleets operation middleware
-func (sh *strictHandler) DeleteFleets(w http.ResponseWriter, r *http.Request) {
-	var request DeleteFleetsRequestObject
+// DeleteRepository operation middleware
+func (sh *strictHandler) DeleteRepository(w http.ResponseWriter, r *http.Request, name string, params DeleteRepositoryParams) {
+	var request DeleteRepositoryRequestObject
+
+	request.Name = name
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteFleets(ctx, request.(DeleteFleetsRequestObject))
+		return sh.ssi.DeleteRepository(ctx, request.(DeleteRepositoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteFleets")
+		handler = middleware(handler, "DeleteRepository")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteFleetsResponseObject); ok {
-		if err := validResponse.VisitDeleteFleetsResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteRepositoryResponseObject); ok {
+		if err := validResponse.VisitDeleteRepositoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -5984,25 +14927,25 @@ func (sh *strictHandler) DeleteFleets(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ListFleets operation middleware
-func (sh *strictHandler) ListFleets(w http.ResponseWriter, r *http.Request, params ListFleetsParams) {
-	var request ListFleetsRequestObject
+// ReadRepository operation middleware
+func (sh *strictHandler) ReadRepository(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadRepositoryRequestObject
 
-	request.Params = params
+	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListFleets(ctx, request.(ListFleetsRequestObject))
+		return sh.ssi.ReadRepository(ctx, request.(ReadRepositoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListFleets")
+		handler = middleware(handler, "ReadRepository")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListFleetsResponseObject); ok {
-		if err := validResponse.VisitListFleetsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadRepositoryResponseObject); ok {
+		if err := validResponse.VisitReadRepositoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6010,11 +14953,13 @@ func (sh *strictHandler) ListFleets(w http.ResponseWriter, r *http.Request, para
 	}
 }
 
-// CreateFleet operation middleware
-func (sh *strictHandler) CreateFleet(w http.ResponseWriter, r *http.Request) {
-	var request CreateFleetRequestObject
+// PatchRepository operation middleware
+func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request, name string) {
+	var request PatchRepositoryRequestObject
 
-	var body CreateFleetJSONRequestBody
+	request.Name = name
+
+	var body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6022,18 +14967,18 @@ func (sh *strictHandler) CreateFleet(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateFleet(ctx, request.(CreateFleetRequestObject))
+		return sh.ssi.PatchRepository(ctx, request.(PatchRepositoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateFleet")
+		handler = middleware(handler, "PatchRepository")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateFleetResponseObject); ok {
-		if err := validResponse.VisitCreateFleetResponse(w); err != nil {
+	} else if validResponse, ok := response.(PatchRepositoryResponseObject); ok {
+		if err := validResponse.VisitPatchRepositoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6041,25 +14986,32 @@ func (sh *strictHandler) CreateFleet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// DeleteTemplateVersions operation middleware
-func (sh *strictHandler) DeleteTemplateVersions(w http.ResponseWriter, r *http.Request, fleet string) {
-	var request DeleteTemplateVersionsRequestObject
+// ReplaceRepository operation middleware
+func (sh *strictHandler) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceRepositoryRequestObject
 
-	request.Fleet = fleet
+	request.Name = name
+
+	var body ReplaceRepositoryJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteTemplateVersions(ctx, request.(DeleteTemplateVersionsRequestObject))
+		return sh.ssi.ReplaceRepository(ctx, request.(ReplaceRepositoryRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteTemplateVersions")
+		handler = middleware(handler, "ReplaceRepository")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteTemplateVersionsResponseObject); ok {
-		if err := validResponse.VisitDeleteTemplateVersionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceRepositoryResponseObject); ok {
+		if err := validResponse.VisitReplaceRepositoryResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6067,26 +15019,32 @@ func (sh *strictHandler) DeleteTemplateVersions(w http.ResponseWriter, r *http.R
 	}
 }
 
-// ListTemplateVersions operation middleware
-func (sh *strictHandler) ListTemplateVersions(w http.ResponseWriter, r *http.Request, fleet string, params ListTemplateVersionsParams) {
-	var request ListTemplateVersionsRequestObject
+// RotateRepositoryCredentials operation middleware
+func (sh *strictHandler) RotateRepositoryCredentials(w http.ResponseWriter, r *http.Request, name string) {
+	var request RotateRepositoryCredentialsRequestObject
 
-	request.Fleet = fleet
-	request.Params = params
+	request.Name = name
+
+	var body RotateRepositoryCredentialsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListTemplateVersions(ctx, request.(ListTemplateVersionsRequestObject))
+		return sh.ssi.RotateRepositoryCredentials(ctx, request.(RotateRepositoryCredentialsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListTemplateVersions")
+		handler = middleware(handler, "RotateRepositoryCredentials")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListTemplateVersionsResponseObject); ok {
-		if err := validResponse.VisitListTemplateVersionsResponse(w); err != nil {
+	} else if validResponse, ok := response.(RotateRepositoryCredentialsResponseObject); ok {
+		if err := validResponse.VisitRotateRepositoryCredentialsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6094,26 +15052,23 @@ func (sh *strictHandler) ListTemplateVersions(w http.ResponseWriter, r *http.Req
 	}
 }
 
-// DeleteTemplateVersion operation middleware
-func (sh *strictHandler) DeleteTemplateVersion(w http.ResponseWriter, r *http.Request, fleet string, name string) {
-	var request DeleteTemplateVersionRequestObject
-
-	request.Fleet = fleet
-	request.Name = name
+// DeleteResourceSyncs operation middleware
+func (sh *strictHandler) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
+	var request DeleteResourceSyncsRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteTemplateVersion(ctx, request.(DeleteTemplateVersionRequestObject))
+		return sh.ssi.DeleteResourceSyncs(ctx, request.(DeleteResourceSyncsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteTemplateVersion")
+		handler = middleware(handler, "DeleteResourceSyncs")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteTemplateVersionResponseObject); ok {
-		if err := validResponse.VisitDeleteTemplateVersionResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteResourceSyncsResponseObject); ok {
+		if err := validResponse.VisitDeleteResourceSyncsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6121,26 +15076,25 @@ func (sh *strictHandler) DeleteTemplateVersion(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// ReadTemplateVersion operation middleware
-func (sh *strictHandler) ReadTemplateVersion(w http.ResponseWriter, r *http.Request, fleet string, name string) {
-	var request ReadTemplateVersionRequestObject
+// ListResourceSync operation middleware
+func (sh *strictHandler) ListResourceSync(w http.ResponseWriter, r *http.Request, params ListResourceSyncParams) {
+	var request ListResourceSyncRequestObject
 
-	request.Fleet = fleet
-	request.Name = name
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadTemplateVersion(ctx, request.(ReadTemplateVersionRequestObject))
+		return sh.ssi.ListResourceSync(ctx, request.(ListResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadTemplateVersion")
+		handler = middleware(handler, "ListResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadTemplateVersionResponseObject); ok {
-		if err := validResponse.VisitReadTemplateVersionResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListResourceSyncResponseObject); ok {
+		if err := validResponse.VisitListResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6148,25 +15102,30 @@ func (sh *strictHandler) ReadTemplateVersion(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// DeleteFleet operation middleware
-func (sh *strictHandler) DeleteFleet(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteFleetRequestObject
+// CreateResourceSync operation middleware
+func (sh *strictHandler) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
+	var request CreateResourceSyncRequestObject
 
-	request.Name = name
+	var body CreateResourceSyncJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteFleet(ctx, request.(DeleteFleetRequestObject))
+		return sh.ssi.CreateResourceSync(ctx, request.(CreateResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteFleet")
+		handler = middleware(handler, "CreateResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteFleetResponseObject); ok {
-		if err := validResponse.VisitDeleteFleetResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateResourceSyncResponseObject); ok {
+		if err := validResponse.VisitCreateResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6174,26 +15133,25 @@ func (sh *strictHandler) DeleteFleet(w http.ResponseWriter, r *http.Request, nam
 	}
 }
 
-// ReadFleet operation middleware
-func (sh *strictHandler) ReadFleet(w http.ResponseWriter, r *http.Request, name string, params ReadFleetParams) {
-	var request ReadFleetRequestObject
+// DeleteResourceSync operation middleware
+func (sh *strictHandler) DeleteResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteResourceSyncRequestObject
 
 	request.Name = name
-	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadFleet(ctx, request.(ReadFleetRequestObject))
+		return sh.ssi.DeleteResourceSync(ctx, request.(DeleteResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadFleet")
+		handler = middleware(handler, "DeleteResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadFleetResponseObject); ok {
-		if err := validResponse.VisitReadFleetResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteResourceSyncResponseObject); ok {
+		if err := validResponse.VisitDeleteResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6201,32 +15159,25 @@ func (sh *strictHandler) ReadFleet(w http.ResponseWriter, r *http.Request, name
 	}
 }
 
-// PatchFleet operation middleware
-func (sh *strictHandler) PatchFleet(w http.ResponseWriter, r *http.Request, name string) {
-	var request PatchFleetRequestObject
+// ReadResourceSync operation middleware
+func (sh *strictHandler) ReadResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadResourceSyncRequestObject
 
 	request.Name = name
 
-	var body PatchFleetApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchFleet(ctx, request.(PatchFleetRequestObject))
+		return sh.ssi.ReadResourceSync(ctx, request.(ReadResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchFleet")
+		handler = middleware(handler, "ReadResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchFleetResponseObject); ok {
-		if err := validResponse.VisitPatchFleetResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadResourceSyncResponseObject); ok {
+		if err := validResponse.VisitReadResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6234,13 +15185,13 @@ func (sh *strictHandler) PatchFleet(w http.ResponseWriter, r *http.Request, name
 	}
 }
 
-// ReplaceFleet operation middleware
-func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceFleetRequestObject
+// PatchResourceSync operation middleware
+func (sh *strictHandler) PatchResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	var request PatchResourceSyncRequestObject
 
 	request.Name = name
 
-	var body ReplaceFleetJSONRequestBody
+	var body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6248,18 +15199,18 @@ func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, na
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceFleet(ctx, request.(ReplaceFleetRequestObject))
+		return sh.ssi.PatchResourceSync(ctx, request.(PatchResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceFleet")
+		handler = middleware(handler, "PatchResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceFleetResponseObject); ok {
-		if err := validResponse.VisitReplaceFleetResponse(w); err != nil {
+	} else if validResponse, ok := response.(PatchResourceSyncResponseObject); ok {
+		if err := validResponse.VisitPatchResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6267,25 +15218,32 @@ func (sh *strictHandler) ReplaceFleet(w http.ResponseWriter, r *http.Request, na
 	}
 }
 
-// ReadFleetStatus operation middleware
-func (sh *strictHandler) ReadFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadFleetStatusRequestObject
+// ReplaceResourceSync operation middleware
+func (sh *strictHandler) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceResourceSyncRequestObject
 
 	request.Name = name
 
+	var body ReplaceResourceSyncJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadFleetStatus(ctx, request.(ReadFleetStatusRequestObject))
+		return sh.ssi.ReplaceResourceSync(ctx, request.(ReplaceResourceSyncRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadFleetStatus")
+		handler = middleware(handler, "ReplaceResourceSync")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadFleetStatusResponseObject); ok {
-		if err := validResponse.VisitReadFleetStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceResourceSyncResponseObject); ok {
+		if err := validResponse.VisitReplaceResourceSyncResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6293,32 +15251,25 @@ func (sh *strictHandler) ReadFleetStatus(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// ReplaceFleetStatus operation middleware
-func (sh *strictHandler) ReplaceFleetStatus(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceFleetStatusRequestObject
-
-	request.Name = name
+// ListImageSboms operation middleware
+func (sh *strictHandler) ListImageSboms(w http.ResponseWriter, r *http.Request, params ListImageSbomsParams) {
+	var request ListImageSbomsRequestObject
 
-	var body ReplaceFleetStatusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceFleetStatus(ctx, request.(ReplaceFleetStatusRequestObject))
+		return sh.ssi.ListImageSboms(ctx, request.(ListImageSbomsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceFleetStatus")
+		handler = middleware(handler, "ListImageSboms")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceFleetStatusResponseObject); ok {
-		if err := validResponse.VisitReplaceFleetStatusResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListImageSbomsResponseObject); ok {
+		if err := validResponse.VisitListImageSbomsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6326,23 +15277,23 @@ func (sh *strictHandler) ReplaceFleetStatus(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// DeleteRepositories operation middleware
-func (sh *strictHandler) DeleteRepositories(w http.ResponseWriter, r *http.Request) {
-	var request DeleteRepositoriesRequestObject
+// DeleteSites operation middleware
+func (sh *strictHandler) DeleteSites(w http.ResponseWriter, r *http.Request) {
+	var request DeleteSitesRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteRepositories(ctx, request.(DeleteRepositoriesRequestObject))
+		return sh.ssi.DeleteSites(ctx, request.(DeleteSitesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteRepositories")
+		handler = middleware(handler, "DeleteSites")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteRepositoriesResponseObject); ok {
-		if err := validResponse.VisitDeleteRepositoriesResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteSitesResponseObject); ok {
+		if err := validResponse.VisitDeleteSitesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6350,25 +15301,25 @@ func (sh *strictHandler) DeleteRepositories(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// ListRepositories operation middleware
-func (sh *strictHandler) ListRepositories(w http.ResponseWriter, r *http.Request, params ListRepositoriesParams) {
-	var request ListRepositoriesRequestObject
+// ListSites operation middleware
+func (sh *strictHandler) ListSites(w http.ResponseWriter, r *http.Request, params ListSitesParams) {
+	var request ListSitesRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListRepositories(ctx, request.(ListRepositoriesRequestObject))
+		return sh.ssi.ListSites(ctx, request.(ListSitesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListRepositories")
+		handler = middleware(handler, "ListSites")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListRepositoriesResponseObject); ok {
-		if err := validResponse.VisitListRepositoriesResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListSitesResponseObject); ok {
+		if err := validResponse.VisitListSitesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6376,11 +15327,11 @@ func (sh *strictHandler) ListRepositories(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// CreateRepository operation middleware
-func (sh *strictHandler) CreateRepository(w http.ResponseWriter, r *http.Request) {
-	var request CreateRepositoryRequestObject
+// CreateSite operation middleware
+func (sh *strictHandler) CreateSite(w http.ResponseWriter, r *http.Request) {
+	var request CreateSiteRequestObject
 
-	var body CreateRepositoryJSONRequestBody
+	var body CreateSiteJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6388,18 +15339,18 @@ func (sh *strictHandler) CreateRepository(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateRepository(ctx, request.(CreateRepositoryRequestObject))
+		return sh.ssi.CreateSite(ctx, request.(CreateSiteRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateRepository")
+		handler = middleware(handler, "CreateSite")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateRepositoryResponseObject); ok {
-		if err := validResponse.VisitCreateRepositoryResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateSiteResponseObject); ok {
+		if err := validResponse.VisitCreateSiteResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6407,25 +15358,25 @@ func (sh *strictHandler) CreateRepository(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// DeleteRepository operation middleware
-func (sh *strictHandler) DeleteRepository(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteRepositoryRequestObject
+// DeleteSite operation middleware
+func (sh *strictHandler) DeleteSite(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteSiteRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteRepository(ctx, request.(DeleteRepositoryRequestObject))
+		return sh.ssi.DeleteSite(ctx, request.(DeleteSiteRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteRepository")
+		handler = middleware(handler, "DeleteSite")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteRepositoryResponseObject); ok {
-		if err := validResponse.VisitDeleteRepositoryResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteSiteResponseObject); ok {
+		if err := validResponse.VisitDeleteSiteResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6433,25 +15384,25 @@ func (sh *strictHandler) DeleteRepository(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// ReadRepository operation middleware
-func (sh *strictHandler) ReadRepository(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadRepositoryRequestObject
+// ReadSite operation middleware
+func (sh *strictHandler) ReadSite(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadSiteRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadRepository(ctx, request.(ReadRepositoryRequestObject))
+		return sh.ssi.ReadSite(ctx, request.(ReadSiteRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadRepository")
+		handler = middleware(handler, "ReadSite")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadRepositoryResponseObject); ok {
-		if err := validResponse.VisitReadRepositoryResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadSiteResponseObject); ok {
+		if err := validResponse.VisitReadSiteResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6459,13 +15410,13 @@ func (sh *strictHandler) ReadRepository(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// PatchRepository operation middleware
-func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request, name string) {
-	var request PatchRepositoryRequestObject
+// ReplaceSite operation middleware
+func (sh *strictHandler) ReplaceSite(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceSiteRequestObject
 
 	request.Name = name
 
-	var body PatchRepositoryApplicationJSONPatchPlusJSONRequestBody
+	var body ReplaceSiteJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6473,18 +15424,18 @@ func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchRepository(ctx, request.(PatchRepositoryRequestObject))
+		return sh.ssi.ReplaceSite(ctx, request.(ReplaceSiteRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchRepository")
+		handler = middleware(handler, "ReplaceSite")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchRepositoryResponseObject); ok {
-		if err := validResponse.VisitPatchRepositoryResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceSiteResponseObject); ok {
+		if err := validResponse.VisitReplaceSiteResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6492,32 +15443,25 @@ func (sh *strictHandler) PatchRepository(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// ReplaceRepository operation middleware
-func (sh *strictHandler) ReplaceRepository(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceRepositoryRequestObject
-
-	request.Name = name
+// ListTrash operation middleware
+func (sh *strictHandler) ListTrash(w http.ResponseWriter, r *http.Request, params ListTrashParams) {
+	var request ListTrashRequestObject
 
-	var body ReplaceRepositoryJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceRepository(ctx, request.(ReplaceRepositoryRequestObject))
+		return sh.ssi.ListTrash(ctx, request.(ListTrashRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceRepository")
+		handler = middleware(handler, "ListTrash")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceRepositoryResponseObject); ok {
-		if err := validResponse.VisitReplaceRepositoryResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListTrashResponseObject); ok {
+		if err := validResponse.VisitListTrashResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6525,23 +15469,23 @@ func (sh *strictHandler) ReplaceRepository(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// DeleteResourceSyncs operation middleware
-func (sh *strictHandler) DeleteResourceSyncs(w http.ResponseWriter, r *http.Request) {
-	var request DeleteResourceSyncsRequestObject
+// DeleteWebhooks operation middleware
+func (sh *strictHandler) DeleteWebhooks(w http.ResponseWriter, r *http.Request) {
+	var request DeleteWebhooksRequestObject
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteResourceSyncs(ctx, request.(DeleteResourceSyncsRequestObject))
+		return sh.ssi.DeleteWebhooks(ctx, request.(DeleteWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteResourceSyncs")
+		handler = middleware(handler, "DeleteWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteResourceSyncsResponseObject); ok {
-		if err := validResponse.VisitDeleteResourceSyncsResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhooksResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6549,25 +15493,25 @@ func (sh *strictHandler) DeleteResourceSyncs(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// ListResourceSync operation middleware
-func (sh *strictHandler) ListResourceSync(w http.ResponseWriter, r *http.Request, params ListResourceSyncParams) {
-	var request ListResourceSyncRequestObject
+// ListWebhooks operation middleware
+func (sh *strictHandler) ListWebhooks(w http.ResponseWriter, r *http.Request, params ListWebhooksParams) {
+	var request ListWebhooksRequestObject
 
 	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListResourceSync(ctx, request.(ListResourceSyncRequestObject))
+		return sh.ssi.ListWebhooks(ctx, request.(ListWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListResourceSync")
+		handler = middleware(handler, "ListWebhooks")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListResourceSyncResponseObject); ok {
-		if err := validResponse.VisitListResourceSyncResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListWebhooksResponseObject); ok {
+		if err := validResponse.VisitListWebhooksResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6575,11 +15519,11 @@ func (sh *strictHandler) ListResourceSync(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// CreateResourceSync operation middleware
-func (sh *strictHandler) CreateResourceSync(w http.ResponseWriter, r *http.Request) {
-	var request CreateResourceSyncRequestObject
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request CreateWebhookRequestObject
 
-	var body CreateResourceSyncJSONRequestBody
+	var body CreateWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6587,44 +15531,18 @@ func (sh *strictHandler) CreateResourceSync(w http.ResponseWriter, r *http.Reque
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateResourceSync(ctx, request.(CreateResourceSyncRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateResourceSync")
-	}
-
-	response, err := handler(r.Context(), w, r, request)
-
-	if err != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateResourceSyncResponseObject); ok {
-		if err := validResponse.VisitCreateResourceSyncResponse(w); err != nil {
-			sh.options.ResponseErrorHandlerFunc(w, r, err)
-		}
-	} else if response != nil {
-		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
-	}
-}
-
-// DeleteResourceSync operation middleware
-func (sh *strictHandler) DeleteResourceSync(w http.ResponseWriter, r *http.Request, name string) {
-	var request DeleteResourceSyncRequestObject
-
-	request.Name = name
-
-	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteResourceSync(ctx, request.(DeleteResourceSyncRequestObject))
+		return sh.ssi.CreateWebhook(ctx, request.(CreateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteResourceSync")
+		handler = middleware(handler, "CreateWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteResourceSyncResponseObject); ok {
-		if err := validResponse.VisitDeleteResourceSyncResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6632,25 +15550,25 @@ func (sh *strictHandler) DeleteResourceSync(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// ReadResourceSync operation middleware
-func (sh *strictHandler) ReadResourceSync(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReadResourceSyncRequestObject
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteWebhookRequestObject
 
 	request.Name = name
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReadResourceSync(ctx, request.(ReadResourceSyncRequestObject))
+		return sh.ssi.DeleteWebhook(ctx, request.(DeleteWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReadResourceSync")
+		handler = middleware(handler, "DeleteWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReadResourceSyncResponseObject); ok {
-		if err := validResponse.VisitReadResourceSyncResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6658,32 +15576,25 @@ func (sh *strictHandler) ReadResourceSync(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// PatchResourceSync operation middleware
-func (sh *strictHandler) PatchResourceSync(w http.ResponseWriter, r *http.Request, name string) {
-	var request PatchResourceSyncRequestObject
+// ReadWebhook operation middleware
+func (sh *strictHandler) ReadWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReadWebhookRequestObject
 
 	request.Name = name
 
-	var body PatchResourceSyncApplicationJSONPatchPlusJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchResourceSync(ctx, request.(PatchResourceSyncRequestObject))
+		return sh.ssi.ReadWebhook(ctx, request.(ReadWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchResourceSync")
+		handler = middleware(handler, "ReadWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchResourceSyncResponseObject); ok {
-		if err := validResponse.VisitPatchResourceSyncResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReadWebhookResponseObject); ok {
+		if err := validResponse.VisitReadWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -6691,13 +15602,13 @@ func (sh *strictHandler) PatchResourceSync(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// ReplaceResourceSync operation middleware
-func (sh *strictHandler) ReplaceResourceSync(w http.ResponseWriter, r *http.Request, name string) {
-	var request ReplaceResourceSyncRequestObject
+// ReplaceWebhook operation middleware
+func (sh *strictHandler) ReplaceWebhook(w http.ResponseWriter, r *http.Request, name string) {
+	var request ReplaceWebhookRequestObject
 
 	request.Name = name
 
-	var body ReplaceResourceSyncJSONRequestBody
+	var body ReplaceWebhookJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -6705,18 +15616,18 @@ func (sh *strictHandler) ReplaceResourceSync(w http.ResponseWriter, r *http.Requ
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ReplaceResourceSync(ctx, request.(ReplaceResourceSyncRequestObject))
+		return sh.ssi.ReplaceWebhook(ctx, request.(ReplaceWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ReplaceResourceSync")
+		handler = middleware(handler, "ReplaceWebhook")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ReplaceResourceSyncResponseObject); ok {
-		if err := validResponse.VisitReplaceResourceSyncResponse(w); err != nil {
+	} else if validResponse, ok := response.(ReplaceWebhookResponseObject); ok {
+		if err := validResponse.VisitReplaceWebhookResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
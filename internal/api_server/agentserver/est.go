@@ -0,0 +1,116 @@
+package agentserver
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/flightctl/flightctl/internal/crypto"
+	service "github.com/flightctl/flightctl/internal/service/agent"
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// estPath is the path prefix of the EST (RFC 7030) operations.
+	estPath = "/.well-known/est"
+
+	// estRetryAfter is the number of seconds after which EST clients retry
+	// enrolling while their enrollment request awaits approval.
+	estRetryAfter = "60"
+
+	// estMaxRequestSize bounds the size of the base64 encoded CSRs.
+	estMaxRequestSize = 64 * 1024
+)
+
+// estHandler serves the cacerts, simpleenroll and simplereenroll operations
+// of EST, so that clients which do not speak the flightctl API can enroll.
+// EST exchanges base64 encoded PKCS structures, so its operations are not
+// part of the OpenAPI spec.
+type estHandler struct {
+	log     logrus.FieldLogger
+	handler *service.AgentServiceHandler
+}
+
+func (h *estHandler) routes(r chi.Router) {
+	r.Get("/cacerts", h.caCerts)
+	r.Post("/simpleenroll", h.enroll(h.handler.EstSimpleEnroll))
+	r.Post("/simplereenroll", h.enroll(h.handler.EstSimpleReenroll))
+}
+
+func (h *estHandler) caCerts(w http.ResponseWriter, r *http.Request) {
+	writeCertsOnly(w, h.log, h.handler.EstCACerts())
+}
+
+func (h *estHandler) enroll(sign func(ctx context.Context, csr *x509.CertificateRequest) ([]byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		csr, err := readCSR(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		certData, err := sign(r.Context(), csr)
+		switch {
+		case err == nil:
+		case errors.Is(err, service.ErrEstPending):
+			w.Header().Set("Retry-After", estRetryAfter)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		case errors.Is(err, service.ErrEstUnauthorized):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case errors.Is(err, service.ErrEstInvalidRequest):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		default:
+			h.log.Errorf("failed EST enrollment: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		certs, err := crypto.ParseCertificates(certData)
+		if err != nil {
+			h.log.Errorf("failed parsing issued certificate: %v", err)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		writeCertsOnly(w, h.log, certs)
+	}
+}
+
+// readCSR reads the base64 encoded PKCS#10 CSR of an enrollment request.
+func readCSR(r *http.Request) (*x509.CertificateRequest, error) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/pkcs10" {
+		return nil, errors.New("content type must be application/pkcs10")
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, estMaxRequestSize))
+	if err != nil {
+		return nil, err
+	}
+	// accept PEM encoded CSRs as well, as some clients send them
+	if block, _ := pem.Decode(body); block != nil {
+		return x509.ParseCertificateRequest(block.Bytes)
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(body)), ""))
+	if err != nil {
+		return nil, errors.New("CSR must be base64 encoded")
+	}
+	return x509.ParseCertificateRequest(der)
+}
+
+func writeCertsOnly(w http.ResponseWriter, log logrus.FieldLogger, certs []*x509.Certificate) {
+	der, err := crypto.EncodeCertsOnly(certs)
+	if err != nil {
+		log.Errorf("failed encoding certificates: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pkcs7-mime; smime-type=certs-only")
+	w.Header().Set("Content-Transfer-Encoding", "base64")
+	_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(der)))
+}
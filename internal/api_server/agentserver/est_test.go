@@ -0,0 +1,194 @@
+package agentserver
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	service "github.com/flightctl/flightctl/internal/service/agent"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type estStore struct {
+	store.Store
+	enrollmentRequests enrollmentRequestStore
+	devices            deviceStore
+	certificates       issuedCertificateStore
+}
+
+func (s *estStore) EnrollmentRequest() store.EnrollmentRequest { return &s.enrollmentRequests }
+func (s *estStore) Device() store.Device                       { return &s.devices }
+func (s *estStore) IssuedCertificate() store.IssuedCertificate { return &s.certificates }
+
+type enrollmentRequestStore struct {
+	store.EnrollmentRequest
+	items map[string]*api.EnrollmentRequest
+}
+
+func (s *enrollmentRequestStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.EnrollmentRequest, error) {
+	if er, ok := s.items[name]; ok {
+		return er, nil
+	}
+	return nil, flterrors.ErrResourceNotFound
+}
+
+func (s *enrollmentRequestStore) Create(ctx context.Context, orgId uuid.UUID, er *api.EnrollmentRequest) (*api.EnrollmentRequest, error) {
+	s.items[*er.Metadata.Name] = er
+	return er, nil
+}
+
+type deviceStore struct {
+	store.Device
+	names []string
+}
+
+func (s *deviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Device, error) {
+	for _, n := range s.names {
+		if n == name {
+			return &api.Device{Metadata: api.ObjectMeta{Name: &name}}, nil
+		}
+	}
+	return nil, flterrors.ErrResourceNotFound
+}
+
+type issuedCertificateStore struct {
+	store.IssuedCertificate
+	recorded []api.IssuedCertificate
+}
+
+func (s *issuedCertificateStore) Record(ctx context.Context, orgId uuid.UUID, certificate *api.IssuedCertificate) error {
+	s.recorded = append(s.recorded, *certificate)
+	return nil
+}
+
+func newESTTestHandler(t *testing.T) (http.Handler, *crypto.CA, *estStore) {
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	st := &estStore{enrollmentRequests: enrollmentRequestStore{items: map[string]*api.EnrollmentRequest{}}}
+	log := logrus.New()
+	est := &estHandler{log: log, handler: service.NewAgentServiceHandler(st, nil, nil, ca, log, "", "", "")}
+	router := chi.NewRouter()
+	router.Route(estPath, est.routes)
+	return router, ca, st
+}
+
+// estRequest sends the base64 encoded CSR as the client with the given common
+// name, which the TLS middleware would take from the client certificate.
+func estRequest(t *testing.T, handler http.Handler, operation string, cn string, csr []byte) *http.Response {
+	ctx := context.WithValue(context.Background(), middleware.TLSCommonNameContextKey, cn)
+	req := httptest.NewRequest(http.MethodPost, estPath+"/"+operation, strings.NewReader(base64.StdEncoding.EncodeToString(csr))).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/pkcs10")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+func newTestCSR(t *testing.T) (*x509.CertificateRequest, string) {
+	_, key, err := crypto.NewKeyPair()
+	require.NoError(t, err)
+	csrPEM, err := crypto.MakeCSR(key.(stdcrypto.Signer), "est-client")
+	require.NoError(t, err)
+	csr, err := crypto.ParseCSR(csrPEM)
+	require.NoError(t, err)
+	name, err := crypto.DeviceFingerprint(csr.PublicKey)
+	require.NoError(t, err)
+	return csr, name
+}
+
+func readCertsOnly(t *testing.T, resp *http.Response) []*x509.Certificate {
+	require.Equal(t, "application/pkcs7-mime; smime-type=certs-only", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	der, err := base64.StdEncoding.DecodeString(string(body))
+	require.NoError(t, err)
+	certs, err := crypto.ParseCertsOnly(der)
+	require.NoError(t, err)
+	return certs
+}
+
+func TestESTCACerts(t *testing.T) {
+	handler, ca, _ := newESTTestHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, estPath+"/cacerts", nil))
+	resp := rec.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	certs := readCertsOnly(t, resp)
+	require.Len(t, certs, 1)
+	require.True(t, certs[0].Equal(ca.Config.Certs[0]))
+}
+
+func TestESTSimpleEnroll(t *testing.T) {
+	require := require.New(t)
+	handler, ca, st := newESTTestHandler(t)
+	csr, name := newTestCSR(t)
+
+	// the first request creates the enrollment request
+	resp := estRequest(t, handler, "simpleenroll", crypto.ClientBootstrapCommonName, csr.Raw)
+	require.Equal(http.StatusAccepted, resp.StatusCode)
+	require.Equal(estRetryAfter, resp.Header.Get("Retry-After"))
+	require.Contains(st.enrollmentRequests.items, name)
+
+	// until it is approved, the client has to retry
+	resp = estRequest(t, handler, "simpleenroll", crypto.ClientBootstrapCommonName, csr.Raw)
+	require.Equal(http.StatusAccepted, resp.StatusCode)
+
+	// approving the enrollment request signs the certificate
+	approved := *csr
+	approved.Subject.CommonName = crypto.DeviceCommonNamePrefix + name
+	certData, err := ca.IssueRequestedClientCertificate(&approved, 3600)
+	require.NoError(err)
+	cert := string(certData)
+	st.enrollmentRequests.items[name].Status.Certificate = &cert
+
+	resp = estRequest(t, handler, "simpleenroll", crypto.ClientBootstrapCommonName, csr.Raw)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	certs := readCertsOnly(t, resp)
+	require.Equal(crypto.DeviceCommonNamePrefix+name, certs[0].Subject.CommonName)
+}
+
+func TestESTSimpleEnrollRequiresEnrollmentCertificate(t *testing.T) {
+	handler, _, st := newESTTestHandler(t)
+	csr, _ := newTestCSR(t)
+
+	resp := estRequest(t, handler, "simpleenroll", crypto.DeviceCommonNamePrefix+"0123456789abcdef", csr.Raw)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Empty(t, st.enrollmentRequests.items)
+}
+
+func TestESTSimpleReenroll(t *testing.T) {
+	require := require.New(t)
+	handler, _, st := newESTTestHandler(t)
+	st.devices.names = []string{"mydevice"}
+	// the device may rotate its key when renewing its certificate
+	csr, _ := newTestCSR(t)
+
+	resp := estRequest(t, handler, "simplereenroll", crypto.DeviceCommonNamePrefix+"mydevice", csr.Raw)
+	require.Equal(http.StatusOK, resp.StatusCode)
+	certs := readCertsOnly(t, resp)
+	require.Equal(crypto.DeviceCommonNamePrefix+"mydevice", certs[0].Subject.CommonName)
+	require.Len(st.certificates.recorded, 1)
+	require.Equal("mydevice", *st.certificates.recorded[0].Device)
+
+	resp = estRequest(t, handler, "simplereenroll", crypto.DeviceCommonNamePrefix+"unknown", csr.Raw)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	resp = estRequest(t, handler, "simplereenroll", crypto.ClientBootstrapCommonName, csr.Raw)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"time"
 
 	pb "github.com/flightctl/flightctl/api/grpc/v1"
 	"github.com/flightctl/flightctl/internal/api_server/middleware"
@@ -61,18 +62,49 @@ func (s *AgentGrpcServer) Run(ctx context.Context) error {
 		s.log.Fatalf("cannot start server: %s", err)
 	}
 
+	stopped := make(chan struct{})
 	go func() {
+		defer close(stopped)
 		<-ctx.Done()
-		server.Stop()
+		timeout := s.cfg.Service.GracefulShutdownTimeout()
+		// sessions still waiting for their peer are ended right away, so that
+		// they are opened again on another instance of the service
+		s.endPendingSessions()
+		graceful := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(graceful)
+		}()
+		select {
+		case <-graceful:
+		case <-time.After(timeout):
+			s.log.Warnf("closing the console sessions still active after %s", timeout)
+			server.Stop()
+		}
 	}()
 
-	return server.Serve(listener)
+	if err := server.Serve(listener); err != nil {
+		return err
+	}
+	<-stopped
+	return nil
+}
+
+// endPendingSessions ends the sessions whose peer did not connect yet.
+func (s *AgentGrpcServer) endPendingSessions() {
+	s.pendingStreams.Range(func(key, value any) bool {
+		if sctx := value.(streamCtx); !sctx.paired && !sctx.closed {
+			sctx.cancel()
+		}
+		return true
+	})
 }
 
 type streamCtx struct {
 	cancel context.CancelFunc
 	stream pb.RouterService_StreamServer
 	closed bool // one side closed the connection and we should not accept any more messages
+	paired bool // the peer connected and the session is forwarded
 }
 
 func (s *AgentGrpcServer) Stream(stream pb.RouterService_StreamServer) error {
@@ -115,6 +147,9 @@ func (s *AgentGrpcServer) Stream(stream pb.RouterService_StreamServer) error {
 			s.log.Infof("client %s, attempted connection to %s which was already closed", clientName, sessionId)
 			return nil
 		}
+		paired := actual.(streamCtx)
+		paired.paired = true
+		s.pendingStreams.Store(sessionId, paired)
 		err := forward(ctx, stream, otherSideStream)
 		if errors.Is(err, io.EOF) {
 			// one side closed the connection, we should not accept any more messages
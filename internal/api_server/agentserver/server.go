@@ -2,19 +2,22 @@ package agentserver
 
 import (
 	"context"
-	"errors"
+	"crypto/x509"
 	"fmt"
 	"net"
-	"net/http"
 	"time"
 
-	api "github.com/flightctl/flightctl/api/v1alpha1"
+	agentapi "github.com/flightctl/flightctl/api/v1alpha1/agent"
 	server "github.com/flightctl/flightctl/internal/api/server/agent"
 	tlsmiddleware "github.com/flightctl/flightctl/internal/api_server/middleware"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 	service "github.com/flightctl/flightctl/internal/service/agent"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/tasks"
+	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
@@ -22,8 +25,7 @@ import (
 )
 
 const (
-	gracefulShutdownTimeout = 5 * time.Second
-	cacheExpirationTime     = 10 * time.Minute
+	cacheExpirationTime = 10 * time.Minute
 )
 
 type AgentServer struct {
@@ -32,6 +34,9 @@ type AgentServer struct {
 	store    store.Store
 	ca       *crypto.CA
 	listener net.Listener
+	provider queues.Provider
+	// clientCAs verify the client certificates relays forward
+	clientCAs *x509.CertPool
 }
 
 // New returns a new instance of a flightctl server.
@@ -41,6 +46,7 @@ func New(
 	store store.Store,
 	ca *crypto.CA,
 	listener net.Listener,
+	provider queues.Provider,
 ) *AgentServer {
 	return &AgentServer{
 		log:      log,
@@ -48,53 +54,77 @@ func New(
 		store:    store,
 		ca:       ca,
 		listener: listener,
+		provider: provider,
 	}
 }
 
-func oapiErrorHandler(w http.ResponseWriter, message string, statusCode int) {
-	http.Error(w, fmt.Sprintf("API Error: %s", message), statusCode)
+// SetClientCAs sets the CAs of the client certificates of the agents, which
+// verify the certificates the relays forward for the devices of their sites.
+func (s *AgentServer) SetClientCAs(clientCAs *x509.CertPool) {
+	s.clientCAs = clientCAs
 }
 
 func (s *AgentServer) Run(ctx context.Context) error {
+	s.log.Println("Initializing Agent-side async jobs")
+	publisher, err := tasks.TaskQueuePublisher(s.provider)
+	if err != nil {
+		return err
+	}
+	callbackManager := tasks.NewCallbackManager(publisher, s.log)
+
 	s.log.Println("Initializing Agent-side API server")
-	swagger, err := api.GetSwagger()
+	// validate against the agent-side spec, which holds the operations only agents use
+	swagger, err := agentapi.GetSwagger()
 	if err != nil {
 		return fmt.Errorf("failed loading swagger spec: %w", err)
 	}
 	// Skip server name validation
 	swagger.Servers = nil
+	// Metrics pushed by devices are passed through as opaque remote-write payloads
+	openapi3filter.RegisterBodyDecoder(remotewrite.ContentType, openapi3filter.FileBodyDecoder)
 
 	oapiOpts := oapimiddleware.Options{
-		ErrorHandler: oapiErrorHandler,
+		ErrorHandler: tlsmiddleware.RequestValidationErrorHandler,
+	}
+
+	var metricsForwarder *remotewrite.Forwarder
+	if s.cfg.Metrics != nil && s.cfg.Metrics.RemoteWriteUrl != "" {
+		metricsForwarder = remotewrite.NewForwarder(s.cfg.Metrics.RemoteWriteUrl, s.log)
+	}
+
+	h := service.NewAgentServiceHandler(s.store, callbackManager, metricsForwarder, s.ca, s.log, s.cfg.Service.BaseAgentGrpcUrl, s.cfg.Service.BaseAgentEndpointUrl, s.cfg.Service.BaseUIUrl)
+	h.SetDuplicateEnrollmentPolicy(s.cfg.Service.DuplicateEnrollment())
+	relayCommonNames := make([]string, 0, len(s.cfg.Service.Relays))
+	for _, name := range s.cfg.Service.Relays {
+		cn, err := crypto.CNFromDeviceFingerprint(name)
+		if err != nil {
+			return fmt.Errorf("invalid relay %q: %w", name, err)
+		}
+		relayCommonNames = append(relayCommonNames, cn)
 	}
+	h.SetRelays(relayCommonNames, s.clientCAs)
 
 	router := chi.NewRouter()
 	router.Use(
 		middleware.RequestID,
 		middleware.Logger,
 		middleware.Recoverer,
-		oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts),
 	)
-
-	h := service.NewAgentServiceHandler(s.store, s.ca, s.log, s.cfg.Service.BaseAgentGrpcUrl)
-	server.HandlerFromMux(server.NewStrictHandler(h, nil), router)
+	est := &estHandler{log: s.log, handler: h}
+	router.Route(estPath, est.routes)
+	router.Group(func(r chi.Router) {
+		r.Use(
+			tlsmiddleware.ErrorResponses,
+			tlsmiddleware.RelayedClientCertificates(relayCommonNames, s.clientCAs, h.AuthorizeRelayedDevice, s.log),
+			oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts),
+		)
+		server.HandlerFromMux(server.NewStrictHandlerWithOptions(h, nil, server.StrictHTTPServerOptions{
+			RequestErrorHandlerFunc:  tlsmiddleware.RequestErrorHandler,
+			ResponseErrorHandlerFunc: tlsmiddleware.ResponseErrorHandler,
+		}), r)
+	})
 
 	srv := tlsmiddleware.NewHTTPServerWithTLSContext(router, s.log, s.cfg.Service.AgentEndpointAddress)
 
-	go func() {
-		<-ctx.Done()
-		s.log.Println("Shutdown signal received:", ctx.Err())
-		ctxTimeout, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
-		defer cancel()
-
-		srv.SetKeepAlivesEnabled(false)
-		_ = srv.Shutdown(ctxTimeout)
-	}()
-
-	s.log.Printf("Listening on %s...", s.listener.Addr().String())
-	if err := srv.Serve(s.listener); err != nil && !errors.Is(err, net.ErrClosed) {
-		return err
-	}
-
-	return nil
+	return tlsmiddleware.Serve(ctx, srv, s.listener, s.cfg.Service.GracefulShutdownTimeout(), s.log)
 }
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+)
+
+const (
+	apiPathV1alpha1 = "/api/v1/"
+	apiPathV1beta1  = "/api/v1beta1/"
+
+	// maxConvertedBodySize bounds the size of the fleets in request bodies,
+	// which are read to convert them.
+	maxConvertedBodySize = 16 * 1024 * 1024
+)
+
+// FleetConversion serves the fleets of the v1beta1 API under /api/v1beta1/fleets.
+// The handlers implement the v1alpha1 API, so the middleware converts the fleets
+// in request bodies to v1alpha1 and the fleets in response bodies back to v1beta1.
+// Other resources are the same in both versions and are passed through.
+func FleetConversion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, apiPathV1beta1) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		resourcePath := strings.TrimPrefix(r.URL.Path, apiPathV1beta1)
+		if resourcePath != "fleets" && !strings.HasPrefix(resourcePath, "fleets/") {
+			http.NotFound(w, r)
+			return
+		}
+		// a JSON patch addresses the fields of the v1alpha1 representation
+		if r.Method == http.MethodPatch {
+			writeConversionError(w, http.StatusMethodNotAllowed, "patching fleets is not supported by the v1beta1 API, use replace instead")
+			return
+		}
+
+		r.URL.Path = apiPathV1alpha1 + resourcePath
+		r.URL.RawPath = ""
+		if err := convertRequestBody(w, r); err != nil {
+			writeConversionError(w, requestBodyErrorStatus(err), err.Error())
+			return
+		}
+
+		recorder := &responseRecorder{header: http.Header{}, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		body, err := convertResponseBody(recorder.body.Bytes())
+		if err != nil {
+			writeConversionError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		for key, values := range recorder.header {
+			w.Header()[key] = values
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(recorder.statusCode)
+		_, _ = w.Write(body)
+	})
+}
+
+func convertRequestBody(w http.ResponseWriter, r *http.Request) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxConvertedBodySize))
+	if err != nil {
+		return fmt.Errorf("failed reading request body: %w", err)
+	}
+	_ = r.Body.Close()
+	if len(body) > 0 {
+		var fleet v1beta1.Fleet
+		if err := json.Unmarshal(body, &fleet); err != nil {
+			return fmt.Errorf("failed parsing fleet: %w", err)
+		}
+		if fleet.ApiVersion != v1beta1.FleetAPI {
+			return fmt.Errorf("apiVersion must be %s, got %q", v1beta1.FleetAPI, fleet.ApiVersion)
+		}
+		if errs := fleet.Validate(); len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+		converted, err := v1beta1.ConvertFleetToV1alpha1(&fleet)
+		if err != nil {
+			return err
+		}
+		if body, err = json.Marshal(converted); err != nil {
+			return fmt.Errorf("failed marshalling fleet: %w", err)
+		}
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
+
+// convertResponseBody converts the fleets in a response body, keeping other responses such as errors as they are.
+func convertResponseBody(body []byte) ([]byte, error) {
+	var object struct {
+		Kind string `json:"kind"`
+	}
+	if len(body) == 0 || json.Unmarshal(body, &object) != nil {
+		return body, nil
+	}
+
+	var converted any
+	switch object.Kind {
+	case "Fleet":
+		var fleet v1alpha1.Fleet
+		if err := json.Unmarshal(body, &fleet); err != nil {
+			return nil, fmt.Errorf("failed parsing fleet: %w", err)
+		}
+		result, err := v1beta1.ConvertFleetFromV1alpha1(&fleet)
+		if err != nil {
+			return nil, err
+		}
+		converted = result
+	case "FleetList":
+		var fleets v1alpha1.FleetList
+		if err := json.Unmarshal(body, &fleets); err != nil {
+			return nil, fmt.Errorf("failed parsing fleet list: %w", err)
+		}
+		result, err := v1beta1.ConvertFleetListFromV1alpha1(&fleets)
+		if err != nil {
+			return nil, err
+		}
+		converted = result
+	default:
+		return body, nil
+	}
+	return json.Marshal(converted)
+}
+
+func writeConversionError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v1alpha1.Error{Message: message})
+}
+
+// responseRecorder buffers the response of the handler so it can be converted.
+type responseRecorder struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+}
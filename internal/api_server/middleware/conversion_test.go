@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+var _ = Describe("Fleet conversion", func() {
+	var (
+		handler      http.Handler
+		stored       *v1alpha1.Fleet
+		requestPaths []string
+	)
+
+	newFleet := func() v1beta1.Fleet {
+		fleet := v1beta1.Fleet{
+			ApiVersion: v1beta1.FleetAPI,
+			Kind:       "Fleet",
+			Metadata:   v1alpha1.ObjectMeta{Name: lo.ToPtr("fleet")},
+		}
+		fleet.Spec.RolloutPolicy = &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr[int32](2)}
+		fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "quay.io/example/os:v1"}
+		return fleet
+	}
+
+	serve := func(method string, path string, body any) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != nil {
+			b, err := json.Marshal(body)
+			Expect(err).ToNot(HaveOccurred())
+			reader = bytes.NewReader(b)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, reader))
+		return recorder
+	}
+
+	BeforeEach(func() {
+		stored = nil
+		requestPaths = nil
+		handler = middleware.FleetConversion(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestPaths = append(requestPaths, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodPost:
+				stored = &v1alpha1.Fleet{}
+				Expect(json.NewDecoder(r.Body).Decode(stored)).To(Succeed())
+				w.WriteHeader(http.StatusCreated)
+				Expect(json.NewEncoder(w).Encode(stored)).To(Succeed())
+			case r.URL.Path == "/api/v1/fleets":
+				Expect(json.NewEncoder(w).Encode(v1alpha1.FleetList{ApiVersion: "v1alpha1", Kind: "FleetList", Items: []v1alpha1.Fleet{*stored}})).To(Succeed())
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				Expect(json.NewEncoder(w).Encode(v1alpha1.Error{Message: "not found"})).To(Succeed())
+			}
+		}))
+	})
+
+	It("stores v1beta1 fleets as v1alpha1 and serves them back", func() {
+		fleet := newFleet()
+		response := serve(http.MethodPost, "/api/v1beta1/fleets", fleet)
+		Expect(response.Code).To(Equal(http.StatusCreated))
+		Expect(requestPaths).To(Equal([]string{"/api/v1/fleets"}))
+		Expect(stored.ApiVersion).To(Equal("v1alpha1"))
+		Expect(*stored.Metadata.Annotations).To(HaveKey(v1beta1.FleetAnnotationConversion))
+
+		var created v1beta1.Fleet
+		Expect(json.Unmarshal(response.Body.Bytes(), &created)).To(Succeed())
+		Expect(created).To(Equal(fleet))
+
+		response = serve(http.MethodGet, "/api/v1beta1/fleets", nil)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		var list v1beta1.FleetList
+		Expect(json.Unmarshal(response.Body.Bytes(), &list)).To(Succeed())
+		Expect(list.ApiVersion).To(Equal(v1beta1.FleetAPI))
+		Expect(list.Items).To(Equal([]v1beta1.Fleet{fleet}))
+	})
+
+	It("passes error responses through", func() {
+		response := serve(http.MethodGet, "/api/v1beta1/fleets/missing", nil)
+		Expect(response.Code).To(Equal(http.StatusNotFound))
+		Expect(response.Body.String()).To(ContainSubstring("not found"))
+	})
+
+	It("rejects invalid v1beta1 fleets", func() {
+		fleet := newFleet()
+		fleet.Spec.RolloutPolicy.BatchSize = lo.ToPtr[int32](0)
+		response := serve(http.MethodPost, "/api/v1beta1/fleets", fleet)
+		Expect(response.Code).To(Equal(http.StatusBadRequest))
+		Expect(requestPaths).To(BeEmpty())
+
+		fleet = newFleet()
+		fleet.ApiVersion = "v1alpha1"
+		response = serve(http.MethodPost, "/api/v1beta1/fleets", fleet)
+		Expect(response.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects fleets which are too large to convert", func() {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/v1beta1/fleets", strings.NewReader(strings.Repeat(" ", 16*1024*1024+1))))
+		Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(requestPaths).To(BeEmpty())
+	})
+
+	It("rejects patches and other resources", func() {
+		Expect(serve(http.MethodPatch, "/api/v1beta1/fleets/fleet", []any{}).Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(serve(http.MethodGet, "/api/v1beta1/devices", nil).Code).To(Equal(http.StatusNotFound))
+		Expect(requestPaths).To(BeEmpty())
+	})
+
+	It("leaves the v1alpha1 API unchanged", func() {
+		response := serve(http.MethodGet, "/api/v1/fleets/missing", nil)
+		Expect(response.Code).To(Equal(http.StatusNotFound))
+		Expect(requestPaths).To(Equal([]string{"/api/v1/fleets/missing"}))
+	})
+})
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/samber/lo"
+)
+
+// ErrorResponses makes every error response of the API an Error with its
+// status, reason, type and retryability, whichever middleware or handler wrote
+// it: the errors of handlers keep their message, reason and field violations,
+// and plain-text errors, such as those of the request validator, become the
+// message of the Error. Other responses are passed through as they are.
+func ErrorResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &errorResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r)
+		if writer.statusCode >= http.StatusBadRequest {
+			WriteError(w, writer.statusCode, errorFromBody(writer.body.Bytes(), writer.statusCode))
+		}
+	})
+}
+
+// WriteError writes the error response, completing the error with the status.
+func WriteError(w http.ResponseWriter, statusCode int, apiErr v1alpha1.Error) {
+	common.CompleteError(&apiErr, statusCode)
+	body, _ := json.Marshal(apiErr)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// RequestValidationErrorHandler writes the errors of the OpenAPI request
+// validator, which are validation failures when they are bad requests.
+func RequestValidationErrorHandler(w http.ResponseWriter, message string, statusCode int) {
+	apiErr := v1alpha1.Error{Message: fmt.Sprintf("API Error: %s", message)}
+	if statusCode == http.StatusBadRequest {
+		apiErr.Reason = lo.ToPtr(v1alpha1.ErrorReasonValidationFailed)
+	}
+	WriteError(w, statusCode, apiErr)
+}
+
+// requestBodyErrorStatus returns the status of the response to a request
+// whose body failed to be read: bodies exceeding the size the middleware reads
+// are too large, others are bad requests.
+func requestBodyErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// RequestErrorHandler writes the errors of requests the strict handlers
+// cannot decode.
+func RequestErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: err.Error()})
+}
+
+// ResponseErrorHandler writes the errors the strict handlers return rather
+// than handle, with the status of the error.
+func ResponseErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	statusCode, apiErr := common.ErrorForHandlerError(err)
+	WriteError(w, statusCode, apiErr)
+}
+
+func errorFromBody(body []byte, statusCode int) v1alpha1.Error {
+	var apiErr v1alpha1.Error
+	if json.Unmarshal(body, &apiErr) != nil {
+		apiErr = v1alpha1.Error{Message: strings.TrimSpace(string(body))}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+	return apiErr
+}
+
+// errorResponseWriter buffers the body of error responses so that they can be
+// rewritten, and writes other responses through.
+type errorResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *errorResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode != 0 {
+		return
+	}
+	w.statusCode = statusCode
+	if statusCode < http.StatusBadRequest {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (w *errorResponseWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.statusCode >= http.StatusBadRequest {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *errorResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && w.statusCode < http.StatusBadRequest {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets handlers take over the connection as without the middleware.
+func (w *errorResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("the response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
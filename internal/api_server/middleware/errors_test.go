@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+)
+
+var _ = Describe("Error responses", func() {
+	serve := func(handler http.HandlerFunc) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		middleware.ErrorResponses(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/devices", nil))
+		return recorder
+	}
+
+	decode := func(recorder *httptest.ResponseRecorder) v1alpha1.Error {
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		var apiErr v1alpha1.Error
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &apiErr)).To(Succeed())
+		return apiErr
+	}
+
+	It("completes the errors of handlers, keeping their reason and field violations", func() {
+		recorder := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(v1alpha1.Error{
+				Message:         "metadata.name: Required value",
+				Reason:          lo.ToPtr(v1alpha1.ErrorReasonValidationFailed),
+				FieldViolations: &[]v1alpha1.FieldViolation{{Field: "metadata.name", Description: "Required value"}},
+			})
+		})
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		apiErr := decode(recorder)
+		Expect(apiErr.Message).To(Equal("metadata.name: Required value"))
+		Expect(*apiErr.Reason).To(Equal(v1alpha1.ErrorReasonValidationFailed))
+		Expect(*apiErr.Status).To(BeEquivalentTo(http.StatusBadRequest))
+		Expect(*apiErr.Type).To(Equal("urn:flightctl:error:ValidationFailed"))
+		Expect(*apiErr.FieldViolations).To(HaveLen(1))
+		Expect(*apiErr.Retryable).To(BeFalse())
+	})
+
+	It("turns plain-text errors into errors", func() {
+		recorder := serve(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		})
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+		apiErr := decode(recorder)
+		Expect(apiErr.Message).To(Equal("service unavailable"))
+		Expect(*apiErr.Reason).To(Equal(v1alpha1.ErrorReasonServiceUnavailable))
+		Expect(*apiErr.Retryable).To(BeTrue())
+	})
+
+	It("gives errors without a body the text of their status", func() {
+		recorder := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+		apiErr := decode(recorder)
+		Expect(apiErr.Message).To(Equal("Unauthorized"))
+		Expect(*apiErr.Reason).To(Equal(v1alpha1.ErrorReasonUnauthorized))
+	})
+
+	It("passes other responses through", func() {
+		recorder := serve(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("created"))
+		})
+		Expect(recorder.Code).To(Equal(http.StatusCreated))
+		Expect(recorder.Body.String()).To(Equal("created"))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("text/plain"))
+	})
+})
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	authcommon "github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// IdempotencyKeyHeader carries a key chosen by the client for a request,
+	// which it sends again with the retries of the request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on the responses replayed to retries.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// IdempotencyKeyRetention is how long the responses of requests with a
+	// key are replayed to their retries.
+	IdempotencyKeyRetention = 24 * time.Hour
+
+	// idempotencyKeyStaleAfter is how long a request with a key may be in
+	// progress before its key is considered abandoned, such as by a restart
+	// of the service, and can be used again.
+	idempotencyKeyStaleAfter = 10 * time.Minute
+
+	maxIdempotencyKeyLength = 255
+
+	// maxIdempotentBodySize bounds the size of the body of a request with a
+	// key, which is read to fingerprint the request.
+	maxIdempotentBodySize = 16 * 1024 * 1024
+)
+
+// IdempotencyKeys serves the create and action requests sent with an
+// Idempotency-Key header at most once, so that clients can retry them safely:
+// the response of the first request with a key is recorded, and replayed to
+// the later requests with the key instead of serving them again. A request
+// with a key which is still in progress is rejected as a retryable conflict,
+// and one with a key already used for another request is rejected.
+//
+// The keys are scoped to the credentials of the client, and the responses of
+// requests which failed on the service side or were rate limited are not
+// recorded, so that their retries are served again.
+func IdempotencyKeys(keys store.IdempotencyKey, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPut && r.Method != http.MethodPatch) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				WriteError(w, http.StatusBadRequest, v1alpha1.Error{
+					Message: fmt.Sprintf("the %s header must not be longer than %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength),
+				})
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				WriteError(w, requestBodyErrorStatus(err), v1alpha1.Error{Message: fmt.Sprintf("failed to read the request: %v", err)})
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// the key stays reserved if the client goes away while it is served
+			ctx := context.WithoutCancel(r.Context())
+			now := time.Now()
+			record := &model.IdempotencyKey{
+				Scope:       idempotencyScope(r),
+				Key:         key,
+				Fingerprint: idempotencyFingerprint(r, body),
+				ExpiresAt:   now.Add(IdempotencyKeyRetention),
+			}
+			existing, err := keys.Reserve(ctx, store.NullOrgId, record, now.Add(-idempotencyKeyStaleAfter))
+			if err != nil {
+				log.Errorf("failed to reserve idempotency key: %v", err)
+				WriteError(w, http.StatusServiceUnavailable, v1alpha1.Error{Message: fmt.Sprintf("failed to reserve the %s", IdempotencyKeyHeader)})
+				return
+			}
+			if existing != nil {
+				replayIdempotentResponse(w, record, existing)
+				return
+			}
+
+			recorder := &idempotentResponseWriter{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+			if recorder.statusCode == 0 {
+				recorder.statusCode = http.StatusOK
+			}
+			if recorder.statusCode >= http.StatusInternalServerError || recorder.statusCode == http.StatusTooManyRequests {
+				if err := keys.Release(ctx, store.NullOrgId, record.Scope, record.Key); err != nil {
+					log.Errorf("failed to release idempotency key: %v", err)
+				}
+				return
+			}
+			record.StatusCode = recorder.statusCode
+			record.ContentType = recorder.Header().Get("Content-Type")
+			record.Body = recorder.body.Bytes()
+			if err := keys.Complete(ctx, store.NullOrgId, record); err != nil {
+				log.Errorf("failed to record the response of idempotency key: %v", err)
+			}
+		})
+	}
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, record *model.IdempotencyKey, existing *model.IdempotencyKey) {
+	switch {
+	case existing.Fingerprint != record.Fingerprint:
+		WriteError(w, http.StatusUnprocessableEntity, v1alpha1.Error{
+			Message: fmt.Sprintf("the %s was already used for another request", IdempotencyKeyHeader),
+			Reason:  lo.ToPtr(v1alpha1.ErrorReasonIdempotencyKeyReused),
+		})
+	case existing.StatusCode == 0:
+		WriteError(w, http.StatusConflict, v1alpha1.Error{
+			Message: fmt.Sprintf("a request with the %s is in progress", IdempotencyKeyHeader),
+			Reason:  lo.ToPtr(v1alpha1.ErrorReasonIdempotencyKeyInProgress),
+		})
+	default:
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(existing.StatusCode)
+		_, _ = w.Write(existing.Body)
+	}
+}
+
+// idempotencyScope returns a hash of the credentials of the request.
+func idempotencyScope(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.Header.Get(authcommon.AuthHeader)))
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyFingerprint returns a hash of the method, URL and body of the
+// request, which its retries must match.
+func idempotencyFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotentResponseWriter writes the response through, keeping its status
+// and a copy of its body.
+type idempotentResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *idempotentResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *idempotentResponseWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,155 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// idempotencyKeys keeps the idempotency keys in memory.
+type idempotencyKeys struct {
+	store.IdempotencyKey
+	records map[string]model.IdempotencyKey
+}
+
+func (k *idempotencyKeys) Reserve(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey, staleBefore time.Time) (*model.IdempotencyKey, error) {
+	if existing, ok := k.records[record.Scope+"/"+record.Key]; ok {
+		return &existing, nil
+	}
+	k.records[record.Scope+"/"+record.Key] = *record
+	return nil, nil
+}
+
+func (k *idempotencyKeys) Complete(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey) error {
+	if _, ok := k.records[record.Scope+"/"+record.Key]; !ok {
+		return flterrors.ErrNoRowsUpdated
+	}
+	k.records[record.Scope+"/"+record.Key] = *record
+	return nil
+}
+
+func (k *idempotencyKeys) Release(ctx context.Context, orgId uuid.UUID, scope string, key string) error {
+	delete(k.records, scope+"/"+key)
+	return nil
+}
+
+var _ = Describe("Idempotency keys", func() {
+	var (
+		keys    *idempotencyKeys
+		served  int
+		status  int
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		keys = &idempotencyKeys{records: map[string]model.IdempotencyKey{}}
+		served = 0
+		status = http.StatusCreated
+		handler = middleware.IdempotencyKeys(keys, log.InitLogs())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"metadata":{"name":"er-1"}}`))
+		}))
+	})
+
+	send := func(method string, key string, token string, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, "/api/v1/enrollmentrequests", strings.NewReader(body))
+		if key != "" {
+			request.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	reason := func(recorder *httptest.ResponseRecorder) v1alpha1.ErrorReason {
+		var apiErr v1alpha1.Error
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &apiErr)).To(Succeed())
+		return *apiErr.Reason
+	}
+
+	It("replays the response of a request to its retries", func() {
+		first := send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		Expect(first.Code).To(Equal(http.StatusCreated))
+		Expect(first.Header().Get(middleware.IdempotentReplayedHeader)).To(BeEmpty())
+
+		retry := send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		Expect(retry.Code).To(Equal(http.StatusCreated))
+		Expect(retry.Body.String()).To(Equal(first.Body.String()))
+		Expect(retry.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(retry.Header().Get(middleware.IdempotentReplayedHeader)).To(Equal("true"))
+		Expect(served).To(Equal(1))
+	})
+
+	It("serves requests without a key and reads every time", func() {
+		send(http.MethodPost, "", "token", `{"spec":{}}`)
+		send(http.MethodPost, "", "token", `{"spec":{}}`)
+		send(http.MethodGet, "key-1", "token", "")
+		send(http.MethodGet, "key-1", "token", "")
+		Expect(served).To(Equal(4))
+		Expect(keys.records).To(BeEmpty())
+	})
+
+	It("rejects a key used for another request", func() {
+		send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		recorder := send(http.MethodPost, "key-1", "token", `{"spec":{"csr":"other"}}`)
+		Expect(recorder.Code).To(Equal(http.StatusUnprocessableEntity))
+		Expect(reason(recorder)).To(Equal(v1alpha1.ErrorReasonIdempotencyKeyReused))
+		Expect(served).To(Equal(1))
+	})
+
+	It("rejects the retries of a request in progress as retryable", func() {
+		send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		for scope, record := range keys.records {
+			record.StatusCode = 0
+			keys.records[scope] = record
+		}
+		recorder := send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		Expect(recorder.Code).To(Equal(http.StatusConflict))
+		Expect(reason(recorder)).To(Equal(v1alpha1.ErrorReasonIdempotencyKeyInProgress))
+	})
+
+	It("scopes the keys to the credentials of the client", func() {
+		send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		recorder := send(http.MethodPost, "key-1", "other-token", `{"spec":{}}`)
+		Expect(recorder.Header().Get(middleware.IdempotentReplayedHeader)).To(BeEmpty())
+		Expect(served).To(Equal(2))
+	})
+
+	It("serves the retries of a request which failed on the service side", func() {
+		status = http.StatusServiceUnavailable
+		send(http.MethodPost, "key-1", "token", `{"spec":{}}`)
+		Expect(keys.records).To(BeEmpty())
+		status = http.StatusCreated
+		Expect(send(http.MethodPost, "key-1", "token", `{"spec":{}}`).Code).To(Equal(http.StatusCreated))
+		Expect(served).To(Equal(2))
+	})
+
+	It("rejects bodies which are too large to fingerprint", func() {
+		recorder := send(http.MethodPost, "key-1", "token", strings.Repeat(" ", 16*1024*1024+1))
+		Expect(recorder.Code).To(Equal(http.StatusRequestEntityTooLarge))
+		Expect(served).To(Equal(0))
+		Expect(keys.records).To(BeEmpty())
+	})
+
+	It("rejects keys which are too long", func() {
+		recorder := send(http.MethodPost, strings.Repeat("k", 256), "token", `{"spec":{}}`)
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(served).To(Equal(0))
+	})
+})
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// ForwardedClientCertHeader carries the URL-escaped PEM chain of the client
+// certificate a device connected to a relay with, leaf first, on the requests
+// the relay forwards for the device.
+const ForwardedClientCertHeader = "X-Forwarded-Client-Cert"
+
+// maxRelayedBodySize bounds the size of the body of a forwarded request, which
+// is read to verify the signature of the device.
+const maxRelayedBodySize = 16 * 1024 * 1024
+
+// RelayAuthorizer checks that the relay with the common name may act for the
+// device with the name, such as by checking that the device is at the site of
+// the relay.
+type RelayAuthorizer func(ctx context.Context, relayCommonName string, deviceName string) error
+
+// RelayedClientCertificates lets the relays forward the requests of the
+// devices of their sites: the requests of a trusted relay carrying the client
+// certificate of a device are served as if the device had connected with it,
+// once the certificate is verified against the client CAs, the request is
+// verified to be signed by the key of the certificate, and the relay is
+// authorized to act for the device. The header is ignored on the requests of
+// clients which are not trusted relays.
+func RelayedClientCertificates(relayCommonNames []string, clientCAs *x509.CertPool, authorize RelayAuthorizer, log logrus.FieldLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwarded := r.Header.Get(ForwardedClientCertHeader)
+			if forwarded == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Header.Del(ForwardedClientCertHeader)
+			cn, _ := r.Context().Value(TLSCommonNameContextKey).(string)
+			if !lo.Contains(relayCommonNames, cn) {
+				log.Warningf("ignoring the client certificate forwarded by %q, which is not a trusted relay", cn)
+				next.ServeHTTP(w, r)
+				return
+			}
+			chain, err := url.QueryUnescape(forwarded)
+			if err != nil {
+				WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: fmt.Sprintf("invalid %s header: %v", ForwardedClientCertHeader, err)})
+				return
+			}
+			cert, err := VerifyClientCertificate(chain, clientCAs)
+			if err != nil {
+				log.Warningf("relay %q forwarded an invalid client certificate: %v", cn, err)
+				WriteError(w, http.StatusUnauthorized, v1alpha1.Error{Message: fmt.Sprintf("the forwarded client certificate is invalid: %v", err)})
+				return
+			}
+			if err := verifyRelayedRequest(w, r, cert); err != nil {
+				log.Warningf("relay %q forwarded a request of %q which the device did not sign: %v", cn, cert.Subject.CommonName, err)
+				WriteError(w, http.StatusUnauthorized, v1alpha1.Error{Message: fmt.Sprintf("the forwarded request is not signed by the device: %v", err)})
+				return
+			}
+			// the certificates of devices not enrolled yet grant no access to a device
+			if name, ok := strings.CutPrefix(cert.Subject.CommonName, crypto.DeviceCommonNamePrefix); ok {
+				if err := authorize(r.Context(), cn, name); err != nil {
+					log.Warningf("relay %q may not act for device %q: %v", cn, name, err)
+					WriteError(w, http.StatusForbidden, v1alpha1.Error{Message: fmt.Sprintf("the relay may not act for the device: %v", err)})
+					return
+				}
+			}
+			ctx := context.WithValue(r.Context(), TLSCommonNameContextKey, cert.Subject.CommonName)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// verifyRelayedRequest checks that a forwarded request is signed by the key
+// of the client certificate of the device, and restores its body.
+func verifyRelayedRequest(w http.ResponseWriter, r *http.Request, cert *x509.Certificate) error {
+	signature, signedAt, err := RequestSignature(r.Header)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRelayedBodySize))
+	if err != nil {
+		return fmt.Errorf("reading the body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return crypto.VerifyRequestSignature(cert, r.Method, r.URL.Path, signedAt, body, signature)
+}
+
+// RequestSignature returns the signature of a request by a device and the
+// time it was signed at, from the headers of the request.
+func RequestSignature(header http.Header) ([]byte, time.Time, error) {
+	signature, err := base64.StdEncoding.DecodeString(header.Get(crypto.DeviceSignatureHeader))
+	if err != nil || len(signature) == 0 {
+		return nil, time.Time{}, fmt.Errorf("missing or invalid %s header", crypto.DeviceSignatureHeader)
+	}
+	seconds, err := strconv.ParseInt(header.Get(crypto.DeviceSignatureTimeHeader), 10, 64)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("missing or invalid %s header", crypto.DeviceSignatureTimeHeader)
+	}
+	return signature, time.Unix(seconds, 0), nil
+}
+
+// VerifyClientCertificate verifies a PEM chain of a client certificate, leaf
+// first, against the client CAs as a TLS handshake would, and returns its
+// leaf.
+func VerifyClientCertificate(chain string, clientCAs *x509.CertPool) (*x509.Certificate, error) {
+	certs, err := crypto.ParseCertificates([]byte(chain))
+	if err != nil {
+		return nil, err
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return certs[0], nil
+}
@@ -0,0 +1,148 @@
+package middleware_test
+
+import (
+	"context"
+	gocrypto "crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Relayed client certificates", func() {
+	const (
+		relayCN       = "device:fedcba9876543210"
+		relayedDevice = "0123456789abcdef"
+		path          = "/api/v1/devices/" + relayedDevice + "/status"
+	)
+
+	var (
+		ca         *crypto.CA
+		served     string
+		servedBody string
+		// sites holds the devices the relay may act for
+		sites   map[string]bool
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir := GinkgoT().TempDir()
+		ca, err = crypto.MakeSelfSignedCA(filepath.Join(tempDir, "ca.crt"), filepath.Join(tempDir, "ca.key"), "", "ca", 1)
+		Expect(err).ToNot(HaveOccurred())
+		clientCAs := x509.NewCertPool()
+		for _, cert := range ca.Config.Certs {
+			clientCAs.AddCert(cert)
+		}
+		served = ""
+		servedBody = ""
+		sites = map[string]bool{relayedDevice: true}
+		authorize := func(ctx context.Context, relayCommonName string, name string) error {
+			Expect(relayCommonName).To(Equal(relayCN))
+			if !sites[name] {
+				return errors.New("the device is at another site")
+			}
+			return nil
+		}
+		handler = middleware.RelayedClientCertificates([]string{relayCN}, clientCAs, authorize, log.InitLogs())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served, _ = r.Context().Value(middleware.TLSCommonNameContextKey).(string)
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			servedBody = string(body)
+			Expect(r.Header.Get(middleware.ForwardedClientCertHeader)).To(BeEmpty())
+		}))
+	})
+
+	issue := func(ca *crypto.CA, cn string) (string, gocrypto.Signer) {
+		cert, keyPEM, err := ca.IssueClientCertificate(cn, 3600)
+		Expect(err).ToNot(HaveOccurred())
+		key, err := crypto.ParseKeyPEM(keyPEM)
+		Expect(err).ToNot(HaveOccurred())
+		return string(cert), key.(gocrypto.Signer)
+	}
+
+	request := func(cn string, forwarded string, signer gocrypto.Signer) *httptest.ResponseRecorder {
+		const body = `{"status":{}}`
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.TLSCommonNameContextKey, cn))
+		if forwarded != "" {
+			req.Header.Set(middleware.ForwardedClientCertHeader, url.QueryEscape(forwarded))
+		}
+		if signer != nil {
+			signedAt := time.Now()
+			signature, err := crypto.SignRequest(signer, http.MethodPut, path, signedAt, []byte(body))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set(crypto.DeviceSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+			req.Header.Set(crypto.DeviceSignatureTimeHeader, strconv.FormatInt(signedAt.Unix(), 10))
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	It("serves the requests of a relay as the device it forwards the certificate of", func() {
+		cert, signer := issue(ca, "device:"+relayedDevice)
+
+		Expect(request(relayCN, cert, signer).Code).To(Equal(http.StatusOK))
+		Expect(served).To(Equal("device:" + relayedDevice))
+		Expect(servedBody).To(Equal(`{"status":{}}`))
+	})
+
+	It("serves the requests of a relay without a forwarded certificate as the relay", func() {
+		Expect(request(relayCN, "", nil).Code).To(Equal(http.StatusOK))
+		Expect(served).To(Equal(relayCN))
+	})
+
+	It("ignores the certificates forwarded by clients which are not relays", func() {
+		cert, signer := issue(ca, "device:"+relayedDevice)
+
+		Expect(request("device:0011223344556677", cert, signer).Code).To(Equal(http.StatusOK))
+		Expect(served).To(Equal("device:0011223344556677"))
+	})
+
+	It("refuses the certificates which the client CAs did not issue", func() {
+		other, err := crypto.MakeSelfSignedCA(filepath.Join(GinkgoT().TempDir(), "ca.crt"), filepath.Join(GinkgoT().TempDir(), "ca.key"), "", "other", 1)
+		Expect(err).ToNot(HaveOccurred())
+		cert, signer := issue(other, "device:"+relayedDevice)
+
+		Expect(request(relayCN, cert, signer).Code).To(Equal(http.StatusUnauthorized))
+		Expect(served).To(BeEmpty())
+	})
+
+	It("refuses the requests which the device did not sign", func() {
+		cert, _ := issue(ca, "device:"+relayedDevice)
+		_, otherSigner := issue(ca, "device:"+relayedDevice)
+
+		Expect(request(relayCN, cert, nil).Code).To(Equal(http.StatusUnauthorized))
+		Expect(request(relayCN, cert, otherSigner).Code).To(Equal(http.StatusUnauthorized))
+		Expect(served).To(BeEmpty())
+	})
+
+	It("refuses the requests of the devices which the relay may not act for", func() {
+		cert, signer := issue(ca, "device:"+relayedDevice)
+		sites[relayedDevice] = false
+
+		Expect(request(relayCN, cert, signer).Code).To(Equal(http.StatusForbidden))
+		Expect(served).To(BeEmpty())
+	})
+
+	It("serves the requests of devices not enrolled yet without a site", func() {
+		cert, signer := issue(ca, crypto.ClientBootstrapCommonName)
+
+		Expect(request(relayCN, cert, signer).Code).To(Equal(http.StatusOK))
+		Expect(served).To(Equal(crypto.ClientBootstrapCommonName))
+	})
+})
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Serve serves the connections of the listener until the context is canceled,
+// then shuts the server down gracefully: it stops accepting connections,
+// closes the idle ones and waits up to the timeout for the requests in flight,
+// such as status updates of agents, to complete. It returns once the server
+// is shut down.
+func Serve(ctx context.Context, srv *http.Server, listener net.Listener, timeout time.Duration, log logrus.FieldLogger) error {
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		log.Println("Shutdown signal received:", ctx.Err())
+		ctxTimeout, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		srv.SetKeepAlivesEnabled(false)
+		if err := srv.Shutdown(ctxTimeout); err != nil {
+			log.Warnf("closing the connections still active after %s: %v", timeout, err)
+			_ = srv.Close()
+		}
+	}()
+
+	log.Printf("Listening on %s...", listener.Addr().String())
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, net.ErrClosed) && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-shutdown
+	return nil
+}
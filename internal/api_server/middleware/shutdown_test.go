@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/pkg/log"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graceful shutdown", func() {
+	var (
+		listener net.Listener
+		release  chan struct{}
+		received chan struct{}
+		srv      *http.Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.Listen("tcp", "localhost:0")
+		Expect(err).ToNot(HaveOccurred())
+		release = make(chan struct{})
+		received = make(chan struct{})
+		srv = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(received)
+			<-release
+			_, _ = w.Write([]byte("done"))
+		})}
+	})
+
+	serve := func(ctx context.Context, timeout time.Duration) chan error {
+		served := make(chan error, 1)
+		go func() {
+			served <- middleware.Serve(ctx, srv, listener, timeout, log.InitLogs())
+		}()
+		return served
+	}
+
+	get := func() chan string {
+		responses := make(chan string, 1)
+		go func() {
+			defer GinkgoRecover()
+			resp, err := http.Get("http://" + listener.Addr().String())
+			if err != nil {
+				responses <- err.Error()
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			responses <- string(body)
+		}()
+		return responses
+	}
+
+	It("completes the requests in flight before returning", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		served := serve(ctx, time.Minute)
+		responses := get()
+		Eventually(received).Should(BeClosed())
+
+		cancel()
+		// new connections are refused while the request in flight completes
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).Should(HaveOccurred())
+		Consistently(served, 100*time.Millisecond).ShouldNot(Receive())
+
+		close(release)
+		Eventually(responses).Should(Receive(Equal("done")))
+		Eventually(served).Should(Receive(BeNil()))
+	})
+
+	It("closes the connections still active after the timeout", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		served := serve(ctx, 100*time.Millisecond)
+		responses := get()
+		Eventually(received).Should(BeClosed())
+
+		cancel()
+		Eventually(served).Should(Receive(BeNil()))
+		Eventually(responses).Should(Receive(ContainSubstring("EOF")))
+		close(release)
+	})
+})
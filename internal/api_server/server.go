@@ -2,32 +2,28 @@ package apiserver
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net"
-	"net/http"
-	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	tlsmiddleware "github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/artifacts"
 	"github.com/flightctl/flightctl/internal/auth"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 	"github.com/flightctl/flightctl/internal/service"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/flightctl/flightctl/pkg/queues"
+	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	oapimiddleware "github.com/oapi-codegen/nethttp-middleware"
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	gracefulShutdownTimeout = 5 * time.Second
-)
-
 type Server struct {
 	log      logrus.FieldLogger
 	cfg      *config.Config
@@ -56,10 +52,6 @@ func New(
 	}
 }
 
-func oapiErrorHandler(w http.ResponseWriter, message string, statusCode int) {
-	http.Error(w, fmt.Sprintf("API Error: %s", message), statusCode)
-}
-
 func (s *Server) Run(ctx context.Context) error {
 	s.log.Println("Initializing async jobs")
 	publisher, err := tasks.TaskQueuePublisher(s.provider)
@@ -75,9 +67,11 @@ func (s *Server) Run(ctx context.Context) error {
 	}
 	// Skip server name validation
 	swagger.Servers = nil
+	// Metrics pushed by devices are passed through as opaque remote-write payloads
+	openapi3filter.RegisterBodyDecoder(remotewrite.ContentType, openapi3filter.FileBodyDecoder)
 
 	oapiOpts := oapimiddleware.Options{
-		ErrorHandler: oapiErrorHandler,
+		ErrorHandler: tlsmiddleware.RequestValidationErrorHandler,
 	}
 
 	authMiddleware, err := auth.CreateAuthMiddleware(s.cfg, s.log)
@@ -89,32 +83,43 @@ func (s *Server) Run(ctx context.Context) error {
 	router.Use(
 		middleware.RequestID,
 		middleware.Logger,
+		tlsmiddleware.ErrorResponses,
 		middleware.Recoverer,
 		authMiddleware,
+		tlsmiddleware.FleetConversion,
 		oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapiOpts),
+		tlsmiddleware.IdempotencyKeys(s.store.IdempotencyKey(), s.log),
 	)
 
-	h := service.NewServiceHandler(s.store, callbackManager, s.ca, s.log, s.cfg.Service.BaseAgentGrpcUrl, s.cfg.Service.BaseAgentEndpointUrl, s.cfg.Service.BaseUIUrl)
-	server.HandlerFromMux(server.NewStrictHandler(h, nil), router)
-
-	srv := tlsmiddleware.NewHTTPServer(router, s.log, s.cfg.Service.Address)
-
-	go func() {
-		<-ctx.Done()
-		s.log.Println("Shutdown signal received:", ctx.Err())
-		ctxTimeout, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
-		defer cancel()
-
-		srv.SetKeepAlivesEnabled(false)
-		_ = srv.Shutdown(ctxTimeout)
-		s.provider.Stop()
-		s.provider.Wait()
-	}()
+	var metricsForwarder *remotewrite.Forwarder
+	if s.cfg.Metrics != nil && s.cfg.Metrics.RemoteWriteUrl != "" {
+		metricsForwarder = remotewrite.NewForwarder(s.cfg.Metrics.RemoteWriteUrl, s.log)
+	}
 
-	s.log.Printf("Listening on %s...", s.listener.Addr().String())
-	if err := srv.Serve(s.listener); err != nil && !errors.Is(err, net.ErrClosed) {
+	artifactStore, err := artifacts.New(s.cfg)
+	if err != nil {
+		return err
+	}
+	urlSigner, err := artifacts.NewURLSigner(s.cfg)
+	if err != nil {
 		return err
 	}
 
-	return nil
+	h := service.NewServiceHandler(s.store, callbackManager, metricsForwarder, s.ca, s.log, s.cfg.Service.BaseAgentGrpcUrl, s.cfg.Service.BaseAgentEndpointUrl, s.cfg.Service.BaseUIUrl, artifactStore, urlSigner)
+	h.SetRequireImageDigests(s.cfg.Service.RequireImageDigests)
+	h.SetTrashRetention(s.cfg.Service.TrashRetentionPeriod())
+	h.SetLabelSchemas(s.cfg.Service.LabelSchemas)
+	h.SetConsoleGrants(s.cfg.Service.ConsoleGrants)
+	h.SetExecPolicies(s.cfg.Service.ExecPolicies)
+	h.SetTunnels(s.cfg.Service.Tunnels)
+	h.SetDuplicateEnrollmentPolicy(s.cfg.Service.DuplicateEnrollment())
+	auth.SetProvisioningTokenValidator(h)
+	server.HandlerFromMux(server.NewStrictHandlerWithOptions(h, nil, server.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc:  tlsmiddleware.RequestErrorHandler,
+		ResponseErrorHandlerFunc: tlsmiddleware.ResponseErrorHandler,
+	}), router)
+
+	srv := tlsmiddleware.NewHTTPServer(router, s.log, s.cfg.Service.Address)
+
+	return tlsmiddleware.Serve(ctx, srv, s.listener, s.cfg.Service.GracefulShutdownTimeout(), s.log)
 }
@@ -0,0 +1,99 @@
+// Package artifacts stores the large files produced by the service, such as
+// fleet reports, in an S3-compatible object storage or a directory rather than
+// in the database.
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+)
+
+// KindReports is the kind of the scheduled fleet reports. The kind of an
+// artifact is the first segment of its key, which retention policies refer to.
+const KindReports = "reports"
+
+// KindInventory is the kind of the CSV exports of the inventory of the devices.
+const KindInventory = "inventory"
+
+var ErrNotFound = errors.New("artifact not found")
+
+// Object describes a stored artifact.
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// Store is a backend storing artifacts by key. Keys are slash-separated paths
+// whose first segment is the kind of the artifact.
+type Store interface {
+	// Put stores body as the artifact key, replacing any existing artifact.
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	// Get returns the content of the artifact key, which the caller must close.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// List returns the artifacts whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete removes the artifact key.
+	Delete(ctx context.Context, key string) error
+}
+
+// New returns the artifact storage configured for the service, or nil if none
+// is configured.
+func New(cfg *config.Config) (Store, error) {
+	if cfg.Artifacts == nil {
+		return nil, nil
+	}
+	switch {
+	case cfg.Artifacts.S3 != nil:
+		s3 := cfg.Artifacts.S3
+		return NewS3Store(s3.Endpoint, s3.Region, s3.Bucket, s3.AccessKeyID, s3.SecretAccessKeyFile), nil
+	case cfg.Artifacts.Filesystem != nil:
+		return NewFilesystemStore(cfg.Artifacts.Filesystem.Path), nil
+	default:
+		return nil, fmt.Errorf("no artifact storage backend configured")
+	}
+}
+
+// Retention returns how long the artifacts of each kind are kept. Kinds
+// without a policy are kept until they are deleted.
+func Retention(cfg *config.Config) (map[string]time.Duration, error) {
+	retention := map[string]time.Duration{}
+	if cfg.Artifacts == nil {
+		return retention, nil
+	}
+	for kind, value := range cfg.Artifacts.Retention {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention of %s: %w", kind, err)
+		}
+		retention[kind] = d
+	}
+	return retention, nil
+}
+
+// ValidateKey checks that key is a clean relative path, so that it can neither
+// escape the directory of the filesystem store nor alias another key.
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." || key == "." {
+		return fmt.Errorf("invalid artifact key %q", key)
+	}
+	return nil
+}
+
+// contentTypeOf guesses the media type of an artifact from the extension of
+// its key, for backends which do not record it.
+func contentTypeOf(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,53 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	store := NewFilesystemStore(t.TempDir())
+
+	objects, err := store.List(ctx, "")
+	require.NoError(err)
+	require.Empty(objects)
+
+	require.NoError(store.Put(ctx, "reports/edge/1.json", []byte(`{"a":1}`), "application/json"))
+	require.NoError(store.Put(ctx, "reports/edge/1.json", []byte(`{}`), "application/json"))
+	require.NoError(store.Put(ctx, "recordings/device.cast", []byte(`x`), ""))
+
+	body, object, err := store.Get(ctx, "reports/edge/1.json")
+	require.NoError(err)
+	content, err := io.ReadAll(body)
+	require.NoError(err)
+	body.Close()
+	require.Equal(`{}`, string(content))
+	require.Equal(int64(2), object.Size)
+	require.Equal("application/json", object.ContentType)
+
+	objects, err = store.List(ctx, "reports/")
+	require.NoError(err)
+	require.Len(objects, 1)
+	require.Equal("reports/edge/1.json", objects[0].Key)
+
+	require.NoError(store.Delete(ctx, "reports/edge/1.json"))
+	_, _, err = store.Get(ctx, "reports/edge/1.json")
+	require.ErrorIs(err, ErrNotFound)
+	require.ErrorIs(store.Delete(ctx, "reports/edge/1.json"), ErrNotFound)
+	_, _, err = store.Get(ctx, "reports/edge")
+	require.ErrorIs(err, ErrNotFound)
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"reports/edge/1.json", "a", "a/b..c"} {
+		require.NoError(t, ValidateKey(key), key)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../secret", "..", ".", "reports/../../secret", "reports//1.json", "reports/"} {
+		require.Error(t, ValidateKey(key), key)
+	}
+}
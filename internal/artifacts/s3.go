@@ -0,0 +1,194 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	defaultRegion  = "us-east-1"
+	requestTimeout = 30 * time.Second
+)
+
+// S3Store stores artifacts in a bucket of an S3-compatible object storage, such
+// as AWS S3 or MinIO, signing its requests with AWS signature version 4. The
+// bucket is addressed in the path of the endpoint.
+type S3Store struct {
+	client              *http.Client
+	endpoint            string
+	region              string
+	bucket              string
+	accessKeyID         string
+	secretAccessKeyFile string
+}
+
+var _ Store = (*S3Store)(nil)
+
+func NewS3Store(endpoint, region, bucket, accessKeyID, secretAccessKeyFile string) *S3Store {
+	if region == "" {
+		region = defaultRegion
+	}
+	return &S3Store{
+		client:              &http.Client{Timeout: requestTimeout},
+		endpoint:            strings.TrimSuffix(endpoint, "/"),
+		region:              region,
+		bucket:              bucket,
+		accessKeyID:         accessKeyID,
+		secretAccessKeyFile: secretAccessKeyFile,
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, s.objectURL(key), body, func(h http.Header) { h.Set("Content-Type", contentType) })
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(key), nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, nil, err
+	}
+	object := &Object{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		object.ModifiedAt = modified
+	}
+	return resp.Body, object, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string) ([]Object, error) {
+	objects := []Object{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, http.MethodGet, fmt.Sprintf("%s/%s?%s", s.endpoint, url.PathEscape(s.bucket), query.Encode()), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = checkResponse(resp)
+		if err == nil {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listing objects: %w", err)
+		}
+
+		for _, content := range result.Contents {
+			objects = append(objects, Object{
+				Key:         content.Key,
+				Size:        content.Size,
+				ContentType: contentTypeOf(content.Key),
+				ModifiedAt:  content.LastModified,
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(key), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (s *S3Store) objectURL(key string) string {
+	return fmt.Sprintf("%s/%s/%s", s.endpoint, url.PathEscape(s.bucket), escapeKey(key))
+}
+
+// do sends a request signed with the access key of the store.
+func (s *S3Store) do(ctx context.Context, method string, requestURL string, body []byte, setHeaders func(http.Header)) (*http.Response, error) {
+	// the secret is read on each request so that it can be rotated
+	secret, err := os.ReadFile(s.secretAccessKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading secret access key: %w", err)
+	}
+	signer := v4.NewSigner(credentials.NewStaticCredentials(s.accessKeyID, strings.TrimSpace(string(secret)), ""))
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if setHeaders != nil {
+		setHeaders(req.Header)
+	}
+	if _, err := signer.Sign(req, bytes.NewReader(body), "s3", s.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("signing request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	return resp, nil
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response status %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// escapeKey escapes the segments of an object key, keeping its slashes.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,101 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Put(t *testing.T) {
+	require := require.New(t)
+
+	var method, path, contentType, authorization string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.EscapedPath()
+		contentType = r.Header.Get("Content-Type")
+		authorization = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(os.WriteFile(secretFile, []byte("secret\n"), 0600))
+	store := NewS3Store(server.URL+"/", "", "reports", "AKID", secretFile)
+
+	require.NoError(store.Put(context.Background(), "daily/fleet a/1.json", []byte(`{}`), "application/json"))
+	require.Equal(http.MethodPut, method)
+	require.Equal("/reports/daily/fleet%20a/1.json", path)
+	require.Equal("application/json", contentType)
+	require.Equal(`{}`, string(body))
+	require.True(strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/"), authorization)
+	require.Contains(authorization, "/us-east-1/s3/aws4_request")
+}
+
+func TestS3PutFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret"), 0600))
+	store := NewS3Store(server.URL, "eu-west-1", "reports", "AKID", secretFile)
+
+	err := store.Put(context.Background(), "report.json", []byte(`{}`), "application/json")
+	require.ErrorContains(t, err, "AccessDenied")
+
+	store = NewS3Store(server.URL, "", "reports", "AKID", filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, store.Put(context.Background(), "report.json", []byte(`{}`), "application/json"))
+}
+
+func TestS3GetAndList(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/artifacts/reports/edge/1.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 06:00:30 GMT")
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/artifacts" && r.URL.Query().Get("continuation-token") == "":
+			require.Equal("reports/", r.URL.Query().Get("prefix"))
+			_, _ = w.Write([]byte(`<ListBucketResult><Contents><Key>reports/edge/1.json</Key><Size>2</Size><LastModified>2026-10-14T06:00:30.000Z</LastModified></Contents><IsTruncated>true</IsTruncated><NextContinuationToken>next</NextContinuationToken></ListBucketResult>`))
+		case r.URL.Path == "/artifacts":
+			_, _ = w.Write([]byte(`<ListBucketResult><Contents><Key>reports/edge/2.json</Key><Size>3</Size><LastModified>2026-10-15T06:00:30.000Z</LastModified></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`))
+		default:
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(os.WriteFile(secretFile, []byte("secret"), 0600))
+	store := NewS3Store(server.URL, "", "artifacts", "AKID", secretFile)
+
+	body, object, err := store.Get(context.Background(), "reports/edge/1.json")
+	require.NoError(err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	require.Equal(`{}`, string(content))
+	require.Equal("application/json", object.ContentType)
+	require.Equal(int64(2), object.Size)
+	require.Equal(2026, object.ModifiedAt.Year())
+
+	_, _, err = store.Get(context.Background(), "reports/edge/missing.json")
+	require.ErrorIs(err, ErrNotFound)
+
+	objects, err := store.List(context.Background(), "reports/")
+	require.NoError(err)
+	require.Len(objects, 2)
+	require.Equal("reports/edge/2.json", objects[1].Key)
+	require.Equal("application/json", objects[1].ContentType)
+}
@@ -0,0 +1,100 @@
+package artifacts
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+)
+
+// DefaultDownloadURLExpiration is how long signed download URLs are valid
+// unless the configuration sets another expiration.
+const DefaultDownloadURLExpiration = time.Hour
+
+// DownloadPath is the path of the endpoint serving signed download URLs, which
+// authenticates requests by their signature rather than by a token.
+const DownloadPath = "/api/v1/artifacts/download"
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrURLExpired       = errors.New("download URL expired")
+)
+
+// URLSigner creates and verifies the signed URLs which download an artifact
+// without further authentication until they expire.
+type URLSigner struct {
+	baseURL        string
+	signingKeyFile string
+	expiration     time.Duration
+}
+
+// NewURLSigner returns the signer of the download URLs configured for the
+// service, or nil if no signing key is configured.
+func NewURLSigner(cfg *config.Config) (*URLSigner, error) {
+	if cfg.Artifacts == nil || cfg.Artifacts.SigningKeyFile == "" {
+		return nil, nil
+	}
+	expiration := DefaultDownloadURLExpiration
+	if cfg.Artifacts.DownloadUrlExpiration != "" {
+		var err error
+		if expiration, err = time.ParseDuration(cfg.Artifacts.DownloadUrlExpiration); err != nil {
+			return nil, fmt.Errorf("invalid download URL expiration: %w", err)
+		}
+	}
+	return &URLSigner{
+		baseURL:        strings.TrimSuffix(cfg.Service.BaseUrl, "/"),
+		signingKeyFile: cfg.Artifacts.SigningKeyFile,
+		expiration:     expiration,
+	}, nil
+}
+
+// Sign returns the download URL of the artifact key and the time it expires at.
+func (s *URLSigner) Sign(key string, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(s.expiration).Truncate(time.Second).UTC()
+	signature, err := s.signature(key, expiresAt.Unix())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	query := url.Values{
+		"key":       {key},
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {signature},
+	}
+	return fmt.Sprintf("%s%s?%s", s.baseURL, DownloadPath, query.Encode()), expiresAt, nil
+}
+
+// Verify checks the signature of a download URL of the artifact key expiring
+// at the Unix time expires.
+func (s *URLSigner) Verify(key string, expires int64, signature string, now time.Time) error {
+	expected, err := s.signature(key, expires)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	if now.Unix() > expires {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(key string, expires int64) (string, error) {
+	// the key is read on each use so that it can be rotated, which invalidates
+	// the URLs signed with the previous key
+	secret, err := os.ReadFile(s.signingKeyFile)
+	if err != nil {
+		return "", fmt.Errorf("reading signing key: %w", err)
+	}
+	mac := hmac.New(sha256.New, []byte(strings.TrimSpace(string(secret))))
+	fmt.Fprintf(mac, "%s\n%d", key, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
@@ -0,0 +1,54 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLSigner(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.NewDefault()
+	signer, err := NewURLSigner(cfg)
+	require.NoError(err)
+	require.Nil(signer)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(os.WriteFile(keyFile, []byte("first\n"), 0600))
+	cfg.Service.BaseUrl = "https://api.example.com/"
+	require.NoError(json.Unmarshal([]byte(`{"signingKeyFile":"`+keyFile+`","downloadUrlExpiration":"10m"}`), &cfg.Artifacts))
+	signer, err = NewURLSigner(cfg)
+	require.NoError(err)
+
+	now := time.Date(2026, 10, 14, 6, 0, 30, 0, time.UTC)
+	signed, expiresAt, err := signer.Sign("reports/edge/1.json", now)
+	require.NoError(err)
+	require.Equal(now.Add(10*time.Minute), expiresAt)
+
+	u, err := url.Parse(signed)
+	require.NoError(err)
+	require.Equal("api.example.com", u.Host)
+	require.Equal(DownloadPath, u.Path)
+	key := u.Query().Get("key")
+	signature := u.Query().Get("signature")
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	require.NoError(err)
+	require.Equal("reports/edge/1.json", key)
+
+	require.NoError(signer.Verify(key, expires, signature, now.Add(time.Minute)))
+	require.ErrorIs(signer.Verify(key, expires, signature, now.Add(11*time.Minute)), ErrURLExpired)
+	require.ErrorIs(signer.Verify("reports/other/1.json", expires, signature, now), ErrInvalidSignature)
+	require.ErrorIs(signer.Verify(key, expires+3600, signature, now), ErrInvalidSignature)
+
+	// rotating the key invalidates the URLs signed with the previous key
+	require.NoError(os.WriteFile(keyFile, []byte("second"), 0600))
+	require.ErrorIs(signer.Verify(key, expires, signature, now), ErrInvalidSignature)
+}
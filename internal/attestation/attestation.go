@@ -0,0 +1,145 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+)
+
+// QuotedPCRs are the PCRs a device quotes when attesting its boot. They cover
+// the firmware, the boot loader and the booted kernel and image, while PCRs
+// 10 to 13, which the OS extends at runtime, are left out as they change
+// without the device being tampered with.
+var QuotedPCRs = []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 14}
+
+// ErrInvalidQuote is returned if the quote is not signed by the attestation
+// key, does not include the nonce or does not match the PCR values.
+var ErrInvalidQuote = errors.New("invalid quote")
+
+// PCRKey returns the key of the PCR in the reported PCR values, such as "pcr04".
+func PCRKey(pcr int) string {
+	return fmt.Sprintf("pcr%02d", pcr)
+}
+
+// VerifyQuote verifies that the quote (TPMS_ATTEST) is signed by the
+// attestation key, whose public area (TPMT_PUBLIC) is akPublic, includes the
+// nonce and attests the hex encoded SHA-256 values of the QuotedPCRs.
+func VerifyQuote(akPublic, quote, signature, nonce []byte, pcrs map[string]string) error {
+	public, err := tpm2.DecodePublic(akPublic)
+	if err != nil {
+		return fmt.Errorf("%w: decoding attestation key: %v", ErrInvalidQuote, err)
+	}
+	if public.Attributes&tpm2.FlagRestricted == 0 || public.Attributes&tpm2.FlagSign == 0 {
+		return fmt.Errorf("%w: attestation key must be a restricted signing key", ErrInvalidQuote)
+	}
+	key, err := public.Key()
+	if err != nil {
+		return fmt.Errorf("%w: decoding attestation key: %v", ErrInvalidQuote, err)
+	}
+	sig, err := tpm2.DecodeSignature(bytes.NewBuffer(signature))
+	if err != nil {
+		return fmt.Errorf("%w: decoding signature: %v", ErrInvalidQuote, err)
+	}
+	hash, err := verifySignature(key, sig, quote)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidQuote, err)
+	}
+
+	attest, err := tpm2.DecodeAttestationData(quote)
+	if err != nil {
+		return fmt.Errorf("%w: decoding attestation data: %v", ErrInvalidQuote, err)
+	}
+	if attest.Type != tpm2.TagAttestQuote || attest.AttestedQuoteInfo == nil {
+		return fmt.Errorf("%w: attestation data is not a quote", ErrInvalidQuote)
+	}
+	if subtle.ConstantTimeCompare(attest.ExtraData, nonce) != 1 {
+		return fmt.Errorf("%w: quote does not include the nonce", ErrInvalidQuote)
+	}
+	selection := attest.AttestedQuoteInfo.PCRSelection
+	if selection.Hash != tpm2.AlgSHA256 || !slices.Equal(sorted(selection.PCRs), QuotedPCRs) {
+		return fmt.Errorf("%w: quote does not select the SHA-256 values of PCRs %v", ErrInvalidQuote, QuotedPCRs)
+	}
+
+	digest := hash.New()
+	for _, pcr := range QuotedPCRs {
+		value, err := hex.DecodeString(pcrs[PCRKey(pcr)])
+		if err != nil || len(value) != crypto.SHA256.Size() {
+			return fmt.Errorf("%w: missing or malformed value of PCR %d", ErrInvalidQuote, pcr)
+		}
+		digest.Write(value)
+	}
+	if !bytes.Equal(digest.Sum(nil), attest.AttestedQuoteInfo.PCRDigest) {
+		return fmt.Errorf("%w: PCR values do not match the quote", ErrInvalidQuote)
+	}
+	return nil
+}
+
+// verifySignature verifies the signature of data and returns the hash
+// algorithm it used, with which the TPM also computed the PCR digest.
+func verifySignature(key crypto.PublicKey, sig *tpm2.Signature, data []byte) (crypto.Hash, error) {
+	var alg tpm2.Algorithm
+	switch {
+	case sig.ECC != nil:
+		alg = sig.ECC.HashAlg
+	case sig.RSA != nil:
+		alg = sig.RSA.HashAlg
+	default:
+		return 0, fmt.Errorf("unsupported signature algorithm 0x%x", sig.Alg)
+	}
+	hash, err := alg.Hash()
+	if err != nil {
+		return 0, err
+	}
+	h := hash.New()
+	h.Write(data)
+	hashed := h.Sum(nil)
+
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if sig.ECC == nil || !ecdsa.Verify(key, hashed, sig.ECC.R, sig.ECC.S) {
+			return 0, errors.New("signature does not match the attestation key")
+		}
+	case *rsa.PublicKey:
+		if sig.RSA == nil {
+			return 0, errors.New("signature does not match the attestation key")
+		}
+		if sig.Alg == tpm2.AlgRSAPSS {
+			err = rsa.VerifyPSS(key, hash, hashed, sig.RSA.Signature, nil)
+		} else {
+			err = rsa.VerifyPKCS1v15(key, hash, hashed, sig.RSA.Signature)
+		}
+		if err != nil {
+			return 0, errors.New("signature does not match the attestation key")
+		}
+	default:
+		return 0, fmt.Errorf("unsupported attestation key type %T", key)
+	}
+	return hash, nil
+}
+
+// DriftedPCRs returns the PCRs whose values differ from the reference, in
+// ascending order.
+func DriftedPCRs(reference, pcrs map[string]string) []int {
+	var drifted []int
+	for _, pcr := range QuotedPCRs {
+		key := PCRKey(pcr)
+		if reference[key] != pcrs[key] {
+			drifted = append(drifted, pcr)
+		}
+	}
+	return drifted
+}
+
+func sorted(pcrs []int) []int {
+	s := slices.Clone(pcrs)
+	slices.Sort(s)
+	return s
+}
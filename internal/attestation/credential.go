@@ -0,0 +1,51 @@
+package attestation
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-tpm/legacy/tpm2"
+	"github.com/google/go-tpm/legacy/tpm2/credactivation"
+)
+
+// credentialBlockSize is the block size of the symmetric algorithm of the
+// endorsement key, AES-128 in the templates of the TCG EK Credential Profile.
+const credentialBlockSize = 16
+
+// EndorsementKeyHash returns the lowercase hex encoded SHA-256 hash of the
+// public endorsement key, DER encoded in PKIX form, which identifies the TPM.
+func EndorsementKeyHash(ekPublic []byte) (string, error) {
+	if _, err := x509.ParsePKIXPublicKey(ekPublic); err != nil {
+		return "", fmt.Errorf("decoding endorsement key: %w", err)
+	}
+	hash := sha256.Sum256(ekPublic)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// MakeCredential protects the secret so that only the TPM holding the
+// endorsement key, DER encoded in PKIX form, can recover it, and only while
+// the attestation key, whose public area (TPMT_PUBLIC) is akPublic, is loaded
+// in the same TPM. It returns the credential (TPM2B_ID_OBJECT) and its seed
+// encrypted to the endorsement key (TPM2B_ENCRYPTED_SECRET), which the TPM
+// activates with TPM2_ActivateCredential.
+func MakeCredential(ekPublic, akPublic, secret []byte) ([]byte, []byte, error) {
+	ek, err := x509.ParsePKIXPublicKey(ekPublic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding endorsement key: %w", err)
+	}
+	public, err := tpm2.DecodePublic(akPublic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding attestation key: %w", err)
+	}
+	if public.Attributes&tpm2.FlagFixedTPM == 0 || public.Attributes&tpm2.FlagRestricted == 0 || public.Attributes&tpm2.FlagSign == 0 {
+		return nil, nil, errors.New("attestation key must be a restricted signing key which cannot leave its TPM")
+	}
+	name, err := public.Name()
+	if err != nil {
+		return nil, nil, fmt.Errorf("computing the name of the attestation key: %w", err)
+	}
+	return credactivation.Generate(name.Digest, ek, credentialBlockSize, secret)
+}
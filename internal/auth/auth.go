@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/flightctl/flightctl/internal/artifacts"
 	"github.com/flightctl/flightctl/internal/auth/authn"
 	"github.com/flightctl/flightctl/internal/auth/authz"
 	"github.com/flightctl/flightctl/internal/auth/common"
@@ -20,6 +21,9 @@ import (
 const (
 	// DisableAuthEnvKey is the environment variable key used to disable auth when developing.
 	DisableAuthEnvKey = "FLIGHTCTL_DISABLE_AUTH"
+
+	// enrollmentRequestsPath is the only path provisioning tokens are valid for
+	enrollmentRequestsPath = "/api/v1/enrollmentrequests"
 )
 
 type AuthNMiddleware interface {
@@ -31,8 +35,16 @@ type AuthZMiddleware interface {
 	CheckPermission(ctx context.Context, resource string, op string) (bool, error)
 }
 
+// ProvisioningTokenValidator validates the bearer tokens of provisioning lines.
+type ProvisioningTokenValidator interface {
+	// ValidateProvisioningToken returns the claims of a valid token, and
+	// common.ErrRateLimited if the token exceeded its rate limit.
+	ValidateProvisioningToken(ctx context.Context, token string) (*common.ProvisioningClaims, error)
+}
+
 var authZ AuthZMiddleware
 var authN AuthNMiddleware
+var provisioningTokens ProvisioningTokenValidator
 
 func GetAuthZ() AuthZMiddleware {
 	return authZ
@@ -42,6 +54,12 @@ func GetAuthN() AuthNMiddleware {
 	return authN
 }
 
+// SetProvisioningTokenValidator sets the validator of the provisioning tokens,
+// which are rejected while none is set.
+func SetProvisioningTokenValidator(validator ProvisioningTokenValidator) {
+	provisioningTokens = validator
+}
+
 func ParseAuthHeader(authHeader string) (string, bool) {
 	authToken := strings.Split(authHeader, "Bearer ")
 	if len(authToken) != 2 {
@@ -103,10 +121,17 @@ func CreateAuthMiddleware(cfg *config.Config, log logrus.FieldLogger) (func(http
 
 	handler := func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/api/v1/auth/config" || r.URL.Path == "/api/v1/auth/validate" {
+			// signed artifact download URLs carry their own authorization
+			if r.URL.Path == "/api/v1/auth/config" || r.URL.Path == "/api/v1/auth/validate" || r.URL.Path == artifacts.DownloadPath {
 				next.ServeHTTP(w, r)
 				return
 			}
+			// provisioning tokens are checked even if auth is disabled, as
+			// they restrict the labels of the enrollment requests they create
+			if token, ok := ParseAuthHeader(r.Header.Get(common.AuthHeader)); ok && strings.HasPrefix(token, common.ProvisioningTokenPrefix) {
+				serveProvisioningRequest(w, r, next, token)
+				return
+			}
 			authToken, ok := getAuthToken(r)
 			if !ok {
 				w.WriteHeader(http.StatusUnauthorized)
@@ -125,6 +150,30 @@ func CreateAuthMiddleware(cfg *config.Config, log logrus.FieldLogger) (func(http
 	return handler, nil
 }
 
+// serveProvisioningRequest serves a request authenticated by a provisioning
+// token, which can only create enrollment requests.
+func serveProvisioningRequest(w http.ResponseWriter, r *http.Request, next http.Handler, token string) {
+	if r.Method != http.MethodPost || r.URL.Path != enrollmentRequestsPath {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if provisioningTokens == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	claims, err := provisioningTokens.ValidateProvisioningToken(r.Context(), token)
+	if errors.Is(err, common.ErrRateLimited) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	if err != nil || claims == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	ctx := context.WithValue(r.Context(), common.ProvisioningClaimsCtxKey, claims)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 type K8sToK8sAuth struct {
 	authz.K8sAuthZ
 }
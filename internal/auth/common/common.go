@@ -1,13 +1,35 @@
 package common
 
+import "errors"
+
 type ctxKeyAuthHeader string
 
 const (
 	AuthHeader  string           = "Authorization"
 	TokenCtxKey ctxKeyAuthHeader = "TokenCtxKey"
+	// ProvisioningClaimsCtxKey holds the claims of the provisioning token of a request.
+	ProvisioningClaimsCtxKey ctxKeyAuthHeader = "ProvisioningClaimsCtxKey"
+
+	// ProvisioningTokenPrefix prefixes the bearer tokens of provisioning lines,
+	// which can only create enrollment requests for the devices of a fleet.
+	ProvisioningTokenPrefix = "fctlpt."
 )
 
+// ErrRateLimited is returned when a provisioning token exceeded its rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
 type AuthConfig struct {
 	Type string
 	Url  string
 }
+
+// ProvisioningClaims are the claims of a valid provisioning token.
+type ProvisioningClaims struct {
+	// Fleet is the name of the fleet of the token
+	Fleet string
+	// TokenId is the ID of the token
+	TokenId string
+	// Labels are the labels of the devices enrolled with the token, which
+	// include the labels selected by the fleet
+	Labels map[string]string
+}
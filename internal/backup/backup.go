@@ -0,0 +1,449 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// FormatVersion is the version of the format of the backups.
+const FormatVersion = 1
+
+// The entries of the archive a backup holds. The manifest comes first, so
+// that a restore can check it before changing anything.
+const (
+	manifestEntry   = "manifest.json"
+	databasePrefix  = "database/"
+	databaseSuffix  = ".jsonl"
+	certsPrefix     = "certs/"
+	artifactsPrefix = "artifacts/"
+
+	contentTypeRecord = "FLIGHTCTL.contentType"
+)
+
+// Manifest describes the content of a backup.
+type Manifest struct {
+	FormatVersion  int       `json:"formatVersion"`
+	ServiceVersion string    `json:"serviceVersion"`
+	CreatedAt      time.Time `json:"createdAt"`
+	// Tables are the tables of the database in restore order, with their number of rows
+	Tables []TableManifest `json:"tables"`
+	// Certificates are the files of the certificate directory, relative to it
+	Certificates []string `json:"certificates"`
+	// Artifacts is the number of artifacts of the artifact store
+	Artifacts int `json:"artifacts"`
+}
+
+type TableManifest struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// Backup creates and restores backups of the database of the service, its
+// certificate directory holding the keys of its CA, and its artifact store.
+type Backup struct {
+	db        *gorm.DB
+	certDir   string
+	artifacts artifacts.Store
+	log       logrus.FieldLogger
+}
+
+// New returns a Backup of the database, the certificate directory and the
+// artifact store, which is nil if the service stores no artifacts.
+func New(db *gorm.DB, certDir string, artifactStore artifacts.Store, log logrus.FieldLogger) *Backup {
+	return &Backup{
+		db:        db,
+		certDir:   certDir,
+		artifacts: artifactStore,
+		log:       log,
+	}
+}
+
+// Create writes a backup encrypted with the passphrase to w. The database is
+// dumped from a single snapshot, so the service does not need to be stopped.
+func (b *Backup) Create(ctx context.Context, w io.Writer, passphrase []byte) (*Manifest, error) {
+	encrypted, err := NewEncryptingWriter(w, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(encrypted)
+	tw := tar.NewWriter(gz)
+
+	manifest := &Manifest{
+		FormatVersion:  FormatVersion,
+		ServiceVersion: version.Get().String(),
+		CreatedAt:      time.Now().UTC(),
+	}
+	certificates, err := listFiles(b.certDir)
+	if err != nil {
+		return nil, fmt.Errorf("listing certificates: %w", err)
+	}
+	manifest.Certificates = certificates
+	var objects []artifacts.Object
+	if b.artifacts != nil {
+		if objects, err = b.artifacts.List(ctx, ""); err != nil {
+			return nil, fmt.Errorf("listing artifacts: %w", err)
+		}
+		manifest.Artifacts = len(objects)
+	}
+
+	err = snapshot(ctx, b.db, func(tx *gorm.DB) error {
+		tables, err := orderedTables(tx)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			rows, err := countRows(tx, table)
+			if err != nil {
+				return err
+			}
+			manifest.Tables = append(manifest.Tables, TableManifest{Name: table, Rows: rows})
+		}
+		if err := writeJSON(tw, manifestEntry, manifest); err != nil {
+			return err
+		}
+		for _, table := range manifest.Tables {
+			if err := writeTable(tw, tx, table); err != nil {
+				return fmt.Errorf("dumping table %s: %w", table.Name, err)
+			}
+			b.log.Infof("Backed up %d rows of table %s", table.Rows, table.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certificate := range certificates {
+		content, err := os.ReadFile(filepath.Join(b.certDir, filepath.FromSlash(certificate)))
+		if err != nil {
+			return nil, err
+		}
+		if err := writeEntry(tw, certsPrefix+certificate, content, 0600, nil); err != nil {
+			return nil, err
+		}
+	}
+	b.log.Infof("Backed up %d files of the certificate directory", len(certificates))
+
+	for _, object := range objects {
+		if err := b.writeArtifact(ctx, tw, object); err != nil {
+			return nil, fmt.Errorf("backing up artifact %s: %w", object.Key, err)
+		}
+	}
+	b.log.Infof("Backed up %d artifacts", len(objects))
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, encrypted.Close()
+}
+
+// writeTable writes the rows of the table as one JSON object per line. The
+// number of rows was counted in the same snapshot, so the size of the entry is
+// known only once the rows are dumped; they are buffered to a temporary file.
+func writeTable(tw *tar.Writer, tx *gorm.DB, table TableManifest) error {
+	tmp, err := os.CreateTemp("", "flightctl-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	buffered := bufio.NewWriter(tmp)
+	var rows int64
+	err = dumpTable(tx, table.Name, func(row []byte) error {
+		rows++
+		if _, err := buffered.Write(row); err != nil {
+			return err
+		}
+		return buffered.WriteByte('\n')
+	})
+	if err != nil {
+		return err
+	}
+	if rows != table.Rows {
+		return fmt.Errorf("dumped %d rows, counted %d", rows, table.Rows)
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: databasePrefix + table.Name + databaseSuffix, Mode: 0600, Size: size}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmp)
+	return err
+}
+
+func (b *Backup) writeArtifact(ctx context.Context, tw *tar.Writer, object artifacts.Object) error {
+	body, info, err := b.artifacts.Get(ctx, object.Key)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	var records map[string]string
+	if info.ContentType != "" {
+		records = map[string]string{contentTypeRecord: info.ContentType}
+	}
+	return writeEntry(tw, artifactsPrefix+object.Key, content, 0600, records)
+}
+
+func writeJSON(tw *tar.Writer, name string, v any) error {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeEntry(tw, name, content, 0600, nil)
+}
+
+func writeEntry(tw *tar.Writer, name string, content []byte, mode int64, records map[string]string) error {
+	header := &tar.Header{Name: name, Mode: mode, Size: int64(len(content)), PAXRecords: records}
+	if len(records) > 0 {
+		header.Format = tar.FormatPAX
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// listFiles returns the regular files of dir and its subdirectories, relative
+// to it with forward slashes.
+func listFiles(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	slices.Sort(files)
+	return files, err
+}
+
+// RestoreOptions configures a restore.
+type RestoreOptions struct {
+	// Force replaces the rows of the database and the files of the
+	// certificate directory that already exist with the ones of the backup
+	Force bool
+}
+
+// Restore restores the backup read from r with the passphrase. The rows of
+// the database are restored in a single transaction, which is committed only
+// once the whole backup has been read and authenticated. The certificates are
+// written after it is committed, and the artifacts are put into the artifact
+// store as they are read. The service should be stopped while it is restored,
+// and the database migrated by the same version of the service as the backup.
+func (b *Backup) Restore(ctx context.Context, r io.Reader, passphrase []byte, opts RestoreOptions) (*Manifest, error) {
+	decrypted, err := NewDecryptingReader(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(decrypted)
+	if err != nil {
+		return nil, wrapReadError(err)
+	}
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil {
+		return nil, wrapReadError(err)
+	}
+	if header.Name != manifestEntry {
+		return nil, fmt.Errorf("backup does not start with its manifest")
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+	if err := b.checkCertificates(manifest.Certificates, opts.Force); err != nil {
+		return nil, err
+	}
+	if manifest.Artifacts > 0 && b.artifacts == nil {
+		return nil, fmt.Errorf("backup holds %d artifacts but the service stores no artifacts", manifest.Artifacts)
+	}
+
+	certificates := map[string][]byte{}
+	artifactCount := 0
+	err = b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tables := make([]string, 0, len(manifest.Tables))
+		for _, table := range manifest.Tables {
+			if !tx.Migrator().HasTable(table.Name) {
+				return fmt.Errorf("table %s of the backup does not exist, migrate the database first", table.Name)
+			}
+			tables = append(tables, table.Name)
+		}
+		if err := emptyTables(tx, tables, opts.Force); err != nil {
+			return err
+		}
+
+		restoredTables := map[string]int64{}
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return wrapReadError(err)
+			}
+			switch {
+			case strings.HasPrefix(header.Name, databasePrefix):
+				table := strings.TrimSuffix(strings.TrimPrefix(header.Name, databasePrefix), databaseSuffix)
+				if !slices.Contains(tables, table) {
+					return fmt.Errorf("backup holds table %s, which is not in its manifest", table)
+				}
+				rows, err := restoreTable(tx, table, tr)
+				if err != nil {
+					return err
+				}
+				restoredTables[table] = rows
+				b.log.Infof("Restored %d rows of table %s", rows, table)
+			case strings.HasPrefix(header.Name, certsPrefix):
+				name := strings.TrimPrefix(header.Name, certsPrefix)
+				if !slices.Contains(manifest.Certificates, name) {
+					return fmt.Errorf("backup holds certificate file %s, which is not in its manifest", name)
+				}
+				content, err := io.ReadAll(tr)
+				if err != nil {
+					return wrapReadError(err)
+				}
+				certificates[name] = content
+			case strings.HasPrefix(header.Name, artifactsPrefix):
+				key := strings.TrimPrefix(header.Name, artifactsPrefix)
+				content, err := io.ReadAll(tr)
+				if err != nil {
+					return wrapReadError(err)
+				}
+				if err := b.artifacts.Put(ctx, key, content, header.PAXRecords[contentTypeRecord]); err != nil {
+					return fmt.Errorf("restoring artifact %s: %w", key, err)
+				}
+				artifactCount++
+			default:
+				return fmt.Errorf("unexpected entry %s in backup", header.Name)
+			}
+		}
+
+		// the backup is authenticated once it is read to its end
+		if _, err := io.Copy(io.Discard, gz); err != nil {
+			return wrapReadError(err)
+		}
+		for _, table := range manifest.Tables {
+			if restoredTables[table.Name] != table.Rows {
+				return fmt.Errorf("backup holds %d rows of table %s, its manifest %d", restoredTables[table.Name], table.Name, table.Rows)
+			}
+		}
+		if len(certificates) != len(manifest.Certificates) || artifactCount != manifest.Artifacts {
+			return fmt.Errorf("backup does not hold all the files of its manifest")
+		}
+		return resetSequences(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.log.Infof("Restored %d artifacts", artifactCount)
+
+	for _, name := range manifest.Certificates {
+		file := filepath.Join(b.certDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(file, certificates[name], 0600); err != nil {
+			return nil, err
+		}
+	}
+	b.log.Infof("Restored %d files of the certificate directory", len(manifest.Certificates))
+	return &manifest, nil
+}
+
+// checkCertificates checks that the certificate files can be restored, before
+// the database is changed.
+func (b *Backup) checkCertificates(certificates []string, force bool) error {
+	for _, name := range certificates {
+		if !filepath.IsLocal(filepath.FromSlash(name)) || path.Clean(name) != name {
+			return fmt.Errorf("invalid certificate file %q in backup", name)
+		}
+		if force {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(b.certDir, filepath.FromSlash(name))); err == nil {
+			return fmt.Errorf("certificate file %s already exists, restore with force to replace it", name)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func restoreTable(tx *gorm.DB, table string, r io.Reader) (int64, error) {
+	restorer := &tableRestorer{tx: tx, table: table}
+	scanner := bufio.NewScanner(r)
+	// rows holding device specs and statuses can be large
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if err := restorer.add(scanner.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, wrapReadError(err)
+	}
+	if err := restorer.flush(); err != nil {
+		return 0, err
+	}
+	return restorer.count, nil
+}
+
+func wrapReadError(err error) error {
+	if errors.Is(err, ErrDecrypt) {
+		return err
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("reading backup: the backup is truncated")
+	}
+	return fmt.Errorf("reading backup: %w", err)
+}
@@ -0,0 +1,193 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// restoreBatchSize is the number of rows inserted by each statement of a restore.
+const restoreBatchSize = 500
+
+// quoteIdentifier quotes the name of a table or column for PostgreSQL.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// snapshot runs fn in a read-only transaction seeing a single snapshot of the
+// database, so that the tables are dumped consistently with each other while
+// the service keeps running.
+func snapshot(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	if db.Dialector.Name() != "postgres" {
+		return fmt.Errorf("backups require a PostgreSQL database")
+	}
+	return db.WithContext(ctx).Transaction(fn, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// orderedTables returns the tables of the database ordered so that the
+// tables referenced by foreign keys come before the tables referencing them.
+func orderedTables(tx *gorm.DB) ([]string, error) {
+	tables, err := tx.Migrator().GetTables()
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(tables)
+
+	var references []struct {
+		Child  string
+		Parent string
+	}
+	err = tx.Raw(`SELECT conrelid::regclass::text AS child, confrelid::regclass::text AS parent
+		FROM pg_constraint WHERE contype = 'f' AND connamespace = current_schema()::regnamespace`).Scan(&references).Error
+	if err != nil {
+		return nil, err
+	}
+	parents := map[string][]string{}
+	for _, reference := range references {
+		if reference.Child != reference.Parent {
+			parents[reference.Child] = append(parents[reference.Child], reference.Parent)
+		}
+	}
+
+	ordered := make([]string, 0, len(tables))
+	visiting := map[string]bool{}
+	visited := map[string]bool{}
+	var visit func(table string) error
+	visit = func(table string) error {
+		if visited[table] {
+			return nil
+		}
+		if visiting[table] {
+			return fmt.Errorf("foreign keys of table %s form a cycle", table)
+		}
+		visiting[table] = true
+		for _, parent := range parents[table] {
+			if slices.Contains(tables, parent) {
+				if err := visit(parent); err != nil {
+					return err
+				}
+			}
+		}
+		visited[table] = true
+		ordered = append(ordered, table)
+		return nil
+	}
+	for _, table := range tables {
+		if err := visit(table); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func countRows(tx *gorm.DB, table string) (int64, error) {
+	var count int64
+	err := tx.Table(table).Count(&count).Error
+	return count, err
+}
+
+// dumpTable calls fn with each row of the table as a JSON object.
+func dumpTable(tx *gorm.DB, table string, fn func(row []byte) error) error {
+	rows, err := tx.Raw("SELECT row_to_json(t) FROM " + quoteIdentifier(table) + " t").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// tableRestorer inserts the rows of a table dumped by dumpTable in batches.
+type tableRestorer struct {
+	tx    *gorm.DB
+	table string
+	batch []string
+	count int64
+}
+
+func (r *tableRestorer) add(row []byte) error {
+	r.batch = append(r.batch, string(row))
+	if len(r.batch) == restoreBatchSize {
+		return r.flush()
+	}
+	return nil
+}
+
+func (r *tableRestorer) flush() error {
+	if len(r.batch) == 0 {
+		return nil
+	}
+	table := quoteIdentifier(r.table)
+	err := r.tx.Exec("INSERT INTO "+table+" SELECT * FROM json_populate_recordset(NULL::"+table+", ?::json)",
+		"["+strings.Join(r.batch, ",")+"]").Error
+	if err != nil {
+		return fmt.Errorf("restoring table %s: %w", r.table, err)
+	}
+	r.count += int64(len(r.batch))
+	r.batch = r.batch[:0]
+	return nil
+}
+
+// emptyTables empties the tables, or fails unless force is set if any of them
+// has rows.
+func emptyTables(tx *gorm.DB, tables []string, force bool) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	if !force {
+		for _, table := range tables {
+			count, err := countRows(tx, table)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("table %s is not empty, restore with force to replace its content", table)
+			}
+		}
+		return nil
+	}
+	quoted := make([]string, 0, len(tables))
+	for _, table := range tables {
+		quoted = append(quoted, quoteIdentifier(table))
+	}
+	return tx.Exec("TRUNCATE " + strings.Join(quoted, ", ") + " CASCADE").Error
+}
+
+// resetSequences sets the sequences of serial columns past the largest value
+// restored, so that rows created after the restore do not collide with it.
+func resetSequences(tx *gorm.DB) error {
+	var sequences []struct {
+		Sequence string
+		Table    string
+		Column   string
+	}
+	err := tx.Raw(`SELECT s.relname AS sequence, t.relname AS "table", a.attname AS "column"
+		FROM pg_class s
+		JOIN pg_depend d ON d.objid = s.oid AND d.deptype IN ('a', 'i')
+		JOIN pg_class t ON t.oid = d.refobjid
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid
+		WHERE s.relkind = 'S' AND s.relnamespace = current_schema()::regnamespace`).Scan(&sequences).Error
+	if err != nil {
+		return err
+	}
+	for _, sequence := range sequences {
+		err := tx.Exec(fmt.Sprintf("SELECT setval(?, COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+			quoteIdentifier(sequence.Column), quoteIdentifier(sequence.Table)), quoteIdentifier(sequence.Sequence)).Error
+		if err != nil {
+			return fmt.Errorf("resetting sequence %s: %w", sequence.Sequence, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// A backup is encrypted with AES-256-GCM under a key derived from a
+// passphrase with scrypt. It starts with a header holding the magic and the
+// salt of the key, followed by chunks of at most chunkSize bytes of content.
+// Each chunk is framed by a flag marking the final chunk and the length of
+// its ciphertext, and is sealed with its sequence number as nonce and the
+// flag as additional data, so that reordered, dropped or truncated chunks
+// fail to decrypt.
+const (
+	magic     = "FCTLBAK\x01"
+	saltSize  = 16
+	keySize   = 32
+	chunkSize = 64 * 1024
+
+	// scrypt parameters, see https://pkg.go.dev/golang.org/x/crypto/scrypt
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// MinPassphraseLength is the minimum length of the passphrase of a backup.
+	MinPassphraseLength = 12
+)
+
+const (
+	moreChunks byte = iota
+	finalChunk
+)
+
+// ErrDecrypt is returned when a backup cannot be decrypted, either because the
+// passphrase is wrong or because the backup is corrupted.
+var ErrDecrypt = errors.New("decrypting backup: wrong passphrase or corrupted backup")
+
+func newAEAD(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(aead cipher.AEAD, seq uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], seq)
+	return nonce
+}
+
+type encryptingWriter struct {
+	w    io.Writer
+	aead cipher.AEAD
+	buf  []byte
+	seq  uint64
+	err  error
+}
+
+// NewEncryptingWriter returns a writer encrypting what is written to it with
+// the passphrase. The backup is complete once the writer is closed.
+func NewEncryptingWriter(w io.Writer, passphrase []byte) (io.WriteCloser, error) {
+	if len(passphrase) < MinPassphraseLength {
+		return nil, fmt.Errorf("the passphrase must have at least %d characters", MinPassphraseLength)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append([]byte(magic), salt...)); err != nil {
+		return nil, err
+	}
+	return &encryptingWriter{w: w, aead: aead, buf: make([]byte, 0, chunkSize)}, nil
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if e.err != nil {
+			return written, e.err
+		}
+		n := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+		if len(e.buf) == cap(e.buf) {
+			e.err = e.writeChunk(moreChunks)
+		}
+	}
+	return written, e.err
+}
+
+func (e *encryptingWriter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.writeChunk(finalChunk); err != nil {
+		e.err = err
+		return err
+	}
+	e.err = errors.New("backup writer is closed")
+	return nil
+}
+
+func (e *encryptingWriter) writeChunk(flag byte) error {
+	sealed := e.aead.Seal(nil, chunkNonce(e.aead, e.seq), e.buf, []byte{flag})
+	e.seq++
+	e.buf = e.buf[:0]
+	frame := make([]byte, 5, 5+len(sealed))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(sealed)))
+	_, err := e.w.Write(append(frame, sealed...))
+	return err
+}
+
+type decryptingReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	buf   bytes.Reader
+	seq   uint64
+	final bool
+}
+
+// NewDecryptingReader returns a reader decrypting the backup with the
+// passphrase. Reading fails with ErrDecrypt if the passphrase is wrong or the
+// backup was modified, and with io.ErrUnexpectedEOF if it was truncated.
+func NewDecryptingReader(r io.Reader, passphrase []byte) (io.Reader, error) {
+	header := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("reading backup header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a Flight Control backup")
+	}
+	aead, err := newAEAD(passphrase, header[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	return &decryptingReader{r: r, aead: aead}, nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for d.buf.Len() == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	return d.buf.Read(p)
+}
+
+func (d *decryptingReader) readChunk() error {
+	frame := make([]byte, 5)
+	if _, err := io.ReadFull(d.r, frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	flag := frame[0]
+	length := binary.BigEndian.Uint32(frame[1:])
+	if (flag != moreChunks && flag != finalChunk) || length < uint32(d.aead.Overhead()) || length > chunkSize+uint32(d.aead.Overhead()) {
+		return ErrDecrypt
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	plain, err := d.aead.Open(nil, chunkNonce(d.aead, d.seq), sealed, []byte{flag})
+	if err != nil {
+		return ErrDecrypt
+	}
+	d.seq++
+	d.buf.Reset(plain)
+	if flag == finalChunk {
+		d.final = true
+		// nothing may follow the final chunk
+		if n, _ := d.r.Read(make([]byte, 1)); n > 0 {
+			return ErrDecrypt
+		}
+	}
+	return nil
+}
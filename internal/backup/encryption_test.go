@@ -0,0 +1,123 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encrypt(t *testing.T, content []byte, passphrase []byte) []byte {
+	var buf bytes.Buffer
+	w, err := NewEncryptingWriter(&buf, passphrase)
+	require.NoError(t, err)
+	// write in pieces not aligned with the chunks
+	for len(content) > 0 {
+		n := min(len(content), 1000)
+		_, err := w.Write(content[:n])
+		require.NoError(t, err)
+		content = content[n:]
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decrypt(encrypted []byte, passphrase []byte) ([]byte, error) {
+	r, err := NewDecryptingReader(bytes.NewReader(encrypted), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestEncryption(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+	content := make([]byte, 3*chunkSize+123)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		content   []byte
+		modify    func(encrypted []byte) []byte
+		reader    []byte
+		expectErr error
+	}{
+		{
+			name:    "round trip",
+			content: content,
+			modify:  func(encrypted []byte) []byte { return encrypted },
+		},
+		{
+			name:    "empty",
+			content: []byte{},
+			modify:  func(encrypted []byte) []byte { return encrypted },
+		},
+		{
+			name:      "wrong passphrase",
+			content:   content,
+			modify:    func(encrypted []byte) []byte { return encrypted },
+			reader:    []byte("wrong passphrase"),
+			expectErr: ErrDecrypt,
+		},
+		{
+			name:      "truncated",
+			content:   content,
+			modify:    func(encrypted []byte) []byte { return encrypted[:len(encrypted)-100] },
+			expectErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "final chunk dropped",
+			content: content,
+			modify: func(encrypted []byte) []byte {
+				// the final chunk holds the 123 remaining bytes
+				return encrypted[:len(encrypted)-5-123-16]
+			},
+			expectErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "modified",
+			content: content,
+			modify: func(encrypted []byte) []byte {
+				encrypted[len(encrypted)/2] ^= 1
+				return encrypted
+			},
+			expectErr: ErrDecrypt,
+		},
+		{
+			name:    "trailing data",
+			content: content,
+			modify: func(encrypted []byte) []byte {
+				return append(encrypted, 0)
+			},
+			expectErr: ErrDecrypt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			encrypted := tt.modify(encrypt(t, tt.content, passphrase))
+			reader := passphrase
+			if tt.reader != nil {
+				reader = tt.reader
+			}
+			decrypted, err := decrypt(encrypted, reader)
+			if tt.expectErr != nil {
+				require.ErrorIs(err, tt.expectErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.content, decrypted)
+		})
+	}
+}
+
+func TestEncryptionRequiresPassphrase(t *testing.T) {
+	_, err := NewEncryptingWriter(io.Discard, []byte("short"))
+	require.Error(t, err)
+
+	_, err = NewDecryptingReader(bytes.NewReader([]byte("not a backup at all, really")), []byte("correct horse battery staple"))
+	require.ErrorContains(t, err, "not a Flight Control backup")
+}
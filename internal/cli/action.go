@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// deviceActions maps the actions of the command to those of the API.
+var deviceActions = map[string]api.DeviceActionType{
+	"reboot":        api.DeviceActionReboot,
+	"shutdown":      api.DeviceActionShutdown,
+	"restart-agent": api.DeviceActionRestartAgent,
+	"enforce-spec":  api.DeviceActionEnforceSpec,
+}
+
+type ActionOptions struct {
+	GlobalOptions
+
+	Reason string
+	Cancel bool
+}
+
+func DefaultActionOptions() *ActionOptions {
+	return &ActionOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Reason:        "",
+		Cancel:        false,
+	}
+}
+
+func NewCmdAction() *cobra.Command {
+	o := DefaultActionOptions()
+	cmd := &cobra.Command{
+		Use:   "action device/NAME (reboot|shutdown|restart-agent|enforce-spec)",
+		Short: "Request an action of a device, or cancel it.",
+		Long: `Request the agent of a device to reboot the device, shut it down or restart itself,
+or to apply the spec it holds back after adopting the existing state of the device.
+The agent carries out the action once it next fetches its rendered spec, and reports
+its progress in status.lastAction. An action the agent did not receive yet can be
+canceled with --cancel.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ActionOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the action is requested, recorded in the audit log.")
+	fs.BoolVar(&o.Cancel, "cancel", o.Cancel, "Cancel the action requested for the device.")
+}
+
+func (o *ActionOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ActionOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device")
+	}
+	if o.Cancel {
+		if len(args) > 1 || o.Reason != "" {
+			return fmt.Errorf("--cancel cannot be combined with an action or --reason")
+		}
+		return nil
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("specify the action: reboot, shutdown, restart-agent or enforce-spec")
+	}
+	if _, ok := deviceActions[args[1]]; !ok {
+		return fmt.Errorf("unknown action %q, must be reboot, shutdown, restart-agent or enforce-spec", args[1])
+	}
+	return nil
+}
+
+func (o *ActionOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.Cancel {
+		response, err := c.CancelDeviceActionWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("canceling the action of device/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("canceling the action of device/%s: %d", name, response.HTTPResponse.StatusCode)
+		}
+		fmt.Printf("device/%s has no pending action\n", name)
+		return nil
+	}
+
+	body := api.DeviceActionRequest{Action: deviceActions[args[1]]}
+	if reason := strings.TrimSpace(o.Reason); reason != "" {
+		body.Reason = &reason
+	}
+	response, err := c.RequestDeviceActionWithResponse(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("requesting %s of device/%s: %w", args[1], name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		if response.JSON409 != nil {
+			return fmt.Errorf("requesting %s of device/%s: %s", args[1], name, response.JSON409.Message)
+		}
+		return fmt.Errorf("requesting %s of device/%s: %d", args[1], name, response.HTTPResponse.StatusCode)
+	}
+	fmt.Printf("device/%s: %s requested as action %s\n", name, args[1], response.JSON200.Id)
+	return nil
+}
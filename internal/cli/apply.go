@@ -216,42 +216,77 @@ func applyFromReader(ctx context.Context, client *apiclient.ClientWithResponses,
 			response, err = client.ReplaceDeviceWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
 			}
 		case EnrollmentRequestKind:
 			var response *apiclient.ReplaceEnrollmentRequestResponse
 			response, err = client.ReplaceEnrollmentRequestWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
 			}
 		case FleetKind:
 			var response *apiclient.ReplaceFleetResponse
 			response, err = client.ReplaceFleetWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
 			}
 		case RepositoryKind:
 			var response *apiclient.ReplaceRepositoryResponse
 			response, err = client.ReplaceRepositoryWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
 			}
 		case ResourceSyncKind:
 			var response *apiclient.ReplaceResourceSyncResponse
 			response, err = client.ReplaceResourceSyncWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
 			}
 		case CertificateSigningRequestKind:
 			var response *apiclient.ReplaceCertificateSigningRequestResponse
 			response, err = client.ReplaceCertificateSigningRequestWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
 			if response != nil {
 				httpResponse = response.HTTPResponse
-				message = string(response.Body)
+				message = errorMessage(response.Body)
+			}
+		case WebhookKind:
+			var response *apiclient.ReplaceWebhookResponse
+			response, err = client.ReplaceWebhookWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
+			if response != nil {
+				httpResponse = response.HTTPResponse
+				message = errorMessage(response.Body)
+			}
+		case LabelRuleKind:
+			var response *apiclient.ReplaceLabelRuleResponse
+			response, err = client.ReplaceLabelRuleWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
+			if response != nil {
+				httpResponse = response.HTTPResponse
+				message = errorMessage(response.Body)
+			}
+		case DeviceIdentityKind:
+			var response *apiclient.ReplaceDeviceIdentityResponse
+			response, err = client.ReplaceDeviceIdentityWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
+			if response != nil {
+				httpResponse = response.HTTPResponse
+				message = errorMessage(response.Body)
+			}
+		case DeviceViewKind:
+			var response *apiclient.ReplaceDeviceViewResponse
+			response, err = client.ReplaceDeviceViewWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
+			if response != nil {
+				httpResponse = response.HTTPResponse
+				message = errorMessage(response.Body)
+			}
+		case SiteKind:
+			var response *apiclient.ReplaceSiteResponse
+			response, err = client.ReplaceSiteWithBodyWithResponse(ctx, resourceName, "application/json", bytes.NewReader(buf))
+			if response != nil {
+				httpResponse = response.HTTPResponse
+				message = errorMessage(response.Body)
 			}
 		default:
 			err = fmt.Errorf("%s: skipping resource of unknown kind %q: %v", filename, kind, resource)
@@ -18,6 +18,10 @@ type ApproveOptions struct {
 	GlobalOptions
 
 	ApproveLabels []string
+	ReplaceDevice bool
+	// replaceDeviceSet is whether --replace-device was set, as the service
+	// applies its policy otherwise
+	replaceDeviceSet bool
 }
 
 func DefaultApproveOptions() *ApproveOptions {
@@ -32,7 +36,9 @@ func NewCmdApprove() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "approve TYPE/NAME",
 		Short: "Approve a request.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Approve an enrollment request or a certificate signing request, or the proposed
+promotion of a template version to a fleet whose promotion requires approval.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := o.Complete(cmd, args); err != nil {
 				return err
@@ -52,12 +58,14 @@ func (o *ApproveOptions) Bind(fs *pflag.FlagSet) {
 	o.GlobalOptions.Bind(fs)
 
 	fs.StringArrayVarP(&o.ApproveLabels, "label", "l", []string{}, "Labels to add to the device, as a comma-separated list of key=value.")
+	fs.BoolVar(&o.ReplaceDevice, "replace-device", o.ReplaceDevice, "If the machine is already enrolled as another device, such as a re-imaged one, move that device to the trash. Set to false to keep both devices.")
 }
 
 func (o *ApproveOptions) Complete(cmd *cobra.Command, args []string) error {
 	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
 		return err
 	}
+	o.replaceDeviceSet = cmd.Flags().Changed("replace-device")
 
 	return nil
 }
@@ -72,8 +80,8 @@ func (o *ApproveOptions) Validate(args []string) error {
 		return err
 	}
 
-	if kind != EnrollmentRequestKind && kind != CertificateSigningRequestKind {
-		return fmt.Errorf("kind must be either %s or %s", EnrollmentRequestKind, CertificateSigningRequestKind)
+	if kind != EnrollmentRequestKind && kind != CertificateSigningRequestKind && kind != FleetKind {
+		return fmt.Errorf("kind must be %s, %s or %s", EnrollmentRequestKind, CertificateSigningRequestKind, FleetKind)
 	}
 
 	if len(name) == 0 {
@@ -84,6 +92,10 @@ func (o *ApproveOptions) Validate(args []string) error {
 		return fmt.Errorf("labels only apply to %s approval", EnrollmentRequestKind)
 	}
 
+	if o.replaceDeviceSet && kind != EnrollmentRequestKind {
+		return fmt.Errorf("--replace-device only applies to %s approval", EnrollmentRequestKind)
+	}
+
 	return nil
 }
 
@@ -107,9 +119,14 @@ func (o *ApproveOptions) Run(ctx context.Context, args []string) error {
 			Approved: true,
 			Labels:   &labels,
 		}
+		if o.replaceDeviceSet {
+			approval.ReplaceDevice = &o.ReplaceDevice
+		}
 		response, err = c.ApproveEnrollmentRequest(ctx, name, approval)
 	case kind == CertificateSigningRequestKind:
 		response, err = c.ApproveCertificateSigningRequest(ctx, name)
+	case kind == FleetKind:
+		response, err = c.ApproveFleetPromotion(ctx, name)
 	default:
 		return fmt.Errorf("unsupported resource kind: %s", kind)
 	}
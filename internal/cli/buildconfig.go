@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultBaseImage         = "quay.io/centos-bootc/centos-bootc:stream9"
+	defaultImageBuilderImage = "quay.io/centos-bootc/bootc-image-builder:latest"
+)
+
+var imageBuilderTypes = []string{"ami", "anaconda-iso", "iso", "qcow2", "raw", "vmdk"}
+
+type BuildConfigOptions struct {
+	GlobalOptions
+
+	PrivateKey        string
+	OutputDir         string
+	Fleet             string
+	BaseImage         string
+	Image             string
+	Type              string
+	ImageBuilderImage string
+}
+
+func DefaultBuildConfigOptions() *BuildConfigOptions {
+	return &BuildConfigOptions{
+		GlobalOptions:     DefaultGlobalOptions(),
+		OutputDir:         ".",
+		BaseImage:         defaultBaseImage,
+		ImageBuilderImage: defaultImageBuilderImage,
+	}
+}
+
+func NewCmdBuildConfig() *cobra.Command {
+	o := DefaultBuildConfigOptions()
+	cmd := &cobra.Command{
+		Use:   "build-config NAME",
+		Short: "Generate the files to build a bootc image that enrolls devices",
+		Long: `Generate the agent configuration and a Containerfile that build a bootc image
+whose devices enroll with the service using the approved certificate signing
+request NAME. With --type, the image is also built with podman and converted
+into a disk image with bootc-image-builder.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *BuildConfigOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.PrivateKey, "private-key", "p", o.PrivateKey, "Path to private key")
+	fs.StringVarP(&o.OutputDir, "output-dir", "o", o.OutputDir, "Directory to write the config.yaml and Containerfile to")
+	fs.StringVar(&o.Fleet, "fleet", o.Fleet, "Fleet whose selector labels are applied to the devices when they enroll")
+	fs.StringVar(&o.BaseImage, "base-image", o.BaseImage, "Bootable base image of the Containerfile")
+	fs.StringVar(&o.Image, "image", o.Image, "Tag of the bootc image to build (required with --type)")
+	fs.StringVar(&o.Type, "type", o.Type, fmt.Sprintf("Type of disk image to build with bootc-image-builder, one of: %s", strings.Join(imageBuilderTypes, ", ")))
+	fs.StringVar(&o.ImageBuilderImage, "image-builder-image", o.ImageBuilderImage, "Image of bootc-image-builder")
+}
+
+func (o *BuildConfigOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *BuildConfigOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+	if len(o.PrivateKey) == 0 {
+		return fmt.Errorf("must specify -p PRIVATE_KEY_FILE")
+	}
+	if len(o.Type) > 0 {
+		if !lo.Contains(imageBuilderTypes, o.Type) {
+			return fmt.Errorf("type must be one of: %s", strings.Join(imageBuilderTypes, ", "))
+		}
+		if len(o.Image) == 0 {
+			return fmt.Errorf("must specify --image when building a disk image")
+		}
+	}
+	return nil
+}
+
+func (o *BuildConfigOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	privKey, err := os.ReadFile(o.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.EnrollmentConfigWithResponse(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	err = validateHttpResponse(response.Body, response.StatusCode(), http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("failed to get enrollment config: %w", err)
+	}
+
+	config := response.JSON200
+	config.EnrollmentService.Authentication.ClientKeyData = base64.StdEncoding.EncodeToString(privKey)
+
+	if len(o.Fleet) > 0 {
+		fleetResponse, err := c.ReadFleetWithResponse(ctx, o.Fleet, nil)
+		if err != nil {
+			return err
+		}
+		err = validateHttpResponse(fleetResponse.Body, fleetResponse.StatusCode(), http.StatusOK)
+		if err != nil {
+			return fmt.Errorf("failed to get fleet %s: %w", o.Fleet, err)
+		}
+		labels := fleetLabels(fleetResponse.JSON200)
+		if len(labels) == 0 {
+			return fmt.Errorf("fleet %s does not select devices by labels", o.Fleet)
+		}
+		config.DefaultLabels = &labels
+	}
+
+	marshalled, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshalling agent config: %w", err)
+	}
+	if err := os.MkdirAll(o.OutputDir, 0755); err != nil {
+		return err
+	}
+	// the config contains the private key of the enrollment certificate
+	if err := os.WriteFile(filepath.Join(o.OutputDir, "config.yaml"), marshalled, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(o.OutputDir, "Containerfile"), []byte(containerfile(o.BaseImage)), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote config.yaml and Containerfile to %s\n", o.OutputDir)
+
+	if len(o.Type) == 0 {
+		return nil
+	}
+	return o.buildImages(ctx)
+}
+
+// buildImages builds the bootc image with podman and converts it into a disk
+// image in the output directory with bootc-image-builder.
+func (o *BuildConfigOptions) buildImages(ctx context.Context) error {
+	outputDir, err := filepath.Abs(filepath.Join(o.OutputDir, "output"))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	if err := runCommand(ctx, "podman", "build", "-t", o.Image, "-f", filepath.Join(o.OutputDir, "Containerfile"), o.OutputDir); err != nil {
+		return fmt.Errorf("building image %s: %w", o.Image, err)
+	}
+	err = runCommand(ctx, "podman", "run", "--rm", "--privileged", "--pull=newer",
+		"--security-opt", "label=type:unconfined_t",
+		"-v", outputDir+":/output",
+		"-v", "/var/lib/containers/storage:/var/lib/containers/storage",
+		o.ImageBuilderImage,
+		"--type", o.Type, "--local", o.Image)
+	if err != nil {
+		return fmt.Errorf("building %s disk image: %w", o.Type, err)
+	}
+	fmt.Printf("Wrote %s disk image to %s\n", o.Type, outputDir)
+	return nil
+}
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// fleetLabels returns the labels a device needs to be selected by the fleet.
+func fleetLabels(fleet *api.Fleet) map[string]string {
+	if fleet.Spec.Selector == nil {
+		return nil
+	}
+	return fleet.Spec.Selector.MatchLabels
+}
+
+func containerfile(baseImage string) string {
+	return fmt.Sprintf(`FROM %s
+
+RUN dnf -y copr enable @redhat-et/flightctl-dev centos-stream-9-x86_64 && \
+    dnf -y install flightctl-agent; \
+    dnf -y clean all; \
+    systemctl enable flightctl-agent.service
+
+ADD config.yaml /etc/flightctl/
+`, baseImage)
+}
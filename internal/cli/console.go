@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -10,18 +11,24 @@ import (
 	"time"
 
 	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api_server/agentserver"
 	"github.com/flightctl/flightctl/internal/auth/common"
 	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/term"
 	"google.golang.org/grpc/metadata"
+	certutil "k8s.io/client-go/util/cert"
 )
 
 type ConsoleOptions struct {
 	GlobalOptions
+
+	Plaintext bool
 }
 
 func DefaultConsoleOptions() *ConsoleOptions {
@@ -56,6 +63,8 @@ func NewConsoleCmd() *cobra.Command {
 
 func (o *ConsoleOptions) Bind(fs *pflag.FlagSet) {
 	o.GlobalOptions.Bind(fs)
+
+	fs.BoolVar(&o.Plaintext, "plaintext", o.Plaintext, "Do not encrypt the session end to end, for the agents which do not support it. The service and any relay of the device can then read the session.")
 }
 
 func (o *ConsoleOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -92,20 +101,43 @@ func (o *ConsoleOptions) Run(ctx context.Context, args []string) error { // noli
 	if err != nil {
 		return err
 	}
-	console, err := c.RequestConsoleWithResponse(ctx, name)
+	// the session is encrypted end to end with a key agreed with the device,
+	// which the relays forwarding it cannot read
+	var operatorKey *console.OperatorKey
+	params := &api.RequestConsoleParams{}
+	if !o.Plaintext {
+		operatorKey, err = console.NewOperatorKey()
+		if err != nil {
+			return fmt.Errorf("generating the session key: %w", err)
+		}
+		params.PublicKey = lo.ToPtr(operatorKey.PublicKey())
+	}
+	resp, err := c.RequestConsoleWithResponse(ctx, name, params)
 
 	if err != nil {
 		return fmt.Errorf("error requesting console: %w", err)
 	}
 
-	if console.HTTPResponse.StatusCode != 200 {
-		return fmt.Errorf("error requesting console: %s, %+v", console.HTTPResponse.Status, console.HTTPResponse.Body)
+	if resp.HTTPResponse.StatusCode != 200 {
+		return fmt.Errorf("error requesting console: %s, %s", resp.HTTPResponse.Status, strings.TrimSpace(string(resp.Body)))
 	}
 
-	grpcEndpoint := console.JSON200.GRPCEndpoint
-	sessionID := console.JSON200.SessionID
+	grpcEndpoint := resp.JSON200.GRPCEndpoint
+	sessionID := resp.JSON200.SessionID
+
+	var e2e *endToEnd
+	if operatorKey != nil {
+		if resp.JSON200.CaBundle == nil {
+			return fmt.Errorf("the service does not support end-to-end encrypted consoles, use --plaintext to connect anyway")
+		}
+		deviceCAs, err := certutil.NewPoolFromBytes([]byte(*resp.JSON200.CaBundle))
+		if err != nil {
+			return fmt.Errorf("parsing the CAs of the device certificates: %w", err)
+		}
+		e2e = &endToEnd{key: operatorKey, deviceName: name, deviceCAs: deviceCAs}
+	}
 
-	err = o.connectViaGRPC(ctx, grpcEndpoint, sessionID, config.AuthInfo.Token)
+	err = o.connectViaGRPC(ctx, grpcEndpoint, sessionID, config.AuthInfo.Token, e2e)
 	if err == io.EOF {
 		fmt.Println("Connection closed")
 		return nil
@@ -113,8 +145,16 @@ func (o *ConsoleOptions) Run(ctx context.Context, args []string) error { // noli
 	return err
 }
 
+// endToEnd is how the client verifies the device of an end-to-end encrypted
+// session.
+type endToEnd struct {
+	key        *console.OperatorKey
+	deviceName string
+	deviceCAs  *x509.CertPool
+}
+
 // TODO: Move this to a websocket call instead later, the console endpoint will redirect to a ws method
-func (o *ConsoleOptions) connectViaGRPC(ctx context.Context, grpcEndpoint, sessionID string, token string) error {
+func (o *ConsoleOptions) connectViaGRPC(ctx context.Context, grpcEndpoint, sessionID string, token string, e2e *endToEnd) error {
 	//grpcEndpoint = "grpcs://192.168.1.10:7444"
 	grpcEndpoint = strings.TrimRight(grpcEndpoint, "/")
 	fmt.Printf("Connecting to %s with session id %s\n", grpcEndpoint, sessionID)
@@ -132,6 +172,25 @@ func (o *ConsoleOptions) connectViaGRPC(ctx context.Context, grpcEndpoint, sessi
 		return fmt.Errorf("error creating stream: %w", err)
 	}
 
+	if e2e != nil {
+		// the device sends its handshake first, once it connects
+		fmt.Printf("Waiting for the device to agree the session key (agents without end-to-end encryption require --plaintext)\n")
+		frame, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("receiving the handshake of the device: %w", err)
+		}
+		if frame.Closed {
+			return io.EOF
+		}
+		session, err := e2e.key.Accept(frame.Payload, sessionID, e2e.deviceName, e2e.deviceCAs)
+		if err != nil {
+			_ = stream.Send(&grpc_v1.StreamRequest{Closed: true})
+			return fmt.Errorf("the device did not encrypt the session, its agent may not support end-to-end encryption (use --plaintext to connect anyway): %w", err)
+		}
+		stream = console.EncryptStream(stream, session)
+		fmt.Printf("The session is encrypted end to end with %s\n", e2e.deviceName)
+	}
+
 	return forwardStdio(ctx, stream)
 
 }
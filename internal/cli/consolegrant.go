@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	consoleGrantRequest = "request"
+	consoleGrantApprove = "approve"
+	consoleGrantRevoke  = "revoke"
+	consoleGrantShow    = "show"
+)
+
+type ConsoleGrantOptions struct {
+	GlobalOptions
+
+	Action      string
+	RequestedBy string
+	Reason      string
+	TTL         string
+	ApprovedBy  string
+}
+
+func DefaultConsoleGrantOptions(action string) *ConsoleGrantOptions {
+	return &ConsoleGrantOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Action:        action,
+	}
+}
+
+func NewCmdConsoleGrant() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console-grant",
+		Short: "Manage the grants required to open a console on devices such as production devices.",
+		Long: `Manage the one-off grants the service requires to open a console on the devices selected
+by its consoleGrants configuration. A grant is requested by one user, approved by a second
+user, and opens a single console before its TTL expires.`,
+	}
+	cmd.AddCommand(NewCmdConsoleGrantAction(consoleGrantRequest))
+	cmd.AddCommand(NewCmdConsoleGrantAction(consoleGrantApprove))
+	cmd.AddCommand(NewCmdConsoleGrantAction(consoleGrantRevoke))
+	cmd.AddCommand(NewCmdConsoleGrantAction(consoleGrantShow))
+	return cmd
+}
+
+func NewCmdConsoleGrantAction(action string) *cobra.Command {
+	o := DefaultConsoleGrantOptions(action)
+	cmd := &cobra.Command{
+		Use:  action + " device/NAME",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	switch action {
+	case consoleGrantRequest:
+		cmd.Short = "Request a grant to open a console on a device."
+	case consoleGrantApprove:
+		cmd.Short = "Approve the pending console grant of a device."
+		cmd.Long = `Approve the pending console grant of a device, which makes it valid for its TTL.
+A grant cannot be approved by the user who requested it.`
+	case consoleGrantRevoke:
+		cmd.Short = "Revoke the console grant of a device."
+	case consoleGrantShow:
+		cmd.Short = "Show the console grant of a device."
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ConsoleGrantOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	switch o.Action {
+	case consoleGrantRequest:
+		fs.StringVar(&o.RequestedBy, "requested-by", o.RequestedBy, "The name of the user requesting the grant.")
+		fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the console is needed, recorded in the audit log.")
+		fs.StringVar(&o.TTL, "ttl", o.TTL, "How long the grant is valid for once approved, such as 30m. Defaults to the maximum of the service.")
+	case consoleGrantApprove:
+		fs.StringVar(&o.ApprovedBy, "approved-by", o.ApprovedBy, "The name of the user approving the grant.")
+	}
+}
+
+func (o *ConsoleGrantOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ConsoleGrantOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device")
+	}
+	switch o.Action {
+	case consoleGrantRequest:
+		if strings.TrimSpace(o.RequestedBy) == "" || strings.TrimSpace(o.Reason) == "" {
+			return fmt.Errorf("specify who requests the grant with --requested-by and why with --reason")
+		}
+		if o.TTL != "" {
+			if _, err := time.ParseDuration(o.TTL); err != nil {
+				return fmt.Errorf("invalid --ttl: %w", err)
+			}
+		}
+	case consoleGrantApprove:
+		if strings.TrimSpace(o.ApprovedBy) == "" {
+			return fmt.Errorf("specify who approves the grant with --approved-by")
+		}
+	}
+	return nil
+}
+
+func (o *ConsoleGrantOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	var httpResponse *http.Response
+	var body []byte
+	var grant *api.ConsoleGrant
+	switch o.Action {
+	case consoleGrantRequest:
+		request := api.ConsoleGrantRequest{RequestedBy: o.RequestedBy, Reason: o.Reason}
+		if o.TTL != "" {
+			request.Ttl = &o.TTL
+		}
+		response, err := c.RequestConsoleGrantWithResponse(ctx, name, request)
+		if err != nil {
+			return fmt.Errorf("requesting console grant of device/%s: %w", name, err)
+		}
+		httpResponse, body, grant = response.HTTPResponse, response.Body, response.JSON200
+	case consoleGrantApprove:
+		response, err := c.ApproveConsoleGrantWithResponse(ctx, name, api.ConsoleGrantApproval{ApprovedBy: o.ApprovedBy})
+		if err != nil {
+			return fmt.Errorf("approving console grant of device/%s: %w", name, err)
+		}
+		httpResponse, body, grant = response.HTTPResponse, response.Body, response.JSON200
+	case consoleGrantRevoke:
+		response, err := c.RevokeConsoleGrantWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("revoking console grant of device/%s: %w", name, err)
+		}
+		httpResponse, body, grant = response.HTTPResponse, response.Body, response.JSON200
+	case consoleGrantShow:
+		response, err := c.GetConsoleGrantWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("getting console grant of device/%s: %w", name, err)
+		}
+		httpResponse, body, grant = response.HTTPResponse, response.Body, response.JSON200
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s console grant of device/%s: %d %s", o.Action, name, httpResponse.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	switch o.Action {
+	case consoleGrantRequest:
+		fmt.Printf("console grant %s of device/%s requested, pending approval by another user\n", grant.Id, name)
+	case consoleGrantApprove:
+		fmt.Printf("console grant %s of device/%s approved until %s\n", grant.Id, name, grant.ExpiresAt.Format(time.RFC3339))
+	case consoleGrantRevoke:
+		fmt.Printf("console grant %s of device/%s revoked\n", grant.Id, name)
+	case consoleGrantShow:
+		printConsoleGrant(grant)
+	}
+	return nil
+}
+
+func printConsoleGrant(grant *api.ConsoleGrant) {
+	fmt.Printf("ID:           %s\n", grant.Id)
+	fmt.Printf("Requested by: %s at %s\n", grant.RequestedBy, grant.RequestedAt.Format(time.RFC3339))
+	fmt.Printf("Reason:       %s\n", grant.Reason)
+	fmt.Printf("TTL:          %s\n", grant.Ttl)
+	if grant.ApprovedBy == nil {
+		fmt.Printf("Approved by:  <pending>\n")
+		return
+	}
+	fmt.Printf("Approved by:  %s at %s\n", *grant.ApprovedBy, lo.FromPtr(grant.ApprovedAt).Format(time.RFC3339))
+	fmt.Printf("Expires at:   %s\n", lo.FromPtr(grant.ExpiresAt).Format(time.RFC3339))
+}
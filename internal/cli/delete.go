@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -14,13 +16,15 @@ import (
 type DeleteOptions struct {
 	GlobalOptions
 
-	FleetName string
+	FleetName         string
+	PropagationPolicy string
 }
 
 func DefaultDeleteOptions() *DeleteOptions {
 	return &DeleteOptions{
-		GlobalOptions: DefaultGlobalOptions(),
-		FleetName:     "",
+		GlobalOptions:     DefaultGlobalOptions(),
+		FleetName:         "",
+		PropagationPolicy: "",
 	}
 }
 
@@ -50,6 +54,7 @@ func (o *DeleteOptions) Bind(fs *pflag.FlagSet) {
 	o.GlobalOptions.Bind(fs)
 
 	fs.StringVarP(&o.FleetName, "fleetname", "f", o.FleetName, "Fleet name for accessing templateversions.")
+	fs.StringVar(&o.PropagationPolicy, "propagation-policy", o.PropagationPolicy, "What to do with the resources depending on a fleet or repository: Block (default), Orphan or, for fleets, Cascade.")
 }
 
 func (o *DeleteOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -65,13 +70,25 @@ func (o *DeleteOptions) Validate(args []string) error {
 		return err
 	}
 
-	kind, _, err := parseAndValidateKindName(args[0])
+	kind, name, err := parseAndValidateKindName(args[0])
 	if err != nil {
 		return err
 	}
 	if kind == TemplateVersionKind && len(o.FleetName) == 0 {
 		return fmt.Errorf("fleetname must be specified when deleting templateversions")
 	}
+	if len(o.PropagationPolicy) > 0 {
+		if (kind != FleetKind && kind != RepositoryKind) || len(name) == 0 {
+			return fmt.Errorf("propagation-policy can only be specified when deleting a fleet or a repository")
+		}
+		policies := []api.DeletionPropagationPolicy{api.DeletionPropagationPolicyBlock, api.DeletionPropagationPolicyOrphan}
+		if kind == FleetKind {
+			policies = append(policies, api.DeletionPropagationPolicyCascade)
+		}
+		if !slices.Contains(policies, api.DeletionPropagationPolicy(o.PropagationPolicy)) {
+			return fmt.Errorf("propagation-policy must be one of %v", policies)
+		}
+	}
 	return nil
 }
 
@@ -98,7 +115,7 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error { // nolin
 	case kind == EnrollmentRequestKind && len(name) == 0:
 		response, err = c.DeleteEnrollmentRequestsWithResponse(ctx)
 	case kind == FleetKind && len(name) > 0:
-		response, err = c.DeleteFleetWithResponse(ctx, name)
+		response, err = c.DeleteFleetWithResponse(ctx, name, &api.DeleteFleetParams{PropagationPolicy: o.propagationPolicy()})
 	case kind == FleetKind && len(name) == 0:
 		response, err = c.DeleteFleetsWithResponse(ctx)
 	case kind == TemplateVersionKind && len(name) > 0:
@@ -106,7 +123,7 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error { // nolin
 	case kind == TemplateVersionKind && len(name) == 0:
 		response, err = c.DeleteTemplateVersionsWithResponse(ctx, o.FleetName)
 	case kind == RepositoryKind && len(name) > 0:
-		response, err = c.DeleteRepositoryWithResponse(ctx, name)
+		response, err = c.DeleteRepositoryWithResponse(ctx, name, &api.DeleteRepositoryParams{PropagationPolicy: o.propagationPolicy()})
 	case kind == RepositoryKind && len(name) == 0:
 		response, err = c.DeleteRepositoriesWithResponse(ctx)
 	case kind == ResourceSyncKind && len(name) > 0:
@@ -117,6 +134,26 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error { // nolin
 		response, err = c.DeleteCertificateSigningRequestWithResponse(ctx, name)
 	case kind == CertificateSigningRequestKind && len(name) == 0:
 		response, err = c.DeleteCertificateSigningRequestsWithResponse(ctx)
+	case kind == WebhookKind && len(name) > 0:
+		response, err = c.DeleteWebhookWithResponse(ctx, name)
+	case kind == WebhookKind && len(name) == 0:
+		response, err = c.DeleteWebhooksWithResponse(ctx)
+	case kind == LabelRuleKind && len(name) > 0:
+		response, err = c.DeleteLabelRuleWithResponse(ctx, name)
+	case kind == LabelRuleKind && len(name) == 0:
+		response, err = c.DeleteLabelRulesWithResponse(ctx)
+	case kind == DeviceIdentityKind && len(name) > 0:
+		response, err = c.DeleteDeviceIdentityWithResponse(ctx, name)
+	case kind == DeviceIdentityKind && len(name) == 0:
+		response, err = c.DeleteDeviceIdentitiesWithResponse(ctx)
+	case kind == DeviceViewKind && len(name) > 0:
+		response, err = c.DeleteDeviceViewWithResponse(ctx, name)
+	case kind == DeviceViewKind && len(name) == 0:
+		response, err = c.DeleteDeviceViewsWithResponse(ctx)
+	case kind == SiteKind && len(name) > 0:
+		response, err = c.DeleteSiteWithResponse(ctx, name)
+	case kind == SiteKind && len(name) == 0:
+		response, err = c.DeleteSitesWithResponse(ctx)
 	default:
 		return fmt.Errorf("unsupported resource kind: %s", kind)
 	}
@@ -124,6 +161,14 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error { // nolin
 	return processDeletionReponse(response, err, kind, name)
 }
 
+func (o *DeleteOptions) propagationPolicy() *api.DeletionPropagationPolicy {
+	if len(o.PropagationPolicy) == 0 {
+		return nil
+	}
+	policy := api.DeletionPropagationPolicy(o.PropagationPolicy)
+	return &policy
+}
+
 func processDeletionReponse(response interface{}, err error, kind string, name string) error {
 	errorPrefix := fmt.Sprintf("deleting %s", kind)
 	if len(name) > 0 {
@@ -135,6 +180,9 @@ func processDeletionReponse(response interface{}, err error, kind string, name s
 	}
 
 	v := reflect.ValueOf(response).Elem()
+	if conflict := v.FieldByName("JSON409"); conflict.IsValid() && !conflict.IsNil() {
+		return fmt.Errorf("%s: %s", errorPrefix, conflict.Interface().(*api.Error).Message)
+	}
 	if v.FieldByName("HTTPResponse").Elem().FieldByName("StatusCode").Int() != http.StatusOK {
 		return fmt.Errorf(errorPrefix+": %s (%d)", v.FieldByName("HTTPResponse").Elem().FieldByName("Status").String(), v.FieldByName("HTTPResponse").Elem().FieldByName("StatusCode").Int())
 	}
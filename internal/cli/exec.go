@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type ExecOptions struct {
+	GlobalOptions
+
+	Reason string
+}
+
+func DefaultExecOptions() *ExecOptions {
+	return &ExecOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Reason:        "",
+	}
+}
+
+func NewCmdExec() *cobra.Command {
+	o := DefaultExecOptions()
+	cmd := &cobra.Command{
+		Use:   "exec device/NAME -- COMMAND [ARGS...]",
+		Short: "Run a command allowed by the exec policies of the service on a device.",
+		Long: `Run a command on a device, which the service only dispatches if one of its exec
+policies allows the command and its arguments for the fleet of the device. The agent
+runs the command once it next fetches its rendered spec, and reports its exit code and
+output in status.lastAction. The command must be an absolute path.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ExecOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the command is run, recorded in the audit log.")
+}
+
+func (o *ExecOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ExecOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device")
+	}
+	if !strings.HasPrefix(args[1], "/") {
+		return fmt.Errorf("the command must be an absolute path")
+	}
+	return nil
+}
+
+func (o *ExecOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	body := api.DeviceExecRequest{Command: args[1]}
+	if len(args) > 2 {
+		commandArgs := args[2:]
+		body.Args = &commandArgs
+	}
+	if reason := strings.TrimSpace(o.Reason); reason != "" {
+		body.Reason = &reason
+	}
+	response, err := c.ExecDeviceCommandWithResponse(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("running command on device/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		if response.JSON400 != nil {
+			return fmt.Errorf("running command on device/%s: %s", name, response.JSON400.Message)
+		}
+		if response.JSON403 != nil {
+			return fmt.Errorf("running command on device/%s: %s", name, response.JSON403.Message)
+		}
+		if response.JSON409 != nil {
+			return fmt.Errorf("running command on device/%s: %s", name, response.JSON409.Message)
+		}
+		return fmt.Errorf("running command on device/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+	fmt.Printf("device/%s: command requested as action %s, its output is reported in status.lastAction\n", name, response.JSON200.Id)
+	return nil
+}
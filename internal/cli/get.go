@@ -16,6 +16,7 @@ import (
 	apiclient "github.com/flightctl/flightctl/internal/api/client"
 	"github.com/flightctl/flightctl/internal/client"
 	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/thoas/go-funk"
@@ -34,26 +35,36 @@ var (
 type GetOptions struct {
 	GlobalOptions
 
-	Owner         string
-	LabelSelector string
-	StatusFilter  []string
-	Output        string
-	Limit         int32
-	Continue      string
-	FleetName     string
-	Rendered      bool
+	Owner              string
+	LabelSelector      string
+	StatusFilter       []string
+	Output             string
+	Limit              int32
+	Continue           string
+	FleetName          string
+	Rendered           bool
+	BoundingBox        string
+	AnnotationSelector string
+	Alias              string
+	AppRestarts        int32
+	View               string
 }
 
 func DefaultGetOptions() *GetOptions {
 	return &GetOptions{
-		GlobalOptions: DefaultGlobalOptions(),
-		Owner:         "",
-		LabelSelector: "",
-		StatusFilter:  []string{},
-		Limit:         0,
-		Continue:      "",
-		FleetName:     "",
-		Rendered:      false,
+		GlobalOptions:      DefaultGlobalOptions(),
+		Owner:              "",
+		LabelSelector:      "",
+		StatusFilter:       []string{},
+		Limit:              0,
+		Continue:           "",
+		FleetName:          "",
+		Rendered:           false,
+		BoundingBox:        "",
+		AnnotationSelector: "",
+		Alias:              "",
+		AppRestarts:        0,
+		View:               "",
 	}
 }
 
@@ -90,6 +101,11 @@ func (o *GetOptions) Bind(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Continue, "continue", o.Continue, "Query more results starting from the value of the 'continue' field in the previous response.")
 	fs.StringVar(&o.FleetName, "fleetname", o.FleetName, "Fleet name for accessing templateversions (use only when getting templateversions).")
 	fs.BoolVar(&o.Rendered, "rendered", false, "Return the rendered device configuration that is presented to the device (use only when getting devices).")
+	fs.StringVar(&o.BoundingBox, "bounding-box", o.BoundingBox, "Filter the devices by their reported location using a bounding box in degrees. Example: --bounding-box=west,south,east,north (use only when listing devices).")
+	fs.StringVar(&o.AnnotationSelector, "annotation-selector", o.AnnotationSelector, "Filter the devices by the annotations written by their agent, as a comma-separated list of key or key=value. Example: --annotation-selector=hardware.flightctl.io/changed (use only when listing devices).")
+	fs.StringVar(&o.Alias, "alias", o.Alias, "Filter the devices by their name, display name or one of their aliases (use only when listing devices).")
+	fs.Int32Var(&o.AppRestarts, "app-restarts", o.AppRestarts, "Filter the devices by an application which restarted at least this many times. Example: --app-restarts=5 (use only when listing devices).")
+	fs.StringVar(&o.View, "view", o.View, "List the devices of the named device view with its columns (use only when listing devices).")
 }
 
 func (o *GetOptions) Complete(cmd *cobra.Command, args []string) error {
@@ -130,6 +146,29 @@ func (o *GetOptions) Validate(args []string) error {
 	if o.Rendered && (kind != DeviceKind || len(name) == 0) {
 		return fmt.Errorf("rendered must only be specified when fetching a specific device")
 	}
+	if len(o.BoundingBox) > 0 && (kind != DeviceKind || len(name) > 0) {
+		return fmt.Errorf("bounding-box can only be specified when listing devices")
+	}
+	if len(o.AnnotationSelector) > 0 && (kind != DeviceKind || len(name) > 0) {
+		return fmt.Errorf("annotation-selector can only be specified when listing devices")
+	}
+	if len(o.Alias) > 0 && (kind != DeviceKind || len(name) > 0) {
+		return fmt.Errorf("alias can only be specified when listing devices")
+	}
+	if o.AppRestarts != 0 && (kind != DeviceKind || len(name) > 0) {
+		return fmt.Errorf("app-restarts can only be specified when listing devices")
+	}
+	if o.AppRestarts < 0 {
+		return fmt.Errorf("app-restarts must be greater than 0")
+	}
+	if len(o.View) > 0 {
+		if kind != DeviceKind || len(name) > 0 {
+			return fmt.Errorf("view can only be specified when listing devices")
+		}
+		if len(o.Owner) > 0 || len(o.StatusFilter) > 0 || len(o.BoundingBox) > 0 || len(o.AnnotationSelector) > 0 || len(o.Alias) > 0 || o.AppRestarts != 0 {
+			return fmt.Errorf("view can only be combined with a label selector")
+		}
+	}
 	if len(o.Output) > 0 && !funk.Contains(legalOutputTypes, o.Output) {
 		return fmt.Errorf("output format must be one of %s", strings.Join(legalOutputTypes, ", "))
 	}
@@ -156,13 +195,19 @@ func (o *GetOptions) Run(ctx context.Context, args []string) error { // nolint:
 		response, err = c.ReadDeviceWithResponse(ctx, name)
 	case kind == DeviceKind && len(name) > 0 && o.Rendered:
 		response, err = c.GetRenderedDeviceSpecWithResponse(ctx, name, &api.GetRenderedDeviceSpecParams{})
+	case kind == DeviceKind && len(name) == 0 && len(o.View) > 0:
+		response, err = o.listDeviceViewDevices(ctx, c)
 	case kind == DeviceKind && len(name) == 0:
 		params := api.ListDevicesParams{
-			Owner:         util.StrToPtrWithNilDefault(o.Owner),
-			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
-			StatusFilter:  util.SliceToPtrWithNilDefault(o.StatusFilter),
-			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
-			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+			Owner:               util.StrToPtrWithNilDefault(o.Owner),
+			LabelSelector:       util.StrToPtrWithNilDefault(o.LabelSelector),
+			StatusFilter:        util.SliceToPtrWithNilDefault(o.StatusFilter),
+			Limit:               util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:            util.StrToPtrWithNilDefault(o.Continue),
+			BoundingBox:         util.StrToPtrWithNilDefault(o.BoundingBox),
+			AnnotationSelector:  util.StrToPtrWithNilDefault(o.AnnotationSelector),
+			Alias:               util.StrToPtrWithNilDefault(o.Alias),
+			ApplicationRestarts: util.Int32ToPtrWithNilDefault(o.AppRestarts),
 		}
 		response, err = c.ListDevicesWithResponse(ctx, &params)
 	case kind == EnrollmentRequestKind && len(name) > 0:
@@ -220,12 +265,87 @@ func (o *GetOptions) Run(ctx context.Context, args []string) error { // nolint:
 			Continue:      util.StrToPtrWithNilDefault(o.Continue),
 		}
 		response, err = c.ListCertificateSigningRequestsWithResponse(ctx, &params)
+	case kind == WebhookKind && len(name) > 0:
+		response, err = c.ReadWebhookWithResponse(ctx, name)
+	case kind == WebhookKind && len(name) == 0:
+		params := api.ListWebhooksParams{
+			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+		}
+		response, err = c.ListWebhooksWithResponse(ctx, &params)
+	case kind == LabelRuleKind && len(name) > 0:
+		response, err = c.ReadLabelRuleWithResponse(ctx, name)
+	case kind == LabelRuleKind && len(name) == 0:
+		params := api.ListLabelRulesParams{
+			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+		}
+		response, err = c.ListLabelRulesWithResponse(ctx, &params)
+	case kind == DeviceIdentityKind && len(name) > 0:
+		response, err = c.ReadDeviceIdentityWithResponse(ctx, name)
+	case kind == DeviceIdentityKind && len(name) == 0:
+		params := api.ListDeviceIdentitiesParams{
+			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+		}
+		response, err = c.ListDeviceIdentitiesWithResponse(ctx, &params)
+	case kind == DeviceViewKind && len(name) > 0:
+		response, err = c.ReadDeviceViewWithResponse(ctx, name)
+	case kind == DeviceViewKind && len(name) == 0:
+		params := api.ListDeviceViewsParams{
+			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+		}
+		response, err = c.ListDeviceViewsWithResponse(ctx, &params)
+	case kind == SiteKind && len(name) > 0:
+		response, err = c.ReadSiteWithResponse(ctx, name)
+	case kind == SiteKind && len(name) == 0:
+		params := api.ListSitesParams{
+			LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+			Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+			Continue:      util.StrToPtrWithNilDefault(o.Continue),
+		}
+		response, err = c.ListSitesWithResponse(ctx, &params)
 	default:
 		return fmt.Errorf("unsupported resource kind: %s", kind)
 	}
 	return processReponse(response, err, kind, name, o.Output)
 }
 
+// deviceViewDevicesResponse carries the columns of a device view along with
+// its devices, so that they are printed the way the view shows them.
+type deviceViewDevicesResponse struct {
+	*apiclient.ListDeviceViewDevicesResponse
+	columns []api.DeviceViewColumn
+}
+
+func (o *GetOptions) listDeviceViewDevices(ctx context.Context, c *apiclient.ClientWithResponses) (*deviceViewDevicesResponse, error) {
+	view, err := c.ReadDeviceViewWithResponse(ctx, o.View)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s/%s: %w", DeviceViewKind, o.View, err)
+	}
+	if view.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading %s/%s: %d", DeviceViewKind, o.View, view.HTTPResponse.StatusCode)
+	}
+	params := api.ListDeviceViewDevicesParams{
+		LabelSelector: util.StrToPtrWithNilDefault(o.LabelSelector),
+		Limit:         util.Int32ToPtrWithNilDefault(o.Limit),
+		Continue:      util.StrToPtrWithNilDefault(o.Continue),
+	}
+	response, err := c.ListDeviceViewDevicesWithResponse(ctx, o.View, &params)
+	if err != nil {
+		return nil, err
+	}
+	return &deviceViewDevicesResponse{
+		ListDeviceViewDevicesResponse: response,
+		columns:                       lo.FromPtr(view.JSON200.Spec.Columns),
+	}, nil
+}
+
 func processReponse(response interface{}, err error, kind string, name string, output string) error {
 	errorPrefix := fmt.Sprintf("reading %s/%s", kind, name)
 	if len(name) == 0 {
@@ -265,6 +385,10 @@ func printTable(response interface{}, kind string, name string) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
 	switch {
 	case kind == DeviceKind && len(name) == 0:
+		if r, ok := response.(*deviceViewDevicesResponse); ok {
+			printDeviceColumnsTable(w, r.columns, r.JSON200.Items...)
+			break
+		}
 		printDevicesTable(w, response.(*apiclient.ListDevicesResponse).JSON200.Items...)
 	case kind == DeviceKind && len(name) > 0:
 		printDevicesTable(w, *(response.(*apiclient.ReadDeviceResponse).JSON200))
@@ -292,6 +416,26 @@ func printTable(response interface{}, kind string, name string) error {
 		printCSRTable(w, response.(*apiclient.ListCertificateSigningRequestsResponse).JSON200.Items...)
 	case kind == CertificateSigningRequestKind && len(name) > 0:
 		printCSRTable(w, *(response.(*apiclient.ReadCertificateSigningRequestResponse).JSON200))
+	case kind == WebhookKind && len(name) == 0:
+		printWebhooksTable(w, response.(*apiclient.ListWebhooksResponse).JSON200.Items...)
+	case kind == WebhookKind && len(name) > 0:
+		printWebhooksTable(w, *(response.(*apiclient.ReadWebhookResponse).JSON200))
+	case kind == LabelRuleKind && len(name) == 0:
+		printLabelRulesTable(w, response.(*apiclient.ListLabelRulesResponse).JSON200.Items...)
+	case kind == LabelRuleKind && len(name) > 0:
+		printLabelRulesTable(w, *(response.(*apiclient.ReadLabelRuleResponse).JSON200))
+	case kind == DeviceIdentityKind && len(name) == 0:
+		printDeviceIdentitiesTable(w, response.(*apiclient.ListDeviceIdentitiesResponse).JSON200.Items...)
+	case kind == DeviceIdentityKind && len(name) > 0:
+		printDeviceIdentitiesTable(w, *(response.(*apiclient.ReadDeviceIdentityResponse).JSON200))
+	case kind == DeviceViewKind && len(name) == 0:
+		printDeviceViewsTable(w, response.(*apiclient.ListDeviceViewsResponse).JSON200.Items...)
+	case kind == DeviceViewKind && len(name) > 0:
+		printDeviceViewsTable(w, *(response.(*apiclient.ReadDeviceViewResponse).JSON200))
+	case kind == SiteKind && len(name) == 0:
+		printSitesTable(w, response.(*apiclient.ListSitesResponse).JSON200.Items...)
+	case kind == SiteKind && len(name) > 0:
+		printSitesTable(w, *(response.(*apiclient.ReadSiteResponse).JSON200))
 	default:
 		return fmt.Errorf("unknown resource type %s", kind)
 	}
@@ -299,31 +443,71 @@ func printTable(response interface{}, kind string, name string) error {
 	return nil
 }
 
-func printDevicesTable(w *tabwriter.Writer, devices ...api.Device) {
-	fmt.Fprintln(w, "NAME\tALIAS\tOWNER\tSYSTEM\tUPDATED\tAPPLICATIONS\tLAST SEEN")
-	for _, d := range devices {
-		lastSeen := "<never>"
-		if !d.Status.LastSeen.IsZero() {
-			lastSeen = humanize.Time(d.Status.LastSeen)
+// deviceColumns are the headers and values of the columns devices can be printed with.
+var deviceColumns = map[api.DeviceViewColumn]struct {
+	header string
+	value  func(d *api.Device) string
+}{
+	api.DeviceViewColumnName: {"NAME", func(d *api.Device) string { return *d.Metadata.Name }},
+	api.DeviceViewColumnDisplayName: {"DISPLAY NAME", func(d *api.Device) string {
+		// devices named before they had display names carry it in an alias label
+		displayName := lo.FromPtr(d.Metadata.DisplayName)
+		if displayName == "" && d.Metadata.Labels != nil {
+			displayName = (*d.Metadata.Labels)["alias"]
 		}
-		alias := ""
-		if d.Metadata.Labels != nil {
-			alias = (*d.Metadata.Labels)["alias"]
+		return displayName
+	}},
+	api.DeviceViewColumnOwner: {"OWNER", func(d *api.Device) string { return util.DefaultIfNil(d.Metadata.Owner, "<none>") }},
+	api.DeviceViewColumnLabels: {"LABELS", func(d *api.Device) string {
+		return strings.Join(util.LabelMapToArray(d.Metadata.Labels), ",")
+	}},
+	api.DeviceViewColumnSystem:       {"SYSTEM", func(d *api.Device) string { return string(d.Status.Summary.Status) }},
+	api.DeviceViewColumnUpdated:      {"UPDATED", func(d *api.Device) string { return string(d.Status.Updated.Status) }},
+	api.DeviceViewColumnApplications: {"APPLICATIONS", func(d *api.Device) string { return string(d.Status.Applications.Summary.Status) }},
+	api.DeviceViewColumnArchitecture: {"ARCHITECTURE", func(d *api.Device) string { return d.Status.SystemInfo.Architecture }},
+	api.DeviceViewColumnOS:           {"OS", func(d *api.Device) string { return d.Status.Os.Image }},
+	api.DeviceViewColumnLastSeen: {"LAST SEEN", func(d *api.Device) string {
+		if d.Status.LastSeen.IsZero() {
+			return "<never>"
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			*d.Metadata.Name,
-			alias,
-			util.DefaultIfNil(d.Metadata.Owner, "<none>"),
-			d.Status.Summary.Status,
-			d.Status.Updated.Status,
-			d.Status.Applications.Summary.Status,
-			lastSeen,
-		)
+		return humanize.Time(d.Status.LastSeen)
+	}},
+}
+
+var defaultDeviceColumns = []api.DeviceViewColumn{
+	api.DeviceViewColumnName,
+	api.DeviceViewColumnDisplayName,
+	api.DeviceViewColumnOwner,
+	api.DeviceViewColumnSystem,
+	api.DeviceViewColumnUpdated,
+	api.DeviceViewColumnApplications,
+	api.DeviceViewColumnLastSeen,
+}
+
+func printDevicesTable(w *tabwriter.Writer, devices ...api.Device) {
+	printDeviceColumnsTable(w, defaultDeviceColumns, devices...)
+}
+
+func printDeviceColumnsTable(w *tabwriter.Writer, columns []api.DeviceViewColumn, devices ...api.Device) {
+	if len(columns) == 0 {
+		columns = defaultDeviceColumns
+	}
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = deviceColumns[column].header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for i := range devices {
+		values := make([]string, len(columns))
+		for j, column := range columns {
+			values[j] = deviceColumns[column].value(&devices[i])
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
 	}
 }
 
 func printEnrollmentRequestsTable(w *tabwriter.Writer, ers ...api.EnrollmentRequest) {
-	fmt.Fprintln(w, "NAME\tAPPROVAL\tAPPROVER\tAPPROVED LABELS")
+	fmt.Fprintln(w, "NAME\tAPPROVAL\tAPPROVER\tAPPROVED LABELS\tDUPLICATE OF")
 	for _, e := range ers {
 		approval, approver, approvedLabels := "Pending", "<none>", ""
 		if e.Status.Approval != nil {
@@ -333,11 +517,12 @@ func printEnrollmentRequestsTable(w *tabwriter.Writer, ers ...api.EnrollmentRequ
 			}
 			approvedLabels = strings.Join(util.LabelMapToArray(e.Status.Approval.Labels), ",")
 		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
 			*e.Metadata.Name,
 			approval,
 			approver,
 			approvedLabels,
+			util.DefaultIfNil(e.Status.DuplicateOf, NoneString),
 		)
 	}
 }
@@ -384,24 +569,30 @@ func printTemplateVersionsTable(w *tabwriter.Writer, tvs ...api.TemplateVersion)
 }
 
 func printRepositoriesTable(w *tabwriter.Writer, repos ...api.Repository) {
-	fmt.Fprintln(w, "NAME\tTYPE\tREPOSITORY URL\tACCESSIBLE")
+	fmt.Fprintln(w, "NAME\tTYPE\tREPOSITORY URL\tACCESSIBLE\tREASON\tLAST ACCESSED")
 	for _, r := range repos {
-		accessible := "Unknown"
+		accessible, reason, lastAccessed := "Unknown", "", "Never"
 		if r.Status != nil {
 			condition := api.FindStatusCondition(r.Status.Conditions, api.RepositoryAccessible)
 			if condition != nil {
 				accessible = string(condition.Status)
+				reason = condition.Reason
+			}
+			if r.Status.LastAccessedAt != nil {
+				lastAccessed = humanize.Time(*r.Status.LastAccessedAt)
 			}
 		}
 
 		repoSpec, _ := r.Spec.GetGenericRepoSpec()
 		repoType := repoSpec.Type
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
 			*r.Metadata.Name,
 			fmt.Sprintf("%v", repoType),
 			util.DefaultIfError(r.Spec.GetRepoURL, ""),
 			accessible,
+			reason,
+			lastAccessed,
 		)
 	}
 }
@@ -467,3 +658,130 @@ func printCSRTable(w *tabwriter.Writer, csrs ...api.CertificateSigningRequest) {
 		)
 	}
 }
+
+func printWebhooksTable(w *tabwriter.Writer, webhooks ...api.Webhook) {
+	fmt.Fprintln(w, "NAME\tURL\tSELECTOR\tTRIGGERS\tDEDUP WINDOW")
+
+	for _, wh := range webhooks {
+		selector := NoneString
+		if wh.Spec.Selector != nil {
+			selector = strings.Join(util.LabelMapToArray(&wh.Spec.Selector.MatchLabels), ",")
+		}
+		triggers := make([]string, len(wh.Spec.Triggers))
+		for i, trigger := range wh.Spec.Triggers {
+			switch {
+			case trigger.SummaryStatus != nil:
+				triggers[i] = string(*trigger.SummaryStatus)
+			case trigger.ConditionType != nil:
+				triggers[i] = fmt.Sprintf("%s=%s", *trigger.ConditionType, lo.FromPtrOr(trigger.ConditionStatus, api.ConditionStatusTrue))
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			*wh.Metadata.Name,
+			wh.Spec.Url,
+			selector,
+			strings.Join(triggers, ","),
+			util.DefaultIfNil(wh.Spec.DedupWindow, NoneString),
+		)
+	}
+}
+
+func printLabelRulesTable(w *tabwriter.Writer, rules ...api.LabelRule) {
+	fmt.Fprintln(w, "NAME\tFIELD\tLABEL\tMATCH VALUES")
+
+	for _, rule := range rules {
+		matchValues := NoneString
+		if rule.Spec.MatchValues != nil {
+			matchValues = strings.Join(*rule.Spec.MatchValues, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s=%s\t%s\n",
+			*rule.Metadata.Name,
+			rule.Spec.Field,
+			rule.Spec.LabelKey,
+			util.DefaultIfNil(rule.Spec.LabelValue, "<fact>"),
+			matchValues,
+		)
+	}
+}
+
+func printDeviceIdentitiesTable(w *tabwriter.Writer, identities ...api.DeviceIdentity) {
+	fmt.Fprintln(w, "NAME\tSERIAL NUMBER\tMAC ADDRESS\tTPM EK HASH\tLABELS\tPROVISIONED")
+
+	for _, identity := range identities {
+		provisioned := NoneString
+		if identity.Status != nil && identity.Status.ProvisionedAt != nil {
+			provisioned = humanize.Time(*identity.Status.ProvisionedAt)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			*identity.Metadata.Name,
+			util.DefaultIfNil(identity.Spec.SerialNumber, NoneString),
+			util.DefaultIfNil(identity.Spec.MacAddress, NoneString),
+			util.DefaultIfNil(identity.Spec.TpmEkHash, NoneString),
+			strings.Join(util.LabelMapToArray(identity.Spec.Labels), ","),
+			provisioned,
+		)
+	}
+}
+
+func printDeviceViewsTable(w *tabwriter.Writer, views ...api.DeviceView) {
+	fmt.Fprintln(w, "NAME\tDESCRIPTION\tSELECTOR\tCOLUMNS")
+
+	for _, view := range views {
+		selector := deviceViewSelectorString(view.Spec.Selector)
+		columns := "<default>"
+		if view.Spec.Columns != nil && len(*view.Spec.Columns) > 0 {
+			columns = strings.Join(lo.Map(*view.Spec.Columns, func(c api.DeviceViewColumn, _ int) string { return string(c) }), ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			*view.Metadata.Name,
+			util.DefaultIfNil(view.Spec.Description, NoneString),
+			selector,
+			columns,
+		)
+	}
+}
+
+func printSitesTable(w *tabwriter.Writer, sites ...api.Site) {
+	fmt.Fprintln(w, "NAME\tDESCRIPTION\tBANDWIDTH BUDGET")
+
+	for _, site := range sites {
+		budget := "<unlimited>"
+		if site.Spec.BandwidthBudget != nil {
+			budget = humanize.Bytes(uint64(*site.Spec.BandwidthBudget)) + "/s"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n",
+			*site.Metadata.Name,
+			util.DefaultIfNil(site.Spec.Description, NoneString),
+			budget,
+		)
+	}
+}
+
+func deviceViewSelectorString(selector api.DeviceViewSelector) string {
+	filters := []string{}
+	if selector.LabelSelector != nil {
+		filters = append(filters, *selector.LabelSelector)
+	}
+	if selector.Owner != nil {
+		filters = append(filters, "owner="+*selector.Owner)
+	}
+	if selector.StatusFilter != nil {
+		filters = append(filters, *selector.StatusFilter...)
+	}
+	if selector.AnnotationSelector != nil {
+		filters = append(filters, "annotations:"+*selector.AnnotationSelector)
+	}
+	if selector.BoundingBox != nil {
+		filters = append(filters, "bounding-box="+*selector.BoundingBox)
+	}
+	if selector.Alias != nil {
+		filters = append(filters, "alias="+*selector.Alias)
+	}
+	if selector.ApplicationRestarts != nil {
+		filters = append(filters, fmt.Sprintf("app-restarts=%d", *selector.ApplicationRestarts))
+	}
+	if len(filters) == 0 {
+		return NoneString
+	}
+	return strings.Join(filters, ",")
+}
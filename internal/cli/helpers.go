@@ -20,6 +20,11 @@ const (
 	ResourceSyncKind              = "resourcesync"
 	TemplateVersionKind           = "templateversion"
 	CertificateSigningRequestKind = "certificatesigningrequest"
+	WebhookKind                   = "webhook"
+	LabelRuleKind                 = "labelrule"
+	DeviceIdentityKind            = "deviceidentity"
+	DeviceViewKind                = "deviceview"
+	SiteKind                      = "site"
 )
 
 var (
@@ -31,6 +36,11 @@ var (
 		ResourceSyncKind:              "resourcesyncs",
 		TemplateVersionKind:           "templateversions",
 		CertificateSigningRequestKind: "certificatesigningrequests",
+		WebhookKind:                   "webhooks",
+		LabelRuleKind:                 "labelrules",
+		DeviceIdentityKind:            "deviceidentities",
+		DeviceViewKind:                "deviceviews",
+		SiteKind:                      "sites",
 	}
 
 	shortnameKinds = map[string]string{
@@ -41,6 +51,11 @@ var (
 		ResourceSyncKind:              "rs",
 		TemplateVersionKind:           "tv",
 		CertificateSigningRequestKind: "csr",
+		WebhookKind:                   "wh",
+		LabelRuleKind:                 "lr",
+		DeviceIdentityKind:            "di",
+		DeviceViewKind:                "dv",
+		SiteKind:                      "st",
 	}
 )
 
@@ -88,12 +103,20 @@ func fullname(kind string) string {
 
 func validateHttpResponse(responseBody []byte, statusCode int, expectedStatusCode int) error {
 	if statusCode != expectedStatusCode {
-		var responseError api.Error
-		err := json.Unmarshal(responseBody, &responseError)
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf("%d %s", statusCode, responseError.Message)
+		return fmt.Errorf("%d %s", statusCode, errorMessage(responseBody))
 	}
 	return nil
 }
+
+// errorMessage returns the message of an error response of the API with its
+// reason, or the body as it is if it is not an error response.
+func errorMessage(responseBody []byte) string {
+	var responseError api.Error
+	if json.Unmarshal(responseBody, &responseError) != nil || responseError.Message == "" {
+		return strings.TrimSpace(string(responseBody))
+	}
+	if responseError.Reason == nil {
+		return responseError.Message
+	}
+	return fmt.Sprintf("%s (%s)", responseError.Message, *responseError.Reason)
+}
@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// bulkImportBatchSize is the number of resources sent in each bulk import,
+// which is the most the service accepts.
+const bulkImportBatchSize = 10000
+
+type ImportOptions struct {
+	GlobalOptions
+
+	Filenames []string
+	DryRun    bool
+}
+
+func DefaultImportOptions() *ImportOptions {
+	return &ImportOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Filenames:     []string{},
+		DryRun:        false,
+	}
+}
+
+func NewCmdImport() *cobra.Command {
+	o := DefaultImportOptions()
+	cmd := &cobra.Command{
+		Use:   "import -f FILENAME",
+		Short: "Create devices and fleets in bulk from CSV, YAML or JSON files.",
+		Long: `Create devices and fleets in bulk, such as the devices of an inventory pre-registered
+before they enroll. A CSV file lists a device on each row, with a header row naming the
+columns: the "name" column holds the name of the device, and every other column a label
+of the device, such as "site". Empty cells set no label. YAML and JSON files hold devices
+and fleets as for apply.
+
+The resources are sent in batches of up to 10000, and those which already exist or are not
+valid are reported without preventing the others from being created.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ImportOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringSliceVarP(&o.Filenames, "filename", "f", o.Filenames, "The CSV, YAML or JSON files that contain the resources to import.")
+	fs.BoolVarP(&o.DryRun, "dry-run", "", o.DryRun, "Only print the number of resources that would be imported, without sending them.")
+}
+
+func (o *ImportOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ImportOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	if len(o.Filenames) == 0 {
+		return fmt.Errorf("must specify -f FILENAME")
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v (did you forget to quote wildcards?)", args)
+	}
+	return nil
+}
+
+func (o *ImportOptions) Run(ctx context.Context, args []string) error {
+	imported := api.BulkImport{Fleets: &[]api.Fleet{}, Devices: &[]api.Device{}}
+	for _, filename := range o.Filenames {
+		if err := readImportFile(filename, &imported); err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+	if o.DryRun {
+		fmt.Printf("importing %d fleets and %d devices (dry run only)\n", len(*imported.Fleets), len(*imported.Devices))
+		return nil
+	}
+
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	var created int64
+	failures := []api.BulkImportFailure{}
+	for _, batch := range bulkImportBatches(imported, bulkImportBatchSize) {
+		response, err := c.CreateBulkImportWithResponse(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("importing: %w", err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			if response.JSON400 != nil {
+				return fmt.Errorf("importing: %s", response.JSON400.Message)
+			}
+			return fmt.Errorf("importing: %d", response.HTTPResponse.StatusCode)
+		}
+		created += response.JSON200.Created
+		failures = append(failures, response.JSON200.Failures...)
+	}
+
+	for _, failure := range failures {
+		fmt.Printf("%s/%s: %s\n", strings.ToLower(failure.Kind), failure.Name, failure.Message)
+	}
+	fmt.Printf("created %d resources, %d failed\n", created, len(failures))
+	if len(failures) > 0 {
+		return fmt.Errorf("%d resources failed to be imported", len(failures))
+	}
+	return nil
+}
+
+// readImportFile adds the resources of the file to the import, reading the
+// devices of a CSV file or the resources of a YAML or JSON file.
+func readImportFile(filename string, imported *api.BulkImport) error {
+	r, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		devices, err := readImportCSV(r)
+		if err != nil {
+			return err
+		}
+		*imported.Devices = append(*imported.Devices, devices...)
+		return nil
+	}
+	return readImportResources(r, imported)
+}
+
+// readImportCSV returns the devices of the rows of the CSV, whose header row
+// names the "name" column and the labels of the other columns.
+func readImportCSV(r io.Reader) ([]api.Device, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading the header row: %w", err)
+	}
+	header = lo.Map(header, func(column string, _ int) string { return strings.TrimSpace(column) })
+	nameColumn := lo.IndexOf(header, "name")
+	if nameColumn < 0 {
+		return nil, fmt.Errorf(`the header row has no "name" column`)
+	}
+
+	devices := []api.Device{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return devices, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSpace(row[nameColumn])
+		if name == "" {
+			line, _ := reader.FieldPos(nameColumn)
+			return nil, fmt.Errorf("line %d: the device has no name", line)
+		}
+		labels := map[string]string{}
+		for i, value := range row {
+			if value = strings.TrimSpace(value); i != nameColumn && value != "" {
+				labels[header[i]] = value
+			}
+		}
+		devices = append(devices, api.Device{
+			ApiVersion: "v1alpha1",
+			Kind:       "Device",
+			Metadata:   api.ObjectMeta{Name: &name, Labels: &labels},
+		})
+	}
+}
+
+// readImportResources adds the devices and fleets of YAML or JSON documents to
+// the import. Fleets of the v1beta1 API are converted to v1alpha1, keeping
+// their v1beta1 properties in their conversion annotation.
+func readImportResources(r io.Reader, imported *api.BulkImport) error {
+	decoder := yamlutil.NewYAMLOrJSONDecoder(r, 100)
+	for {
+		var resource json.RawMessage
+		err := decoder.Decode(&resource)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var header struct {
+			ApiVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+		}
+		if err := json.Unmarshal(resource, &header); err != nil {
+			return err
+		}
+		switch {
+		case strings.EqualFold(header.Kind, DeviceKind):
+			var device api.Device
+			if err := json.Unmarshal(resource, &device); err != nil {
+				return err
+			}
+			*imported.Devices = append(*imported.Devices, device)
+		case strings.EqualFold(header.Kind, FleetKind) && header.ApiVersion == v1beta1.FleetAPI:
+			var fleet v1beta1.Fleet
+			if err := json.Unmarshal(resource, &fleet); err != nil {
+				return err
+			}
+			converted, err := v1beta1.ConvertFleetToV1alpha1(&fleet)
+			if err != nil {
+				return err
+			}
+			*imported.Fleets = append(*imported.Fleets, *converted)
+		case strings.EqualFold(header.Kind, FleetKind):
+			var fleet api.Fleet
+			if err := json.Unmarshal(resource, &fleet); err != nil {
+				return err
+			}
+			*imported.Fleets = append(*imported.Fleets, fleet)
+		default:
+			return fmt.Errorf("cannot import resources of kind %q, only devices and fleets", header.Kind)
+		}
+	}
+}
+
+// bulkImportBatches splits the import into batches of up to size resources,
+// the fleets first so that they exist when the devices are created.
+func bulkImportBatches(imported api.BulkImport, size int) []api.BulkImport {
+	batches := []api.BulkImport{}
+	fleets, devices := lo.FromPtr(imported.Fleets), lo.FromPtr(imported.Devices)
+	for len(fleets) > 0 || len(devices) > 0 {
+		batchFleets := fleets[:min(len(fleets), size)]
+		fleets = fleets[len(batchFleets):]
+		batchDevices := devices[:min(len(devices), size-len(batchFleets))]
+		devices = devices[len(batchDevices):]
+		batches = append(batches, api.BulkImport{Fleets: &batchFleets, Devices: &batchDevices})
+	}
+	return batches
+}
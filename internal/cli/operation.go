@@ -0,0 +1,386 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// operationWatchInterval is the interval at which a watched operation is polled.
+const operationWatchInterval = 2 * time.Second
+
+func NewCmdOperation() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operation",
+		Short: "Run actions on many devices in the background, and follow their progress.",
+		Long: `Run actions on the devices matching a label selector as operations, which the service
+runs in the background: creating an operation prints its name, with which its progress and
+result can be shown, or the operation canceled.`,
+	}
+	cmd.AddCommand(NewCmdOperationCreate(api.OperationTypeBulkLabel))
+	cmd.AddCommand(NewCmdOperationCreate(api.OperationTypeDeviceDecommission))
+	cmd.AddCommand(NewCmdOperationStatus())
+	cmd.AddCommand(NewCmdOperationCancel())
+	return cmd
+}
+
+type OperationCreateOptions struct {
+	GlobalOptions
+
+	Type          api.OperationType
+	LabelSelector string
+	Labels        map[string]string
+	RemoveLabels  []string
+	Watch         bool
+}
+
+func DefaultOperationCreateOptions(operationType api.OperationType) *OperationCreateOptions {
+	return &OperationCreateOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Type:          operationType,
+		Labels:        map[string]string{},
+		RemoveLabels:  []string{},
+		Watch:         false,
+	}
+}
+
+func NewCmdOperationCreate(operationType api.OperationType) *cobra.Command {
+	o := DefaultOperationCreateOptions(operationType)
+	cmd := &cobra.Command{
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	switch operationType {
+	case api.OperationTypeBulkLabel:
+		cmd.Use = "label -l SELECTOR [--set KEY=VALUE] [--remove KEY]"
+		cmd.Short = "Set and remove labels of the devices matching a label selector."
+	case api.OperationTypeDeviceDecommission:
+		cmd.Use = "decommission -l SELECTOR"
+		cmd.Short = "Decommission the devices matching a label selector."
+		cmd.Long = `Decommission the devices matching a label selector, which are moved to the trash:
+they can be restored until they are purged, when their certificates are revoked.`
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *OperationCreateOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.LabelSelector, "selector", "l", o.LabelSelector, "Selector (label query) of the devices, supports '=' (e.g. -l key1=value1,key2=value2).")
+	if o.Type == api.OperationTypeBulkLabel {
+		fs.StringToStringVar(&o.Labels, "set", o.Labels, "Labels to set on the devices (e.g. --set key1=value1,key2=value2).")
+		fs.StringSliceVar(&o.RemoveLabels, "remove", o.RemoveLabels, "Keys of the labels to remove from the devices.")
+	}
+	fs.BoolVarP(&o.Watch, "watch", "w", o.Watch, "Watch the operation until it finishes.")
+}
+
+func (o *OperationCreateOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *OperationCreateOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	if len(o.LabelSelector) == 0 {
+		return fmt.Errorf("must specify the devices with -l SELECTOR")
+	}
+	if o.Type == api.OperationTypeBulkLabel && len(o.Labels) == 0 && len(o.RemoveLabels) == 0 {
+		return fmt.Errorf("must specify labels to --set or --remove")
+	}
+	return nil
+}
+
+func (o *OperationCreateOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	operation := api.Operation{
+		ApiVersion: "v1alpha1",
+		Kind:       "Operation",
+		Spec:       api.OperationSpec{Type: o.Type, LabelSelector: o.LabelSelector},
+	}
+	if o.Type == api.OperationTypeBulkLabel {
+		operation.Spec.Labels = lo.Ternary(len(o.Labels) > 0, &o.Labels, nil)
+		operation.Spec.RemoveLabels = lo.Ternary(len(o.RemoveLabels) > 0, &o.RemoveLabels, nil)
+	}
+	response, err := c.CreateOperationWithResponse(ctx, operation)
+	if err != nil {
+		return fmt.Errorf("creating operation: %w", err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusCreated {
+		return fmt.Errorf("creating operation: %d %s", response.HTTPResponse.StatusCode, errorMessage(response.Body))
+	}
+	name := *response.JSON201.Metadata.Name
+	fmt.Printf("operation/%s created\n", name)
+	if !o.Watch {
+		return nil
+	}
+	return watchOperation(ctx, c, name)
+}
+
+type OperationStatusOptions struct {
+	GlobalOptions
+
+	Watch bool
+}
+
+func DefaultOperationStatusOptions() *OperationStatusOptions {
+	return &OperationStatusOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Watch:         false,
+	}
+}
+
+func NewCmdOperationStatus() *cobra.Command {
+	o := DefaultOperationStatusOptions()
+	cmd := &cobra.Command{
+		Use:   "status NAME",
+		Short: "Show the progress and result of an operation.",
+		Long: `Show the progress and result of an operation: its state, the number of devices it
+succeeded and failed for, and the first devices it failed for.
+
+With --watch, the progress is printed as it changes until the operation finishes,
+and the command fails if the operation failed or was canceled.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *OperationStatusOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.BoolVarP(&o.Watch, "watch", "w", o.Watch, "Watch the operation until it finishes.")
+}
+
+func (o *OperationStatusOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *OperationStatusOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	if len(args[0]) == 0 {
+		return fmt.Errorf("specify the operation to show the status of")
+	}
+	return nil
+}
+
+func (o *OperationStatusOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	if o.Watch {
+		return watchOperation(ctx, c, args[0])
+	}
+	operation, err := readOperation(ctx, c, args[0])
+	if err != nil {
+		return err
+	}
+	printOperationStatus(os.Stdout, operation)
+	return nil
+}
+
+type OperationCancelOptions struct {
+	GlobalOptions
+}
+
+func DefaultOperationCancelOptions() *OperationCancelOptions {
+	return &OperationCancelOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+	}
+}
+
+func NewCmdOperationCancel() *cobra.Command {
+	o := DefaultOperationCancelOptions()
+	cmd := &cobra.Command{
+		Use:   "cancel NAME",
+		Short: "Cancel an operation.",
+		Long: `Cancel an operation which has not finished: it stops applying to further devices,
+and the devices it already applied to keep its changes.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *OperationCancelOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+}
+
+func (o *OperationCancelOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *OperationCancelOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	if len(args[0]) == 0 {
+		return fmt.Errorf("specify the operation to cancel")
+	}
+	return nil
+}
+
+func (o *OperationCancelOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	response, err := c.CancelOperationWithResponse(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("canceling operation/%s: %w", args[0], err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("canceling operation/%s: %d %s", args[0], response.HTTPResponse.StatusCode, errorMessage(response.Body))
+	}
+	fmt.Printf("operation/%s canceled\n", args[0])
+	return nil
+}
+
+func readOperation(ctx context.Context, c *apiclient.ClientWithResponses, name string) (*api.Operation, error) {
+	response, err := c.ReadOperationWithResponse(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("reading operation/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading operation/%s: %d %s", name, response.HTTPResponse.StatusCode, errorMessage(response.Body))
+	}
+	return response.JSON200, nil
+}
+
+// watchOperation prints the progress of the operation as it changes until it
+// finishes, failing if the operation failed or was canceled.
+func watchOperation(ctx context.Context, c *apiclient.ClientWithResponses, name string) error {
+	last := ""
+	for {
+		operation, err := readOperation(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		status := lo.FromPtr(operation.Status)
+		if line := operationProgressLine(status); line != last {
+			fmt.Println(line)
+			last = line
+		}
+		switch status.State {
+		case api.OperationStateSucceeded:
+			return nil
+		case api.OperationStateFailed:
+			return fmt.Errorf("operation/%s failed", name)
+		case api.OperationStateCanceled:
+			return fmt.Errorf("operation/%s was canceled", name)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(operationWatchInterval):
+		}
+	}
+}
+
+// operationProgressLine summarizes the progress of an operation.
+func operationProgressLine(status api.OperationStatus) string {
+	switch status.State {
+	case api.OperationStatePending, "":
+		return "Waiting for the operation to start..."
+	case api.OperationStateRunning:
+		return fmt.Sprintf("Waiting for the operation to finish: %d of %d devices done, %d failed...",
+			status.Succeeded+status.Failed, status.Total, status.Failed)
+	}
+	return fmt.Sprintf("operation %s: %s", strings.ToLower(string(status.State)), lo.FromPtr(status.Message))
+}
+
+func printOperationStatus(out io.Writer, operation *api.Operation) {
+	status := lo.FromPtr(operation.Status)
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintf(w, "OPERATION:\t%s\n", lo.FromPtr(operation.Metadata.Name))
+	fmt.Fprintf(w, "TYPE:\t%s\n", operation.Spec.Type)
+	fmt.Fprintf(w, "SELECTOR:\t%s\n", operation.Spec.LabelSelector)
+	fmt.Fprintf(w, "STATE:\t%s\n", status.State)
+	fmt.Fprintf(w, "DEVICES:\t%d\n", status.Total)
+	fmt.Fprintf(w, "SUCCEEDED:\t%d\n", status.Succeeded)
+	fmt.Fprintf(w, "FAILED:\t%d\n", status.Failed)
+	fmt.Fprintf(w, "STARTED AT:\t%s\n", formatRolloutTime(status.StartedAt))
+	fmt.Fprintf(w, "FINISHED AT:\t%s\n", formatRolloutTime(status.FinishedAt))
+	if status.Message != nil {
+		fmt.Fprintf(w, "MESSAGE:\t%s\n", *status.Message)
+	}
+	w.Flush()
+
+	failures := lo.FromPtr(status.Failures)
+	if len(failures) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "DEVICE\tFAILURE")
+	for _, failure := range failures {
+		fmt.Fprintf(w, "%s\t%s\n", failure.Name, failure.Message)
+	}
+	w.Flush()
+}
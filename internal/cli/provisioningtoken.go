@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	provisioningTokenCreate = "create"
+	provisioningTokenList   = "list"
+	provisioningTokenRevoke = "revoke"
+)
+
+type ProvisioningTokenOptions struct {
+	GlobalOptions
+
+	Action             string
+	Labels             []string
+	Description        string
+	TTL                string
+	EnrollmentsPerHour int32
+}
+
+func DefaultProvisioningTokenOptions(action string) *ProvisioningTokenOptions {
+	return &ProvisioningTokenOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Action:        action,
+		Labels:        []string{},
+	}
+}
+
+func NewCmdProvisioningToken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "provisioning-token",
+		Short: "Manage the tokens provisioning lines use to enroll devices into a fleet.",
+		Long: `Manage the bearer tokens of provisioning lines, such as the ones of contract manufacturers.
+A token can only create enrollment requests for devices joining its fleet, at a limited rate
+and until it expires, and cannot read or modify any other resource.`,
+	}
+	cmd.AddCommand(NewCmdProvisioningTokenAction(provisioningTokenCreate))
+	cmd.AddCommand(NewCmdProvisioningTokenAction(provisioningTokenList))
+	cmd.AddCommand(NewCmdProvisioningTokenAction(provisioningTokenRevoke))
+	return cmd
+}
+
+func NewCmdProvisioningTokenAction(action string) *cobra.Command {
+	o := DefaultProvisioningTokenOptions(action)
+	cmd := &cobra.Command{
+		Use:  action + " fleet/NAME",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	switch action {
+	case provisioningTokenCreate:
+		cmd.Short = "Create a provisioning token for a fleet."
+		cmd.Long = `Create a provisioning token for a fleet. The token is only printed once, store it
+securely and hand it to the provisioning line, which passes it as a bearer token.`
+	case provisioningTokenList:
+		cmd.Short = "List the provisioning tokens of a fleet."
+	case provisioningTokenRevoke:
+		cmd.Use = action + " fleet/NAME ID"
+		cmd.Args = cobra.ExactArgs(2)
+		cmd.Short = "Revoke a provisioning token of a fleet."
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ProvisioningTokenOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	if o.Action == provisioningTokenCreate {
+		fs.StringArrayVarP(&o.Labels, "label", "l", []string{}, "Labels to set on the devices enrolled with the token besides the labels of the fleet, as a comma-separated list of key=value.")
+		fs.StringVarP(&o.Description, "description", "d", o.Description, "What the token is for, such as the provisioning line using it.")
+		fs.StringVar(&o.TTL, "ttl", o.TTL, "How long the token is valid for, such as 720h. Defaults to 7 days.")
+		fs.Int32Var(&o.EnrollmentsPerHour, "enrollments-per-hour", o.EnrollmentsPerHour, "The number of devices the token can enroll per hour. Defaults to 100.")
+	}
+}
+
+func (o *ProvisioningTokenOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ProvisioningTokenOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != FleetKind {
+		return fmt.Errorf("kind must be %s", FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific fleet")
+	}
+	if o.TTL != "" {
+		if _, err := time.ParseDuration(o.TTL); err != nil {
+			return fmt.Errorf("invalid --ttl: %w", err)
+		}
+	}
+	if o.EnrollmentsPerHour < 0 {
+		return fmt.Errorf("--enrollments-per-hour must be positive")
+	}
+	return nil
+}
+
+func (o *ProvisioningTokenOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch o.Action {
+	case provisioningTokenCreate:
+		request := api.ProvisioningTokenRequest{}
+		if len(o.Labels) > 0 {
+			request.Labels = lo.ToPtr(util.LabelArrayToMap(o.Labels))
+		}
+		if o.Description != "" {
+			request.Description = &o.Description
+		}
+		if o.TTL != "" {
+			request.Ttl = &o.TTL
+		}
+		if o.EnrollmentsPerHour > 0 {
+			request.EnrollmentsPerHour = &o.EnrollmentsPerHour
+		}
+		response, err := c.CreateProvisioningTokenWithResponse(ctx, name, request)
+		if err != nil {
+			return fmt.Errorf("creating provisioning token of fleet/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusCreated {
+			return fmt.Errorf("creating provisioning token of fleet/%s: %d %s", name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+		}
+		token := response.JSON201
+		fmt.Fprintf(os.Stderr, "provisioning token %s of fleet/%s created, valid until %s\n", token.Id, name, token.ExpiresAt.Format(time.RFC3339))
+		fmt.Println(lo.FromPtr(token.Token))
+	case provisioningTokenList:
+		response, err := c.ListProvisioningTokensWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("listing provisioning tokens of fleet/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("listing provisioning tokens of fleet/%s: %d %s", name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+		}
+		printProvisioningTokens(response.JSON200.Items)
+	case provisioningTokenRevoke:
+		response, err := c.DeleteProvisioningTokenWithResponse(ctx, name, args[1])
+		if err != nil {
+			return fmt.Errorf("revoking provisioning token %s of fleet/%s: %w", args[1], name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("revoking provisioning token %s of fleet/%s: %d %s", args[1], name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+		}
+		fmt.Printf("provisioning token %s of fleet/%s revoked\n", args[1], name)
+	}
+	return nil
+}
+
+func printProvisioningTokens(tokens []api.ProvisioningToken) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "ID\tDESCRIPTION\tLABELS\tENROLLMENTS/HOUR\tEXPIRES AT")
+	for _, token := range tokens {
+		expiresAt := token.ExpiresAt.Format(time.RFC3339)
+		if !time.Now().Before(token.ExpiresAt) {
+			expiresAt += " (expired)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+			token.Id,
+			util.DefaultIfNil(token.Description, NoneString),
+			strings.Join(util.LabelMapToArray(token.Labels), ","),
+			token.EnrollmentsPerHour,
+			expiresAt)
+	}
+	w.Flush()
+}
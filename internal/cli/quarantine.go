@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type QuarantineOptions struct {
+	GlobalOptions
+
+	Reason           string
+	StopApplications bool
+	Release          bool
+}
+
+func DefaultQuarantineOptions() *QuarantineOptions {
+	return &QuarantineOptions{
+		GlobalOptions:    DefaultGlobalOptions(),
+		Reason:           "",
+		StopApplications: false,
+		Release:          false,
+	}
+}
+
+func NewCmdQuarantine() *cobra.Command {
+	o := DefaultQuarantineOptions()
+	cmd := &cobra.Command{
+		Use:   "quarantine device/NAME",
+		Short: "Quarantine a device, or release it from quarantine.",
+		Long: `Quarantine a device: the device keeps its current configuration but is served no
+new rendered specs and no console sessions, and optionally stops its applications,
+until it is released with --release.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *QuarantineOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the device is quarantined.")
+	fs.BoolVar(&o.StopApplications, "stop-applications", o.StopApplications, "Stop the applications of the device while it is quarantined.")
+	fs.BoolVar(&o.Release, "release", o.Release, "Release the device from quarantine.")
+}
+
+func (o *QuarantineOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *QuarantineOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device to quarantine")
+	}
+	if o.Release && (o.Reason != "" || o.StopApplications) {
+		return fmt.Errorf("--release cannot be combined with --reason or --stop-applications")
+	}
+	if !o.Release && strings.TrimSpace(o.Reason) == "" {
+		return fmt.Errorf("specify the reason of the quarantine with --reason")
+	}
+	return nil
+}
+
+func (o *QuarantineOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.Release {
+		response, err := c.ReleaseDeviceQuarantineWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("releasing device/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("releasing device/%s: %d", name, response.HTTPResponse.StatusCode)
+		}
+		fmt.Printf("device/%s released from quarantine\n", name)
+		return nil
+	}
+
+	body := api.DeviceQuarantine{Reason: o.Reason}
+	if o.StopApplications {
+		body.StopApplications = &o.StopApplications
+	}
+	response, err := c.QuarantineDeviceWithResponse(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("quarantining device/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("quarantining device/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+	fmt.Printf("device/%s quarantined\n", name)
+	return nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type RenameOptions struct {
+	GlobalOptions
+
+	DisplayName string
+	Aliases     []string
+
+	displayNameSet bool
+	aliasesSet     bool
+}
+
+func DefaultRenameOptions() *RenameOptions {
+	return &RenameOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		DisplayName:   "",
+		Aliases:       []string{},
+	}
+}
+
+func NewCmdRename() *cobra.Command {
+	o := DefaultRenameOptions()
+	cmd := &cobra.Command{
+		Use:   "rename device/NAME",
+		Short: "Set the display name and the aliases of a device.",
+		Long: `Set the display name a device is shown with and the aliases it can be found by.
+The name of the device is derived from its identity and cannot change. Settings not
+passed are kept; pass an empty --display-name or --aliases to remove them.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *RenameOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVar(&o.DisplayName, "display-name", o.DisplayName, "The human-friendly name the device is shown with.")
+	fs.StringSliceVar(&o.Aliases, "aliases", o.Aliases, "The comma-separated aliases the device can be found by, replacing its current ones.")
+}
+
+func (o *RenameOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	o.displayNameSet = cmd.Flags().Changed("display-name")
+	o.aliasesSet = cmd.Flags().Changed("aliases")
+	return nil
+}
+
+func (o *RenameOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device to rename")
+	}
+	if !o.displayNameSet && !o.aliasesSet {
+		return fmt.Errorf("specify --display-name, --aliases or both")
+	}
+	return nil
+}
+
+func (o *RenameOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	current, err := c.ReadDeviceWithResponse(ctx, name)
+	if err != nil {
+		return fmt.Errorf("reading device/%s: %w", name, err)
+	}
+	if current.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading device/%s: %d", name, current.HTTPResponse.StatusCode)
+	}
+
+	body := api.DeviceAliases{
+		DisplayName: current.JSON200.Metadata.DisplayName,
+		Aliases:     current.JSON200.Metadata.Aliases,
+	}
+	if o.displayNameSet {
+		body.DisplayName = &o.DisplayName
+	}
+	if o.aliasesSet {
+		body.Aliases = &o.Aliases
+	}
+	response, err := c.ReplaceDeviceAliasesWithResponse(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("renaming device/%s: %w", name, err)
+	}
+	switch {
+	case response.HTTPResponse.StatusCode == http.StatusOK:
+	case response.JSON400 != nil:
+		return fmt.Errorf("renaming device/%s: %s", name, response.JSON400.Message)
+	case response.JSON409 != nil:
+		return fmt.Errorf("renaming device/%s: %s", name, response.JSON409.Message)
+	default:
+		return fmt.Errorf("renaming device/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+	fmt.Printf("device/%s renamed\n", name)
+	return nil
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/thoas/go-funk"
+	"sigs.k8s.io/yaml"
+)
+
+const csvFormat = "csv"
+
+var legalReportOutputTypes = []string{jsonFormat, yamlFormat, csvFormat}
+
+type ReportOptions struct {
+	GlobalOptions
+
+	Output string
+}
+
+func DefaultReportOptions() *ReportOptions {
+	return &ReportOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Output:        "",
+	}
+}
+
+func NewCmdReport() *cobra.Command {
+	o := DefaultReportOptions()
+	cmd := &cobra.Command{
+		Use:   "report fleet/NAME",
+		Short: "Generate a compliance report of a fleet.",
+		Long: `Generate a compliance report of a fleet: the devices on the target OS image,
+the devices with config drift and the failed devices with their reasons.
+
+The csv output contains the report summary, followed by an empty line and the failed devices.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *ReportOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format. One of: (%s).", strings.Join(legalReportOutputTypes, ", ")))
+}
+
+func (o *ReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *ReportOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != FleetKind {
+		return fmt.Errorf("kind must be %s", FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific fleet to report on")
+	}
+	if len(o.Output) > 0 && !funk.Contains(legalReportOutputTypes, o.Output) {
+		return fmt.Errorf("output format must be one of %s", strings.Join(legalReportOutputTypes, ", "))
+	}
+	return nil
+}
+
+func (o *ReportOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	response, err := c.ReadFleetReportWithResponse(ctx, name)
+	if err != nil {
+		return fmt.Errorf("reporting on fleet/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("reporting on fleet/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+
+	return printReport(os.Stdout, response.JSON200, o.Output)
+}
+
+func printReport(out io.Writer, report *api.FleetReport, output string) error {
+	switch output {
+	case jsonFormat:
+		marshalled, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("marshalling report: %w", err)
+		}
+		fmt.Fprintf(out, "%s\n", string(marshalled))
+		return nil
+	case yamlFormat:
+		marshalled, err := yaml.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("marshalling report: %w", err)
+		}
+		fmt.Fprintf(out, "%s\n", string(marshalled))
+		return nil
+	case csvFormat:
+		return printReportCSV(out, report)
+	default:
+		printReportTable(out, report)
+		return nil
+	}
+}
+
+func sortedSummaryStatuses(report *api.FleetReport) []string {
+	statuses := make([]string, 0, len(report.SummaryStatus))
+	for status := range report.SummaryStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+func printReportTable(out io.Writer, report *api.FleetReport) {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintf(w, "FLEET:\t%s\n", report.Fleet)
+	fmt.Fprintf(w, "GENERATED AT:\t%s\n", report.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "TEMPLATE VERSION:\t%s\n", util.DefaultIfNil(report.TemplateVersion, NoneString))
+	fmt.Fprintf(w, "TARGET OS IMAGE:\t%s\n", util.DefaultIfNil(report.TargetOsImage, NoneString))
+	fmt.Fprintf(w, "TOTAL DEVICES:\t%d\n", report.TotalDevices)
+	fmt.Fprintf(w, "ON TARGET OS:\t%d\n", report.DevicesOnTargetOs)
+	fmt.Fprintf(w, "CONFIG DRIFT:\t%d\n", report.DevicesWithConfigDrift)
+	for _, status := range sortedSummaryStatuses(report) {
+		fmt.Fprintf(w, "%s:\t%d\n", strings.ToUpper(status), report.SummaryStatus[status])
+	}
+	w.Flush()
+
+	if len(report.FailedDevices) > 0 {
+		fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+		fmt.Fprintln(w, "FAILED DEVICE\tSTATUS\tOS IMAGE\tREASON")
+		for _, d := range report.FailedDevices {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Name, d.SummaryStatus, d.OsImage, util.DefaultIfNil(d.Reason, NoneString))
+		}
+		w.Flush()
+	}
+
+	if report.WorkloadEvents != nil && len(*report.WorkloadEvents) > 0 {
+		fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+		fmt.Fprintln(w, "WORKLOAD\tEVENT\tDEVICES\tEVENTS")
+		for _, e := range *report.WorkloadEvents {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", e.Workload, e.Type, e.Devices, e.Events)
+		}
+		w.Flush()
+	}
+}
+
+func printReportCSV(out io.Writer, report *api.FleetReport) error {
+	statuses := sortedSummaryStatuses(report)
+
+	header := []string{"fleet", "generatedAt", "templateVersion", "targetOsImage", "totalDevices", "devicesOnTargetOs", "devicesWithConfigDrift"}
+	row := []string{
+		report.Fleet,
+		report.GeneratedAt.Format(time.RFC3339),
+		util.DefaultIfNil(report.TemplateVersion, ""),
+		util.DefaultIfNil(report.TargetOsImage, ""),
+		strconv.FormatInt(report.TotalDevices, 10),
+		strconv.FormatInt(report.DevicesOnTargetOs, 10),
+		strconv.FormatInt(report.DevicesWithConfigDrift, 10),
+	}
+	for _, status := range statuses {
+		header = append(header, status)
+		row = append(row, strconv.FormatInt(report.SummaryStatus[status], 10))
+	}
+
+	w := csv.NewWriter(out)
+	if err := w.WriteAll([][]string{header, row}); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	fmt.Fprintln(out)
+
+	records := [][]string{{"device", "summaryStatus", "osImage", "reason"}}
+	for _, d := range report.FailedDevices {
+		records = append(records, []string{d.Name, string(d.SummaryStatus), d.OsImage, util.DefaultIfNil(d.Reason, "")})
+	}
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	if report.WorkloadEvents != nil && len(*report.WorkloadEvents) > 0 {
+		fmt.Fprintln(out)
+		records = [][]string{{"workload", "event", "devices", "events"}}
+		for _, e := range *report.WorkloadEvents {
+			records = append(records, []string{e.Workload, string(e.Type), strconv.FormatInt(e.Devices, 10), strconv.FormatInt(e.Events, 10)})
+		}
+		if err := w.WriteAll(records); err != nil {
+			return fmt.Errorf("writing report: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type RestoreOptions struct {
+	GlobalOptions
+}
+
+func DefaultRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+	}
+}
+
+func NewCmdRestore() *cobra.Command {
+	o := DefaultRestoreOptions()
+	cmd := &cobra.Command{
+		Use:   "restore [(device | fleet)/NAME]",
+		Short: "Restore a deleted device or fleet, or list the deleted ones.",
+		Long: `Restore a device or fleet deleted less than the trash retention of the service ago.
+Without arguments, list the devices and fleets which can be restored and when they
+are purged.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *RestoreOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+}
+
+func (o *RestoreOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *RestoreOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind && kind != FleetKind {
+		return fmt.Errorf("kind must be %s or %s", DeviceKind, FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific %s to restore", kind)
+	}
+	return nil
+}
+
+func (o *RestoreOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	if len(args) == 0 {
+		response, err := c.ListTrashWithResponse(ctx, &api.ListTrashParams{})
+		if err != nil {
+			return fmt.Errorf("listing deleted resources: %w", err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK || response.JSON200 == nil {
+			return fmt.Errorf("listing deleted resources: %d", response.HTTPResponse.StatusCode)
+		}
+		printTrashTable(os.Stdout, response.JSON200.Items)
+		return nil
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	var statusCode int
+	switch kind {
+	case DeviceKind:
+		response, err := c.RestoreDeviceWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("restoring %s/%s: %w", kind, name, err)
+		}
+		statusCode = response.HTTPResponse.StatusCode
+	case FleetKind:
+		response, err := c.RestoreFleetWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("restoring %s/%s: %w", kind, name, err)
+		}
+		statusCode = response.HTTPResponse.StatusCode
+	}
+	if statusCode == http.StatusNotFound {
+		return fmt.Errorf("restoring %s/%s: not found in the trash", kind, name)
+	}
+	if statusCode != http.StatusOK {
+		return fmt.Errorf("restoring %s/%s: %d", kind, name, statusCode)
+	}
+	fmt.Printf("%s/%s restored\n", kind, name)
+	return nil
+}
+
+func printTrashTable(out io.Writer, items []api.TrashedResource) {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tOWNER\tDELETED AGO\tPURGE AT")
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			item.Kind,
+			item.Name,
+			util.DefaultIfNil(item.Owner, NoneString),
+			time.Since(item.DeletedAt).Round(time.Second).String(),
+			item.PurgeAt.Format(time.RFC3339),
+		)
+	}
+	w.Flush()
+}
@@ -0,0 +1,364 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// rolloutWatchInterval is the interval at which a watched rollout is polled.
+const rolloutWatchInterval = 5 * time.Second
+
+type RolloutStatusOptions struct {
+	GlobalOptions
+
+	Watch bool
+}
+
+func DefaultRolloutStatusOptions() *RolloutStatusOptions {
+	return &RolloutStatusOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Watch:         false,
+	}
+}
+
+func NewCmdRollout() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollout",
+		Short: "Manage the rollout of a fleet's template to its devices.",
+	}
+	cmd.AddCommand(NewCmdRolloutStatus())
+	cmd.AddCommand(NewCmdRolloutAction(rolloutPause))
+	cmd.AddCommand(NewCmdRolloutAction(rolloutResume))
+	cmd.AddCommand(NewCmdRolloutAction(rolloutAbort))
+	return cmd
+}
+
+func NewCmdRolloutStatus() *cobra.Command {
+	o := DefaultRolloutStatusOptions()
+	cmd := &cobra.Command{
+		Use:   "status fleet/NAME",
+		Short: "Show the status of the rollout of a fleet.",
+		Long: `Show the status of the rollout of the newest template version of a fleet: its state,
+the batch being rolled out and the devices of each batch by their progress.
+
+With --watch, the progress is printed as it changes until the rollout completes,
+and the command fails if the rollout is blocked or aborted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *RolloutStatusOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.BoolVarP(&o.Watch, "watch", "w", o.Watch, "Watch the rollout until it completes.")
+}
+
+func (o *RolloutStatusOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *RolloutStatusOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != FleetKind {
+		return fmt.Errorf("kind must be %s", FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific fleet to show the rollout of")
+	}
+	return nil
+}
+
+func (o *RolloutStatusOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if !o.Watch {
+		rollout, err := readFleetRollout(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		printRolloutStatus(os.Stdout, name, rollout)
+		return nil
+	}
+
+	last := ""
+	for {
+		rollout, err := readFleetRollout(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		if line := rolloutProgressLine(rollout); line != last {
+			fmt.Println(line)
+			last = line
+		}
+		switch rollout.State {
+		case api.FleetRolloutStateCompleted:
+			return nil
+		case api.FleetRolloutStateBlocked:
+			return fmt.Errorf("rollout of fleet/%s is blocked", name)
+		case api.FleetRolloutStateAborted:
+			return fmt.Errorf("rollout of fleet/%s was aborted", name)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rolloutWatchInterval):
+		}
+	}
+}
+
+const (
+	rolloutPause  = "pause"
+	rolloutResume = "resume"
+	rolloutAbort  = "abort"
+)
+
+type RolloutActionOptions struct {
+	GlobalOptions
+
+	Action string
+	Revert bool
+}
+
+func DefaultRolloutActionOptions(action string) *RolloutActionOptions {
+	return &RolloutActionOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Action:        action,
+		Revert:        false,
+	}
+}
+
+func NewCmdRolloutAction(action string) *cobra.Command {
+	o := DefaultRolloutActionOptions(action)
+	cmd := &cobra.Command{
+		Use:  action + " fleet/NAME",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	switch action {
+	case rolloutPause:
+		cmd.Short = "Pause the rollout of a fleet."
+		cmd.Long = `Pause the rollout of a fleet: no further batch of devices is updated to its template
+version until the rollout is resumed. The devices of the current batch keep updating.`
+	case rolloutResume:
+		cmd.Short = "Resume the paused rollout of a fleet."
+	case rolloutAbort:
+		cmd.Short = "Abort the rollout of a fleet."
+		cmd.Long = `Abort the rollout of a fleet: no further device is updated to its template version.
+With --revert, the devices already updated are rolled back to the previous template version.`
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *RolloutActionOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	if o.Action == rolloutAbort {
+		fs.BoolVar(&o.Revert, "revert", o.Revert, "Revert the devices already updated to the previous template version.")
+	}
+}
+
+func (o *RolloutActionOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *RolloutActionOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != FleetKind {
+		return fmt.Errorf("kind must be %s", FleetKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific fleet to %s the rollout of", o.Action)
+	}
+	return nil
+}
+
+func (o *RolloutActionOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	var httpResponse *http.Response
+	var body []byte
+	switch o.Action {
+	case rolloutPause:
+		response, err := c.PauseFleetRolloutWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("pausing rollout of fleet/%s: %w", name, err)
+		}
+		httpResponse, body = response.HTTPResponse, response.Body
+	case rolloutResume:
+		response, err := c.ResumeFleetRolloutWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("resuming rollout of fleet/%s: %w", name, err)
+		}
+		httpResponse, body = response.HTTPResponse, response.Body
+	case rolloutAbort:
+		response, err := c.AbortFleetRolloutWithResponse(ctx, name, api.FleetRolloutAbort{Revert: &o.Revert})
+		if err != nil {
+			return fmt.Errorf("aborting rollout of fleet/%s: %w", name, err)
+		}
+		httpResponse, body = response.HTTPResponse, response.Body
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s rollout of fleet/%s: %d %s", rolloutActionVerbs[o.Action], name, httpResponse.StatusCode, strings.TrimSpace(string(body)))
+	}
+	fmt.Printf("rollout of fleet/%s %s\n", name, rolloutActionDone[o.Action])
+	return nil
+}
+
+var rolloutActionVerbs = map[string]string{
+	rolloutPause:  "pausing",
+	rolloutResume: "resuming",
+	rolloutAbort:  "aborting",
+}
+
+var rolloutActionDone = map[string]string{
+	rolloutPause:  "paused",
+	rolloutResume: "resumed",
+	rolloutAbort:  "aborted",
+}
+
+func readFleetRollout(ctx context.Context, c *apiclient.ClientWithResponses, name string) (*api.FleetRolloutStatus, error) {
+	response, err := c.ReadFleetWithResponse(ctx, name, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading fleet/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading fleet/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+	if response.JSON200.Status == nil || response.JSON200.Status.Rollout == nil {
+		return nil, fmt.Errorf("fleet/%s has no rollout", name)
+	}
+	return response.JSON200.Status.Rollout, nil
+}
+
+// rolloutProgressLine summarizes the progress of a rollout in the way of
+// kubectl rollout status.
+func rolloutProgressLine(rollout *api.FleetRolloutStatus) string {
+	switch rollout.State {
+	case api.FleetRolloutStateCompleted:
+		return fmt.Sprintf("template version %q successfully rolled out", rollout.TemplateVersion)
+	case api.FleetRolloutStateBlocked:
+		reason := ""
+		if rollout.BlockingReason != nil {
+			reason = ": " + *rollout.BlockingReason
+		}
+		return fmt.Sprintf("rollout of template version %q is blocked in batch %d%s", rollout.TemplateVersion, rollout.CurrentBatch, reason)
+	case api.FleetRolloutStatePaused:
+		return fmt.Sprintf("rollout of template version %q is paused in batch %d, resume it to continue", rollout.TemplateVersion, rollout.CurrentBatch)
+	case api.FleetRolloutStateAborted:
+		return fmt.Sprintf("rollout of template version %q was aborted in batch %d", rollout.TemplateVersion, rollout.CurrentBatch)
+	}
+	if rollout.CurrentBatch == 0 || rollout.CurrentBatch > len(rollout.Batches) {
+		return fmt.Sprintf("Waiting for the rollout of template version %q to start...", rollout.TemplateVersion)
+	}
+	batch := rollout.Batches[rollout.CurrentBatch-1]
+	total := batch.InProgress + batch.Succeeded + batch.Failed
+	return fmt.Sprintf("Waiting for batch %d of %d to finish: %d of %d devices updated, %d failed...",
+		rollout.CurrentBatch, len(rollout.Batches), batch.Succeeded, total, batch.Failed)
+}
+
+func printRolloutStatus(out io.Writer, fleet string, rollout *api.FleetRolloutStatus) {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintf(w, "FLEET:\t%s\n", fleet)
+	fmt.Fprintf(w, "TEMPLATE VERSION:\t%s\n", rollout.TemplateVersion)
+	fmt.Fprintf(w, "PREVIOUS TEMPLATE VERSION:\t%s\n", util.DefaultIfNil(rollout.PreviousTemplateVersion, NoneString))
+	fmt.Fprintf(w, "STATE:\t%s\n", rollout.State)
+	fmt.Fprintf(w, "CURRENT BATCH:\t%d/%d\n", rollout.CurrentBatch, len(rollout.Batches))
+	fmt.Fprintf(w, "STARTED AT:\t%s\n", rollout.StartedAt.Format(time.RFC3339))
+	fmt.Fprintf(w, "FINISHED AT:\t%s\n", formatRolloutTime(rollout.FinishedAt))
+	if rollout.BlockingReason != nil {
+		fmt.Fprintf(w, "BLOCKING REASON:\t%s\n", *rollout.BlockingReason)
+	}
+	w.Flush()
+
+	if len(rollout.Batches) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "BATCH\tPENDING\tIN PROGRESS\tSUCCEEDED\tFAILED\tSTARTED AT\tFINISHED AT")
+	for i, batch := range rollout.Batches {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\t%s\n", i+1, batch.Pending, batch.InProgress, batch.Succeeded, batch.Failed,
+			formatRolloutTime(batch.StartedAt), formatRolloutTime(batch.FinishedAt))
+	}
+	w.Flush()
+}
+
+func formatRolloutTime(t *time.Time) string {
+	if t == nil {
+		return NoneString
+	}
+	return t.Format(time.RFC3339)
+}
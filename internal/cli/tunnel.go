@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/api_server/agentserver"
+	"github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/metadata"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+const (
+	tunnelOpen  = "open"
+	tunnelClose = "close"
+	tunnelShow  = "show"
+)
+
+type TunnelOptions struct {
+	GlobalOptions
+
+	Action      string
+	Port        int32
+	LocalPort   int
+	RequestedBy string
+	Reason      string
+	TTL         string
+}
+
+func DefaultTunnelOptions(action string) *TunnelOptions {
+	return &TunnelOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Action:        action,
+	}
+}
+
+func NewCmdTunnel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tunnel",
+		Short: "Manage the support tunnels forwarding TCP connections to services of devices.",
+		Long: `Manage the support tunnels which forward the TCP connections of a local port to a service
+listening on localhost of a device, such as a vendor web UI. The service only allows tunnels
+to the ports of its tunnels configuration, and each tunnel closes once its TTL expires.`,
+	}
+	cmd.AddCommand(NewCmdTunnelAction(tunnelOpen))
+	cmd.AddCommand(NewCmdTunnelAction(tunnelClose))
+	cmd.AddCommand(NewCmdTunnelAction(tunnelShow))
+	return cmd
+}
+
+func NewCmdTunnelAction(action string) *cobra.Command {
+	o := DefaultTunnelOptions(action)
+	cmd := &cobra.Command{
+		Use:  action + " device/NAME",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	switch action {
+	case tunnelOpen:
+		cmd.Short = "Open a tunnel to a service of a device and forward a local port to it."
+		cmd.Long = `Open a tunnel to a service listening on localhost of a device, and forward the connections
+to a local port through it until the tunnel expires or the command is interrupted, which
+closes the tunnel.`
+	case tunnelClose:
+		cmd.Short = "Close the tunnel of a device."
+	case tunnelShow:
+		cmd.Short = "Show the tunnel of a device."
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *TunnelOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	if o.Action == tunnelOpen {
+		fs.Int32VarP(&o.Port, "port", "p", o.Port, "The TCP port of the service on localhost of the device.")
+		fs.IntVar(&o.LocalPort, "local-port", o.LocalPort, "The local port forwarded to the service. Defaults to the port of the service.")
+		fs.StringVar(&o.RequestedBy, "requested-by", o.RequestedBy, "The name of the user opening the tunnel.")
+		fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the tunnel is needed, recorded in the audit log.")
+		fs.StringVar(&o.TTL, "ttl", o.TTL, "How long the tunnel stays open, such as 30m. Defaults to the maximum of the service.")
+	}
+}
+
+func (o *TunnelOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+	if o.LocalPort == 0 {
+		o.LocalPort = int(o.Port)
+	}
+	return nil
+}
+
+func (o *TunnelOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device")
+	}
+	if o.Action == tunnelOpen {
+		if o.Port < 1 || o.Port > 65535 {
+			return fmt.Errorf("specify the port of the service on the device with --port")
+		}
+		if o.LocalPort < 1 || o.LocalPort > 65535 {
+			return fmt.Errorf("invalid --local-port %d", o.LocalPort)
+		}
+		if strings.TrimSpace(o.RequestedBy) == "" || strings.TrimSpace(o.Reason) == "" {
+			return fmt.Errorf("specify who opens the tunnel with --requested-by and why with --reason")
+		}
+		if o.TTL != "" {
+			if _, err := time.ParseDuration(o.TTL); err != nil {
+				return fmt.Errorf("invalid --ttl: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (o *TunnelOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch o.Action {
+	case tunnelOpen:
+		return o.open(ctx, c, name)
+	case tunnelClose:
+		response, err := c.CloseDeviceTunnelWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("closing tunnel of device/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("close tunnel of device/%s: %d %s", name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+		}
+		fmt.Printf("tunnel %s of device/%s closed\n", response.JSON200.Id, name)
+	case tunnelShow:
+		response, err := c.GetDeviceTunnelWithResponse(ctx, name)
+		if err != nil {
+			return fmt.Errorf("getting tunnel of device/%s: %w", name, err)
+		}
+		if response.HTTPResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("show tunnel of device/%s: %d %s", name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+		}
+		printTunnel(response.JSON200)
+	}
+	return nil
+}
+
+// open opens the tunnel and forwards the local port through it until it
+// expires or the command is interrupted, and then closes it.
+func (o *TunnelOptions) open(ctx context.Context, c *apiclient.ClientWithResponses, name string) error {
+	config, err := client.ParseConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("parsing config file: %w", err)
+	}
+	// the tunnel is always encrypted end to end with a key agreed with the
+	// device, which the service and the relays forwarding it cannot read
+	operatorKey, err := console.NewOperatorKey()
+	if err != nil {
+		return fmt.Errorf("generating the tunnel key: %w", err)
+	}
+	// the local port is listened on first, so that a busy port does not open
+	// a tunnel nothing is forwarded through
+	listener, err := net.Listen("tcp", net.JoinHostPort("localhost", strconv.Itoa(o.LocalPort)))
+	if err != nil {
+		return fmt.Errorf("listening on local port %d: %w", o.LocalPort, err)
+	}
+	defer listener.Close()
+
+	request := api.DeviceTunnelRequest{Port: o.Port, PublicKey: operatorKey.PublicKey(), RequestedBy: o.RequestedBy, Reason: o.Reason}
+	if o.TTL != "" {
+		request.Ttl = &o.TTL
+	}
+	response, err := c.RequestDeviceTunnelWithResponse(ctx, name, request)
+	if err != nil {
+		return fmt.Errorf("opening tunnel of device/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("open tunnel of device/%s: %d %s", name, response.HTTPResponse.StatusCode, strings.TrimSpace(string(response.Body)))
+	}
+	tunnel := response.JSON200
+	defer func() {
+		// the tunnel is closed whatever ends the forwarding, the service only
+		// knows of it as expired otherwise
+		closeCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := c.CloseDeviceTunnelWithResponse(closeCtx, name); err != nil {
+			fmt.Fprintf(os.Stderr, "closing tunnel %s of device/%s: %v\n", tunnel.Id, name, err)
+		}
+	}()
+	if tunnel.GRPCEndpoint == nil || tunnel.CaBundle == nil {
+		return fmt.Errorf("the service did not return the endpoint and CAs of tunnel %s", tunnel.Id)
+	}
+	deviceCAs, err := certutil.NewPoolFromBytes([]byte(*tunnel.CaBundle))
+	if err != nil {
+		return fmt.Errorf("parsing the CAs of the device certificates: %w", err)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, tunnel.ExpiresAt)
+	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	grpcEndpoint := strings.TrimRight(*tunnel.GRPCEndpoint, "/")
+	grpcClient, err := client.NewGrpcClientFromConfigFile(o.ConfigFilePath, grpcEndpoint)
+	if err != nil {
+		return fmt.Errorf("creating grpc client: %w", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, agentserver.SessionIDKey, tunnel.Id)
+	ctx = metadata.AppendToOutgoingContext(ctx, agentserver.ClientNameKey, "flightctl-cli")
+	ctx = metadata.AppendToOutgoingContext(ctx, common.AuthHeader, fmt.Sprintf("Bearer %s", config.AuthInfo.Token))
+	stream, err := grpcClient.Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating stream: %w", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	// the device sends its handshake first, once it connects
+	fmt.Printf("Waiting for device/%s to connect tunnel %s\n", name, tunnel.Id)
+	frame, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("receiving the handshake of the device: %w", err)
+	}
+	if frame.Closed {
+		return fmt.Errorf("the device closed tunnel %s", tunnel.Id)
+	}
+	session, err := operatorKey.Accept(frame.Payload, tunnel.Id, name, deviceCAs)
+	if err != nil {
+		_ = stream.Send(&grpc_v1.StreamRequest{Closed: true})
+		return fmt.Errorf("verifying the handshake of the device: %w", err)
+	}
+
+	fmt.Printf("Forwarding %s to port %d of device/%s until %s, interrupt to close the tunnel\n",
+		listener.Addr(), tunnel.Port, name, tunnel.ExpiresAt.Local().Format(time.RFC3339))
+	err = console.ForwardTunnel(ctx, console.EncryptStream(stream, session), listener)
+	_ = stream.Send(&grpc_v1.StreamRequest{Closed: true})
+	if err != nil && ctx.Err() == nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	fmt.Printf("Tunnel %s closed\n", tunnel.Id)
+	return nil
+}
+
+func printTunnel(tunnel *api.DeviceTunnel) {
+	fmt.Printf("ID:           %s\n", tunnel.Id)
+	fmt.Printf("Port:         %d\n", tunnel.Port)
+	fmt.Printf("Requested by: %s at %s\n", tunnel.RequestedBy, tunnel.RequestedAt.Format(time.RFC3339))
+	fmt.Printf("Reason:       %s\n", tunnel.Reason)
+	fmt.Printf("TTL:          %s\n", tunnel.Ttl)
+	fmt.Printf("Expires at:   %s\n", tunnel.ExpiresAt.Format(time.RFC3339))
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type WakeOptions struct {
+	GlobalOptions
+
+	Peer      string
+	SiteLabel string
+	Reason    string
+}
+
+func DefaultWakeOptions() *WakeOptions {
+	return &WakeOptions{
+		GlobalOptions: DefaultGlobalOptions(),
+		Peer:          "",
+		SiteLabel:     "",
+		Reason:        "",
+	}
+}
+
+func NewCmdWake() *cobra.Command {
+	o := DefaultWakeOptions()
+	cmd := &cobra.Command{
+		Use:   "wake device/NAME",
+		Short: "Wake a powered off device through another device at its site.",
+		Long: `Wake a powered off device by asking an online device with the same site label to send
+a Wake-on-LAN packet to it once it next fetches its rendered spec. The outcome is reported
+in the status.lastAction of the peer device.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Complete(cmd, args); err != nil {
+				return err
+			}
+			if err := o.Validate(args); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context(), args)
+		},
+		SilenceUsage: true,
+	}
+	o.Bind(cmd.Flags())
+	return cmd
+}
+
+func (o *WakeOptions) Bind(fs *pflag.FlagSet) {
+	o.GlobalOptions.Bind(fs)
+
+	fs.StringVar(&o.Peer, "peer", o.Peer, "The device/NAME of the device sending the packet, by default the online device of the site seen most recently.")
+	fs.StringVar(&o.SiteLabel, "site-label", o.SiteLabel, "The key of the label naming the site of the devices (default \"site\").")
+	fs.StringVarP(&o.Reason, "reason", "r", o.Reason, "Why the device is woken, recorded in the audit log.")
+}
+
+func (o *WakeOptions) Complete(cmd *cobra.Command, args []string) error {
+	if err := o.GlobalOptions.Complete(cmd, args); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *WakeOptions) Validate(args []string) error {
+	if err := o.GlobalOptions.Validate(args); err != nil {
+		return err
+	}
+
+	kind, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+	if kind != DeviceKind {
+		return fmt.Errorf("kind must be %s", DeviceKind)
+	}
+	if len(name) == 0 {
+		return fmt.Errorf("specify a specific device")
+	}
+	if o.Peer != "" {
+		kind, name, err := parseAndValidateKindName(o.Peer)
+		if err != nil {
+			return err
+		}
+		if kind != DeviceKind || len(name) == 0 {
+			return fmt.Errorf("--peer must be a specific device/NAME")
+		}
+	}
+	return nil
+}
+
+func (o *WakeOptions) Run(ctx context.Context, args []string) error {
+	c, err := client.NewFromConfigFile(o.ConfigFilePath)
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+
+	_, name, err := parseAndValidateKindName(args[0])
+	if err != nil {
+		return err
+	}
+
+	body := api.DeviceWakeRequest{}
+	if o.Peer != "" {
+		_, peer, err := parseAndValidateKindName(o.Peer)
+		if err != nil {
+			return err
+		}
+		body.Peer = &peer
+	}
+	if siteLabel := strings.TrimSpace(o.SiteLabel); siteLabel != "" {
+		body.SiteLabel = &siteLabel
+	}
+	if reason := strings.TrimSpace(o.Reason); reason != "" {
+		body.Reason = &reason
+	}
+	response, err := c.WakeDeviceWithResponse(ctx, name, body)
+	if err != nil {
+		return fmt.Errorf("waking device/%s: %w", name, err)
+	}
+	if response.HTTPResponse.StatusCode != http.StatusOK {
+		if response.JSON400 != nil {
+			return fmt.Errorf("waking device/%s: %s", name, response.JSON400.Message)
+		}
+		if response.JSON409 != nil {
+			return fmt.Errorf("waking device/%s: %s", name, response.JSON409.Message)
+		}
+		return fmt.Errorf("waking device/%s: %d", name, response.HTTPResponse.StatusCode)
+	}
+	fmt.Printf("device/%s: Wake-on-LAN requested from device/%s as action %s\n", name, response.JSON200.Peer, response.JSON200.Action.Id)
+	return nil
+}
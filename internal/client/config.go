@@ -170,7 +170,8 @@ func NewDefault() *Config {
 }
 
 // NewFromConfig returns a new FlightCtl API client from the given config.
-func NewFromConfig(config *Config) (*client.ClientWithResponses, error) {
+// The options are applied after the defaults, so they may replace the HTTP client.
+func NewFromConfig(config *Config, opts ...client.ClientOption) (*client.ClientWithResponses, error) {
 
 	httpClient, err := NewHTTPClientFromConfig(config)
 	if err != nil {
@@ -183,7 +184,7 @@ func NewFromConfig(config *Config) (*client.ClientWithResponses, error) {
 		}
 		return nil
 	})
-	return client.NewClientWithResponses(config.Service.Server, client.WithHTTPClient(httpClient), ref)
+	return client.NewClientWithResponses(config.Service.Server, append([]client.ClientOption{client.WithHTTPClient(httpClient), ref}, opts...)...)
 }
 
 // NewHTTPClientFromConfig returns a new HTTP Client from the given config.
@@ -4,21 +4,74 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/flightctl/flightctl/internal/fips"
+	"github.com/flightctl/flightctl/internal/mqtt"
 	"github.com/flightctl/flightctl/internal/util"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
 )
 
 const (
 	appName = "flightctl"
+
+	// DefaultShutdownTimeout is the default time the service drains its
+	// connections and task queues for once it is asked to stop.
+	DefaultShutdownTimeout = 30 * time.Second
+
+	// DefaultTrashRetention is the default time deleted devices and fleets
+	// are kept in the trash for before they are purged.
+	DefaultTrashRetention = 7 * 24 * time.Hour
+
+	// DefaultEnrollmentRequestTTL is the default time enrollment requests
+	// are kept pending approval for before they expire and are deleted.
+	DefaultEnrollmentRequestTTL = 7 * 24 * time.Hour
+
+	// DuplicateEnrollmentReject, DuplicateEnrollmentRequireApproval and
+	// DuplicateEnrollmentReplace are the policies for the enrollment of a
+	// machine already enrolled as another device, such as a re-imaged one.
+	DuplicateEnrollmentReject          = "Reject"
+	DuplicateEnrollmentRequireApproval = "RequireApproval"
+	DuplicateEnrollmentReplace         = "Replace"
+
+	// DefaultConsoleGrantMaxTTL is the default longest time an approved
+	// console grant is valid for.
+	DefaultConsoleGrantMaxTTL = time.Hour
+
+	// DefaultTunnelMaxTTL is the default longest time a support tunnel stays
+	// open for.
+	DefaultTunnelMaxTTL = time.Hour
+
+	// DefaultDBMaxOpenConns and DefaultDBMaxIdleConns are the default sizes
+	// of the connection pools of each service to the database and to each of
+	// its read replicas.
+	DefaultDBMaxOpenConns = 100
+	DefaultDBMaxIdleConns = 10
+
+	// DefaultRenderedSpecCacheSize is the default number of devices whose
+	// rendered specs each API service caches.
+	DefaultRenderedSpecCacheSize = 10000
 )
 
 type Config struct {
-	Database *dbConfig    `json:"database,omitempty"`
-	Service  *svcConfig   `json:"service,omitempty"`
-	Queue    *queueConfig `json:"queue,omitempty"`
-	Auth     *authConfig  `json:"auth,omitempty"`
+	Database  *dbConfig        `json:"database,omitempty"`
+	Service   *svcConfig       `json:"service,omitempty"`
+	Queue     *queueConfig     `json:"queue,omitempty"`
+	Auth      *authConfig      `json:"auth,omitempty"`
+	Metrics   *metricsConfig   `json:"metrics,omitempty"`
+	ACM       *acmConfig       `json:"acm,omitempty"`
+	CA        *caConfig        `json:"ca,omitempty"`
+	Artifacts *artifactsConfig `json:"artifacts,omitempty"`
+	Inventory *inventoryConfig `json:"inventory,omitempty"`
+	// InventoryExport is the asset management system the inventory of the devices is exported to
+	InventoryExport *inventoryExportConfig `json:"inventoryExport,omitempty"`
+	// SpecDelivery is the MQTT broker devices are notified of their new rendered specs through
+	SpecDelivery *specDeliveryConfig `json:"specDelivery,omitempty"`
 }
 
 type dbConfig struct {
@@ -28,6 +81,27 @@ type dbConfig struct {
 	Name     string `json:"name,omitempty"`
 	User     string `json:"user,omitempty"`
 	Password string `json:"password,omitempty"`
+
+	// MaxOpenConns is the most connections each service opens to the
+	// database, and MaxIdleConns the most it keeps open while they are idle.
+	MaxOpenConns int `json:"maxOpenConns,omitempty"`
+	MaxIdleConns int `json:"maxIdleConns,omitempty"`
+	// ConnMaxLifetime and ConnMaxIdleTime are the durations, such as 30m,
+	// after which connections are closed since they were opened and since
+	// they were last used. Connections are kept open if they are not set.
+	ConnMaxLifetime string `json:"connMaxLifetime,omitempty"`
+	ConnMaxIdleTime string `json:"connMaxIdleTime,omitempty"`
+
+	// ReadReplicas are read replicas of a pgsql database, which serve the
+	// device, fleet and enrollment request lists and the fleet summaries of
+	// the API in turn. They are connected to with the name, user and password
+	// of the database, and with pools of the same size.
+	ReadReplicas []dbReplicaConfig `json:"readReplicas,omitempty"`
+}
+
+type dbReplicaConfig struct {
+	Hostname string `json:"hostname,omitempty"`
+	Port     uint   `json:"port,omitempty"`
 }
 
 type svcConfig struct {
@@ -45,6 +119,93 @@ type svcConfig struct {
 	SrvKeyFile           string   `json:"srvKeyFile,omitempty"`
 	AltNames             []string `json:"altNames,omitempty"`
 	LogLevel             string   `json:"logLevel,omitempty"`
+	// RequireFIPS refuses to start the service unless it is built with FIPS-validated crypto
+	RequireFIPS bool `json:"requireFips,omitempty"`
+	// RequireImageDigests rejects devices and fleets whose images are referenced by a tag only
+	RequireImageDigests bool `json:"requireImageDigests,omitempty"`
+	// DuplicateEnrollmentPolicy is what happens to the enrollment of a machine already enrolled as another device: Reject refuses it, RequireApproval (the default) requires its approval to choose whether the other device is replaced, Replace moves the other device to the trash once it is approved
+	DuplicateEnrollmentPolicy string `json:"duplicateEnrollmentPolicy,omitempty"`
+	// EnrollmentRequestTtl is how long enrollment requests are kept pending approval before they expire and are deleted, 168h by default
+	EnrollmentRequestTtl string `json:"enrollmentRequestTtl,omitempty"`
+	// ShutdownTimeout bounds how long the service drains its connections and task queues once it is asked to stop, 30s by default
+	ShutdownTimeout string `json:"shutdownTimeout,omitempty"`
+	// TrashRetention is how long deleted devices and fleets can be restored for before they are purged, 168h by default
+	TrashRetention string `json:"trashRetention,omitempty"`
+	// TrustedClientCAFiles are PEM bundles of the CAs of other instances whose devices were imported, which the agent endpoints trust for client certificates
+	TrustedClientCAFiles []string `json:"trustedClientCAFiles,omitempty"`
+	// Acme obtains and renews the certificate of the API endpoint from an ACME CA, the agent endpoints keep the certificate of the service CA
+	Acme *acmeConfig `json:"acme,omitempty"`
+	// LabelSchemas are the rules the API enforces on the labels of devices with the given keys
+	LabelSchemas []LabelSchema `json:"labelSchemas,omitempty"`
+	// ConsoleGrants require a grant approved by a second user to open a console on the devices they select
+	ConsoleGrants *ConsoleGrantConfig `json:"consoleGrants,omitempty"`
+	// ExecPolicies allow-list the commands users can run on devices with remote exec, any other command is refused
+	ExecPolicies []ExecPolicy `json:"execPolicies,omitempty"`
+	// Tunnels allow users to open support tunnels to services listening on localhost of devices, none are allowed if unset
+	Tunnels *TunnelConfig `json:"tunnels,omitempty"`
+	// RenderedSpecCacheSize is the number of devices whose rendered specs the API caches in memory for the agents polling them, 10000 by default, none if negative
+	RenderedSpecCacheSize int `json:"renderedSpecCacheSize,omitempty"`
+	// Relays are the names of the devices running a relay for the devices of their site, whose agent certificates may forward the signed requests of the devices labeled with the same site
+	Relays []string `json:"relays,omitempty"`
+}
+
+// ExecPolicy allows users to run commands on devices with remote exec.
+type ExecPolicy struct {
+	// Name identifies the policy in the audit log
+	Name string `json:"name"`
+	// Role restricts the policy to the users allowed the role as a verb on the devices/exec resource, all users if empty
+	Role string `json:"role,omitempty"`
+	// Fleets restricts the policy to the devices owned by the fleets, all devices if empty
+	Fleets []string `json:"fleets,omitempty"`
+	// Commands are the commands the policy allows
+	Commands []ExecCommand `json:"commands"`
+}
+
+// ExecCommand is a command an exec policy allows.
+type ExecCommand struct {
+	// Command is the absolute path of the command
+	Command string `json:"command"`
+	// Args are the glob patterns the arguments are matched against one by one, a last pattern ** matching any remaining arguments
+	Args []string `json:"args,omitempty"`
+}
+
+// ConsoleGrantConfig selects the devices whose consoles require a grant.
+type ConsoleGrantConfig struct {
+	// Selector is the label selector of the devices whose consoles require a grant, such as environment=production
+	Selector string `json:"selector"`
+	// MaxTTL is the longest time an approved grant is valid for, 1h by default
+	MaxTTL string `json:"maxTtl,omitempty"`
+}
+
+// TunnelConfig allows support tunnels to the services of devices.
+type TunnelConfig struct {
+	// Ports are the TCP ports on localhost of the devices which tunnels can be opened to, such as 8080 for a vendor web UI
+	Ports []int `json:"ports"`
+	// MaxTTL is the longest time a tunnel stays open for, 1h by default
+	MaxTTL string `json:"maxTtl,omitempty"`
+}
+
+// LabelSchema is the schema of the device labels with a key.
+type LabelSchema struct {
+	// Key is the label key the schema applies to
+	Key string `json:"key"`
+	// Required rejects creating or enrolling devices without the label, and removing it from devices which have it
+	Required bool `json:"required,omitempty"`
+	// AllowedValues are the only values the label can be set to, any value if empty
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	// Immutable rejects changing or removing the label once it is set on a device
+	Immutable bool `json:"immutable,omitempty"`
+}
+
+type acmeConfig struct {
+	// Domains are the names the API endpoint is reachable at on port 443, which the certificate is obtained for
+	Domains []string `json:"domains,omitempty"`
+	// Email is the contact of the ACME account
+	Email string `json:"email,omitempty"`
+	// DirectoryUrl is the directory of the ACME CA, Let's Encrypt by default
+	DirectoryUrl string `json:"directoryUrl,omitempty"`
+	// CacheDir keeps the account key and the certificates, the acme directory of the certificate store by default
+	CacheDir string `json:"cacheDir,omitempty"`
 }
 
 type queueConfig struct {
@@ -59,6 +220,147 @@ type authConfig struct {
 	InsecureSkipTlsVerify bool   `json:"insecureSkipTlsVerify,omitempty"`
 }
 
+type metricsConfig struct {
+	// RemoteWriteUrl is the Prometheus remote-write endpoint device metrics are forwarded to
+	RemoteWriteUrl string `json:"remoteWriteUrl,omitempty"`
+	// Address is where the periodic tasks serve their Prometheus metrics on /metrics, such as :9090, not served if empty
+	Address string `json:"address,omitempty"`
+}
+
+type acmConfig struct {
+	// Enabled registers the devices labeled flightctl.io/managed-cluster=true as managed clusters of the ACM hub the service runs on
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type caConfig struct {
+	// External signs the enrollment and management certificates of devices with an external CA instead of the CA of the service
+	External *externalCAConfig `json:"external,omitempty"`
+}
+
+// externalCAConfig configures exactly one of the supported external CAs.
+type externalCAConfig struct {
+	// CACertFile is the PEM bundle of the external CA, which the agent endpoint trusts for client certificates
+	CACertFile  string             `json:"caCertFile,omitempty"`
+	Vault       *vaultConfig       `json:"vault,omitempty"`
+	CertManager *certManagerConfig `json:"certManager,omitempty"`
+	EST         *estConfig         `json:"est,omitempty"`
+}
+
+type vaultConfig struct {
+	// Address is the URL of the Vault server
+	Address string `json:"address,omitempty"`
+	// Mount is the path the PKI secrets engine is mounted at, pki by default
+	Mount string `json:"mount,omitempty"`
+	// Role is the PKI role certificates are signed with; it must allow any common name
+	Role string `json:"role,omitempty"`
+	// TokenFile is the file the Vault token is read from on each request
+	TokenFile string `json:"tokenFile,omitempty"`
+	// ServerCACertFile is the PEM bundle verifying the Vault server, the system's trusted CAs by default
+	ServerCACertFile string `json:"serverCaCertFile,omitempty"`
+}
+
+type certManagerConfig struct {
+	// Namespace is the namespace the CertificateRequests are created in
+	Namespace string `json:"namespace,omitempty"`
+	// IssuerName is the name of the issuer signing the CertificateRequests
+	IssuerName string `json:"issuerName,omitempty"`
+	// IssuerKind is the kind of the issuer, Issuer by default
+	IssuerKind string `json:"issuerKind,omitempty"`
+	// IssuerGroup is the API group of the issuer, cert-manager.io by default
+	IssuerGroup string `json:"issuerGroup,omitempty"`
+}
+
+type estConfig struct {
+	// Url is the URL of the EST server including the optional CA label, such as https://est.example.com/.well-known/est/flightctl
+	Url string `json:"url,omitempty"`
+	// Username authenticates with the EST server using HTTP basic authentication
+	Username string `json:"username,omitempty"`
+	// PasswordFile is the file the password for basic authentication is read from
+	PasswordFile string `json:"passwordFile,omitempty"`
+	// ServerCACertFile is the PEM bundle verifying the EST server, the system's trusted CAs by default
+	ServerCACertFile string `json:"serverCaCertFile,omitempty"`
+}
+
+// artifactsConfig configures the storage of large files such as fleet reports,
+// in exactly one of the supported backends.
+type artifactsConfig struct {
+	S3         *s3Config         `json:"s3,omitempty"`
+	Filesystem *filesystemConfig `json:"filesystem,omitempty"`
+	// SigningKeyFile is the file the secret signing download URLs is read from; URLs are not offered without it
+	SigningKeyFile string `json:"signingKeyFile,omitempty"`
+	// DownloadUrlExpiration is how long signed download URLs are valid, 1h by default
+	DownloadUrlExpiration string `json:"downloadUrlExpiration,omitempty"`
+	// Retention maps kinds of artifacts, such as reports, to how long they are kept; artifacts are kept forever by default
+	Retention map[string]string `json:"retention,omitempty"`
+}
+
+type s3Config struct {
+	// Endpoint is the URL of the S3-compatible service, such as https://s3.us-east-1.amazonaws.com or the URL of a MinIO server
+	Endpoint string `json:"endpoint,omitempty"`
+	// Region is the region requests are signed for, us-east-1 by default
+	Region string `json:"region,omitempty"`
+	// Bucket is the bucket the artifacts are stored in, addressed in the path of the endpoint
+	Bucket string `json:"bucket,omitempty"`
+	// AccessKeyID identifies the access key the requests are signed with
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+	// SecretAccessKeyFile is the file the secret access key is read from on each request
+	SecretAccessKeyFile string `json:"secretAccessKeyFile,omitempty"`
+}
+
+// inventoryConfig configures the external inventory system, such as a CMDB,
+// which the parameters of fleet templates can be looked up in.
+type inventoryConfig struct {
+	// Url is the endpoint the identity and labels of each device are POSTed to
+	Url string `json:"url,omitempty"`
+	// TokenFile is the file the bearer token authenticating with the endpoint is read from on each request
+	TokenFile string `json:"tokenFile,omitempty"`
+	// ServerCACertFile is the PEM bundle verifying the endpoint, the system's trusted CAs by default
+	ServerCACertFile string `json:"serverCaCertFile,omitempty"`
+	// Timeout is how long a single lookup may take, 10s by default
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// inventoryExportConfig configures the periodic export of the inventory of the
+// devices to an asset management system, such as ServiceNow, to an endpoint
+// the changes are POSTed to, CSV files in the artifact storage, or both.
+type inventoryExportConfig struct {
+	// Url is the endpoint the records of the devices changed since the last export are POSTed to
+	Url string `json:"url,omitempty"`
+	// TokenFile is the file the bearer token authenticating with the endpoint is read from on each request
+	TokenFile string `json:"tokenFile,omitempty"`
+	// ServerCACertFile is the PEM bundle verifying the endpoint, the system's trusted CAs by default
+	ServerCACertFile string `json:"serverCaCertFile,omitempty"`
+	// Csv stores the inventory and its changes as CSV files of the inventory kind in the artifact storage
+	Csv bool `json:"csv,omitempty"`
+	// Interval is how often the inventory is exported, 1h by default
+	Interval string `json:"interval,omitempty"`
+	// Fields maps the fields of the exported records to the fields of the device they hold, such as status.systemInfo.hardware.serialNumber; a default set of fields is exported if unset
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// specDeliveryConfig configures the MQTT broker the service publishes the
+// notifications of new rendered specs to, for devices on constrained networks.
+type specDeliveryConfig struct {
+	// Broker is the URL of the broker, such as ssl://broker.example.com:8883
+	Broker string `json:"broker,omitempty"`
+	// ClientId identifies the service with the broker, flightctl-worker by default
+	ClientId string `json:"clientId,omitempty"`
+	// Username and PasswordFile are the credentials of the service, the password being read from the file on start
+	Username     string `json:"username,omitempty"`
+	PasswordFile string `json:"passwordFile,omitempty"`
+	// ServerCACertFile is the PEM bundle verifying the broker, the system's trusted CAs by default
+	ServerCACertFile string `json:"serverCaCertFile,omitempty"`
+	// TopicPrefix is the prefix of the per-device topics, flightctl/devices by default
+	TopicPrefix string `json:"topicPrefix,omitempty"`
+	// MaxSpecSize is the size in bytes up to which the rendered spec is published with the notification, 16384 by default
+	MaxSpecSize int `json:"maxSpecSize,omitempty"`
+}
+
+type filesystemConfig struct {
+	// Path is the directory the artifacts are stored in, shared by the API server and the periodic tasks
+	Path string `json:"path,omitempty"`
+}
+
 func ConfigDir() string {
 	return filepath.Join(util.MustString(os.UserHomeDir), "."+appName)
 }
@@ -149,9 +451,321 @@ func Save(cfg *Config, cfgFile string) error {
 }
 
 func Validate(cfg *Config) error {
+	if cfg.Database != nil {
+		if err := validateDatabase(cfg.Database); err != nil {
+			return fmt.Errorf("invalid database: %v", err)
+		}
+	}
+	if cfg.Service != nil && cfg.Service.RequireFIPS {
+		if err := fips.Require(); err != nil {
+			return err
+		}
+	}
+	if cfg.Service != nil && cfg.Service.ShutdownTimeout != "" {
+		if d, err := time.ParseDuration(cfg.Service.ShutdownTimeout); err != nil || d <= 0 {
+			return fmt.Errorf("invalid shutdownTimeout: must be a positive duration such as 30s")
+		}
+	}
+	if cfg.Service != nil && cfg.Service.TrashRetention != "" {
+		if d, err := time.ParseDuration(cfg.Service.TrashRetention); err != nil || d <= 0 {
+			return fmt.Errorf("invalid trashRetention: must be a positive duration such as 168h")
+		}
+	}
+	if cfg.Service != nil && cfg.Service.DuplicateEnrollmentPolicy != "" {
+		switch cfg.Service.DuplicateEnrollmentPolicy {
+		case DuplicateEnrollmentReject, DuplicateEnrollmentRequireApproval, DuplicateEnrollmentReplace:
+		default:
+			return fmt.Errorf("invalid duplicateEnrollmentPolicy: must be one of %s, %s and %s", DuplicateEnrollmentReject, DuplicateEnrollmentRequireApproval, DuplicateEnrollmentReplace)
+		}
+	}
+	if cfg.Service != nil && cfg.Service.EnrollmentRequestTtl != "" {
+		if d, err := time.ParseDuration(cfg.Service.EnrollmentRequestTtl); err != nil || d <= 0 {
+			return fmt.Errorf("invalid enrollmentRequestTtl: must be a positive duration such as 168h")
+		}
+	}
+	if cfg.Service != nil && cfg.Service.Acme != nil && len(cfg.Service.Acme.Domains) == 0 {
+		return fmt.Errorf("invalid acme: domains must be set")
+	}
+	if cfg.Service != nil {
+		if err := validateLabelSchemas(cfg.Service.LabelSchemas); err != nil {
+			return fmt.Errorf("invalid labelSchemas: %v", err)
+		}
+	}
+	if cfg.Service != nil && cfg.Service.ConsoleGrants != nil {
+		if err := validateConsoleGrants(cfg.Service.ConsoleGrants); err != nil {
+			return fmt.Errorf("invalid consoleGrants: %v", err)
+		}
+	}
+	if cfg.Service != nil && cfg.Service.Tunnels != nil {
+		if err := validateTunnels(cfg.Service.Tunnels); err != nil {
+			return fmt.Errorf("invalid tunnels: %v", err)
+		}
+	}
+	if cfg.Service != nil {
+		if err := validateExecPolicies(cfg.Service.ExecPolicies); err != nil {
+			return fmt.Errorf("invalid execPolicies: %v", err)
+		}
+	}
+	if cfg.CA != nil && cfg.CA.External != nil {
+		if err := validateExternalCA(cfg.CA.External); err != nil {
+			return fmt.Errorf("invalid external CA: %v", err)
+		}
+	}
+	if cfg.Artifacts != nil {
+		if err := validateArtifacts(cfg.Artifacts); err != nil {
+			return fmt.Errorf("invalid artifacts: %v", err)
+		}
+	}
+	if cfg.Inventory != nil {
+		if cfg.Inventory.Url == "" {
+			return fmt.Errorf("invalid inventory: url must be set")
+		}
+		if cfg.Inventory.Timeout != "" {
+			if d, err := time.ParseDuration(cfg.Inventory.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("invalid inventory: timeout must be a positive duration such as 10s")
+			}
+		}
+	}
+	if cfg.InventoryExport != nil {
+		if err := validateInventoryExport(cfg.InventoryExport, cfg.Artifacts != nil); err != nil {
+			return fmt.Errorf("invalid inventoryExport: %v", err)
+		}
+	}
+	if cfg.SpecDelivery != nil {
+		if _, err := mqtt.New(mqtt.Options{Broker: cfg.SpecDelivery.Broker}); err != nil {
+			return fmt.Errorf("invalid specDelivery: %v", err)
+		}
+		if cfg.SpecDelivery.MaxSpecSize < 0 {
+			return fmt.Errorf("invalid specDelivery: maxSpecSize must not be negative")
+		}
+	}
+	return nil
+}
+
+func validateArtifacts(c *artifactsConfig) error {
+	switch {
+	case (c.S3 != nil) == (c.Filesystem != nil):
+		return fmt.Errorf("exactly one of s3 or filesystem must be configured")
+	case c.S3 != nil:
+		if c.S3.Endpoint == "" || c.S3.Bucket == "" || c.S3.AccessKeyID == "" || c.S3.SecretAccessKeyFile == "" {
+			return fmt.Errorf("s3 requires endpoint, bucket, accessKeyId and secretAccessKeyFile")
+		}
+	case c.Filesystem != nil:
+		if c.Filesystem.Path == "" {
+			return fmt.Errorf("filesystem requires path")
+		}
+	}
+	if c.DownloadUrlExpiration != "" {
+		if _, err := time.ParseDuration(c.DownloadUrlExpiration); err != nil {
+			return fmt.Errorf("downloadUrlExpiration: %v", err)
+		}
+	}
+	for kind, retention := range c.Retention {
+		if d, err := time.ParseDuration(retention); err != nil || d <= 0 {
+			return fmt.Errorf("retention of %s must be a positive duration such as 720h", kind)
+		}
+	}
 	return nil
 }
 
+func validateInventoryExport(c *inventoryExportConfig, artifactsConfigured bool) error {
+	if c.Url == "" && !c.Csv {
+		return fmt.Errorf("url or csv must be set")
+	}
+	if c.Csv && !artifactsConfigured {
+		return fmt.Errorf("csv requires the artifact storage to be configured")
+	}
+	if c.Interval != "" {
+		if d, err := time.ParseDuration(c.Interval); err != nil || d < time.Minute {
+			return fmt.Errorf("interval must be a duration of at least 1m such as 1h")
+		}
+	}
+	for name, field := range c.Fields {
+		if name == "" {
+			return fmt.Errorf("fields must not have an empty name")
+		}
+		if !strings.HasPrefix(field, "metadata.") && !strings.HasPrefix(field, "spec.") && !strings.HasPrefix(field, "status.") {
+			return fmt.Errorf("field %s must refer to a field of the metadata, spec or status of the device, such as status.systemInfo.architecture", name)
+		}
+	}
+	return nil
+}
+
+func validateExternalCA(c *externalCAConfig) error {
+	configured := 0
+	for _, set := range []bool{c.Vault != nil, c.CertManager != nil, c.EST != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return fmt.Errorf("exactly one of vault, certManager or est must be configured")
+	}
+	if c.CACertFile == "" {
+		return fmt.Errorf("caCertFile must be set")
+	}
+	switch {
+	case c.Vault != nil:
+		if c.Vault.Address == "" || c.Vault.Role == "" || c.Vault.TokenFile == "" {
+			return fmt.Errorf("vault requires address, role and tokenFile")
+		}
+	case c.CertManager != nil:
+		if c.CertManager.Namespace == "" || c.CertManager.IssuerName == "" {
+			return fmt.Errorf("certManager requires namespace and issuerName")
+		}
+	case c.EST != nil:
+		if c.EST.Url == "" {
+			return fmt.Errorf("est requires url")
+		}
+	}
+	return nil
+}
+
+func validateDatabase(c *dbConfig) error {
+	if c.MaxOpenConns < 0 || c.MaxIdleConns < 0 {
+		return fmt.Errorf("maxOpenConns and maxIdleConns must not be negative")
+	}
+	if c.MaxIdleConns > c.MaxOpenConnections() {
+		return fmt.Errorf("maxIdleConns must not exceed maxOpenConns")
+	}
+	for name, value := range map[string]string{"connMaxLifetime": c.ConnMaxLifetime, "connMaxIdleTime": c.ConnMaxIdleTime} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration such as 30m", name)
+		}
+	}
+	if len(c.ReadReplicas) > 0 && c.Type != "pgsql" {
+		return fmt.Errorf("readReplicas are only supported for pgsql databases")
+	}
+	for i, replica := range c.ReadReplicas {
+		if replica.Hostname == "" {
+			return fmt.Errorf("readReplicas[%d]: hostname must be set", i)
+		}
+	}
+	return nil
+}
+
+// MaxOpenConnections returns the most connections each service opens to the
+// database and to each of its read replicas.
+func (c *dbConfig) MaxOpenConnections() int {
+	if c == nil || c.MaxOpenConns == 0 {
+		return DefaultDBMaxOpenConns
+	}
+	return c.MaxOpenConns
+}
+
+// MaxIdleConnections returns the most idle connections each service keeps
+// open to the database and to each of its read replicas.
+func (c *dbConfig) MaxIdleConnections() int {
+	if c == nil || c.MaxIdleConns == 0 {
+		return min(DefaultDBMaxIdleConns, c.MaxOpenConnections())
+	}
+	return c.MaxIdleConns
+}
+
+// ConnectionMaxLifetime returns the time after which connections are closed
+// since they were opened, or 0 if they are kept open.
+func (c *dbConfig) ConnectionMaxLifetime() time.Duration {
+	if c == nil {
+		return 0
+	}
+	d, _ := time.ParseDuration(c.ConnMaxLifetime)
+	return max(d, 0)
+}
+
+// ConnectionMaxIdleTime returns the time after which connections are closed
+// since they were last used, or 0 if they are kept open.
+func (c *dbConfig) ConnectionMaxIdleTime() time.Duration {
+	if c == nil {
+		return 0
+	}
+	d, _ := time.ParseDuration(c.ConnMaxIdleTime)
+	return max(d, 0)
+}
+
+// GracefulShutdownTimeout returns the time the service drains its connections
+// and task queues for once it is asked to stop.
+func (c *svcConfig) GracefulShutdownTimeout() time.Duration {
+	if c == nil || c.ShutdownTimeout == "" {
+		return DefaultShutdownTimeout
+	}
+	timeout, err := time.ParseDuration(c.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		return DefaultShutdownTimeout
+	}
+	return timeout
+}
+
+// TrashRetentionPeriod returns the time deleted devices and fleets are kept
+// in the trash for before they are purged.
+func (c *svcConfig) TrashRetentionPeriod() time.Duration {
+	if c == nil || c.TrashRetention == "" {
+		return DefaultTrashRetention
+	}
+	retention, err := time.ParseDuration(c.TrashRetention)
+	if err != nil || retention <= 0 {
+		return DefaultTrashRetention
+	}
+	return retention
+}
+
+// EnrollmentRequestTTL returns the time enrollment requests are kept pending
+// approval for before they expire and are deleted.
+func (c *svcConfig) EnrollmentRequestTTL() time.Duration {
+	if c == nil || c.EnrollmentRequestTtl == "" {
+		return DefaultEnrollmentRequestTTL
+	}
+	ttl, err := time.ParseDuration(c.EnrollmentRequestTtl)
+	if err != nil || ttl <= 0 {
+		return DefaultEnrollmentRequestTTL
+	}
+	return ttl
+}
+
+// RenderedSpecCacheEntries returns the number of devices whose rendered specs
+// the API caches, or 0 if they are not cached.
+func (c *svcConfig) RenderedSpecCacheEntries() int {
+	if c == nil || c.RenderedSpecCacheSize == 0 {
+		return DefaultRenderedSpecCacheSize
+	}
+	return max(c.RenderedSpecCacheSize, 0)
+}
+
+// DuplicateEnrollment returns the policy for the enrollment of a machine
+// already enrolled as another device.
+func (c *svcConfig) DuplicateEnrollment() string {
+	if c == nil || c.DuplicateEnrollmentPolicy == "" {
+		return DuplicateEnrollmentRequireApproval
+	}
+	return c.DuplicateEnrollmentPolicy
+}
+
+// GrantMaxTTL returns the longest time an approved console grant is valid for.
+func (c *ConsoleGrantConfig) GrantMaxTTL() time.Duration {
+	if c == nil || c.MaxTTL == "" {
+		return DefaultConsoleGrantMaxTTL
+	}
+	ttl, err := time.ParseDuration(c.MaxTTL)
+	if err != nil || ttl <= 0 {
+		return DefaultConsoleGrantMaxTTL
+	}
+	return ttl
+}
+
+// TunnelMaxTTL returns the longest time a support tunnel stays open for.
+func (c *TunnelConfig) TunnelMaxTTL() time.Duration {
+	if c == nil || c.MaxTTL == "" {
+		return DefaultTunnelMaxTTL
+	}
+	ttl, err := time.ParseDuration(c.MaxTTL)
+	if err != nil || ttl <= 0 {
+		return DefaultTunnelMaxTTL
+	}
+	return ttl
+}
+
 func (cfg *Config) String() string {
 	contents, err := json.Marshal(cfg)
 	if err != nil {
@@ -159,3 +773,84 @@ func (cfg *Config) String() string {
 	}
 	return string(contents)
 }
+
+func validateLabelSchemas(schemas []LabelSchema) error {
+	keys := map[string]struct{}{}
+	for _, schema := range schemas {
+		if errs := validation.IsQualifiedName(schema.Key); len(errs) > 0 {
+			return fmt.Errorf("key %q: %s", schema.Key, strings.Join(errs, ", "))
+		}
+		if _, ok := keys[schema.Key]; ok {
+			return fmt.Errorf("key %q has several schemas", schema.Key)
+		}
+		keys[schema.Key] = struct{}{}
+		for _, value := range schema.AllowedValues {
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("key %q: allowed value %q: %s", schema.Key, value, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func validateConsoleGrants(c *ConsoleGrantConfig) error {
+	if strings.TrimSpace(c.Selector) == "" {
+		return fmt.Errorf("selector must be set")
+	}
+	if _, err := labels.Parse(c.Selector); err != nil {
+		return fmt.Errorf("selector: %v", err)
+	}
+	if c.MaxTTL != "" {
+		if d, err := time.ParseDuration(c.MaxTTL); err != nil || d <= 0 {
+			return fmt.Errorf("maxTtl must be a positive duration such as 1h")
+		}
+	}
+	return nil
+}
+
+func validateTunnels(c *TunnelConfig) error {
+	if len(c.Ports) == 0 {
+		return fmt.Errorf("ports must be set")
+	}
+	for _, port := range c.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d is not a TCP port", port)
+		}
+	}
+	if c.MaxTTL != "" {
+		if d, err := time.ParseDuration(c.MaxTTL); err != nil || d <= 0 {
+			return fmt.Errorf("maxTtl must be a positive duration such as 1h")
+		}
+	}
+	return nil
+}
+
+func validateExecPolicies(policies []ExecPolicy) error {
+	names := map[string]struct{}{}
+	for _, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("name must be set")
+		}
+		if _, ok := names[policy.Name]; ok {
+			return fmt.Errorf("several policies are named %q", policy.Name)
+		}
+		names[policy.Name] = struct{}{}
+		if len(policy.Commands) == 0 {
+			return fmt.Errorf("policy %q: commands must be set", policy.Name)
+		}
+		for _, command := range policy.Commands {
+			if !filepath.IsAbs(command.Command) {
+				return fmt.Errorf("policy %q: command %q must be an absolute path", policy.Name, command.Command)
+			}
+			for i, pattern := range command.Args {
+				if pattern == "**" && i != len(command.Args)-1 {
+					return fmt.Errorf("policy %q: command %q: ** must be the last argument pattern", policy.Name, command.Command)
+				}
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("policy %q: command %q: invalid argument pattern %q", policy.Name, command.Command, pattern)
+				}
+			}
+		}
+	}
+	return nil
+}
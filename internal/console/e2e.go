@@ -0,0 +1,267 @@
+package console
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	fcrypto "github.com/flightctl/flightctl/internal/crypto"
+	"golang.org/x/crypto/hkdf"
+)
+
+// handshakeContext binds the signature of the device and the keys of a
+// session to their purpose.
+const handshakeContext = "flightctl console e2e v1"
+
+// Handshake is the first message the device sends on an end-to-end encrypted
+// session. It carries the ephemeral public key of the device, signed with the
+// key of its agent certificate, so that the operator knows the session key is
+// agreed with the device rather than with whatever forwards the session.
+type Handshake struct {
+	// PublicKey is the base64-encoded ephemeral X25519 public key of the device.
+	PublicKey string `json:"publicKey"`
+	// Certificate is the PEM chain of the agent certificate of the device, leaf first.
+	Certificate string `json:"certificate"`
+	// Signature is the base64-encoded signature of the transcript of the handshake.
+	Signature string `json:"signature"`
+}
+
+// ParsePublicKey parses a base64-encoded X25519 public key.
+func ParsePublicKey(key string) (*ecdh.PublicKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("decoding public key: %w", err)
+	}
+	publicKey, err := ecdh.X25519().NewPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	return publicKey, nil
+}
+
+// OperatorKey is the ephemeral key of the operator of an end-to-end encrypted
+// session. Its public key is sent with the console request, and the service
+// passes it on to the device.
+type OperatorKey struct {
+	key *ecdh.PrivateKey
+}
+
+// NewOperatorKey generates the key of a new session.
+func NewOperatorKey() (*OperatorKey, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &OperatorKey{key: key}, nil
+}
+
+// PublicKey returns the base64-encoded public key of the operator.
+func (k *OperatorKey) PublicKey() string {
+	return base64.StdEncoding.EncodeToString(k.key.PublicKey().Bytes())
+}
+
+// Accept verifies the handshake of the device of a session against the CAs of
+// the device certificates, and returns the encryption of the session.
+func (k *OperatorKey) Accept(message []byte, sessionID string, deviceName string, deviceCAs *x509.CertPool) (*Session, error) {
+	var handshake Handshake
+	if err := json.Unmarshal(message, &handshake); err != nil {
+		return nil, fmt.Errorf("decoding handshake: %w", err)
+	}
+	deviceKey, err := ParsePublicKey(handshake.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := middleware.VerifyClientCertificate(handshake.Certificate, deviceCAs)
+	if err != nil {
+		return nil, fmt.Errorf("verifying the certificate of the device: %w", err)
+	}
+	cn, err := fcrypto.CNFromDeviceFingerprint(deviceName)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Subject.CommonName != cn {
+		return nil, fmt.Errorf("the certificate of the device is issued to %q instead of %q", cert.Subject.CommonName, cn)
+	}
+	signature, err := base64.StdEncoding.DecodeString(handshake.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	digest := transcriptDigest(sessionID, k.key.PublicKey(), deviceKey)
+	if err := verifySignature(cert.PublicKey, digest, signature); err != nil {
+		return nil, err
+	}
+	shared, err := k.key.ECDH(deviceKey)
+	if err != nil {
+		return nil, err
+	}
+	toDevice, fromDevice, err := sessionKeys(shared, sessionID, k.key.PublicKey(), deviceKey)
+	if err != nil {
+		return nil, err
+	}
+	return newSession(toDevice, fromDevice)
+}
+
+// AcceptOperator agrees the key of a session with the public key of its
+// operator on the device, signing the handshake with the agent certificate.
+// It returns the handshake the device sends first, and the encryption of the
+// session.
+func AcceptOperator(operatorKey string, sessionID string, identity tls.Certificate) ([]byte, *Session, error) {
+	operator, err := ParsePublicKey(operatorKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := identity.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.New("the key of the agent certificate cannot sign")
+	}
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := signer.Sign(rand.Reader, transcriptDigest(sessionID, operator, key.PublicKey()), crypto.SHA256)
+	if err != nil {
+		return nil, nil, fmt.Errorf("signing handshake: %w", err)
+	}
+	chain := []byte{}
+	for _, cert := range identity.Certificate {
+		chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})...)
+	}
+	message, err := json.Marshal(Handshake{
+		PublicKey:   base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Certificate: string(chain),
+		Signature:   base64.StdEncoding.EncodeToString(signature),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	shared, err := key.ECDH(operator)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDevice, fromDevice, err := sessionKeys(shared, sessionID, operator, key.PublicKey())
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := newSession(fromDevice, toDevice)
+	if err != nil {
+		return nil, nil, err
+	}
+	return message, session, nil
+}
+
+// transcriptDigest returns the digest the device signs: the session and both
+// of its public keys, so that the signature cannot be replayed on another.
+func transcriptDigest(sessionID string, operator, device *ecdh.PublicKey) []byte {
+	h := sha256.New()
+	h.Write([]byte(handshakeContext))
+	h.Write([]byte{0})
+	h.Write([]byte(sessionID))
+	h.Write([]byte{0})
+	h.Write(operator.Bytes())
+	h.Write(device.Bytes())
+	return h.Sum(nil)
+}
+
+func verifySignature(publicKey crypto.PublicKey, digest []byte, signature []byte) error {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("invalid signature of the handshake")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("invalid signature of the handshake: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T of the device certificate", publicKey)
+	}
+}
+
+// sessionKeys derives the keys of both directions of a session from the
+// shared secret of its public keys.
+func sessionKeys(shared []byte, sessionID string, operator, device *ecdh.PublicKey) ([]byte, []byte, error) {
+	salt := append(append([]byte{}, operator.Bytes()...), device.Bytes()...)
+	keys := make([]byte, 64)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, salt, []byte(handshakeContext+" "+sessionID)), keys); err != nil {
+		return nil, nil, err
+	}
+	return keys[:32], keys[32:], nil
+}
+
+// Session encrypts the payloads of an end-to-end encrypted session with
+// AES-GCM. Each direction has its own key, and the nonce of a payload is its
+// sequence number, so payloads which are dropped, replayed or reordered fail
+// to open.
+type Session struct {
+	sendMu  sync.Mutex
+	send    cipher.AEAD
+	sendSeq uint64
+
+	recvMu  sync.Mutex
+	recv    cipher.AEAD
+	recvSeq uint64
+}
+
+func newSession(sendKey, recvKey []byte) (*Session, error) {
+	send, err := newAEAD(sendKey)
+	if err != nil {
+		return nil, err
+	}
+	recv, err := newAEAD(recvKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{send: send, recv: recv}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func nonce(size int, seq uint64) []byte {
+	n := make([]byte, size)
+	binary.BigEndian.PutUint64(n[size-8:], seq)
+	return n
+}
+
+// seal encrypts the next payload sent. The caller holds sendMu, so that the
+// payloads are sent in the order they are sealed.
+func (s *Session) seal(payload []byte) []byte {
+	sealed := s.send.Seal(nil, nonce(s.send.NonceSize(), s.sendSeq), payload, nil)
+	s.sendSeq++
+	return sealed
+}
+
+// open decrypts the next payload received.
+func (s *Session) open(payload []byte) ([]byte, error) {
+	s.recvMu.Lock()
+	defer s.recvMu.Unlock()
+	opened, err := s.recv.Open(nil, nonce(s.recv.NonceSize(), s.recvSeq), payload, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting payload %d of the session: %w", s.recvSeq, err)
+	}
+	s.recvSeq++
+	return opened, nil
+}
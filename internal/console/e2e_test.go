@@ -0,0 +1,137 @@
+package console
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"path/filepath"
+	"testing"
+
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const deviceName = "0123456789abcdef"
+
+func newDeviceIdentity(t *testing.T, name string) (tls.Certificate, *x509.CertPool) {
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	cert, key, err := ca.IssueClientCertificate("device:"+name, 3600)
+	require.NoError(t, err)
+	identity, err := tls.X509KeyPair(cert, key)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	for _, caCert := range ca.Config.Certs {
+		pool.AddCert(caCert)
+	}
+	return identity, pool
+}
+
+// pipeStream is one side of a console stream, which the other side of the
+// test reads what it sends from.
+type pipeStream struct {
+	grpc_v1.RouterService_StreamClient
+	sent []*grpc_v1.StreamRequest
+	recv []*grpc_v1.StreamResponse
+}
+
+func (s *pipeStream) Send(msg *grpc_v1.StreamRequest) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func (s *pipeStream) Recv() (*grpc_v1.StreamResponse, error) {
+	if len(s.recv) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.recv[0]
+	s.recv = s.recv[1:]
+	return msg, nil
+}
+
+// deliver passes the messages the stream sent on to the other side.
+func (s *pipeStream) deliver(to *pipeStream) {
+	for _, msg := range s.sent {
+		to.recv = append(to.recv, &grpc_v1.StreamResponse{Payload: msg.Payload, Closed: msg.Closed})
+	}
+	s.sent = nil
+}
+
+func TestEndToEndSession(t *testing.T) {
+	require := require.New(t)
+	identity, deviceCAs := newDeviceIdentity(t, deviceName)
+
+	operatorKey, err := NewOperatorKey()
+	require.NoError(err)
+	handshake, deviceSession, err := AcceptOperator(operatorKey.PublicKey(), "session-1", identity)
+	require.NoError(err)
+	operatorSession, err := operatorKey.Accept(handshake, "session-1", deviceName, deviceCAs)
+	require.NoError(err)
+
+	deviceSide, operatorSide := &pipeStream{}, &pipeStream{}
+	device := EncryptStream(deviceSide, deviceSession)
+	operator := EncryptStream(operatorSide, operatorSession)
+
+	// what is forwarded is not the payload, which each side reads in order
+	require.NoError(operator.Send(&grpc_v1.StreamRequest{Payload: []byte("ls -l\n")}))
+	require.NoError(operator.Send(&grpc_v1.StreamRequest{Payload: []byte("exit\n")}))
+	require.NotContains(string(operatorSide.sent[0].Payload), "ls -l")
+	operatorSide.deliver(deviceSide)
+	msg, err := device.Recv()
+	require.NoError(err)
+	require.Equal("ls -l\n", string(msg.Payload))
+	msg, err = device.Recv()
+	require.NoError(err)
+	require.Equal("exit\n", string(msg.Payload))
+
+	require.NoError(device.Send(&grpc_v1.StreamRequest{Payload: []byte("total 0\n")}))
+	require.NoError(device.Send(&grpc_v1.StreamRequest{Closed: true}))
+	deviceSide.deliver(operatorSide)
+	msg, err = operator.Recv()
+	require.NoError(err)
+	require.Equal("total 0\n", string(msg.Payload))
+	msg, err = operator.Recv()
+	require.NoError(err)
+	require.True(msg.Closed)
+
+	// a payload which is replayed or altered does not open
+	require.NoError(device.Send(&grpc_v1.StreamRequest{Payload: []byte("secret\n")}))
+	replayed := deviceSide.sent[0]
+	deviceSide.deliver(operatorSide)
+	_, err = operator.Recv()
+	require.NoError(err)
+	operatorSide.recv = append(operatorSide.recv, &grpc_v1.StreamResponse{Payload: replayed.Payload})
+	_, err = operator.Recv()
+	require.Error(err)
+}
+
+func TestEndToEndHandshakeVerifiesTheDevice(t *testing.T) {
+	require := require.New(t)
+	identity, deviceCAs := newDeviceIdentity(t, deviceName)
+	operatorKey, err := NewOperatorKey()
+	require.NoError(err)
+	handshake, _, err := AcceptOperator(operatorKey.PublicKey(), "session-1", identity)
+	require.NoError(err)
+
+	// the handshake is bound to its session and device
+	_, err = operatorKey.Accept(handshake, "session-2", deviceName, deviceCAs)
+	require.ErrorContains(err, "signature")
+	_, err = operatorKey.Accept(handshake, "session-1", "fedcba9876543210", deviceCAs)
+	require.ErrorContains(err, "issued to")
+
+	// and to the CAs of the device certificates
+	_, otherCAs := newDeviceIdentity(t, deviceName)
+	_, err = operatorKey.Accept(handshake, "session-1", deviceName, otherCAs)
+	require.Error(err)
+
+	// and to the key of the operator
+	otherKey, err := NewOperatorKey()
+	require.NoError(err)
+	_, err = otherKey.Accept(handshake, "session-1", deviceName, deviceCAs)
+	require.ErrorContains(err, "signature")
+
+	_, _, err = AcceptOperator("not a key", "session-1", identity)
+	require.Error(err)
+}
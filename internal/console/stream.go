@@ -0,0 +1,43 @@
+package console
+
+import (
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+)
+
+// encryptedStream encrypts the payloads of a console stream, so that the
+// service and the relays forwarding it only see their size. The close
+// messages are sent as they are, since the service ends the session on them.
+type encryptedStream struct {
+	grpc_v1.RouterService_StreamClient
+	session *Session
+}
+
+// EncryptStream returns a console stream which encrypts the payloads sent on
+// stream, and decrypts those received, with the encryption of a session.
+func EncryptStream(stream grpc_v1.RouterService_StreamClient, session *Session) grpc_v1.RouterService_StreamClient {
+	return &encryptedStream{RouterService_StreamClient: stream, session: session}
+}
+
+func (s *encryptedStream) Send(msg *grpc_v1.StreamRequest) error {
+	if len(msg.Payload) == 0 {
+		return s.RouterService_StreamClient.Send(msg)
+	}
+	s.session.sendMu.Lock()
+	defer s.session.sendMu.Unlock()
+	return s.RouterService_StreamClient.Send(&grpc_v1.StreamRequest{
+		Payload: s.session.seal(msg.Payload),
+		Closed:  msg.Closed,
+	})
+}
+
+func (s *encryptedStream) Recv() (*grpc_v1.StreamResponse, error) {
+	msg, err := s.RouterService_StreamClient.Recv()
+	if err != nil || len(msg.Payload) == 0 {
+		return msg, err
+	}
+	payload, err := s.session.open(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &grpc_v1.StreamResponse{Payload: payload, Closed: msg.Closed}, nil
+}
@@ -0,0 +1,196 @@
+package console
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+)
+
+// The payloads of a tunnel stream are frames, which multiplex the TCP
+// connections forwarded through the tunnel. Each frame is its type and the ID
+// of its connection, followed by the data of the connection for data frames.
+// The operator side allocates the IDs, so that the two sides never collide.
+const (
+	frameOpen byte = iota + 1
+	frameData
+	frameClose
+
+	frameHeaderSize = 5
+	// maxFrameData keeps the frames well below the message size limit of gRPC
+	maxFrameData = 32 * 1024
+)
+
+// tunnelMux forwards the connections of a tunnel over its stream.
+type tunnelMux struct {
+	stream grpc_v1.RouterService_StreamClient
+	sendMu sync.Mutex
+
+	mu    sync.Mutex
+	conns map[uint32]net.Conn
+}
+
+func newTunnelMux(stream grpc_v1.RouterService_StreamClient) *tunnelMux {
+	return &tunnelMux{stream: stream, conns: map[uint32]net.Conn{}}
+}
+
+func (m *tunnelMux) send(frameType byte, id uint32, data []byte) error {
+	frame := make([]byte, frameHeaderSize+len(data))
+	frame[0] = frameType
+	binary.BigEndian.PutUint32(frame[1:], id)
+	copy(frame[frameHeaderSize:], data)
+	m.sendMu.Lock()
+	defer m.sendMu.Unlock()
+	return m.stream.Send(&grpc_v1.StreamRequest{Payload: frame})
+}
+
+func (m *tunnelMux) add(id uint32, conn net.Conn) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conns[id] = conn
+}
+
+// remove closes a connection, and returns false if it was already removed.
+func (m *tunnelMux) remove(id uint32) bool {
+	m.mu.Lock()
+	conn, ok := m.conns[id]
+	delete(m.conns, id)
+	m.mu.Unlock()
+	if ok {
+		conn.Close()
+	}
+	return ok
+}
+
+func (m *tunnelMux) get(id uint32) net.Conn {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conns[id]
+}
+
+func (m *tunnelMux) closeAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, conn := range m.conns {
+		conn.Close()
+		delete(m.conns, id)
+	}
+}
+
+// pump forwards what is read from a connection until it ends, and then tells
+// the other side to close it, unless it was the other side which closed it.
+func (m *tunnelMux) pump(id uint32, conn net.Conn) {
+	buffer := make([]byte, maxFrameData)
+	for {
+		n, err := conn.Read(buffer)
+		if n > 0 {
+			if sendErr := m.send(frameData, id, buffer[:n]); sendErr != nil {
+				m.remove(id)
+				return
+			}
+		}
+		if err != nil {
+			if m.remove(id) {
+				_ = m.send(frameClose, id, nil)
+			}
+			return
+		}
+	}
+}
+
+// receive dispatches the frames received on the stream until it ends. The
+// open frames are passed to open, which is only set on the device side.
+func (m *tunnelMux) receive(open func(id uint32) error) error {
+	defer m.closeAll()
+	for {
+		msg, err := m.stream.Recv()
+		if errors.Is(err, io.EOF) || msg != nil && msg.Closed {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		payload := msg.GetPayload()
+		if len(payload) < frameHeaderSize {
+			return fmt.Errorf("tunnel frame of %d bytes is too short", len(payload))
+		}
+		id := binary.BigEndian.Uint32(payload[1:frameHeaderSize])
+		switch payload[0] {
+		case frameOpen:
+			if open == nil {
+				return fmt.Errorf("unexpected open frame for connection %d", id)
+			}
+			if err := open(id); err != nil {
+				return err
+			}
+		case frameData:
+			// the data of a connection the other side has yet to learn is
+			// closed is dropped
+			conn := m.get(id)
+			if conn == nil {
+				continue
+			}
+			if _, err := conn.Write(payload[frameHeaderSize:]); err != nil && m.remove(id) {
+				if err := m.send(frameClose, id, nil); err != nil {
+					return err
+				}
+			}
+		case frameClose:
+			m.remove(id)
+		default:
+			return fmt.Errorf("unknown tunnel frame type %d", payload[0])
+		}
+	}
+}
+
+// ServeTunnel serves the connections forwarded through a tunnel on the
+// device, with dial connecting each of them to the service of the tunnel. It
+// returns once the stream ends.
+func ServeTunnel(ctx context.Context, stream grpc_v1.RouterService_StreamClient, dial func(ctx context.Context) (net.Conn, error)) error {
+	m := newTunnelMux(stream)
+	return m.receive(func(id uint32) error {
+		conn, err := dial(ctx)
+		if err != nil {
+			// the connection fails, not the tunnel
+			return m.send(frameClose, id, nil)
+		}
+		m.add(id, conn)
+		go m.pump(id, conn)
+		return nil
+	})
+}
+
+// ForwardTunnel forwards the connections accepted on listener through a
+// tunnel on the operator side. It closes listener, and returns once the
+// stream or the context ends.
+func ForwardTunnel(ctx context.Context, stream grpc_v1.RouterService_StreamClient, listener net.Listener) error {
+	m := newTunnelMux(stream)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	go func() {
+		var id uint32
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			id++
+			m.add(id, conn)
+			if err := m.send(frameOpen, id, nil); err != nil {
+				m.remove(id)
+				return
+			}
+			go m.pump(id, conn)
+		}
+	}()
+	return m.receive(nil)
+}
@@ -0,0 +1,122 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	grpc_v1 "github.com/flightctl/flightctl/api/grpc/v1"
+	"github.com/stretchr/testify/require"
+)
+
+// chanStream is one side of a tunnel stream, whose messages are received by
+// the other side as they are sent.
+type chanStream struct {
+	grpc_v1.RouterService_StreamClient
+	ctx  context.Context
+	send chan<- *grpc_v1.StreamResponse
+	recv <-chan *grpc_v1.StreamResponse
+}
+
+func newChanStreams(ctx context.Context) (*chanStream, *chanStream) {
+	a, b := make(chan *grpc_v1.StreamResponse, 16), make(chan *grpc_v1.StreamResponse, 16)
+	return &chanStream{ctx: ctx, send: a, recv: b}, &chanStream{ctx: ctx, send: b, recv: a}
+}
+
+func (s *chanStream) Send(msg *grpc_v1.StreamRequest) error {
+	select {
+	case s.send <- &grpc_v1.StreamResponse{Payload: msg.Payload, Closed: msg.Closed}:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func (s *chanStream) Recv() (*grpc_v1.StreamResponse, error) {
+	select {
+	case msg := <-s.recv:
+		return msg, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func TestTunnelForwardsConnections(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// the service on the device echoes what it reads
+	service, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer service.Close()
+	go func() {
+		for {
+			conn, err := service.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	deviceSide, operatorSide := newChanStreams(ctx)
+	dials := 0
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeTunnel(ctx, deviceSide, func(ctx context.Context) (net.Conn, error) {
+			dials++
+			if dials > 1 {
+				return nil, errors.New("refused")
+			}
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", service.Addr().String())
+		})
+	}()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	forwarded := make(chan error, 1)
+	go func() { forwarded <- ForwardTunnel(ctx, operatorSide, listener) }()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("hello"))
+	require.NoError(err)
+	reply := make([]byte, 5)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(err)
+	require.Equal("hello", string(reply))
+
+	// a connection which the device cannot dial is closed, and the tunnel
+	// keeps forwarding the others
+	refused, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer refused.Close()
+	_, err = refused.Read(reply)
+	require.ErrorIs(err, io.EOF)
+	_, err = conn.Write([]byte("again"))
+	require.NoError(err)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(err)
+	require.Equal("again", string(reply))
+
+	// closing the stream ends both sides
+	require.NoError(deviceSide.Send(&grpc_v1.StreamRequest{Closed: true}))
+	require.NoError(<-forwarded)
+	require.Eventually(func() bool {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err == nil {
+			conn.Close()
+		}
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(operatorSide.Send(&grpc_v1.StreamRequest{Closed: true}))
+	require.NoError(<-served)
+}
@@ -1,8 +1,13 @@
 package container
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
@@ -130,6 +135,63 @@ func (b *BootcCmd) Switch(ctx context.Context, image string) error {
 	}
 }
 
+// BootcProgress is the progress bootc reports while it pulls an image, in
+// bytes and in layers.
+type BootcProgress struct {
+	Type       string `json:"type"`
+	Bytes      int64  `json:"bytes"`
+	BytesTotal int64  `json:"bytesTotal"`
+	Steps      int32  `json:"steps"`
+	StepsTotal int32  `json:"stepsTotal"`
+}
+
+// ParseBootcProgress parses a line of the progress bootc writes to its
+// progress fd, which is a progress if it reports bytes.
+func ParseBootcProgress(line []byte) (BootcProgress, bool) {
+	var progress BootcProgress
+	if err := json.Unmarshal(line, &progress); err != nil || progress.Type != "ProgressBytes" {
+		return BootcProgress{}, false
+	}
+	return progress, true
+}
+
+// SwitchWithProgress stages the image like Switch, calling progress with the
+// progress bootc reports while it pulls the image. A version of bootc which
+// cannot report its progress stages the image without it.
+func (b *BootcCmd) SwitchWithProgress(ctx context.Context, image string, progress func(BootcProgress)) error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("creating progress pipe: %w", err)
+	}
+	defer reader.Close()
+
+	// the progress is written to the first extra file, fd 3
+	cmd := b.executer.CommandContext(ctx, CmdBootc, "switch", "--retain", "--progress-fd", "3", image)
+	cmd.ExtraFiles = []*os.File{writer}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		writer.Close()
+		return fmt.Errorf("stage image: %w", err)
+	}
+	writer.Close()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if p, ok := ParseBootcProgress(scanner.Bytes()); ok {
+			progress(p)
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		if strings.Contains(stderr.String(), "--progress-fd") {
+			return b.Switch(ctx, image)
+		}
+		return fmt.Errorf("stage image: %s", stderr.String())
+	}
+	return nil
+}
+
 // Apply restart or reboot into the new target image.
 func (b *BootcCmd) Apply(ctx context.Context) error {
 	args := []string{"upgrade", "--apply"}
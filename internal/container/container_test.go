@@ -39,3 +39,18 @@ func TestBootcHost(t *testing.T) {
 	require.Equal(4, status.Status.Staged.Ostree.DeploySerial)
 
 }
+
+func TestParseBootcProgress(t *testing.T) {
+	require := require.New(t)
+
+	progress, ok := ParseBootcProgress([]byte(`{"type":"ProgressBytes","apiVersion":"org.containers.bootc.progress/v1",` +
+		`"task":"pulling","description":"Pulling Image: quay.io/acme/os:2","bytesCached":0,"bytes":1048576,"bytesTotal":4194304,` +
+		`"stepsCached":0,"steps":2,"stepsTotal":8,"subtasks":[]}`))
+	require.True(ok)
+	require.Equal(BootcProgress{Type: "ProgressBytes", Bytes: 1048576, BytesTotal: 4194304, Steps: 2, StepsTotal: 8}, progress)
+
+	_, ok = ParseBootcProgress([]byte(`{"type":"ProgressSteps","task":"deploying","steps":1,"stepsTotal":3}`))
+	require.False(ok)
+	_, ok = ParseBootcProgress([]byte(`Pulling image`))
+	require.False(ok)
+}
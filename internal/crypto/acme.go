@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"crypto/tls"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ACMEConfig configures obtaining the certificate of the API server from an
+// ACME CA such as Let's Encrypt.
+type ACMEConfig struct {
+	// Domains are the names the certificate is obtained for
+	Domains []string
+	// Email is the contact of the ACME account, optional
+	Email string
+	// DirectoryURL is the directory of the ACME CA, Let's Encrypt by default
+	DirectoryURL string
+	// CacheDir keeps the ACME account key and the certificates across restarts
+	CacheDir string
+}
+
+// WithACME returns a copy of the TLS config of the API server that serves a
+// certificate obtained and renewed from the ACME CA to clients connecting to
+// one of the domains. Other clients, such as those connecting by IP address
+// or to an internal name, are served the certificate of the TLS config. The
+// domains are validated with the TLS-ALPN-01 challenge, so the API server
+// must be reachable on port 443 of the domains.
+func WithACME(tlsConfig *tls.Config, cfg ACMEConfig) *tls.Config {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.Domains...),
+		Cache:      autocert.DirCache(cfg.CacheDir),
+		Email:      cfg.Email,
+	}
+	if cfg.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: cfg.DirectoryURL}
+	}
+
+	domains := make([]string, 0, len(cfg.Domains))
+	for _, domain := range cfg.Domains {
+		domains = append(domains, strings.ToLower(domain))
+	}
+	fallback := tlsConfig.GetCertificate
+
+	acmeTlsConfig := tlsConfig.Clone()
+	nextProtos := slices.Clone(tlsConfig.NextProtos)
+	if len(nextProtos) == 0 {
+		// clients offering application protocols are refused once the
+		// server sets some, unless one of them is common
+		nextProtos = []string{"http/1.1"}
+	}
+	acmeTlsConfig.NextProtos = append(nextProtos, acme.ALPNProto)
+	acmeTlsConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if !slices.Contains(domains, strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))) {
+			return fallback(hello)
+		}
+		return manager.GetCertificate(hello)
+	}
+	return acmeTlsConfig
+}
@@ -42,6 +42,10 @@ type CA struct {
 	Config *TLSCertificateConfig
 
 	SerialGenerator oscrypto.SerialGenerator
+
+	// clientSigner signs client certificates instead of the CA if set
+	clientSigner      ClientSigner
+	clientSignerCerts []*x509.Certificate
 }
 
 func EnsureCA(certFile, keyFile, serialFile, subjectName string, expireDays int) (*CA, bool, error) {
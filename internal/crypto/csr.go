@@ -59,6 +59,9 @@ func ParseCSR(csrPEM []byte) (*x509.CertificateRequest, error) {
 // IssueRequestedClientCertificate issues a client certificate based on the provided
 // Certificate Signing Request (CSR) and the desired expiration time in seconds.
 func (ca *CA) IssueRequestedClientCertificate(csr *x509.CertificateRequest, expirySeconds int) ([]byte, error) {
+	if ca.clientSigner != nil {
+		return ca.signClientCertificate(csr, expirySeconds)
+	}
 	now := time.Now()
 	template := &x509.Certificate{
 		Subject: csr.Subject,
@@ -92,3 +95,63 @@ func (ca *CA) IssueRequestedClientCertificate(csr *x509.CertificateRequest, expi
 
 	return certData, nil
 }
+
+// IssueClientCertificate issues a client certificate with the given subject
+// for a newly generated key, and returns the PEM encoded certificate and key.
+func (ca *CA) IssueClientCertificate(subject string, expirySeconds int) ([]byte, []byte, error) {
+	publicKey, privateKey, err := NewKeyPair()
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyHash, err := HashPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyData, err := PEMEncodeKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ca.clientSigner != nil {
+		csrPEM, err := MakeCSR(privateKey.(crypto.Signer), subject)
+		if err != nil {
+			return nil, nil, err
+		}
+		csr, err := ParseCSR(csrPEM)
+		if err != nil {
+			return nil, nil, err
+		}
+		certData, err := ca.signClientCertificate(csr, expirySeconds)
+		if err != nil {
+			return nil, nil, err
+		}
+		return certData, keyData, nil
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		Subject: pkix.Name{CommonName: subject},
+
+		SignatureAlgorithm: x509.ECDSAWithSHA256,
+
+		NotBefore:    now.Add(-1 * time.Second),
+		NotAfter:     now.Add(time.Duration(expirySeconds) * time.Second),
+		SerialNumber: big.NewInt(1),
+
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+
+		AuthorityKeyId: ca.Config.Certs[0].SubjectKeyId,
+		SubjectKeyId:   publicKeyHash,
+	}
+	cert, err := ca.signCertificate(template, publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certData, err := oscrypto.EncodeCertificates(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certData, keyData, nil
+}
@@ -6,13 +6,16 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base32"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func NewKeyPair() (crypto.PublicKey, crypto.PrivateKey, error) {
@@ -42,6 +45,9 @@ func HashPublicKey(key crypto.PublicKey) ([]byte, error) {
 		return hashECDSAKey(&key), nil
 	case *ecdsa.PublicKey:
 		return hashECDSAKey(key), nil
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(x509.MarshalPKCS1PublicKey(key))
+		return hash[:], nil
 	case *crypto.PublicKey:
 		return HashPublicKey(*key)
 	case *crypto.PrivateKey:
@@ -55,6 +61,16 @@ func HashPublicKey(key crypto.PublicKey) ([]byte, error) {
 	}
 }
 
+// DeviceFingerprint returns the name of the device owning the key, which is
+// derived from the hash of the public key.
+func DeviceFingerprint(key crypto.PublicKey) (string, error) {
+	publicKeyHash, err := HashPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(base32.HexEncoding.WithPadding(base32.NoPadding).EncodeToString(publicKeyHash)), nil
+}
+
 func hashECDSAKey(publicKey *ecdsa.PublicKey) []byte {
 	hash := sha256.New()
 	hash.Write(publicKey.X.Bytes())
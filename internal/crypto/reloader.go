@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CertificateReloaderInterval is the default interval the certificate files
+// are checked for changes.
+const CertificateReloaderInterval = time.Minute
+
+// CertificateReloader serves the certificate of a pair of certificate and key
+// files and reloads it whenever the files change, so that a rotated
+// certificate is served to new connections without restarting the service.
+// Established connections, such as those of long-polling agents, are kept.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+	log      logrus.FieldLogger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	certPEM  []byte
+	keyPEM   []byte
+	notAfter time.Time
+}
+
+// NewCertificateReloader returns a reloader serving the certificate of the
+// files, which must be valid when it is created.
+func NewCertificateReloader(certFile, keyFile string, log logrus.FieldLogger) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		log:      log,
+	}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the certificate last loaded, to be used as
+// tls.Config.GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Run reloads the certificate whenever the files change until the context is
+// canceled. A certificate that fails to load, such as one whose key is not
+// written yet, is retried at the next interval while the previous one is kept.
+func (r *CertificateReloader) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		reloaded, err := r.Reload()
+		if err != nil {
+			r.log.Errorf("failed reloading certificate %s: %v", r.certFile, err)
+			continue
+		}
+		if reloaded {
+			r.mu.RLock()
+			notAfter := r.notAfter
+			r.mu.RUnlock()
+			r.log.Infof("reloaded certificate %s, which expires at %s", r.certFile, notAfter.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+// Reload loads the certificate of the files if they changed, and returns
+// whether it did.
+func (r *CertificateReloader) Reload() (bool, error) {
+	certPEM, err := os.ReadFile(r.certFile)
+	if err != nil {
+		return false, err
+	}
+	keyPEM, err := os.ReadFile(r.keyFile)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := bytes.Equal(certPEM, r.certPEM) && bytes.Equal(keyPEM, r.keyPEM)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return false, fmt.Errorf("loading %s: %w", r.certFile, err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return false, fmt.Errorf("parsing %s: %w", r.certFile, err)
+	}
+	cert.Leaf = leaf
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.certPEM = certPEM
+	r.keyPEM = keyPEM
+	r.notAfter = leaf.NotAfter
+	return true, nil
+}
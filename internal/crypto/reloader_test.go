@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateReloader(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca, err := MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	_, err = ca.MakeAndWriteServerCert(certFile, keyFile, []string{"first.example.com"}, 1)
+	require.NoError(err)
+
+	reloader, err := NewCertificateReloader(certFile, keyFile, logrus.New())
+	require.NoError(err)
+	cert, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(err)
+	require.Equal("first.example.com", cert.Leaf.Subject.CommonName)
+
+	reloaded, err := reloader.Reload()
+	require.NoError(err)
+	require.False(reloaded)
+
+	// a certificate whose key is not written yet is not loaded
+	second, err := ca.MakeServerCert([]string{"second.example.com"}, 1)
+	require.NoError(err)
+	certPEM, keyPEM, err := second.GetPEMBytes()
+	require.NoError(err)
+	require.NoError(os.WriteFile(certFile, certPEM, 0600))
+	_, err = reloader.Reload()
+	require.Error(err)
+	cert, err = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(err)
+	require.Equal("first.example.com", cert.Leaf.Subject.CommonName)
+
+	require.NoError(os.WriteFile(keyFile, keyPEM, 0600))
+	reloaded, err = reloader.Reload()
+	require.NoError(err)
+	require.True(reloaded)
+	cert, err = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(err)
+	require.Equal("second.example.com", cert.Leaf.Subject.CommonName)
+
+	// the certificate served by the TLS configs follows the reloader
+	_, agentTlsConfig, _ := TLSConfigForReloadingServer(ca.Config, reloader)
+	third, err := ca.MakeAndWriteServerCert(certFile, keyFile, []string{"third.example.com"}, 1)
+	require.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Run(ctx, 10*time.Millisecond)
+	require.Eventually(func() bool {
+		cert, err := agentTlsConfig.GetCertificate(&tls.ClientHelloInfo{})
+		return err == nil && cert.Leaf.Equal(third.Certs[0])
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWithACMEFallback(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	ca, err := MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+	serverCerts, err := ca.MakeServerCert([]string{"localhost"}, 1)
+	require.NoError(err)
+	tlsConfig, _, _, err := TLSConfigForServer(ca.Config, serverCerts)
+	require.NoError(err)
+
+	acmeTlsConfig := WithACME(tlsConfig, ACMEConfig{Domains: []string{"API.example.com"}, CacheDir: filepath.Join(dir, "acme")})
+	require.Equal([]string{"http/1.1", "acme-tls/1"}, acmeTlsConfig.NextProtos)
+	require.Empty(tlsConfig.NextProtos)
+
+	// clients connecting to other names are served the certificate of the service
+	for _, serverName := range []string{"", "localhost", "other.example.com"} {
+		cert, err := acmeTlsConfig.GetCertificate(&tls.ClientHelloInfo{ServerName: serverName})
+		require.NoError(err)
+		require.Equal(serverCerts.Certs[0].Raw, cert.Certificate[0])
+	}
+}
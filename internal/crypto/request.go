@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// DeviceSignatureHeader carries the base64-encoded signature of a request
+	// by the key of the client certificate of the device which sent it, so
+	// that a relay forwarding the request cannot forge it.
+	DeviceSignatureHeader = "Flightctl-Device-Signature"
+	// DeviceSignatureTimeHeader carries the time a request was signed at, in
+	// seconds since the epoch.
+	DeviceSignatureTimeHeader = "Flightctl-Device-Signature-Time"
+	// MaxDeviceSignatureAge bounds how long after it was signed a request is
+	// accepted.
+	MaxDeviceSignatureAge = 10 * time.Minute
+)
+
+const requestSignatureContext = "flightctl device request v1"
+
+// requestDigest returns the digest a device signs for a request: its method,
+// path, time and body. The query is not signed, so that a relay may ask for
+// the spec version it cached rather than the one of the device.
+func requestDigest(method string, path string, signedAt time.Time, body []byte) []byte {
+	bodyDigest := sha256.Sum256(body)
+	h := sha256.New()
+	h.Write([]byte(requestSignatureContext))
+	h.Write([]byte{0})
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(signedAt.Unix(), 10)))
+	h.Write([]byte{0})
+	h.Write(bodyDigest[:])
+	return h.Sum(nil)
+}
+
+// SignRequest signs a request of a device with the key of its client
+// certificate.
+func SignRequest(signer crypto.Signer, method string, path string, signedAt time.Time, body []byte) ([]byte, error) {
+	return signer.Sign(rand.Reader, requestDigest(method, path, signedAt, body), crypto.SHA256)
+}
+
+// VerifyRequestSignature checks that a request was signed by the key of the
+// client certificate of a device, and that it was signed recently.
+func VerifyRequestSignature(cert *x509.Certificate, method string, path string, signedAt time.Time, body []byte, signature []byte) error {
+	if age := time.Since(signedAt); age > MaxDeviceSignatureAge || age < -MaxDeviceSignatureAge {
+		return fmt.Errorf("the request was signed at %s, which is not within %s", signedAt.UTC().Format(time.RFC3339), MaxDeviceSignatureAge)
+	}
+	digest := requestDigest(method, path, signedAt, body)
+	switch key := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest, signature) {
+			return errors.New("invalid signature of the request")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("invalid signature of the request: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T of the client certificate", cert.PublicKey)
+	}
+}
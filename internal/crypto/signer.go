@@ -0,0 +1,86 @@
+package crypto
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// ClientSigner signs client certificates with a CA outside of the service,
+// such as Vault PKI, cert-manager or an EST server.
+type ClientSigner interface {
+	// SignClientCertificate returns the PEM encoded certificate issued for the
+	// CSR, followed by any intermediate certificates. The external CA may
+	// apply its own validity instead of the requested one.
+	SignClientCertificate(csr *x509.CertificateRequest, validity time.Duration) ([]byte, error)
+}
+
+// SetClientSigner makes the CA delegate signing client certificates, both
+// enrollment and management certificates, to signer, whose CA certificates are
+// certs. The certificates of the service itself are still signed by the CA.
+func (ca *CA) SetClientSigner(signer ClientSigner, certs []*x509.Certificate) {
+	ca.clientSigner = signer
+	ca.clientSignerCerts = certs
+}
+
+// ClientCACerts returns the certificates of the CAs client certificates are
+// signed with, including the CA of the service.
+func (ca *CA) ClientCACerts() []*x509.Certificate {
+	certs := append([]*x509.Certificate{}, ca.Config.Certs...)
+	return append(certs, ca.clientSignerCerts...)
+}
+
+// signClientCertificate signs the CSR with the external CA.
+func (ca *CA) signClientCertificate(csr *x509.CertificateRequest, expirySeconds int) ([]byte, error) {
+	certData, err := ca.clientSigner.SignClientCertificate(csr, time.Duration(expirySeconds)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("signing client certificate with external CA: %w", err)
+	}
+	block, _ := pem.Decode(certData)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("external CA returned an invalid certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate of external CA: %w", err)
+	}
+	// the service identifies clients by the common name of their certificate
+	if cert.Subject.CommonName != csr.Subject.CommonName {
+		return nil, fmt.Errorf("external CA issued a certificate for %q instead of %q", cert.Subject.CommonName, csr.Subject.CommonName)
+	}
+	return certData, nil
+}
+
+// EncodeCertificates returns the PEM bundle of the certificates.
+func EncodeCertificates(certs []*x509.Certificate) []byte {
+	var bundle []byte
+	for _, cert := range certs {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return bundle
+}
+
+// ParseCertificates parses the PEM encoded certificates of a bundle.
+func ParseCertificates(bundle []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found")
+	}
+	return certs, nil
+}
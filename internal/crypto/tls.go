@@ -7,7 +7,10 @@ import (
 	oscrypto "github.com/openshift/library-go/pkg/crypto"
 )
 
-func TLSConfigForServer(caConfig, serverConfig *TLSCertificateConfig) (*tls.Config, *tls.Config, *tls.Config, error) {
+// TLSConfigForServer returns the TLS configs of the API, agent and gRPC
+// servers. Clients with certificates signed by the CA or by any of the
+// additional client CAs are accepted.
+func TLSConfigForServer(caConfig, serverConfig *TLSCertificateConfig, clientCAs ...*x509.Certificate) (*tls.Config, *tls.Config, *tls.Config, error) {
 	certBytes, err := oscrypto.EncodeCertificates(serverConfig.Certs...)
 	if err != nil {
 		return nil, nil, nil, err
@@ -20,32 +23,49 @@ func TLSConfigForServer(caConfig, serverConfig *TLSCertificateConfig) (*tls.Conf
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	getCertificate := func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &cert, nil }
+	tlsConfig, agentTlsConfig, grpcTlsConfig := tlsConfigsForServer(caConfig, getCertificate, clientCAs)
+	return tlsConfig, agentTlsConfig, grpcTlsConfig, nil
+}
+
+// TLSConfigForReloadingServer returns the TLS configs of the API, agent and
+// gRPC servers like TLSConfigForServer, serving the certificate the reloader
+// last loaded.
+func TLSConfigForReloadingServer(caConfig *TLSCertificateConfig, reloader *CertificateReloader, clientCAs ...*x509.Certificate) (*tls.Config, *tls.Config, *tls.Config) {
+	return tlsConfigsForServer(caConfig, reloader.GetCertificate, clientCAs)
+}
 
+func tlsConfigsForServer(caConfig *TLSCertificateConfig, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error), clientCAs []*x509.Certificate) (*tls.Config, *tls.Config, *tls.Config) {
 	caPool := x509.NewCertPool()
 	for _, caCert := range caConfig.Certs {
 		caPool.AddCert(caCert)
 	}
+	for _, caCert := range clientCAs {
+		caPool.AddCert(caCert)
+	}
 
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		MinVersion:   tls.VersionTLS13,
+		GetCertificate: getCertificate,
+		MinVersion:     tls.VersionTLS13,
 	}
 
 	agentTlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientCAs:    caPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
-		MinVersion:   tls.VersionTLS13,
+		GetCertificate: getCertificate,
+		ClientCAs:      caPool,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		MinVersion:     tls.VersionTLS13,
+		// relays multiplex the requests of the devices of their sites over one connection
+		NextProtos: []string{"h2", "http/1.1"},
 	}
 
 	grpcTlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cert},
-		ClientCAs:    caPool,
-		ClientAuth:   tls.VerifyClientCertIfGiven,
-		MinVersion:   tls.VersionTLS13,
+		GetCertificate: getCertificate,
+		ClientCAs:      caPool,
+		ClientAuth:     tls.VerifyClientCertIfGiven,
+		MinVersion:     tls.VersionTLS13,
 	}
 
-	return tlsConfig, agentTlsConfig, grpcTlsConfig, nil
+	return tlsConfig, agentTlsConfig, grpcTlsConfig
 }
 
 func TLSConfigForClient(caConfig, clientConfig *TLSCertificateConfig) (*tls.Config, error) {
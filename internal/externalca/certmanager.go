@@ -0,0 +1,34 @@
+package externalca
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/k8sclient"
+)
+
+// CertManagerSigner signs certificates with a cert-manager issuer by creating
+// CertificateRequests in the cluster the service runs in.
+type CertManagerSigner struct {
+	client k8sclient.CertificateRequestClient
+	issuer k8sclient.IssuerRef
+}
+
+func NewCertManagerSigner(client k8sclient.CertificateRequestClient, issuer k8sclient.IssuerRef) *CertManagerSigner {
+	if issuer.Kind == "" {
+		issuer.Kind = "Issuer"
+	}
+	if issuer.Group == "" {
+		issuer.Group = "cert-manager.io"
+	}
+	return &CertManagerSigner{client: client, issuer: issuer}
+}
+
+func (s *CertManagerSigner) SignClientCertificate(csr *x509.CertificateRequest, validity time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr.Raw})
+	return s.client.SignCertificateRequest(ctx, s.issuer, csrPEM, validity)
+}
@@ -0,0 +1,104 @@
+package externalca
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/crypto"
+)
+
+// ESTSigner signs certificates with the simpleenroll operation of an
+// Enrollment over Secure Transport (RFC 7030) server, such as EJBCA.
+type ESTSigner struct {
+	client       *http.Client
+	url          string
+	username     string
+	passwordFile string
+}
+
+func NewESTSigner(client *http.Client, url, username, passwordFile string) *ESTSigner {
+	return &ESTSigner{
+		client:       client,
+		url:          strings.TrimSuffix(url, "/"),
+		username:     username,
+		passwordFile: passwordFile,
+	}
+}
+
+// SignClientCertificate enrolls the CSR. EST does not allow requesting a
+// validity, so the certificate profile of the EST server applies.
+func (s *ESTSigner) SignClientCertificate(csr *x509.CertificateRequest, _ time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	body := base64.StdEncoding.EncodeToString(csr.Raw)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+"/simpleenroll", strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/pkcs10")
+	req.Header.Set("Content-Transfer-Encoding", "base64")
+	if s.username != "" {
+		password := ""
+		if s.passwordFile != "" {
+			if password, err = readSecret(s.passwordFile); err != nil {
+				return nil, err
+			}
+		}
+		req.SetBasicAuth(s.username, password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to EST server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response of EST server: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusAccepted:
+		// the service has no way to retry later, as it signs while approving
+		return nil, fmt.Errorf("EST server requires manual approval of the request")
+	default:
+		return nil, fmt.Errorf("EST server returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(contents)))
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(contents)), ""))
+	if err != nil {
+		return nil, fmt.Errorf("decoding response of EST server: %w", err)
+	}
+	certs, err := crypto.ParseCertsOnly(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing response of EST server: %w", err)
+	}
+	return encodeChain(certs, csr)
+}
+
+// encodeChain PEM encodes the certificate issued for the CSR followed by the
+// other certificates, as the order of the certificates is not defined.
+func encodeChain(certs []*x509.Certificate, csr *x509.CertificateRequest) ([]byte, error) {
+	var leaf []byte
+	var others []byte
+	for _, cert := range certs {
+		encoded := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if leaf == nil && bytes.Equal(cert.RawSubjectPublicKeyInfo, csr.RawSubjectPublicKeyInfo) {
+			leaf = encoded
+			continue
+		}
+		others = append(others, encoded...)
+	}
+	if leaf == nil {
+		return nil, fmt.Errorf("no certificate issued for the requested key")
+	}
+	return append(leaf, others...), nil
+}
@@ -0,0 +1,99 @@
+// Package externalca signs the client certificates of the service with a CA
+// outside of the service, for deployments which must not let an application
+// embedded CA issue device identities.
+package externalca
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/pkg/k8sclient"
+)
+
+// requestTimeout bounds the requests to the external CA, as signing blocks
+// the approval of enrollment requests.
+const requestTimeout = 30 * time.Second
+
+// New returns the signer of the external CA configured for the service and
+// the certificates of the external CA, or nil if none is configured.
+func New(cfg *config.Config) (crypto.ClientSigner, []*x509.Certificate, error) {
+	if cfg.CA == nil || cfg.CA.External == nil {
+		return nil, nil, nil
+	}
+	external := cfg.CA.External
+	bundle, err := os.ReadFile(external.CACertFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading CA bundle of external CA: %w", err)
+	}
+	caCerts, err := crypto.ParseCertificates(bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing CA bundle of external CA: %w", err)
+	}
+
+	var signer crypto.ClientSigner
+	switch {
+	case external.Vault != nil:
+		client, err := httpClient(external.Vault.ServerCACertFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer = NewVaultSigner(client, external.Vault.Address, external.Vault.Mount, external.Vault.Role, external.Vault.TokenFile)
+	case external.CertManager != nil:
+		client, err := k8sclient.NewCertificateRequestClient(external.CertManager.Namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer = NewCertManagerSigner(client, k8sclient.IssuerRef{
+			Name:  external.CertManager.IssuerName,
+			Kind:  external.CertManager.IssuerKind,
+			Group: external.CertManager.IssuerGroup,
+		})
+	case external.EST != nil:
+		client, err := httpClient(external.EST.ServerCACertFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer = NewESTSigner(client, external.EST.Url, external.EST.Username, external.EST.PasswordFile)
+	default:
+		return nil, nil, fmt.Errorf("no external CA configured")
+	}
+	return signer, caCerts, nil
+}
+
+// httpClient returns a client verifying the server with the CAs of caFile,
+// or with the system's trusted CAs if caFile is empty.
+func httpClient(caFile string) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		bundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading server CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}, nil
+}
+
+// readSecret reads a token or password from a file, so that it can be rotated
+// without restarting the service.
+func readSecret(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
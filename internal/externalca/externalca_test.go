@@ -0,0 +1,194 @@
+package externalca
+
+import (
+	stdcrypto "crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCA returns a self-signed CA, which also stands in for the external CA.
+func newTestCA(t *testing.T) *crypto.CA {
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "external-ca", 1)
+	require.NoError(t, err)
+	return ca
+}
+
+func newCSR(t *testing.T, commonName string) *x509.CertificateRequest {
+	_, key, err := crypto.NewKeyPair()
+	require.NoError(t, err)
+	csrPEM, err := crypto.MakeCSR(key.(stdcrypto.Signer), commonName)
+	require.NoError(t, err)
+	csr, err := crypto.ParseCSR(csrPEM)
+	require.NoError(t, err)
+	return csr
+}
+
+func TestVaultSigner(t *testing.T) {
+	require := require.New(t)
+	external := newTestCA(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(os.WriteFile(tokenFile, []byte("s.token\n"), 0600))
+
+	var received vaultSignRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/v1/pki_int/sign/devices", r.URL.Path)
+		require.Equal("s.token", r.Header.Get("X-Vault-Token"))
+		require.NoError(json.NewDecoder(r.Body).Decode(&received))
+		csr, err := crypto.ParseCSR([]byte(received.CSR))
+		require.NoError(err)
+		cert, err := external.IssueRequestedClientCertificate(csr, 3600)
+		require.NoError(err)
+		caCert, _, err := external.Config.GetPEMBytes()
+		require.NoError(err)
+		resp := vaultSignResponse{}
+		resp.Data.Certificate = string(cert)
+		resp.Data.CAChain = []string{string(caCert)}
+		require.NoError(json.NewEncoder(w).Encode(resp))
+	}))
+	defer server.Close()
+
+	signer := NewVaultSigner(server.Client(), server.URL+"/", "pki_int", "devices", tokenFile)
+	certData, err := signer.SignClientCertificate(newCSR(t, "device:0123456789abcdef"), time.Hour)
+	require.NoError(err)
+	require.Equal("device:0123456789abcdef", received.CommonName)
+	require.Equal("3600s", received.TTL)
+
+	certs, err := crypto.ParseCertificates(certData)
+	require.NoError(err)
+	require.Len(certs, 2)
+	require.Equal("device:0123456789abcdef", certs[0].Subject.CommonName)
+	require.Equal("external-ca", certs[1].Subject.CommonName)
+}
+
+func TestVaultSignerError(t *testing.T) {
+	require := require.New(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(os.WriteFile(tokenFile, []byte("s.token"), 0600))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":["common name not allowed by this role"]}`))
+	}))
+	defer server.Close()
+
+	signer := NewVaultSigner(server.Client(), server.URL, "", "devices", tokenFile)
+	_, err := signer.SignClientCertificate(newCSR(t, "device:0123456789abcdef"), time.Hour)
+	require.ErrorContains(err, "common name not allowed by this role")
+}
+
+// certsOnly returns a certs-only PKCS#7 SignedData holding the certificates.
+func certsOnly(t *testing.T, certs ...[]byte) []byte {
+	var parsed []*x509.Certificate
+	for _, cert := range certs {
+		c, err := crypto.ParseCertificates(cert)
+		require.NoError(t, err)
+		parsed = append(parsed, c...)
+	}
+	der, err := crypto.EncodeCertsOnly(parsed)
+	require.NoError(t, err)
+	return der
+}
+
+func TestESTSigner(t *testing.T) {
+	require := require.New(t)
+	external := newTestCA(t)
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(os.WriteFile(passwordFile, []byte("secret"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/.well-known/est/flightctl/simpleenroll", r.URL.Path)
+		require.Equal("application/pkcs10", r.Header.Get("Content-Type"))
+		username, password, ok := r.BasicAuth()
+		require.True(ok)
+		require.Equal("flightctl", username)
+		require.Equal("secret", password)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		der, err := base64.StdEncoding.DecodeString(string(body))
+		require.NoError(err)
+		csr, err := x509.ParseCertificateRequest(der)
+		require.NoError(err)
+		cert, err := external.IssueRequestedClientCertificate(csr, 3600)
+		require.NoError(err)
+		caCert, _, err := external.Config.GetPEMBytes()
+		require.NoError(err)
+
+		// the CA certificate comes first, as the order is not defined
+		w.Header().Set("Content-Type", "application/pkcs7-mime; smime-type=certs-only")
+		w.Header().Set("Content-Transfer-Encoding", "base64")
+		_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(certsOnly(t, caCert, cert))))
+	}))
+	defer server.Close()
+
+	signer := NewESTSigner(server.Client(), server.URL+"/.well-known/est/flightctl", "flightctl", passwordFile)
+	certData, err := signer.SignClientCertificate(newCSR(t, "client-enrollment-0123456789abcdef"), time.Hour)
+	require.NoError(err)
+
+	certs, err := crypto.ParseCertificates(certData)
+	require.NoError(err)
+	require.Len(certs, 2)
+	require.Equal("client-enrollment-0123456789abcdef", certs[0].Subject.CommonName)
+	require.Equal("external-ca", certs[1].Subject.CommonName)
+}
+
+func TestESTSignerPendingApproval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	signer := NewESTSigner(server.Client(), server.URL, "", "")
+	_, err := signer.SignClientCertificate(newCSR(t, "device:0123456789abcdef"), time.Hour)
+	require.ErrorContains(t, err, "manual approval")
+}
+
+// fakeSigner signs with a CA standing in for the external CA, optionally
+// replacing the requested common name.
+type fakeSigner struct {
+	ca         *crypto.CA
+	commonName string
+}
+
+func (s *fakeSigner) SignClientCertificate(csr *x509.CertificateRequest, validity time.Duration) ([]byte, error) {
+	if s.commonName != "" {
+		replaced := *csr
+		replaced.Subject.CommonName = s.commonName
+		csr = &replaced
+	}
+	return s.ca.IssueRequestedClientCertificate(csr, int(validity.Seconds()))
+}
+
+func TestCAWithClientSigner(t *testing.T) {
+	require := require.New(t)
+	ca := newTestCA(t)
+	external := newTestCA(t)
+	ca.SetClientSigner(&fakeSigner{ca: external}, external.Config.Certs)
+
+	certData, keyData, err := ca.IssueClientCertificate("client-enrollment-0123456789abcdef", 3600)
+	require.NoError(err)
+	require.NotEmpty(keyData)
+	certs, err := crypto.ParseCertificates(certData)
+	require.NoError(err)
+	require.Equal(external.Config.Certs[0].Subject.String(), certs[0].Issuer.String())
+	require.NoError(certs[0].CheckSignatureFrom(external.Config.Certs[0]))
+	require.Len(ca.ClientCACerts(), 2)
+
+	// the service identifies clients by their common name, which the
+	// external CA must keep
+	ca.SetClientSigner(&fakeSigner{ca: external, commonName: "someone-else"}, external.Config.Certs)
+	_, err = ca.IssueRequestedClientCertificate(newCSR(t, "device:0123456789abcdef"), 3600)
+	require.ErrorContains(err, "instead of")
+}
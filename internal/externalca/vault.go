@@ -0,0 +1,103 @@
+package externalca
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultSigner signs certificates with the sign endpoint of a Vault PKI
+// secrets engine.
+type VaultSigner struct {
+	client    *http.Client
+	address   string
+	mount     string
+	role      string
+	tokenFile string
+}
+
+func NewVaultSigner(client *http.Client, address, mount, role, tokenFile string) *VaultSigner {
+	if mount == "" {
+		mount = "pki"
+	}
+	return &VaultSigner{
+		client:    client,
+		address:   strings.TrimSuffix(address, "/"),
+		mount:     strings.Trim(mount, "/"),
+		role:      role,
+		tokenFile: tokenFile,
+	}
+}
+
+type vaultSignRequest struct {
+	CSR        string `json:"csr"`
+	CommonName string `json:"common_name"`
+	TTL        string `json:"ttl,omitempty"`
+}
+
+type vaultSignResponse struct {
+	Errors []string `json:"errors,omitempty"`
+	Data   struct {
+		Certificate string   `json:"certificate"`
+		IssuingCA   string   `json:"issuing_ca"`
+		CAChain     []string `json:"ca_chain"`
+	} `json:"data"`
+}
+
+func (s *VaultSigner) SignClientCertificate(csr *x509.CertificateRequest, validity time.Duration) ([]byte, error) {
+	token, err := readSecret(s.tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(vaultSignRequest{
+		CSR:        string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr.Raw})),
+		CommonName: csr.Subject.CommonName,
+		TTL:        fmt.Sprintf("%ds", int64(validity.Seconds())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	url := fmt.Sprintf("%s/v1/%s/sign/%s", s.address, s.mount, s.role)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var signed vaultSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&signed); err != nil {
+		return nil, fmt.Errorf("decoding response of Vault (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(signed.Errors, "; "))
+	}
+
+	// return the certificate with its chain, so that clients present the
+	// intermediate CAs as well
+	chain := []string{signed.Data.Certificate}
+	if len(signed.Data.CAChain) > 0 {
+		chain = append(chain, signed.Data.CAChain...)
+	} else if signed.Data.IssuingCA != "" {
+		chain = append(chain, signed.Data.IssuingCA)
+	}
+	var certData []byte
+	for _, cert := range chain {
+		certData = append(certData, []byte(strings.TrimSpace(cert)+"\n")...)
+	}
+	return certData, nil
+}
@@ -0,0 +1,20 @@
+// Package fips reports whether the binary uses FIPS-validated crypto.
+//
+// Binaries built with GOEXPERIMENT=boringcrypto use the FIPS-validated
+// BoringCrypto module and restrict TLS to the FIPS-approved settings.
+package fips
+
+import "fmt"
+
+// Enabled reports whether the crypto of the binary is FIPS-validated.
+func Enabled() bool {
+	return enabled()
+}
+
+// Require returns an error if the crypto of the binary is not FIPS-validated.
+func Require() error {
+	if !Enabled() {
+		return fmt.Errorf("FIPS-validated crypto is required, but the binary was not built with GOEXPERIMENT=boringcrypto")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build boringcrypto
+
+package fips
+
+import (
+	"crypto/boring"
+	// restrict TLS to the FIPS-approved versions, cipher suites and curves
+	_ "crypto/tls/fipsonly"
+)
+
+func enabled() bool {
+	return boring.Enabled()
+}
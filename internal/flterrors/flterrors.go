@@ -21,6 +21,16 @@ var (
 	ErrTemplateVersionIsNil   = errors.New("spec.templateVersion not set")
 	ErrInvalidTemplateVersion = errors.New("device's templateVersion is not valid")
 	ErrNoRenderedVersion      = errors.New("no rendered version for device")
+	ErrNoCommonSpecVersion    = errors.New("the agent supports none of the rendered spec versions of the service")
+	ErrAliasInUse             = errors.New("the alias refers to another device")
+
+	// device identities
+	ErrAlreadyProvisioned          = errors.New("the machine has already been provisioned")
+	ErrAmbiguousDeviceIdentity     = errors.New("more than one device identity matches the machine")
+	ErrProvisioningChallengeFailed = errors.New("the machine did not prove to hold the TPM endorsement key of the device identity")
+
+	// operations
+	ErrOperationFinished = errors.New("the operation has already finished")
 )
 
 func ErrorFromGormError(err error) error {
@@ -0,0 +1,333 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+const (
+	// DefaultExportInterval is the default interval between two exports.
+	DefaultExportInterval = time.Hour
+
+	exportTimeout = 30 * time.Second
+	// snapshotKey is the artifact holding all the devices as of the last export
+	snapshotKey = artifacts.KindInventory + "/devices.csv"
+)
+
+// DefaultExportFields are the fields of the exported records if the
+// configuration maps none. Fields which change on every status update, such
+// as status.lastSeen, are left out, as they would export every device each
+// time.
+var DefaultExportFields = map[string]string{
+	"displayName":     "metadata.displayName",
+	"fleet":           "metadata.owner",
+	"labels":          "metadata.labels",
+	"status":          "status.summary.status",
+	"updateStatus":    "status.updated.status",
+	"osImage":         "status.os.image",
+	"architecture":    "status.systemInfo.architecture",
+	"operatingSystem": "status.systemInfo.operatingSystem",
+	"vendor":          "status.systemInfo.hardware.vendor",
+	"productName":     "status.systemInfo.hardware.productName",
+	"serialNumber":    "status.systemInfo.hardware.serialNumber",
+}
+
+// Record is the exported record of a device, keyed by the names of the
+// fields of the configuration.
+type Record map[string]string
+
+// ExportedDevice is a device added or changed since the last export.
+type ExportedDevice struct {
+	Name   string `json:"name"`
+	Fields Record `json:"fields"`
+}
+
+// ExportRequest is the body POSTed to the asset management system with the
+// changes of the inventory since the last export.
+type ExportRequest struct {
+	OrgId       string    `json:"orgId"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Full is set if Devices holds all the devices, as on the first export
+	// after the service started
+	Full bool `json:"full"`
+	// Devices are the records of the devices added or changed since the last export
+	Devices []ExportedDevice `json:"devices"`
+	// Removed are the names of the devices deleted since the last export
+	Removed []string `json:"removed"`
+}
+
+// Exporter exports the inventory of the devices to an asset management
+// system. It remembers the records it exported last, so that each export only
+// carries the devices which changed since. The records are kept in memory, so
+// the first export after the service started carries all the devices.
+type Exporter struct {
+	url        string
+	tokenFile  string
+	httpClient *http.Client
+	artifacts  artifacts.Store
+	interval   time.Duration
+	fields     map[string]string
+	columns    []string
+
+	exported map[string]string
+}
+
+// NewExporter returns the exporter of the inventory configured for the
+// service, or nil if none is configured. The artifact store is nil if no
+// artifact storage is configured.
+func NewExporter(cfg *config.Config, artifactStore artifacts.Store) (*Exporter, error) {
+	if cfg.InventoryExport == nil {
+		return nil, nil
+	}
+	exportCfg := cfg.InventoryExport
+	interval := DefaultExportInterval
+	if exportCfg.Interval != "" {
+		var err error
+		if interval, err = time.ParseDuration(exportCfg.Interval); err != nil {
+			return nil, fmt.Errorf("parsing inventory export interval: %w", err)
+		}
+	}
+	var httpClient *http.Client
+	if exportCfg.Url != "" {
+		var err error
+		if httpClient, err = newHTTPClient(exportCfg.ServerCACertFile, exportTimeout); err != nil {
+			return nil, err
+		}
+	}
+	if !exportCfg.Csv {
+		artifactStore = nil
+	} else if artifactStore == nil {
+		return nil, fmt.Errorf("exporting the inventory as CSV requires the artifact storage")
+	}
+	return NewExportClient(exportCfg.Url, exportCfg.TokenFile, httpClient, artifactStore, interval, exportCfg.Fields), nil
+}
+
+// NewExportClient returns an exporter POSTing the changes of the inventory to
+// the URL, if set, and storing them as CSV files in the artifact store, if
+// set. The default fields are exported if fields is empty.
+func NewExportClient(url string, tokenFile string, httpClient *http.Client, artifactStore artifacts.Store, interval time.Duration, fields map[string]string) *Exporter {
+	if len(fields) == 0 {
+		fields = DefaultExportFields
+	}
+	columns := lo.Keys(fields)
+	sort.Strings(columns)
+	return &Exporter{
+		url:        url,
+		tokenFile:  tokenFile,
+		httpClient: httpClient,
+		artifacts:  artifactStore,
+		interval:   interval,
+		fields:     fields,
+		columns:    columns,
+	}
+}
+
+// Interval returns how often the inventory is exported.
+func (e *Exporter) Interval() time.Duration {
+	return e.interval
+}
+
+// Export exports the changes of the inventory of the devices since the last
+// export. The exported records are only remembered once all the destinations
+// received them, so that the changes are exported again after a failure.
+func (e *Exporter) Export(ctx context.Context, orgId uuid.UUID, devices []api.Device, now time.Time) error {
+	request := ExportRequest{
+		OrgId:       orgId.String(),
+		GeneratedAt: now.UTC(),
+		Full:        e.exported == nil,
+		Devices:     []ExportedDevice{},
+		Removed:     []string{},
+	}
+	records := make([]ExportedDevice, 0, len(devices))
+	exported := make(map[string]string, len(devices))
+	for i := range devices {
+		record, err := e.record(&devices[i])
+		if err != nil {
+			return err
+		}
+		name := lo.FromPtr(devices[i].Metadata.Name)
+		records = append(records, ExportedDevice{Name: name, Fields: record})
+		fingerprint, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		exported[name] = string(fingerprint)
+		if previous, ok := e.exported[name]; !ok || previous != string(fingerprint) {
+			request.Devices = append(request.Devices, records[len(records)-1])
+		}
+	}
+	for name := range e.exported {
+		if _, ok := exported[name]; !ok {
+			request.Removed = append(request.Removed, name)
+		}
+	}
+	sort.Strings(request.Removed)
+	if !request.Full && len(request.Devices) == 0 && len(request.Removed) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+	if e.url != "" {
+		if err := e.post(ctx, &request); err != nil {
+			return err
+		}
+	}
+	if e.artifacts != nil {
+		if err := e.store(ctx, &request, records); err != nil {
+			return err
+		}
+	}
+	e.exported = exported
+	return nil
+}
+
+func (e *Exporter) post(ctx context.Context, request *ExportRequest) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("marshalling inventory export: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating inventory export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setToken(req, e.tokenFile); err != nil {
+		return err
+	}
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("exporting inventory: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("exporting inventory: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// store writes the changes of the export as a CSV file named after the time
+// of the export, with an action column telling whether each device was
+// updated or removed, and replaces the snapshot of all the devices. Both have
+// a device column with the name of the device besides the fields.
+func (e *Exporter) store(ctx context.Context, request *ExportRequest, records []ExportedDevice) error {
+	changes := make([][]string, 0, len(request.Devices)+len(request.Removed))
+	for _, device := range request.Devices {
+		changes = append(changes, append([]string{"updated", device.Name}, e.row(device.Fields)...))
+	}
+	for _, name := range request.Removed {
+		changes = append(changes, append([]string{"removed", name}, e.row(Record{})...))
+	}
+	content, err := e.csv(append([]string{"action", "device"}, e.columns...), changes)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s/changes/%s.csv", artifacts.KindInventory, request.GeneratedAt.Format("20060102T150405Z"))
+	if err := e.artifacts.Put(ctx, key, content, "text/csv"); err != nil {
+		return fmt.Errorf("storing inventory changes: %w", err)
+	}
+
+	rows := make([][]string, 0, len(records))
+	for _, device := range records {
+		rows = append(rows, append([]string{device.Name}, e.row(device.Fields)...))
+	}
+	if content, err = e.csv(append([]string{"device"}, e.columns...), rows); err != nil {
+		return err
+	}
+	if err := e.artifacts.Put(ctx, snapshotKey, content, "text/csv"); err != nil {
+		return fmt.Errorf("storing inventory: %w", err)
+	}
+	return nil
+}
+
+func (e *Exporter) row(record Record) []string {
+	row := make([]string, 0, len(e.columns))
+	for _, column := range e.columns {
+		row = append(row, record[column])
+	}
+	return row
+}
+
+func (e *Exporter) csv(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// record maps the fields of the device to the fields of the exported record.
+func (e *Exporter) record(device *api.Device) (Record, error) {
+	content, err := json.Marshal(device)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling device: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshalling device: %w", err)
+	}
+	record := make(Record, len(e.fields))
+	for name, path := range e.fields {
+		value, err := formatField(lookupField(fields, strings.Split(path, ".")))
+		if err != nil {
+			return nil, fmt.Errorf("exporting field %s of device %s: %w", name, lo.FromPtr(device.Metadata.Name), err)
+		}
+		record[name] = value
+	}
+	return record, nil
+}
+
+// lookupField returns the value at the path of dot-separated segments, nil if
+// there is none. As the keys of labels and annotations may contain dots, the
+// longest key matching the next segments is looked up first, so that
+// metadata.labels.topology.kubernetes.io/zone refers to that label.
+func lookupField(value any, segments []string) any {
+	if len(segments) == 0 {
+		return value
+	}
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	for i := len(segments); i > 0; i-- {
+		if next, ok := fields[strings.Join(segments[:i], ".")]; ok {
+			return lookupField(next, segments[i:])
+		}
+	}
+	return nil
+}
+
+// formatField formats a value as a string, objects and arrays as JSON.
+func formatField(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		content, err := json.Marshal(v)
+		return string(content), err
+	}
+}
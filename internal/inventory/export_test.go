@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	orgId := uuid.New()
+	requests := []ExportRequest{}
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request ExportRequest
+		require.NoError(json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	artifactStore := artifacts.NewFilesystemStore(t.TempDir())
+	exporter := NewExportClient(server.URL, "", server.Client(), artifactStore, time.Hour, map[string]string{
+		"site":   "metadata.labels.site",
+		"zone":   "metadata.labels.topology.kubernetes.io/zone",
+		"serial": "status.systemInfo.hardware.serialNumber",
+		"status": "status.summary.status",
+	})
+	device := func(name string, site string, summary api.DeviceSummaryStatusType) api.Device {
+		return api.Device{
+			Metadata: api.ObjectMeta{
+				Name:   util.StrToPtr(name),
+				Labels: &map[string]string{"site": site, "topology.kubernetes.io/zone": "z1"},
+			},
+			Status: &api.DeviceStatus{
+				Summary:    api.DeviceSummaryStatus{Status: summary},
+				SystemInfo: api.DeviceSystemInfo{Hardware: &api.DeviceHardwareInfo{SerialNumber: util.StrToPtr("SN-" + name)}},
+			},
+		}
+	}
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	// the first export carries all the devices
+	devices := []api.Device{device("a", "plant-1", api.DeviceSummaryStatusOnline), device("b", "plant-2", api.DeviceSummaryStatusOnline)}
+	require.NoError(exporter.Export(ctx, orgId, devices, now))
+	require.Len(requests, 1)
+	require.True(requests[0].Full)
+	require.Equal([]ExportedDevice{
+		{Name: "a", Fields: Record{"site": "plant-1", "zone": "z1", "serial": "SN-a", "status": "Online"}},
+		{Name: "b", Fields: Record{"site": "plant-2", "zone": "z1", "serial": "SN-b", "status": "Online"}},
+	}, requests[0].Devices)
+
+	// nothing is exported while no device changed
+	require.NoError(exporter.Export(ctx, orgId, devices, now.Add(time.Hour)))
+	require.Len(requests, 1)
+
+	// the changes are exported again until the endpoint accepts them
+	devices = []api.Device{device("a", "plant-1", api.DeviceSummaryStatusDegraded)}
+	status = http.StatusServiceUnavailable
+	require.ErrorContains(exporter.Export(ctx, orgId, devices, now.Add(2*time.Hour)), "503")
+	status = http.StatusAccepted
+	require.NoError(exporter.Export(ctx, orgId, devices, now.Add(3*time.Hour)))
+	require.Len(requests, 3)
+	require.False(requests[2].Full)
+	require.Equal([]ExportedDevice{{Name: "a", Fields: Record{"site": "plant-1", "zone": "z1", "serial": "SN-a", "status": "Degraded"}}}, requests[2].Devices)
+	require.Equal([]string{"b"}, requests[2].Removed)
+
+	// the CSV files hold the changes and all the devices
+	body, _, err := artifactStore.Get(ctx, "inventory/changes/20261014T120000Z.csv")
+	require.NoError(err)
+	content, err := io.ReadAll(body)
+	require.NoError(err)
+	body.Close()
+	require.Equal("action,device,serial,site,status,zone\nupdated,a,SN-a,plant-1,Degraded,z1\nremoved,b,,,,\n", string(content))
+	body, _, err = artifactStore.Get(ctx, "inventory/devices.csv")
+	require.NoError(err)
+	content, err = io.ReadAll(body)
+	require.NoError(err)
+	body.Close()
+	require.Equal("device,serial,site,status,zone\na,SN-a,plant-1,Degraded,z1\n", string(content))
+}
+
+func TestFormatField(t *testing.T) {
+	require := require.New(t)
+	var fields map[string]any
+	require.NoError(json.Unmarshal([]byte(`{"a":{"b":2,"c":true,"d":["x"],"e":{"f":"g"}}}`), &fields))
+	for path, expected := range map[string]string{
+		"a.b":     "2",
+		"a.c":     "true",
+		"a.d":     `["x"]`,
+		"a.e":     `{"f":"g"}`,
+		"a.e.f":   "g",
+		"a.b.c":   "",
+		"missing": "",
+	} {
+		value, err := formatField(lookupField(fields, strings.Split(path, ".")))
+		require.NoError(err)
+		require.Equal(expected, value, path)
+	}
+}
@@ -0,0 +1,163 @@
+// Package inventory integrates the service with external inventory systems. It
+// looks up the parameters of devices in an inventory system, such as a CMDB,
+// so that fleet templates can refer to data kept there without duplicating it
+// into the labels of the devices, and exports the inventory of the devices to
+// asset management systems, so that they stay current without scraping the
+// API.
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxResponseLength bounds the size of the parameters of a device.
+	maxResponseLength = 1024 * 1024
+)
+
+// Request is the body POSTed to the inventory system for each device.
+type Request struct {
+	OrgId  string            `json:"orgId"`
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	// Fleet is the name of the fleet the device belongs to
+	Fleet string `json:"fleet,omitempty"`
+}
+
+// Response is the body the inventory system answers with.
+type Response struct {
+	Parameters map[string]string `json:"parameters"`
+}
+
+// Client looks up the parameters of devices in the inventory system.
+type Client struct {
+	url        string
+	tokenFile  string
+	httpClient *http.Client
+}
+
+// New returns the client of the inventory system configured for the service,
+// or nil if none is configured.
+func New(cfg *config.Config) (*Client, error) {
+	if cfg.Inventory == nil {
+		return nil, nil
+	}
+	timeout := defaultTimeout
+	if cfg.Inventory.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Inventory.Timeout); err != nil {
+			return nil, fmt.Errorf("parsing inventory timeout: %w", err)
+		}
+	}
+
+	httpClient, err := newHTTPClient(cfg.Inventory.ServerCACertFile, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(cfg.Inventory.Url, cfg.Inventory.TokenFile, httpClient), nil
+}
+
+// newHTTPClient returns a client verifying the server with the CA bundle, or
+// with the system's trusted CAs if caFile is empty.
+func newHTTPClient(caFile string, timeout time.Duration) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		bundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading inventory server CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}, nil
+}
+
+// setToken sets the bearer token read from tokenFile on the request, if any.
+func setToken(req *http.Request, tokenFile string) error {
+	if tokenFile == "" {
+		return nil
+	}
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("reading inventory token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return nil
+}
+
+// NewClient returns the client of the inventory system at the URL. The token
+// is read from the file on each request, so that it can be rotated without
+// restarting the service.
+func NewClient(url string, tokenFile string, httpClient *http.Client) *Client {
+	return &Client{url: url, tokenFile: tokenFile, httpClient: httpClient}
+}
+
+// Lookup returns the parameters of the device. Devices the inventory system
+// does not know, which it answers with 404 Not Found, have no parameters.
+func (c *Client) Lookup(ctx context.Context, orgId uuid.UUID, device *api.Device) (map[string]string, error) {
+	request := Request{
+		OrgId:  orgId.String(),
+		Name:   lo.FromPtr(device.Metadata.Name),
+		Labels: lo.FromPtrOr(device.Metadata.Labels, map[string]string{}),
+	}
+	if kind, name, err := util.GetResourceOwner(device.Metadata.Owner); err == nil && kind == model.FleetKind {
+		request.Fleet = name
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling inventory request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating inventory request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := setToken(req, c.tokenFile); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("looking up device %s in inventory: %w", request.Name, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return map[string]string{}, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("looking up device %s in inventory: unexpected status %s", request.Name, resp.Status)
+	}
+
+	var response Response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseLength)).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decoding inventory response of device %s: %w", request.Name, err)
+	}
+	if response.Parameters == nil {
+		return map[string]string{}, nil
+	}
+	return response.Parameters, nil
+}
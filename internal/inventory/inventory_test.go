@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	require := require.New(t)
+	orgId := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("Bearer secret", r.Header.Get("Authorization"))
+		var request Request
+		require.NoError(json.NewDecoder(r.Body).Decode(&request))
+		switch request.Name {
+		case "known":
+			require.Equal(Request{OrgId: orgId.String(), Name: "known", Labels: map[string]string{"site": "plant-1"}, Fleet: "factory"}, request)
+			_ = json.NewEncoder(w).Encode(Response{Parameters: map[string]string{"rack": "r12"}})
+		case "unknown":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	client := NewClient(server.URL, tokenFile, server.Client())
+	device := func(name string) *api.Device {
+		return &api.Device{Metadata: api.ObjectMeta{
+			Name:   util.StrToPtr(name),
+			Labels: &map[string]string{"site": "plant-1"},
+			Owner:  util.SetResourceOwner("Fleet", "factory"),
+		}}
+	}
+
+	parameters, err := client.Lookup(context.Background(), orgId, device("known"))
+	require.NoError(err)
+	require.Equal(map[string]string{"rack": "r12"}, parameters)
+
+	parameters, err = client.Lookup(context.Background(), orgId, device("unknown"))
+	require.NoError(err)
+	require.Empty(parameters)
+
+	_, err = client.Lookup(context.Background(), orgId, device("broken"))
+	require.ErrorContains(err, "unexpected status 500 Internal Server Error")
+}
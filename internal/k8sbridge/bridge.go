@@ -0,0 +1,322 @@
+package k8sbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/client"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// Group and Version are the API group and version of the custom resources.
+	Group   = "flightctl.io"
+	Version = "v1alpha1"
+
+	flightctlAPIVersion = "v1alpha1"
+
+	// Finalizer keeps a custom resource until the bridge deleted its flightctl resource.
+	Finalizer = "flightctl.io/k8s-bridge"
+	// AnnotationSyncedGeneration is the generation of the custom resource last synced with flightctl.
+	AnnotationSyncedGeneration = "flightctl.io/synced-generation"
+	// AnnotationSyncedResourceVersion is the resourceVersion of the flightctl resource last synced with Kubernetes.
+	AnnotationSyncedResourceVersion = "flightctl.io/synced-resource-version"
+	// AnnotationOwner is the owner of the flightctl resource, which manages its spec.
+	AnnotationOwner = "flightctl.io/owner"
+)
+
+// Bridge mirrors flightctl resources as custom resources in a namespace of a
+// Kubernetes cluster. Changes to the labels and spec are synced both ways,
+// while the status is only synced from flightctl.
+type Bridge struct {
+	log       logrus.FieldLogger
+	k8s       dynamic.Interface
+	namespace string
+	kinds     []kind
+}
+
+func New(log logrus.FieldLogger, flightctl *client.Client, k8s dynamic.Interface, namespace string) *Bridge {
+	return &Bridge{
+		log:       log,
+		k8s:       k8s,
+		namespace: namespace,
+		kinds:     kinds(flightctl),
+	}
+}
+
+// Run syncs the resources every interval until the context is canceled.
+func (b *Bridge) Run(ctx context.Context, interval time.Duration) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := b.Sync(ctx); err != nil {
+			b.log.Errorf("failed syncing resources: %v", err)
+		}
+	}, interval)
+}
+
+// Sync syncs the resources of all kinds once.
+func (b *Bridge) Sync(ctx context.Context) error {
+	var errs []error
+	for i := range b.kinds {
+		if err := b.syncKind(ctx, &b.kinds[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", b.kinds[i].resource, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bridge) syncKind(ctx context.Context, k *kind) error {
+	objects, err := k.listObjects(ctx)
+	if err != nil {
+		return fmt.Errorf("listing flightctl resources: %w", err)
+	}
+	crs := b.k8s.Resource(k.gvr()).Namespace(b.namespace)
+	list, err := crs.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing custom resources: %w", err)
+	}
+
+	objectsByName := make(map[string]*object, len(objects))
+	for i := range objects {
+		objectsByName[lo.FromPtr(objects[i].Metadata.Name)] = &objects[i]
+	}
+
+	var errs []error
+	mirrored := make(map[string]bool, len(list.Items))
+	for i := range list.Items {
+		cr := &list.Items[i]
+		mirrored[cr.GetName()] = true
+		if err := b.syncResource(ctx, k, crs, cr, objectsByName[cr.GetName()]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", cr.GetName(), err))
+		}
+	}
+	for i := range objects {
+		name := lo.FromPtr(objects[i].Metadata.Name)
+		if mirrored[name] {
+			continue
+		}
+		if err := b.mirror(ctx, k, crs, &objects[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncResource syncs a custom resource with its flightctl resource, which is nil if it does not exist.
+func (b *Bridge) syncResource(ctx context.Context, k *kind, crs dynamic.ResourceInterface, cr *unstructured.Unstructured, o *object) error {
+	switch {
+	case cr.GetDeletionTimestamp() != nil:
+		if err := k.deleteObject(ctx, cr.GetName()); err != nil {
+			return fmt.Errorf("deleting flightctl resource: %w", err)
+		}
+		b.log.Infof("deleted %s %s", k.name, cr.GetName())
+		return b.removeFinalizer(ctx, crs, cr)
+
+	case o == nil && cr.GetAnnotations()[AnnotationSyncedResourceVersion] != "":
+		// the resource was synced before, so it was deleted in flightctl
+		if err := b.removeFinalizer(ctx, crs, cr); err != nil {
+			return err
+		}
+		if err := crs.Delete(ctx, cr.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("deleting custom resource: %w", err)
+		}
+		return nil
+
+	case o == nil || changedInKubernetes(cr, o):
+		spec, _, err := unstructured.NestedFieldNoCopy(cr.Object, "spec")
+		if err != nil {
+			return fmt.Errorf("reading spec: %w", err)
+		}
+		// when both sides changed the Kubernetes change wins, as the custom
+		// resources are expected to be managed by a GitOps pipeline
+		updated, err := k.replaceObject(ctx, cr.GetName(), cr.GetLabels(), spec)
+		if err != nil {
+			return fmt.Errorf("replacing flightctl resource: %w", err)
+		}
+		b.log.Infof("synced %s %s to flightctl", k.name, cr.GetName())
+		return b.recordSync(ctx, crs, cr, updated)
+
+	case changedInFlightctl(cr, o):
+		if err := setSpec(cr, o); err != nil {
+			return err
+		}
+		cr.SetLabels(lo.FromPtr(o.Metadata.Labels))
+		updated, err := crs.Update(ctx, cr, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("updating custom resource: %w", err)
+		}
+		b.log.Infof("synced %s %s from flightctl", k.name, cr.GetName())
+		return b.recordSync(ctx, crs, updated, o)
+
+	default:
+		return b.updateStatus(ctx, crs, cr, o)
+	}
+}
+
+// mirror creates the custom resource of a flightctl resource.
+func (b *Bridge) mirror(ctx context.Context, k *kind, crs dynamic.ResourceInterface, o *object) error {
+	cr := &unstructured.Unstructured{Object: map[string]any{}}
+	cr.SetAPIVersion(Group + "/" + Version)
+	cr.SetKind(k.name)
+	cr.SetName(lo.FromPtr(o.Metadata.Name))
+	cr.SetNamespace(b.namespace)
+	cr.SetLabels(lo.FromPtr(o.Metadata.Labels))
+	cr.SetFinalizers([]string{Finalizer})
+	if err := setSpec(cr, o); err != nil {
+		return err
+	}
+	created, err := crs.Create(ctx, cr, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating custom resource: %w", err)
+	}
+	b.log.Infof("mirrored %s %s", k.name, cr.GetName())
+	return b.recordSync(ctx, crs, created, o)
+}
+
+// recordSync records that the custom resource and the flightctl resource are
+// in sync, and then updates the status from flightctl.
+func (b *Bridge) recordSync(ctx context.Context, crs dynamic.ResourceInterface, cr *unstructured.Unstructured, o *object) error {
+	annotations := cr.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationSyncedGeneration] = strconv.FormatInt(cr.GetGeneration(), 10)
+	annotations[AnnotationSyncedResourceVersion] = lo.FromPtr(o.Metadata.ResourceVersion)
+	if o.Metadata.Owner != nil {
+		annotations[AnnotationOwner] = *o.Metadata.Owner
+	} else {
+		delete(annotations, AnnotationOwner)
+	}
+	cr.SetAnnotations(annotations)
+	if !lo.Contains(cr.GetFinalizers(), Finalizer) {
+		cr.SetFinalizers(append(cr.GetFinalizers(), Finalizer))
+	}
+
+	// metadata changes do not change the generation
+	updated, err := crs.Update(ctx, cr, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("recording sync: %w", err)
+	}
+	return b.updateStatus(ctx, crs, updated, o)
+}
+
+func (b *Bridge) updateStatus(ctx context.Context, crs dynamic.ResourceInterface, cr *unstructured.Unstructured, o *object) error {
+	status, err := toUnstructured(o.Status)
+	if err != nil {
+		return fmt.Errorf("parsing status: %w", err)
+	}
+	current, _, _ := unstructured.NestedFieldNoCopy(cr.Object, "status")
+	if equalJSON(current, status) {
+		return nil
+	}
+	if status == nil {
+		unstructured.RemoveNestedField(cr.Object, "status")
+	} else {
+		cr.Object["status"] = status
+	}
+	if _, err := crs.UpdateStatus(ctx, cr, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating status: %w", err)
+	}
+	return nil
+}
+
+func (b *Bridge) removeFinalizer(ctx context.Context, crs dynamic.ResourceInterface, cr *unstructured.Unstructured) error {
+	if !lo.Contains(cr.GetFinalizers(), Finalizer) {
+		return nil
+	}
+	cr.SetFinalizers(lo.Without(cr.GetFinalizers(), Finalizer))
+	if _, err := crs.Update(ctx, cr, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("removing finalizer: %w", err)
+	}
+	return nil
+}
+
+// changedInKubernetes returns whether the labels or spec of the custom resource
+// changed since the last sync. The spec of an owned resource is managed by its
+// owner, so such changes are overwritten instead.
+func changedInKubernetes(cr *unstructured.Unstructured, o *object) bool {
+	if o.Metadata.Owner != nil {
+		return false
+	}
+	if cr.GetAnnotations()[AnnotationSyncedGeneration] != strconv.FormatInt(cr.GetGeneration(), 10) {
+		return true
+	}
+	// label changes do not change the generation, so they are detected by comparing
+	// the labels, unless the flightctl resource changed too
+	return lo.FromPtr(o.Metadata.ResourceVersion) == cr.GetAnnotations()[AnnotationSyncedResourceVersion] &&
+		!maps.Equal(cr.GetLabels(), lo.FromPtr(o.Metadata.Labels))
+}
+
+// changedInFlightctl returns whether the flightctl resource changed since the
+// last sync, or whether an owned resource must overwrite changes to its custom resource.
+func changedInFlightctl(cr *unstructured.Unstructured, o *object) bool {
+	if lo.FromPtr(o.Metadata.ResourceVersion) != cr.GetAnnotations()[AnnotationSyncedResourceVersion] {
+		return true
+	}
+	return o.Metadata.Owner != nil && cr.GetAnnotations()[AnnotationSyncedGeneration] != strconv.FormatInt(cr.GetGeneration(), 10)
+}
+
+func setSpec(cr *unstructured.Unstructured, o *object) error {
+	spec, err := toUnstructured(o.Spec)
+	if err != nil {
+		return fmt.Errorf("parsing spec: %w", err)
+	}
+	if spec == nil {
+		unstructured.RemoveNestedField(cr.Object, "spec")
+		return nil
+	}
+	cr.Object["spec"] = spec
+	return nil
+}
+
+// toUnstructured converts JSON to the representation of custom resource
+// fields, where integers are int64 rather than float64.
+func toUnstructured(data json.RawMessage) (any, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return convertNumbers(value), nil
+}
+
+func convertNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+func equalJSON(a any, b any) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
@@ -0,0 +1,216 @@
+package k8sbridge
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+const namespace = "edge"
+
+// fakeFlightctl stores the fleets of a fake flightctl service.
+type fakeFlightctl struct {
+	fleets          map[string]object
+	resourceVersion int
+}
+
+func (f *fakeFlightctl) set(name string, labels map[string]string, spec string, owner *string) {
+	f.resourceVersion++
+	f.fleets[name] = object{
+		Metadata: api.ObjectMeta{
+			Name:            &name,
+			Labels:          &labels,
+			Owner:           owner,
+			ResourceVersion: lo.ToPtr(strconv.Itoa(f.resourceVersion)),
+		},
+		Spec:   json.RawMessage(spec),
+		Status: json.RawMessage(`{"conditions":[]}`),
+	}
+}
+
+func (f *fakeFlightctl) kind() kind {
+	return kind{
+		name:     "Fleet",
+		resource: "fleets",
+		list: func(ctx context.Context, cont *string) (*response, error) {
+			list := objectList{Items: lo.Values(f.fleets)}
+			sort.Slice(list.Items, func(i, j int) bool { return *list.Items[i].Metadata.Name < *list.Items[j].Metadata.Name })
+			body, err := json.Marshal(list)
+			return &response{http.StatusOK, body}, err
+		},
+		replace: func(ctx context.Context, name string, body io.Reader) (*response, error) {
+			var o object
+			if err := json.NewDecoder(body).Decode(&o); err != nil {
+				return nil, err
+			}
+			f.set(name, lo.FromPtr(o.Metadata.Labels), string(o.Spec), f.fleets[name].Metadata.Owner)
+			result, err := json.Marshal(f.fleets[name])
+			return &response{http.StatusOK, result}, err
+		},
+		delete: func(ctx context.Context, name string) (*response, error) {
+			if _, ok := f.fleets[name]; !ok {
+				return &response{statusCode: http.StatusNotFound}, nil
+			}
+			delete(f.fleets, name)
+			return &response{statusCode: http.StatusOK}, nil
+		},
+	}
+}
+
+func newTestBridge(t *testing.T) (*Bridge, *fakeFlightctl, dynamic.ResourceInterface) {
+	flightctl := &fakeFlightctl{fleets: map[string]object{}}
+	k := flightctl.kind()
+	k8s := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{k.gvr(): "FleetList"})
+	bridge := &Bridge{log: logrus.New(), k8s: k8s, namespace: namespace, kinds: []kind{k}}
+	return bridge, flightctl, k8s.Resource(k.gvr()).Namespace(namespace)
+}
+
+func getCR(t *testing.T, crs dynamic.ResourceInterface, name string) *unstructured.Unstructured {
+	cr, err := crs.Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return cr
+}
+
+// editCR changes the spec of a custom resource like the API server would, which
+// the fake client does not do by itself.
+func editCR(t *testing.T, crs dynamic.ResourceInterface, name string, spec map[string]any) {
+	cr := getCR(t, crs, name)
+	cr.Object["spec"] = spec
+	cr.SetGeneration(cr.GetGeneration() + 1)
+	_, err := crs.Update(context.Background(), cr, metav1.UpdateOptions{})
+	require.NoError(t, err)
+}
+
+func TestMirrorFlightctlResource(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	flightctl.set("fleet", map[string]string{"env": "prod"}, `{"selector":{"matchLabels":{"site":"a"}},"template":{"spec":{"os":{"image":"os:1"}}}}`, nil)
+
+	require.NoError(bridge.Sync(context.Background()))
+
+	cr := getCR(t, crs, "fleet")
+	require.Equal(map[string]string{"env": "prod"}, cr.GetLabels())
+	image, _, _ := unstructured.NestedString(cr.Object, "spec", "template", "spec", "os", "image")
+	require.Equal("os:1", image)
+	require.Contains(cr.GetFinalizers(), Finalizer)
+	require.Equal("1", cr.GetAnnotations()[AnnotationSyncedResourceVersion])
+	_, found, _ := unstructured.NestedSlice(cr.Object, "status", "conditions")
+	require.True(found)
+
+	// nothing changed, so nothing is written to flightctl
+	require.NoError(bridge.Sync(context.Background()))
+	require.Equal(1, flightctl.resourceVersion)
+}
+
+func TestSyncFromKubernetes(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	cr := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{"selector": map[string]any{}}}}
+	cr.SetAPIVersion(Group + "/" + Version)
+	cr.SetKind("Fleet")
+	cr.SetName("fleet")
+	cr.SetNamespace(namespace)
+	cr.SetGeneration(1)
+	cr.SetLabels(map[string]string{"env": "prod"})
+	_, err := crs.Create(context.Background(), cr, metav1.CreateOptions{})
+	require.NoError(err)
+
+	// created in flightctl
+	require.NoError(bridge.Sync(context.Background()))
+	require.Contains(flightctl.fleets, "fleet")
+	require.Equal(map[string]string{"env": "prod"}, *flightctl.fleets["fleet"].Metadata.Labels)
+	cr = getCR(t, crs, "fleet")
+	require.Equal("1", cr.GetAnnotations()[AnnotationSyncedGeneration])
+	require.Contains(cr.GetFinalizers(), Finalizer)
+
+	// spec changed in Kubernetes
+	editCR(t, crs, "fleet", map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"site": "b"}}})
+	require.NoError(bridge.Sync(context.Background()))
+	require.JSONEq(`{"selector":{"matchLabels":{"site":"b"}}}`, string(flightctl.fleets["fleet"].Spec))
+
+	// labels changed in Kubernetes
+	cr = getCR(t, crs, "fleet")
+	cr.SetLabels(map[string]string{"env": "dev"})
+	_, err = crs.Update(context.Background(), cr, metav1.UpdateOptions{})
+	require.NoError(err)
+	require.NoError(bridge.Sync(context.Background()))
+	require.Equal(map[string]string{"env": "dev"}, *flightctl.fleets["fleet"].Metadata.Labels)
+}
+
+func TestSyncFromFlightctl(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	flightctl.set("fleet", nil, `{"selector":{}}`, nil)
+	require.NoError(bridge.Sync(context.Background()))
+
+	flightctl.set("fleet", map[string]string{"env": "prod"}, `{"selector":{"matchLabels":{"site":"c"}}}`, nil)
+	require.NoError(bridge.Sync(context.Background()))
+
+	cr := getCR(t, crs, "fleet")
+	site, _, _ := unstructured.NestedString(cr.Object, "spec", "selector", "matchLabels", "site")
+	require.Equal("c", site)
+	require.Equal(map[string]string{"env": "prod"}, cr.GetLabels())
+	require.Equal(*flightctl.fleets["fleet"].Metadata.ResourceVersion, cr.GetAnnotations()[AnnotationSyncedResourceVersion])
+}
+
+func TestOwnedResourceIsNotSyncedFromKubernetes(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	flightctl.set("fleet", nil, `{"selector":{"matchLabels":{"site":"a"}}}`, util.StrToPtr("ResourceSync/sync"))
+	require.NoError(bridge.Sync(context.Background()))
+	require.Equal("ResourceSync/sync", getCR(t, crs, "fleet").GetAnnotations()[AnnotationOwner])
+
+	editCR(t, crs, "fleet", map[string]any{"selector": map[string]any{"matchLabels": map[string]any{"site": "b"}}})
+	require.NoError(bridge.Sync(context.Background()))
+
+	require.JSONEq(`{"selector":{"matchLabels":{"site":"a"}}}`, string(flightctl.fleets["fleet"].Spec))
+	site, _, _ := unstructured.NestedString(getCR(t, crs, "fleet").Object, "spec", "selector", "matchLabels", "site")
+	require.Equal("a", site)
+}
+
+func TestDeleteInKubernetes(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	flightctl.set("fleet", nil, `{"selector":{}}`, nil)
+	require.NoError(bridge.Sync(context.Background()))
+
+	// the API server only marks resources with finalizers as deleted
+	cr := getCR(t, crs, "fleet")
+	cr.SetDeletionTimestamp(lo.ToPtr(metav1.Now()))
+	_, err := crs.Update(context.Background(), cr, metav1.UpdateOptions{})
+	require.NoError(err)
+
+	require.NoError(bridge.Sync(context.Background()))
+	require.NotContains(flightctl.fleets, "fleet")
+	require.NotContains(getCR(t, crs, "fleet").GetFinalizers(), Finalizer)
+}
+
+func TestDeleteInFlightctl(t *testing.T) {
+	require := require.New(t)
+	bridge, flightctl, crs := newTestBridge(t)
+	flightctl.set("fleet", nil, `{"selector":{}}`, nil)
+	require.NoError(bridge.Sync(context.Background()))
+
+	delete(flightctl.fleets, "fleet")
+	require.NoError(bridge.Sync(context.Background()))
+
+	list, err := crs.List(context.Background(), metav1.ListOptions{})
+	require.NoError(err)
+	require.Empty(list.Items)
+}
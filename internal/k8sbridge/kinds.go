@@ -0,0 +1,193 @@
+package k8sbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/client"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// object is the kind-independent representation of a flightctl resource.
+type object struct {
+	Metadata api.ObjectMeta  `json:"metadata"`
+	Spec     json.RawMessage `json:"spec,omitempty"`
+	Status   json.RawMessage `json:"status,omitempty"`
+}
+
+type objectList struct {
+	Metadata api.ListMeta `json:"metadata"`
+	Items    []object     `json:"items"`
+}
+
+// response is the status code and body of a flightctl API response.
+type response struct {
+	statusCode int
+	body       []byte
+}
+
+// kind is a flightctl resource kind mirrored as a custom resource.
+type kind struct {
+	// name is the kind of both the flightctl resource and the custom resource
+	name string
+	// resource is the plural name of both the flightctl API path and the custom resource
+	resource string
+
+	list    func(ctx context.Context, cont *string) (*response, error)
+	replace func(ctx context.Context, name string, body io.Reader) (*response, error)
+	delete  func(ctx context.Context, name string) (*response, error)
+}
+
+func (k *kind) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: Group, Version: Version, Resource: k.resource}
+}
+
+func (k *kind) listObjects(ctx context.Context) ([]object, error) {
+	objects := []object{}
+	err := client.Paginate(ctx, func(ctx context.Context, cont *string) ([]object, *string, error) {
+		resp, err := k.list(ctx, cont)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.statusCode != http.StatusOK {
+			return nil, nil, client.ResponseError(resp.statusCode, resp.body)
+		}
+		var list objectList
+		if err := json.Unmarshal(resp.body, &list); err != nil {
+			return nil, nil, fmt.Errorf("parsing %s: %w", k.resource, err)
+		}
+		return list.Items, list.Metadata.Continue, nil
+	}, func(o *object) error {
+		objects = append(objects, *o)
+		return nil
+	})
+	return objects, err
+}
+
+// replaceObject creates or replaces the flightctl resource with the given labels and spec.
+func (k *kind) replaceObject(ctx context.Context, name string, labels map[string]string, spec any) (*object, error) {
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": flightctlAPIVersion,
+		"kind":       k.name,
+		"metadata":   api.ObjectMeta{Name: &name, Labels: &labels},
+		"spec":       spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.replace(ctx, name, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode != http.StatusOK && resp.statusCode != http.StatusCreated {
+		return nil, client.ResponseError(resp.statusCode, resp.body)
+	}
+	var o object
+	if err := json.Unmarshal(resp.body, &o); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", k.name, err)
+	}
+	return &o, nil
+}
+
+// deleteObject deletes the flightctl resource, if it exists.
+func (k *kind) deleteObject(ctx context.Context, name string) error {
+	resp, err := k.delete(ctx, name)
+	if err != nil {
+		return err
+	}
+	if resp.statusCode != http.StatusOK && resp.statusCode != http.StatusNotFound {
+		return client.ResponseError(resp.statusCode, resp.body)
+	}
+	return nil
+}
+
+// kinds returns the mirrored kinds, in the order in which they are synced so
+// that the resources referenced by others exist first.
+func kinds(c *client.Client) []kind {
+	const contentType = "application/json"
+	return []kind{
+		{
+			name:     "Repository",
+			resource: "repositories",
+			list: func(ctx context.Context, cont *string) (*response, error) {
+				resp, err := c.ListRepositoriesWithResponse(ctx, &api.ListRepositoriesParams{Continue: cont})
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			replace: func(ctx context.Context, name string, body io.Reader) (*response, error) {
+				resp, err := c.ReplaceRepositoryWithBodyWithResponse(ctx, name, contentType, body)
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			delete: func(ctx context.Context, name string) (*response, error) {
+				// the resource was deleted in Kubernetes, so it is deleted even if others depend on it
+				resp, err := c.DeleteRepositoryWithResponse(ctx, name, &api.DeleteRepositoryParams{PropagationPolicy: lo.ToPtr(api.DeletionPropagationPolicyOrphan)})
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+		},
+		{
+			name:     "Fleet",
+			resource: "fleets",
+			list: func(ctx context.Context, cont *string) (*response, error) {
+				resp, err := c.ListFleetsWithResponse(ctx, &api.ListFleetsParams{Continue: cont})
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			replace: func(ctx context.Context, name string, body io.Reader) (*response, error) {
+				resp, err := c.ReplaceFleetWithBodyWithResponse(ctx, name, contentType, body)
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			delete: func(ctx context.Context, name string) (*response, error) {
+				// the resource was deleted in Kubernetes, so it is deleted even if others depend on it
+				resp, err := c.DeleteFleetWithResponse(ctx, name, &api.DeleteFleetParams{PropagationPolicy: lo.ToPtr(api.DeletionPropagationPolicyOrphan)})
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+		},
+		{
+			name:     "Device",
+			resource: "devices",
+			list: func(ctx context.Context, cont *string) (*response, error) {
+				resp, err := c.ListDevicesWithResponse(ctx, &api.ListDevicesParams{Continue: cont})
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			replace: func(ctx context.Context, name string, body io.Reader) (*response, error) {
+				resp, err := c.ReplaceDeviceWithBodyWithResponse(ctx, name, contentType, body)
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+			delete: func(ctx context.Context, name string) (*response, error) {
+				resp, err := c.DeleteDeviceWithResponse(ctx, name)
+				if err != nil {
+					return nil, err
+				}
+				return &response{resp.StatusCode(), resp.Body}, nil
+			},
+		},
+	}
+}
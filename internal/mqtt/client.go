@@ -0,0 +1,193 @@
+// Package mqtt exchanges messages with the MQTT 3.1.1 brokers already running
+// at the edge of some deployments, using the Eclipse Paho client.
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/samber/lo"
+)
+
+const (
+	// DefaultTimeout bounds connecting to the broker and each exchange with it.
+	DefaultTimeout = 10 * time.Second
+	// DefaultKeepAlive is the interval between two pings of a subscription.
+	DefaultKeepAlive = 60 * time.Second
+
+	protocolVersion = 4
+	// disconnectQuiesce is how long disconnecting waits for the work in
+	// progress, in milliseconds.
+	disconnectQuiesce   = 250
+	subscriptionRefused = 0x80
+)
+
+// Options are the settings of the connection to the broker.
+type Options struct {
+	// Broker is the URL of the broker: tcp://host:1883, or ssl://host:8883
+	// and mqtts://host:8883 for TLS.
+	Broker   string
+	ClientID string
+	Username string
+	Password string
+	// TLSConfig is the configuration of the TLS connection to ssl and mqtts brokers.
+	TLSConfig *tls.Config
+	// Timeout bounds connecting to the broker and each exchange with it,
+	// DefaultTimeout if unset.
+	Timeout time.Duration
+	// KeepAlive is the interval between two pings of a subscription, which
+	// the broker closes once it misses one, DefaultKeepAlive if unset.
+	KeepAlive time.Duration
+}
+
+// Client publishes and subscribes to the messages of a broker.
+type Client struct {
+	opts    Options
+	address string
+	useTLS  bool
+}
+
+// New creates a client of the broker of the options. It does not connect to
+// the broker until a message is published.
+func New(opts Options) (*Client, error) {
+	u, err := url.Parse(opts.Broker)
+	if err != nil {
+		return nil, fmt.Errorf("parsing broker URL: %w", err)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("broker URL %q has no host", opts.Broker)
+	}
+	c := &Client{opts: opts, address: u.Host}
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		if u.Port() == "" {
+			c.address = net.JoinHostPort(u.Hostname(), "1883")
+		}
+	case "ssl", "tls", "mqtts":
+		c.useTLS = true
+		if u.Port() == "" {
+			c.address = net.JoinHostPort(u.Hostname(), "8883")
+		}
+	default:
+		return nil, fmt.Errorf("broker URL %q: scheme must be tcp, mqtt, ssl, tls or mqtts", opts.Broker)
+	}
+	if c.opts.Timeout <= 0 {
+		c.opts.Timeout = DefaultTimeout
+	}
+	if c.opts.KeepAlive <= 0 {
+		c.opts.KeepAlive = DefaultKeepAlive
+	}
+	if c.opts.KeepAlive > 65535*time.Second {
+		return nil, fmt.Errorf("keep alive must not exceed 65535 seconds")
+	}
+	if len(c.opts.ClientID) > 65535 || len(c.opts.Username) > 65535 || len(c.opts.Password) > 65535 {
+		return nil, fmt.Errorf("client identifier, user name and password must not exceed 65535 bytes")
+	}
+	return c, nil
+}
+
+// Publish connects to the broker, publishes the payload to the topic with
+// QoS 0 and disconnects. Retained messages are delivered to the clients
+// subscribing to the topic later on.
+func (c *Client) Publish(ctx context.Context, topic string, payload []byte, retain bool) error {
+	if topic == "" || len(topic) > 65535 {
+		return fmt.Errorf("topic must be between 1 and 65535 bytes")
+	}
+	// the session stays open for a single exchange, so keep alive is disabled
+	client, err := c.connect(ctx, c.clientOptions(0))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(disconnectQuiesce)
+
+	if err := c.wait(ctx, client.Publish(topic, 0, retain, payload)); err != nil {
+		return fmt.Errorf("publishing to %q: %w", topic, err)
+	}
+	return nil
+}
+
+// Subscribe connects to the broker, subscribes to the topic with QoS 0 and
+// calls the handler with the payload of each message published to it. It
+// blocks until the context is done, returning nil, or the connection fails,
+// so callers reconnect by calling it again.
+func (c *Client) Subscribe(ctx context.Context, topic string, handler func(payload []byte)) error {
+	if topic == "" || len(topic) > 65535 {
+		return fmt.Errorf("topic must be between 1 and 65535 bytes")
+	}
+	lost := make(chan error, 1)
+	opts := c.clientOptions(c.opts.KeepAlive).SetConnectionLostHandler(func(_ paho.Client, err error) {
+		lost <- err
+	})
+	client, err := c.connect(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(disconnectQuiesce)
+
+	token := client.Subscribe(topic, 0, func(_ paho.Client, message paho.Message) {
+		handler(message.Payload())
+	})
+	if err := c.wait(ctx, token); err != nil {
+		return fmt.Errorf("subscribing to %q: %w", topic, err)
+	}
+	if code, ok := token.(*paho.SubscribeToken).Result()[topic]; !ok || code == subscriptionRefused {
+		return fmt.Errorf("broker refused the subscription to %q", topic)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-lost:
+		return fmt.Errorf("reading from broker: %w", err)
+	}
+}
+
+// clientOptions returns the options of a client opening a clean session with
+// the broker. The client does not reconnect by itself; callers of Subscribe
+// reconnect instead.
+func (c *Client) clientOptions(keepAlive time.Duration) *paho.ClientOptions {
+	return paho.NewClientOptions().
+		AddBroker(lo.Ternary(c.useTLS, "ssl://", "tcp://") + c.address).
+		SetClientID(c.opts.ClientID).
+		SetUsername(c.opts.Username).
+		SetPassword(c.opts.Password).
+		SetTLSConfig(c.opts.TLSConfig).
+		SetProtocolVersion(protocolVersion).
+		SetCleanSession(true).
+		SetAutoReconnect(false).
+		SetConnectTimeout(c.opts.Timeout).
+		SetWriteTimeout(c.opts.Timeout).
+		SetPingTimeout(c.opts.Timeout).
+		SetKeepAlive(keepAlive)
+}
+
+// connect connects a client of the options to the broker.
+func (c *Client) connect(ctx context.Context, opts *paho.ClientOptions) (paho.Client, error) {
+	client := paho.NewClient(opts)
+	if err := c.wait(ctx, client.Connect()); err != nil {
+		// stop a connection attempt that timed out
+		client.Disconnect(0)
+		return nil, fmt.Errorf("connecting to broker: %w", err)
+	}
+	return client, nil
+}
+
+// wait waits for the exchange of the token with the broker to complete.
+func (c *Client) wait(ctx context.Context, token paho.Token) error {
+	timer := time.NewTimer(c.opts.Timeout)
+	defer timer.Stop()
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return errors.New("timed out waiting for the broker")
+	}
+}
@@ -0,0 +1,155 @@
+package mqtt
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/require"
+)
+
+// serveBroker accepts a single connection, answers its CONNECT with the
+// return code and sends the packets it reads to the returned channel. Once it
+// has acknowledged a subscription, it delivers the retained message of the
+// topic and closes the connection if closeAfterSubscribe is set.
+func serveBroker(t *testing.T, returnCode byte, closeAfterSubscribe bool) (string, <-chan packets.ControlPacket) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan packets.ControlPacket, 10)
+	go func() {
+		defer close(received)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			packet, err := packets.ReadPacket(conn)
+			if err != nil {
+				return
+			}
+			received <- packet
+			switch p := packet.(type) {
+			case *packets.ConnectPacket:
+				connack := packets.NewControlPacket(packets.Connack).(*packets.ConnackPacket)
+				connack.ReturnCode = returnCode
+				_ = connack.Write(conn)
+			case *packets.SubscribePacket:
+				suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+				suback.MessageID = p.MessageID
+				suback.ReturnCodes = []byte{0}
+				_ = suback.Write(conn)
+				publish := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				publish.TopicName = p.Topics[0]
+				publish.Retain = true
+				publish.Payload = []byte("retained")
+				_ = publish.Write(conn)
+				if closeAfterSubscribe {
+					return
+				}
+			case *packets.PingreqPacket:
+				_ = packets.NewControlPacket(packets.Pingresp).Write(conn)
+			}
+		}
+	}()
+	return "tcp://" + listener.Addr().String(), received
+}
+
+func TestPublish(t *testing.T) {
+	require := require.New(t)
+	broker, received := serveBroker(t, packets.Accepted, false)
+
+	client, err := New(Options{Broker: broker, ClientID: "device", Username: "user", Password: "secret"})
+	require.NoError(err)
+	require.NoError(client.Publish(context.Background(), "devices/device/status", []byte(`{"ok":true}`), true))
+
+	connect, ok := (<-received).(*packets.ConnectPacket)
+	require.True(ok)
+	require.Equal(byte(protocolVersion), connect.ProtocolVersion)
+	require.True(connect.CleanSession)
+	require.Equal("device", connect.ClientIdentifier)
+	require.Equal("user", connect.Username)
+	require.Equal([]byte("secret"), connect.Password)
+	require.Zero(connect.Keepalive)
+
+	publish, ok := (<-received).(*packets.PublishPacket)
+	require.True(ok)
+	require.Equal("devices/device/status", publish.TopicName)
+	require.Equal(`{"ok":true}`, string(publish.Payload))
+	require.True(publish.Retain)
+	require.Zero(publish.Qos)
+
+	require.IsType(&packets.DisconnectPacket{}, <-received)
+}
+
+func TestSubscribe(t *testing.T) {
+	require := require.New(t)
+	broker, received := serveBroker(t, packets.Accepted, false)
+
+	client, err := New(Options{Broker: broker, ClientID: "device", KeepAlive: 30 * time.Second})
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	messages := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.Subscribe(ctx, "devices/device/spec", func(payload []byte) {
+			messages <- string(payload)
+		})
+	}()
+	require.Equal("retained", <-messages)
+	cancel()
+	require.NoError(<-errs)
+
+	connect, ok := (<-received).(*packets.ConnectPacket)
+	require.True(ok)
+	// the keep alive is announced in seconds
+	require.Equal(uint16(30), connect.Keepalive)
+	subscribe, ok := (<-received).(*packets.SubscribePacket)
+	require.True(ok)
+	require.Equal([]string{"devices/device/spec"}, subscribe.Topics)
+	require.Equal([]byte{0}, subscribe.Qoss)
+	require.IsType(&packets.DisconnectPacket{}, <-received)
+}
+
+func TestSubscribeConnectionLost(t *testing.T) {
+	require := require.New(t)
+	broker, _ := serveBroker(t, packets.Accepted, true)
+
+	client, err := New(Options{Broker: broker, ClientID: "device"})
+	require.NoError(err)
+	// the subscription ends with the connection, so that the caller reconnects
+	err = client.Subscribe(context.Background(), "devices/device/spec", func(payload []byte) {})
+	require.ErrorContains(err, "reading from broker")
+}
+
+func TestPublishRefused(t *testing.T) {
+	broker, _ := serveBroker(t, packets.ErrRefusedNotAuthorised, false)
+	client, err := New(Options{Broker: broker})
+	require.NoError(t, err)
+	err = client.Publish(context.Background(), "status", nil, false)
+	require.ErrorIs(t, err, packets.ErrorRefusedNotAuthorised)
+}
+
+func TestNew(t *testing.T) {
+	require := require.New(t)
+
+	client, err := New(Options{Broker: "mqtts://broker.example.com"})
+	require.NoError(err)
+	require.True(client.useTLS)
+	require.Equal("broker.example.com:8883", client.address)
+
+	client, err = New(Options{Broker: "tcp://broker.example.com"})
+	require.NoError(err)
+	require.False(client.useTLS)
+	require.Equal("broker.example.com:1883", client.address)
+
+	for _, broker := range []string{"http://broker.example.com", "tcp://", "broker.example.com:1883"} {
+		_, err := New(Options{Broker: broker})
+		require.Error(err, broker)
+	}
+}
@@ -0,0 +1,26 @@
+package mqtt
+
+import (
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+)
+
+// DefaultSpecTopicPrefix is the prefix of the topics the service notifies
+// devices of new rendered specs on.
+const DefaultSpecTopicPrefix = "flightctl/devices"
+
+// SpecTopic returns the topic the service notifies the device of new
+// rendered specs on.
+func SpecTopic(prefix string, deviceName string) string {
+	return strings.TrimSuffix(prefix, "/") + "/" + deviceName + "/spec"
+}
+
+// SpecNotification is the message announcing a new rendered spec of a device.
+type SpecNotification struct {
+	RenderedVersion string `json:"renderedVersion"`
+	// Spec is the rendered spec itself, unless it exceeds the size of the
+	// messages the service publishes, in which case devices fetch it from the
+	// management API.
+	Spec *v1alpha1.RenderedDeviceSpec `json:"spec,omitempty"`
+}
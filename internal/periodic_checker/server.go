@@ -1,16 +1,27 @@
 package periodic
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/flightctl/flightctl/internal/artifacts"
 	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/inventory"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
+	"github.com/flightctl/flightctl/pkg/k8sclient"
 	"github.com/flightctl/flightctl/pkg/queues"
 	"github.com/flightctl/flightctl/pkg/thread"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,8 +44,16 @@ func New(
 	}
 }
 
-// TODO: expose metrics
 func (s *Server) Run() error {
+	if s.cfg.Metrics != nil && s.cfg.Metrics.Address != "" {
+		metricsServer := s.serveMetrics(s.cfg.Metrics.Address)
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Service.GracefulShutdownTimeout())
+			defer cancel()
+			_ = metricsServer.Shutdown(ctx)
+		}()
+	}
+
 	provider := queues.NewAmqpProvider(s.cfg.Queue.AmqpURL, s.log)
 	defer provider.Stop()
 
@@ -53,6 +72,7 @@ func (s *Server) Run() error {
 
 	// resource sync
 	resourceSync := tasks.NewResourceSync(callbackManager, s.store, s.log)
+	resourceSync.SetRequireImageDigests(s.cfg.Service.RequireImageDigests)
 	resourceSyncThread := thread.New(
 		s.log.WithField("pkg", "resourcesync"), "ResourceSync", 2*time.Minute, resourceSync.Poll)
 	resourceSyncThread.Start()
@@ -65,6 +85,134 @@ func (s *Server) Run() error {
 	deviceDisconnectedThread.Start()
 	defer deviceDisconnectedThread.Stop()
 
+	// certificate expiry
+	var metricsForwarder *remotewrite.Forwarder
+	if s.cfg.Metrics != nil && s.cfg.Metrics.RemoteWriteUrl != "" {
+		metricsForwarder = remotewrite.NewForwarder(s.cfg.Metrics.RemoteWriteUrl, s.log)
+	}
+	certificateExpiry := tasks.NewCertificateExpiry(s.log, s.store, metricsForwarder)
+	certificateExpiryThread := thread.New(
+		s.log.WithField("pkg", "certificate-expiry"), "Certificate expiry", tasks.CertificateExpiryPollingInterval, certificateExpiry.Poll)
+	certificateExpiryThread.Start()
+	defer certificateExpiryThread.Stop()
+
+	// crypto compliance
+	cryptoCompliance := tasks.NewCryptoCompliance(s.log, s.store)
+	cryptoComplianceThread := thread.New(
+		s.log.WithField("pkg", "crypto-compliance"), "Crypto compliance", tasks.CryptoCompliancePollingInterval, cryptoCompliance.Poll)
+	cryptoComplianceThread.Start()
+	defer cryptoComplianceThread.Stop()
+
+	// clock skew
+	clockSkew := tasks.NewClockSkew(s.log, s.store)
+	clockSkewThread := thread.New(
+		s.log.WithField("pkg", "clock-skew"), "Clock skew", tasks.ClockSkewPollingInterval, clockSkew.Poll)
+	clockSkewThread.Start()
+	defer clockSkewThread.Stop()
+
+	// fleet reports
+	artifactStore, err := artifacts.New(s.cfg)
+	if err != nil {
+		return fmt.Errorf("creating artifact storage: %w", err)
+	}
+	fleetReports := tasks.NewFleetReports(s.log, s.store, artifactStore)
+	fleetReportsThread := thread.New(
+		s.log.WithField("pkg", "fleet-reports"), "Fleet reports", tasks.FleetReportsPollingInterval, fleetReports.Poll)
+	fleetReportsThread.Start()
+	defer fleetReportsThread.Stop()
+
+	// inventory export
+	inventoryExporter, err := inventory.NewExporter(s.cfg, artifactStore)
+	if err != nil {
+		return fmt.Errorf("creating inventory exporter: %w", err)
+	}
+	if inventoryExporter != nil {
+		inventoryExport := tasks.NewInventoryExport(s.log, s.store, inventoryExporter)
+		inventoryExportThread := thread.New(
+			s.log.WithField("pkg", "inventory-export"), "Inventory export", inventoryExporter.Interval(), inventoryExport.Poll)
+		inventoryExportThread.Start()
+		defer inventoryExportThread.Stop()
+	}
+
+	// fleet rollout progress
+	inventoryClient, err := inventory.New(s.cfg)
+	if err != nil {
+		return fmt.Errorf("creating inventory client: %w", err)
+	}
+	fleetRolloutProgress := tasks.NewFleetRolloutProgress(s.log, s.store, callbackManager, inventoryClient)
+	fleetRolloutProgressThread := thread.New(
+		s.log.WithField("pkg", "fleet-rollout-progress"), "Fleet rollout progress", tasks.FleetRolloutProgressPollingInterval, fleetRolloutProgress.Poll)
+	fleetRolloutProgressThread.Start()
+	defer fleetRolloutProgressThread.Stop()
+
+	// fleet promotion
+	fleetPromotion := tasks.NewFleetPromotion(s.log, s.store, callbackManager)
+	fleetPromotionThread := thread.New(
+		s.log.WithField("pkg", "fleet-promotion"), "Fleet promotion", tasks.FleetPromotionPollingInterval, fleetPromotion.Poll)
+	fleetPromotionThread.Start()
+	defer fleetPromotionThread.Stop()
+
+	// os streams
+	osStreams := tasks.NewOsStreams(s.log, s.store, callbackManager, registry.NewClient(nil))
+	osStreamsThread := thread.New(
+		s.log.WithField("pkg", "os-streams"), "OS streams", tasks.OsStreamsPollingInterval, osStreams.Poll)
+	osStreamsThread.Start()
+	defer osStreamsThread.Stop()
+
+	// SBOM collection
+	sbomCollector := tasks.NewSbomCollector(s.log, s.store, registry.NewClient(nil))
+	sbomCollectorThread := thread.New(
+		s.log.WithField("pkg", "sbom-collector"), "SBOM collector", tasks.SbomCollectorPollingInterval, sbomCollector.Poll)
+	sbomCollectorThread.Start()
+	defer sbomCollectorThread.Stop()
+
+	// trash purge
+	trashPurge := tasks.NewTrashPurge(s.log, s.store, callbackManager, s.cfg.Service.TrashRetentionPeriod())
+	trashPurgeThread := thread.New(
+		s.log.WithField("pkg", "trash-purge"), "Trash purge", tasks.TrashPurgePollingInterval, trashPurge.Poll)
+	trashPurgeThread.Start()
+	defer trashPurgeThread.Stop()
+
+	// idempotency key purge
+	idempotencyKeyPurge := tasks.NewIdempotencyKeyPurge(s.log, s.store)
+	idempotencyKeyPurgeThread := thread.New(
+		s.log.WithField("pkg", "idempotency-key-purge"), "Idempotency key purge", tasks.IdempotencyKeyPurgePollingInterval, idempotencyKeyPurge.Poll)
+	idempotencyKeyPurgeThread.Start()
+	defer idempotencyKeyPurgeThread.Stop()
+
+	// artifact retention
+	if artifactStore != nil {
+		retention, err := artifacts.Retention(s.cfg)
+		if err != nil {
+			return err
+		}
+		artifactRetention := tasks.NewArtifactRetention(s.log, artifactStore, retention)
+		artifactRetentionThread := thread.New(
+			s.log.WithField("pkg", "artifact-retention"), "Artifact retention", tasks.ArtifactRetentionPollingInterval, artifactRetention.Poll)
+		artifactRetentionThread.Start()
+		defer artifactRetentionThread.Stop()
+	}
+
+	// enrollment request expiry
+	enrollmentRequestExpiry := tasks.NewEnrollmentRequestExpiry(s.log, s.store, s.cfg.Service.EnrollmentRequestTTL())
+	enrollmentRequestExpiryThread := thread.New(
+		s.log.WithField("pkg", "enrollment-request-expiry"), "Enrollment request expiry", tasks.EnrollmentRequestExpiryPollingInterval, enrollmentRequestExpiry.Poll)
+	enrollmentRequestExpiryThread.Start()
+	defer enrollmentRequestExpiryThread.Stop()
+
+	// ACM registration
+	if s.cfg.ACM != nil && s.cfg.ACM.Enabled {
+		hub, err := k8sclient.NewManagedClusterClient()
+		if err != nil {
+			return fmt.Errorf("initializing ACM hub client: %w", err)
+		}
+		acmRegistration := tasks.NewACMRegistration(s.log, s.store, callbackManager, hub)
+		acmRegistrationThread := thread.New(
+			s.log.WithField("pkg", "acm-registration"), "ACM registration", tasks.ACMRegistrationPollingInterval, acmRegistration.Poll)
+		acmRegistrationThread.Start()
+		defer acmRegistrationThread.Stop()
+	}
+
 	sigShutdown := make(chan os.Signal, 1)
 
 	signal.Notify(sigShutdown, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGQUIT)
@@ -72,3 +220,20 @@ func (s *Server) Run() error {
 	s.log.Println("Shutdown signal received")
 	return nil
 }
+
+// serveMetrics serves the Prometheus metrics of the periodic tasks on
+// /metrics of the given address.
+func (s *Server) serveMetrics(address string) *http.Server {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(tasks.Collectors()...)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		s.log.Infof("Serving metrics on %s", address)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.log.WithError(err).Errorf("metrics server listen on %s", address)
+		}
+	}()
+	return srv
+}
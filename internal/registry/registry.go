@@ -0,0 +1,377 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	dockerHubDomain   = "docker.io"
+	dockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+	requestTimeout    = 10 * time.Second
+
+	ociIndexMediaType = "application/vnd.oci.image.index.v1+json"
+	// maxManifestSize is the size limit of manifests the distribution spec
+	// recommends registries to enforce.
+	maxManifestSize = 4 << 20
+	maxBlobSize     = 64 << 20
+)
+
+// manifestMediaTypes are the media types of the manifests the client accepts,
+// both single-platform manifests and multi-platform indexes.
+var manifestMediaTypes = []string{
+	ociIndexMediaType,
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var ErrManifestNotFound = errors.New("manifest not found")
+
+// Descriptor describes the content of a manifest, a blob or an artifact.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an image or artifact manifest, or an index of manifests.
+type Manifest struct {
+	MediaType    string       `json:"mediaType"`
+	ArtifactType string       `json:"artifactType,omitempty"`
+	Config       *Descriptor  `json:"config,omitempty"`
+	Layers       []Descriptor `json:"layers,omitempty"`
+	Manifests    []Descriptor `json:"manifests,omitempty"`
+}
+
+// Reference is a parsed image reference such as quay.io/org/app:v1.
+type Reference struct {
+	// Registry is the host of the registry, with its port if any.
+	Registry string
+	// Repository is the path of the repository in the registry.
+	Repository string
+	// Tag is the tag of the image, empty if the reference has a digest.
+	Tag string
+	// Digest is the digest of the image, such as sha256:abc.
+	Digest string
+}
+
+// ParseReference parses an image reference the way podman does, defaulting to
+// the Docker Hub registry and to the latest tag.
+func ParseReference(image string) (Reference, error) {
+	ref := Reference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref.Digest = name[:i], name[i+1:]
+		if !strings.Contains(ref.Digest, ":") {
+			return Reference{}, fmt.Errorf("invalid digest in image %q", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Tag = name[:i], name[i+1:]
+		if ref.Tag == "" {
+			return Reference{}, fmt.Errorf("invalid tag in image %q", image)
+		}
+	}
+	if name == "" || strings.HasSuffix(name, "/") {
+		return Reference{}, fmt.Errorf("invalid image %q", image)
+	}
+
+	ref.Registry = dockerHubDomain
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry, name = host, name[i+1:]
+		}
+	}
+	if ref.Registry == dockerHubDomain && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+	return ref, nil
+}
+
+// host returns the host serving the registry of the image.
+func (r Reference) host() string {
+	if r.Registry == dockerHubDomain {
+		return dockerHubRegistry
+	}
+	return r.Registry
+}
+
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// Client queries the manifests, referrers and blobs of images from their
+// registries, anonymously.
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	return &Client{httpClient: httpClient}
+}
+
+// ManifestDigest returns the digest of the manifest of the image, and an error
+// wrapping ErrManifestNotFound if the registry has no such tag or digest.
+func (c *Client) ManifestDigest(ctx context.Context, ref Reference) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, ref, manifestURL(ref), strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		digest := resp.Header.Get("Docker-Content-Digest")
+		if digest == "" {
+			digest = ref.Digest
+		}
+		return digest, nil
+	case http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrManifestNotFound, ref)
+	default:
+		return "", fmt.Errorf("failed getting manifest of image %s: %s", ref, resp.Status)
+	}
+}
+
+// Manifest returns the manifest of the image, and an error wrapping
+// ErrManifestNotFound if the registry has no such tag or digest.
+func (c *Client) Manifest(ctx context.Context, ref Reference) (*Manifest, error) {
+	resp, err := c.do(ctx, http.MethodGet, ref, manifestURL(ref), strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var manifest Manifest
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("failed decoding manifest of image %s: %w", ref, err)
+		}
+		return &manifest, nil
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrManifestNotFound, ref)
+	default:
+		return nil, fmt.Errorf("failed getting manifest of image %s: %s", ref, resp.Status)
+	}
+}
+
+// Referrers returns the artifacts of the given type which refer to the image
+// pinned by digest, such as its SBOMs or signatures, or the artifacts of all
+// types if artifactType is empty. Registries that do not serve the referrers
+// API are queried for the index tagged with the digest of the image instead,
+// as the distribution spec describes for them.
+func (c *Client) Referrers(ctx context.Context, ref Reference, artifactType string) ([]Descriptor, error) {
+	if ref.Digest == "" {
+		return nil, fmt.Errorf("image %s is not pinned by digest", ref)
+	}
+	referrersURL := fmt.Sprintf("https://%s/v2/%s/referrers/%s", ref.host(), ref.Repository, ref.Digest)
+	if artifactType != "" {
+		referrersURL += "?artifactType=" + url.QueryEscape(artifactType)
+	}
+	resp, err := c.do(ctx, http.MethodGet, ref, referrersURL, ociIndexMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var index Manifest
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&index); err != nil {
+			return nil, fmt.Errorf("failed decoding referrers of image %s: %w", ref, err)
+		}
+	case http.StatusNotFound:
+		tagged, err := c.Manifest(ctx, Reference{Registry: ref.Registry, Repository: ref.Repository, Tag: strings.Replace(ref.Digest, ":", "-", 1)})
+		if errors.Is(err, ErrManifestNotFound) {
+			return []Descriptor{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		index = *tagged
+	default:
+		return nil, fmt.Errorf("failed getting referrers of image %s: %s", ref, resp.Status)
+	}
+
+	referrers := []Descriptor{}
+	for _, descriptor := range index.Manifests {
+		if artifactType == "" || descriptor.ArtifactType == artifactType {
+			referrers = append(referrers, descriptor)
+		}
+	}
+	return referrers, nil
+}
+
+// Blob returns the content of a blob of the repository of the image, after
+// checking it matches its digest.
+func (c *Client) Blob(ctx context.Context, ref Reference, digest string) ([]byte, error) {
+	algorithm, hash, _ := strings.Cut(digest, ":")
+	if algorithm != "sha256" {
+		return nil, fmt.Errorf("unsupported digest %q", digest)
+	}
+	blobURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", ref.host(), ref.Repository, digest)
+	resp, err := c.do(ctx, http.MethodGet, ref, blobURL, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed getting blob %s of repository %s/%s: %s", digest, ref.Registry, ref.Repository, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBlobSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading blob %s: %w", digest, err)
+	}
+	if len(data) > maxBlobSize {
+		return nil, fmt.Errorf("blob %s exceeds %d bytes", digest, maxBlobSize)
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != hash {
+		return nil, fmt.Errorf("blob %s does not match its digest", digest)
+	}
+	return data, nil
+}
+
+func manifestURL(ref Reference) string {
+	if ref.Digest == "" {
+		return fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.host(), ref.Repository, ref.Tag)
+	}
+	return fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.host(), ref.Repository, ref.Digest)
+}
+
+// do sends a request to the registry of the image, authenticating with an
+// anonymous token if the registry challenges the request.
+func (c *Client) do(ctx context.Context, method string, ref Reference, requestURL string, accept string) (*http.Response, error) {
+	resp, err := c.send(ctx, method, requestURL, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	resp.Body.Close()
+	token, err := c.token(ctx, resp.Header.Get("WWW-Authenticate"))
+	if err != nil {
+		return nil, fmt.Errorf("failed authenticating to registry %s: %w", ref.Registry, err)
+	}
+	return c.send(ctx, method, requestURL, accept, token)
+}
+
+func (c *Client) send(ctx context.Context, method string, requestURL string, accept string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying registry: %w", err)
+	}
+	return resp, nil
+}
+
+// token requests an anonymous token from the authorization server named by
+// the bearer challenge of the registry.
+func (c *Client) token(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	values := parseChallengeParams(params)
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return "", fmt.Errorf("invalid realm in authentication challenge %q", challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if values[key] != "" {
+			query.Set(key, values[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: %s", resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed decoding token: %w", err)
+	}
+	if body.Token == "" {
+		return body.AccessToken, nil
+	}
+	return body.Token, nil
+}
+
+// parseChallengeParams parses the comma separated key="value" parameters of a
+// WWW-Authenticate challenge.
+func parseChallengeParams(params string) map[string]string {
+	values := map[string]string{}
+	for params != "" {
+		key, rest, ok := strings.Cut(params, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			rest = "," + rest
+		}
+		values[key] = value
+		_, params, _ = strings.Cut(rest, ",")
+	}
+	return values
+}
@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected Reference
+	}{
+		{"nginx", Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{"org/app:v1", Reference{Registry: "docker.io", Repository: "org/app", Tag: "v1"}},
+		{"quay.io/org/app:v1", Reference{Registry: "quay.io", Repository: "org/app", Tag: "v1"}},
+		{"localhost:5000/app", Reference{Registry: "localhost:5000", Repository: "app", Tag: "latest"}},
+		{"quay.io/org/app@sha256:abc", Reference{Registry: "quay.io", Repository: "org/app", Digest: "sha256:abc"}},
+		{"quay.io/org/app:v1@sha256:abc", Reference{Registry: "quay.io", Repository: "org/app", Tag: "v1", Digest: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		ref, err := ParseReference(tt.image)
+		require.NoError(t, err, tt.image)
+		require.Equal(t, tt.expected, ref, tt.image)
+	}
+
+	for _, image := range []string{"", "quay.io/org/app:", "quay.io/org/app@abc"} {
+		_, err := ParseReference(image)
+		require.Error(t, err, image)
+	}
+}
+
+func TestManifestDigest(t *testing.T) {
+	require := require.New(t)
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			require.Equal("repository:org/app:pull", r.URL.Query().Get("scope"))
+			fmt.Fprint(w, `{"token":"secret"}`)
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/app:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/org/app/manifests/v1":
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.Client())
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	digest, err := client.ManifestDigest(context.Background(), Reference{Registry: host, Repository: "org/app", Tag: "v1"})
+	require.NoError(err)
+	require.Equal("sha256:abc", digest)
+
+	_, err = client.ManifestDigest(context.Background(), Reference{Registry: host, Repository: "org/app", Tag: "v2"})
+	require.True(errors.Is(err, ErrManifestNotFound))
+}
+
+func TestReferrers(t *testing.T) {
+	require := require.New(t)
+	sbom := []byte(`{"spdxVersion":"SPDX-2.3"}`)
+	sum := sha256.Sum256(sbom)
+	sbomDigest := "sha256:" + hex.EncodeToString(sum[:])
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/org/app/referrers/sha256:abc":
+			fmt.Fprint(w, `{"manifests":[
+				{"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/spdx+json","digest":"sha256:111"},
+				{"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/vnd.dev.cosign.artifact.sig.v1+json","digest":"sha256:222"}]}`)
+		case "/v2/org/legacy/manifests/sha256-def":
+			// a registry without referrers API serves them in a tagged index
+			fmt.Fprint(w, `{"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/spdx+json","digest":"sha256:333"}]}`)
+		case "/v2/org/app/blobs/" + sbomDigest:
+			_, _ = w.Write(sbom)
+		case "/v2/org/app/blobs/sha256:444":
+			_, _ = w.Write(sbom)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.Client())
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	referrers, err := client.Referrers(context.Background(), Reference{Registry: host, Repository: "org/app", Digest: "sha256:abc"}, "application/spdx+json")
+	require.NoError(err)
+	require.Len(referrers, 1)
+	require.Equal("sha256:111", referrers[0].Digest)
+
+	referrers, err = client.Referrers(context.Background(), Reference{Registry: host, Repository: "org/legacy", Digest: "sha256:def"}, "")
+	require.NoError(err)
+	require.Len(referrers, 1)
+	require.Equal("sha256:333", referrers[0].Digest)
+
+	referrers, err = client.Referrers(context.Background(), Reference{Registry: host, Repository: "org/other", Digest: "sha256:abc"}, "")
+	require.NoError(err)
+	require.Empty(referrers)
+
+	data, err := client.Blob(context.Background(), Reference{Registry: host, Repository: "org/app"}, sbomDigest)
+	require.NoError(err)
+	require.Equal(sbom, data)
+
+	_, err = client.Blob(context.Background(), Reference{Registry: host, Repository: "org/app"}, "sha256:444")
+	require.ErrorContains(err, "does not match its digest")
+}
@@ -0,0 +1,123 @@
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/agent"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultConfigFile is the default path to the relay's configuration file
+	DefaultConfigFile = agent.DefaultConfigDir + "/relay.yaml"
+	// DefaultListenAddress is the default address the relay serves the agents of its site on
+	DefaultListenAddress = ":7443"
+	// DefaultGrpcListenAddress is the default address the relay serves the console sessions of the agents on
+	DefaultGrpcListenAddress = ":7444"
+	// DefaultStatusBatchInterval is the default longest time the relay holds the statuses of devices before sending them to the service
+	DefaultStatusBatchInterval = util.Duration(10 * time.Second)
+	// DefaultStatusBatchSize is the default number of statuses after which the relay sends them to the service without waiting
+	DefaultStatusBatchSize = 500
+	// MaxStatusBatchSize is the most statuses the service accepts in a batch
+	MaxStatusBatchSize = 1000
+)
+
+type Config struct {
+	// ListenAddress is the address the relay serves the agents of its site on, :7443 by default
+	ListenAddress string `json:"listen-address,omitempty"`
+	// GrpcListenAddress is the address the relay serves the console sessions of the agents on, :7444 by default
+	GrpcListenAddress string `json:"grpc-listen-address,omitempty"`
+	// ServerCertificate is the path to the certificate the relay serves the agents with, which the CA bundle of the agents must trust for the name they connect to
+	ServerCertificate string `json:"server-certificate"`
+	// ServerKey is the path to the key of the server certificate
+	ServerKey string `json:"server-key"`
+	// ClientCertificateAuthority is the path to the CAs of the client certificates of the agents, the CA bundle of the management service by default
+	ClientCertificateAuthority string `json:"client-certificate-authority,omitempty"`
+	// ManagementService is the client configuration for connecting to the agent endpoint of the service, with the agent certificate of the device the relay runs on by default, which the service must trust as a relay
+	ManagementService client.Config `json:"management-service"`
+	// GrpcManagementEndpoint is the gRPC endpoint of the service the relay forwards the console sessions of the agents to. The relay does not serve console sessions if it is not set
+	GrpcManagementEndpoint string `json:"grpc-management-endpoint,omitempty"`
+	// SpecRefreshInterval is how long the relay serves a rendered spec it cached without asking the service whether it changed, 0 by default
+	SpecRefreshInterval util.Duration `json:"spec-refresh-interval,omitempty"`
+	// StatusBatchInterval is the longest time the relay holds the statuses of devices before sending them to the service, 10s by default
+	StatusBatchInterval util.Duration `json:"status-batch-interval,omitempty"`
+	// StatusBatchSize is the number of statuses after which the relay sends them to the service without waiting, 500 by default and at most 1000
+	StatusBatchSize int `json:"status-batch-size,omitempty"`
+	// LogLevel is the level of logging, info by default
+	LogLevel string `json:"log-level,omitempty"`
+}
+
+// LoadConfig reads the configuration of the relay, filling in the defaults.
+func LoadConfig(cfgFile string) (*Config, error) {
+	contents, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	cfg := &Config{ManagementService: *client.NewDefault()}
+	if err := yaml.Unmarshal(contents, cfg); err != nil {
+		return nil, fmt.Errorf("unmarshalling config file: %w", err)
+	}
+	cfg.ManagementService.SetBaseDir(filepath.Dir(cfgFile))
+	cfg.Complete()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Complete fills in defaults for fields not set by the config file
+func (cfg *Config) Complete() {
+	if cfg.ListenAddress == "" {
+		cfg.ListenAddress = DefaultListenAddress
+	}
+	if cfg.GrpcListenAddress == "" {
+		cfg.GrpcListenAddress = DefaultGrpcListenAddress
+	}
+	certsDir := filepath.Join(agent.DefaultDataDir, agent.DefaultCertsDirName)
+	service := &cfg.ManagementService
+	if service.Service.CertificateAuthority == "" && len(service.Service.CertificateAuthorityData) == 0 {
+		service.Service.CertificateAuthority = filepath.Join(agent.DefaultConfigDir, agent.DefaultCertsDirName, agent.CacertFile)
+	}
+	if service.AuthInfo.ClientCertificate == "" && len(service.AuthInfo.ClientCertificateData) == 0 {
+		service.AuthInfo.ClientCertificate = filepath.Join(certsDir, agent.GeneratedCertFile)
+		service.AuthInfo.ClientKey = filepath.Join(certsDir, agent.KeyFile)
+	}
+	if cfg.ClientCertificateAuthority == "" {
+		cfg.ClientCertificateAuthority = service.Service.CertificateAuthority
+	}
+	if cfg.StatusBatchInterval == 0 {
+		cfg.StatusBatchInterval = DefaultStatusBatchInterval
+	}
+	if cfg.StatusBatchSize == 0 {
+		cfg.StatusBatchSize = DefaultStatusBatchSize
+	}
+}
+
+// Validate checks that the required fields are set.
+func (cfg *Config) Validate() error {
+	if cfg.ServerCertificate == "" || cfg.ServerKey == "" {
+		return errors.New("server-certificate and server-key are required")
+	}
+	if cfg.ClientCertificateAuthority == "" {
+		return errors.New("client-certificate-authority is required when the CA bundle of the management service is inline")
+	}
+	if err := cfg.ManagementService.Validate(); err != nil {
+		return fmt.Errorf("management-service: %w", err)
+	}
+	if cfg.SpecRefreshInterval < 0 {
+		return errors.New("spec-refresh-interval: must not be negative")
+	}
+	if cfg.StatusBatchInterval < 0 {
+		return errors.New("status-batch-interval: must not be negative")
+	}
+	if cfg.StatusBatchSize < 0 || cfg.StatusBatchSize > MaxStatusBatchSize {
+		return fmt.Errorf("status-batch-size: must be between 1 and %d", MaxStatusBatchSize)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package relay
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	pb "github.com/flightctl/flightctl/api/grpc/v1"
+	"github.com/flightctl/flightctl/internal/api_server/agentserver"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// consoleForwarder forwards the console sessions of the agents to the gRPC
+// endpoint of the service. The relay only forwards the sessions which are
+// encrypted end to end, so it passes on payloads it cannot read.
+type consoleForwarder struct {
+	pb.UnimplementedRouterServiceServer
+	log      logrus.FieldLogger
+	upstream pb.RouterServiceClient
+}
+
+// serveConsoles serves the console sessions of the agents until the context
+// is canceled.
+func (r *Relay) serveConsoles(ctx context.Context, tlsConfig *tls.Config) error {
+	upstream, err := client.NewGRPCClientFromConfig(&r.cfg.ManagementService, r.cfg.GrpcManagementEndpoint)
+	if err != nil {
+		return fmt.Errorf("creating the gRPC client of the service: %w", err)
+	}
+	listener, err := net.Listen("tcp", r.cfg.GrpcListenAddress)
+	if err != nil {
+		return fmt.Errorf("creating gRPC listener: %w", err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	pb.RegisterRouterServiceServer(server, &consoleForwarder{log: r.log, upstream: upstream})
+	go func() {
+		<-ctx.Done()
+		// the sessions are not drained, the operators open them again
+		server.Stop()
+	}()
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			r.log.Errorf("serving console sessions: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stream forwards the console session of an agent to the service, along with
+// the session ID the service pairs it with the operator by.
+func (f *consoleForwarder) Stream(stream pb.RouterService_StreamServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	sessionIDs := md.Get(agentserver.SessionIDKey)
+	if len(sessionIDs) != 1 {
+		return status.Error(codes.InvalidArgument, "missing "+agentserver.SessionIDKey)
+	}
+	outgoing := metadata.Pairs(agentserver.SessionIDKey, sessionIDs[0])
+	outgoing.Set(agentserver.ClientNameKey, md.Get(agentserver.ClientNameKey)...)
+
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(stream.Context(), outgoing))
+	defer cancel()
+	upstream, err := f.upstream.Stream(ctx)
+	if err != nil {
+		f.log.Warnf("console session %s: connecting to the service: %v", sessionIDs[0], err)
+		return status.Error(codes.Unavailable, "the relay cannot reach the service")
+	}
+	f.log.Infof("forwarding console session %s", sessionIDs[0])
+	defer f.log.Infof("console session %s ended", sessionIDs[0])
+
+	go func() {
+		// the service ends the session once the agent closes its side
+		defer func() { _ = upstream.CloseSend() }()
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					cancel()
+				}
+				return
+			}
+			if err := upstream.Send(&pb.StreamRequest{Payload: msg.GetPayload(), Closed: msg.GetClosed()}); err != nil || msg.GetClosed() {
+				return
+			}
+		}
+	}()
+	for {
+		msg, err := upstream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&pb.StreamResponse{Payload: msg.GetPayload(), Closed: msg.GetClosed()}); err != nil {
+			return err
+		}
+		if msg.GetClosed() {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package relay
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentclient "github.com/flightctl/flightctl/internal/api/client/agent"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+// shutdownTimeout bounds how long the relay drains the requests in flight and
+// sends the statuses it holds once it is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
+// A relay serves the agents of a site where only the host the relay runs on
+// can reach the service. It terminates the connections of the agents and
+// forwards their requests over one connection to the agent endpoint of the
+// service, authenticated with the agent certificate of its host, which the
+// service trusts as a relay. Each forwarded request carries the client
+// certificate the agent connected with and the signature of the request by
+// the agent, so that the service authorizes it as if the device had connected
+// to it. The service only lets the relay act for the devices of its site.
+//
+// The relay caches the rendered specs of the devices, which it serves while
+// the service cannot be reached, and sends the statuses of the devices to the
+// service in batches. It forwards the console sessions of the devices to the
+// gRPC endpoint of the service, as long as they are encrypted end to end.
+type Relay struct {
+	log        logrus.FieldLogger
+	cfg        *Config
+	upstream   *url.URL
+	httpClient *http.Client
+	client     *agentclient.ClientWithResponses
+	proxy      *httputil.ReverseProxy
+	specs      *specCache
+	statuses   *statusQueue
+	// refusedConsoles holds the last console session refused for each device
+	refusedConsoles sync.Map
+}
+
+// New returns a relay connecting to the service as configured.
+func New(cfg *Config, log logrus.FieldLogger) (*Relay, error) {
+	httpClient, err := client.NewHTTPClientFromConfig(&cfg.ManagementService)
+	if err != nil {
+		return nil, err
+	}
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected transport %T of the management service client", httpClient.Transport)
+	}
+	// the requests of all the devices share one connection to the service
+	transport.ForceAttemptHTTP2 = true
+	transport.MaxConnsPerHost = 1
+	transport.Proxy = http.ProxyFromEnvironment
+	return newRelay(cfg, httpClient, log)
+}
+
+func newRelay(cfg *Config, httpClient *http.Client, log logrus.FieldLogger) (*Relay, error) {
+	upstream, err := url.Parse(cfg.ManagementService.Service.Server)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the management service server: %w", err)
+	}
+	c, err := agentclient.NewClientWithResponses(upstream.String(), agentclient.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	r := &Relay{
+		log:        log,
+		cfg:        cfg,
+		upstream:   upstream,
+		httpClient: httpClient,
+		client:     c,
+		specs:      newSpecCache(),
+		statuses:   newStatusQueue(cfg.StatusBatchSize),
+	}
+	r.proxy = &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(upstream)
+			pr.Out.Header.Set(middleware.ForwardedClientCertHeader, forwardedClientCertificate(pr.In))
+		},
+		Transport: httpClient.Transport,
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			log.Warnf("forwarding %s %s: %v", req.Method, req.URL.Path, err)
+			middleware.WriteError(w, http.StatusBadGateway, v1alpha1.Error{Message: "the relay cannot reach the service"})
+		},
+	}
+	return r, nil
+}
+
+// Handler returns the handler of the requests of the agents.
+func (r *Relay) Handler() http.Handler {
+	router := chi.NewRouter()
+	router.Use(
+		chimiddleware.RequestID,
+		chimiddleware.Logger,
+		chimiddleware.Recoverer,
+	)
+	router.Get("/api/v1/devices/{name}/rendered", r.serveRenderedSpec)
+	router.Put("/api/v1/devices/{name}/status", r.queueStatus)
+	// the other requests, such as enrollment requests, are forwarded as they are
+	router.NotFound(r.proxy.ServeHTTP)
+	router.MethodNotAllowed(r.proxy.ServeHTTP)
+	return router
+}
+
+// Run serves the agents until the context is canceled.
+func (r *Relay) Run(ctx context.Context) error {
+	reloader, err := crypto.NewCertificateReloader(r.cfg.ServerCertificate, r.cfg.ServerKey, r.log)
+	if err != nil {
+		return fmt.Errorf("loading the server certificate: %w", err)
+	}
+	go reloader.Run(ctx, crypto.CertificateReloaderInterval)
+	bundle, err := os.ReadFile(r.cfg.ClientCertificateAuthority)
+	if err != nil {
+		return fmt.Errorf("reading the client CA bundle: %w", err)
+	}
+	caCerts, err := crypto.ParseCertificates(bundle)
+	if err != nil {
+		return fmt.Errorf("parsing the client CA bundle: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	for _, caCert := range caCerts {
+		clientCAs.AddCert(caCert)
+	}
+	tlsConfig := &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		ClientCAs:      clientCAs,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		MinVersion:     tls.VersionTLS13,
+	}
+	if r.cfg.GrpcManagementEndpoint != "" {
+		if err := r.serveConsoles(ctx, tlsConfig); err != nil {
+			return err
+		}
+	}
+	listener, err := middleware.NewTLSListener(r.cfg.ListenAddress, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("creating listener: %w", err)
+	}
+	return r.Serve(ctx, listener)
+}
+
+// Serve serves the agents connecting to the listener until the context is
+// canceled, then sends the statuses it still holds to the service.
+func (r *Relay) Serve(ctx context.Context, listener net.Listener) error {
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		r.sendStatuses(ctx)
+	}()
+	srv := middleware.NewHTTPServer(r.Handler(), r.log, r.cfg.ListenAddress)
+	err := middleware.Serve(ctx, srv, listener, shutdownTimeout, r.log)
+	<-sent
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	r.flushStatuses(flushCtx)
+	return err
+}
+
+// forwardedClientCertificate returns the URL-escaped PEM chain of the client
+// certificate the agent connected with, leaf first.
+func forwardedClientCertificate(req *http.Request) string {
+	return url.QueryEscape(clientCertificateChain(req))
+}
+
+// clientCertificateChain returns the PEM chain of the client certificate the
+// agent connected with, leaf first.
+func clientCertificateChain(req *http.Request) string {
+	if req.TLS == nil {
+		return ""
+	}
+	var chain strings.Builder
+	for _, cert := range req.TLS.PeerCertificates {
+		_ = pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return chain.String()
+}
@@ -0,0 +1,224 @@
+package relay
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	relayedDevice = "0123456789abcdef"
+	// testSignature stands for the signature of a request by the device,
+	// which the service verifies rather than the relay
+	testSignature = "c2lnbmF0dXJl"
+)
+
+// service fakes the agent endpoint of the service.
+type service struct {
+	mu         sync.Mutex
+	statusCode int
+	spec       v1alpha1.RenderedDeviceSpec
+	// requests holds the query, forwarded certificate and signature of each
+	// request
+	requests   []url.Values
+	forwarded  []string
+	signatures []string
+	batches    []v1alpha1.RelayedDeviceStatusBatch
+}
+
+func (s *service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.URL.Query())
+	s.forwarded = append(s.forwarded, r.Header.Get(middleware.ForwardedClientCertHeader))
+	s.signatures = append(s.signatures, r.Header.Get(crypto.DeviceSignatureHeader))
+	if s.statusCode != http.StatusOK {
+		w.WriteHeader(s.statusCode)
+		return
+	}
+	switch r.URL.Path {
+	case "/api/v1/devices/" + relayedDevice + "/rendered":
+		if r.URL.Query().Get("knownRenderedVersion") == s.spec.RenderedVersion && s.spec.Action == nil && s.spec.Console == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.spec)
+	case "/api/v1/relay/devicestatuses":
+		var batch v1alpha1.RelayedDeviceStatusBatch
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		s.batches = append(s.batches, batch)
+		result := v1alpha1.DeviceStatusBatchResult{Updated: int64(len(batch.Devices))}
+		for _, item := range batch.Devices {
+			result.Results = append(result.Results, v1alpha1.DeviceStatusResult{Name: item.Name, Code: http.StatusOK})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	default:
+		w.WriteHeader(http.StatusTeapot)
+	}
+}
+
+type relayTest struct {
+	t       *testing.T
+	service *service
+	relay   *Relay
+	tls     *tls.ConnectionState
+	// unsigned sends the requests without the signature of the device
+	unsigned bool
+}
+
+func newRelayTest(t *testing.T, batchSize int) *relayTest {
+	svc := &service{statusCode: http.StatusOK, spec: v1alpha1.RenderedDeviceSpec{RenderedVersion: "1"}}
+	upstream := httptest.NewServer(svc)
+	t.Cleanup(upstream.Close)
+
+	cfg := &Config{ManagementService: client.Config{Service: client.Service{Server: upstream.URL}}}
+	cfg.StatusBatchSize = batchSize
+	cfg.Complete()
+	r, err := newRelay(cfg, upstream.Client(), logrus.New())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	return &relayTest{t: t, service: svc, relay: r, tls: &tls.ConnectionState{PeerCertificates: ca.Config.Certs}}
+}
+
+func (r *relayTest) do(method string, target string, body any) *httptest.ResponseRecorder {
+	var req *http.Request
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		require.NoError(r.t, err)
+		req = httptest.NewRequest(method, target, strings.NewReader(string(encoded)))
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	req.TLS = r.tls
+	if !r.unsigned {
+		req.Header.Set(crypto.DeviceSignatureHeader, testSignature)
+		req.Header.Set(crypto.DeviceSignatureTimeHeader, "1700000000")
+	}
+	recorder := httptest.NewRecorder()
+	r.relay.Handler().ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestRelayRenderedSpecs(t *testing.T) {
+	require := require.New(t)
+	test := newRelayTest(t, DefaultStatusBatchSize)
+	rendered := "/api/v1/devices/" + relayedDevice + "/rendered?specVersions=v1"
+
+	resp := test.do(http.MethodGet, rendered, nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"renderedVersion":"1"`)
+	chain, err := url.QueryUnescape(test.service.forwarded[0])
+	require.NoError(err)
+	require.Equal(clientCertificateChain(&http.Request{TLS: test.tls}), chain)
+	// the service verifies the signature of the device on the request of the relay
+	require.Equal(testSignature, test.service.signatures[0])
+
+	// the service is asked for the version the relay has, which it does not send again
+	resp = test.do(http.MethodGet, rendered, nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Equal("1", test.service.requests[1].Get("knownRenderedVersion"))
+	require.Equal(http.StatusNoContent, test.do(http.MethodGet, rendered+"&knownRenderedVersion=1", nil).Code)
+
+	// the devices are served the cached spec while the service cannot serve them
+	test.service.statusCode = http.StatusServiceUnavailable
+	resp = test.do(http.MethodGet, rendered, nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"renderedVersion":"1"`)
+
+	// but not the spec of an action, which is served once
+	test.service.statusCode = http.StatusOK
+	test.service.spec = v1alpha1.RenderedDeviceSpec{RenderedVersion: "2", Action: &v1alpha1.DeviceAction{Id: "reboot-1", Action: v1alpha1.DeviceActionReboot}}
+	resp = test.do(http.MethodGet, rendered+"&knownRenderedVersion=1", nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"reboot-1"`)
+	test.service.statusCode = http.StatusServiceUnavailable
+	require.Equal(http.StatusBadGateway, test.do(http.MethodGet, rendered, nil).Code)
+}
+
+func TestRelayRefusesPlaintextConsoles(t *testing.T) {
+	require := require.New(t)
+	test := newRelayTest(t, DefaultStatusBatchSize)
+	rendered := "/api/v1/devices/" + relayedDevice + "/rendered"
+
+	// the relay does not forward sessions it could read
+	test.service.spec = v1alpha1.RenderedDeviceSpec{RenderedVersion: "1", Console: &v1alpha1.DeviceConsole{SessionID: "plaintext", GRPCEndpoint: "grpcs://localhost:7444"}}
+	resp := test.do(http.MethodGet, rendered, nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"renderedVersion":"1"`)
+	require.NotContains(resp.Body.String(), "plaintext")
+
+	// but does those encrypted end to end
+	test.service.spec.Console = &v1alpha1.DeviceConsole{SessionID: "encrypted", GRPCEndpoint: "grpcs://localhost:7444", PublicKey: lo.ToPtr("key")}
+	resp = test.do(http.MethodGet, rendered+"?knownRenderedVersion=1", nil)
+	require.Equal(http.StatusOK, resp.Code)
+	require.Contains(resp.Body.String(), `"encrypted"`)
+}
+
+func TestRelayStatuses(t *testing.T) {
+	require := require.New(t)
+	test := newRelayTest(t, 2)
+	status := func(name string, summary v1alpha1.DeviceSummaryStatusType) *httptest.ResponseRecorder {
+		device := v1alpha1.Device{Status: &v1alpha1.DeviceStatus{Summary: v1alpha1.DeviceSummaryStatus{Status: summary}}}
+		return test.do(http.MethodPut, "/api/v1/devices/"+name+"/status", device)
+	}
+
+	// the agents are answered at once, and a newer status replaces the pending one
+	require.Equal(http.StatusOK, status(relayedDevice, v1alpha1.DeviceSummaryStatusDegraded).Code)
+	require.Equal(http.StatusOK, status(relayedDevice, v1alpha1.DeviceSummaryStatusOnline).Code)
+	require.Equal(http.StatusOK, status("0011223344556677", v1alpha1.DeviceSummaryStatusOnline).Code)
+	require.Equal(http.StatusOK, status("8899aabbccddeeff", v1alpha1.DeviceSummaryStatusOnline).Code)
+	require.Empty(test.service.batches)
+
+	// the statuses which failed to be sent are sent with the next batches
+	test.service.statusCode = http.StatusBadGateway
+	test.relay.flushStatuses(context.Background())
+	require.Empty(test.service.batches)
+	test.service.statusCode = http.StatusOK
+	test.relay.flushStatuses(context.Background())
+	require.Len(test.service.batches, 2)
+	names := lo.Map(append(test.service.batches[0].Devices, test.service.batches[1].Devices...), func(item v1alpha1.RelayedDeviceStatus, _ int) string {
+		return item.Name
+	})
+	require.Equal([]string{relayedDevice, "0011223344556677", "8899aabbccddeeff"}, names)
+
+	// the statuses are sent as the devices signed them
+	first := test.service.batches[0].Devices[0]
+	var device v1alpha1.Device
+	require.NoError(json.Unmarshal(first.Request, &device))
+	require.Equal(v1alpha1.DeviceSummaryStatusOnline, device.Status.Summary.Status)
+	require.Equal(clientCertificateChain(&http.Request{TLS: test.tls}), first.ClientCertificate)
+	require.Equal("signature", string(first.Signature))
+	require.Equal(int64(1700000000), first.SignedAt.Unix())
+
+	// the statuses which the devices did not sign are refused
+	test.unsigned = true
+	require.Equal(http.StatusBadRequest, status(relayedDevice, v1alpha1.DeviceSummaryStatusOnline).Code)
+}
+
+func TestRelayForwardsOtherRequests(t *testing.T) {
+	require := require.New(t)
+	test := newRelayTest(t, DefaultStatusBatchSize)
+
+	resp := test.do(http.MethodGet, "/api/v1/enrollmentrequests/"+relayedDevice, nil)
+	require.Equal(http.StatusTeapot, resp.Code)
+	require.NotEmpty(test.service.forwarded[0])
+}
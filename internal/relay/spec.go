@@ -0,0 +1,182 @@
+package relay
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/go-chi/chi/v5"
+)
+
+// cachedSpec is the latest rendered spec the service served for a device.
+type cachedSpec struct {
+	renderedVersion string
+	body            []byte
+	fetchedAt       time.Time
+}
+
+// specCache keeps the latest rendered spec of each device of the site, by the
+// spec versions its agent supports, which the service converts the spec to.
+type specCache struct {
+	mu    sync.Mutex
+	specs map[string]*cachedSpec
+}
+
+func newSpecCache() *specCache {
+	return &specCache{specs: map[string]*cachedSpec{}}
+}
+
+func specCacheKey(name string, specVersions []string) string {
+	return name + "?" + strings.Join(specVersions, ",")
+}
+
+func (c *specCache) get(key string) *cachedSpec {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.specs[key]
+}
+
+func (c *specCache) put(key string, spec *cachedSpec) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.specs[key] = spec
+}
+
+func (c *specCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.specs, key)
+}
+
+// serveRenderedSpec serves the rendered spec of a device from the cache when
+// the service has no newer version or cannot be reached. The service is asked
+// for the version the relay cached, so that it only sends a spec which
+// changed.
+func (r *Relay) serveRenderedSpec(w http.ResponseWriter, req *http.Request) {
+	name := chi.URLParam(req, "name")
+	query := req.URL.Query()
+	knownRenderedVersion := query.Get("knownRenderedVersion")
+	key := specCacheKey(name, query["specVersions"])
+
+	cached := r.specs.get(key)
+	if cached == nil || time.Since(cached.fetchedAt) >= time.Duration(r.cfg.SpecRefreshInterval) {
+		if cached != nil {
+			query.Set("knownRenderedVersion", cached.renderedVersion)
+		}
+		statusCode, body, err := r.fetchRenderedSpec(req, query)
+		switch {
+		case err != nil || statusCode >= http.StatusInternalServerError:
+			if err == nil {
+				r.log.Warnf("device %s: the service failed to serve the rendered spec: %d %s", name, statusCode, body)
+			} else {
+				r.log.Warnf("device %s: fetching the rendered spec: %v", name, err)
+			}
+			if cached == nil {
+				middleware.WriteError(w, http.StatusBadGateway, v1alpha1.Error{Message: "the relay cannot reach the service"})
+				return
+			}
+			// the devices keep the spec the relay cached until the service is back
+		case statusCode == http.StatusNoContent:
+			if cached == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			cached = &cachedSpec{renderedVersion: cached.renderedVersion, body: cached.body, fetchedAt: time.Now()}
+			r.specs.put(key, cached)
+		case statusCode == http.StatusOK:
+			body = r.refusePlaintextConsole(name, body)
+			cached = r.cacheRenderedSpec(key, body)
+			if cached == nil {
+				writeJSON(w, http.StatusOK, body)
+				return
+			}
+		default:
+			r.specs.remove(key)
+			writeJSON(w, statusCode, body)
+			return
+		}
+	}
+
+	if knownRenderedVersion == cached.renderedVersion {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, cached.body)
+}
+
+// cacheRenderedSpec caches a rendered spec the service served, unless it is
+// only served once, such as one requesting an action of the agent or a
+// console session or a tunnel. It returns the cached spec, or nil if it is
+// not cached.
+func (r *Relay) cacheRenderedSpec(key string, body []byte) *cachedSpec {
+	var spec v1alpha1.RenderedDeviceSpec
+	if err := json.Unmarshal(body, &spec); err != nil {
+		r.specs.remove(key)
+		return nil
+	}
+	if spec.Action != nil || spec.Console != nil || spec.Tunnel != nil || spec.Migration != nil || spec.Quarantine != nil {
+		r.specs.remove(key)
+		return nil
+	}
+	cached := &cachedSpec{renderedVersion: spec.RenderedVersion, body: body, fetchedAt: time.Now()}
+	r.specs.put(key, cached)
+	return cached
+}
+
+// refusePlaintextConsole removes the console session from a rendered spec
+// unless it is encrypted end to end, so that the relay cannot read the
+// sessions it forwards.
+func (r *Relay) refusePlaintextConsole(name string, body []byte) []byte {
+	var spec v1alpha1.RenderedDeviceSpec
+	if err := json.Unmarshal(body, &spec); err != nil || spec.Console == nil || spec.Console.PublicKey != nil {
+		return body
+	}
+	// the service serves the session on each poll until another replaces it
+	if previous, _ := r.refusedConsoles.Swap(name, spec.Console.SessionID); previous != spec.Console.SessionID {
+		r.log.Warnf("device %s: refusing console session %s, which is not encrypted end to end", name, spec.Console.SessionID)
+	}
+	spec.Console = nil
+	refused, err := json.Marshal(spec)
+	if err != nil {
+		return body
+	}
+	return refused
+}
+
+// fetchRenderedSpec requests the rendered spec of the device from the service
+// with the query, on behalf of the agent.
+func (r *Relay) fetchRenderedSpec(req *http.Request, query url.Values) (int, []byte, error) {
+	u := r.upstream.JoinPath(req.URL.Path)
+	u.RawQuery = query.Encode()
+	out, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	out.Header.Set(middleware.ForwardedClientCertHeader, forwardedClientCertificate(req))
+	// the agent signed the path of its request, not the query
+	out.Header.Set(crypto.DeviceSignatureHeader, req.Header.Get(crypto.DeviceSignatureHeader))
+	out.Header.Set(crypto.DeviceSignatureTimeHeader, req.Header.Get(crypto.DeviceSignatureTimeHeader))
+	resp, err := r.httpClient.Do(out)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,187 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/samber/lo"
+)
+
+// maxStatusSize bounds the size of the status of a device.
+const maxStatusSize = 10 * 1024 * 1024
+
+// statusQueue holds the latest status of each device until it is sent to the
+// service: a newer status of a device replaces the one waiting to be sent.
+type statusQueue struct {
+	mu        sync.Mutex
+	batchSize int
+	pending   map[string]v1alpha1.RelayedDeviceStatus
+	// order holds the devices with a pending status, in the order they reported
+	order []string
+	// full is signaled once a batch of statuses is pending
+	full chan struct{}
+}
+
+func newStatusQueue(batchSize int) *statusQueue {
+	return &statusQueue{
+		batchSize: batchSize,
+		pending:   map[string]v1alpha1.RelayedDeviceStatus{},
+		full:      make(chan struct{}, 1),
+	}
+}
+
+func (q *statusQueue) add(status v1alpha1.RelayedDeviceStatus) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	name := status.Name
+	if _, ok := q.pending[name]; !ok {
+		q.order = append(q.order, name)
+	}
+	q.pending[name] = status
+	if len(q.order) >= q.batchSize {
+		select {
+		case q.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// take removes a batch of the statuses which reported first.
+func (q *statusQueue) take() []v1alpha1.RelayedDeviceStatus {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := min(len(q.order), q.batchSize)
+	batch := make([]v1alpha1.RelayedDeviceStatus, 0, n)
+	for _, name := range q.order[:n] {
+		batch = append(batch, q.pending[name])
+		delete(q.pending, name)
+	}
+	q.order = q.order[n:]
+	return batch
+}
+
+// requeue puts back the statuses of a batch which failed to be sent, unless
+// their devices reported newer statuses meanwhile.
+func (q *statusQueue) requeue(batch []v1alpha1.RelayedDeviceStatus) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	requeued := []string{}
+	for _, status := range batch {
+		name := status.Name
+		if _, newer := q.pending[name]; newer {
+			continue
+		}
+		q.pending[name] = status
+		requeued = append(requeued, name)
+	}
+	q.order = append(requeued, q.order...)
+}
+
+// queueStatus accepts the status of a device, which is sent to the service
+// with the next batch. The status is sent as the device signed it, so that
+// the service can verify that the device sent it.
+func (r *Relay) queueStatus(w http.ResponseWriter, req *http.Request) {
+	name := chi.URLParam(req, "name")
+	signature, signedAt, err := middleware.RequestSignature(req.Header)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: fmt.Sprintf("the status is not signed: %v", err)})
+		return
+	}
+	request, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxStatusSize))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: fmt.Sprintf("failed to read the status: %v", err)})
+		return
+	}
+	var device v1alpha1.Device
+	if err := json.Unmarshal(request, &device); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: fmt.Sprintf("failed to decode the status: %v", err)})
+		return
+	}
+	if device.Status == nil {
+		middleware.WriteError(w, http.StatusBadRequest, v1alpha1.Error{Message: "the device has no status"})
+		return
+	}
+	// the service checks that the client certificate is the one of the device
+	// and that the device signed the status
+	r.statuses.add(v1alpha1.RelayedDeviceStatus{
+		Name:              name,
+		ClientCertificate: clientCertificateChain(req),
+		Request:           request,
+		Signature:         signature,
+		SignedAt:          signedAt,
+	})
+
+	device.Metadata.Name = lo.ToPtr(name)
+	body, err := json.Marshal(device)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, v1alpha1.Error{Message: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// sendStatuses sends the pending statuses to the service every batch
+// interval, or once a batch of them is pending, until the context is
+// canceled.
+func (r *Relay) sendStatuses(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(r.cfg.StatusBatchInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.statuses.full:
+		}
+		r.flushStatuses(ctx)
+	}
+}
+
+// flushStatuses sends the pending statuses to the service in batches. The
+// statuses of a batch which failed to be sent are sent again with the next
+// flush.
+func (r *Relay) flushStatuses(ctx context.Context) {
+	for {
+		batch := r.statuses.take()
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.sendStatusBatch(ctx, batch); err != nil {
+			r.log.Warnf("sending the statuses of %d devices: %v", len(batch), err)
+			r.statuses.requeue(batch)
+			return
+		}
+	}
+}
+
+// sendStatusBatch sends a batch of statuses, and fails if it should be sent
+// again.
+func (r *Relay) sendStatusBatch(ctx context.Context, batch []v1alpha1.RelayedDeviceStatus) error {
+	resp, err := r.client.ReplaceRelayedDeviceStatusesWithResponse(ctx, v1alpha1.RelayedDeviceStatusBatch{Devices: batch})
+	if err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		if resp.StatusCode() >= http.StatusInternalServerError || resp.StatusCode() == http.StatusTooManyRequests {
+			return fmt.Errorf("the service responded with %d: %s", resp.StatusCode(), resp.Body)
+		}
+		// the service refuses the batch as a whole, which sending it again would not change
+		r.log.Errorf("the service refused the statuses of %d devices with %d: %s", len(batch), resp.StatusCode(), resp.Body)
+		return nil
+	}
+	for _, result := range resp.JSON200.Results {
+		if result.Code != http.StatusOK {
+			r.log.Warnf("device %s: the service refused the status with %d: %s", result.Name, result.Code, lo.FromPtr(result.Message))
+		}
+	}
+	r.log.Debugf("sent the statuses of %d devices, %d were replaced", len(batch), resp.JSON200.Updated)
+	return nil
+}
@@ -0,0 +1,100 @@
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DeviceLabel is the label identifying the device a forwarded series was scraped on.
+	DeviceLabel = "flightctl_device"
+	// MaxPayloadLength is the maximum size of a compressed payload accepted from a device.
+	MaxPayloadLength = 8 * 1024 * 1024
+
+	forwardTimeout = 30 * time.Second
+)
+
+// Forwarder relays remote-write payloads pushed by devices to an upstream receiver.
+type Forwarder struct {
+	url        string
+	httpClient *http.Client
+	log        logrus.FieldLogger
+}
+
+// NewForwarder creates a forwarder to the remote-write receiver at url.
+func NewForwarder(url string, log logrus.FieldLogger) *Forwarder {
+	return &Forwarder{
+		url:        url,
+		httpClient: &http.Client{Timeout: forwardTimeout},
+		log:        log,
+	}
+}
+
+// Forward labels every series of the payload with the name of the device that
+// pushed it, overriding any value the device set itself, and sends the result upstream.
+func (f *Forwarder) Forward(ctx context.Context, deviceName string, body io.Reader) error {
+	payload, err := io.ReadAll(io.LimitReader(body, MaxPayloadLength+1))
+	if err != nil {
+		return fmt.Errorf("reading payload: %w", err)
+	}
+	if len(payload) > MaxPayloadLength {
+		return fmt.Errorf("%w: payload exceeds %d bytes", ErrInvalidPayload, MaxPayloadLength)
+	}
+
+	req, err := Decode(payload)
+	if err != nil {
+		return err
+	}
+	for i := range req.Timeseries {
+		SetLabel(&req.Timeseries[i], DeviceLabel, deviceName)
+	}
+	payload, err = Encode(req)
+	if err != nil {
+		return err
+	}
+	if err := f.send(ctx, payload); err != nil {
+		return err
+	}
+	f.log.Debugf("forwarded %d series of device %s", len(req.Timeseries), deviceName)
+	return nil
+}
+
+// Write sends series produced by the service itself upstream.
+func (f *Forwarder) Write(ctx context.Context, req *WriteRequest) error {
+	payload, err := Encode(req)
+	if err != nil {
+		return err
+	}
+	return f.send(ctx, payload)
+}
+
+func (f *Forwarder) send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	SetHeaders(req.Header)
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("remote-write receiver returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SetHeaders sets the headers required by remote-write receivers.
+func SetHeaders(header http.Header) {
+	header.Set("Content-Type", ContentType)
+	header.Set("Content-Encoding", ContentEncoding)
+	header.Set("X-Prometheus-Remote-Write-Version", ProtocolVersion)
+}
@@ -0,0 +1,81 @@
+// Package remotewrite implements the Prometheus remote-write protocol used to
+// forward metrics scraped on devices: write requests protobuf-encoded with
+// the prompb types of Prometheus and snappy-compressed.
+package remotewrite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+const (
+	// ContentType is the content type of remote-write payloads.
+	ContentType = "application/x-protobuf"
+	// ContentEncoding is the content encoding of remote-write payloads.
+	ContentEncoding = "snappy"
+	// ProtocolVersion is the remote-write protocol version implemented by this package.
+	ProtocolVersion = "0.1.0"
+
+	// MaxDecodedLength is the maximum size of a decompressed payload.
+	MaxDecodedLength = 32 * 1024 * 1024
+)
+
+var (
+	ErrInvalidPayload = errors.New("invalid remote-write payload")
+	ErrPayloadTooLong = errors.New("decoded payload too long")
+)
+
+type (
+	WriteRequest = prompb.WriteRequest
+	TimeSeries   = prompb.TimeSeries
+	Label        = prompb.Label
+	// Sample holds a value and its timestamp in milliseconds since the epoch.
+	Sample = prompb.Sample
+)
+
+// SetLabel sets the named label of the series, replacing any existing value,
+// and keeps the labels sorted by name.
+func SetLabel(ts *TimeSeries, name string, value string) {
+	for i := range ts.Labels {
+		if ts.Labels[i].Name == name {
+			ts.Labels[i].Value = value
+			return
+		}
+	}
+	ts.Labels = append(ts.Labels, Label{Name: name, Value: value})
+	sort.Slice(ts.Labels, func(i, j int) bool { return ts.Labels[i].Name < ts.Labels[j].Name })
+}
+
+// Encode marshals and compresses the request for sending.
+func Encode(req *WriteRequest) ([]byte, error) {
+	raw, err := req.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("marshalling write request: %w", err)
+	}
+	return snappy.Encode(nil, raw), nil
+}
+
+// Decode decompresses and unmarshals a received payload, refusing payloads
+// that would decompress to more than MaxDecodedLength bytes.
+func Decode(data []byte) (*WriteRequest, error) {
+	length, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	if length > MaxDecodedLength {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, ErrPayloadTooLong)
+	}
+	raw, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	req := &WriteRequest{}
+	if err := req.Unmarshal(raw); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+	}
+	return req, nil
+}
@@ -0,0 +1,114 @@
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func testWriteRequest() *WriteRequest {
+	return &WriteRequest{
+		Timeseries: []TimeSeries{
+			{
+				Labels:  []Label{{Name: "__name__", Value: "node_load1"}, {Name: "instance", Value: "localhost:9100"}},
+				Samples: []Sample{{Value: 0.42, Timestamp: 1700000000000}},
+			},
+			{
+				Labels:  []Label{{Name: "__name__", Value: "up"}, {Name: DeviceLabel, Value: "spoofed"}},
+				Samples: []Sample{{Value: 1, Timestamp: 1700000000000}, {Value: -3.5, Timestamp: -1}},
+			},
+		},
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	require := require.New(t)
+
+	req := testWriteRequest()
+	payload, err := Encode(req)
+	require.NoError(err)
+	decoded, err := Decode(payload)
+	require.NoError(err)
+	require.Equal(req, decoded)
+
+	_, err = Decode([]byte("not snappy"))
+	require.ErrorIs(err, ErrInvalidPayload)
+
+	// the decoded length is checked before decompressing
+	tooLong := snappy.Encode(nil, bytes.Repeat([]byte{0}, MaxDecodedLength+1))
+	_, err = Decode(tooLong)
+	require.ErrorIs(err, ErrInvalidPayload)
+	require.ErrorIs(err, ErrPayloadTooLong)
+}
+
+func TestSetLabel(t *testing.T) {
+	series := TimeSeries{Labels: []Label{{Name: "instance", Value: "localhost:9100"}}}
+	SetLabel(&series, "__name__", "up")
+	SetLabel(&series, "instance", "localhost:9200")
+	require.Equal(t, []Label{{Name: "__name__", Value: "up"}, {Name: "instance", Value: "localhost:9200"}}, series.Labels)
+}
+
+func FuzzDecode(f *testing.F) {
+	payload, err := Encode(testWriteRequest())
+	require.NoError(f, err)
+	f.Add(payload)
+	f.Add([]byte("not snappy"))
+	f.Add(snappy.Encode(nil, []byte("not protobuf")))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req, err := Decode(data)
+		if err != nil {
+			require.ErrorIs(t, err, ErrInvalidPayload)
+			return
+		}
+		// whatever decodes is forwarded, so it has to encode again
+		payload, err := Encode(req)
+		require.NoError(t, err)
+		_, err = Decode(payload)
+		require.NoError(t, err)
+	})
+}
+
+func TestForward(t *testing.T) {
+	require := require.New(t)
+
+	var received *WriteRequest
+	var header http.Header
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		received, err = Decode(body)
+		require.NoError(err)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer upstream.Close()
+
+	forwarder := NewForwarder(upstream.URL, logrus.New())
+	payload, err := Encode(testWriteRequest())
+	require.NoError(err)
+	err = forwarder.Forward(context.Background(), "mydevice", bytes.NewReader(payload))
+	require.NoError(err)
+
+	require.Equal(ContentType, header.Get("Content-Type"))
+	require.Equal(ContentEncoding, header.Get("Content-Encoding"))
+	require.Len(received.Timeseries, 2)
+	require.Equal([]Label{
+		{Name: "__name__", Value: "node_load1"},
+		{Name: DeviceLabel, Value: "mydevice"},
+		{Name: "instance", Value: "localhost:9100"},
+	}, received.Timeseries[0].Labels)
+	// the device cannot pick the device label
+	require.Equal([]Label{{Name: "__name__", Value: "up"}, {Name: DeviceLabel, Value: "mydevice"}}, received.Timeseries[1].Labels)
+
+	err = forwarder.Forward(context.Background(), "mydevice", strings.NewReader("garbage"))
+	require.ErrorIs(err, ErrInvalidPayload)
+}
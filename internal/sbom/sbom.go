@@ -0,0 +1,151 @@
+package sbom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/samber/lo"
+)
+
+// The artifact types of the SBOMs attached to images.
+const (
+	SPDXArtifactType      = "application/spdx+json"
+	CycloneDXArtifactType = "application/vnd.cyclonedx+json"
+)
+
+// Document is the content of an SBOM that the service keeps.
+type Document struct {
+	Format          api.SbomFormat
+	Components      []api.SbomComponent
+	Vulnerabilities []string
+}
+
+// Registry is the part of the registry client the SBOMs of images are fetched
+// with.
+type Registry interface {
+	Referrers(ctx context.Context, ref registry.Reference, artifactType string) ([]registry.Descriptor, error)
+	Manifest(ctx context.Context, ref registry.Reference) (*registry.Manifest, error)
+	Blob(ctx context.Context, ref registry.Reference, digest string) ([]byte, error)
+}
+
+// Fetch returns the SBOM the registry attaches to the image pinned by digest,
+// or nil if it attaches none. A CycloneDX SBOM is preferred over an SPDX one,
+// since it can also report the vulnerabilities of the image.
+func Fetch(ctx context.Context, reg Registry, ref registry.Reference) (*Document, error) {
+	referrers, err := reg.Referrers(ctx, ref, "")
+	if err != nil {
+		return nil, err
+	}
+	sbom, ok := lo.Find(referrers, func(d registry.Descriptor) bool { return d.ArtifactType == CycloneDXArtifactType })
+	if !ok {
+		sbom, ok = lo.Find(referrers, func(d registry.Descriptor) bool { return d.ArtifactType == SPDXArtifactType })
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	manifest, err := reg.Manifest(ctx, registry.Reference{Registry: ref.Registry, Repository: ref.Repository, Digest: sbom.Digest})
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("SBOM %s of image %s has no content", sbom.Digest, ref)
+	}
+	data, err := reg.Blob(ctx, ref, manifest.Layers[0].Digest)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(sbom.ArtifactType, data)
+}
+
+// Parse parses an SBOM in the SPDX or CycloneDX JSON format.
+func Parse(artifactType string, data []byte) (*Document, error) {
+	switch artifactType {
+	case SPDXArtifactType:
+		return parseSPDX(data)
+	case CycloneDXArtifactType:
+		return parseCycloneDX(data)
+	default:
+		return nil, fmt.Errorf("unsupported SBOM type %q", artifactType)
+	}
+}
+
+type spdxDocument struct {
+	Packages []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var spdx spdxDocument
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, fmt.Errorf("invalid SPDX document: %w", err)
+	}
+	document := &Document{Format: api.SbomFormatSPDX, Components: []api.SbomComponent{}}
+	for _, pkg := range spdx.Packages {
+		component := api.SbomComponent{Name: pkg.Name}
+		if pkg.VersionInfo != "" {
+			component.Version = lo.ToPtr(pkg.VersionInfo)
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				component.Purl = lo.ToPtr(ref.ReferenceLocator)
+				break
+			}
+		}
+		document.Components = append(document.Components, component)
+	}
+	return document, nil
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	Purl       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXDocument struct {
+	Components      []cycloneDXComponent `json:"components"`
+	Vulnerabilities []struct {
+		ID string `json:"id"`
+	} `json:"vulnerabilities"`
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var cyclonedx cycloneDXDocument
+	if err := json.Unmarshal(data, &cyclonedx); err != nil {
+		return nil, fmt.Errorf("invalid CycloneDX document: %w", err)
+	}
+	document := &Document{Format: api.SbomFormatCycloneDX, Components: []api.SbomComponent{}}
+	var addComponents func(components []cycloneDXComponent)
+	addComponents = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			component := api.SbomComponent{Name: c.Name}
+			if c.Version != "" {
+				component.Version = lo.ToPtr(c.Version)
+			}
+			if c.Purl != "" {
+				component.Purl = lo.ToPtr(c.Purl)
+			}
+			document.Components = append(document.Components, component)
+			addComponents(c.Components)
+		}
+	}
+	addComponents(cyclonedx.Components)
+	for _, vulnerability := range cyclonedx.Vulnerabilities {
+		if vulnerability.ID != "" && !slices.Contains(document.Vulnerabilities, vulnerability.ID) {
+			document.Vulnerabilities = append(document.Vulnerabilities, vulnerability.ID)
+		}
+	}
+	return document, nil
+}
@@ -0,0 +1,113 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+const spdxSbom = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {"name": "openssl-libs", "versionInfo": "3.0.7-27.el9", "externalRefs": [
+      {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:openssl:openssl:3.0.7"},
+      {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:rpm/redhat/openssl-libs@3.0.7-27.el9"}
+    ]},
+    {"name": "kiosk-os"}
+  ]
+}`
+
+const cycloneDXSbom = `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    {"name": "xz-libs", "version": "5.6.0", "purl": "pkg:rpm/fedora/xz-libs@5.6.0", "components": [
+      {"name": "liblzma", "version": "5.6.0"}
+    ]}
+  ],
+  "vulnerabilities": [{"id": "CVE-2024-3094"}, {"id": "CVE-2024-3094"}]
+}`
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	document, err := Parse(SPDXArtifactType, []byte(spdxSbom))
+	require.NoError(err)
+	require.Equal(&Document{
+		Format: api.SbomFormatSPDX,
+		Components: []api.SbomComponent{
+			{Name: "openssl-libs", Version: lo.ToPtr("3.0.7-27.el9"), Purl: lo.ToPtr("pkg:rpm/redhat/openssl-libs@3.0.7-27.el9")},
+			{Name: "kiosk-os"},
+		},
+	}, document)
+
+	document, err = Parse(CycloneDXArtifactType, []byte(cycloneDXSbom))
+	require.NoError(err)
+	require.Equal(&Document{
+		Format: api.SbomFormatCycloneDX,
+		Components: []api.SbomComponent{
+			{Name: "xz-libs", Version: lo.ToPtr("5.6.0"), Purl: lo.ToPtr("pkg:rpm/fedora/xz-libs@5.6.0")},
+			{Name: "liblzma", Version: lo.ToPtr("5.6.0")},
+		},
+		Vulnerabilities: []string{"CVE-2024-3094"},
+	}, document)
+
+	_, err = Parse(SPDXArtifactType, []byte("not json"))
+	require.Error(err)
+	_, err = Parse("text/plain", []byte(spdxSbom))
+	require.Error(err)
+}
+
+// artifacts is a registry of a single repository serving SBOM artifacts.
+type artifacts struct {
+	referrers []registry.Descriptor
+	blobs     map[string]string
+}
+
+func (a *artifacts) Referrers(ctx context.Context, ref registry.Reference, artifactType string) ([]registry.Descriptor, error) {
+	return a.referrers, nil
+}
+
+func (a *artifacts) Manifest(ctx context.Context, ref registry.Reference) (*registry.Manifest, error) {
+	return &registry.Manifest{Layers: []registry.Descriptor{{Digest: "blob-" + ref.Digest}}}, nil
+}
+
+func (a *artifacts) Blob(ctx context.Context, ref registry.Reference, digest string) ([]byte, error) {
+	blob, ok := a.blobs[digest]
+	if !ok {
+		return nil, fmt.Errorf("no blob %s", digest)
+	}
+	return []byte(blob), nil
+}
+
+func TestFetch(t *testing.T) {
+	require := require.New(t)
+	ref := registry.Reference{Registry: "quay.io", Repository: "org/app", Digest: "sha256:abc"}
+	reg := &artifacts{
+		referrers: []registry.Descriptor{
+			{ArtifactType: SPDXArtifactType, Digest: "spdx"},
+			{ArtifactType: "application/vnd.dev.cosign.artifact.sig.v1+json", Digest: "signature"},
+			{ArtifactType: CycloneDXArtifactType, Digest: "cyclonedx"},
+		},
+		blobs: map[string]string{"blob-spdx": spdxSbom, "blob-cyclonedx": cycloneDXSbom},
+	}
+
+	// CycloneDX is preferred since it reports vulnerabilities
+	document, err := Fetch(context.Background(), reg, ref)
+	require.NoError(err)
+	require.Equal(api.SbomFormatCycloneDX, document.Format)
+
+	reg.referrers = reg.referrers[:2]
+	document, err = Fetch(context.Background(), reg, ref)
+	require.NoError(err)
+	require.Equal(api.SbomFormatSPDX, document.Format)
+
+	reg.referrers = reg.referrers[1:]
+	document, err = Fetch(context.Background(), reg, ref)
+	require.NoError(err)
+	require.Nil(document)
+}
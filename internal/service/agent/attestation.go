@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+)
+
+const (
+	// attestationChallengeValidity is how long a device has to quote its
+	// measurements with the nonce of a challenge.
+	attestationChallengeValidity = 5 * time.Minute
+	attestationNonceSize         = 32
+)
+
+// (POST /api/v1/devices/{name}/attestation/challenge)
+func (s *AgentServiceHandler) CreateDeviceAttestationChallenge(ctx context.Context, request agentServer.CreateDeviceAttestationChallengeRequestObject) (agentServer.CreateDeviceAttestationChallengeResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.CreateDeviceAttestationChallenge401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+	orgId := store.NullOrgId
+
+	if _, err := s.store.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return agentServer.CreateDeviceAttestationChallenge404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	nonce := make([]byte, attestationNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(attestationChallengeValidity).UTC()
+	if err := s.store.DeviceAttestation().SetChallenge(ctx, orgId, request.Name, nonce, expiresAt); err != nil {
+		return nil, err
+	}
+	return agentServer.CreateDeviceAttestationChallenge200JSONResponse{
+		Nonce:          base64.StdEncoding.EncodeToString(nonce),
+		ExpirationTime: expiresAt,
+	}, nil
+}
+
+// (POST /api/v1/devices/{name}/attestation)
+func (s *AgentServiceHandler) ReportDeviceAttestation(ctx context.Context, request agentServer.ReportDeviceAttestationRequestObject) (agentServer.ReportDeviceAttestationResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.ReportDeviceAttestation401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+	orgId := store.NullOrgId
+
+	device, err := s.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return agentServer.ReportDeviceAttestation404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+
+	report := request.Body
+	nonce, err1 := base64.StdEncoding.DecodeString(report.Nonce)
+	akPublic, err2 := base64.StdEncoding.DecodeString(report.AttestationKey)
+	quote, err3 := base64.StdEncoding.DecodeString(report.Quote)
+	signature, err4 := base64.StdEncoding.DecodeString(report.Signature)
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return agentServer.ReportDeviceAttestation400JSONResponse{Message: fmt.Sprintf("attestation report must be base64 encoded: %v", err)}, nil
+	}
+
+	state, err := s.store.DeviceAttestation().Get(ctx, orgId, request.Name)
+	if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+		return nil, err
+	}
+	if state == nil || !bytes.Equal(state.Nonce, nonce) || state.NonceExpiresAt == nil || time.Now().After(*state.NonceExpiresAt) {
+		return agentServer.ReportDeviceAttestation409JSONResponse{Message: "the challenge of the attestation expired or was superseded"}, nil
+	}
+
+	condition := s.verifyAttestation(device, state, akPublic, quote, signature, nonce, report)
+	if err := s.store.DeviceAttestation().Update(ctx, orgId, state); err != nil {
+		if errors.Is(err, flterrors.ErrNoRowsUpdated) {
+			return agentServer.ReportDeviceAttestation409JSONResponse{Message: "the challenge of the attestation expired or was superseded"}, nil
+		}
+		return nil, err
+	}
+
+	existing := v1alpha1.FindStatusCondition(lo.FromPtr(device.Status).Conditions, v1alpha1.DeviceIntegrityVerified)
+	if existing == nil || existing.Status != condition.Status || existing.Reason != condition.Reason || existing.Message != condition.Message {
+		if err := s.store.Device().SetServiceConditions(ctx, orgId, request.Name, []v1alpha1.Condition{condition}); err != nil {
+			return nil, err
+		}
+	}
+	if condition.Status != v1alpha1.ConditionStatusTrue {
+		s.log.Warnf("attestation of device %s failed: %s", request.Name, condition.Message)
+	}
+	return agentServer.ReportDeviceAttestation204Response{}, nil
+}
+
+// verifyAttestation verifies the quote against the attestation key and the
+// reference measurements of the booted image, updates the attestation state
+// accordingly and returns the resulting IntegrityVerified condition.
+//
+// The device is trusted on its first attestation: it binds the attestation
+// key, and the first quote of each image the device is expected to boot
+// becomes the reference for the later quotes of that image.
+func (s *AgentServiceHandler) verifyAttestation(device *v1alpha1.Device, state *model.DeviceAttestation, akPublic, quote, signature, nonce []byte, report *v1alpha1.AttestationReport) v1alpha1.Condition {
+	invalid := func(message string) v1alpha1.Condition {
+		return v1alpha1.Condition{
+			Type:    v1alpha1.DeviceIntegrityVerified,
+			Status:  v1alpha1.ConditionStatusFalse,
+			Reason:  "InvalidQuote",
+			Message: message,
+		}
+	}
+	if state.AttestationKey != nil && !bytes.Equal(state.AttestationKey, akPublic) {
+		return invalid("The quote is signed by another attestation key than the one the device attested with before")
+	}
+	if err := attestation.VerifyQuote(akPublic, quote, signature, nonce, report.Pcrs); err != nil {
+		return invalid(fmt.Sprintf("The quote failed verification: %v", err))
+	}
+
+	now := time.Now().UTC()
+	state.LastAttestedAt = &now
+	image := lo.FromPtr(report.BootedImage)
+	previousImage := state.BootedImage
+	// only verified attestations bind the key and the booted image
+	verified := func(message string) v1alpha1.Condition {
+		state.AttestationKey = akPublic
+		state.BootedImage = image
+		return v1alpha1.Condition{
+			Type:    v1alpha1.DeviceIntegrityVerified,
+			Status:  v1alpha1.ConditionStatusTrue,
+			Reason:  "Verified",
+			Message: message,
+		}
+	}
+
+	references := map[string]map[string]string{}
+	if state.References != nil && state.References.Data != nil {
+		references = state.References.Data
+	}
+	if reference, ok := references[image]; ok {
+		if drifted := attestation.DriftedPCRs(reference, report.Pcrs); len(drifted) > 0 {
+			return v1alpha1.Condition{
+				Type:    v1alpha1.DeviceIntegrityVerified,
+				Status:  v1alpha1.ConditionStatusFalse,
+				Reason:  "MeasurementsDrifted",
+				Message: fmt.Sprintf("The device may have been tampered with: PCRs %s differ from the measurements of image %q", joinPCRs(drifted), image),
+			}
+		}
+		return verified(fmt.Sprintf("The measurements match those of image %q", image))
+	}
+
+	// while updating, the device still boots the previous image
+	expectedImage := ""
+	if device.Spec != nil && device.Spec.Os != nil {
+		expectedImage = device.Spec.Os.Image
+	}
+	if expectedImage != "" && image != expectedImage && image != previousImage {
+		return v1alpha1.Condition{
+			Type:    v1alpha1.DeviceIntegrityVerified,
+			Status:  v1alpha1.ConditionStatusFalse,
+			Reason:  "UnexpectedImage",
+			Message: fmt.Sprintf("The device booted image %q instead of image %q", image, expectedImage),
+		}
+	}
+
+	// keep the reference of the previous image, which the device boots again
+	// when rolling back
+	reference := map[string]string{}
+	for _, pcr := range attestation.QuotedPCRs {
+		key := attestation.PCRKey(pcr)
+		reference[key] = report.Pcrs[key]
+	}
+	updated := map[string]map[string]string{image: reference}
+	if previous, ok := references[previousImage]; ok {
+		updated[previousImage] = previous
+	}
+	state.References = model.MakeJSONField(updated)
+	return verified(fmt.Sprintf("Recorded the measurements of image %q as reference", image))
+}
+
+func joinPCRs(pcrs []int) string {
+	return strings.Join(lo.Map(pcrs, func(pcr int, _ int) string { return fmt.Sprint(pcr) }), ", ")
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const attestedDevice = "0123456789abcdef"
+
+type attestationStore struct {
+	store.Store
+	devices      attestationDeviceStore
+	attestations deviceAttestationStore
+}
+
+func (s *attestationStore) Device() store.Device                       { return &s.devices }
+func (s *attestationStore) DeviceAttestation() store.DeviceAttestation { return &s.attestations }
+
+type attestationDeviceStore struct {
+	store.Device
+	device     v1alpha1.Device
+	conditions []v1alpha1.Condition
+}
+
+func (s *attestationDeviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Device, error) {
+	if name != *s.device.Metadata.Name {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	device := s.device
+	device.Status = &v1alpha1.DeviceStatus{Conditions: s.conditions}
+	return &device, nil
+}
+
+func (s *attestationDeviceStore) SetServiceConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []v1alpha1.Condition) error {
+	for _, condition := range conditions {
+		v1alpha1.SetStatusCondition(&s.conditions, condition)
+	}
+	return nil
+}
+
+type deviceAttestationStore struct {
+	store.DeviceAttestation
+	state *model.DeviceAttestation
+}
+
+func (s *deviceAttestationStore) Get(ctx context.Context, orgId uuid.UUID, device string) (*model.DeviceAttestation, error) {
+	if s.state == nil {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	state := *s.state
+	return &state, nil
+}
+
+func (s *deviceAttestationStore) SetChallenge(ctx context.Context, orgId uuid.UUID, device string, nonce []byte, expiresAt time.Time) error {
+	if s.state == nil {
+		s.state = &model.DeviceAttestation{Device: device}
+	}
+	s.state.Nonce = nonce
+	s.state.NonceExpiresAt = &expiresAt
+	return nil
+}
+
+func (s *deviceAttestationStore) Update(ctx context.Context, orgId uuid.UUID, attestation *model.DeviceAttestation) error {
+	if s.state == nil || string(s.state.Nonce) != string(attestation.Nonce) {
+		return flterrors.ErrNoRowsUpdated
+	}
+	updated := *attestation
+	updated.Nonce = nil
+	updated.NonceExpiresAt = nil
+	s.state = &updated
+	return nil
+}
+
+type attestationTest struct {
+	t       *testing.T
+	ctx     context.Context
+	handler *AgentServiceHandler
+	store   *attestationStore
+	tpm     *tpm.TPM
+}
+
+func newAttestationTest(t *testing.T) *attestationTest {
+	simulator, err := tpm.OpenTPMSimulator()
+	require.NoError(t, err)
+	t.Cleanup(simulator.Close)
+
+	name := attestedDevice
+	st := &attestationStore{}
+	st.devices.device = v1alpha1.Device{
+		Metadata: v1alpha1.ObjectMeta{Name: &name},
+		Spec:     &v1alpha1.DeviceSpec{Os: &v1alpha1.DeviceOSSpec{Image: "quay.io/flightctl/os:v1"}},
+	}
+	return &attestationTest{
+		t:       t,
+		ctx:     context.WithValue(context.Background(), middleware.TLSCommonNameContextKey, "device:"+name),
+		handler: NewAgentServiceHandler(st, nil, nil, nil, logrus.New(), "", "", ""),
+		store:   st,
+		tpm:     simulator,
+	}
+}
+
+// attest runs an attestation of the booted image, letting tamper modify the
+// quote before it is reported, and returns the IntegrityVerified condition.
+func (a *attestationTest) attest(image string, tamper func(report *v1alpha1.AttestationReport)) *v1alpha1.Condition {
+	require := require.New(a.t)
+	resp, err := a.handler.CreateDeviceAttestationChallenge(a.ctx, agentServer.CreateDeviceAttestationChallengeRequestObject{Name: attestedDevice})
+	require.NoError(err)
+	challenge, ok := resp.(agentServer.CreateDeviceAttestationChallenge200JSONResponse)
+	require.True(ok)
+
+	nonce, err := base64.StdEncoding.DecodeString(challenge.Nonce)
+	require.NoError(err)
+	quote, err := a.tpm.Quote(attestation.QuotedPCRs, nonce)
+	require.NoError(err)
+	report := v1alpha1.AttestationReport{
+		Nonce:          challenge.Nonce,
+		AttestationKey: base64.StdEncoding.EncodeToString(quote.AttestationKey),
+		Quote:          base64.StdEncoding.EncodeToString(quote.Quote),
+		Signature:      base64.StdEncoding.EncodeToString(quote.Signature),
+		Pcrs:           quote.PCRs,
+		BootedImage:    &image,
+	}
+	if tamper != nil {
+		tamper(&report)
+	}
+
+	reportResp, err := a.handler.ReportDeviceAttestation(a.ctx, agentServer.ReportDeviceAttestationRequestObject{Name: attestedDevice, Body: &report})
+	require.NoError(err)
+	require.IsType(agentServer.ReportDeviceAttestation204Response{}, reportResp)
+	return v1alpha1.FindStatusCondition(a.store.devices.conditions, v1alpha1.DeviceIntegrityVerified)
+}
+
+// extendPCR changes the measurements the way a modified boot chain would.
+func (a *attestationTest) extendPCR(pcr int) {
+	require.NoError(a.t, a.tpm.ExtendPCR(pcr, []byte("tampered")))
+}
+
+func TestReportDeviceAttestation(t *testing.T) {
+	require := require.New(t)
+	a := newAttestationTest(t)
+
+	// the first quote of the expected image becomes the reference
+	condition := a.attest("quay.io/flightctl/os:v1", nil)
+	require.Equal(v1alpha1.ConditionStatusTrue, condition.Status)
+	require.Contains(condition.Message, "as reference")
+	require.NotNil(a.store.attestations.state.AttestationKey)
+
+	condition = a.attest("quay.io/flightctl/os:v1", nil)
+	require.Equal(v1alpha1.ConditionStatusTrue, condition.Status)
+	require.Equal("Verified", condition.Reason)
+
+	a.extendPCR(4)
+	condition = a.attest("quay.io/flightctl/os:v1", nil)
+	require.Equal(v1alpha1.ConditionStatusFalse, condition.Status)
+	require.Equal("MeasurementsDrifted", condition.Reason)
+	require.Contains(condition.Message, "PCRs 4 differ")
+}
+
+func TestReportDeviceAttestationImageUpdate(t *testing.T) {
+	require := require.New(t)
+	a := newAttestationTest(t)
+
+	condition := a.attest("quay.io/flightctl/os:v1", nil)
+	require.Equal(v1alpha1.ConditionStatusTrue, condition.Status)
+
+	// an image the device is not expected to boot does not become a reference
+	condition = a.attest("quay.io/evil/os:v1", nil)
+	require.Equal("UnexpectedImage", condition.Reason)
+	// nor is it accepted as the previously booted image afterwards
+	condition = a.attest("quay.io/evil/os:v1", nil)
+	require.Equal("UnexpectedImage", condition.Reason)
+
+	// once updated, the quote of the new image becomes its reference, while
+	// the reference of the previous image is kept for rollbacks
+	a.store.devices.device.Spec.Os.Image = "quay.io/flightctl/os:v2"
+	a.extendPCR(9)
+	condition = a.attest("quay.io/flightctl/os:v2", nil)
+	require.Equal(v1alpha1.ConditionStatusTrue, condition.Status)
+	require.Len(a.store.attestations.state.References.Data, 2)
+}
+
+func TestReportDeviceAttestationInvalidQuote(t *testing.T) {
+	require := require.New(t)
+	a := newAttestationTest(t)
+
+	condition := a.attest("quay.io/flightctl/os:v1", func(report *v1alpha1.AttestationReport) {
+		report.Pcrs[attestation.PCRKey(0)] = report.Pcrs[attestation.PCRKey(1)] + "00"
+	})
+	require.Equal(v1alpha1.ConditionStatusFalse, condition.Status)
+	require.Equal("InvalidQuote", condition.Reason)
+	// a failed attestation does not bind the attestation key
+	require.Nil(a.store.attestations.state.AttestationKey)
+}
+
+func TestReportDeviceAttestationReplayedNonce(t *testing.T) {
+	require := require.New(t)
+	a := newAttestationTest(t)
+
+	var replayed v1alpha1.AttestationReport
+	a.attest("quay.io/flightctl/os:v1", func(report *v1alpha1.AttestationReport) {
+		replayed = *report
+	})
+
+	resp, err := a.handler.ReportDeviceAttestation(a.ctx, agentServer.ReportDeviceAttestationRequestObject{Name: attestedDevice, Body: &replayed})
+	require.NoError(err)
+	require.IsType(agentServer.ReportDeviceAttestation409JSONResponse{}, resp)
+}
+
+func TestReportDeviceAttestationRequiresDeviceCertificate(t *testing.T) {
+	a := newAttestationTest(t)
+	ctx := context.WithValue(context.Background(), middleware.TLSCommonNameContextKey, "device:fedcba9876543210")
+
+	resp, err := a.handler.CreateDeviceAttestationChallenge(ctx, agentServer.CreateDeviceAttestationChallengeRequestObject{Name: attestedDevice})
+	require.Error(t, err)
+	require.IsType(t, agentServer.CreateDeviceAttestationChallenge401JSONResponse{}, resp)
+}
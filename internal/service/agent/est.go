@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+)
+
+// reenrollCertValidity is the validity of the management certificates renewed
+// through EST, which matches the certificates signed when approving
+// enrollment requests.
+const reenrollCertValidity = 365 * 24 * time.Hour
+
+var (
+	// ErrEstUnauthorized is returned if the client certificate does not permit the EST operation.
+	ErrEstUnauthorized = errors.New("unauthorized")
+	// ErrEstInvalidRequest is returned if the certificate signing request is invalid.
+	ErrEstInvalidRequest = errors.New("invalid certificate signing request")
+	// ErrEstPending is returned while the enrollment request of the device awaits approval.
+	ErrEstPending = errors.New("enrollment request is pending approval")
+)
+
+// EstCACerts returns the certificates of the service's CA and of the external
+// CA signing the client certificates, if any (EST cacerts).
+func (s *AgentServiceHandler) EstCACerts() []*x509.Certificate {
+	return s.ca.ClientCACerts()
+}
+
+// EstSimpleEnroll maps an EST simpleenroll request onto the enrollment request
+// workflow: the first request creates an enrollment request for the device
+// owning the key of the CSR, and once it is approved, the request returns the
+// management certificate signed with the approval.
+func (s *AgentServiceHandler) EstSimpleEnroll(ctx context.Context, csr *x509.CertificateRequest) ([]byte, error) {
+	if err := ValidateEnrollmentAccessFromContext(ctx, s.log); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEstUnauthorized, err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEstInvalidRequest, err)
+	}
+	name, err := crypto.DeviceFingerprint(csr.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEstInvalidRequest, err)
+	}
+	orgId := store.NullOrgId
+
+	enrollmentRequest, err := s.store.EnrollmentRequest().Get(ctx, orgId, name)
+	switch {
+	case err == nil:
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		request := server.CreateEnrollmentRequestRequestObject{
+			Body: &v1alpha1.EnrollmentRequest{
+				ApiVersion: model.EnrollmentRequestAPI,
+				Kind:       model.EnrollmentRequestKind,
+				Metadata:   v1alpha1.ObjectMeta{Name: &name},
+				Spec: v1alpha1.EnrollmentRequestSpec{
+					Csr: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr.Raw})),
+				},
+			},
+		}
+		resp, err := common.CreateEnrollmentRequest(ctx, s.store, request, s.duplicatePolicy)
+		if err != nil {
+			return nil, err
+		}
+		if invalid, ok := resp.(server.CreateEnrollmentRequest400JSONResponse); ok {
+			return nil, fmt.Errorf("%w: %s", ErrEstInvalidRequest, invalid.Message)
+		}
+		s.log.Infof("created enrollment request %s for EST enrollment", name)
+		return nil, ErrEstPending
+	default:
+		return nil, err
+	}
+
+	if enrollmentRequest.Status == nil || enrollmentRequest.Status.Certificate == nil {
+		return nil, ErrEstPending
+	}
+	return []byte(*enrollmentRequest.Status.Certificate), nil
+}
+
+// EstSimpleReenroll renews the management certificate of the device
+// authenticated by its current management certificate (EST simplereenroll).
+// The device may present a new key, but keeps its name.
+func (s *AgentServiceHandler) EstSimpleReenroll(ctx context.Context, csr *x509.CertificateRequest) ([]byte, error) {
+	cn, ok := ctx.Value(middleware.TLSCommonNameContextKey).(string)
+	if !ok || !strings.HasPrefix(cn, crypto.DeviceCommonNamePrefix) {
+		return nil, fmt.Errorf("%w: reenrollment requires the management certificate of a device", ErrEstUnauthorized)
+	}
+	name := strings.TrimPrefix(cn, crypto.DeviceCommonNamePrefix)
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEstInvalidRequest, err)
+	}
+	orgId := store.NullOrgId
+
+	if _, err := s.store.Device().Get(ctx, orgId, name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, fmt.Errorf("%w: device %s does not exist", ErrEstUnauthorized, name)
+		}
+		return nil, err
+	}
+
+	// the service identifies the device by the common name
+	csr.Subject.CommonName = cn
+	certData, err := s.ca.IssueRequestedClientCertificate(csr, int(reenrollCertValidity.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	owner := util.SetResourceOwner(model.DeviceKind, name)
+	if err := common.RecordIssuedCertificate(ctx, s.store, orgId, certData, v1alpha1.IssuedCertificateUsageManagement, owner, &name); err != nil {
+		return nil, err
+	}
+	s.log.Infof("renewed management certificate of device %s through EST", name)
+	return certData, nil
+}
@@ -2,23 +2,34 @@ package service
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"strings"
 
+	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
 	"github.com/flightctl/flightctl/internal/api_server/middleware"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/sirupsen/logrus"
 )
 
 type AgentServiceHandler struct {
 	store             store.Store
+	callbackManager   tasks.CallbackManager
+	metricsForwarder  *remotewrite.Forwarder
 	ca                *crypto.CA
 	log               logrus.FieldLogger
 	agentGrpcEndpoint string
+	agentEndpoint     string
+	uiUrl             string
+	duplicatePolicy   string
+	relayCommonNames  []string
+	clientCAs         *x509.CertPool
 }
 
 // Make sure we conform to servers Service interface
@@ -54,15 +65,25 @@ func ValidateEnrollmentAccessFromContext(ctx context.Context, log logrus.FieldLo
 	return nil
 }
 
-func NewAgentServiceHandler(store store.Store, ca *crypto.CA, log logrus.FieldLogger, agentGrpcEndpoint string) *AgentServiceHandler {
+func NewAgentServiceHandler(store store.Store, callbackManager tasks.CallbackManager, metricsForwarder *remotewrite.Forwarder, ca *crypto.CA, log logrus.FieldLogger, agentGrpcEndpoint string, agentEndpoint string, uiUrl string) *AgentServiceHandler {
 	return &AgentServiceHandler{
 		store:             store,
+		callbackManager:   callbackManager,
+		metricsForwarder:  metricsForwarder,
 		ca:                ca,
 		log:               log,
 		agentGrpcEndpoint: agentGrpcEndpoint,
+		agentEndpoint:     agentEndpoint,
+		uiUrl:             uiUrl,
 	}
 }
 
+// SetDuplicateEnrollmentPolicy sets what happens to the enrollment of a
+// machine already enrolled as another device.
+func (s *AgentServiceHandler) SetDuplicateEnrollmentPolicy(policy string) {
+	s.duplicatePolicy = policy
+}
+
 // (GET /api/v1/devices/{name}/rendered)
 func (s *AgentServiceHandler) GetRenderedDeviceSpec(ctx context.Context, request agentServer.GetRenderedDeviceSpecRequestObject) (agentServer.GetRenderedDeviceSpecResponseObject, error) {
 
@@ -76,7 +97,11 @@ func (s *AgentServiceHandler) GetRenderedDeviceSpec(ctx context.Context, request
 		Name:   request.Name,
 		Params: request.Params,
 	}
-	return common.GetRenderedDeviceSpec(ctx, s.store, serverRequest, s.agentGrpcEndpoint)
+	// agents which predate version negotiation only know the first version
+	if serverRequest.Params.SpecVersions == nil || len(*serverRequest.Params.SpecVersions) == 0 {
+		serverRequest.Params.SpecVersions = &[]string{v1alpha1.RenderedSpecVersion1}
+	}
+	return common.GetRenderedDeviceSpec(ctx, s.store, s.log, serverRequest, s.agentGrpcEndpoint)
 }
 
 // (PUT /api/v1/devices/{name}/status)
@@ -92,7 +117,23 @@ func (s *AgentServiceHandler) ReplaceDeviceStatus(ctx context.Context, request a
 		Name: request.Name,
 		Body: request.Body,
 	}
-	return common.ReplaceDeviceStatus(ctx, s.store, serverRequest)
+	return common.ReplaceDeviceStatus(ctx, s.store, s.log, s.callbackManager.DeviceStatusUpdatedCallback, serverRequest)
+}
+
+// (POST /api/v1/devices/{name}/metrics)
+func (s *AgentServiceHandler) PushDeviceMetrics(ctx context.Context, request agentServer.PushDeviceMetricsRequestObject) (agentServer.PushDeviceMetricsResponseObject, error) {
+
+	if err := ValidateDeviceAccessFromContext(ctx, request.Name, s.log); err != nil {
+		return agentServer.PushDeviceMetrics401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+
+	serverRequest := server.PushDeviceMetricsRequestObject{
+		Name: request.Name,
+		Body: request.Body,
+	}
+	return common.PushDeviceMetrics(ctx, s.metricsForwarder, serverRequest)
 }
 
 // (POST /api/v1/enrollmentrequests)
@@ -107,7 +148,7 @@ func (s *AgentServiceHandler) CreateEnrollmentRequest(ctx context.Context, reque
 	serverRequest := server.CreateEnrollmentRequestRequestObject{
 		Body: request.Body,
 	}
-	return common.CreateEnrollmentRequest(ctx, s.store, serverRequest)
+	return common.CreateEnrollmentRequest(ctx, s.store, serverRequest, s.duplicatePolicy)
 }
 
 // (GET /api/v1/enrollmentrequests/{name})
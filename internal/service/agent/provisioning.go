@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+const (
+	// provisioningCertValidity is how long a provisioned machine can enroll
+	// with the credentials it receives.
+	provisioningCertValidity = 7 * 24 * time.Hour
+	// provisioningChallengeValidity is how long a machine has to recover the
+	// secret of a provisioning challenge with its TPM.
+	provisioningChallengeValidity = 5 * time.Minute
+	provisioningSecretSize        = 32
+)
+
+// provisioningIdentity returns the hardware identity a machine presented, and
+// the TPM endorsement key it presented, if any.
+func provisioningIdentity(request *v1alpha1.ProvisioningRequest) (store.ProvisioningIdentity, []byte, error) {
+	identity := store.ProvisioningIdentity{HardwareIdentity: store.HardwareIdentity{
+		SerialNumber: lo.FromPtr(request.SerialNumber),
+		MacAddresses: lo.FromPtr(request.MacAddresses),
+	}}
+	if lo.FromPtr(request.TpmEndorsementKey) == "" {
+		return identity, nil, nil
+	}
+	ek, err := base64.StdEncoding.DecodeString(*request.TpmEndorsementKey)
+	if err != nil {
+		return identity, nil, fmt.Errorf("tpmEndorsementKey must be base64 encoded: %w", err)
+	}
+	identity.TpmEkHash, err = attestation.EndorsementKeyHash(ek)
+	if err != nil {
+		return identity, nil, err
+	}
+	return identity, ek, nil
+}
+
+// (POST /api/v1/provisioning/challenge)
+func (s *AgentServiceHandler) CreateProvisioningChallenge(ctx context.Context, request agentServer.CreateProvisioningChallengeRequestObject) (agentServer.CreateProvisioningChallengeResponseObject, error) {
+	if err := ValidateEnrollmentAccessFromContext(ctx, s.log); err != nil {
+		return agentServer.CreateProvisioningChallenge401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+	orgId := store.NullOrgId
+
+	identity, ek, err := provisioningIdentity(request.Body)
+	if err != nil {
+		return agentServer.CreateProvisioningChallenge400JSONResponse{Message: err.Error()}, nil
+	}
+	akPublic, err := base64.StdEncoding.DecodeString(lo.FromPtr(request.Body.TpmAttestationKey))
+	if ek == nil || err != nil || len(akPublic) == 0 {
+		return agentServer.CreateProvisioningChallenge400JSONResponse{Message: "a challenge requires the base64 encoded endorsement and attestation keys of the TPM"}, nil
+	}
+
+	deviceIdentity, err := s.store.DeviceIdentity().Match(ctx, orgId, identity)
+	switch {
+	case err == nil:
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		return agentServer.CreateProvisioningChallenge404JSONResponse{Message: "no device identity matches the machine"}, nil
+	case errors.Is(err, flterrors.ErrAmbiguousDeviceIdentity):
+		return agentServer.CreateProvisioningChallenge409JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+	if deviceIdentity.Status != nil && deviceIdentity.Status.ProvisionedAt != nil {
+		return agentServer.CreateProvisioningChallenge409JSONResponse{Message: flterrors.ErrAlreadyProvisioned.Error()}, nil
+	}
+
+	secret := make([]byte, provisioningSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	credential, encryptedSecret, err := attestation.MakeCredential(ek, akPublic, secret)
+	if err != nil {
+		return agentServer.CreateProvisioningChallenge400JSONResponse{Message: err.Error()}, nil
+	}
+	secretHash := sha256.Sum256(secret)
+	expiresAt := time.Now().Add(provisioningChallengeValidity).UTC()
+	if err := s.store.DeviceIdentity().SetProvisioningChallenge(ctx, orgId, *deviceIdentity.Metadata.Name, secretHash[:], expiresAt); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return agentServer.CreateProvisioningChallenge404JSONResponse{Message: "no device identity matches the machine"}, nil
+		}
+		return nil, err
+	}
+	return agentServer.CreateProvisioningChallenge200JSONResponse{
+		Credential:     base64.StdEncoding.EncodeToString(credential),
+		Secret:         base64.StdEncoding.EncodeToString(encryptedSecret),
+		ExpirationTime: expiresAt,
+	}, nil
+}
+
+// (POST /api/v1/provisioning)
+func (s *AgentServiceHandler) ProvisionDevice(ctx context.Context, request agentServer.ProvisionDeviceRequestObject) (agentServer.ProvisionDeviceResponseObject, error) {
+	if err := ValidateEnrollmentAccessFromContext(ctx, s.log); err != nil {
+		return agentServer.ProvisionDevice401JSONResponse{
+			Message: err.Error(),
+		}, err
+	}
+	orgId := store.NullOrgId
+
+	identity, _, err := provisioningIdentity(request.Body)
+	if err != nil {
+		return agentServer.ProvisionDevice400JSONResponse{Message: err.Error()}, nil
+	}
+	secret, err := base64.StdEncoding.DecodeString(lo.FromPtr(request.Body.TpmActivatedCredential))
+	if err != nil {
+		return agentServer.ProvisionDevice400JSONResponse{Message: fmt.Sprintf("tpmActivatedCredential must be base64 encoded: %v", err)}, nil
+	}
+
+	deviceIdentity, err := s.store.DeviceIdentity().Provision(ctx, orgId, identity, secret)
+	switch {
+	case err == nil:
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		return agentServer.ProvisionDevice404JSONResponse{Message: "no device identity matches the machine"}, nil
+	case errors.Is(err, flterrors.ErrAlreadyProvisioned), errors.Is(err, flterrors.ErrAmbiguousDeviceIdentity):
+		return agentServer.ProvisionDevice409JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, flterrors.ErrProvisioningChallengeFailed):
+		s.log.Warnf("refusing to provision a machine which did not prove to hold the TPM endorsement key of its device identity")
+		return agentServer.ProvisionDevice401JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+
+	// like the credentials of golden images, the credentials are only valid
+	// for enrollment
+	cn := crypto.ClientBootstrapCommonNamePrefix + "identity-" + *deviceIdentity.Metadata.Name
+	cert, key, err := s.ca.IssueClientCertificate(cn, int(provisioningCertValidity.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	owner := util.SetResourceOwner(model.DeviceIdentityKind, *deviceIdentity.Metadata.Name)
+	if err := common.RecordIssuedCertificate(ctx, s.store, orgId, cert, v1alpha1.IssuedCertificateUsageEnrollment, owner, nil); err != nil {
+		return nil, err
+	}
+	config, err := common.EnrollmentConfig(s.ca, cert, key, s.agentEndpoint, s.uiUrl, s.agentGrpcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	if deviceIdentity.Spec.Labels != nil && len(*deviceIdentity.Spec.Labels) > 0 {
+		config.DefaultLabels = deviceIdentity.Spec.Labels
+	}
+	s.log.Infof("provisioned machine for device identity %s", *deviceIdentity.Metadata.Name)
+	return agentServer.ProvisionDevice200JSONResponse(*config), nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/tpm"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type provisioningStore struct {
+	store.Store
+	deviceIdentities provisioningDeviceIdentityStore
+}
+
+func (s *provisioningStore) DeviceIdentity() store.DeviceIdentity { return &s.deviceIdentities }
+
+// provisioningDeviceIdentityStore matches every machine with the device
+// identity it holds, or fails with err, and records the challenges set.
+type provisioningDeviceIdentityStore struct {
+	store.DeviceIdentity
+	deviceIdentity *v1alpha1.DeviceIdentity
+	err            error
+	challenge      []byte
+	provisioned    store.ProvisioningIdentity
+}
+
+func (s *provisioningDeviceIdentityStore) Match(ctx context.Context, orgId uuid.UUID, identity store.ProvisioningIdentity) (*v1alpha1.DeviceIdentity, error) {
+	return s.deviceIdentity, s.err
+}
+
+func (s *provisioningDeviceIdentityStore) SetProvisioningChallenge(ctx context.Context, orgId uuid.UUID, name string, secretHash []byte, expiresAt time.Time) error {
+	s.challenge = secretHash
+	return nil
+}
+
+func (s *provisioningDeviceIdentityStore) Provision(ctx context.Context, orgId uuid.UUID, identity store.ProvisioningIdentity, secret []byte) (*v1alpha1.DeviceIdentity, error) {
+	s.provisioned = identity
+	return s.deviceIdentity, s.err
+}
+
+func newProvisioningTest(err error) (*AgentServiceHandler, *provisioningStore) {
+	st := &provisioningStore{deviceIdentities: provisioningDeviceIdentityStore{
+		deviceIdentity: &v1alpha1.DeviceIdentity{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("gateway")}},
+		err:            err,
+	}}
+	return NewAgentServiceHandler(st, nil, nil, nil, logrus.New(), "", "", ""), st
+}
+
+func enrollmentContext() context.Context {
+	return context.WithValue(context.Background(), middleware.TLSCommonNameContextKey, crypto.ClientBootstrapCommonName)
+}
+
+func TestCreateProvisioningChallenge(t *testing.T) {
+	require := require.New(t)
+	tpmSim, err := tpm.OpenTPMSimulator()
+	require.NoError(err)
+	defer tpmSim.Close()
+	ek, err := tpmSim.GetEndorsementKey()
+	require.NoError(err)
+	ak, err := tpmSim.GetAttestationKey()
+	require.NoError(err)
+	ekHash, err := attestation.EndorsementKeyHash(ek)
+	require.NoError(err)
+	request := &v1alpha1.ProvisioningRequest{
+		SerialNumber:      lo.ToPtr("SN-1"),
+		TpmEndorsementKey: lo.ToPtr(base64.StdEncoding.EncodeToString(ek)),
+		TpmAttestationKey: lo.ToPtr(base64.StdEncoding.EncodeToString(ak)),
+	}
+
+	handler, st := newProvisioningTest(nil)
+	resp, err := handler.CreateProvisioningChallenge(enrollmentContext(), agentServer.CreateProvisioningChallengeRequestObject{Body: request})
+	require.NoError(err)
+	challenge, ok := resp.(agentServer.CreateProvisioningChallenge200JSONResponse)
+	require.True(ok, "unexpected response %T", resp)
+
+	// only the TPM holding the endorsement key recovers the secret
+	credential, err := base64.StdEncoding.DecodeString(challenge.Credential)
+	require.NoError(err)
+	encryptedSecret, err := base64.StdEncoding.DecodeString(challenge.Secret)
+	require.NoError(err)
+	secret, err := tpmSim.ActivateCredential(credential, encryptedSecret)
+	require.NoError(err)
+	secretHash := sha256.Sum256(secret)
+	require.Equal(secretHash[:], st.deviceIdentities.challenge)
+
+	// the machine is matched by the hash of the endorsement key it presented
+	request.TpmActivatedCredential = lo.ToPtr(base64.StdEncoding.EncodeToString(secret))
+	st.deviceIdentities.err = flterrors.ErrProvisioningChallengeFailed
+	_, err = handler.ProvisionDevice(enrollmentContext(), agentServer.ProvisionDeviceRequestObject{Body: request})
+	require.NoError(err)
+	require.Equal(ekHash, st.deviceIdentities.provisioned.TpmEkHash)
+
+	// several matching device identities are refused
+	st.deviceIdentities.err = flterrors.ErrAmbiguousDeviceIdentity
+	resp, err = handler.CreateProvisioningChallenge(enrollmentContext(), agentServer.CreateProvisioningChallengeRequestObject{Body: request})
+	require.NoError(err)
+	require.IsType(agentServer.CreateProvisioningChallenge409JSONResponse{}, resp)
+
+	// a challenge requires the attestation key
+	request.TpmAttestationKey = nil
+	resp, err = handler.CreateProvisioningChallenge(enrollmentContext(), agentServer.CreateProvisioningChallengeRequestObject{Body: request})
+	require.NoError(err)
+	require.IsType(agentServer.CreateProvisioningChallenge400JSONResponse{}, resp)
+}
+
+func TestProvisionDeviceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected agentServer.ProvisionDeviceResponseObject
+	}{
+		{name: "no match", err: flterrors.ErrResourceNotFound, expected: agentServer.ProvisionDevice404JSONResponse{}},
+		{name: "several matches", err: flterrors.ErrAmbiguousDeviceIdentity, expected: agentServer.ProvisionDevice409JSONResponse{}},
+		{name: "already provisioned", err: flterrors.ErrAlreadyProvisioned, expected: agentServer.ProvisionDevice409JSONResponse{}},
+		{name: "failed challenge", err: flterrors.ErrProvisioningChallengeFailed, expected: agentServer.ProvisionDevice401JSONResponse{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			handler, _ := newProvisioningTest(tt.err)
+			resp, err := handler.ProvisionDevice(enrollmentContext(), agentServer.ProvisionDeviceRequestObject{Body: &v1alpha1.ProvisioningRequest{SerialNumber: lo.ToPtr("SN-1")}})
+			require.NoError(err)
+			require.IsType(tt.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/samber/lo"
+)
+
+// siteLabel is the label whose value names the site of a device. A relay only
+// acts for the devices labeled with the same site as the device it runs on.
+const siteLabel = "site"
+
+// SetRelays sets the common names of the agent certificates of the devices
+// which relay for the devices of their sites, and the CAs which verify the
+// client certificates of the devices they relay.
+func (s *AgentServiceHandler) SetRelays(relayCommonNames []string, clientCAs *x509.CertPool) {
+	s.relayCommonNames = relayCommonNames
+	s.clientCAs = clientCAs
+}
+
+// (PUT /api/v1/relay/devicestatuses)
+func (s *AgentServiceHandler) ReplaceRelayedDeviceStatuses(ctx context.Context, request agentServer.ReplaceRelayedDeviceStatusesRequestObject) (agentServer.ReplaceRelayedDeviceStatusesResponseObject, error) {
+	cn, ok := ctx.Value(middleware.TLSCommonNameContextKey).(string)
+	if !ok {
+		return agentServer.ReplaceRelayedDeviceStatuses401JSONResponse{Message: "no common name in certificate"}, nil
+	}
+	if !lo.Contains(s.relayCommonNames, cn) {
+		s.log.Warningf("an attempt to relay the statuses of devices with a certificate with CN %q has been detected", cn)
+		return agentServer.ReplaceRelayedDeviceStatuses403JSONResponse{Message: "the certificate is not the one of a trusted relay"}, nil
+	}
+	if len(request.Body.Devices) > common.MaxDeviceStatusBatchSize {
+		return agentServer.ReplaceRelayedDeviceStatuses400JSONResponse{Message: fmt.Sprintf("a batch holds the statuses of at most %d devices, not %d", common.MaxDeviceStatusBatchSize, len(request.Body.Devices))}, nil
+	}
+
+	// a relay only replaces the statuses which the devices of its site signed
+	results := make([]v1alpha1.DeviceStatusResult, len(request.Body.Devices))
+	devices := []v1alpha1.Device{}
+	indexes := []int{}
+	for i, item := range request.Body.Devices {
+		results[i] = v1alpha1.DeviceStatusResult{Name: item.Name, Code: http.StatusOK}
+		device, code, err := s.validateRelayedDeviceStatus(ctx, cn, item)
+		if err != nil {
+			s.log.Warningf("relay %q: refusing the status of device %q: %v", cn, item.Name, err)
+			results[i].Code = code
+			results[i].Message = lo.ToPtr(err.Error())
+			continue
+		}
+		devices = append(devices, *device)
+		indexes = append(indexes, i)
+	}
+
+	serverRequest := server.ReplaceDeviceStatusesRequestObject{
+		Body: &v1alpha1.DeviceStatusBatch{Devices: devices},
+	}
+	resp, err := common.ReplaceDeviceStatuses(ctx, s.store, s.log, s.callbackManager.DeviceStatusUpdatedCallback, serverRequest)
+	if err != nil {
+		return nil, err
+	}
+	switch resp := resp.(type) {
+	case server.ReplaceDeviceStatuses200JSONResponse:
+		for j, result := range resp.Results {
+			results[indexes[j]] = result
+		}
+		return agentServer.ReplaceRelayedDeviceStatuses200JSONResponse{Updated: resp.Updated, Results: results}, nil
+	case server.ReplaceDeviceStatuses400JSONResponse:
+		return agentServer.ReplaceRelayedDeviceStatuses400JSONResponse(resp), nil
+	default:
+		return nil, fmt.Errorf("unexpected response to the batch of statuses: %T", resp)
+	}
+}
+
+// AuthorizeRelayedDevice checks that the relay with the common name may act
+// for the device: the device must be labeled with the same site as the
+// device the relay runs on.
+func (s *AgentServiceHandler) AuthorizeRelayedDevice(ctx context.Context, relayCommonName string, name string) error {
+	relayName, ok := strings.CutPrefix(relayCommonName, crypto.DeviceCommonNamePrefix)
+	if !ok {
+		return fmt.Errorf("the relay %q is not a device", relayCommonName)
+	}
+	orgId := store.NullOrgId
+	relay, err := s.store.Device().Get(ctx, orgId, relayName)
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		return errors.New("the device of the relay does not exist")
+	} else if err != nil {
+		return fmt.Errorf("getting the device of the relay: %w", err)
+	}
+	site := lo.FromPtr(relay.Metadata.Labels)[siteLabel]
+	if site == "" {
+		return fmt.Errorf("the device of the relay has no %q label", siteLabel)
+	}
+	device, err := s.store.Device().Get(ctx, orgId, name)
+	if err != nil {
+		return fmt.Errorf("getting the device: %w", err)
+	}
+	if deviceSite := lo.FromPtr(device.Metadata.Labels)[siteLabel]; deviceSite != site {
+		return fmt.Errorf("the device is at site %q, not at site %q of the relay", deviceSite, site)
+	}
+	return nil
+}
+
+// validateRelayedDeviceStatus checks that the status a relay forwarded for a
+// device is signed by the key of the client certificate of the device, and
+// that the relay may act for the device. It returns the device with the
+// status, or the code of the result refusing it.
+func (s *AgentServiceHandler) validateRelayedDeviceStatus(ctx context.Context, relayCommonName string, item v1alpha1.RelayedDeviceStatus) (*v1alpha1.Device, int32, error) {
+	cert, err := middleware.VerifyClientCertificate(item.ClientCertificate, s.clientCAs)
+	if err != nil {
+		return nil, http.StatusUnauthorized, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	expectedCn, err := crypto.CNFromDeviceFingerprint(item.Name)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if cert.Subject.CommonName != expectedCn {
+		return nil, http.StatusUnauthorized, fmt.Errorf("the client certificate with CN %q is not the one of the device", cert.Subject.CommonName)
+	}
+	if err := crypto.VerifyRequestSignature(cert, http.MethodPut, fmt.Sprintf("/api/v1/devices/%s/status", item.Name), item.SignedAt, item.Request, item.Signature); err != nil {
+		return nil, http.StatusUnauthorized, err
+	}
+	if err := s.AuthorizeRelayedDevice(ctx, relayCommonName, item.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, http.StatusNotFound, err
+		}
+		return nil, http.StatusForbidden, err
+	}
+	var device v1alpha1.Device
+	if err := json.Unmarshal(item.Request, &device); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("failed to decode the status: %w", err)
+	}
+	device.Metadata.Name = lo.ToPtr(item.Name)
+	return &device, http.StatusOK, nil
+}
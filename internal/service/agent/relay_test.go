@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	gocrypto "crypto"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	agentServer "github.com/flightctl/flightctl/internal/api/server/agent"
+	"github.com/flightctl/flightctl/internal/api_server/middleware"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/tasks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	relayDevice   = "fedcba9876543210"
+	siteBRelay    = "fedcba9876543211"
+	relayedDevice = "0123456789abcdef"
+	siteBDevice   = "8899aabbccddeeff"
+	otherDevice   = "0011223344556677"
+)
+
+type relayStore struct {
+	store.Store
+	devices relayDeviceStore
+}
+
+func (s *relayStore) Device() store.Device { return &s.devices }
+
+// relayDeviceStore holds the devices by name, with the site they are at.
+type relayDeviceStore struct {
+	store.Device
+	sites   map[string]string
+	updated []string
+}
+
+func (s *relayDeviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Device, error) {
+	site, ok := s.sites[name]
+	if !ok {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	return &v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr(name), Labels: &map[string]string{"site": site}}}, nil
+}
+
+func (s *relayDeviceStore) UpdateStatuses(ctx context.Context, orgId uuid.UUID, devices []*v1alpha1.Device, callback store.DeviceStoreCallback) ([]error, error) {
+	errs := make([]error, len(devices))
+	for i, device := range devices {
+		if _, ok := s.sites[*device.Metadata.Name]; !ok {
+			errs[i] = flterrors.ErrResourceNotFound
+			continue
+		}
+		s.updated = append(s.updated, *device.Metadata.Name)
+		callback(&model.Device{}, &model.Device{})
+	}
+	return errs, nil
+}
+
+type relayCallbacks struct {
+	tasks.CallbackManager
+}
+
+func (relayCallbacks) DeviceStatusUpdatedCallback(before *model.Device, after *model.Device) {}
+
+type relayedDeviceIdentity struct {
+	cert   string
+	signer gocrypto.Signer
+}
+
+func issueRelayedDeviceIdentity(t *testing.T, ca *crypto.CA, name string) relayedDeviceIdentity {
+	cert, keyPEM, err := ca.IssueClientCertificate("device:"+name, 3600)
+	require.NoError(t, err)
+	key, err := crypto.ParseKeyPEM(keyPEM)
+	require.NoError(t, err)
+	return relayedDeviceIdentity{cert: string(cert), signer: key.(gocrypto.Signer)}
+}
+
+// status returns the status of a device as the relay forwards it, signed by
+// the identity.
+func (id relayedDeviceIdentity) status(t *testing.T, name string) v1alpha1.RelayedDeviceStatus {
+	request, err := json.Marshal(v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr(name)}, Status: &v1alpha1.DeviceStatus{}})
+	require.NoError(t, err)
+	signedAt := time.Now()
+	signature, err := crypto.SignRequest(id.signer, http.MethodPut, "/api/v1/devices/"+name+"/status", signedAt, request)
+	require.NoError(t, err)
+	return v1alpha1.RelayedDeviceStatus{Name: name, ClientCertificate: id.cert, Request: request, Signature: signature, SignedAt: signedAt}
+}
+
+func codes(results []v1alpha1.DeviceStatusResult) []int32 {
+	return lo.Map(results, func(r v1alpha1.DeviceStatusResult, _ int) int32 { return r.Code })
+}
+
+func newRelayTest(t *testing.T) (*AgentServiceHandler, *relayStore, *crypto.CA) {
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	for _, cert := range ca.Config.Certs {
+		clientCAs.AddCert(cert)
+	}
+	st := &relayStore{devices: relayDeviceStore{sites: map[string]string{
+		relayDevice:   "a",
+		relayedDevice: "a",
+		siteBRelay:    "b",
+		siteBDevice:   "b",
+	}}}
+	handler := NewAgentServiceHandler(st, relayCallbacks{}, nil, ca, logrus.New(), "", "", "")
+	handler.SetRelays([]string{"device:" + relayDevice, "device:" + siteBRelay}, clientCAs)
+	return handler, st, ca
+}
+
+func relayContext(relay string) context.Context {
+	return context.WithValue(context.Background(), middleware.TLSCommonNameContextKey, "device:"+relay)
+}
+
+func TestReplaceRelayedDeviceStatuses(t *testing.T) {
+	require := require.New(t)
+	handler, st, ca := newRelayTest(t)
+	relayed := issueRelayedDeviceIdentity(t, ca, relayedDevice)
+	other := issueRelayedDeviceIdentity(t, ca, otherDevice)
+	dir := t.TempDir()
+	untrusted, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "other.crt"), filepath.Join(dir, "other.key"), "", "other", 1)
+	require.NoError(err)
+	forged := issueRelayedDeviceIdentity(t, untrusted, relayedDevice)
+
+	// a status signed by another key than the one of the certificate
+	unsigned := relayed.status(t, relayedDevice)
+	unsigned.Signature = other.status(t, relayedDevice).Signature
+	// a status signed long ago
+	stale := relayed.status(t, relayedDevice)
+	stale.SignedAt = stale.SignedAt.Add(-2 * crypto.MaxDeviceSignatureAge)
+
+	request := agentServer.ReplaceRelayedDeviceStatusesRequestObject{
+		Body: &v1alpha1.RelayedDeviceStatusBatch{Devices: []v1alpha1.RelayedDeviceStatus{
+			relayed.status(t, otherDevice),
+			relayed.status(t, relayedDevice),
+			forged.status(t, relayedDevice),
+			other.status(t, otherDevice),
+			unsigned,
+			stale,
+		}},
+	}
+
+	// only a trusted relay relays statuses
+	resp, err := handler.ReplaceRelayedDeviceStatuses(relayContext(relayedDevice), request)
+	require.NoError(err)
+	require.IsType(agentServer.ReplaceRelayedDeviceStatuses403JSONResponse{}, resp)
+	require.Empty(st.devices.updated)
+
+	resp, err = handler.ReplaceRelayedDeviceStatuses(relayContext(relayDevice), request)
+	require.NoError(err)
+	result, ok := resp.(agentServer.ReplaceRelayedDeviceStatuses200JSONResponse)
+	require.True(ok)
+	require.Equal(int64(1), result.Updated)
+	require.Equal([]int32{http.StatusUnauthorized, http.StatusOK, http.StatusUnauthorized, http.StatusNotFound, http.StatusUnauthorized, http.StatusUnauthorized}, codes(result.Results))
+	require.Equal([]string{relayedDevice}, st.devices.updated)
+}
+
+func TestReplaceRelayedDeviceStatusesOfAnotherSite(t *testing.T) {
+	require := require.New(t)
+	handler, st, ca := newRelayTest(t)
+	siteB := issueRelayedDeviceIdentity(t, ca, siteBDevice)
+	request := agentServer.ReplaceRelayedDeviceStatusesRequestObject{
+		Body: &v1alpha1.RelayedDeviceStatusBatch{Devices: []v1alpha1.RelayedDeviceStatus{siteB.status(t, siteBDevice)}},
+	}
+
+	// the relay of site a may not replace the status of a device of site b,
+	// even with a status the device signed
+	resp, err := handler.ReplaceRelayedDeviceStatuses(relayContext(relayDevice), request)
+	require.NoError(err)
+	result, ok := resp.(agentServer.ReplaceRelayedDeviceStatuses200JSONResponse)
+	require.True(ok)
+	require.Equal(int64(0), result.Updated)
+	require.Equal([]int32{http.StatusForbidden}, codes(result.Results))
+	require.Empty(st.devices.updated)
+
+	resp, err = handler.ReplaceRelayedDeviceStatuses(relayContext(siteBRelay), request)
+	require.NoError(err)
+	result, ok = resp.(agentServer.ReplaceRelayedDeviceStatuses200JSONResponse)
+	require.True(ok)
+	require.Equal(int64(1), result.Updated)
+	require.Equal([]string{siteBDevice}, st.devices.updated)
+}
+
+func TestAuthorizeRelayedDevice(t *testing.T) {
+	require := require.New(t)
+	handler, st, _ := newRelayTest(t)
+
+	require.NoError(handler.AuthorizeRelayedDevice(context.Background(), "device:"+relayDevice, relayedDevice))
+	require.Error(handler.AuthorizeRelayedDevice(context.Background(), "device:"+relayDevice, siteBDevice))
+	require.ErrorIs(handler.AuthorizeRelayedDevice(context.Background(), "device:"+relayDevice, otherDevice), flterrors.ErrResourceNotFound)
+
+	// a relay whose device is at no site acts for no device
+	st.devices.sites[relayDevice] = ""
+	require.Error(handler.AuthorizeRelayedDevice(context.Background(), "device:"+relayDevice, relayedDevice))
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/samber/lo"
+)
+
+// (GET /api/v1/artifacts)
+func (h *ServiceHandler) ListArtifacts(ctx context.Context, request server.ListArtifactsRequestObject) (server.ListArtifactsResponseObject, error) {
+	if h.artifacts == nil {
+		return server.ListArtifacts503JSONResponse{Message: "no artifact storage is configured"}, nil
+	}
+
+	objects, err := h.artifacts.List(ctx, lo.FromPtr(request.Params.Prefix))
+	if err != nil {
+		return nil, err
+	}
+	list := api.ArtifactList{Items: make([]api.Artifact, 0, len(objects))}
+	for _, object := range objects {
+		list.Items = append(list.Items, api.Artifact{
+			Key:         object.Key,
+			Size:        object.Size,
+			ContentType: lo.EmptyableToPtr(object.ContentType),
+			ModifiedAt:  object.ModifiedAt,
+		})
+	}
+	return server.ListArtifacts200JSONResponse(list), nil
+}
+
+// (GET /api/v1/artifacts/downloadurl)
+func (h *ServiceHandler) CreateArtifactDownloadUrl(ctx context.Context, request server.CreateArtifactDownloadUrlRequestObject) (server.CreateArtifactDownloadUrlResponseObject, error) {
+	if h.artifacts == nil || h.urlSigner == nil {
+		return server.CreateArtifactDownloadUrl503JSONResponse{Message: "no artifact storage or signing key is configured"}, nil
+	}
+
+	// only URLs of existing artifacts are signed, so that the URL reports a
+	// missing artifact right away
+	body, _, err := h.artifacts.Get(ctx, request.Params.Key)
+	if errors.Is(err, artifacts.ErrNotFound) {
+		return server.CreateArtifactDownloadUrl404JSONResponse{Message: err.Error()}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	body.Close()
+
+	url, expiresAt, err := h.urlSigner.Sign(request.Params.Key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return server.CreateArtifactDownloadUrl200JSONResponse{Url: url, ExpiresAt: expiresAt}, nil
+}
+
+// (GET /api/v1/artifacts/download)
+func (h *ServiceHandler) DownloadArtifact(ctx context.Context, request server.DownloadArtifactRequestObject) (server.DownloadArtifactResponseObject, error) {
+	if h.artifacts == nil || h.urlSigner == nil {
+		return server.DownloadArtifact503JSONResponse{Message: "no artifact storage or signing key is configured"}, nil
+	}
+
+	err := h.urlSigner.Verify(request.Params.Key, request.Params.Expires, request.Params.Signature, time.Now())
+	if errors.Is(err, artifacts.ErrInvalidSignature) || errors.Is(err, artifacts.ErrURLExpired) {
+		return server.DownloadArtifact403JSONResponse{Message: err.Error()}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	body, object, err := h.artifacts.Get(ctx, request.Params.Key)
+	if errors.Is(err, artifacts.ErrNotFound) {
+		return server.DownloadArtifact404JSONResponse{Message: err.Error()}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return server.DownloadArtifact200ApplicationoctetStreamResponse{Body: body, ContentLength: object.Size}, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactDownload(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(os.WriteFile(keyFile, []byte("secret"), 0600))
+	cfg := config.NewDefault()
+	require.NoError(json.Unmarshal([]byte(`{"signingKeyFile":"`+keyFile+`"}`), &cfg.Artifacts))
+	urlSigner, err := artifacts.NewURLSigner(cfg)
+	require.NoError(err)
+
+	artifactStore := artifacts.NewFilesystemStore(t.TempDir())
+	require.NoError(artifactStore.Put(ctx, "reports/edge/1.json", []byte(`{}`), "application/json"))
+	h := &ServiceHandler{artifacts: artifactStore, urlSigner: urlSigner}
+
+	listResp, err := h.ListArtifacts(ctx, server.ListArtifactsRequestObject{})
+	require.NoError(err)
+	list, ok := listResp.(server.ListArtifacts200JSONResponse)
+	require.True(ok)
+	require.Len(list.Items, 1)
+	require.Equal("reports/edge/1.json", list.Items[0].Key)
+
+	missingResp, err := h.CreateArtifactDownloadUrl(ctx, server.CreateArtifactDownloadUrlRequestObject{Params: v1alpha1.CreateArtifactDownloadUrlParams{Key: "reports/edge/2.json"}})
+	require.NoError(err)
+	require.IsType(server.CreateArtifactDownloadUrl404JSONResponse{}, missingResp)
+
+	urlResp, err := h.CreateArtifactDownloadUrl(ctx, server.CreateArtifactDownloadUrlRequestObject{Params: v1alpha1.CreateArtifactDownloadUrlParams{Key: "reports/edge/1.json"}})
+	require.NoError(err)
+	downloadUrl, ok := urlResp.(server.CreateArtifactDownloadUrl200JSONResponse)
+	require.True(ok)
+	u, err := url.Parse(downloadUrl.Url)
+	require.NoError(err)
+	require.Equal(artifacts.DownloadPath, u.Path)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	require.NoError(err)
+	params := v1alpha1.DownloadArtifactParams{Key: u.Query().Get("key"), Expires: expires, Signature: u.Query().Get("signature")}
+
+	resp, err := h.DownloadArtifact(ctx, server.DownloadArtifactRequestObject{Params: params})
+	require.NoError(err)
+	download, ok := resp.(server.DownloadArtifact200ApplicationoctetStreamResponse)
+	require.True(ok)
+	content, err := io.ReadAll(download.Body)
+	require.NoError(err)
+	download.Body.(io.Closer).Close()
+	require.Equal(`{}`, string(content))
+
+	params.Key = "reports/edge/other.json"
+	resp, err = h.DownloadArtifact(ctx, server.DownloadArtifactRequestObject{Params: params})
+	require.NoError(err)
+	require.IsType(server.DownloadArtifact403JSONResponse{}, resp)
+
+	resp, err = (&ServiceHandler{}).DownloadArtifact(ctx, server.DownloadArtifactRequestObject{Params: params})
+	require.NoError(err)
+	require.IsType(server.DownloadArtifact503JSONResponse{}, resp)
+}
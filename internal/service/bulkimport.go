@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+)
+
+// maxBulkImportResources bounds the resources of a bulk import, so that a
+// single request does not hold the service for too long.
+const maxBulkImportResources = 10000
+
+// (POST /api/v1/bulkimports)
+func (h *ServiceHandler) CreateBulkImport(ctx context.Context, request server.CreateBulkImportRequestObject) (server.CreateBulkImportResponseObject, error) {
+	fleets := lo.FromPtr(request.Body.Fleets)
+	devices := lo.FromPtr(request.Body.Devices)
+	if total := len(fleets) + len(devices); total > maxBulkImportResources {
+		return server.CreateBulkImport400JSONResponse{Message: fmt.Sprintf("a bulk import creates at most %d resources, not %d", maxBulkImportResources, total)}, nil
+	}
+
+	// the resources are created one by one as by their create endpoints, so
+	// that they are validated and processed the same way
+	result := api.BulkImportResult{Failures: []api.BulkImportFailure{}}
+	fail := func(kind string, name *string, message string) {
+		result.Failures = append(result.Failures, api.BulkImportFailure{Kind: kind, Name: lo.FromPtr(name), Message: message})
+	}
+	for i := range fleets {
+		fleet := &fleets[i]
+		resp, err := h.CreateFleet(ctx, server.CreateFleetRequestObject{Body: fleet})
+		if err != nil {
+			fail(model.FleetKind, fleet.Metadata.Name, err.Error())
+			continue
+		}
+		switch resp := resp.(type) {
+		case server.CreateFleet201JSONResponse:
+			result.Created++
+		case server.CreateFleet400JSONResponse:
+			fail(model.FleetKind, fleet.Metadata.Name, resp.Message)
+		default:
+			fail(model.FleetKind, fleet.Metadata.Name, fmt.Sprintf("unexpected response %T", resp))
+		}
+	}
+	for i := range devices {
+		device := &devices[i]
+		resp, err := h.CreateDevice(ctx, server.CreateDeviceRequestObject{Body: device})
+		if err != nil {
+			fail(model.DeviceKind, device.Metadata.Name, err.Error())
+			continue
+		}
+		switch resp := resp.(type) {
+		case server.CreateDevice201JSONResponse:
+			result.Created++
+		case server.CreateDevice400JSONResponse:
+			fail(model.DeviceKind, device.Metadata.Name, resp.Message)
+		default:
+			fail(model.DeviceKind, device.Metadata.Name, fmt.Sprintf("unexpected response %T", resp))
+		}
+	}
+	if len(result.Failures) > 0 {
+		h.log.Warnf("bulk import created %d resources, %d failed", result.Created, len(result.Failures))
+	}
+	return server.CreateBulkImport200JSONResponse(result), nil
+}
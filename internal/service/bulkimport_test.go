@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type bulkImportStore struct {
+	store.Store
+	devices *importedDevices
+}
+
+func (s *bulkImportStore) Device() store.Device {
+	return s.devices
+}
+
+type importedDevices struct {
+	store.Device
+	items map[string]v1alpha1.Device
+}
+
+func (d *importedDevices) Create(ctx context.Context, orgId uuid.UUID, device *v1alpha1.Device, callback store.DeviceStoreCallback) (*v1alpha1.Device, error) {
+	if _, ok := d.items[*device.Metadata.Name]; ok {
+		return nil, flterrors.ErrDuplicateName
+	}
+	d.items[*device.Metadata.Name] = *device
+	return device, nil
+}
+
+func TestCreateBulkImport(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	devices := &importedDevices{items: map[string]v1alpha1.Device{
+		"existing": {Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("existing")}},
+	}}
+	h := &ServiceHandler{
+		store:           &bulkImportStore{devices: devices},
+		log:             logrus.New(),
+		callbackManager: dummyCallbackManager(),
+	}
+	device := func(name string, labels map[string]string) v1alpha1.Device {
+		return v1alpha1.Device{
+			ApiVersion: "v1alpha1",
+			Kind:       "Device",
+			Metadata:   v1alpha1.ObjectMeta{Name: lo.ToPtr(name), Labels: &labels},
+		}
+	}
+
+	// the resources which fail do not prevent the others from being created
+	resp, err := h.CreateBulkImport(ctx, server.CreateBulkImportRequestObject{Body: &v1alpha1.BulkImport{Devices: &[]v1alpha1.Device{
+		device("store-1-pos", map[string]string{"site": "store-1"}),
+		device("existing", nil),
+		device("Not_A_Name", nil),
+		device("store-2-pos", map[string]string{"site": "store-2"}),
+	}}})
+	require.NoError(err)
+	result, ok := resp.(server.CreateBulkImport200JSONResponse)
+	require.True(ok)
+	require.Equal(int64(2), result.Created)
+	require.Len(result.Failures, 2)
+	require.Equal(v1alpha1.BulkImportFailure{Kind: "Device", Name: "existing", Message: flterrors.ErrDuplicateName.Error()}, result.Failures[0])
+	require.Equal("Not_A_Name", result.Failures[1].Name)
+	require.Equal(map[string]string{"site": "store-2"}, *devices.items["store-2-pos"].Metadata.Labels)
+
+	// an import is bounded
+	tooMany := make([]v1alpha1.Device, maxBulkImportResources+1)
+	resp, err = h.CreateBulkImport(ctx, server.CreateBulkImportRequestObject{Body: &v1alpha1.BulkImport{Devices: &tooMany}})
+	require.NoError(err)
+	require.IsType(server.CreateBulkImport400JSONResponse{}, resp)
+}
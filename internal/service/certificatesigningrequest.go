@@ -12,6 +12,8 @@ import (
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
 	"github.com/go-openapi/swag"
 	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/labels"
@@ -118,7 +120,7 @@ func (h *ServiceHandler) CreateCertificateSigningRequest(ctx context.Context, re
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateCertificateSigningRequest400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateCertificateSigningRequest400JSONResponse(common.ValidationError(errs)), nil
 	}
 
 	result, err := h.store.CertificateSigningRequest().Create(ctx, orgId, request.Body)
@@ -199,19 +201,18 @@ func (h *ServiceHandler) PatchCertificateSigningRequest(ctx context.Context, req
 	}
 
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
 	result, err := h.store.CertificateSigningRequest().Update(ctx, orgId, newObj)
 
 	switch err {
 	case nil:
 		return server.PatchCertificateSigningRequest200JSONResponse(*result), nil
-	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.PatchCertificateSigningRequest400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.PatchCertificateSigningRequest404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.PatchCertificateSigningRequest409JSONResponse{}, nil
+		return server.PatchCertificateSigningRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -226,7 +227,7 @@ func (h *ServiceHandler) ReplaceCertificateSigningRequest(ctx context.Context, r
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceCertificateSigningRequest400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceCertificateSigningRequest400JSONResponse(common.ValidationError(errs)), nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceCertificateSigningRequest400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
@@ -242,12 +243,12 @@ func (h *ServiceHandler) ReplaceCertificateSigningRequest(ctx context.Context, r
 		}
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceCertificateSigningRequest400JSONResponse{Message: err.Error()}, nil
-	case flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.ReplaceCertificateSigningRequest400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceCertificateSigningRequest404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceCertificateSigningRequest409JSONResponse{}, nil
+		return server.ReplaceCertificateSigningRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -278,6 +279,11 @@ func (h *ServiceHandler) ApproveCertificateSigningRequest(ctx context.Context, r
 	if err != nil {
 		return nil, err
 	}
+	// the signer only issues enrollment certificates
+	owner := util.SetResourceOwner(model.CertificateSigningRequestKind, request.Name)
+	if err := common.RecordIssuedCertificate(ctx, h.store, orgId, signedCert, api.IssuedCertificateUsageEnrollment, owner, nil); err != nil {
+		return nil, err
+	}
 
 	approvedCondition := api.Condition{
 		Type:    api.CertificateSigningRequestApproved,
@@ -296,7 +302,7 @@ func (h *ServiceHandler) ApproveCertificateSigningRequest(ctx context.Context, r
 	case flterrors.ErrResourceNotFound:
 		return server.ApproveCertificateSigningRequest404JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ApproveCertificateSigningRequest409JSONResponse{Message: err.Error()}, nil
+		return server.ApproveCertificateSigningRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -325,7 +331,7 @@ func (h *ServiceHandler) DenyCertificateSigningRequest(ctx context.Context, requ
 	case flterrors.ErrResourceNotFound:
 		return server.DenyCertificateSigningRequest404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.DenyCertificateSigningRequest409JSONResponse{}, nil
+		return server.DenyCertificateSigningRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -7,20 +7,26 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"unicode"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util/validation"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/getkin/kin-openapi/routers/gorillamux"
 )
 
 var (
-	ErrorInvalidFieldKey   = errors.New("invalid field filter key")
-	ErrorInvalidFieldValue = errors.New("invalid field filter value")
+	ErrorInvalidFieldKey    = errors.New("invalid field filter key")
+	ErrorInvalidFieldValue  = errors.New("invalid field filter value")
+	ErrorInvalidBoundingBox = errors.New("invalid bounding box")
+	ErrorInvalidAnnotation  = errors.New("invalid annotation selector")
 )
 
 func validateAgainstSchema(ctx context.Context, obj []byte, objPath string) error {
@@ -142,3 +148,59 @@ func validateFieldValue(value string) (string, error) {
 	}
 	return value, nil
 }
+
+// ParseAnnotationSelector parses an annotationSelector query param of
+// comma-separated "key" or "key=value" requirements.
+func ParseAnnotationSelector(param *string) ([]store.AnnotationRequirement, error) {
+	if param == nil || strings.TrimSpace(*param) == "" {
+		return nil, nil
+	}
+	requirements := []store.AnnotationRequirement{}
+	for _, part := range strings.Split(*param, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if errs := validation.ValidateLabelKey(&key, "annotationSelector"); len(errs) > 0 {
+			return nil, fmt.Errorf("%w: %v", ErrorInvalidAnnotation, errors.Join(errs...))
+		}
+		requirement := store.AnnotationRequirement{Key: key}
+		if found {
+			requirement.Value = &value
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// ParseBoundingBox parses a boundingBox query param of the form "west,south,east,north".
+// The box crosses the antimeridian if its west edge is greater than its east edge.
+func ParseBoundingBox(param *string) (*store.BoundingBox, error) {
+	if param == nil {
+		return nil, nil
+	}
+	parts := strings.Split(*param, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("%w: %q must be west,south,east,north", ErrorInvalidBoundingBox, *param)
+	}
+	coordinates := make([]float64, len(parts))
+	for i, part := range parts {
+		coordinate, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(coordinate) {
+			return nil, fmt.Errorf("%w: %q is not a number", ErrorInvalidBoundingBox, part)
+		}
+		coordinates[i] = coordinate
+	}
+	box := &store.BoundingBox{West: coordinates[0], South: coordinates[1], East: coordinates[2], North: coordinates[3]}
+	for _, longitude := range []float64{box.West, box.East} {
+		if longitude < -180 || longitude > 180 {
+			return nil, fmt.Errorf("%w: longitude %v must be between -180 and 180", ErrorInvalidBoundingBox, longitude)
+		}
+	}
+	for _, latitude := range []float64{box.South, box.North} {
+		if latitude < -90 || latitude > 90 {
+			return nil, fmt.Errorf("%w: latitude %v must be between -90 and 90", ErrorInvalidBoundingBox, latitude)
+		}
+	}
+	if box.South > box.North {
+		return nil, fmt.Errorf("%w: south %v is greater than north %v", ErrorInvalidBoundingBox, box.South, box.North)
+	}
+	return box, nil
+}
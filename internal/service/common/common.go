@@ -1,6 +1,10 @@
 package common
 
-import "github.com/flightctl/flightctl/api/v1alpha1"
+import (
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+	"github.com/samber/lo"
+)
 
 func NilOutManagedObjectMetaProperties(om *v1alpha1.ObjectMeta) {
 	om.Generation = nil
@@ -8,4 +12,16 @@ func NilOutManagedObjectMetaProperties(om *v1alpha1.ObjectMeta) {
 	om.Annotations = nil
 	om.CreationTimestamp = nil
 	om.DeletionTimestamp = nil
+	om.DisplayName = nil
+	om.Aliases = nil
+}
+
+// NilOutManagedFleetMetaProperties keeps the conversion annotation of a fleet,
+// which is not managed by the service but holds spec fields of the v1beta1 API.
+func NilOutManagedFleetMetaProperties(om *v1alpha1.ObjectMeta) {
+	conversion, ok := lo.FromPtr(om.Annotations)[v1beta1.FleetAnnotationConversion]
+	NilOutManagedObjectMetaProperties(om)
+	if ok {
+		om.Annotations = &map[string]string{v1beta1.FleetAnnotationConversion: conversion}
+	}
 }
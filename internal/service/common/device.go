@@ -2,43 +2,184 @@ package common
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
 )
 
-func ReplaceDeviceStatus(ctx context.Context, st store.Store, request server.ReplaceDeviceStatusRequestObject) (server.ReplaceDeviceStatusResponseObject, error) {
+func ReplaceDeviceStatus(ctx context.Context, st store.Store, log logrus.FieldLogger, callback store.DeviceStoreCallback, request server.ReplaceDeviceStatusRequestObject) (server.ReplaceDeviceStatusResponseObject, error) {
 	orgId := store.NullOrgId
 
 	device := request.Body
+	if errs := validation.ValidateAnnotations(device.Status.Annotations); len(errs) > 0 {
+		return server.ReplaceDeviceStatus400JSONResponse(ValidationError(errs)), nil
+	}
+	// the existing device holds the annotations the agent does not report and
+	// the action it may confirm
+	var existing *api.Device
+	if (device.Status.Annotations == nil || device.Status.LastAction != nil) && device.Metadata.Name != nil {
+		var err error
+		if existing, err = st.Device().Get(ctx, orgId, *device.Metadata.Name); err != nil {
+			existing = nil
+		}
+	}
+	// agents which predate the annotations of the device do not report them,
+	// which leaves those written by a previous agent in place
+	if device.Status.Annotations == nil && existing != nil && existing.Status != nil {
+		device.Status.Annotations = existing.Status.Annotations
+	}
 	device.Status.LastSeen = time.Now()
+	// the issued certificates are filled in from the inventory when reading the device
+	device.Status.Certificates = nil
 
-	result, err := st.Device().UpdateStatus(ctx, orgId, device)
+	result, err := st.Device().UpdateStatus(ctx, orgId, device, callback)
 	switch err {
 	case nil:
+		if existing != nil && device.Status.LastAction != nil {
+			// the action stays pending and is confirmed by the next status update
+			if err := confirmDeviceAction(ctx, st, log, existing, device.Status.LastAction); err != nil {
+				log.Warnf("device %s: confirming action %s: %v", *device.Metadata.Name, device.Status.LastAction.Id, err)
+			}
+		}
 		return server.ReplaceDeviceStatus200JSONResponse(*result), nil
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceDeviceStatus400JSONResponse{Message: err.Error()}, nil
-	case flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.ReplaceDeviceStatus400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceDeviceStatus404JSONResponse{}, nil
+	case flterrors.ErrResourceVersionConflict:
+		return server.ReplaceDeviceStatus409JSONResponse(ConflictError(err)), nil
 	default:
 		return nil, err
 	}
 }
 
-func GetRenderedDeviceSpec(ctx context.Context, st store.Store, request server.GetRenderedDeviceSpecRequestObject, consoleGrpcEndpoint string) (server.GetRenderedDeviceSpecResponseObject, error) {
+// MaxDeviceStatusBatchSize bounds the devices of a batch of statuses, so
+// that a single request does not hold their records locked for too long.
+const MaxDeviceStatusBatchSize = 1000
+
+// ReplaceDeviceStatuses replaces the statuses of the devices of the batch as
+// ReplaceDeviceStatus does, writing them to the store at once, and reports
+// the result for each device.
+func ReplaceDeviceStatuses(ctx context.Context, st store.Store, log logrus.FieldLogger, callback store.DeviceStoreCallback, request server.ReplaceDeviceStatusesRequestObject) (server.ReplaceDeviceStatusesResponseObject, error) {
 	orgId := store.NullOrgId
 
+	if len(request.Body.Devices) > MaxDeviceStatusBatchSize {
+		return server.ReplaceDeviceStatuses400JSONResponse{Message: fmt.Sprintf("a batch holds the statuses of at most %d devices, not %d", MaxDeviceStatusBatchSize, len(request.Body.Devices))}, nil
+	}
+
+	results := make([]api.DeviceStatusResult, len(request.Body.Devices))
+	devices := []*api.Device{}
+	indexes := []int{}
+	seen := map[string]bool{}
+	now := time.Now()
+	for i := range request.Body.Devices {
+		device := &request.Body.Devices[i]
+		name := lo.FromPtr(device.Metadata.Name)
+		results[i] = api.DeviceStatusResult{Name: name, Code: http.StatusOK}
+		fail := func(code int, message string) {
+			results[i].Code = int32(code)
+			results[i].Message = lo.ToPtr(message)
+		}
+		switch {
+		case device.Metadata.Name == nil:
+			fail(http.StatusBadRequest, flterrors.ErrResourceNameIsNil.Error())
+			continue
+		case device.Status == nil:
+			fail(http.StatusBadRequest, flterrors.ErrResourceIsNil.Error())
+			continue
+		case seen[name]:
+			fail(http.StatusBadRequest, "the batch holds the status of the device more than once")
+			continue
+		}
+		if errs := validation.ValidateAnnotations(device.Status.Annotations); len(errs) > 0 {
+			fail(http.StatusBadRequest, errors.Join(errs...).Error())
+			continue
+		}
+		seen[name] = true
+		device.Status.LastSeen = now
+		// the issued certificates are filled in from the inventory when reading the device
+		device.Status.Certificates = nil
+		devices = append(devices, device)
+		indexes = append(indexes, i)
+	}
+
+	// the existing devices hold the actions the agents may confirm
+	existing := map[string]*api.Device{}
+	errs, err := st.Device().UpdateStatuses(ctx, orgId, devices, func(before *model.Device, after *model.Device) {
+		if after.Status != nil && after.Status.Data.LastAction != nil {
+			existing[before.Name] = lo.ToPtr(before.ToApiResource())
+		}
+		callback(before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := api.DeviceStatusBatchResult{Results: results}
+	for j, err := range errs {
+		i := indexes[j]
+		switch err {
+		case nil:
+			result.Updated++
+			if device := devices[j]; existing[*device.Metadata.Name] != nil {
+				if err := confirmDeviceAction(ctx, st, log, existing[*device.Metadata.Name], device.Status.LastAction); err != nil {
+					log.Warnf("device %s: confirming action %s: %v", *device.Metadata.Name, device.Status.LastAction.Id, err)
+				}
+			}
+			continue
+		case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+			results[i].Code = http.StatusBadRequest
+		case flterrors.ErrResourceNotFound:
+			results[i].Code = http.StatusNotFound
+		case flterrors.ErrResourceVersionConflict:
+			results[i].Code = http.StatusConflict
+		default:
+			results[i].Code = http.StatusInternalServerError
+		}
+		results[i].Message = lo.ToPtr(err.Error())
+	}
+	if failed := len(results) - int(result.Updated); failed > 0 {
+		log.Warnf("batch of statuses replaced %d statuses, %d failed", result.Updated, failed)
+	}
+	return server.ReplaceDeviceStatuses200JSONResponse(result), nil
+}
+
+func GetRenderedDeviceSpec(ctx context.Context, st store.Store, log logrus.FieldLogger, request server.GetRenderedDeviceSpecRequestObject, consoleGrpcEndpoint string) (server.GetRenderedDeviceSpecResponseObject, error) {
+	orgId := store.NullOrgId
+
+	specVersion, err := NegotiateRenderedSpecVersion(request.Params.SpecVersions)
+	if err != nil {
+		log.Warnf("device %s: %v", request.Name, err)
+		return server.GetRenderedDeviceSpec409JSONResponse{Message: err.Error()}, nil
+	}
+
 	result, err := st.Device().GetRendered(ctx, orgId, request.Name, request.Params.KnownRenderedVersion, consoleGrpcEndpoint)
 	switch err {
 	case nil:
 		if result == nil {
 			return server.GetRenderedDeviceSpec204Response{}, nil
 		}
+		if err := CheckRenderedDeviceSpec(result, specVersion); err != nil {
+			log.Warnf("device %s: %v", request.Name, err)
+			return server.GetRenderedDeviceSpec409JSONResponse{Message: err.Error()}, nil
+		}
+		if removed := ConvertRenderedDeviceSpec(result, specVersion); len(removed) > 0 {
+			log.Warnf("device %s: agent supports rendered spec version %s, not serving unsupported settings %s", request.Name, specVersion, strings.Join(removed, ", "))
+		}
+		result.SpecVersion = &specVersion
 		return server.GetRenderedDeviceSpec200JSONResponse(*result), nil
 	case flterrors.ErrResourceNotFound:
 		return server.GetRenderedDeviceSpec404JSONResponse{}, nil
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type statusBatchStore struct {
+	store.Store
+	devices *statusBatchDevices
+}
+
+func (s *statusBatchStore) Device() store.Device {
+	return s.devices
+}
+
+type statusBatchDevices struct {
+	store.Device
+	existing          map[string]*model.Device
+	updated           []string
+	deletedAnnotation []string
+}
+
+func (d *statusBatchDevices) UpdateStatuses(ctx context.Context, orgId uuid.UUID, devices []*api.Device, callback store.DeviceStoreCallback) ([]error, error) {
+	errs := make([]error, len(devices))
+	for i, device := range devices {
+		existing, ok := d.existing[*device.Metadata.Name]
+		if !ok {
+			errs[i] = flterrors.ErrResourceNotFound
+			continue
+		}
+		d.updated = append(d.updated, *device.Metadata.Name)
+		updated := *existing
+		updated.Status = model.MakeJSONField(*device.Status)
+		callback(existing, &updated)
+	}
+	return errs, nil
+}
+
+func (d *statusBatchDevices) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	d.deletedAnnotation = append(d.deletedAnnotation, name)
+	return nil
+}
+
+func TestReplaceDeviceStatuses(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	existing := func(name string, annotations ...string) *model.Device {
+		return &model.Device{Resource: model.Resource{Name: name, Annotations: annotations}}
+	}
+	devices := &statusBatchDevices{existing: map[string]*model.Device{
+		"dev-1": existing("dev-1"),
+		"dev-2": existing("dev-2", model.DeviceAnnotationAction+`={"id":"42","action":"Reboot","requestedAt":"2024-01-01T00:00:00Z"}`),
+	}}
+	device := func(name string, status *api.DeviceStatus) api.Device {
+		return api.Device{Metadata: api.ObjectMeta{Name: lo.ToPtr(name)}, Status: status}
+	}
+	status := func() *api.DeviceStatus {
+		return lo.ToPtr(api.NewDeviceStatus())
+	}
+	acknowledged := status()
+	acknowledged.LastAction = &api.DeviceActionStatus{Id: "42", Action: api.DeviceActionReboot, State: api.DeviceActionAcknowledged}
+
+	var callbacks []string
+	resp, err := ReplaceDeviceStatuses(ctx, &statusBatchStore{devices: devices}, logrus.New(), func(before *model.Device, after *model.Device) {
+		callbacks = append(callbacks, after.Name)
+	}, server.ReplaceDeviceStatusesRequestObject{Body: &api.DeviceStatusBatch{Devices: []api.Device{
+		device("dev-1", status()),
+		device("dev-2", acknowledged),
+		device("dev-1", status()),
+		device("missing", status()),
+		device("dev-3", nil),
+		{Status: status()},
+	}}})
+	require.NoError(err)
+	result, ok := resp.(server.ReplaceDeviceStatuses200JSONResponse)
+	require.True(ok)
+
+	// each device gets the code it would get from replaceDeviceStatus
+	require.Equal(int64(2), result.Updated)
+	codes := lo.Map(result.Results, func(r api.DeviceStatusResult, _ int) int32 { return r.Code })
+	require.Equal([]int32{http.StatusOK, http.StatusOK, http.StatusBadRequest, http.StatusNotFound, http.StatusBadRequest, http.StatusBadRequest}, codes)
+	require.Equal("dev-2", result.Results[1].Name)
+	require.Nil(result.Results[1].Message)
+	require.Contains(*result.Results[2].Message, "more than once")
+	require.Equal([]string{"dev-1", "dev-2"}, devices.updated)
+	require.Equal([]string{"dev-1", "dev-2"}, callbacks)
+
+	// the acknowledged action is confirmed
+	require.Equal([]string{"dev-2"}, devices.deletedAnnotation)
+
+	// a batch is bounded
+	resp, err = ReplaceDeviceStatuses(ctx, &statusBatchStore{devices: devices}, logrus.New(), nil, server.ReplaceDeviceStatusesRequestObject{Body: &api.DeviceStatusBatch{
+		Devices: make([]api.Device, MaxDeviceStatusBatchSize+1),
+	}})
+	require.NoError(err)
+	require.IsType(server.ReplaceDeviceStatuses400JSONResponse{}, resp)
+}
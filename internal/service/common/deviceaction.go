@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Events of the audit log of device actions, besides the states the agent reports.
+const (
+	DeviceActionRequested = "Requested"
+	DeviceActionCanceled  = "Canceled"
+	DeviceActionDenied    = "Denied"
+)
+
+// PendingDeviceAction returns the action requested for the device which its
+// agent did not acknowledge yet, or nil if there is none.
+func PendingDeviceAction(device *api.Device) (*api.DeviceAction, error) {
+	val, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationAction]
+	if !ok {
+		return nil, nil
+	}
+	var action api.DeviceAction
+	if err := json.Unmarshal([]byte(val), &action); err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// AuditDeviceAction writes an event of a device action to the audit log,
+// which are the service logs flagged with the audit field.
+func AuditDeviceAction(log logrus.FieldLogger, deviceName string, id string, action api.DeviceActionType, event string, message string) {
+	entry := fmt.Sprintf("device %s: %s action %s %s", deviceName, action, id, strings.ToLower(event))
+	if message != "" {
+		entry += ": " + message
+	}
+	log.WithFields(logrus.Fields{
+		"audit":    "DeviceAction",
+		"device":   deviceName,
+		"actionId": id,
+		"action":   action,
+		"event":    event,
+	}).Info(entry)
+}
+
+// confirmDeviceAction audits the progress of the action the agent reports,
+// and stops serving the action once the agent acknowledged it.
+func confirmDeviceAction(ctx context.Context, st store.Store, log logrus.FieldLogger, existing *api.Device, reported *api.DeviceActionStatus) error {
+	name := lo.FromPtr(existing.Metadata.Name)
+	var previous *api.DeviceActionStatus
+	if existing.Status != nil {
+		previous = existing.Status.LastAction
+	}
+	if previous == nil || previous.Id != reported.Id || previous.State != reported.State {
+		AuditDeviceAction(log, name, reported.Id, reported.Action, string(reported.State), lo.FromPtr(reported.Message))
+	}
+
+	pending, err := PendingDeviceAction(existing)
+	if err != nil || pending == nil || pending.Id != reported.Id {
+		return err
+	}
+	return st.Device().UpdateAnnotations(ctx, store.NullOrgId, name, nil, []string{model.DeviceAnnotationAction})
+}
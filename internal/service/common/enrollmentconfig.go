@@ -0,0 +1,38 @@
+package common
+
+import (
+	"encoding/base64"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/crypto"
+)
+
+// EnrollmentConfig returns the agent configuration for enrolling with the
+// given client certificate and key. The key is left empty if it is nil.
+func EnrollmentConfig(ca *crypto.CA, clientCert []byte, clientKey []byte, agentEndpoint string, uiUrl string, grpcEndpoint string) (*v1alpha1.EnrollmentConfig, error) {
+	cert, _, err := ca.Config.GetPEMBytes()
+	if err != nil {
+		return nil, err
+	}
+	clientKeyData := ""
+	if clientKey != nil {
+		clientKeyData = base64.StdEncoding.EncodeToString(clientKey)
+	}
+	return &v1alpha1.EnrollmentConfig{
+		EnrollmentService: v1alpha1.EnrollmentService{
+			Authentication: v1alpha1.EnrollmentServiceAuth{
+				ClientCertificateData: base64.StdEncoding.EncodeToString(clientCert),
+				ClientKeyData:         clientKeyData,
+			},
+			Service: v1alpha1.EnrollmentServiceService{
+				CertificateAuthorityData: base64.StdEncoding.EncodeToString(cert),
+				Server:                   agentEndpoint,
+			},
+			EnrollmentUiEndpoint: uiUrl,
+		},
+		GrpcManagementEndpoint: grpcEndpoint,
+		SpecFetchInterval:      "0m10s",
+		StatusUpdateInterval:   "0m10s",
+		TpmPath:                "/dev/tpmrm0",
+	}, nil
+}
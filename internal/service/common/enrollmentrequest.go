@@ -2,14 +2,33 @@ package common
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
+// placeholderSerialNumbers are the serial numbers, in lowercase, firmwares
+// report when the vendor did not set one, which many machines share.
+var placeholderSerialNumbers = []string{
+	"0",
+	"0123456789",
+	"default string",
+	"none",
+	"not applicable",
+	"not specified",
+	"system serial number",
+	"to be filled by o.e.m.",
+}
+
 func ValidateAndCompleteEnrollmentRequest(enrollmentRequest *v1alpha1.EnrollmentRequest) error {
 	if enrollmentRequest.Status == nil {
 		enrollmentRequest.Status = &v1alpha1.EnrollmentRequestStatus{
@@ -20,7 +39,59 @@ func ValidateAndCompleteEnrollmentRequest(enrollmentRequest *v1alpha1.Enrollment
 	return nil
 }
 
-func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server.CreateEnrollmentRequestRequestObject) (server.CreateEnrollmentRequestResponseObject, error) {
+// HardwareIdentity returns the serial number and the MAC addresses the machine
+// reported in the device status of the enrollment request, or nil if it
+// reported neither.
+func HardwareIdentity(enrollmentRequest *v1alpha1.EnrollmentRequest) *store.HardwareIdentity {
+	if enrollmentRequest.Spec.DeviceStatus == nil || enrollmentRequest.Spec.DeviceStatus.SystemInfo.Hardware == nil {
+		return nil
+	}
+	hardware := enrollmentRequest.Spec.DeviceStatus.SystemInfo.Hardware
+	identity := &store.HardwareIdentity{}
+	if serialNumber := strings.TrimSpace(lo.FromPtr(hardware.SerialNumber)); serialNumber != "" && !slices.Contains(placeholderSerialNumbers, strings.ToLower(serialNumber)) {
+		identity.SerialNumber = serialNumber
+	}
+	for _, networkInterface := range hardware.NetworkInterfaces {
+		macAddress, err := net.ParseMAC(networkInterface.MacAddress)
+		if err != nil || len(macAddress) != 6 || slices.Equal(macAddress, make(net.HardwareAddr, 6)) {
+			continue
+		}
+		identity.MacAddresses = append(identity.MacAddresses, macAddress.String())
+	}
+	if identity.SerialNumber == "" && len(identity.MacAddresses) == 0 {
+		return nil
+	}
+	identity.MacAddresses = lo.Uniq(identity.MacAddresses)
+	return identity
+}
+
+// FindDuplicateDevice returns the name of the enrolled device which reported
+// the serial number or one of the MAC addresses of the machine of the
+// enrollment request, such as the device a re-imaged machine was, or nil if
+// there is none. The device the enrollment request enrolled is not a
+// duplicate of it.
+func FindDuplicateDevice(ctx context.Context, st store.Store, orgId uuid.UUID, enrollmentRequest *v1alpha1.EnrollmentRequest) (*string, error) {
+	identity := HardwareIdentity(enrollmentRequest)
+	if identity == nil {
+		return nil, nil
+	}
+	devices, err := st.Device().List(ctx, orgId, store.ListParams{HardwareIdentity: identity})
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices.Items {
+		if *device.Metadata.Name != lo.FromPtr(enrollmentRequest.Metadata.Name) {
+			return device.Metadata.Name, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateEnrollmentRequest creates the enrollment request of a machine, which
+// records the device the machine is already enrolled as, if any. The
+// enrollment of such a machine is refused if the duplicate enrollment policy
+// is Reject.
+func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server.CreateEnrollmentRequestRequestObject, duplicatePolicy string) (server.CreateEnrollmentRequestResponseObject, error) {
 	orgId := store.NullOrgId
 
 	// don't set fields that are managed by the service
@@ -28,7 +99,7 @@ func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server
 	NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateEnrollmentRequest400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateEnrollmentRequest400JSONResponse(ValidationError(errs)), nil
 	}
 
 	// verify if the enrollment request already exists, and return it with a 208 status code if it does
@@ -36,10 +107,19 @@ func CreateEnrollmentRequest(ctx context.Context, st store.Store, request server
 		return server.CreateEnrollmentRequest208JSONResponse(*enrollmentReq), nil
 	}
 
+	duplicateOf, err := FindDuplicateDevice(ctx, st, orgId, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if duplicateOf != nil && duplicatePolicy == config.DuplicateEnrollmentReject {
+		return server.CreateEnrollmentRequest409JSONResponse{Message: fmt.Sprintf("the machine is already enrolled as device %s", *duplicateOf)}, nil
+	}
+
 	// if the enrollment request does not exist, create it
 	if err := ValidateAndCompleteEnrollmentRequest(request.Body); err != nil {
 		return nil, err
 	}
+	request.Body.Status.DuplicateOf = duplicateOf
 
 	result, err := st.EnrollmentRequest().Create(ctx, orgId, request.Body)
 	switch err {
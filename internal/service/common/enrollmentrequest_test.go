@@ -0,0 +1,37 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHardwareIdentity(t *testing.T) {
+	require := require.New(t)
+	enrollmentRequest := func(hardware *v1alpha1.DeviceHardwareInfo) *v1alpha1.EnrollmentRequest {
+		status := v1alpha1.NewDeviceStatus()
+		status.SystemInfo.Hardware = hardware
+		return &v1alpha1.EnrollmentRequest{Spec: v1alpha1.EnrollmentRequestSpec{DeviceStatus: &status}}
+	}
+
+	require.Equal(&store.HardwareIdentity{SerialNumber: "SN-42", MacAddresses: []string{"52:54:00:12:34:56"}}, HardwareIdentity(enrollmentRequest(&v1alpha1.DeviceHardwareInfo{
+		SerialNumber: lo.ToPtr(" SN-42 "),
+		NetworkInterfaces: []v1alpha1.DeviceNetworkInterfaceInfo{
+			{Name: "eth0", MacAddress: "52:54:00:12:34:56"},
+			{Name: "eth1", MacAddress: "52:54:00:12:34:56"},
+			{Name: "dummy0", MacAddress: "00:00:00:00:00:00"},
+		},
+	})))
+
+	// placeholder serial numbers do not identify a machine
+	require.Equal(&store.HardwareIdentity{MacAddresses: []string{"52:54:00:ab:cd:ef"}}, HardwareIdentity(enrollmentRequest(&v1alpha1.DeviceHardwareInfo{
+		SerialNumber:      lo.ToPtr("To Be Filled By O.E.M."),
+		NetworkInterfaces: []v1alpha1.DeviceNetworkInterfaceInfo{{Name: "eth0", MacAddress: "52:54:00:AB:CD:EF"}},
+	})))
+	require.Nil(HardwareIdentity(enrollmentRequest(&v1alpha1.DeviceHardwareInfo{SerialNumber: lo.ToPtr("Default string")})))
+	require.Nil(HardwareIdentity(enrollmentRequest(nil)))
+	require.Nil(HardwareIdentity(&v1alpha1.EnrollmentRequest{}))
+}
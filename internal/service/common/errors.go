@@ -0,0 +1,124 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ErrorTypePrefix prefixes the reasons of errors to form their types.
+const ErrorTypePrefix = "urn:flightctl:error:"
+
+// ValidationError returns the error of a request which failed validation,
+// with a field violation for each error of a field.
+func ValidationError(errs []error) api.Error {
+	violations := []api.FieldViolation{}
+	for _, err := range errs {
+		var fieldErr *field.Error
+		if errors.As(err, &fieldErr) {
+			violations = append(violations, api.FieldViolation{Field: fieldErr.Field, Description: fieldErr.ErrorBody()})
+		}
+	}
+	apiErr := api.Error{
+		Message: errors.Join(errs...).Error(),
+		Reason:  lo.ToPtr(api.ErrorReasonValidationFailed),
+	}
+	if len(violations) > 0 {
+		apiErr.FieldViolations = &violations
+	}
+	return apiErr
+}
+
+// ConflictError returns the error of a write which conflicts with the
+// resource, which may be retried if the resource changed since it was read.
+func ConflictError(err error) api.Error {
+	_, apiErr := ErrorForHandlerError(err)
+	return apiErr
+}
+
+// ErrorReasonForStatus returns the reason of an error response with the
+// status code, for the responses whose handler gave no reason.
+func ErrorReasonForStatus(statusCode int) api.ErrorReason {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.ErrorReasonBadRequest
+	case http.StatusUnauthorized:
+		return api.ErrorReasonUnauthorized
+	case http.StatusForbidden:
+		return api.ErrorReasonForbidden
+	case http.StatusNotFound:
+		return api.ErrorReasonNotFound
+	case http.StatusMethodNotAllowed:
+		return api.ErrorReasonMethodNotAllowed
+	case http.StatusConflict:
+		return api.ErrorReasonConflict
+	case http.StatusRequestEntityTooLarge:
+		return api.ErrorReasonRequestTooLarge
+	case http.StatusTooManyRequests:
+		return api.ErrorReasonTooManyRequests
+	case http.StatusInternalServerError:
+		return api.ErrorReasonInternalError
+	case http.StatusServiceUnavailable:
+		return api.ErrorReasonServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return api.ErrorReasonTimeout
+	default:
+		return api.ErrorReasonUnknown
+	}
+}
+
+// IsRetryableErrorReason returns whether a request which failed for the
+// reason may succeed if it is sent again later.
+func IsRetryableErrorReason(reason api.ErrorReason) bool {
+	switch reason {
+	case api.ErrorReasonResourceVersionConflict, api.ErrorReasonIdempotencyKeyInProgress, api.ErrorReasonTooManyRequests, api.ErrorReasonServiceUnavailable, api.ErrorReasonTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// CompleteError sets the status, reason, type and retryability of an error
+// response which its handler left unset.
+func CompleteError(apiErr *api.Error, statusCode int) {
+	if apiErr.Status == nil {
+		apiErr.Status = lo.ToPtr(int32(statusCode))
+	}
+	if apiErr.Reason == nil {
+		apiErr.Reason = lo.ToPtr(ErrorReasonForStatus(statusCode))
+	}
+	if apiErr.Type == nil {
+		apiErr.Type = lo.ToPtr(ErrorTypePrefix + string(*apiErr.Reason))
+	}
+	if apiErr.Retryable == nil {
+		apiErr.Retryable = lo.ToPtr(IsRetryableErrorReason(*apiErr.Reason))
+	}
+}
+
+// ErrorForHandlerError returns the status code and error response of an error
+// a handler returned rather than handled, such as an error of the store.
+func ErrorForHandlerError(err error) (int, api.Error) {
+	apiErr := api.Error{Message: err.Error()}
+	statusCode := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, flterrors.ErrDuplicateName):
+		statusCode = http.StatusConflict
+		apiErr.Reason = lo.ToPtr(api.ErrorReasonAlreadyExists)
+	case errors.Is(err, flterrors.ErrResourceVersionConflict), errors.Is(err, flterrors.ErrNoRowsUpdated):
+		statusCode = http.StatusConflict
+		apiErr.Reason = lo.ToPtr(api.ErrorReasonResourceVersionConflict)
+	case errors.Is(err, flterrors.ErrUpdatingResourceWithOwnerNotAllowed):
+		statusCode = http.StatusConflict
+	case errors.Is(err, context.DeadlineExceeded):
+		statusCode = http.StatusGatewayTimeout
+	}
+	CompleteError(&apiErr, statusCode)
+	return statusCode, apiErr
+}
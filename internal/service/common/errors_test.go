@@ -0,0 +1,76 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError(t *testing.T) {
+	require := require.New(t)
+	errs := append(validation.ValidateResourceName(lo.ToPtr("Not_A_Name")), errors.New("spec: something else is wrong"))
+	apiErr := ValidationError(errs)
+	require.Equal(api.ErrorReasonValidationFailed, *apiErr.Reason)
+	require.Equal(errors.Join(errs...).Error(), apiErr.Message)
+	require.NotNil(apiErr.FieldViolations)
+	require.NotEmpty(*apiErr.FieldViolations)
+	for _, violation := range *apiErr.FieldViolations {
+		require.Equal("metadata.name", violation.Field)
+		require.NotEmpty(violation.Description)
+	}
+
+	require.Nil(ValidationError([]error{errors.New("invalid")}).FieldViolations)
+}
+
+func TestCompleteError(t *testing.T) {
+	require := require.New(t)
+	apiErr := api.Error{Message: "too many requests"}
+	CompleteError(&apiErr, http.StatusTooManyRequests)
+	require.Equal(api.Error{
+		Message:   "too many requests",
+		Status:    lo.ToPtr[int32](http.StatusTooManyRequests),
+		Reason:    lo.ToPtr(api.ErrorReasonTooManyRequests),
+		Type:      lo.ToPtr("urn:flightctl:error:TooManyRequests"),
+		Retryable: lo.ToPtr(true),
+	}, apiErr)
+
+	// the reason the handler gave is kept
+	apiErr = api.Error{Message: "invalid", Reason: lo.ToPtr(api.ErrorReasonValidationFailed)}
+	CompleteError(&apiErr, http.StatusBadRequest)
+	require.Equal(api.ErrorReasonValidationFailed, *apiErr.Reason)
+	require.Equal("urn:flightctl:error:ValidationFailed", *apiErr.Type)
+	require.False(*apiErr.Retryable)
+}
+
+func TestErrorForHandlerError(t *testing.T) {
+	tests := []struct {
+		err        error
+		statusCode int
+		reason     api.ErrorReason
+		retryable  bool
+	}{
+		{flterrors.ErrResourceNotFound, http.StatusNotFound, api.ErrorReasonNotFound, false},
+		{fmt.Errorf("creating device: %w", flterrors.ErrDuplicateName), http.StatusConflict, api.ErrorReasonAlreadyExists, false},
+		{flterrors.ErrResourceVersionConflict, http.StatusConflict, api.ErrorReasonResourceVersionConflict, true},
+		{flterrors.ErrUpdatingResourceWithOwnerNotAllowed, http.StatusConflict, api.ErrorReasonConflict, false},
+		{errors.New("the database is on fire"), http.StatusInternalServerError, api.ErrorReasonInternalError, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			require := require.New(t)
+			statusCode, apiErr := ErrorForHandlerError(tt.err)
+			require.Equal(tt.statusCode, statusCode)
+			require.Equal(tt.err.Error(), apiErr.Message)
+			require.Equal(tt.reason, *apiErr.Reason)
+			require.Equal(int32(tt.statusCode), *apiErr.Status)
+			require.Equal(tt.retryable, *apiErr.Retryable)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// Events of the audit log of fleet promotions.
+const (
+	FleetPromotionProposed = "Proposed"
+	FleetPromotionApproved = "Approved"
+	FleetPromotionPromoted = "Promoted"
+)
+
+// ErrPromotedTemplateChanged is returned when the template of the fleet
+// promoted from is no longer the template of the template version promoted.
+var ErrPromotedTemplateChanged = errors.New("the template of the fleet promoted from changed")
+
+// PromoteFleetTemplate replaces the template of the fleet with the template
+// of the fleet it is promoted from, which was rendered to the template
+// version. The new template version of the fleet is rolled out to its devices
+// by its rollout policy.
+func PromoteFleetTemplate(ctx context.Context, st store.Store, callback store.FleetStoreCallback, orgId uuid.UUID, fleet *api.Fleet, templateVersion string) error {
+	from, err := st.Fleet().Get(ctx, orgId, fleet.Spec.Promotion.From)
+	if err != nil {
+		return fmt.Errorf("failed to get fleet %s: %w", fleet.Spec.Promotion.From, err)
+	}
+	if lo.FromPtr(from.Metadata.Annotations)[model.FleetAnnotationTemplateVersion] != templateVersion {
+		return fmt.Errorf("%w since template version %s", ErrPromotedTemplateChanged, templateVersion)
+	}
+	promoted := *fleet
+	promoted.Spec.Template = from.Spec.Template
+	if _, err := st.Fleet().Update(ctx, orgId, &promoted, callback); err != nil {
+		return fmt.Errorf("failed to update the template of fleet %s: %w", lo.FromPtr(fleet.Metadata.Name), err)
+	}
+	return nil
+}
+
+// AuditFleetPromotion writes an event of the promotion of a template version
+// to the audit log, which are the service logs flagged with the audit field.
+func AuditFleetPromotion(log logrus.FieldLogger, fleetName string, from string, templateVersion string, event string) {
+	log.WithFields(logrus.Fields{
+		"audit":           "FleetPromotion",
+		"fleet":           fleetName,
+		"from":            from,
+		"templateVersion": templateVersion,
+		"event":           event,
+	}).Infof("fleet %s: promotion of template version %s of fleet %s %s", fleetName, templateVersion, from, strings.ToLower(event))
+}
@@ -0,0 +1,167 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// GenerateFleetReport reports the compliance of the devices owned by the fleet
+// at now. It serves both the report endpoint and the scheduled reports.
+func GenerateFleetReport(ctx context.Context, st store.Store, orgId uuid.UUID, fleet *v1alpha1.Fleet, now time.Time) (*v1alpha1.FleetReport, error) {
+	var templateVersion *v1alpha1.TemplateVersion
+	if name, ok := lo.FromPtr(fleet.Metadata.Annotations)[model.FleetAnnotationTemplateVersion]; ok {
+		var err error
+		templateVersion, err = st.TemplateVersion().Get(ctx, orgId, *fleet.Metadata.Name, name)
+		if err != nil && !errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, err
+		}
+	}
+	report := newFleetReport(fleet, templateVersion, now)
+	listParams := store.ListParams{
+		Owners: []string{*util.SetResourceOwner(model.FleetKind, *fleet.Metadata.Name)},
+		Limit:  store.MaxRecordsPerListRequest,
+	}
+	for {
+		devices, err := st.Device().List(ctx, orgId, listParams)
+		if err != nil {
+			return nil, err
+		}
+		for i := range devices.Items {
+			report.addDevice(&devices.Items[i])
+		}
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+	return &report.FleetReport, nil
+}
+
+type fleetReport struct {
+	v1alpha1.FleetReport
+	// targetOs is the OS of the template the devices are compared with
+	targetOs *v1alpha1.DeviceOSSpec
+}
+
+// newFleetReport returns the report of the fleet, whose devices are compared
+// with its current template version once it is rendered: the image of a
+// template following a stream is then pinned by digest, as the devices
+// report it.
+func newFleetReport(fleet *v1alpha1.Fleet, templateVersion *v1alpha1.TemplateVersion, now time.Time) *fleetReport {
+	report := &fleetReport{
+		FleetReport: v1alpha1.FleetReport{
+			Fleet:         *fleet.Metadata.Name,
+			GeneratedAt:   now.UTC(),
+			SummaryStatus: map[string]int64{},
+			FailedDevices: []v1alpha1.FleetReportDevice{},
+		},
+	}
+	if fleet.Metadata.Annotations != nil {
+		if templateVersion, ok := (*fleet.Metadata.Annotations)[model.FleetAnnotationTemplateVersion]; ok {
+			report.TemplateVersion = lo.ToPtr(templateVersion)
+		}
+	}
+	report.targetOs = fleet.Spec.Template.Spec.Os
+	if templateVersion != nil && templateVersion.Status != nil && templateVersion.Status.Os != nil {
+		report.targetOs = templateVersion.Status.Os
+	}
+	if report.targetOs != nil {
+		report.TargetOsImage = lo.ToPtr(report.targetOs.Image)
+	}
+	return report
+}
+
+func (r *fleetReport) addDevice(device *v1alpha1.Device) {
+	r.TotalDevices++
+
+	status := lo.FromPtr(device.Status)
+	summaryStatus := status.Summary.Status
+	if summaryStatus == "" {
+		summaryStatus = v1alpha1.DeviceSummaryStatusUnknown
+	}
+	r.SummaryStatus[string(summaryStatus)]++
+
+	if status.Compliance != nil {
+		if r.ComplianceStatus == nil {
+			r.ComplianceStatus = &map[string]int64{}
+		}
+		(*r.ComplianceStatus)[string(status.Compliance.Status)]++
+	}
+
+	// The specs of devices lag behind the template of their fleet until they are
+	// rendered, so devices are compared with the image of the template for
+	// their architecture. Without an OS image in the template, all devices run
+	// the target OS.
+	if r.targetOs == nil || status.Os.Image == r.targetOs.ImageForArchitecture(status.SystemInfo.Architecture) {
+		r.DevicesOnTargetOs++
+	}
+
+	annotations := lo.FromPtr(device.Metadata.Annotations)
+	templateVersionDrift := r.TemplateVersion != nil && annotations[model.DeviceAnnotationTemplateVersion] != *r.TemplateVersion
+	renderedVersionDrift := annotations[model.DeviceAnnotationRenderedVersion] != status.Config.RenderedVersion
+	if templateVersionDrift || renderedVersionDrift {
+		r.DevicesWithConfigDrift++
+	}
+
+	if summaryStatus == v1alpha1.DeviceSummaryStatusError || summaryStatus == v1alpha1.DeviceSummaryStatusDegraded {
+		r.FailedDevices = append(r.FailedDevices, v1alpha1.FleetReportDevice{
+			Name:          *device.Metadata.Name,
+			SummaryStatus: summaryStatus,
+			OsImage:       status.Os.Image,
+			Reason:        status.Summary.Info,
+		})
+	}
+
+	r.addWorkloadEvents(lo.FromPtr(status.Events))
+}
+
+// addWorkloadEvents counts the events of a device per workload, so that a
+// workload running out of memory on many devices stands out of the crashes
+// of single devices.
+func (r *fleetReport) addWorkloadEvents(events []v1alpha1.DeviceEvent) {
+	if len(events) == 0 {
+		return
+	}
+	workloadEvents := lo.FromPtr(r.WorkloadEvents)
+	counted := map[int]bool{}
+	for _, event := range events {
+		workload := lo.FromPtr(event.Unit)
+		if workload == "" {
+			workload = lo.FromPtr(event.Process)
+		}
+		_, i, found := lo.FindIndexOf(workloadEvents, func(w v1alpha1.FleetReportWorkloadEvents) bool {
+			return w.Workload == workload && w.Type == event.Type
+		})
+		if !found {
+			workloadEvents = append(workloadEvents, v1alpha1.FleetReportWorkloadEvents{Workload: workload, Type: event.Type})
+			i = len(workloadEvents) - 1
+		}
+		workloadEvents[i].Events++
+		if !counted[i] {
+			workloadEvents[i].Devices++
+			counted[i] = true
+		}
+	}
+	sort.SliceStable(workloadEvents, func(i, j int) bool {
+		if workloadEvents[i].Devices != workloadEvents[j].Devices {
+			return workloadEvents[i].Devices > workloadEvents[j].Devices
+		}
+		return workloadEvents[i].Events > workloadEvents[j].Events
+	})
+	r.WorkloadEvents = &workloadEvents
+}
@@ -0,0 +1,195 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func testReportDevice(name string, desiredOs string, status v1alpha1.DeviceStatus, templateVersion string, renderedVersion string) v1alpha1.Device {
+	return v1alpha1.Device{
+		Metadata: v1alpha1.ObjectMeta{
+			Name: util.StrToPtr(name),
+			Annotations: &map[string]string{
+				model.DeviceAnnotationTemplateVersion: templateVersion,
+				model.DeviceAnnotationRenderedVersion: renderedVersion,
+			},
+		},
+		Spec:   &v1alpha1.DeviceSpec{Os: &v1alpha1.DeviceOSSpec{Image: desiredOs}},
+		Status: &status,
+	}
+}
+
+func TestFleetReport(t *testing.T) {
+	require := require.New(t)
+
+	fleet := v1alpha1.Fleet{
+		Metadata: v1alpha1.ObjectMeta{
+			Name:        util.StrToPtr("fleet"),
+			Annotations: &map[string]string{model.FleetAnnotationTemplateVersion: "tv-2"},
+		},
+		Spec: v1alpha1.FleetSpec{},
+	}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "os:2"}
+
+	now := time.Now()
+	report := newFleetReport(&fleet, nil, now)
+
+	// device on its target, failing its compliance scan
+	device := testReportDevice("dev1", "os:2", v1alpha1.DeviceStatus{
+		Summary:    v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusOnline},
+		Os:         v1alpha1.DeviceOSStatus{Image: "os:2"},
+		Config:     v1alpha1.DeviceConfigStatus{RenderedVersion: "5"},
+		Compliance: &v1alpha1.DeviceComplianceStatus{Profile: "cis", Status: v1alpha1.DeviceComplianceStatusNonCompliant, Failed: 3},
+	}, "tv-2", "5")
+	report.addDevice(&device)
+
+	// device still on the previous template version and OS image
+	device = testReportDevice("dev2", "os:1", v1alpha1.DeviceStatus{
+		Summary: v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusOnline},
+		Os:      v1alpha1.DeviceOSStatus{Image: "os:0"},
+		Config:  v1alpha1.DeviceConfigStatus{RenderedVersion: "3"},
+	}, "tv-1", "3")
+	report.addDevice(&device)
+
+	// failed device that has not applied its rendered config
+	device = testReportDevice("dev3", "os:2", v1alpha1.DeviceStatus{
+		Summary: v1alpha1.DeviceSummaryStatus{Status: v1alpha1.DeviceSummaryStatusError, Info: util.StrToPtr("disk full")},
+		Os:      v1alpha1.DeviceOSStatus{Image: "os:2"},
+		Config:  v1alpha1.DeviceConfigStatus{RenderedVersion: "4"},
+	}, "tv-2", "5")
+	report.addDevice(&device)
+
+	require.Equal("fleet", report.Fleet)
+	require.Equal(now.UTC(), report.GeneratedAt)
+	require.Equal("tv-2", *report.TemplateVersion)
+	require.Equal("os:2", *report.TargetOsImage)
+	require.Equal(int64(3), report.TotalDevices)
+	require.Equal(int64(2), report.DevicesOnTargetOs)
+	require.Equal(int64(2), report.DevicesWithConfigDrift)
+	require.Equal(map[string]int64{"Online": 2, "Error": 1}, report.SummaryStatus)
+	require.Equal(map[string]int64{"NonCompliant": 1}, *report.ComplianceStatus)
+	require.Equal([]v1alpha1.FleetReportDevice{{
+		Name:          "dev3",
+		SummaryStatus: v1alpha1.DeviceSummaryStatusError,
+		OsImage:       "os:2",
+		Reason:        util.StrToPtr("disk full"),
+	}}, report.FailedDevices)
+}
+
+func TestFleetReportTargetOsLaggingSpec(t *testing.T) {
+	require := require.New(t)
+
+	fleet := v1alpha1.Fleet{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")}}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "os:2"}
+	report := newFleetReport(&fleet, nil, time.Now())
+
+	// device updated to the template whose spec has not been rendered yet
+	device := testReportDevice("dev1", "os:1", v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os:2"}}, "", "")
+	report.addDevice(&device)
+	// device running the OS image of its spec, which lags behind the template
+	device = testReportDevice("dev2", "os:1", v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os:1"}}, "", "")
+	report.addDevice(&device)
+
+	require.Equal(int64(2), report.TotalDevices)
+	require.Equal(int64(1), report.DevicesOnTargetOs)
+}
+
+func TestFleetReportTargetOsMissingSpecOs(t *testing.T) {
+	require := require.New(t)
+
+	device := testReportDevice("dev1", "", v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os:1"}}, "", "")
+	device.Spec.Os = nil
+
+	// a device without an OS image in its spec is still compared with the template
+	fleet := v1alpha1.Fleet{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")}}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "os:2"}
+	report := newFleetReport(&fleet, nil, time.Now())
+	report.addDevice(&device)
+	require.Equal(int64(0), report.DevicesOnTargetOs)
+
+	// without an OS image in the template, every device runs the target OS
+	fleet.Spec.Template.Spec.Os = nil
+	report = newFleetReport(&fleet, nil, time.Now())
+	report.addDevice(&device)
+	require.Nil(report.TargetOsImage)
+	require.Equal(int64(1), report.DevicesOnTargetOs)
+}
+
+func TestFleetReportTargetOsArchitectures(t *testing.T) {
+	require := require.New(t)
+
+	fleet := v1alpha1.Fleet{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")}}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{
+		Image:              "os:2",
+		ArchitectureImages: &map[string]string{"arm64": "os-arm64:2"},
+	}
+	templateVersion := &v1alpha1.TemplateVersion{Status: &v1alpha1.TemplateVersionStatus{Os: fleet.Spec.Template.Spec.Os}}
+	report := newFleetReport(&fleet, templateVersion, time.Now())
+
+	// each device is compared with the image of the template for its architecture
+	status := v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os-arm64:2"}}
+	status.SystemInfo.Architecture = "arm64"
+	device := testReportDevice("dev1", "os-arm64:2", status, "", "")
+	report.addDevice(&device)
+	status = v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os:2"}}
+	status.SystemInfo.Architecture = "amd64"
+	device = testReportDevice("dev2", "os:2", status, "", "")
+	report.addDevice(&device)
+	// an arm64 device running the default image is not on target
+	status = v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: "os:2"}}
+	status.SystemInfo.Architecture = "arm64"
+	device = testReportDevice("dev3", "os:2", status, "", "")
+	report.addDevice(&device)
+
+	require.Equal("os:2", *report.TargetOsImage)
+	require.Equal(int64(2), report.DevicesOnTargetOs)
+}
+
+func TestFleetReportTargetOsStream(t *testing.T) {
+	require := require.New(t)
+
+	pinned := "quay.io/example/os@sha256:" + strings.Repeat("a", 64)
+	fleet := v1alpha1.Fleet{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")}}
+	fleet.Spec.Template.Spec.Os = &v1alpha1.DeviceOSSpec{Image: "quay.io/example/os", Stream: util.StrToPtr("9-stable")}
+	templateVersion := &v1alpha1.TemplateVersion{Status: &v1alpha1.TemplateVersionStatus{
+		Os: &v1alpha1.DeviceOSSpec{Image: pinned, Stream: util.StrToPtr("9-stable")},
+	}}
+	report := newFleetReport(&fleet, templateVersion, time.Now())
+
+	// devices following the stream are compared with the image it resolved to
+	device := testReportDevice("dev1", pinned, v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: pinned}}, "", "")
+	report.addDevice(&device)
+	previous := "quay.io/example/os@sha256:" + strings.Repeat("b", 64)
+	device = testReportDevice("dev2", previous, v1alpha1.DeviceStatus{Os: v1alpha1.DeviceOSStatus{Image: previous}}, "", "")
+	report.addDevice(&device)
+
+	require.Equal(pinned, *report.TargetOsImage)
+	require.Equal(int64(1), report.DevicesOnTargetOs)
+}
+
+func TestFleetReportWorkloadEvents(t *testing.T) {
+	require := require.New(t)
+
+	fleet := v1alpha1.Fleet{Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")}}
+	report := newFleetReport(&fleet, nil, time.Now())
+	oomKill := v1alpha1.DeviceEvent{Type: v1alpha1.DeviceEventOOMKilled, Unit: util.StrToPtr("web.service")}
+	crash := v1alpha1.DeviceEvent{Type: v1alpha1.DeviceEventCrashed, Process: util.StrToPtr("sensor")}
+
+	device := testReportDevice("dev1", "os:2", v1alpha1.DeviceStatus{Events: &[]v1alpha1.DeviceEvent{crash, oomKill, oomKill}}, "", "")
+	report.addDevice(&device)
+	device = testReportDevice("dev2", "os:2", v1alpha1.DeviceStatus{Events: &[]v1alpha1.DeviceEvent{oomKill}}, "", "")
+	report.addDevice(&device)
+	device = testReportDevice("dev3", "os:2", v1alpha1.DeviceStatus{}, "", "")
+	report.addDevice(&device)
+
+	require.Equal([]v1alpha1.FleetReportWorkloadEvents{
+		{Workload: "web.service", Type: v1alpha1.DeviceEventOOMKilled, Devices: 2, Events: 3},
+		{Workload: "sensor", Type: v1alpha1.DeviceEventCrashed, Devices: 1, Events: 1},
+	}, *report.WorkloadEvents)
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/samber/lo"
+)
+
+// SpecImages returns the images the agent pulls for the device spec: the OS
+// image, the image of the agent update and the images of the containers of
+// the pods, along with the images they specify for each architecture. The
+// images of compose files are not known to the service.
+func SpecImages(spec *api.DeviceSpec) []string {
+	if spec == nil {
+		return nil
+	}
+	images := []string{}
+	add := func(image string) {
+		if image != "" && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+	addForArchitectures := func(image string, architectureImages *map[string]string) {
+		add(image)
+		architectures := lo.Keys(lo.FromPtr(architectureImages))
+		slices.Sort(architectures)
+		for _, architecture := range architectures {
+			add((*architectureImages)[architecture])
+		}
+	}
+	if spec.Os != nil {
+		addForArchitectures(spec.Os.Image, spec.Os.ArchitectureImages)
+	}
+	if spec.Agent != nil && spec.Agent.Update != nil {
+		add(lo.FromPtr(spec.Agent.Update.Image))
+	}
+	for _, application := range lo.FromPtr(spec.Applications) {
+		if application.Pod == nil {
+			continue
+		}
+		for _, container := range lo.FromPtr(application.Pod.InitContainers) {
+			addForArchitectures(container.Image, container.ArchitectureImages)
+		}
+		for _, container := range application.Pod.Containers {
+			addForArchitectures(container.Image, container.ArchitectureImages)
+		}
+	}
+	return images
+}
+
+// UnpinnedImages returns the images of the device spec that are referenced by
+// a tag rather than a digest. The OS image of a fleet template following a
+// stream is not reported, since it is pinned when the template is rendered.
+func UnpinnedImages(spec *api.DeviceSpec, template bool) []string {
+	if spec == nil {
+		return nil
+	}
+	streamImage := ""
+	if template && spec.Os != nil && spec.Os.Stream != nil {
+		streamImage = spec.Os.Image
+	}
+	return lo.Filter(SpecImages(spec), func(image string, _ int) bool {
+		if image == streamImage {
+			return false
+		}
+		ref, err := registry.ParseReference(image)
+		return err != nil || ref.Digest == ""
+	})
+}
+
+// CheckImageDigests returns an error naming the images of the device spec
+// that are referenced by a tag rather than a digest, as the service requires
+// when it is configured with requireImageDigests.
+func CheckImageDigests(spec *api.DeviceSpec, template bool) error {
+	unpinned := UnpinnedImages(spec, template)
+	if len(unpinned) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the service requires images pinned by digest, but these are referenced by tag: %s", strings.Join(unpinned, ", "))
+}
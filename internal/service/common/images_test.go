@@ -0,0 +1,49 @@
+package common
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpinnedImages(t *testing.T) {
+	require := require.New(t)
+	spec := &api.DeviceSpec{
+		Os: &api.DeviceOSSpec{Image: "quay.io/org/os", Stream: lo.ToPtr("9-stable")},
+		Applications: &[]api.ApplicationSpec{
+			{Name: "pod", Pod: &api.ApplicationPodSpec{
+				InitContainers: &[]api.ApplicationContainer{{Name: "migrate", Image: "quay.io/org/migrate:1"}},
+				Containers:     []api.ApplicationContainer{{Name: "app", Image: "quay.io/org/app@sha256:1a2b"}},
+			}},
+		},
+	}
+
+	// the stream of a fleet template is pinned when it is rendered
+	require.Equal([]string{"quay.io/org/migrate:1"}, UnpinnedImages(spec, true))
+	require.Equal([]string{"quay.io/org/os", "quay.io/org/migrate:1"}, UnpinnedImages(spec, false))
+	require.Empty(UnpinnedImages(nil, false))
+}
+
+func TestSpecImagesForArchitectures(t *testing.T) {
+	require := require.New(t)
+	spec := &api.DeviceSpec{
+		Os: &api.DeviceOSSpec{Image: "quay.io/org/os:9", ArchitectureImages: &map[string]string{"arm64": "quay.io/org/os-arm:9", "amd64": "quay.io/org/os:9"}},
+		Applications: &[]api.ApplicationSpec{
+			{Name: "pod", Pod: &api.ApplicationPodSpec{
+				Containers: []api.ApplicationContainer{{Name: "app", Image: "quay.io/org/app@sha256:1a2b", ArchitectureImages: &map[string]string{"arm64": "quay.io/org/app-arm:1"}}},
+			}},
+		},
+	}
+
+	// the images of all architectures are checked against the image policy
+	require.Equal([]string{"quay.io/org/os:9", "quay.io/org/os-arm:9", "quay.io/org/app@sha256:1a2b", "quay.io/org/app-arm:1"}, SpecImages(spec))
+	require.Equal([]string{"quay.io/org/os:9", "quay.io/org/os-arm:9", "quay.io/org/app-arm:1"}, UnpinnedImages(spec, false))
+
+	// the agent of a device pulls the images of its architecture only
+	require.Equal([]string{"quay.io/org/os-arm:9", "quay.io/org/app-arm:1"}, SpecImages(spec.ForArchitecture("arm64")))
+	require.Equal([]string{"quay.io/org/os:9", "quay.io/org/app@sha256:1a2b"}, SpecImages(spec.ForArchitecture("s390x")))
+	require.Equal([]string{"quay.io/org/os:9", "quay.io/org/app@sha256:1a2b"}, SpecImages(spec.ForArchitecture("")))
+	require.NotNil(spec.Os.ArchitectureImages)
+}
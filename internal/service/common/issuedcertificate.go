@@ -0,0 +1,35 @@
+package common
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+)
+
+// RecordIssuedCertificate adds a PEM encoded certificate issued by the service
+// to the inventory of issued certificates. The owner, as returned by
+// util.SetResourceOwner, is the resource the certificate was issued for.
+func RecordIssuedCertificate(ctx context.Context, st store.Store, orgId uuid.UUID, certPEM []byte, usage api.IssuedCertificateUsage, owner *string, device *string) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return errors.New("issued certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	return st.IssuedCertificate().Record(ctx, orgId, &api.IssuedCertificate{
+		SerialNumber: cert.SerialNumber.Text(16),
+		CommonName:   cert.Subject.CommonName,
+		Usage:        usage,
+		Owner:        owner,
+		Device:       device,
+		NotBefore:    cert.NotBefore.UTC(),
+		NotAfter:     cert.NotAfter.UTC(),
+	})
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/samber/lo"
+)
+
+// CheckLabelSchemas returns why the labels of a device do not comply with the
+// label schemas, or an empty string if they do. The current device is the
+// device before the change, nil if it is being created or enrolled.
+//
+// Devices which predate a schema keep their missing or disallowed labels, but
+// once set, a label has to comply with the schema.
+func CheckLabelSchemas(schemas []config.LabelSchema, current *api.Device, labels *map[string]string) string {
+	creating := current == nil
+	currentLabels, newLabels := map[string]string{}, lo.FromPtr(labels)
+	if current != nil {
+		currentLabels = lo.FromPtr(current.Metadata.Labels)
+	}
+	problems := []string{}
+	for _, schema := range schemas {
+		value, set := newLabels[schema.Key]
+		currentValue, wasSet := currentLabels[schema.Key]
+		switch {
+		case !set && schema.Required && (creating || wasSet):
+			problems = append(problems, fmt.Sprintf("label %q is required", schema.Key))
+		case wasSet && schema.Immutable && (!set || value != currentValue):
+			problems = append(problems, fmt.Sprintf("label %q is immutable", schema.Key))
+		case set && len(schema.AllowedValues) > 0 && !lo.Contains(schema.AllowedValues, value) && (!wasSet || value != currentValue):
+			problems = append(problems, fmt.Sprintf("label %q must be one of %s, not %q", schema.Key, strings.Join(schema.AllowedValues, ", "), value))
+		}
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	return "the labels do not comply with the label schemas of the service: " + strings.Join(problems, "; ")
+}
@@ -0,0 +1,25 @@
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/remotewrite"
+)
+
+func PushDeviceMetrics(ctx context.Context, forwarder *remotewrite.Forwarder, request server.PushDeviceMetricsRequestObject) (server.PushDeviceMetricsResponseObject, error) {
+	if forwarder == nil {
+		return server.PushDeviceMetrics503JSONResponse{Message: "no metrics receiver is configured"}, nil
+	}
+
+	err := forwarder.Forward(ctx, request.Name, request.Body)
+	switch {
+	case err == nil:
+		return server.PushDeviceMetrics204Response{}, nil
+	case errors.Is(err, remotewrite.ErrInvalidPayload):
+		return server.PushDeviceMetrics400JSONResponse{Message: err.Error()}, nil
+	default:
+		return server.PushDeviceMetrics503JSONResponse{Message: err.Error()}, nil
+	}
+}
@@ -0,0 +1,313 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/samber/lo"
+)
+
+// NegotiateRenderedSpecVersion returns the latest rendered spec version
+// supported by both the agent and the service. Requests which announce no
+// versions are served the latest version.
+func NegotiateRenderedSpecVersion(agentVersions *[]string) (string, error) {
+	latest := api.RenderedSpecVersions[len(api.RenderedSpecVersions)-1]
+	if agentVersions == nil || len(*agentVersions) == 0 {
+		return latest, nil
+	}
+	for i := len(api.RenderedSpecVersions) - 1; i >= 0; i-- {
+		if lo.Contains(*agentVersions, api.RenderedSpecVersions[i]) {
+			return api.RenderedSpecVersions[i], nil
+		}
+	}
+	return "", fmt.Errorf("%w: agent supports %s, service supports %s", flterrors.ErrNoCommonSpecVersion,
+		strings.Join(*agentVersions, ","), strings.Join(api.RenderedSpecVersions, ","))
+}
+
+// CheckRenderedDeviceSpec returns an error if an agent of the given version
+// would apply the rendered spec rather than only check it, since removing
+// agent.checkOnly makes an older agent apply the spec.
+func CheckRenderedDeviceSpec(spec *api.RenderedDeviceSpec, version string) error {
+	if spec.Agent != nil && lo.FromPtr(spec.Agent.CheckOnly) && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion25) {
+		return fmt.Errorf("agent supports rendered spec version %s, which cannot only check renderedVersion %s: check-only mode requires version %s",
+			version, spec.RenderedVersion, api.RenderedSpecVersion25)
+	}
+	return nil
+}
+
+// ConvertRenderedDeviceSpec converts the rendered spec to the schema of the
+// given version by removing the settings introduced by later versions, which
+// an agent of that version would ignore. It returns the removed settings.
+func ConvertRenderedDeviceSpec(spec *api.RenderedDeviceSpec, version string) []string {
+	removed := []string{}
+	if spec.Tunnel != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion27) {
+		spec.Tunnel = nil
+		removed = append(removed, "tunnel")
+	}
+	if spec.Console != nil && spec.Console.PublicKey != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion26) {
+		// an older agent would open the session in the clear, which the
+		// operator did not ask for
+		spec.Console = nil
+		removed = append(removed, "console.publicKey")
+	}
+	if spec.Drift != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion24) {
+		spec.Drift = nil
+		removed = append(removed, "drift")
+	}
+	if spec.Action != nil && spec.Action.Action == api.DeviceActionEnforceSpec && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion23) {
+		// an older agent never holds back its spec, so there is nothing to
+		// enforce, and the request stays pending until it is canceled
+		spec.Action = nil
+		removed = append(removed, "action.enforceSpec")
+	}
+	if spec.GarbageCollection != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion22) {
+		spec.GarbageCollection = nil
+		removed = append(removed, "garbageCollection")
+	}
+	if spec.Applications != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion21) {
+		// an older agent syncs the applications one after the other, in the
+		// order of the spec
+		if applications, ok := withoutApplicationDependencies(*spec.Applications); ok {
+			spec.Applications = &applications
+			removed = append(removed, "applications.dependsOn")
+		}
+	}
+	if spec.Firewall != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion20) {
+		spec.Firewall = nil
+		removed = append(removed, "firewall")
+	}
+	if spec.Action != nil && spec.Action.Action == api.DeviceActionExec && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion19) {
+		// as for Wake-on-LAN, the command stays pending until it is canceled
+		spec.Action = nil
+		removed = append(removed, "action.exec")
+	}
+	if spec.Migration != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion18) {
+		// an older agent cannot migrate, it keeps requesting specs from this
+		// instance until the migration is canceled
+		spec.Migration = nil
+		removed = append(removed, "migration")
+	}
+	if spec.Compliance != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion17) {
+		spec.Compliance = nil
+		removed = append(removed, "compliance")
+	}
+	if spec.ImageDigests != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion16) {
+		spec.ImageDigests = nil
+		removed = append(removed, "imageDigests")
+	}
+	if spec.Action != nil && spec.Action.Action == api.DeviceActionWakeOnLan && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion15) {
+		// an older agent would fail the action it does not know, so the
+		// request stays pending until it is canceled
+		spec.Action = nil
+		removed = append(removed, "action.wakeOnLan")
+	}
+	if spec.Action != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion14) {
+		spec.Action = nil
+		removed = append(removed, "action")
+	}
+	if spec.Applications != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion13) {
+		// an older agent cannot run a pod at all, so it does not run the
+		// application rather than failing on it
+		applications := lo.Reject(*spec.Applications, func(application api.ApplicationSpec, _ int) bool { return application.Pod != nil })
+		if len(applications) != len(*spec.Applications) {
+			spec.Applications = &applications
+			removed = append(removed, "applications.pod")
+		}
+	}
+	if spec.Applications != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion12) {
+		if applications, ok := withoutApplicationResources(*spec.Applications); ok {
+			spec.Applications = &applications
+			removed = append(removed, "applications.resources")
+		}
+	}
+	if spec.Applications != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion11) {
+		if applications, ok := withoutApplicationVolumes(*spec.Applications); ok {
+			spec.Applications = &applications
+			removed = append(removed, "applications.volumes")
+		}
+	}
+	if spec.Applications != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion10) {
+		spec.Applications = nil
+		removed = append(removed, "applications")
+	}
+	if spec.Hooks != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion9) {
+		if hooks, ok := withoutHookBatches(spec.Hooks); ok {
+			spec.Hooks = hooks
+			removed = append(removed, "hooks.batch")
+		}
+	}
+	if spec.Hooks != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion8) {
+		if hooks, ok := withoutHookSandboxes(spec.Hooks); ok {
+			spec.Hooks = hooks
+			removed = append(removed, "hooks.sandbox")
+		}
+	}
+	if spec.Quarantine != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion6) {
+		spec.Quarantine = nil
+		removed = append(removed, "quarantine")
+	}
+	if spec.Time != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion5) {
+		spec.Time = nil
+		removed = append(removed, "time")
+	}
+	if spec.Encryption != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion4) {
+		spec.Encryption = nil
+		removed = append(removed, "encryption")
+	}
+	if spec.Agent == nil {
+		return removed
+	}
+
+	if !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion7) {
+		agent := *spec.Agent
+		if agent.LogLevel != nil {
+			agent.LogLevel = nil
+			removed = append(removed, "agent.logLevel")
+		}
+		if agent.MonitorThresholds != nil {
+			agent.MonitorThresholds = nil
+			removed = append(removed, "agent.monitorThresholds")
+		}
+		if agent.AllowedHookPaths != nil {
+			agent.AllowedHookPaths = nil
+			removed = append(removed, "agent.allowedHookPaths")
+		}
+		spec.Agent = &agent
+	}
+	if spec.Agent.Update != nil && !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion3) {
+		agent := *spec.Agent
+		agent.Update = nil
+		spec.Agent = &agent
+		removed = append(removed, "agent.update")
+	}
+	if !atLeastRenderedSpecVersion(version, api.RenderedSpecVersion2) {
+		spec.Agent = nil
+		removed = append(removed, "agent")
+	}
+	return removed
+}
+
+// withoutApplicationDependencies returns a copy of the applications without
+// their dependencies, and whether any application set them.
+func withoutApplicationDependencies(applications []api.ApplicationSpec) ([]api.ApplicationSpec, bool) {
+	found := false
+	stripped := slices.Clone(applications)
+	for i := range stripped {
+		if stripped[i].DependsOn != nil {
+			stripped[i].DependsOn = nil
+			found = true
+		}
+	}
+	return stripped, found
+}
+
+// withoutApplicationResources returns a copy of the applications without their
+// resource limits, and whether any application set them.
+func withoutApplicationResources(applications []api.ApplicationSpec) ([]api.ApplicationSpec, bool) {
+	found := false
+	stripped := slices.Clone(applications)
+	for i := range stripped {
+		if stripped[i].Resources != nil {
+			stripped[i].Resources = nil
+			found = true
+		}
+	}
+	return stripped, found
+}
+
+// withoutApplicationVolumes returns a copy of the applications without their
+// volumes and data retention, and whether any application set them.
+func withoutApplicationVolumes(applications []api.ApplicationSpec) ([]api.ApplicationSpec, bool) {
+	found := false
+	stripped := slices.Clone(applications)
+	for i := range stripped {
+		if stripped[i].Volumes != nil || stripped[i].RetainData != nil {
+			stripped[i].Volumes = nil
+			stripped[i].RetainData = nil
+			found = true
+		}
+	}
+	return stripped, found
+}
+
+// withoutHookBatches returns a copy of the hooks running their actions for
+// each changed file, and whether any hook was batched.
+func withoutHookBatches(hooks *api.DeviceHooksSpec) (*api.DeviceHooksSpec, bool) {
+	found := false
+	stripUpdateHooks := func(hookSpecs *[]api.DeviceUpdateHookSpec) *[]api.DeviceUpdateHookSpec {
+		if hookSpecs == nil {
+			return nil
+		}
+		stripped := slices.Clone(*hookSpecs)
+		for i := range stripped {
+			if stripped[i].Batch != nil {
+				stripped[i].Batch = nil
+				found = true
+			}
+		}
+		return &stripped
+	}
+
+	result := *hooks
+	result.BeforeUpdating = stripUpdateHooks(hooks.BeforeUpdating)
+	result.AfterUpdating = stripUpdateHooks(hooks.AfterUpdating)
+	return &result, found
+}
+
+// withoutHookSandboxes returns a copy of the hooks without the sandboxes of
+// their executable actions, and whether any action had a sandbox.
+func withoutHookSandboxes(hooks *api.DeviceHooksSpec) (*api.DeviceHooksSpec, bool) {
+	found := false
+	stripActions := func(actions []api.HookAction) []api.HookAction {
+		stripped := slices.Clone(actions)
+		for i := range stripped {
+			if actionType, err := stripped[i].Type(); err != nil || actionType != "executable" {
+				continue
+			}
+			action, err := stripped[i].AsHookAction0()
+			if err != nil || action.Executable.Sandbox == nil {
+				continue
+			}
+			action.Executable.Sandbox = nil
+			if err := stripped[i].FromHookAction0(action); err == nil {
+				found = true
+			}
+		}
+		return stripped
+	}
+	stripUpdateHooks := func(hookSpecs *[]api.DeviceUpdateHookSpec) *[]api.DeviceUpdateHookSpec {
+		if hookSpecs == nil {
+			return nil
+		}
+		stripped := slices.Clone(*hookSpecs)
+		for i := range stripped {
+			stripped[i].Actions = stripActions(stripped[i].Actions)
+		}
+		return &stripped
+	}
+	stripRebootHooks := func(hookSpecs *[]api.DeviceRebootHookSpec) *[]api.DeviceRebootHookSpec {
+		if hookSpecs == nil {
+			return nil
+		}
+		stripped := slices.Clone(*hookSpecs)
+		for i := range stripped {
+			stripped[i].Actions = stripActions(stripped[i].Actions)
+		}
+		return &stripped
+	}
+
+	result := &api.DeviceHooksSpec{
+		BeforeUpdating:  stripUpdateHooks(hooks.BeforeUpdating),
+		AfterUpdating:   stripUpdateHooks(hooks.AfterUpdating),
+		BeforeRebooting: stripRebootHooks(hooks.BeforeRebooting),
+		AfterRebooting:  stripRebootHooks(hooks.AfterRebooting),
+	}
+	return result, found
+}
+
+func atLeastRenderedSpecVersion(version string, minVersion string) bool {
+	return lo.IndexOf(api.RenderedSpecVersions, version) >= lo.IndexOf(api.RenderedSpecVersions, minVersion)
+}
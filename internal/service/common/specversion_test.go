@@ -0,0 +1,568 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateRenderedSpecVersion(t *testing.T) {
+	testCases := []struct {
+		name          string
+		agentVersions *[]string
+		expected      string
+		expectErr     bool
+	}{
+		{
+			name:     "no versions announced",
+			expected: api.RenderedSpecVersion27,
+		},
+		{
+			name:          "first version only",
+			agentVersions: &[]string{api.RenderedSpecVersion1},
+			expected:      api.RenderedSpecVersion1,
+		},
+		{
+			name:          "latest common version",
+			agentVersions: &[]string{api.RenderedSpecVersion1, api.RenderedSpecVersion2},
+			expected:      api.RenderedSpecVersion2,
+		},
+		{
+			name:          "agent newer than the service",
+			agentVersions: &[]string{api.RenderedSpecVersion4, api.RenderedSpecVersion5, "100"},
+			expected:      api.RenderedSpecVersion5,
+		},
+		{
+			name:          "no common version",
+			agentVersions: &[]string{"100"},
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			version, err := NegotiateRenderedSpecVersion(tc.agentVersions)
+			if tc.expectErr {
+				require.True(errors.Is(err, flterrors.ErrNoCommonSpecVersion))
+				return
+			}
+			require.NoError(err)
+			require.Equal(tc.expected, version)
+		})
+	}
+}
+
+func TestConvertRenderedDeviceSpec(t *testing.T) {
+	newSpec := func() *api.RenderedDeviceSpec {
+		action := api.HookAction{}
+		_ = action.FromHookAction0(api.HookAction0{Executable: api.HookActionExecutableSpec{
+			Run:     "systemctl reload app",
+			Sandbox: &api.HookActionSandbox{User: lo.ToPtr("app")},
+		}})
+		return &api.RenderedDeviceSpec{
+			Hooks: &api.DeviceHooksSpec{
+				AfterUpdating: &[]api.DeviceUpdateHookSpec{{Path: lo.ToPtr("/etc/app/*.conf"), Batch: lo.ToPtr(true), Actions: []api.HookAction{action}}},
+			},
+			RenderedVersion: "5",
+			Os:              &api.DeviceOSSpec{Image: "quay.io/flightctl/device:v2"},
+			Agent: &api.DeviceAgentSpec{
+				SpecFetchInterval: lo.ToPtr("5m"),
+				LogLevel:          lo.ToPtr(api.DeviceAgentSpecLogLevelDebug),
+				AllowedHookPaths:  &[]string{"/etc/app"},
+				Update:            &api.AgentUpdateSpec{Version: "v0.3.1", Image: lo.ToPtr("quay.io/flightctl/flightctl-agent:v0.3.1")},
+			},
+			Encryption: &api.DeviceEncryptionSpec{
+				Volumes: []api.EncryptedVolumeSpec{{Device: "/dev/vda4", Tpm2: &api.TpmPinSpec{}}},
+			},
+			Time:       &api.DeviceTimeSpec{Servers: &[]string{"ntp.example.com"}},
+			Quarantine: &api.DeviceQuarantine{Reason: "incident 42"},
+			Action:     &api.DeviceAction{Id: "1", Action: api.DeviceActionReboot},
+			Applications: &[]api.ApplicationSpec{{
+				Name:           "web",
+				Path:           lo.ToPtr("/var/run/flightctl/compose/web.yaml"),
+				UpdateStrategy: &api.ApplicationUpdateStrategy{Type: api.ApplicationUpdateStrategyInPlace},
+				Volumes:        &[]api.ApplicationVolume{{Name: "data"}},
+				Resources:      &api.ApplicationResources{Memory: lo.ToPtr("512Mi")},
+			}, {
+				Name: "db",
+				Pod:  &api.ApplicationPodSpec{Containers: []api.ApplicationContainer{{Name: "postgres", Image: "quay.io/sclorg/postgresql-15-c9s"}}},
+			}},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		version          string
+		expectAgent      bool
+		expectUpdate     bool
+		expectEncryption bool
+		expectTime       bool
+		expectQuarantine bool
+		expectAction     bool
+		expectSettings   bool
+		expectSandbox    bool
+		expectBatch      bool
+		expectApps       bool
+		expectVolumes    bool
+		expectResources  bool
+		expectPods       bool
+		expectRemoved    []string
+	}{
+		{
+			name:             "latest version",
+			version:          api.RenderedSpecVersion16,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectAction:     true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectApps:       true,
+			expectVolumes:    true,
+			expectResources:  true,
+			expectPods:       true,
+			expectRemoved:    []string{},
+		},
+		{
+			name:             "agent without device actions",
+			version:          api.RenderedSpecVersion13,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectApps:       true,
+			expectVolumes:    true,
+			expectResources:  true,
+			expectPods:       true,
+			expectRemoved:    []string{"action"},
+		},
+		{
+			name:             "agent without pods",
+			version:          api.RenderedSpecVersion12,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectApps:       true,
+			expectVolumes:    true,
+			expectResources:  true,
+			expectRemoved:    []string{"action", "applications.pod"},
+		},
+		{
+			name:             "agent without application resource limits",
+			version:          api.RenderedSpecVersion11,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectApps:       true,
+			expectVolumes:    true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources"},
+		},
+		{
+			name:             "agent without application volumes",
+			version:          api.RenderedSpecVersion10,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectApps:       true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes"},
+		},
+		{
+			name:             "agent without application update strategies",
+			version:          api.RenderedSpecVersion9,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectBatch:      true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications"},
+		},
+		{
+			name:             "agent without batched hooks",
+			version:          api.RenderedSpecVersion8,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectSandbox:    true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch"},
+		},
+		{
+			name:             "agent without hook sandboxes",
+			version:          api.RenderedSpecVersion7,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectSettings:   true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox"},
+		},
+		{
+			name:             "agent without log level and hook paths",
+			version:          api.RenderedSpecVersion6,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectQuarantine: true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "agent.logLevel", "agent.allowedHookPaths"},
+		},
+		{
+			name:             "agent without quarantine",
+			version:          api.RenderedSpecVersion5,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectTime:       true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "quarantine", "agent.logLevel", "agent.allowedHookPaths"},
+		},
+		{
+			name:             "agent without time synchronization",
+			version:          api.RenderedSpecVersion4,
+			expectAgent:      true,
+			expectUpdate:     true,
+			expectEncryption: true,
+			expectRemoved:    []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "quarantine", "time", "agent.logLevel", "agent.allowedHookPaths"},
+		},
+		{
+			name:          "agent without disk encryption",
+			version:       api.RenderedSpecVersion3,
+			expectAgent:   true,
+			expectUpdate:  true,
+			expectRemoved: []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "quarantine", "time", "encryption", "agent.logLevel", "agent.allowedHookPaths"},
+		},
+		{
+			name:          "agent without self-update",
+			version:       api.RenderedSpecVersion2,
+			expectAgent:   true,
+			expectRemoved: []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "quarantine", "time", "encryption", "agent.logLevel", "agent.allowedHookPaths", "agent.update"},
+		},
+		{
+			name:          "agent without agent settings",
+			version:       api.RenderedSpecVersion1,
+			expectRemoved: []string{"action", "applications.pod", "applications.resources", "applications.volumes", "applications", "hooks.batch", "hooks.sandbox", "quarantine", "time", "encryption", "agent.logLevel", "agent.allowedHookPaths", "agent.update", "agent"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			spec := newSpec()
+			agent := spec.Agent
+			hooks := spec.Hooks
+			applications := spec.Applications
+
+			removed := ConvertRenderedDeviceSpec(spec, tc.version)
+			require.Equal(tc.expectRemoved, removed)
+			require.Equal("quay.io/flightctl/device:v2", spec.Os.Image)
+			require.Equal(tc.expectAgent, spec.Agent != nil)
+			require.Equal(tc.expectEncryption, spec.Encryption != nil)
+			require.Equal(tc.expectTime, spec.Time != nil)
+			require.Equal(tc.expectQuarantine, spec.Quarantine != nil)
+			require.Equal(tc.expectAction, spec.Action != nil)
+			require.Equal(tc.expectApps, spec.Applications != nil)
+			if tc.expectApps {
+				require.Equal(tc.expectVolumes, (*spec.Applications)[0].Volumes != nil)
+				require.Equal(tc.expectResources, (*spec.Applications)[0].Resources != nil)
+				require.Equal(tc.expectPods, len(*spec.Applications) == 2)
+			}
+			if tc.expectAgent {
+				require.Equal(tc.expectUpdate, spec.Agent.Update != nil)
+				require.Equal(lo.ToPtr("5m"), spec.Agent.SpecFetchInterval)
+				require.Equal(tc.expectSettings, spec.Agent.LogLevel != nil)
+				require.Equal(tc.expectSettings, spec.Agent.AllowedHookPaths != nil)
+			}
+			sandboxed := func(spec *api.RenderedDeviceSpec) bool {
+				action, err := (*spec.Hooks.AfterUpdating)[0].Actions[0].AsHookAction0()
+				require.NoError(err)
+				return action.Executable.Sandbox != nil
+			}
+			require.Equal(tc.expectSandbox, sandboxed(spec))
+			require.Equal(tc.expectBatch, (*spec.Hooks.AfterUpdating)[0].Batch != nil)
+			// the agent settings are copied rather than modified in place
+			require.NotNil(agent.Update)
+			require.NotNil(agent.LogLevel)
+			require.NotNil((*hooks.AfterUpdating)[0].Batch)
+			require.NotNil((*applications)[0].Volumes)
+			require.NotNil((*applications)[0].Resources)
+			require.Len(*applications, 2)
+		})
+	}
+}
+
+func TestConvertWakeOnLanAction(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Action: &api.DeviceAction{Id: "1", Action: api.DeviceActionWakeOnLan, WakeOnLan: &api.DeviceWakeOnLan{
+				Target:       "kiosk-2",
+				MacAddresses: []string{"52:54:00:12:34:56"},
+			}},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion15))
+	require.NotNil(spec.Action)
+
+	spec = newSpec()
+	require.Equal([]string{"action.wakeOnLan"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion14))
+	require.Nil(spec.Action)
+}
+
+func TestConvertDrift(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Drift:           &api.DeviceDriftSpec{Remediation: lo.ToPtr(api.DriftReport)},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion24))
+	require.NotNil(spec.Drift)
+
+	spec = newSpec()
+	require.Equal([]string{"drift"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion23))
+	require.Nil(spec.Drift)
+}
+
+func TestConvertEncryptedConsole(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Console:         &api.DeviceConsole{SessionID: "1", GRPCEndpoint: "grpcs://localhost:7444", PublicKey: lo.ToPtr("key")},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion26))
+	require.NotNil(spec.Console)
+
+	spec = newSpec()
+	require.Equal([]string{"console.publicKey"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion25))
+	require.Nil(spec.Console)
+
+	// plaintext sessions are served to older agents as they were
+	spec = newSpec()
+	spec.Console.PublicKey = nil
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion25))
+	require.NotNil(spec.Console)
+}
+
+func TestConvertTunnel(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Tunnel:          &api.DeviceTunnel{Id: "1", Port: 8080, PublicKey: "key", RequestedBy: "alice", Reason: "vendor UI", Ttl: "1h"},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion27))
+	require.NotNil(spec.Tunnel)
+
+	spec = newSpec()
+	require.Equal([]string{"tunnel"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion26))
+	require.Nil(spec.Tunnel)
+}
+
+func TestCheckRenderedDeviceSpec(t *testing.T) {
+	require := require.New(t)
+	spec := &api.RenderedDeviceSpec{RenderedVersion: "1", Agent: &api.DeviceAgentSpec{CheckOnly: lo.ToPtr(true)}}
+
+	require.NoError(CheckRenderedDeviceSpec(spec, api.RenderedSpecVersion25))
+	require.Error(CheckRenderedDeviceSpec(spec, api.RenderedSpecVersion24))
+
+	spec.Agent.CheckOnly = lo.ToPtr(false)
+	require.NoError(CheckRenderedDeviceSpec(spec, api.RenderedSpecVersion24))
+}
+
+func TestConvertEnforceSpecAction(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Action:          &api.DeviceAction{Id: "1", Action: api.DeviceActionEnforceSpec},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion23))
+	require.NotNil(spec.Action)
+
+	spec = newSpec()
+	require.Equal([]string{"action.enforceSpec"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion22))
+	require.Nil(spec.Action)
+}
+
+func TestConvertApplicationDependencies(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Applications: &[]api.ApplicationSpec{
+				{Name: "broker", Path: lo.ToPtr("/etc/compose/broker.yaml")},
+				{Name: "web", Path: lo.ToPtr("/etc/compose/web.yaml"), DependsOn: &[]string{"broker"}},
+			},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion21))
+	require.Equal(&[]string{"broker"}, (*spec.Applications)[1].DependsOn)
+
+	spec = newSpec()
+	require.Equal([]string{"applications.dependsOn"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion20))
+	require.Len(*spec.Applications, 2)
+	require.Nil((*spec.Applications)[1].DependsOn)
+}
+
+func TestConvertGarbageCollection(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion:   "1",
+			GarbageCollection: &api.DeviceGarbageCollectionSpec{KeepOsDeployments: lo.ToPtr(int32(0))},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion22))
+	require.NotNil(spec.GarbageCollection)
+
+	spec = newSpec()
+	require.Equal([]string{"garbageCollection"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion21))
+	require.Nil(spec.GarbageCollection)
+}
+
+func TestConvertFirewall(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Firewall:        &api.DeviceFirewallSpec{InputPolicy: lo.ToPtr(api.FirewallDrop)},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion20))
+	require.NotNil(spec.Firewall)
+
+	spec = newSpec()
+	require.Equal([]string{"firewall"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion19))
+	require.Nil(spec.Firewall)
+}
+
+func TestConvertExecAction(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Action: &api.DeviceAction{Id: "1", Action: api.DeviceActionExec, Exec: &api.DeviceExec{
+				Command: "/usr/bin/systemctl",
+				Args:    &[]string{"restart", "kiosk.service"},
+			}},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion19))
+	require.NotNil(spec.Action)
+
+	spec = newSpec()
+	require.Equal([]string{"action.exec"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion18))
+	require.Nil(spec.Action)
+}
+
+func TestConvertImageDigests(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Os:              &api.DeviceOSSpec{Image: "quay.io/example/os:9-stable"},
+			ImageDigests:    &[]api.ImageDigest{{Image: "quay.io/example/os:9-stable", Digest: lo.ToPtr("sha256:4f5a")}},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion16))
+	require.NotNil(spec.ImageDigests)
+
+	spec = newSpec()
+	require.Equal([]string{"imageDigests"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion15))
+	require.Nil(spec.ImageDigests)
+	require.NotNil(spec.Os)
+}
+
+func TestConvertCompliance(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Compliance:      &api.DeviceComplianceSpec{Profile: "cis"},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion17))
+	require.NotNil(spec.Compliance)
+
+	spec = newSpec()
+	require.Equal([]string{"compliance"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion16))
+	require.Nil(spec.Compliance)
+}
+
+func TestConvertMigration(t *testing.T) {
+	require := require.New(t)
+	newSpec := func() *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: "1",
+			Migration:       &api.DeviceMigration{ManagementEndpoint: "https://flightctl.example.com:7443"},
+		}
+	}
+
+	spec := newSpec()
+	require.Equal([]string{}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion18))
+	require.NotNil(spec.Migration)
+
+	spec = newSpec()
+	require.Equal([]string{"migration"}, ConvertRenderedDeviceSpec(spec, api.RenderedSpecVersion17))
+	require.Nil(spec.Migration)
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"testing"
 
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -77,3 +79,82 @@ func TestConvertSelectorToFieldsMap(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBoundingBox(t *testing.T) {
+	require := require.New(t)
+	tests := []struct {
+		name    string
+		param   *string
+		want    *store.BoundingBox
+		wantErr bool
+	}{
+		{
+			name:  "unset",
+			param: nil,
+			want:  nil,
+		},
+		{
+			name:  "valid box",
+			param: lo.ToPtr("2.2,48.8,2.5,48.9"),
+			want:  &store.BoundingBox{West: 2.2, South: 48.8, East: 2.5, North: 48.9},
+		},
+		{
+			name:  "box crossing the antimeridian",
+			param: lo.ToPtr("170, -50, -170, -30"),
+			want:  &store.BoundingBox{West: 170, South: -50, East: -170, North: -30},
+		},
+		{
+			name:    "missing coordinate",
+			param:   lo.ToPtr("2.2,48.8,2.5"),
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			param:   lo.ToPtr("2.2,north,2.5,48.9"),
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			param:   lo.ToPtr("2.2,48.8,2.5,91"),
+			wantErr: true,
+		},
+		{
+			name:    "longitude out of range",
+			param:   lo.ToPtr("-181,48.8,2.5,48.9"),
+			wantErr: true,
+		},
+		{
+			name:    "south greater than north",
+			param:   lo.ToPtr("2.2,48.9,2.5,48.8"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBoundingBox(tt.param)
+			if tt.wantErr {
+				require.ErrorIs(err, ErrorInvalidBoundingBox)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestParseAnnotationSelector(t *testing.T) {
+	require := require.New(t)
+	requirements, err := ParseAnnotationSelector(nil)
+	require.NoError(err)
+	require.Nil(requirements)
+
+	requirements, err = ParseAnnotationSelector(lo.ToPtr("hardware.flightctl.io/changed, disk=replace"))
+	require.NoError(err)
+	require.Equal([]store.AnnotationRequirement{
+		{Key: "hardware.flightctl.io/changed"},
+		{Key: "disk", Value: lo.ToPtr("replace")},
+	}, requirements)
+
+	_, err = ParseAnnotationSelector(lo.ToPtr("bad key=value"))
+	require.ErrorIs(err, ErrorInvalidAnnotation)
+}
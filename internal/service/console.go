@@ -2,19 +2,31 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 )
 
 func (h *ServiceHandler) RequestConsole(ctx context.Context, request server.RequestConsoleRequestObject) (server.RequestConsoleResponseObject, error) {
 	orgId := store.NullOrgId
 
+	publicKey := lo.FromPtr(request.Params.PublicKey)
+	if publicKey != "" {
+		if _, err := console.ParsePublicKey(publicKey); err != nil {
+			return server.RequestConsole400JSONResponse{Message: fmt.Sprintf("invalid public key: %v", err)}, nil
+		}
+	}
+
 	// make sure the device exists
-	_, err := h.store.Device().Get(ctx, orgId, request.Name)
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
 	if err != nil {
 		switch err {
 		case flterrors.ErrResourceNotFound:
@@ -23,19 +35,47 @@ func (h *ServiceHandler) RequestConsole(ctx context.Context, request server.Requ
 			return nil, err
 		}
 	}
+	if isQuarantined(device) {
+		return server.RequestConsole409JSONResponse{Message: "the device is quarantined"}, nil
+	}
+
+	var grant *api.ConsoleGrant
+	if h.requiresConsoleGrant(device) {
+		var denied string
+		grant, denied, err = h.useConsoleGrant(ctx, orgId, device)
+		if err != nil {
+			return nil, err
+		}
+		if denied != "" {
+			return server.RequestConsole409JSONResponse{Message: denied}, nil
+		}
+	}
 
 	sessionId := uuid.New().String()
 
-	annotations := map[string]string{model.DeviceAnnotationConsole: sessionId}
+	annotations := map[string]string{model.DeviceAnnotationConsole: model.ConsoleAnnotation(sessionId, publicKey)}
+	deleteKeys := []string{}
+	if grant != nil {
+		deleteKeys = append(deleteKeys, model.DeviceAnnotationConsoleGrant)
+	}
 
-	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, annotations, []string{}); err != nil {
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, annotations, deleteKeys); err != nil {
 		return server.RequestConsole401JSONResponse{Message: "Unable to annotate device for console setup"}, err
 	}
+	if grant != nil {
+		auditConsoleGrant(h.log, request.Name, grant, consoleGrantUsed, fmt.Sprintf("console session %s", sessionId))
+	}
 
 	// create a new console session
-	return server.RequestConsole200JSONResponse{
+	session := server.RequestConsole200JSONResponse{
 		SessionID:    sessionId,
 		GRPCEndpoint: h.consoleGrpcEndpoint,
-	}, nil
-
+	}
+	if publicKey != "" {
+		// the operator verifies the device of the session against the CAs
+		// of the device certificates
+		session.PublicKey = &publicKey
+		session.CaBundle = lo.ToPtr(string(crypto.EncodeCertificates(h.ca.ClientCACerts())))
+	}
+	return session, nil
 }
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestEndToEndConsole(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("foo")}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, ca: ca, log: logrus.New(), consoleGrpcEndpoint: "grpcs://localhost:7444"}
+
+	resp, err := h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo", Params: v1alpha1.RequestConsoleParams{PublicKey: lo.ToPtr("bm90IGEga2V5")}})
+	require.NoError(err)
+	require.IsType(server.RequestConsole400JSONResponse{}, resp)
+	require.NotContains(lo.FromPtr(device.device.Metadata.Annotations), model.DeviceAnnotationConsole)
+
+	// the public key of the operator is passed on to the device, and the
+	// operator is given the CAs to verify the device against
+	operatorKey, err := console.NewOperatorKey()
+	require.NoError(err)
+	resp, err = h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo", Params: v1alpha1.RequestConsoleParams{PublicKey: lo.ToPtr(operatorKey.PublicKey())}})
+	require.NoError(err)
+	session, ok := resp.(server.RequestConsole200JSONResponse)
+	require.True(ok)
+	require.Equal(operatorKey.PublicKey(), lo.FromPtr(session.PublicKey))
+	require.Equal(string(crypto.EncodeCertificates(ca.Config.Certs)), lo.FromPtr(session.CaBundle))
+	sessionID, publicKey := model.ParseConsoleAnnotation((*device.device.Metadata.Annotations)[model.DeviceAnnotationConsole])
+	require.Equal(session.SessionID, sessionID)
+	require.Equal(operatorKey.PublicKey(), publicKey)
+
+	// plaintext sessions keep the annotation they had
+	resp, err = h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	session, ok = resp.(server.RequestConsole200JSONResponse)
+	require.True(ok)
+	require.Nil(session.CaBundle)
+	require.Equal(session.SessionID, (*device.device.Metadata.Annotations)[model.DeviceAnnotationConsole])
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// consoleGrantRoleResource is the resource users are allowed the create,
+// approve and delete verbs on to request, approve and revoke console grants.
+// The service does not verify the names of the requester and the approver,
+// so granting the approve verb to other users than the create verb is what
+// makes two users take part in each grant.
+const consoleGrantRoleResource = "devices/consolegrant"
+
+// Events of the audit log of console grants.
+const (
+	consoleGrantRequested = "Requested"
+	consoleGrantApproved  = "Approved"
+	consoleGrantRevoked   = "Revoked"
+	consoleGrantUsed      = "Used"
+	consoleGrantExpired   = "Expired"
+)
+
+// SetConsoleGrants makes opening a console on the devices selected by the
+// configuration require a one-off grant approved by a second user.
+func (h *ServiceHandler) SetConsoleGrants(grants *config.ConsoleGrantConfig) {
+	if grants == nil {
+		h.consoleGrants = nil
+		return
+	}
+	selector, err := labels.Parse(grants.Selector)
+	if err != nil {
+		// the configuration is validated when it is loaded, an invalid
+		// selector gates the consoles of all devices rather than none
+		selector = labels.Everything()
+	}
+	h.consoleGrants = selector
+	h.consoleGrantMaxTTL = grants.GrantMaxTTL()
+}
+
+// requiresConsoleGrant returns whether opening a console on the device
+// requires an approved console grant.
+func (h *ServiceHandler) requiresConsoleGrant(device *api.Device) bool {
+	return h.consoleGrants != nil && h.consoleGrants.Matches(labels.Set(lo.FromPtr(device.Metadata.Labels)))
+}
+
+// consoleGrant returns the console grant of the device, or nil if it has none.
+func consoleGrant(device *api.Device) (*api.ConsoleGrant, error) {
+	val, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationConsoleGrant]
+	if !ok {
+		return nil, nil
+	}
+	var grant api.ConsoleGrant
+	if err := json.Unmarshal([]byte(val), &grant); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationConsoleGrant, err)
+	}
+	return &grant, nil
+}
+
+// grantExpired returns whether the grant was approved and expired.
+// Pending grants do not expire, their TTL starts once they are approved.
+func grantExpired(grant *api.ConsoleGrant, now time.Time) bool {
+	return grant.ExpiresAt != nil && !now.Before(*grant.ExpiresAt)
+}
+
+// auditConsoleGrant writes an event of a console grant to the audit log,
+// which are the service logs flagged with the audit field. The names of the
+// requester and the approver are the ones they gave, which the service does
+// not verify.
+func auditConsoleGrant(log logrus.FieldLogger, deviceName string, grant *api.ConsoleGrant, event string, message string) {
+	entry := fmt.Sprintf("device %s: console grant %s %s", deviceName, grant.Id, strings.ToLower(event))
+	if message != "" {
+		entry += ": " + message
+	}
+	log.WithFields(logrus.Fields{
+		"audit":                 "ConsoleGrant",
+		"device":                deviceName,
+		"grantId":               grant.Id,
+		"requestedByUnverified": grant.RequestedBy,
+		"approvedByUnverified":  lo.FromPtr(grant.ApprovedBy),
+		"event":                 event,
+	}).Info(entry)
+}
+
+func (h *ServiceHandler) setConsoleGrant(ctx context.Context, orgId uuid.UUID, name string, grant *api.ConsoleGrant) error {
+	value, err := json.Marshal(grant)
+	if err != nil {
+		return err
+	}
+	return h.store.Device().UpdateAnnotations(ctx, orgId, name, map[string]string{model.DeviceAnnotationConsoleGrant: string(value)}, nil)
+}
+
+// (GET /api/v1/devices/{name}/consolegrant)
+func (h *ServiceHandler) GetConsoleGrant(ctx context.Context, request server.GetConsoleGrantRequestObject) (server.GetConsoleGrantResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.GetConsoleGrant404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	grant, err := consoleGrant(device)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil {
+		return server.GetConsoleGrant404JSONResponse{Message: "the device has no console grant"}, nil
+	}
+	return server.GetConsoleGrant200JSONResponse(*grant), nil
+}
+
+// (POST /api/v1/devices/{name}/consolegrant)
+func (h *ServiceHandler) RequestConsoleGrant(ctx context.Context, request server.RequestConsoleGrantRequestObject) (server.RequestConsoleGrantResponseObject, error) {
+	orgId := store.NullOrgId
+
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, consoleGrantRoleResource, "create")
+	if err != nil {
+		return server.RequestConsoleGrant400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.RequestConsoleGrant403JSONResponse{Message: "cannot request console grants"}, nil
+	}
+
+	requestedBy := strings.TrimSpace(request.Body.RequestedBy)
+	reason := strings.TrimSpace(request.Body.Reason)
+	if requestedBy == "" || reason == "" {
+		return server.RequestConsoleGrant400JSONResponse{Message: "requestedBy and reason must be set"}, nil
+	}
+	ttl := h.consoleGrantMaxTTL
+	if request.Body.Ttl != nil {
+		requested, err := time.ParseDuration(*request.Body.Ttl)
+		if err != nil || requested <= 0 {
+			return server.RequestConsoleGrant400JSONResponse{Message: fmt.Sprintf("invalid ttl %q: must be a positive duration such as 30m", *request.Body.Ttl)}, nil
+		}
+		if requested > h.consoleGrantMaxTTL {
+			return server.RequestConsoleGrant400JSONResponse{Message: fmt.Sprintf("ttl %s exceeds the maximum of %s", requested, h.consoleGrantMaxTTL)}, nil
+		}
+		ttl = requested
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.RequestConsoleGrant404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	if !h.requiresConsoleGrant(device) {
+		return server.RequestConsoleGrant400JSONResponse{Message: "consoles of the device do not require a grant"}, nil
+	}
+	// a device has a single grant at a time, which has to be used, revoked or
+	// expire before another one is requested
+	existing, err := consoleGrant(device)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if existing != nil && !grantExpired(existing, now) {
+		return server.RequestConsoleGrant409JSONResponse{Message: fmt.Sprintf("the device has a console grant %s requested by %s", existing.Id, existing.RequestedBy)}, nil
+	}
+
+	grant := &api.ConsoleGrant{
+		Id:          uuid.New().String(),
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		Ttl:         ttl.String(),
+		RequestedAt: now,
+	}
+	if err := h.setConsoleGrant(ctx, orgId, request.Name, grant); err != nil {
+		return nil, err
+	}
+	auditConsoleGrant(h.log, request.Name, grant, consoleGrantRequested, fmt.Sprintf("for %s: %s", grant.Ttl, grant.Reason))
+
+	return server.RequestConsoleGrant200JSONResponse(*grant), nil
+}
+
+// (POST /api/v1/devices/{name}/consolegrant/approval)
+func (h *ServiceHandler) ApproveConsoleGrant(ctx context.Context, request server.ApproveConsoleGrantRequestObject) (server.ApproveConsoleGrantResponseObject, error) {
+	orgId := store.NullOrgId
+
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, consoleGrantRoleResource, "approve")
+	if err != nil {
+		return server.ApproveConsoleGrant400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.ApproveConsoleGrant403JSONResponse{Message: "cannot approve console grants"}, nil
+	}
+
+	approvedBy := strings.TrimSpace(request.Body.ApprovedBy)
+	if approvedBy == "" {
+		return server.ApproveConsoleGrant400JSONResponse{Message: "approvedBy must be set"}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ApproveConsoleGrant404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	grant, err := consoleGrant(device)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil {
+		return server.ApproveConsoleGrant404JSONResponse{Message: "the device has no console grant"}, nil
+	}
+	if grant.ApprovedBy != nil {
+		return server.ApproveConsoleGrant409JSONResponse{Message: fmt.Sprintf("the console grant %s is already approved by %s", grant.Id, *grant.ApprovedBy)}, nil
+	}
+	if strings.EqualFold(approvedBy, grant.RequestedBy) {
+		return server.ApproveConsoleGrant400JSONResponse{Message: "a console grant must be approved by a user other than the one who requested it"}, nil
+	}
+	ttl, err := time.ParseDuration(grant.Ttl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ttl of console grant %s: %w", grant.Id, err)
+	}
+
+	now := time.Now().UTC()
+	grant.ApprovedBy = &approvedBy
+	grant.ApprovedAt = &now
+	grant.ExpiresAt = lo.ToPtr(now.Add(ttl))
+	if err := h.setConsoleGrant(ctx, orgId, request.Name, grant); err != nil {
+		return nil, err
+	}
+	auditConsoleGrant(h.log, request.Name, grant, consoleGrantApproved, fmt.Sprintf("until %s", grant.ExpiresAt.Format(time.RFC3339)))
+
+	return server.ApproveConsoleGrant200JSONResponse(*grant), nil
+}
+
+// (DELETE /api/v1/devices/{name}/consolegrant)
+func (h *ServiceHandler) RevokeConsoleGrant(ctx context.Context, request server.RevokeConsoleGrantRequestObject) (server.RevokeConsoleGrantResponseObject, error) {
+	orgId := store.NullOrgId
+
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, consoleGrantRoleResource, "delete")
+	if err != nil {
+		return server.RevokeConsoleGrant400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.RevokeConsoleGrant403JSONResponse{Message: "cannot revoke console grants"}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.RevokeConsoleGrant404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	grant, err := consoleGrant(device)
+	if err != nil {
+		return nil, err
+	}
+	if grant == nil {
+		return server.RevokeConsoleGrant404JSONResponse{Message: "the device has no console grant"}, nil
+	}
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, nil, []string{model.DeviceAnnotationConsoleGrant}); err != nil {
+		return nil, err
+	}
+	auditConsoleGrant(h.log, request.Name, grant, consoleGrantRevoked, "")
+
+	return server.RevokeConsoleGrant200JSONResponse(*grant), nil
+}
+
+// useConsoleGrant checks that the device has an approved console grant which
+// did not expire, and returns it. The grant is used up by the console session
+// it opens, so it is removed along with the session annotation.
+func (h *ServiceHandler) useConsoleGrant(ctx context.Context, orgId uuid.UUID, device *api.Device) (*api.ConsoleGrant, string, error) {
+	name := lo.FromPtr(device.Metadata.Name)
+	grant, err := consoleGrant(device)
+	if err != nil {
+		return nil, "", err
+	}
+	if grant == nil || grant.ApprovedBy == nil {
+		return nil, "opening a console on the device requires an approved console grant", nil
+	}
+	if grantExpired(grant, time.Now()) {
+		if err := h.store.Device().UpdateAnnotations(ctx, orgId, name, nil, []string{model.DeviceAnnotationConsoleGrant}); err != nil {
+			return nil, "", err
+		}
+		auditConsoleGrant(h.log, name, grant, consoleGrantExpired, "")
+		return nil, fmt.Sprintf("the console grant %s expired at %s", grant.Id, grant.ExpiresAt.Format(time.RFC3339)), nil
+	}
+	return grant, "", nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleGrant(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	t.Setenv(auth.DisableAuthEnvKey, "true")
+	_, err := auth.CreateAuthMiddleware(&config.Config{}, logrus.New())
+	require.NoError(err)
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{
+		Name:   lo.ToPtr("foo"),
+		Labels: &map[string]string{"environment": "production"},
+	}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, log: logrus.New()}
+	h.SetConsoleGrants(&config.ConsoleGrantConfig{Selector: "environment=production", MaxTTL: "1h"})
+
+	consoleResp, err := h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole409JSONResponse{}, consoleResp)
+
+	resp, err := h.RequestConsoleGrant(ctx, server.RequestConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantRequest{RequestedBy: "alice", Reason: "debugging", Ttl: lo.ToPtr("2h")},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestConsoleGrant400JSONResponse{}, resp)
+
+	resp, err = h.RequestConsoleGrant(ctx, server.RequestConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantRequest{RequestedBy: "alice", Reason: "debugging", Ttl: lo.ToPtr("30m")},
+	})
+	require.NoError(err)
+	grant, ok := resp.(server.RequestConsoleGrant200JSONResponse)
+	require.True(ok)
+	require.NotEmpty(grant.Id)
+	require.Equal("30m0s", grant.Ttl)
+
+	// a second grant is refused while the first one is pending
+	resp, err = h.RequestConsoleGrant(ctx, server.RequestConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantRequest{RequestedBy: "bob", Reason: "debugging"},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestConsoleGrant409JSONResponse{}, resp)
+
+	// a pending grant does not open a console
+	consoleResp, err = h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole409JSONResponse{}, consoleResp)
+
+	approveResp, err := h.ApproveConsoleGrant(ctx, server.ApproveConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantApproval{ApprovedBy: "Alice"},
+	})
+	require.NoError(err)
+	require.IsType(server.ApproveConsoleGrant400JSONResponse{}, approveResp)
+
+	approveResp, err = h.ApproveConsoleGrant(ctx, server.ApproveConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantApproval{ApprovedBy: "bob"},
+	})
+	require.NoError(err)
+	approved, ok := approveResp.(server.ApproveConsoleGrant200JSONResponse)
+	require.True(ok)
+	require.Equal("bob", lo.FromPtr(approved.ApprovedBy))
+	require.WithinDuration(time.Now().Add(30*time.Minute), lo.FromPtr(approved.ExpiresAt), time.Minute)
+
+	// the approved grant opens a single console
+	consoleResp, err = h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole200JSONResponse{}, consoleResp)
+	require.NotContains(*device.device.Metadata.Annotations, model.DeviceAnnotationConsoleGrant)
+
+	consoleResp, err = h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole409JSONResponse{}, consoleResp)
+}
+
+func TestConsoleGrantExpiry(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	t.Setenv(auth.DisableAuthEnvKey, "true")
+	_, err := auth.CreateAuthMiddleware(&config.Config{}, logrus.New())
+	require.NoError(err)
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{
+		Name:   lo.ToPtr("foo"),
+		Labels: &map[string]string{"environment": "production"},
+	}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, log: logrus.New()}
+	h.SetConsoleGrants(&config.ConsoleGrantConfig{Selector: "environment=production"})
+
+	requestedAt := time.Now().Add(-2 * time.Hour)
+	require.NoError(h.setConsoleGrant(ctx, store.NullOrgId, "foo", &v1alpha1.ConsoleGrant{
+		Id:          "expired",
+		RequestedBy: "alice",
+		Reason:      "debugging",
+		Ttl:         "1h0m0s",
+		RequestedAt: requestedAt,
+		ApprovedBy:  lo.ToPtr("bob"),
+		ApprovedAt:  &requestedAt,
+		ExpiresAt:   lo.ToPtr(requestedAt.Add(time.Hour)),
+	}))
+
+	consoleResp, err := h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole409JSONResponse{}, consoleResp)
+	require.NotContains(*device.device.Metadata.Annotations, model.DeviceAnnotationConsoleGrant)
+
+	// an expired grant is replaced by a new request
+	resp, err := h.RequestConsoleGrant(ctx, server.RequestConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantRequest{RequestedBy: "alice", Reason: "debugging"},
+	})
+	require.NoError(err)
+	grant, ok := resp.(server.RequestConsoleGrant200JSONResponse)
+	require.True(ok)
+	require.Equal("1h0m0s", grant.Ttl)
+
+	revokeResp, err := h.RevokeConsoleGrant(ctx, server.RevokeConsoleGrantRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RevokeConsoleGrant200JSONResponse{}, revokeResp)
+
+	getResp, err := h.GetConsoleGrant(ctx, server.GetConsoleGrantRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.GetConsoleGrant404JSONResponse{}, getResp)
+}
+
+func TestConsoleGrantNotRequired(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	t.Setenv(auth.DisableAuthEnvKey, "true")
+	_, err := auth.CreateAuthMiddleware(&config.Config{}, logrus.New())
+	require.NoError(err)
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{
+		Name:   lo.ToPtr("foo"),
+		Labels: &map[string]string{"environment": "staging"},
+	}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, log: logrus.New()}
+	h.SetConsoleGrants(&config.ConsoleGrantConfig{Selector: "environment=production"})
+
+	resp, err := h.RequestConsoleGrant(ctx, server.RequestConsoleGrantRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.ConsoleGrantRequest{RequestedBy: "alice", Reason: "debugging"},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestConsoleGrant400JSONResponse{}, resp)
+
+	consoleResp, err := h.RequestConsole(ctx, server.RequestConsoleRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.RequestConsole200JSONResponse{}, consoleResp)
+}
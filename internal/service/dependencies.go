@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// maxListedDependents is the number of dependents named in the message of a
+// deletion blocked by them.
+const maxListedDependents = 5
+
+var errUnsupportedDependencyKind = fmt.Errorf("kind must be %s, %s, %s or %s", model.DeviceKind, model.FleetKind, model.RepositoryKind, model.ResourceSyncKind)
+
+// (GET /api/v1/dependencies)
+func (h *ServiceHandler) ReadDependencies(ctx context.Context, request server.ReadDependenciesRequestObject) (server.ReadDependenciesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.resourceDependencies(ctx, orgId, request.Params.Kind, request.Params.Name)
+	switch err {
+	case nil:
+		return server.ReadDependencies200JSONResponse(*result), nil
+	case errUnsupportedDependencyKind:
+		return server.ReadDependencies400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadDependencies404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// resourceDependencies returns the resources the resource depends on, its
+// owner and the repositories it references, and the resources depending on
+// it, those it owns and those referencing it.
+func (h *ServiceHandler) resourceDependencies(ctx context.Context, orgId uuid.UUID, kind string, name string) (*api.ResourceDependencies, error) {
+	result := &api.ResourceDependencies{
+		Kind:         kind,
+		Name:         name,
+		Dependencies: []api.ResourceDependency{},
+		Dependents:   []api.ResourceDependency{},
+	}
+	addOwner := func(owner *string) {
+		if ownerKind, ownerName, err := util.GetResourceOwner(owner); err == nil {
+			result.Dependencies = append(result.Dependencies, api.ResourceDependency{Kind: ownerKind, Name: ownerName, Type: api.Ownership})
+		}
+	}
+	addRepositories := func(repositories *api.RepositoryList) {
+		for _, repository := range repositories.Items {
+			result.Dependencies = append(result.Dependencies, api.ResourceDependency{Kind: model.RepositoryKind, Name: *repository.Metadata.Name, Type: api.Reference})
+		}
+	}
+	addDependent := func(kind string, name string, dependencyType api.ResourceDependencyType) {
+		result.Dependents = append(result.Dependents, api.ResourceDependency{Kind: kind, Name: name, Type: dependencyType})
+	}
+
+	switch kind {
+	case model.DeviceKind:
+		device, err := h.store.Device().Get(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		addOwner(device.Metadata.Owner)
+		repositories, err := h.store.Device().GetRepositoryRefs(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		addRepositories(repositories)
+
+	case model.FleetKind:
+		fleet, err := h.store.Fleet().Get(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		addOwner(fleet.Metadata.Owner)
+		repositories, err := h.store.Fleet().GetRepositoryRefs(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		addRepositories(repositories)
+		devices, err := h.store.Device().List(ctx, orgId, store.ListParams{Owners: []string{*util.SetResourceOwner(model.FleetKind, name)}})
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range devices.Items {
+			addDependent(model.DeviceKind, *device.Metadata.Name, api.Ownership)
+		}
+		templateVersions, err := h.store.TemplateVersion().List(ctx, orgId, store.ListParams{FleetName: &name})
+		if err != nil {
+			return nil, err
+		}
+		for _, templateVersion := range templateVersions.Items {
+			addDependent(model.TemplateVersionKind, *templateVersion.Metadata.Name, api.Ownership)
+		}
+
+	case model.RepositoryKind:
+		if _, err := h.store.Repository().Get(ctx, orgId, name); err != nil {
+			return nil, err
+		}
+		fleets, err := h.store.Repository().GetFleetRefs(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, fleet := range fleets.Items {
+			addDependent(model.FleetKind, *fleet.Metadata.Name, api.Reference)
+		}
+		devices, err := h.store.Repository().GetDeviceRefs(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range devices.Items {
+			addDependent(model.DeviceKind, *device.Metadata.Name, api.Reference)
+		}
+		resourceSyncs, err := h.store.ResourceSync().List(ctx, orgId, store.ListParams{})
+		if err != nil {
+			return nil, err
+		}
+		for _, resourceSync := range resourceSyncs.Items {
+			if resourceSync.Spec.Repository == name {
+				addDependent(model.ResourceSyncKind, *resourceSync.Metadata.Name, api.Reference)
+			}
+		}
+
+	case model.ResourceSyncKind:
+		resourceSync, err := h.store.ResourceSync().Get(ctx, orgId, name)
+		if err != nil {
+			return nil, err
+		}
+		result.Dependencies = append(result.Dependencies, api.ResourceDependency{Kind: model.RepositoryKind, Name: resourceSync.Spec.Repository, Type: api.Reference})
+		fleets, err := h.store.Fleet().List(ctx, orgId, store.ListParams{Owners: []string{*util.SetResourceOwner(model.ResourceSyncKind, name)}})
+		if err != nil {
+			return nil, err
+		}
+		for _, fleet := range fleets.Items {
+			addDependent(model.FleetKind, *fleet.Metadata.Name, api.Ownership)
+		}
+
+	default:
+		return nil, errUnsupportedDependencyKind
+	}
+	return result, nil
+}
+
+// dependentsMessage explains that the resource cannot be deleted while the
+// dependents depend on it, and with which propagation policies it can.
+func dependentsMessage(kind string, name string, dependents []api.ResourceDependency, policies ...api.DeletionPropagationPolicy) string {
+	names := lo.Map(dependents, func(d api.ResourceDependency, _ int) string { return strings.ToLower(d.Kind) + "/" + d.Name })
+	if len(names) > maxListedDependents {
+		names = append(names[:maxListedDependents], fmt.Sprintf("%d more", len(names)-maxListedDependents))
+	}
+	return fmt.Sprintf("cannot delete %s/%s while %d %s on it (%s): delete them first, or delete it with propagationPolicy %s",
+		strings.ToLower(kind), name, len(dependents), lo.Ternary(len(dependents) == 1, "resource depends", "resources depend"),
+		strings.Join(names, ", "), strings.Join(lo.Map(policies, func(p api.DeletionPropagationPolicy, _ int) string { return string(p) }), " or "))
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependentsMessage(t *testing.T) {
+	devices := func(n int) []api.ResourceDependency {
+		dependents := []api.ResourceDependency{}
+		for i := 1; i <= n; i++ {
+			dependents = append(dependents, api.ResourceDependency{Kind: "Device", Name: fmt.Sprintf("kiosk-%d", i), Type: api.Ownership})
+		}
+		return dependents
+	}
+	tests := []struct {
+		name       string
+		kind       string
+		dependents []api.ResourceDependency
+		policies   []api.DeletionPropagationPolicy
+		expected   string
+	}{
+		{
+			name:       "single dependent",
+			kind:       "Fleet",
+			dependents: devices(1),
+			policies:   []api.DeletionPropagationPolicy{api.DeletionPropagationPolicyOrphan, api.DeletionPropagationPolicyCascade},
+			expected:   "cannot delete fleet/kiosks while 1 resource depends on it (device/kiosk-1): delete them first, or delete it with propagationPolicy Orphan or Cascade",
+		},
+		{
+			name:       "more dependents than listed",
+			kind:       "Fleet",
+			dependents: devices(7),
+			policies:   []api.DeletionPropagationPolicy{api.DeletionPropagationPolicyOrphan},
+			expected: "cannot delete fleet/kiosks while 7 resources depend on it " +
+				"(device/kiosk-1, device/kiosk-2, device/kiosk-3, device/kiosk-4, device/kiosk-5, 2 more): delete them first, or delete it with propagationPolicy Orphan",
+		},
+		{
+			name: "references of a repository",
+			kind: "Repository",
+			dependents: []api.ResourceDependency{
+				{Kind: "Fleet", Name: "kiosks", Type: api.Reference},
+				{Kind: "ResourceSync", Name: "sync", Type: api.Reference},
+			},
+			policies: []api.DeletionPropagationPolicy{api.DeletionPropagationPolicyOrphan},
+			expected: "cannot delete repository/kiosks while 2 resources depend on it (fleet/kiosks, resourcesync/sync): delete them first, or delete it with propagationPolicy Orphan",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, dependentsMessage(tt.kind, "kiosks", tt.dependents, tt.policies...))
+		})
+	}
+}
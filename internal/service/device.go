@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -16,6 +15,7 @@ import (
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -28,7 +28,13 @@ func (h *ServiceHandler) CreateDevice(ctx context.Context, request server.Create
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateDevice400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateDevice400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if msg := h.checkImagePolicy(request.Body.Spec, false); msg != "" {
+		return server.CreateDevice400JSONResponse{Message: msg}, nil
+	}
+	if msg := h.checkLabelPolicy(nil, request.Body.Metadata.Labels); msg != "" {
+		return server.CreateDevice400JSONResponse{Message: msg}, nil
 	}
 
 	result, err := h.store.Device().Create(ctx, orgId, request.Body, h.callbackManager.DeviceUpdatedCallback)
@@ -54,10 +60,28 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 
 	orgId := store.NullOrgId
 
+	listParams, err := deviceListParams(request.Params)
+	if err != nil {
+		return server.ListDevices400JSONResponse{Message: err.Error()}, nil
+	}
+
+	// the lists of the API tolerate replication lag, so that they can be served by a read replica
+	result, err := h.store.Device().List(store.WithReplicaReads(ctx), orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListDevices200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// deviceListParams converts the parameters of a device list request to the
+// parameters of the store, failing if any of them is invalid.
+func deviceListParams(params v1alpha1.ListDevicesParams) (store.ListParams, error) {
 	labelSelector := ""
-	if request.Params.LabelSelector != nil {
+	if params.LabelSelector != nil {
 		// If a label selector is provided, ensure keys without value still have '=' appended
-		labels := strings.Split(*request.Params.LabelSelector, ",")
+		labels := strings.Split(*params.LabelSelector, ",")
 		for i, label := range labels {
 			l := strings.Split(label, "=")
 			if len(l) == 1 {
@@ -67,46 +91,61 @@ func (h *ServiceHandler) ListDevices(ctx context.Context, request server.ListDev
 		labelSelector = strings.Join(labels, ",")
 	}
 	statusFilter := []string{}
-	if request.Params.StatusFilter != nil {
-		statusFilter = *request.Params.StatusFilter
+	if params.StatusFilter != nil {
+		statusFilter = *params.StatusFilter
 	}
 
 	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
 	if err != nil {
-		return server.ListDevices400JSONResponse{Message: err.Error()}, nil
+		return store.ListParams{}, err
 	}
 
 	filterMap, err := ConvertStatusFilterParamsToMap(statusFilter)
 	if err != nil {
-		return server.ListDevices400JSONResponse{Message: fmt.Sprintf("failed to convert status filter: %v", err)}, nil
+		return store.ListParams{}, fmt.Errorf("failed to convert status filter: %v", err)
+	}
+
+	boundingBox, err := ParseBoundingBox(params.BoundingBox)
+	if err != nil {
+		return store.ListParams{}, err
 	}
 
-	cont, err := store.ParseContinueString(request.Params.Continue)
+	annotations, err := ParseAnnotationSelector(params.AnnotationSelector)
 	if err != nil {
-		return server.ListDevices400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+		return store.ListParams{}, err
+	}
+
+	var applicationRestarts *int
+	if params.ApplicationRestarts != nil {
+		if *params.ApplicationRestarts < 1 {
+			return store.ListParams{}, fmt.Errorf("applicationRestarts must be at least 1")
+		}
+		applicationRestarts = lo.ToPtr(int(*params.ApplicationRestarts))
+	}
+
+	cont, err := store.ParseContinueString(params.Continue)
+	if err != nil {
+		return store.ListParams{}, fmt.Errorf("failed to parse continue parameter: %v", err)
 	}
 
 	listParams := store.ListParams{
-		Labels:   labelMap,
-		Filter:   filterMap,
-		Limit:    int(swag.Int32Value(request.Params.Limit)),
-		Continue: cont,
-		Owners:   util.OwnerQueryParamsToArray(request.Params.Owner),
+		Labels:              labelMap,
+		Filter:              filterMap,
+		Limit:               int(swag.Int32Value(params.Limit)),
+		Continue:            cont,
+		Owners:              util.OwnerQueryParamsToArray(params.Owner),
+		BoundingBox:         boundingBox,
+		Annotations:         annotations,
+		Alias:               params.Alias,
+		ApplicationRestarts: applicationRestarts,
 	}
 	if listParams.Limit == 0 {
 		listParams.Limit = store.MaxRecordsPerListRequest
 	}
 	if listParams.Limit > store.MaxRecordsPerListRequest {
-		return server.ListDevices400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
-	}
-
-	result, err := h.store.Device().List(ctx, orgId, listParams)
-	switch err {
-	case nil:
-		return server.ListDevices200JSONResponse(*result), nil
-	default:
-		return nil, err
+		return store.ListParams{}, fmt.Errorf("limit cannot exceed %d", store.MaxRecordsPerListRequest)
 	}
+	return listParams, nil
 }
 
 // (DELETE /api/v1/devices)
@@ -114,6 +153,12 @@ func (h *ServiceHandler) DeleteDevices(ctx context.Context, request server.Delet
 	orgId := store.NullOrgId
 
 	err := h.store.Device().DeleteAll(ctx, orgId, h.callbackManager.AllDevicesDeletedCallback)
+	if err == nil {
+		err = h.store.IssuedCertificate().DeleteForAllDevices(ctx, orgId)
+	}
+	if err == nil {
+		err = h.store.DeviceAttestation().DeleteForAllDevices(ctx, orgId)
+	}
 	switch err {
 	case nil:
 		return server.DeleteDevices200JSONResponse{}, nil
@@ -127,6 +172,9 @@ func (h *ServiceHandler) ReadDevice(ctx context.Context, request server.ReadDevi
 	orgId := store.NullOrgId
 
 	result, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err == nil {
+		err = h.addIssuedCertificates(ctx, orgId, result)
+	}
 	switch err {
 	case nil:
 		return server.ReadDevice200JSONResponse(*result), nil
@@ -146,11 +194,27 @@ func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.Repla
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceDevice400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceDevice400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if msg := h.checkImagePolicy(request.Body.Spec, false); msg != "" {
+		return server.ReplaceDevice400JSONResponse{Message: msg}, nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceDevice400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
 	}
+	if len(h.labelSchemas) > 0 {
+		current, err := h.store.Device().Get(ctx, orgId, request.Name)
+		switch err {
+		case nil:
+		case flterrors.ErrResourceNotFound:
+			current = nil
+		default:
+			return nil, err
+		}
+		if msg := h.checkLabelPolicy(current, request.Body.Metadata.Labels); msg != "" {
+			return server.ReplaceDevice400JSONResponse{Message: msg}, nil
+		}
+	}
 
 	result, created, err := h.store.Device().CreateOrUpdate(ctx, orgId, request.Body, nil, true, h.callbackManager.DeviceUpdatedCallback)
 	switch err {
@@ -167,7 +231,7 @@ func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.Repla
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceDevice404JSONResponse{}, nil
 	case flterrors.ErrUpdatingResourceWithOwnerNotAllowed, flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceDevice409JSONResponse{Message: err.Error()}, nil
+		return server.ReplaceDevice409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -177,6 +241,7 @@ func (h *ServiceHandler) ReplaceDevice(ctx context.Context, request server.Repla
 func (h *ServiceHandler) DeleteDevice(ctx context.Context, request server.DeleteDeviceRequestObject) (server.DeleteDeviceResponseObject, error) {
 	orgId := store.NullOrgId
 
+	// the certificates and the attestation of the device are deleted once it is purged from the trash
 	err := h.store.Device().Delete(ctx, orgId, request.Name, h.callbackManager.DeviceUpdatedCallback)
 	switch err {
 	case nil:
@@ -193,6 +258,9 @@ func (h *ServiceHandler) ReadDeviceStatus(ctx context.Context, request server.Re
 	orgId := store.NullOrgId
 
 	result, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err == nil {
+		err = h.addIssuedCertificates(ctx, orgId, result)
+	}
 	switch err {
 	case nil:
 		return server.ReadDeviceStatus200JSONResponse(*result), nil
@@ -205,12 +273,29 @@ func (h *ServiceHandler) ReadDeviceStatus(ctx context.Context, request server.Re
 
 // (PUT /api/v1/devices/{name}/status)
 func (h *ServiceHandler) ReplaceDeviceStatus(ctx context.Context, request server.ReplaceDeviceStatusRequestObject) (server.ReplaceDeviceStatusResponseObject, error) {
-	return common.ReplaceDeviceStatus(ctx, h.store, request)
+	return common.ReplaceDeviceStatus(ctx, h.store, h.log, h.callbackManager.DeviceStatusUpdatedCallback, request)
+}
+
+// (PUT /api/v1/devicestatuses)
+func (h *ServiceHandler) ReplaceDeviceStatuses(ctx context.Context, request server.ReplaceDeviceStatusesRequestObject) (server.ReplaceDeviceStatusesResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices/status", "update")
+	if err != nil {
+		return server.ReplaceDeviceStatuses400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.ReplaceDeviceStatuses403JSONResponse{Message: "cannot update the statuses of devices"}, nil
+	}
+	return common.ReplaceDeviceStatuses(ctx, h.store, h.log, h.callbackManager.DeviceStatusUpdatedCallback, request)
+}
+
+// (POST /api/v1/devices/{name}/metrics)
+func (h *ServiceHandler) PushDeviceMetrics(ctx context.Context, request server.PushDeviceMetricsRequestObject) (server.PushDeviceMetricsResponseObject, error) {
+	return common.PushDeviceMetrics(ctx, h.metricsForwarder, request)
 }
 
 // (GET /api/v1/devices/{name}/rendered)
 func (h *ServiceHandler) GetRenderedDeviceSpec(ctx context.Context, request server.GetRenderedDeviceSpecRequestObject) (server.GetRenderedDeviceSpecResponseObject, error) {
-	return common.GetRenderedDeviceSpec(ctx, h.store, request, h.consoleGrpcEndpoint)
+	return common.GetRenderedDeviceSpec(ctx, h.store, h.log, request, h.consoleGrpcEndpoint)
 }
 
 // (PATCH /api/v1/devices/{name})
@@ -248,9 +333,14 @@ func (h *ServiceHandler) PatchDevice(ctx context.Context, request server.PatchDe
 	if !reflect.DeepEqual(currentObj.Status, newObj.Status) {
 		return server.PatchDevice400JSONResponse{Message: "status is immutable"}, nil
 	}
+	if msg := h.checkImagePolicy(newObj.Spec, false); msg != "" {
+		return server.PatchDevice400JSONResponse{Message: msg}, nil
+	}
+	if msg := h.checkLabelPolicy(currentObj, newObj.Metadata.Labels); msg != "" {
+		return server.PatchDevice400JSONResponse{Message: msg}, nil
+	}
 
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
 	var updateCallback func(before *model.Device, after *model.Device)
 
@@ -268,7 +358,7 @@ func (h *ServiceHandler) PatchDevice(ctx context.Context, request server.PatchDe
 	case flterrors.ErrResourceNotFound:
 		return server.PatchDevice404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.PatchDevice409JSONResponse{}, nil
+		return server.PatchDevice409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+var deviceActions = []api.DeviceActionType{api.DeviceActionReboot, api.DeviceActionShutdown, api.DeviceActionRestartAgent, api.DeviceActionEnforceSpec}
+
+// (POST /api/v1/devices/{name}/action)
+func (h *ServiceHandler) RequestDeviceAction(ctx context.Context, request server.RequestDeviceActionRequestObject) (server.RequestDeviceActionResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if !slices.Contains(deviceActions, request.Body.Action) {
+		return server.RequestDeviceAction400JSONResponse{Message: fmt.Sprintf("unsupported action %q", request.Body.Action)}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.RequestDeviceAction404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	// a device carries out a single action at a time, so that a shutdown
+	// cannot be lost behind a reboot
+	pending, err := common.PendingDeviceAction(device)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationAction, err)
+	}
+	if pending != nil {
+		return server.RequestDeviceAction409JSONResponse{Message: fmt.Sprintf("the device has a pending %s action %s", pending.Action, pending.Id)}, nil
+	}
+
+	action := api.DeviceAction{
+		Id:          uuid.New().String(),
+		Action:      request.Body.Action,
+		RequestedAt: time.Now().UTC(),
+	}
+	if reason := strings.TrimSpace(lo.FromPtr(request.Body.Reason)); reason != "" {
+		action.Reason = &reason
+	}
+	if err := h.setPendingDeviceAction(ctx, orgId, request.Name, action); err != nil {
+		return nil, err
+	}
+	common.AuditDeviceAction(h.log, request.Name, action.Id, action.Action, common.DeviceActionRequested, lo.FromPtr(action.Reason))
+
+	return server.RequestDeviceAction200JSONResponse(action), nil
+}
+
+// setPendingDeviceAction stores the action in the annotations of the device,
+// which serves it to the agent until the agent acknowledges it.
+func (h *ServiceHandler) setPendingDeviceAction(ctx context.Context, orgId uuid.UUID, name string, action api.DeviceAction) error {
+	value, err := json.Marshal(action)
+	if err != nil {
+		return err
+	}
+	return h.store.Device().UpdateAnnotations(ctx, orgId, name, map[string]string{model.DeviceAnnotationAction: string(value)}, nil)
+}
+
+// (DELETE /api/v1/devices/{name}/action)
+func (h *ServiceHandler) CancelDeviceAction(ctx context.Context, request server.CancelDeviceActionRequestObject) (server.CancelDeviceActionResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.CancelDeviceAction404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	if _, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationAction]; !ok {
+		return server.CancelDeviceAction200JSONResponse(*device), nil
+	}
+	// an invalid action is removed all the same
+	pending, _ := common.PendingDeviceAction(device)
+
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, nil, []string{model.DeviceAnnotationAction}); err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		common.AuditDeviceAction(h.log, request.Name, pending.Id, pending.Action, common.DeviceActionCanceled, "")
+	}
+
+	device, err = h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.CancelDeviceAction200JSONResponse(*device), nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type annotatedDeviceStore struct {
+	store.Store
+	device *annotatedDevice
+}
+
+func (s *annotatedDeviceStore) Device() store.Device {
+	return s.device
+}
+
+type annotatedDevice struct {
+	store.Device
+	device v1alpha1.Device
+}
+
+func (d *annotatedDevice) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Device, error) {
+	if name != *d.device.Metadata.Name {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	device := d.device
+	annotations := lo.Assign(lo.FromPtr(d.device.Metadata.Annotations))
+	device.Metadata.Annotations = &annotations
+	return &device, nil
+}
+
+func (d *annotatedDevice) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	updated := lo.OmitByKeys(lo.Assign(lo.FromPtr(d.device.Metadata.Annotations), annotations), deleteKeys)
+	d.device.Metadata.Annotations = &updated
+	return nil
+}
+
+func (d *annotatedDevice) ReplaceAnnotation(ctx context.Context, orgId uuid.UUID, name string, key string, expected *string, value *string) error {
+	current, ok := lo.FromPtr(d.device.Metadata.Annotations)[key]
+	if ok != (expected != nil) || (ok && current != *expected) {
+		return flterrors.ErrResourceVersionConflict
+	}
+	if value == nil {
+		return d.UpdateAnnotations(ctx, orgId, name, nil, []string{key})
+	}
+	return d.UpdateAnnotations(ctx, orgId, name, map[string]string{key: *value}, nil)
+}
+
+func TestDeviceAction(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("foo")}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, log: logrus.New()}
+
+	resp, err := h.RequestDeviceAction(ctx, server.RequestDeviceActionRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.DeviceActionRequest{Action: "Explode"},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestDeviceAction400JSONResponse{}, resp)
+
+	resp, err = h.RequestDeviceAction(ctx, server.RequestDeviceActionRequestObject{
+		Name: "bar",
+		Body: &v1alpha1.DeviceActionRequest{Action: v1alpha1.DeviceActionReboot},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestDeviceAction404JSONResponse{}, resp)
+
+	resp, err = h.RequestDeviceAction(ctx, server.RequestDeviceActionRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.DeviceActionRequest{Action: v1alpha1.DeviceActionReboot, Reason: lo.ToPtr(" kernel update ")},
+	})
+	require.NoError(err)
+	action, ok := resp.(server.RequestDeviceAction200JSONResponse)
+	require.True(ok)
+	require.NotEmpty(action.Id)
+	require.Equal(v1alpha1.DeviceActionReboot, action.Action)
+	require.Equal("kernel update", lo.FromPtr(action.Reason))
+	require.Contains(*device.device.Metadata.Annotations, model.DeviceAnnotationAction)
+
+	// a second action is refused while the first one is pending
+	resp, err = h.RequestDeviceAction(ctx, server.RequestDeviceActionRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.DeviceActionRequest{Action: v1alpha1.DeviceActionShutdown},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestDeviceAction409JSONResponse{}, resp)
+
+	cancelResp, err := h.CancelDeviceAction(ctx, server.CancelDeviceActionRequestObject{Name: "foo"})
+	require.NoError(err)
+	canceled, ok := cancelResp.(server.CancelDeviceAction200JSONResponse)
+	require.True(ok)
+	require.NotContains(*canceled.Metadata.Annotations, model.DeviceAnnotationAction)
+
+	resp, err = h.RequestDeviceAction(ctx, server.RequestDeviceActionRequestObject{
+		Name: "foo",
+		Body: &v1alpha1.DeviceActionRequest{Action: v1alpha1.DeviceActionShutdown},
+	})
+	require.NoError(err)
+	require.IsType(server.RequestDeviceAction200JSONResponse{}, resp)
+}
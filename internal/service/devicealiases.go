@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/samber/lo"
+)
+
+// (PUT /api/v1/devices/{name}/aliases)
+func (h *ServiceHandler) ReplaceDeviceAliases(ctx context.Context, request server.ReplaceDeviceAliasesRequestObject) (server.ReplaceDeviceAliasesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	aliases := normalizeDeviceAliases(*request.Body)
+	if errs := aliases.Validate(); len(errs) > 0 {
+		return server.ReplaceDeviceAliases400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if lo.Contains(lo.FromPtr(aliases.Aliases), request.Name) {
+		return server.ReplaceDeviceAliases400JSONResponse{Message: fmt.Sprintf("aliases: %s is the name of the device", request.Name)}, nil
+	}
+
+	device, err := h.store.Device().UpdateAliases(ctx, orgId, request.Name, aliases.DisplayName, lo.FromPtr(aliases.Aliases))
+	switch {
+	case err == nil:
+		return server.ReplaceDeviceAliases200JSONResponse(*device), nil
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		return server.ReplaceDeviceAliases404JSONResponse{}, nil
+	case errors.Is(err, flterrors.ErrAliasInUse), errors.Is(err, flterrors.ErrNoRowsUpdated):
+		return server.ReplaceDeviceAliases409JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
+
+// normalizeDeviceAliases trims the display name and the aliases, and unsets
+// the display name if it is empty.
+func normalizeDeviceAliases(aliases api.DeviceAliases) api.DeviceAliases {
+	displayName := strings.TrimSpace(lo.FromPtr(aliases.DisplayName))
+	normalized := api.DeviceAliases{
+		DisplayName: lo.EmptyableToPtr(displayName),
+	}
+	if aliases.Aliases != nil {
+		normalized.Aliases = lo.ToPtr(lo.Map(*aliases.Aliases, func(alias string, _ int) string { return strings.TrimSpace(alias) }))
+	}
+	return normalized
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type aliasedDeviceStore struct {
+	store.Store
+	device *aliasedDevice
+}
+
+func (s *aliasedDeviceStore) Device() store.Device {
+	return s.device
+}
+
+type aliasedDevice struct {
+	annotatedDevice
+	displayName *string
+	aliases     []string
+}
+
+func (d *aliasedDevice) UpdateAliases(ctx context.Context, orgId uuid.UUID, name string, displayName *string, aliases []string) (*v1alpha1.Device, error) {
+	device, err := d.Get(ctx, orgId, name)
+	if err != nil {
+		return nil, err
+	}
+	d.displayName, d.aliases = displayName, aliases
+	return device, nil
+}
+
+func TestReplaceDeviceAliases(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	device := &aliasedDevice{annotatedDevice: annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("f2a1c3")}}}}
+	h := &ServiceHandler{store: &aliasedDeviceStore{device: device}, log: logrus.New()}
+
+	tests := []struct {
+		name        string
+		body        v1alpha1.DeviceAliases
+		expected    interface{}
+		displayName *string
+		aliases     []string
+	}{
+		{
+			name:     "invalid alias",
+			body:     v1alpha1.DeviceAliases{Aliases: &[]string{"Lobby Kiosk"}},
+			expected: server.ReplaceDeviceAliases400JSONResponse{},
+		},
+		{
+			name:     "duplicate alias",
+			body:     v1alpha1.DeviceAliases{Aliases: &[]string{"kiosk", "kiosk"}},
+			expected: server.ReplaceDeviceAliases400JSONResponse{},
+		},
+		{
+			name:     "name as alias",
+			body:     v1alpha1.DeviceAliases{Aliases: &[]string{"f2a1c3"}},
+			expected: server.ReplaceDeviceAliases400JSONResponse{},
+		},
+		{
+			name:        "display name and aliases",
+			body:        v1alpha1.DeviceAliases{DisplayName: lo.ToPtr(" Lobby kiosk "), Aliases: &[]string{" kiosk-lobby", "kiosk-7"}},
+			expected:    server.ReplaceDeviceAliases200JSONResponse{},
+			displayName: lo.ToPtr("Lobby kiosk"),
+			aliases:     []string{"kiosk-lobby", "kiosk-7"},
+		},
+		{
+			name:     "blank display name",
+			body:     v1alpha1.DeviceAliases{DisplayName: lo.ToPtr("  ")},
+			expected: server.ReplaceDeviceAliases200JSONResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device.displayName, device.aliases = nil, nil
+			resp, err := h.ReplaceDeviceAliases(ctx, server.ReplaceDeviceAliasesRequestObject{Name: "f2a1c3", Body: &tt.body})
+			require.NoError(err)
+			require.IsType(tt.expected, resp)
+			require.Equal(tt.displayName, device.displayName)
+			require.Equal(tt.aliases, device.aliases)
+		})
+	}
+
+	resp, err := h.ReplaceDeviceAliases(ctx, server.ReplaceDeviceAliasesRequestObject{Name: "bar", Body: &v1alpha1.DeviceAliases{}})
+	require.NoError(err)
+	require.IsType(server.ReplaceDeviceAliases404JSONResponse{}, resp)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+)
+
+// (DELETE /api/v1/devices/{name}/attestation)
+func (h *ServiceHandler) ResetDeviceAttestation(ctx context.Context, request server.ResetDeviceAttestationRequestObject) (server.ResetDeviceAttestationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if _, err := h.store.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ResetDeviceAttestation404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	// the next attestation binds the attestation key and records the
+	// reference measurements anew, e.g. after replacing the TPM of the device
+	if err := h.store.DeviceAttestation().DeleteForDevice(ctx, orgId, request.Name); err != nil {
+		return nil, err
+	}
+	condition := api.Condition{
+		Type:    api.DeviceIntegrityVerified,
+		Status:  api.ConditionStatusUnknown,
+		Reason:  "Reset",
+		Message: "The reference measurements were reset and are recorded with the next attestation",
+	}
+	if err := h.store.Device().SetServiceConditions(ctx, orgId, request.Name, []api.Condition{condition}); err != nil {
+		return nil, err
+	}
+	return server.ResetDeviceAttestation200JSONResponse{}, nil
+}
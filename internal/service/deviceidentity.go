@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/deviceidentities)
+func (h *ServiceHandler) CreateDeviceIdentity(ctx context.Context, request server.CreateDeviceIdentityRequestObject) (server.CreateDeviceIdentityResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	request.Body.Status = nil
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateDeviceIdentity400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.DeviceIdentity().Create(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		return server.CreateDeviceIdentity201JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.CreateDeviceIdentity400JSONResponse{Message: err.Error()}, nil
+
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/deviceidentities)
+func (h *ServiceHandler) ListDeviceIdentities(ctx context.Context, request server.ListDeviceIdentitiesRequestObject) (server.ListDeviceIdentitiesResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListDeviceIdentities400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListDeviceIdentities400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListDeviceIdentities400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.DeviceIdentity().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListDeviceIdentities200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/deviceidentities)
+func (h *ServiceHandler) DeleteDeviceIdentities(ctx context.Context, request server.DeleteDeviceIdentitiesRequestObject) (server.DeleteDeviceIdentitiesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.DeviceIdentity().DeleteAll(ctx, orgId)
+	switch err {
+	case nil:
+		return server.DeleteDeviceIdentities200JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/deviceidentities/{name})
+func (h *ServiceHandler) ReadDeviceIdentity(ctx context.Context, request server.ReadDeviceIdentityRequestObject) (server.ReadDeviceIdentityResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.DeviceIdentity().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadDeviceIdentity200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadDeviceIdentity404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/deviceidentities/{name})
+func (h *ServiceHandler) ReplaceDeviceIdentity(ctx context.Context, request server.ReplaceDeviceIdentityRequestObject) (server.ReplaceDeviceIdentityResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't overwrite fields that are managed by the service
+	request.Body.Status = nil
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.ReplaceDeviceIdentity400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.ReplaceDeviceIdentity400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	result, created, err := h.store.DeviceIdentity().CreateOrUpdate(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		if created {
+			return server.ReplaceDeviceIdentity201JSONResponse(*result), nil
+		} else {
+			return server.ReplaceDeviceIdentity200JSONResponse(*result), nil
+		}
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceDeviceIdentity400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceDeviceIdentity400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReplaceDeviceIdentity404JSONResponse{}, nil
+	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
+		return server.ReplaceDeviceIdentity409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/deviceidentities/{name})
+func (h *ServiceHandler) DeleteDeviceIdentity(ctx context.Context, request server.DeleteDeviceIdentityRequestObject) (server.DeleteDeviceIdentityResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.DeviceIdentity().Delete(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.DeleteDeviceIdentity200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteDeviceIdentity404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+)
+
+// (PUT /api/v1/devices/{name}/quarantine)
+func (h *ServiceHandler) QuarantineDevice(ctx context.Context, request server.QuarantineDeviceRequestObject) (server.QuarantineDeviceResponseObject, error) {
+	orgId := store.NullOrgId
+
+	quarantine := *request.Body
+	quarantine.Reason = strings.TrimSpace(quarantine.Reason)
+	if quarantine.Reason == "" {
+		return server.QuarantineDevice400JSONResponse{Message: "the reason of the quarantine must be set"}, nil
+	}
+	value, err := json.Marshal(quarantine)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.store.Device().Get(ctx, orgId, request.Name); err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.QuarantineDevice404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	// removing the console session and the tunnel closes them on the device
+	annotations := map[string]string{model.DeviceAnnotationQuarantine: string(value)}
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, annotations, []string{model.DeviceAnnotationConsole, model.DeviceAnnotationTunnel}); err != nil {
+		return nil, err
+	}
+	if err := h.store.Device().SetServiceConditions(ctx, orgId, request.Name, []api.Condition{quarantinedCondition(&quarantine)}); err != nil {
+		return nil, err
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.QuarantineDevice200JSONResponse(*device), nil
+}
+
+// (DELETE /api/v1/devices/{name}/quarantine)
+func (h *ServiceHandler) ReleaseDeviceQuarantine(ctx context.Context, request server.ReleaseDeviceQuarantineRequestObject) (server.ReleaseDeviceQuarantineResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ReleaseDeviceQuarantine404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	if !isQuarantined(device) {
+		return server.ReleaseDeviceQuarantine200JSONResponse(*device), nil
+	}
+
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, request.Name, nil, []string{model.DeviceAnnotationQuarantine}); err != nil {
+		return nil, err
+	}
+	if err := h.store.Device().SetServiceConditions(ctx, orgId, request.Name, []api.Condition{quarantinedCondition(nil)}); err != nil {
+		return nil, err
+	}
+
+	device, err = h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.ReleaseDeviceQuarantine200JSONResponse(*device), nil
+}
+
+// isQuarantined returns whether the device was quarantined and not released yet.
+func isQuarantined(device *api.Device) bool {
+	_, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationQuarantine]
+	return ok
+}
+
+// quarantinedCondition returns the condition flagging the device as
+// quarantined, or as released if quarantine is nil.
+func quarantinedCondition(quarantine *api.DeviceQuarantine) api.Condition {
+	if quarantine == nil {
+		return api.Condition{
+			Type:    api.DeviceQuarantined,
+			Status:  api.ConditionStatusFalse,
+			Reason:  "Released",
+			Message: "The device was released from quarantine",
+		}
+	}
+	message := fmt.Sprintf("The device is quarantined: %s", quarantine.Reason)
+	if lo.FromPtr(quarantine.StopApplications) {
+		message += ". Its applications are stopped"
+	}
+	return api.Condition{
+		Type:    api.DeviceQuarantined,
+		Status:  api.ConditionStatusTrue,
+		Reason:  "Quarantined",
+		Message: message,
+	}
+}
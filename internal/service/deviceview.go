@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/deviceviews)
+func (h *ServiceHandler) CreateDeviceView(ctx context.Context, request server.CreateDeviceViewRequestObject) (server.CreateDeviceViewResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := validateDeviceView(request.Body); len(errs) > 0 {
+		return server.CreateDeviceView400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.DeviceView().Create(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		return server.CreateDeviceView201JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.CreateDeviceView400JSONResponse{Message: err.Error()}, nil
+
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/deviceviews)
+func (h *ServiceHandler) ListDeviceViews(ctx context.Context, request server.ListDeviceViewsRequestObject) (server.ListDeviceViewsResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListDeviceViews400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListDeviceViews400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListDeviceViews400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.DeviceView().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListDeviceViews200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/deviceviews)
+func (h *ServiceHandler) DeleteDeviceViews(ctx context.Context, request server.DeleteDeviceViewsRequestObject) (server.DeleteDeviceViewsResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.DeviceView().DeleteAll(ctx, orgId)
+	switch err {
+	case nil:
+		return server.DeleteDeviceViews200JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/deviceviews/{name})
+func (h *ServiceHandler) ReadDeviceView(ctx context.Context, request server.ReadDeviceViewRequestObject) (server.ReadDeviceViewResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.DeviceView().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadDeviceView200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadDeviceView404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/deviceviews/{name})
+func (h *ServiceHandler) ReplaceDeviceView(ctx context.Context, request server.ReplaceDeviceViewRequestObject) (server.ReplaceDeviceViewResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't overwrite fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := validateDeviceView(request.Body); len(errs) > 0 {
+		return server.ReplaceDeviceView400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.ReplaceDeviceView400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	result, created, err := h.store.DeviceView().CreateOrUpdate(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		if created {
+			return server.ReplaceDeviceView201JSONResponse(*result), nil
+		} else {
+			return server.ReplaceDeviceView200JSONResponse(*result), nil
+		}
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceDeviceView400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceDeviceView400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReplaceDeviceView404JSONResponse{}, nil
+	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
+		return server.ReplaceDeviceView409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/deviceviews/{name})
+func (h *ServiceHandler) DeleteDeviceView(ctx context.Context, request server.DeleteDeviceViewRequestObject) (server.DeleteDeviceViewResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.DeviceView().Delete(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.DeleteDeviceView200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteDeviceView404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/deviceviews/{name}/devices)
+func (h *ServiceHandler) ListDeviceViewDevices(ctx context.Context, request server.ListDeviceViewDevicesRequestObject) (server.ListDeviceViewDevicesResponseObject, error) {
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, "devices", "list")
+	if err != nil {
+		return server.ListDeviceViewDevices400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.ListDeviceViewDevices403JSONResponse{Message: "cannot list devices"}, nil
+	}
+
+	orgId := store.NullOrgId
+
+	view, err := h.store.DeviceView().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.ListDeviceViewDevices404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+
+	params := deviceViewListParams(view.Spec.Selector)
+	params.LabelSelector = joinLabelSelectors(params.LabelSelector, request.Params.LabelSelector)
+	params.Continue = request.Params.Continue
+	params.Limit = request.Params.Limit
+	listParams, err := deviceListParams(params)
+	if err != nil {
+		return server.ListDeviceViewDevices400JSONResponse{Message: err.Error()}, nil
+	}
+
+	result, err := h.store.Device().List(store.WithReplicaReads(ctx), orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListDeviceViewDevices200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// validateDeviceView validates the device view, including that its selector
+// is a valid device list request.
+func validateDeviceView(view *api.DeviceView) []error {
+	errs := view.Validate()
+	if _, err := deviceListParams(deviceViewListParams(view.Spec.Selector)); err != nil {
+		errs = append(errs, fmt.Errorf("spec.selector: %w", err))
+	}
+	return errs
+}
+
+// deviceViewListParams returns the device list request selecting the devices of the view.
+func deviceViewListParams(selector api.DeviceViewSelector) api.ListDevicesParams {
+	return api.ListDevicesParams{
+		LabelSelector:       selector.LabelSelector,
+		Owner:               selector.Owner,
+		StatusFilter:        selector.StatusFilter,
+		AnnotationSelector:  selector.AnnotationSelector,
+		BoundingBox:         selector.BoundingBox,
+		Alias:               selector.Alias,
+		ApplicationRestarts: selector.ApplicationRestarts,
+	}
+}
+
+// joinLabelSelectors returns a selector requiring the labels of both selectors.
+func joinLabelSelectors(a, b *string) *string {
+	selectors := lo.Filter([]string{lo.FromPtr(a), lo.FromPtr(b)}, func(s string, _ int) bool { return s != "" })
+	if len(selectors) == 0 {
+		return nil
+	}
+	return lo.ToPtr(strings.Join(selectors, ","))
+}
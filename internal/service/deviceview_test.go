@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDeviceView(t *testing.T) {
+	view := func(selector api.DeviceViewSelector, columns ...api.DeviceViewColumn) *api.DeviceView {
+		v := &api.DeviceView{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr("degraded-arm")},
+			Spec:     api.DeviceViewSpec{Selector: selector},
+		}
+		if len(columns) > 0 {
+			v.Spec.Columns = &columns
+		}
+		return v
+	}
+
+	tests := []struct {
+		name      string
+		view      *api.DeviceView
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			view: view(api.DeviceViewSelector{
+				LabelSelector: lo.ToPtr("region=emea"),
+				StatusFilter:  &[]string{"summary.status=Degraded"},
+			}, api.DeviceViewColumnName, api.DeviceViewColumnArchitecture),
+		},
+		{
+			name: "empty selector",
+			view: view(api.DeviceViewSelector{}),
+		},
+		{
+			name:      "invalid label selector",
+			view:      view(api.DeviceViewSelector{LabelSelector: lo.ToPtr("region in (emea")}),
+			expectErr: true,
+		},
+		{
+			name:      "invalid status filter",
+			view:      view(api.DeviceViewSelector{StatusFilter: &[]string{"summary.status"}}),
+			expectErr: true,
+		},
+		{
+			name:      "invalid bounding box",
+			view:      view(api.DeviceViewSelector{BoundingBox: lo.ToPtr("1,2,3")}),
+			expectErr: true,
+		},
+		{
+			name:      "invalid application restarts",
+			view:      view(api.DeviceViewSelector{ApplicationRestarts: lo.ToPtr(int32(0))}),
+			expectErr: true,
+		},
+		{
+			name:      "unknown column",
+			view:      view(api.DeviceViewSelector{}, api.DeviceViewColumn("Serial")),
+			expectErr: true,
+		},
+		{
+			name:      "duplicate column",
+			view:      view(api.DeviceViewSelector{}, api.DeviceViewColumnName, api.DeviceViewColumnName),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateDeviceView(tt.view)
+			if tt.expectErr {
+				require.NotEmpty(t, errs)
+				return
+			}
+			require.Empty(t, errs)
+		})
+	}
+}
+
+func TestJoinLabelSelectors(t *testing.T) {
+	require := require.New(t)
+	require.Nil(joinLabelSelectors(nil, nil))
+	require.Nil(joinLabelSelectors(lo.ToPtr(""), nil))
+	require.Equal("region=emea", *joinLabelSelectors(lo.ToPtr("region=emea"), nil))
+	require.Equal("site=lobby", *joinLabelSelectors(nil, lo.ToPtr("site=lobby")))
+	require.Equal("region=emea,site=lobby", *joinLabelSelectors(lo.ToPtr("region=emea"), lo.ToPtr("site=lobby")))
+}
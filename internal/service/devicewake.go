@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util/validation"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// defaultSiteLabel is the label whose value names the site of a device,
+// unless the request names another one.
+const defaultSiteLabel = "site"
+
+// (POST /api/v1/devices/{name}/wake)
+func (h *ServiceHandler) WakeDevice(ctx context.Context, request server.WakeDeviceRequestObject) (server.WakeDeviceResponseObject, error) {
+	orgId := store.NullOrgId
+
+	siteLabel := strings.TrimSpace(lo.FromPtr(request.Body.SiteLabel))
+	if siteLabel == "" {
+		siteLabel = defaultSiteLabel
+	}
+
+	target, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.WakeDevice404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	site, ok := lo.FromPtr(target.Metadata.Labels)[siteLabel]
+	if !ok || site == "" {
+		return server.WakeDevice400JSONResponse{Message: fmt.Sprintf("the device has no %s label naming its site", siteLabel)}, nil
+	}
+	macAddresses := wakeableMacAddresses(target)
+	if len(macAddresses) == 0 {
+		return server.WakeDevice400JSONResponse{Message: "the device did not report the hardware addresses of its network interfaces"}, nil
+	}
+	if summary := deviceSummaryStatus(target); summary != api.DeviceSummaryStatusPoweredOff && summary != api.DeviceSummaryStatusUnknown {
+		return server.WakeDevice409JSONResponse{Message: fmt.Sprintf("the device is not powered off but %s", summary)}, nil
+	}
+
+	var peer *api.Device
+	if request.Body.Peer != nil {
+		peer, err = h.store.Device().Get(ctx, orgId, *request.Body.Peer)
+		if err != nil {
+			if errors.Is(err, flterrors.ErrResourceNotFound) {
+				return server.WakeDevice404JSONResponse{Message: fmt.Sprintf("peer device %s not found", *request.Body.Peer)}, nil
+			}
+			return nil, err
+		}
+		if lo.FromPtr(peer.Metadata.Name) == request.Name || lo.FromPtr(peer.Metadata.Labels)[siteLabel] != site {
+			return server.WakeDevice400JSONResponse{Message: fmt.Sprintf("the peer must be another device with the label %s=%s", siteLabel, site)}, nil
+		}
+		if !deviceIsOnline(peer) {
+			return server.WakeDevice409JSONResponse{Message: fmt.Sprintf("peer device %s is not online", *request.Body.Peer)}, nil
+		}
+	} else {
+		peer, err = h.selectWakePeer(ctx, orgId, request.Name, siteLabel, site)
+		if err != nil {
+			return nil, err
+		}
+		if peer == nil {
+			return server.WakeDevice409JSONResponse{Message: fmt.Sprintf("no other device with the label %s=%s is online", siteLabel, site)}, nil
+		}
+	}
+	peerName := lo.FromPtr(peer.Metadata.Name)
+
+	pending, err := common.PendingDeviceAction(peer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationAction, err)
+	}
+	if pending != nil {
+		return server.WakeDevice409JSONResponse{Message: fmt.Sprintf("peer device %s has a pending %s action %s", peerName, pending.Action, pending.Id)}, nil
+	}
+
+	action := api.DeviceAction{
+		Id:          uuid.New().String(),
+		Action:      api.DeviceActionWakeOnLan,
+		RequestedAt: time.Now().UTC(),
+		WakeOnLan: &api.DeviceWakeOnLan{
+			Target:       request.Name,
+			MacAddresses: macAddresses,
+		},
+	}
+	if reason := strings.TrimSpace(lo.FromPtr(request.Body.Reason)); reason != "" {
+		action.Reason = &reason
+	}
+	if err := h.setPendingDeviceAction(ctx, orgId, peerName, action); err != nil {
+		return nil, err
+	}
+	common.AuditDeviceAction(h.log, request.Name, action.Id, action.Action, common.DeviceActionRequested, fmt.Sprintf("through peer %s", peerName))
+	common.AuditDeviceAction(h.log, peerName, action.Id, action.Action, common.DeviceActionRequested, fmt.Sprintf("waking %s", request.Name))
+
+	return server.WakeDevice200JSONResponse{Peer: peerName, Action: action}, nil
+}
+
+// selectWakePeer returns the online device of the site seen most recently,
+// or nil if there is none.
+func (h *ServiceHandler) selectWakePeer(ctx context.Context, orgId uuid.UUID, target string, siteLabel string, site string) (*api.Device, error) {
+	devices, err := h.store.Device().List(ctx, orgId, store.ListParams{
+		Labels: map[string]string{siteLabel: site},
+		Limit:  store.MaxRecordsPerListRequest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	candidates := lo.Filter(devices.Items, func(device api.Device, _ int) bool {
+		return lo.FromPtr(device.Metadata.Name) != target && deviceIsOnline(&device)
+	})
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Status.LastSeen.After(candidates[j].Status.LastSeen)
+	})
+	return &candidates[0], nil
+}
+
+// wakeableMacAddresses returns the hardware addresses the device reported for
+// its network interfaces.
+func wakeableMacAddresses(device *api.Device) []string {
+	if device.Status == nil || device.Status.SystemInfo.Hardware == nil {
+		return nil
+	}
+	macAddresses := []string{}
+	for _, nic := range device.Status.SystemInfo.Hardware.NetworkInterfaces {
+		mac := strings.ToLower(nic.MacAddress)
+		if validation.MacAddressRegexp.MatchString(mac) && !slices.Contains(macAddresses, mac) {
+			macAddresses = append(macAddresses, mac)
+		}
+	}
+	return macAddresses
+}
+
+func deviceSummaryStatus(device *api.Device) api.DeviceSummaryStatusType {
+	if device.Status == nil || device.Status.Summary.Status == "" {
+		return api.DeviceSummaryStatusUnknown
+	}
+	return device.Status.Summary.Status
+}
+
+// deviceIsOnline returns whether the agent of the device reports, whatever
+// the health of the device.
+func deviceIsOnline(device *api.Device) bool {
+	switch deviceSummaryStatus(device) {
+	case api.DeviceSummaryStatusOnline, api.DeviceSummaryStatusDegraded, api.DeviceSummaryStatusError:
+		return true
+	default:
+		return false
+	}
+}
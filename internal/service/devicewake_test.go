@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type siteDeviceStore struct {
+	store.Store
+	devices *siteDevices
+}
+
+func (s *siteDeviceStore) Device() store.Device {
+	return s.devices
+}
+
+type siteDevices struct {
+	store.Device
+	devices map[string]*v1alpha1.Device
+}
+
+func (d *siteDevices) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Device, error) {
+	device, ok := d.devices[name]
+	if !ok {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	return device, nil
+}
+
+func (d *siteDevices) List(ctx context.Context, orgId uuid.UUID, listParams store.ListParams) (*v1alpha1.DeviceList, error) {
+	list := &v1alpha1.DeviceList{}
+	for _, device := range d.devices {
+		labels := lo.FromPtr(device.Metadata.Labels)
+		if lo.EveryBy(lo.Entries(listParams.Labels), func(e lo.Entry[string, string]) bool { return labels[e.Key] == e.Value }) {
+			list.Items = append(list.Items, *device)
+		}
+	}
+	return list, nil
+}
+
+func (d *siteDevices) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	device := d.devices[name]
+	updated := lo.OmitByKeys(lo.Assign(lo.FromPtr(device.Metadata.Annotations), annotations), deleteKeys)
+	device.Metadata.Annotations = &updated
+	return nil
+}
+
+func newSiteDevice(name string, site string, summary v1alpha1.DeviceSummaryStatusType, lastSeen time.Time) *v1alpha1.Device {
+	status := v1alpha1.NewDeviceStatus()
+	status.Summary.Status = summary
+	status.LastSeen = lastSeen
+	status.SystemInfo.Hardware = &v1alpha1.DeviceHardwareInfo{
+		NetworkInterfaces: []v1alpha1.DeviceNetworkInterfaceInfo{{Name: "eth0", MacAddress: "52:54:00:12:34:5" + name[len(name)-1:]}},
+	}
+	return &v1alpha1.Device{
+		Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr(name), Labels: &map[string]string{"site": site}},
+		Status:   &status,
+	}
+}
+
+func TestWakeDevice(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	now := time.Now()
+	devices := &siteDevices{devices: map[string]*v1alpha1.Device{
+		"kiosk-1": newSiteDevice("kiosk-1", "branch-7", v1alpha1.DeviceSummaryStatusPoweredOff, now.Add(-10*time.Hour)),
+		"kiosk-2": newSiteDevice("kiosk-2", "branch-7", v1alpha1.DeviceSummaryStatusOnline, now.Add(-time.Minute)),
+		"kiosk-3": newSiteDevice("kiosk-3", "branch-7", v1alpha1.DeviceSummaryStatusDegraded, now),
+		"kiosk-4": newSiteDevice("kiosk-4", "branch-7", v1alpha1.DeviceSummaryStatusUnknown, now),
+		"kiosk-5": newSiteDevice("kiosk-5", "branch-8", v1alpha1.DeviceSummaryStatusOnline, now),
+	}}
+	h := &ServiceHandler{store: &siteDeviceStore{devices: devices}, log: logrus.New()}
+
+	// an online device is not woken
+	resp, err := h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-2", Body: &v1alpha1.DeviceWakeRequest{}})
+	require.NoError(err)
+	require.IsType(server.WakeDevice409JSONResponse{}, resp)
+
+	// the peer must be at the same site
+	resp, err = h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-1", Body: &v1alpha1.DeviceWakeRequest{Peer: lo.ToPtr("kiosk-5")}})
+	require.NoError(err)
+	require.IsType(server.WakeDevice400JSONResponse{}, resp)
+
+	// the online device of the site seen most recently sends the packet
+	resp, err = h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-1", Body: &v1alpha1.DeviceWakeRequest{Reason: lo.ToPtr("opening hours")}})
+	require.NoError(err)
+	wake, ok := resp.(server.WakeDevice200JSONResponse)
+	require.True(ok)
+	require.Equal("kiosk-3", wake.Peer)
+	require.Equal(v1alpha1.DeviceActionWakeOnLan, wake.Action.Action)
+	require.Equal(&v1alpha1.DeviceWakeOnLan{Target: "kiosk-1", MacAddresses: []string{"52:54:00:12:34:51"}}, wake.Action.WakeOnLan)
+
+	var pending v1alpha1.DeviceAction
+	require.NoError(json.Unmarshal([]byte((*devices.devices["kiosk-3"].Metadata.Annotations)[model.DeviceAnnotationAction]), &pending))
+	require.Equal(wake.Action.Id, pending.Id)
+
+	// the peer carries out a single action at a time
+	resp, err = h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-1", Body: &v1alpha1.DeviceWakeRequest{Peer: lo.ToPtr("kiosk-3")}})
+	require.NoError(err)
+	require.IsType(server.WakeDevice409JSONResponse{}, resp)
+
+	// a device which does not report is no peer
+	resp, err = h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-1", Body: &v1alpha1.DeviceWakeRequest{Peer: lo.ToPtr("kiosk-4")}})
+	require.NoError(err)
+	require.IsType(server.WakeDevice409JSONResponse{}, resp)
+
+	resp, err = h.WakeDevice(ctx, server.WakeDeviceRequestObject{Name: "kiosk-1", Body: &v1alpha1.DeviceWakeRequest{Peer: lo.ToPtr("kiosk-2")}})
+	require.NoError(err)
+	require.IsType(server.WakeDevice200JSONResponse{}, resp)
+}
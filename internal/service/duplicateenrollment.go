@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// duplicateEnrollment is the device a machine is already enrolled as, and
+// whether approving the enrollment request of the machine replaces it.
+type duplicateEnrollment struct {
+	device  string
+	replace bool
+}
+
+// SetDuplicateEnrollmentPolicy sets what happens to the enrollment of a
+// machine already enrolled as another device.
+func (h *ServiceHandler) SetDuplicateEnrollmentPolicy(policy string) {
+	h.duplicatePolicy = policy
+}
+
+// checkDuplicateEnrollment looks up the device the machine of the enrollment
+// request is already enrolled as before it is approved, as the device may be
+// enrolled or deleted since the request was created. It returns the response
+// refusing the approval if the policy or the approval do not resolve the
+// duplicate.
+func (h *ServiceHandler) checkDuplicateEnrollment(ctx context.Context, orgId uuid.UUID, enrollmentRequest *v1alpha1.EnrollmentRequest, approval *v1alpha1.EnrollmentRequestApproval) (*duplicateEnrollment, server.ApproveEnrollmentRequestResponseObject, error) {
+	device, err := common.FindDuplicateDevice(ctx, h.store, orgId, enrollmentRequest)
+	if err != nil || device == nil {
+		return nil, nil, err
+	}
+	switch {
+	case h.duplicatePolicy == config.DuplicateEnrollmentReject:
+		return nil, server.ApproveEnrollmentRequest409JSONResponse{Message: fmt.Sprintf("the machine is already enrolled as device %s", *device)}, nil
+	case approval.ReplaceDevice != nil:
+		return &duplicateEnrollment{device: *device, replace: *approval.ReplaceDevice}, nil, nil
+	case h.duplicatePolicy == config.DuplicateEnrollmentReplace:
+		return &duplicateEnrollment{device: *device, replace: true}, nil, nil
+	default:
+		return nil, server.ApproveEnrollmentRequest400JSONResponse{Message: fmt.Sprintf(
+			"the machine is already enrolled as device %s: set replaceDevice to true to move that device to the trash, or to false to keep both devices", *device)}, nil
+	}
+}
+
+// resolveDuplicateEnrollment links the device the enrollment request enrolled
+// and the device its machine was already enrolled as. A replaced device is
+// moved to the trash, from which it can be restored until it is purged.
+func (h *ServiceHandler) resolveDuplicateEnrollment(ctx context.Context, orgId uuid.UUID, name string, duplicate *duplicateEnrollment) error {
+	if !duplicate.replace {
+		if err := h.store.Device().UpdateAnnotations(ctx, orgId, duplicate.device, map[string]string{model.DeviceAnnotationDuplicateOf: name}, nil); err != nil {
+			return err
+		}
+		if err := h.store.Device().UpdateAnnotations(ctx, orgId, name, map[string]string{model.DeviceAnnotationDuplicateOf: duplicate.device}, nil); err != nil {
+			return err
+		}
+		auditDuplicateEnrollment(h.log, name, duplicate, "both devices kept")
+		return nil
+	}
+
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, duplicate.device, map[string]string{model.DeviceAnnotationReplacedBy: name}, nil); err != nil {
+		return err
+	}
+	if err := h.store.Device().Delete(ctx, orgId, duplicate.device, h.callbackManager.DeviceUpdatedCallback); err != nil {
+		return err
+	}
+	if err := h.store.Device().UpdateAnnotations(ctx, orgId, name, map[string]string{model.DeviceAnnotationReplaces: duplicate.device}, nil); err != nil {
+		return err
+	}
+	auditDuplicateEnrollment(h.log, name, duplicate, fmt.Sprintf("device %s moved to the trash", duplicate.device))
+	return nil
+}
+
+// auditDuplicateEnrollment writes the resolution of a duplicate enrollment to
+// the audit log, which are the service logs flagged with the audit field.
+func auditDuplicateEnrollment(log logrus.FieldLogger, name string, duplicate *duplicateEnrollment, message string) {
+	log.WithFields(logrus.Fields{
+		"audit":     "DuplicateEnrollment",
+		"device":    name,
+		"duplicate": duplicate.device,
+		"replaced":  duplicate.replace,
+	}).Infof("device %s enrolled the machine of device %s: %s", name, duplicate.device, message)
+}
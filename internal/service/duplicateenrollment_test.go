@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type duplicateEnrollmentStore struct {
+	store.Store
+	devices            *enrolledDevices
+	enrollmentRequests *createdEnrollmentRequests
+}
+
+func (s *duplicateEnrollmentStore) Device() store.Device {
+	return s.devices
+}
+
+func (s *duplicateEnrollmentStore) EnrollmentRequest() store.EnrollmentRequest {
+	return &approvedEnrollmentRequests{createdEnrollmentRequests: s.enrollmentRequests}
+}
+
+func (s *duplicateEnrollmentStore) IssuedCertificate() store.IssuedCertificate {
+	return &recordedCertificates{}
+}
+
+type enrolledDevices struct {
+	store.Device
+	items   map[string]v1alpha1.Device
+	trashed []string
+}
+
+func (d *enrolledDevices) List(ctx context.Context, orgId uuid.UUID, listParams store.ListParams) (*v1alpha1.DeviceList, error) {
+	list := &v1alpha1.DeviceList{}
+	for _, device := range d.items {
+		if device.Status != nil && device.Status.SystemInfo.Hardware != nil &&
+			lo.FromPtr(device.Status.SystemInfo.Hardware.SerialNumber) == listParams.HardwareIdentity.SerialNumber {
+			list.Items = append(list.Items, device)
+		}
+	}
+	return list, nil
+}
+
+func (d *enrolledDevices) Create(ctx context.Context, orgId uuid.UUID, device *v1alpha1.Device, callback store.DeviceStoreCallback) (*v1alpha1.Device, error) {
+	d.items[*device.Metadata.Name] = *device
+	return device, nil
+}
+
+func (d *enrolledDevices) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	device, ok := d.items[name]
+	if !ok {
+		return flterrors.ErrResourceNotFound
+	}
+	updated := lo.Assign(lo.FromPtr(device.Metadata.Annotations), annotations)
+	device.Metadata.Annotations = &updated
+	d.items[name] = device
+	return nil
+}
+
+func (d *enrolledDevices) Delete(ctx context.Context, orgId uuid.UUID, name string, callback store.DeviceStoreCallback) error {
+	d.trashed = append(d.trashed, name)
+	delete(d.items, name)
+	return nil
+}
+
+type approvedEnrollmentRequests struct {
+	*createdEnrollmentRequests
+}
+
+func (e *approvedEnrollmentRequests) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *v1alpha1.EnrollmentRequest) (*v1alpha1.EnrollmentRequest, error) {
+	e.items[*resource.Metadata.Name] = *resource
+	return resource, nil
+}
+
+type recordedCertificates struct {
+	store.IssuedCertificate
+}
+
+func (c *recordedCertificates) Record(ctx context.Context, orgId uuid.UUID, certificate *v1alpha1.IssuedCertificate) error {
+	return nil
+}
+
+func TestDuplicateEnrollment(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+
+	hardware := func(serialNumber string) *v1alpha1.DeviceStatus {
+		status := v1alpha1.NewDeviceStatus()
+		status.SystemInfo.Hardware = &v1alpha1.DeviceHardwareInfo{SerialNumber: lo.ToPtr(serialNumber)}
+		return &status
+	}
+	devices := &enrolledDevices{items: map[string]v1alpha1.Device{
+		"before-reimage": {Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("before-reimage")}, Status: hardware("SN-42")},
+	}}
+	enrollmentRequests := &createdEnrollmentRequests{items: map[string]v1alpha1.EnrollmentRequest{}}
+	h := &ServiceHandler{
+		store:           &duplicateEnrollmentStore{devices: devices, enrollmentRequests: enrollmentRequests},
+		ca:              ca,
+		log:             logrus.New(),
+		callbackManager: dummyCallbackManager(),
+		duplicatePolicy: config.DuplicateEnrollmentReject,
+	}
+
+	// the machine is refused by the Reject policy
+	enrollmentRequest := newTestEnrollmentRequest(require)
+	enrollmentRequest.Spec.DeviceStatus = hardware("SN-42")
+	resp, err := h.CreateEnrollmentRequest(ctx, server.CreateEnrollmentRequestRequestObject{Body: enrollmentRequest})
+	require.NoError(err)
+	require.IsType(server.CreateEnrollmentRequest409JSONResponse{}, resp)
+
+	// the enrollment request records the device the machine is enrolled as
+	h.SetDuplicateEnrollmentPolicy(config.DuplicateEnrollmentRequireApproval)
+	resp, err = h.CreateEnrollmentRequest(ctx, server.CreateEnrollmentRequestRequestObject{Body: enrollmentRequest})
+	require.NoError(err)
+	created, ok := resp.(server.CreateEnrollmentRequest201JSONResponse)
+	require.True(ok)
+	require.Equal(lo.ToPtr("before-reimage"), created.Status.DuplicateOf)
+	name := *created.Metadata.Name
+
+	// the approval must choose whether the device is replaced
+	approve := func(replaceDevice *bool) server.ApproveEnrollmentRequestResponseObject {
+		resp, err := h.ApproveEnrollmentRequest(ctx, server.ApproveEnrollmentRequestRequestObject{
+			Name: name,
+			Body: &v1alpha1.EnrollmentRequestApproval{Approved: true, Labels: &map[string]string{}, ReplaceDevice: replaceDevice},
+		})
+		require.NoError(err)
+		return resp
+	}
+	require.IsType(server.ApproveEnrollmentRequest400JSONResponse{}, approve(nil))
+	require.NotContains(devices.items, name)
+
+	// the replaced device is moved to the trash and both devices are linked
+	require.IsType(server.ApproveEnrollmentRequest200JSONResponse{}, approve(lo.ToPtr(true)))
+	require.Equal([]string{"before-reimage"}, devices.trashed)
+	require.Equal(map[string]string{model.DeviceAnnotationReplaces: "before-reimage"}, *devices.items[name].Metadata.Annotations)
+	require.Equal(lo.ToPtr("before-reimage"), enrollmentRequests.items[name].Status.DuplicateOf)
+}
@@ -2,11 +2,11 @@ package service
 
 import (
 	"context"
-	"encoding/base64"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
 )
 
@@ -30,25 +30,15 @@ func (h *ServiceHandler) EnrollmentConfig(ctx context.Context, request server.En
 		return server.EnrollmentConfig400JSONResponse{Message: "CSR is not signed"}, nil
 	}
 
-	cert, _, err := h.ca.Config.GetPEMBytes()
+	config, err := h.enrollmentConfig(*csr.Status.Certificate, nil)
 	if err != nil {
 		return server.EnrollmentConfig400JSONResponse{Message: err.Error()}, nil
 	}
-	return server.EnrollmentConfig200JSONResponse{
-		EnrollmentService: v1alpha1.EnrollmentService{
-			Authentication: v1alpha1.EnrollmentServiceAuth{
-				ClientCertificateData: base64.StdEncoding.EncodeToString(*csr.Status.Certificate),
-				ClientKeyData:         "",
-			},
-			Service: v1alpha1.EnrollmentServiceService{
-				CertificateAuthorityData: base64.StdEncoding.EncodeToString(cert),
-				Server:                   h.agentEndpoint,
-			},
-			EnrollmentUiEndpoint: h.uiUrl,
-		},
-		GrpcManagementEndpoint: h.consoleGrpcEndpoint,
-		SpecFetchInterval:      "0m10s",
-		StatusUpdateInterval:   "0m10s",
-		TpmPath:                "/dev/tpmrm0",
-	}, nil
+	return server.EnrollmentConfig200JSONResponse(*config), nil
+}
+
+// enrollmentConfig returns the agent configuration for enrolling with the
+// given client certificate and key. The key is left empty if it is nil.
+func (h *ServiceHandler) enrollmentConfig(clientCert []byte, clientKey []byte) (*v1alpha1.EnrollmentConfig, error) {
+	return common.EnrollmentConfig(h.ca, clientCert, clientKey, h.agentEndpoint, h.uiUrl, h.consoleGrpcEndpoint)
 }
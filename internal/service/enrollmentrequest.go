@@ -8,10 +8,12 @@ import (
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
+	authcommon "github.com/flightctl/flightctl/internal/auth/common"
 	"github.com/flightctl/flightctl/internal/crypto"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/go-openapi/swag"
 	"github.com/google/uuid"
@@ -56,12 +58,7 @@ func approveAndSignEnrollmentRequest(ca *crypto.CA, enrollmentRequest *v1alpha1.
 
 	// union user-provided labels with agent-provided labels
 	if enrollmentRequest.Spec.Labels != nil {
-		for k, v := range *enrollmentRequest.Spec.Labels {
-			// don't override user-provided labels
-			if _, ok := (*enrollmentRequest.Status.Approval.Labels)[k]; !ok {
-				(*enrollmentRequest.Status.Approval.Labels)[k] = v
-			}
-		}
+		approval.Labels = enrollmentLabels(enrollmentRequest, approval)
 	}
 
 	condition := v1alpha1.Condition{
@@ -92,7 +89,10 @@ func (h *ServiceHandler) createDeviceFromEnrollmentRequest(ctx context.Context,
 
 // (POST /api/v1/enrollmentrequests)
 func (h *ServiceHandler) CreateEnrollmentRequest(ctx context.Context, request server.CreateEnrollmentRequestRequestObject) (server.CreateEnrollmentRequestResponseObject, error) {
-	return common.CreateEnrollmentRequest(ctx, h.store, request)
+	if claims, ok := ctx.Value(authcommon.ProvisioningClaimsCtxKey).(*authcommon.ProvisioningClaims); ok {
+		return h.createProvisionedEnrollmentRequest(ctx, claims, request)
+	}
+	return common.CreateEnrollmentRequest(ctx, h.store, request, h.duplicatePolicy)
 }
 
 // (GET /api/v1/enrollmentrequests)
@@ -125,7 +125,7 @@ func (h *ServiceHandler) ListEnrollmentRequests(ctx context.Context, request ser
 		return server.ListEnrollmentRequests400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
 	}
 
-	result, err := h.store.EnrollmentRequest().List(ctx, orgId, listParams)
+	result, err := h.store.EnrollmentRequest().List(store.WithReplicaReads(ctx), orgId, listParams)
 	switch err {
 	case nil:
 		return server.ListEnrollmentRequests200JSONResponse(*result), nil
@@ -157,7 +157,7 @@ func (h *ServiceHandler) ReplaceEnrollmentRequest(ctx context.Context, request s
 	orgId := store.NullOrgId
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceEnrollmentRequest400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceEnrollmentRequest400JSONResponse(common.ValidationError(errs)), nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceEnrollmentRequest400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
@@ -180,7 +180,7 @@ func (h *ServiceHandler) ReplaceEnrollmentRequest(ctx context.Context, request s
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceEnrollmentRequest404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceEnrollmentRequest409JSONResponse{}, nil
+		return server.ReplaceEnrollmentRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -221,7 +221,7 @@ func (h *ServiceHandler) ApproveEnrollmentRequest(ctx context.Context, request s
 	orgId := store.NullOrgId
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ApproveEnrollmentRequest400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ApproveEnrollmentRequest400JSONResponse(common.ValidationError(errs)), nil
 	}
 	enrollmentReq, err := h.store.EnrollmentRequest().Get(ctx, orgId, request.Name)
 	switch err {
@@ -240,6 +240,16 @@ func (h *ServiceHandler) ApproveEnrollmentRequest(ctx context.Context, request s
 		if request.Body.ApprovedAt != nil {
 			return server.ApproveEnrollmentRequest400JSONResponse{Message: "ApprovedAt is not allowed to be set when approving enrollment requests"}, nil
 		}
+		if msg := h.checkLabelPolicy(nil, enrollmentLabels(enrollmentReq, request.Body)); msg != "" {
+			return server.ApproveEnrollmentRequest400JSONResponse{Message: msg}, nil
+		}
+		duplicate, refused, err := h.checkDuplicateEnrollment(ctx, orgId, enrollmentReq, request.Body)
+		if err != nil {
+			return nil, err
+		}
+		if refused != nil {
+			return refused, nil
+		}
 		request.Body.ApprovedAt = util.TimeToPtr(time.Now())
 
 		// The same check should happen for ApprovedBy, but we don't have a way to identify
@@ -251,11 +261,23 @@ func (h *ServiceHandler) ApproveEnrollmentRequest(ctx context.Context, request s
 		if err := approveAndSignEnrollmentRequest(h.ca, enrollmentReq, request.Body); err != nil {
 			return server.ApproveEnrollmentRequest400JSONResponse{Message: fmt.Sprintf("Error approving and signing enrollment request: %v", err.Error())}, nil
 		}
+		if duplicate != nil {
+			enrollmentReq.Status.DuplicateOf = &duplicate.device
+		}
+		owner := util.SetResourceOwner(model.EnrollmentRequestKind, request.Name)
+		if err := common.RecordIssuedCertificate(ctx, h.store, orgId, []byte(*enrollmentReq.Status.Certificate), v1alpha1.IssuedCertificateUsageManagement, owner, &request.Name); err != nil {
+			return nil, err
+		}
 
 		// in case of error we return 500 as it will be caused by creating device in db and not by problem with enrollment request
 		if err := h.createDeviceFromEnrollmentRequest(ctx, orgId, enrollmentReq); err != nil {
 			return server.ApproveEnrollmentRequest500JSONResponse{Message: fmt.Sprintf("Error creating device from enrollment request: %v", err.Error())}, nil
 		}
+		if duplicate != nil {
+			if err := h.resolveDuplicateEnrollment(ctx, orgId, request.Name, duplicate); err != nil {
+				return server.ApproveEnrollmentRequest500JSONResponse{Message: fmt.Sprintf("Error replacing device %s: %v", duplicate.device, err.Error())}, nil
+			}
+		}
 	}
 	_, err = h.store.EnrollmentRequest().UpdateStatus(ctx, orgId, enrollmentReq)
 	switch err {
@@ -263,6 +285,8 @@ func (h *ServiceHandler) ApproveEnrollmentRequest(ctx context.Context, request s
 		return server.ApproveEnrollmentRequest200JSONResponse{}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ApproveEnrollmentRequest404JSONResponse{}, nil
+	case flterrors.ErrResourceVersionConflict:
+		return server.ApproveEnrollmentRequest409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -280,8 +304,12 @@ func (h *ServiceHandler) ReplaceEnrollmentRequestStatus(ctx context.Context, req
 	switch err {
 	case nil:
 		return server.ReplaceEnrollmentRequestStatus200JSONResponse(*result), nil
+	case flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceEnrollmentRequestStatus400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceEnrollmentRequestStatus404JSONResponse{}, nil
+	case flterrors.ErrResourceVersionConflict:
+		return server.ReplaceEnrollmentRequestStatus409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// execRoleResource is the resource whose verbs name the roles of exec
+// policies, so that roles are granted with the RBAC of the service.
+const execRoleResource = "devices/exec"
+
+// SetExecPolicies makes the service only dispatch the remote exec commands
+// allowed by the policies, and refuse any command if there are none.
+func (h *ServiceHandler) SetExecPolicies(policies []config.ExecPolicy) {
+	h.execPolicies = policies
+}
+
+// (POST /api/v1/devices/{name}/exec)
+func (h *ServiceHandler) ExecDeviceCommand(ctx context.Context, request server.ExecDeviceCommandRequestObject) (server.ExecDeviceCommandResponseObject, error) {
+	orgId := store.NullOrgId
+
+	command, args := request.Body.Command, lo.FromPtr(request.Body.Args)
+	if !filepath.IsAbs(command) {
+		return server.ExecDeviceCommand400JSONResponse{Message: fmt.Sprintf("command %q must be an absolute path", command)}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ExecDeviceCommand404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	fleet := ""
+	if ownerKind, ownerName, err := util.GetResourceOwner(device.Metadata.Owner); err == nil && ownerKind == model.FleetKind {
+		fleet = ownerName
+	}
+
+	action := api.DeviceAction{
+		Id:          uuid.New().String(),
+		Action:      api.DeviceActionExec,
+		Exec:        &api.DeviceExec{Command: command},
+		RequestedAt: time.Now().UTC(),
+	}
+	if len(args) > 0 {
+		action.Exec.Args = &args
+	}
+	if reason := strings.TrimSpace(lo.FromPtr(request.Body.Reason)); reason != "" {
+		action.Reason = &reason
+	}
+
+	policy, err := matchExecPolicy(h.execPolicies, fleet, command, args, func(role string) (bool, error) {
+		return auth.GetAuthZ().CheckPermission(ctx, execRoleResource, role)
+	})
+	if err != nil {
+		return server.ExecDeviceCommand400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if policy == nil {
+		common.AuditDeviceAction(h.log, request.Name, action.Id, action.Action, common.DeviceActionDenied, execAuditMessage(action, ""))
+		return server.ExecDeviceCommand403JSONResponse{Message: fmt.Sprintf("no exec policy allows running %s on the device", execCommandLine(command, args))}, nil
+	}
+
+	// an exec is a device action, so it waits for the pending one like any
+	// other action
+	pending, err := common.PendingDeviceAction(device)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationAction, err)
+	}
+	if pending != nil {
+		return server.ExecDeviceCommand409JSONResponse{Message: fmt.Sprintf("the device has a pending %s action %s", pending.Action, pending.Id)}, nil
+	}
+
+	if err := h.setPendingDeviceAction(ctx, orgId, request.Name, action); err != nil {
+		return nil, err
+	}
+	common.AuditDeviceAction(h.log, request.Name, action.Id, action.Action, common.DeviceActionRequested, execAuditMessage(action, policy.Name))
+
+	return server.ExecDeviceCommand200JSONResponse(action), nil
+}
+
+// matchExecPolicy returns the first policy allowing the command to run on a
+// device owned by the fleet, empty for devices without a fleet, or nil if no
+// policy allows it. hasRole reports whether the user has the role of a policy.
+func matchExecPolicy(policies []config.ExecPolicy, fleet string, command string, args []string, hasRole func(role string) (bool, error)) (*config.ExecPolicy, error) {
+	for i := range policies {
+		policy := &policies[i]
+		if len(policy.Fleets) > 0 && !slices.Contains(policy.Fleets, fleet) {
+			continue
+		}
+		if !lo.ContainsBy(policy.Commands, func(allowed config.ExecCommand) bool {
+			return allowed.Command == command && execArgsMatch(allowed.Args, args)
+		}) {
+			continue
+		}
+		if policy.Role != "" {
+			allowed, err := hasRole(policy.Role)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				continue
+			}
+		}
+		return policy, nil
+	}
+	return nil, nil
+}
+
+// execArgsMatch returns whether each argument matches the glob pattern at its
+// position, a last pattern ** matching any number of remaining arguments.
+func execArgsMatch(patterns []string, args []string) bool {
+	if len(patterns) > 0 && patterns[len(patterns)-1] == "**" {
+		patterns = patterns[:len(patterns)-1]
+		if len(args) < len(patterns) {
+			return false
+		}
+		args = args[:len(patterns)]
+	}
+	if len(args) != len(patterns) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if matched, err := path.Match(pattern, args[i]); err != nil || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func execCommandLine(command string, args []string) string {
+	return strings.Join(append([]string{command}, args...), " ")
+}
+
+func execAuditMessage(action api.DeviceAction, policy string) string {
+	message := execCommandLine(action.Exec.Command, lo.FromPtr(action.Exec.Args))
+	if policy != "" {
+		message += fmt.Sprintf(" (allowed by policy %s)", policy)
+	}
+	if action.Reason != nil {
+		message += ": " + *action.Reason
+	}
+	return message
+}
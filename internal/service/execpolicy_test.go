@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecArgsMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		args     []string
+		want     bool
+	}{
+		{name: "no arguments", patterns: nil, args: nil, want: true},
+		{name: "exact", patterns: []string{"status", "nginx"}, args: []string{"status", "nginx"}, want: true},
+		{name: "glob", patterns: []string{"status", "*.service"}, args: []string{"status", "nginx.service"}, want: true},
+		{name: "glob mismatch", patterns: []string{"status", "*.service"}, args: []string{"status", "nginx"}, want: false},
+		{name: "extra argument", patterns: []string{"status"}, args: []string{"status", "nginx"}, want: false},
+		{name: "missing argument", patterns: []string{"status", "*"}, args: []string{"status"}, want: false},
+		{name: "remaining arguments", patterns: []string{"-u", "*", "**"}, args: []string{"-u", "nginx", "--since", "1h"}, want: true},
+		{name: "no remaining arguments", patterns: []string{"-u", "*", "**"}, args: []string{"-u", "nginx"}, want: true},
+		{name: "remaining arguments mismatch", patterns: []string{"-u", "*", "**"}, args: []string{"--all"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, execArgsMatch(tt.patterns, tt.args))
+		})
+	}
+}
+
+func TestMatchExecPolicy(t *testing.T) {
+	require := require.New(t)
+	policies := []config.ExecPolicy{
+		{Name: "operators", Role: "operator", Fleets: []string{"kiosks"}, Commands: []config.ExecCommand{
+			{Command: "/usr/bin/systemctl", Args: []string{"restart", "*.service"}},
+		}},
+		{Name: "diagnostics", Commands: []config.ExecCommand{
+			{Command: "/usr/bin/journalctl", Args: []string{"**"}},
+		}},
+	}
+	operator := func(role string) (bool, error) { return role == "operator", nil }
+	viewer := func(role string) (bool, error) { return false, nil }
+
+	policy, err := matchExecPolicy(policies, "kiosks", "/usr/bin/systemctl", []string{"restart", "kiosk.service"}, operator)
+	require.NoError(err)
+	require.Equal("operators", policy.Name)
+
+	// the role and the fleet of the policy are required
+	policy, err = matchExecPolicy(policies, "kiosks", "/usr/bin/systemctl", []string{"restart", "kiosk.service"}, viewer)
+	require.NoError(err)
+	require.Nil(policy)
+	policy, err = matchExecPolicy(policies, "", "/usr/bin/systemctl", []string{"restart", "kiosk.service"}, operator)
+	require.NoError(err)
+	require.Nil(policy)
+
+	// a policy without a role or fleets applies to any user and device
+	policy, err = matchExecPolicy(policies, "", "/usr/bin/journalctl", []string{"-u", "kiosk", "-n", "100"}, viewer)
+	require.NoError(err)
+	require.Equal("diagnostics", policy.Name)
+
+	policy, err = matchExecPolicy(policies, "kiosks", "/usr/bin/rm", []string{"-rf", "/"}, operator)
+	require.NoError(err)
+	require.Nil(policy)
+
+	_, err = matchExecPolicy(policies, "kiosks", "/usr/bin/systemctl", []string{"restart", "kiosk.service"}, func(string) (bool, error) {
+		return false, errors.New("unreachable")
+	})
+	require.Error(err)
+}
+
+func TestExecDeviceCommand(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{
+		Name:  lo.ToPtr("foo"),
+		Owner: util.SetResourceOwner(model.FleetKind, "kiosks"),
+	}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, log: logrus.New()}
+	exec := func(name string, command string, args ...string) server.ExecDeviceCommandResponseObject {
+		resp, err := h.ExecDeviceCommand(ctx, server.ExecDeviceCommandRequestObject{
+			Name: name,
+			Body: &v1alpha1.DeviceExecRequest{Command: command, Args: &args, Reason: lo.ToPtr("kiosk frozen")},
+		})
+		require.NoError(err)
+		return resp
+	}
+
+	// without policies no command is allowed
+	require.IsType(server.ExecDeviceCommand403JSONResponse{}, exec("foo", "/usr/bin/systemctl", "restart", "kiosk.service"))
+
+	h.SetExecPolicies([]config.ExecPolicy{{Name: "kiosks", Fleets: []string{"kiosks"}, Commands: []config.ExecCommand{
+		{Command: "/usr/bin/systemctl", Args: []string{"restart", "*.service"}},
+	}}})
+	require.IsType(server.ExecDeviceCommand400JSONResponse{}, exec("foo", "systemctl", "restart", "kiosk.service"))
+	require.IsType(server.ExecDeviceCommand404JSONResponse{}, exec("bar", "/usr/bin/systemctl", "restart", "kiosk.service"))
+	require.IsType(server.ExecDeviceCommand403JSONResponse{}, exec("foo", "/usr/bin/systemctl", "stop", "kiosk.service"))
+	require.NotContains(lo.FromPtr(device.device.Metadata.Annotations), model.DeviceAnnotationAction)
+
+	resp := exec("foo", "/usr/bin/systemctl", "restart", "kiosk.service")
+	action, ok := resp.(server.ExecDeviceCommand200JSONResponse)
+	require.True(ok)
+	require.Equal(v1alpha1.DeviceActionExec, action.Action)
+	require.Equal("/usr/bin/systemctl", action.Exec.Command)
+	require.Equal([]string{"restart", "kiosk.service"}, *action.Exec.Args)
+	require.Equal("kiosk frozen", lo.FromPtr(action.Reason))
+	require.Contains(*device.device.Metadata.Annotations, model.DeviceAnnotationAction)
+
+	// a second command waits for the first one
+	require.IsType(server.ExecDeviceCommand409JSONResponse{}, exec("foo", "/usr/bin/systemctl", "restart", "kiosk.service"))
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -16,6 +15,7 @@ import (
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -33,13 +33,16 @@ func (h *ServiceHandler) CreateFleet(ctx context.Context, request server.CreateF
 
 	// don't set fields that are managed by the service
 	request.Body.Status = nil
-	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+	common.NilOutManagedFleetMetaProperties(&request.Body.Metadata)
 	if request.Body.Spec.Template.Metadata != nil {
 		common.NilOutManagedObjectMetaProperties(request.Body.Spec.Template.Metadata)
 	}
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateFleet400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateFleet400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if msg := h.checkImagePolicy(&request.Body.Spec.Template.Spec, true); msg != "" {
+		return server.CreateFleet400JSONResponse{Message: msg}, nil
 	}
 
 	result, err := h.store.Fleet().Create(ctx, orgId, request.Body, h.callbackManager.FleetUpdatedCallback)
@@ -84,7 +87,7 @@ func (h *ServiceHandler) ListFleets(ctx context.Context, request server.ListFlee
 		return server.ListFleets400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
 	}
 
-	result, err := h.store.Fleet().List(ctx, orgId, listParams, store.WithDeviceCount(true))
+	result, err := h.store.Fleet().List(store.WithReplicaReads(ctx), orgId, listParams, store.WithDeviceCount(true))
 	switch err {
 	case nil:
 		return server.ListFleets200JSONResponse(*result), nil
@@ -110,7 +113,8 @@ func (h *ServiceHandler) DeleteFleets(ctx context.Context, request server.Delete
 func (h *ServiceHandler) ReadFleet(ctx context.Context, request server.ReadFleetRequestObject) (server.ReadFleetResponseObject, error) {
 	orgId := store.NullOrgId
 
-	result, err := h.store.Fleet().Get(ctx, orgId, request.Name, store.WithSummary(util.DefaultBoolIfNil(request.Params.AddDevicesSummary, false)))
+	// the summary of the devices of the fleet is served by a read replica, if any
+	result, err := h.store.Fleet().Get(store.WithReplicaReads(ctx), orgId, request.Name, store.WithSummary(util.DefaultBoolIfNil(request.Params.AddDevicesSummary, false)))
 	switch err {
 	case nil:
 		return server.ReadFleet200JSONResponse(*result), nil
@@ -127,13 +131,16 @@ func (h *ServiceHandler) ReplaceFleet(ctx context.Context, request server.Replac
 
 	// don't overwrite fields that are managed by the service
 	request.Body.Status = nil
-	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+	common.NilOutManagedFleetMetaProperties(&request.Body.Metadata)
 	if request.Body.Spec.Template.Metadata != nil {
 		common.NilOutManagedObjectMetaProperties(request.Body.Spec.Template.Metadata)
 	}
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceFleet400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceFleet400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if msg := h.checkImagePolicy(&request.Body.Spec.Template.Spec, true); msg != "" {
+		return server.ReplaceFleet400JSONResponse{Message: msg}, nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceFleet400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
@@ -149,12 +156,12 @@ func (h *ServiceHandler) ReplaceFleet(ctx context.Context, request server.Replac
 		}
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceFleet400JSONResponse{Message: err.Error()}, nil
-	case flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.ReplaceFleet400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceFleet404JSONResponse{}, nil
 	case flterrors.ErrUpdatingResourceWithOwnerNotAllowed, flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceFleet409JSONResponse{Message: err.Error()}, nil
+		return server.ReplaceFleet409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -168,11 +175,39 @@ func (h *ServiceHandler) DeleteFleet(ctx context.Context, request server.DeleteF
 	if err == flterrors.ErrResourceNotFound {
 		return server.DeleteFleet404JSONResponse{}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 	if f.Metadata.Owner != nil {
 		// Can't delete via api
 		return server.DeleteFleet409JSONResponse{Message: "could not delete fleet because it is owned by another resource"}, nil
 	}
 
+	devices, err := h.store.Device().List(ctx, orgId, store.ListParams{Owners: []string{*util.SetResourceOwner(model.FleetKind, request.Name)}})
+	if err != nil {
+		return nil, err
+	}
+	switch lo.FromPtrOr(request.Params.PropagationPolicy, v1alpha1.DeletionPropagationPolicyBlock) {
+	case v1alpha1.DeletionPropagationPolicyBlock:
+		if len(devices.Items) > 0 {
+			dependents := lo.Map(devices.Items, func(d v1alpha1.Device, _ int) v1alpha1.ResourceDependency {
+				return v1alpha1.ResourceDependency{Kind: model.DeviceKind, Name: *d.Metadata.Name, Type: v1alpha1.Ownership}
+			})
+			return server.DeleteFleet409JSONResponse{Message: dependentsMessage(model.FleetKind, request.Name, dependents,
+				v1alpha1.DeletionPropagationPolicyOrphan, v1alpha1.DeletionPropagationPolicyCascade)}, nil
+		}
+	case v1alpha1.DeletionPropagationPolicyOrphan:
+		// the devices are released by the callback of the deleted fleet
+	case v1alpha1.DeletionPropagationPolicyCascade:
+		for _, device := range devices.Items {
+			if err := h.store.Device().Delete(ctx, orgId, *device.Metadata.Name, h.callbackManager.DeviceUpdatedCallback); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return server.DeleteFleet400JSONResponse{Message: "propagationPolicy must be Block, Orphan or Cascade"}, nil
+	}
+
 	err = h.store.Fleet().Delete(ctx, orgId, h.callbackManager.FleetUpdatedCallback, request.Name)
 	switch err {
 	case nil:
@@ -207,8 +242,12 @@ func (h *ServiceHandler) ReplaceFleetStatus(ctx context.Context, request server.
 	switch err {
 	case nil:
 		return server.ReplaceFleetStatus200JSONResponse(*result), nil
+	case flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceFleetStatus400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceFleetStatus404JSONResponse{}, nil
+	case flterrors.ErrResourceVersionConflict:
+		return server.ReplaceFleetStatus409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -249,9 +288,11 @@ func (h *ServiceHandler) PatchFleet(ctx context.Context, request server.PatchFle
 	if !reflect.DeepEqual(currentObj.Status, newObj.Status) {
 		return server.PatchFleet400JSONResponse{Message: "status is immutable"}, nil
 	}
+	if msg := h.checkImagePolicy(&newObj.Spec.Template.Spec, true); msg != "" {
+		return server.PatchFleet400JSONResponse{Message: msg}, nil
+	}
 
-	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
+	common.NilOutManagedFleetMetaProperties(&newObj.Metadata)
 
 	var updateCallback func(before *model.Fleet, after *model.Fleet)
 
@@ -263,12 +304,12 @@ func (h *ServiceHandler) PatchFleet(ctx context.Context, request server.PatchFle
 	switch err {
 	case nil:
 		return server.PatchFleet200JSONResponse(*result), nil
-	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.PatchFleet400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.PatchFleet404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.PatchFleet409JSONResponse{}, nil
+		return server.PatchFleet409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/tasks"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// (POST /api/v1/fleets/{name}/lint)
+func (h *ServiceHandler) LintFleet(ctx context.Context, request server.LintFleetRequestObject) (server.LintFleetResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.LintFleet400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.LintFleet400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	linter := fleetLinter{handler: h, orgId: orgId, warnings: []api.FleetLintWarning{}}
+	if err := linter.lintTemplate(ctx, &request.Body.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	return server.LintFleet200JSONResponse{Warnings: linter.warnings}, nil
+}
+
+// fleetLinter collects the problems found in the template of a fleet. Unlike
+// the validation of the fleet, it checks the template against the
+// repositories of the service and the registries of the images.
+type fleetLinter struct {
+	handler  *ServiceHandler
+	orgId    uuid.UUID
+	warnings []api.FleetLintWarning
+}
+
+func (l *fleetLinter) warn(warningType api.FleetLintWarningType, path string, format string, args ...any) {
+	l.warnings = append(l.warnings, api.FleetLintWarning{Type: warningType, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *fleetLinter) lintTemplate(ctx context.Context, spec *api.DeviceSpec) error {
+	for i, configItem := range lo.FromPtr(spec.Config) {
+		if err := l.lintConfigItem(ctx, &configItem, fmt.Sprintf("spec.template.spec.config[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	if spec.Os != nil {
+		if spec.Os.Stream != nil {
+			// the image names the repository whose tag the stream follows
+			l.lintImage(ctx, spec.Os.Image+":"+*spec.Os.Stream, "spec.template.spec.os.stream")
+		} else {
+			l.lintImage(ctx, spec.Os.Image, "spec.template.spec.os.image")
+		}
+		l.lintArchitectureImages(ctx, spec.Os.ArchitectureImages, "spec.template.spec.os.architectureImages")
+	}
+	if spec.Agent != nil && spec.Agent.Update != nil && spec.Agent.Update.Image != nil {
+		l.lintImage(ctx, *spec.Agent.Update.Image, "spec.template.spec.agent.update.image")
+	}
+	for i, application := range lo.FromPtr(spec.Applications) {
+		if application.Pod == nil {
+			continue
+		}
+		path := fmt.Sprintf("spec.template.spec.applications[%d].pod", i)
+		for j, container := range lo.FromPtr(application.Pod.InitContainers) {
+			l.lintImage(ctx, container.Image, fmt.Sprintf("%s.initContainers[%d].image", path, j))
+			l.lintArchitectureImages(ctx, container.ArchitectureImages, fmt.Sprintf("%s.initContainers[%d].architectureImages", path, j))
+		}
+		for j, container := range application.Pod.Containers {
+			l.lintImage(ctx, container.Image, fmt.Sprintf("%s.containers[%d].image", path, j))
+			l.lintArchitectureImages(ctx, container.ArchitectureImages, fmt.Sprintf("%s.containers[%d].architectureImages", path, j))
+		}
+	}
+	return nil
+}
+
+func (l *fleetLinter) lintConfigItem(ctx context.Context, configItem *api.DeviceSpec_Config_Item, path string) error {
+	cfgJson, err := configItem.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed converting configuration to json: %w", err)
+	}
+	for _, param := range tasks.InvalidParameters(cfgJson) {
+		l.warn(api.FleetLintWarningTypeUnknownParameter, path, "unknown parameter %s, parameters can only refer to device.metadata.name, device.metadata.labels[KEY] and device.inventory[KEY]", param)
+	}
+
+	disc, err := configItem.Discriminator()
+	if err != nil {
+		return nil
+	}
+	switch disc {
+	case string(api.TemplateDiscriminatorGitConfig):
+		gitSpec, err := configItem.AsGitConfigProviderSpec()
+		if err != nil {
+			return nil
+		}
+		return l.lintRepository(ctx, gitSpec.GitRef.Repository, path)
+	case string(api.TemplateDiscriminatorHttpConfig):
+		httpSpec, err := configItem.AsHttpConfigProviderSpec()
+		if err != nil {
+			return nil
+		}
+		return l.lintRepository(ctx, httpSpec.HttpRef.Repository, path)
+	case string(api.TemplateDiscriminatorInlineConfig):
+		inlineSpec, err := configItem.AsInlineConfigProviderSpec()
+		if err != nil {
+			return nil
+		}
+		// configurations which do not parse are reported when the fleet is rendered
+		problems, err := tasks.ValidateInlineConfigUnits(&inlineSpec)
+		if err != nil {
+			return nil
+		}
+		for _, problem := range problems {
+			l.warn(api.FleetLintWarningTypeInvalidUnit, path, "%s", problem)
+		}
+	}
+	return nil
+}
+
+// lintRepository checks that the repository exists and that the repository
+// tester of the service could last access it.
+func (l *fleetLinter) lintRepository(ctx context.Context, name string, path string) error {
+	repository, err := l.handler.store.Repository().Get(ctx, l.orgId, name)
+	switch {
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		l.warn(api.FleetLintWarningTypeUnreachableRepository, path, "repository %s does not exist", name)
+		return nil
+	case err != nil:
+		return err
+	}
+	if repository.Status == nil {
+		return nil
+	}
+	condition := api.FindStatusCondition(repository.Status.Conditions, api.RepositoryAccessible)
+	if condition != nil && condition.Status == api.ConditionStatusFalse {
+		l.warn(api.FleetLintWarningTypeUnreachableRepository, path, "repository %s is not accessible: %s", name, condition.Message)
+	}
+	return nil
+}
+
+// lintArchitectureImages checks the images specified for each architecture.
+func (l *fleetLinter) lintArchitectureImages(ctx context.Context, images *map[string]string, path string) {
+	architectures := lo.Keys(lo.FromPtr(images))
+	slices.Sort(architectures)
+	for _, architecture := range architectures {
+		l.lintImage(ctx, (*images)[architecture], path+"."+architecture)
+	}
+}
+
+// lintImage checks that the tag or digest of the image exists in its registry.
+// Images the service cannot query, such as those of registries requiring
+// credentials, are not reported.
+func (l *fleetLinter) lintImage(ctx context.Context, image string, path string) {
+	if strings.TrimSpace(image) == "" {
+		return
+	}
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		l.warn(api.FleetLintWarningTypeImageNotFound, path, "%v", err)
+		return
+	}
+	if _, err := l.handler.registry.ManifestDigest(ctx, ref); err != nil {
+		if errors.Is(err, registry.ErrManifestNotFound) {
+			l.warn(api.FleetLintWarningTypeImageNotFound, path, "image %s does not exist", image)
+		} else {
+			l.handler.log.Debugf("failed checking image %s: %v", image, err)
+		}
+	}
+}
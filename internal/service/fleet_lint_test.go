@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func inlineConfigItem(require *require.Assertions, name string, path string, source string) v1alpha1.DeviceSpec_Config_Item {
+	item := v1alpha1.DeviceSpec_Config_Item{}
+	err := item.FromInlineConfigProviderSpec(v1alpha1.InlineConfigProviderSpec{
+		ConfigType: string(v1alpha1.TemplateDiscriminatorInlineConfig),
+		Name:       name,
+		Inline: map[string]interface{}{
+			"ignition": map[string]interface{}{"version": "3.4.0"},
+			"storage": map[string]interface{}{
+				"files": []interface{}{
+					map[string]interface{}{"path": path, "contents": map[string]interface{}{"source": source}},
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	return item
+}
+
+func TestLintFleet(t *testing.T) {
+	require := require.New(t)
+	fleet := v1alpha1.Fleet{
+		ApiVersion: "v1alpha1",
+		Kind:       "Fleet",
+		Metadata:   v1alpha1.ObjectMeta{Name: util.StrToPtr("foo")},
+		Spec: v1alpha1.FleetSpec{
+			Template: struct {
+				Metadata *v1alpha1.ObjectMeta `json:"metadata,omitempty"`
+				Spec     v1alpha1.DeviceSpec  `json:"spec"`
+			}{
+				Spec: v1alpha1.DeviceSpec{Config: &[]v1alpha1.DeviceSpec_Config_Item{
+					inlineConfigItem(require, "unit", "/etc/systemd/system/app.service", "data:,Description%3Dapp%0A%5BService%5D%0AExecStart%20%2Fbin%2Fapp%0A"),
+					inlineConfigItem(require, "motd", "/etc/{{ device.metadata.site }}", "data:,hello"),
+				}},
+			},
+		},
+	}
+
+	serviceHandler := ServiceHandler{}
+	resp, err := serviceHandler.LintFleet(context.Background(), server.LintFleetRequestObject{Name: "foo", Body: &fleet})
+	require.NoError(err)
+	require.Equal(server.LintFleet200JSONResponse{Warnings: []v1alpha1.FleetLintWarning{
+		{
+			Type:    v1alpha1.FleetLintWarningTypeInvalidUnit,
+			Path:    "spec.template.spec.config[0]",
+			Message: "/etc/systemd/system/app.service: line 1: setting Description is outside of a section",
+		},
+		{
+			Type:    v1alpha1.FleetLintWarningTypeInvalidUnit,
+			Path:    "spec.template.spec.config[0]",
+			Message: `/etc/systemd/system/app.service: line 3: expected a section header or KEY=VALUE, found "ExecStart /bin/app"`,
+		},
+		{
+			Type:    v1alpha1.FleetLintWarningTypeInvalidUnit,
+			Path:    "spec.template.spec.config[0]",
+			Message: "/etc/systemd/system/app.service: service has no ExecStart= setting",
+		},
+		{
+			Type:    v1alpha1.FleetLintWarningTypeUnknownParameter,
+			Path:    "spec.template.spec.config[1]",
+			Message: "unknown parameter {{ device.metadata.site }}, parameters can only refer to device.metadata.name, device.metadata.labels[KEY] and device.inventory[KEY]",
+		},
+	}}, resp)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+)
+
+// (PUT /api/v1/fleets/{name}/migration)
+func (h *ServiceHandler) MigrateFleetDevices(ctx context.Context, request server.MigrateFleetDevicesRequestObject) (server.MigrateFleetDevicesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	migration := *request.Body
+	if err := validateDeviceMigration(&migration); err != nil {
+		return server.MigrateFleetDevices400JSONResponse{Message: err.Error()}, nil
+	}
+	value, err := json.Marshal(migration)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := h.store.Fleet().Get(ctx, orgId, request.Name); err != nil {
+		if err == flterrors.ErrResourceNotFound {
+			return server.MigrateFleetDevices404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	devices, err := h.fleetDeviceNames(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{model.DeviceAnnotationMigration: string(value)}
+	for _, device := range devices {
+		if err := h.store.Device().UpdateAnnotations(ctx, orgId, device, annotations, nil); err != nil {
+			return nil, err
+		}
+	}
+	return server.MigrateFleetDevices200JSONResponse(api.DeviceMigrationResult{Devices: int64(len(devices))}), nil
+}
+
+// (DELETE /api/v1/fleets/{name}/migration)
+func (h *ServiceHandler) CancelFleetMigration(ctx context.Context, request server.CancelFleetMigrationRequestObject) (server.CancelFleetMigrationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if _, err := h.store.Fleet().Get(ctx, orgId, request.Name); err != nil {
+		if err == flterrors.ErrResourceNotFound {
+			return server.CancelFleetMigration404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	devices, err := h.fleetDeviceNames(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		if err := h.store.Device().UpdateAnnotations(ctx, orgId, device, nil, []string{model.DeviceAnnotationMigration}); err != nil {
+			return nil, err
+		}
+	}
+	return server.CancelFleetMigration200JSONResponse(api.DeviceMigrationResult{Devices: int64(len(devices))}), nil
+}
+
+// fleetDeviceNames returns the names of the devices owned by the fleet.
+func (h *ServiceHandler) fleetDeviceNames(ctx context.Context, orgId uuid.UUID, fleet string) ([]string, error) {
+	names := []string{}
+	listParams := store.ListParams{
+		Owners: []string{*util.SetResourceOwner(model.FleetKind, fleet)},
+		Limit:  store.MaxRecordsPerListRequest,
+	}
+	for {
+		result, err := h.store.Device().List(ctx, orgId, listParams)
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range result.Items {
+			names = append(names, *device.Metadata.Name)
+		}
+		if result.Metadata.Continue == nil {
+			return names, nil
+		}
+		cont, err := store.ParseContinueString(result.Metadata.Continue)
+		if err != nil {
+			return nil, err
+		}
+		listParams.Continue = cont
+	}
+}
+
+func validateDeviceMigration(migration *api.DeviceMigration) error {
+	if err := validateHTTPSEndpoint(migration.ManagementEndpoint); err != nil {
+		return fmt.Errorf("invalid managementEndpoint: %w", err)
+	}
+	if migration.GrpcManagementEndpoint != nil {
+		if err := validateHTTPSEndpoint(*migration.GrpcManagementEndpoint); err != nil {
+			return fmt.Errorf("invalid grpcManagementEndpoint: %w", err)
+		}
+	}
+	if migration.CaBundle != nil {
+		if _, err := crypto.ParseCertificates([]byte(*migration.CaBundle)); err != nil {
+			return fmt.Errorf("invalid caBundle: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateHTTPSEndpoint(endpoint string) error {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("must be an https URL such as https://flightctl.example.com:7443")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/samber/lo"
+)
+
+// (PUT /api/v1/fleets/{name}/promotion/approve)
+func (h *ServiceHandler) ApproveFleetPromotion(ctx context.Context, request server.ApproveFleetPromotionRequestObject) (server.ApproveFleetPromotionResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.ApproveFleetPromotion404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	var promotion *api.FleetPromotionStatus
+	if fleet.Status != nil {
+		promotion = fleet.Status.Promotion
+	}
+	if fleet.Spec.Promotion == nil || promotion == nil || promotion.State != api.FleetPromotionStateProposed {
+		return server.ApproveFleetPromotion409JSONResponse{Message: "the fleet has no proposed promotion"}, nil
+	}
+
+	common.AuditFleetPromotion(h.log, request.Name, fleet.Spec.Promotion.From, promotion.TemplateVersion, common.FleetPromotionApproved)
+	err = common.PromoteFleetTemplate(ctx, h.store, h.callbackManager.FleetUpdatedCallback, orgId, fleet, promotion.TemplateVersion)
+	switch {
+	case err == nil:
+	case errors.Is(err, common.ErrPromotedTemplateChanged):
+		return server.ApproveFleetPromotion409JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+	common.AuditFleetPromotion(h.log, request.Name, fleet.Spec.Promotion.From, promotion.TemplateVersion, common.FleetPromotionPromoted)
+	promotion.State = api.FleetPromotionStatePromoted
+	promotion.PromotedAt = lo.ToPtr(time.Now())
+	if err := h.store.Fleet().UpdatePromotion(ctx, orgId, request.Name, promotion); err != nil {
+		return nil, err
+	}
+
+	fleet, err = h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.ApproveFleetPromotion200JSONResponse(*fleet), nil
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultProvisioningValidity = 7 * 24 * time.Hour
+	agentConfigPath             = "/etc/flightctl/config.yaml"
+	agentServiceName            = "flightctl-agent.service"
+)
+
+// (GET /api/v1/fleets/{name}/provisioning)
+func (h *ServiceHandler) ReadFleetProvisioning(ctx context.Context, request server.ReadFleetProvisioningRequestObject) (server.ReadFleetProvisioningResponseObject, error) {
+	orgId := store.NullOrgId
+
+	validity := defaultProvisioningValidity
+	if request.Params.Validity != nil {
+		var err error
+		validity, err = time.ParseDuration(*request.Params.Validity)
+		if err != nil || validity <= 0 {
+			return server.ReadFleetProvisioning400JSONResponse{Message: fmt.Sprintf("invalid validity %q: must be a positive duration", *request.Params.Validity)}, nil
+		}
+	}
+	if request.Params.Format != v1alpha1.ProvisioningFormatKickstart && request.Params.Format != v1alpha1.ProvisioningFormatIgnition {
+		return server.ReadFleetProvisioning400JSONResponse{Message: fmt.Sprintf("unsupported format %q", request.Params.Format)}, nil
+	}
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.ReadFleetProvisioning404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	if fleet.Spec.Selector == nil || len(fleet.Spec.Selector.MatchLabels) == 0 {
+		return server.ReadFleetProvisioning400JSONResponse{Message: fmt.Sprintf("fleet %s does not select devices by labels", request.Name)}, nil
+	}
+
+	// the credentials are only valid for enrollment, and devices receive their
+	// own management certificate once their enrollment request is approved
+	cn := crypto.ClientBootstrapCommonNamePrefix + "fleet-" + request.Name
+	cert, key, err := h.ca.IssueClientCertificate(cn, int(validity.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	if err := common.RecordIssuedCertificate(ctx, h.store, orgId, cert, v1alpha1.IssuedCertificateUsageEnrollment, util.SetResourceOwner(model.FleetKind, request.Name), nil); err != nil {
+		return nil, err
+	}
+	config, err := h.enrollmentConfig(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	labels := fleet.Spec.Selector.MatchLabels
+	config.DefaultLabels = &labels
+	configYAML, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	provisioning := v1alpha1.FleetProvisioning{
+		Fleet:          request.Name,
+		Format:         request.Params.Format,
+		ExpirationTime: time.Now().Add(validity).UTC().Truncate(time.Second),
+	}
+	switch request.Params.Format {
+	case v1alpha1.ProvisioningFormatKickstart:
+		provisioning.Content = kickstartFragment(configYAML)
+	case v1alpha1.ProvisioningFormatIgnition:
+		provisioning.Content, err = ignitionFragment(configYAML)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return server.ReadFleetProvisioning200JSONResponse(provisioning), nil
+}
+
+// kickstartFragment returns a %post section which installs the agent and
+// writes its configuration.
+func kickstartFragment(config []byte) string {
+	return fmt.Sprintf(`%%post --erroronfail
+dnf -y install dnf-plugins-core
+dnf -y copr enable @redhat-et/flightctl-dev
+dnf -y install flightctl-agent
+mkdir -p /etc/flightctl
+base64 -d > %s <<'EOF'
+%s
+EOF
+chmod 0600 %s
+systemctl enable %s
+%%end
+`, agentConfigPath, base64.StdEncoding.EncodeToString(config), agentConfigPath, agentServiceName)
+}
+
+// ignitionFragment returns an ignition config which writes the configuration
+// of the agent and enables it. Ignition cannot install packages, so the agent
+// must be part of the OS image.
+func ignitionFragment(config []byte) (string, error) {
+	type contents struct {
+		Source string `json:"source"`
+	}
+	type file struct {
+		Path      string   `json:"path"`
+		Mode      int      `json:"mode"`
+		Overwrite bool     `json:"overwrite"`
+		Contents  contents `json:"contents"`
+	}
+	type unit struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	}
+	ignition := map[string]any{
+		"ignition": map[string]any{"version": "3.3.0"},
+		"storage": map[string]any{
+			"files": []file{{
+				Path:      agentConfigPath,
+				Mode:      0600,
+				Overwrite: true,
+				Contents:  contents{Source: "data:;base64," + base64.StdEncoding.EncodeToString(config)},
+			}},
+		},
+		"systemd": map[string]any{
+			"units": []unit{{Name: agentServiceName, Enabled: true}},
+		},
+	}
+	marshalled, err := json.MarshalIndent(ignition, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(marshalled), nil
+}
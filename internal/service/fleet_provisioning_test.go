@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	oscrypto "github.com/openshift/library-go/pkg/crypto"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+type provisioningStore struct {
+	FleetStore
+	certificates DummyIssuedCertificate
+}
+
+func (s *provisioningStore) IssuedCertificate() store.IssuedCertificate {
+	return &s.certificates
+}
+
+type DummyIssuedCertificate struct {
+	store.IssuedCertificate
+	Recorded []v1alpha1.IssuedCertificate
+}
+
+func (s *DummyIssuedCertificate) Record(ctx context.Context, orgId uuid.UUID, certificate *v1alpha1.IssuedCertificate) error {
+	s.Recorded = append(s.Recorded, *certificate)
+	return nil
+}
+
+func newProvisioningTestHandler(t *testing.T, selector *v1alpha1.LabelSelector) *ServiceHandler {
+	dir := t.TempDir()
+	ca, _, err := crypto.EnsureCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(t, err)
+	fleet := v1alpha1.Fleet{
+		Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("fleet")},
+		Spec:     v1alpha1.FleetSpec{Selector: selector},
+	}
+	return &ServiceHandler{store: &provisioningStore{FleetStore: FleetStore{FleetVal: fleet}}, ca: ca, agentEndpoint: "https://agent.example.com:7443"}
+}
+
+func readProvisioning(t *testing.T, h *ServiceHandler, params v1alpha1.ReadFleetProvisioningParams) server.ReadFleetProvisioningResponseObject {
+	resp, err := h.ReadFleetProvisioning(context.Background(), server.ReadFleetProvisioningRequestObject{Name: "fleet", Params: params})
+	require.NoError(t, err)
+	return resp
+}
+
+// provisionedConfig returns the agent configuration encoded in a fragment.
+func provisionedConfig(t *testing.T, encoded string) v1alpha1.EnrollmentConfig {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	var config v1alpha1.EnrollmentConfig
+	require.NoError(t, yaml.Unmarshal(data, &config))
+	return config
+}
+
+func TestFleetProvisioningKickstart(t *testing.T) {
+	require := require.New(t)
+	h := newProvisioningTestHandler(t, &v1alpha1.LabelSelector{MatchLabels: map[string]string{"site": "a"}})
+
+	resp := readProvisioning(t, h, v1alpha1.ReadFleetProvisioningParams{Format: v1alpha1.ProvisioningFormatKickstart, Validity: lo.ToPtr("24h")})
+	provisioning, ok := resp.(server.ReadFleetProvisioning200JSONResponse)
+	require.True(ok)
+	require.Contains(provisioning.Content, "%post --erroronfail\n")
+	require.Contains(provisioning.Content, "dnf -y install flightctl-agent\n")
+	require.Contains(provisioning.Content, "systemctl enable flightctl-agent.service\n")
+
+	encoded := regexp.MustCompile(`<<'EOF'\n(.*)\nEOF`).FindStringSubmatch(provisioning.Content)
+	require.Len(encoded, 2)
+	config := provisionedConfig(t, encoded[1])
+	require.Equal(map[string]string{"site": "a"}, *config.DefaultLabels)
+	require.Equal("https://agent.example.com:7443", config.EnrollmentService.Service.Server)
+	require.NotEmpty(config.EnrollmentService.Authentication.ClientKeyData)
+
+	certPEM, err := base64.StdEncoding.DecodeString(config.EnrollmentService.Authentication.ClientCertificateData)
+	require.NoError(err)
+	certs, err := oscrypto.CertsFromPEM(certPEM)
+	require.NoError(err)
+	require.Equal("client-enrollment-fleet-fleet", certs[0].Subject.CommonName)
+	require.WithinDuration(provisioning.ExpirationTime, certs[0].NotAfter, 2*time.Second)
+
+	// the credentials are recorded in the inventory of issued certificates
+	recorded := h.store.(*provisioningStore).certificates.Recorded
+	require.Len(recorded, 1)
+	require.Equal(certs[0].SerialNumber.Text(16), recorded[0].SerialNumber)
+	require.Equal(v1alpha1.IssuedCertificateUsageEnrollment, recorded[0].Usage)
+	require.Equal("Fleet/fleet", *recorded[0].Owner)
+	require.Nil(recorded[0].Device)
+}
+
+func TestFleetProvisioningIgnition(t *testing.T) {
+	require := require.New(t)
+	h := newProvisioningTestHandler(t, &v1alpha1.LabelSelector{MatchLabels: map[string]string{"site": "a"}})
+
+	resp := readProvisioning(t, h, v1alpha1.ReadFleetProvisioningParams{Format: v1alpha1.ProvisioningFormatIgnition})
+	provisioning, ok := resp.(server.ReadFleetProvisioning200JSONResponse)
+	require.True(ok)
+
+	var ignition struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+		Storage struct {
+			Files []struct {
+				Path     string `json:"path"`
+				Mode     int    `json:"mode"`
+				Contents struct {
+					Source string `json:"source"`
+				} `json:"contents"`
+			} `json:"files"`
+		} `json:"storage"`
+		Systemd struct {
+			Units []struct {
+				Name    string `json:"name"`
+				Enabled bool   `json:"enabled"`
+			} `json:"units"`
+		} `json:"systemd"`
+	}
+	require.NoError(json.Unmarshal([]byte(provisioning.Content), &ignition))
+	require.Equal("3.3.0", ignition.Ignition.Version)
+	require.Len(ignition.Storage.Files, 1)
+	require.Equal("/etc/flightctl/config.yaml", ignition.Storage.Files[0].Path)
+	require.Equal(0600, ignition.Storage.Files[0].Mode)
+	config := provisionedConfig(t, ignition.Storage.Files[0].Contents.Source[len("data:;base64,"):])
+	require.Equal(map[string]string{"site": "a"}, *config.DefaultLabels)
+	require.Len(ignition.Systemd.Units, 1)
+	require.True(ignition.Systemd.Units[0].Enabled)
+}
+
+func TestFleetProvisioningBadRequest(t *testing.T) {
+	require := require.New(t)
+
+	h := newProvisioningTestHandler(t, nil)
+	resp := readProvisioning(t, h, v1alpha1.ReadFleetProvisioningParams{Format: v1alpha1.ProvisioningFormatKickstart})
+	_, ok := resp.(server.ReadFleetProvisioning400JSONResponse)
+	require.True(ok)
+
+	h = newProvisioningTestHandler(t, &v1alpha1.LabelSelector{MatchLabels: map[string]string{"site": "a"}})
+	resp = readProvisioning(t, h, v1alpha1.ReadFleetProvisioningParams{Format: v1alpha1.ProvisioningFormatKickstart, Validity: lo.ToPtr("-1h")})
+	_, ok = resp.(server.ReadFleetProvisioning400JSONResponse)
+	require.True(ok)
+
+	resp = readProvisioning(t, h, v1alpha1.ReadFleetProvisioningParams{Format: "pxe"})
+	_, ok = resp.(server.ReadFleetProvisioning400JSONResponse)
+	require.True(ok)
+
+	resp, err := h.ReadFleetProvisioning(context.Background(), server.ReadFleetProvisioningRequestObject{Name: "other", Params: v1alpha1.ReadFleetProvisioningParams{Format: v1alpha1.ProvisioningFormatKickstart}})
+	require.NoError(err)
+	_, ok = resp.(server.ReadFleetProvisioning404JSONResponse)
+	require.True(ok)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+)
+
+// (GET /api/v1/fleets/{name}/report)
+func (h *ServiceHandler) ReadFleetReport(ctx context.Context, request server.ReadFleetReportRequestObject) (server.ReadFleetReportResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.ReadFleetReport404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+
+	report, err := common.GenerateFleetReport(ctx, h.store, orgId, fleet, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return server.ReadFleetReport200JSONResponse(*report), nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+)
+
+const noRolloutInProgress = "the fleet has no rollout in progress"
+
+// (PUT /api/v1/fleets/{name}/rollout/pause)
+func (h *ServiceHandler) PauseFleetRollout(ctx context.Context, request server.PauseFleetRolloutRequestObject) (server.PauseFleetRolloutResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.PauseFleetRollout404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	rollout := fleetRollout(fleet)
+	if !rolloutInProgress(rollout) {
+		return server.PauseFleetRollout409JSONResponse{Message: noRolloutInProgress}, nil
+	}
+	if rollout.State == api.FleetRolloutStatePaused {
+		return server.PauseFleetRollout200JSONResponse(*fleet), nil
+	}
+
+	annotations := map[string]string{model.FleetAnnotationRolloutPaused: "true"}
+	if err := h.store.Fleet().UpdateAnnotations(ctx, orgId, request.Name, annotations, nil); err != nil {
+		return nil, err
+	}
+	rollout.State = api.FleetRolloutStatePaused
+	if err := h.store.Fleet().UpdateRollout(ctx, orgId, request.Name, rollout); err != nil {
+		return nil, err
+	}
+
+	fleet, err = h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.PauseFleetRollout200JSONResponse(*fleet), nil
+}
+
+// (PUT /api/v1/fleets/{name}/rollout/resume)
+func (h *ServiceHandler) ResumeFleetRollout(ctx context.Context, request server.ResumeFleetRolloutRequestObject) (server.ResumeFleetRolloutResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.ResumeFleetRollout404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	if _, paused := lo.FromPtr(fleet.Metadata.Annotations)[model.FleetAnnotationRolloutPaused]; !paused {
+		return server.ResumeFleetRollout200JSONResponse(*fleet), nil
+	}
+
+	if err := h.store.Fleet().UpdateAnnotations(ctx, orgId, request.Name, nil, []string{model.FleetAnnotationRolloutPaused}); err != nil {
+		return nil, err
+	}
+	// the rollout task recomputes the state from the devices of the fleet
+	if rollout := fleetRollout(fleet); rollout != nil && rollout.State == api.FleetRolloutStatePaused {
+		rollout.State = api.FleetRolloutStateProgressing
+		if err := h.store.Fleet().UpdateRollout(ctx, orgId, request.Name, rollout); err != nil {
+			return nil, err
+		}
+	}
+	h.callbackManager.FleetRolloutUpdated(orgId, request.Name)
+
+	fleet, err = h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.ResumeFleetRollout200JSONResponse(*fleet), nil
+}
+
+// (PUT /api/v1/fleets/{name}/rollout/abort)
+func (h *ServiceHandler) AbortFleetRollout(ctx context.Context, request server.AbortFleetRolloutRequestObject) (server.AbortFleetRolloutResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.AbortFleetRollout404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	rollout := fleetRollout(fleet)
+	if !rolloutInProgress(rollout) {
+		return server.AbortFleetRollout409JSONResponse{Message: noRolloutInProgress}, nil
+	}
+	revert := lo.FromPtr(request.Body.Revert)
+	if revert && rollout.PreviousTemplateVersion == nil {
+		return server.AbortFleetRollout400JSONResponse{Message: "the rollout has no previous template version to revert to"}, nil
+	}
+
+	value, err := json.Marshal(model.FleetRolloutAbort{TemplateVersion: rollout.TemplateVersion, Revert: revert})
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{model.FleetAnnotationRolloutAborted: string(value)}
+	if err := h.store.Fleet().UpdateAnnotations(ctx, orgId, request.Name, annotations, []string{model.FleetAnnotationRolloutPaused}); err != nil {
+		return nil, err
+	}
+	rollout.State = api.FleetRolloutStateAborted
+	rollout.BlockingReason = nil
+	rollout.FinishedAt = lo.ToPtr(time.Now())
+	if err := h.store.Fleet().UpdateRollout(ctx, orgId, request.Name, rollout); err != nil {
+		return nil, err
+	}
+	h.callbackManager.FleetRolloutUpdated(orgId, request.Name)
+
+	fleet, err = h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return server.AbortFleetRollout200JSONResponse(*fleet), nil
+}
+
+func fleetRollout(fleet *api.Fleet) *api.FleetRolloutStatus {
+	if fleet.Status == nil {
+		return nil
+	}
+	return fleet.Status.Rollout
+}
+
+// rolloutInProgress returns whether the rollout can still be paused or
+// aborted, which is the case until it completed or was aborted.
+func rolloutInProgress(rollout *api.FleetRolloutStatus) bool {
+	return rollout != nil && rollout.State != api.FleetRolloutStateCompleted && rollout.State != api.FleetRolloutStateAborted
+}
@@ -5,11 +5,13 @@ import (
 	"testing"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -252,3 +254,63 @@ func TestFleetNonExistingResource(t *testing.T) {
 	require.NoError(err)
 	require.Equal(server.PatchFleet404JSONResponse{}, resp)
 }
+
+func TestFleetPatchKeepsConversionAnnotation(t *testing.T) {
+	require := require.New(t)
+	var value interface{} = "newimg"
+	pr := v1alpha1.PatchRequest{
+		{Op: "add", Path: "/metadata/annotations", Value: lo.ToPtr[interface{}](map[string]interface{}{
+			v1beta1.FleetAnnotationConversion:  `{"rolloutPolicy":{"batchSize":2}}`,
+			"fleet-controller/templateVersion": "tv",
+		})},
+		{Op: "replace", Path: "/spec/template/spec/os/image", Value: &value},
+	}
+	resp, _ := testFleetPatch(require, pr)
+	result, ok := resp.(server.PatchFleet200JSONResponse)
+	require.True(ok)
+	require.Equal(map[string]string{v1beta1.FleetAnnotationConversion: `{"rolloutPolicy":{"batchSize":2}}`}, *result.Metadata.Annotations)
+}
+
+func TestAbortFleetRolloutRejected(t *testing.T) {
+	fleet := func(rollout *v1alpha1.FleetRolloutStatus) v1alpha1.Fleet {
+		return v1alpha1.Fleet{
+			Metadata: v1alpha1.ObjectMeta{Name: util.StrToPtr("foo")},
+			Status:   &v1alpha1.FleetStatus{Conditions: []v1alpha1.Condition{}, Rollout: rollout},
+		}
+	}
+	tests := []struct {
+		name   string
+		fleet  v1alpha1.Fleet
+		revert bool
+		want   server.AbortFleetRolloutResponseObject
+	}{
+		{
+			name:  "no rollout",
+			fleet: fleet(nil),
+			want:  server.AbortFleetRollout409JSONResponse{Message: noRolloutInProgress},
+		},
+		{
+			name:  "completed rollout",
+			fleet: fleet(&v1alpha1.FleetRolloutStatus{TemplateVersion: "v2", State: v1alpha1.FleetRolloutStateCompleted}),
+			want:  server.AbortFleetRollout409JSONResponse{Message: noRolloutInProgress},
+		},
+		{
+			name:   "revert without previous template version",
+			fleet:  fleet(&v1alpha1.FleetRolloutStatus{TemplateVersion: "v1", State: v1alpha1.FleetRolloutStateProgressing}),
+			revert: true,
+			want:   server.AbortFleetRollout400JSONResponse{Message: "the rollout has no previous template version to revert to"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			serviceHandler := ServiceHandler{store: &FleetStore{FleetVal: tt.fleet}}
+			resp, err := serviceHandler.AbortFleetRollout(context.Background(), server.AbortFleetRolloutRequestObject{
+				Name: "foo",
+				Body: &v1alpha1.FleetRolloutAbort{Revert: lo.ToPtr(tt.revert)},
+			})
+			require.NoError(err)
+			require.Equal(tt.want, resp)
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package service
 
 import (
+	"time"
+
 	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/remotewrite"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 type ServiceHandler struct {
@@ -13,22 +20,43 @@ type ServiceHandler struct {
 	ca                  *crypto.CA
 	log                 logrus.FieldLogger
 	callbackManager     tasks.CallbackManager
+	metricsForwarder    *remotewrite.Forwarder
 	consoleGrpcEndpoint string
 	agentEndpoint       string
 	uiUrl               string
+	artifacts           artifacts.Store
+	urlSigner           *artifacts.URLSigner
+	registry            *registry.Client
+	requireImageDigests bool
+	trashRetention      time.Duration
+	labelSchemas        []config.LabelSchema
+	consoleGrants       labels.Selector
+	consoleGrantMaxTTL  time.Duration
+	execPolicies        []config.ExecPolicy
+	tunnelPorts         []int
+	tunnelMaxTTL        time.Duration
+	provisioningLimiter *provisioningRateLimiter
+	duplicatePolicy     string
 }
 
 // Make sure we conform to servers Service interface
 var _ server.Service = (*ServiceHandler)(nil)
 
-func NewServiceHandler(store store.Store, callbackManager tasks.CallbackManager, ca *crypto.CA, log logrus.FieldLogger, consoleGrpcEndpoint string, agentEndpoint string, uiUrl string) *ServiceHandler {
+func NewServiceHandler(store store.Store, callbackManager tasks.CallbackManager, metricsForwarder *remotewrite.Forwarder, ca *crypto.CA, log logrus.FieldLogger, consoleGrpcEndpoint string, agentEndpoint string, uiUrl string, artifactStore artifacts.Store, urlSigner *artifacts.URLSigner) *ServiceHandler {
 	return &ServiceHandler{
 		store:               store,
 		ca:                  ca,
 		log:                 log,
 		callbackManager:     callbackManager,
+		metricsForwarder:    metricsForwarder,
 		consoleGrpcEndpoint: consoleGrpcEndpoint,
 		agentEndpoint:       agentEndpoint,
 		uiUrl:               uiUrl,
+		artifacts:           artifactStore,
+		urlSigner:           urlSigner,
+		registry:            registry.NewClient(nil),
+		trashRetention:      config.DefaultTrashRetention,
+		provisioningLimiter: newProvisioningRateLimiter(),
+		duplicatePolicy:     config.DuplicateEnrollmentRequireApproval,
 	}
 }
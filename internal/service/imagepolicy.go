@@ -0,0 +1,25 @@
+package service
+
+import (
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/service/common"
+)
+
+// SetRequireImageDigests makes the service reject devices and fleets whose
+// images are referenced by a tag only, so that each rendered spec names the
+// exact content the devices run.
+func (h *ServiceHandler) SetRequireImageDigests(requireImageDigests bool) {
+	h.requireImageDigests = requireImageDigests
+}
+
+// checkImagePolicy returns why the images of the device spec do not comply
+// with the image policy of the service, or an empty string if they do.
+func (h *ServiceHandler) checkImagePolicy(spec *api.DeviceSpec, template bool) string {
+	if !h.requireImageDigests {
+		return ""
+	}
+	if err := common.CheckImageDigests(spec, template); err != nil {
+		return err.Error()
+	}
+	return ""
+}
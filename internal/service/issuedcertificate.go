@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+)
+
+// (GET /api/v1/certificates)
+func (h *ServiceHandler) ListIssuedCertificates(ctx context.Context, request server.ListIssuedCertificatesRequestObject) (server.ListIssuedCertificatesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	listParams := store.IssuedCertificateListParams{Device: request.Params.Device}
+	if request.Params.ExpiringWithin != nil {
+		within, err := time.ParseDuration(*request.Params.ExpiringWithin)
+		if err != nil || within < 0 {
+			return server.ListIssuedCertificates400JSONResponse{Message: fmt.Sprintf("invalid expiringWithin %q: must be a non-negative duration", *request.Params.ExpiringWithin)}, nil
+		}
+		expiresBefore := time.Now().Add(within)
+		listParams.ExpiresBefore = &expiresBefore
+	}
+
+	result, err := h.store.IssuedCertificate().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListIssuedCertificates200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// addIssuedCertificates fills in the certificates issued to the device.
+func (h *ServiceHandler) addIssuedCertificates(ctx context.Context, orgId uuid.UUID, device *api.Device) error {
+	if device.Status == nil {
+		return nil
+	}
+	certificates, err := h.store.IssuedCertificate().List(ctx, orgId, store.IssuedCertificateListParams{Device: device.Metadata.Name})
+	if err != nil {
+		return err
+	}
+	if len(certificates.Items) > 0 {
+		device.Status.Certificates = &certificates.Items
+	}
+	return nil
+}
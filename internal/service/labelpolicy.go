@@ -0,0 +1,37 @@
+package service
+
+import (
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+)
+
+// SetLabelSchemas makes the service enforce the schemas of label keys on the
+// labels of devices written through the API, so that the labels fleets select
+// devices by keep a known set of keys and values.
+func (h *ServiceHandler) SetLabelSchemas(schemas []config.LabelSchema) {
+	h.labelSchemas = schemas
+}
+
+// checkLabelPolicy returns why the labels of a device do not comply with the
+// label schemas of the service, or an empty string if they do. The current
+// device is the device before the change, nil if it is being created or
+// enrolled.
+func (h *ServiceHandler) checkLabelPolicy(current *api.Device, labels *map[string]string) string {
+	return common.CheckLabelSchemas(h.labelSchemas, current, labels)
+}
+
+// enrollmentLabels returns the labels the device of an enrollment request
+// receives once it is approved. The approved labels take precedence over the
+// labels requested by the agent, except for enrollment requests created with
+// a provisioning token: their labels are the ones of the token, which must not
+// be overridden so that the device joins the fleet of the token.
+func enrollmentLabels(enrollmentRequest *api.EnrollmentRequest, approval *api.EnrollmentRequestApproval) *map[string]string {
+	requested, approved := lo.FromPtr(enrollmentRequest.Spec.Labels), lo.FromPtr(approval.Labels)
+	if _, provisioned := lo.FromPtr(enrollmentRequest.Metadata.Labels)[model.EnrollmentRequestLabelProvisioningToken]; provisioned {
+		return lo.ToPtr(lo.Assign(approved, requested))
+	}
+	return lo.ToPtr(lo.Assign(requested, approved))
+}
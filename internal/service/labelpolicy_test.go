@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLabelPolicy(t *testing.T) {
+	h := &ServiceHandler{}
+	h.SetLabelSchemas([]config.LabelSchema{
+		{Key: "region", Required: true, AllowedValues: []string{"emea", "apac"}},
+		{Key: "site", Immutable: true},
+	})
+	device := func(labels map[string]string) *api.Device {
+		return &api.Device{Metadata: api.ObjectMeta{Labels: &labels}}
+	}
+
+	tests := []struct {
+		name      string
+		current   *api.Device
+		labels    map[string]string
+		expectMsg string
+	}{
+		{
+			name:   "create with allowed value",
+			labels: map[string]string{"region": "emea"},
+		},
+		{
+			name:      "create without required label",
+			labels:    map[string]string{"site": "lobby"},
+			expectMsg: `label "region" is required`,
+		},
+		{
+			name:      "create with disallowed value",
+			labels:    map[string]string{"region": "EMEA"},
+			expectMsg: `label "region" must be one of emea, apac, not "EMEA"`,
+		},
+		{
+			name:      "remove required label",
+			current:   device(map[string]string{"region": "emea"}),
+			labels:    map[string]string{},
+			expectMsg: `label "region" is required`,
+		},
+		{
+			name:    "update device predating the schema",
+			current: device(map[string]string{"region": "us"}),
+			labels:  map[string]string{"region": "us", "env": "prod"},
+		},
+		{
+			name:    "update device without required label",
+			current: device(map[string]string{}),
+			labels:  map[string]string{"env": "prod"},
+		},
+		{
+			name:    "set immutable label",
+			current: device(map[string]string{"region": "emea"}),
+			labels:  map[string]string{"region": "emea", "site": "lobby"},
+		},
+		{
+			name:      "change immutable label",
+			current:   device(map[string]string{"region": "emea", "site": "lobby"}),
+			labels:    map[string]string{"region": "emea", "site": "hall"},
+			expectMsg: `label "site" is immutable`,
+		},
+		{
+			name:      "remove immutable label",
+			current:   device(map[string]string{"region": "emea", "site": "lobby"}),
+			labels:    map[string]string{"region": "emea"},
+			expectMsg: `label "site" is immutable`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := h.checkLabelPolicy(tt.current, &tt.labels)
+			if tt.expectMsg == "" {
+				require.Empty(t, msg)
+				return
+			}
+			require.Contains(t, msg, tt.expectMsg)
+		})
+	}
+}
+
+func TestCheckLabelPolicyWithoutSchemas(t *testing.T) {
+	h := &ServiceHandler{}
+	require.Empty(t, h.checkLabelPolicy(nil, nil))
+}
+
+func TestEnrollmentLabels(t *testing.T) {
+	er := &api.EnrollmentRequest{Spec: api.EnrollmentRequestSpec{Labels: &map[string]string{"region": "apac", "arch": "arm64"}}}
+	approval := &api.EnrollmentRequestApproval{Labels: &map[string]string{"region": "emea"}}
+	require.Equal(t, map[string]string{"region": "emea", "arch": "arm64"}, *enrollmentLabels(er, approval))
+	require.Equal(t, map[string]string{"region": "apac", "arch": "arm64"}, *enrollmentLabels(er, &api.EnrollmentRequestApproval{}))
+
+	// the labels of a provisioning token are not overridden
+	er.Metadata.Labels = &map[string]string{model.EnrollmentRequestLabelProvisioningToken: "factory"}
+	require.Equal(t, map[string]string{"region": "apac", "arch": "arm64"}, *enrollmentLabels(er, approval))
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/labelrules)
+func (h *ServiceHandler) CreateLabelRule(ctx context.Context, request server.CreateLabelRuleRequestObject) (server.CreateLabelRuleResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateLabelRule400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.LabelRule().Create(ctx, orgId, request.Body, h.callbackManager.LabelRulesUpdatedCallback)
+	switch err {
+	case nil:
+		return server.CreateLabelRule201JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.CreateLabelRule400JSONResponse{Message: err.Error()}, nil
+
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/labelrules)
+func (h *ServiceHandler) ListLabelRules(ctx context.Context, request server.ListLabelRulesRequestObject) (server.ListLabelRulesResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListLabelRules400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListLabelRules400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListLabelRules400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.LabelRule().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListLabelRules200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/labelrules)
+func (h *ServiceHandler) DeleteLabelRules(ctx context.Context, request server.DeleteLabelRulesRequestObject) (server.DeleteLabelRulesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.LabelRule().DeleteAll(ctx, orgId, h.callbackManager.LabelRulesUpdatedCallback)
+	switch err {
+	case nil:
+		return server.DeleteLabelRules200JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/labelrules/{name})
+func (h *ServiceHandler) ReadLabelRule(ctx context.Context, request server.ReadLabelRuleRequestObject) (server.ReadLabelRuleResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.LabelRule().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadLabelRule200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadLabelRule404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/labelrules/{name})
+func (h *ServiceHandler) ReplaceLabelRule(ctx context.Context, request server.ReplaceLabelRuleRequestObject) (server.ReplaceLabelRuleResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't overwrite fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.ReplaceLabelRule400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.ReplaceLabelRule400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	result, created, err := h.store.LabelRule().CreateOrUpdate(ctx, orgId, request.Body, h.callbackManager.LabelRulesUpdatedCallback)
+	switch err {
+	case nil:
+		if created {
+			return server.ReplaceLabelRule201JSONResponse(*result), nil
+		} else {
+			return server.ReplaceLabelRule200JSONResponse(*result), nil
+		}
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceLabelRule400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceLabelRule400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReplaceLabelRule404JSONResponse{}, nil
+	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
+		return server.ReplaceLabelRule409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/labelrules/{name})
+func (h *ServiceHandler) DeleteLabelRule(ctx context.Context, request server.DeleteLabelRuleRequestObject) (server.DeleteLabelRuleResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.LabelRule().Delete(ctx, orgId, request.Name, h.callbackManager.LabelRulesUpdatedCallback)
+	switch err {
+	case nil:
+		return server.DeleteLabelRule200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteLabelRule404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
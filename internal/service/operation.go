@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/operations)
+func (h *ServiceHandler) CreateOperation(ctx context.Context, request server.CreateOperationRequestObject) (server.CreateOperationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+	request.Body.Status = nil
+	if request.Body.Metadata.Name == nil {
+		request.Body.Metadata.Name = lo.ToPtr(uuid.NewString())
+	}
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateOperation400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.Operation().Create(ctx, orgId, request.Body)
+	switch {
+	case err == nil:
+		h.callbackManager.OperationCreated(orgId, *result.Metadata.Name)
+		return server.CreateOperation201JSONResponse(*result), nil
+	case errors.Is(err, flterrors.ErrResourceIsNil):
+		return server.CreateOperation400JSONResponse{Message: err.Error()}, nil
+	case errors.Is(err, flterrors.ErrDuplicateName):
+		return server.CreateOperation409JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/operations)
+func (h *ServiceHandler) ListOperations(ctx context.Context, request server.ListOperationsRequestObject) (server.ListOperationsResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListOperations400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListOperations400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListOperations400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.Operation().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListOperations200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/operations/{name})
+func (h *ServiceHandler) ReadOperation(ctx context.Context, request server.ReadOperationRequestObject) (server.ReadOperationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Operation().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadOperation200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadOperation404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/operations/{name})
+func (h *ServiceHandler) DeleteOperation(ctx context.Context, request server.DeleteOperationRequestObject) (server.DeleteOperationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	operation, err := h.store.Operation().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteOperation404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+	if isOperationActive(operation) {
+		return server.DeleteOperation409JSONResponse{Message: "the operation has not finished, cancel it before deleting it"}, nil
+	}
+
+	err = h.store.Operation().Delete(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.DeleteOperation200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteOperation404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/operations/{name}/cancel)
+func (h *ServiceHandler) CancelOperation(ctx context.Context, request server.CancelOperationRequestObject) (server.CancelOperationResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Operation().Cancel(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.CancelOperation200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.CancelOperation404JSONResponse{}, nil
+	case flterrors.ErrOperationFinished:
+		return server.CancelOperation409JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+}
+
+// isOperationActive returns whether the operation is yet to finish.
+func isOperationActive(operation *api.Operation) bool {
+	if operation.Status == nil {
+		return true
+	}
+	return operation.Status.State == api.OperationStatePending || operation.Status.State == api.OperationStateRunning
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type operationStore struct {
+	store.Store
+	operations *dummyOperations
+}
+
+func (s *operationStore) Operation() store.Operation {
+	return s.operations
+}
+
+type dummyOperations struct {
+	store.Operation
+	items map[string]v1alpha1.Operation
+}
+
+func (o *dummyOperations) Create(ctx context.Context, orgId uuid.UUID, operation *v1alpha1.Operation) (*v1alpha1.Operation, error) {
+	operation.Status = &v1alpha1.OperationStatus{State: v1alpha1.OperationStatePending}
+	o.items[*operation.Metadata.Name] = *operation
+	return operation, nil
+}
+
+func (o *dummyOperations) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Operation, error) {
+	operation, ok := o.items[name]
+	if !ok {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	return &operation, nil
+}
+
+func (o *dummyOperations) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	delete(o.items, name)
+	return nil
+}
+
+func TestOperations(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	operations := &dummyOperations{items: map[string]v1alpha1.Operation{}}
+	h := &ServiceHandler{
+		store:           &operationStore{operations: operations},
+		log:             logrus.New(),
+		callbackManager: dummyCallbackManager(),
+	}
+	operation := func(spec v1alpha1.OperationSpec) *v1alpha1.Operation {
+		return &v1alpha1.Operation{ApiVersion: "v1alpha1", Kind: "Operation", Spec: spec}
+	}
+
+	// the name of an operation is generated
+	resp, err := h.CreateOperation(ctx, server.CreateOperationRequestObject{Body: operation(v1alpha1.OperationSpec{
+		Type:          v1alpha1.OperationTypeBulkLabel,
+		LabelSelector: "site=store-1",
+		Labels:        &map[string]string{"tier": "canary"},
+	})})
+	require.NoError(err)
+	created, ok := resp.(server.CreateOperation201JSONResponse)
+	require.True(ok)
+	require.NotEmpty(*created.Metadata.Name)
+	require.Equal(v1alpha1.OperationStatePending, created.Status.State)
+
+	// an operation must select devices, and only sets labels if it is a BulkLabel operation
+	for _, spec := range []v1alpha1.OperationSpec{
+		{Type: v1alpha1.OperationTypeDeviceDecommission, LabelSelector: ""},
+		{Type: v1alpha1.OperationTypeDeviceDecommission, LabelSelector: "site=store-1", Labels: &map[string]string{"tier": "canary"}},
+		{Type: v1alpha1.OperationTypeBulkLabel, LabelSelector: "site=store-1"},
+		{Type: v1alpha1.OperationTypeBulkLabel, LabelSelector: "site=store-1", RemoveLabels: &[]string{"not a key"}},
+		{Type: "Reboot", LabelSelector: "site=store-1"},
+	} {
+		resp, err = h.CreateOperation(ctx, server.CreateOperationRequestObject{Body: operation(spec)})
+		require.NoError(err)
+		require.IsType(server.CreateOperation400JSONResponse{}, resp)
+	}
+
+	// an operation which has not finished cannot be deleted
+	name := *created.Metadata.Name
+	deleteResp, err := h.DeleteOperation(ctx, server.DeleteOperationRequestObject{Name: name})
+	require.NoError(err)
+	require.IsType(server.DeleteOperation409JSONResponse{}, deleteResp)
+
+	operations.items[name] = v1alpha1.Operation{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr(name)}, Status: &v1alpha1.OperationStatus{State: v1alpha1.OperationStateSucceeded}}
+	deleteResp, err = h.DeleteOperation(ctx, server.DeleteOperationRequestObject{Name: name})
+	require.NoError(err)
+	require.IsType(server.DeleteOperation200JSONResponse{}, deleteResp)
+	require.Empty(operations.items)
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	authcommon "github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultProvisioningTokenTTL  = 7 * 24 * time.Hour
+	defaultEnrollmentsPerHour    = 100
+	provisioningTokenSecretBytes = 32
+)
+
+// Events of the audit log of provisioning tokens.
+const (
+	provisioningTokenCreated  = "Created"
+	provisioningTokenRevoked  = "Revoked"
+	provisioningTokenEnrolled = "Enrolled"
+)
+
+var errInvalidProvisioningToken = errors.New("invalid provisioning token")
+
+// provisioningToken is a provisioning token as it is stored in the annotation
+// of its fleet, which holds the hash of its secret rather than the secret.
+type provisioningToken struct {
+	api.ProvisioningToken
+	SecretHash string `json:"secretHash"`
+}
+
+// provisioningRateLimiter counts the enrollment requests each provisioning
+// token created in the last hour. The counts are kept in memory, so each API
+// server enforces the limits on its own.
+type provisioningRateLimiter struct {
+	mu   sync.Mutex
+	uses map[string][]time.Time
+}
+
+func newProvisioningRateLimiter() *provisioningRateLimiter {
+	return &provisioningRateLimiter{uses: map[string][]time.Time{}}
+}
+
+// allow records a use of the token and returns whether it is within the limit
+// of uses per hour.
+func (l *provisioningRateLimiter) allow(id string, limit int32, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	since := now.Add(-time.Hour)
+	uses := lo.Filter(l.uses[id], func(use time.Time, _ int) bool { return use.After(since) })
+	if len(uses) >= int(limit) {
+		l.uses[id] = uses
+		return false
+	}
+	l.uses[id] = append(uses, now)
+	return true
+}
+
+func (l *provisioningRateLimiter) forget(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.uses, id)
+}
+
+// formatProvisioningToken returns the bearer token of a provisioning token,
+// which names its fleet and ID besides the secret, so that it is validated
+// without looking up the tokens of all the fleets.
+func formatProvisioningToken(fleet string, id string, secret string) string {
+	return authcommon.ProvisioningTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(fleet)) + "." + id + "." + secret
+}
+
+func parseProvisioningToken(token string) (fleet string, id string, secret string, err error) {
+	parts := strings.Split(strings.TrimPrefix(token, authcommon.ProvisioningTokenPrefix), ".")
+	if len(parts) != 3 {
+		return "", "", "", errInvalidProvisioningToken
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", "", "", errInvalidProvisioningToken
+	}
+	return string(decoded), parts[1], parts[2], nil
+}
+
+func hashProvisioningSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// provisioningTokens returns the provisioning tokens of the fleet.
+func provisioningTokens(fleet *api.Fleet) ([]provisioningToken, error) {
+	val, ok := lo.FromPtr(fleet.Metadata.Annotations)[model.FleetAnnotationProvisioningTokens]
+	if !ok {
+		return nil, nil
+	}
+	var tokens []provisioningToken
+	if err := json.Unmarshal([]byte(val), &tokens); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", model.FleetAnnotationProvisioningTokens, err)
+	}
+	return tokens, nil
+}
+
+func (h *ServiceHandler) setProvisioningTokens(ctx context.Context, orgId uuid.UUID, name string, tokens []provisioningToken) error {
+	if len(tokens) == 0 {
+		return h.store.Fleet().UpdateAnnotations(ctx, orgId, name, nil, []string{model.FleetAnnotationProvisioningTokens})
+	}
+	value, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	return h.store.Fleet().UpdateAnnotations(ctx, orgId, name, map[string]string{model.FleetAnnotationProvisioningTokens: string(value)}, nil)
+}
+
+// auditProvisioningToken writes an event of a provisioning token to the audit
+// log, which are the service logs flagged with the audit field.
+func auditProvisioningToken(log logrus.FieldLogger, fleet string, id string, event string, message string) {
+	entry := fmt.Sprintf("fleet %s: provisioning token %s %s", fleet, id, strings.ToLower(event))
+	if message != "" {
+		entry += ": " + message
+	}
+	log.WithFields(logrus.Fields{
+		"audit":   "ProvisioningToken",
+		"fleet":   fleet,
+		"tokenId": id,
+		"event":   event,
+	}).Info(entry)
+}
+
+// (GET /api/v1/fleets/{name}/provisioningtokens)
+func (h *ServiceHandler) ListProvisioningTokens(ctx context.Context, request server.ListProvisioningTokensRequestObject) (server.ListProvisioningTokensResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.ListProvisioningTokens404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	tokens, err := provisioningTokens(fleet)
+	if err != nil {
+		return nil, err
+	}
+	list := api.ProvisioningTokenList{Items: []api.ProvisioningToken{}}
+	for _, token := range tokens {
+		list.Items = append(list.Items, token.ProvisioningToken)
+	}
+	return server.ListProvisioningTokens200JSONResponse(list), nil
+}
+
+// (POST /api/v1/fleets/{name}/provisioningtokens)
+func (h *ServiceHandler) CreateProvisioningToken(ctx context.Context, request server.CreateProvisioningTokenRequestObject) (server.CreateProvisioningTokenResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateProvisioningToken400JSONResponse(common.ValidationError(errs)), nil
+	}
+	ttl := defaultProvisioningTokenTTL
+	if request.Body.Ttl != nil {
+		var err error
+		ttl, err = time.ParseDuration(*request.Body.Ttl)
+		if err != nil || ttl <= 0 {
+			return server.CreateProvisioningToken400JSONResponse{Message: fmt.Sprintf("invalid ttl %q: must be a positive duration such as 720h", *request.Body.Ttl)}, nil
+		}
+	}
+	enrollmentsPerHour := lo.FromPtrOr(request.Body.EnrollmentsPerHour, defaultEnrollmentsPerHour)
+	if enrollmentsPerHour < 1 {
+		return server.CreateProvisioningToken400JSONResponse{Message: "enrollmentsPerHour must be at least 1"}, nil
+	}
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.CreateProvisioningToken404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	if fleet.Spec.Selector == nil || len(fleet.Spec.Selector.MatchLabels) == 0 {
+		return server.CreateProvisioningToken400JSONResponse{Message: fmt.Sprintf("fleet %s does not select devices by labels", request.Name)}, nil
+	}
+	// the labels of the token must not keep the devices out of the fleet
+	for key, value := range lo.FromPtr(request.Body.Labels) {
+		if selected, ok := fleet.Spec.Selector.MatchLabels[key]; ok && selected != value {
+			return server.CreateProvisioningToken400JSONResponse{Message: fmt.Sprintf("label %s=%s conflicts with the label %s=%s selected by fleet %s", key, value, key, selected, request.Name)}, nil
+		}
+	}
+	tokens, err := provisioningTokens(fleet)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, provisioningTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+	now := time.Now().UTC().Truncate(time.Second)
+	token := provisioningToken{
+		ProvisioningToken: api.ProvisioningToken{
+			Id:                 uuid.New().String(),
+			Fleet:              request.Name,
+			Description:        request.Body.Description,
+			Labels:             request.Body.Labels,
+			EnrollmentsPerHour: enrollmentsPerHour,
+			CreatedAt:          now,
+			ExpiresAt:          now.Add(ttl),
+		},
+		SecretHash: hashProvisioningSecret(encodedSecret),
+	}
+	// expired tokens are dropped whenever a token is created
+	tokens = lo.Filter(tokens, func(t provisioningToken, _ int) bool { return now.Before(t.ExpiresAt) })
+	if err := h.setProvisioningTokens(ctx, orgId, request.Name, append(tokens, token)); err != nil {
+		return nil, err
+	}
+	auditProvisioningToken(h.log, request.Name, token.Id, provisioningTokenCreated, fmt.Sprintf("until %s, %d enrollments per hour", token.ExpiresAt.Format(time.RFC3339), enrollmentsPerHour))
+
+	created := token.ProvisioningToken
+	created.Token = lo.ToPtr(formatProvisioningToken(request.Name, token.Id, encodedSecret))
+	return server.CreateProvisioningToken201JSONResponse(created), nil
+}
+
+// (DELETE /api/v1/fleets/{name}/provisioningtokens/{id})
+func (h *ServiceHandler) DeleteProvisioningToken(ctx context.Context, request server.DeleteProvisioningTokenRequestObject) (server.DeleteProvisioningTokenResponseObject, error) {
+	orgId := store.NullOrgId
+
+	fleet, err := h.store.Fleet().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.DeleteProvisioningToken404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	tokens, err := provisioningTokens(fleet)
+	if err != nil {
+		return nil, err
+	}
+	token, i, found := lo.FindIndexOf(tokens, func(t provisioningToken) bool { return t.Id == request.Id })
+	if !found {
+		return server.DeleteProvisioningToken404JSONResponse{Message: fmt.Sprintf("fleet %s has no provisioning token %s", request.Name, request.Id)}, nil
+	}
+	if err := h.setProvisioningTokens(ctx, orgId, request.Name, append(tokens[:i:i], tokens[i+1:]...)); err != nil {
+		return nil, err
+	}
+	h.provisioningLimiter.forget(token.Id)
+	auditProvisioningToken(h.log, request.Name, token.Id, provisioningTokenRevoked, "")
+
+	return server.DeleteProvisioningToken200JSONResponse(token.ProvisioningToken), nil
+}
+
+// ValidateProvisioningToken validates the bearer token of a provisioning
+// line, and returns its claims with the labels of the devices enrolled with
+// it: the labels selected by its fleet and the labels of the token.
+func (h *ServiceHandler) ValidateProvisioningToken(ctx context.Context, bearer string) (*authcommon.ProvisioningClaims, error) {
+	orgId := store.NullOrgId
+
+	fleetName, id, secret, err := parseProvisioningToken(bearer)
+	if err != nil {
+		return nil, err
+	}
+	fleet, err := h.store.Fleet().Get(ctx, orgId, fleetName)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil, errInvalidProvisioningToken
+		}
+		return nil, err
+	}
+	tokens, err := provisioningTokens(fleet)
+	if err != nil {
+		return nil, err
+	}
+	token, found := lo.Find(tokens, func(t provisioningToken) bool { return t.Id == id })
+	if !found || subtle.ConstantTimeCompare([]byte(token.SecretHash), []byte(hashProvisioningSecret(secret))) != 1 {
+		return nil, errInvalidProvisioningToken
+	}
+	now := time.Now()
+	if !now.Before(token.ExpiresAt) {
+		return nil, fmt.Errorf("provisioning token %s expired at %s", id, token.ExpiresAt.Format(time.RFC3339))
+	}
+	if fleet.Spec.Selector == nil || len(fleet.Spec.Selector.MatchLabels) == 0 {
+		return nil, fmt.Errorf("fleet %s does not select devices by labels", fleetName)
+	}
+	if !h.provisioningLimiter.allow(id, token.EnrollmentsPerHour, now) {
+		return nil, authcommon.ErrRateLimited
+	}
+
+	labels := maps.Clone(lo.FromPtr(token.Labels))
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	maps.Copy(labels, fleet.Spec.Selector.MatchLabels)
+	return &authcommon.ProvisioningClaims{Fleet: fleetName, TokenId: id, Labels: labels}, nil
+}
+
+// createProvisionedEnrollmentRequest creates an enrollment request with a
+// provisioning token. The device is labeled with the labels of the token
+// rather than the labels it asks for, and the enrollment request records the
+// token which created it. Provisioning lines cannot read the rest of the
+// tenant, so an existing enrollment request is a conflict rather than returned.
+func (h *ServiceHandler) createProvisionedEnrollmentRequest(ctx context.Context, claims *authcommon.ProvisioningClaims, request server.CreateEnrollmentRequestRequestObject) (server.CreateEnrollmentRequestResponseObject, error) {
+	orgId := store.NullOrgId
+
+	request.Body.Status = nil
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+	request.Body.Metadata.Labels = &map[string]string{model.EnrollmentRequestLabelProvisioningToken: claims.TokenId}
+	labels := maps.Clone(claims.Labels)
+	request.Body.Spec.Labels = &labels
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateEnrollmentRequest400JSONResponse(common.ValidationError(errs)), nil
+	}
+	name := lo.FromPtr(request.Body.Metadata.Name)
+	if _, err := h.store.EnrollmentRequest().Get(ctx, orgId, name); err == nil {
+		return server.CreateEnrollmentRequest409JSONResponse{Message: fmt.Sprintf("enrollment request %s already exists", name)}, nil
+	} else if !errors.Is(err, flterrors.ErrResourceNotFound) {
+		return nil, err
+	}
+	duplicateOf, err := common.FindDuplicateDevice(ctx, h.store, orgId, request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if duplicateOf != nil && h.duplicatePolicy == config.DuplicateEnrollmentReject {
+		return server.CreateEnrollmentRequest409JSONResponse{Message: "the machine is already enrolled as another device"}, nil
+	}
+	if err := common.ValidateAndCompleteEnrollmentRequest(request.Body); err != nil {
+		return nil, err
+	}
+	request.Body.Status.DuplicateOf = duplicateOf
+
+	result, err := h.store.EnrollmentRequest().Create(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.CreateEnrollmentRequest400JSONResponse{Message: err.Error()}, nil
+	default:
+		return nil, err
+	}
+	auditProvisioningToken(h.log, claims.Fleet, claims.TokenId, provisioningTokenEnrolled, fmt.Sprintf("enrollment request %s", name))
+	return server.CreateEnrollmentRequest201JSONResponse(*result), nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	stdcrypto "crypto"
+	"testing"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	authcommon "github.com/flightctl/flightctl/internal/auth/common"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type provisioningTokenStore struct {
+	store.Store
+	fleet              *annotatedFleet
+	enrollmentRequests *createdEnrollmentRequests
+}
+
+func (s *provisioningTokenStore) Fleet() store.Fleet {
+	return s.fleet
+}
+
+func (s *provisioningTokenStore) EnrollmentRequest() store.EnrollmentRequest {
+	return s.enrollmentRequests
+}
+
+type annotatedFleet struct {
+	store.Fleet
+	fleet v1alpha1.Fleet
+}
+
+func (f *annotatedFleet) Get(ctx context.Context, orgId uuid.UUID, name string, opts ...store.GetOption) (*v1alpha1.Fleet, error) {
+	if name != *f.fleet.Metadata.Name {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	fleet := f.fleet
+	annotations := lo.Assign(lo.FromPtr(f.fleet.Metadata.Annotations))
+	fleet.Metadata.Annotations = &annotations
+	return &fleet, nil
+}
+
+func (f *annotatedFleet) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	updated := lo.OmitByKeys(lo.Assign(lo.FromPtr(f.fleet.Metadata.Annotations), annotations), deleteKeys)
+	f.fleet.Metadata.Annotations = &updated
+	return nil
+}
+
+type createdEnrollmentRequests struct {
+	store.EnrollmentRequest
+	items map[string]v1alpha1.EnrollmentRequest
+}
+
+func (e *createdEnrollmentRequests) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.EnrollmentRequest, error) {
+	enrollmentRequest, ok := e.items[name]
+	if !ok {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	return &enrollmentRequest, nil
+}
+
+func (e *createdEnrollmentRequests) Create(ctx context.Context, orgId uuid.UUID, resource *v1alpha1.EnrollmentRequest) (*v1alpha1.EnrollmentRequest, error) {
+	e.items[*resource.Metadata.Name] = *resource
+	return resource, nil
+}
+
+func newTestEnrollmentRequest(require *require.Assertions) *v1alpha1.EnrollmentRequest {
+	_, key, err := crypto.NewKeyPair()
+	require.NoError(err)
+	csrPEM, err := crypto.MakeCSR(key.(stdcrypto.Signer), "device")
+	require.NoError(err)
+	csr, err := crypto.ParseCSR(csrPEM)
+	require.NoError(err)
+	name, err := crypto.DeviceFingerprint(csr.PublicKey)
+	require.NoError(err)
+	return &v1alpha1.EnrollmentRequest{
+		Metadata: v1alpha1.ObjectMeta{Name: &name, Labels: &map[string]string{"owner": "factory"}},
+		Spec:     v1alpha1.EnrollmentRequestSpec{Csr: string(csrPEM), Labels: &map[string]string{"site": "other", "fleet": "other"}},
+	}
+}
+
+func TestProvisioningToken(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	fleet := &annotatedFleet{fleet: v1alpha1.Fleet{
+		Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("line")},
+		Spec:     v1alpha1.FleetSpec{Selector: &v1alpha1.LabelSelector{MatchLabels: map[string]string{"fleet": "line"}}},
+	}}
+	enrollmentRequests := &createdEnrollmentRequests{items: map[string]v1alpha1.EnrollmentRequest{}}
+	h := &ServiceHandler{
+		store:               &provisioningTokenStore{fleet: fleet, enrollmentRequests: enrollmentRequests},
+		log:                 logrus.New(),
+		provisioningLimiter: newProvisioningRateLimiter(),
+	}
+
+	// the labels of a token cannot keep devices out of the fleet
+	resp, err := h.CreateProvisioningToken(ctx, server.CreateProvisioningTokenRequestObject{
+		Name: "line",
+		Body: &v1alpha1.ProvisioningTokenRequest{Labels: &map[string]string{"fleet": "other"}},
+	})
+	require.NoError(err)
+	require.IsType(server.CreateProvisioningToken400JSONResponse{}, resp)
+
+	resp, err = h.CreateProvisioningToken(ctx, server.CreateProvisioningTokenRequestObject{
+		Name: "line",
+		Body: &v1alpha1.ProvisioningTokenRequest{Labels: &map[string]string{"site": "plant-1"}, EnrollmentsPerHour: lo.ToPtr(int32(1))},
+	})
+	require.NoError(err)
+	created, ok := resp.(server.CreateProvisioningToken201JSONResponse)
+	require.True(ok)
+	require.NotNil(created.Token)
+	require.Contains(*fleet.fleet.Metadata.Annotations, model.FleetAnnotationProvisioningTokens)
+	require.NotContains((*fleet.fleet.Metadata.Annotations)[model.FleetAnnotationProvisioningTokens], *created.Token)
+
+	// the secret is not listed
+	listResp, err := h.ListProvisioningTokens(ctx, server.ListProvisioningTokensRequestObject{Name: "line"})
+	require.NoError(err)
+	list, ok := listResp.(server.ListProvisioningTokens200JSONResponse)
+	require.True(ok)
+	require.Len(list.Items, 1)
+	require.Equal(created.Id, list.Items[0].Id)
+	require.Nil(list.Items[0].Token)
+
+	// a token with another secret is invalid
+	fleetName, id, _, err := parseProvisioningToken(*created.Token)
+	require.NoError(err)
+	_, err = h.ValidateProvisioningToken(ctx, formatProvisioningToken(fleetName, id, "guessed"))
+	require.Error(err)
+
+	// the token labels the devices it enrolls with the labels of the fleet and
+	// of the token, whatever labels the device asks for
+	claims, err := h.ValidateProvisioningToken(ctx, *created.Token)
+	require.NoError(err)
+	require.Equal(map[string]string{"fleet": "line", "site": "plant-1"}, claims.Labels)
+	enrollmentRequest := newTestEnrollmentRequest(require)
+	ctx = context.WithValue(ctx, authcommon.ProvisioningClaimsCtxKey, claims)
+	erResp, err := h.CreateEnrollmentRequest(ctx, server.CreateEnrollmentRequestRequestObject{Body: enrollmentRequest})
+	require.NoError(err)
+	result, ok := erResp.(server.CreateEnrollmentRequest201JSONResponse)
+	require.True(ok)
+	require.Equal(map[string]string{"fleet": "line", "site": "plant-1"}, *result.Spec.Labels)
+	require.Equal(map[string]string{model.EnrollmentRequestLabelProvisioningToken: created.Id}, *result.Metadata.Labels)
+
+	// an existing enrollment request is not returned to the token
+	enrollmentRequest = newTestEnrollmentRequest(require)
+	enrollmentRequest.Metadata.Name = result.Metadata.Name
+	erResp, err = h.CreateEnrollmentRequest(ctx, server.CreateEnrollmentRequestRequestObject{Body: enrollmentRequest})
+	require.NoError(err)
+	require.IsType(server.CreateEnrollmentRequest409JSONResponse{}, erResp)
+
+	// the token allows a single enrollment per hour
+	_, err = h.ValidateProvisioningToken(ctx, *created.Token)
+	require.ErrorIs(err, authcommon.ErrRateLimited)
+
+	// a revoked token is invalid
+	deleteResp, err := h.DeleteProvisioningToken(ctx, server.DeleteProvisioningTokenRequestObject{Name: "line", Id: created.Id})
+	require.NoError(err)
+	require.IsType(server.DeleteProvisioningToken200JSONResponse{}, deleteResp)
+	require.NotContains(*fleet.fleet.Metadata.Annotations, model.FleetAnnotationProvisioningTokens)
+	_, err = h.ValidateProvisioningToken(ctx, *created.Token)
+	require.Error(err)
+	require.NotErrorIs(err, authcommon.ErrRateLimited)
+}
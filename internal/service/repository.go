@@ -2,9 +2,9 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
@@ -12,7 +12,9 @@ import (
 	"github.com/flightctl/flightctl/internal/service/common"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/go-openapi/swag"
+	"github.com/samber/lo"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
@@ -25,7 +27,7 @@ func (h *ServiceHandler) CreateRepository(ctx context.Context, request server.Cr
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateRepository400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateRepository400JSONResponse(common.ValidationError(errs)), nil
 	}
 
 	result, err := h.store.Repository().Create(ctx, orgId, request.Body, h.callbackManager.RepositoryUpdatedCallback)
@@ -116,7 +118,7 @@ func (h *ServiceHandler) ReplaceRepository(ctx context.Context, request server.R
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceRepository400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceRepository400JSONResponse(common.ValidationError(errs)), nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceRepository400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
@@ -132,12 +134,12 @@ func (h *ServiceHandler) ReplaceRepository(ctx context.Context, request server.R
 		}
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceRepository400JSONResponse{Message: err.Error()}, nil
-	case flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.ReplaceRepository400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceRepository404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceRepository409JSONResponse{}, nil
+		return server.ReplaceRepository409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -147,6 +149,25 @@ func (h *ServiceHandler) ReplaceRepository(ctx context.Context, request server.R
 func (h *ServiceHandler) DeleteRepository(ctx context.Context, request server.DeleteRepositoryRequestObject) (server.DeleteRepositoryResponseObject, error) {
 	orgId := store.NullOrgId
 
+	switch lo.FromPtrOr(request.Params.PropagationPolicy, v1alpha1.DeletionPropagationPolicyBlock) {
+	case v1alpha1.DeletionPropagationPolicyBlock:
+		dependencies, err := h.resourceDependencies(ctx, orgId, model.RepositoryKind, request.Name)
+		if err == flterrors.ErrResourceNotFound {
+			return server.DeleteRepository404JSONResponse{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(dependencies.Dependents) > 0 {
+			return server.DeleteRepository409JSONResponse{Message: dependentsMessage(model.RepositoryKind, request.Name, dependencies.Dependents,
+				v1alpha1.DeletionPropagationPolicyOrphan)}, nil
+		}
+	case v1alpha1.DeletionPropagationPolicyOrphan:
+		// the fleets and devices referencing the repository become invalid
+	default:
+		return server.DeleteRepository400JSONResponse{Message: "propagationPolicy must be Block or Orphan"}, nil
+	}
+
 	err := h.store.Repository().Delete(ctx, orgId, request.Name, h.callbackManager.RepositoryUpdatedCallback)
 	switch err {
 	case nil:
@@ -195,7 +216,6 @@ func (h *ServiceHandler) PatchRepository(ctx context.Context, request server.Pat
 	}
 
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 
 	var updateCallback func(repo *model.Repository)
 
@@ -207,13 +227,99 @@ func (h *ServiceHandler) PatchRepository(ctx context.Context, request server.Pat
 	switch err {
 	case nil:
 		return server.PatchRepository200JSONResponse(*result), nil
-	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.PatchRepository400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.PatchRepository404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.PatchRepository409JSONResponse{}, nil
+		return server.PatchRepository409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
 }
+
+// (PUT /api/v1/repositories/{name}/credentials)
+// The new credentials are stored only once the service accessed the repository with them.
+func (h *ServiceHandler) RotateRepositoryCredentials(ctx context.Context, request server.RotateRepositoryCredentialsRequestObject) (server.RotateRepositoryCredentialsResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if (request.Body.HttpConfig == nil) == (request.Body.SshConfig == nil) {
+		return server.RotateRepositoryCredentials400JSONResponse{Message: "exactly one of httpConfig and sshConfig must be set"}, nil
+	}
+
+	repository, err := h.store.Repository().GetInternal(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.RotateRepositoryCredentials404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+
+	spec, err := rotatedRepositorySpec(repository.Spec.Data, request.Body)
+	if err != nil {
+		return server.RotateRepositoryCredentials400JSONResponse{Message: err.Error()}, nil
+	}
+	apiRepository := v1alpha1.Repository{Metadata: v1alpha1.ObjectMeta{Name: &repository.Name}, Spec: spec}
+	if errs := apiRepository.Validate(); len(errs) > 0 {
+		return server.RotateRepositoryCredentials400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	rotated := *repository
+	rotated.Spec = model.MakeJSONField(spec)
+	accessErr := (&tasks.GitRepoTester{}).TestAccess(&rotated)
+	if accessErr != nil {
+		return server.RotateRepositoryCredentials400JSONResponse{Message: fmt.Sprintf("the repository rejected the new credentials: %v", accessErr)}, nil
+	}
+
+	status := v1alpha1.RepositoryStatus{Conditions: []v1alpha1.Condition{}}
+	if repository.Status != nil {
+		status = repository.Status.Data
+	}
+	now := time.Now()
+	tasks.SetRepositoryAccess(&status, nil, now)
+	status.CredentialsRotatedAt = &now
+
+	var updateCallback store.RepositoryStoreCallback
+	if h.callbackManager != nil {
+		updateCallback = h.callbackManager.RepositoryUpdatedCallback
+	}
+	result, err := h.store.Repository().RotateCredentials(ctx, orgId, request.Name, lo.FromPtr(repository.ResourceVersion), spec, status, updateCallback)
+	switch err {
+	case nil:
+		return server.RotateRepositoryCredentials200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.RotateRepositoryCredentials404JSONResponse{}, nil
+	case flterrors.ErrResourceVersionConflict:
+		return server.RotateRepositoryCredentials409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// rotatedRepositorySpec returns the spec of the repository with the new
+// credentials, keeping its type and URL.
+func rotatedRepositorySpec(current v1alpha1.RepositorySpec, credentials *v1alpha1.RepositoryCredentials) (v1alpha1.RepositorySpec, error) {
+	spec := v1alpha1.RepositorySpec{}
+	base, err := current.AsGenericRepoSpec()
+	if err != nil {
+		return spec, fmt.Errorf("invalid repository spec: %w", err)
+	}
+	// repositories without credentials can be given either kind of them
+	_, genericErr := current.GetGenericRepoSpec()
+	_, httpErr := current.GetHttpRepoSpec()
+	_, sshErr := current.GetSshRepoSpec()
+
+	if credentials.HttpConfig != nil {
+		if genericErr != nil && sshErr == nil {
+			return spec, fmt.Errorf("the repository uses ssh credentials")
+		}
+		err = spec.FromHttpRepoSpec(v1alpha1.HttpRepoSpec{Type: base.Type, Url: base.Url, HttpConfig: *credentials.HttpConfig})
+	} else {
+		if base.Type == v1alpha1.Http || (genericErr != nil && httpErr == nil) {
+			return spec, fmt.Errorf("the repository uses http credentials")
+		}
+		err = spec.FromSshRepoSpec(v1alpha1.SshRepoSpec{Type: base.Type, Url: base.Url, SshConfig: *credentials.SshConfig})
+	}
+	return spec, err
+}
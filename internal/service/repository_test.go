@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/api/server"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -42,6 +45,20 @@ func (s *DummyRepository) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, r
 	return repository, false, nil
 }
 
+func (s *DummyRepository) GetInternal(ctx context.Context, orgId uuid.UUID, name string) (*model.Repository, error) {
+	if name == *s.RepositoryVal.Metadata.Name {
+		return model.NewRepositoryFromApiResource(&s.RepositoryVal)
+	}
+	return nil, flterrors.ErrResourceNotFound
+}
+
+func (s *DummyRepository) RotateCredentials(ctx context.Context, orgId uuid.UUID, name string, resourceVersion int64, spec v1alpha1.RepositorySpec, status v1alpha1.RepositoryStatus, callback store.RepositoryStoreCallback) (*v1alpha1.Repository, error) {
+	repository := s.RepositoryVal
+	repository.Spec = spec
+	repository.Status = &status
+	return &repository, nil
+}
+
 func verifyRepoPatchFailed(require *require.Assertions, resp server.PatchRepositoryResponseObject) {
 	_, ok := resp.(server.PatchRepository400JSONResponse)
 	require.True(ok)
@@ -203,3 +220,56 @@ func TestRepositoryNonExistingResource(t *testing.T) {
 	require.NoError(err)
 	require.Equal(server.PatchRepository404JSONResponse{}, resp)
 }
+
+func TestRotateRepositoryCredentials(t *testing.T) {
+	require := require.New(t)
+	repoServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, password, _ := r.BasicAuth(); password != "new" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer repoServer.Close()
+
+	spec := v1alpha1.RepositorySpec{}
+	err := spec.FromHttpRepoSpec(v1alpha1.HttpRepoSpec{
+		Url:        repoServer.URL,
+		Type:       v1alpha1.Http,
+		HttpConfig: v1alpha1.HttpConfig{Username: util.StrToPtr("user"), Password: util.StrToPtr("old")},
+	})
+	require.NoError(err)
+	repository := v1alpha1.Repository{
+		ApiVersion: "v1",
+		Kind:       "Repository",
+		Metadata:   v1alpha1.ObjectMeta{Name: util.StrToPtr("foo")},
+		Spec:       spec,
+	}
+	serviceHandler := ServiceHandler{
+		store:           &RepositoryStore{RepositoryVal: repository},
+		callbackManager: dummyCallbackManager(),
+	}
+	rotate := func(credentials v1alpha1.RepositoryCredentials) server.RotateRepositoryCredentialsResponseObject {
+		resp, err := serviceHandler.RotateRepositoryCredentials(context.Background(), server.RotateRepositoryCredentialsRequestObject{Name: "foo", Body: &credentials})
+		require.NoError(err)
+		return resp
+	}
+
+	resp := rotate(v1alpha1.RepositoryCredentials{})
+	require.Equal(server.RotateRepositoryCredentials400JSONResponse{Message: "exactly one of httpConfig and sshConfig must be set"}, resp)
+
+	resp = rotate(v1alpha1.RepositoryCredentials{SshConfig: &v1alpha1.SshConfig{}})
+	require.Equal(server.RotateRepositoryCredentials400JSONResponse{Message: "the repository uses http credentials"}, resp)
+
+	resp = rotate(v1alpha1.RepositoryCredentials{HttpConfig: &v1alpha1.HttpConfig{Username: util.StrToPtr("user"), Password: util.StrToPtr("wrong")}})
+	require.IsType(server.RotateRepositoryCredentials400JSONResponse{}, resp)
+	require.Contains(resp.(server.RotateRepositoryCredentials400JSONResponse).Message, "the repository rejected the new credentials")
+
+	resp = rotate(v1alpha1.RepositoryCredentials{HttpConfig: &v1alpha1.HttpConfig{Username: util.StrToPtr("user"), Password: util.StrToPtr("new")}})
+	require.IsType(server.RotateRepositoryCredentials200JSONResponse{}, resp)
+	rotated := v1alpha1.Repository(resp.(server.RotateRepositoryCredentials200JSONResponse))
+	httpSpec, err := rotated.Spec.GetHttpRepoSpec()
+	require.NoError(err)
+	require.Equal("new", *httpSpec.HttpConfig.Password)
+	require.NotNil(rotated.Status.CredentialsRotatedAt)
+	require.NotNil(rotated.Status.LastAccessedAt)
+	require.True(v1alpha1.IsStatusConditionTrue(rotated.Status.Conditions, v1alpha1.RepositoryAccessible))
+}
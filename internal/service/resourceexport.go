@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/flightctl/flightctl/pkg/version"
+	"github.com/samber/lo"
+)
+
+// (POST /api/v1/exports)
+func (h *ServiceHandler) CreateResourceExport(ctx context.Context, request server.CreateResourceExportRequestObject) (server.CreateResourceExportResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if len(request.Body.Fleets) == 0 {
+		return server.CreateResourceExport400JSONResponse{Message: "at least one fleet must be exported"}, nil
+	}
+	export, err := h.store.ResourceExport().Export(ctx, orgId, request.Body.Fleets)
+	switch err {
+	case nil:
+	case flterrors.ErrResourceNotFound:
+		return server.CreateResourceExport404JSONResponse{Message: "one of the fleets does not exist"}, nil
+	default:
+		return nil, err
+	}
+	export.ServiceVersion = version.Get().String()
+	export.CaBundle = string(crypto.EncodeCertificates(h.ca.ClientCACerts()))
+	return server.CreateResourceExport200JSONResponse(*export), nil
+}
+
+// (POST /api/v1/imports)
+func (h *ServiceHandler) CreateResourceImport(ctx context.Context, request server.CreateResourceImportRequestObject) (server.CreateResourceImportResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if errs := validateResourceExport(request.Body); len(errs) > 0 {
+		return server.CreateResourceImport400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if errs := h.checkResourceExportPolicies(request.Body); len(errs) > 0 {
+		return server.CreateResourceImport400JSONResponse(common.ValidationError(errs)), nil
+	}
+	result, err := h.store.ResourceExport().Import(ctx, orgId, request.Body, h.callbackManager.FleetUpdatedCallback, h.callbackManager.DeviceUpdatedCallback)
+	switch err {
+	case nil:
+		return server.CreateResourceImport201JSONResponse(*result), nil
+	case flterrors.ErrDuplicateName:
+		return server.CreateResourceImport409JSONResponse{Message: "one of the imported resources already exists"}, nil
+	default:
+		return nil, err
+	}
+}
+
+// checkResourceExportPolicies checks the resources of the export against the
+// image and label policies of the service, as if each was created through
+// the API.
+func (h *ServiceHandler) checkResourceExportPolicies(export *api.ResourceExport) []error {
+	errs := []error{}
+	for i := range export.Fleets {
+		fleet := &export.Fleets[i]
+		if msg := h.checkImagePolicy(&fleet.Spec.Template.Spec, true); msg != "" {
+			errs = append(errs, fmt.Errorf("fleet %s: %s", lo.FromPtr(fleet.Metadata.Name), msg))
+		}
+	}
+	for i := range export.Devices {
+		device := &export.Devices[i].Device
+		if msg := h.checkImagePolicy(device.Spec, false); msg != "" {
+			errs = append(errs, fmt.Errorf("device %s: %s", lo.FromPtr(device.Metadata.Name), msg))
+		}
+		if msg := h.checkLabelPolicy(nil, device.Metadata.Labels); msg != "" {
+			errs = append(errs, fmt.Errorf("device %s: %s", lo.FromPtr(device.Metadata.Name), msg))
+		}
+	}
+	return errs
+}
+
+// validateResourceExport checks that the export is complete: the template
+// versions and devices belong to the exported fleets, and the certificates
+// to the exported devices.
+func validateResourceExport(export *api.ResourceExport) []error {
+	if export.Kind != store.ResourceExportKind {
+		return []error{fmt.Errorf("kind must be %s", store.ResourceExportKind)}
+	}
+	errs := []error{}
+	fleets := map[string]bool{}
+	for i := range export.Fleets {
+		fleet := &export.Fleets[i]
+		errs = append(errs, fleet.Validate()...)
+		if fleet.Metadata.Name != nil {
+			fleets[*fleet.Metadata.Name] = true
+		}
+	}
+	for i := range export.TemplateVersions {
+		templateVersion := &export.TemplateVersions[i]
+		if templateVersion.Metadata.Name == nil || !fleets[templateVersion.Spec.Fleet] {
+			errs = append(errs, fmt.Errorf("template version %s does not belong to an exported fleet", lo.FromPtr(templateVersion.Metadata.Name)))
+		}
+	}
+	devices := map[string]bool{}
+	for i := range export.Devices {
+		device := &export.Devices[i].Device
+		errs = append(errs, device.Validate()...)
+		_, owner, _ := util.GetResourceOwner(device.Metadata.Owner)
+		if !fleets[owner] {
+			errs = append(errs, fmt.Errorf("device %s does not belong to an exported fleet", lo.FromPtr(device.Metadata.Name)))
+		}
+		if _, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationMigration]; ok {
+			errs = append(errs, fmt.Errorf("device %s is migrating", lo.FromPtr(device.Metadata.Name)))
+		}
+		if device.Metadata.Name != nil {
+			devices[*device.Metadata.Name] = true
+		}
+	}
+	for _, certificate := range export.Certificates {
+		if !devices[lo.FromPtr(certificate.Device)] {
+			errs = append(errs, fmt.Errorf("certificate %s was not issued to an exported device", certificate.SerialNumber))
+		}
+	}
+	return errs
+}
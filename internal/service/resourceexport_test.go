@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func newResourceExport() *api.ResourceExport {
+	owner := util.SetResourceOwner(model.FleetKind, "kiosks")
+	device := api.Device{
+		Metadata: api.ObjectMeta{Name: lo.ToPtr("kiosk-1"), Owner: owner},
+		Spec:     &api.DeviceSpec{},
+	}
+	return &api.ResourceExport{
+		ApiVersion: store.ResourceExportAPI,
+		Kind:       store.ResourceExportKind,
+		Fleets: []api.Fleet{{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr("kiosks")},
+			Spec:     api.FleetSpec{},
+		}},
+		TemplateVersions: []api.TemplateVersion{{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr("kiosks-1"), Owner: owner},
+			Spec:     api.TemplateVersionSpec{Fleet: "kiosks"},
+		}},
+		Devices: []api.ExportedDevice{{Device: device, RenderedConfig: lo.ToPtr("{}")}},
+		Certificates: []api.IssuedCertificate{{
+			SerialNumber: "1f",
+			Usage:        api.IssuedCertificateUsageManagement,
+			Device:       lo.ToPtr("kiosk-1"),
+		}},
+	}
+}
+
+func TestValidateResourceExport(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(export *api.ResourceExport)
+		expectErr string
+	}{
+		{
+			name:   "complete export",
+			modify: func(export *api.ResourceExport) {},
+		},
+		{
+			name:      "wrong kind",
+			modify:    func(export *api.ResourceExport) { export.Kind = "Device" },
+			expectErr: "kind must be ResourceExport",
+		},
+		{
+			name:      "template version of another fleet",
+			modify:    func(export *api.ResourceExport) { export.TemplateVersions[0].Spec.Fleet = "other" },
+			expectErr: "template version kiosks-1 does not belong to an exported fleet",
+		},
+		{
+			name: "device of another fleet",
+			modify: func(export *api.ResourceExport) {
+				export.Devices[0].Device.Metadata.Owner = util.SetResourceOwner(model.FleetKind, "other")
+			},
+			expectErr: "device kiosk-1 does not belong to an exported fleet",
+		},
+		{
+			name: "migrating device",
+			modify: func(export *api.ResourceExport) {
+				export.Devices[0].Device.Metadata.Annotations = &map[string]string{model.DeviceAnnotationMigration: "{}"}
+			},
+			expectErr: "device kiosk-1 is migrating",
+		},
+		{
+			name:      "certificate of another device",
+			modify:    func(export *api.ResourceExport) { export.Certificates[0].Device = lo.ToPtr("kiosk-2") },
+			expectErr: "certificate 1f was not issued to an exported device",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := newResourceExport()
+			tt.modify(export)
+			errs := validateResourceExport(export)
+			if tt.expectErr == "" {
+				require.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			require.ErrorContains(t, errs[0], tt.expectErr)
+		})
+	}
+}
+
+func TestValidateDeviceMigration(t *testing.T) {
+	require := require.New(t)
+	require.NoError(validateDeviceMigration(&api.DeviceMigration{ManagementEndpoint: "https://target.example.com:7443"}))
+	require.ErrorContains(validateDeviceMigration(&api.DeviceMigration{ManagementEndpoint: "target.example.com:7443"}), "invalid managementEndpoint")
+	require.ErrorContains(validateDeviceMigration(&api.DeviceMigration{
+		ManagementEndpoint:     "https://target.example.com:7443",
+		GrpcManagementEndpoint: lo.ToPtr("http://target.example.com:7444"),
+	}), "invalid grpcManagementEndpoint")
+	require.ErrorContains(validateDeviceMigration(&api.DeviceMigration{
+		ManagementEndpoint: "https://target.example.com:7443",
+		CaBundle:           lo.ToPtr("not a certificate"),
+	}), "invalid caBundle")
+}
+
+func TestCheckResourceExportPolicies(t *testing.T) {
+	require := require.New(t)
+	h := &ServiceHandler{}
+	export := newResourceExport()
+	export.Fleets[0].Spec.Template.Spec.Os = &api.DeviceOSSpec{Image: "quay.io/example/os:latest"}
+	export.Devices[0].Device.Spec.Os = &api.DeviceOSSpec{Image: "quay.io/example/os:latest"}
+	require.Empty(h.checkResourceExportPolicies(export))
+
+	// the resources of an import comply with the policies as if they were
+	// created through the API
+	h.SetRequireImageDigests(true)
+	h.SetLabelSchemas([]config.LabelSchema{{Key: "site", Required: true}})
+	errs := h.checkResourceExportPolicies(export)
+	require.Len(errs, 3)
+	require.ErrorContains(errs[0], "fleet kiosks: the service requires images pinned by digest")
+	require.ErrorContains(errs[1], "device kiosk-1: the service requires images pinned by digest")
+	require.ErrorContains(errs[2], `device kiosk-1: the labels do not comply with the label schemas of the service: label "site" is required`)
+}
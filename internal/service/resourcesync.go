@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"reflect"
 
@@ -24,7 +23,7 @@ func (h *ServiceHandler) CreateResourceSync(ctx context.Context, request server.
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.CreateResourceSync400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.CreateResourceSync400JSONResponse(common.ValidationError(errs)), nil
 	}
 
 	result, err := h.store.ResourceSync().Create(ctx, orgId, request.Body)
@@ -115,7 +114,7 @@ func (h *ServiceHandler) ReplaceResourceSync(ctx context.Context, request server
 	request.Body.Status = nil
 	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
 	if errs := request.Body.Validate(); len(errs) > 0 {
-		return server.ReplaceResourceSync400JSONResponse{Message: errors.Join(errs...).Error()}, nil
+		return server.ReplaceResourceSync400JSONResponse(common.ValidationError(errs)), nil
 	}
 	if request.Name != *request.Body.Metadata.Name {
 		return server.ReplaceResourceSync400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
@@ -131,12 +130,12 @@ func (h *ServiceHandler) ReplaceResourceSync(ctx context.Context, request server
 		}
 	case flterrors.ErrResourceIsNil:
 		return server.ReplaceResourceSync400JSONResponse{Message: err.Error()}, nil
-	case flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.ReplaceResourceSync400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.ReplaceResourceSync404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.ReplaceResourceSync409JSONResponse{}, nil
+		return server.ReplaceResourceSync409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -193,18 +192,17 @@ func (h *ServiceHandler) PatchResourceSync(ctx context.Context, request server.P
 	}
 
 	common.NilOutManagedObjectMetaProperties(&newObj.Metadata)
-	newObj.Metadata.ResourceVersion = nil
 	result, err := h.store.ResourceSync().Update(ctx, orgId, newObj)
 
 	switch err {
 	case nil:
 		return server.PatchResourceSync200JSONResponse(*result), nil
-	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil:
+	case flterrors.ErrResourceIsNil, flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
 		return server.PatchResourceSync400JSONResponse{Message: err.Error()}, nil
 	case flterrors.ErrResourceNotFound:
 		return server.PatchResourceSync404JSONResponse{}, nil
 	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
-		return server.PatchResourceSync409JSONResponse{}, nil
+		return server.PatchResourceSync409JSONResponse(common.ConflictError(err)), nil
 	default:
 		return nil, err
 	}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+// (GET /api/v1/sboms)
+func (h *ServiceHandler) ListImageSboms(ctx context.Context, request server.ListImageSbomsRequestObject) (server.ListImageSbomsResponseObject, error) {
+	orgId := store.NullOrgId
+
+	if request.Params.Version != nil && request.Params.Component == nil {
+		return server.ListImageSboms400JSONResponse{Message: "version can only be specified together with component"}, nil
+	}
+	listParams := store.ImageSbomListParams{
+		Component:     request.Params.Component,
+		Version:       request.Params.Version,
+		Vulnerability: request.Params.Vulnerability,
+	}
+	if request.Params.Device != nil {
+		digests, err := h.deviceImageDigests(ctx, orgId, *request.Params.Device)
+		if err != nil {
+			return nil, err
+		}
+		listParams.Digests = digests
+	}
+
+	result, err := h.store.ImageSbom().List(ctx, orgId, listParams)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.addSbomDevices(ctx, orgId, result.Items); err != nil {
+		return nil, err
+	}
+	return server.ListImageSboms200JSONResponse(*result), nil
+}
+
+// deviceImageDigests returns the digests of the images the device reports it
+// applied, which is empty if the device does not exist.
+func (h *ServiceHandler) deviceImageDigests(ctx context.Context, orgId uuid.UUID, name string) ([]string, error) {
+	device, err := h.store.Device().Get(ctx, orgId, name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	return imageDigests(device), nil
+}
+
+func imageDigests(device *api.Device) []string {
+	digests := []string{}
+	if device.Status == nil || device.Status.Provenance == nil {
+		return digests
+	}
+	for _, image := range device.Status.Provenance.Images {
+		if image.Digest != nil {
+			digests = append(digests, *image.Digest)
+		}
+	}
+	return digests
+}
+
+// addSbomDevices fills in the names of the devices running the images of the
+// SBOMs.
+func (h *ServiceHandler) addSbomDevices(ctx context.Context, orgId uuid.UUID, sboms []api.ImageSbom) error {
+	if len(sboms) == 0 {
+		return nil
+	}
+	devices := map[string][]string{}
+	listParams := store.ListParams{
+		ImageDigests: lo.Map(sboms, func(sbom api.ImageSbom, _ int) string { return sbom.Digest }),
+		Limit:        store.MaxRecordsPerListRequest,
+	}
+	for {
+		result, err := h.store.Device().List(ctx, orgId, listParams)
+		if err != nil {
+			return err
+		}
+		for _, device := range result.Items {
+			for _, digest := range lo.Uniq(imageDigests(&device)) {
+				devices[digest] = append(devices[digest], *device.Metadata.Name)
+			}
+		}
+		if result.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(result.Metadata.Continue)
+		if err != nil {
+			return err
+		}
+		listParams.Continue = cont
+	}
+	for i := range sboms {
+		if names, ok := devices[sboms[i].Digest]; ok {
+			sboms[i].Devices = lo.ToPtr(names)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/sites)
+func (h *ServiceHandler) CreateSite(ctx context.Context, request server.CreateSiteRequestObject) (server.CreateSiteResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateSite400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.Site().Create(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		return server.CreateSite201JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.CreateSite400JSONResponse{Message: err.Error()}, nil
+
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/sites)
+func (h *ServiceHandler) ListSites(ctx context.Context, request server.ListSitesRequestObject) (server.ListSitesResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListSites400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListSites400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListSites400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.Site().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListSites200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/sites)
+func (h *ServiceHandler) DeleteSites(ctx context.Context, request server.DeleteSitesRequestObject) (server.DeleteSitesResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.Site().DeleteAll(ctx, orgId)
+	switch err {
+	case nil:
+		return server.DeleteSites200JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/sites/{name})
+func (h *ServiceHandler) ReadSite(ctx context.Context, request server.ReadSiteRequestObject) (server.ReadSiteResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Site().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadSite200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadSite404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/sites/{name})
+func (h *ServiceHandler) ReplaceSite(ctx context.Context, request server.ReplaceSiteRequestObject) (server.ReplaceSiteResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't overwrite fields that are managed by the service
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.ReplaceSite400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.ReplaceSite400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	result, created, err := h.store.Site().CreateOrUpdate(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		if created {
+			return server.ReplaceSite201JSONResponse(*result), nil
+		} else {
+			return server.ReplaceSite200JSONResponse(*result), nil
+		}
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceSite400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceSite400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReplaceSite404JSONResponse{}, nil
+	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
+		return server.ReplaceSite409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/sites/{name})
+func (h *ServiceHandler) DeleteSite(ctx context.Context, request server.DeleteSiteRequestObject) (server.DeleteSiteResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.Site().Delete(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.DeleteSite200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteSite404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
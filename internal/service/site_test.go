@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type siteStore struct {
+	store.Store
+	sites *createdSites
+}
+
+func (s *siteStore) Site() store.Site {
+	return s.sites
+}
+
+type createdSites struct {
+	store.Site
+	items map[string]api.Site
+}
+
+func (s *createdSites) Create(ctx context.Context, orgId uuid.UUID, site *api.Site) (*api.Site, error) {
+	s.items[*site.Metadata.Name] = *site
+	return site, nil
+}
+
+func TestCreateSite(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	sites := &createdSites{items: map[string]api.Site{}}
+	h := &ServiceHandler{store: &siteStore{sites: sites}, log: logrus.New()}
+
+	site := func(name string, budget *int64) *api.Site {
+		return &api.Site{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr(name)},
+			Spec:     api.SiteSpec{BandwidthBudget: budget},
+		}
+	}
+	tests := []struct {
+		name      string
+		site      *api.Site
+		expectErr bool
+	}{
+		{name: "with a budget", site: site("plant-1", lo.ToPtr(int64(2000000)))},
+		{name: "without a budget", site: site("store-42", nil)},
+		{name: "no bandwidth", site: site("plant-2", lo.ToPtr(int64(0))), expectErr: true},
+		{name: "name too long for a label value", site: site(strings.Repeat("a", 64), nil), expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := h.CreateSite(ctx, server.CreateSiteRequestObject{Body: tt.site})
+			require.NoError(err)
+			if tt.expectErr {
+				require.IsType(server.CreateSite400JSONResponse{}, resp)
+				require.NotContains(sites.items, *tt.site.Metadata.Name)
+			} else {
+				require.IsType(server.CreateSite201JSONResponse{}, resp)
+				require.Contains(sites.items, *tt.site.Metadata.Name)
+			}
+		})
+	}
+}
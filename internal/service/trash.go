@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+)
+
+// SetTrashRetention sets how long deleted devices and fleets are kept in the
+// trash for, which the listed trash reports the purge times with.
+func (h *ServiceHandler) SetTrashRetention(retention time.Duration) {
+	h.trashRetention = retention
+}
+
+// (GET /api/v1/trash)
+func (h *ServiceHandler) ListTrash(ctx context.Context, request server.ListTrashRequestObject) (server.ListTrashResponseObject, error) {
+	orgId := store.NullOrgId
+
+	kind := request.Params.Kind
+	if kind != nil && *kind != model.DeviceKind && *kind != model.FleetKind {
+		return server.ListTrash400JSONResponse{Message: fmt.Sprintf("kind must be %s or %s", model.DeviceKind, model.FleetKind)}, nil
+	}
+	result, err := h.store.Trash().List(ctx, orgId, kind, h.trashRetention)
+	if err != nil {
+		return nil, err
+	}
+	return server.ListTrash200JSONResponse(*result), nil
+}
+
+// (POST /api/v1/devices/{name}/restore)
+func (h *ServiceHandler) RestoreDevice(ctx context.Context, request server.RestoreDeviceRequestObject) (server.RestoreDeviceResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Device().Restore(ctx, orgId, request.Name, h.callbackManager.DeviceUpdatedCallback)
+	switch err {
+	case nil:
+		return server.RestoreDevice200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.RestoreDevice404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (POST /api/v1/fleets/{name}/restore)
+func (h *ServiceHandler) RestoreFleet(ctx context.Context, request server.RestoreFleetRequestObject) (server.RestoreFleetResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Fleet().Restore(ctx, orgId, request.Name, h.callbackManager.FleetUpdatedCallback)
+	switch err {
+	case nil:
+		return server.RestoreFleet200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.RestoreFleet404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// tunnelRoleResource is the resource users are allowed the create and delete
+// verbs on to open and close support tunnels.
+const tunnelRoleResource = "devices/tunnel"
+
+// Events of the audit log of support tunnels.
+const (
+	tunnelRequested = "Requested"
+	tunnelDenied    = "Denied"
+	tunnelClosed    = "Closed"
+	tunnelExpired   = "Expired"
+)
+
+// SetTunnels allows support tunnels to the ports of the configuration. No
+// tunnels are allowed if it is nil.
+func (h *ServiceHandler) SetTunnels(tunnels *config.TunnelConfig) {
+	if tunnels == nil {
+		h.tunnelPorts = nil
+		return
+	}
+	h.tunnelPorts = tunnels.Ports
+	h.tunnelMaxTTL = tunnels.TunnelMaxTTL()
+}
+
+// deviceTunnel returns the support tunnel of the device, or nil if it has none,
+// along with the annotation it is read from.
+func deviceTunnel(device *api.Device) (*api.DeviceTunnel, *string, error) {
+	val, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationTunnel]
+	if !ok {
+		return nil, nil, nil
+	}
+	var tunnel api.DeviceTunnel
+	if err := json.Unmarshal([]byte(val), &tunnel); err != nil {
+		return nil, nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationTunnel, err)
+	}
+	return &tunnel, &val, nil
+}
+
+// auditTunnel writes an event of a support tunnel to the audit log, which are
+// the service logs flagged with the audit field. The name of the user opening
+// the tunnel is the one they give, which the service does not verify.
+func auditTunnel(log logrus.FieldLogger, deviceName string, tunnel *api.DeviceTunnel, event string, message string) {
+	entry := fmt.Sprintf("device %s: tunnel %s to port %d %s", deviceName, tunnel.Id, tunnel.Port, strings.ToLower(event))
+	if message != "" {
+		entry += ": " + message
+	}
+	log.WithFields(logrus.Fields{
+		"audit":       "DeviceTunnel",
+		"device":      deviceName,
+		"tunnelId":    tunnel.Id,
+		"port":        tunnel.Port,
+		"requestedByUnverified": tunnel.RequestedBy,
+		"event":                 event,
+	}).Info(entry)
+}
+
+// (GET /api/v1/devices/{name}/tunnel)
+func (h *ServiceHandler) GetDeviceTunnel(ctx context.Context, request server.GetDeviceTunnelRequestObject) (server.GetDeviceTunnelResponseObject, error) {
+	orgId := store.NullOrgId
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.GetDeviceTunnel404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	tunnel, _, err := deviceTunnel(device)
+	if err != nil {
+		return nil, err
+	}
+	if tunnel == nil {
+		return server.GetDeviceTunnel404JSONResponse{Message: "the device has no tunnel"}, nil
+	}
+	return server.GetDeviceTunnel200JSONResponse(*tunnel), nil
+}
+
+// (POST /api/v1/devices/{name}/tunnel)
+func (h *ServiceHandler) RequestDeviceTunnel(ctx context.Context, request server.RequestDeviceTunnelRequestObject) (server.RequestDeviceTunnelResponseObject, error) {
+	orgId := store.NullOrgId
+
+	requestedBy := strings.TrimSpace(request.Body.RequestedBy)
+	reason := strings.TrimSpace(request.Body.Reason)
+	if requestedBy == "" || reason == "" {
+		return server.RequestDeviceTunnel400JSONResponse{Message: "requestedBy and reason must be set"}, nil
+	}
+	if request.Body.Port < 1 || request.Body.Port > 65535 {
+		return server.RequestDeviceTunnel400JSONResponse{Message: fmt.Sprintf("port %d is not a TCP port", request.Body.Port)}, nil
+	}
+	if _, err := console.ParsePublicKey(request.Body.PublicKey); err != nil {
+		return server.RequestDeviceTunnel400JSONResponse{Message: fmt.Sprintf("invalid public key: %v", err)}, nil
+	}
+	ttl := h.tunnelMaxTTL
+	if request.Body.Ttl != nil {
+		requested, err := time.ParseDuration(*request.Body.Ttl)
+		if err != nil || requested <= 0 {
+			return server.RequestDeviceTunnel400JSONResponse{Message: fmt.Sprintf("invalid ttl %q: must be a positive duration such as 30m", *request.Body.Ttl)}, nil
+		}
+		if requested > h.tunnelMaxTTL {
+			return server.RequestDeviceTunnel400JSONResponse{Message: fmt.Sprintf("ttl %s exceeds the maximum of %s", requested, h.tunnelMaxTTL)}, nil
+		}
+		ttl = requested
+	}
+
+	now := time.Now().UTC()
+	tunnel := &api.DeviceTunnel{
+		Id:          uuid.New().String(),
+		Port:        request.Body.Port,
+		PublicKey:   request.Body.PublicKey,
+		RequestedBy: requestedBy,
+		Reason:      reason,
+		Ttl:         ttl.String(),
+		RequestedAt: now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, tunnelRoleResource, "create")
+	if err != nil {
+		return server.RequestDeviceTunnel400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		auditTunnel(h.log, request.Name, tunnel, tunnelDenied, "the user cannot open tunnels")
+		return server.RequestDeviceTunnel403JSONResponse{Message: "cannot open tunnels to devices"}, nil
+	}
+	if !lo.Contains(h.tunnelPorts, int(tunnel.Port)) {
+		auditTunnel(h.log, request.Name, tunnel, tunnelDenied, "the port is not allowed")
+		return server.RequestDeviceTunnel403JSONResponse{Message: fmt.Sprintf("the service does not allow tunnels to port %d", tunnel.Port)}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.RequestDeviceTunnel404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	if isQuarantined(device) {
+		return server.RequestDeviceTunnel409JSONResponse{Message: "the device is quarantined"}, nil
+	}
+	// a device has a single tunnel at a time, which has to be closed or
+	// expire before another one is opened
+	existing, existingAnnotation, err := deviceTunnel(device)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if now.Before(existing.ExpiresAt) {
+			return server.RequestDeviceTunnel409JSONResponse{Message: fmt.Sprintf("the device has a tunnel %s to port %d opened by %s", existing.Id, existing.Port, existing.RequestedBy)}, nil
+		}
+		auditTunnel(h.log, request.Name, existing, tunnelExpired, "")
+	}
+
+	value, err := json.Marshal(tunnel)
+	if err != nil {
+		return nil, err
+	}
+	// the tunnel only replaces the one checked above, so that of concurrent
+	// requests only one opens a tunnel
+	err = h.store.Device().ReplaceAnnotation(ctx, orgId, request.Name, model.DeviceAnnotationTunnel, existingAnnotation, lo.ToPtr(string(value)))
+	switch {
+	case errors.Is(err, flterrors.ErrResourceVersionConflict):
+		return server.RequestDeviceTunnel409JSONResponse{Message: "another tunnel to the device was opened concurrently"}, nil
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		return server.RequestDeviceTunnel404JSONResponse{}, nil
+	case err != nil:
+		return nil, err
+	}
+	auditTunnel(h.log, request.Name, tunnel, tunnelRequested, fmt.Sprintf("for %s: %s", tunnel.Ttl, tunnel.Reason))
+
+	// the operator verifies the device of the tunnel against the CAs of the
+	// device certificates
+	tunnel.GRPCEndpoint = &h.consoleGrpcEndpoint
+	tunnel.CaBundle = lo.ToPtr(string(crypto.EncodeCertificates(h.ca.ClientCACerts())))
+	return server.RequestDeviceTunnel200JSONResponse(*tunnel), nil
+}
+
+// (DELETE /api/v1/devices/{name}/tunnel)
+func (h *ServiceHandler) CloseDeviceTunnel(ctx context.Context, request server.CloseDeviceTunnelRequestObject) (server.CloseDeviceTunnelResponseObject, error) {
+	orgId := store.NullOrgId
+
+	allowed, err := auth.GetAuthZ().CheckPermission(ctx, tunnelRoleResource, "delete")
+	if err != nil {
+		return server.CloseDeviceTunnel400JSONResponse{Message: fmt.Sprintf("auth failed: %v", err)}, nil
+	}
+	if !allowed {
+		return server.CloseDeviceTunnel403JSONResponse{Message: "cannot close tunnels to devices"}, nil
+	}
+
+	device, err := h.store.Device().Get(ctx, orgId, request.Name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return server.CloseDeviceTunnel404JSONResponse{}, nil
+		}
+		return nil, err
+	}
+	tunnel, annotation, err := deviceTunnel(device)
+	if err != nil {
+		return nil, err
+	}
+	if tunnel == nil {
+		return server.CloseDeviceTunnel404JSONResponse{Message: "the device has no tunnel"}, nil
+	}
+	// removing the tunnel closes it on the device, unless it was replaced by
+	// another tunnel in the meantime
+	err = h.store.Device().ReplaceAnnotation(ctx, orgId, request.Name, model.DeviceAnnotationTunnel, annotation, nil)
+	switch {
+	case errors.Is(err, flterrors.ErrResourceVersionConflict):
+		return server.CloseDeviceTunnel409JSONResponse{Message: "the tunnel of the device changed concurrently"}, nil
+	case errors.Is(err, flterrors.ErrResourceNotFound):
+		return server.CloseDeviceTunnel404JSONResponse{}, nil
+	case err != nil:
+		return nil, err
+	}
+	if time.Now().Before(tunnel.ExpiresAt) {
+		auditTunnel(h.log, request.Name, tunnel, tunnelClosed, "")
+	} else {
+		auditTunnel(h.log, request.Name, tunnel, tunnelExpired, "")
+	}
+
+	return server.CloseDeviceTunnel200JSONResponse(*tunnel), nil
+}
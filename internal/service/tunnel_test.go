@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/auth"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/console"
+	"github.com/flightctl/flightctl/internal/crypto"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// racingTunnelDevice opens a tunnel of another request each time the device
+// is read, as if that request were served concurrently.
+type racingTunnelDevice struct {
+	*annotatedDevice
+	opened int
+}
+
+func (d *racingTunnelDevice) Get(ctx context.Context, orgId uuid.UUID, name string) (*v1alpha1.Device, error) {
+	device, err := d.annotatedDevice.Get(ctx, orgId, name)
+	if err != nil {
+		return nil, err
+	}
+	d.opened++
+	return device, d.UpdateAnnotations(ctx, orgId, name, map[string]string{model.DeviceAnnotationTunnel: fmt.Sprintf(`{"id":"other-%d"}`, d.opened)}, nil)
+}
+
+func TestDeviceTunnel(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	t.Setenv(auth.DisableAuthEnvKey, "true")
+	_, err := auth.CreateAuthMiddleware(&config.Config{}, logrus.New())
+	require.NoError(err)
+	dir := t.TempDir()
+	ca, err := crypto.MakeSelfSignedCA(filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key"), "", "ca", 1)
+	require.NoError(err)
+	device := &annotatedDevice{device: v1alpha1.Device{Metadata: v1alpha1.ObjectMeta{Name: lo.ToPtr("foo")}}}
+	h := &ServiceHandler{store: &annotatedDeviceStore{device: device}, ca: ca, log: logrus.New(), consoleGrpcEndpoint: "grpcs://localhost:7444"}
+	operatorKey, err := console.NewOperatorKey()
+	require.NoError(err)
+	request := func(port int32, ttl *string) server.RequestDeviceTunnelResponseObject {
+		resp, err := h.RequestDeviceTunnel(ctx, server.RequestDeviceTunnelRequestObject{
+			Name: "foo",
+			Body: &v1alpha1.DeviceTunnelRequest{Port: port, PublicKey: operatorKey.PublicKey(), RequestedBy: "alice", Reason: "vendor UI", Ttl: ttl},
+		})
+		require.NoError(err)
+		return resp
+	}
+
+	// tunnels are refused unless the service allows their port
+	require.IsType(server.RequestDeviceTunnel403JSONResponse{}, request(8080, nil))
+	h.SetTunnels(&config.TunnelConfig{Ports: []int{8080}, MaxTTL: "1h"})
+	require.IsType(server.RequestDeviceTunnel403JSONResponse{}, request(22, nil))
+	require.IsType(server.RequestDeviceTunnel400JSONResponse{}, request(8080, lo.ToPtr("2h")))
+	require.NotContains(lo.FromPtr(device.device.Metadata.Annotations), model.DeviceAnnotationTunnel)
+
+	tunnel, ok := request(8080, lo.ToPtr("30m")).(server.RequestDeviceTunnel200JSONResponse)
+	require.True(ok)
+	require.NotEmpty(tunnel.Id)
+	require.Equal("30m0s", tunnel.Ttl)
+	require.WithinDuration(time.Now().Add(30*time.Minute), tunnel.ExpiresAt, time.Minute)
+	require.Equal("grpcs://localhost:7444", lo.FromPtr(tunnel.GRPCEndpoint))
+	require.Equal(string(crypto.EncodeCertificates(ca.Config.Certs)), lo.FromPtr(tunnel.CaBundle))
+	require.Contains(*device.device.Metadata.Annotations, model.DeviceAnnotationTunnel)
+
+	// a second tunnel is refused while the first one is open
+	require.IsType(server.RequestDeviceTunnel409JSONResponse{}, request(8080, nil))
+	got, err := h.GetDeviceTunnel(ctx, server.GetDeviceTunnelRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.Equal(tunnel.Id, got.(server.GetDeviceTunnel200JSONResponse).Id)
+	// the CAs are only given to the operator opening the tunnel
+	require.Nil(got.(server.GetDeviceTunnel200JSONResponse).CaBundle)
+
+	closed, err := h.CloseDeviceTunnel(ctx, server.CloseDeviceTunnelRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.CloseDeviceTunnel200JSONResponse{}, closed)
+	require.NotContains(*device.device.Metadata.Annotations, model.DeviceAnnotationTunnel)
+	closed, err = h.CloseDeviceTunnel(ctx, server.CloseDeviceTunnelRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.CloseDeviceTunnel404JSONResponse{}, closed)
+
+	require.IsType(server.RequestDeviceTunnel200JSONResponse{}, request(8080, nil))
+
+	// a tunnel opened concurrently is neither replaced nor closed
+	h.store = &annotatedDeviceStore{device: device}
+	_, err = h.CloseDeviceTunnel(ctx, server.CloseDeviceTunnelRequestObject{Name: "foo"})
+	require.NoError(err)
+	h.store = &racingDeviceStore{device: &racingTunnelDevice{annotatedDevice: device}}
+	require.IsType(server.RequestDeviceTunnel409JSONResponse{}, request(8080, nil))
+	require.Contains((*device.device.Metadata.Annotations)[model.DeviceAnnotationTunnel], `"other-1"`)
+	closed, err = h.CloseDeviceTunnel(ctx, server.CloseDeviceTunnelRequestObject{Name: "foo"})
+	require.NoError(err)
+	require.IsType(server.CloseDeviceTunnel409JSONResponse{}, closed)
+	require.Contains((*device.device.Metadata.Annotations)[model.DeviceAnnotationTunnel], `"other-2"`)
+}
+
+type racingDeviceStore struct {
+	store.Store
+	device *racingTunnelDevice
+}
+
+func (s *racingDeviceStore) Device() store.Device {
+	return s.device
+}
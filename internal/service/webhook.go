@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/flightctl/flightctl/internal/api/server"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/go-openapi/swag"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// (POST /api/v1/webhooks)
+func (h *ServiceHandler) CreateWebhook(ctx context.Context, request server.CreateWebhookRequestObject) (server.CreateWebhookResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't set fields that are managed by the service
+	request.Body.Status = nil
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.CreateWebhook400JSONResponse(common.ValidationError(errs)), nil
+	}
+
+	result, err := h.store.Webhook().Create(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		return server.CreateWebhook201JSONResponse(*result), nil
+	case flterrors.ErrResourceIsNil:
+		return server.CreateWebhook400JSONResponse{Message: err.Error()}, nil
+
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/webhooks)
+func (h *ServiceHandler) ListWebhooks(ctx context.Context, request server.ListWebhooksRequestObject) (server.ListWebhooksResponseObject, error) {
+	orgId := store.NullOrgId
+	labelSelector := ""
+	if request.Params.LabelSelector != nil {
+		labelSelector = *request.Params.LabelSelector
+	}
+
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return server.ListWebhooks400JSONResponse{Message: err.Error()}, nil
+	}
+
+	cont, err := store.ParseContinueString(request.Params.Continue)
+	if err != nil {
+		return server.ListWebhooks400JSONResponse{Message: fmt.Sprintf("failed to parse continue parameter: %v", err)}, nil
+	}
+
+	listParams := store.ListParams{
+		Labels:   labelMap,
+		Limit:    int(swag.Int32Value(request.Params.Limit)),
+		Continue: cont,
+	}
+	if listParams.Limit == 0 {
+		listParams.Limit = store.MaxRecordsPerListRequest
+	}
+	if listParams.Limit > store.MaxRecordsPerListRequest {
+		return server.ListWebhooks400JSONResponse{Message: fmt.Sprintf("limit cannot exceed %d", store.MaxRecordsPerListRequest)}, nil
+	}
+
+	result, err := h.store.Webhook().List(ctx, orgId, listParams)
+	switch err {
+	case nil:
+		return server.ListWebhooks200JSONResponse(*result), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/webhooks)
+func (h *ServiceHandler) DeleteWebhooks(ctx context.Context, request server.DeleteWebhooksRequestObject) (server.DeleteWebhooksResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.Webhook().DeleteAll(ctx, orgId)
+	switch err {
+	case nil:
+		return server.DeleteWebhooks200JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (GET /api/v1/webhooks/{name})
+func (h *ServiceHandler) ReadWebhook(ctx context.Context, request server.ReadWebhookRequestObject) (server.ReadWebhookResponseObject, error) {
+	orgId := store.NullOrgId
+
+	result, err := h.store.Webhook().Get(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.ReadWebhook200JSONResponse(*result), nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReadWebhook404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
+
+// (PUT /api/v1/webhooks/{name})
+func (h *ServiceHandler) ReplaceWebhook(ctx context.Context, request server.ReplaceWebhookRequestObject) (server.ReplaceWebhookResponseObject, error) {
+	orgId := store.NullOrgId
+
+	// don't overwrite fields that are managed by the service
+	request.Body.Status = nil
+	common.NilOutManagedObjectMetaProperties(&request.Body.Metadata)
+
+	if errs := request.Body.Validate(); len(errs) > 0 {
+		return server.ReplaceWebhook400JSONResponse(common.ValidationError(errs)), nil
+	}
+	if request.Name != *request.Body.Metadata.Name {
+		return server.ReplaceWebhook400JSONResponse{Message: "resource name specified in metadata does not match name in path"}, nil
+	}
+
+	result, created, err := h.store.Webhook().CreateOrUpdate(ctx, orgId, request.Body)
+	switch err {
+	case nil:
+		if created {
+			return server.ReplaceWebhook201JSONResponse(*result), nil
+		} else {
+			return server.ReplaceWebhook200JSONResponse(*result), nil
+		}
+	case flterrors.ErrResourceIsNil:
+		return server.ReplaceWebhook400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNameIsNil, flterrors.ErrIllegalResourceVersionFormat:
+		return server.ReplaceWebhook400JSONResponse{Message: err.Error()}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.ReplaceWebhook404JSONResponse{}, nil
+	case flterrors.ErrNoRowsUpdated, flterrors.ErrResourceVersionConflict:
+		return server.ReplaceWebhook409JSONResponse(common.ConflictError(err)), nil
+	default:
+		return nil, err
+	}
+}
+
+// (DELETE /api/v1/webhooks/{name})
+func (h *ServiceHandler) DeleteWebhook(ctx context.Context, request server.DeleteWebhookRequestObject) (server.DeleteWebhookResponseObject, error) {
+	orgId := store.NullOrgId
+
+	err := h.store.Webhook().Delete(ctx, orgId, request.Name)
+	switch err {
+	case nil:
+		return server.DeleteWebhook200JSONResponse{}, nil
+	case flterrors.ErrResourceNotFound:
+		return server.DeleteWebhook404JSONResponse{}, nil
+	default:
+		return nil, err
+	}
+}
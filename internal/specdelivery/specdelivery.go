@@ -0,0 +1,116 @@
+// Package specdelivery notifies devices of their new rendered specs through
+// an MQTT broker, so that devices on constrained networks apply them without
+// waiting for their next poll of the management API.
+package specdelivery
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/mqtt"
+	"github.com/samber/lo"
+)
+
+const (
+	defaultClientID = "flightctl-worker"
+	// defaultMaxSpecSize is the size of the notifications up to which the
+	// rendered spec is published with them.
+	defaultMaxSpecSize = 16 * 1024
+)
+
+// Publisher publishes the notifications of new rendered specs to the
+// per-device topics of the broker.
+type Publisher struct {
+	client      *mqtt.Client
+	topicPrefix string
+	maxSpecSize int
+}
+
+// New returns the publisher configured for the service, or nil if none is
+// configured.
+func New(cfg *config.Config) (*Publisher, error) {
+	if cfg.SpecDelivery == nil {
+		return nil, nil
+	}
+	opts := mqtt.Options{
+		Broker:   cfg.SpecDelivery.Broker,
+		ClientID: lo.Ternary(cfg.SpecDelivery.ClientId != "", cfg.SpecDelivery.ClientId, defaultClientID),
+		Username: cfg.SpecDelivery.Username,
+	}
+	if cfg.SpecDelivery.PasswordFile != "" {
+		password, err := os.ReadFile(cfg.SpecDelivery.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading spec delivery password: %w", err)
+		}
+		opts.Password = strings.TrimSpace(string(password))
+	}
+	if cfg.SpecDelivery.ServerCACertFile != "" {
+		bundle, err := os.ReadFile(cfg.SpecDelivery.ServerCACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading spec delivery server CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.SpecDelivery.ServerCACertFile)
+		}
+		opts.TLSConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	client, err := mqtt.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublisher(client,
+		lo.Ternary(cfg.SpecDelivery.TopicPrefix != "", cfg.SpecDelivery.TopicPrefix, mqtt.DefaultSpecTopicPrefix),
+		lo.Ternary(cfg.SpecDelivery.MaxSpecSize > 0, cfg.SpecDelivery.MaxSpecSize, defaultMaxSpecSize)), nil
+}
+
+// NewPublisher returns the publisher of the notifications to the topics
+// below the prefix, which carry the rendered spec up to maxSpecSize bytes.
+func NewPublisher(client *mqtt.Client, topicPrefix string, maxSpecSize int) *Publisher {
+	return &Publisher{client: client, topicPrefix: topicPrefix, maxSpecSize: maxSpecSize}
+}
+
+// Notify publishes the notification of the rendered spec of the device as a
+// retained message, so that a device receives it as soon as it reconnects.
+func (p *Publisher) Notify(ctx context.Context, deviceName string, spec *api.RenderedDeviceSpec) error {
+	payload, err := p.notification(spec)
+	if err != nil {
+		return err
+	}
+	if err := p.client.Publish(ctx, mqtt.SpecTopic(p.topicPrefix, deviceName), payload, true); err != nil {
+		return fmt.Errorf("notifying device %s of rendered version %s: %w", deviceName, spec.RenderedVersion, err)
+	}
+	return nil
+}
+
+func (p *Publisher) notification(spec *api.RenderedDeviceSpec) ([]byte, error) {
+	notification := mqtt.SpecNotification{RenderedVersion: spec.RenderedVersion}
+	// quarantines, console sessions, tunnels and actions are only served by
+	// the management API, which knows the device's state and the console
+	// endpoint and records the acknowledgement of actions
+	if spec.Quarantine == nil && spec.Console == nil && spec.Tunnel == nil && spec.Action == nil {
+		withSpec := *spec
+		withSpec.SpecVersion = lo.ToPtr(api.RenderedSpecVersions[len(api.RenderedSpecVersions)-1])
+		notification.Spec = &withSpec
+		payload, err := json.Marshal(notification)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling spec notification: %w", err)
+		}
+		if len(payload) <= p.maxSpecSize {
+			return payload, nil
+		}
+		notification.Spec = nil
+	}
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling spec notification: %w", err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,47 @@
+package specdelivery
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/mqtt"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotification(t *testing.T) {
+	decode := func(payload []byte) mqtt.SpecNotification {
+		var notification mqtt.SpecNotification
+		require.NoError(t, json.Unmarshal(payload, &notification))
+		return notification
+	}
+	publisher := NewPublisher(nil, mqtt.DefaultSpecTopicPrefix, 1024)
+
+	small := &api.RenderedDeviceSpec{RenderedVersion: "5", Os: &api.DeviceOSSpec{Image: "quay.io/example/os:v2"}}
+	payload, err := publisher.notification(small)
+	require.NoError(t, err)
+	notification := decode(payload)
+	require.Equal(t, "5", notification.RenderedVersion)
+	require.NotNil(t, notification.Spec)
+	require.Equal(t, "quay.io/example/os:v2", notification.Spec.Os.Image)
+	require.Equal(t, api.RenderedSpecVersions[len(api.RenderedSpecVersions)-1], lo.FromPtr(notification.Spec.SpecVersion))
+
+	large := &api.RenderedDeviceSpec{RenderedVersion: "6", Config: lo.ToPtr(strings.Repeat("x", 2048))}
+	payload, err = publisher.notification(large)
+	require.NoError(t, err)
+	notification = decode(payload)
+	require.Equal(t, "6", notification.RenderedVersion)
+	require.Nil(t, notification.Spec)
+
+	quarantined := &api.RenderedDeviceSpec{RenderedVersion: "6", Quarantine: &api.DeviceQuarantine{}}
+	payload, err = publisher.notification(quarantined)
+	require.NoError(t, err)
+	require.Nil(t, decode(payload).Spec)
+
+	withAction := &api.RenderedDeviceSpec{RenderedVersion: "6", Action: &api.DeviceAction{Id: "1", Action: api.DeviceActionReboot}}
+	payload, err = publisher.notification(withAction)
+	require.NoError(t, err)
+	require.Nil(t, decode(payload).Spec)
+}
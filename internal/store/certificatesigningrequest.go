@@ -209,14 +209,10 @@ func (s *CertificateSigningRequestStore) UpdateStatus(ctx context.Context, orgId
 	if resource.Metadata.Name == nil {
 		return nil, flterrors.ErrResourceNameIsNil
 	}
-	certificateSigningRequest := model.CertificateSigningRequest{
-		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
+	if err := updateResourceStatus[model.CertificateSigningRequest](s.db, orgId, *resource.Metadata.Name, resource.Metadata.ResourceVersion, model.MakeJSONField(resource.Status)); err != nil {
+		return nil, err
 	}
-	result := s.db.Model(&certificateSigningRequest).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
-	})
-	return resource, flterrors.ErrorFromGormError(result.Error)
+	return resource, nil
 }
 
 func (s *CertificateSigningRequestStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
@@ -3,12 +3,14 @@ package store
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -39,6 +41,36 @@ func BuildBaseListQuery(query *gorm.DB, orgId uuid.UUID, listParams ListParams)
 	if listParams.FleetName != nil {
 		query = query.Where("fleet_name = ?", *listParams.FleetName)
 	}
+
+	if listParams.BoundingBox != nil {
+		queryStr, args := createBoundingBoxQuery(*listParams.BoundingBox)
+		query = query.Where(queryStr, args...)
+	}
+
+	for _, requirement := range listParams.Annotations {
+		queryStr, args := createAnnotationQuery(requirement)
+		query = query.Where(queryStr, args...)
+	}
+
+	if listParams.ImageDigests != nil {
+		queryStr, args := createImageDigestQuery(listParams.ImageDigests)
+		query = query.Where(queryStr, args...)
+	}
+
+	if listParams.Alias != nil {
+		queryStr, args := createAliasQuery(*listParams.Alias)
+		query = query.Where(queryStr, args...)
+	}
+
+	if listParams.ApplicationRestarts != nil {
+		queryStr, args := createApplicationRestartsQuery(*listParams.ApplicationRestarts)
+		query = query.Where(queryStr, args...)
+	}
+
+	if listParams.HardwareIdentity != nil {
+		queryStr, args := createHardwareIdentityQuery(*listParams.HardwareIdentity)
+		query = query.Where(queryStr, args...)
+	}
 	return query
 }
 
@@ -60,6 +92,49 @@ func CountRemainingItems(query *gorm.DB, lastItemName string) int64 {
 	return count
 }
 
+// whereResourceVersion restricts an update to the resource version the client
+// read, if it set one, so that concurrent changes are not overwritten.
+func whereResourceVersion(query *gorm.DB, resourceVersion *string) (*gorm.DB, error) {
+	if resourceVersion == nil {
+		return query, nil
+	}
+	version, err := strconv.ParseInt(*resourceVersion, 10, 64)
+	if err != nil {
+		return nil, flterrors.ErrIllegalResourceVersionFormat
+	}
+	return query.Where("resource_version = ?", version), nil
+}
+
+// updateResourceStatus replaces the status of the resource and increments its
+// resource version, at the resource version the client read if it set one.
+// It returns ErrResourceNotFound if the resource does not exist and
+// ErrResourceVersionConflict if it was changed since it was read.
+func updateResourceStatus[M any](db *gorm.DB, orgId uuid.UUID, name string, resourceVersion *string, status any) error {
+	var record M
+	query, err := whereResourceVersion(db.Model(&record).Where("org_id = ? AND name = ?", orgId, name), resourceVersion)
+	if err != nil {
+		return err
+	}
+	result := query.Updates(map[string]interface{}{
+		"status":           status,
+		"resource_version": gorm.Expr("resource_version + 1"),
+	})
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	existingRecord, err := getExistingRecord[M](db, name, orgId)
+	if err != nil {
+		return err
+	}
+	if existingRecord == nil {
+		return flterrors.ErrResourceNotFound
+	}
+	return flterrors.ErrResourceVersionConflict
+}
+
 func GetNonNilFieldsFromResource(resource model.Resource) []string {
 	ret := []string{}
 	if resource.Generation != nil {
@@ -152,6 +227,77 @@ func createQueryFromFilterMap(fieldMap map[string][]string) (string, []interface
 	return query, args
 }
 
+// createBoundingBoxQuery selects the devices whose reported location lies within the box.
+// Devices which do not report a location have no coordinates and are never selected.
+func createBoundingBoxQuery(box BoundingBox) (string, []interface{}) {
+	latitude := "(status -> 'location' ->> 'latitude')::float8"
+	longitude := "(status -> 'location' ->> 'longitude')::float8"
+	query := fmt.Sprintf("%s BETWEEN ? AND ?", latitude)
+	args := []interface{}{box.South, box.North}
+	if box.West <= box.East {
+		query += fmt.Sprintf(" AND %s BETWEEN ? AND ?", longitude)
+	} else {
+		// the box crosses the antimeridian
+		query += fmt.Sprintf(" AND (%s >= ? OR %s <= ?)", longitude, longitude)
+	}
+	args = append(args, box.West, box.East)
+	return query, args
+}
+
+// createAnnotationQuery selects the devices whose agent wrote the annotation
+// of the requirement. jsonb_exists stands for the ? operator of jsonb, which
+// GORM would take for a placeholder.
+func createAnnotationQuery(requirement AnnotationRequirement) (string, []interface{}) {
+	if requirement.Value == nil {
+		return "jsonb_exists(status -> 'annotations', ?)", []interface{}{requirement.Key}
+	}
+	return "status -> 'annotations' ->> ? = ?", []interface{}{requirement.Key, *requirement.Value}
+}
+
+// createImageDigestQuery selects the devices which reported an image with one
+// of the digests in their status.provenance.
+func createImageDigestQuery(digests []string) (string, []interface{}) {
+	if len(digests) == 0 {
+		return "FALSE", nil
+	}
+	return "EXISTS (SELECT 1 FROM jsonb_array_elements(status -> 'provenance' -> 'images') AS i WHERE i ->> 'digest' IN ?)", []interface{}{digests}
+}
+
+// createAliasQuery selects the devices known by the alias, whether it is their
+// name, their display name or one of their aliases. The containment operator
+// lets the GIN index of the aliases serve the query.
+func createAliasQuery(alias string) (string, []interface{}) {
+	return "(name = ? OR display_name = ? OR aliases @> ?)", []interface{}{alias, alias, pq.StringArray{alias}}
+}
+
+// createApplicationRestartsQuery selects the devices which reported an
+// application with at least the number of restarts in their
+// status.applications.
+func createApplicationRestartsQuery(restarts int) (string, []interface{}) {
+	return "EXISTS (SELECT 1 FROM jsonb_each(status -> 'applications' -> 'data') AS a WHERE (a.value ->> 'restarts')::int >= ?)", []interface{}{restarts}
+}
+
+// createHardwareIdentityQuery selects the devices which reported the serial
+// number or one of the MAC addresses of the identity in their
+// status.systemInfo.hardware. The containment operator does not fail on
+// devices which reported no network interfaces.
+func createHardwareIdentityQuery(identity HardwareIdentity) (string, []interface{}) {
+	queries := []string{}
+	args := []interface{}{}
+	if identity.SerialNumber != "" {
+		queries = append(queries, "status -> 'systemInfo' -> 'hardware' ->> 'serialNumber' = ?")
+		args = append(args, identity.SerialNumber)
+	}
+	for _, macAddress := range identity.MacAddresses {
+		queries = append(queries, "status -> 'systemInfo' -> 'hardware' -> 'networkInterfaces' @> ?::jsonb")
+		args = append(args, fmt.Sprintf(`[{"macAddress":%q}]`, macAddress))
+	}
+	if len(queries) == 0 {
+		return "FALSE", nil
+	}
+	return "(" + strings.Join(queries, " OR ") + ")", args
+}
+
 func createParamsFromKey(key string) string {
 	parts := strings.Split(key, ".")
 	params := "status"
@@ -204,6 +350,14 @@ func getExistingRecord[R any](db *gorm.DB, name string, orgId uuid.UUID) (*R, er
 	return &existingRecord, nil
 }
 
+// purgeDeletedRecord permanently deletes the record of the name from the
+// trash, so that a new resource of the same name replaces it.
+func purgeDeletedRecord[R any](db *gorm.DB, orgId uuid.UUID, name string) error {
+	var record R
+	result := db.Unscoped().Where("org_id = ? and name = ? and deleted_at is not null", orgId, name).Delete(&record)
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
 func retryCreateOrUpdate[A any](fn func() (*A, bool, bool, error)) (*A, bool, error) {
 	var (
 		a              *A
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/lib/pq"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 )
 
@@ -125,3 +127,81 @@ func TestCreateOrQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBoundingBoxQuery(t *testing.T) {
+	require := require.New(t)
+	tests := []struct {
+		name          string
+		box           BoundingBox
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "box",
+			box:           BoundingBox{West: 2.2, South: 48.8, East: 2.5, North: 48.9},
+			expectedQuery: "(status -> 'location' ->> 'latitude')::float8 BETWEEN ? AND ? AND (status -> 'location' ->> 'longitude')::float8 BETWEEN ? AND ?",
+			expectedArgs:  []interface{}{48.8, 48.9, 2.2, 2.5},
+		},
+		{
+			name:          "box crossing the antimeridian",
+			box:           BoundingBox{West: 170, South: -50, East: -170, North: -30},
+			expectedQuery: "(status -> 'location' ->> 'latitude')::float8 BETWEEN ? AND ? AND ((status -> 'location' ->> 'longitude')::float8 >= ? OR (status -> 'location' ->> 'longitude')::float8 <= ?)",
+			expectedArgs:  []interface{}{-50.0, -30.0, 170.0, -170.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, args := createBoundingBoxQuery(test.box)
+			require.Equal(test.expectedQuery, query)
+			require.Equal(test.expectedArgs, args)
+		})
+	}
+}
+
+func TestCreateAnnotationQuery(t *testing.T) {
+	require := require.New(t)
+	query, args := createAnnotationQuery(AnnotationRequirement{Key: "hardware.flightctl.io/changed"})
+	require.Equal("jsonb_exists(status -> 'annotations', ?)", query)
+	require.Equal([]interface{}{"hardware.flightctl.io/changed"}, args)
+
+	query, args = createAnnotationQuery(AnnotationRequirement{Key: "disk", Value: lo.ToPtr("replace")})
+	require.Equal("status -> 'annotations' ->> ? = ?", query)
+	require.Equal([]interface{}{"disk", "replace"}, args)
+}
+
+func TestCreateImageDigestQuery(t *testing.T) {
+	require := require.New(t)
+	query, args := createImageDigestQuery([]string{"sha256:1a2b", "sha256:3c4d"})
+	require.Equal("EXISTS (SELECT 1 FROM jsonb_array_elements(status -> 'provenance' -> 'images') AS i WHERE i ->> 'digest' IN ?)", query)
+	require.Equal([]interface{}{[]string{"sha256:1a2b", "sha256:3c4d"}}, args)
+
+	query, args = createImageDigestQuery([]string{})
+	require.Equal("FALSE", query)
+	require.Nil(args)
+}
+
+func TestCreateAliasQuery(t *testing.T) {
+	require := require.New(t)
+	query, args := createAliasQuery("kiosk-lobby")
+	require.Equal("(name = ? OR display_name = ? OR aliases @> ?)", query)
+	require.Equal([]interface{}{"kiosk-lobby", "kiosk-lobby", pq.StringArray{"kiosk-lobby"}}, args)
+}
+
+func TestCreateApplicationRestartsQuery(t *testing.T) {
+	require := require.New(t)
+	query, args := createApplicationRestartsQuery(3)
+	require.Equal("EXISTS (SELECT 1 FROM jsonb_each(status -> 'applications' -> 'data') AS a WHERE (a.value ->> 'restarts')::int >= ?)", query)
+	require.Equal([]interface{}{3}, args)
+}
+
+func TestCreateHardwareIdentityQuery(t *testing.T) {
+	require := require.New(t)
+	query, args := createHardwareIdentityQuery(HardwareIdentity{SerialNumber: "SN-42", MacAddresses: []string{"52:54:00:12:34:56"}})
+	require.Equal("(status -> 'systemInfo' -> 'hardware' ->> 'serialNumber' = ? OR status -> 'systemInfo' -> 'hardware' -> 'networkInterfaces' @> ?::jsonb)", query)
+	require.Equal([]interface{}{"SN-42", `[{"macAddress":"52:54:00:12:34:56"}]`}, args)
+
+	query, args = createHardwareIdentityQuery(HardwareIdentity{})
+	require.Equal("FALSE", query)
+	require.Nil(args)
+}
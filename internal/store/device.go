@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -20,6 +21,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Device interface {
@@ -28,12 +30,16 @@ type Device interface {
 	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceList, error)
 	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Device, error)
 	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, device *api.Device, fieldsToUnset []string, fromAPI bool, callback DeviceStoreCallback) (*api.Device, bool, error)
-	UpdateStatus(ctx context.Context, orgId uuid.UUID, device *api.Device) (*api.Device, error)
+	UpdateStatus(ctx context.Context, orgId uuid.UUID, device *api.Device, callback DeviceStoreCallback) (*api.Device, error)
+	UpdateStatuses(ctx context.Context, orgId uuid.UUID, devices []*api.Device, callback DeviceStoreCallback) ([]error, error)
 	UpdateSummaryStatusBatch(ctx context.Context, orgId uuid.UUID, deviceNames []string, status api.DeviceSummaryStatusType, statusInfo string) error
 	DeleteAll(ctx context.Context, orgId uuid.UUID, callback DeviceStoreAllDeletedCallback) error
 	Delete(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) error
+	Restore(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) (*api.Device, error)
 	UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error
-	UpdateRendered(ctx context.Context, orgId uuid.UUID, name string, rendered string) error
+	ReplaceAnnotation(ctx context.Context, orgId uuid.UUID, name string, key string, expected *string, value *string) error
+	UpdateAliases(ctx context.Context, orgId uuid.UUID, name string, displayName *string, aliases []string) (*api.Device, error)
+	UpdateRendered(ctx context.Context, orgId uuid.UUID, name string, rendered string, imageDigests []api.ImageDigest) error
 	GetRendered(ctx context.Context, orgId uuid.UUID, name string, knownRenderedVersion *string, consoleGrpcEndpoint string) (*api.RenderedDeviceSpec, error)
 	SetServiceConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error
 	OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string, repositoryNames ...string) error
@@ -44,8 +50,10 @@ type Device interface {
 
 type IntegrationTestCallback func()
 type DeviceStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db            *gorm.DB
+	reads         *ReadReplicas
+	renderedSpecs *renderedSpecCache
+	log           logrus.FieldLogger
 
 	IntegrationTestCreateOrUpdateCallback IntegrationTestCallback
 }
@@ -56,8 +64,10 @@ type DeviceStoreAllDeletedCallback func(orgId uuid.UUID)
 // Make sure we conform to Device interface
 var _ Device = (*DeviceStore)(nil)
 
-func NewDevice(db *gorm.DB, log logrus.FieldLogger) Device {
-	return &DeviceStore{db: db, log: log, IntegrationTestCreateOrUpdateCallback: func() {}}
+// NewDevice returns the store of the devices, which caches the rendered specs
+// of up to renderedSpecCacheSize devices.
+func NewDevice(db *gorm.DB, reads *ReadReplicas, renderedSpecCacheSize int, log logrus.FieldLogger) Device {
+	return &DeviceStore{db: db, reads: reads, renderedSpecs: newRenderedSpecCache(renderedSpecCacheSize), log: log, IntegrationTestCreateOrUpdateCallback: func() {}}
 }
 
 func (s *DeviceStore) SetIntegrationTestCreateOrUpdateCallback(c IntegrationTestCallback) {
@@ -97,6 +107,19 @@ func (s *DeviceStore) InitialMigration() error {
 		}
 	}
 
+	// Create GIN index for device aliases
+	if !s.db.Migrator().HasIndex(&model.Device{}, "idx_device_aliases") {
+		if s.db.Dialector.Name() == "postgres" {
+			if err := s.db.Exec("CREATE INDEX idx_device_aliases ON devices USING GIN (aliases)").Error; err != nil {
+				return err
+			}
+		} else {
+			if err := s.db.Migrator().CreateIndex(&model.Device{}, "Aliases"); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Create GIN index for device status
 	if !s.db.Migrator().HasIndex(&model.Device{}, "idx_device_status") {
 		if s.db.Dialector.Name() == "postgres" {
@@ -130,7 +153,8 @@ func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams List
 	var nextContinue *string
 	var numRemaining *int64
 
-	query := BuildBaseListQuery(s.db.Model(&devices), orgId, listParams)
+	db := s.reads.DB(ctx)
+	query := BuildBaseListQuery(db.Model(&devices), orgId, listParams)
 	if listParams.Limit > 0 {
 		// Request 1 more than the user asked for to see if we need to return "continue"
 		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
@@ -152,7 +176,7 @@ func (s *DeviceStore) List(ctx context.Context, orgId uuid.UUID, listParams List
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery := BuildBaseListQuery(s.db.Model(&devices), orgId, listParams)
+			countQuery := BuildBaseListQuery(db.Model(&devices), orgId, listParams)
 			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
 		}
 		nextContinueStruct.Count = numRemainingVal
@@ -193,6 +217,9 @@ func (s *DeviceStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*a
 func (s *DeviceStore) createDevice(device *model.Device) (bool, error) {
 	device.Generation = lo.ToPtr[int64](1)
 	device.ResourceVersion = lo.ToPtr[int64](1)
+	if err := purgeDeletedRecord[model.Device](s.db, device.OrgID, device.Name); err != nil {
+		return false, err
+	}
 	if result := s.db.Create(device); result.Error != nil {
 		err := flterrors.ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
@@ -315,23 +342,173 @@ func (s *DeviceStore) UpdateSummaryStatusBatch(ctx context.Context, orgId uuid.U
 	return s.db.WithContext(ctx).Exec(query, args...).Error
 }
 
-func (s *DeviceStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Device) (*api.Device, error) {
+// UpdateStatus replaces the status of the device, at the resource version the
+// client read if it set one. The previous status the callback detects
+// transitions from is returned by the statement replacing it, which locks the
+// device, so that no status written in between is missed and no separate read
+// is needed.
+func (s *DeviceStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Device, callback DeviceStoreCallback) (*api.Device, error) {
 	if resource == nil {
 		return nil, flterrors.ErrResourceIsNil
 	}
 	if resource.Metadata.Name == nil {
 		return nil, flterrors.ErrResourceNameIsNil
 	}
-	device := model.Device{
-		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
+	name := *resource.Metadata.Name
+
+	status, err := json.Marshal(resource.Status)
+	if err != nil {
+		return nil, err
 	}
-	result := s.db.Model(&device).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
+	versionCondition := ""
+	args := []interface{}{string(status), time.Now(), orgId, name}
+	if resource.Metadata.ResourceVersion != nil {
+		version, err := strconv.ParseInt(*resource.Metadata.ResourceVersion, 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		versionCondition = " AND d.resource_version = ?"
+		args = append(args, version)
+	}
+
+	var updated []struct {
+		PreviousStatus  *model.JSONField[api.DeviceStatus]
+		Spec            *model.JSONField[api.DeviceSpec]
+		ResourceVersion *int64
+	}
+	query := `UPDATE devices AS d SET status = ?::jsonb, resource_version = d.resource_version + 1, updated_at = ?
+		FROM (SELECT org_id, name, status FROM devices WHERE org_id = ? AND name = ? AND deleted_at IS NULL FOR UPDATE) AS existing
+		WHERE d.org_id = existing.org_id AND d.name = existing.name` + versionCondition + `
+		RETURNING existing.status AS previous_status, d.spec, d.resource_version`
+	if err := s.db.Raw(query, args...).Scan(&updated).Error; err != nil {
+		return nil, flterrors.ErrorFromGormError(err)
+	}
+	if len(updated) == 0 {
+		existingRecord, err := getExistingRecord[model.Device](s.db, name, orgId)
+		if err != nil {
+			return nil, err
+		}
+		if existingRecord == nil {
+			return nil, flterrors.ErrResourceNotFound
+		}
+		return nil, flterrors.ErrResourceVersionConflict
+	}
+
+	if callback != nil {
+		existingRecord := model.Device{
+			Resource: model.Resource{OrgID: orgId, Name: name, ResourceVersion: lo.ToPtr(lo.FromPtr(updated[0].ResourceVersion) - 1)},
+			Spec:     updated[0].Spec,
+			Status:   updated[0].PreviousStatus,
+		}
+		updatedRecord := existingRecord
+		updatedRecord.ResourceVersion = updated[0].ResourceVersion
+		updatedRecord.Status = model.MakeJSONField(lo.FromPtr(resource.Status))
+		callback(&existingRecord, &updatedRecord)
+	}
+	return resource, nil
+}
+
+// UpdateStatuses replaces the statuses of the devices, whose names must be
+// distinct, in a single transaction: their records are locked and all their
+// statuses are written with one statement. It returns the error of each
+// device, in the order of the devices and nil for those whose status was
+// replaced, such as ErrResourceNotFound for a device which does not exist and
+// ErrResourceVersionConflict for one whose resource version is not the given
+// one. A status without annotations keeps those of the current status. The
+// callback is called for the replaced statuses once they are committed.
+func (s *DeviceStore) UpdateStatuses(ctx context.Context, orgId uuid.UUID, resources []*api.Device, callback DeviceStoreCallback) ([]error, error) {
+	errs := make([]error, len(resources))
+	names := []string{}
+	for i, resource := range resources {
+		switch {
+		case resource == nil:
+			errs[i] = flterrors.ErrResourceIsNil
+		case resource.Metadata.Name == nil:
+			errs[i] = flterrors.ErrResourceNameIsNil
+		default:
+			names = append(names, *resource.Metadata.Name)
+		}
+	}
+	if len(names) == 0 {
+		return errs, nil
+	}
+
+	existingRecords := map[string]*model.Device{}
+	updated := []int{}
+	err := s.db.Transaction(func(innerTx *gorm.DB) error {
+		var records []model.Device
+		result := innerTx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("org_id = ? AND name IN ?", orgId, names).Find(&records)
+		if result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+		for i := range records {
+			existingRecords[records[i].Name] = &records[i]
+		}
+
+		values := []string{}
+		args := []interface{}{}
+		for i, resource := range resources {
+			if errs[i] != nil {
+				continue
+			}
+			existingRecord, ok := existingRecords[*resource.Metadata.Name]
+			if !ok {
+				errs[i] = flterrors.ErrResourceNotFound
+				continue
+			}
+			if resource.Metadata.ResourceVersion != nil {
+				version, err := strconv.ParseInt(*resource.Metadata.ResourceVersion, 10, 64)
+				if err != nil {
+					errs[i] = flterrors.ErrIllegalResourceVersionFormat
+					continue
+				}
+				if version != lo.FromPtr(existingRecord.ResourceVersion) {
+					errs[i] = flterrors.ErrResourceVersionConflict
+					continue
+				}
+			}
+			// agents which predate the annotations of the device do not
+			// report them, which leaves those written by a previous agent in place
+			if resource.Status != nil && resource.Status.Annotations == nil && existingRecord.Status != nil {
+				resource.Status.Annotations = existingRecord.Status.Data.Annotations
+			}
+			status, err := json.Marshal(resource.Status)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			values = append(values, "(?, ?::jsonb)")
+			args = append(args, *resource.Metadata.Name, string(status))
+			updated = append(updated, i)
+		}
+		if len(values) == 0 {
+			return nil
+		}
+
+		query := fmt.Sprintf(`UPDATE devices AS d SET status = v.status, resource_version = d.resource_version + 1, updated_at = ?
+			FROM (VALUES %s) AS v(name, status) WHERE d.org_id = ? AND d.name = v.name`, strings.Join(values, ", "))
+		args = append([]interface{}{time.Now()}, append(args, orgId)...)
+		return flterrors.ErrorFromGormError(innerTx.Exec(query, args...).Error)
 	})
-	return resource, flterrors.ErrorFromGormError(result.Error)
+	if err != nil {
+		return nil, err
+	}
+
+	if callback != nil {
+		for _, i := range updated {
+			existingRecord := existingRecords[*resources[i].Metadata.Name]
+			updatedRecord := *existingRecord
+			updatedRecord.Status = model.MakeJSONField(lo.FromPtr(resources[i].Status))
+			updatedRecord.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+			callback(existingRecord, &updatedRecord)
+		}
+	}
+	return errs, nil
 }
 
+// Delete moves the device to the trash, keeping its rendered spec,
+// certificates and attestation until the trash is purged. Its enrollment
+// request is deleted, so that the device can enroll again.
 func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) error {
 	var existingRecord model.Device
 	log := log.WithReqIDFromCtx(ctx, s.log)
@@ -344,7 +521,7 @@ func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string,
 
 		associatedRecord := model.EnrollmentRequest{Resource: model.Resource{OrgID: orgId, Name: name}}
 
-		if err := innerTx.Unscoped().Delete(&existingRecord).Error; err != nil {
+		if err := innerTx.Delete(&existingRecord).Error; err != nil {
 			return flterrors.ErrorFromGormError(err)
 		}
 
@@ -362,28 +539,50 @@ func (s *DeviceStore) Delete(ctx context.Context, orgId uuid.UUID, name string,
 		return err
 	}
 
+	s.renderedSpecs.remove(renderedSpecKey{orgId: orgId, name: name})
 	callback(&existingRecord, nil)
 	return nil
 }
 
-func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
+// Restore moves the device back from the trash.
+func (s *DeviceStore) Restore(ctx context.Context, orgId uuid.UUID, name string, callback DeviceStoreCallback) (*api.Device, error) {
+	restored := []model.Device{}
+	if err := s.db.Raw(`update devices set deleted_at = null, resource_version = resource_version + 1 where org_id = ? and name = ? and deleted_at is not null returning *`, orgId, name).Scan(&restored).Error; err != nil {
+		return nil, flterrors.ErrorFromGormError(err)
+	}
+	if len(restored) == 0 {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	callback(nil, &restored[0])
+	device := restored[0].ToApiResource()
+	return &device, nil
+}
+
+func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string, precondition func(map[string]string) error) (bool, error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 	result := s.db.First(&existingRecord)
 	if result.Error != nil {
 		return false, flterrors.ErrorFromGormError(result.Error)
 	}
 	existingAnnotations := util.LabelArrayToMap(existingRecord.Annotations)
+	if precondition != nil {
+		if err := precondition(existingAnnotations); err != nil {
+			return false, err
+		}
+	}
 
 	existingConsoleAnnotation := util.DefaultIfNotInMap(existingAnnotations, model.DeviceAnnotationConsole, "")
+	existingTunnelAnnotation := util.DefaultIfNotInMap(existingAnnotations, model.DeviceAnnotationTunnel, "")
 	existingAnnotations = util.MergeLabels(existingAnnotations, annotations)
 
 	for _, deleteKey := range deleteKeys {
 		delete(existingAnnotations, deleteKey)
 	}
 	newConsoleAnnotation := util.DefaultIfNotInMap(existingAnnotations, model.DeviceAnnotationConsole, "")
+	newTunnelAnnotation := util.DefaultIfNotInMap(existingAnnotations, model.DeviceAnnotationTunnel, "")
 
-	// Changing the console annotation requires bumping the renderedVersion annotation
-	if existingConsoleAnnotation != newConsoleAnnotation {
+	// Changing the console or tunnel annotation requires bumping the renderedVersion annotation
+	if existingConsoleAnnotation != newConsoleAnnotation || existingTunnelAnnotation != newTunnelAnnotation {
 		nextRenderedVersion, err := getNextRenderedVersion(existingAnnotations)
 		if err != nil {
 			return false, err
@@ -411,11 +610,81 @@ func (s *DeviceStore) updateAnnotations(orgId uuid.UUID, name string, annotation
 
 func (s *DeviceStore) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateAnnotations(orgId, name, annotations, deleteKeys)
+		return s.updateAnnotations(orgId, name, annotations, deleteKeys, nil)
+	})
+}
+
+// ReplaceAnnotation sets the annotation of the key to the value, or deletes it
+// if the value is nil, provided that its current value is the expected one,
+// or that it is not set if the expected value is nil. Otherwise it fails with
+// ErrResourceVersionConflict, so that concurrent writers of the annotation do
+// not overwrite each other.
+func (s *DeviceStore) ReplaceAnnotation(ctx context.Context, orgId uuid.UUID, name string, key string, expected *string, value *string) error {
+	annotations, deleteKeys := map[string]string{}, []string{}
+	if value != nil {
+		annotations[key] = *value
+	} else {
+		deleteKeys = append(deleteKeys, key)
+	}
+	precondition := func(existing map[string]string) error {
+		current, ok := existing[key]
+		if ok != (expected != nil) || (ok && current != *expected) {
+			return flterrors.ErrResourceVersionConflict
+		}
+		return nil
+	}
+	return retryUpdate(func() (bool, error) {
+		return s.updateAnnotations(orgId, name, annotations, deleteKeys, precondition)
+	})
+}
+
+// UpdateAliases replaces the display name and the aliases of the device. An
+// alias must not be the name or an alias of another device of the organization.
+func (s *DeviceStore) UpdateAliases(ctx context.Context, orgId uuid.UUID, name string, displayName *string, aliases []string) (*api.Device, error) {
+	var updated model.Device
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
+		if result := tx.First(&existingRecord); result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+
+		if len(aliases) > 0 {
+			var others model.DeviceList
+			result := tx.Model(&model.Device{}).Select("name", "aliases").
+				Where("org_id = ? AND name <> ?", orgId, name).
+				Where("name IN ? OR aliases && ?", aliases, pq.StringArray(aliases)).
+				Limit(1).Find(&others)
+			if result.Error != nil {
+				return flterrors.ErrorFromGormError(result.Error)
+			}
+			if len(others) > 0 {
+				alias, _ := lo.Find(aliases, func(a string) bool { return a == others[0].Name || lo.Contains(others[0].Aliases, a) })
+				return fmt.Errorf("%w: %s is already used by device %s", flterrors.ErrAliasInUse, alias, others[0].Name)
+			}
+		}
+
+		result := tx.Model(&existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"display_name":     displayName,
+			"aliases":          pq.StringArray(aliases),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+		if result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return flterrors.ErrNoRowsUpdated
+		}
+		updated = model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
+		return flterrors.ErrorFromGormError(tx.First(&updated).Error)
 	})
+	if err != nil {
+		return nil, err
+	}
+	device := updated.ToApiResource()
+	return &device, nil
 }
 
-func (s *DeviceStore) updateRendered(orgId uuid.UUID, name string, rendered string) (retry bool, err error) {
+func (s *DeviceStore) updateRendered(orgId uuid.UUID, name string, rendered string, imageDigests []api.ImageDigest) (retry bool, err error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 	result := s.db.First(&existingRecord)
 	if result.Error != nil {
@@ -429,6 +698,16 @@ func (s *DeviceStore) updateRendered(orgId uuid.UUID, name string, rendered stri
 	}
 
 	existingAnnotations[model.DeviceAnnotationRenderedVersion] = nextRenderedVersion
+	// the digests are recorded with the rendered version they are of
+	if len(imageDigests) == 0 {
+		delete(existingAnnotations, model.DeviceAnnotationImageDigests)
+	} else {
+		digests, err := json.Marshal(imageDigests)
+		if err != nil {
+			return false, err
+		}
+		existingAnnotations[model.DeviceAnnotationImageDigests] = string(digests)
+	}
 	annotationsArray := util.LabelMapToArray(&existingAnnotations)
 
 	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
@@ -462,22 +741,70 @@ func getNextRenderedVersion(annotations map[string]string) (string, error) {
 	return strconv.FormatInt(currentRenderedVersion, 10), nil
 }
 
-func (s *DeviceStore) UpdateRendered(ctx context.Context, orgId uuid.UUID, name string, rendered string) error {
+func (s *DeviceStore) UpdateRendered(ctx context.Context, orgId uuid.UUID, name string, rendered string, imageDigests []api.ImageDigest) error {
 	return retryUpdate(func() (bool, error) {
-		return s.updateRendered(orgId, name, rendered)
+		return s.updateRendered(orgId, name, rendered, imageDigests)
 	})
 }
 
+// renderState is what GetRendered loads of a device on each request, which
+// leaves out its spec, status and rendered config unless they are not cached.
+type renderState struct {
+	Annotations  pq.StringArray
+	CreatedAt    time.Time
+	Architecture string
+}
+
 func (s *DeviceStore) GetRendered(ctx context.Context, orgId uuid.UUID, name string, knownRenderedVersion *string, consoleGrpcEndpoint string) (*api.RenderedDeviceSpec, error) {
-	device := model.Device{
-		Resource: model.Resource{OrgID: orgId, Name: name},
-	}
-	result := s.db.First(&device)
+	var state renderState
+	result := s.db.Model(&model.Device{}).
+		Select("annotations", "created_at", "COALESCE(status -> 'systemInfo' ->> 'architecture', '') AS architecture").
+		Where("org_id = ? AND name = ?", orgId, name).
+		Take(&state)
 	if result.Error != nil {
 		return nil, flterrors.ErrorFromGormError(result.Error)
 	}
 
-	annotations := util.LabelArrayToMap(device.Annotations)
+	annotations := util.LabelArrayToMap(state.Annotations)
+
+	// a requested action is served until the agent acknowledges it, whether
+	// or not the device has the latest rendered version
+	var action *api.DeviceAction
+	if val, ok := annotations[model.DeviceAnnotationAction]; ok {
+		action = &api.DeviceAction{}
+		if err := json.Unmarshal([]byte(val), action); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationAction, err)
+		}
+	}
+
+	// a migrating device is only told where it migrates to, on each request
+	// until it stops requesting specs from this instance
+	if val, ok := annotations[model.DeviceAnnotationMigration]; ok {
+		var migration api.DeviceMigration
+		if err := json.Unmarshal([]byte(val), &migration); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationMigration, err)
+		}
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: lo.FromPtr(knownRenderedVersion),
+			Migration:       &migration,
+			Action:          action,
+		}, nil
+	}
+
+	// a quarantined device keeps the version it has and is only told about
+	// the quarantine, on each request so that it cannot miss it
+	if val, ok := annotations[model.DeviceAnnotationQuarantine]; ok {
+		var quarantine api.DeviceQuarantine
+		if err := json.Unmarshal([]byte(val), &quarantine); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationQuarantine, err)
+		}
+		return &api.RenderedDeviceSpec{
+			RenderedVersion: lo.FromPtr(knownRenderedVersion),
+			Quarantine:      &quarantine,
+			Action:          action,
+		}, nil
+	}
+
 	renderedVersion, ok := annotations[model.DeviceAnnotationRenderedVersion]
 	if !ok {
 		return nil, flterrors.ErrNoRenderedVersion
@@ -486,32 +813,108 @@ func (s *DeviceStore) GetRendered(ctx context.Context, orgId uuid.UUID, name str
 	var console *api.DeviceConsole
 
 	if val, ok := annotations[model.DeviceAnnotationConsole]; ok {
+		sessionID, publicKey := model.ParseConsoleAnnotation(val)
 		console = &api.DeviceConsole{
 			GRPCEndpoint: consoleGrpcEndpoint,
-			SessionID:    val,
+			SessionID:    sessionID,
+		}
+		if publicKey != "" {
+			console.PublicKey = &publicKey
 		}
 	}
 
-	// if we have a console request we ignore the rendered version
+	// a tunnel is served until it expires, and then closes on the device
+	var tunnel *api.DeviceTunnel
+	if val, ok := annotations[model.DeviceAnnotationTunnel]; ok {
+		tunnel = &api.DeviceTunnel{}
+		if err := json.Unmarshal([]byte(val), tunnel); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationTunnel, err)
+		}
+		if time.Now().After(tunnel.ExpiresAt) {
+			tunnel = nil
+		} else {
+			tunnel.GRPCEndpoint = &consoleGrpcEndpoint
+		}
+	}
+
+	// if we have a console or tunnel request we ignore the rendered version
 	// TODO: bump the rendered version instead?
-	if console == nil && knownRenderedVersion != nil && renderedVersion == *knownRenderedVersion {
+	if console == nil && tunnel == nil && action == nil && knownRenderedVersion != nil && renderedVersion == *knownRenderedVersion {
 		return nil, nil
 	}
 
-	renderedConfig := api.RenderedDeviceSpec{
-		RenderedVersion: renderedVersion,
-		Config:          device.RenderedConfig,
-		Containers:      device.Spec.Data.Containers,
-		Os:              device.Spec.Data.Os,
-		Systemd:         device.Spec.Data.Systemd,
-		Resources:       device.Spec.Data.Resources,
-		Hooks:           device.Spec.Data.Hooks,
-		Console:         console,
+	key := renderedSpecKey{orgId: orgId, name: name}
+	spec, ok := s.renderedSpecs.get(key, renderedSpecVersion{createdAt: state.CreatedAt, renderedVersion: renderedVersion, architecture: state.Architecture})
+	if !ok {
+		var err error
+		if spec, err = s.loadRenderedSpec(key); err != nil {
+			return nil, err
+		}
 	}
 
+	renderedConfig := *spec
+	renderedConfig.Console = console
+	renderedConfig.Tunnel = tunnel
+	renderedConfig.Action = action
 	return &renderedConfig, nil
 }
 
+// loadRenderedSpec loads the rendered spec of the device, without its console
+// and action, and caches it. The device may have rendered a newer version
+// since its state was loaded, which is then the version served and cached.
+func (s *DeviceStore) loadRenderedSpec(key renderedSpecKey) (*api.RenderedDeviceSpec, error) {
+	device := model.Device{
+		Resource: model.Resource{OrgID: key.orgId, Name: key.name},
+	}
+	result := s.db.First(&device)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+
+	annotations := util.LabelArrayToMap(device.Annotations)
+	renderedVersion, ok := annotations[model.DeviceAnnotationRenderedVersion]
+	if !ok {
+		return nil, flterrors.ErrNoRenderedVersion
+	}
+
+	var imageDigests *[]api.ImageDigest
+	if val, ok := annotations[model.DeviceAnnotationImageDigests]; ok {
+		imageDigests = &[]api.ImageDigest{}
+		if err := json.Unmarshal([]byte(val), imageDigests); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s: %w", model.DeviceAnnotationImageDigests, err)
+		}
+	}
+
+	// the agent pulls the images for the architecture the device reported
+	architecture := ""
+	if device.Status != nil {
+		architecture = device.Status.Data.SystemInfo.Architecture
+	}
+	spec := device.Spec.Data.ForArchitecture(architecture)
+
+	renderedConfig := &api.RenderedDeviceSpec{
+		RenderedVersion:   renderedVersion,
+		Config:            device.RenderedConfig,
+		Containers:        spec.Containers,
+		Os:                spec.Os,
+		Systemd:           spec.Systemd,
+		Resources:         spec.Resources,
+		Hooks:             spec.Hooks,
+		Agent:             spec.Agent,
+		Encryption:        spec.Encryption,
+		Time:              spec.Time,
+		Compliance:        spec.Compliance,
+		Firewall:          spec.Firewall,
+		GarbageCollection: spec.GarbageCollection,
+		Drift:             spec.Drift,
+		Applications:      spec.Applications,
+		ImageDigests:      imageDigests,
+	}
+
+	s.renderedSpecs.add(key, renderedSpecVersion{createdAt: device.CreatedAt, renderedVersion: renderedVersion, architecture: architecture}, renderedConfig)
+	return renderedConfig, nil
+}
+
 func (s *DeviceStore) setServiceConditions(orgId uuid.UUID, name string, conditions []api.Condition) (retry bool, err error) {
 	existingRecord := model.Device{Resource: model.Resource{OrgID: orgId, Name: name}}
 	result := s.db.First(&existingRecord)
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeviceAttestation interface {
+	Get(ctx context.Context, orgId uuid.UUID, device string) (*model.DeviceAttestation, error)
+	SetChallenge(ctx context.Context, orgId uuid.UUID, device string, nonce []byte, expiresAt time.Time) error
+	Update(ctx context.Context, orgId uuid.UUID, attestation *model.DeviceAttestation) error
+	DeleteForDevice(ctx context.Context, orgId uuid.UUID, device string) error
+	DeleteForAllDevices(ctx context.Context, orgId uuid.UUID) error
+	InitialMigration() error
+}
+
+type DeviceAttestationStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to DeviceAttestation interface
+var _ DeviceAttestation = (*DeviceAttestationStore)(nil)
+
+func NewDeviceAttestation(db *gorm.DB, log logrus.FieldLogger) DeviceAttestation {
+	return &DeviceAttestationStore{db: db, log: log}
+}
+
+func (s *DeviceAttestationStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.DeviceAttestation{})
+}
+
+func (s *DeviceAttestationStore) Get(ctx context.Context, orgId uuid.UUID, device string) (*model.DeviceAttestation, error) {
+	attestation := model.DeviceAttestation{OrgID: orgId, Device: device}
+	result := s.db.WithContext(ctx).First(&attestation)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	return &attestation, nil
+}
+
+// SetChallenge replaces the outstanding challenge of the device, creating
+// the attestation state of the device when it attests for the first time.
+func (s *DeviceAttestationStore) SetChallenge(ctx context.Context, orgId uuid.UUID, device string, nonce []byte, expiresAt time.Time) error {
+	attestation := model.DeviceAttestation{OrgID: orgId, Device: device, Nonce: nonce, NonceExpiresAt: &expiresAt}
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "org_id"}, {Name: "device"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nonce", "nonce_expires_at", "updated_at"}),
+	}).Create(&attestation)
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+// Update stores the outcome of an attestation. It fails with
+// ErrNoRowsUpdated if the nonce was consumed by a concurrent attestation, so
+// that each nonce is only accepted once.
+func (s *DeviceAttestationStore) Update(ctx context.Context, orgId uuid.UUID, attestation *model.DeviceAttestation) error {
+	result := s.db.WithContext(ctx).Model(&model.DeviceAttestation{}).
+		Where("org_id = ? AND device = ? AND nonce = ?", orgId, attestation.Device, attestation.Nonce).
+		Updates(map[string]any{
+			"attestation_key":  attestation.AttestationKey,
+			"nonce":            nil,
+			"nonce_expires_at": nil,
+			"references":       attestation.References,
+			"booted_image":     attestation.BootedImage,
+			"last_attested_at": attestation.LastAttestedAt,
+		})
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrNoRowsUpdated
+	}
+	return nil
+}
+
+func (s *DeviceAttestationStore) DeleteForDevice(ctx context.Context, orgId uuid.UUID, device string) error {
+	result := s.db.WithContext(ctx).Where("org_id = ? AND device = ?", orgId, device).Delete(&model.DeviceAttestation{})
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *DeviceAttestationStore) DeleteForAllDevices(ctx context.Context, orgId uuid.UUID) error {
+	result := s.db.WithContext(ctx).Where("org_id = ?", orgId).Delete(&model.DeviceAttestation{})
+	return flterrors.ErrorFromGormError(result.Error)
+}
@@ -0,0 +1,324 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DeviceIdentity interface {
+	Create(ctx context.Context, orgId uuid.UUID, req *api.DeviceIdentity) (*api.DeviceIdentity, error)
+	Update(ctx context.Context, orgId uuid.UUID, req *api.DeviceIdentity) (*api.DeviceIdentity, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceIdentityList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceIdentity, error)
+	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, deviceIdentity *api.DeviceIdentity) (*api.DeviceIdentity, bool, error)
+	Match(ctx context.Context, orgId uuid.UUID, identity ProvisioningIdentity) (*api.DeviceIdentity, error)
+	SetProvisioningChallenge(ctx context.Context, orgId uuid.UUID, name string, secretHash []byte, expiresAt time.Time) error
+	Provision(ctx context.Context, orgId uuid.UUID, identity ProvisioningIdentity, secret []byte) (*api.DeviceIdentity, error)
+	DeleteAll(ctx context.Context, orgId uuid.UUID) error
+	Delete(ctx context.Context, orgId uuid.UUID, name string) error
+	InitialMigration() error
+}
+
+// ProvisioningIdentity is the hardware identity a machine presents to be
+// provisioned. The hash of the TPM endorsement key is the one of the key the
+// machine presented, which it proves to hold by recovering the secret of a
+// provisioning challenge.
+type ProvisioningIdentity struct {
+	HardwareIdentity
+	TpmEkHash string
+}
+
+type DeviceIdentityStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to DeviceIdentity interface
+var _ DeviceIdentity = (*DeviceIdentityStore)(nil)
+
+func NewDeviceIdentity(db *gorm.DB, log logrus.FieldLogger) DeviceIdentity {
+	return &DeviceIdentityStore{db: db, log: log}
+}
+
+func (s *DeviceIdentityStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.DeviceIdentity{})
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *DeviceIdentityStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.DeviceIdentity) (*api.DeviceIdentity, error) {
+	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly)
+	return updatedResource, err
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *DeviceIdentityStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.DeviceIdentity) (*api.DeviceIdentity, error) {
+	updatedResource, _, err := retryCreateOrUpdate(func() (*api.DeviceIdentity, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeUpdateOnly)
+	})
+	return updatedResource, err
+}
+
+func (s *DeviceIdentityStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceIdentityList, error) {
+	var deviceIdentitys model.DeviceIdentityList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&deviceIdentitys), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&deviceIdentitys)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(deviceIdentitys) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    deviceIdentitys[len(deviceIdentitys)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		deviceIdentitys = deviceIdentitys[:len(deviceIdentitys)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&deviceIdentitys), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiDeviceIdentityList := deviceIdentitys.ToApiResource(nextContinue, numRemaining)
+	return &apiDeviceIdentityList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *DeviceIdentityStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
+	condition := model.DeviceIdentity{}
+	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *DeviceIdentityStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceIdentity, error) {
+	deviceIdentity := model.DeviceIdentity{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&deviceIdentity)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiDeviceIdentity := deviceIdentity.ToApiResource()
+	return &apiDeviceIdentity, nil
+}
+
+func (s *DeviceIdentityStore) createDeviceIdentity(deviceIdentity *model.DeviceIdentity) (bool, error) {
+	deviceIdentity.Generation = lo.ToPtr[int64](1)
+	deviceIdentity.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(deviceIdentity); result.Error != nil {
+		err := flterrors.ErrorFromGormError(result.Error)
+		return err == flterrors.ErrDuplicateName, err
+	}
+	return false, nil
+}
+
+func (s *DeviceIdentityStore) updateDeviceIdentity(existingRecord, deviceIdentity *model.DeviceIdentity) (bool, error) {
+	updateSpec := deviceIdentity.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, deviceIdentity.Spec)
+
+	// Update the generation if the spec was updated
+	if updateSpec {
+		deviceIdentity.Generation = lo.ToPtr(lo.FromPtr(existingRecord.Generation) + 1)
+	}
+	if deviceIdentity.ResourceVersion != nil && lo.FromPtr(existingRecord.ResourceVersion) != lo.FromPtr(deviceIdentity.ResourceVersion) {
+		return false, flterrors.ErrResourceVersionConflict
+	}
+	deviceIdentity.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+	where := model.DeviceIdentity{Resource: model.Resource{OrgID: deviceIdentity.OrgID, Name: deviceIdentity.Name}}
+	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+
+	result := query.Updates(&deviceIdentity)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *DeviceIdentityStore) createOrUpdate(orgId uuid.UUID, resource *api.DeviceIdentity, mode CreateOrUpdateMode) (*api.DeviceIdentity, bool, bool, error) {
+	if resource == nil {
+		return nil, false, false, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, false, false, flterrors.ErrResourceNameIsNil
+	}
+
+	deviceIdentity, err := model.NewDeviceIdentityFromApiResource(resource)
+	if err != nil {
+		return nil, false, false, err
+	}
+	deviceIdentity.OrgID = orgId
+	deviceIdentity.Status = nil
+
+	existingRecord, err := getExistingRecord[model.DeviceIdentity](s.db, deviceIdentity.Name, orgId)
+	if err != nil {
+		return nil, false, false, err
+	}
+	exists := existingRecord != nil
+
+	if exists && mode == ModeCreateOnly {
+		return nil, false, false, flterrors.ErrDuplicateName
+	}
+	if !exists && mode == ModeUpdateOnly {
+		return nil, false, false, flterrors.ErrResourceNotFound
+	}
+
+	if !exists {
+		if retry, err := s.createDeviceIdentity(deviceIdentity); err != nil {
+			return nil, false, retry, err
+		}
+	} else {
+		if retry, err := s.updateDeviceIdentity(existingRecord, deviceIdentity); err != nil {
+			return nil, false, retry, err
+		}
+	}
+
+	updatedResource := deviceIdentity.ToApiResource()
+	return &updatedResource, !exists, false, nil
+}
+
+func (s *DeviceIdentityStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.DeviceIdentity) (*api.DeviceIdentity, bool, error) {
+	return retryCreateOrUpdate(func() (*api.DeviceIdentity, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate)
+	})
+}
+
+// match returns the device identity all of whose recorded identifiers match
+// the hardware identity of a machine. It fails with ErrAmbiguousDeviceIdentity
+// if more than one does, rather than picking one of them.
+func (s *DeviceIdentityStore) match(ctx context.Context, orgId uuid.UUID, identity ProvisioningIdentity) (*model.DeviceIdentity, error) {
+	if identity.SerialNumber == "" && len(identity.MacAddresses) == 0 && identity.TpmEkHash == "" {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	query := s.db.WithContext(ctx).Where("org_id = ?", orgId).
+		Where("(spec ->> 'serialNumber' IS NULL OR spec ->> 'serialNumber' = ?)", identity.SerialNumber).
+		Where("(spec ->> 'macAddress' IS NULL OR spec ->> 'macAddress' IN ?)", identity.MacAddresses).
+		Where("(spec ->> 'tpmEkHash' IS NULL OR spec ->> 'tpmEkHash' = ?)", identity.TpmEkHash)
+
+	var deviceIdentities []model.DeviceIdentity
+	if result := query.Order("name").Limit(2).Find(&deviceIdentities); result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	switch len(deviceIdentities) {
+	case 0:
+		return nil, flterrors.ErrResourceNotFound
+	case 1:
+		return &deviceIdentities[0], nil
+	default:
+		s.log.Warnf("device identities %s and %s both match the same machine", deviceIdentities[0].Name, deviceIdentities[1].Name)
+		return nil, flterrors.ErrAmbiguousDeviceIdentity
+	}
+}
+
+// Match returns the device identity matching the hardware identity of a
+// machine: each of the serial number, MAC address and TPM endorsement key
+// hash it records must match.
+func (s *DeviceIdentityStore) Match(ctx context.Context, orgId uuid.UUID, identity ProvisioningIdentity) (*api.DeviceIdentity, error) {
+	deviceIdentity, err := s.match(ctx, orgId, identity)
+	if err != nil {
+		return nil, err
+	}
+	apiDeviceIdentity := deviceIdentity.ToApiResource()
+	return &apiDeviceIdentity, nil
+}
+
+// SetProvisioningChallenge replaces the outstanding provisioning challenge of
+// the device identity with the hash of its secret.
+func (s *DeviceIdentityStore) SetProvisioningChallenge(ctx context.Context, orgId uuid.UUID, name string, secretHash []byte, expiresAt time.Time) error {
+	result := s.db.WithContext(ctx).Model(&model.DeviceIdentity{Resource: model.Resource{OrgID: orgId, Name: name}}).
+		Updates(map[string]interface{}{
+			"provisioning_challenge":            secretHash,
+			"provisioning_challenge_expires_at": expiresAt,
+		})
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrResourceNotFound
+	}
+	return nil
+}
+
+// Provision finds the device identity matching the hardware identity of a
+// machine and records the time of its provisioning. A device identity which
+// records a TPM endorsement key is only provisioned with the secret of its
+// outstanding provisioning challenge. Each device identity can only be
+// provisioned once.
+func (s *DeviceIdentityStore) Provision(ctx context.Context, orgId uuid.UUID, identity ProvisioningIdentity, secret []byte) (*api.DeviceIdentity, error) {
+	deviceIdentity, err := s.match(ctx, orgId, identity)
+	if err != nil {
+		return nil, err
+	}
+	if deviceIdentity.Status != nil && deviceIdentity.Status.Data.ProvisionedAt != nil {
+		return nil, flterrors.ErrAlreadyProvisioned
+	}
+	if deviceIdentity.Spec != nil && deviceIdentity.Spec.Data.TpmEkHash != nil {
+		secretHash := sha256.Sum256(secret)
+		if len(secret) == 0 || deviceIdentity.ProvisioningChallengeExpiresAt == nil || time.Now().After(*deviceIdentity.ProvisioningChallengeExpiresAt) ||
+			subtle.ConstantTimeCompare(deviceIdentity.ProvisioningChallenge, secretHash[:]) != 1 {
+			return nil, flterrors.ErrProvisioningChallengeFailed
+		}
+	}
+
+	deviceIdentity.Status = model.MakeJSONField(api.DeviceIdentityStatus{ProvisionedAt: lo.ToPtr(time.Now().UTC())})
+	// only the first of concurrent requests of the same machine is provisioned
+	result := s.db.WithContext(ctx).Model(&model.DeviceIdentity{Resource: model.Resource{OrgID: orgId, Name: deviceIdentity.Name}}).
+		Where("resource_version = ?", lo.FromPtr(deviceIdentity.ResourceVersion)).
+		Updates(map[string]interface{}{
+			"status":                            deviceIdentity.Status,
+			"provisioning_challenge":            nil,
+			"provisioning_challenge_expires_at": nil,
+			"resource_version":                  gorm.Expr("resource_version + 1"),
+		})
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, flterrors.ErrAlreadyProvisioned
+	}
+	deviceIdentity.ResourceVersion = lo.ToPtr(lo.FromPtr(deviceIdentity.ResourceVersion) + 1)
+	apiDeviceIdentity := deviceIdentity.ToApiResource()
+	return &apiDeviceIdentity, nil
+}
+
+func (s *DeviceIdentityStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	condition := model.DeviceIdentity{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return flterrors.ErrorFromGormError(result.Error)
+}
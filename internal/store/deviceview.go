@@ -0,0 +1,216 @@
+package store
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type DeviceView interface {
+	Create(ctx context.Context, orgId uuid.UUID, req *api.DeviceView) (*api.DeviceView, error)
+	Update(ctx context.Context, orgId uuid.UUID, req *api.DeviceView) (*api.DeviceView, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceViewList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceView, error)
+	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, deviceView *api.DeviceView) (*api.DeviceView, bool, error)
+	DeleteAll(ctx context.Context, orgId uuid.UUID) error
+	Delete(ctx context.Context, orgId uuid.UUID, name string) error
+	InitialMigration() error
+}
+
+type DeviceViewStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to DeviceView interface
+var _ DeviceView = (*DeviceViewStore)(nil)
+
+func NewDeviceView(db *gorm.DB, log logrus.FieldLogger) DeviceView {
+	return &DeviceViewStore{db: db, log: log}
+}
+
+func (s *DeviceViewStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.DeviceView{})
+}
+
+func (s *DeviceViewStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.DeviceView) (*api.DeviceView, error) {
+	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly)
+	return updatedResource, err
+}
+
+func (s *DeviceViewStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.DeviceView) (*api.DeviceView, error) {
+	updatedResource, _, err := retryCreateOrUpdate(func() (*api.DeviceView, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeUpdateOnly)
+	})
+	return updatedResource, err
+}
+
+func (s *DeviceViewStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.DeviceViewList, error) {
+	var deviceViews model.DeviceViewList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&deviceViews), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&deviceViews)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(deviceViews) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    deviceViews[len(deviceViews)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		deviceViews = deviceViews[:len(deviceViews)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&deviceViews), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiDeviceViewList := deviceViews.ToApiResource(nextContinue, numRemaining)
+	return &apiDeviceViewList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *DeviceViewStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
+	condition := model.DeviceView{}
+	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	return nil
+}
+
+func (s *DeviceViewStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceView, error) {
+	deviceView := model.DeviceView{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&deviceView)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiDeviceView := deviceView.ToApiResource()
+	return &apiDeviceView, nil
+}
+
+func (s *DeviceViewStore) createDeviceView(deviceView *model.DeviceView) (bool, error) {
+	deviceView.Generation = lo.ToPtr[int64](1)
+	deviceView.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(deviceView); result.Error != nil {
+		err := flterrors.ErrorFromGormError(result.Error)
+		return err == flterrors.ErrDuplicateName, err
+	}
+	return false, nil
+}
+
+func (s *DeviceViewStore) updateDeviceView(existingRecord, deviceView *model.DeviceView) (bool, error) {
+	updateSpec := deviceView.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, deviceView.Spec)
+
+	// Update the generation if the spec was updated
+	if updateSpec {
+		deviceView.Generation = lo.ToPtr(lo.FromPtr(existingRecord.Generation) + 1)
+	}
+	if deviceView.ResourceVersion != nil && lo.FromPtr(existingRecord.ResourceVersion) != lo.FromPtr(deviceView.ResourceVersion) {
+		return false, flterrors.ErrResourceVersionConflict
+	}
+	deviceView.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+	where := model.DeviceView{Resource: model.Resource{OrgID: deviceView.OrgID, Name: deviceView.Name}}
+	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+
+	result := query.Updates(&deviceView)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+func (s *DeviceViewStore) createOrUpdate(orgId uuid.UUID, resource *api.DeviceView, mode CreateOrUpdateMode) (*api.DeviceView, bool, bool, error) {
+	if resource == nil {
+		return nil, false, false, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, false, false, flterrors.ErrResourceNameIsNil
+	}
+
+	deviceView, err := model.NewDeviceViewFromApiResource(resource)
+	if err != nil {
+		return nil, false, false, err
+	}
+	deviceView.OrgID = orgId
+
+	existingRecord, err := getExistingRecord[model.DeviceView](s.db, deviceView.Name, orgId)
+	if err != nil {
+		return nil, false, false, err
+	}
+	exists := existingRecord != nil
+
+	if exists && mode == ModeCreateOnly {
+		return nil, false, false, flterrors.ErrDuplicateName
+	}
+	if !exists && mode == ModeUpdateOnly {
+		return nil, false, false, flterrors.ErrResourceNotFound
+	}
+
+	if !exists {
+		if retry, err := s.createDeviceView(deviceView); err != nil {
+			return nil, false, retry, err
+		}
+	} else {
+		if retry, err := s.updateDeviceView(existingRecord, deviceView); err != nil {
+			return nil, false, retry, err
+		}
+	}
+
+	updatedResource := deviceView.ToApiResource()
+	return &updatedResource, !exists, false, nil
+}
+
+func (s *DeviceViewStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.DeviceView) (*api.DeviceView, bool, error) {
+	return retryCreateOrUpdate(func() (*api.DeviceView, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate)
+	})
+}
+
+func (s *DeviceViewStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	condition := model.DeviceView{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrResourceNotFound
+	}
+	return nil
+}
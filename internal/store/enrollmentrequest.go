@@ -28,15 +28,16 @@ type EnrollmentRequest interface {
 }
 
 type EnrollmentRequestStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db    *gorm.DB
+	reads *ReadReplicas
+	log   logrus.FieldLogger
 }
 
 // Make sure we conform to EnrollmentRequest interface
 var _ EnrollmentRequest = (*EnrollmentRequestStore)(nil)
 
-func NewEnrollmentRequest(db *gorm.DB, log logrus.FieldLogger) EnrollmentRequest {
-	return &EnrollmentRequestStore{db: db, log: log}
+func NewEnrollmentRequest(db *gorm.DB, reads *ReadReplicas, log logrus.FieldLogger) EnrollmentRequest {
+	return &EnrollmentRequestStore{db: db, reads: reads, log: log}
 }
 
 func (s *EnrollmentRequestStore) InitialMigration() error {
@@ -61,7 +62,8 @@ func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, list
 	var nextContinue *string
 	var numRemaining *int64
 
-	query := BuildBaseListQuery(s.db.Model(&enrollmentRequests), orgId, listParams)
+	db := s.reads.DB(ctx)
+	query := BuildBaseListQuery(db.Model(&enrollmentRequests), orgId, listParams)
 	if listParams.Limit > 0 {
 		// Request 1 more than the user asked for to see if we need to return "continue"
 		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
@@ -83,7 +85,7 @@ func (s *EnrollmentRequestStore) List(ctx context.Context, orgId uuid.UUID, list
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery := BuildBaseListQuery(s.db.Model(&enrollmentRequests), orgId, listParams)
+			countQuery := BuildBaseListQuery(db.Model(&enrollmentRequests), orgId, listParams)
 			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
 		}
 		nextContinueStruct.Count = numRemainingVal
@@ -196,14 +198,10 @@ func (s *EnrollmentRequestStore) UpdateStatus(ctx context.Context, orgId uuid.UU
 	if resource.Metadata.Name == nil {
 		return nil, flterrors.ErrResourceNameIsNil
 	}
-	enrollmentRequest := model.EnrollmentRequest{
-		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
+	if err := updateResourceStatus[model.EnrollmentRequest](s.db, orgId, *resource.Metadata.Name, resource.Metadata.ResourceVersion, model.MakeJSONField(resource.Status)); err != nil {
+		return nil, err
 	}
-	result := s.db.Model(&enrollmentRequest).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
-	})
-	return resource, flterrors.ErrorFromGormError(result.Error)
+	return resource, nil
 }
 
 func (s *EnrollmentRequestStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
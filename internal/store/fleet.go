@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
@@ -31,19 +33,24 @@ type Fleet interface {
 	UpdateStatusMultiple(ctx context.Context, orgId uuid.UUID, fleets ...*api.Fleet) error
 	DeleteAll(ctx context.Context, orgId uuid.UUID, callback FleetStoreAllDeletedCallback) error
 	Delete(ctx context.Context, orgId uuid.UUID, callback FleetStoreCallback, names ...string) error
+	Restore(ctx context.Context, orgId uuid.UUID, name string, callback FleetStoreCallback) (*api.Fleet, error)
 	UnsetOwner(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, owner string) error
 	UnsetOwnerByKind(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, resourceKind string) error
 	ListIgnoreOrg() ([]model.Fleet, error)
 	UpdateConditions(ctx context.Context, orgId uuid.UUID, name string, conditions []api.Condition) error
+	UpdateRollout(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) error
+	UpdatePromotion(ctx context.Context, orgId uuid.UUID, name string, promotion *api.FleetPromotionStatus) error
 	UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error
 	OverwriteRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string, repositoryNames ...string) error
 	GetRepositoryRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.RepositoryList, error)
+	MigrateStorageVersion(ctx context.Context) (int, error)
 	InitialMigration() error
 }
 
 type FleetStore struct {
-	db  *gorm.DB
-	log logrus.FieldLogger
+	db    *gorm.DB
+	reads *ReadReplicas
+	log   logrus.FieldLogger
 }
 
 type FleetStoreCallback func(before *model.Fleet, after *model.Fleet)
@@ -52,8 +59,8 @@ type FleetStoreAllDeletedCallback func(orgId uuid.UUID)
 // Make sure we conform to Fleet interface
 var _ Fleet = (*FleetStore)(nil)
 
-func NewFleet(db *gorm.DB, log logrus.FieldLogger) Fleet {
-	return &FleetStore{db: db, log: log}
+func NewFleet(db *gorm.DB, reads *ReadReplicas, log logrus.FieldLogger) Fleet {
+	return &FleetStore{db: db, reads: reads, log: log}
 }
 
 func (s *FleetStore) InitialMigration() error {
@@ -91,7 +98,7 @@ type fleetWithCount struct {
 
 func fleetSelectStr(withDeviceCount bool) string {
 	return lo.Ternary(withDeviceCount,
-		fmt.Sprintf("*, (select count(*) from devices where org_id = fleets.org_id and owner = CONCAT('%s/', fleets.name)) as device_count", model.FleetKind),
+		fmt.Sprintf("*, (select count(*) from devices where org_id = fleets.org_id and owner = CONCAT('%s/', fleets.name) and deleted_at is null) as device_count", model.FleetKind),
 		"*")
 }
 
@@ -101,7 +108,8 @@ func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListP
 	var numRemaining *int64
 	var options listOptions
 	lo.ForEach(opts, func(opt ListOption, _ int) { opt(&options) })
-	dbModel := s.db.Table("fleets").Select(fleetSelectStr(options.withDeviceCount))
+	db := s.reads.DB(ctx)
+	dbModel := db.Table("fleets").Where("deleted_at IS NULL").Select(fleetSelectStr(options.withDeviceCount))
 	query := BuildBaseListQuery(dbModel, orgId, listParams)
 	if listParams.Limit > 0 {
 		// Request 1 more than the user asked for to see if we need to return "continue"
@@ -124,7 +132,7 @@ func (s *FleetStore) List(ctx context.Context, orgId uuid.UUID, listParams ListP
 				numRemainingVal = 1
 			}
 		} else {
-			countQuery := BuildBaseListQuery(s.db.Model(&model.Fleet{}), orgId, listParams)
+			countQuery := BuildBaseListQuery(db.Model(&model.Fleet{}), orgId, listParams)
 			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
 		}
 		nextContinueStruct.Count = numRemainingVal
@@ -186,7 +194,7 @@ func (s *FleetStore) Get(ctx context.Context, orgId uuid.UUID, name string, opts
 	}
 
 	var fleet fleetWithCount
-	result := s.db.Table("fleets").Where("org_id = ? and name = ?", orgId, name).
+	result := s.db.Table("fleets").Where("org_id = ? and name = ? and deleted_at IS NULL", orgId, name).
 		Select(fleetSelectStr(true)).
 		Scan(&fleet)
 	if result.Error != nil {
@@ -209,6 +217,13 @@ func (s *FleetStore) Get(ctx context.Context, orgId uuid.UUID, name string, opts
 		if err != nil {
 			return nil, flterrors.ErrorFromGormError(err)
 		}
+
+		summary.ComplianceStatus, err = s.getDeviceSummary(ctx, orgId, name, "compliance")
+		if err != nil {
+			return nil, flterrors.ErrorFromGormError(err)
+		}
+		// devices not reporting a compliance scan have no compliance status
+		delete(*summary.ComplianceStatus, "")
 	}
 
 	apiFleet := fleet.ToApiResource(model.WithSummary(&summary))
@@ -224,12 +239,12 @@ func (s *FleetStore) getDeviceSummary(ctx context.Context, orgId uuid.UUID, flee
 	queryStr := `
 	SELECT count(*) as count, status::jsonb->'%s'->>'status' as status
 	FROM devices
-	WHERE owner = '%s' AND org_id = '%s'
+	WHERE owner = '%s' AND org_id = '%s' AND deleted_at IS NULL
 	GROUP BY status::jsonb->'%s'->>'status'`
 	summaryQueryStr := fmt.Sprintf(queryStr, summaryField, *util.SetResourceOwner(model.FleetKind, fleetName), orgId, summaryField)
 
 	var statusCounts []StatusCount
-	if err := s.db.WithContext(ctx).Raw(summaryQueryStr).Scan(&statusCounts).Error; err != nil {
+	if err := s.reads.DB(ctx).WithContext(ctx).Raw(summaryQueryStr).Scan(&statusCounts).Error; err != nil {
 		return nil, err
 	}
 	return lo.ToPtr(lo.SliceToMap(statusCounts, func(s StatusCount) (string, int) { return s.Status, s.Count })), nil
@@ -242,6 +257,9 @@ func (s *FleetStore) createFleet(fleet *model.Fleet) (bool, error) {
 	fleet.Spec.Data.Template.Metadata.Generation = lo.ToPtr[int64](1)
 	fleet.Generation = lo.ToPtr[int64](1)
 	fleet.ResourceVersion = lo.ToPtr[int64](1)
+	if err := purgeDeletedRecord[model.Fleet](s.db, fleet.OrgID, fleet.Name); err != nil {
+		return false, err
+	}
 	if result := s.db.Create(fleet); result.Error != nil {
 		err := flterrors.ErrorFromGormError(result.Error)
 		return err == flterrors.ErrDuplicateName, err
@@ -257,7 +275,8 @@ func (s *FleetStore) updateFleet(existingRecord, fleet *model.Fleet) (bool, erro
 		return false, flterrors.ErrResourceVersionConflict
 	}
 
-	sameSpec := reflect.DeepEqual(existingRecord.Spec, fleet.Spec)
+	// The annotations are only set if the fields of later API versions changed
+	sameSpec := reflect.DeepEqual(existingRecord.Spec, fleet.Spec) && fleet.Annotations == nil
 
 	// Update the generation if the spec was updated
 	fleet.Generation = lo.Ternary(!sameSpec, lo.ToPtr(lo.FromPtr(existingRecord.Generation)+1), existingRecord.Generation)
@@ -304,6 +323,7 @@ func (s *FleetStore) createOrUpdate(orgId uuid.UUID, resource *api.Fleet, mode C
 	fleet.OrgID = orgId
 
 	// Use the dedicated API to update annotations
+	conversion := util.LabelArrayToMap(fleet.Annotations)[v1beta1.FleetAnnotationConversion]
 	fleet.Annotations = nil
 
 	fleet.Owner = resource.Metadata.Owner
@@ -313,6 +333,7 @@ func (s *FleetStore) createOrUpdate(orgId uuid.UUID, resource *api.Fleet, mode C
 		return nil, false, false, err
 	}
 	exists := existingRecord != nil
+	fleet.Annotations = conversionAnnotations(existingRecord, conversion)
 
 	if exists && mode == ModeCreateOnly {
 		return nil, false, false, flterrors.ErrDuplicateName
@@ -337,6 +358,26 @@ func (s *FleetStore) createOrUpdate(orgId uuid.UUID, resource *api.Fleet, mode C
 	return &updatedResource, !exists, false, nil
 }
 
+// conversionAnnotations returns the annotations of the existing fleet with the
+// given conversion annotation, or nil if the conversion annotation is unchanged.
+// Unlike the other annotations it holds spec fields of later API versions, so it
+// is written together with the spec.
+func conversionAnnotations(existingRecord *model.Fleet, conversion string) pq.StringArray {
+	annotations := map[string]string{}
+	if existingRecord != nil {
+		annotations = util.LabelArrayToMap(existingRecord.Annotations)
+	}
+	if annotations[v1beta1.FleetAnnotationConversion] == conversion {
+		return nil
+	}
+	if conversion == "" {
+		delete(annotations, v1beta1.FleetAnnotationConversion)
+	} else {
+		annotations[v1beta1.FleetAnnotationConversion] = conversion
+	}
+	return util.LabelMapToArray(&annotations)
+}
+
 func (s *FleetStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Fleet, callback FleetStoreCallback) (*api.Fleet, bool, error) {
 	return retryCreateOrUpdate(func() (*api.Fleet, bool, bool, error) {
 		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate, callback)
@@ -375,14 +416,10 @@ func (s *FleetStore) updateStatus(tx *gorm.DB, orgId uuid.UUID, resource *api.Fl
 	if resource.Metadata.Name == nil {
 		return nil, flterrors.ErrResourceNameIsNil
 	}
-	fleet := model.Fleet{
-		Resource: model.Resource{OrgID: orgId, Name: *resource.Metadata.Name},
+	if err := updateResourceStatus[model.Fleet](tx, orgId, *resource.Metadata.Name, resource.Metadata.ResourceVersion, model.MakeJSONField(resource.Status)); err != nil {
+		return nil, err
 	}
-	result := tx.Model(&fleet).Updates(map[string]interface{}{
-		"status":           model.MakeJSONField(resource.Status),
-		"resource_version": gorm.Expr("resource_version + 1"),
-	})
-	return resource, flterrors.ErrorFromGormError(result.Error)
+	return resource, nil
 }
 
 func (s *FleetStore) UnsetOwner(ctx context.Context, tx *gorm.DB, orgId uuid.UUID, owner string) error {
@@ -415,9 +452,13 @@ func (s *FleetStore) UnsetOwnerByKind(ctx context.Context, tx *gorm.DB, orgId uu
 	return flterrors.ErrorFromGormError(result.Error)
 }
 
+// Delete moves the fleets to the trash. The callback is called with each
+// deleted fleet, so that its devices are released like those of a fleet that
+// is deleted permanently. Its template versions are kept until the trash is
+// purged.
 func (s *FleetStore) Delete(ctx context.Context, orgId uuid.UUID, callback FleetStoreCallback, names ...string) error {
 	deleted := []model.Fleet{}
-	if err := s.db.Raw(`delete from fleets where org_id = ? and name in (?) returning *`, orgId, names).Scan(&deleted).Error; err != nil {
+	if err := s.db.Raw(`update fleets set deleted_at = ? where org_id = ? and name in (?) and deleted_at is null returning *`, time.Now(), orgId, names).Scan(&deleted).Error; err != nil {
 		return flterrors.ErrorFromGormError(err)
 	}
 	for i := range deleted {
@@ -426,6 +467,21 @@ func (s *FleetStore) Delete(ctx context.Context, orgId uuid.UUID, callback Fleet
 	return nil
 }
 
+// Restore moves the fleet back from the trash. The callback is called with
+// the restored fleet as with a new one, so that it selects its devices again.
+func (s *FleetStore) Restore(ctx context.Context, orgId uuid.UUID, name string, callback FleetStoreCallback) (*api.Fleet, error) {
+	restored := []model.Fleet{}
+	if err := s.db.Raw(`update fleets set deleted_at = null, resource_version = resource_version + 1 where org_id = ? and name = ? and deleted_at is not null returning *`, orgId, name).Scan(&restored).Error; err != nil {
+		return nil, flterrors.ErrorFromGormError(err)
+	}
+	if len(restored) == 0 {
+		return nil, flterrors.ErrResourceNotFound
+	}
+	callback(nil, &restored[0])
+	fleet := restored[0].ToApiResource()
+	return &fleet, nil
+}
+
 func (s *FleetStore) updateConditions(orgId uuid.UUID, name string, conditions []api.Condition) (bool, error) {
 	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
 	result := s.db.First(&existingRecord)
@@ -467,6 +523,78 @@ func (s *FleetStore) UpdateConditions(ctx context.Context, orgId uuid.UUID, name
 	})
 }
 
+func (s *FleetStore) updateRollout(orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) (bool, error) {
+	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
+	result := s.db.First(&existingRecord)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+
+	if existingRecord.Status == nil {
+		existingRecord.Status = model.MakeJSONField(api.FleetStatus{Conditions: []api.Condition{}})
+	}
+	if reflect.DeepEqual(existingRecord.Status.Data.Rollout, rollout) {
+		return false, nil
+	}
+	existingRecord.Status.Data.Rollout = rollout
+
+	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+		"status":           existingRecord.Status,
+		"resource_version": gorm.Expr("resource_version + 1"),
+	})
+	err := flterrors.ErrorFromGormError(result.Error)
+	if err != nil {
+		return strings.Contains(err.Error(), "deadlock"), err
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+// UpdateRollout replaces the rollout progress in the status of the fleet.
+func (s *FleetStore) UpdateRollout(ctx context.Context, orgId uuid.UUID, name string, rollout *api.FleetRolloutStatus) error {
+	return retryUpdate(func() (bool, error) {
+		return s.updateRollout(orgId, name, rollout)
+	})
+}
+
+func (s *FleetStore) updatePromotion(orgId uuid.UUID, name string, promotion *api.FleetPromotionStatus) (bool, error) {
+	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
+	result := s.db.First(&existingRecord)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+
+	if existingRecord.Status == nil {
+		existingRecord.Status = model.MakeJSONField(api.FleetStatus{Conditions: []api.Condition{}})
+	}
+	if reflect.DeepEqual(existingRecord.Status.Data.Promotion, promotion) {
+		return false, nil
+	}
+	existingRecord.Status.Data.Promotion = promotion
+
+	result = s.db.Model(existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+		"status":           existingRecord.Status,
+		"resource_version": gorm.Expr("resource_version + 1"),
+	})
+	err := flterrors.ErrorFromGormError(result.Error)
+	if err != nil {
+		return strings.Contains(err.Error(), "deadlock"), err
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+// UpdatePromotion replaces the promotion progress in the status of the fleet.
+func (s *FleetStore) UpdatePromotion(ctx context.Context, orgId uuid.UUID, name string, promotion *api.FleetPromotionStatus) error {
+	return retryUpdate(func() (bool, error) {
+		return s.updatePromotion(orgId, name, promotion)
+	})
+}
+
 func (s *FleetStore) updateAnnotations(orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) (bool, error) {
 	existingRecord := model.Fleet{Resource: model.Resource{OrgID: orgId, Name: name}}
 	result := s.db.First(&existingRecord)
@@ -528,3 +656,46 @@ func (s *FleetStore) GetRepositoryRefs(ctx context.Context, orgId uuid.UUID, nam
 	}
 	return &repositories, nil
 }
+
+// MigrateStorageVersion rewrites the fleets whose stored representation differs
+// from the one produced by the current conversion, so that fleets written by
+// earlier releases are stored in the current storage version. The rewrite does
+// not change the meaning of a fleet, so its generation is kept. It returns the
+// number of rewritten fleets.
+func (s *FleetStore) MigrateStorageVersion(ctx context.Context) (int, error) {
+	fleets, err := s.ListIgnoreOrg()
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for i := range fleets {
+		fleet := fleets[i].ToApiResource()
+		converted, err := v1beta1.ConvertFleetFromV1alpha1(&fleet)
+		if err != nil {
+			s.log.Warnf("skipping storage version migration of fleet %s/%s: %v", fleets[i].OrgID, fleets[i].Name, err)
+			continue
+		}
+		stored, err := v1beta1.ConvertFleetToV1alpha1(converted)
+		if err != nil {
+			return migrated, err
+		}
+		if reflect.DeepEqual(stored.Spec, fleet.Spec) && reflect.DeepEqual(stored.Metadata.Annotations, fleet.Metadata.Annotations) {
+			continue
+		}
+
+		result := s.db.Model(&model.Fleet{}).Where("org_id = ? and name = ? and resource_version = ?", fleets[i].OrgID, fleets[i].Name, lo.FromPtr(fleets[i].ResourceVersion)).Updates(map[string]interface{}{
+			"spec":             model.MakeJSONField(stored.Spec),
+			"annotations":      pq.StringArray(util.LabelMapToArray(stored.Metadata.Annotations)),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+		if result.Error != nil {
+			return migrated, flterrors.ErrorFromGormError(result.Error)
+		}
+		// a fleet updated in the meantime was written in the current storage version
+		if result.RowsAffected > 0 {
+			migrated++
+		}
+	}
+	return migrated, nil
+}
@@ -14,14 +14,35 @@ import (
 )
 
 func InitDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
+	return openDB(cfg, cfg.Database.Hostname, cfg.Database.Port, log)
+}
+
+// InitReadReplicas connects to the read replicas of the database, if any.
+func InitReadReplicas(cfg *config.Config, log *logrus.Logger) ([]*gorm.DB, error) {
+	replicas := []*gorm.DB{}
+	for _, replica := range cfg.Database.ReadReplicas {
+		port := replica.Port
+		if port == 0 {
+			port = cfg.Database.Port
+		}
+		db, err := openDB(cfg, replica.Hostname, port, log)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to read replica %s: %w", replica.Hostname, err)
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas, nil
+}
+
+func openDB(cfg *config.Config, hostname string, port uint, log *logrus.Logger) (*gorm.DB, error) {
 	var dia gorm.Dialector
 
 	if cfg.Database.Type == "pgsql" {
 		dsn := fmt.Sprintf("host=%s user=%s password=%s port=%d",
-			cfg.Database.Hostname,
+			hostname,
 			cfg.Database.User,
 			cfg.Database.Password,
-			cfg.Database.Port,
+			port,
 		)
 		if cfg.Database.Name != "" {
 			dsn = fmt.Sprintf("%s dbname=%s", dsn, cfg.Database.Name)
@@ -53,8 +74,10 @@ func InitDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
 		klog.Fatalf("failed to configure connections: %v", err)
 		return nil, err
 	}
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
+	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConnections())
+	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConnections())
+	sqlDB.SetConnMaxLifetime(cfg.Database.ConnectionMaxLifetime())
+	sqlDB.SetConnMaxIdleTime(cfg.Database.ConnectionMaxIdleTime())
 
 	if cfg.Database.Type == "pgsql" {
 		var minorVersion string
@@ -63,7 +86,7 @@ func InitDB(cfg *config.Config, log *logrus.Logger) (*gorm.DB, error) {
 			return nil, result.Error
 		}
 
-		klog.Infof("PostgreSQL information: '%s'", minorVersion)
+		klog.Infof("PostgreSQL information at %s: '%s'", hostname, minorVersion)
 	}
 
 	return newDB, nil
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKey interface {
+	Reserve(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey, staleBefore time.Time) (*model.IdempotencyKey, error)
+	Complete(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey) error
+	Release(ctx context.Context, orgId uuid.UUID, scope string, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	InitialMigration() error
+}
+
+type IdempotencyKeyStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to IdempotencyKey interface
+var _ IdempotencyKey = (*IdempotencyKeyStore)(nil)
+
+func NewIdempotencyKey(db *gorm.DB, log logrus.FieldLogger) IdempotencyKey {
+	return &IdempotencyKeyStore{db: db, log: log}
+}
+
+func (s *IdempotencyKeyStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.IdempotencyKey{})
+}
+
+// Reserve records the key of a request before it is served. It returns nil
+// once the key is reserved, or the record of the key if it is already
+// reserved: by a request in progress, or with its response once it
+// completed. A key which expired, or whose request has been in progress since
+// before staleBefore and so was abandoned, is reserved again.
+func (s *IdempotencyKeyStore) Reserve(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey, staleBefore time.Time) (*model.IdempotencyKey, error) {
+	record.OrgID = orgId
+	var existing *model.IdempotencyKey
+	err := s.db.WithContext(ctx).Transaction(func(innerTx *gorm.DB) error {
+		result := innerTx.Where("org_id = ? AND scope = ? AND key = ?", orgId, record.Scope, record.Key).
+			Where("expires_at < ? OR (status_code = 0 AND created_at < ?)", time.Now(), staleBefore).
+			Delete(&model.IdempotencyKey{})
+		if result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+		result = innerTx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		existing = &model.IdempotencyKey{OrgID: orgId, Scope: record.Scope, Key: record.Key}
+		return flterrors.ErrorFromGormError(innerTx.First(existing).Error)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// Complete records the response of the request of a reserved key.
+func (s *IdempotencyKeyStore) Complete(ctx context.Context, orgId uuid.UUID, record *model.IdempotencyKey) error {
+	result := s.db.WithContext(ctx).Model(&model.IdempotencyKey{}).
+		Where("org_id = ? AND scope = ? AND key = ? AND fingerprint = ?", orgId, record.Scope, record.Key, record.Fingerprint).
+		Updates(map[string]any{
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         record.Body,
+		})
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// Release deletes a reserved key, so that the request can be retried with it.
+func (s *IdempotencyKeyStore) Release(ctx context.Context, orgId uuid.UUID, scope string, key string) error {
+	result := s.db.WithContext(ctx).Where("org_id = ? AND scope = ? AND key = ?", orgId, scope, key).Delete(&model.IdempotencyKey{})
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+// DeleteExpired deletes the keys of all organizations which expired, and
+// returns their number.
+func (s *IdempotencyKeyStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&model.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, flterrors.ErrorFromGormError(result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ImageSbom interface {
+	Record(ctx context.Context, orgId uuid.UUID, sbom *api.ImageSbom) error
+	List(ctx context.Context, orgId uuid.UUID, listParams ImageSbomListParams) (*api.ImageSbomList, error)
+	InitialMigration() error
+}
+
+// ImageSbomListParams restricts the listed SBOMs.
+type ImageSbomListParams struct {
+	// Digests, if not nil, only lists the SBOMs of the images with these digests
+	Digests []string
+	// Component only lists the SBOMs containing a component of this name or
+	// package URL, with or without version, and only the matching components
+	Component *string
+	// Version only lists the components of this version, together with Component
+	Version *string
+	// Vulnerability only lists the SBOMs reporting this vulnerability, and
+	// only the matching vulnerability
+	Vulnerability *string
+}
+
+type ImageSbomStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to ImageSbom interface
+var _ ImageSbom = (*ImageSbomStore)(nil)
+
+func NewImageSbom(db *gorm.DB, log logrus.FieldLogger) ImageSbom {
+	return &ImageSbomStore{db: db, log: log}
+}
+
+func (s *ImageSbomStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.ImageSbom{})
+}
+
+// Record stores the SBOM of an image, replacing the one collected before.
+func (s *ImageSbomStore) Record(ctx context.Context, orgId uuid.UUID, sbom *api.ImageSbom) error {
+	record := model.NewImageSbomFromApiResource(sbom)
+	record.OrgID = orgId
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "org_id"}, {Name: "digest"}},
+		DoUpdates: clause.AssignmentColumns([]string{"image", "format", "components", "vulnerabilities", "collected_at"}),
+	}).Create(record)
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *ImageSbomStore) List(ctx context.Context, orgId uuid.UUID, listParams ImageSbomListParams) (*api.ImageSbomList, error) {
+	var sboms model.ImageSbomList
+	query := s.db.WithContext(ctx).Where("org_id = ?", orgId)
+	if listParams.Digests != nil {
+		query = query.Where("digest IN ?", listParams.Digests)
+	}
+	if listParams.Component != nil {
+		queryStr, args := createComponentQuery(*listParams.Component, listParams.Version)
+		query = query.Where(queryStr, args...)
+	}
+	if listParams.Vulnerability != nil {
+		query = query.Where("? = ANY(vulnerabilities)", *listParams.Vulnerability)
+	}
+	if result := query.Order("digest").Find(&sboms); result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+
+	apiList := sboms.ToApiResource()
+	for i := range apiList.Items {
+		sbom := &apiList.Items[i]
+		if listParams.Component != nil {
+			components := lo.Filter(lo.FromPtr(sbom.Components), func(component api.SbomComponent, _ int) bool {
+				return ComponentMatches(component, *listParams.Component, listParams.Version)
+			})
+			sbom.Components = &components
+		}
+		if listParams.Vulnerability != nil {
+			sbom.Vulnerabilities = &[]string{*listParams.Vulnerability}
+		}
+	}
+	return &apiList, nil
+}
+
+// createComponentQuery selects the SBOMs containing a component matching
+// ComponentMatches.
+func createComponentQuery(component string, version *string) (string, []interface{}) {
+	query := "EXISTS (SELECT 1 FROM jsonb_array_elements(components) AS c WHERE (c ->> 'name' = ? OR c ->> 'purl' = ? OR split_part(c ->> 'purl', '@', 1) = ?)"
+	args := []interface{}{component, component, component}
+	if version != nil {
+		query += " AND c ->> 'version' = ?"
+		args = append(args, *version)
+	}
+	return query + ")", args
+}
+
+// ComponentMatches returns whether the component has the given name or package
+// URL, with or without version, and the version if not nil.
+func ComponentMatches(component api.SbomComponent, nameOrPurl string, version *string) bool {
+	purl := lo.FromPtr(component.Purl)
+	purlWithoutVersion, _, _ := strings.Cut(purl, "@")
+	if component.Name != nameOrPurl && purl != nameOrPurl && purlWithoutVersion != nameOrPurl {
+		return false
+	}
+	return version == nil || lo.FromPtr(component.Version) == *version
+}
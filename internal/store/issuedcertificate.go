@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type IssuedCertificate interface {
+	Record(ctx context.Context, orgId uuid.UUID, certificate *api.IssuedCertificate) error
+	List(ctx context.Context, orgId uuid.UUID, listParams IssuedCertificateListParams) (*api.IssuedCertificateList, error)
+	DeleteForDevice(ctx context.Context, orgId uuid.UUID, device string) error
+	DeleteForAllDevices(ctx context.Context, orgId uuid.UUID) error
+	InitialMigration() error
+}
+
+// IssuedCertificateListParams restricts the listed certificates.
+type IssuedCertificateListParams struct {
+	// Device only lists the certificates issued to the device
+	Device *string
+	// ExpiresBefore only lists the certificates which expire before this time
+	ExpiresBefore *time.Time
+}
+
+type IssuedCertificateStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to IssuedCertificate interface
+var _ IssuedCertificate = (*IssuedCertificateStore)(nil)
+
+func NewIssuedCertificate(db *gorm.DB, log logrus.FieldLogger) IssuedCertificate {
+	return &IssuedCertificateStore{db: db, log: log}
+}
+
+func (s *IssuedCertificateStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.IssuedCertificate{})
+}
+
+// Record adds a certificate to the inventory. A management certificate
+// supersedes the previous management certificates of its device, which the
+// device no longer uses once it re-enrolled.
+func (s *IssuedCertificateStore) Record(ctx context.Context, orgId uuid.UUID, certificate *api.IssuedCertificate) error {
+	record := model.NewIssuedCertificateFromApiResource(certificate)
+	record.OrgID = orgId
+	return s.db.WithContext(ctx).Transaction(func(innerTx *gorm.DB) error {
+		if certificate.Usage == api.IssuedCertificateUsageManagement && certificate.Device != nil {
+			result := innerTx.Where("org_id = ? AND device = ? AND usage = ?", orgId, *certificate.Device, string(api.IssuedCertificateUsageManagement)).
+				Delete(&model.IssuedCertificate{})
+			if result.Error != nil {
+				return flterrors.ErrorFromGormError(result.Error)
+			}
+		}
+		if result := innerTx.Create(record); result.Error != nil {
+			return flterrors.ErrorFromGormError(result.Error)
+		}
+		return nil
+	})
+}
+
+func (s *IssuedCertificateStore) List(ctx context.Context, orgId uuid.UUID, listParams IssuedCertificateListParams) (*api.IssuedCertificateList, error) {
+	var certificates model.IssuedCertificateList
+	query := s.db.WithContext(ctx).Where("org_id = ?", orgId)
+	if listParams.Device != nil {
+		query = query.Where("device = ?", *listParams.Device)
+	}
+	if listParams.ExpiresBefore != nil {
+		query = query.Where("not_after < ?", *listParams.ExpiresBefore)
+	}
+	if result := query.Order("not_after, serial_number").Find(&certificates); result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiList := certificates.ToApiResource()
+	return &apiList, nil
+}
+
+func (s *IssuedCertificateStore) DeleteForDevice(ctx context.Context, orgId uuid.UUID, device string) error {
+	result := s.db.WithContext(ctx).Where("org_id = ? AND device = ?", orgId, device).Delete(&model.IssuedCertificate{})
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *IssuedCertificateStore) DeleteForAllDevices(ctx context.Context, orgId uuid.UUID) error {
+	result := s.db.WithContext(ctx).Where("org_id = ? AND device IS NOT NULL", orgId).Delete(&model.IssuedCertificate{})
+	return flterrors.ErrorFromGormError(result.Error)
+}
@@ -0,0 +1,222 @@
+package store
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LabelRule interface {
+	Create(ctx context.Context, orgId uuid.UUID, req *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, error)
+	Update(ctx context.Context, orgId uuid.UUID, req *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.LabelRuleList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.LabelRule, error)
+	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, labelRule *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, bool, error)
+	DeleteAll(ctx context.Context, orgId uuid.UUID, callback LabelRuleStoreCallback) error
+	Delete(ctx context.Context, orgId uuid.UUID, name string, callback LabelRuleStoreCallback) error
+	InitialMigration() error
+}
+
+type LabelRuleStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// LabelRuleStoreCallback is called with the organization whose label rules changed
+type LabelRuleStoreCallback func(orgId uuid.UUID)
+
+// Make sure we conform to LabelRule interface
+var _ LabelRule = (*LabelRuleStore)(nil)
+
+func NewLabelRule(db *gorm.DB, log logrus.FieldLogger) LabelRule {
+	return &LabelRuleStore{db: db, log: log}
+}
+
+func (s *LabelRuleStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.LabelRule{})
+}
+
+func (s *LabelRuleStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, error) {
+	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly, callback)
+	return updatedResource, err
+}
+
+func (s *LabelRuleStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, error) {
+	updatedResource, _, err := retryCreateOrUpdate(func() (*api.LabelRule, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeUpdateOnly, callback)
+	})
+	return updatedResource, err
+}
+
+func (s *LabelRuleStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.LabelRuleList, error) {
+	var labelRules model.LabelRuleList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&labelRules), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&labelRules)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(labelRules) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    labelRules[len(labelRules)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		labelRules = labelRules[:len(labelRules)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&labelRules), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiLabelRuleList := labelRules.ToApiResource(nextContinue, numRemaining)
+	return &apiLabelRuleList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *LabelRuleStore) DeleteAll(ctx context.Context, orgId uuid.UUID, callback LabelRuleStoreCallback) error {
+	condition := model.LabelRule{}
+	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	callback(orgId)
+	return nil
+}
+
+func (s *LabelRuleStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.LabelRule, error) {
+	labelRule := model.LabelRule{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&labelRule)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiLabelRule := labelRule.ToApiResource()
+	return &apiLabelRule, nil
+}
+
+func (s *LabelRuleStore) createLabelRule(labelRule *model.LabelRule) (bool, error) {
+	labelRule.Generation = lo.ToPtr[int64](1)
+	labelRule.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(labelRule); result.Error != nil {
+		err := flterrors.ErrorFromGormError(result.Error)
+		return err == flterrors.ErrDuplicateName, err
+	}
+	return false, nil
+}
+
+func (s *LabelRuleStore) updateLabelRule(existingRecord, labelRule *model.LabelRule) (bool, error) {
+	updateSpec := labelRule.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, labelRule.Spec)
+
+	// Update the generation if the spec was updated
+	if updateSpec {
+		labelRule.Generation = lo.ToPtr(lo.FromPtr(existingRecord.Generation) + 1)
+	}
+	if labelRule.ResourceVersion != nil && lo.FromPtr(existingRecord.ResourceVersion) != lo.FromPtr(labelRule.ResourceVersion) {
+		return false, flterrors.ErrResourceVersionConflict
+	}
+	labelRule.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+	where := model.LabelRule{Resource: model.Resource{OrgID: labelRule.OrgID, Name: labelRule.Name}}
+	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+
+	result := query.Updates(&labelRule)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+func (s *LabelRuleStore) createOrUpdate(orgId uuid.UUID, resource *api.LabelRule, mode CreateOrUpdateMode, callback LabelRuleStoreCallback) (*api.LabelRule, bool, bool, error) {
+	if resource == nil {
+		return nil, false, false, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, false, false, flterrors.ErrResourceNameIsNil
+	}
+
+	labelRule, err := model.NewLabelRuleFromApiResource(resource)
+	if err != nil {
+		return nil, false, false, err
+	}
+	labelRule.OrgID = orgId
+
+	existingRecord, err := getExistingRecord[model.LabelRule](s.db, labelRule.Name, orgId)
+	if err != nil {
+		return nil, false, false, err
+	}
+	exists := existingRecord != nil
+
+	if exists && mode == ModeCreateOnly {
+		return nil, false, false, flterrors.ErrDuplicateName
+	}
+	if !exists && mode == ModeUpdateOnly {
+		return nil, false, false, flterrors.ErrResourceNotFound
+	}
+
+	if !exists {
+		if retry, err := s.createLabelRule(labelRule); err != nil {
+			return nil, false, retry, err
+		}
+	} else {
+		if retry, err := s.updateLabelRule(existingRecord, labelRule); err != nil {
+			return nil, false, retry, err
+		}
+	}
+
+	callback(orgId)
+
+	updatedResource := labelRule.ToApiResource()
+	return &updatedResource, !exists, false, nil
+}
+
+func (s *LabelRuleStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.LabelRule, callback LabelRuleStoreCallback) (*api.LabelRule, bool, error) {
+	return retryCreateOrUpdate(func() (*api.LabelRule, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate, callback)
+	})
+}
+
+func (s *LabelRuleStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback LabelRuleStoreCallback) error {
+	condition := model.LabelRule{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		callback(orgId)
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package model
+
+import "strings"
+
+// ConsoleAnnotation returns the value of the console annotation of a session.
+// The public key of the operator of an end-to-end encrypted session follows
+// its ID.
+func ConsoleAnnotation(sessionID string, publicKey string) string {
+	if publicKey == "" {
+		return sessionID
+	}
+	return sessionID + ":" + publicKey
+}
+
+// ParseConsoleAnnotation returns the session ID and the public key of the
+// operator, if any, of the value of the console annotation.
+func ParseConsoleAnnotation(value string) (string, string) {
+	sessionID, publicKey, _ := strings.Cut(value, ":")
+	return sessionID, publicKey
+}
@@ -7,6 +7,7 @@ import (
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/util"
+	"github.com/lib/pq"
 	"github.com/samber/lo"
 )
 
@@ -16,8 +17,20 @@ var (
 	DeviceListKind = "DeviceList"
 
 	DeviceAnnotationTemplateVersion = "fleet-controller/templateVersion"
+	DeviceAnnotationRolloutBatch    = "fleet-controller/rolloutBatch"
 	DeviceAnnotationRenderedVersion = "device-controller/renderedVersion"
 	DeviceAnnotationConsole         = "device-controller/console"
+	DeviceAnnotationRuleLabels      = "label-controller/labels"
+	DeviceAnnotationManagedCluster  = "acm-controller/managedCluster"
+	DeviceAnnotationQuarantine      = "device-controller/quarantine"
+	DeviceAnnotationAction          = "device-controller/action"
+	DeviceAnnotationImageDigests    = "device-controller/imageDigests"
+	DeviceAnnotationMigration       = "device-controller/migration"
+	DeviceAnnotationConsoleGrant    = "device-controller/consoleGrant"
+	DeviceAnnotationReplaces        = "device-controller/replaces"
+	DeviceAnnotationReplacedBy      = "device-controller/replacedBy"
+	DeviceAnnotationDuplicateOf     = "device-controller/duplicateOf"
+	DeviceAnnotationTunnel          = "device-controller/tunnel"
 )
 
 type Device struct {
@@ -35,6 +48,12 @@ type Device struct {
 	// The rendered ignition config, exposed in a separate endpoint.
 	RenderedConfig *string
 
+	// A human-friendly name of the device, as opposed to its name which is derived from its identity.
+	DisplayName *string `gorm:"index"`
+
+	// Further names the device can be found by, unique within the organization.
+	Aliases pq.StringArray `gorm:"type:text[]"`
+
 	// Join table with the relationship of devices to repositories (only maintained for standalone devices)
 	Repositories []Repository `gorm:"many2many:device_repos;constraint:OnDelete:CASCADE;"`
 }
@@ -113,6 +132,10 @@ func (d *Device) ToApiResource() api.Device {
 			status.Conditions = []api.Condition{}
 		}
 		status.Conditions = append(status.Conditions, *d.ServiceConditions.Data.Conditions...)
+		// the service renders the spec, so the generation it last rendered is the generation it observed
+		if condition := api.FindStatusCondition(*d.ServiceConditions.Data.Conditions, api.DeviceSpecValid); condition != nil {
+			status.ObservedGeneration = condition.ObservedGeneration
+		}
 	}
 
 	metadataLabels := util.LabelArrayToMap(d.Resource.Labels)
@@ -122,6 +145,10 @@ func (d *Device) ToApiResource() api.Device {
 	if d.ResourceVersion != nil {
 		resourceVersion = lo.ToPtr(strconv.FormatInt(*d.ResourceVersion, 10))
 	}
+	var aliases *[]string
+	if len(d.Aliases) > 0 {
+		aliases = lo.ToPtr([]string(d.Aliases))
+	}
 	return api.Device{
 		ApiVersion: DeviceAPI,
 		Kind:       DeviceKind,
@@ -133,6 +160,8 @@ func (d *Device) ToApiResource() api.Device {
 			Owner:             d.Owner,
 			Annotations:       &metadataAnnotations,
 			ResourceVersion:   resourceVersion,
+			DisplayName:       d.DisplayName,
+			Aliases:           aliases,
 		},
 		Spec:   &spec,
 		Status: &status,
@@ -151,12 +180,17 @@ func (dl DeviceList) ToApiResource(cont *string, numRemaining *int64) api.Device
 	deviceList := make([]api.Device, len(dl))
 	summaryStatuses := make(map[string]int)
 	updateStatuses := make(map[string]int)
+	complianceStatuses := make(map[string]int)
 	for i, device := range dl {
 		deviceList[i] = device.ToApiResource()
 		summaryStatus := string(deviceList[i].Status.Summary.Status)
 		summaryStatuses[summaryStatus] = summaryStatuses[summaryStatus] + 1
 		updateStatus := string(deviceList[i].Status.Updated.Status)
 		updateStatuses[updateStatus] = updateStatuses[updateStatus] + 1
+		if deviceList[i].Status.Compliance != nil {
+			complianceStatus := string(deviceList[i].Status.Compliance.Status)
+			complianceStatuses[complianceStatus] = complianceStatuses[complianceStatus] + 1
+		}
 	}
 	ret := api.DeviceList{
 		ApiVersion: DeviceAPI,
@@ -164,11 +198,14 @@ func (dl DeviceList) ToApiResource(cont *string, numRemaining *int64) api.Device
 		Items:      deviceList,
 		Metadata:   api.ListMeta{},
 		Summary: &api.DevicesSummary{
-			SummaryStatus: &summaryStatuses,
+			SummaryStatus:    &summaryStatuses,
 			UpdateStatus:  &updateStatuses,
 			Total:         len(dl),
 		},
 	}
+	if len(complianceStatuses) > 0 {
+		ret.Summary.ComplianceStatus = &complianceStatuses
+	}
 	if cont != nil {
 		ret.Metadata.Continue = cont
 		ret.Metadata.RemainingItemCount = numRemaining
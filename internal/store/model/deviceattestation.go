@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeviceAttestation holds the state of the periodic attestation of a device.
+// Like IssuedCertificate it is not a resource, but internal to the service.
+type DeviceAttestation struct {
+	OrgID  uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Device string    `gorm:"primary_key;"`
+
+	// The public area (TPMT_PUBLIC) of the attestation key the device used in
+	// its first attestation, which all later quotes must be signed with.
+	AttestationKey []byte
+
+	// The nonce of the outstanding challenge, if any.
+	Nonce          []byte
+	NonceExpiresAt *time.Time
+
+	// The reference PCR values, keyed by the image they were measured with.
+	References *JSONField[map[string]map[string]string] `gorm:"type:jsonb"`
+
+	// The image booted when the device last attested.
+	BootedImage    string
+	LastAttestedAt *time.Time
+
+	UpdatedAt time.Time
+}
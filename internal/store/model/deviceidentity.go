@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	DeviceIdentityAPI      = "v1alpha1"
+	DeviceIdentityKind     = "DeviceIdentity"
+	DeviceIdentityListKind = "DeviceIdentityList"
+)
+
+type DeviceIdentity struct {
+	Resource
+
+	// The desired state, stored as a JSON object that is queried for the hardware identity.
+	Spec *JSONField[api.DeviceIdentitySpec] `gorm:"type:jsonb"`
+
+	// The last reported state, stored as opaque JSON object.
+	Status *JSONField[api.DeviceIdentityStatus]
+
+	// The SHA-256 hash of the secret of the outstanding provisioning
+	// challenge, which the machine recovers with its TPM. Like the nonces of
+	// attestations, it is internal to the service.
+	ProvisioningChallenge          []byte
+	ProvisioningChallengeExpiresAt *time.Time
+}
+
+type DeviceIdentityList []DeviceIdentity
+
+func (r DeviceIdentity) String() string {
+	val, _ := json.Marshal(r)
+	return string(val)
+}
+
+func NewDeviceIdentityFromApiResource(resource *api.DeviceIdentity) (*DeviceIdentity, error) {
+	if resource == nil || resource.Metadata.Name == nil {
+		return &DeviceIdentity{}, nil
+	}
+
+	var resourceVersion *int64
+	if resource.Metadata.ResourceVersion != nil {
+		i, err := strconv.ParseInt(lo.FromPtr(resource.Metadata.ResourceVersion), 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		resourceVersion = &i
+	}
+	var status *JSONField[api.DeviceIdentityStatus]
+	if resource.Status != nil {
+		status = MakeJSONField(*resource.Status)
+	}
+	return &DeviceIdentity{
+		Resource: Resource{
+			Name:            *resource.Metadata.Name,
+			Labels:          util.LabelMapToArray(resource.Metadata.Labels),
+			ResourceVersion: resourceVersion,
+		},
+		Spec:   MakeJSONField(resource.Spec),
+		Status: status,
+	}, nil
+}
+
+func (r *DeviceIdentity) ToApiResource() api.DeviceIdentity {
+	if r == nil {
+		return api.DeviceIdentity{}
+	}
+
+	metadataLabels := util.LabelArrayToMap(r.Resource.Labels)
+
+	deviceIdentity := api.DeviceIdentity{
+		ApiVersion: DeviceIdentityAPI,
+		Kind:       DeviceIdentityKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(r.Name),
+			CreationTimestamp: util.TimeToPtr(r.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(r.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(r.ResourceVersion), 10)), nil),
+		},
+		Spec: r.Spec.Data,
+	}
+	if r.Status != nil {
+		deviceIdentity.Status = lo.ToPtr(r.Status.Data)
+	}
+	return deviceIdentity
+}
+
+func (rl DeviceIdentityList) ToApiResource(cont *string, numRemaining *int64) api.DeviceIdentityList {
+	if rl == nil {
+		return api.DeviceIdentityList{
+			ApiVersion: DeviceIdentityAPI,
+			Kind:       DeviceIdentityListKind,
+			Items:      []api.DeviceIdentity{},
+		}
+	}
+
+	identityList := make([]api.DeviceIdentity, len(rl))
+	for i, identity := range rl {
+		identityList[i] = identity.ToApiResource()
+	}
+	ret := api.DeviceIdentityList{
+		ApiVersion: DeviceIdentityAPI,
+		Kind:       DeviceIdentityListKind,
+		Items:      identityList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
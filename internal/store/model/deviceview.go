@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	DeviceViewAPI      = "v1alpha1"
+	DeviceViewKind     = "DeviceView"
+	DeviceViewListKind = "DeviceViewList"
+)
+
+type DeviceView struct {
+	Resource
+
+	// The desired state, stored as opaque JSON object.
+	Spec *JSONField[api.DeviceViewSpec]
+}
+
+type DeviceViewList []DeviceView
+
+func (r DeviceView) String() string {
+	val, _ := json.Marshal(r)
+	return string(val)
+}
+
+func NewDeviceViewFromApiResource(resource *api.DeviceView) (*DeviceView, error) {
+	if resource == nil || resource.Metadata.Name == nil {
+		return &DeviceView{}, nil
+	}
+
+	var resourceVersion *int64
+	if resource.Metadata.ResourceVersion != nil {
+		i, err := strconv.ParseInt(lo.FromPtr(resource.Metadata.ResourceVersion), 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		resourceVersion = &i
+	}
+	return &DeviceView{
+		Resource: Resource{
+			Name:            *resource.Metadata.Name,
+			Labels:          util.LabelMapToArray(resource.Metadata.Labels),
+			ResourceVersion: resourceVersion,
+		},
+		Spec: MakeJSONField(resource.Spec),
+	}, nil
+}
+
+func (r *DeviceView) ToApiResource() api.DeviceView {
+	if r == nil {
+		return api.DeviceView{}
+	}
+
+	metadataLabels := util.LabelArrayToMap(r.Resource.Labels)
+
+	return api.DeviceView{
+		ApiVersion: DeviceViewAPI,
+		Kind:       DeviceViewKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(r.Name),
+			CreationTimestamp: util.TimeToPtr(r.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(r.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(r.ResourceVersion), 10)), nil),
+		},
+		Spec: r.Spec.Data,
+	}
+}
+
+func (rl DeviceViewList) ToApiResource(cont *string, numRemaining *int64) api.DeviceViewList {
+	if rl == nil {
+		return api.DeviceViewList{
+			ApiVersion: DeviceViewAPI,
+			Kind:       DeviceViewListKind,
+			Items:      []api.DeviceView{},
+		}
+	}
+
+	deviceViewList := make([]api.DeviceView, len(rl))
+	for i, deviceView := range rl {
+		deviceViewList[i] = deviceView.ToApiResource()
+	}
+	ret := api.DeviceViewList{
+		ApiVersion: DeviceViewAPI,
+		Kind:       DeviceViewListKind,
+		Items:      deviceViewList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
@@ -14,6 +14,10 @@ var (
 	EnrollmentRequestAPI      = "v1alpha1"
 	EnrollmentRequestKind     = "EnrollmentRequest"
 	EnrollmentRequestListKind = "EnrollmentRequestList"
+
+	// EnrollmentRequestLabelProvisioningToken labels the enrollment requests
+	// created with a provisioning token with the ID of the token
+	EnrollmentRequestLabelProvisioningToken = "flightctl.io/provisioning-token"
 )
 
 type EnrollmentRequest struct {
@@ -16,8 +16,21 @@ var (
 	FleetListKind = "FleetList"
 
 	FleetAnnotationTemplateVersion = "fleet-controller/templateVersion"
+	FleetAnnotationLastReportTime  = "fleet-controller/lastReportTime"
+	FleetAnnotationRolloutPaused   = "fleet-controller/rolloutPaused"
+	FleetAnnotationRolloutAborted  = "fleet-controller/rolloutAborted"
+	// FleetAnnotationProvisioningTokens holds the provisioning tokens of the
+	// fleet as JSON, with the hashes of their secrets
+	FleetAnnotationProvisioningTokens = "fleet-controller/provisioningTokens"
 )
 
+// FleetRolloutAbort is the value of the FleetAnnotationRolloutAborted
+// annotation, recording the rollout which was aborted.
+type FleetRolloutAbort struct {
+	TemplateVersion string `json:"templateVersion"`
+	Revert          bool   `json:"revert,omitempty"`
+}
+
 type Fleet struct {
 	Resource
 
@@ -96,6 +109,9 @@ func (f *Fleet) ToApiResource(opts ...APIResourceOption) api.Fleet {
 		status = f.Status.Data
 	}
 	status.DevicesSummary = options.summary
+	if condition := api.FindStatusCondition(status.Conditions, api.FleetValid); condition != nil {
+		status.ObservedGeneration = condition.ObservedGeneration
+	}
 
 	metadataLabels := util.LabelArrayToMap(f.Resource.Labels)
 	metadataAnnotations := util.LabelArrayToMap(f.Resource.Annotations)
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetObservedGeneration(t *testing.T) {
+	require := require.New(t)
+	fleet := &Fleet{
+		Resource: Resource{Name: "fleet", Generation: util.Int64ToPtr(3)},
+		Spec:     MakeJSONField(api.FleetSpec{}),
+		Status:   MakeJSONField(api.FleetStatus{Conditions: []api.Condition{}}),
+	}
+
+	// not validated yet
+	require.Nil(fleet.ToApiResource().Status.ObservedGeneration)
+
+	fleet.Status.Data.Conditions = []api.Condition{{Type: api.FleetValid, Status: api.ConditionStatusTrue, ObservedGeneration: util.Int64ToPtr(2)}}
+	require.Equal(int64(2), *fleet.ToApiResource().Status.ObservedGeneration)
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey records a request sent with an Idempotency-Key header and,
+// once it completed, its response, which is replayed to the retries of the
+// request. Like IssuedCertificate it is not a resource, but internal to the
+// service.
+type IdempotencyKey struct {
+	OrgID uuid.UUID `gorm:"type:uuid;primary_key;"`
+
+	// A hash of the credentials of the client, so that the responses are only
+	// replayed to the client which sent the request.
+	Scope string `gorm:"primary_key;"`
+	Key   string `gorm:"primary_key;"`
+
+	// A hash of the method, URL and body of the request.
+	Fingerprint string
+
+	// The response, once the request completed: StatusCode is 0 while the
+	// request is in progress.
+	StatusCode  int
+	ContentType string
+	Body        []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time `gorm:"index"`
+}
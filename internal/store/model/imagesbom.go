@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/samber/lo"
+)
+
+var (
+	ImageSbomAPI      = "v1alpha1"
+	ImageSbomListKind = "ImageSbomList"
+)
+
+// ImageSbom holds the SBOM of an image run by devices. Like IssuedCertificate
+// it is not a resource: it is collected by the service for each digest the
+// devices report.
+type ImageSbom struct {
+	OrgID  uuid.UUID `gorm:"type:uuid;primary_key;"`
+	Digest string    `gorm:"primary_key;"`
+
+	// The image as referenced by the device specs, when it was collected.
+	Image string
+
+	// The format of the SBOM, nil if the registry attaches none to the image.
+	Format *string
+
+	Components      *JSONField[[]api.SbomComponent] `gorm:"type:jsonb"`
+	Vulnerabilities pq.StringArray                  `gorm:"type:text[]"`
+
+	CollectedAt time.Time
+}
+
+type ImageSbomList []ImageSbom
+
+func NewImageSbomFromApiResource(resource *api.ImageSbom) *ImageSbom {
+	return &ImageSbom{
+		Digest:          resource.Digest,
+		Image:           resource.Image,
+		Format:          (*string)(resource.Format),
+		Components:      MakeJSONField(lo.FromPtr(resource.Components)),
+		Vulnerabilities: pq.StringArray(lo.FromPtr(resource.Vulnerabilities)),
+		CollectedAt:     resource.CollectedAt,
+	}
+}
+
+func (s *ImageSbom) ToApiResource() api.ImageSbom {
+	sbom := api.ImageSbom{
+		Digest:      s.Digest,
+		Image:       s.Image,
+		Format:      (*api.SbomFormat)(s.Format),
+		CollectedAt: s.CollectedAt.UTC(),
+	}
+	if s.Components != nil && len(s.Components.Data) > 0 {
+		sbom.Components = lo.ToPtr(s.Components.Data)
+	}
+	if len(s.Vulnerabilities) > 0 {
+		sbom.Vulnerabilities = lo.ToPtr([]string(s.Vulnerabilities))
+	}
+	return sbom
+}
+
+func (sl ImageSbomList) ToApiResource() api.ImageSbomList {
+	return api.ImageSbomList{
+		ApiVersion: ImageSbomAPI,
+		Kind:       ImageSbomListKind,
+		Items: lo.Map(sl, func(s ImageSbom, _ int) api.ImageSbom {
+			return s.ToApiResource()
+		}),
+		Metadata: api.ListMeta{},
+	}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+)
+
+var (
+	IssuedCertificateAPI      = "v1alpha1"
+	IssuedCertificateListKind = "IssuedCertificateList"
+)
+
+// IssuedCertificate records a certificate issued by the service. Unlike other
+// models it is not a resource: certificates are only ever added and removed.
+type IssuedCertificate struct {
+	OrgID uuid.UUID `gorm:"type:uuid;primary_key;"`
+
+	// The hex encoded serial number, which is unique for the CA of the service.
+	SerialNumber string `gorm:"primary_key;"`
+
+	CommonName string
+	Usage      string
+
+	// The "kind/name" of the resource the certificate was issued for.
+	Owner *string
+
+	// The name of the device the certificate was issued to, if any.
+	Device *string `gorm:"index"`
+
+	NotBefore time.Time
+	NotAfter  time.Time `gorm:"index"`
+	CreatedAt time.Time
+}
+
+type IssuedCertificateList []IssuedCertificate
+
+func NewIssuedCertificateFromApiResource(resource *api.IssuedCertificate) *IssuedCertificate {
+	return &IssuedCertificate{
+		SerialNumber: resource.SerialNumber,
+		CommonName:   resource.CommonName,
+		Usage:        string(resource.Usage),
+		Owner:        resource.Owner,
+		Device:       resource.Device,
+		NotBefore:    resource.NotBefore,
+		NotAfter:     resource.NotAfter,
+	}
+}
+
+func (c *IssuedCertificate) ToApiResource() api.IssuedCertificate {
+	return api.IssuedCertificate{
+		SerialNumber: c.SerialNumber,
+		CommonName:   c.CommonName,
+		Usage:        api.IssuedCertificateUsage(c.Usage),
+		Owner:        c.Owner,
+		Device:       c.Device,
+		NotBefore:    c.NotBefore.UTC(),
+		NotAfter:     c.NotAfter.UTC(),
+	}
+}
+
+func (cl IssuedCertificateList) ToApiResource() api.IssuedCertificateList {
+	return api.IssuedCertificateList{
+		ApiVersion: IssuedCertificateAPI,
+		Kind:       IssuedCertificateListKind,
+		Items: lo.Map(cl, func(c IssuedCertificate, _ int) api.IssuedCertificate {
+			return c.ToApiResource()
+		}),
+		Metadata: api.ListMeta{},
+	}
+}
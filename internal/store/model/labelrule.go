@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	LabelRuleAPI      = "v1alpha1"
+	LabelRuleKind     = "LabelRule"
+	LabelRuleListKind = "LabelRuleList"
+)
+
+type LabelRule struct {
+	Resource
+
+	// The desired state, stored as opaque JSON object.
+	Spec *JSONField[api.LabelRuleSpec]
+}
+
+type LabelRuleList []LabelRule
+
+func (r LabelRule) String() string {
+	val, _ := json.Marshal(r)
+	return string(val)
+}
+
+func NewLabelRuleFromApiResource(resource *api.LabelRule) (*LabelRule, error) {
+	if resource == nil || resource.Metadata.Name == nil {
+		return &LabelRule{}, nil
+	}
+
+	var resourceVersion *int64
+	if resource.Metadata.ResourceVersion != nil {
+		i, err := strconv.ParseInt(lo.FromPtr(resource.Metadata.ResourceVersion), 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		resourceVersion = &i
+	}
+	return &LabelRule{
+		Resource: Resource{
+			Name:            *resource.Metadata.Name,
+			Labels:          util.LabelMapToArray(resource.Metadata.Labels),
+			ResourceVersion: resourceVersion,
+		},
+		Spec: MakeJSONField(resource.Spec),
+	}, nil
+}
+
+func (r *LabelRule) ToApiResource() api.LabelRule {
+	if r == nil {
+		return api.LabelRule{}
+	}
+
+	metadataLabels := util.LabelArrayToMap(r.Resource.Labels)
+
+	return api.LabelRule{
+		ApiVersion: LabelRuleAPI,
+		Kind:       LabelRuleKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(r.Name),
+			CreationTimestamp: util.TimeToPtr(r.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(r.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(r.ResourceVersion), 10)), nil),
+		},
+		Spec: r.Spec.Data,
+	}
+}
+
+func (rl LabelRuleList) ToApiResource(cont *string, numRemaining *int64) api.LabelRuleList {
+	if rl == nil {
+		return api.LabelRuleList{
+			ApiVersion: LabelRuleAPI,
+			Kind:       LabelRuleListKind,
+			Items:      []api.LabelRule{},
+		}
+	}
+
+	labelRuleList := make([]api.LabelRule, len(rl))
+	for i, labelRule := range rl {
+		labelRuleList[i] = labelRule.ToApiResource()
+	}
+	ret := api.LabelRuleList{
+		ApiVersion: LabelRuleAPI,
+		Kind:       LabelRuleListKind,
+		Items:      labelRuleList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
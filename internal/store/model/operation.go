@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	OperationAPI      = "v1alpha1"
+	OperationKind     = "Operation"
+	OperationListKind = "OperationList"
+)
+
+type Operation struct {
+	Resource
+
+	// The action of the operation, stored as opaque JSON object.
+	Spec *JSONField[api.OperationSpec]
+
+	// The progress and result of the operation, stored as opaque JSON object.
+	Status *JSONField[api.OperationStatus]
+}
+
+type OperationList []Operation
+
+func (o Operation) String() string {
+	val, _ := json.Marshal(o)
+	return string(val)
+}
+
+func NewOperationFromApiResource(resource *api.Operation) *Operation {
+	if resource == nil {
+		return &Operation{}
+	}
+
+	status := api.OperationStatus{State: api.OperationStatePending}
+	if resource.Status != nil {
+		status = *resource.Status
+	}
+	return &Operation{
+		Resource: Resource{
+			Name:   lo.FromPtr(resource.Metadata.Name),
+			Labels: util.LabelMapToArray(resource.Metadata.Labels),
+		},
+		Spec:   MakeJSONField(resource.Spec),
+		Status: MakeJSONField(status),
+	}
+}
+
+func (o *Operation) ToApiResource() api.Operation {
+	if o == nil {
+		return api.Operation{}
+	}
+
+	status := api.OperationStatus{State: api.OperationStatePending}
+	if o.Status != nil {
+		status = o.Status.Data
+	}
+
+	metadataLabels := util.LabelArrayToMap(o.Resource.Labels)
+
+	return api.Operation{
+		ApiVersion: OperationAPI,
+		Kind:       OperationKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(o.Name),
+			CreationTimestamp: util.TimeToPtr(o.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(o.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(o.ResourceVersion), 10)), nil),
+		},
+		Spec:   o.Spec.Data,
+		Status: &status,
+	}
+}
+
+func (ol OperationList) ToApiResource(cont *string, numRemaining *int64) api.OperationList {
+	if ol == nil {
+		return api.OperationList{
+			ApiVersion: OperationAPI,
+			Kind:       OperationListKind,
+			Items:      []api.Operation{},
+		}
+	}
+
+	operationList := make([]api.Operation, len(ol))
+	for i, operation := range ol {
+		operationList[i] = operation.ToApiResource()
+	}
+	ret := api.OperationList{
+		ApiVersion: OperationAPI,
+		Kind:       OperationListKind,
+		Items:      operationList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
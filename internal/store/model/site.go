@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	SiteAPI      = "v1alpha1"
+	SiteKind     = "Site"
+	SiteListKind = "SiteList"
+)
+
+type Site struct {
+	Resource
+
+	// The desired state, stored as opaque JSON object.
+	Spec *JSONField[api.SiteSpec]
+}
+
+type SiteList []Site
+
+func (r Site) String() string {
+	val, _ := json.Marshal(r)
+	return string(val)
+}
+
+func NewSiteFromApiResource(resource *api.Site) (*Site, error) {
+	if resource == nil || resource.Metadata.Name == nil {
+		return &Site{}, nil
+	}
+
+	var resourceVersion *int64
+	if resource.Metadata.ResourceVersion != nil {
+		i, err := strconv.ParseInt(lo.FromPtr(resource.Metadata.ResourceVersion), 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		resourceVersion = &i
+	}
+	return &Site{
+		Resource: Resource{
+			Name:            *resource.Metadata.Name,
+			Labels:          util.LabelMapToArray(resource.Metadata.Labels),
+			ResourceVersion: resourceVersion,
+		},
+		Spec: MakeJSONField(resource.Spec),
+	}, nil
+}
+
+func (r *Site) ToApiResource() api.Site {
+	if r == nil {
+		return api.Site{}
+	}
+
+	metadataLabels := util.LabelArrayToMap(r.Resource.Labels)
+
+	return api.Site{
+		ApiVersion: SiteAPI,
+		Kind:       SiteKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(r.Name),
+			CreationTimestamp: util.TimeToPtr(r.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(r.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(r.ResourceVersion), 10)), nil),
+		},
+		Spec: r.Spec.Data,
+	}
+}
+
+func (rl SiteList) ToApiResource(cont *string, numRemaining *int64) api.SiteList {
+	if rl == nil {
+		return api.SiteList{
+			ApiVersion: SiteAPI,
+			Kind:       SiteListKind,
+			Items:      []api.Site{},
+		}
+	}
+
+	siteList := make([]api.Site, len(rl))
+	for i, site := range rl {
+		siteList[i] = site.ToApiResource()
+	}
+	ret := api.SiteList{
+		ApiVersion: SiteAPI,
+		Kind:       SiteListKind,
+		Items:      siteList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"encoding/json"
+	"strconv"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+)
+
+var (
+	WebhookAPI      = "v1alpha1"
+	WebhookKind     = "Webhook"
+	WebhookListKind = "WebhookList"
+)
+
+type Webhook struct {
+	Resource
+
+	// The desired state, stored as opaque JSON object.
+	Spec *JSONField[api.WebhookSpec]
+
+	// The last reported state, stored as opaque JSON object.
+	Status *JSONField[api.WebhookStatus]
+}
+
+type WebhookList []Webhook
+
+func (w Webhook) String() string {
+	val, _ := json.Marshal(w)
+	return string(val)
+}
+
+func NewWebhookFromApiResource(resource *api.Webhook) (*Webhook, error) {
+	if resource == nil || resource.Metadata.Name == nil {
+		return &Webhook{}, nil
+	}
+
+	status := api.WebhookStatus{Conditions: []api.Condition{}}
+	if resource.Status != nil {
+		status = *resource.Status
+	}
+	var resourceVersion *int64
+	if resource.Metadata.ResourceVersion != nil {
+		i, err := strconv.ParseInt(lo.FromPtr(resource.Metadata.ResourceVersion), 10, 64)
+		if err != nil {
+			return nil, flterrors.ErrIllegalResourceVersionFormat
+		}
+		resourceVersion = &i
+	}
+	return &Webhook{
+		Resource: Resource{
+			Name:            *resource.Metadata.Name,
+			Labels:          util.LabelMapToArray(resource.Metadata.Labels),
+			ResourceVersion: resourceVersion,
+		},
+		Spec:   MakeJSONField(resource.Spec),
+		Status: MakeJSONField(status),
+	}, nil
+}
+
+func (w *Webhook) ToApiResource() api.Webhook {
+	if w == nil {
+		return api.Webhook{}
+	}
+
+	status := api.WebhookStatus{Conditions: []api.Condition{}}
+	if w.Status != nil {
+		status = w.Status.Data
+	}
+
+	metadataLabels := util.LabelArrayToMap(w.Resource.Labels)
+
+	return api.Webhook{
+		ApiVersion: WebhookAPI,
+		Kind:       WebhookKind,
+		Metadata: api.ObjectMeta{
+			Name:              util.StrToPtr(w.Name),
+			CreationTimestamp: util.TimeToPtr(w.CreatedAt.UTC()),
+			Labels:            &metadataLabels,
+			ResourceVersion:   lo.Ternary(w.ResourceVersion != nil, lo.ToPtr(strconv.FormatInt(lo.FromPtr(w.ResourceVersion), 10)), nil),
+		},
+		Spec:   w.Spec.Data,
+		Status: &status,
+	}
+}
+
+func (wl WebhookList) ToApiResource(cont *string, numRemaining *int64) api.WebhookList {
+	if wl == nil {
+		return api.WebhookList{
+			ApiVersion: WebhookAPI,
+			Kind:       WebhookListKind,
+			Items:      []api.Webhook{},
+		}
+	}
+
+	webhookList := make([]api.Webhook, len(wl))
+	for i, webhook := range wl {
+		webhookList[i] = webhook.ToApiResource()
+	}
+	ret := api.WebhookList{
+		ApiVersion: WebhookAPI,
+		Kind:       WebhookListKind,
+		Items:      webhookList,
+		Metadata:   api.ListMeta{},
+	}
+	if cont != nil {
+		ret.Metadata.Continue = cont
+		ret.Metadata.RemainingItemCount = numRemaining
+	}
+	return ret
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Operation interface {
+	Create(ctx context.Context, orgId uuid.UUID, operation *api.Operation) (*api.Operation, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.OperationList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Operation, error)
+	Start(ctx context.Context, orgId uuid.UUID, name string, status *api.OperationStatus) error
+	UpdateStatus(ctx context.Context, orgId uuid.UUID, name string, status *api.OperationStatus) error
+	Cancel(ctx context.Context, orgId uuid.UUID, name string) (*api.Operation, error)
+	Delete(ctx context.Context, orgId uuid.UUID, name string) error
+	InitialMigration() error
+}
+
+type OperationStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Operation interface
+var _ Operation = (*OperationStore)(nil)
+
+func NewOperation(db *gorm.DB, log logrus.FieldLogger) Operation {
+	return &OperationStore{db: db, log: log}
+}
+
+func (s *OperationStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.Operation{})
+}
+
+// Create creates a pending operation, whose name is generated if it is not set.
+func (s *OperationStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.Operation) (*api.Operation, error) {
+	if resource == nil {
+		return nil, flterrors.ErrResourceIsNil
+	}
+	operation := model.NewOperationFromApiResource(resource)
+	operation.OrgID = orgId
+	operation.Status = model.MakeJSONField(api.OperationStatus{State: api.OperationStatePending})
+	operation.Generation = lo.ToPtr[int64](1)
+	operation.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(operation); result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	created := operation.ToApiResource()
+	return &created, nil
+}
+
+func (s *OperationStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.OperationList, error) {
+	var operations model.OperationList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&operations), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&operations)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(operations) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    operations[len(operations)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		operations = operations[:len(operations)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&operations), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiOperationList := operations.ToApiResource(nextContinue, numRemaining)
+	return &apiOperationList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *OperationStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Operation, error) {
+	operation := model.Operation{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&operation)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiOperation := operation.ToApiResource()
+	return &apiOperation, nil
+}
+
+// Start records the status of a pending operation which starts running. It
+// fails with ErrNoRowsUpdated if the operation is no longer pending, such as
+// when it already started or was canceled, so that it runs only once.
+func (s *OperationStore) Start(ctx context.Context, orgId uuid.UUID, name string, status *api.OperationStatus) error {
+	return s.updateStatus(orgId, name, status, "status::jsonb->>'state' = ?", api.OperationStatePending)
+}
+
+// UpdateStatus records the progress of a running operation. It fails with
+// ErrNoRowsUpdated if the operation was canceled or deleted meanwhile, so
+// that the operation stops.
+func (s *OperationStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, name string, status *api.OperationStatus) error {
+	return s.updateStatus(orgId, name, status, "status::jsonb->>'state' <> ?", api.OperationStateCanceled)
+}
+
+func (s *OperationStore) updateStatus(orgId uuid.UUID, name string, status *api.OperationStatus, stateCondition string, state api.OperationState) error {
+	operation := model.Operation{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Model(&operation).
+		Where(stateCondition, string(state)).
+		Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrNoRowsUpdated
+	}
+	return nil
+}
+
+// Cancel cancels an operation which has not finished, keeping its progress.
+// It fails with ErrOperationFinished if the operation already finished.
+func (s *OperationStore) Cancel(ctx context.Context, orgId uuid.UUID, name string) (*api.Operation, error) {
+	var canceled *api.Operation
+	err := retryUpdate(func() (bool, error) {
+		existingRecord := model.Operation{Resource: model.Resource{OrgID: orgId, Name: name}}
+		if result := s.db.First(&existingRecord); result.Error != nil {
+			return false, flterrors.ErrorFromGormError(result.Error)
+		}
+		status := existingRecord.Status.Data
+		if status.State != api.OperationStatePending && status.State != api.OperationStateRunning {
+			return false, flterrors.ErrOperationFinished
+		}
+		status.State = api.OperationStateCanceled
+		status.FinishedAt = lo.ToPtr(time.Now())
+		status.Message = lo.ToPtr("the operation was canceled")
+		result := s.db.Model(&existingRecord).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion)).Updates(map[string]interface{}{
+			"status":           model.MakeJSONField(status),
+			"resource_version": gorm.Expr("resource_version + 1"),
+		})
+		if result.Error != nil {
+			return false, flterrors.ErrorFromGormError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return true, flterrors.ErrNoRowsUpdated
+		}
+		existingRecord.Status = model.MakeJSONField(status)
+		existingRecord.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+		canceled = lo.ToPtr(existingRecord.ToApiResource())
+		return false, nil
+	})
+	return canceled, err
+}
+
+func (s *OperationStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	condition := model.Operation{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return flterrors.ErrorFromGormError(result.Error)
+}
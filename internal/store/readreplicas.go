@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+type replicaReadsCtxKey struct{}
+
+// WithReplicaReads marks the reads of the context as tolerating replication
+// lag, such as the lists and summaries served by the API: they are then sent
+// to a read replica of the database, if any, rather than to the primary.
+// Reads of the service which it acts on, such as those of the tasks, are not
+// marked, so that they see the writes which triggered them.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsCtxKey{}, true)
+}
+
+func replicaReads(ctx context.Context) bool {
+	marked, _ := ctx.Value(replicaReadsCtxKey{}).(bool)
+	return marked
+}
+
+// ReadReplicas sends the heavy reads which tolerate replication lag to the
+// read replicas of the database in turn, and all other queries to the
+// primary.
+type ReadReplicas struct {
+	primary  *gorm.DB
+	replicas []*gorm.DB
+	next     atomic.Uint64
+}
+
+func NewReadReplicas(primary *gorm.DB, replicas ...*gorm.DB) *ReadReplicas {
+	return &ReadReplicas{primary: primary, replicas: replicas}
+}
+
+// DB returns the database to read from: a read replica if the reads of the
+// context are marked with WithReplicaReads, otherwise the primary.
+func (r *ReadReplicas) DB(ctx context.Context) *gorm.DB {
+	if len(r.replicas) == 0 || !replicaReads(ctx) {
+		return r.primary
+	}
+	return r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+}
+
+func (r *ReadReplicas) close() error {
+	for _, replica := range r.replicas {
+		sqlDB, err := replica.DB()
+		if err != nil {
+			return err
+		}
+		if err := sqlDB.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReadReplicas(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	primary, replica1, replica2 := &gorm.DB{}, &gorm.DB{}, &gorm.DB{}
+
+	// without replicas every read is served by the primary
+	reads := NewReadReplicas(primary)
+	require.Same(primary, reads.DB(ctx))
+	require.Same(primary, reads.DB(WithReplicaReads(ctx)))
+
+	// the marked reads are served by the replicas in turn, the others by the primary
+	reads = NewReadReplicas(primary, replica1, replica2)
+	require.Same(primary, reads.DB(ctx))
+	require.Same(replica1, reads.DB(WithReplicaReads(ctx)))
+	require.Same(replica2, reads.DB(WithReplicaReads(ctx)))
+	require.Same(replica1, reads.DB(WithReplicaReads(ctx)))
+	require.Same(primary, reads.DB(ctx))
+}
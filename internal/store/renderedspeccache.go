@@ -0,0 +1,116 @@
+package store
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+)
+
+// renderedSpecVersion identifies what the rendered spec of a device is
+// built from: a device renders a new version whenever its spec, its
+// rendered config or its console changes, and its images depend on the
+// architecture it reported. The creation time tells apart a device deleted
+// and created again, whose rendered versions start over.
+type renderedSpecVersion struct {
+	createdAt       time.Time
+	renderedVersion string
+	architecture    string
+}
+
+func (v renderedSpecVersion) equal(other renderedSpecVersion) bool {
+	return v.createdAt.Equal(other.createdAt) && v.renderedVersion == other.renderedVersion && v.architecture == other.architecture
+}
+
+type renderedSpecKey struct {
+	orgId uuid.UUID
+	name  string
+}
+
+type renderedSpecEntry struct {
+	key     renderedSpecKey
+	version renderedSpecVersion
+	spec    *api.RenderedDeviceSpec
+}
+
+// renderedSpecCache keeps the rendered specs of the devices which last
+// fetched them, up to size devices, so that the agents polling for their
+// specs are served without loading their devices from the database. It
+// holds one version per device, which a newer version replaces. A nil cache
+// caches nothing.
+type renderedSpecCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[renderedSpecKey]*list.Element
+	// order holds the entries from the most to the least recently used
+	order *list.List
+}
+
+// newRenderedSpecCache returns a cache of the rendered specs of size
+// devices, or nil if size is not positive.
+func newRenderedSpecCache(size int) *renderedSpecCache {
+	if size <= 0 {
+		return nil
+	}
+	return &renderedSpecCache{size: size, entries: map[renderedSpecKey]*list.Element{}, order: list.New()}
+}
+
+// get returns the rendered spec of the device at the version, if it is
+// cached. The spec must not be modified.
+func (c *renderedSpecCache) get(key renderedSpecKey, version renderedSpecVersion) (*api.RenderedDeviceSpec, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*renderedSpecEntry)
+	if !entry.version.equal(version) {
+		// the device rendered another version since
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.spec, true
+}
+
+// add caches the rendered spec of the device at the version, evicting the
+// least recently used device if the cache is full. The spec must not be
+// modified afterwards.
+func (c *renderedSpecCache) add(key renderedSpecKey, version renderedSpecVersion, spec *api.RenderedDeviceSpec) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value = &renderedSpecEntry{key: key, version: version, spec: spec}
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&renderedSpecEntry{key: key, version: version, spec: spec})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*renderedSpecEntry).key)
+	}
+}
+
+// remove drops the rendered spec of the device, such as once it is deleted.
+func (c *renderedSpecCache) remove(key renderedSpecKey) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+}
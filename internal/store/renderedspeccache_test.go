@@ -0,0 +1,71 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderedSpecCache(t *testing.T) {
+	require := require.New(t)
+	createdAt := time.Now()
+	key := func(name string) renderedSpecKey { return renderedSpecKey{orgId: uuid.Nil, name: name} }
+	version := func(renderedVersion string) renderedSpecVersion {
+		return renderedSpecVersion{createdAt: createdAt, renderedVersion: renderedVersion, architecture: "amd64"}
+	}
+	spec := func(renderedVersion string) *api.RenderedDeviceSpec {
+		return &api.RenderedDeviceSpec{RenderedVersion: renderedVersion}
+	}
+
+	cache := newRenderedSpecCache(2)
+	cache.add(key("dev1"), version("1"), spec("1"))
+	cached, ok := cache.get(key("dev1"), version("1"))
+	require.True(ok)
+	require.Equal("1", cached.RenderedVersion)
+
+	// another version of the device is not served, and drops the cached one
+	_, ok = cache.get(key("dev1"), version("2"))
+	require.False(ok)
+	_, ok = cache.get(key("dev1"), version("1"))
+	require.False(ok)
+
+	// a device created again or reporting another architecture is another version
+	cache.add(key("dev1"), version("1"), spec("1"))
+	_, ok = cache.get(key("dev1"), renderedSpecVersion{createdAt: createdAt.Add(time.Second), renderedVersion: "1", architecture: "amd64"})
+	require.False(ok)
+	cache.add(key("dev1"), version("1"), spec("1"))
+	_, ok = cache.get(key("dev1"), renderedSpecVersion{createdAt: createdAt, renderedVersion: "1", architecture: "arm64"})
+	require.False(ok)
+
+	// the least recently used device is evicted
+	cache.add(key("dev1"), version("1"), spec("1"))
+	cache.add(key("dev2"), version("1"), spec("1"))
+	_, ok = cache.get(key("dev1"), version("1"))
+	require.True(ok)
+	cache.add(key("dev3"), version("1"), spec("1"))
+	_, ok = cache.get(key("dev2"), version("1"))
+	require.False(ok)
+	_, ok = cache.get(key("dev1"), version("1"))
+	require.True(ok)
+	_, ok = cache.get(key("dev3"), version("1"))
+	require.True(ok)
+
+	// a newer version replaces the cached one
+	cache.add(key("dev3"), version("2"), spec("2"))
+	cached, ok = cache.get(key("dev3"), version("2"))
+	require.True(ok)
+	require.Equal("2", cached.RenderedVersion)
+
+	cache.remove(key("dev3"))
+	_, ok = cache.get(key("dev3"), version("2"))
+	require.False(ok)
+
+	// a disabled cache caches nothing
+	disabled := newRenderedSpecCache(0)
+	disabled.add(key("dev1"), version("1"), spec("1"))
+	_, ok = disabled.get(key("dev1"), version("1"))
+	require.False(ok)
+}
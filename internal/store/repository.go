@@ -26,6 +26,7 @@ type Repository interface {
 	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, repository *api.Repository, callback RepositoryStoreCallback) (*api.Repository, bool, error)
 	Delete(ctx context.Context, orgId uuid.UUID, name string, callback RepositoryStoreCallback) error
 	UpdateStatusIgnoreOrg(repository *model.Repository) error
+	RotateCredentials(ctx context.Context, orgId uuid.UUID, name string, resourceVersion int64, spec api.RepositorySpec, status api.RepositoryStatus, callback RepositoryStoreCallback) (*api.Repository, error)
 	GetFleetRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.FleetList, error)
 	GetDeviceRefs(ctx context.Context, orgId uuid.UUID, name string) (*api.DeviceList, error)
 	InitialMigration() error
@@ -243,6 +244,33 @@ func (s *RepositoryStore) UpdateStatusIgnoreOrg(resource *model.Repository) erro
 	return flterrors.ErrorFromGormError(result.Error)
 }
 
+// RotateCredentials replaces the spec and the status of the repository in a
+// single update, provided that the repository was not changed since it was
+// read at the resource version.
+func (s *RepositoryStore) RotateCredentials(ctx context.Context, orgId uuid.UUID, name string, resourceVersion int64, spec api.RepositorySpec, status api.RepositoryStatus, callback RepositoryStoreCallback) (*api.Repository, error) {
+	where := model.Repository{Resource: model.Resource{OrgID: orgId, Name: name}}
+	result := s.db.Model(where).Where("spec IS NOT NULL AND resource_version = ?", resourceVersion).Updates(map[string]interface{}{
+		"spec":             model.MakeJSONField(spec),
+		"status":           model.MakeJSONField(status),
+		"generation":       gorm.Expr("generation + 1"),
+		"resource_version": gorm.Expr("resource_version + 1"),
+	})
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, flterrors.ErrResourceVersionConflict
+	}
+
+	repository, err := s.GetInternal(ctx, orgId, name)
+	if err != nil {
+		return nil, err
+	}
+	callback(repository)
+	apiRepository, err := repository.ToApiResource()
+	return &apiRepository, err
+}
+
 func (s *RepositoryStore) Delete(ctx context.Context, orgId uuid.UUID, name string, callback RepositoryStoreCallback) error {
 	var existingRecords []*model.Repository
 	if err := s.db.Raw(`delete from repositories where org_id = ? and name = ? and spec is not null returning *`, orgId, name).Scan(&existingRecords).Error; err != nil {
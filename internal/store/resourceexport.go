@@ -0,0 +1,202 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ResourceExportAPI  = "v1alpha1"
+	ResourceExportKind = "ResourceExport"
+)
+
+// exportOmittedDeviceAnnotations are the annotations of devices which only
+// apply to the instance they are exported from.
+var exportOmittedDeviceAnnotations = []string{
+	model.DeviceAnnotationConsole,
+	model.DeviceAnnotationMigration,
+	model.DeviceAnnotationTunnel,
+}
+
+// ResourceExport moves fleets and the server-side records of their devices
+// between Flight Control instances.
+type ResourceExport interface {
+	Export(ctx context.Context, orgId uuid.UUID, fleetNames []string) (*api.ResourceExport, error)
+	Import(ctx context.Context, orgId uuid.UUID, export *api.ResourceExport, fleetCallback FleetStoreCallback, deviceCallback DeviceStoreCallback) (*api.ResourceImportResult, error)
+}
+
+type ResourceExportStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to ResourceExport interface
+var _ ResourceExport = (*ResourceExportStore)(nil)
+
+func NewResourceExport(db *gorm.DB, log logrus.FieldLogger) ResourceExport {
+	return &ResourceExportStore{db: db, log: log}
+}
+
+// Export returns the fleets, their template versions, the devices they own
+// with their rendered configuration and the certificates issued to those
+// devices, all read from the same snapshot of the database. It fails with
+// ErrResourceNotFound if any of the fleets does not exist.
+func (s *ResourceExportStore) Export(ctx context.Context, orgId uuid.UUID, fleetNames []string) (*api.ResourceExport, error) {
+	fleetNames = lo.Uniq(fleetNames)
+	export := &api.ResourceExport{
+		ApiVersion:       ResourceExportAPI,
+		Kind:             ResourceExportKind,
+		Fleets:           []api.Fleet{},
+		TemplateVersions: []api.TemplateVersion{},
+		Devices:          []api.ExportedDevice{},
+		Certificates:     []api.IssuedCertificate{},
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var fleets model.FleetList
+		if err := tx.Where("org_id = ? AND name IN ?", orgId, fleetNames).Order("name").Find(&fleets).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		if len(fleets) != len(fleetNames) {
+			return flterrors.ErrResourceNotFound
+		}
+		for i := range fleets {
+			export.Fleets = append(export.Fleets, fleets[i].ToApiResource())
+		}
+
+		var templateVersions model.TemplateVersionList
+		if err := tx.Where("org_id = ? AND fleet_name IN ?", orgId, fleetNames).Order("fleet_name, created_at").Find(&templateVersions).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		for i := range templateVersions {
+			export.TemplateVersions = append(export.TemplateVersions, templateVersions[i].ToApiResource())
+		}
+
+		owners := lo.Map(fleetNames, func(name string, _ int) string { return *util.SetResourceOwner(model.FleetKind, name) })
+		var devices model.DeviceList
+		if err := tx.Where("org_id = ? AND owner IN ?", orgId, owners).Order("name").Find(&devices).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		deviceNames := make([]string, 0, len(devices))
+		for i := range devices {
+			device := devices[i].ToApiResource()
+			if device.Metadata.Annotations != nil {
+				for _, key := range exportOmittedDeviceAnnotations {
+					delete(*device.Metadata.Annotations, key)
+				}
+			}
+			export.Devices = append(export.Devices, api.ExportedDevice{Device: device, RenderedConfig: devices[i].RenderedConfig})
+			deviceNames = append(deviceNames, devices[i].Name)
+		}
+		if len(deviceNames) == 0 {
+			return nil
+		}
+
+		var certificates model.IssuedCertificateList
+		if err := tx.Where("org_id = ? AND device IN ?", orgId, deviceNames).Order("device, not_after").Find(&certificates).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		export.Certificates = certificates.ToApiResource().Items
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Import creates the resources of an export in a single transaction. What was
+// rendered by the source instance is not trusted: the template versions are
+// imported without their rendered templates and the devices without their
+// rendered configuration, and the callbacks have the fleets and the devices
+// rendered again. The devices keep their rendered versions, so that the
+// versions rendered by this instance follow the ones they applied. It fails
+// with ErrDuplicateName, importing nothing, if any of the resources already
+// exists.
+func (s *ResourceExportStore) Import(ctx context.Context, orgId uuid.UUID, export *api.ResourceExport, fleetCallback FleetStoreCallback, deviceCallback DeviceStoreCallback) (*api.ResourceImportResult, error) {
+	result := &api.ResourceImportResult{}
+	fleets := make([]*model.Fleet, 0, len(export.Fleets))
+	devices := make([]*model.Device, 0, len(export.Devices))
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range export.Fleets {
+			apiFleet := export.Fleets[i]
+			// the fleet is rolled out once its template is rendered again
+			if apiFleet.Metadata.Annotations != nil {
+				apiFleet.Metadata.Annotations = lo.ToPtr(lo.OmitByKeys(*apiFleet.Metadata.Annotations, []string{model.FleetAnnotationTemplateVersion}))
+			}
+			fleet, err := model.NewFleetFromApiResource(&apiFleet)
+			if err != nil {
+				return err
+			}
+			fleet.OrgID = orgId
+			fleet.ResourceVersion = lo.ToPtr[int64](1)
+			if err := tx.Create(fleet).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+			fleets = append(fleets, fleet)
+			result.Fleets++
+		}
+
+		for i := range export.TemplateVersions {
+			templateVersion, err := model.NewTemplateVersionFromApiResource(&export.TemplateVersions[i])
+			if err != nil {
+				return err
+			}
+			templateVersion.OrgID = orgId
+			templateVersion.ResourceVersion = lo.ToPtr[int64](1)
+			status := api.TemplateVersionStatus{}
+			api.SetStatusCondition(&status.Conditions, api.Condition{Type: api.TemplateVersionValid, Status: api.ConditionStatusUnknown})
+			templateVersion.Status = model.MakeJSONField(status)
+			templateVersion.Valid = nil
+			if err := tx.Create(templateVersion).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+			result.TemplateVersions++
+		}
+
+		for i := range export.Devices {
+			device, err := model.NewDeviceFromApiResource(&export.Devices[i].Device)
+			if err != nil {
+				return err
+			}
+			device.OrgID = orgId
+			device.ResourceVersion = lo.ToPtr[int64](1)
+			// the display name and the aliases are not taken from the API resource otherwise
+			device.DisplayName = export.Devices[i].Device.Metadata.DisplayName
+			device.Aliases = lo.FromPtr(export.Devices[i].Device.Metadata.Aliases)
+			if err := tx.Create(device).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+			devices = append(devices, device)
+			result.Devices++
+		}
+
+		for i := range export.Certificates {
+			certificate := model.NewIssuedCertificateFromApiResource(&export.Certificates[i])
+			certificate.OrgID = orgId
+			if err := tx.Create(certificate).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+			result.Certificates++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, fleet := range fleets {
+		fleetCallback(nil, fleet)
+	}
+	for _, device := range devices {
+		deviceCallback(nil, device)
+	}
+	return result, nil
+}
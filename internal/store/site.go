@@ -0,0 +1,216 @@
+package store
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Site interface {
+	Create(ctx context.Context, orgId uuid.UUID, req *api.Site) (*api.Site, error)
+	Update(ctx context.Context, orgId uuid.UUID, req *api.Site) (*api.Site, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.SiteList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Site, error)
+	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, site *api.Site) (*api.Site, bool, error)
+	DeleteAll(ctx context.Context, orgId uuid.UUID) error
+	Delete(ctx context.Context, orgId uuid.UUID, name string) error
+	InitialMigration() error
+}
+
+type SiteStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Site interface
+var _ Site = (*SiteStore)(nil)
+
+func NewSite(db *gorm.DB, log logrus.FieldLogger) Site {
+	return &SiteStore{db: db, log: log}
+}
+
+func (s *SiteStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.Site{})
+}
+
+func (s *SiteStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.Site) (*api.Site, error) {
+	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly)
+	return updatedResource, err
+}
+
+func (s *SiteStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.Site) (*api.Site, error) {
+	updatedResource, _, err := retryCreateOrUpdate(func() (*api.Site, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeUpdateOnly)
+	})
+	return updatedResource, err
+}
+
+func (s *SiteStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.SiteList, error) {
+	var sites model.SiteList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&sites), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&sites)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(sites) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    sites[len(sites)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		sites = sites[:len(sites)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&sites), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiSiteList := sites.ToApiResource(nextContinue, numRemaining)
+	return &apiSiteList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *SiteStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
+	condition := model.Site{}
+	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	return nil
+}
+
+func (s *SiteStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Site, error) {
+	site := model.Site{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&site)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiSite := site.ToApiResource()
+	return &apiSite, nil
+}
+
+func (s *SiteStore) createSite(site *model.Site) (bool, error) {
+	site.Generation = lo.ToPtr[int64](1)
+	site.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(site); result.Error != nil {
+		err := flterrors.ErrorFromGormError(result.Error)
+		return err == flterrors.ErrDuplicateName, err
+	}
+	return false, nil
+}
+
+func (s *SiteStore) updateSite(existingRecord, site *model.Site) (bool, error) {
+	updateSpec := site.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, site.Spec)
+
+	// Update the generation if the spec was updated
+	if updateSpec {
+		site.Generation = lo.ToPtr(lo.FromPtr(existingRecord.Generation) + 1)
+	}
+	if site.ResourceVersion != nil && lo.FromPtr(existingRecord.ResourceVersion) != lo.FromPtr(site.ResourceVersion) {
+		return false, flterrors.ErrResourceVersionConflict
+	}
+	site.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+	where := model.Site{Resource: model.Resource{OrgID: site.OrgID, Name: site.Name}}
+	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+
+	result := query.Updates(&site)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+func (s *SiteStore) createOrUpdate(orgId uuid.UUID, resource *api.Site, mode CreateOrUpdateMode) (*api.Site, bool, bool, error) {
+	if resource == nil {
+		return nil, false, false, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, false, false, flterrors.ErrResourceNameIsNil
+	}
+
+	site, err := model.NewSiteFromApiResource(resource)
+	if err != nil {
+		return nil, false, false, err
+	}
+	site.OrgID = orgId
+
+	existingRecord, err := getExistingRecord[model.Site](s.db, site.Name, orgId)
+	if err != nil {
+		return nil, false, false, err
+	}
+	exists := existingRecord != nil
+
+	if exists && mode == ModeCreateOnly {
+		return nil, false, false, flterrors.ErrDuplicateName
+	}
+	if !exists && mode == ModeUpdateOnly {
+		return nil, false, false, flterrors.ErrResourceNotFound
+	}
+
+	if !exists {
+		if retry, err := s.createSite(site); err != nil {
+			return nil, false, retry, err
+		}
+	} else {
+		if retry, err := s.updateSite(existingRecord, site); err != nil {
+			return nil, false, retry, err
+		}
+	}
+
+	updatedResource := site.ToApiResource()
+	return &updatedResource, !exists, false, nil
+}
+
+func (s *SiteStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Site) (*api.Site, bool, error) {
+	return retryCreateOrUpdate(func() (*api.Site, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate)
+	})
+}
+
+func (s *SiteStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	condition := model.Site{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if result.Error != nil {
+		return flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return flterrors.ErrResourceNotFound
+	}
+	return nil
+}
@@ -24,6 +24,18 @@ type Store interface {
 	TemplateVersion() TemplateVersion
 	Repository() Repository
 	ResourceSync() ResourceSync
+	Webhook() Webhook
+	LabelRule() LabelRule
+	DeviceView() DeviceView
+	Site() Site
+	DeviceIdentity() DeviceIdentity
+	IssuedCertificate() IssuedCertificate
+	DeviceAttestation() DeviceAttestation
+	ImageSbom() ImageSbom
+	ResourceExport() ResourceExport
+	Trash() Trash
+	Operation() Operation
+	IdempotencyKey() IdempotencyKey
 	InitialMigration() error
 	Close() error
 }
@@ -36,20 +48,74 @@ type DataStore struct {
 	templateVersion           TemplateVersion
 	repository                Repository
 	resourceSync              ResourceSync
+	webhook                   Webhook
+	labelRule                 LabelRule
+	deviceView                DeviceView
+	site                      Site
+	deviceIdentity            DeviceIdentity
+	issuedCertificate         IssuedCertificate
+	deviceAttestation         DeviceAttestation
+	imageSbom                 ImageSbom
+	resourceExport            ResourceExport
+	trash                     Trash
+	operation                 Operation
+	idempotencyKey            IdempotencyKey
 
-	db *gorm.DB
+	db    *gorm.DB
+	reads *ReadReplicas
 }
 
-func NewStore(db *gorm.DB, log logrus.FieldLogger) Store {
+type storeOptions struct {
+	replicas              []*gorm.DB
+	renderedSpecCacheSize int
+}
+
+type StoreOption func(*storeOptions)
+
+// WithReadReplicaDBs sends the reads marked with WithReplicaReads to the read
+// replicas of the database.
+func WithReadReplicaDBs(replicas ...*gorm.DB) StoreOption {
+	return func(o *storeOptions) {
+		o.replicas = replicas
+	}
+}
+
+// WithRenderedSpecCache caches the rendered specs of up to size devices in
+// memory, for the agents polling them.
+func WithRenderedSpecCache(size int) StoreOption {
+	return func(o *storeOptions) {
+		o.renderedSpecCacheSize = size
+	}
+}
+
+func NewStore(db *gorm.DB, log logrus.FieldLogger, opts ...StoreOption) Store {
+	options := storeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	reads := NewReadReplicas(db, options.replicas...)
 	return &DataStore{
-		device:                    NewDevice(db, log),
-		enrollmentRequest:         NewEnrollmentRequest(db, log),
+		device:                    NewDevice(db, reads, options.renderedSpecCacheSize, log),
+		enrollmentRequest:         NewEnrollmentRequest(db, reads, log),
 		certificateSigningRequest: NewCertificateSigningRequest(db, log),
-		fleet:                     NewFleet(db, log),
+		fleet:                     NewFleet(db, reads, log),
 		templateVersion:           NewTemplateVersion(db, log),
 		repository:                NewRepository(db, log),
 		resourceSync:              NewResourceSync(db, log),
+		webhook:                   NewWebhook(db, log),
+		labelRule:                 NewLabelRule(db, log),
+		deviceView:                NewDeviceView(db, log),
+		site:                      NewSite(db, log),
+		deviceIdentity:            NewDeviceIdentity(db, log),
+		issuedCertificate:         NewIssuedCertificate(db, log),
+		deviceAttestation:         NewDeviceAttestation(db, log),
+		imageSbom:                 NewImageSbom(db, log),
+		resourceExport:            NewResourceExport(db, log),
+		trash:                     NewTrash(db, log),
+		operation:                 NewOperation(db, log),
+		idempotencyKey:            NewIdempotencyKey(db, log),
 		db:                        db,
+		reads:                     reads,
 	}
 }
 
@@ -81,6 +147,54 @@ func (s *DataStore) ResourceSync() ResourceSync {
 	return s.resourceSync
 }
 
+func (s *DataStore) Webhook() Webhook {
+	return s.webhook
+}
+
+func (s *DataStore) LabelRule() LabelRule {
+	return s.labelRule
+}
+
+func (s *DataStore) DeviceView() DeviceView {
+	return s.deviceView
+}
+
+func (s *DataStore) Site() Site {
+	return s.site
+}
+
+func (s *DataStore) DeviceIdentity() DeviceIdentity {
+	return s.deviceIdentity
+}
+
+func (s *DataStore) IssuedCertificate() IssuedCertificate {
+	return s.issuedCertificate
+}
+
+func (s *DataStore) DeviceAttestation() DeviceAttestation {
+	return s.deviceAttestation
+}
+
+func (s *DataStore) ImageSbom() ImageSbom {
+	return s.imageSbom
+}
+
+func (s *DataStore) ResourceExport() ResourceExport {
+	return s.resourceExport
+}
+
+func (s *DataStore) Trash() Trash {
+	return s.trash
+}
+
+func (s *DataStore) Operation() Operation {
+	return s.operation
+}
+
+func (s *DataStore) IdempotencyKey() IdempotencyKey {
+	return s.idempotencyKey
+}
+
 func (s *DataStore) InitialMigration() error {
 	if err := s.Device().InitialMigration(); err != nil {
 		return err
@@ -103,6 +217,36 @@ func (s *DataStore) InitialMigration() error {
 	if err := s.ResourceSync().InitialMigration(); err != nil {
 		return err
 	}
+	if err := s.Webhook().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.LabelRule().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.DeviceView().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.Site().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.DeviceIdentity().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.IssuedCertificate().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.DeviceAttestation().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.ImageSbom().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.Operation().InitialMigration(); err != nil {
+		return err
+	}
+	if err := s.IdempotencyKey().InitialMigration(); err != nil {
+		return err
+	}
 	return s.customizeMigration()
 }
 
@@ -121,6 +265,9 @@ func (s *DataStore) customizeMigration() error {
 }
 
 func (s *DataStore) Close() error {
+	if err := s.reads.close(); err != nil {
+		return err
+	}
 	sqlDB, err := s.db.DB()
 	if err != nil {
 		return err
@@ -136,6 +283,45 @@ type ListParams struct {
 	Limit        int
 	Continue     *Continue
 	FleetName    *string
+	BoundingBox  *BoundingBox
+	Annotations  []AnnotationRequirement
+	// ImageDigests, if not nil, selects the devices which applied an image
+	// with one of the digests, as reported in their status.provenance
+	ImageDigests []string
+	// Alias, if not nil, selects the devices with the value as their name,
+	// their display name or one of their aliases
+	Alias *string
+	// ApplicationRestarts, if not nil, selects the devices with an
+	// application which restarted at least as many times, as reported in
+	// their status.applications
+	ApplicationRestarts *int
+	// HardwareIdentity, if not nil, selects the devices which reported its
+	// serial number or one of its MAC addresses in their
+	// status.systemInfo.hardware
+	HardwareIdentity *HardwareIdentity
+}
+
+// HardwareIdentity identifies a machine by its serial number and the MAC
+// addresses of its network interfaces, which outlive re-imaging it.
+type HardwareIdentity struct {
+	SerialNumber string
+	MacAddresses []string
+}
+
+// AnnotationRequirement selects the devices whose agent wrote the annotation
+// Key in their status, with the value Value unless it is nil.
+type AnnotationRequirement struct {
+	Key   string
+	Value *string
+}
+
+// BoundingBox selects the devices whose reported location lies within the
+// box, in degrees. The box crosses the antimeridian if West is greater than East.
+type BoundingBox struct {
+	West  float64
+	South float64
+	East  float64
+	North float64
 }
 
 type Continue struct {
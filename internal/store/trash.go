@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	TrashAPI      = "v1alpha1"
+	TrashListKind = "TrashList"
+)
+
+// Trash holds the devices and fleets which were deleted, until they are
+// restored through their stores or purged once the trash retention elapsed.
+type Trash interface {
+	List(ctx context.Context, orgId uuid.UUID, kind *string, retention time.Duration) (*api.TrashList, error)
+	Purge(ctx context.Context, deletedBefore time.Time, callback FleetStoreCallback) (int, error)
+}
+
+type TrashStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Trash interface
+var _ Trash = (*TrashStore)(nil)
+
+func NewTrash(db *gorm.DB, log logrus.FieldLogger) Trash {
+	return &TrashStore{db: db, log: log}
+}
+
+type trashedRecord struct {
+	Name      string
+	Owner     *string
+	DeletedAt time.Time
+}
+
+// List returns the devices and fleets in the trash of the organization,
+// optionally only those of one kind, ordered by the time they are purged at
+// after the retention.
+func (s *TrashStore) List(ctx context.Context, orgId uuid.UUID, kind *string, retention time.Duration) (*api.TrashList, error) {
+	items := []api.TrashedResource{}
+	for _, table := range []struct {
+		kind  string
+		model any
+	}{
+		{kind: model.DeviceKind, model: &model.Device{}},
+		{kind: model.FleetKind, model: &model.Fleet{}},
+	} {
+		if kind != nil && *kind != table.kind {
+			continue
+		}
+		var records []trashedRecord
+		result := s.db.WithContext(ctx).Unscoped().Model(table.model).Select("name, owner, deleted_at").
+			Where("org_id = ? and deleted_at is not null", orgId).Scan(&records)
+		if result.Error != nil {
+			return nil, flterrors.ErrorFromGormError(result.Error)
+		}
+		for _, record := range records {
+			items = append(items, api.TrashedResource{
+				Kind:      table.kind,
+				Name:      record.Name,
+				Owner:     record.Owner,
+				DeletedAt: record.DeletedAt,
+				PurgeAt:   record.DeletedAt.Add(retention),
+			})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DeletedAt.Before(items[j].DeletedAt) })
+	return &api.TrashList{
+		ApiVersion: TrashAPI,
+		Kind:       TrashListKind,
+		Items:      items,
+	}, nil
+}
+
+// Purge permanently deletes the devices and fleets of all organizations which
+// were deleted before the time, together with the certificates and the
+// attestations of the devices. The callback is called with each purged fleet,
+// so that any device still owned by it is released. It returns the number of purged devices
+// and fleets.
+func (s *TrashStore) Purge(ctx context.Context, deletedBefore time.Time, callback FleetStoreCallback) (int, error) {
+	var fleets []model.Fleet
+	purged := 0
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var devices []model.Device
+		if err := tx.Raw(`delete from devices where deleted_at < ? returning *`, deletedBefore).Scan(&devices).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		for i := range devices {
+			if err := tx.Where("org_id = ? and device = ?", devices[i].OrgID, devices[i].Name).Delete(&model.IssuedCertificate{}).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+			if err := tx.Where("org_id = ? and device = ?", devices[i].OrgID, devices[i].Name).Delete(&model.DeviceAttestation{}).Error; err != nil {
+				return flterrors.ErrorFromGormError(err)
+			}
+		}
+		if err := tx.Raw(`delete from fleets where deleted_at < ? returning *`, deletedBefore).Scan(&fleets).Error; err != nil {
+			return flterrors.ErrorFromGormError(err)
+		}
+		purged = len(devices) + len(fleets)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i := range fleets {
+		callback(&fleets[i], nil)
+	}
+	return purged, nil
+}
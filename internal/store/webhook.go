@@ -0,0 +1,225 @@
+package store
+
+import (
+	"context"
+	b64 "encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Webhook interface {
+	Create(ctx context.Context, orgId uuid.UUID, req *api.Webhook) (*api.Webhook, error)
+	Update(ctx context.Context, orgId uuid.UUID, req *api.Webhook) (*api.Webhook, error)
+	List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.WebhookList, error)
+	Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Webhook, error)
+	CreateOrUpdate(ctx context.Context, orgId uuid.UUID, webhook *api.Webhook) (*api.Webhook, bool, error)
+	UpdateStatus(ctx context.Context, orgId uuid.UUID, webhook *api.Webhook) (*api.Webhook, error)
+	DeleteAll(ctx context.Context, orgId uuid.UUID) error
+	Delete(ctx context.Context, orgId uuid.UUID, name string) error
+	InitialMigration() error
+}
+
+type WebhookStore struct {
+	db  *gorm.DB
+	log logrus.FieldLogger
+}
+
+// Make sure we conform to Webhook interface
+var _ Webhook = (*WebhookStore)(nil)
+
+func NewWebhook(db *gorm.DB, log logrus.FieldLogger) Webhook {
+	return &WebhookStore{db: db, log: log}
+}
+
+func (s *WebhookStore) InitialMigration() error {
+	return s.db.AutoMigrate(&model.Webhook{})
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *WebhookStore) Create(ctx context.Context, orgId uuid.UUID, resource *api.Webhook) (*api.Webhook, error) {
+	updatedResource, _, _, err := s.createOrUpdate(orgId, resource, ModeCreateOnly)
+	return updatedResource, err
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *WebhookStore) Update(ctx context.Context, orgId uuid.UUID, resource *api.Webhook) (*api.Webhook, error) {
+	updatedResource, _, err := retryCreateOrUpdate(func() (*api.Webhook, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeUpdateOnly)
+	})
+	return updatedResource, err
+}
+
+func (s *WebhookStore) List(ctx context.Context, orgId uuid.UUID, listParams ListParams) (*api.WebhookList, error) {
+	var webhooks model.WebhookList
+	var nextContinue *string
+	var numRemaining *int64
+
+	query := BuildBaseListQuery(s.db.Model(&webhooks), orgId, listParams)
+	if listParams.Limit > 0 {
+		// Request 1 more than the user asked for to see if we need to return "continue"
+		query = AddPaginationToQuery(query, listParams.Limit+1, listParams.Continue)
+	}
+	result := query.Find(&webhooks)
+
+	// If we got more than the user requested, remove one record and calculate "continue"
+	if listParams.Limit > 0 && len(webhooks) > listParams.Limit {
+		nextContinueStruct := Continue{
+			Name:    webhooks[len(webhooks)-1].Name,
+			Version: CurrentContinueVersion,
+		}
+		webhooks = webhooks[:len(webhooks)-1]
+
+		var numRemainingVal int64
+		if listParams.Continue != nil {
+			numRemainingVal = listParams.Continue.Count - int64(listParams.Limit)
+			if numRemainingVal < 1 {
+				numRemainingVal = 1
+			}
+		} else {
+			countQuery := BuildBaseListQuery(s.db.Model(&webhooks), orgId, listParams)
+			numRemainingVal = CountRemainingItems(countQuery, nextContinueStruct.Name)
+		}
+		nextContinueStruct.Count = numRemainingVal
+		contByte, _ := json.Marshal(nextContinueStruct)
+		contStr := b64.StdEncoding.EncodeToString(contByte)
+		nextContinue = &contStr
+		numRemaining = &numRemainingVal
+	}
+
+	apiWebhookList := webhooks.ToApiResource(nextContinue, numRemaining)
+	return &apiWebhookList, flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *WebhookStore) DeleteAll(ctx context.Context, orgId uuid.UUID) error {
+	condition := model.Webhook{}
+	result := s.db.Unscoped().Where("org_id = ?", orgId).Delete(&condition)
+	return flterrors.ErrorFromGormError(result.Error)
+}
+
+func (s *WebhookStore) Get(ctx context.Context, orgId uuid.UUID, name string) (*api.Webhook, error) {
+	webhook := model.Webhook{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.First(&webhook)
+	if result.Error != nil {
+		return nil, flterrors.ErrorFromGormError(result.Error)
+	}
+	apiWebhook := webhook.ToApiResource()
+	return &apiWebhook, nil
+}
+
+func (s *WebhookStore) createWebhook(webhook *model.Webhook) (bool, error) {
+	webhook.Generation = lo.ToPtr[int64](1)
+	webhook.ResourceVersion = lo.ToPtr[int64](1)
+	if result := s.db.Create(webhook); result.Error != nil {
+		err := flterrors.ErrorFromGormError(result.Error)
+		return err == flterrors.ErrDuplicateName, err
+	}
+	return false, nil
+}
+
+func (s *WebhookStore) updateWebhook(existingRecord, webhook *model.Webhook) (bool, error) {
+	updateSpec := webhook.Spec != nil && !reflect.DeepEqual(existingRecord.Spec, webhook.Spec)
+
+	// Update the generation if the spec was updated
+	if updateSpec {
+		webhook.Generation = lo.ToPtr(lo.FromPtr(existingRecord.Generation) + 1)
+	}
+	if webhook.ResourceVersion != nil && lo.FromPtr(existingRecord.ResourceVersion) != lo.FromPtr(webhook.ResourceVersion) {
+		return false, flterrors.ErrResourceVersionConflict
+	}
+	webhook.ResourceVersion = lo.ToPtr(lo.FromPtr(existingRecord.ResourceVersion) + 1)
+	where := model.Webhook{Resource: model.Resource{OrgID: webhook.OrgID, Name: webhook.Name}}
+	query := s.db.Model(where).Where("resource_version = ?", lo.FromPtr(existingRecord.ResourceVersion))
+
+	result := query.Updates(&webhook)
+	if result.Error != nil {
+		return false, flterrors.ErrorFromGormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return true, flterrors.ErrNoRowsUpdated
+	}
+	return false, nil
+}
+
+// Warning: this is a user-facing function and will set the Status to nil
+func (s *WebhookStore) createOrUpdate(orgId uuid.UUID, resource *api.Webhook, mode CreateOrUpdateMode) (*api.Webhook, bool, bool, error) {
+	if resource == nil {
+		return nil, false, false, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, false, false, flterrors.ErrResourceNameIsNil
+	}
+
+	webhook, err := model.NewWebhookFromApiResource(resource)
+	if err != nil {
+		return nil, false, false, err
+	}
+	webhook.OrgID = orgId
+	webhook.Status = nil
+
+	existingRecord, err := getExistingRecord[model.Webhook](s.db, webhook.Name, orgId)
+	if err != nil {
+		return nil, false, false, err
+	}
+	exists := existingRecord != nil
+
+	if exists && mode == ModeCreateOnly {
+		return nil, false, false, flterrors.ErrDuplicateName
+	}
+	if !exists && mode == ModeUpdateOnly {
+		return nil, false, false, flterrors.ErrResourceNotFound
+	}
+
+	if !exists {
+		if retry, err := s.createWebhook(webhook); err != nil {
+			return nil, false, retry, err
+		}
+	} else {
+		if retry, err := s.updateWebhook(existingRecord, webhook); err != nil {
+			return nil, false, retry, err
+		}
+	}
+
+	updatedResource := webhook.ToApiResource()
+	return &updatedResource, !exists, false, nil
+}
+
+func (s *WebhookStore) CreateOrUpdate(ctx context.Context, orgId uuid.UUID, resource *api.Webhook) (*api.Webhook, bool, error) {
+	return retryCreateOrUpdate(func() (*api.Webhook, bool, bool, error) {
+		return s.createOrUpdate(orgId, resource, ModeCreateOrUpdate)
+	})
+}
+
+func (s *WebhookStore) UpdateStatus(ctx context.Context, orgId uuid.UUID, resource *api.Webhook) (*api.Webhook, error) {
+	if resource == nil {
+		return nil, flterrors.ErrResourceIsNil
+	}
+	if resource.Metadata.Name == nil {
+		return nil, flterrors.ErrResourceNameIsNil
+	}
+	if err := updateResourceStatus[model.Webhook](s.db, orgId, *resource.Metadata.Name, resource.Metadata.ResourceVersion, model.MakeJSONField(resource.Status)); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (s *WebhookStore) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	condition := model.Webhook{
+		Resource: model.Resource{OrgID: orgId, Name: name},
+	}
+	result := s.db.Unscoped().Delete(&condition)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return flterrors.ErrorFromGormError(result.Error)
+}
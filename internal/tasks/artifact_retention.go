@@ -0,0 +1,48 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/sirupsen/logrus"
+)
+
+// ArtifactRetentionPollingInterval is the interval at which expired artifacts
+// are deleted.
+const ArtifactRetentionPollingInterval = time.Hour
+
+// ArtifactRetention deletes the artifacts which are older than the retention
+// configured for their kind.
+type ArtifactRetention struct {
+	log       logrus.FieldLogger
+	artifacts artifacts.Store
+	retention map[string]time.Duration
+}
+
+func NewArtifactRetention(log logrus.FieldLogger, artifactStore artifacts.Store, retention map[string]time.Duration) *ArtifactRetention {
+	return &ArtifactRetention{log: log, artifacts: artifactStore, retention: retention}
+}
+
+func (t *ArtifactRetention) Poll() {
+	t.log.Info("Running ArtifactRetention Polling")
+	t.deleteExpired(context.Background(), time.Now())
+}
+
+func (t *ArtifactRetention) deleteExpired(ctx context.Context, now time.Time) {
+	for kind, retention := range t.retention {
+		objects, err := t.artifacts.List(ctx, kind+"/")
+		if err != nil {
+			t.log.WithError(err).Errorf("failed to list artifacts of kind %s", kind)
+			continue
+		}
+		for _, object := range objects {
+			if now.Sub(object.ModifiedAt) <= retention {
+				continue
+			}
+			if err := t.artifacts.Delete(ctx, object.Key); err != nil {
+				t.log.WithError(err).Errorf("failed to delete expired artifact %s", object.Key)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package tasks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactRetention(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	root := t.TempDir()
+	store := artifacts.NewFilesystemStore(root)
+
+	now := time.Now()
+	for key, age := range map[string]time.Duration{
+		"reports/edge/old.json":   48 * time.Hour,
+		"reports/edge/new.json":   time.Hour,
+		"recordings/old.cast":     48 * time.Hour,
+		"reportsarchive/old.json": 48 * time.Hour,
+	} {
+		require.NoError(store.Put(ctx, key, []byte(`{}`), "application/json"))
+		modified := now.Add(-age)
+		require.NoError(os.Chtimes(filepath.Join(root, filepath.FromSlash(key)), modified, modified))
+	}
+
+	task := NewArtifactRetention(log.InitLogs(), store, map[string]time.Duration{artifacts.KindReports: 24 * time.Hour})
+	task.deleteExpired(ctx, now)
+
+	objects, err := store.List(ctx, "")
+	require.NoError(err)
+	keys := []string{}
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	require.ElementsMatch([]string{"reports/edge/new.json", "recordings/old.cast", "reportsarchive/old.json"}, keys)
+}
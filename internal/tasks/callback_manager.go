@@ -10,6 +10,7 @@ import (
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/queues"
 	"github.com/google/uuid"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
@@ -31,6 +32,15 @@ const (
 
 	// Task to re-evaluate fleets and devices if a repository resource changes
 	RepositoryUpdatesTask = "repository-updates"
+
+	// Task to notify webhooks about device status transitions
+	DeviceStatusWebhookTask = "device-status-webhook"
+
+	// Task to assign labels to devices according to label rules
+	DeviceLabelRulesTask = "device-label-rules"
+
+	// Task to run an operation on the devices it selects
+	OperationRunTask = "operation-run"
 )
 
 type CallbackManager interface {
@@ -40,10 +50,15 @@ type CallbackManager interface {
 	AllFleetsDeletedCallback(orgId uuid.UUID)
 	AllDevicesDeletedCallback(orgId uuid.UUID)
 	DeviceUpdatedCallback(before *model.Device, after *model.Device)
+	DeviceStatusUpdatedCallback(before *model.Device, after *model.Device)
+	DeviceStatusWebhookDelivery(orgId uuid.UUID, device string, delivery WebhookDelivery)
+	LabelRulesUpdatedCallback(orgId uuid.UUID)
 	TemplateVersionCreatedCallback(templateVersion *model.TemplateVersion)
 	TemplateVersionValidatedCallback(templateVersion *model.TemplateVersion)
 	FleetSourceUpdated(orgId uuid.UUID, name string)
+	FleetRolloutUpdated(orgId uuid.UUID, name string)
 	DeviceSourceUpdated(orgId uuid.UUID, name string)
+	OperationCreated(orgId uuid.UUID, name string)
 }
 
 type callbackManager struct {
@@ -123,6 +138,13 @@ func (t *callbackManager) FleetSourceUpdated(orgId uuid.UUID, name string) {
 	t.submitTask(FleetValidateTask, ref, FleetValidateOpUpdate)
 }
 
+// FleetRolloutUpdated re-evaluates the rollout of a fleet after it was paused,
+// resumed or aborted.
+func (t *callbackManager) FleetRolloutUpdated(orgId uuid.UUID, name string) {
+	ref := ResourceReference{OrgID: orgId, Kind: model.FleetKind, Name: name}
+	t.submitTask(FleetRolloutTask, ref, FleetRolloutOpUpdate)
+}
+
 func (t *callbackManager) RepositoryUpdatedCallback(repository *model.Repository) {
 	resourceRef := ResourceReference{
 		OrgID: repository.OrgID,
@@ -181,6 +203,10 @@ func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *mod
 		// check if we need to update its spec according to its new fleet
 		t.submitTask(FleetRolloutTask, ref, FleetRolloutOpUpdate)
 	}
+	if labelsUpdated && after != nil && before != nil {
+		// Re-assign the labels of label rules that the update may have dropped
+		t.submitTask(DeviceLabelRulesTask, ref, DeviceLabelRulesOpUpdate)
+	}
 	if labelsUpdated {
 		// Check if the new labels cause the device to move to a different fleet
 		op := FleetSelectorMatchOpUpdate
@@ -195,11 +221,83 @@ func (t *callbackManager) DeviceUpdatedCallback(before *model.Device, after *mod
 	}
 }
 
+func (t *callbackManager) DeviceStatusUpdatedCallback(before *model.Device, after *model.Device) {
+	if before == nil || after == nil {
+		return
+	}
+
+	var beforeStatus, afterStatus api.DeviceStatus
+	if before.Status != nil {
+		beforeStatus = before.Status.Data
+	}
+	if after.Status != nil {
+		afterStatus = after.Status.Data
+	}
+
+	// Only transitions are of interest, steady-state status updates are dropped here
+	transitions := deviceStatusTransitions(&beforeStatus, &afterStatus)
+	if len(transitions) > 0 {
+		ref := ResourceReference{OrgID: after.OrgID, Kind: model.DeviceKind, Name: after.Name, StatusTransitions: transitions}
+		t.submitTask(DeviceStatusWebhookTask, ref, DeviceStatusWebhookOpTransition)
+	}
+
+	// Label rules are derived from the system info, which rarely changes
+	if !reflect.DeepEqual(beforeStatus.SystemInfo, afterStatus.SystemInfo) {
+		ref := ResourceReference{OrgID: after.OrgID, Kind: model.DeviceKind, Name: after.Name}
+		t.submitTask(DeviceLabelRulesTask, ref, DeviceLabelRulesOpUpdate)
+	}
+
+	// The images of the rendered spec depend on the architecture of the device,
+	// which is reported once the device enrolled, and whether the spec can be
+	// rendered depends on the capabilities its agent reports
+	archChanged := beforeStatus.SystemInfo.Architecture != afterStatus.SystemInfo.Architecture && after.Spec != nil && hasArchitectureImages(&after.Spec.Data)
+	if archChanged || !reflect.DeepEqual(beforeStatus.SystemInfo.Capabilities, afterStatus.SystemInfo.Capabilities) {
+		ref := ResourceReference{OrgID: after.OrgID, Kind: model.DeviceKind, Name: after.Name}
+		t.submitTask(DeviceRenderTask, ref, DeviceRenderOpUpdate)
+	}
+}
+
+// DeviceStatusWebhookDelivery submits a notification of a webhook about a
+// device to be delivered, or to be retried once delivering it failed.
+func (t *callbackManager) DeviceStatusWebhookDelivery(orgId uuid.UUID, device string, delivery WebhookDelivery) {
+	ref := ResourceReference{OrgID: orgId, Kind: model.DeviceKind, Name: device, WebhookDelivery: &delivery}
+	t.submitTask(DeviceStatusWebhookTask, ref, DeviceStatusWebhookOpDeliver)
+}
+
+// hasArchitectureImages returns whether the spec has images depending on the
+// architecture of the device.
+func hasArchitectureImages(spec *api.DeviceSpec) bool {
+	if spec.Os != nil && len(lo.FromPtr(spec.Os.ArchitectureImages)) > 0 {
+		return true
+	}
+	for _, application := range lo.FromPtr(spec.Applications) {
+		if application.Pod == nil {
+			continue
+		}
+		for _, containers := range [][]api.ApplicationContainer{application.Pod.Containers, lo.FromPtr(application.Pod.InitContainers)} {
+			if lo.SomeBy(containers, func(c api.ApplicationContainer) bool { return len(lo.FromPtr(c.ArchitectureImages)) > 0 }) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *callbackManager) LabelRulesUpdatedCallback(orgId uuid.UUID) {
+	ref := ResourceReference{OrgID: orgId, Kind: model.LabelRuleKind}
+	t.submitTask(DeviceLabelRulesTask, ref, DeviceLabelRulesOpUpdateAll)
+}
+
 func (t *callbackManager) DeviceSourceUpdated(orgId uuid.UUID, name string) {
 	ref := ResourceReference{OrgID: orgId, Kind: model.DeviceKind, Name: name}
 	t.submitTask(DeviceRenderTask, ref, DeviceRenderOpUpdate)
 }
 
+func (t *callbackManager) OperationCreated(orgId uuid.UUID, name string) {
+	ref := ResourceReference{OrgID: orgId, Kind: model.OperationKind, Name: name}
+	t.submitTask(OperationRunTask, ref, OperationRunOpStart)
+}
+
 func (t *callbackManager) TemplateVersionCreatedCallback(templateVersion *model.TemplateVersion) {
 	resourceRef := ResourceReference{
 		OrgID: templateVersion.OrgID,
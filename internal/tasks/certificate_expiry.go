@@ -0,0 +1,165 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/remotewrite"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// TODO: make configurable
+	// CertificateExpiryWarningPeriod is how long before the expiry of its management certificate a device is reported as expiring.
+	CertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+	// CertificateExpiryPollingInterval is the interval at which the certificate expiry task runs.
+	CertificateExpiryPollingInterval = time.Hour
+
+	// CertificateExpiryMetric is the name of the series holding the expiry of each issued certificate as a Unix timestamp.
+	CertificateExpiryMetric = "flightctl_certificate_expiry_timestamp_seconds"
+)
+
+// CertificateExpiry reports devices whose management certificate expires soon
+// in their CertificateExpiring condition, so that webhooks can alert on it, and
+// exports the expiry of the issued certificates as metrics.
+type CertificateExpiry struct {
+	log       logrus.FieldLogger
+	store     store.Store
+	forwarder *remotewrite.Forwarder
+}
+
+// NewCertificateExpiry creates the task. The metrics are only exported if forwarder is not nil.
+func NewCertificateExpiry(log logrus.FieldLogger, store store.Store, forwarder *remotewrite.Forwarder) *CertificateExpiry {
+	return &CertificateExpiry{
+		log:       log,
+		store:     store,
+		forwarder: forwarder,
+	}
+}
+
+// Poll updates the conditions of the devices and exports the metrics.
+func (t *CertificateExpiry) Poll() {
+	t.log.Info("Running CertificateExpiry Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+	now := time.Now()
+
+	certificates, err := t.store.IssuedCertificate().List(ctx, orgID, store.IssuedCertificateListParams{})
+	if err != nil {
+		t.log.WithError(err).Error("failed to list issued certificates")
+		return
+	}
+	managementCertificates := map[string]*api.IssuedCertificate{}
+	for i := range certificates.Items {
+		certificate := &certificates.Items[i]
+		if certificate.Usage == api.IssuedCertificateUsageManagement && certificate.Device != nil {
+			managementCertificates[*certificate.Device] = certificate
+		}
+	}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		devices, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list devices")
+			return
+		}
+
+		for _, device := range devices.Items {
+			name := *device.Metadata.Name
+			certificate, ok := managementCertificates[name]
+			if !ok {
+				// enrolled before the service recorded the certificates it issues
+				continue
+			}
+			condition := certificateExpiringCondition(certificate, now)
+			existing := api.FindStatusCondition(lo.FromPtr(device.Status).Conditions, api.DeviceCertificateExpiring)
+			if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+				continue
+			}
+			if err := t.store.Device().SetServiceConditions(ctx, orgID, name, []api.Condition{condition}); err != nil {
+				t.log.WithError(err).Errorf("failed to update certificate condition of device %s", name)
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+
+	if t.forwarder != nil {
+		req := certificateExpirySeries(certificates.Items, now)
+		if err := t.forwarder.Write(ctx, req); err != nil {
+			t.log.WithError(err).Error("failed to export certificate expiry metrics")
+		}
+	}
+}
+
+// certificateExpiringCondition returns the device condition reflecting the
+// expiry of its management certificate.
+func certificateExpiringCondition(certificate *api.IssuedCertificate, now time.Time) api.Condition {
+	expiry := certificate.NotAfter.UTC().Format(time.RFC3339)
+	switch {
+	case !now.Before(certificate.NotAfter):
+		return api.Condition{
+			Type:    api.DeviceCertificateExpiring,
+			Status:  api.ConditionStatusTrue,
+			Reason:  "Expired",
+			Message: fmt.Sprintf("The management certificate expired on %s", expiry),
+		}
+	case now.Add(CertificateExpiryWarningPeriod).After(certificate.NotAfter):
+		return api.Condition{
+			Type:    api.DeviceCertificateExpiring,
+			Status:  api.ConditionStatusTrue,
+			Reason:  "Expiring",
+			Message: fmt.Sprintf("The management certificate expires on %s", expiry),
+		}
+	default:
+		return api.Condition{
+			Type:    api.DeviceCertificateExpiring,
+			Status:  api.ConditionStatusFalse,
+			Reason:  "Valid",
+			Message: fmt.Sprintf("The management certificate is valid until %s", expiry),
+		}
+	}
+}
+
+// certificateExpirySeries returns a series with the expiry of each certificate
+// in use. Expired enrollment certificates are left out, while the certificates
+// of devices remain until the devices re-enroll or are deleted.
+func certificateExpirySeries(certificates []api.IssuedCertificate, now time.Time) *remotewrite.WriteRequest {
+	req := &remotewrite.WriteRequest{}
+	for _, certificate := range certificates {
+		if certificate.Device == nil && !now.Before(certificate.NotAfter) {
+			continue
+		}
+		series := remotewrite.TimeSeries{
+			Samples: []remotewrite.Sample{{Value: float64(certificate.NotAfter.Unix()), Timestamp: now.UnixMilli()}},
+		}
+		remotewrite.SetLabel(&series, "__name__", CertificateExpiryMetric)
+		remotewrite.SetLabel(&series, "serial_number", certificate.SerialNumber)
+		remotewrite.SetLabel(&series, "common_name", certificate.CommonName)
+		remotewrite.SetLabel(&series, "usage", string(certificate.Usage))
+		if certificate.Owner != nil {
+			remotewrite.SetLabel(&series, "owner", *certificate.Owner)
+		}
+		if certificate.Device != nil {
+			remotewrite.SetLabel(&series, remotewrite.DeviceLabel, *certificate.Device)
+		}
+		req.Timeseries = append(req.Timeseries, series)
+	}
+	return req
+}
@@ -0,0 +1,71 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/remotewrite"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateExpiringCondition(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		notAfter       time.Time
+		expectedStatus api.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "valid",
+			notAfter:       now.Add(CertificateExpiryWarningPeriod + time.Hour),
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "Valid",
+		},
+		{
+			name:           "expiring",
+			notAfter:       now.Add(CertificateExpiryWarningPeriod - time.Hour),
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "Expiring",
+		},
+		{
+			name:           "expired",
+			notAfter:       now,
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "Expired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			condition := certificateExpiringCondition(&api.IssuedCertificate{NotAfter: tt.notAfter}, now)
+			require.Equal(api.DeviceCertificateExpiring, condition.Type)
+			require.Equal(tt.expectedStatus, condition.Status)
+			require.Equal(tt.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestCertificateExpirySeries(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	certificates := []api.IssuedCertificate{
+		{SerialNumber: "1", CommonName: "device", Usage: api.IssuedCertificateUsageManagement, Device: lo.ToPtr("device"), NotAfter: now.Add(-time.Hour)},
+		{SerialNumber: "2", CommonName: "client-enrollment", Usage: api.IssuedCertificateUsageEnrollment, Owner: lo.ToPtr("Fleet/fleet"), NotAfter: now.Add(time.Hour)},
+		{SerialNumber: "3", CommonName: "client-enrollment", Usage: api.IssuedCertificateUsageEnrollment, NotAfter: now.Add(-time.Hour)},
+	}
+
+	req := certificateExpirySeries(certificates, now)
+	require.Len(req.Timeseries, 2)
+
+	// expired device certificates are still exported
+	require.Contains(req.Timeseries[0].Labels, remotewrite.Label{Name: "__name__", Value: CertificateExpiryMetric})
+	require.Contains(req.Timeseries[0].Labels, remotewrite.Label{Name: remotewrite.DeviceLabel, Value: "device"})
+	require.Equal(float64(now.Add(-time.Hour).Unix()), req.Timeseries[0].Samples[0].Value)
+
+	require.Contains(req.Timeseries[1].Labels, remotewrite.Label{Name: "serial_number", Value: "2"})
+	require.Contains(req.Timeseries[1].Labels, remotewrite.Label{Name: "owner", Value: "Fleet/fleet"})
+	require.Contains(req.Timeseries[1].Labels, remotewrite.Label{Name: "usage", Value: "enrollment"})
+}
@@ -0,0 +1,129 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ClockSkewPollingInterval is the interval at which the clock skew task runs.
+	ClockSkewPollingInterval = 10 * time.Minute
+	// DefaultMaxClockSkew is the offset beyond which the clock of a device is
+	// skewed, unless its spec sets another one.
+	DefaultMaxClockSkew = 10 * time.Second
+)
+
+// ClockSkew compares the clocks of the devices with the clock of the service
+// and reports the result in their ClockSkewed condition. Skewed clocks break
+// the validation of TLS certificates and the correlation of logs.
+type ClockSkew struct {
+	log   logrus.FieldLogger
+	store store.Store
+}
+
+func NewClockSkew(log logrus.FieldLogger, store store.Store) *ClockSkew {
+	return &ClockSkew{
+		log:   log,
+		store: store,
+	}
+}
+
+// Poll updates the ClockSkewed condition of the devices.
+func (t *ClockSkew) Poll() {
+	t.log.Info("Running ClockSkew Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		devices, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list devices")
+			return
+		}
+
+		for _, device := range devices.Items {
+			name := *device.Metadata.Name
+			status := lo.FromPtr(device.Status)
+			existing := api.FindStatusCondition(status.Conditions, api.DeviceClockSkewed)
+			// agents which predate the time status are not flagged
+			if status.Time == nil && existing == nil {
+				continue
+			}
+			maxSkew := DefaultMaxClockSkew
+			if device.Spec != nil && device.Spec.Time != nil && device.Spec.Time.MaxSkewSeconds != nil {
+				maxSkew = time.Duration(*device.Spec.Time.MaxSkewSeconds) * time.Second
+			}
+			condition := clockSkewedCondition(maxSkew, status)
+			if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+				continue
+			}
+			if err := t.store.Device().SetServiceConditions(ctx, orgID, name, []api.Condition{condition}); err != nil {
+				t.log.WithError(err).Errorf("failed to update clock condition of device %s", name)
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+// clockSkewedCondition returns the device condition reflecting whether the
+// clock of the device is off by more than maxSkew. The skew is the larger of
+// the offset chrony estimates and the difference between the time the device
+// sent its status at and the time the service received it at, which detects
+// clocks that are not synchronized at all.
+func clockSkewedCondition(maxSkew time.Duration, status api.DeviceStatus) api.Condition {
+	condition := api.Condition{Type: api.DeviceClockSkewed, Status: api.ConditionStatusFalse}
+	if status.Time == nil {
+		condition.Status = api.ConditionStatusUnknown
+		condition.Reason = "NotReported"
+		condition.Message = "The device does not report the state of its clock"
+		return condition
+	}
+
+	skew := status.Time.ReportedAt.Sub(status.LastSeen)
+	if status.Time.Synchronized && status.Time.OffsetSeconds != nil {
+		offset := time.Duration(*status.Time.OffsetSeconds * float64(time.Second))
+		if offset.Abs() > skew.Abs() {
+			skew = offset
+		}
+	}
+
+	switch {
+	case skew.Abs() > maxSkew:
+		direction := "ahead of"
+		if skew < 0 {
+			direction = "behind"
+		}
+		condition.Status = api.ConditionStatusTrue
+		condition.Reason = "Skewed"
+		condition.Message = fmt.Sprintf("The clock of the device is %s %s the service, more than the allowed %s", skew.Abs().Round(time.Second), direction, maxSkew)
+		if !status.Time.Synchronized {
+			condition.Message += ", and is not synchronized with NTP"
+		}
+	case !status.Time.Synchronized:
+		condition.Reason = "NotSynchronized"
+		condition.Message = fmt.Sprintf("The clock of the device is within %s of the service, but is not synchronized with NTP", maxSkew)
+	default:
+		condition.Reason = "Synchronized"
+		condition.Message = fmt.Sprintf("The clock of the device is synchronized with %s", lo.FromPtrOr(status.Time.Source, "NTP"))
+	}
+	return condition
+}
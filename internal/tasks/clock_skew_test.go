@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewedCondition(t *testing.T) {
+	received := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		time           *api.DeviceTimeStatus
+		expectedStatus api.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "synchronized",
+			time:           &api.DeviceTimeStatus{Synchronized: true, Source: lo.ToPtr("ntp.example.com"), OffsetSeconds: lo.ToPtr(0.002), ReportedAt: received.Add(-300 * time.Millisecond)},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "Synchronized",
+		},
+		{
+			name:           "not reported",
+			expectedStatus: api.ConditionStatusUnknown,
+			expectedReason: "NotReported",
+		},
+		{
+			name:           "unsynchronized clock ahead of the service",
+			time:           &api.DeviceTimeStatus{ReportedAt: received.Add(2 * time.Minute)},
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "Skewed",
+		},
+		{
+			name:           "unsynchronized clock within the threshold",
+			time:           &api.DeviceTimeStatus{ReportedAt: received.Add(-3 * time.Second)},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "NotSynchronized",
+		},
+		{
+			name:           "offset estimated by chrony",
+			time:           &api.DeviceTimeStatus{Synchronized: true, OffsetSeconds: lo.ToPtr(-42.0), ReportedAt: received},
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "Skewed",
+		},
+		{
+			name:           "offset of an unsynchronized clock is ignored",
+			time:           &api.DeviceTimeStatus{OffsetSeconds: lo.ToPtr(-42.0), ReportedAt: received},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "NotSynchronized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			status := api.NewDeviceStatus()
+			status.LastSeen = received
+			status.Time = tt.time
+			condition := clockSkewedCondition(DefaultMaxClockSkew, status)
+			require.Equal(api.DeviceClockSkewed, condition.Type)
+			require.Equal(tt.expectedStatus, condition.Status)
+			require.Equal(tt.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestClockSkewedConditionMessage(t *testing.T) {
+	received := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	status := api.NewDeviceStatus()
+	status.LastSeen = received
+	status.Time = &api.DeviceTimeStatus{ReportedAt: received.Add(-90 * time.Second)}
+
+	condition := clockSkewedCondition(time.Minute, status)
+	require.Equal(t, "The clock of the device is 1m30s behind the service, more than the allowed 1m0s, and is not synchronized with NTP", condition.Message)
+}
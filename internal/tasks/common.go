@@ -21,6 +21,11 @@ const (
 	DeviceRenderOpUpdate              = "update"
 	RepositoryUpdateOpUpdate          = "update"
 	RepositoryUpdateOpDeleteAll       = "delete-all"
+	DeviceStatusWebhookOpTransition   = "transition"
+	DeviceStatusWebhookOpDeliver      = "deliver"
+	DeviceLabelRulesOpUpdate          = "update"
+	DeviceLabelRulesOpUpdateAll       = "update-all"
+	OperationRunOpStart               = "start"
 )
 
 type ResourceReference struct {
@@ -30,6 +35,11 @@ type ResourceReference struct {
 	Kind     string
 	Name     string
 	Owner    string
+
+	// StatusTransitions is only set for DeviceStatusWebhookTask
+	StatusTransitions []DeviceStatusTransition `json:",omitempty"`
+	// WebhookDelivery is only set for the deliveries of DeviceStatusWebhookTask
+	WebhookDelivery *WebhookDelivery `json:",omitempty"`
 }
 
 var ErrUnknownConfigName = errors.New("failed to find configuration item name")
@@ -12,6 +12,8 @@ var (
 	paramsRegex *regexp.Regexp = regexp.MustCompile(`(?P<full>{{\w*(?P<param>.*?)\w*}})`)
 	labelRegex  *regexp.Regexp = regexp.MustCompile(`^(?P<full>{{\s*(?:(?P<label>device\.metadata\.labels\[(?P<key>.*)\]))\s*}})$`)
 	nameRegex   *regexp.Regexp = regexp.MustCompile(`^(?P<full>{{\s*(?:(?P<name>device\.metadata\.name))\s*}})$`)
+	// inventoryRegex matches the parameters looked up in the inventory system
+	inventoryRegex *regexp.Regexp = regexp.MustCompile(`^(?P<full>{{\s*(?:(?P<inventory>device\.inventory\[(?P<key>.*)\]))\s*}})$`)
 )
 
 func ContainsParameter(b []byte) bool {
@@ -19,17 +21,41 @@ func ContainsParameter(b []byte) bool {
 }
 
 func ValidateParameterFormat(b []byte) error {
+	if invalid := InvalidParameters(b); len(invalid) > 0 {
+		return fmt.Errorf("invalid parameter: %s", invalid[0])
+	}
+	return nil
+}
+
+// ContainsInventoryParameter returns whether any parameter is looked up in the
+// inventory system.
+func ContainsInventoryParameter(b []byte) bool {
+	for _, match := range paramsRegex.FindAllString(string(b), -1) {
+		if inventoryRegex.MatchString(match) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidParameters returns the parameters that are neither the name, a
+// label nor an inventory value of the device, in the order they appear.
+func InvalidParameters(b []byte) []string {
+	invalid := []string{}
 	matches := paramsRegex.FindAllStringSubmatch(string(b), -1)
 	for _, match := range matches {
 		param := match[0]
-		if !labelRegex.MatchString(param) && !nameRegex.MatchString(param) {
-			return fmt.Errorf("invalid parameter: %s", param)
+		if !labelRegex.MatchString(param) && !nameRegex.MatchString(param) && !inventoryRegex.MatchString(param) {
+			invalid = append(invalid, param)
 		}
 	}
-	return nil
+	return invalid
 }
 
-func ReplaceParameters(b []byte, objectMeta api.ObjectMeta) ([]byte, []string) {
+// ReplaceParameters replaces the parameters with the name and the labels of
+// the device and with the values looked up for it in the inventory system,
+// which is nil if none is configured.
+func ReplaceParameters(b []byte, objectMeta api.ObjectMeta, inventory map[string]string) ([]byte, []string) {
 	replacements := map[string]string{}
 	paramsToMatches := map[string]string{}
 
@@ -49,7 +75,7 @@ func ReplaceParameters(b []byte, objectMeta api.ObjectMeta) ([]byte, []string) {
 			}
 			replacements[match] = *objectMeta.Name
 		case labelRegex.MatchString(param):
-			key, err := findKeyinLabelParam(param)
+			key, err := findKeyInParam(labelRegex, param)
 			if err != nil {
 				warnings = append(warnings, err.Error())
 				continue
@@ -64,6 +90,22 @@ func ReplaceParameters(b []byte, objectMeta api.ObjectMeta) ([]byte, []string) {
 				continue
 			}
 			replacements[match] = val
+		case inventoryRegex.MatchString(param):
+			key, err := findKeyInParam(inventoryRegex, param)
+			if err != nil {
+				warnings = append(warnings, err.Error())
+				continue
+			}
+			if inventory == nil {
+				warnings = append(warnings, fmt.Sprintf("parameter referenced inventory value %s, but no inventory system is configured", key))
+				continue
+			}
+			val, ok := inventory[key]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("no inventory value found with key %s", key))
+				continue
+			}
+			replacements[match] = val
 		default:
 			warnings = append(warnings, fmt.Sprintf("found unknown parameter: %s", param))
 		}
@@ -77,12 +119,12 @@ func ReplaceParameters(b []byte, objectMeta api.ObjectMeta) ([]byte, []string) {
 	return []byte(outputStr), warnings
 }
 
-func findKeyinLabelParam(param string) (string, error) {
-	matches := labelRegex.FindStringSubmatch(param)
-	for i, name := range labelRegex.SubexpNames() {
+func findKeyInParam(regex *regexp.Regexp, param string) (string, error) {
+	matches := regex.FindStringSubmatch(param)
+	for i, name := range regex.SubexpNames() {
 		if name == "key" {
 			return matches[i], nil
 		}
 	}
-	return "", fmt.Errorf("could not find label key in param %s", param)
+	return "", fmt.Errorf("could not find key in param %s", param)
 }
@@ -21,7 +21,7 @@ var _ = Describe("config helpers", func() {
 			configItem := "ignition blah blah {{ device.metadata.labels[key]}} blah blah {{ device.metadata.labels[key2] }} blah {{ device.metadata.name }} ok"
 			labels := map[string]string{"key": "val", "key2": "val2", "otherkey": "otherval"}
 			meta := api.ObjectMeta{Labels: &labels, Name: util.StrToPtr("devname")}
-			new, warnings := ReplaceParameters([]byte(configItem), meta)
+			new, warnings := ReplaceParameters([]byte(configItem), meta, nil)
 			Expect(warnings).To(HaveLen(0))
 			Expect(string(new)).To(Equal("ignition blah blah val blah blah val2 blah devname ok"))
 		})
@@ -32,7 +32,7 @@ var _ = Describe("config helpers", func() {
 			configItem := "ignition blah blah {{ device.metadata.labels[key]}} blah blah {{ device.metadata.labels[key2] }} blah"
 			labels := map[string]string{"key": "val", "otherkey": "otherval"}
 			meta := api.ObjectMeta{Labels: &labels, Name: util.StrToPtr("devname")}
-			_, warnings := ReplaceParameters([]byte(configItem), meta)
+			_, warnings := ReplaceParameters([]byte(configItem), meta, nil)
 			Expect(warnings).To(HaveLen(1))
 		})
 	})
@@ -42,11 +42,32 @@ var _ = Describe("config helpers", func() {
 			configItem := "ignition blah blah {{ device.metadata.labels[key]}} blah blah {{ device.metadata.name }} blah"
 			labels := map[string]string{"key": "val", "otherkey": "otherval"}
 			meta := api.ObjectMeta{Labels: &labels}
-			_, warnings := ReplaceParameters([]byte(configItem), meta)
+			_, warnings := ReplaceParameters([]byte(configItem), meta, nil)
 			Expect(warnings).To(HaveLen(1))
 		})
 	})
 
+	When("the config references inventory values", func() {
+		It("will replace them with the values of the device", func() {
+			configItem := "ignition blah blah {{ device.inventory[rack] }} blah {{ device.metadata.name }} ok"
+			meta := api.ObjectMeta{Name: util.StrToPtr("devname")}
+			Expect(ContainsInventoryParameter([]byte(configItem))).To(BeTrue())
+			new, warnings := ReplaceParameters([]byte(configItem), meta, map[string]string{"rack": "r12"})
+			Expect(warnings).To(HaveLen(0))
+			Expect(string(new)).To(Equal("ignition blah blah r12 blah devname ok"))
+		})
+
+		It("will return an error if the value is missing or no inventory system is configured", func() {
+			configItem := "ignition blah blah {{ device.inventory[rack] }} blah"
+			meta := api.ObjectMeta{Name: util.StrToPtr("devname")}
+			_, warnings := ReplaceParameters([]byte(configItem), meta, map[string]string{})
+			Expect(warnings).To(Equal([]string{"no inventory value found with key rack"}))
+			_, warnings = ReplaceParameters([]byte(configItem), meta, nil)
+			Expect(warnings).To(Equal([]string{"parameter referenced inventory value rack, but no inventory system is configured"}))
+			Expect(ContainsInventoryParameter([]byte("ignition blah blah {{ device.metadata.labels[rack] }} blah"))).To(BeFalse())
+		})
+	})
+
 	When("the config has an invalid parameter", func() {
 		It("will return a validation error", func() {
 			configItems := []string{
@@ -62,6 +83,7 @@ var _ = Describe("config helpers", func() {
 				"ignition blah blah {{ device.metadata.labels[x]x }} blah",
 				"ignition blah blah {{ xdevice.metadata.labels[x] }} blah",
 				"ignition blah blah {{ hello }} blah",
+				"ignition blah blah {{ inventory[x] }} blah",
 			}
 			for _, configItem := range configItems {
 				err := ValidateParameterFormat([]byte(configItem))
@@ -75,6 +97,7 @@ var _ = Describe("config helpers", func() {
 			configItems := []string{
 				"ignition blah blah {{ device.metadata.name  }} blah",
 				"ignition blah blah {{  device.metadata.labels[x] }} blah",
+				"ignition blah blah {{ device.inventory[x] }} blah",
 			}
 			for _, configItem := range configItems {
 				fmt.Printf("testing: %s\n", configItem)
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/inventory"
+	"github.com/flightctl/flightctl/internal/specdelivery"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/pkg/k8sclient"
 	"github.com/flightctl/flightctl/pkg/queues"
@@ -13,7 +16,7 @@ import (
 
 const TaskQueue = "task-queue"
 
-func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient) queues.ConsumeHandler {
+func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient, inventory *inventory.Client, specDelivery *specdelivery.Publisher, webhookDedup *webhookDedup, labelSchemas []config.LabelSchema) queues.ConsumeHandler {
 	return func(ctx context.Context, payload []byte, log logrus.FieldLogger) error {
 		var reference ResourceReference
 		if err := json.Unmarshal(payload, &reference); err != nil {
@@ -24,7 +27,7 @@ func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient
 			reference.TaskName, reference.Op, reference.Kind, reference.OrgID, reference.Name)
 		switch reference.TaskName {
 		case FleetRolloutTask:
-			return fleetRollout(ctx, &reference, store, callbackManager, log)
+			return fleetRollout(ctx, &reference, store, callbackManager, inventory, log)
 		case FleetSelectorMatchTask:
 			return fleetSelectorMatching(ctx, &reference, store, callbackManager, log)
 		case TemplateVersionPopulateTask:
@@ -32,9 +35,15 @@ func dispatchTasks(store store.Store, callbackManager CallbackManager, k8sClient
 		case FleetValidateTask:
 			return fleetValidate(ctx, &reference, store, callbackManager, k8sClient, log)
 		case DeviceRenderTask:
-			return deviceRender(ctx, &reference, store, callbackManager, k8sClient, log)
+			return deviceRender(ctx, &reference, store, callbackManager, k8sClient, specDelivery, log)
 		case RepositoryUpdatesTask:
 			return repositoryUpdate(ctx, &reference, store, callbackManager, log)
+		case DeviceStatusWebhookTask:
+			return deviceStatusWebhook(ctx, &reference, store, callbackManager, webhookDedup, log)
+		case DeviceLabelRulesTask:
+			return deviceLabelRules(ctx, &reference, store, callbackManager, labelSchemas, log)
+		case OperationRunTask:
+			return runOperation(ctx, &reference, store, callbackManager, log)
 		default:
 			return fmt.Errorf("unexpected task name %s", reference.TaskName)
 		}
@@ -46,14 +55,18 @@ func LaunchConsumers(ctx context.Context,
 	store store.Store,
 	callbackManager CallbackManager,
 	k8sClient k8sclient.K8SClient,
+	inventory *inventory.Client,
+	specDelivery *specdelivery.Publisher,
+	labelSchemas []config.LabelSchema,
 	numConsumers, threadsPerConsumer int) error {
+	webhookDedup := newWebhookDedup()
 	for i := 0; i != numConsumers; i++ {
 		consumer, err := provider.NewConsumer(TaskQueue)
 		if err != nil {
 			return err
 		}
 		for j := 0; j != threadsPerConsumer; j++ {
-			if err = consumer.Consume(ctx, dispatchTasks(store, callbackManager, k8sClient)); err != nil {
+			if err = consumer.Consume(ctx, dispatchTasks(store, callbackManager, k8sClient, inventory, specDelivery, webhookDedup, labelSchemas)); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// CryptoCompliancePollingInterval is the interval at which the crypto compliance task runs.
+const CryptoCompliancePollingInterval = 10 * time.Minute
+
+// CryptoCompliance checks the crypto configuration the devices report against
+// the crypto requirements of their spec, and reports the result in their
+// CryptoCompliant condition so that webhooks can alert on non-compliant devices.
+type CryptoCompliance struct {
+	log   logrus.FieldLogger
+	store store.Store
+}
+
+func NewCryptoCompliance(log logrus.FieldLogger, store store.Store) *CryptoCompliance {
+	return &CryptoCompliance{
+		log:   log,
+		store: store,
+	}
+}
+
+// Poll updates the CryptoCompliant condition of the devices.
+func (t *CryptoCompliance) Poll() {
+	t.log.Info("Running CryptoCompliance Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		devices, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list devices")
+			return
+		}
+
+		for _, device := range devices.Items {
+			name := *device.Metadata.Name
+			existing := api.FindStatusCondition(lo.FromPtr(device.Status).Conditions, api.DeviceCryptoCompliant)
+			var requirements *api.DeviceCryptoSpec
+			if device.Spec != nil {
+				requirements = device.Spec.Crypto
+			}
+			if requirements == nil && existing == nil {
+				continue
+			}
+			condition := cryptoCompliantCondition(requirements, lo.FromPtr(device.Status).SystemInfo.Crypto)
+			if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+				continue
+			}
+			if err := t.store.Device().SetServiceConditions(ctx, orgID, name, []api.Condition{condition}); err != nil {
+				t.log.WithError(err).Errorf("failed to update crypto condition of device %s", name)
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+// cryptoCompliantCondition returns the device condition reflecting whether the
+// reported crypto configuration meets the requirements.
+func cryptoCompliantCondition(requirements *api.DeviceCryptoSpec, info *api.DeviceCryptoInfo) api.Condition {
+	condition := api.Condition{Type: api.DeviceCryptoCompliant, Status: api.ConditionStatusFalse}
+	switch {
+	case requirements == nil:
+		// the requirements were removed from the spec
+		condition.Status = api.ConditionStatusTrue
+		condition.Reason = "NotRequired"
+		condition.Message = "The device spec sets no crypto requirements"
+	case info == nil:
+		condition.Status = api.ConditionStatusUnknown
+		condition.Reason = "NotReported"
+		condition.Message = "The device does not report its crypto configuration"
+	case lo.FromPtr(requirements.RequireFips) && !info.FipsEnabled:
+		condition.Reason = "FIPSDisabled"
+		condition.Message = "The device does not run in FIPS mode"
+	case lo.FromPtr(requirements.RequireFips) && !info.AgentFips:
+		condition.Reason = "AgentNotFIPS"
+		condition.Message = "The agent of the device is not built with FIPS-validated crypto"
+	case requirements.AllowedPolicies != nil && !lo.Contains(*requirements.AllowedPolicies, lo.FromPtr(info.Policy)):
+		condition.Reason = "PolicyNotAllowed"
+		condition.Message = fmt.Sprintf("The crypto policy %q of the device is not one of %s", lo.FromPtr(info.Policy), strings.Join(*requirements.AllowedPolicies, ", "))
+	default:
+		condition.Status = api.ConditionStatusTrue
+		condition.Reason = "Compliant"
+		condition.Message = "The device meets the crypto requirements"
+	}
+	return condition
+}
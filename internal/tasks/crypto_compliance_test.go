@@ -0,0 +1,76 @@
+package tasks
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoCompliantCondition(t *testing.T) {
+	fipsDevice := &api.DeviceCryptoInfo{FipsEnabled: true, AgentFips: true, Policy: lo.ToPtr("FIPS")}
+	tests := []struct {
+		name           string
+		requirements   *api.DeviceCryptoSpec
+		info           *api.DeviceCryptoInfo
+		expectedStatus api.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "compliant",
+			requirements:   &api.DeviceCryptoSpec{RequireFips: lo.ToPtr(true), AllowedPolicies: &[]string{"FIPS", "FIPS:OSPP"}},
+			info:           fipsDevice,
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "Compliant",
+		},
+		{
+			name:           "not reported",
+			requirements:   &api.DeviceCryptoSpec{RequireFips: lo.ToPtr(true)},
+			expectedStatus: api.ConditionStatusUnknown,
+			expectedReason: "NotReported",
+		},
+		{
+			name:           "kernel not in FIPS mode",
+			requirements:   &api.DeviceCryptoSpec{RequireFips: lo.ToPtr(true)},
+			info:           &api.DeviceCryptoInfo{AgentFips: true, Policy: lo.ToPtr("DEFAULT")},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "FIPSDisabled",
+		},
+		{
+			name:           "agent without FIPS-validated crypto",
+			requirements:   &api.DeviceCryptoSpec{RequireFips: lo.ToPtr(true)},
+			info:           &api.DeviceCryptoInfo{FipsEnabled: true, Policy: lo.ToPtr("FIPS")},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "AgentNotFIPS",
+		},
+		{
+			name:           "policy not allowed",
+			requirements:   &api.DeviceCryptoSpec{AllowedPolicies: &[]string{"FUTURE"}},
+			info:           fipsDevice,
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "PolicyNotAllowed",
+		},
+		{
+			name:           "device without crypto policies",
+			requirements:   &api.DeviceCryptoSpec{AllowedPolicies: &[]string{"DEFAULT"}},
+			info:           &api.DeviceCryptoInfo{},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "PolicyNotAllowed",
+		},
+		{
+			name:           "requirements removed",
+			info:           &api.DeviceCryptoInfo{},
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "NotRequired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := cryptoCompliantCondition(tt.requirements, tt.info)
+			require.Equal(t, api.DeviceCryptoCompliant, condition.Type)
+			require.Equal(t, tt.expectedStatus, condition.Status)
+			require.Equal(t, tt.expectedReason, condition.Reason)
+		})
+	}
+}
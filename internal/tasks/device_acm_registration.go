@@ -0,0 +1,220 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/k8sclient"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DeviceLabelManagedCluster opts a device running MicroShift into being registered as a managed cluster of ACM.
+	DeviceLabelManagedCluster = "flightctl.io/managed-cluster"
+	// ACMRegistrationPollingInterval is the interval at which the ACM registration task runs.
+	ACMRegistrationPollingInterval = 2 * time.Minute
+
+	managedClusterLabelManagedBy = "flightctl.io/managed-by"
+	managedClusterSelector       = managedClusterLabelManagedBy + "=flightctl"
+
+	// the default hooks of the agent apply the manifests in this directory to MicroShift
+	klusterletManifestsPath = "/var/usr/klusterlet-manifests"
+
+	// condition types of an Open Cluster Management ManagedCluster
+	managedClusterConditionAvailable = "ManagedClusterConditionAvailable"
+	managedClusterConditionJoined    = "ManagedClusterJoined"
+)
+
+// ACMRegistration registers the devices labeled with DeviceLabelManagedCluster
+// as managed clusters of an ACM hub and reports the availability of the
+// clusters in the status of the devices.
+type ACMRegistration struct {
+	log             logrus.FieldLogger
+	store           store.Store
+	callbackManager CallbackManager
+	hub             k8sclient.ManagedClusterClient
+}
+
+func NewACMRegistration(log logrus.FieldLogger, store store.Store, callbackManager CallbackManager, hub k8sclient.ManagedClusterClient) *ACMRegistration {
+	return &ACMRegistration{
+		log:             log,
+		store:           store,
+		callbackManager: callbackManager,
+		hub:             hub,
+	}
+}
+
+// Poll creates the managed clusters of new devices, updates the status of the
+// registered devices, and deletes the managed clusters of devices which are no
+// longer labeled.
+func (t *ACMRegistration) Poll() {
+	t.log.Info("Running ACMRegistration Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	clusters, err := t.hub.ListManagedClusters(ctx, managedClusterSelector)
+	if err != nil {
+		t.log.WithError(err).Error("failed to list managed clusters")
+		return
+	}
+	clustersByName := lo.SliceToMap(clusters, func(c k8sclient.ManagedCluster) (string, *k8sclient.ManagedCluster) {
+		return c.Name, &c
+	})
+
+	registered := map[string]bool{}
+	listParams := store.ListParams{Limit: ItemsPerPage, Labels: map[string]string{DeviceLabelManagedCluster: "true"}}
+	for {
+		devices, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list devices")
+			return
+		}
+
+		for i := range devices.Items {
+			name := *devices.Items[i].Metadata.Name
+			registered[name] = true
+			if err := t.register(ctx, orgID, &devices.Items[i], clustersByName[name]); err != nil {
+				t.log.WithError(err).Errorf("failed to register device %s as managed cluster", name)
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+
+	for _, cluster := range clusters {
+		if registered[cluster.Name] {
+			continue
+		}
+		if err := t.deregister(ctx, orgID, cluster.Name); err != nil {
+			t.log.WithError(err).Errorf("failed to deregister managed cluster %s", cluster.Name)
+		}
+	}
+}
+
+func (t *ACMRegistration) register(ctx context.Context, orgID uuid.UUID, device *api.Device, cluster *k8sclient.ManagedCluster) error {
+	name := *device.Metadata.Name
+	if cluster == nil {
+		labels := map[string]string{
+			managedClusterLabelManagedBy: "flightctl",
+			"cloud":                      "auto-detect",
+			"vendor":                     "auto-detect",
+		}
+		if err := t.hub.CreateManagedCluster(ctx, name, labels); err != nil {
+			return err
+		}
+		t.log.Infof("created managed cluster for device %s/%s", orgID, name)
+	}
+
+	// the rendered spec of the device includes the klusterlet manifests once annotated
+	if lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationManagedCluster] != name {
+		annotations := map[string]string{model.DeviceAnnotationManagedCluster: name}
+		if err := t.store.Device().UpdateAnnotations(ctx, orgID, name, annotations, nil); err != nil {
+			return fmt.Errorf("failed to annotate device: %w", err)
+		}
+		t.callbackManager.DeviceSourceUpdated(orgID, name)
+	}
+
+	condition := managedClusterCondition(cluster)
+	existing := api.FindStatusCondition(lo.FromPtr(device.Status).Conditions, api.DeviceManagedClusterAvailable)
+	if existing != nil && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+	return t.store.Device().SetServiceConditions(ctx, orgID, name, []api.Condition{condition})
+}
+
+func (t *ACMRegistration) deregister(ctx context.Context, orgID uuid.UUID, name string) error {
+	if err := t.hub.DeleteManagedCluster(ctx, name); err != nil {
+		return err
+	}
+	t.log.Infof("deleted managed cluster of device %s/%s", orgID, name)
+
+	err := t.store.Device().UpdateAnnotations(ctx, orgID, name, nil, []string{model.DeviceAnnotationManagedCluster})
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		// the device was deleted
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to remove device annotation: %w", err)
+	}
+	t.callbackManager.DeviceSourceUpdated(orgID, name)
+	condition := api.Condition{
+		Type:    api.DeviceManagedClusterAvailable,
+		Status:  api.ConditionStatusFalse,
+		Reason:  "Deregistered",
+		Message: "The device is no longer registered as a managed cluster",
+	}
+	return t.store.Device().SetServiceConditions(ctx, orgID, name, []api.Condition{condition})
+}
+
+// managedClusterCondition returns the device condition reflecting the
+// availability of its managed cluster. The cluster is nil if it was just created.
+func managedClusterCondition(cluster *k8sclient.ManagedCluster) api.Condition {
+	condition := api.Condition{Type: api.DeviceManagedClusterAvailable, Status: api.ConditionStatusUnknown}
+	if cluster == nil {
+		condition.Reason = "Registering"
+		condition.Message = "The managed cluster was created"
+		return condition
+	}
+
+	if available := findManagedClusterCondition(cluster.Conditions, managedClusterConditionAvailable); available != nil {
+		condition.Status = api.ConditionStatus(available.Status)
+		condition.Reason = available.Reason
+		condition.Message = available.Message
+		return condition
+	}
+	if joined := findManagedClusterCondition(cluster.Conditions, managedClusterConditionJoined); joined == nil || joined.Status != metav1.ConditionTrue {
+		condition.Status = api.ConditionStatusFalse
+		condition.Reason = "NotJoined"
+		condition.Message = "The klusterlet of the device has not joined the hub"
+		return condition
+	}
+	condition.Reason = "Joined"
+	condition.Message = "The klusterlet of the device joined the hub"
+	return condition
+}
+
+func findManagedClusterCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// klusterletConfigItem returns the config item which installs the klusterlet
+// manifests that the hub generates in the import secret of a managed cluster.
+func klusterletConfigItem(clusterName string) (*api.DeviceSpec_Config_Item, error) {
+	spec := api.KubernetesSecretProviderSpec{
+		ConfigType: string(api.TemplateDiscriminatorKubernetesSec),
+		Name:       "acm-klusterlet",
+	}
+	spec.SecretRef.Name = clusterName + "-import"
+	spec.SecretRef.Namespace = clusterName
+	spec.SecretRef.MountPath = klusterletManifestsPath
+
+	var item api.DeviceSpec_Config_Item
+	if err := item.FromKubernetesSecretProviderSpec(spec); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
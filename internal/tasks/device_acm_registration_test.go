@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/k8sclient"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestManagedClusterCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		cluster        *k8sclient.ManagedCluster
+		expectedStatus api.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "just created",
+			expectedStatus: api.ConditionStatusUnknown,
+			expectedReason: "Registering",
+		},
+		{
+			name:           "not joined",
+			cluster:        &k8sclient.ManagedCluster{Name: "device"},
+			expectedStatus: api.ConditionStatusFalse,
+			expectedReason: "NotJoined",
+		},
+		{
+			name: "joined",
+			cluster: &k8sclient.ManagedCluster{Name: "device", Conditions: []metav1.Condition{
+				{Type: managedClusterConditionJoined, Status: metav1.ConditionTrue, Reason: "ManagedClusterJoined"},
+			}},
+			expectedStatus: api.ConditionStatusUnknown,
+			expectedReason: "Joined",
+		},
+		{
+			name: "available",
+			cluster: &k8sclient.ManagedCluster{Name: "device", Conditions: []metav1.Condition{
+				{Type: managedClusterConditionJoined, Status: metav1.ConditionTrue, Reason: "ManagedClusterJoined"},
+				{Type: managedClusterConditionAvailable, Status: metav1.ConditionTrue, Reason: "ManagedClusterAvailable"},
+			}},
+			expectedStatus: api.ConditionStatusTrue,
+			expectedReason: "ManagedClusterAvailable",
+		},
+		{
+			name: "lease expired",
+			cluster: &k8sclient.ManagedCluster{Name: "device", Conditions: []metav1.Condition{
+				{Type: managedClusterConditionJoined, Status: metav1.ConditionTrue, Reason: "ManagedClusterJoined"},
+				{Type: managedClusterConditionAvailable, Status: metav1.ConditionUnknown, Reason: "ManagedClusterLeaseUpdateStopped"},
+			}},
+			expectedStatus: api.ConditionStatusUnknown,
+			expectedReason: "ManagedClusterLeaseUpdateStopped",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			condition := managedClusterCondition(tt.cluster)
+			require.Equal(api.DeviceManagedClusterAvailable, condition.Type)
+			require.Equal(tt.expectedStatus, condition.Status)
+			require.Equal(tt.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func TestKlusterletConfigItem(t *testing.T) {
+	require := require.New(t)
+
+	item, err := klusterletConfigItem("device")
+	require.NoError(err)
+	spec, err := item.AsKubernetesSecretProviderSpec()
+	require.NoError(err)
+	require.Equal(string(api.TemplateDiscriminatorKubernetesSec), spec.ConfigType)
+	require.Equal("device-import", spec.SecretRef.Name)
+	require.Equal("device", spec.SecretRef.Namespace)
+	require.Equal(klusterletManifestsPath, spec.SecretRef.MountPath)
+}
@@ -0,0 +1,54 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var ErrInsufficientResources = errors.New("the applications exceed the resources of the device")
+
+// admitApplications checks that the resource limits of the applications of the
+// device spec add up to no more than the CPUs and memory the device reports,
+// so that the device is not rendered a spec it cannot run. Devices which have
+// not reported their hardware yet are admitted.
+func admitApplications(device *api.Device) error {
+	if device.Spec == nil || device.Status == nil || device.Status.SystemInfo.Hardware == nil {
+		return nil
+	}
+	hardware := device.Status.SystemInfo.Hardware
+
+	cpu := resource.Quantity{}
+	memory := resource.Quantity{}
+	for _, application := range lo.FromPtr(device.Spec.Applications) {
+		if application.Resources == nil {
+			continue
+		}
+		if application.Resources.Cpu != nil {
+			quantity, err := resource.ParseQuantity(*application.Resources.Cpu)
+			if err != nil {
+				return fmt.Errorf("application %s: invalid CPU limit: %w", application.Name, err)
+			}
+			cpu.Add(quantity)
+		}
+		if application.Resources.Memory != nil {
+			quantity, err := resource.ParseQuantity(*application.Resources.Memory)
+			if err != nil {
+				return fmt.Errorf("application %s: invalid memory limit: %w", application.Name, err)
+			}
+			memory.Add(quantity)
+		}
+	}
+
+	if hardware.Cpu.Cores > 0 && cpu.Cmp(*resource.NewQuantity(int64(hardware.Cpu.Cores), resource.DecimalSI)) > 0 {
+		return fmt.Errorf("%w: the applications request %s CPUs, the device has %d", ErrInsufficientResources, cpu.String(), hardware.Cpu.Cores)
+	}
+	if hardware.MemoryBytes > 0 && memory.Cmp(*resource.NewQuantity(hardware.MemoryBytes, resource.BinarySI)) > 0 {
+		return fmt.Errorf("%w: the applications request %s of memory, the device has %s", ErrInsufficientResources,
+			memory.String(), resource.NewQuantity(hardware.MemoryBytes, resource.BinarySI).String())
+	}
+	return nil
+}
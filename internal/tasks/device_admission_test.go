@@ -0,0 +1,68 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmitApplications(t *testing.T) {
+	hardware := &api.DeviceHardwareInfo{Cpu: api.DeviceCPUInfo{Cores: 2}, MemoryBytes: 2 << 30}
+	application := func(name string, cpu string, memory string) api.ApplicationSpec {
+		resources := &api.ApplicationResources{}
+		if cpu != "" {
+			resources.Cpu = lo.ToPtr(cpu)
+		}
+		if memory != "" {
+			resources.Memory = lo.ToPtr(memory)
+		}
+		return api.ApplicationSpec{Name: name, Path: lo.ToPtr("/etc/compose/" + name + ".yaml"), Resources: resources}
+	}
+	tests := []struct {
+		name         string
+		hardware     *api.DeviceHardwareInfo
+		applications []api.ApplicationSpec
+		wantErr      string
+	}{
+		{
+			name:         "fits the device",
+			hardware:     hardware,
+			applications: []api.ApplicationSpec{application("web", "1.5", "1Gi"), application("db", "500m", "1Gi"), {Name: "jobs"}},
+		},
+		{
+			name:         "exceeds the CPUs",
+			hardware:     hardware,
+			applications: []api.ApplicationSpec{application("web", "1.5", ""), application("db", "600m", "")},
+			wantErr:      "the applications request 2100m CPUs, the device has 2",
+		},
+		{
+			name:         "exceeds the memory",
+			hardware:     hardware,
+			applications: []api.ApplicationSpec{application("web", "", "1.5Gi"), application("db", "", "1Gi")},
+			wantErr:      "the applications request 2560Mi of memory, the device has 2Gi",
+		},
+		{
+			name:         "hardware not reported",
+			applications: []api.ApplicationSpec{application("web", "64", "")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			device := &api.Device{
+				Spec:   &api.DeviceSpec{Applications: &tt.applications},
+				Status: &api.DeviceStatus{SystemInfo: api.DeviceSystemInfo{Hardware: tt.hardware}},
+			}
+			err := admitApplications(device)
+			if tt.wantErr == "" {
+				require.NoError(err)
+				return
+			}
+			require.True(errors.Is(err, ErrInsufficientResources))
+			require.ErrorContains(err, tt.wantErr)
+		})
+	}
+}
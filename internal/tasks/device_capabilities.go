@@ -0,0 +1,66 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+)
+
+var ErrUnsupportedFeatures = errors.New("the device does not support features of its spec")
+
+// checkCapabilities checks that the agent of the device reported the
+// capabilities the features of the device spec require, so that the device is
+// not rendered a spec its agent would fail to apply. Devices whose agents
+// predate capability reporting are not checked.
+func checkCapabilities(device *api.Device) error {
+	if device.Spec == nil || device.Status == nil || device.Status.SystemInfo.Capabilities == nil {
+		return nil
+	}
+	spec := device.Spec
+	required := map[string]api.DeviceCapability{}
+	if spec.Os != nil {
+		required["spec.os"] = api.DeviceCapabilityBootcOSImage
+	}
+	for _, application := range lo.FromPtr(spec.Applications) {
+		if application.Path != nil {
+			required[fmt.Sprintf("application %s", application.Name)] = api.DeviceCapabilityComposeApplications
+		}
+		if application.Pod != nil {
+			required[fmt.Sprintf("application %s", application.Name)] = api.DeviceCapabilityPodApplications
+		}
+	}
+	if spec.Encryption != nil {
+		required["spec.encryption"] = api.DeviceCapabilityDiskEncryption
+	}
+	if spec.Time != nil {
+		required["spec.time"] = api.DeviceCapabilityTimeSync
+	}
+	if spec.Compliance != nil {
+		required["spec.compliance"] = api.DeviceCapabilityComplianceScans
+	}
+	if spec.Firewall != nil {
+		required["spec.firewall"] = api.DeviceCapabilityFirewall
+	}
+	if spec.GarbageCollection != nil {
+		required["spec.garbageCollection"] = api.DeviceCapabilityGarbageCollection
+	}
+	if spec.Agent != nil && spec.Agent.Update != nil {
+		required["spec.agent.update"] = api.DeviceCapabilityAgentUpdate
+	}
+
+	problems := []string{}
+	for _, feature := range lo.Keys(required) {
+		if !lo.Contains(*device.Status.SystemInfo.Capabilities, required[feature]) {
+			problems = append(problems, fmt.Sprintf("%s requires %s", feature, required[feature]))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	slices.Sort(problems)
+	return fmt.Errorf("%w: %s", ErrUnsupportedFeatures, strings.Join(problems, ", "))
+}
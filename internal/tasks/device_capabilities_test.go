@@ -0,0 +1,60 @@
+package tasks
+
+import (
+	"errors"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCapabilities(t *testing.T) {
+	spec := &api.DeviceSpec{
+		Os: &api.DeviceOSSpec{Image: "quay.io/flightctl/os:v1"},
+		Applications: &[]api.ApplicationSpec{
+			{Name: "web", Path: lo.ToPtr("/etc/compose/web.yaml")},
+			{Name: "broker", Pod: &api.ApplicationPodSpec{}},
+		},
+		Time: &api.DeviceTimeSpec{Pools: &[]string{"pool.ntp.org"}},
+	}
+	tests := []struct {
+		name         string
+		capabilities *[]api.DeviceCapability
+		wantErr      string
+	}{
+		{
+			name: "supported",
+			capabilities: &[]api.DeviceCapability{
+				api.DeviceCapabilityBootcOSImage,
+				api.DeviceCapabilityComposeApplications,
+				api.DeviceCapabilityPodApplications,
+				api.DeviceCapabilityTimeSync,
+			},
+		},
+		{
+			name:         "unsupported",
+			capabilities: &[]api.DeviceCapability{api.DeviceCapabilityAgentUpdate, api.DeviceCapabilityComposeApplications},
+			wantErr:      "application broker requires PodApplications, spec.os requires BootcOSImage, spec.time requires TimeSync",
+		},
+		{
+			name: "capabilities not reported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			device := &api.Device{
+				Spec:   spec,
+				Status: &api.DeviceStatus{SystemInfo: api.DeviceSystemInfo{Capabilities: tt.capabilities}},
+			}
+			err := checkCapabilities(device)
+			if tt.wantErr == "" {
+				require.NoError(err)
+				return
+			}
+			require.True(errors.Is(err, ErrUnsupportedFeatures))
+			require.ErrorContains(err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	k8sutilvalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+const systemInfoFieldPrefix = "systemInfo."
+
+// Label rules assign labels to devices based on the facts in their system info.
+//
+// We have 2 cases:
+// 1. Device system info or labels updated:
+//    Reference kind: Device
+//    Task description: Evaluate all rules of the org against the device and update its labels as necessary
+// 2. Label rule created/updated/deleted:
+//    Reference kind: LabelRule
+//    Task description: Evaluate all rules of the org against every device of the org
+//
+// The keys of the labels assigned by rules are recorded in the DeviceAnnotationRuleLabels
+// annotation, so that labels the user set are never removed when no rule applies.
+//
+// The labels are subject to the label schemas of the service like the labels set
+// through the API: the labels of a device are left as they are when the rules
+// would break a schema.
+
+func deviceLabelRules(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, labelSchemas []config.LabelSchema, log logrus.FieldLogger) error {
+	logic := NewDeviceLabelRulesLogic(callbackManager, log, store, *resourceRef, labelSchemas)
+
+	switch {
+	case resourceRef.Op == DeviceLabelRulesOpUpdate && resourceRef.Kind == model.DeviceKind:
+		err := logic.ApplyRulesToDevice(ctx)
+		if err != nil {
+			log.Errorf("failed to apply label rules to device %s/%s: %v", resourceRef.OrgID, resourceRef.Name, err)
+		}
+	case resourceRef.Op == DeviceLabelRulesOpUpdateAll && resourceRef.Kind == model.LabelRuleKind:
+		err := logic.ApplyRulesToAllDevices(ctx)
+		if err != nil {
+			log.Errorf("failed to apply label rules to devices of org %s: %v", resourceRef.OrgID, err)
+		}
+	default:
+		log.Errorf("DeviceLabelRules called with unexpected kind %s and op %s", resourceRef.Kind, resourceRef.Op)
+	}
+	return nil
+}
+
+type DeviceLabelRulesLogic struct {
+	callbackManager CallbackManager
+	log             logrus.FieldLogger
+	store           store.Store
+	resourceRef     ResourceReference
+	labelSchemas    []config.LabelSchema
+}
+
+func NewDeviceLabelRulesLogic(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store, resourceRef ResourceReference, labelSchemas []config.LabelSchema) DeviceLabelRulesLogic {
+	return DeviceLabelRulesLogic{callbackManager: callbackManager, log: log, store: store, resourceRef: resourceRef, labelSchemas: labelSchemas}
+}
+
+func (t *DeviceLabelRulesLogic) listRules(ctx context.Context) ([]api.LabelRule, error) {
+	rules, err := t.store.LabelRule().List(ctx, t.resourceRef.OrgID, store.ListParams{})
+	if err != nil {
+		return nil, fmt.Errorf("fetching label rules: %w", err)
+	}
+	return rules.Items, nil
+}
+
+func (t *DeviceLabelRulesLogic) ApplyRulesToDevice(ctx context.Context) error {
+	rules, err := t.listRules(ctx)
+	if err != nil {
+		return err
+	}
+	device, err := t.store.Device().Get(ctx, t.resourceRef.OrgID, t.resourceRef.Name)
+	if err != nil {
+		return fmt.Errorf("fetching device: %w", err)
+	}
+	return t.applyRules(ctx, rules, device)
+}
+
+func (t *DeviceLabelRulesLogic) ApplyRulesToAllDevices(ctx context.Context) error {
+	rules, err := t.listRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	errors := 0
+	for {
+		devices, err := t.store.Device().List(ctx, t.resourceRef.OrgID, listParams)
+		if err != nil {
+			return fmt.Errorf("fetching devices: %w", err)
+		}
+
+		for devIndex := range devices.Items {
+			device := devices.Items[devIndex]
+			if err := t.applyRules(ctx, rules, &device); err != nil {
+				t.log.Errorf("failed to apply label rules to device %s/%s: %v", t.resourceRef.OrgID, *device.Metadata.Name, err)
+				errors++
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+
+	if errors != 0 {
+		return fmt.Errorf("failed to apply label rules to %d devices", errors)
+	}
+	return nil
+}
+
+func (t *DeviceLabelRulesLogic) applyRules(ctx context.Context, rules []api.LabelRule, device *api.Device) error {
+	deviceName := *device.Metadata.Name
+
+	var status api.DeviceStatus
+	if device.Status != nil {
+		status = *device.Status
+	}
+	assigned, err := evaluateLabelRules(rules, &status.SystemInfo, t.log)
+	if err != nil {
+		return err
+	}
+
+	annotations := lo.FromPtr(device.Metadata.Annotations)
+	previousKeys := ruleLabelKeys(annotations[model.DeviceAnnotationRuleLabels])
+	currentLabels := lo.FromPtrOr(device.Metadata.Labels, map[string]string{})
+
+	labels := lo.Assign(currentLabels)
+	for _, key := range previousKeys {
+		if _, ok := assigned[key]; !ok {
+			delete(labels, key)
+		}
+	}
+	for key, value := range assigned {
+		labels[key] = value
+	}
+
+	if !reflect.DeepEqual(labels, currentLabels) {
+		if msg := common.CheckLabelSchemas(t.labelSchemas, device, &labels); msg != "" {
+			return errors.New(msg)
+		}
+		t.log.Infof("Updating labels of device %s according to label rules", deviceName)
+		device.Metadata.Labels = &labels
+		if _, err := t.store.Device().Update(ctx, t.resourceRef.OrgID, device, nil, false, t.callbackManager.DeviceUpdatedCallback); err != nil {
+			return fmt.Errorf("updating labels: %w", err)
+		}
+	}
+
+	keys := lo.Keys(assigned)
+	sort.Strings(keys)
+	if reflect.DeepEqual(keys, previousKeys) {
+		return nil
+	}
+	if len(keys) == 0 {
+		return t.store.Device().UpdateAnnotations(ctx, t.resourceRef.OrgID, deviceName, nil, []string{model.DeviceAnnotationRuleLabels})
+	}
+	newAnnotations := map[string]string{model.DeviceAnnotationRuleLabels: strings.Join(keys, ",")}
+	return t.store.Device().UpdateAnnotations(ctx, t.resourceRef.OrgID, deviceName, newAnnotations, nil)
+}
+
+// ruleLabelKeys parses the sorted keys recorded in the DeviceAnnotationRuleLabels annotation.
+func ruleLabelKeys(annotation string) []string {
+	if annotation == "" {
+		return []string{}
+	}
+	keys := strings.Split(annotation, ",")
+	sort.Strings(keys)
+	return keys
+}
+
+// evaluateLabelRules returns the labels the rules assign to a device with the given system info.
+// When several rules assign the same key, the first rule by name wins.
+func evaluateLabelRules(rules []api.LabelRule, systemInfo *api.DeviceSystemInfo, log logrus.FieldLogger) (map[string]string, error) {
+	assigned := map[string]string{}
+	if len(rules) == 0 {
+		return assigned, nil
+	}
+
+	var facts map[string]interface{}
+	data, err := json.Marshal(systemInfo)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling system info: %w", err)
+	}
+	if err := json.Unmarshal(data, &facts); err != nil {
+		return nil, fmt.Errorf("unmarshalling system info: %w", err)
+	}
+
+	rules = append([]api.LabelRule{}, rules...)
+	sort.Slice(rules, func(i, j int) bool { return *rules[i].Metadata.Name < *rules[j].Metadata.Name })
+	for _, rule := range rules {
+		if _, ok := assigned[rule.Spec.LabelKey]; ok {
+			continue
+		}
+		fact, ok := lookupFact(facts, rule.Spec.Field)
+		if !ok || fact == "" {
+			continue
+		}
+		if rule.Spec.MatchValues != nil && !lo.Contains(*rule.Spec.MatchValues, fact) {
+			continue
+		}
+		value := lo.FromPtrOr(rule.Spec.LabelValue, fact)
+		if msgs := k8sutilvalidation.IsValidLabelValue(value); len(msgs) > 0 {
+			log.Debugf("label rule %s: %q is not a valid label value: %s", *rule.Metadata.Name, value, strings.Join(msgs, "; "))
+			continue
+		}
+		assigned[rule.Spec.LabelKey] = value
+	}
+	return assigned, nil
+}
+
+// lookupFact returns the value at the dotted path in the system info as a string.
+// Facts which are not reported, or which are objects or arrays, are not found.
+// Empty strings are returned as found and left to the caller.
+func lookupFact(facts map[string]interface{}, field string) (string, bool) {
+	if !strings.HasPrefix(field, systemInfoFieldPrefix) {
+		return "", false
+	}
+
+	var value interface{} = facts
+	for _, elem := range strings.Split(strings.TrimPrefix(field, systemInfoFieldPrefix), ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = m[elem]; !ok {
+			return "", false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
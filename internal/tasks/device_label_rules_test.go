@@ -0,0 +1,146 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newLabelRule(name string, spec api.LabelRuleSpec) api.LabelRule {
+	return api.LabelRule{Metadata: api.ObjectMeta{Name: lo.ToPtr(name)}, Spec: spec}
+}
+
+func TestEvaluateLabelRules(t *testing.T) {
+	require := require.New(t)
+
+	systemInfo := api.DeviceSystemInfo{
+		Architecture:    "arm64",
+		OperatingSystem: "linux",
+		Hardware: &api.DeviceHardwareInfo{
+			Cpu:         api.DeviceCPUInfo{Cores: 8, Model: lo.ToPtr("Cortex-A72 (r0p3)")},
+			MemoryBytes: 4294967296,
+			GpuPresent:  true,
+		},
+	}
+	rules := []api.LabelRule{
+		newLabelRule("arch", api.LabelRuleSpec{Field: "systemInfo.architecture", LabelKey: "arch"}),
+		newLabelRule("gpu", api.LabelRuleSpec{Field: "systemInfo.hardware.gpuPresent", LabelKey: "gpu"}),
+		newLabelRule("cores", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu.cores", LabelKey: "cores"}),
+		newLabelRule("large", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu.cores", LabelKey: "size", MatchValues: &[]string{"16", "32"}, LabelValue: lo.ToPtr("large")}),
+		newLabelRule("medium", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu.cores", LabelKey: "size", MatchValues: &[]string{"4", "8"}, LabelValue: lo.ToPtr("medium")}),
+		newLabelRule("small", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu.cores", LabelKey: "size", LabelValue: lo.ToPtr("small")}),
+		// not a valid label value
+		newLabelRule("cpu-model", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu.model", LabelKey: "cpu"}),
+		// not reported
+		newLabelRule("vendor", api.LabelRuleSpec{Field: "systemInfo.hardware.vendor", LabelKey: "vendor"}),
+		// not a scalar
+		newLabelRule("disks", api.LabelRuleSpec{Field: "systemInfo.hardware.cpu", LabelKey: "disks"}),
+	}
+
+	assigned, err := evaluateLabelRules(rules, &systemInfo, log.NewPrefixLogger("test"))
+	require.NoError(err)
+	require.Equal(map[string]string{
+		"arch":  "arm64",
+		"gpu":   "true",
+		"cores": "8",
+		"size":  "medium",
+	}, assigned)
+
+	// a device that has not reported its system info gets no labels
+	assigned, err = evaluateLabelRules(rules, &api.DeviceSystemInfo{}, log.NewPrefixLogger("test"))
+	require.NoError(err)
+	require.Empty(assigned)
+}
+
+func TestRuleLabelKeys(t *testing.T) {
+	require := require.New(t)
+
+	require.Empty(ruleLabelKeys(""))
+	require.Equal([]string{"arch", "gpu"}, ruleLabelKeys("gpu,arch"))
+}
+
+type labelRulesStore struct {
+	store.Store
+	devices labelRulesDeviceStore
+}
+
+func (s *labelRulesStore) Device() store.Device { return &s.devices }
+
+// labelRulesDeviceStore records the labels and annotations written to a device.
+type labelRulesDeviceStore struct {
+	store.Device
+	labels      *map[string]string
+	annotations map[string]string
+}
+
+func (s *labelRulesDeviceStore) Update(ctx context.Context, orgId uuid.UUID, device *api.Device, fieldsToUnset []string, fromAPI bool, callback store.DeviceStoreCallback) (*api.Device, error) {
+	s.labels = device.Metadata.Labels
+	return device, nil
+}
+
+func (s *labelRulesDeviceStore) UpdateAnnotations(ctx context.Context, orgId uuid.UUID, name string, annotations map[string]string, deleteKeys []string) error {
+	s.annotations = annotations
+	return nil
+}
+
+func TestApplyRulesLabelSchemas(t *testing.T) {
+	rules := []api.LabelRule{
+		newLabelRule("arch", api.LabelRuleSpec{Field: "systemInfo.architecture", LabelKey: "arch"}),
+	}
+	tests := []struct {
+		name     string
+		schemas  []config.LabelSchema
+		expected *map[string]string
+	}{
+		{
+			name:     "no schemas",
+			expected: &map[string]string{"site": "lab", "arch": "arm64"},
+		},
+		{
+			name:     "allowed value",
+			schemas:  []config.LabelSchema{{Key: "arch", AllowedValues: []string{"amd64", "arm64"}}},
+			expected: &map[string]string{"site": "lab", "arch": "arm64"},
+		},
+		{
+			name:    "disallowed value",
+			schemas: []config.LabelSchema{{Key: "arch", AllowedValues: []string{"amd64"}}},
+		},
+		{
+			name:    "required and immutable labels",
+			schemas: []config.LabelSchema{{Key: "arch", Immutable: true}, {Key: "site", Required: true}},
+			// a label set for the first time is not changed
+			expected: &map[string]string{"site": "lab", "arch": "arm64"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			st := &labelRulesStore{}
+			logic := NewDeviceLabelRulesLogic(NewMockCallbackManager(gomock.NewController(t)), log.NewPrefixLogger("test"), st, ResourceReference{}, tt.schemas)
+			device := &api.Device{
+				Metadata: api.ObjectMeta{Name: lo.ToPtr("device"), Labels: &map[string]string{"site": "lab"}},
+				Status:   &api.DeviceStatus{SystemInfo: api.DeviceSystemInfo{Architecture: "arm64"}},
+			}
+
+			err := logic.applyRules(context.Background(), rules, device)
+			if tt.expected == nil {
+				require.Error(err)
+				require.Nil(st.devices.labels)
+				require.Nil(st.devices.annotations)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, st.devices.labels)
+			require.Equal(map[string]string{model.DeviceAnnotationRuleLabels: "arch"}, st.devices.annotations)
+		})
+	}
+}
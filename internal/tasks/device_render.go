@@ -5,15 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"path/filepath"
 	"strings"
 
 	config_latest "github.com/coreos/ignition/v2/config/v3_4"
 	config_latest_types "github.com/coreos/ignition/v2/config/v3_4/types"
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/specdelivery"
 	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
 	"github.com/flightctl/flightctl/pkg/ignition"
 	"github.com/flightctl/flightctl/pkg/k8sclient"
@@ -23,8 +24,9 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
-func deviceRender(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient, log logrus.FieldLogger) error {
+func deviceRender(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, k8sClient k8sclient.K8SClient, specDelivery *specdelivery.Publisher, log logrus.FieldLogger) error {
 	logic := NewDeviceRenderLogic(callbackManager, log, store, k8sClient, *resourceRef)
+	logic.SetSpecDelivery(specDelivery)
 	if resourceRef.Op == DeviceRenderOpUpdate {
 		err := logic.RenderDevice(ctx)
 		if err != nil {
@@ -44,10 +46,18 @@ type DeviceRenderLogic struct {
 	store           store.Store
 	k8sClient       k8sclient.K8SClient
 	resourceRef     ResourceReference
+	specDelivery    *specdelivery.Publisher
+	registry        manifestDigester
 }
 
 func NewDeviceRenderLogic(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store, k8sClient k8sclient.K8SClient, resourceRef ResourceReference) DeviceRenderLogic {
-	return DeviceRenderLogic{callbackManager: callbackManager, log: log, store: store, k8sClient: k8sClient, resourceRef: resourceRef}
+	return DeviceRenderLogic{callbackManager: callbackManager, log: log, store: store, k8sClient: k8sClient, resourceRef: resourceRef, registry: registry.NewClient(nil)}
+}
+
+// SetSpecDelivery sets the publisher notifying the device of each newly
+// rendered spec, if the service delivers specs through an MQTT broker.
+func (t *DeviceRenderLogic) SetSpecDelivery(specDelivery *specdelivery.Publisher) {
+	t.specDelivery = specDelivery
 }
 
 func (t *DeviceRenderLogic) RenderDevice(ctx context.Context) error {
@@ -63,6 +73,13 @@ func (t *DeviceRenderLogic) RenderDevice(ctx context.Context) error {
 	if device.Spec != nil {
 		config = device.Spec.Config
 	}
+	if clusterName, ok := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationManagedCluster]; ok {
+		klusterletConfig, err := klusterletConfigItem(clusterName)
+		if err != nil {
+			return t.setStatus(ctx, device.Metadata.Generation, fmt.Errorf("adding klusterlet configuration: %w", err))
+		}
+		config = lo.ToPtr(append(lo.FromPtr(config), *klusterletConfig))
+	}
 
 	renderedConfig, repoNames, renderErr := renderConfig(ctx, t.resourceRef.OrgID, t.store, t.k8sClient, config, !util.IsEmptyString(device.Metadata.Owner), false)
 
@@ -73,20 +90,50 @@ func (t *DeviceRenderLogic) RenderDevice(ctx context.Context) error {
 	if device.Metadata.Owner == nil || *device.Metadata.Owner == "" {
 		err = t.store.Device().OverwriteRepositoryRefs(ctx, t.resourceRef.OrgID, *device.Metadata.Name, repoNames...)
 		if err != nil {
-			return t.setStatus(ctx, fmt.Errorf("setting repository references: %w", err))
+			return t.setStatus(ctx, device.Metadata.Generation, fmt.Errorf("setting repository references: %w", err))
 		}
 	}
 
 	if renderErr != nil {
-		return t.setStatus(ctx, renderErr)
+		return t.setStatus(ctx, device.Metadata.Generation, renderErr)
+	}
+
+	if err := admitApplications(device); err != nil {
+		return t.setStatus(ctx, device.Metadata.Generation, err)
+	}
+	if err := checkCapabilities(device); err != nil {
+		return t.setStatus(ctx, device.Metadata.Generation, err)
+	}
+
+	architecture := ""
+	if device.Status != nil {
+		architecture = device.Status.SystemInfo.Architecture
 	}
+	imageDigests := resolveImageDigests(ctx, t.registry, device.Spec.ForArchitecture(architecture), t.log)
 
-	err = t.store.Device().UpdateRendered(ctx, t.resourceRef.OrgID, t.resourceRef.Name, string(renderedConfig))
-	return t.setStatus(ctx, err)
+	err = t.store.Device().UpdateRendered(ctx, t.resourceRef.OrgID, t.resourceRef.Name, string(renderedConfig), imageDigests)
+	if err == nil && t.specDelivery != nil {
+		t.notifyDevice(ctx)
+	}
+	return t.setStatus(ctx, device.Metadata.Generation, err)
 }
 
-func (t *DeviceRenderLogic) setStatus(ctx context.Context, renderErr error) error {
-	condition := api.Condition{Type: api.DeviceSpecValid}
+// notifyDevice publishes the new rendered spec of the device to the broker.
+// Devices keep polling the management API, so a failed notification only
+// delays the update.
+func (t *DeviceRenderLogic) notifyDevice(ctx context.Context) {
+	rendered, err := t.store.Device().GetRendered(ctx, t.resourceRef.OrgID, t.resourceRef.Name, nil, "")
+	if err != nil {
+		t.log.Warnf("failed getting rendered spec of device %s/%s to notify it: %v", t.resourceRef.OrgID, t.resourceRef.Name, err)
+		return
+	}
+	if err := t.specDelivery.Notify(ctx, t.resourceRef.Name, rendered); err != nil {
+		t.log.Warnf("failed notifying device %s/%s: %v", t.resourceRef.OrgID, t.resourceRef.Name, err)
+	}
+}
+
+func (t *DeviceRenderLogic) setStatus(ctx context.Context, generation *int64, renderErr error) error {
+	condition := api.Condition{Type: api.DeviceSpecValid, ObservedGeneration: generation}
 
 	if renderErr == nil {
 		condition.Status = api.ConditionStatusTrue
@@ -94,6 +141,12 @@ func (t *DeviceRenderLogic) setStatus(ctx context.Context, renderErr error) erro
 	} else {
 		condition.Status = api.ConditionStatusFalse
 		condition.Reason = "Invalid"
+		switch {
+		case errors.Is(renderErr, ErrInsufficientResources):
+			condition.Reason = "InsufficientResources"
+		case errors.Is(renderErr, ErrUnsupportedFeatures):
+			condition.Reason = "UnsupportedFeatures"
+		}
 		condition.Message = renderErr.Error()
 	}
 
@@ -137,6 +190,12 @@ func renderConfig(ctx context.Context, orgId uuid.UUID, store store.Store, k8sCl
 		return nil, args.repoNames, nil
 	}
 
+	// units of configurations from repositories or with parameters are only
+	// known once rendered
+	if problems := ValidateIgnitionUnits(args.ignitionConfig); len(problems) > 0 {
+		return nil, args.repoNames, unitProblemsError(problems)
+	}
+
 	renderedConfig, err = json.Marshal(args.ignitionConfig)
 	if err != nil {
 		return nil, args.repoNames, fmt.Errorf("failed marshalling configuration: %w", err)
@@ -201,7 +260,7 @@ func validateConfigParameters(configItem *api.DeviceSpec_Config_Item, args *rend
 	// If we're rendering the device config and it still has parameters, something went wrong
 	if ContainsParameter(cfgJson) {
 		if args.deviceBelongsToFleet {
-			return fmt.Errorf("configuration contains parameter, perhaps due to a missing device label")
+			return fmt.Errorf("configuration contains parameter, perhaps due to a missing device label or inventory value")
 		} else {
 			return fmt.Errorf("configuration contains parameter, but parameters can only be used in fleet templates")
 		}
@@ -312,10 +371,13 @@ func renderInlineConfig(configItem *api.DeviceSpec_Config_Item, args *renderConf
 	// If we are validating and parameters are present, the ignition conversion will fail.
 	if args.validateOnly {
 		if !ContainsParameter(jsonBytes) {
-			_, _, err = config_latest.ParseCompatibleVersion(jsonBytes)
+			ignitionConfig, _, err := config_latest.ParseCompatibleVersion(jsonBytes)
 			if err != nil {
 				return inlineSpec.Name, fmt.Errorf("failed parsing inline config item %s: %w", inlineSpec.Name, err)
 			}
+			if problems := ValidateIgnitionUnits(&ignitionConfig); len(problems) > 0 {
+				return inlineSpec.Name, unitProblemsError(problems)
+			}
 		}
 		return inlineSpec.Name, nil
 	}
@@ -357,38 +419,18 @@ func renderHttpProviderConfig(ctx context.Context, configItem *api.DeviceSpec_Co
 	if args.validateOnly {
 		return httpConfigProviderSpec.Name, nil
 	}
-	req, err := http.NewRequest("GET", repoURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
-	}
-
 	repoHttpSpec, err := repo.Spec.Data.GetHttpRepoSpec()
 	if err != nil {
 		return "", err
 	}
-
-	req, tlsConfig, err := buildHttpRepoRequestAuth(repoHttpSpec, req)
+	body, err := fetchHttpConfig(repoHttpSpec, repoURL)
 	if err != nil {
-		return "", fmt.Errorf("error building request authentication: %w", err)
-	}
-
-	// Set up the HTTP client with the configured TLS settings
-	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: tlsConfig,
-		},
+		return httpConfigProviderSpec.Name, err
 	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("sending request: %w", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
-	}
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("reading response body: %w", err)
+	if httpConfigProviderSpec.HttpRef.Sha256 != nil {
+		if err := verifyChecksum(body, *httpConfigProviderSpec.HttpRef.Sha256); err != nil {
+			return httpConfigProviderSpec.Name, err
+		}
 	}
 
 	// Convert body to ignition config
@@ -397,7 +439,13 @@ func renderHttpProviderConfig(ctx context.Context, configItem *api.DeviceSpec_Co
 		return httpConfigProviderSpec.Name, fmt.Errorf("failed to create ignition wrapper: %w", err)
 	}
 
-	ignitionWrapper.SetFile(httpConfigProviderSpec.HttpRef.FilePath, body, 0o644)
+	if lo.FromPtr(httpConfigProviderSpec.HttpRef.Format) == api.HttpContentFormatArchive {
+		if err := setArchiveFiles(ignitionWrapper, body, httpConfigProviderSpec.HttpRef.FilePath); err != nil {
+			return httpConfigProviderSpec.Name, err
+		}
+	} else {
+		ignitionWrapper.SetFile(httpConfigProviderSpec.HttpRef.FilePath, body, 0o644)
+	}
 	if !args.validateOnly {
 		args.ignitionConfig = lo.ToPtr(ignitionWrapper.Merge(*args.ignitionConfig))
 	}
@@ -0,0 +1,304 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookDeliveryTimeout bounds how long a single notification may take.
+	webhookDeliveryTimeout = 10 * time.Second
+	// maxWebhookDedupEntries is the number of recorded notifications after which expired entries are pruned.
+	maxWebhookDedupEntries = 10000
+	// maxWebhookDeliveryAttempts is the number of times a notification is sent before it is dropped.
+	maxWebhookDeliveryAttempts = 5
+	// webhookRetryBackoff is the delay before the first retry of a notification, doubled for each further retry.
+	webhookRetryBackoff = 2 * time.Second
+)
+
+// DeviceStatusTransition records a change of a device's summary status or of one of its conditions.
+type DeviceStatusTransition struct {
+	// ConditionType is empty for a summary status transition
+	ConditionType api.ConditionType `json:",omitempty"`
+	Previous      string            `json:",omitempty"`
+	Current       string
+}
+
+func deviceStatusTransitions(before *api.DeviceStatus, after *api.DeviceStatus) []DeviceStatusTransition {
+	var transitions []DeviceStatusTransition
+	if before.Summary.Status != after.Summary.Status {
+		transitions = append(transitions, DeviceStatusTransition{
+			Previous: string(before.Summary.Status),
+			Current:  string(after.Summary.Status),
+		})
+	}
+	for _, condition := range after.Conditions {
+		previous := api.FindStatusCondition(before.Conditions, condition.Type)
+		if previous != nil && previous.Status == condition.Status {
+			continue
+		}
+		transition := DeviceStatusTransition{ConditionType: condition.Type, Current: string(condition.Status)}
+		if previous != nil {
+			transition.Previous = string(previous.Status)
+		}
+		transitions = append(transitions, transition)
+	}
+	return transitions
+}
+
+func transitionMatchesTrigger(transition DeviceStatusTransition, trigger api.WebhookTrigger) bool {
+	if trigger.SummaryStatus != nil {
+		return transition.ConditionType == "" && transition.Current == string(*trigger.SummaryStatus)
+	}
+	if trigger.ConditionType != nil {
+		status := lo.FromPtrOr(trigger.ConditionStatus, api.ConditionStatusTrue)
+		return transition.ConditionType == *trigger.ConditionType && transition.Current == string(status)
+	}
+	return false
+}
+
+func triggerKey(trigger api.WebhookTrigger) string {
+	if trigger.SummaryStatus != nil {
+		return "summary=" + string(*trigger.SummaryStatus)
+	}
+	return fmt.Sprintf("condition=%s=%s", lo.FromPtr(trigger.ConditionType), lo.FromPtrOr(trigger.ConditionStatus, api.ConditionStatusTrue))
+}
+
+// WebhookDelivery is a notification of a webhook to be delivered. A
+// notification which fails is submitted again to the task queue with the
+// attempts made so far and the time before which it is not retried.
+type WebhookDelivery struct {
+	Event     api.WebhookEvent
+	Attempt   int
+	NotBefore time.Time
+}
+
+// webhookDedup remembers recently sent notifications so that a device flapping
+// in and out of a watched state does not flood the receiver.
+// The state is kept in memory and is therefore per worker process.
+type webhookDedup struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+}
+
+func newWebhookDedup() *webhookDedup {
+	return &webhookDedup{expires: make(map[string]time.Time)}
+}
+
+// allow reports whether a notification identified by key may be sent at now,
+// and if so suppresses further notifications for the same key during window.
+func (d *webhookDedup) allow(key string, window time.Duration, now time.Time) bool {
+	if window <= 0 {
+		return true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if expires, ok := d.expires[key]; ok && now.Before(expires) {
+		return false
+	}
+	if len(d.expires) >= maxWebhookDedupEntries {
+		for k, expires := range d.expires {
+			if !now.Before(expires) {
+				delete(d.expires, k)
+			}
+		}
+	}
+	d.expires[key] = now.Add(window)
+	return true
+}
+
+func deviceStatusWebhook(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, dedup *webhookDedup, log logrus.FieldLogger) error {
+	logic := NewDeviceStatusWebhookLogic(callbackManager, log, store, dedup, *resourceRef)
+
+	switch {
+	case resourceRef.Op == DeviceStatusWebhookOpTransition && resourceRef.Kind == model.DeviceKind:
+		err := logic.NotifyWebhooks(ctx)
+		if err != nil {
+			log.Errorf("failed to notify webhooks of status transitions of device %s/%s: %v", resourceRef.OrgID, resourceRef.Name, err)
+		}
+	case resourceRef.Op == DeviceStatusWebhookOpDeliver && resourceRef.Kind == model.DeviceKind && resourceRef.WebhookDelivery != nil:
+		err := logic.Deliver(ctx)
+		if err != nil {
+			log.Errorf("failed to notify webhook %s of device %s/%s: %v", resourceRef.WebhookDelivery.Event.Webhook, resourceRef.OrgID, resourceRef.Name, err)
+		}
+	default:
+		log.Errorf("DeviceStatusWebhook called with unexpected kind %s and op %s", resourceRef.Kind, resourceRef.Op)
+	}
+	return nil
+}
+
+type DeviceStatusWebhookLogic struct {
+	callbackManager CallbackManager
+	log             logrus.FieldLogger
+	store           store.Store
+	dedup           *webhookDedup
+	resourceRef     ResourceReference
+	httpClient      *http.Client
+}
+
+func NewDeviceStatusWebhookLogic(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store, dedup *webhookDedup, resourceRef ResourceReference) DeviceStatusWebhookLogic {
+	return DeviceStatusWebhookLogic{
+		callbackManager: callbackManager,
+		log:             log,
+		store:           store,
+		dedup:           dedup,
+		resourceRef:     resourceRef,
+		httpClient:      &http.Client{Timeout: webhookDeliveryTimeout},
+	}
+}
+
+// NotifyWebhooks submits a delivery for each notification the transitions of
+// the device trigger, so that each is delivered and retried on its own.
+func (t *DeviceStatusWebhookLogic) NotifyWebhooks(ctx context.Context) error {
+	webhooks, err := t.store.Webhook().List(ctx, t.resourceRef.OrgID, store.ListParams{})
+	if err != nil {
+		return fmt.Errorf("fetching webhooks: %w", err)
+	}
+	if len(webhooks.Items) == 0 {
+		return nil
+	}
+
+	var deviceLabels map[string]string
+	for _, webhook := range webhooks.Items {
+		if webhook.Spec.Selector != nil {
+			if deviceLabels == nil {
+				device, err := t.store.Device().Get(ctx, t.resourceRef.OrgID, t.resourceRef.Name)
+				if err != nil {
+					return fmt.Errorf("fetching device: %w", err)
+				}
+				deviceLabels = lo.FromPtrOr(device.Metadata.Labels, map[string]string{})
+			}
+			if !util.LabelsMatchLabelSelector(deviceLabels, webhook.Spec.Selector.MatchLabels) {
+				continue
+			}
+		}
+		t.notifyWebhook(&webhook)
+	}
+	return nil
+}
+
+func (t *DeviceStatusWebhookLogic) notifyWebhook(webhook *api.Webhook) {
+	webhookName := lo.FromPtr(webhook.Metadata.Name)
+
+	var dedupWindow time.Duration
+	if webhook.Spec.DedupWindow != nil {
+		var err error
+		if dedupWindow, err = time.ParseDuration(*webhook.Spec.DedupWindow); err != nil {
+			t.log.Errorf("webhook %s has an invalid dedup window %q: %v", webhookName, *webhook.Spec.DedupWindow, err)
+		}
+	}
+
+	now := time.Now()
+	for _, transition := range t.resourceRef.StatusTransitions {
+		for _, trigger := range webhook.Spec.Triggers {
+			if !transitionMatchesTrigger(transition, trigger) {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s/%s", t.resourceRef.OrgID, webhookName, t.resourceRef.Name, triggerKey(trigger))
+			if !t.dedup.allow(key, dedupWindow, now) {
+				t.log.Debugf("dropping duplicate notification of webhook %s for device %s", webhookName, t.resourceRef.Name)
+				continue
+			}
+
+			event := api.WebhookEvent{
+				Webhook:   webhookName,
+				Device:    t.resourceRef.Name,
+				Trigger:   trigger,
+				Current:   transition.Current,
+				Timestamp: now.UTC(),
+			}
+			if transition.Previous != "" {
+				event.Previous = lo.ToPtr(transition.Previous)
+			}
+			t.callbackManager.DeviceStatusWebhookDelivery(t.resourceRef.OrgID, t.resourceRef.Name, WebhookDelivery{Event: event})
+		}
+	}
+}
+
+// Deliver POSTs the notification to the current URL of its webhook. If that
+// fails, the notification is submitted again to be retried after a backoff
+// which doubles with each attempt, until maxWebhookDeliveryAttempts attempts
+// failed.
+func (t *DeviceStatusWebhookLogic) Deliver(ctx context.Context) error {
+	delivery := *t.resourceRef.WebhookDelivery
+
+	// the task queue does not delay messages, so the backoff is waited out here
+	if wait := time.Until(delivery.NotBefore); wait > 0 {
+		select {
+		case <-ctx.Done():
+			// submitted again for the consumers which remain to retry it
+			t.callbackManager.DeviceStatusWebhookDelivery(t.resourceRef.OrgID, t.resourceRef.Name, delivery)
+			return nil
+		case <-time.After(wait):
+		}
+	}
+
+	webhook, err := t.store.Webhook().Get(ctx, t.resourceRef.OrgID, delivery.Event.Webhook)
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		t.log.Debugf("dropping notification of deleted webhook %s for device %s", delivery.Event.Webhook, t.resourceRef.Name)
+		return nil
+	}
+	if err == nil {
+		err = postJSON(ctx, t.httpClient, webhook.Spec.Url, &delivery.Event)
+	}
+	if err == nil {
+		return nil
+	}
+
+	delivery, retry := retryWebhookDelivery(delivery, time.Now())
+	if !retry {
+		return fmt.Errorf("dropping notification after %d attempts: %w", delivery.Attempt, err)
+	}
+	t.log.Warnf("failed to notify webhook %s of device %s, retrying at %s: %v", delivery.Event.Webhook, t.resourceRef.Name, delivery.NotBefore.Format(time.RFC3339), err)
+	t.callbackManager.DeviceStatusWebhookDelivery(t.resourceRef.OrgID, t.resourceRef.Name, delivery)
+	return nil
+}
+
+// retryWebhookDelivery records a failed attempt of the delivery at now, and
+// returns when it is retried, or false if no attempts remain.
+func retryWebhookDelivery(delivery WebhookDelivery, now time.Time) (WebhookDelivery, bool) {
+	delivery.Attempt++
+	if delivery.Attempt >= maxWebhookDeliveryAttempts {
+		return delivery, false
+	}
+	delivery.NotBefore = now.Add(webhookRetryBackoff << (delivery.Attempt - 1))
+	return delivery, true
+}
+
+// postJSON POSTs payload to the URL of a webhook.
+func postJSON(ctx context.Context, httpClient *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshalling payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
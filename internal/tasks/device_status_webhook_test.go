@@ -0,0 +1,84 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceStatusTransitions(t *testing.T) {
+	require := require.New(t)
+
+	before := api.DeviceStatus{
+		Summary:    api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusOnline},
+		Conditions: []api.Condition{{Type: api.DeviceUpdating, Status: api.ConditionStatusFalse}},
+	}
+
+	// steady state produces no transitions
+	require.Empty(deviceStatusTransitions(&before, &before))
+
+	after := api.DeviceStatus{
+		Summary: api.DeviceSummaryStatus{Status: api.DeviceSummaryStatusDegraded},
+		Conditions: []api.Condition{
+			{Type: api.DeviceUpdating, Status: api.ConditionStatusTrue},
+			{Type: api.DeviceSpecValid, Status: api.ConditionStatusFalse},
+		},
+	}
+	require.Equal([]DeviceStatusTransition{
+		{Previous: "Online", Current: "Degraded"},
+		{ConditionType: api.DeviceUpdating, Previous: "False", Current: "True"},
+		{ConditionType: api.DeviceSpecValid, Current: "False"},
+	}, deviceStatusTransitions(&before, &after))
+}
+
+func TestTransitionMatchesTrigger(t *testing.T) {
+	require := require.New(t)
+
+	degraded := api.WebhookTrigger{SummaryStatus: lo.ToPtr(api.DeviceSummaryStatusDegraded)}
+	updating := api.WebhookTrigger{ConditionType: lo.ToPtr(api.DeviceUpdating)}
+	specInvalid := api.WebhookTrigger{ConditionType: lo.ToPtr(api.DeviceSpecValid), ConditionStatus: lo.ToPtr(api.ConditionStatusFalse)}
+
+	require.True(transitionMatchesTrigger(DeviceStatusTransition{Previous: "Online", Current: "Degraded"}, degraded))
+	require.False(transitionMatchesTrigger(DeviceStatusTransition{Previous: "Degraded", Current: "Online"}, degraded))
+	require.True(transitionMatchesTrigger(DeviceStatusTransition{ConditionType: api.DeviceUpdating, Current: "True"}, updating))
+	require.False(transitionMatchesTrigger(DeviceStatusTransition{ConditionType: api.DeviceUpdating, Current: "False"}, updating))
+	require.True(transitionMatchesTrigger(DeviceStatusTransition{ConditionType: api.DeviceSpecValid, Current: "False"}, specInvalid))
+	require.False(transitionMatchesTrigger(DeviceStatusTransition{ConditionType: api.DeviceSpecValid, Current: "False"}, degraded))
+}
+
+func TestWebhookDedup(t *testing.T) {
+	require := require.New(t)
+	dedup := newWebhookDedup()
+	now := time.Now()
+
+	require.True(dedup.allow("key", time.Minute, now))
+	require.False(dedup.allow("key", time.Minute, now.Add(30*time.Second)))
+	require.True(dedup.allow("other", time.Minute, now.Add(30*time.Second)))
+	require.True(dedup.allow("key", time.Minute, now.Add(time.Minute)))
+
+	// no window, no deduplication
+	require.True(dedup.allow("nowindow", 0, now))
+	require.True(dedup.allow("nowindow", 0, now))
+}
+
+func TestRetryWebhookDelivery(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+
+	delivery := WebhookDelivery{Event: api.WebhookEvent{Webhook: "hook", Device: "dev", Current: "Degraded"}}
+	var backoffs []time.Duration
+	for {
+		var retry bool
+		delivery, retry = retryWebhookDelivery(delivery, now)
+		if !retry {
+			break
+		}
+		backoffs = append(backoffs, delivery.NotBefore.Sub(now))
+	}
+	require.Equal([]time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second}, backoffs)
+	require.Equal(maxWebhookDeliveryAttempts, delivery.Attempt)
+	require.Equal("hook", delivery.Event.Webhook)
+}
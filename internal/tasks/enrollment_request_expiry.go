@@ -0,0 +1,105 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// EnrollmentRequestExpiryPollingInterval is the interval at which the pending
+// enrollment requests older than their TTL are deleted.
+const EnrollmentRequestExpiryPollingInterval = time.Hour
+
+var (
+	enrollmentRequestsExpired = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "flightctl",
+		Subsystem: "enrollmentrequests",
+		Name:      "expired_total",
+		Help:      "Total number of pending enrollment requests deleted as they were older than their TTL",
+	})
+	enrollmentRequestsPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "flightctl",
+		Subsystem: "enrollmentrequests",
+		Name:      "pending",
+		Help:      "Number of enrollment requests pending approval as of the last expiry check",
+	})
+)
+
+// Collectors returns the Prometheus metrics of the periodic tasks.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{enrollmentRequestsExpired, enrollmentRequestsPending, siteUpdateBandwidth, siteBandwidthBudget}
+}
+
+// EnrollmentRequestExpiry deletes the enrollment requests which have been
+// pending approval for longer than the TTL, such as the ones of devices which
+// were provisioned but never shipped. Deleting them frees their names, which
+// are the fingerprints of the devices, so that the devices can request
+// enrollment again.
+type EnrollmentRequestExpiry struct {
+	log   logrus.FieldLogger
+	store store.Store
+	ttl   time.Duration
+}
+
+func NewEnrollmentRequestExpiry(log logrus.FieldLogger, store store.Store, ttl time.Duration) *EnrollmentRequestExpiry {
+	return &EnrollmentRequestExpiry{log: log, store: store, ttl: ttl}
+}
+
+func (t *EnrollmentRequestExpiry) Poll() {
+	t.log.Info("Running EnrollmentRequestExpiry Polling")
+	t.deleteExpired(context.Background(), time.Now())
+}
+
+func (t *EnrollmentRequestExpiry) deleteExpired(ctx context.Context, now time.Time) {
+	orgID := uuid.UUID{}
+
+	// the expired enrollment requests are deleted once all are listed, so
+	// that deleting them does not shift the pages
+	expired := []string{}
+	pending := 0
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		list, err := t.store.EnrollmentRequest().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list enrollment requests")
+			return
+		}
+		for i := range list.Items {
+			enrollmentRequest := &list.Items[i]
+			if enrollmentRequest.Status != nil && api.IsStatusConditionTrue(enrollmentRequest.Status.Conditions, api.EnrollmentRequestApproved) {
+				continue
+			}
+			pending++
+			created := enrollmentRequest.Metadata.CreationTimestamp
+			if created != nil && now.Sub(*created) > t.ttl {
+				expired = append(expired, *enrollmentRequest.Metadata.Name)
+			}
+		}
+		if list.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(list.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+
+	deleted := 0
+	for _, name := range expired {
+		if err := t.store.EnrollmentRequest().Delete(ctx, orgID, name); err != nil {
+			t.log.WithError(err).Errorf("failed to delete expired enrollment request %s", name)
+			continue
+		}
+		deleted++
+		t.log.Infof("deleted enrollment request %s, which was pending for longer than %s", name, t.ttl)
+	}
+	enrollmentRequestsExpired.Add(float64(deleted))
+	enrollmentRequestsPending.Set(float64(pending - deleted))
+}
@@ -0,0 +1,80 @@
+package tasks
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+type expiringEnrollmentRequestStore struct {
+	store.Store
+	enrollmentRequests *pagedEnrollmentRequests
+}
+
+func (s *expiringEnrollmentRequestStore) EnrollmentRequest() store.EnrollmentRequest {
+	return s.enrollmentRequests
+}
+
+type pagedEnrollmentRequests struct {
+	store.EnrollmentRequest
+	items   []api.EnrollmentRequest
+	deleted []string
+}
+
+func (e *pagedEnrollmentRequests) List(ctx context.Context, orgId uuid.UUID, listParams store.ListParams) (*api.EnrollmentRequestList, error) {
+	// pages of a single enrollment request, continuing from the named one
+	start := 0
+	if listParams.Continue != nil {
+		start = lo.IndexOf(lo.Map(e.items, func(item api.EnrollmentRequest, _ int) string { return *item.Metadata.Name }), listParams.Continue.Name)
+	}
+	list := &api.EnrollmentRequestList{Items: e.items[start : start+1]}
+	if start+1 < len(e.items) {
+		cont, _ := json.Marshal(store.Continue{Version: store.CurrentContinueVersion, Name: *e.items[start+1].Metadata.Name})
+		list.Metadata.Continue = lo.ToPtr(base64.StdEncoding.EncodeToString(cont))
+	}
+	return list, nil
+}
+
+func (e *pagedEnrollmentRequests) Delete(ctx context.Context, orgId uuid.UUID, name string) error {
+	e.deleted = append(e.deleted, name)
+	return nil
+}
+
+func TestEnrollmentRequestExpiry(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	enrollmentRequest := func(name string, age time.Duration, approved bool) api.EnrollmentRequest {
+		enrollmentRequest := api.EnrollmentRequest{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr(name), CreationTimestamp: lo.ToPtr(now.Add(-age))},
+			Status:   &api.EnrollmentRequestStatus{},
+		}
+		if approved {
+			api.SetStatusCondition(&enrollmentRequest.Status.Conditions, api.Condition{Type: api.EnrollmentRequestApproved, Status: api.ConditionStatusTrue})
+		}
+		return enrollmentRequest
+	}
+	enrollmentRequests := &pagedEnrollmentRequests{items: []api.EnrollmentRequest{
+		enrollmentRequest("abandoned", 8*24*time.Hour, false),
+		enrollmentRequest("approved", 30*24*time.Hour, true),
+		enrollmentRequest("recent", time.Hour, false),
+		enrollmentRequest("stale", 7*24*time.Hour+time.Minute, false),
+	}}
+	expiry := NewEnrollmentRequestExpiry(logrus.New(), &expiringEnrollmentRequestStore{enrollmentRequests: enrollmentRequests}, 7*24*time.Hour)
+	expired := testutil.ToFloat64(enrollmentRequestsExpired)
+
+	// only the enrollment requests pending for longer than the TTL are deleted
+	expiry.deleteExpired(context.Background(), now)
+	require.Equal([]string{"abandoned", "stale"}, enrollmentRequests.deleted)
+	require.Equal(expired+2, testutil.ToFloat64(enrollmentRequestsExpired))
+	require.Equal(1.0, testutil.ToFloat64(enrollmentRequestsPending))
+}
@@ -0,0 +1,186 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// FleetPromotionPollingInterval is the interval at which the fleet promotion
+// task runs, which is the resolution of the soak times.
+const FleetPromotionPollingInterval = time.Minute
+
+// FleetPromotion promotes the template versions which soaked in the fleets
+// other fleets are promoted from, such as from a staging to a production
+// fleet, or proposes them if the promotion requires approval.
+type FleetPromotion struct {
+	log             logrus.FieldLogger
+	store           store.Store
+	callbackManager CallbackManager
+}
+
+func NewFleetPromotion(log logrus.FieldLogger, store store.Store, callbackManager CallbackManager) *FleetPromotion {
+	return &FleetPromotion{
+		log:             log,
+		store:           store,
+		callbackManager: callbackManager,
+	}
+}
+
+// Poll follows the health of the newest template versions of the fleets
+// promoted from, and promotes those which were healthy for the soak time.
+func (t *FleetPromotion) Poll() {
+	t.log.Info("Running FleetPromotion Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		fleets, err := t.store.Fleet().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list fleets")
+			return
+		}
+
+		for i := range fleets.Items {
+			fleet := &fleets.Items[i]
+			if fleet.Spec.Promotion == nil {
+				continue
+			}
+			if err := t.promote(ctx, orgID, fleet, time.Now()); err != nil {
+				t.log.WithError(err).Errorf("failed to promote to fleet %s", *fleet.Metadata.Name)
+			}
+		}
+
+		if fleets.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(fleets.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+func (t *FleetPromotion) promote(ctx context.Context, orgID uuid.UUID, fleet *api.Fleet, now time.Time) error {
+	name := *fleet.Metadata.Name
+	spec := fleet.Spec.Promotion
+	from, err := t.store.Fleet().Get(ctx, orgID, spec.From, store.WithSummary(true))
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		t.log.Debugf("fleet %s which fleet %s is promoted from does not exist", spec.From, name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get fleet %s: %w", spec.From, err)
+	}
+	templateVersion, err := t.store.TemplateVersion().GetNewestValid(ctx, orgID, spec.From)
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get templateVersion of fleet %s: %w", spec.From, err)
+	}
+
+	var previous *api.FleetPromotionStatus
+	if fleet.Status != nil {
+		previous = fleet.Status.Promotion
+	}
+	promotion, promote := planPromotion(previous, spec, from, *templateVersion.Metadata.Name, now)
+	if promote {
+		if err := common.PromoteFleetTemplate(ctx, t.store, t.callbackManager.FleetUpdatedCallback, orgID, fleet, promotion.TemplateVersion); err != nil {
+			return err
+		}
+	}
+	if promotion.State != lo.FromPtr(previous).State || promotion.TemplateVersion != lo.FromPtr(previous).TemplateVersion {
+		switch promotion.State {
+		case api.FleetPromotionStateProposed:
+			common.AuditFleetPromotion(t.log, name, spec.From, promotion.TemplateVersion, common.FleetPromotionProposed)
+		case api.FleetPromotionStatePromoted:
+			common.AuditFleetPromotion(t.log, name, spec.From, promotion.TemplateVersion, common.FleetPromotionPromoted)
+		}
+	}
+	if err := t.store.Fleet().UpdatePromotion(ctx, orgID, name, promotion); err != nil {
+		return fmt.Errorf("failed updating promotion status: %w", err)
+	}
+	return nil
+}
+
+// planPromotion returns the promotion status of the newest template version
+// of the fleet promoted from, and whether it is promoted now. The soak time
+// starts over whenever the template version is not healthy. A template
+// version which was proposed stays proposed until it is approved or a newer
+// one replaces it.
+func planPromotion(previous *api.FleetPromotionStatus, spec *api.FleetPromotionSpec, from *api.Fleet, templateVersion string, now time.Time) (*api.FleetPromotionStatus, bool) {
+	if previous != nil && previous.TemplateVersion == templateVersion && previous.State != api.FleetPromotionStateSoaking {
+		return previous, false
+	}
+	promotion := &api.FleetPromotionStatus{TemplateVersion: templateVersion, State: api.FleetPromotionStateSoaking}
+	if reason := promotionUnhealthyReason(from, templateVersion); reason != "" {
+		promotion.Message = &reason
+		return promotion, false
+	}
+	promotion.HealthySince = lo.ToPtr(now)
+	if previous != nil && previous.TemplateVersion == templateVersion && previous.HealthySince != nil {
+		promotion.HealthySince = previous.HealthySince
+	}
+	// the soak time was validated with the fleet
+	soakTime, _ := time.ParseDuration(spec.SoakTime)
+	if now.Sub(*promotion.HealthySince) < soakTime {
+		return promotion, false
+	}
+	if lo.FromPtr(spec.Approval) == api.FleetPromotionApprovalManual {
+		promotion.State = api.FleetPromotionStateProposed
+		promotion.ProposedAt = lo.ToPtr(now)
+		return promotion, false
+	}
+	promotion.State = api.FleetPromotionStatePromoted
+	promotion.PromotedAt = lo.ToPtr(now)
+	return promotion, true
+}
+
+// promotionUnhealthyReason returns why the template version is not healthy in
+// the fleet promoted from, or "" if it is: its template is the template of the
+// fleet, its rollout completed without failed devices and no device of the
+// fleet is in Error or Degraded status.
+func promotionUnhealthyReason(from *api.Fleet, templateVersion string) string {
+	name := lo.FromPtr(from.Metadata.Name)
+	if lo.FromPtr(from.Metadata.Annotations)[model.FleetAnnotationTemplateVersion] != templateVersion {
+		return fmt.Sprintf("the template of fleet %s changed since template version %s", name, templateVersion)
+	}
+	var rollout *api.FleetRolloutStatus
+	var summary *map[string]int
+	if from.Status != nil {
+		rollout = from.Status.Rollout
+		if from.Status.DevicesSummary != nil {
+			summary = from.Status.DevicesSummary.SummaryStatus
+		}
+	}
+	if rollout == nil || rollout.TemplateVersion != templateVersion {
+		return fmt.Sprintf("template version %s did not start rolling out to fleet %s", templateVersion, name)
+	}
+	if rollout.State != api.FleetRolloutStateCompleted {
+		return fmt.Sprintf("template version %s is rolling out to fleet %s", templateVersion, name)
+	}
+	if failed := lo.SumBy(rollout.Batches, func(batch api.FleetRolloutBatchStatus) int { return batch.Failed }); failed > 0 {
+		return fmt.Sprintf("%d devices of fleet %s failed to update to template version %s", failed, name, templateVersion)
+	}
+	unhealthy := lo.FromPtr(summary)[string(api.DeviceSummaryStatusError)] + lo.FromPtr(summary)[string(api.DeviceSummaryStatusDegraded)]
+	if unhealthy > 0 {
+		return fmt.Sprintf("%d devices of fleet %s are in Error or Degraded status", unhealthy, name)
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func promotedFromFleet(templateVersion string, state api.FleetRolloutState, failed int, summary map[string]int) *api.Fleet {
+	return &api.Fleet{
+		Metadata: api.ObjectMeta{
+			Name:        lo.ToPtr("staging"),
+			Annotations: &map[string]string{model.FleetAnnotationTemplateVersion: templateVersion},
+		},
+		Status: &api.FleetStatus{
+			Rollout: &api.FleetRolloutStatus{
+				TemplateVersion: templateVersion,
+				State:           state,
+				Batches:         []api.FleetRolloutBatchStatus{{Succeeded: 2, Failed: failed}},
+			},
+			DevicesSummary: &api.DevicesSummary{SummaryStatus: &summary},
+		},
+	}
+}
+
+func TestPlanPromotion(t *testing.T) {
+	require := require.New(t)
+	start := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	spec := &api.FleetPromotionSpec{From: "staging", SoakTime: "24h"}
+	healthy := promotedFromFleet("staging-2", api.FleetRolloutStateCompleted, 0, map[string]int{"Online": 2})
+
+	// the soak time starts once the template version is healthy
+	promotion, promote := planPromotion(nil, spec, promotedFromFleet("staging-2", api.FleetRolloutStateProgressing, 0, nil), "staging-2", start)
+	require.False(promote)
+	require.Equal(&api.FleetPromotionStatus{
+		TemplateVersion: "staging-2",
+		State:           api.FleetPromotionStateSoaking,
+		Message:         lo.ToPtr("template version staging-2 is rolling out to fleet staging"),
+	}, promotion)
+
+	promotion, promote = planPromotion(promotion, spec, healthy, "staging-2", start)
+	require.False(promote)
+	require.Equal(&start, promotion.HealthySince)
+	require.Nil(promotion.Message)
+
+	// the soak time starts over when the template version is unhealthy
+	unhealthy := promotedFromFleet("staging-2", api.FleetRolloutStateCompleted, 0, map[string]int{"Online": 1, "Error": 1})
+	promotion, promote = planPromotion(promotion, spec, unhealthy, "staging-2", start.Add(time.Hour))
+	require.False(promote)
+	require.Nil(promotion.HealthySince)
+	require.Equal("1 devices of fleet staging are in Error or Degraded status", lo.FromPtr(promotion.Message))
+
+	soaking, promote := planPromotion(promotion, spec, healthy, "staging-2", start.Add(2*time.Hour))
+	require.False(promote)
+	promotion, promote = planPromotion(soaking, spec, healthy, "staging-2", start.Add(25*time.Hour))
+	require.False(promote)
+	require.Equal(api.FleetPromotionStateSoaking, promotion.State)
+
+	// the template version is promoted once it soaked, and only once
+	now := start.Add(26 * time.Hour)
+	promotion, promote = planPromotion(soaking, spec, healthy, "staging-2", now)
+	require.True(promote)
+	require.Equal(api.FleetPromotionStatePromoted, promotion.State)
+	require.Equal(&now, promotion.PromotedAt)
+	_, promote = planPromotion(promotion, spec, healthy, "staging-2", now.Add(time.Hour))
+	require.False(promote)
+
+	// a promotion which requires approval is proposed instead
+	spec.Approval = lo.ToPtr(api.FleetPromotionApprovalManual)
+	promotion, promote = planPromotion(soaking, spec, healthy, "staging-2", now)
+	require.False(promote)
+	require.Equal(api.FleetPromotionStateProposed, promotion.State)
+	require.Equal(&now, promotion.ProposedAt)
+
+	// a newer template version replaces the proposed one
+	promotion, _ = planPromotion(promotion, spec, promotedFromFleet("staging-3", api.FleetRolloutStateCompleted, 0, nil), "staging-3", now)
+	require.Equal("staging-3", promotion.TemplateVersion)
+	require.Equal(api.FleetPromotionStateSoaking, promotion.State)
+}
+
+func TestPromotionUnhealthyReason(t *testing.T) {
+	require := require.New(t)
+	require.Empty(promotionUnhealthyReason(promotedFromFleet("staging-2", api.FleetRolloutStateCompleted, 0, nil), "staging-2"))
+	require.Equal("2 devices of fleet staging failed to update to template version staging-2",
+		promotionUnhealthyReason(promotedFromFleet("staging-2", api.FleetRolloutStateCompleted, 2, nil), "staging-2"))
+	require.Equal("the template of fleet staging changed since template version staging-2",
+		promotionUnhealthyReason(promotedFromFleet("staging-3", api.FleetRolloutStateCompleted, 0, nil), "staging-2"))
+
+	fleet := promotedFromFleet("staging-2", api.FleetRolloutStateCompleted, 0, nil)
+	fleet.Status.Rollout = nil
+	require.Equal("template version staging-2 did not start rolling out to fleet staging", promotionUnhealthyReason(fleet, "staging-2"))
+}
@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/pkg/cron"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// FleetReportsPollingInterval is the interval at which the fleet reports task
+// runs, which is the resolution of the cron schedules.
+const FleetReportsPollingInterval = time.Minute
+
+// FleetReports generates the health reports of the fleets whose schedule is
+// due, POSTs them to the webhooks of the fleet and stores them as artifacts.
+type FleetReports struct {
+	log        logrus.FieldLogger
+	store      store.Store
+	artifacts  artifacts.Store
+	httpClient *http.Client
+}
+
+// NewFleetReports creates the task. The artifact store is nil if no artifact
+// storage is configured.
+func NewFleetReports(log logrus.FieldLogger, store store.Store, artifactStore artifacts.Store) *FleetReports {
+	return &FleetReports{
+		log:        log,
+		store:      store,
+		artifacts:  artifactStore,
+		httpClient: &http.Client{Timeout: webhookDeliveryTimeout},
+	}
+}
+
+// Poll sends the reports of the fleets whose schedule activated since their
+// last report.
+func (t *FleetReports) Poll() {
+	t.log.Info("Running FleetReports Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		fleets, err := t.store.Fleet().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list fleets")
+			return
+		}
+
+		now := time.Now()
+		for i := range fleets.Items {
+			fleet := &fleets.Items[i]
+			if fleet.Spec.Reports == nil {
+				continue
+			}
+			due, err := fleetReportDue(fleet, now)
+			if err != nil {
+				t.log.WithError(err).Errorf("invalid report schedule of fleet %s", *fleet.Metadata.Name)
+				continue
+			}
+			if due {
+				t.report(ctx, orgID, fleet, now)
+			}
+		}
+
+		if fleets.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(fleets.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+// fleetReportDue returns whether the report schedule of the fleet activated
+// since its last report, or since the fleet was created if it was never
+// reported. Activations missed while the service was down yield one report.
+func fleetReportDue(fleet *api.Fleet, now time.Time) (bool, error) {
+	schedule, err := cron.Parse(fleet.Spec.Reports.Schedule)
+	if err != nil {
+		return false, err
+	}
+	last := lo.FromPtrOr(fleet.Metadata.CreationTimestamp, now)
+	if value, ok := lo.FromPtr(fleet.Metadata.Annotations)[model.FleetAnnotationLastReportTime]; ok {
+		if last, err = time.Parse(time.RFC3339, value); err != nil {
+			return false, fmt.Errorf("parsing annotation %s: %w", model.FleetAnnotationLastReportTime, err)
+		}
+	}
+	next := schedule.Next(last)
+	return !next.IsZero() && !next.After(now), nil
+}
+
+// report delivers the report of the fleet and records the time of the report,
+// even if a delivery failed, so that the other destinations do not receive the
+// report again before the next activation.
+func (t *FleetReports) report(ctx context.Context, orgID uuid.UUID, fleet *api.Fleet, now time.Time) {
+	name := *fleet.Metadata.Name
+	report, err := common.GenerateFleetReport(ctx, t.store, orgID, fleet, now)
+	if err != nil {
+		t.log.WithError(err).Errorf("failed to generate report of fleet %s", name)
+		return
+	}
+
+	for _, webhookName := range lo.FromPtr(fleet.Spec.Reports.Webhooks) {
+		webhook, err := t.store.Webhook().Get(ctx, orgID, webhookName)
+		if err != nil {
+			t.log.WithError(err).Errorf("failed to fetch webhook %s for the report of fleet %s", webhookName, name)
+			continue
+		}
+		if err := postJSON(ctx, t.httpClient, webhook.Spec.Url, report); err != nil {
+			t.log.WithError(err).Errorf("failed to send the report of fleet %s to webhook %s", name, webhookName)
+		}
+	}
+
+	if fleet.Spec.Reports.ObjectStorage != nil {
+		if err := t.upload(ctx, fleet.Spec.Reports.ObjectStorage, report); err != nil {
+			t.log.WithError(err).Errorf("failed to store the report of fleet %s", name)
+		}
+	}
+
+	annotations := map[string]string{model.FleetAnnotationLastReportTime: now.UTC().Format(time.RFC3339)}
+	if err := t.store.Fleet().UpdateAnnotations(ctx, orgID, name, annotations, nil); err != nil {
+		t.log.WithError(err).Errorf("failed to record the report time of fleet %s", name)
+	}
+}
+
+func (t *FleetReports) upload(ctx context.Context, objectStorage *api.FleetReportsObjectStorage, report *api.FleetReport) error {
+	if t.artifacts == nil {
+		return fmt.Errorf("no artifact storage is configured for reports")
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshalling report: %w", err)
+	}
+	return t.artifacts.Put(ctx, fleetReportKey(objectStorage, report), body, "application/json")
+}
+
+// fleetReportKey returns the artifact key of the report, below the reports
+// kind so that the retention of reports applies to it.
+func fleetReportKey(objectStorage *api.FleetReportsObjectStorage, report *api.FleetReport) string {
+	return fmt.Sprintf("%s/%s%s/%s.json", artifacts.KindReports, lo.FromPtr(objectStorage.Prefix), report.Fleet, report.GeneratedAt.UTC().Format("20060102T150405Z"))
+}
@@ -0,0 +1,79 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetReportDue(t *testing.T) {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 14, 6, 0, 30, 0, time.UTC)
+	tests := []struct {
+		name       string
+		schedule   string
+		lastReport string
+		want       bool
+		wantErr    bool
+	}{
+		{
+			name:     "never reported",
+			schedule: "0 6 * * *",
+			want:     true,
+		},
+		{
+			name:       "reported at the last activation",
+			schedule:   "0 6 * * *",
+			lastReport: "2026-10-14T06:00:05Z",
+		},
+		{
+			name:       "activation since the last report",
+			schedule:   "0 6 * * *",
+			lastReport: "2026-10-13T06:00:05Z",
+			want:       true,
+		},
+		{
+			name:       "next activation ahead",
+			schedule:   "@weekly",
+			lastReport: "2026-10-11T00:00:10Z",
+		},
+		{
+			name:     "invalid schedule",
+			schedule: "daily",
+			wantErr:  true,
+		},
+		{
+			name:       "invalid report time",
+			schedule:   "@daily",
+			lastReport: "yesterday",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			fleet := &api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr("fleet"), CreationTimestamp: &created}}
+			fleet.Spec.Reports = &api.FleetReportsSpec{Schedule: tt.schedule}
+			if tt.lastReport != "" {
+				fleet.Metadata.Annotations = &map[string]string{model.FleetAnnotationLastReportTime: tt.lastReport}
+			}
+			due, err := fleetReportDue(fleet, now)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.want, due)
+		})
+	}
+}
+
+func TestFleetReportKey(t *testing.T) {
+	report := &api.FleetReport{Fleet: "edge", GeneratedAt: time.Date(2026, 10, 14, 6, 0, 30, 0, time.UTC)}
+	require.Equal(t, "reports/edge/20261014T060030Z.json", fleetReportKey(&api.FleetReportsObjectStorage{}, report))
+	require.Equal(t, "reports/daily/edge/20261014T060030Z.json", fleetReportKey(&api.FleetReportsObjectStorage{Prefix: lo.ToPtr("daily/")}, report))
+}
@@ -5,22 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/inventory"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
+	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
 )
 
-func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, log logrus.FieldLogger) error {
+func fleetRollout(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, inventory *inventory.Client, log logrus.FieldLogger) error {
 	if resourceRef.Op != FleetRolloutOpUpdate {
 		log.Errorf("received unknown op %s", resourceRef.Op)
 		return nil
 	}
 	logic := NewFleetRolloutsLogic(callbackManager, log, store, *resourceRef)
+	logic.SetInventory(inventory)
 	switch resourceRef.Kind {
 	case model.FleetKind:
 		err := logic.RolloutFleet(ctx)
@@ -45,9 +50,11 @@ type FleetRolloutsLogic struct {
 	fleetStore      store.Fleet
 	devStore        store.Device
 	tvStore         store.TemplateVersion
+	siteStore       store.Site
 	resourceRef     ResourceReference
 	itemsPerPage    int
 	owner           string
+	inventory       *inventory.Client
 }
 
 func NewFleetRolloutsLogic(callbackManager CallbackManager, log logrus.FieldLogger, storeInst store.Store, resourceRef ResourceReference) FleetRolloutsLogic {
@@ -57,6 +64,7 @@ func NewFleetRolloutsLogic(callbackManager CallbackManager, log logrus.FieldLogg
 		fleetStore:      storeInst.Fleet(),
 		devStore:        storeInst.Device(),
 		tvStore:         storeInst.TemplateVersion(),
+		siteStore:       storeInst.Site(),
 		resourceRef:     resourceRef,
 		itemsPerPage:    ItemsPerPage,
 	}
@@ -66,53 +74,275 @@ func (f *FleetRolloutsLogic) SetItemsPerPage(items int) {
 	f.itemsPerPage = items
 }
 
+// SetInventory sets the inventory system the device.inventory[KEY] parameters
+// of the templates are looked up in.
+func (f *FleetRolloutsLogic) SetInventory(inventory *inventory.Client) {
+	f.inventory = inventory
+}
+
 func (f FleetRolloutsLogic) RolloutFleet(ctx context.Context) error {
 	f.log.Infof("Rolling out fleet %s/%s", f.resourceRef.OrgID, f.resourceRef.Name)
+	return f.rolloutFleet(ctx, true)
+}
+
+// ProgressRollout updates the rollout progress in the status of the fleet and
+// starts the next batch of devices once the current one finished.
+func (f FleetRolloutsLogic) ProgressRollout(ctx context.Context) error {
+	return f.rolloutFleet(ctx, false)
+}
+
+// rolloutFleet rolls out the newest template version to the devices of the
+// next batch, and with resync also to the devices of the batches which already
+// started, whose spec may have changed along with the fleet.
+func (f FleetRolloutsLogic) rolloutFleet(ctx context.Context, resync bool) error {
+	fleet, err := f.fleetStore.Get(ctx, f.resourceRef.OrgID, f.resourceRef.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get fleet: %w", err)
+	}
+	policy, err := fleetRolloutPolicy(fleet)
+	if err != nil {
+		return err
+	}
 
 	templateVersion, err := f.tvStore.GetNewestValid(ctx, f.resourceRef.OrgID, f.resourceRef.Name)
 	if err != nil {
 		return fmt.Errorf("failed to get templateVersion: %w", err)
 	}
 
-	failureCount := 0
 	owner := util.SetResourceOwner(model.FleetKind, f.resourceRef.Name)
 	f.owner = *owner
+	devices, err := f.listDevices(ctx, *owner)
+	if err != nil {
+		// TODO: Retry when we have a mechanism that allows it
+		return err
+	}
 
-	listParams := store.ListParams{Owners: []string{*owner}, Limit: ItemsPerPage}
+	control, err := fleetRolloutControl(fleet, *templateVersion.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	var previous *api.FleetRolloutStatus
+	if fleet.Status != nil {
+		previous = fleet.Status.Rollout
+	}
+	// the batches of a batched rollout respect the bandwidth budgets of the
+	// sites of their devices and the concurrency limit of the policy
+	var limits rolloutLimits
+	if policy != nil {
+		if limits.sites, err = f.siteBandwidths(ctx); err != nil {
+			return err
+		}
+	}
+	if policy != nil && policy.ConcurrencyLimit != nil {
+		if limits.updating, err = f.updatingDevices(ctx, policy.ConcurrencyLimit.Label, devices, *templateVersion.Metadata.Name); err != nil {
+			return err
+		}
+	}
+	if policy != nil && policy.DependsOn != nil {
+		if limits.dependency, err = f.rolloutDependency(ctx, *policy.DependsOn); err != nil {
+			return err
+		}
+	}
+	rollout, next := planRollout(previous, *templateVersion.Metadata.Name, devices, policy, limits, control, time.Now())
+	starting := map[string]bool{}
+	for _, name := range next {
+		starting[name] = true
+	}
 
+	// An aborted rollout is reverted by rolling out the previous template version to its devices
+	target := templateVersion
+	revert := control.abort != nil && control.abort.Revert && rollout.PreviousTemplateVersion != nil
+	if revert {
+		target, err = f.tvStore.Get(ctx, f.resourceRef.OrgID, f.resourceRef.Name, *rollout.PreviousTemplateVersion)
+		if err != nil {
+			return fmt.Errorf("failed to get templateVersion %s to revert to: %w", *rollout.PreviousTemplateVersion, err)
+		}
+	}
+
+	failureCount := 0
+	for devIndex := range devices {
+		device := &devices[devIndex]
+		batch, started := deviceRolloutBatch(device, *templateVersion.Metadata.Name)
+		if starting[*device.Metadata.Name] {
+			batch = rollout.CurrentBatch
+		} else if !started || !(resync || revert) {
+			continue
+		}
+		err = f.updateDeviceToFleetTemplate(ctx, device, target, batch)
+		if err != nil {
+			f.log.Errorf("failed to update target generation for device %s (fleet %s): %v", *device.Metadata.Name, f.resourceRef.Name, err)
+			failureCount++
+		}
+	}
+
+	if err := f.fleetStore.UpdateRollout(ctx, f.resourceRef.OrgID, f.resourceRef.Name, rollout); err != nil {
+		return fmt.Errorf("failed updating rollout status: %w", err)
+	}
+
+	if failureCount != 0 {
+		// TODO: Retry when we have a mechanism that allows it
+		return fmt.Errorf("failed updating %d devices", failureCount)
+	}
+
+	return nil
+}
+
+func (f FleetRolloutsLogic) listDevices(ctx context.Context, owner string) ([]api.Device, error) {
+	result := []api.Device{}
+	listParams := store.ListParams{Owners: []string{owner}, Limit: f.itemsPerPage}
 	for {
 		devices, err := f.devStore.List(ctx, f.resourceRef.OrgID, listParams)
 		if err != nil {
-			// TODO: Retry when we have a mechanism that allows it
-			return fmt.Errorf("failed fetching devices: %w", err)
+			return nil, fmt.Errorf("failed fetching devices: %w", err)
+		}
+		result = append(result, devices.Items...)
+
+		if devices.Metadata.Continue == nil {
+			return result, nil
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+}
+
+// rolloutDependency returns what the rollout of the fleet waits for until the
+// fleets it depends on completed the rollouts of their newest template
+// versions without failed devices, or "" once they did. A fleet without a
+// valid template version has nothing to roll out.
+func (f FleetRolloutsLogic) rolloutDependency(ctx context.Context, dependsOn []string) (string, error) {
+	for _, name := range dependsOn {
+		fleet, err := f.fleetStore.Get(ctx, f.resourceRef.OrgID, name)
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return fmt.Sprintf("waiting for fleet %s, which does not exist", name), nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get fleet %s: %w", name, err)
+		}
+		templateVersion, err := f.tvStore.GetNewestValid(ctx, f.resourceRef.OrgID, name)
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get templateVersion of fleet %s: %w", name, err)
 		}
+		if reason := dependencyWaitingReason(fleet, *templateVersion.Metadata.Name); reason != "" {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
 
-		for devIndex := range devices.Items {
-			device := &devices.Items[devIndex]
-			err = f.updateDeviceToFleetTemplate(ctx, device, templateVersion)
+// siteBandwidths returns the bandwidth budgets of the sites which have one,
+// with the bandwidth the updates in progress at each site are estimated to
+// use, whichever fleets its devices belong to.
+func (f FleetRolloutsLogic) siteBandwidths(ctx context.Context) (map[string]siteBandwidth, error) {
+	sites := map[string]siteBandwidth{}
+	listParams := store.ListParams{Limit: f.itemsPerPage}
+	for {
+		list, err := f.siteStore.List(ctx, f.resourceRef.OrgID, listParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching sites: %w", err)
+		}
+		for _, site := range list.Items {
+			if site.Spec.BandwidthBudget == nil {
+				continue
+			}
+			bandwidth, err := f.siteBandwidth(ctx, *site.Metadata.Name, *site.Spec.BandwidthBudget)
 			if err != nil {
-				f.log.Errorf("failed to update target generation for device %s (fleet %s): %v", *device.Metadata.Name, f.resourceRef.Name, err)
-				failureCount++
+				return nil, err
 			}
+			sites[*site.Metadata.Name] = bandwidth
+			siteBandwidthBudget.WithLabelValues(*site.Metadata.Name).Set(float64(bandwidth.budget))
+			siteUpdateBandwidth.WithLabelValues(*site.Metadata.Name).Set(bandwidth.inUse)
+		}
+		if list.Metadata.Continue == nil {
+			return sites, nil
 		}
+		cont, err := store.ParseContinueString(list.Metadata.Continue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+}
 
+// siteBandwidth estimates the bandwidth the updates in progress at the site
+// use from the history of the updates of its devices, and the average rate of
+// the updates of the devices of the site.
+func (f FleetRolloutsLogic) siteBandwidth(ctx context.Context, site string, budget int64) (siteBandwidth, error) {
+	bandwidth := siteBandwidth{budget: budget}
+	updating := []*api.Device{}
+	total, known := 0.0, 0
+	listParams := store.ListParams{Labels: map[string]string{siteLabel: site}, Limit: f.itemsPerPage}
+	for {
+		devices, err := f.devStore.List(ctx, f.resourceRef.OrgID, listParams)
+		if err != nil {
+			return bandwidth, fmt.Errorf("failed fetching the devices of site %s: %w", site, err)
+		}
+		for i := range devices.Items {
+			device := &devices.Items[i]
+			if rate, ok := deviceUpdateRate(device); ok {
+				total += rate
+				known++
+			}
+			if deviceUpdating(device) {
+				updating = append(updating, device)
+			}
+		}
 		if devices.Metadata.Continue == nil {
 			break
-		} else {
-			cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return bandwidth, fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+	if known > 0 {
+		bandwidth.averageRate = total / float64(known)
+	}
+	for _, device := range updating {
+		rate, ok := deviceUpdateRate(device)
+		bandwidth.inUse += lo.Ternary(ok, rate, bandwidth.averageRate)
+	}
+	return bandwidth, nil
+}
+
+// updatingDevices returns the number of devices updating, in any fleet, with
+// each value of the label which the devices of the fleet not rolled out to
+// the template version yet have.
+func (f FleetRolloutsLogic) updatingDevices(ctx context.Context, label string, devices []api.Device, templateVersion string) (map[string]int, error) {
+	updating := map[string]int{}
+	for i := range devices {
+		value, ok := lo.FromPtr(devices[i].Metadata.Labels)[label]
+		if _, counted := updating[value]; !ok || counted {
+			continue
+		}
+		if _, started := deviceRolloutBatch(&devices[i], templateVersion); started {
+			continue
+		}
+		updating[value] = 0
+		listParams := store.ListParams{Labels: map[string]string{label: value}, Limit: f.itemsPerPage}
+		for {
+			list, err := f.devStore.List(ctx, f.resourceRef.OrgID, listParams)
+			if err != nil {
+				return nil, fmt.Errorf("failed fetching the devices with label %s=%s: %w", label, value, err)
+			}
+			updating[value] += lo.CountBy(list.Items, func(device api.Device) bool { return deviceUpdating(&device) })
+			if list.Metadata.Continue == nil {
+				break
+			}
+			cont, err := store.ParseContinueString(list.Metadata.Continue)
 			if err != nil {
-				return fmt.Errorf("failed to parse continuation for paging: %w", err)
+				return nil, fmt.Errorf("failed to parse continuation for paging: %w", err)
 			}
 			listParams.Continue = cont
 		}
 	}
-
-	if failureCount != 0 {
-		// TODO: Retry when we have a mechanism that allows it
-		return fmt.Errorf("failed updating %d devices", failureCount)
-	}
-
-	return nil
+	return updating, nil
 }
 
 // The device's owner was changed, roll out if necessary
@@ -141,15 +371,36 @@ func (f FleetRolloutsLogic) RolloutDevice(ctx context.Context) error {
 	}
 	f.owner = *device.Metadata.Owner
 
+	fleet, err := f.fleetStore.Get(ctx, f.resourceRef.OrgID, ownerName)
+	if err != nil {
+		return fmt.Errorf("failed to get fleet: %w", err)
+	}
+	policy, err := fleetRolloutPolicy(fleet)
+	if err != nil {
+		return err
+	}
+	var rollout *api.FleetRolloutStatus
+	if fleet.Status != nil {
+		rollout = fleet.Status.Rollout
+	}
+	if holdsNewDevices(rollout, policy) {
+		f.log.Infof("Not rolling out device %s/%s while the rollout of fleet %s is in progress, paused or aborted", f.resourceRef.OrgID, f.resourceRef.Name, ownerName)
+		return nil
+	}
+	batch := 1
+	if rollout != nil && rollout.CurrentBatch > 0 {
+		batch = rollout.CurrentBatch
+	}
+
 	templateVersion, err := f.tvStore.GetNewestValid(ctx, f.resourceRef.OrgID, ownerName)
 	if err != nil {
 		return fmt.Errorf("failed to get templateVersion: %w", err)
 	}
 
-	return f.updateDeviceToFleetTemplate(ctx, device, templateVersion)
+	return f.updateDeviceToFleetTemplate(ctx, device, templateVersion, batch)
 }
 
-func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, device *api.Device, templateVersion *api.TemplateVersion) error {
+func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, device *api.Device, templateVersion *api.TemplateVersion, batch int) error {
 	currentVersion := ""
 	if device.Metadata.Annotations != nil {
 		v, ok := (*device.Metadata.Annotations)[model.DeviceAnnotationTemplateVersion]
@@ -158,17 +409,26 @@ func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, dev
 		}
 	}
 
-	deviceConfig, err := f.getDeviceConfig(device, templateVersion)
+	deviceConfig, err := f.getDeviceConfig(ctx, device, templateVersion)
 	if err != nil {
 		return err
 	}
 	newDeviceSpec := api.DeviceSpec{
-		Config:     deviceConfig,
-		Containers: templateVersion.Status.Containers,
-		Os:         templateVersion.Status.Os,
-		Systemd:    templateVersion.Status.Systemd,
-		Resources:  templateVersion.Status.Resources,
-		Hooks:      templateVersion.Status.Hooks,
+		Config:            deviceConfig,
+		Containers:        templateVersion.Status.Containers,
+		Os:                templateVersion.Status.Os,
+		Systemd:           templateVersion.Status.Systemd,
+		Resources:         templateVersion.Status.Resources,
+		Hooks:             templateVersion.Status.Hooks,
+		Agent:             templateVersion.Status.Agent,
+		Encryption:        templateVersion.Status.Encryption,
+		Crypto:            templateVersion.Status.Crypto,
+		Time:              templateVersion.Status.Time,
+		Compliance:        templateVersion.Status.Compliance,
+		Firewall:          templateVersion.Status.Firewall,
+		GarbageCollection: templateVersion.Status.GarbageCollection,
+		Drift:             templateVersion.Status.Drift,
+		Applications:      templateVersion.Status.Applications,
 	}
 
 	if currentVersion == *templateVersion.Metadata.Name && reflect.DeepEqual(newDeviceSpec, *device.Spec) {
@@ -184,6 +444,7 @@ func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, dev
 
 	annotations := map[string]string{
 		model.DeviceAnnotationTemplateVersion: *templateVersion.Metadata.Name,
+		model.DeviceAnnotationRolloutBatch:    strconv.Itoa(batch),
 	}
 	err = f.devStore.UpdateAnnotations(ctx, f.resourceRef.OrgID, *device.Metadata.Name, annotations, nil)
 	if err != nil {
@@ -193,11 +454,13 @@ func (f FleetRolloutsLogic) updateDeviceToFleetTemplate(ctx context.Context, dev
 	return err
 }
 
-func (f FleetRolloutsLogic) getDeviceConfig(device *api.Device, templateVersion *api.TemplateVersion) (*[]api.DeviceSpec_Config_Item, error) {
+func (f FleetRolloutsLogic) getDeviceConfig(ctx context.Context, device *api.Device, templateVersion *api.TemplateVersion) (*[]api.DeviceSpec_Config_Item, error) {
 	if templateVersion.Status.Config == nil {
 		return nil, nil
 	}
 
+	// the inventory system is only asked for the devices of templates referring to it
+	var inventoryValues map[string]string
 	deviceConfig := []api.DeviceSpec_Config_Item{}
 	for _, configItem := range *templateVersion.Status.Config {
 		cfgJson, err := configItem.MarshalJSON()
@@ -205,7 +468,13 @@ func (f FleetRolloutsLogic) getDeviceConfig(device *api.Device, templateVersion
 			return nil, fmt.Errorf("failed converting configuration to json: %w", err)
 		}
 
-		cfgJsonReplaced, warnings := ReplaceParameters(cfgJson, device.Metadata)
+		if inventoryValues == nil && f.inventory != nil && ContainsInventoryParameter(cfgJson) {
+			inventoryValues, err = f.inventory.Lookup(ctx, f.resourceRef.OrgID, device)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cfgJsonReplaced, warnings := ReplaceParameters(cfgJson, device.Metadata, inventoryValues)
 		if len(warnings) > 0 {
 			f.log.Infof("failed replacing parameters for device %s/%s: %s", f.resourceRef.OrgID, *device.Metadata.Name, strings.Join(warnings, ", "))
 		}
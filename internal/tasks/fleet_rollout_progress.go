@@ -0,0 +1,464 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+	"github.com/flightctl/flightctl/internal/inventory"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// FleetRolloutProgressPollingInterval is the interval at which the rollout
+// progress of the fleets is updated from the status of their devices.
+const FleetRolloutProgressPollingInterval = time.Minute
+
+// maxBlockingDevices is the number of failed devices named in the blocking
+// reason of a rollout.
+const maxBlockingDevices = 5
+
+var (
+	siteUpdateBandwidth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "flightctl",
+		Subsystem: "sites",
+		Name:      "update_bandwidth_bytes_per_second",
+		Help:      "Estimated bandwidth used by the updates in progress at the sites with a bandwidth budget, as of the last rollout progress",
+	}, []string{"site"})
+	siteBandwidthBudget = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "flightctl",
+		Subsystem: "sites",
+		Name:      "bandwidth_budget_bytes_per_second",
+		Help:      "Bandwidth budget shared by the updates of the devices of the sites",
+	}, []string{"site"})
+)
+
+// siteLabel is the label whose value names the site of a device, as for
+// waking devices through another device at the same site.
+const siteLabel = "site"
+
+// rolloutControl holds whether the rollout of a fleet was paused or aborted.
+type rolloutControl struct {
+	paused bool
+	abort  *model.FleetRolloutAbort
+}
+
+type deviceRolloutState int
+
+const (
+	deviceRolloutInProgress deviceRolloutState = iota
+	deviceRolloutSucceeded
+	deviceRolloutFailed
+)
+
+// siteBandwidth is the bandwidth budget in bytes per second of a site, which
+// the updates of its devices share, with the bandwidth the updates in
+// progress at the site are estimated to use. The average rate of the updates
+// of the devices of the site stands in for the devices which completed no
+// update yet.
+type siteBandwidth struct {
+	budget      int64
+	inUse       float64
+	averageRate float64
+}
+
+// rolloutLimits holds what limits the next batch of a rollout besides its
+// size: the bandwidth budgets of the sites, the devices updating with each
+// value of the label of the concurrency limit of the policy, and what the
+// first batch waits for while the fleets the fleet depends on did not
+// complete their rollouts.
+type rolloutLimits struct {
+	sites      map[string]siteBandwidth
+	updating   map[string]int
+	dependency string
+}
+
+// FleetRolloutProgress follows the rollouts of the fleets, which progress as
+// their devices report the rendered versions of the template version.
+type FleetRolloutProgress struct {
+	log             logrus.FieldLogger
+	store           store.Store
+	callbackManager CallbackManager
+	inventory       *inventory.Client
+}
+
+func NewFleetRolloutProgress(log logrus.FieldLogger, store store.Store, callbackManager CallbackManager, inventory *inventory.Client) *FleetRolloutProgress {
+	return &FleetRolloutProgress{
+		log:             log,
+		store:           store,
+		callbackManager: callbackManager,
+		inventory:       inventory,
+	}
+}
+
+// Poll updates the rollout status of the fleets and starts the next batch of
+// the rollouts whose current batch finished.
+func (t *FleetRolloutProgress) Poll() {
+	t.log.Info("Running FleetRolloutProgress Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		fleets, err := t.store.Fleet().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list fleets")
+			return
+		}
+
+		for i := range fleets.Items {
+			name := *fleets.Items[i].Metadata.Name
+			logic := NewFleetRolloutsLogic(t.callbackManager, t.log, t.store, ResourceReference{OrgID: orgID, Kind: model.FleetKind, Name: name})
+			logic.SetInventory(t.inventory)
+			if err := logic.ProgressRollout(ctx); err != nil {
+				t.log.WithError(err).Debugf("failed to progress the rollout of fleet %s", name)
+			}
+		}
+
+		if fleets.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(fleets.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+// fleetRolloutPolicy returns the rollout policy of the fleet, which is a field
+// of the v1beta1 API kept in the conversion annotation of the stored fleet.
+func fleetRolloutPolicy(fleet *api.Fleet) (*v1beta1.FleetRolloutPolicy, error) {
+	converted, err := v1beta1.ConvertFleetFromV1alpha1(fleet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollout policy: %w", err)
+	}
+	return converted.Spec.RolloutPolicy, nil
+}
+
+// fleetRolloutControl returns the pause and abort of the rollout of the fleet
+// to the template version. The abort of a previous rollout no longer applies.
+func fleetRolloutControl(fleet *api.Fleet, templateVersion string) (rolloutControl, error) {
+	annotations := lo.FromPtr(fleet.Metadata.Annotations)
+	_, paused := annotations[model.FleetAnnotationRolloutPaused]
+	control := rolloutControl{paused: paused}
+	value, ok := annotations[model.FleetAnnotationRolloutAborted]
+	if !ok {
+		return control, nil
+	}
+	var abort model.FleetRolloutAbort
+	if err := json.Unmarshal([]byte(value), &abort); err != nil {
+		return control, fmt.Errorf("failed parsing annotation %s: %w", model.FleetAnnotationRolloutAborted, err)
+	}
+	if abort.TemplateVersion == templateVersion {
+		control.abort = &abort
+	}
+	return control, nil
+}
+
+// holdsNewDevices returns whether devices joining the fleet wait for the
+// rollout rather than being updated to the template version at once, which is
+// the case while a batched rollout is in progress and once it was paused or
+// aborted.
+func holdsNewDevices(rollout *api.FleetRolloutStatus, policy *v1beta1.FleetRolloutPolicy) bool {
+	if rollout == nil {
+		return policy != nil
+	}
+	switch rollout.State {
+	case api.FleetRolloutStatePaused, api.FleetRolloutStateAborted:
+		return true
+	case api.FleetRolloutStateCompleted:
+		return false
+	default:
+		return policy != nil
+	}
+}
+
+// deviceRolloutBatch returns the batch in which the device was rolled out to
+// the template version, and false if it was not rolled out yet. Devices rolled
+// out before batches were recorded count as the first batch.
+func deviceRolloutBatch(device *api.Device, templateVersion string) (int, bool) {
+	annotations := lo.FromPtr(device.Metadata.Annotations)
+	if annotations[model.DeviceAnnotationTemplateVersion] != templateVersion {
+		return 0, false
+	}
+	batch, err := strconv.Atoi(annotations[model.DeviceAnnotationRolloutBatch])
+	if err != nil || batch < 1 {
+		return 1, true
+	}
+	return batch, true
+}
+
+// deviceRolloutOutcome returns whether the device applied the spec it was
+// rolled out to, which it did once it reports the rendered version of its
+// spec. Devices in error, and with an update timeout devices taking longer
+// than it since the start of their batch, failed.
+func deviceRolloutOutcome(device *api.Device, startedAt *time.Time, updateTimeout time.Duration, now time.Time) deviceRolloutState {
+	status := lo.FromPtr(device.Status)
+	if status.Summary.Status == api.DeviceSummaryStatusError || status.Summary.Status == api.DeviceSummaryStatusDegraded {
+		return deviceRolloutFailed
+	}
+	renderedVersion := lo.FromPtr(device.Metadata.Annotations)[model.DeviceAnnotationRenderedVersion]
+	if renderedVersion != "" && status.Config.RenderedVersion == renderedVersion {
+		return deviceRolloutSucceeded
+	}
+	if updateTimeout > 0 && startedAt != nil && now.Sub(*startedAt) > updateTimeout {
+		return deviceRolloutFailed
+	}
+	return deviceRolloutInProgress
+}
+
+// dependencyWaitingReason returns what a dependent rollout waits for until
+// the fleet completed the rollout of the template version without failed
+// devices, or "" once it did.
+func dependencyWaitingReason(fleet *api.Fleet, templateVersion string) string {
+	name := lo.FromPtr(fleet.Metadata.Name)
+	var rollout *api.FleetRolloutStatus
+	if fleet.Status != nil {
+		rollout = fleet.Status.Rollout
+	}
+	if rollout == nil || rollout.TemplateVersion != templateVersion {
+		return fmt.Sprintf("waiting for fleet %s to start rolling out template version %s", name, templateVersion)
+	}
+	if rollout.State != api.FleetRolloutStateCompleted {
+		return fmt.Sprintf("waiting for the rollout of template version %s of fleet %s, which is %s", templateVersion, name, rollout.State)
+	}
+	failed := lo.SumBy(rollout.Batches, func(batch api.FleetRolloutBatchStatus) int { return batch.Failed })
+	if failed > 0 {
+		return fmt.Sprintf("waiting for fleet %s, whose rollout of template version %s completed with %d failed devices", name, templateVersion, failed)
+	}
+	return ""
+}
+
+// deviceUpdating returns whether the device is updating to a new spec, which
+// it reports with the progress of its update or its Updating condition.
+func deviceUpdating(device *api.Device) bool {
+	status := lo.FromPtr(device.Status)
+	return status.UpdateProgress != nil || api.IsStatusConditionTrue(status.Conditions, api.DeviceUpdating)
+}
+
+// deviceUpdateRate returns the bandwidth in bytes per second the updates of
+// the device used on average, and false if it completed no update yet.
+func deviceUpdateRate(device *api.Device) (float64, bool) {
+	history := lo.FromPtr(device.Status).UpdateHistory
+	if history == nil || history.AverageDurationSeconds <= 0 {
+		return 0, false
+	}
+	return float64(history.AverageDownloadedBytes) / float64(history.AverageDurationSeconds), true
+}
+
+// planRollout computes the progress of the rollout of the template version to
+// the devices of a fleet, keeping the times of the previous status of the same
+// rollout. Once the current batch has no device in progress, the next batch
+// starts with the pending devices in the order of their names, up to the batch
+// size of the policy, within the bandwidth budgets of their sites and the
+// concurrency limit of the policy, and planRollout returns the names of its
+// devices. A policy pausing on failure
+// blocks the rollout while any device failed, and no batch starts once the
+// rollout was paused or aborted.
+func planRollout(previous *api.FleetRolloutStatus, templateVersion string, devices []api.Device, policy *v1beta1.FleetRolloutPolicy, limits rolloutLimits, control rolloutControl, now time.Time) (*api.FleetRolloutStatus, []string) {
+	rollout := &api.FleetRolloutStatus{
+		TemplateVersion: templateVersion,
+		State:           api.FleetRolloutStateProgressing,
+		StartedAt:       now,
+		Batches:         []api.FleetRolloutBatchStatus{},
+	}
+	if previous != nil && previous.TemplateVersion != templateVersion {
+		// an aborted rollout does not count as rolled out
+		rollout.PreviousTemplateVersion = lo.Ternary(previous.State == api.FleetRolloutStateAborted, previous.PreviousTemplateVersion, &previous.TemplateVersion)
+		previous = nil
+	}
+	if previous != nil {
+		rollout.StartedAt = previous.StartedAt
+		rollout.PreviousTemplateVersion = previous.PreviousTemplateVersion
+	}
+
+	var updateTimeout time.Duration
+	if policy != nil && policy.UpdateTimeout != nil {
+		// the update timeout was validated with the fleet
+		updateTimeout, _ = time.ParseDuration(*policy.UpdateTimeout)
+	}
+
+	pending := []string{}
+	failed := []string{}
+	devicesByName := map[string]*api.Device{}
+	for i := range devices {
+		device := &devices[i]
+		batch, started := deviceRolloutBatch(device, templateVersion)
+		if !started {
+			pending = append(pending, *device.Metadata.Name)
+			devicesByName[*device.Metadata.Name] = device
+			continue
+		}
+		for len(rollout.Batches) < batch {
+			rollout.Batches = append(rollout.Batches, startedBatch(previous, len(rollout.Batches), now))
+		}
+		status := &rollout.Batches[batch-1]
+		switch deviceRolloutOutcome(device, status.StartedAt, updateTimeout, now) {
+		case deviceRolloutSucceeded:
+			status.Succeeded++
+		case deviceRolloutFailed:
+			status.Failed++
+			failed = append(failed, *device.Metadata.Name)
+		default:
+			status.InProgress++
+		}
+	}
+	sort.Strings(pending)
+	sort.Strings(failed)
+	rollout.CurrentBatch = len(rollout.Batches)
+
+	batchSize := len(pending)
+	if policy != nil && policy.BatchSize != nil {
+		batchSize = min(batchSize, int(*policy.BatchSize))
+	}
+	var next []string
+	currentInProgress := rollout.CurrentBatch > 0 && rollout.Batches[rollout.CurrentBatch-1].InProgress > 0
+	switch {
+	case control.abort != nil:
+		rollout.State = api.FleetRolloutStateAborted
+	case control.paused:
+		rollout.State = api.FleetRolloutStatePaused
+	case policy != nil && lo.FromPtr(policy.PauseOnFailure) && len(failed) > 0:
+		rollout.State = api.FleetRolloutStateBlocked
+		rollout.BlockingReason = lo.ToPtr(rolloutBlockingReason(failed))
+	case rollout.CurrentBatch == 0 && len(pending) > 0 && limits.dependency != "":
+		rollout.BlockingReason = lo.ToPtr(limits.dependency)
+	case !currentInProgress && len(pending) > 0:
+		var waiting string
+		next, pending, waiting = nextBatch(pending, devicesByName, batchSize, lo.FromPtr(policy).ConcurrencyLimit, limits)
+		if len(next) == 0 {
+			rollout.BlockingReason = &waiting
+			break
+		}
+		batch := startedBatch(previous, len(rollout.Batches), now)
+		batch.InProgress = len(next)
+		rollout.Batches = append(rollout.Batches, batch)
+		rollout.CurrentBatch++
+	}
+
+	for i := range rollout.Batches {
+		batch := &rollout.Batches[i]
+		if batch.InProgress > 0 {
+			continue
+		}
+		batch.FinishedAt = lo.ToPtr(now)
+		if previous != nil && i < len(previous.Batches) && previous.Batches[i].FinishedAt != nil {
+			batch.FinishedAt = previous.Batches[i].FinishedAt
+		}
+	}
+	for len(pending) > 0 {
+		size := min(len(pending), max(batchSize, 1))
+		rollout.Batches = append(rollout.Batches, api.FleetRolloutBatchStatus{Pending: size})
+		pending = pending[size:]
+	}
+
+	inProgress := lo.ContainsBy(rollout.Batches, func(batch api.FleetRolloutBatchStatus) bool {
+		return batch.InProgress > 0 || batch.Pending > 0
+	})
+	if rollout.State == api.FleetRolloutStateProgressing && !inProgress {
+		rollout.State = api.FleetRolloutStateCompleted
+	}
+	if rollout.State == api.FleetRolloutStateCompleted || rollout.State == api.FleetRolloutStateAborted {
+		rollout.FinishedAt = lo.ToPtr(now)
+		if previous != nil && previous.FinishedAt != nil {
+			rollout.FinishedAt = previous.FinishedAt
+		}
+	}
+	return rollout, next
+}
+
+// nextBatch returns the pending devices starting the next batch, up to the
+// batch size, and the devices which stay pending. A device with the label of
+// the concurrency limit only starts while fewer devices with the same value
+// of the label update than the limit allows. A device at a site with a
+// bandwidth budget only starts while the bandwidth of the updates at the site
+// stays within the budget, except for the first update at an idle site so
+// that the rollout progresses. If no device starts, nextBatch returns what
+// the first device waits for.
+func nextBatch(pending []string, devices map[string]*api.Device, batchSize int, concurrency *v1beta1.FleetRolloutConcurrencyLimit, limits rolloutLimits) ([]string, []string, string) {
+	next := []string{}
+	rest := []string{}
+	waiting := ""
+	inUse := map[string]float64{}
+	for site, bandwidth := range limits.sites {
+		inUse[site] = bandwidth.inUse
+	}
+	updating := map[string]int{}
+	for value, count := range limits.updating {
+		updating[value] = count
+	}
+	wait := func(name string, reason string) {
+		rest = append(rest, name)
+		if waiting == "" {
+			waiting = reason
+		}
+	}
+	for _, name := range pending {
+		if len(next) == batchSize {
+			rest = append(rest, name)
+			continue
+		}
+		device := devices[name]
+		labels := lo.FromPtr(device.Metadata.Labels)
+		value, limited := "", false
+		if concurrency != nil {
+			value, limited = labels[concurrency.Label]
+		}
+		if limited && updating[value] >= int(concurrency.MaxUpdating) {
+			wait(name, fmt.Sprintf("%d devices with label %s=%s are updating, and at most %d may update at the same time",
+				updating[value], concurrency.Label, value, concurrency.MaxUpdating))
+			continue
+		}
+		site := labels[siteLabel]
+		bandwidth, budgeted := limits.sites[site]
+		rate := 0.0
+		if budgeted {
+			var ok bool
+			if rate, ok = deviceUpdateRate(device); !ok {
+				rate = bandwidth.averageRate
+			}
+			if inUse[site] > 0 && inUse[site]+rate > float64(bandwidth.budget) {
+				wait(name, fmt.Sprintf("the updates in progress at site %s use up its bandwidth budget", site))
+				continue
+			}
+		}
+		if limited {
+			updating[value]++
+		}
+		inUse[site] += rate
+		next = append(next, name)
+	}
+	return next, rest, waiting
+}
+
+// startedBatch returns the status of a batch which started, at the time it
+// started in the previous status of the rollout if it did already.
+func startedBatch(previous *api.FleetRolloutStatus, index int, now time.Time) api.FleetRolloutBatchStatus {
+	batch := api.FleetRolloutBatchStatus{StartedAt: lo.ToPtr(now)}
+	if previous != nil && index < len(previous.Batches) && previous.Batches[index].StartedAt != nil {
+		batch.StartedAt = previous.Batches[index].StartedAt
+	}
+	return batch
+}
+
+func rolloutBlockingReason(failed []string) string {
+	names := strings.Join(lo.Slice(failed, 0, maxBlockingDevices), ", ")
+	if len(failed) > maxBlockingDevices {
+		names += fmt.Sprintf(" and %d more", len(failed)-maxBlockingDevices)
+	}
+	return fmt.Sprintf("the rollout pauses on failure and %d devices failed to update: %s", len(failed), names)
+}
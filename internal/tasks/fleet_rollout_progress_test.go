@@ -0,0 +1,270 @@
+package tasks
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func rolloutDevice(name string, templateVersion string, batch int, summary api.DeviceSummaryStatusType, applied bool) api.Device {
+	device := api.Device{Metadata: api.ObjectMeta{Name: lo.ToPtr(name)}, Status: lo.ToPtr(api.NewDeviceStatus())}
+	annotations := map[string]string{model.DeviceAnnotationRenderedVersion: "2"}
+	if templateVersion != "" {
+		annotations[model.DeviceAnnotationTemplateVersion] = templateVersion
+		annotations[model.DeviceAnnotationRolloutBatch] = fmt.Sprint(batch)
+	}
+	device.Metadata.Annotations = &annotations
+	device.Status.Summary.Status = summary
+	if applied {
+		device.Status.Config.RenderedVersion = "2"
+	}
+	return device
+}
+
+func TestPlanRollout(t *testing.T) {
+	started := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	now := started.Add(10 * time.Minute)
+	previous := &api.FleetRolloutStatus{
+		TemplateVersion: "v2",
+		StartedAt:       started,
+		Batches:         []api.FleetRolloutBatchStatus{{StartedAt: &started}},
+	}
+	policy := &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr(int32(2))}
+
+	t.Run("the first batch starts with the devices in the order of their names", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("c", "v1", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("a", "v1", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("b", "", 0, api.DeviceSummaryStatusOnline, false),
+		}
+		rollout, next := planRollout(previous, "v3", devices, policy, rolloutLimits{}, rolloutControl{}, now)
+		require.Equal([]string{"a", "b"}, next)
+		require.Equal(api.FleetRolloutStateProgressing, rollout.State)
+		require.Equal(now, rollout.StartedAt)
+		require.Equal("v2", lo.FromPtr(rollout.PreviousTemplateVersion))
+		require.Equal(1, rollout.CurrentBatch)
+		require.Equal([]api.FleetRolloutBatchStatus{{InProgress: 2, StartedAt: &now}, {Pending: 1}}, rollout.Batches)
+	})
+
+	t.Run("the next batch waits for the devices in progress", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("b", "v2", 1, api.DeviceSummaryStatusOnline, false),
+			rolloutDevice("c", "v1", 1, api.DeviceSummaryStatusOnline, true),
+		}
+		rollout, next := planRollout(previous, "v2", devices, policy, rolloutLimits{}, rolloutControl{}, now)
+		require.Empty(next)
+		require.Equal(started, rollout.StartedAt)
+		require.Equal([]api.FleetRolloutBatchStatus{{Succeeded: 1, InProgress: 1, StartedAt: &started}, {Pending: 1}}, rollout.Batches)
+	})
+
+	t.Run("a device exceeding the update timeout failed", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("b", "v2", 1, api.DeviceSummaryStatusOnline, false),
+			rolloutDevice("c", "v1", 1, api.DeviceSummaryStatusOnline, true),
+		}
+		rollout, next := planRollout(previous, "v2", devices, &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr(int32(2)), UpdateTimeout: lo.ToPtr("5m")}, rolloutLimits{}, rolloutControl{}, now)
+		require.Equal([]string{"c"}, next)
+		require.Equal(2, rollout.CurrentBatch)
+		require.Equal([]api.FleetRolloutBatchStatus{
+			{Succeeded: 1, Failed: 1, StartedAt: &started, FinishedAt: &now},
+			{InProgress: 1, StartedAt: &now},
+		}, rollout.Batches)
+	})
+
+	t.Run("a failure blocks a rollout pausing on failure", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusError, false),
+			rolloutDevice("b", "v2", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("c", "v1", 1, api.DeviceSummaryStatusOnline, true),
+		}
+		rollout, next := planRollout(previous, "v2", devices, &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr(int32(2)), PauseOnFailure: lo.ToPtr(true)}, rolloutLimits{}, rolloutControl{}, now)
+		require.Empty(next)
+		require.Equal(api.FleetRolloutStateBlocked, rollout.State)
+		require.Equal("the rollout pauses on failure and 1 devices failed to update: a", lo.FromPtr(rollout.BlockingReason))
+		require.Equal([]api.FleetRolloutBatchStatus{{Succeeded: 1, Failed: 1, StartedAt: &started, FinishedAt: &now}, {Pending: 1}}, rollout.Batches)
+	})
+
+	t.Run("the rollout completes once all devices were updated", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("b", "v2", 1, api.DeviceSummaryStatusOnline, true),
+		}
+		rollout, next := planRollout(previous, "v2", devices, nil, rolloutLimits{}, rolloutControl{}, now)
+		require.Empty(next)
+		require.Equal(api.FleetRolloutStateCompleted, rollout.State)
+		require.Equal(&now, rollout.FinishedAt)
+		require.Equal([]api.FleetRolloutBatchStatus{{Succeeded: 2, StartedAt: &started, FinishedAt: &now}}, rollout.Batches)
+	})
+
+	t.Run("no batch starts once the rollout was paused or aborted", func(t *testing.T) {
+		require := require.New(t)
+		devices := []api.Device{
+			rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusOnline, true),
+			rolloutDevice("b", "v1", 1, api.DeviceSummaryStatusOnline, true),
+		}
+		rollout, next := planRollout(previous, "v2", devices, policy, rolloutLimits{}, rolloutControl{paused: true}, now)
+		require.Empty(next)
+		require.Equal(api.FleetRolloutStatePaused, rollout.State)
+		require.Nil(rollout.FinishedAt)
+
+		rollout, next = planRollout(previous, "v2", devices, policy, rolloutLimits{}, rolloutControl{abort: &model.FleetRolloutAbort{TemplateVersion: "v2"}}, now)
+		require.Empty(next)
+		require.Equal(api.FleetRolloutStateAborted, rollout.State)
+		require.Equal(&now, rollout.FinishedAt)
+		require.Equal([]api.FleetRolloutBatchStatus{{Succeeded: 1, StartedAt: &started, FinishedAt: &now}, {Pending: 1}}, rollout.Batches)
+	})
+}
+
+func TestPlanRolloutSiteBandwidth(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	atSite := func(name string, site string, rate int64) api.Device {
+		device := rolloutDevice(name, "", 0, api.DeviceSummaryStatusOnline, false)
+		device.Metadata.Labels = &map[string]string{siteLabel: site}
+		if rate > 0 {
+			device.Status.UpdateHistory = &api.DeviceUpdateHistory{AverageDownloadedBytes: rate * 100, AverageDurationSeconds: 100}
+		}
+		return device
+	}
+	devices := []api.Device{
+		atSite("a", "plant-1", 40),
+		atSite("b", "plant-1", 40),
+		atSite("c", "plant-1", 0),
+		atSite("d", "plant-2", 40),
+		atSite("e", "store", 0),
+	}
+	policy := &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr(int32(4))}
+
+	// the devices without history are estimated with the average of the
+	// site, and the sites without a budget are not limited
+	sites := map[string]siteBandwidth{
+		"plant-1": {budget: 100, averageRate: 40},
+		"plant-2": {budget: 100, averageRate: 40},
+	}
+	rollout, next := planRollout(nil, "v2", devices, policy, rolloutLimits{sites: sites}, rolloutControl{}, now)
+	require.Equal([]string{"a", "b", "d", "e"}, next)
+	require.Equal([]api.FleetRolloutBatchStatus{{InProgress: 4, StartedAt: &now}, {Pending: 1}}, rollout.Batches)
+
+	// the first update at an idle site starts even beyond its budget
+	sites = map[string]siteBandwidth{"plant-1": {budget: 10, averageRate: 40}}
+	_, next = planRollout(nil, "v2", devices, policy, rolloutLimits{sites: sites}, rolloutControl{}, now)
+	require.Equal([]string{"a", "d", "e"}, next)
+
+	// no batch starts while the updates of other fleets use up the budgets
+	devices = devices[:3]
+	sites = map[string]siteBandwidth{"plant-1": {budget: 100, inUse: 80, averageRate: 40}}
+	rollout, next = planRollout(nil, "v2", devices, policy, rolloutLimits{sites: sites}, rolloutControl{}, now)
+	require.Empty(next)
+	require.Equal(api.FleetRolloutStateProgressing, rollout.State)
+	require.Equal("the updates in progress at site plant-1 use up its bandwidth budget", lo.FromPtr(rollout.BlockingReason))
+	require.Equal(0, rollout.CurrentBatch)
+	require.Equal([]api.FleetRolloutBatchStatus{{Pending: 3}}, rollout.Batches)
+}
+
+func TestPlanRolloutConcurrencyLimit(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	inStore := func(name string, store string) api.Device {
+		device := rolloutDevice(name, "", 0, api.DeviceSummaryStatusOnline, false)
+		if store != "" {
+			device.Metadata.Labels = &map[string]string{"store": store}
+		}
+		return device
+	}
+	devices := []api.Device{
+		inStore("a1", "42"),
+		inStore("a2", "42"),
+		inStore("b1", "43"),
+		inStore("b2", "43"),
+		inStore("c", ""),
+	}
+	policy := &v1beta1.FleetRolloutPolicy{
+		BatchSize:        lo.ToPtr(int32(10)),
+		ConcurrencyLimit: &v1beta1.FleetRolloutConcurrencyLimit{Label: "store", MaxUpdating: 1},
+	}
+
+	// a single device of each store starts, and the devices without the
+	// label are not limited
+	rollout, next := planRollout(nil, "v2", devices, policy, rolloutLimits{}, rolloutControl{}, now)
+	require.Equal([]string{"a1", "b1", "c"}, next)
+	require.Equal([]api.FleetRolloutBatchStatus{{InProgress: 3, StartedAt: &now}, {Pending: 2}}, rollout.Batches)
+
+	// the devices updating in other fleets count towards the limit
+	rollout, next = planRollout(nil, "v2", devices[:2], policy, rolloutLimits{updating: map[string]int{"42": 1}}, rolloutControl{}, now)
+	require.Empty(next)
+	require.Equal("1 devices with label store=42 are updating, and at most 1 may update at the same time", lo.FromPtr(rollout.BlockingReason))
+}
+
+func TestPlanRolloutDependency(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC)
+	policy := &v1beta1.FleetRolloutPolicy{BatchSize: lo.ToPtr(int32(1)), DependsOn: &[]string{"gateways"}}
+	limits := rolloutLimits{dependency: "waiting for fleet gateways to start rolling out template version v5"}
+
+	// the first batch waits for the fleets the fleet depends on
+	devices := []api.Device{
+		rolloutDevice("a", "", 0, api.DeviceSummaryStatusOnline, false),
+		rolloutDevice("b", "", 0, api.DeviceSummaryStatusOnline, false),
+	}
+	rollout, next := planRollout(nil, "v2", devices, policy, limits, rolloutControl{}, now)
+	require.Empty(next)
+	require.Equal(api.FleetRolloutStateProgressing, rollout.State)
+	require.Equal(limits.dependency, lo.FromPtr(rollout.BlockingReason))
+	require.Equal([]api.FleetRolloutBatchStatus{{Pending: 1}, {Pending: 1}}, rollout.Batches)
+
+	// a rollout which started is not held back by a later rollout of the
+	// fleets it depends on
+	devices[0] = rolloutDevice("a", "v2", 1, api.DeviceSummaryStatusOnline, true)
+	_, next = planRollout(nil, "v2", devices, policy, limits, rolloutControl{}, now)
+	require.Equal([]string{"b"}, next)
+}
+
+func TestDependencyWaitingReason(t *testing.T) {
+	require := require.New(t)
+	gateways := &api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr("gateways")}}
+	require.Equal("waiting for fleet gateways to start rolling out template version v5", dependencyWaitingReason(gateways, "v5"))
+
+	gateways.Status = &api.FleetStatus{Rollout: &api.FleetRolloutStatus{
+		TemplateVersion: "v5",
+		State:           api.FleetRolloutStateProgressing,
+		Batches:         []api.FleetRolloutBatchStatus{{Succeeded: 1, Failed: 1}, {InProgress: 1}},
+	}}
+	require.Equal("waiting for the rollout of template version v5 of fleet gateways, which is Progressing", dependencyWaitingReason(gateways, "v5"))
+
+	gateways.Status.Rollout.State = api.FleetRolloutStateCompleted
+	gateways.Status.Rollout.Batches[1] = api.FleetRolloutBatchStatus{Succeeded: 1}
+	require.Equal("waiting for fleet gateways, whose rollout of template version v5 completed with 1 failed devices", dependencyWaitingReason(gateways, "v5"))
+
+	gateways.Status.Rollout.Batches[0].Failed = 0
+	require.Empty(dependencyWaitingReason(gateways, "v5"))
+}
+
+func TestFleetRolloutControl(t *testing.T) {
+	require := require.New(t)
+	fleet := &api.Fleet{Metadata: api.ObjectMeta{Annotations: &map[string]string{
+		model.FleetAnnotationRolloutPaused:  "true",
+		model.FleetAnnotationRolloutAborted: `{"templateVersion":"v2","revert":true}`,
+	}}}
+	control, err := fleetRolloutControl(fleet, "v2")
+	require.NoError(err)
+	require.True(control.paused)
+	require.Equal(&model.FleetRolloutAbort{TemplateVersion: "v2", Revert: true}, control.abort)
+
+	// the abort of a previous rollout does not apply to the next one
+	control, err = fleetRolloutControl(fleet, "v3")
+	require.NoError(err)
+	require.Nil(control.abort)
+}
@@ -54,7 +54,7 @@ func (t *FleetValidateLogic) CreateNewTemplateVersionIfFleetValid(ctx context.Co
 	}
 
 	if validationErr != nil {
-		return t.setStatus(ctx, validationErr)
+		return t.setStatus(ctx, fleet.Metadata.Generation, validationErr)
 	}
 
 	templateVersion := api.TemplateVersion{
@@ -67,7 +67,7 @@ func (t *FleetValidateLogic) CreateNewTemplateVersionIfFleetValid(ctx context.Co
 
 	tv, err := t.store.TemplateVersion().Create(ctx, t.resourceRef.OrgID, &templateVersion, t.callbackManager.TemplateVersionCreatedCallback)
 	if err != nil {
-		return t.setStatus(ctx, fmt.Errorf("creating templateVersion for valid fleet: %w", err))
+		return t.setStatus(ctx, fleet.Metadata.Generation, fmt.Errorf("creating templateVersion for valid fleet: %w", err))
 	}
 
 	annotations := map[string]string{
@@ -75,14 +75,14 @@ func (t *FleetValidateLogic) CreateNewTemplateVersionIfFleetValid(ctx context.Co
 	}
 	err = t.store.Fleet().UpdateAnnotations(ctx, t.resourceRef.OrgID, *fleet.Metadata.Name, annotations, nil)
 	if err != nil {
-		return t.setStatus(ctx, fmt.Errorf("setting fleet annotation with newly-created templateVersion: %w", err))
+		return t.setStatus(ctx, fleet.Metadata.Generation, fmt.Errorf("setting fleet annotation with newly-created templateVersion: %w", err))
 	}
 
-	return t.setStatus(ctx, nil)
+	return t.setStatus(ctx, fleet.Metadata.Generation, nil)
 }
 
-func (t *FleetValidateLogic) setStatus(ctx context.Context, validationErr error) error {
-	condition := api.Condition{Type: api.FleetValid}
+func (t *FleetValidateLogic) setStatus(ctx context.Context, generation *int64, validationErr error) error {
+	condition := api.Condition{Type: api.FleetValid, ObservedGeneration: generation}
 
 	if validationErr == nil {
 		condition.Status = api.ConditionStatusTrue
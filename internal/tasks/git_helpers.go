@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/fs"
 	"net/http"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -26,6 +27,7 @@ import (
 	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	gitmemory "github.com/go-git/go-git/v5/storage/memory"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 // Ref: https://github.com/git/git/blob/master/Documentation/urls.txt#L37
@@ -107,28 +109,31 @@ func GetAuth(repository *model.Repository) (transport.AuthMethod, error) {
 			if sshSpec.SshConfig.PrivateKeyPassphrase != nil {
 				password = *sshSpec.SshConfig.PrivateKeyPassphrase
 			}
-			user := ""
-			repoSubmatch := scpLikeUrlRegExp.FindStringSubmatch(sshSpec.Url)
-			if len(repoSubmatch) > 1 {
-				user = repoSubmatch[1]
-			}
-			auth, err = gitssh.NewPublicKeys(user, sshPrivateKey, password)
+			auth, err = gitssh.NewPublicKeys(sshUser(sshSpec.Url), sshPrivateKey, password)
 			if err != nil {
 				return nil, err
 			}
-			if sshSpec.SshConfig.SkipServerVerification != nil && *sshSpec.SshConfig.SkipServerVerification {
-				auth.HostKeyCallbackHelper = gitssh.HostKeyCallbackHelper{
-					HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
-				}
-			}
 		}
-		if sshSpec.SshConfig.SkipServerVerification != nil && *sshSpec.SshConfig.SkipServerVerification {
+		switch {
+		case sshSpec.SshConfig.SkipServerVerification != nil && *sshSpec.SshConfig.SkipServerVerification:
 			if auth == nil {
 				auth = &gitssh.PublicKeys{}
 			}
 			auth.HostKeyCallbackHelper = gitssh.HostKeyCallbackHelper{
 				HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
 			}
+		case sshSpec.SshConfig.KnownHosts != nil:
+			hostKeyCallback, err := knownHostsCallback(*sshSpec.SshConfig.KnownHosts)
+			if err != nil {
+				return nil, err
+			}
+			if auth == nil {
+				auth = &gitssh.PublicKeys{User: sshUser(sshSpec.Url)}
+			}
+			auth.HostKeyCallbackHelper = gitssh.HostKeyCallbackHelper{HostKeyCallback: hostKeyCallback}
+		}
+		if auth == nil {
+			return nil, nil
 		}
 		return auth, nil
 	} else {
@@ -152,6 +157,42 @@ func GetAuth(repository *model.Repository) (transport.AuthMethod, error) {
 	return nil, nil
 }
 
+// sshUser returns the user of the SSH URL, either of the ssh://user@host/path
+// or of the scp-like user@host:path forms.
+func sshUser(repoURL string) string {
+	if endpoint, err := transport.NewEndpoint(repoURL); err == nil && endpoint.User != "" {
+		return endpoint.User
+	}
+	repoSubmatch := scpLikeUrlRegExp.FindStringSubmatch(repoURL)
+	if len(repoSubmatch) > 1 {
+		return repoSubmatch[1]
+	}
+	return ""
+}
+
+// knownHostsCallback returns the callback accepting only the host keys of
+// the known_hosts file.
+func knownHostsCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	file, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating known_hosts file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(knownHosts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed writing known_hosts file: %w", err)
+	}
+	// the file is read when the callback is created
+	callback, err := knownhosts.New(file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("invalid known hosts: %w", err)
+	}
+	return callback, nil
+}
+
 func configureRepoHTTPSClient(httpConfig api.HttpConfig) error {
 	tlsConfig := tls.Config{} //nolint:gosec
 	if httpConfig.SkipServerVerification != nil {
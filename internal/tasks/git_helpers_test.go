@@ -1,14 +1,24 @@
 package tasks
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
+	"net"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/go-git/go-billy/v5/memfs"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 var _ = Describe("ConvertFileSystemToIgnition", func() {
@@ -83,3 +93,67 @@ var _ = Describe("ConvertFileSystemToIgnition", func() {
 		})
 	})
 })
+
+var _ = Describe("GetAuth", func() {
+	var (
+		hostKey  ssh.PublicKey
+		otherKey ssh.PublicKey
+		userKey  string
+	)
+
+	newPublicKey := func() (ssh.PublicKey, ed25519.PrivateKey) {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		key, err := ssh.NewPublicKey(public)
+		Expect(err).ToNot(HaveOccurred())
+		return key, private
+	}
+
+	sshRepository := func(url string, config api.SshConfig) *model.Repository {
+		spec := api.RepositorySpec{}
+		Expect(spec.FromSshRepoSpec(api.SshRepoSpec{Url: url, Type: api.Git, SshConfig: config})).To(Succeed())
+		return &model.Repository{Spec: model.MakeJSONField(spec)}
+	}
+
+	BeforeEach(func() {
+		hostKey, _ = newPublicKey()
+		otherKey, _ = newPublicKey()
+		_, private := newPublicKey()
+		block, err := ssh.MarshalPrivateKey(private, "")
+		Expect(err).ToNot(HaveOccurred())
+		userKey = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(block))
+	})
+
+	When("the repository pins the host keys", func() {
+		It("accepts only the pinned keys", func() {
+			knownHosts := "# git server\n" + knownhosts.Line([]string{"git.example.com"}, hostKey) + "\n"
+			auth, err := GetAuth(sshRepository("ssh://deploy@git.example.com/org/repo.git", api.SshConfig{SshPrivateKey: &userKey, KnownHosts: &knownHosts}))
+			Expect(err).ToNot(HaveOccurred())
+			publicKeys, ok := auth.(*gitssh.PublicKeys)
+			Expect(ok).To(BeTrue())
+			Expect(publicKeys.User).To(Equal("deploy"))
+
+			addr := &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 22}
+			Expect(publicKeys.HostKeyCallback("git.example.com:22", addr, hostKey)).To(Succeed())
+			err = publicKeys.HostKeyCallback("git.example.com:22", addr, otherKey)
+			Expect(err).To(HaveOccurred())
+			Expect(accessFailureReason(err)).To(Equal(RepositoryHostKeyReason))
+		})
+	})
+
+	When("the repository uses an scp-like URL", func() {
+		It("authenticates as the user of the URL", func() {
+			auth, err := GetAuth(sshRepository("git@git.example.com:org/repo.git", api.SshConfig{SshPrivateKey: &userKey}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(auth.(*gitssh.PublicKeys).User).To(Equal("git"))
+		})
+	})
+
+	When("the repository has no SSH credentials", func() {
+		It("returns no authentication", func() {
+			auth, err := GetAuth(sshRepository("git@git.example.com:org/repo.git", api.SshConfig{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(auth).To(BeNil())
+		})
+	})
+})
@@ -1,12 +1,23 @@
 package tasks
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"strings"
+	"sync"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/ignition"
 )
 
 func buildHttpRepoRequestAuth(repoHttpSpec api.HttpRepoSpec, req *http.Request) (*http.Request, *tls.Config, error) {
@@ -16,6 +27,13 @@ func buildHttpRepoRequestAuth(repoHttpSpec api.HttpRepoSpec, req *http.Request)
 	if repoHttpSpec.HttpConfig.Token != nil {
 		req.Header.Set("Authorization", "Bearer "+*repoHttpSpec.HttpConfig.Token)
 	}
+	headers, err := repoHttpSpec.HttpConfig.ExpandHeaders()
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 	tlsConfig := &tls.Config{
 		MinVersion: tls.VersionTLS12,
 	}
@@ -60,3 +78,151 @@ func buildHttpRepoRequestAuth(repoHttpSpec api.HttpRepoSpec, req *http.Request)
 
 	return req, tlsConfig, nil
 }
+
+// maxHttpCacheEntries is the number of responses of HTTP configuration
+// sources kept to revalidate with their servers.
+const maxHttpCacheEntries = 256
+
+type httpCacheEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// httpResponseCache keeps the responses of HTTP configuration sources that
+// have an ETag or a Last-Modified header, so that rendering the devices
+// using a source downloads it only when it changed.
+type httpResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]httpCacheEntry
+}
+
+var httpConfigCache = &httpResponseCache{entries: map[string]httpCacheEntry{}}
+
+// cacheKey identifies the response to the request by its URL and headers, as
+// the response of servers depends on the credentials sent.
+func (c *httpResponseCache) cacheKey(req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.String()))
+	_ = req.Header.Write(hash)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *httpResponseCache) get(key string) (httpCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *httpResponseCache) set(key string, entry httpCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxHttpCacheEntries {
+		for evicted := range c.entries {
+			delete(c.entries, evicted)
+			break
+		}
+	}
+	c.entries[key] = entry
+}
+
+// fetchHttpConfig downloads the URL of the HTTP repository, sending the
+// validators of the response last downloaded so that the server can answer
+// that it did not change.
+func fetchHttpConfig(repoHttpSpec api.HttpRepoSpec, url string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req, tlsConfig, err := buildHttpRepoRequestAuth(repoHttpSpec, req)
+	if err != nil {
+		return nil, fmt.Errorf("error building request authentication: %w", err)
+	}
+
+	key := httpConfigCache.cacheKey(req)
+	cached, isCached := httpConfigCache.get(key)
+	if isCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	// Set up the HTTP client with the configured TLS settings
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && isCached {
+		return cached.body, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	entry := httpCacheEntry{etag: resp.Header.Get("ETag"), lastModified: resp.Header.Get("Last-Modified"), body: body}
+	if entry.etag != "" || entry.lastModified != "" {
+		httpConfigCache.set(key, entry)
+	}
+	return body, nil
+}
+
+// verifyChecksum checks that the body has the hex encoded SHA-256 checksum.
+func verifyChecksum(body []byte, expected string) error {
+	sum := sha256.Sum256(body)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("the SHA-256 checksum of the response is %s, expected %s", actual, expected)
+	}
+	return nil
+}
+
+// setArchiveFiles stores the regular files of the tar archive, which may be
+// gzip compressed, below the directory.
+func setArchiveFiles(ignitionWrapper ignition.Wrapper, archive []byte, dir string) error {
+	var reader io.Reader = bytes.NewReader(archive)
+	if bytes.HasPrefix(archive, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return fmt.Errorf("reading gzip archive: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		// the files of the archive must stay below the directory
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid path %s in archive", header.Name)
+		}
+		contents, err := io.ReadAll(tarReader)
+		if err != nil {
+			return fmt.Errorf("reading %s from tar archive: %w", header.Name, err)
+		}
+		ignitionWrapper.SetFile(path.Join(dir, name), contents, int(header.Mode&0o7777))
+	}
+}
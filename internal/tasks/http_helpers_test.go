@@ -1,6 +1,9 @@
 package tasks
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -8,11 +11,15 @@ import (
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
+	"fmt"
 	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"time"
 
+	config_latest_types "github.com/coreos/ignition/v2/config/v3_4/types"
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/pkg/ignition"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -122,6 +129,32 @@ var _ = Describe("buildHttpRepoRequestAuth", func() {
 		})
 	})
 
+	When("headers are provided", func() {
+		It("sets the headers with the credentials they refer to", func() {
+			token := "token"
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			repoHttpSpec := api.HttpRepoSpec{
+				HttpConfig: api.HttpConfig{
+					Token:   &token,
+					Headers: &map[string]string{"X-JFrog-Art-Api": "{{ token }}", "X-Client": "flightctl"},
+				},
+			}
+			req, _, err := buildHttpRepoRequestAuth(repoHttpSpec, req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.Header.Get("X-JFrog-Art-Api")).To(Equal("token"))
+			Expect(req.Header.Get("X-Client")).To(Equal("flightctl"))
+		})
+
+		It("fails if a header refers to a credential that is not set", func() {
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			repoHttpSpec := api.HttpRepoSpec{
+				HttpConfig: api.HttpConfig{Headers: &map[string]string{"PRIVATE-TOKEN": "{{ token }}"}},
+			}
+			_, _, err := buildHttpRepoRequestAuth(repoHttpSpec, req)
+			Expect(err).To(MatchError("header PRIVATE-TOKEN refers to token, which is not set"))
+		})
+	})
+
 	When("no authentication details are provided", func() {
 		It("does not set any auth headers", func() {
 			req, _ := http.NewRequest("GET", "http://example.com", nil)
@@ -133,3 +166,70 @@ var _ = Describe("buildHttpRepoRequestAuth", func() {
 		})
 	})
 })
+
+var _ = Describe("fetchHttpConfig", func() {
+	It("downloads the response again only when it changed", func() {
+		etag := `"v1"`
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			fmt.Fprint(w, etag)
+		}))
+		defer server.Close()
+
+		for _, expected := range []string{`"v1"`, `"v1"`} {
+			body, err := fetchHttpConfig(api.HttpRepoSpec{}, server.URL+"/config")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal(expected))
+		}
+		etag = `"v2"`
+		body, err := fetchHttpConfig(api.HttpRepoSpec{}, server.URL+"/config")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`"v2"`))
+		Expect(requests).To(Equal(3))
+	})
+})
+
+var _ = Describe("verifyChecksum", func() {
+	It("accepts only the pinned checksum", func() {
+		Expect(verifyChecksum([]byte("hello"), "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824")).To(Succeed())
+		Expect(verifyChecksum([]byte("hello!"), "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("setArchiveFiles", func() {
+	archive := func(files map[string]string) []byte {
+		buf := &bytes.Buffer{}
+		gzipWriter := gzip.NewWriter(buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+		for name, contents := range files {
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(contents)), Typeflag: tar.TypeReg})).To(Succeed())
+			_, err := tarWriter.Write([]byte(contents))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	It("stores the files of the archive below the directory", func() {
+		ignitionWrapper, err := ignition.NewWrapper()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(setArchiveFiles(ignitionWrapper, archive(map[string]string{"./app/config.yaml": "a: 1"}), "/etc")).To(Succeed())
+		config := ignitionWrapper.Merge(config_latest_types.Config{})
+		Expect(config.Storage.Files).To(HaveLen(1))
+		Expect(config.Storage.Files[0].Path).To(Equal("/etc/app/config.yaml"))
+		Expect(*config.Storage.Files[0].Mode).To(Equal(0o600))
+	})
+
+	It("rejects files outside of the directory", func() {
+		ignitionWrapper, err := ignition.NewWrapper()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(setArchiveFiles(ignitionWrapper, archive(map[string]string{"../passwd": "root"}), "/etc/app")).To(MatchError("invalid path ../passwd in archive"))
+	})
+})
@@ -0,0 +1,40 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/sirupsen/logrus"
+)
+
+// IdempotencyKeyPurgePollingInterval is the interval at which the expired
+// idempotency keys of requests are deleted.
+const IdempotencyKeyPurgePollingInterval = time.Hour
+
+// IdempotencyKeyPurge deletes the idempotency keys whose responses are no
+// longer replayed. Expired keys are already ignored, so this only reclaims
+// the space of their recorded responses.
+type IdempotencyKeyPurge struct {
+	log   logrus.FieldLogger
+	store store.Store
+}
+
+func NewIdempotencyKeyPurge(log logrus.FieldLogger, store store.Store) *IdempotencyKeyPurge {
+	return &IdempotencyKeyPurge{
+		log:   log,
+		store: store,
+	}
+}
+
+func (t *IdempotencyKeyPurge) Poll() {
+	t.log.Info("Running IdempotencyKeyPurge Polling")
+	deleted, err := t.store.IdempotencyKey().DeleteExpired(context.Background(), time.Now())
+	if err != nil {
+		t.log.WithError(err).Error("failed to delete expired idempotency keys")
+		return
+	}
+	if deleted > 0 {
+		t.log.Infof("deleted %d expired idempotency keys", deleted)
+	}
+}
@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/service/common"
+	"github.com/sirupsen/logrus"
+)
+
+// resolveImageDigests returns the digests the images of the device spec
+// resolve to, which are recorded with the rendered version for supply-chain
+// traceability. An image pinned by digest resolves to it without contacting
+// its registry. An image whose tag cannot be resolved, such as one of a
+// registry requiring credentials, is recorded without a digest rather than
+// failing the render.
+func resolveImageDigests(ctx context.Context, digester manifestDigester, spec *api.DeviceSpec, log logrus.FieldLogger) []api.ImageDigest {
+	images := common.SpecImages(spec)
+	digests := make([]api.ImageDigest, 0, len(images))
+	for _, image := range images {
+		digest, err := imageDigest(ctx, digester, image)
+		if err != nil {
+			log.Warnf("failed resolving the digest of image %s: %v", image, err)
+		}
+		digests = append(digests, api.ImageDigest{Image: image, Digest: digest})
+	}
+	return digests
+}
+
+func imageDigest(ctx context.Context, digester manifestDigester, image string) (*string, error) {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Digest != "" {
+		return &ref.Digest, nil
+	}
+	digest, err := digester.ManifestDigest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
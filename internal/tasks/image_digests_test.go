@@ -0,0 +1,41 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveImageDigests(t *testing.T) {
+	require := require.New(t)
+	digester := tagDigests{"quay.io/org/os:9.4": "sha256:1a2b"}
+	spec := &api.DeviceSpec{
+		Os: &api.DeviceOSSpec{Image: "quay.io/org/os:9.4"},
+		Agent: &api.DeviceAgentSpec{Update: &api.AgentUpdateSpec{
+			Version: "0.4.0",
+			Image:   lo.ToPtr("quay.io/org/agent@sha256:3c4d"),
+		}},
+		Applications: &[]api.ApplicationSpec{
+			{Name: "compose", Path: lo.ToPtr("/etc/compose/app.yaml")},
+			{Name: "pod", Pod: &api.ApplicationPodSpec{Containers: []api.ApplicationContainer{
+				{Name: "broker", Image: "registry.example.com/broker:2"},
+				{Name: "exporter", Image: "quay.io/org/os:9.4"},
+			}}},
+		},
+	}
+
+	// the images are recorded once, and pinned images resolve to their digest
+	// without the registry; an unresolvable tag is recorded without a digest
+	digests := resolveImageDigests(context.Background(), digester, spec, logrus.New())
+	require.Equal([]api.ImageDigest{
+		{Image: "quay.io/org/os:9.4", Digest: lo.ToPtr("sha256:1a2b")},
+		{Image: "quay.io/org/agent@sha256:3c4d", Digest: lo.ToPtr("sha256:3c4d")},
+		{Image: "registry.example.com/broker:2"},
+	}, digests)
+
+	require.Empty(resolveImageDigests(context.Background(), digester, nil, logrus.New()))
+}
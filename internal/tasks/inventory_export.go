@@ -0,0 +1,57 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/inventory"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// InventoryExport exports the inventory of the devices to the asset
+// management system configured for the service, once per export interval.
+type InventoryExport struct {
+	log      logrus.FieldLogger
+	store    store.Store
+	exporter *inventory.Exporter
+}
+
+func NewInventoryExport(log logrus.FieldLogger, store store.Store, exporter *inventory.Exporter) *InventoryExport {
+	return &InventoryExport{log: log, store: store, exporter: exporter}
+}
+
+// Poll exports the changes of the devices since the last export.
+func (t *InventoryExport) Poll() {
+	t.log.Info("Running InventoryExport Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	devices := []api.Device{}
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		list, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list devices")
+			return
+		}
+		devices = append(devices, list.Items...)
+		if list.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(list.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+
+	if err := t.exporter.Export(ctx, orgID, devices, time.Now()); err != nil {
+		t.log.WithError(err).Error("failed to export the inventory of the devices")
+	}
+}
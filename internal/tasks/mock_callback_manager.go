@@ -88,6 +88,30 @@ func (mr *MockCallbackManagerMockRecorder) DeviceSourceUpdated(orgId, name any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceSourceUpdated", reflect.TypeOf((*MockCallbackManager)(nil).DeviceSourceUpdated), orgId, name)
 }
 
+// DeviceStatusUpdatedCallback mocks base method.
+func (m *MockCallbackManager) DeviceStatusUpdatedCallback(before, after *model.Device) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeviceStatusUpdatedCallback", before, after)
+}
+
+// DeviceStatusUpdatedCallback indicates an expected call of DeviceStatusUpdatedCallback.
+func (mr *MockCallbackManagerMockRecorder) DeviceStatusUpdatedCallback(before, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceStatusUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).DeviceStatusUpdatedCallback), before, after)
+}
+
+// DeviceStatusWebhookDelivery mocks base method.
+func (m *MockCallbackManager) DeviceStatusWebhookDelivery(orgId uuid.UUID, device string, delivery WebhookDelivery) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeviceStatusWebhookDelivery", orgId, device, delivery)
+}
+
+// DeviceStatusWebhookDelivery indicates an expected call of DeviceStatusWebhookDelivery.
+func (mr *MockCallbackManagerMockRecorder) DeviceStatusWebhookDelivery(orgId, device, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceStatusWebhookDelivery", reflect.TypeOf((*MockCallbackManager)(nil).DeviceStatusWebhookDelivery), orgId, device, delivery)
+}
+
 // DeviceUpdatedCallback mocks base method.
 func (m *MockCallbackManager) DeviceUpdatedCallback(before, after *model.Device) {
 	m.ctrl.T.Helper()
@@ -100,6 +124,18 @@ func (mr *MockCallbackManagerMockRecorder) DeviceUpdatedCallback(before, after a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).DeviceUpdatedCallback), before, after)
 }
 
+// FleetRolloutUpdated mocks base method.
+func (m *MockCallbackManager) FleetRolloutUpdated(orgId uuid.UUID, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FleetRolloutUpdated", orgId, name)
+}
+
+// FleetRolloutUpdated indicates an expected call of FleetRolloutUpdated.
+func (mr *MockCallbackManagerMockRecorder) FleetRolloutUpdated(orgId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetRolloutUpdated", reflect.TypeOf((*MockCallbackManager)(nil).FleetRolloutUpdated), orgId, name)
+}
+
 // FleetSourceUpdated mocks base method.
 func (m *MockCallbackManager) FleetSourceUpdated(orgId uuid.UUID, name string) {
 	m.ctrl.T.Helper()
@@ -124,6 +160,30 @@ func (mr *MockCallbackManagerMockRecorder) FleetUpdatedCallback(before, after an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).FleetUpdatedCallback), before, after)
 }
 
+// LabelRulesUpdatedCallback mocks base method.
+func (m *MockCallbackManager) LabelRulesUpdatedCallback(orgId uuid.UUID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LabelRulesUpdatedCallback", orgId)
+}
+
+// LabelRulesUpdatedCallback indicates an expected call of LabelRulesUpdatedCallback.
+func (mr *MockCallbackManagerMockRecorder) LabelRulesUpdatedCallback(orgId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LabelRulesUpdatedCallback", reflect.TypeOf((*MockCallbackManager)(nil).LabelRulesUpdatedCallback), orgId)
+}
+
+// OperationCreated mocks base method.
+func (m *MockCallbackManager) OperationCreated(orgId uuid.UUID, name string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OperationCreated", orgId, name)
+}
+
+// OperationCreated indicates an expected call of OperationCreated.
+func (mr *MockCallbackManagerMockRecorder) OperationCreated(orgId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationCreated", reflect.TypeOf((*MockCallbackManager)(nil).OperationCreated), orgId, name)
+}
+
 // RepositoryUpdatedCallback mocks base method.
 func (m *MockCallbackManager) RepositoryUpdatedCallback(repository *model.Repository) {
 	m.ctrl.T.Helper()
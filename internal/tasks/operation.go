@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// operationProgressInterval is the number of devices after which the
+	// progress of a running operation is recorded, and its cancellation seen.
+	operationProgressInterval = 100
+
+	// maxOperationFailures bounds the failures recorded in the status of an
+	// operation, which counts all of them.
+	maxOperationFailures = 100
+)
+
+// Operations are actions that take time, which the service runs in the
+// background once they are created, recording their progress in their status:
+// the devices an operation applies to are those matching its label selector
+// when it starts, and it runs once. A canceled operation stops at its next
+// progress update, keeping the changes it already made.
+
+func runOperation(ctx context.Context, resourceRef *ResourceReference, store store.Store, callbackManager CallbackManager, log logrus.FieldLogger) error {
+	if resourceRef.Op != OperationRunOpStart || resourceRef.Kind != model.OperationKind {
+		log.Errorf("RunOperation called with unexpected kind %s and op %s", resourceRef.Kind, resourceRef.Op)
+		return nil
+	}
+	logic := NewOperationLogic(callbackManager, log, store, *resourceRef)
+	if err := logic.Run(ctx); err != nil {
+		log.Errorf("failed to run operation %s/%s: %v", resourceRef.OrgID, resourceRef.Name, err)
+	}
+	return nil
+}
+
+type OperationLogic struct {
+	callbackManager CallbackManager
+	log             logrus.FieldLogger
+	store           store.Store
+	resourceRef     ResourceReference
+}
+
+func NewOperationLogic(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store, resourceRef ResourceReference) OperationLogic {
+	return OperationLogic{callbackManager: callbackManager, log: log, store: store, resourceRef: resourceRef}
+}
+
+// Run runs a pending operation to its end, unless it is canceled meanwhile.
+func (t *OperationLogic) Run(ctx context.Context) error {
+	orgId, name := t.resourceRef.OrgID, t.resourceRef.Name
+	operation, err := t.store.Operation().Get(ctx, orgId, name)
+	if errors.Is(err, flterrors.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get operation: %w", err)
+	}
+
+	status := &api.OperationStatus{State: api.OperationStateRunning, StartedAt: lo.ToPtr(time.Now())}
+	switch err := t.store.Operation().Start(ctx, orgId, name, status); {
+	case errors.Is(err, flterrors.ErrNoRowsUpdated):
+		t.log.Infof("operation %s is no longer pending", name)
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to start operation: %w", err)
+	}
+
+	devices, err := t.listDevices(ctx, operation.Spec.LabelSelector)
+	if err != nil {
+		status.State = api.OperationStateFailed
+		status.Message = lo.ToPtr(err.Error())
+		status.FinishedAt = lo.ToPtr(time.Now())
+		return t.record(ctx, status)
+	}
+	status.Total = len(devices)
+	runOperationDevices(status, devices,
+		func(device string) error { return t.apply(ctx, &operation.Spec, device) },
+		func(status *api.OperationStatus) error { return t.record(ctx, status) })
+	return nil
+}
+
+// record records the status of the operation, which fails with
+// ErrNoRowsUpdated once the operation was canceled.
+func (t *OperationLogic) record(ctx context.Context, status *api.OperationStatus) error {
+	err := t.store.Operation().UpdateStatus(ctx, t.resourceRef.OrgID, t.resourceRef.Name, status)
+	if err != nil && !errors.Is(err, flterrors.ErrNoRowsUpdated) {
+		t.log.Errorf("failed to record the progress of operation %s: %v", t.resourceRef.Name, err)
+	}
+	return err
+}
+
+func (t *OperationLogic) listDevices(ctx context.Context, labelSelector string) ([]string, error) {
+	labelMap, err := labels.ConvertSelectorToLabelsMap(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	if len(labelMap) == 0 {
+		// an empty selector matches no device rather than all of them
+		return []string{}, nil
+	}
+	names := []string{}
+	listParams := store.ListParams{Labels: labelMap, Limit: ItemsPerPage}
+	for {
+		devices, err := t.store.Device().List(ctx, t.resourceRef.OrgID, listParams)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list devices: %w", err)
+		}
+		for _, device := range devices.Items {
+			names = append(names, *device.Metadata.Name)
+		}
+		if devices.Metadata.Continue == nil {
+			return names, nil
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse continuation for paging: %w", err)
+		}
+		listParams.Continue = cont
+	}
+}
+
+func (t *OperationLogic) apply(ctx context.Context, spec *api.OperationSpec, name string) error {
+	orgId := t.resourceRef.OrgID
+	switch spec.Type {
+	case api.OperationTypeBulkLabel:
+		device, err := t.store.Device().Get(ctx, orgId, name)
+		if err != nil {
+			return err
+		}
+		updated, changed := bulkLabels(lo.FromPtr(device.Metadata.Labels), spec)
+		if !changed {
+			return nil
+		}
+		device.Metadata.Labels = &updated
+		_, err = t.store.Device().Update(ctx, orgId, device, nil, false, t.callbackManager.DeviceUpdatedCallback)
+		return err
+	case api.OperationTypeDeviceDecommission:
+		// the certificates and the attestation of the device are deleted once it is purged from the trash
+		err := t.store.Device().Delete(ctx, orgId, name, t.callbackManager.DeviceUpdatedCallback)
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			return nil
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown operation type %q", spec.Type)
+	}
+}
+
+// runOperationDevices applies the operation to each device, recording its
+// progress every operationProgressInterval devices and its result once it
+// applied to all of them. It stops once recording the status fails, such as
+// when the operation was canceled.
+func runOperationDevices(status *api.OperationStatus, devices []string, apply func(device string) error, record func(status *api.OperationStatus) error) {
+	if err := record(status); err != nil {
+		return
+	}
+	for i, device := range devices {
+		if err := apply(device); err != nil {
+			status.Failed++
+			if status.Failed <= maxOperationFailures {
+				failures := append(lo.FromPtr(status.Failures), api.OperationFailure{Name: device, Message: err.Error()})
+				status.Failures = &failures
+			}
+		} else {
+			status.Succeeded++
+		}
+		if done := i + 1; done%operationProgressInterval == 0 && done < len(devices) {
+			if err := record(status); err != nil {
+				return
+			}
+		}
+	}
+
+	status.State = api.OperationStateSucceeded
+	status.Message = lo.ToPtr(fmt.Sprintf("applied to %d devices", status.Succeeded))
+	if status.Failed > 0 {
+		status.State = api.OperationStateFailed
+		status.Message = lo.ToPtr(fmt.Sprintf("applied to %d devices, failed for %d devices", status.Succeeded, status.Failed))
+	}
+	status.FinishedAt = lo.ToPtr(time.Now())
+	_ = record(status)
+}
+
+// bulkLabels returns the labels of a device after a BulkLabel operation, and
+// whether they changed.
+func bulkLabels(current map[string]string, spec *api.OperationSpec) (map[string]string, bool) {
+	updated := lo.Assign(current, lo.FromPtr(spec.Labels))
+	for _, key := range lo.FromPtr(spec.RemoveLabels) {
+		delete(updated, key)
+	}
+	return updated, !maps.Equal(current, updated)
+}
@@ -0,0 +1,90 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOperationDevices(t *testing.T) {
+	require := require.New(t)
+
+	devices := make([]string, 250)
+	for i := range devices {
+		devices[i] = fmt.Sprintf("device-%d", i)
+	}
+	recorded := []api.OperationStatus{}
+	record := func(status *api.OperationStatus) error {
+		recorded = append(recorded, *status)
+		return nil
+	}
+	// every third device fails
+	apply := func(device string) error {
+		if lo.IndexOf(devices, device)%3 == 0 {
+			return errors.New("unreachable")
+		}
+		return nil
+	}
+
+	status := &api.OperationStatus{State: api.OperationStateRunning, Total: len(devices)}
+	runOperationDevices(status, devices, apply, record)
+	require.Equal(api.OperationStateFailed, status.State)
+	require.Equal(166, status.Succeeded)
+	require.Equal(84, status.Failed)
+	require.Len(*status.Failures, 84)
+	require.Equal(api.OperationFailure{Name: "device-0", Message: "unreachable"}, (*status.Failures)[0])
+	require.NotNil(status.FinishedAt)
+	// the start, the progress after 100 and 200 devices, and the result
+	require.Len(recorded, 4)
+	require.Equal(200, recorded[2].Succeeded+recorded[2].Failed)
+
+	// the failures recorded are bounded
+	status = &api.OperationStatus{State: api.OperationStateRunning}
+	runOperationDevices(status, devices, func(string) error { return errors.New("unreachable") }, record)
+	require.Equal(250, status.Failed)
+	require.Len(*status.Failures, maxOperationFailures)
+
+	// a canceled operation stops at its next progress update
+	applied := 0
+	status = &api.OperationStatus{State: api.OperationStateRunning}
+	runOperationDevices(status, devices,
+		func(string) error {
+			applied++
+			return nil
+		},
+		func(status *api.OperationStatus) error {
+			if status.Succeeded > 0 {
+				return flterrors.ErrNoRowsUpdated
+			}
+			return nil
+		})
+	require.Equal(operationProgressInterval, applied)
+	require.Equal(api.OperationStateRunning, status.State)
+}
+
+func TestBulkLabels(t *testing.T) {
+	require := require.New(t)
+
+	current := map[string]string{"site": "store-1", "tier": "canary"}
+	spec := &api.OperationSpec{
+		Type:         api.OperationTypeBulkLabel,
+		Labels:       &map[string]string{"site": "store-2", "region": "emea"},
+		RemoveLabels: &[]string{"tier"},
+	}
+	updated, changed := bulkLabels(current, spec)
+	require.True(changed)
+	require.Equal(map[string]string{"site": "store-2", "region": "emea"}, updated)
+	// the labels of the device are not modified in place
+	require.Equal("canary", current["tier"])
+
+	// a device which already has the labels is not updated
+	_, changed = bulkLabels(map[string]string{"site": "store-2", "region": "emea"}, spec)
+	require.False(changed)
+	_, changed = bulkLabels(nil, &api.OperationSpec{RemoveLabels: &[]string{"tier"}})
+	require.False(changed)
+}
@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// OsStreamsPollingInterval is the interval at which the tags followed by the
+// OS images of the fleets are resolved.
+const OsStreamsPollingInterval = 5 * time.Minute
+
+// manifestDigester resolves image references to the digests of their
+// manifests.
+type manifestDigester interface {
+	ManifestDigest(ctx context.Context, ref registry.Reference) (string, error)
+}
+
+// resolveOsStream returns the OS spec with the image pinned by the digest the
+// tag of its stream resolves to, or the OS spec itself if it follows no
+// stream.
+func resolveOsStream(ctx context.Context, digester manifestDigester, os *api.DeviceOSSpec) (*api.DeviceOSSpec, error) {
+	if os == nil || os.Stream == nil {
+		return os, nil
+	}
+	digest, err := osStreamDigest(ctx, digester, os)
+	if err != nil {
+		return nil, err
+	}
+	return &api.DeviceOSSpec{
+		Image:  os.Image + "@" + digest,
+		Stream: os.Stream,
+	}, nil
+}
+
+func osStreamDigest(ctx context.Context, digester manifestDigester, os *api.DeviceOSSpec) (string, error) {
+	ref, err := registry.ParseReference(os.Image)
+	if err != nil {
+		return "", err
+	}
+	ref.Tag, ref.Digest = *os.Stream, ""
+	digest, err := digester.ManifestDigest(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed resolving stream %s of os image %s: %w", *os.Stream, os.Image, err)
+	}
+	return digest, nil
+}
+
+// pinnedDigest returns the digest the image of the repository is pinned by,
+// or an empty string if the image is of another repository.
+func pinnedDigest(image string, repository string) string {
+	pinned, digest, _ := strings.Cut(image, "@")
+	if pinned != repository {
+		return ""
+	}
+	return digest
+}
+
+// OsStreams rolls out the fleets whose OS image follows a stream when its tag
+// moves to another digest than the one of their newest template version.
+type OsStreams struct {
+	log             logrus.FieldLogger
+	store           store.Store
+	callbackManager CallbackManager
+	registry        manifestDigester
+}
+
+func NewOsStreams(log logrus.FieldLogger, store store.Store, callbackManager CallbackManager, registry manifestDigester) *OsStreams {
+	return &OsStreams{
+		log:             log,
+		store:           store,
+		callbackManager: callbackManager,
+		registry:        registry,
+	}
+}
+
+func (t *OsStreams) Poll() {
+	t.log.Info("Running OsStreams Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		fleets, err := t.store.Fleet().List(ctx, orgID, listParams)
+		if err != nil {
+			t.log.WithError(err).Error("failed to list fleets")
+			return
+		}
+
+		for i := range fleets.Items {
+			fleet := &fleets.Items[i]
+			if fleet.Spec.Template.Spec.Os == nil || fleet.Spec.Template.Spec.Os.Stream == nil {
+				continue
+			}
+			if err := t.checkFleet(ctx, orgID, fleet); err != nil {
+				t.log.WithError(err).Errorf("failed checking the os stream of fleet %s", lo.FromPtr(fleet.Metadata.Name))
+			}
+		}
+
+		if fleets.Metadata.Continue == nil {
+			break
+		}
+		cont, err := store.ParseContinueString(fleets.Metadata.Continue)
+		if err != nil {
+			t.log.WithError(err).Error("failed to parse continuation for paging")
+			return
+		}
+		listParams.Continue = cont
+	}
+}
+
+// checkFleet renders a new template version of the fleet if the tag of its
+// stream moved.
+func (t *OsStreams) checkFleet(ctx context.Context, orgID uuid.UUID, fleet *api.Fleet) error {
+	name := lo.FromPtr(fleet.Metadata.Name)
+	os := fleet.Spec.Template.Spec.Os
+	digest, err := osStreamDigest(ctx, t.registry, os)
+	if err != nil {
+		return err
+	}
+
+	templateVersion, err := t.store.TemplateVersion().GetNewestValid(ctx, orgID, name)
+	if err != nil {
+		if errors.Is(err, flterrors.ErrResourceNotFound) {
+			// the fleet is rendered once it is valid
+			return nil
+		}
+		return err
+	}
+	current := ""
+	if templateVersion.Status != nil && templateVersion.Status.Os != nil && lo.FromPtr(templateVersion.Status.Os.Stream) == *os.Stream {
+		current = pinnedDigest(templateVersion.Status.Os.Image, os.Image)
+	}
+	if current == digest {
+		return nil
+	}
+	t.log.Infof("Stream %s of os image %s of fleet %s moved to %s, rendering a new template version", *os.Stream, os.Image, name, digest)
+	t.callbackManager.FleetSourceUpdated(orgID, name)
+	return nil
+}
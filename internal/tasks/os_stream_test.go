@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+// tagDigests resolves the tags of images to the digests in the map.
+type tagDigests map[string]string
+
+func (d tagDigests) ManifestDigest(ctx context.Context, ref registry.Reference) (string, error) {
+	digest, ok := d[ref.String()]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", registry.ErrManifestNotFound, ref)
+	}
+	return digest, nil
+}
+
+func TestResolveOsStream(t *testing.T) {
+	digester := tagDigests{"quay.io/org/os:9-stable": "sha256:1a2b"}
+	tests := []struct {
+		name      string
+		os        *api.DeviceOSSpec
+		expected  *api.DeviceOSSpec
+		expectErr bool
+	}{
+		{
+			name: "no os",
+		},
+		{
+			name:     "no stream",
+			os:       &api.DeviceOSSpec{Image: "quay.io/org/os:9.4"},
+			expected: &api.DeviceOSSpec{Image: "quay.io/org/os:9.4"},
+		},
+		{
+			name:     "pinned by the digest of the tag",
+			os:       &api.DeviceOSSpec{Image: "quay.io/org/os", Stream: lo.ToPtr("9-stable")},
+			expected: &api.DeviceOSSpec{Image: "quay.io/org/os@sha256:1a2b", Stream: lo.ToPtr("9-stable")},
+		},
+		{
+			name:      "unknown tag",
+			os:        &api.DeviceOSSpec{Image: "quay.io/org/os", Stream: lo.ToPtr("10-stable")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			os, err := resolveOsStream(context.Background(), digester, tt.os)
+			if tt.expectErr {
+				require.ErrorIs(err, registry.ErrManifestNotFound)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, os)
+		})
+	}
+}
+
+func TestPinnedDigest(t *testing.T) {
+	require := require.New(t)
+	require.Equal("sha256:1a2b", pinnedDigest("quay.io/org/os@sha256:1a2b", "quay.io/org/os"))
+	require.Empty(pinnedDigest("quay.io/org/other@sha256:1a2b", "quay.io/org/os"))
+	require.Empty(pinnedDigest("quay.io/org/os:9.4", "quay.io/org/os"))
+}
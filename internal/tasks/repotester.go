@@ -2,7 +2,14 @@ package tasks
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
 	"github.com/flightctl/flightctl/internal/store"
@@ -12,8 +19,10 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 type API interface {
@@ -59,6 +68,19 @@ func (r *RepoTester) TestRepositories() {
 	}
 }
 
+// The reasons of the Accessible condition of repositories.
+const (
+	RepositoryAccessibleReason     = "Accessible"
+	RepositoryAuthFailedReason     = "AuthenticationFailed"
+	RepositoryTLSErrorReason       = "TLSError"
+	RepositoryHostKeyReason        = "HostKeyRejected"
+	RepositoryUnreachableReason    = "Unreachable"
+	RepositoryInaccessibleReason   = "Inaccessible"
+	repositoryAccessRequestTimeout = 30 * time.Second
+)
+
+var errRepositoryUnauthorized = errors.New("the repository rejected the credentials")
+
 type TypeSpecificRepoTester interface {
 	TestAccess(repository *model.Repository) error
 }
@@ -70,6 +92,10 @@ func (r *GitRepoTester) TestAccess(repository *model.Repository) error {
 	if repository.Spec == nil {
 		return fmt.Errorf("repository has no spec")
 	}
+	// repositories of type http are HTTP endpoints rather than Git repositories
+	if httpSpec, err := repository.Spec.Data.GetHttpRepoSpec(); err == nil && httpSpec.Type == api.Http {
+		return testHttpRepoAccess(httpSpec)
+	}
 	repoURL, err := repository.Spec.Data.GetRepoURL()
 	if err != nil {
 		return err
@@ -91,16 +117,88 @@ func (r *GitRepoTester) TestAccess(repository *model.Repository) error {
 	return err
 }
 
+func testHttpRepoAccess(httpSpec api.HttpRepoSpec) error {
+	req, err := http.NewRequest(http.MethodGet, httpSpec.Url, nil)
+	if err != nil {
+		return fmt.Errorf("failed creating request: %w", err)
+	}
+	req, tlsConfig, err := buildHttpRepoRequestAuth(httpSpec, req)
+	if err != nil {
+		return fmt.Errorf("error building request authentication: %w", err)
+	}
+	client := &http.Client{
+		Timeout:   repositoryAccessRequestTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", errRepositoryUnauthorized, resp.Status)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	// the endpoint is reachable even if its base URL serves no config
+	return nil
+}
+
+// accessFailureReason classifies the error accessing a repository, so that
+// broken credentials, TLS configurations and pinned SSH host keys stand out
+// from outages.
+func accessFailureReason(err error) string {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostname         x509.HostnameError
+		invalid          x509.CertificateInvalidError
+		verification     *tls.CertificateVerificationError
+		recordHeader     tls.RecordHeaderError
+		netErr           net.Error
+		hostKeyErr       *knownhosts.KeyError
+	)
+	message := err.Error()
+	switch {
+	case errors.Is(err, errRepositoryUnauthorized), errors.Is(err, transport.ErrAuthenticationRequired), errors.Is(err, transport.ErrAuthorizationFailed),
+		strings.Contains(message, "unable to authenticate"):
+		return RepositoryAuthFailedReason
+	case errors.As(err, &unknownAuthority), errors.As(err, &hostname), errors.As(err, &invalid), errors.As(err, &verification), errors.As(err, &recordHeader),
+		strings.Contains(message, "x509: "), strings.Contains(message, "tls: "):
+		return RepositoryTLSErrorReason
+	case errors.As(err, &hostKeyErr), strings.Contains(message, "knownhosts: "):
+		return RepositoryHostKeyReason
+	case errors.As(err, &netErr), strings.Contains(message, "connection refused"), strings.Contains(message, "no such host"), strings.Contains(message, "i/o timeout"):
+		return RepositoryUnreachableReason
+	default:
+		return RepositoryInaccessibleReason
+	}
+}
+
+// SetAccessCondition records the outcome of testing access to the repository
+// in its status: the Accessible condition, with the kind of failure as its
+// reason, and the times of the test and of the last successful access.
 func (r *RepoTester) SetAccessCondition(repository model.Repository, err error) error {
 	if repository.Status == nil {
 		repository.Status = model.MakeJSONField(api.RepositoryStatus{Conditions: []api.Condition{}})
 	}
-	if repository.Status.Data.Conditions == nil {
-		repository.Status.Data.Conditions = []api.Condition{}
+	SetRepositoryAccess(&repository.Status.Data, err, time.Now())
+	return r.repoStore.UpdateStatusIgnoreOrg(&repository)
+}
+
+// SetRepositoryAccess sets the Accessible condition of the status of a
+// repository whose access was tested at the time.
+func SetRepositoryAccess(status *api.RepositoryStatus, err error, now time.Time) {
+	if status.Conditions == nil {
+		status.Conditions = []api.Condition{}
+	}
+	reason := RepositoryAccessibleReason
+	if err != nil {
+		reason = accessFailureReason(err)
 	}
-	changed := api.SetStatusConditionByError(&repository.Status.Data.Conditions, api.RepositoryAccessible, "Accessible", "Inaccessible", err)
-	if changed {
-		return r.repoStore.UpdateStatusIgnoreOrg(&repository)
+	api.SetStatusConditionByError(&status.Conditions, api.RepositoryAccessible, RepositoryAccessibleReason, reason, err)
+	status.LastCheckedAt = &now
+	if err == nil {
+		status.LastAccessedAt = &now
 	}
-	return nil
 }
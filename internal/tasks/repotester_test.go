@@ -0,0 +1,51 @@
+package tasks
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessFailureReason(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{transport.ErrAuthenticationRequired, RepositoryAuthFailedReason},
+		{fmt.Errorf("%w: 401 Unauthorized", errRepositoryUnauthorized), RepositoryAuthFailedReason},
+		{errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none publickey]"), RepositoryAuthFailedReason},
+		{fmt.Errorf("Get \"https://repo\": %w", x509.UnknownAuthorityError{}), RepositoryTLSErrorReason},
+		{errors.New("Get \"https://repo\": tls: failed to verify certificate: x509: certificate signed by unknown authority"), RepositoryTLSErrorReason},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, RepositoryUnreachableReason},
+		{errors.New("dial tcp: lookup repo: no such host"), RepositoryUnreachableReason},
+		{errors.New("repository not found"), RepositoryInaccessibleReason},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, accessFailureReason(tt.err), tt.err.Error())
+	}
+}
+
+func TestSetRepositoryAccess(t *testing.T) {
+	require := require.New(t)
+	status := api.RepositoryStatus{}
+	accessed := time.Now().Add(-time.Hour)
+	SetRepositoryAccess(&status, nil, accessed)
+	require.Equal(accessed, *status.LastAccessedAt)
+	require.Equal(accessed, *status.LastCheckedAt)
+	require.True(api.IsStatusConditionTrue(status.Conditions, api.RepositoryAccessible))
+
+	checked := time.Now()
+	SetRepositoryAccess(&status, transport.ErrAuthorizationFailed, checked)
+	require.Equal(accessed, *status.LastAccessedAt)
+	require.Equal(checked, *status.LastCheckedAt)
+	condition := api.FindStatusCondition(status.Conditions, api.RepositoryAccessible)
+	require.Equal(api.ConditionStatusFalse, condition.Status)
+	require.Equal(RepositoryAuthFailedReason, condition.Reason)
+}
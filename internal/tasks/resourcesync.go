@@ -23,9 +23,10 @@ import (
 )
 
 type ResourceSync struct {
-	log             logrus.FieldLogger
-	store           store.Store
-	callbackManager CallbackManager
+	log                 logrus.FieldLogger
+	store               store.Store
+	callbackManager     CallbackManager
+	requireImageDigests bool
 }
 
 type genericResourceMap map[string]interface{}
@@ -41,6 +42,13 @@ func NewResourceSync(callbackManager CallbackManager, store store.Store, log log
 	}
 }
 
+// SetRequireImageDigests makes the resource sync refuse to apply fleets whose
+// images are referenced by a tag only, as the service does for the fleets
+// created through its API.
+func (r *ResourceSync) SetRequireImageDigests(requireImageDigests bool) {
+	r.requireImageDigests = requireImageDigests
+}
+
 func (r *ResourceSync) Poll() {
 	reqid.OverridePrefix("resourcesync")
 	requestID := reqid.NextRequestID()
@@ -91,6 +99,14 @@ func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *mode
 	}
 	rs.AddResourceParsedCondition(nil)
 
+	// none of the fleets is applied unless all of them comply with the image
+	// policy, so that a commit is synced as a whole
+	if err := r.checkImagePolicy(fleets); err != nil {
+		log.Errorf("resourcesync/%s: not applying the fleets: %s", rs.Name, err.Error())
+		rs.AddSyncedCondition(err)
+		return err
+	}
+
 	fleetsPreOwned := make([]api.Fleet, 0)
 
 	listParams := store.ListParams{
@@ -141,6 +157,20 @@ func (r *ResourceSync) run(ctx context.Context, log logrus.FieldLogger, rs *mode
 	return nil
 }
 
+// checkImagePolicy returns why the fleets do not comply with the image policy
+// of the service, if they do not.
+func (r *ResourceSync) checkImagePolicy(fleets []*api.Fleet) error {
+	if !r.requireImageDigests {
+		return nil
+	}
+	for _, fleet := range fleets {
+		if err := common.CheckImageDigests(&fleet.Spec.Template.Spec, true); err != nil {
+			return fmt.Errorf("fleet/%s: %w", *fleet.Metadata.Name, err)
+		}
+	}
+	return nil
+}
+
 // Returns a list of names that are no longer present
 func fleetsDelta(owned []api.Fleet, newOwned []*api.Fleet) []string {
 	dfleets := make([]string, 0)
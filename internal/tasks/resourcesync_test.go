@@ -3,6 +3,7 @@ package tasks
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
@@ -315,3 +316,26 @@ func writeCopy(fs billy.Filesystem, localPath, path string) {
 		panic(err)
 	}
 }
+
+func TestCheckImagePolicy(t *testing.T) {
+	require := require.New(t)
+
+	memfs := memfs.New()
+	require.NoError(memfs.MkdirAll("/fleets", 0666))
+	writeCopy(memfs, "../../examples/fleet.yaml", "/fleets/fleet.yaml")
+
+	rsTask := NewResourceSync(resourceSyncParams(t))
+	genericResources, err := rsTask.extractResourcesFromDir(memfs, "/fleets")
+	require.NoError(err)
+	fleets, err := rsTask.parseFleets(genericResources, util.SetResourceOwner(model.ResourceSyncKind, "foo"))
+	require.NoError(err)
+	require.NoError(rsTask.checkImagePolicy(fleets))
+
+	// the example fleet references its OS image by tag
+	rsTask.SetRequireImageDigests(true)
+	err = rsTask.checkImagePolicy(fleets)
+	require.ErrorContains(err, "quay.io/redhat/rhde:9.2")
+
+	fleets[0].Spec.Template.Spec.Os.Image = "quay.io/redhat/rhde@sha256:" + strings.Repeat("a", 64)
+	require.NoError(rsTask.checkImagePolicy(fleets))
+}
@@ -0,0 +1,145 @@
+package tasks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
+	"github.com/flightctl/flightctl/internal/sbom"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+// SbomCollectorPollingInterval is the interval at which the SBOMs of the
+// images newly applied by devices are collected.
+const SbomCollectorPollingInterval = 10 * time.Minute
+
+// sbomRecheckInterval is how long the service waits before looking up the SBOM
+// of an image again if its registry attached none, since SBOMs are often
+// attached some time after the image is pushed.
+const sbomRecheckInterval = 24 * time.Hour
+
+// SbomCollector collects the SBOMs the registries attach to the images the
+// devices report in their status.provenance.
+type SbomCollector struct {
+	log       logrus.FieldLogger
+	store     store.Store
+	fetchSbom func(ctx context.Context, ref registry.Reference) (*sbom.Document, error)
+}
+
+func NewSbomCollector(log logrus.FieldLogger, store store.Store, reg sbom.Registry) *SbomCollector {
+	return &SbomCollector{
+		log:   log,
+		store: store,
+		fetchSbom: func(ctx context.Context, ref registry.Reference) (*sbom.Document, error) {
+			return sbom.Fetch(ctx, reg, ref)
+		},
+	}
+}
+
+func (t *SbomCollector) Poll() {
+	t.log.Info("Running SbomCollector Polling")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	orgID := uuid.UUID{}
+
+	images, err := t.deviceImages(ctx, orgID)
+	if err != nil {
+		t.log.WithError(err).Error("failed to list the images of devices")
+		return
+	}
+	if len(images) == 0 {
+		return
+	}
+	collected, err := t.store.ImageSbom().List(ctx, orgID, store.ImageSbomListParams{Digests: lo.Keys(images)})
+	if err != nil {
+		t.log.WithError(err).Error("failed to list collected SBOMs")
+		return
+	}
+
+	for _, digest := range sbomsToCollect(images, collected.Items, time.Now()) {
+		if err := t.collect(ctx, orgID, images[digest], digest); err != nil {
+			t.log.WithError(err).Errorf("failed collecting the SBOM of image %s", images[digest])
+		}
+	}
+}
+
+// sbomsToCollect returns the sorted digests of the images whose SBOM was not
+// collected yet, or whose registry attached none when last looked up more than
+// sbomRecheckInterval ago.
+func sbomsToCollect(images map[string]string, collected []api.ImageSbom, now time.Time) []string {
+	known := lo.SliceToMap(collected, func(sbom api.ImageSbom) (string, api.ImageSbom) { return sbom.Digest, sbom })
+	digests := lo.Filter(lo.Keys(images), func(digest string, _ int) bool {
+		sbom, ok := known[digest]
+		return !ok || (sbom.Format == nil && now.Sub(sbom.CollectedAt) >= sbomRecheckInterval)
+	})
+	sort.Strings(digests)
+	return digests
+}
+
+// deviceImages returns the images the devices applied, keyed by digest.
+func (t *SbomCollector) deviceImages(ctx context.Context, orgID uuid.UUID) (map[string]string, error) {
+	images := map[string]string{}
+	listParams := store.ListParams{Limit: ItemsPerPage}
+	for {
+		devices, err := t.store.Device().List(ctx, orgID, listParams)
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range devices.Items {
+			if device.Status == nil || device.Status.Provenance == nil {
+				continue
+			}
+			for _, image := range device.Status.Provenance.Images {
+				if image.Digest != nil {
+					if _, ok := images[*image.Digest]; !ok {
+						images[*image.Digest] = image.Image
+					}
+				}
+			}
+		}
+
+		if devices.Metadata.Continue == nil {
+			return images, nil
+		}
+		cont, err := store.ParseContinueString(devices.Metadata.Continue)
+		if err != nil {
+			return nil, err
+		}
+		listParams.Continue = cont
+	}
+}
+
+// collect looks up the SBOM of the image pinned by its digest and records it,
+// or records that the registry attaches none.
+func (t *SbomCollector) collect(ctx context.Context, orgID uuid.UUID, image string, digest string) error {
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	ref.Tag, ref.Digest = "", digest
+	document, err := t.fetchSbom(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	record := &api.ImageSbom{
+		Image:       image,
+		Digest:      digest,
+		CollectedAt: time.Now(),
+	}
+	if document != nil {
+		record.Format = &document.Format
+		record.Components = &document.Components
+		if len(document.Vulnerabilities) > 0 {
+			record.Vulnerabilities = &document.Vulnerabilities
+		}
+		t.log.Infof("Collected %s SBOM of image %s with %d components", document.Format, image, len(document.Components))
+	}
+	return t.store.ImageSbom().Record(ctx, orgID, record)
+}
@@ -0,0 +1,28 @@
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSbomsToCollect(t *testing.T) {
+	require := require.New(t)
+	now := time.Now()
+	images := map[string]string{
+		"sha256:new":      "quay.io/org/app:1",
+		"sha256:sbom":     "quay.io/org/os:9.4",
+		"sha256:recent":   "quay.io/org/app:2",
+		"sha256:outdated": "quay.io/org/app:3",
+	}
+	collected := []api.ImageSbom{
+		{Digest: "sha256:sbom", Format: lo.ToPtr(api.SbomFormatSPDX), CollectedAt: now.Add(-48 * time.Hour)},
+		{Digest: "sha256:recent", CollectedAt: now.Add(-time.Hour)},
+		{Digest: "sha256:outdated", CollectedAt: now.Add(-25 * time.Hour)},
+	}
+	require.Equal([]string{"sha256:new", "sha256:outdated"}, sbomsToCollect(images, collected, now))
+	require.Empty(sbomsToCollect(map[string]string{}, collected, now))
+}
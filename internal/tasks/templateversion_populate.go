@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/registry"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/store/model"
 	"github.com/flightctl/flightctl/internal/util"
@@ -34,13 +35,15 @@ type TemplateVersionPopulateLogic struct {
 	store           store.Store
 	k8sClient       k8sclient.K8SClient
 	resourceRef     ResourceReference
+	registry        manifestDigester
 	templateVersion *api.TemplateVersion
 	fleet           *api.Fleet
 	frozenConfig    []api.TemplateVersionStatus_Config_Item
+	frozenOs        *api.DeviceOSSpec
 }
 
 func NewTemplateVersionPopulateLogic(callbackManager CallbackManager, log logrus.FieldLogger, store store.Store, k8sClient k8sclient.K8SClient, resourceRef ResourceReference) TemplateVersionPopulateLogic {
-	return TemplateVersionPopulateLogic{callbackManager: callbackManager, log: log, store: store, resourceRef: resourceRef, k8sClient: k8sClient}
+	return TemplateVersionPopulateLogic{callbackManager: callbackManager, log: log, store: store, resourceRef: resourceRef, k8sClient: k8sClient, registry: registry.NewClient(nil)}
 }
 
 func (t *TemplateVersionPopulateLogic) SyncFleetTemplateToTemplateVersion(ctx context.Context) error {
@@ -57,6 +60,12 @@ func (t *TemplateVersionPopulateLogic) SyncFleetTemplateToTemplateVersion(ctx co
 		return t.setStatus(ctx, err)
 	}
 
+	// pin the os image of a stream by digest
+	t.frozenOs, err = resolveOsStream(ctx, t.registry, t.fleet.Spec.Template.Spec.Os)
+	if err != nil {
+		return t.setStatus(ctx, err)
+	}
+
 	// freeze the config source
 	if t.fleet.Spec.Template.Spec.Config != nil {
 		t.frozenConfig = []api.TemplateVersionStatus_Config_Item{}
@@ -241,12 +250,21 @@ func (t *TemplateVersionPopulateLogic) setStatus(ctx context.Context, validation
 	if validationErr != nil {
 		t.log.Errorf("failed syncing template to template version: %v", validationErr)
 	} else {
-		t.templateVersion.Status.Os = t.fleet.Spec.Template.Spec.Os
+		t.templateVersion.Status.Os = t.frozenOs
 		t.templateVersion.Status.Containers = t.fleet.Spec.Template.Spec.Containers
 		t.templateVersion.Status.Systemd = t.fleet.Spec.Template.Spec.Systemd
 		t.templateVersion.Status.Config = &t.frozenConfig
 		t.templateVersion.Status.Hooks = t.fleet.Spec.Template.Spec.Hooks
 		t.templateVersion.Status.Resources = t.fleet.Spec.Template.Spec.Resources
+		t.templateVersion.Status.Agent = t.fleet.Spec.Template.Spec.Agent
+		t.templateVersion.Status.Encryption = t.fleet.Spec.Template.Spec.Encryption
+		t.templateVersion.Status.Crypto = t.fleet.Spec.Template.Spec.Crypto
+		t.templateVersion.Status.Time = t.fleet.Spec.Template.Spec.Time
+		t.templateVersion.Status.Compliance = t.fleet.Spec.Template.Spec.Compliance
+		t.templateVersion.Status.Firewall = t.fleet.Spec.Template.Spec.Firewall
+		t.templateVersion.Status.GarbageCollection = t.fleet.Spec.Template.Spec.GarbageCollection
+		t.templateVersion.Status.Drift = t.fleet.Spec.Template.Spec.Drift
+		t.templateVersion.Status.Applications = t.fleet.Spec.Template.Spec.Applications
 	}
 	api.SetStatusConditionByError(&t.templateVersion.Status.Conditions, api.TemplateVersionValid, "Valid", "Invalid", validationErr)
 
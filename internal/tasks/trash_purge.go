@@ -0,0 +1,44 @@
+package tasks
+
+import (
+	"context"
+	"time"
+
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/sirupsen/logrus"
+)
+
+// TrashPurgePollingInterval is the interval at which the devices and fleets
+// deleted for longer than the trash retention are purged.
+const TrashPurgePollingInterval = time.Hour
+
+// TrashPurge permanently deletes the devices and fleets which have been in the
+// trash for longer than the retention. Any device still owned by a purged
+// fleet is released from it.
+type TrashPurge struct {
+	log             logrus.FieldLogger
+	store           store.Store
+	callbackManager CallbackManager
+	retention       time.Duration
+}
+
+func NewTrashPurge(log logrus.FieldLogger, store store.Store, callbackManager CallbackManager, retention time.Duration) *TrashPurge {
+	return &TrashPurge{
+		log:             log,
+		store:           store,
+		callbackManager: callbackManager,
+		retention:       retention,
+	}
+}
+
+func (t *TrashPurge) Poll() {
+	t.log.Info("Running TrashPurge Polling")
+	purged, err := t.store.Trash().Purge(context.Background(), time.Now().Add(-t.retention), t.callbackManager.FleetUpdatedCallback)
+	if err != nil {
+		t.log.WithError(err).Error("failed to purge the trash")
+		return
+	}
+	if purged > 0 {
+		t.log.Infof("purged %d devices and fleets from the trash", purged)
+	}
+}
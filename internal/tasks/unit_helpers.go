@@ -0,0 +1,302 @@
+package tasks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	config_latest "github.com/coreos/ignition/v2/config/v3_4"
+	config_latest_types "github.com/coreos/ignition/v2/config/v3_4/types"
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+	"github.com/vincent-petithory/dataurl"
+	"sigs.k8s.io/yaml"
+)
+
+// systemdUnitDir is the directory the units of the ignition config are written to.
+const systemdUnitDir = "/etc/systemd/system"
+
+// maxUnitProblems is the number of problems of the units of a configuration
+// reported in its render error.
+const maxUnitProblems = 5
+
+// unitSections are the sections of the units of each type, in addition to the
+// Unit and Install sections of all units, which systemd and podman accept.
+// Quadlet files also have the Service and Quadlet sections.
+var unitSections = map[string][]string{
+	".service":   {"Service"},
+	".socket":    {"Socket"},
+	".device":    {},
+	".mount":     {"Mount"},
+	".automount": {"Automount"},
+	".swap":      {"Swap"},
+	".target":    {},
+	".path":      {"Path"},
+	".timer":     {"Timer"},
+	".slice":     {"Slice"},
+	".scope":     {"Scope"},
+	".container": {"Container", "Service", "Quadlet"},
+	".pod":       {"Pod", "Service", "Quadlet"},
+	".volume":    {"Volume", "Service", "Quadlet"},
+	".network":   {"Network", "Service", "Quadlet"},
+	".kube":      {"Kube", "Service", "Quadlet"},
+	".image":     {"Image", "Service", "Quadlet"},
+	".build":     {"Build", "Service", "Quadlet"},
+}
+
+// requiredUnitSettings are the settings of which units of each type must have
+// at least one, checked on units but not on their drop-ins.
+var requiredUnitSettings = map[string]struct {
+	section  string
+	settings []string
+}{
+	".socket":    {"Socket", []string{"ListenStream", "ListenDatagram", "ListenSequentialPacket", "ListenFIFO", "ListenSpecial", "ListenNetlink", "ListenMessageQueue", "ListenUSBFunction"}},
+	".timer":     {"Timer", []string{"OnCalendar", "OnActiveSec", "OnBootSec", "OnStartupSec", "OnUnitActiveSec", "OnUnitInactiveSec"}},
+	".path":      {"Path", []string{"PathExists", "PathExistsGlob", "PathChanged", "PathModified", "DirectoryNotEmpty"}},
+	".container": {"Container", []string{"Image", "Rootfs"}},
+	".kube":      {"Kube", []string{"Yaml"}},
+	".image":     {"Image", []string{"Image"}},
+	".build":     {"Build", []string{"ImageTag"}},
+}
+
+// unitSettingValues are the values systemd accepts for settings taking one of
+// a fixed set of values.
+var unitSettingValues = map[string][]string{
+	"Service.Type":    {"simple", "exec", "forking", "oneshot", "dbus", "notify", "notify-reload", "idle"},
+	"Service.Restart": {"no", "always", "on-success", "on-failure", "on-abnormal", "on-abort", "on-watchdog"},
+}
+
+// unitDependencySettings are the settings of the Unit section listing units.
+var unitDependencySettings = []string{
+	"Requires", "Requisite", "Wants", "BindsTo", "PartOf", "Upholds", "Conflicts", "Before", "After", "OnFailure", "OnSuccess",
+}
+
+type unitFile struct {
+	sections []unitSection
+}
+
+type unitSection struct {
+	name     string
+	line     int
+	settings []unitSetting
+}
+
+type unitSetting struct {
+	line  int
+	key   string
+	value string
+}
+
+// setting returns the last value of the setting in the sections with the
+// name, which is the one systemd applies, and false if it is not set.
+func (u *unitFile) setting(section string, key string) (string, bool) {
+	value, found := "", false
+	for _, s := range u.sections {
+		if s.name != section {
+			continue
+		}
+		for _, setting := range s.settings {
+			if setting.key == key {
+				value, found = setting.value, true
+			}
+		}
+	}
+	return value, found
+}
+
+// IsUnitFile returns whether the file is a systemd unit, a drop-in of one or a
+// Quadlet file, which systemd and podman read from their systemd directories.
+func IsUnitFile(filePath string) bool {
+	if !strings.Contains(filePath, "/systemd/") {
+		return false
+	}
+	_, ok := unitSections[unitType(filePath)]
+	return ok
+}
+
+// unitType returns the extension of the unit, which is that of the directory
+// of a drop-in.
+func unitType(unitPath string) string {
+	if path.Ext(unitPath) == ".conf" {
+		dir := path.Dir(unitPath)
+		if !strings.HasSuffix(dir, ".d") {
+			return ""
+		}
+		return path.Ext(strings.TrimSuffix(dir, ".d"))
+	}
+	return path.Ext(unitPath)
+}
+
+// parseUnit parses the contents of a unit file the way systemd parses them,
+// as sections of KEY=VALUE settings, and returns the problems found by line.
+func parseUnit(contents []byte) (*unitFile, []string) {
+	unit := &unitFile{}
+	problems := []string{}
+	var section *unitSection
+	lines := strings.Split(string(contents), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		// settings continue on the next line after a trailing backslash
+		for strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = strings.TrimSuffix(line, `\`) + " " + strings.TrimSpace(lines[i])
+		}
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+		case strings.HasPrefix(line, "["):
+			name, ok := strings.CutSuffix(line, "]")
+			name = strings.TrimPrefix(name, "[")
+			if !ok || name == "" || strings.ContainsAny(name, "[]") {
+				problems = append(problems, fmt.Sprintf("line %d: invalid section header %q", lineNumber, line))
+				// the settings of an invalid section are not reported again
+				name = ""
+			}
+			unit.sections = append(unit.sections, unitSection{name: name, line: lineNumber})
+			section = &unit.sections[len(unit.sections)-1]
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			key = strings.TrimSpace(key)
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("line %d: expected a section header or KEY=VALUE, found %q", lineNumber, line))
+			case key == "" || strings.ContainsAny(key, " \t"):
+				problems = append(problems, fmt.Sprintf("line %d: invalid setting name %q", lineNumber, key))
+			case section == nil:
+				problems = append(problems, fmt.Sprintf("line %d: setting %s is outside of a section", lineNumber, key))
+			default:
+				section.settings = append(section.settings, unitSetting{line: lineNumber, key: key, value: strings.TrimSpace(value)})
+			}
+		}
+	}
+	return unit, problems
+}
+
+// ValidateUnitSyntax checks that the contents of a unit file parse, and
+// returns the problems found by line.
+func ValidateUnitSyntax(contents []byte) []string {
+	_, problems := parseUnit(contents)
+	return problems
+}
+
+// AnalyzeUnit checks a unit file the way systemd-analyze verify does, without
+// loading it: that it parses, that its sections are those of its type, that it
+// has the settings its type requires and that the settings taking one of a
+// fixed set of values and the dependencies on other units are valid.
+func AnalyzeUnit(unitPath string, contents []byte) []string {
+	unit, problems := parseUnit(contents)
+	ext := unitType(unitPath)
+	allowed, ok := unitSections[ext]
+	if !ok {
+		return problems
+	}
+	allowed = append([]string{"Unit", "Install"}, allowed...)
+
+	for _, section := range unit.sections {
+		if section.name == "" || strings.HasPrefix(section.name, "X-") {
+			continue
+		}
+		if !lo.Contains(allowed, section.name) {
+			problems = append(problems, fmt.Sprintf("line %d: unknown section [%s] in a %s unit", section.line, section.name, ext))
+			continue
+		}
+		for _, setting := range section.settings {
+			if values, ok := unitSettingValues[section.name+"."+setting.key]; ok && setting.value != "" && !lo.Contains(values, setting.value) {
+				problems = append(problems, fmt.Sprintf("line %d: invalid value %q of %s=, expected one of %s", setting.line, setting.value, setting.key, strings.Join(values, ", ")))
+			}
+			if section.name == "Unit" && lo.Contains(unitDependencySettings, setting.key) {
+				for _, name := range strings.Fields(setting.value) {
+					if _, ok := unitSections[path.Ext(name)]; !ok {
+						problems = append(problems, fmt.Sprintf("line %d: invalid unit name %q in %s=", setting.line, name, setting.key))
+					}
+				}
+			}
+		}
+	}
+
+	// drop-ins only change some settings of their unit
+	if path.Ext(unitPath) == ".conf" {
+		return problems
+	}
+	if ext == ".service" {
+		serviceType, _ := unit.setting("Service", "Type")
+		_, hasExecStart := unit.setting("Service", "ExecStart")
+		_, hasExecStop := unit.setting("Service", "ExecStop")
+		if !hasExecStart && !(serviceType == "oneshot" && hasExecStop) {
+			problems = append(problems, "service has no ExecStart= setting")
+		}
+	}
+	if required, ok := requiredUnitSettings[ext]; ok {
+		if !lo.ContainsBy(required.settings, func(key string) bool {
+			_, found := unit.setting(required.section, key)
+			return found
+		}) {
+			problems = append(problems, fmt.Sprintf("[%s] has none of the settings %s", required.section, strings.Join(lo.Map(required.settings, func(key string, _ int) string { return key + "=" }), ", ")))
+		}
+	}
+	return problems
+}
+
+// ValidateIgnitionUnits analyzes the systemd and Quadlet units of the ignition
+// config, both its units and the unit files it writes. Files whose contents
+// are not inline data URLs are not checked.
+func ValidateIgnitionUnits(ignitionConfig *config_latest_types.Config) []string {
+	problems := []string{}
+	check := func(unitPath string, contents []byte) {
+		for _, problem := range AnalyzeUnit(unitPath, contents) {
+			problems = append(problems, fmt.Sprintf("%s: %s", unitPath, problem))
+		}
+	}
+
+	for _, unit := range ignitionConfig.Systemd.Units {
+		if unit.Contents != nil {
+			check(path.Join(systemdUnitDir, unit.Name), []byte(*unit.Contents))
+		}
+		for _, dropin := range unit.Dropins {
+			if dropin.Contents != nil {
+				check(path.Join(systemdUnitDir, unit.Name+".d", dropin.Name), []byte(*dropin.Contents))
+			}
+		}
+	}
+	for _, file := range ignitionConfig.Storage.Files {
+		if !IsUnitFile(file.Path) || file.Contents.Source == nil || lo.FromPtr(file.Contents.Compression) != "" {
+			continue
+		}
+		data, err := dataurl.DecodeString(*file.Contents.Source)
+		if err != nil {
+			continue
+		}
+		check(file.Path, data.Data)
+	}
+	return problems
+}
+
+// ValidateInlineConfigUnits analyzes the systemd and Quadlet units of an
+// inline configuration. Configurations with template parameters are checked
+// as they are, before the parameters are replaced.
+func ValidateInlineConfigUnits(inlineSpec *api.InlineConfigProviderSpec) ([]string, error) {
+	yamlBytes, err := yaml.Marshal(inlineSpec.Inline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid yaml in inline config item %s: %w", inlineSpec.Name, err)
+	}
+	jsonBytes, err := yaml.YAMLToJSON(yamlBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting yaml to json in inline config item %s: %w", inlineSpec.Name, err)
+	}
+	ignitionConfig, _, err := config_latest.ParseCompatibleVersion(jsonBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing inline config item %s: %w", inlineSpec.Name, err)
+	}
+	return ValidateIgnitionUnits(&ignitionConfig), nil
+}
+
+// unitProblemsError returns the render error of the problems found in the
+// units of a configuration, naming the first few of them.
+func unitProblemsError(problems []string) error {
+	message := strings.Join(lo.Slice(problems, 0, maxUnitProblems), "; ")
+	if len(problems) > maxUnitProblems {
+		message += fmt.Sprintf(" and %d more", len(problems)-maxUnitProblems)
+	}
+	return fmt.Errorf("%d problems in systemd units: %s", len(problems), message)
+}
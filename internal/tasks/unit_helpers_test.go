@@ -0,0 +1,167 @@
+package tasks
+
+import (
+	"context"
+	"testing"
+
+	config_latest_types "github.com/coreos/ignition/v2/config/v3_4/types"
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUnitFile(t *testing.T) {
+	require := require.New(t)
+	require.True(IsUnitFile("/etc/systemd/system/app.service"))
+	require.True(IsUnitFile("/etc/systemd/system/app.service.d/override.conf"))
+	require.True(IsUnitFile("/etc/containers/systemd/app.container"))
+	require.False(IsUnitFile("/etc/systemd/journald.conf"))
+	require.False(IsUnitFile("/etc/app/app.service"))
+}
+
+func TestValidateUnitSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		expected []string
+	}{
+		{
+			name:     "valid unit",
+			contents: "# comment\n[Unit]\nDescription=app\n\n[Service]\nExecStart=/bin/app \\\n  --verbose\n; comment\nEnvironment=\n",
+			expected: []string{},
+		},
+		{
+			name:     "setting outside of a section",
+			contents: "Description=app\n[Unit]\n",
+			expected: []string{"line 1: setting Description is outside of a section"},
+		},
+		{
+			name:     "invalid section header",
+			contents: "[Unit\nDescription=app\n[]\n",
+			expected: []string{`line 1: invalid section header "[Unit"`, `line 3: invalid section header "[]"`},
+		},
+		{
+			name:     "invalid settings",
+			contents: "[Service]\nExecStart /bin/app\nExec Start=/bin/app\n=value\n",
+			expected: []string{
+				`line 2: expected a section header or KEY=VALUE, found "ExecStart /bin/app"`,
+				`line 3: invalid setting name "Exec Start"`,
+				`line 4: invalid setting name ""`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, ValidateUnitSyntax([]byte(tt.contents)))
+		})
+	}
+}
+
+func TestValidateIgnitionUnits(t *testing.T) {
+	require := require.New(t)
+	ignitionConfig := config_latest_types.Config{
+		Systemd: config_latest_types.Systemd{Units: []config_latest_types.Unit{
+			{Name: "app.service", Contents: lo.ToPtr("[Service]\nExecStart /bin/app\n")},
+		}},
+		Storage: config_latest_types.Storage{Files: []config_latest_types.File{
+			{Node: config_latest_types.Node{Path: "/etc/containers/systemd/app.container"}, FileEmbedded1: config_latest_types.FileEmbedded1{
+				Contents: config_latest_types.Resource{Source: lo.ToPtr("data:,Image%3Dquay.io%2Forg%2Fapp%0A")},
+			}},
+			{Node: config_latest_types.Node{Path: "/etc/motd"}, FileEmbedded1: config_latest_types.FileEmbedded1{
+				Contents: config_latest_types.Resource{Source: lo.ToPtr("data:,hello")},
+			}},
+		}},
+	}
+	require.Equal([]string{
+		`/etc/systemd/system/app.service: line 2: expected a section header or KEY=VALUE, found "ExecStart /bin/app"`,
+		"/etc/systemd/system/app.service: service has no ExecStart= setting",
+		"/etc/containers/systemd/app.container: line 1: setting Image is outside of a section",
+		"/etc/containers/systemd/app.container: [Container] has none of the settings Image=, Rootfs=",
+	}, ValidateIgnitionUnits(&ignitionConfig))
+}
+
+func TestAnalyzeUnit(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		contents string
+		expected []string
+	}{
+		{
+			name:     "valid service",
+			path:     "/etc/systemd/system/app.service",
+			contents: "[Unit]\nAfter=network-online.target app-data.mount\n[Service]\nType=notify\nExecStart=/bin/app\nRestart=on-failure\n[Install]\nWantedBy=multi-user.target\n[X-Vendor]\nKey=value\n",
+			expected: []string{},
+		},
+		{
+			name:     "oneshot service stopping",
+			path:     "/etc/systemd/system/app.service",
+			contents: "[Service]\nType=oneshot\nExecStop=/bin/app stop\n",
+			expected: []string{},
+		},
+		{
+			name:     "invalid service",
+			path:     "/etc/systemd/system/app.service",
+			contents: "[Unit]\nAfter=network-online\n[Service]\nEnvironment=DEBUG=1\n[Timer]\nOnCalendar=daily\n",
+			expected: []string{
+				`line 2: invalid unit name "network-online" in After=`,
+				`line 5: unknown section [Timer] in a .service unit`,
+				"service has no ExecStart= setting",
+			},
+		},
+		{
+			name:     "invalid setting values",
+			path:     "/etc/systemd/system/app.service",
+			contents: "[Service]\nExecStart=/bin/app\nType=daemon\nRestart=sometimes\n",
+			expected: []string{
+				`line 3: invalid value "daemon" of Type=, expected one of simple, exec, forking, oneshot, dbus, notify, notify-reload, idle`,
+				`line 4: invalid value "sometimes" of Restart=, expected one of no, always, on-success, on-failure, on-abnormal, on-abort, on-watchdog`,
+			},
+		},
+		{
+			name:     "drop-in without the required settings",
+			path:     "/etc/systemd/system/app.service.d/override.conf",
+			contents: "[Service]\nEnvironment=DEBUG=1\n",
+			expected: []string{},
+		},
+		{
+			name:     "timer without trigger",
+			path:     "/etc/systemd/system/app.timer",
+			contents: "[Timer]\nPersistent=true\n",
+			expected: []string{"[Timer] has none of the settings OnCalendar=, OnActiveSec=, OnBootSec=, OnStartupSec=, OnUnitActiveSec=, OnUnitInactiveSec="},
+		},
+		{
+			name:     "Quadlet container",
+			path:     "/etc/containers/systemd/app.container",
+			contents: "[Container]\nImage=quay.io/org/app:v1\n[Service]\nRestart=always\n[Quadlet]\nDefaultDependencies=false\n",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, AnalyzeUnit(tt.path, []byte(tt.contents)))
+		})
+	}
+}
+
+func TestRenderConfigRejectsInvalidUnits(t *testing.T) {
+	require := require.New(t)
+	item := api.DeviceSpec_Config_Item{}
+	err := item.FromInlineConfigProviderSpec(api.InlineConfigProviderSpec{
+		ConfigType: string(api.TemplateDiscriminatorInlineConfig),
+		Name:       "units",
+		Inline: map[string]interface{}{
+			"ignition": map[string]interface{}{"version": "3.4.0"},
+			"systemd": map[string]interface{}{
+				"units": []interface{}{map[string]interface{}{"name": "app.timer", "contents": "[Timer]\nPersistent=true\n"}},
+			},
+		},
+	})
+	require.NoError(err)
+
+	for _, validateOnly := range []bool{true, false} {
+		_, _, err = renderConfig(context.Background(), uuid.New(), nil, nil, &[]api.DeviceSpec_Config_Item{item}, true, validateOnly)
+		require.ErrorContains(err, "1 problems in systemd units: /etc/systemd/system/app.timer: [Timer] has none of the settings")
+	}
+}
@@ -1,10 +1,13 @@
 package tpm
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"io"
 
+	"github.com/google/go-tpm-tools/client"
 	"github.com/google/go-tpm-tools/simulator"
 	"github.com/google/go-tpm/legacy/tpm2"
 	"github.com/google/go-tpm/tpmutil"
@@ -51,3 +54,120 @@ func (t *TPM) GetPCRValues(measurements map[string]string) error {
 	}
 	return nil
 }
+
+// GetEndorsementKey returns the DER encoded public key of the RSA endorsement
+// key in PKIX form, which identifies the TPM.
+func (t *TPM) GetEndorsementKey() ([]byte, error) {
+	ek, err := client.EndorsementKeyRSA(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("reading endorsement key: %w", err)
+	}
+	defer ek.Close()
+	der, err := x509.MarshalPKIXPublicKey(ek.PublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling endorsement key: %w", err)
+	}
+	return der, nil
+}
+
+// GetAttestationKey returns the public area (TPMT_PUBLIC) of the ECC
+// attestation key of the TPM.
+func (t *TPM) GetAttestationKey() ([]byte, error) {
+	ak, err := client.AttestationKeyECC(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("creating attestation key: %w", err)
+	}
+	defer ak.Close()
+	public, err := ak.PublicArea().Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding attestation key: %w", err)
+	}
+	return public, nil
+}
+
+// ActivateCredential recovers the secret of a credential (TPM2B_ID_OBJECT)
+// bound to the attestation key, whose seed (TPM2B_ENCRYPTED_SECRET) is
+// encrypted to the endorsement key, which proves that the TPM holds both.
+func (t *TPM) ActivateCredential(credential, secret []byte) ([]byte, error) {
+	var credBlob, encSecret tpmutil.U16Bytes
+	if _, err := tpmutil.Unpack(credential, &credBlob); err != nil {
+		return nil, fmt.Errorf("decoding credential: %w", err)
+	}
+	if _, err := tpmutil.Unpack(secret, &encSecret); err != nil {
+		return nil, fmt.Errorf("decoding secret: %w", err)
+	}
+	ek, err := client.EndorsementKeyRSA(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("reading endorsement key: %w", err)
+	}
+	defer ek.Close()
+	ak, err := client.AttestationKeyECC(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("creating attestation key: %w", err)
+	}
+	defer ak.Close()
+	// the endorsement key is only usable with the policy of the endorsement hierarchy
+	session, err := client.NewEKSession(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("starting endorsement key session: %w", err)
+	}
+	defer session.Close()
+	ekAuth, err := session.Auth()
+	if err != nil {
+		return nil, fmt.Errorf("authorizing endorsement key: %w", err)
+	}
+	akAuth := tpm2.AuthCommand{Session: tpm2.HandlePasswordSession, Attributes: tpm2.AttrContinueSession}
+	recovered, err := tpm2.ActivateCredentialUsingAuth(t.channel, []tpm2.AuthCommand{akAuth, ekAuth}, ak.Handle(), ek.Handle(), credBlob, encSecret)
+	if err != nil {
+		return nil, fmt.Errorf("activating credential: %w", err)
+	}
+	return recovered, nil
+}
+
+// Quote is a quote of PCR values signed by the attestation key of the TPM.
+type Quote struct {
+	// AttestationKey is the public area (TPMT_PUBLIC) of the attestation key.
+	AttestationKey []byte
+	// Quote is the attestation data (TPMS_ATTEST) the TPM signed.
+	Quote []byte
+	// Signature is the signature (TPMT_SIGNATURE) of the attestation data.
+	Signature []byte
+	// PCRs are the hex encoded SHA-256 values of the quoted PCRs, keyed like
+	// the values returned by GetPCRValues.
+	PCRs map[string]string
+}
+
+// Quote signs the SHA-256 values of the PCRs together with the nonce using
+// the ECC attestation key of the TPM, which the TPM derives from its
+// endorsement hierarchy and thus stays the same across quotes.
+func (t *TPM) Quote(pcrs []int, nonce []byte) (*Quote, error) {
+	ak, err := client.AttestationKeyECC(t.channel)
+	if err != nil {
+		return nil, fmt.Errorf("creating attestation key: %w", err)
+	}
+	defer ak.Close()
+	public, err := ak.PublicArea().Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding attestation key: %w", err)
+	}
+	quote, err := ak.Quote(tpm2.PCRSelection{Hash: tpm2.AlgSHA256, PCRs: pcrs}, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("quoting PCRs: %w", err)
+	}
+	values := make(map[string]string, len(quote.Pcrs.GetPcrs()))
+	for pcr, value := range quote.Pcrs.GetPcrs() {
+		values[fmt.Sprintf("pcr%02d", pcr)] = hex.EncodeToString(value)
+	}
+	return &Quote{
+		AttestationKey: public,
+		Quote:          quote.Quote,
+		Signature:      quote.RawSig,
+		PCRs:           values,
+	}, nil
+}
+
+// ExtendPCR extends the SHA-256 bank of the PCR with the hash of data.
+func (t *TPM) ExtendPCR(pcr int, data []byte) error {
+	hash := sha256.Sum256(data)
+	return tpm2.PCRExtend(t.channel, tpmutil.Handle(pcr), tpm2.AlgSHA256, hash[:], "")
+}
@@ -0,0 +1,83 @@
+package tpm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/flightctl/flightctl/internal/attestation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEndorsementKey(t *testing.T) {
+	require := require.New(t)
+	tpm, err := OpenTPMSimulator()
+	require.NoError(err)
+	defer tpm.Close()
+
+	ek, err := tpm.GetEndorsementKey()
+	require.NoError(err)
+	hash, err := attestation.EndorsementKeyHash(ek)
+	require.NoError(err)
+	require.Len(hash, 64)
+
+	again, err := tpm.GetEndorsementKey()
+	require.NoError(err)
+	require.Equal(ek, again)
+}
+
+func TestActivateCredential(t *testing.T) {
+	require := require.New(t)
+	tpm, err := OpenTPMSimulator()
+	require.NoError(err)
+	defer tpm.Close()
+
+	ek, err := tpm.GetEndorsementKey()
+	require.NoError(err)
+	ak, err := tpm.GetAttestationKey()
+	require.NoError(err)
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	credential, encryptedSecret, err := attestation.MakeCredential(ek, ak, secret)
+	require.NoError(err)
+
+	recovered, err := tpm.ActivateCredential(credential, encryptedSecret)
+	require.NoError(err)
+	require.Equal(secret, recovered)
+
+	// a credential encrypted to another endorsement key cannot be activated
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(err)
+	other, err := x509.MarshalPKIXPublicKey(&otherKey.PublicKey)
+	require.NoError(err)
+	otherCredential, otherSecret, err := attestation.MakeCredential(other, ak, secret)
+	require.NoError(err)
+	_, err = tpm.ActivateCredential(otherCredential, otherSecret)
+	require.Error(err)
+}
+
+func TestQuote(t *testing.T) {
+	require := require.New(t)
+	tpm, err := OpenTPMSimulator()
+	require.NoError(err)
+	defer tpm.Close()
+
+	nonce := []byte("0123456789abcdef0123456789abcdef")
+	quote, err := tpm.Quote(attestation.QuotedPCRs, nonce)
+	require.NoError(err)
+	require.Len(quote.PCRs, len(attestation.QuotedPCRs))
+	require.NoError(attestation.VerifyQuote(quote.AttestationKey, quote.Quote, quote.Signature, nonce, quote.PCRs))
+
+	// the service rejects quotes for another nonce or other PCR values
+	require.ErrorIs(attestation.VerifyQuote(quote.AttestationKey, quote.Quote, quote.Signature, []byte("another nonce"), quote.PCRs), attestation.ErrInvalidQuote)
+	tampered := maps.Clone(quote.PCRs)
+	tampered["pcr04"] = strings.Repeat("ff", 32)
+	require.ErrorIs(attestation.VerifyQuote(quote.AttestationKey, quote.Quote, quote.Signature, nonce, tampered), attestation.ErrInvalidQuote)
+
+	// the attestation key stays the same, so the service can bind a device to it
+	again, err := tpm.Quote(attestation.QuotedPCRs, nonce)
+	require.NoError(err)
+	require.Equal(quote.AttestationKey, again.AttestationKey)
+}
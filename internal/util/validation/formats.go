@@ -1,7 +1,13 @@
 package validation
 
 import (
+	"fmt"
+	"net"
 	"regexp"
+	"time"
+
+	"github.com/flightctl/flightctl/pkg/cron"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -40,6 +46,13 @@ func ValidateOciImageReference(s *string, path string) []error {
 	return ValidateString(s, path, 1, OciImageReferenceMaxLength, OciImageReferenceRegexp, OciImageReferenceFmt, "quay.io/flightctl/flightctl:latest")
 }
 
+var OciImageTagRegexp = regexp.MustCompile("^" + OciImageTagFmt + "$")
+
+// Validates the tag of an OCI image.
+func ValidateOciImageTag(s *string, path string) []error {
+	return ValidateString(s, path, 1, 128, OciImageTagRegexp, OciImageTagFmt, "9-stable")
+}
+
 const (
 	// as per https://docs.github.com/en/get-started/using-git/dealing-with-special-characters-in-branch-and-tag-names#naming-branches-and-tags
 	GitRevisionFmt string = `[a-zA-Z0-9]([a-zA-Z0-9\.\-\_\/])*`
@@ -52,3 +65,106 @@ var GitRevisionRegexp = regexp.MustCompile("^" + GitRevisionFmt + "$")
 func ValidateGitRevision(name *string, path string) []error {
 	return ValidateString(name, path, 1, GitRevisionMaxLength, GitRevisionRegexp, GitRevisionFmt)
 }
+
+const (
+	// the path of a fact in the system info of a device status, in the JSON field names of the API
+	SystemInfoFieldFmt       string = `systemInfo(\.[a-zA-Z][a-zA-Z0-9]*)+`
+	SystemInfoFieldMaxLength int    = 256
+)
+
+var SystemInfoFieldRegexp = regexp.MustCompile("^" + SystemInfoFieldFmt + "$")
+
+func ValidateSystemInfoField(s *string, path string) []error {
+	return ValidateString(s, path, 1, SystemInfoFieldMaxLength, SystemInfoFieldRegexp, SystemInfoFieldFmt, "systemInfo.architecture")
+}
+
+const (
+	// a released version such as a git tag, also used as a directory name
+	VersionFmt       string = `[a-zA-Z0-9][a-zA-Z0-9._+-]*`
+	VersionMaxLength int    = 128
+)
+
+var VersionRegexp = regexp.MustCompile("^" + VersionFmt + "$")
+
+func ValidateVersion(s *string, path string) []error {
+	return ValidateString(s, path, 1, VersionMaxLength, VersionRegexp, VersionFmt, "v0.3.1")
+}
+
+const (
+	Sha256Fmt string = `[a-f0-9]{64}`
+)
+
+var Sha256Regexp = regexp.MustCompile("^" + Sha256Fmt + "$")
+
+// Validates a hex encoded SHA-256 checksum.
+func ValidateSha256(s *string, path string) []error {
+	return ValidateString(s, path, 64, 64, Sha256Regexp, Sha256Fmt)
+}
+
+const (
+	// a duration in the short form of the API, such as the agent intervals
+	DurationFmt       string = `[1-9]\d*[smh]`
+	DurationMaxLength int    = 16
+)
+
+var DurationRegexp = regexp.MustCompile("^" + DurationFmt + "$")
+
+func ValidateDuration(s *string, path string) []error {
+	return ValidateString(s, path, 1, DurationMaxLength, DurationRegexp, DurationFmt, "30m")
+}
+
+const (
+	// a MAC address in the canonical form reported by the agent
+	MacAddressFmt string = `[0-9a-f]{2}(:[0-9a-f]{2}){5}`
+)
+
+var MacAddressRegexp = regexp.MustCompile("^" + MacAddressFmt + "$")
+
+func ValidateMacAddress(s *string, path string) []error {
+	return ValidateString(s, path, 17, 17, MacAddressRegexp, MacAddressFmt, "52:54:00:12:34:56")
+}
+
+const (
+	HostnameFmt       string = ociDomainCompFmt + `(?:[.]` + ociDomainCompFmt + `)*`
+	HostnameMaxLength int    = 253
+)
+
+var HostnameRegexp = regexp.MustCompile("^" + HostnameFmt + "$")
+
+// Validates a host name or an IP address.
+func ValidateHostname(s *string, path string) []error {
+	if s != nil && net.ParseIP(*s) != nil {
+		return nil
+	}
+	return ValidateString(s, path, 1, HostnameMaxLength, HostnameRegexp, HostnameFmt, "ntp.example.com")
+}
+
+// Validates a cron expression of a schedule, which must activate at some point.
+func ValidateCronSchedule(s *string, path string) []error {
+	if s == nil {
+		return []error{}
+	}
+	schedule, err := cron.Parse(*s)
+	if err != nil {
+		return []error{fmt.Errorf("%s: invalid cron expression %q: %w", path, *s, err)}
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return []error{fmt.Errorf("%s: cron expression %q never activates", path, *s)}
+	}
+	return []error{}
+}
+
+// Validates a positive resource quantity, such as 500m or 512Mi.
+func ValidateQuantity(s *string, path string) []error {
+	if s == nil {
+		return []error{}
+	}
+	quantity, err := resource.ParseQuantity(*s)
+	if err != nil {
+		return []error{fmt.Errorf("%s: invalid quantity %q: %w", path, *s, err)}
+	}
+	if quantity.Sign() <= 0 {
+		return []error{fmt.Errorf("%s: quantity %q must be positive", path, *s)}
+	}
+	return []error{}
+}
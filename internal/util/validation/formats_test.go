@@ -94,3 +94,220 @@ func TestValidateGitRevision(t *testing.T) {
 		assert.NotEmpty(ValidateGitRevision(&val, "bad.image.ref"), fmt.Sprintf("value: %q", val))
 	}
 }
+
+func TestValidateSystemInfoField(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"systemInfo.architecture",
+		"systemInfo.hardware.gpuPresent",
+		"systemInfo.hardware.cpu.cores",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateSystemInfoField(&val, "good.field"))
+	}
+
+	badValues := []string{
+		"",
+		"systemInfo",
+		"systemInfo.",
+		"systemInfo..architecture",
+		"summary.status",
+		"systemInfo.hardware.disks[0]",
+		"systemInfo.1st",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateSystemInfoField(&val, "bad.field"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"v0.3.1",
+		"0.4.0-rc1",
+		"v0.4.0+hotfix.2",
+		"latest",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateVersion(&val, "good.version"))
+	}
+
+	badValues := []string{
+		"",
+		"..",
+		"../v0.3.1",
+		"v0.3.1/bin",
+		"-v0.3.1",
+		"v0.3 1",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateVersion(&val, "bad.version"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateSha256(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateSha256(&val, "good.sha256"))
+	}
+
+	badValues := []string{
+		"",
+		"e3b0c44298fc1c149afbf4c8996fb924",
+		"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855a",
+		"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b785",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateSha256(&val, "bad.sha256"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"1s",
+		"30m",
+		"24h",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateDuration(&val, "good.duration"))
+	}
+
+	badValues := []string{
+		"",
+		"0s",
+		"10",
+		"1d",
+		"1h30m",
+		"-5m",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateDuration(&val, "bad.duration"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateMacAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"52:54:00:12:34:56",
+		"00:00:00:00:00:00",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateMacAddress(&val, "good.mac"))
+	}
+
+	badValues := []string{
+		"",
+		"52:54:00:12:34",
+		"52:54:00:AB:CD:EF",
+		"52-54-00-12-34-56",
+		"5254.0012.3456",
+		"52:54:00:12:34:56:78",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateMacAddress(&val, "bad.mac"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"ntp.example.com",
+		"Time-1.Example.com",
+		"localhost",
+		"192.168.1.10",
+		"2001:db8::123",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateHostname(&val, "good.hostname"))
+	}
+
+	badValues := []string{
+		"",
+		"ntp.example.com iburst",
+		"ntp.example.com\nserver evil.example.com",
+		"-ntp.example.com",
+		"ntp..example.com",
+		"ntp.example.com:123",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateHostname(&val, "bad.hostname"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateCronSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"0 6 * * *",
+		"*/15 8-18 * * mon-fri",
+		"@daily",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateCronSchedule(&val, "good.schedule"))
+	}
+
+	badValues := []string{
+		"",
+		"0 6 * *",
+		"61 * * * *",
+		"@sometimes",
+		"0 0 31 2 *",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateCronSchedule(&val, "bad.schedule"), fmt.Sprintf("value: %q", val))
+	}
+}
+
+func TestValidateQuantity(t *testing.T) {
+	assert := assert.New(t)
+
+	goodValues := []string{
+		"1",
+		"1.5",
+		"500m",
+		"512Mi",
+		"2G",
+	}
+	for _, val := range goodValues {
+		val := val
+		assert.Empty(ValidateQuantity(&val, "good.quantity"))
+	}
+
+	badValues := []string{
+		"",
+		"0",
+		"-1",
+		"1.5 cores",
+		"512MB",
+	}
+	for _, val := range badValues {
+		val := val
+		assert.NotEmpty(ValidateQuantity(&val, "bad.quantity"), fmt.Sprintf("value: %q", val))
+	}
+}
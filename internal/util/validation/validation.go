@@ -40,6 +40,27 @@ func ValidateLabelsWithPath(labels *map[string]string, path string) []error {
 	return asErrors(errs)
 }
 
+// ValidateLabelKey validates that a string is a valid K8s label key.
+func ValidateLabelKey(key *string, path string) []error {
+	if key == nil {
+		return []error{}
+	}
+	errs := k8smetav1validation.ValidateLabelName(*key, fieldPathFor(path))
+	return asErrors(errs)
+}
+
+// ValidateLabelValue validates that a string is a valid K8s label value.
+func ValidateLabelValue(value *string, path string) []error {
+	if value == nil {
+		return []error{}
+	}
+	errs := field.ErrorList{}
+	for _, msg := range k8sutilvalidation.IsValidLabelValue(*value) {
+		errs = append(errs, field.Invalid(fieldPathFor(path), *value, msg))
+	}
+	return asErrors(errs)
+}
+
 // ValidateAnnotations validates that a set of annotations are valid K8s annotations.
 func ValidateAnnotations(annotations *map[string]string) []error {
 	if annotations == nil {
@@ -72,6 +93,14 @@ func ValidateString(s *string, path string, minLen int, maxLen int, patternRegex
 	return asErrors(errs)
 }
 
+// ValidateMinimum validates that an optional integer is at least the minimum.
+func ValidateMinimum(i *int32, path string, minimum int32) []error {
+	if i == nil || *i >= minimum {
+		return []error{}
+	}
+	return asErrors(field.ErrorList{field.Invalid(fieldPathFor(path), *i, fmt.Sprintf("must be at least %d", minimum))})
+}
+
 func ValidateBase64Field(s string, path string, maxLen int) []error {
 	errs := field.ErrorList{}
 
@@ -7,6 +7,8 @@ import (
 	"syscall"
 
 	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/inventory"
+	"github.com/flightctl/flightctl/internal/specdelivery"
 	"github.com/flightctl/flightctl/internal/store"
 	"github.com/flightctl/flightctl/internal/tasks"
 	"github.com/flightctl/flightctl/pkg/k8sclient"
@@ -47,7 +49,17 @@ func (s *Server) Run() error {
 		return err
 	}
 	callbackManager := tasks.NewCallbackManager(publisher, s.log)
-	if err = tasks.LaunchConsumers(context.Background(), s.provider, s.store, callbackManager, s.k8sClient, 1, 1); err != nil {
+	inventoryClient, err := inventory.New(s.cfg)
+	if err != nil {
+		s.log.WithError(err).Error("failed to create inventory client")
+		return err
+	}
+	specDelivery, err := specdelivery.New(s.cfg)
+	if err != nil {
+		s.log.WithError(err).Error("failed to create spec delivery publisher")
+		return err
+	}
+	if err = tasks.LaunchConsumers(context.Background(), s.provider, s.store, callbackManager, s.k8sClient, inventoryClient, specDelivery, s.cfg.Service.LabelSchemas, 1, 1); err != nil {
 		s.log.WithError(err).Error("failed to launch consumers")
 		return err
 	}
@@ -0,0 +1,127 @@
+// Package client is a client of the flightctl API for automation. It wraps the
+// client generated from the OpenAPI spec with client-side rate limiting, retries
+// with backoff, pagination and watch helpers.
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	apiclient "github.com/flightctl/flightctl/internal/api/client"
+	"github.com/flightctl/flightctl/internal/client"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultQPS is the default sustained rate of requests per second
+	DefaultQPS = 20
+	// DefaultBurst is the default number of requests which may exceed the rate
+	DefaultBurst = 50
+)
+
+// DefaultBackoff is the default backoff between the retries of a failed request.
+var DefaultBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    4,
+	Cap:      10 * time.Second,
+}
+
+// Config holds the information needed to connect to a flightctl API server,
+// as read from the config file written by "flightctl login".
+type Config = client.Config
+
+// Client is a client of the flightctl API. The generated methods of the
+// embedded client are rate limited and retried like the helpers of Client.
+type Client struct {
+	*apiclient.ClientWithResponses
+}
+
+type Option func(*options)
+
+type options struct {
+	qps     float64
+	burst   int
+	backoff wait.Backoff
+}
+
+// WithRateLimit limits the client to qps requests per second on average,
+// allowing bursts of up to burst requests. A qps of zero disables the limit.
+func WithRateLimit(qps float64, burst int) Option {
+	return func(o *options) {
+		o.qps = qps
+		o.burst = burst
+	}
+}
+
+// WithBackoff sets the backoff between retries of failed requests. The number
+// of steps is the number of retries, zero steps disables retries.
+func WithBackoff(backoff wait.Backoff) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// New returns a new client for the API server of the config.
+func New(config *Config, opts ...Option) (*Client, error) {
+	options := options{
+		qps:     DefaultQPS,
+		burst:   DefaultBurst,
+		backoff: DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient, err := client.NewHTTPClientFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	t := &transport{next: httpClient.Transport, backoff: options.backoff}
+	if options.qps > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(options.qps), options.burst)
+	}
+	httpClient.Transport = t
+
+	c, err := client.NewFromConfig(config, apiclient.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{ClientWithResponses: c}, nil
+}
+
+// NewFromConfigFile returns a new client using the config read from the given file.
+func NewFromConfigFile(filename string, opts ...Option) (*Client, error) {
+	config, err := client.ParseConfigFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return New(config, opts...)
+}
+
+// APIError is an error response of the API server.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// ResponseError returns the error of a response with an unexpected status code.
+func ResponseError(statusCode int, body []byte) error {
+	apiErr := &APIError{StatusCode: statusCode}
+	var errorBody api.Error
+	if err := json.Unmarshal(body, &errorBody); err == nil && errorBody.Message != "" {
+		apiErr.Message = errorBody.Message
+	} else {
+		apiErr.Message = string(body)
+	}
+	return apiErr
+}
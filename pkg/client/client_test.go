@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/client"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 2}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := client.NewDefault()
+	config.Service.Server = server.URL
+	c, err := New(config, append([]Option{WithBackoff(testBackoff)}, opts...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	require.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+func newDevice(name string, resourceVersion string) api.Device {
+	return api.Device{
+		ApiVersion: "v1alpha1",
+		Kind:       "Device",
+		Metadata:   api.ObjectMeta{Name: lo.ToPtr(name), ResourceVersion: lo.ToPtr(resourceVersion)},
+	}
+}
+
+func TestRetryIdempotentRequests(t *testing.T) {
+	require := require.New(t)
+
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			writeJSON(t, w, http.StatusServiceUnavailable, api.Error{Message: "unavailable"})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, newDevice("device", "1"))
+	})
+
+	resp, err := c.ReadDeviceWithResponse(context.Background(), "device")
+	require.NoError(err)
+	require.Equal(http.StatusOK, resp.StatusCode())
+	require.Equal(int32(3), requests.Load())
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	require := require.New(t)
+
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(t, w, http.StatusServiceUnavailable, api.Error{Message: "unavailable"})
+	})
+
+	resp, err := c.ReadDeviceWithResponse(context.Background(), "device")
+	require.NoError(err)
+	require.Equal(http.StatusServiceUnavailable, resp.StatusCode())
+	require.Equal(int32(testBackoff.Steps+1), requests.Load())
+}
+
+func TestRetryNonIdempotentRequests(t *testing.T) {
+	require := require.New(t)
+
+	var requests atomic.Int32
+	statusCode := http.StatusServiceUnavailable
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var device api.Device
+		require.NoError(json.NewDecoder(r.Body).Decode(&device))
+		require.Equal("device", *device.Metadata.Name)
+		if requests.Add(1) == 1 {
+			writeJSON(t, w, statusCode, api.Error{Message: "retry"})
+			return
+		}
+		writeJSON(t, w, http.StatusCreated, device)
+	})
+
+	// the request may have been processed
+	resp, err := c.CreateDeviceWithResponse(context.Background(), newDevice("device", ""))
+	require.NoError(err)
+	require.Equal(http.StatusServiceUnavailable, resp.StatusCode())
+	require.Equal(int32(1), requests.Load())
+
+	// the request was rejected before it was processed
+	requests.Store(0)
+	statusCode = http.StatusTooManyRequests
+	resp, err = c.CreateDeviceWithResponse(context.Background(), newDevice("device", ""))
+	require.NoError(err)
+	require.Equal(http.StatusCreated, resp.StatusCode())
+	require.Equal(int32(2), requests.Load())
+}
+
+func TestRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, newDevice("device", "1"))
+	}, WithRateLimit(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := c.ReadDeviceWithResponse(context.Background(), "device")
+		require.NoError(err)
+	}
+	require.GreaterOrEqual(time.Since(start), 90*time.Millisecond)
+}
+
+func TestListAllDevices(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("2", r.URL.Query().Get("limit"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("continue"))
+		list := api.DeviceList{ApiVersion: "v1alpha1", Kind: "DeviceList"}
+		for i := page * 2; i < min(page*2+2, 5); i++ {
+			list.Items = append(list.Items, newDevice("device-"+strconv.Itoa(i), "1"))
+		}
+		if page < 2 {
+			list.Metadata.Continue = lo.ToPtr(strconv.Itoa(page + 1))
+		}
+		writeJSON(t, w, http.StatusOK, list)
+	})
+
+	devices, err := c.ListAllDevices(context.Background(), api.ListDevicesParams{Limit: lo.ToPtr[int32](2)})
+	require.NoError(err)
+	require.Len(devices, 5)
+	require.Equal("device-4", *devices[4].Metadata.Name)
+}
+
+func TestListAllDevicesError(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusBadRequest, api.Error{Message: "invalid label selector"})
+	})
+
+	_, err := c.ListAllDevices(context.Background(), api.ListDevicesParams{})
+	var apiErr *APIError
+	require.ErrorAs(err, &apiErr)
+	require.Equal(http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal("invalid label selector", apiErr.Message)
+}
+
+func TestWatchDevice(t *testing.T) {
+	require := require.New(t)
+
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// the device changes with every second poll
+		version := (requests.Add(1) + 1) / 2
+		writeJSON(t, w, http.StatusOK, newDevice("device", strconv.Itoa(int(version))))
+	})
+
+	versions := []string{}
+	err := c.WatchDevice(context.Background(), "device", time.Millisecond, func(device *api.Device) (bool, error) {
+		versions = append(versions, *device.Metadata.ResourceVersion)
+		return len(versions) == 3, nil
+	})
+	require.NoError(err)
+	require.Equal([]string{"1", "2", "3"}, versions)
+	require.Equal(int32(5), requests.Load())
+}
+
+func TestWatchDeviceCanceled(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, newDevice("device", "1"))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	calls := 0
+	err := c.WatchDevice(ctx, "device", time.Millisecond, func(device *api.Device) (bool, error) {
+		calls++
+		return false, nil
+	})
+	require.ErrorIs(err, context.DeadlineExceeded)
+	require.Equal(1, calls)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+)
+
+// PageFunc fetches the page of a list starting at the continue token, and
+// returns its items and the continue token of the next page.
+type PageFunc[T any] func(ctx context.Context, cont *string) ([]T, *string, error)
+
+// Paginate calls fn with every item of a paged list, fetching the pages as
+// needed. It stops at the first error returned by list or fn.
+func Paginate[T any](ctx context.Context, list PageFunc[T], fn func(*T) error) error {
+	var cont *string
+	for {
+		items, next, err := list(ctx, cont)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			if err := fn(&items[i]); err != nil {
+				return err
+			}
+		}
+		if lo.FromPtr(next) == "" {
+			return nil
+		}
+		cont = next
+	}
+}
+
+// ForEachDevice calls fn with every device matching the params. The continue
+// token of the params is ignored, while the limit sets the page size.
+func (c *Client) ForEachDevice(ctx context.Context, params api.ListDevicesParams, fn func(*api.Device) error) error {
+	return Paginate(ctx, func(ctx context.Context, cont *string) ([]api.Device, *string, error) {
+		params.Continue = cont
+		resp, err := c.ListDevicesWithResponse(ctx, &params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, nil, ResponseError(resp.StatusCode(), resp.Body)
+		}
+		return resp.JSON200.Items, resp.JSON200.Metadata.Continue, nil
+	}, fn)
+}
+
+// ListAllDevices returns all devices matching the params, across all pages.
+func (c *Client) ListAllDevices(ctx context.Context, params api.ListDevicesParams) ([]api.Device, error) {
+	devices := []api.Device{}
+	err := c.ForEachDevice(ctx, params, func(device *api.Device) error {
+		devices = append(devices, *device)
+		return nil
+	})
+	return devices, err
+}
+
+// ForEachFleet calls fn with every fleet matching the params. The continue
+// token of the params is ignored, while the limit sets the page size.
+func (c *Client) ForEachFleet(ctx context.Context, params api.ListFleetsParams, fn func(*api.Fleet) error) error {
+	return Paginate(ctx, func(ctx context.Context, cont *string) ([]api.Fleet, *string, error) {
+		params.Continue = cont
+		resp, err := c.ListFleetsWithResponse(ctx, &params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, nil, ResponseError(resp.StatusCode(), resp.Body)
+		}
+		return resp.JSON200.Items, resp.JSON200.Metadata.Continue, nil
+	}, fn)
+}
+
+// ListAllFleets returns all fleets matching the params, across all pages.
+func (c *Client) ListAllFleets(ctx context.Context, params api.ListFleetsParams) ([]api.Fleet, error) {
+	fleets := []api.Fleet{}
+	err := c.ForEachFleet(ctx, params, func(fleet *api.Fleet) error {
+		fleets = append(fleets, *fleet)
+		return nil
+	})
+	return fleets, err
+}
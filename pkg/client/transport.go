@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// transport rate limits the requests and retries them with backoff if they
+// failed in a way that is safe to retry.
+type transport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	backoff wait.Backoff
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := t.backoff
+	for retries := 0; ; retries++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context()); err != nil {
+				// the limiter fails early if waiting would exceed the deadline of the request
+				if _, ok := req.Context().Deadline(); ok && req.Context().Err() == nil {
+					return nil, fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+				}
+				return nil, err
+			}
+		}
+
+		attempt := req
+		if retries > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = req.Clone(req.Context())
+			attempt.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attempt)
+		if retries >= t.backoff.Steps || !retriable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff.Step()
+		if resp != nil {
+			if retryAfter := retryAfter(resp); retryAfter > delay {
+				delay = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retriable returns whether the request may be sent again after the response
+// or error. Requests which were not processed are always retried, requests
+// which may have been processed only if they are idempotent.
+func retriable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return idempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header of the
+// response in seconds, or zero if there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
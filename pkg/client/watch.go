@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/samber/lo"
+)
+
+// WatchFunc is called with every new version of a watched resource. Returning
+// true or an error ends the watch.
+type WatchFunc[T any] func(*T) (bool, error)
+
+// watch polls a resource every interval and calls fn whenever its
+// resourceVersion changed, until fn is done or the context is canceled.
+func watch[T any](ctx context.Context, interval time.Duration, get func(ctx context.Context) (*T, *api.ObjectMeta, error), fn WatchFunc[T]) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastVersion := ""
+	for {
+		resource, metadata, err := get(ctx)
+		if err != nil {
+			return err
+		}
+		// resources without a resourceVersion are passed on every poll
+		if version := lo.FromPtr(metadata.ResourceVersion); version == "" || version != lastVersion {
+			lastVersion = version
+			done, err := fn(resource)
+			if err != nil || done {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchDevice polls the device every interval and calls fn with every new
+// version of it, until fn is done or the context is canceled. It can be used
+// to wait for a device to reach a state, such as being updated to a version.
+func (c *Client) WatchDevice(ctx context.Context, name string, interval time.Duration, fn WatchFunc[api.Device]) error {
+	return watch(ctx, interval, func(ctx context.Context) (*api.Device, *api.ObjectMeta, error) {
+		resp, err := c.ReadDeviceWithResponse(ctx, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, nil, ResponseError(resp.StatusCode(), resp.Body)
+		}
+		return resp.JSON200, &resp.JSON200.Metadata, nil
+	}, fn)
+}
+
+// WatchFleet polls the fleet every interval and calls fn with every new
+// version of it, until fn is done or the context is canceled.
+func (c *Client) WatchFleet(ctx context.Context, name string, interval time.Duration, fn WatchFunc[api.Fleet]) error {
+	return watch(ctx, interval, func(ctx context.Context) (*api.Fleet, *api.ObjectMeta, error) {
+		resp, err := c.ReadFleetWithResponse(ctx, name, &api.ReadFleetParams{AddDevicesSummary: lo.ToPtr(true)})
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode() != http.StatusOK || resp.JSON200 == nil {
+			return nil, nil, ResponseError(resp.StatusCode(), resp.Body)
+		}
+		return resp.JSON200, &resp.JSON200.Metadata, nil
+	}, fn)
+}
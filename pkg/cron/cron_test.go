@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@often",
+	} {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2026, 10, 14, 9, 30, 15, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2026, 10, 14, 9, 31, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)},
+		{expr: "@daily", want: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 6 * * *", want: time.Date(2026, 10, 15, 6, 0, 0, 0, time.UTC)},
+		{expr: "45 9 * * *", want: time.Date(2026, 10, 14, 9, 45, 0, 0, time.UTC)},
+		{expr: "*/20 * * * *", want: time.Date(2026, 10, 14, 9, 40, 0, 0, time.UTC)},
+		{expr: "0 8-17/4 * * *", want: time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * mon-fri", want: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 jan *", want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// restricted days of month and of week match either
+		{expr: "0 0 20 * fri", want: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestNextIsUTC(t *testing.T) {
+	s, err := Parse("0 6 * * *")
+	require.NoError(t, err)
+	from := time.Date(2026, 10, 14, 7, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	require.Equal(t, time.Date(2026, 10, 14, 6, 0, 0, 0, time.UTC), s.Next(from))
+}
@@ -0,0 +1,115 @@
+package k8sclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// CertificateRequestGVR is the resource of cert-manager's certificate requests.
+var CertificateRequestGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificaterequests"}
+
+// IssuerRef references the cert-manager issuer signing a certificate request.
+type IssuerRef struct {
+	Name  string
+	Kind  string
+	Group string
+}
+
+type CertificateRequestClient interface {
+	// SignCertificateRequest creates a CertificateRequest for the PEM encoded
+	// CSR, waits until the issuer signed it and returns the PEM encoded
+	// certificate. The CertificateRequest is deleted again.
+	SignCertificateRequest(ctx context.Context, issuer IssuerRef, csrPEM []byte, duration time.Duration) ([]byte, error)
+}
+
+type certificateRequestClient struct {
+	client dynamic.ResourceInterface
+}
+
+func NewCertificateRequestClient(namespace string) (CertificateRequestClient, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return &certificateRequestClient{
+		client: client.Resource(CertificateRequestGVR).Namespace(namespace),
+	}, nil
+}
+
+func (c *certificateRequestClient) SignCertificateRequest(ctx context.Context, issuer IssuerRef, csrPEM []byte, duration time.Duration) ([]byte, error) {
+	request := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"request":  base64.StdEncoding.EncodeToString(csrPEM),
+			"duration": duration.String(),
+			"usages":   []interface{}{"client auth", "digital signature", "key encipherment"},
+			"issuerRef": map[string]interface{}{
+				"name":  issuer.Name,
+				"kind":  issuer.Kind,
+				"group": issuer.Group,
+			},
+		},
+	}}
+	request.SetAPIVersion(CertificateRequestGVR.GroupVersion().String())
+	request.SetKind("CertificateRequest")
+	request.SetGenerateName("flightctl-")
+	created, err := c.client.Create(ctx, request, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+	name := created.GetName()
+	defer func() {
+		// the certificate is returned to the caller, so the request is not needed anymore
+		_ = c.client.Delete(context.Background(), name, metav1.DeleteOptions{})
+	}()
+
+	var certificate []byte
+	err = wait.PollImmediateWithContext(ctx, time.Second, 30*time.Second, func(ctx context.Context) (bool, error) {
+		current, err := c.client.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		var status struct {
+			Certificate string             `json:"certificate,omitempty"`
+			Conditions  []metav1.Condition `json:"conditions,omitempty"`
+		}
+		if statusObject, ok := current.Object["status"].(map[string]interface{}); ok {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusObject, &status); err != nil {
+				return false, fmt.Errorf("failed to parse status: %w", err)
+			}
+		}
+		for _, condition := range status.Conditions {
+			switch {
+			case condition.Type == "Denied" && condition.Status == metav1.ConditionTrue,
+				condition.Type == "InvalidRequest" && condition.Status == metav1.ConditionTrue,
+				condition.Type == "Ready" && condition.Status == metav1.ConditionFalse && condition.Reason == "Failed":
+				return false, fmt.Errorf("%s: %s", condition.Reason, condition.Message)
+			}
+		}
+		if status.Certificate == "" {
+			return false, nil
+		}
+		certificate, err = base64.StdEncoding.DecodeString(status.Certificate)
+		if err != nil {
+			return false, fmt.Errorf("failed to decode certificate: %w", err)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("certificate request %s was not signed: %w", name, err)
+	}
+	return certificate, nil
+}
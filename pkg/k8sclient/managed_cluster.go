@@ -0,0 +1,96 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// ManagedClusterGVR is the resource of the clusters managed by an Open Cluster Management hub, such as ACM.
+var ManagedClusterGVR = schema.GroupVersionResource{Group: "cluster.open-cluster-management.io", Version: "v1", Resource: "managedclusters"}
+
+// ManagedCluster is the part of an Open Cluster Management ManagedCluster used by flightctl.
+type ManagedCluster struct {
+	Name       string
+	Labels     map[string]string
+	Conditions []metav1.Condition
+}
+
+type ManagedClusterClient interface {
+	ListManagedClusters(ctx context.Context, labelSelector string) ([]ManagedCluster, error)
+	CreateManagedCluster(ctx context.Context, name string, labels map[string]string) error
+	DeleteManagedCluster(ctx context.Context, name string) error
+}
+
+type managedClusterClient struct {
+	client dynamic.NamespaceableResourceInterface
+}
+
+func NewManagedClusterClient() (ManagedClusterClient, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	return &managedClusterClient{
+		client: client.Resource(ManagedClusterGVR),
+	}, nil
+}
+
+func (m *managedClusterClient) ListManagedClusters(ctx context.Context, labelSelector string) ([]ManagedCluster, error) {
+	list, err := m.client.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed clusters: %w", err)
+	}
+	clusters := make([]ManagedCluster, 0, len(list.Items))
+	for i := range list.Items {
+		var status struct {
+			Conditions []metav1.Condition `json:"conditions,omitempty"`
+		}
+		if statusObject, ok := list.Items[i].Object["status"].(map[string]interface{}); ok {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusObject, &status); err != nil {
+				return nil, fmt.Errorf("failed to parse status of managed cluster %s: %w", list.Items[i].GetName(), err)
+			}
+		}
+		clusters = append(clusters, ManagedCluster{
+			Name:       list.Items[i].GetName(),
+			Labels:     list.Items[i].GetLabels(),
+			Conditions: status.Conditions,
+		})
+	}
+	return clusters, nil
+}
+
+// CreateManagedCluster creates a ManagedCluster which the hub accepts, so
+// that the hub generates the klusterlet manifests for importing the cluster.
+func (m *managedClusterClient) CreateManagedCluster(ctx context.Context, name string, labels map[string]string) error {
+	cluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"hubAcceptsClient": true,
+		},
+	}}
+	cluster.SetAPIVersion(ManagedClusterGVR.GroupVersion().String())
+	cluster.SetKind("ManagedCluster")
+	cluster.SetName(name)
+	cluster.SetLabels(labels)
+	if _, err := m.client.Create(ctx, cluster, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create managed cluster %s: %w", name, err)
+	}
+	return nil
+}
+
+func (m *managedClusterClient) DeleteManagedCluster(ctx context.Context, name string) error {
+	if err := m.client.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete managed cluster %s: %w", name, err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/flightctl/flightctl/pkg/log"
 	"github.com/flightctl/flightctl/pkg/reqid"
@@ -14,6 +15,11 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// DrainTimeout bounds how long a stopping provider waits for the messages being
+// handled to be acknowledged. The messages not acknowledged by then are
+// delivered again once a consumer connects.
+const DrainTimeout = 30 * time.Second
+
 type amqpProvider struct {
 	url     string
 	log     logrus.FieldLogger
@@ -91,14 +97,23 @@ func (a *amqpProvider) NewPublisher(queueName string) (Publisher, error) {
 	return a.newQueue(queueName)
 }
 
+// Stop stops the deliveries of all consumers, waits for the messages being
+// handled to be acknowledged and closes the queues.
 func (a *amqpProvider) Stop() {
 	a.mu.Lock()
-	defer a.mu.Unlock()
 	if a.stopped.Swap(true) {
+		a.mu.Unlock()
 		return
 	}
+	queues := a.queues
+	a.mu.Unlock()
 	defer a.wg.Done()
-	for _, q := range a.queues {
+
+	deadline := time.Now().Add(DrainTimeout)
+	for _, q := range queues {
+		q.drain(deadline)
+	}
+	for _, q := range queues {
 		q.Close()
 	}
 }
@@ -114,6 +129,11 @@ type amqpQueue struct {
 	wg         *sync.WaitGroup
 	log        logrus.FieldLogger
 	closed     atomic.Bool
+	draining   atomic.Bool
+
+	mu             sync.Mutex
+	stopDeliveries []context.CancelFunc
+	consumers      sync.WaitGroup
 }
 
 func (r *amqpQueue) Publish(payload []byte) error {
@@ -132,9 +152,10 @@ func (r *amqpQueue) Publish(payload []byte) error {
 }
 
 func (r *amqpQueue) Consume(ctx context.Context, handler ConsumeHandler) error {
-	var cancel context.CancelFunc
-	ctx, cancel = context.WithCancel(ctx)
-	msgs, err := r.channel.ConsumeWithContext(ctx,
+	// the deliveries are stopped separately from the handlers, so that the
+	// messages being handled when the queue is drained are still acknowledged
+	deliveryCtx, stopDeliveries := context.WithCancel(ctx)
+	msgs, err := r.channel.ConsumeWithContext(deliveryCtx,
 		r.name,
 		"",    // consumer (exchange)
 		false, // auto ack
@@ -143,14 +164,19 @@ func (r *amqpQueue) Consume(ctx context.Context, handler ConsumeHandler) error {
 		false, // no wait
 		nil)   // args
 	if err != nil {
-		cancel()
+		stopDeliveries()
 		return err
 	}
+	r.mu.Lock()
+	r.stopDeliveries = append(r.stopDeliveries, stopDeliveries)
+	r.mu.Unlock()
 	r.wg.Add(1)
+	r.consumers.Add(1)
 	go func() {
 		var err error
 		defer r.wg.Done()
-		defer cancel()
+		defer r.consumers.Done()
+		defer stopDeliveries()
 		for d := range msgs {
 			requestID := reqid.NextRequestID()
 			reqCtx := context.WithValue(ctx, middleware.RequestIDKey, requestID)
@@ -162,13 +188,35 @@ func (r *amqpQueue) Consume(ctx context.Context, handler ConsumeHandler) error {
 				log.WithError(err).Errorf("failed to acknowledge message")
 			}
 		}
-		if !r.closed.Load() {
+		if !r.closed.Load() && !r.draining.Load() {
 			r.log.Fatal("channel was closed by AMQP provider")
 		}
 	}()
 	return nil
 }
 
+// drain stops the deliveries of the consumers of the queue and waits until
+// the deadline for the messages already delivered to be handled.
+func (r *amqpQueue) drain(deadline time.Time) {
+	r.draining.Store(true)
+	r.mu.Lock()
+	for _, stop := range r.stopDeliveries {
+		stop()
+	}
+	r.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		r.consumers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(time.Until(deadline)):
+		r.log.Warnf("closing queue %s before the messages being handled are acknowledged", r.name)
+	}
+}
+
 func (a *amqpQueue) Close() {
 	if a.closed.Swap(true) {
 		return
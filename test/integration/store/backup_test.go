@@ -0,0 +1,144 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/flightctl/flightctl/internal/artifacts"
+	"github.com/flightctl/flightctl/internal/backup"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/store"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var _ = Describe("Backup and restore", func() {
+	var (
+		log           *logrus.Logger
+		ctx           context.Context
+		orgId         uuid.UUID
+		sourceStore   store.Store
+		sourceCfg     *config.Config
+		sourceDBName  string
+		sourceDB      *gorm.DB
+		targetStore   store.Store
+		targetCfg     *config.Config
+		targetDBName  string
+		targetDB      *gorm.DB
+		sourceCertDir string
+		targetCertDir string
+		sourceFiles   artifacts.Store
+		targetFiles   artifacts.Store
+		passphrase    []byte
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		sourceStore, sourceCfg, sourceDBName, sourceDB = store.PrepareDBForUnitTests(log)
+		targetStore, targetCfg, targetDBName, targetDB = store.PrepareDBForUnitTests(log)
+		sourceCertDir = GinkgoT().TempDir()
+		targetCertDir = GinkgoT().TempDir()
+		sourceFiles = artifacts.NewFilesystemStore(GinkgoT().TempDir())
+		targetFiles = artifacts.NewFilesystemStore(GinkgoT().TempDir())
+		passphrase = []byte("correct horse battery staple")
+
+		testutil.CreateTestDevices(ctx, 3, sourceStore.Device(), orgId, nil, false)
+		testutil.CreateTestFleets(ctx, 2, sourceStore.Fleet(), orgId, "myfleet", false, nil)
+		Expect(testutil.CreateTestTemplateVersions(ctx, 2, sourceStore.TemplateVersion(), orgId, "myfleet-1")).To(Succeed())
+		Expect(testutil.CreateRepositories(ctx, 2, sourceStore, orgId)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(sourceCertDir, "ca.key"), []byte("ca key"), 0600)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(sourceCertDir, "acme"), 0700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(sourceCertDir, "acme", "account"), []byte("account key"), 0600)).To(Succeed())
+		Expect(sourceFiles.Put(ctx, "bundles/a.tar", []byte("bundle"), "application/x-tar")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, sourceCfg, sourceStore, sourceDBName)
+		store.DeleteTestDB(log, targetCfg, targetStore, targetDBName)
+	})
+
+	createBackup := func() []byte {
+		var buf bytes.Buffer
+		manifest, err := backup.New(sourceDB, sourceCertDir, sourceFiles, log).Create(ctx, &buf, passphrase)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(manifest.Certificates).To(Equal([]string{"acme/account", "ca.key"}))
+		Expect(manifest.Artifacts).To(Equal(1))
+		return buf.Bytes()
+	}
+
+	It("restores the database, the certificates and the artifacts", func() {
+		archive := createBackup()
+		manifest, err := backup.New(targetDB, targetCertDir, targetFiles, log).Restore(ctx, bytes.NewReader(archive), passphrase, backup.RestoreOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		tables := lo.SliceToMap(manifest.Tables, func(t backup.TableManifest) (string, int64) { return t.Name, t.Rows })
+		Expect(tables).To(HaveKeyWithValue("devices", int64(3)))
+		Expect(tables).To(HaveKeyWithValue("fleets", int64(2)))
+		// fleets are restored before the template versions referencing them
+		names := lo.Map(manifest.Tables, func(t backup.TableManifest, _ int) string { return t.Name })
+		Expect(lo.IndexOf(names, "fleets")).To(BeNumerically("<", lo.IndexOf(names, "template_versions")))
+
+		devices, err := targetStore.Device().List(ctx, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devices.Items).To(HaveLen(3))
+		device, err := targetStore.Device().Get(ctx, orgId, "mydevice-1")
+		Expect(err).ToNot(HaveOccurred())
+		sourceDevice, err := sourceStore.Device().Get(ctx, orgId, "mydevice-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(device.Metadata).To(Equal(sourceDevice.Metadata))
+		Expect(device.Spec).To(Equal(sourceDevice.Spec))
+		tvs, err := targetStore.TemplateVersion().List(ctx, orgId, store.ListParams{FleetName: lo.ToPtr("myfleet-1")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tvs.Items).To(HaveLen(2))
+		repos, err := targetStore.Repository().List(ctx, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(repos.Items).To(HaveLen(2))
+
+		content, err := os.ReadFile(filepath.Join(targetCertDir, "acme", "account"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("account key"))
+		body, object, err := targetFiles.Get(ctx, "bundles/a.tar")
+		Expect(err).ToNot(HaveOccurred())
+		defer body.Close()
+		Expect(object.ContentType).To(Equal("application/x-tar"))
+		content, err = io.ReadAll(body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("bundle"))
+
+		// the service restored into is not overwritten without force
+		_, err = backup.New(targetDB, targetCertDir, targetFiles, log).Restore(ctx, bytes.NewReader(archive), passphrase, backup.RestoreOptions{})
+		Expect(err).To(MatchError(ContainSubstring("restore with force")))
+		_, err = backup.New(targetDB, targetCertDir, targetFiles, log).Restore(ctx, bytes.NewReader(archive), passphrase, backup.RestoreOptions{Force: true})
+		Expect(err).ToNot(HaveOccurred())
+		devices, err = targetStore.Device().List(ctx, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devices.Items).To(HaveLen(3))
+	})
+
+	It("leaves the database untouched if the backup is truncated", func() {
+		archive := createBackup()
+		_, err := backup.New(targetDB, targetCertDir, targetFiles, log).Restore(ctx, bytes.NewReader(archive[:len(archive)-10]), passphrase, backup.RestoreOptions{})
+		Expect(err).To(HaveOccurred())
+		devices, err := targetStore.Device().List(ctx, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(devices.Items).To(BeEmpty())
+		_, err = os.Stat(filepath.Join(targetCertDir, "ca.key"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("refuses a wrong passphrase", func() {
+		archive := createBackup()
+		_, err := backup.New(targetDB, targetCertDir, targetFiles, log).Restore(ctx, bytes.NewReader(archive), []byte("wrong passphrase"), backup.RestoreOptions{})
+		Expect(err).To(MatchError(backup.ErrDecrypt))
+	})
+})
@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -211,7 +212,7 @@ var _ = Describe("DeviceStore create", func() {
 				updatedStatus := fmt.Sprintf("updated-%d", i)
 				d.Status.Updated.Status = api.DeviceUpdatedStatusType(updatedStatus)
 				expectedUpdatedMap[updatedStatus] = expectedUpdatedMap[updatedStatus] + 1
-				_, err = devStore.UpdateStatus(ctx, orgId, d)
+				_, err = devStore.UpdateStatus(ctx, orgId, d, callback)
 				Expect(err).ToNot(HaveOccurred())
 			}
 			allDevices, err = devStore.List(ctx, orgId, store.ListParams{})
@@ -286,6 +287,27 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(len(devices.Items)).To(Equal(3))
 		})
 
+		It("List with application restarts", func() {
+			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			dev.Status.Applications.Data = map[string]api.ApplicationStatus{
+				"web":   {Name: "web", Status: api.ApplicationStatusRunning, Restarts: 1},
+				"cache": {Name: "cache", Status: api.ApplicationStatusError, Restarts: 7},
+			}
+			dev.Metadata.ResourceVersion = nil
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			devices, err := devStore.List(ctx, orgId, store.ListParams{ApplicationRestarts: lo.ToPtr(5)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices.Items).To(HaveLen(1))
+			Expect(*devices.Items[0].Metadata.Name).To(Equal("mydevice-1"))
+
+			devices, err = devStore.List(ctx, orgId, store.ListParams{ApplicationRestarts: lo.ToPtr(8)})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(devices.Items).To(HaveLen(0))
+		})
+
 		It("List with owner selector", func() {
 			testutil.CreateTestDevice(ctx, devStore, orgId, "fleet-a-device", util.StrToPtr("Fleet/fleet-a"), nil, nil)
 			testutil.CreateTestDevice(ctx, devStore, orgId, "fleet-b-device", util.StrToPtr("Fleet/fleet-b"), nil, nil)
@@ -389,8 +411,9 @@ var _ = Describe("DeviceStore create", func() {
 				Status: &status,
 			}
 			api.SetStatusCondition(&device.Status.Conditions, condition)
-			_, err := devStore.UpdateStatus(ctx, orgId, &device)
+			_, err := devStore.UpdateStatus(ctx, orgId, &device, callback)
 			Expect(err).ToNot(HaveOccurred())
+			Expect(called).To(BeTrue())
 			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(dev.ApiVersion).To(Equal(model.DeviceAPI))
@@ -400,6 +423,91 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(api.IsStatusConditionFalse(dev.Status.Conditions, api.DeviceUpdating)).To(BeTrue())
 		})
 
+		It("UpdateDeviceStatus with stale resourceVersion", func() {
+			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			// dev still has the resourceVersion from before the update
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).To(MatchError(flterrors.ErrResourceVersionConflict))
+
+			// the agent reports its status without a resourceVersion
+			dev.Metadata.ResourceVersion = nil
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("UpdateDeviceStatus passes the previous status to the callback", func() {
+			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			dev.Status.Summary.Status = api.DeviceSummaryStatusOnline
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			var before, after *model.Device
+			recordCallback := store.DeviceStoreCallback(func(b *model.Device, a *model.Device) { before, after = b, a })
+			dev.Metadata.ResourceVersion = nil
+			dev.Status.Summary.Status = api.DeviceSummaryStatusDegraded
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, recordCallback)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(before.Status.Data.Summary.Status).To(Equal(api.DeviceSummaryStatusOnline))
+			Expect(after.Status.Data.Summary.Status).To(Equal(api.DeviceSummaryStatusDegraded))
+			Expect(*after.ResourceVersion).To(Equal(*before.ResourceVersion + 1))
+			Expect(after.Spec.Data.Os.Image).To(Equal("os"))
+		})
+
+		It("UpdateStatuses", func() {
+			stale, err := devStore.Get(ctx, orgId, "mydevice-2")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = devStore.UpdateStatus(ctx, orgId, stale, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			status := func(annotations *map[string]string) *api.DeviceStatus {
+				status := api.NewDeviceStatus()
+				status.Summary.Status = api.DeviceSummaryStatusOnline
+				status.Annotations = annotations
+				return &status
+			}
+			first := api.Device{Metadata: api.ObjectMeta{Name: util.StrToPtr("mydevice-1")}, Status: status(&map[string]string{"reported": "1"})}
+			devices := []*api.Device{
+				&first,
+				stale,
+				{Metadata: api.ObjectMeta{Name: util.StrToPtr("missing")}, Status: status(nil)},
+				{Metadata: api.ObjectMeta{Name: util.StrToPtr("mydevice-3")}, Status: status(nil)},
+			}
+			updated := []string{}
+			errs, err := devStore.UpdateStatuses(ctx, orgId, devices, func(before *model.Device, after *model.Device) {
+				updated = append(updated, after.Name)
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0]).ToNot(HaveOccurred())
+			Expect(errs[1]).To(MatchError(flterrors.ErrResourceVersionConflict))
+			Expect(errs[2]).To(MatchError(flterrors.ErrResourceNotFound))
+			Expect(errs[3]).ToNot(HaveOccurred())
+			Expect(updated).To(Equal([]string{"mydevice-1", "mydevice-3"}))
+
+			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dev.Status.Summary.Status).To(Equal(api.DeviceSummaryStatusOnline))
+			Expect(*dev.Status.Annotations).To(HaveKeyWithValue("reported", "1"))
+
+			// a status without annotations keeps those of the current status
+			first.Status = status(nil)
+			errs, err = devStore.UpdateStatuses(ctx, orgId, []*api.Device{&first}, callback)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(errs[0]).ToNot(HaveOccurred())
+			dev, err = devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*dev.Status.Annotations).To(HaveKeyWithValue("reported", "1"))
+
+			dev, err = devStore.Get(ctx, orgId, "mydevice-2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dev.Status.Summary.Status).ToNot(Equal(api.DeviceSummaryStatusOnline))
+		})
+
 		It("UpdateOwner", func() {
 			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
 			Expect(err).ToNot(HaveOccurred())
@@ -452,6 +560,26 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(*dev.Metadata.Annotations).To(HaveLen(0))
 		})
 
+		It("ReplaceDeviceAnnotation", func() {
+			err := devStore.ReplaceAnnotation(ctx, orgId, "mydevice-1", "key1", nil, lo.ToPtr("val1"))
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.ReplaceAnnotation(ctx, orgId, "mydevice-1", "key1", nil, lo.ToPtr("val2"))
+			Expect(err).To(MatchError(flterrors.ErrResourceVersionConflict))
+			err = devStore.ReplaceAnnotation(ctx, orgId, "mydevice-1", "key1", lo.ToPtr("other"), nil)
+			Expect(err).To(MatchError(flterrors.ErrResourceVersionConflict))
+			dev, err := devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect((*dev.Metadata.Annotations)["key1"]).To(Equal("val1"))
+
+			err = devStore.ReplaceAnnotation(ctx, orgId, "mydevice-1", "key1", lo.ToPtr("val1"), lo.ToPtr("val2"))
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.ReplaceAnnotation(ctx, orgId, "mydevice-1", "key1", lo.ToPtr("val2"), nil)
+			Expect(err).ToNot(HaveOccurred())
+			dev, err = devStore.Get(ctx, orgId, "mydevice-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*dev.Metadata.Annotations).ToNot(HaveKey("key1"))
+		})
+
 		It("UpdateDeviceAnnotations console", func() {
 			firstAnnotations := map[string]string{"key1": "val1"}
 			err := devStore.UpdateAnnotations(ctx, orgId, "mydevice-1", firstAnnotations, nil)
@@ -482,6 +610,35 @@ var _ = Describe("DeviceStore create", func() {
 			Expect((*dev.Metadata.Annotations)[model.DeviceAnnotationRenderedVersion]).To(Equal("2"))
 		})
 
+		It("GetRendered tunnel", func() {
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			err := devStore.UpdateRendered(ctx, orgId, "dev", "config", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			// an open tunnel is served whatever the rendered version of the device
+			tunnel := api.DeviceTunnel{Id: "tunnel", Port: 8080, PublicKey: "key", RequestedBy: "alice", Reason: "vendor UI", Ttl: "1h", RequestedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+			value, err := json.Marshal(tunnel)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", map[string]string{model.DeviceAnnotationTunnel: string(value)}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err := devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("2"), "grpcs://localhost:7444")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RenderedVersion).To(Equal("2"))
+			Expect(renderedConfig.Tunnel).ToNot(BeNil())
+			Expect(renderedConfig.Tunnel.Id).To(Equal("tunnel"))
+			Expect(lo.FromPtr(renderedConfig.Tunnel.GRPCEndpoint)).To(Equal("grpcs://localhost:7444"))
+
+			// and no longer once it expires
+			tunnel.ExpiresAt = time.Now().Add(-time.Minute)
+			value, err = json.Marshal(tunnel)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", map[string]string{model.DeviceAnnotationTunnel: string(value)}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("3"), "grpcs://localhost:7444")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig).To(BeNil())
+		})
+
 		It("GetRendered", func() {
 			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
 
@@ -491,7 +648,7 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(err).Should(MatchError(flterrors.ErrNoRenderedVersion))
 
 			// Set first rendered config
-			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the first config")
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the first config", nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Getting first rendered config
@@ -507,7 +664,7 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(renderedConfig).To(BeNil())
 
 			// Set second rendered config
-			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the second config")
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the second config", nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Passing previous renderedVersion
@@ -518,6 +675,147 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(renderedConfig.RenderedVersion).To(Equal("2"))
 		})
 
+		It("GetRendered with images for the architecture of the device", func() {
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			dev, err := devStore.Get(ctx, orgId, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			dev.Spec.Os.ArchitectureImages = &map[string]string{"arm64": "os-arm64"}
+			dev.Spec.Applications = &[]api.ApplicationSpec{{Name: "pod", Pod: &api.ApplicationPodSpec{
+				Containers: []api.ApplicationContainer{{Name: "app", Image: "app", ArchitectureImages: &map[string]string{"amd64": "app-amd64"}}},
+			}}}
+			_, err = devStore.Update(ctx, orgId, dev, nil, false, callback)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "config", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			// a device that did not report its architecture gets the default images
+			renderedConfig, err := devStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.Os.Image).To(Equal("os"))
+			Expect(renderedConfig.Os.ArchitectureImages).To(BeNil())
+			Expect((*renderedConfig.Applications)[0].Pod.Containers[0].Image).To(Equal("app"))
+
+			dev, err = devStore.Get(ctx, orgId, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			dev.Status.SystemInfo.Architecture = "arm64"
+			_, err = devStore.UpdateStatus(ctx, orgId, dev, callback)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.Os.Image).To(Equal("os-arm64"))
+			Expect((*renderedConfig.Applications)[0].Pod.Containers[0].Image).To(Equal("app"))
+			Expect((*renderedConfig.Applications)[0].Pod.Containers[0].ArchitectureImages).To(BeNil())
+
+			// the spec of the device keeps the images of all architectures
+			dev, err = devStore.Get(ctx, orgId, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*dev.Spec.Os.ArchitectureImages).To(HaveKeyWithValue("arm64", "os-arm64"))
+		})
+
+		It("GetRendered with cached rendered specs", func() {
+			cachedStore := store.NewStore(db, log.WithField("pkg", "store"), store.WithRenderedSpecCache(10)).Device()
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			err := devStore.UpdateRendered(ctx, orgId, "dev", "this is the first config", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			renderedConfig, err := cachedStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the first config"))
+			Expect(renderedConfig.RenderedVersion).To(Equal("1"))
+
+			// the cached spec is not changed by what its callers change
+			renderedConfig.Config = nil
+			renderedConfig, err = cachedStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the first config"))
+
+			// a new rendered version, such as rendered by another service, replaces the cached one
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the second config", nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = cachedStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the second config"))
+			Expect(renderedConfig.RenderedVersion).To(Equal("2"))
+
+			// the action and console of the device are served with the cached spec
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", map[string]string{model.DeviceAnnotationAction: `{"id":"42","action":"Reboot","requestedAt":"2024-01-01T00:00:00Z"}`}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = cachedStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("2"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the second config"))
+			Expect(renderedConfig.Action.Id).To(Equal("42"))
+
+			// a device created again with the same name starts over its rendered versions
+			err = devStore.Delete(ctx, orgId, "dev", callback)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = storeInst.Trash().Purge(ctx, time.Now().Add(time.Minute), func(before *model.Fleet, after *model.Fleet) {})
+			Expect(err).ToNot(HaveOccurred())
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			// rendered twice, to the version of the cached spec of the deleted device
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the config of the new device", nil)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the config of the new device", nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = cachedStore.GetRendered(ctx, orgId, "dev", nil, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the config of the new device"))
+			Expect(renderedConfig.Action).To(BeNil())
+		})
+
+		It("GetRendered of a quarantined device", func() {
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			err := devStore.UpdateRendered(ctx, orgId, "dev", "this is the first config", nil)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", map[string]string{model.DeviceAnnotationQuarantine: `{"reason":"incident 42","stopApplications":true}`}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the known version is served again, with the quarantine only
+			renderedConfig, err := devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RenderedVersion).To(Equal("1"))
+			Expect(renderedConfig.Config).To(BeNil())
+			Expect(renderedConfig.Quarantine.Reason).To(Equal("incident 42"))
+			Expect(*renderedConfig.Quarantine.StopApplications).To(BeTrue())
+
+			// new rendered versions are not served
+			err = devStore.UpdateRendered(ctx, orgId, "dev", "this is the second config", nil)
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RenderedVersion).To(Equal("1"))
+			Expect(renderedConfig.Config).To(BeNil())
+
+			// releasing the device serves the latest rendered version
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", nil, []string{model.DeviceAnnotationQuarantine})
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*renderedConfig.Config).To(Equal("this is the second config"))
+			Expect(renderedConfig.Quarantine).To(BeNil())
+		})
+
+		It("GetRendered of a device with a requested action", func() {
+			testutil.CreateTestDevice(ctx, storeInst.Device(), orgId, "dev", nil, nil, nil)
+			err := devStore.UpdateRendered(ctx, orgId, "dev", "this is the first config", nil)
+			Expect(err).ToNot(HaveOccurred())
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", map[string]string{model.DeviceAnnotationAction: `{"id":"42","action":"Reboot","requestedAt":"2024-01-01T00:00:00Z"}`}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the action is served with the known version
+			renderedConfig, err := devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig.RenderedVersion).To(Equal("1"))
+			Expect(renderedConfig.Action.Id).To(Equal("42"))
+			Expect(renderedConfig.Action.Action).To(Equal(api.DeviceActionReboot))
+
+			// acknowledging the action stops serving it
+			err = devStore.UpdateAnnotations(ctx, orgId, "dev", nil, []string{model.DeviceAnnotationAction})
+			Expect(err).ToNot(HaveOccurred())
+			renderedConfig, err = devStore.GetRendered(ctx, orgId, "dev", util.StrToPtr("1"), "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(renderedConfig).To(BeNil())
+		})
+
 		It("OverwriteRepositoryRefs", func() {
 			err := testutil.CreateRepositories(ctx, 2, storeInst, orgId)
 			Expect(err).ToNot(HaveOccurred())
@@ -582,5 +880,40 @@ var _ = Describe("DeviceStore create", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(called).To(BeTrue())
 		})
+
+		It("UpdateAliases", func() {
+			dev, err := devStore.UpdateAliases(ctx, orgId, "mydevice-1", lo.ToPtr("Lobby kiosk"), []string{"kiosk-lobby", "kiosk-7"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dev.Metadata.DisplayName).To(Equal(lo.ToPtr("Lobby kiosk")))
+			Expect(*dev.Metadata.Aliases).To(Equal([]string{"kiosk-lobby", "kiosk-7"}))
+
+			// updates through the API keep the display name and the aliases
+			dev.Metadata.Labels = &map[string]string{"site": "lobby"}
+			dev.Metadata.ResourceVersion = nil
+			_, _, err = devStore.CreateOrUpdate(ctx, orgId, dev, nil, true, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, alias := range []string{"mydevice-1", "Lobby kiosk", "kiosk-7"} {
+				devices, err := devStore.List(ctx, orgId, store.ListParams{Alias: lo.ToPtr(alias)})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(devices.Items).To(HaveLen(1))
+				Expect(*devices.Items[0].Metadata.Name).To(Equal("mydevice-1"))
+				Expect(devices.Items[0].Metadata.DisplayName).To(Equal(lo.ToPtr("Lobby kiosk")))
+			}
+
+			_, err = devStore.UpdateAliases(ctx, orgId, "mydevice-2", nil, []string{"kiosk-7"})
+			Expect(err).To(MatchError(flterrors.ErrAliasInUse))
+			_, err = devStore.UpdateAliases(ctx, orgId, "mydevice-2", nil, []string{"mydevice-1"})
+			Expect(err).To(MatchError(flterrors.ErrAliasInUse))
+			_, err = devStore.UpdateAliases(ctx, orgId, "mydevice-4", nil, nil)
+			Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+			dev, err = devStore.UpdateAliases(ctx, orgId, "mydevice-1", nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dev.Metadata.DisplayName).To(BeNil())
+			Expect(dev.Metadata.Aliases).To(BeNil())
+			_, err = devStore.UpdateAliases(ctx, orgId, "mydevice-2", nil, []string{"kiosk-7"})
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 })
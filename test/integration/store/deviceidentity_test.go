@@ -0,0 +1,107 @@
+package store_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("DeviceIdentityStore provisioning", func() {
+	var (
+		log       *logrus.Logger
+		ctx       context.Context
+		orgId     uuid.UUID
+		storeInst store.Store
+		cfg       *config.Config
+		dbName    string
+		ekHash    = strings.Repeat("ab", 32)
+	)
+
+	create := func(name string, spec api.DeviceIdentitySpec) {
+		_, err := storeInst.DeviceIdentity().Create(ctx, orgId, &api.DeviceIdentity{
+			Metadata: api.ObjectMeta{Name: lo.ToPtr(name)},
+			Spec:     spec,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	machine := func(serialNumber string, macAddresses []string, tpmEkHash string) store.ProvisioningIdentity {
+		return store.ProvisioningIdentity{
+			HardwareIdentity: store.HardwareIdentity{SerialNumber: serialNumber, MacAddresses: macAddresses},
+			TpmEkHash:        tpmEkHash,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, _ = store.PrepareDBForUnitTests(log)
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	It("matches only the machines presenting all the identifiers of a device identity", func() {
+		create("gateway", api.DeviceIdentitySpec{SerialNumber: lo.ToPtr("SN-1"), MacAddress: lo.ToPtr("52:54:00:ab:cd:01")})
+
+		_, err := storeInst.DeviceIdentity().Match(ctx, orgId, machine("SN-1", nil, ""))
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+		_, err = storeInst.DeviceIdentity().Match(ctx, orgId, machine("SN-2", []string{"52:54:00:ab:cd:01"}, ""))
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+		identity, err := storeInst.DeviceIdentity().Match(ctx, orgId, machine("SN-1", []string{"52:54:00:ab:cd:02", "52:54:00:ab:cd:01"}, ekHash))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*identity.Metadata.Name).To(Equal("gateway"))
+	})
+
+	It("refuses to pick one of several matching device identities", func() {
+		create("gateway-a", api.DeviceIdentitySpec{SerialNumber: lo.ToPtr("SN-1")})
+		create("gateway-b", api.DeviceIdentitySpec{SerialNumber: lo.ToPtr("SN-1"), MacAddress: lo.ToPtr("52:54:00:ab:cd:01")})
+
+		_, err := storeInst.DeviceIdentity().Match(ctx, orgId, machine("SN-1", []string{"52:54:00:ab:cd:01"}, ""))
+		Expect(err).To(MatchError(flterrors.ErrAmbiguousDeviceIdentity))
+		_, err = storeInst.DeviceIdentity().Provision(ctx, orgId, machine("SN-1", []string{"52:54:00:ab:cd:01"}, ""), nil)
+		Expect(err).To(MatchError(flterrors.ErrAmbiguousDeviceIdentity))
+
+		identity, err := storeInst.DeviceIdentity().Provision(ctx, orgId, machine("SN-1", nil, ""), nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*identity.Metadata.Name).To(Equal("gateway-a"))
+	})
+
+	It("provisions a device identity recording a TPM endorsement key only with the secret of its challenge", func() {
+		create("gateway", api.DeviceIdentitySpec{SerialNumber: lo.ToPtr("SN-1"), TpmEkHash: lo.ToPtr(ekHash)})
+		presented := machine("SN-1", nil, ekHash)
+
+		_, err := storeInst.DeviceIdentity().Provision(ctx, orgId, presented, []byte("secret"))
+		Expect(err).To(MatchError(flterrors.ErrProvisioningChallengeFailed))
+
+		secretHash := sha256.Sum256([]byte("secret"))
+		Expect(storeInst.DeviceIdentity().SetProvisioningChallenge(ctx, orgId, "gateway", secretHash[:], time.Now().Add(-time.Minute))).To(Succeed())
+		_, err = storeInst.DeviceIdentity().Provision(ctx, orgId, presented, []byte("secret"))
+		Expect(err).To(MatchError(flterrors.ErrProvisioningChallengeFailed))
+
+		Expect(storeInst.DeviceIdentity().SetProvisioningChallenge(ctx, orgId, "gateway", secretHash[:], time.Now().Add(time.Minute))).To(Succeed())
+		_, err = storeInst.DeviceIdentity().Provision(ctx, orgId, presented, []byte("another secret"))
+		Expect(err).To(MatchError(flterrors.ErrProvisioningChallengeFailed))
+		identity, err := storeInst.DeviceIdentity().Provision(ctx, orgId, presented, []byte("secret"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(identity.Status.ProvisionedAt).ToNot(BeNil())
+
+		_, err = storeInst.DeviceIdentity().Provision(ctx, orgId, presented, []byte("secret"))
+		Expect(err).To(MatchError(flterrors.ErrAlreadyProvisioned))
+	})
+})
@@ -243,5 +243,33 @@ var _ = Describe("enrollmentRequestStore create", func() {
 			Expect(dev.Status.Conditions).ToNot(BeEmpty())
 			Expect(dev.Status.Conditions[0].Type).To(Equal(api.EnrollmentRequestApproved))
 		})
+
+		It("UpdateEnrollmentRequestStatus with stale resourceVersion", func() {
+			enrollmentrequest, err := storeInst.EnrollmentRequest().Get(ctx, orgId, "myenrollmentrequest-1")
+			Expect(err).ToNot(HaveOccurred())
+			enrollmentrequest.Status = &api.EnrollmentRequestStatus{Conditions: []api.Condition{}}
+			_, err = storeInst.EnrollmentRequest().UpdateStatus(ctx, orgId, enrollmentrequest)
+			Expect(err).ToNot(HaveOccurred())
+
+			// the enrollmentrequest still has the resourceVersion from before the update
+			_, err = storeInst.EnrollmentRequest().UpdateStatus(ctx, orgId, enrollmentrequest)
+			Expect(err).To(MatchError(flterrors.ErrResourceVersionConflict))
+		})
+
+		It("UpdateEnrollmentRequestStatus - not found error", func() {
+			enrollmentrequest := api.EnrollmentRequest{
+				Metadata: api.ObjectMeta{
+					Name:            util.StrToPtr("nonexistent"),
+					ResourceVersion: util.StrToPtr("1"),
+				},
+				Status: &api.EnrollmentRequestStatus{Conditions: []api.Condition{}},
+			}
+			_, err := storeInst.EnrollmentRequest().UpdateStatus(ctx, orgId, &enrollmentrequest)
+			Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+			enrollmentrequest.Metadata.ResourceVersion = nil
+			_, err = storeInst.EnrollmentRequest().UpdateStatus(ctx, orgId, &enrollmentrequest)
+			Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+		})
 	})
 })
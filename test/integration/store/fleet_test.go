@@ -6,6 +6,7 @@ import (
 	"time"
 
 	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/api/v1beta1"
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/flterrors"
 	"github.com/flightctl/flightctl/internal/store"
@@ -82,26 +83,26 @@ var _ = Describe("FleetStore create", func() {
 					},
 				},
 			}
-			_, err := storeInst.Device().UpdateStatus(ctx, orgId, &device)
+			_, err := storeInst.Device().UpdateStatus(ctx, orgId, &device, nil)
 			Expect(err).ToNot(HaveOccurred())
 			device.Metadata.Name = util.StrToPtr("mydevice-2")
 			device.Status.Summary.Status = api.DeviceSummaryStatusDegraded
-			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device)
+			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device, nil)
 			Expect(err).ToNot(HaveOccurred())
 			device.Metadata.Name = util.StrToPtr("mydevice-3")
 			device.Status.Summary.Status = api.DeviceSummaryStatusOnline
 			device.Status.Updated.Status = api.DeviceUpdatedStatusUpdating
-			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device)
+			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device, nil)
 			Expect(err).ToNot(HaveOccurred())
 			device.Metadata.Name = util.StrToPtr("mydevice-4")
 			device.Status.Summary.Status = api.DeviceSummaryStatusRebooting
 			device.Status.Updated.Status = api.DeviceUpdatedStatusUpdating
-			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device)
+			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device, nil)
 			Expect(err).ToNot(HaveOccurred())
 			device.Metadata.Name = util.StrToPtr("mydevice-5")
 			device.Status.Summary.Status = api.DeviceSummaryStatusError
 			device.Status.Updated.Status = api.DeviceUpdatedStatusUnknown
-			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device)
+			_, err = storeInst.Device().UpdateStatus(ctx, orgId, &device, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// A device in another org that shouldn't be included
@@ -137,6 +138,12 @@ var _ = Describe("FleetStore create", func() {
 			err := storeInst.Fleet().Delete(ctx, orgId, callback, "myfleet-1")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(called).To(BeTrue())
+			_, err = storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).Should(MatchError(flterrors.ErrResourceNotFound))
+			trash, err := storeInst.Trash().List(ctx, orgId, nil, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trash.Items).To(HaveLen(1))
+			Expect(trash.Items[0].Name).To(Equal("myfleet-1"))
 		})
 
 		It("Delete fleet success when not found", func() {
@@ -354,6 +361,59 @@ var _ = Describe("FleetStore create", func() {
 			Expect(*updatedFleet.Spec.Template.Metadata.Generation).To(Equal(int64(2)))
 		})
 
+		It("CreateOrUpdate keeps the conversion annotation with the spec", func() {
+			err := storeInst.Fleet().UpdateAnnotations(ctx, orgId, "myfleet-1", map[string]string{"key": "value"}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			fleet.Status = nil
+			(*fleet.Metadata.Annotations)[v1beta1.FleetAnnotationConversion] = `{"rolloutPolicy":{"batchSize":2}}`
+			(*fleet.Metadata.Annotations)["ignored"] = "value"
+			callback := store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {})
+			_, _, err = storeInst.Fleet().CreateOrUpdate(ctx, orgId, fleet, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedFleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*updatedFleet.Metadata.Annotations).To(Equal(map[string]string{
+				"key":                             "value",
+				v1beta1.FleetAnnotationConversion: `{"rolloutPolicy":{"batchSize":2}}`,
+			}))
+			Expect(*updatedFleet.Metadata.Generation).To(Equal(int64(2)))
+			Expect(*updatedFleet.Spec.Template.Metadata.Generation).To(Equal(int64(1)))
+
+			delete(*updatedFleet.Metadata.Annotations, v1beta1.FleetAnnotationConversion)
+			updatedFleet.Status = nil
+			_, _, err = storeInst.Fleet().CreateOrUpdate(ctx, orgId, updatedFleet, callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedFleet, err = storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*updatedFleet.Metadata.Annotations).To(Equal(map[string]string{"key": "value"}))
+			Expect(*updatedFleet.Metadata.Generation).To(Equal(int64(3)))
+		})
+
+		It("MigrateStorageVersion", func() {
+			err := storeInst.Fleet().UpdateAnnotations(ctx, orgId, "myfleet-1", map[string]string{
+				v1beta1.FleetAnnotationConversion: `{"rolloutPolicy": {"batchSize": 2}}`,
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			migrated, err := storeInst.Fleet().MigrateStorageVersion(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrated).To(Equal(1))
+
+			fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect((*fleet.Metadata.Annotations)[v1beta1.FleetAnnotationConversion]).To(Equal(`{"rolloutPolicy":{"batchSize":2}}`))
+			Expect(*fleet.Metadata.Generation).To(Equal(int64(1)))
+
+			migrated, err = storeInst.Fleet().MigrateStorageVersion(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(migrated).To(Equal(0))
+		})
+
 		It("CreateOrUpdate wrong owner", func() {
 			fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
 			Expect(err).ToNot(HaveOccurred())
@@ -544,6 +604,26 @@ var _ = Describe("FleetStore create", func() {
 			Expect(updatedFleet.Status.Conditions[0].Type).To(Equal(api.FleetValid))
 		})
 
+		It("UpdateStatus with stale resourceVersion", func() {
+			fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1")
+			Expect(err).ToNot(HaveOccurred())
+			fleet.Status = &api.FleetStatus{Conditions: []api.Condition{}}
+			_, err = storeInst.Fleet().UpdateStatus(ctx, orgId, fleet)
+			Expect(err).ToNot(HaveOccurred())
+
+			// fleet still has the resourceVersion from before the update
+			_, err = storeInst.Fleet().UpdateStatus(ctx, orgId, fleet)
+			Expect(err).To(MatchError(flterrors.ErrResourceVersionConflict))
+
+			fleet.Metadata.ResourceVersion = util.StrToPtr("abc")
+			_, err = storeInst.Fleet().UpdateStatus(ctx, orgId, fleet)
+			Expect(err).To(MatchError(flterrors.ErrIllegalResourceVersionFormat))
+
+			fleet.Metadata.ResourceVersion = nil
+			_, err = storeInst.Fleet().UpdateStatus(ctx, orgId, fleet)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("List with owner param", func() {
 			owner := "owner"
 			listParams := store.ListParams{
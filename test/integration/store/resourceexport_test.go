@@ -0,0 +1,139 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Resource export and import", func() {
+	var (
+		log          *logrus.Logger
+		ctx          context.Context
+		orgId        uuid.UUID
+		sourceStore  store.Store
+		sourceCfg    *config.Config
+		sourceDBName string
+		targetStore  store.Store
+		targetCfg    *config.Config
+		targetDBName string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		sourceStore, sourceCfg, sourceDBName, _ = store.PrepareDBForUnitTests(log)
+		targetStore, targetCfg, targetDBName, _ = store.PrepareDBForUnitTests(log)
+
+		testutil.CreateTestFleets(ctx, 2, sourceStore.Fleet(), orgId, "myfleet", false, nil)
+		Expect(testutil.CreateTestTemplateVersions(ctx, 2, sourceStore.TemplateVersion(), orgId, "myfleet-1")).To(Succeed())
+		testutil.CreateTestDevices(ctx, 2, sourceStore.Device(), orgId, util.SetResourceOwner(model.FleetKind, "myfleet-1"), false)
+		testutil.CreateTestDevicesWithOffset(ctx, 1, sourceStore.Device(), orgId, util.SetResourceOwner(model.FleetKind, "myfleet-2"), false, 2)
+		Expect(sourceStore.Device().UpdateAnnotations(ctx, orgId, "mydevice-1", map[string]string{
+			model.DeviceAnnotationMigration: `{"managementEndpoint":"https://target.example.com"}`,
+		}, nil)).To(Succeed())
+		renderedConfig := `{"ignition":{"version":"3.4.0"}}`
+		Expect(sourceStore.Device().UpdateRendered(ctx, orgId, "mydevice-2", renderedConfig, nil)).To(Succeed())
+		for _, device := range []string{"mydevice-1", "mydevice-3"} {
+			Expect(sourceStore.IssuedCertificate().Record(ctx, orgId, &api.IssuedCertificate{
+				SerialNumber: device,
+				CommonName:   device,
+				Device:       lo.ToPtr(device),
+				Usage:        api.IssuedCertificateUsageManagement,
+				NotBefore:    time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+				NotAfter:     time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+			})).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, sourceCfg, sourceStore, sourceDBName)
+		store.DeleteTestDB(log, targetCfg, targetStore, targetDBName)
+	})
+
+	It("exports the fleets with their devices and imports them into another instance", func() {
+		export, err := sourceStore.ResourceExport().Export(ctx, orgId, []string{"myfleet-1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(export.Fleets).To(HaveLen(1))
+		Expect(export.TemplateVersions).To(HaveLen(2))
+		Expect(export.TemplateVersions[0].Status.Os).ToNot(BeNil())
+		Expect(lo.Map(export.Devices, func(d api.ExportedDevice, _ int) string { return *d.Device.Metadata.Name })).To(Equal([]string{"mydevice-1", "mydevice-2"}))
+		Expect(export.Certificates).To(HaveLen(1))
+		// the migration only applies to the source instance
+		Expect(*export.Devices[0].Device.Metadata.Annotations).ToNot(HaveKey(model.DeviceAnnotationMigration))
+
+		// the export is sent to the target instance as JSON
+		content, err := json.Marshal(export)
+		Expect(err).ToNot(HaveOccurred())
+		imported := &api.ResourceExport{}
+		Expect(json.Unmarshal(content, imported)).To(Succeed())
+
+		renderedFleets, renderedDevices := []string{}, []string{}
+		fleetCallback := func(before *model.Fleet, after *model.Fleet) { renderedFleets = append(renderedFleets, after.Name) }
+		deviceCallback := func(before *model.Device, after *model.Device) { renderedDevices = append(renderedDevices, after.Name) }
+		result, err := targetStore.ResourceExport().Import(ctx, orgId, imported, fleetCallback, deviceCallback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*result).To(Equal(api.ResourceImportResult{Fleets: 1, TemplateVersions: 2, Devices: 2, Certificates: 1}))
+		// what the source instance rendered is rendered again by the target
+		Expect(renderedFleets).To(Equal([]string{"myfleet-1"}))
+		Expect(renderedDevices).To(Equal([]string{"mydevice-1", "mydevice-2"}))
+		templateVersions, err := targetStore.TemplateVersion().List(ctx, orgId, store.ListParams{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(templateVersions.Items).To(HaveLen(2))
+		for _, templateVersion := range templateVersions.Items {
+			Expect(templateVersion.Status.Os).To(BeNil())
+			Expect(api.IsStatusConditionTrue(templateVersion.Status.Conditions, api.TemplateVersionValid)).To(BeFalse())
+		}
+
+		device, err := targetStore.Device().Get(ctx, orgId, "mydevice-1")
+		Expect(err).ToNot(HaveOccurred())
+		sourceDevice, err := sourceStore.Device().Get(ctx, orgId, "mydevice-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(device.Spec).To(Equal(sourceDevice.Spec))
+		Expect(device.Metadata.Owner).To(Equal(sourceDevice.Metadata.Owner))
+		Expect(device.Metadata.Labels).To(Equal(sourceDevice.Metadata.Labels))
+		// the devices keep their rendered versions, but not the rendered
+		// configuration of the source instance
+		rendered, err := targetStore.Device().GetRendered(ctx, orgId, "mydevice-2", nil, "")
+		Expect(err).ToNot(HaveOccurred())
+		sourceRendered, err := sourceStore.Device().GetRendered(ctx, orgId, "mydevice-2", nil, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sourceRendered.Config).ToNot(BeNil())
+		Expect(rendered.RenderedVersion).To(Equal(sourceRendered.RenderedVersion))
+		Expect(rendered.Config).To(BeNil())
+		certificates, err := targetStore.IssuedCertificate().List(ctx, orgId, store.IssuedCertificateListParams{Device: lo.ToPtr("mydevice-1")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates.Items).To(HaveLen(1))
+		_, err = targetStore.Fleet().Get(ctx, orgId, "myfleet-2")
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+		// importing again fails without importing anything
+		imported.Fleets = append(imported.Fleets, api.Fleet{Metadata: api.ObjectMeta{Name: lo.ToPtr("myfleet-3")}})
+		renderedFleets = []string{}
+		_, err = targetStore.ResourceExport().Import(ctx, orgId, imported, fleetCallback, deviceCallback)
+		Expect(err).To(MatchError(flterrors.ErrDuplicateName))
+		Expect(renderedFleets).To(BeEmpty())
+		_, err = targetStore.Fleet().Get(ctx, orgId, "myfleet-3")
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+	})
+
+	It("fails to export a fleet which does not exist", func() {
+		_, err := sourceStore.ResourceExport().Export(ctx, orgId, []string{"myfleet-1", "missing"})
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+	})
+})
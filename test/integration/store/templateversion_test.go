@@ -2,6 +2,7 @@ package store_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/flightctl/flightctl/internal/config"
 	"github.com/flightctl/flightctl/internal/flterrors"
@@ -127,7 +128,7 @@ var _ = Describe("TemplateVersion", func() {
 			Expect(len(templateVersions.Items)).To(Equal(0))
 		})
 
-		It("Delete fleet deletes its templateVersions", func() {
+		It("Purging a deleted fleet deletes its templateVersions", func() {
 			numResources := 5
 			testutil.CreateTestFleet(ctx, storeInst.Fleet(), orgId, "myfleet", nil, nil)
 			err := testutil.CreateTestTemplateVersions(ctx, numResources, tvStore, orgId, "myfleet")
@@ -142,7 +143,15 @@ var _ = Describe("TemplateVersion", func() {
 			err = storeInst.Fleet().Delete(ctx, otherOrgId, callback, "myfleet")
 			Expect(err).ToNot(HaveOccurred())
 
-			templateVersions, err := storeInst.TemplateVersion().List(ctx, orgId, store.ListParams{})
+			// the templateVersions are kept while the fleet can be restored
+			templateVersions, err := storeInst.TemplateVersion().List(ctx, otherOrgId, store.ListParams{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(templateVersions.Items)).To(Equal(numResources))
+
+			_, err = storeInst.Trash().Purge(ctx, time.Now(), callback)
+			Expect(err).ToNot(HaveOccurred())
+
+			templateVersions, err = storeInst.TemplateVersion().List(ctx, orgId, store.ListParams{})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(len(templateVersions.Items)).To(Equal(numResources))
 
@@ -0,0 +1,144 @@
+package store_test
+
+import (
+	"context"
+	"time"
+
+	api "github.com/flightctl/flightctl/api/v1alpha1"
+	"github.com/flightctl/flightctl/internal/config"
+	"github.com/flightctl/flightctl/internal/flterrors"
+	"github.com/flightctl/flightctl/internal/store"
+	"github.com/flightctl/flightctl/internal/store/model"
+	"github.com/flightctl/flightctl/internal/util"
+	flightlog "github.com/flightctl/flightctl/pkg/log"
+	testutil "github.com/flightctl/flightctl/test/util"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/samber/lo"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = Describe("Trash", func() {
+	var (
+		log            *logrus.Logger
+		ctx            context.Context
+		orgId          uuid.UUID
+		storeInst      store.Store
+		cfg            *config.Config
+		dbName         string
+		deviceCallback store.DeviceStoreCallback
+		fleetCallback  store.FleetStoreCallback
+		releasedFleets []string
+		restoredFleets []string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		orgId, _ = uuid.NewUUID()
+		log = flightlog.InitLogs()
+		storeInst, cfg, dbName, _ = store.PrepareDBForUnitTests(log)
+		deviceCallback = store.DeviceStoreCallback(func(before *model.Device, after *model.Device) {})
+		releasedFleets, restoredFleets = nil, nil
+		fleetCallback = store.FleetStoreCallback(func(before *model.Fleet, after *model.Fleet) {
+			if after == nil {
+				releasedFleets = append(releasedFleets, before.Name)
+			} else {
+				restoredFleets = append(restoredFleets, after.Name)
+			}
+		})
+
+		testutil.CreateTestFleets(ctx, 2, storeInst.Fleet(), orgId, "myfleet", false, nil)
+		testutil.CreateTestDevices(ctx, 2, storeInst.Device(), orgId, util.SetResourceOwner(model.FleetKind, "myfleet-1"), false)
+		Expect(storeInst.IssuedCertificate().Record(ctx, orgId, &api.IssuedCertificate{
+			SerialNumber: "mydevice-1",
+			CommonName:   "mydevice-1",
+			Device:       lo.ToPtr("mydevice-1"),
+			Usage:        api.IssuedCertificateUsageManagement,
+			NotBefore:    time.Now().Add(-time.Hour).UTC().Truncate(time.Second),
+			NotAfter:     time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		store.DeleteTestDB(log, cfg, storeInst, dbName)
+	})
+
+	It("lists and restores deleted devices and fleets", func() {
+		Expect(storeInst.Device().Delete(ctx, orgId, "mydevice-1", deviceCallback)).To(Succeed())
+		Expect(storeInst.Fleet().Delete(ctx, orgId, fleetCallback, "myfleet-1")).To(Succeed())
+		// the devices of the deleted fleet are released
+		Expect(releasedFleets).To(Equal([]string{"myfleet-1"}))
+
+		trash, err := storeInst.Trash().List(ctx, orgId, nil, 24*time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trash.Items).To(HaveLen(2))
+		Expect(trash.Items[0].Kind).To(Equal(model.DeviceKind))
+		Expect(trash.Items[0].Name).To(Equal("mydevice-1"))
+		Expect(trash.Items[0].Owner).To(Equal(util.SetResourceOwner(model.FleetKind, "myfleet-1")))
+		Expect(trash.Items[0].PurgeAt).To(Equal(trash.Items[0].DeletedAt.Add(24 * time.Hour)))
+		Expect(trash.Items[1].Kind).To(Equal(model.FleetKind))
+		trash, err = storeInst.Trash().List(ctx, orgId, lo.ToPtr(model.FleetKind), 24*time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trash.Items).To(HaveLen(1))
+
+		_, err = storeInst.Device().Get(ctx, orgId, "mydevice-1")
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+
+		restoredDevice, err := storeInst.Device().Restore(ctx, orgId, "mydevice-1", deviceCallback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*restoredDevice.Metadata.Name).To(Equal("mydevice-1"))
+		restoredFleet, err := storeInst.Fleet().Restore(ctx, orgId, "myfleet-1", fleetCallback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*restoredFleet.Metadata.Name).To(Equal("myfleet-1"))
+		// the restored fleet selects its devices again
+		Expect(restoredFleets).To(Equal([]string{"myfleet-1"}))
+
+		fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-1", store.WithSummary(true))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fleet.Status.DevicesSummary.Total).To(Equal(2))
+		certificates, err := storeInst.IssuedCertificate().List(ctx, orgId, store.IssuedCertificateListParams{Device: lo.ToPtr("mydevice-1")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates.Items).To(HaveLen(1))
+		trash, err = storeInst.Trash().List(ctx, orgId, nil, 24*time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trash.Items).To(BeEmpty())
+
+		_, err = storeInst.Device().Restore(ctx, orgId, "mydevice-1", deviceCallback)
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+	})
+
+	It("purges the resources deleted before the retention", func() {
+		Expect(storeInst.Device().Delete(ctx, orgId, "mydevice-1", deviceCallback)).To(Succeed())
+		Expect(storeInst.Fleet().Delete(ctx, orgId, fleetCallback, "myfleet-1")).To(Succeed())
+		releasedFleets = nil
+
+		purged, err := storeInst.Trash().Purge(ctx, time.Now().Add(-time.Hour), fleetCallback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(purged).To(Equal(0))
+
+		purged, err = storeInst.Trash().Purge(ctx, time.Now(), fleetCallback)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(purged).To(Equal(2))
+		Expect(releasedFleets).To(Equal([]string{"myfleet-1"}))
+		certificates, err := storeInst.IssuedCertificate().List(ctx, orgId, store.IssuedCertificateListParams{Device: lo.ToPtr("mydevice-1")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates.Items).To(BeEmpty())
+		trash, err := storeInst.Trash().List(ctx, orgId, nil, 24*time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(trash.Items).To(BeEmpty())
+		_, err = storeInst.Fleet().Restore(ctx, orgId, "myfleet-1", fleetCallback)
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+	})
+
+	It("replaces a deleted resource created again", func() {
+		Expect(storeInst.Fleet().Delete(ctx, orgId, fleetCallback, "myfleet-2")).To(Succeed())
+		testutil.CreateTestFleet(ctx, storeInst.Fleet(), orgId, "myfleet-2", nil, nil)
+
+		fleet, err := storeInst.Fleet().Get(ctx, orgId, "myfleet-2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*fleet.Metadata.Generation).To(Equal(int64(1)))
+		_, err = storeInst.Fleet().Restore(ctx, orgId, "myfleet-2", fleetCallback)
+		Expect(err).To(MatchError(flterrors.ErrResourceNotFound))
+	})
+})
@@ -120,7 +120,7 @@ func NewTestApiServer(log logrus.FieldLogger, cfg *config.Config, store store.St
 }
 
 // NewTestServer creates a new test server and returns the server and the listener listening on localhost's next available port.
-func NewTestAgentServer(log logrus.FieldLogger, cfg *config.Config, store store.Store, ca *crypto.CA, serverCerts *crypto.TLSCertificateConfig) (*agentserver.AgentServer, net.Listener, error) {
+func NewTestAgentServer(log logrus.FieldLogger, cfg *config.Config, store store.Store, ca *crypto.CA, serverCerts *crypto.TLSCertificateConfig, provider queues.Provider) (*agentserver.AgentServer, net.Listener, error) {
 	// create a listener using the next available port
 	_, tlsConfig, _, err := crypto.TLSConfigForServer(ca.Config, serverCerts)
 	if err != nil {
@@ -133,7 +133,7 @@ func NewTestAgentServer(log logrus.FieldLogger, cfg *config.Config, store store.
 		return nil, nil, fmt.Errorf("NewTestAgentServer: error creating TLS certs: %w", err)
 	}
 
-	return agentserver.New(log, cfg, store, ca, listener), listener, nil
+	return agentserver.New(log, cfg, store, ca, listener, provider), listener, nil
 }
 
 // NewTestStore creates a new test store and returns the store and the database name.